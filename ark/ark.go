@@ -0,0 +1,248 @@
+// Package ark mints ARKs (Archival Resource Keys) for hub records, from
+// EZID or from a Noid minter behind HTTP, for collections that get
+// persistent identifiers without DOIs.
+package ark
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+// EZIDURL is the EZID API.
+const EZIDURL = "https://ezid.cdlib.org"
+
+const userAgent = "crosswalk-mint-ark (https://github.com/lehigh-university-libraries/crosswalk)"
+
+// Minter mints one new ARK for a record.
+type Minter interface {
+	Mint(ctx context.Context, record *hubv1.Record) (string, error)
+}
+
+// Error is a failed minting request.
+type Error struct {
+	Status  int
+	Message string
+}
+
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("minter returned %d %s", e.Status, http.StatusText(e.Status))
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+// EZID mints ARKs with the EZID API under NAAN/Shoulder (e.g. 99999/fk4),
+// describing each with the record's ERC metadata and, when it has one,
+// pointing it at the record's URL.
+type EZID struct {
+	BaseURL    string
+	HTTPClient *http.Client
+	UserAgent  string
+
+	// Username and Password are the EZID account, sent with HTTP basic
+	// authentication.
+	Username string
+	Password string
+
+	NAAN     string
+	Shoulder string
+}
+
+// NewEZID returns an EZID minter for the API at baseURL.
+func NewEZID(baseURL, naan, shoulder string) *EZID {
+	return &EZID{
+		BaseURL:    strings.TrimSuffix(baseURL, "/"),
+		HTTPClient: &http.Client{Timeout: 60 * time.Second},
+		UserAgent:  userAgent,
+		NAAN:       naan,
+		Shoulder:   shoulder,
+	}
+}
+
+// Mint implements Minter.
+func (e *EZID) Mint(ctx context.Context, record *hubv1.Record) (string, error) {
+	target := e.BaseURL + "/shoulder/ark:/" + e.NAAN + "/" + e.Shoulder
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, strings.NewReader(anvl(ercMetadata(record))))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "text/plain; charset=UTF-8")
+	if e.UserAgent != "" {
+		req.Header.Set("User-Agent", e.UserAgent)
+	}
+	if e.Username != "" {
+		req.SetBasicAuth(e.Username, e.Password)
+	}
+	status, body, err := send(e.HTTPClient, req)
+	if err != nil {
+		return "", err
+	}
+
+	// EZID answers "success: ark:/99999/fk4..." or "error: reason"
+	first, _, _ := strings.Cut(strings.TrimSpace(string(body)), "\n")
+	key, value, _ := strings.Cut(first, ":")
+	value = strings.TrimSpace(value)
+	if status != http.StatusCreated || key != "success" {
+		if key == "error" {
+			return "", &Error{Status: status, Message: value}
+		}
+		return "", &Error{Status: status, Message: first}
+	}
+	// A DOI shoulder appends "| ark:/b5072/..."; the first identifier is
+	// the one minted
+	id, _, _ := strings.Cut(value, "|")
+	return strings.TrimSpace(id), nil
+}
+
+// ercMetadata is the record as EZID's ERC profile: who, what, when, and
+// the landing page the ARK resolves to.
+func ercMetadata(record *hubv1.Record) [][2]string {
+	meta := [][2]string{{"_profile", "erc"}}
+	if target := landingPage(record); target != "" {
+		meta = append(meta, [2]string{"_target", target})
+	}
+	var who []string
+	for _, c := range record.Contributors {
+		who = append(who, hub.InvertedName(c))
+	}
+	if len(who) > 0 {
+		meta = append(meta, [2]string{"erc.who", strings.Join(who, "; ")})
+	}
+	if record.Title != "" {
+		meta = append(meta, [2]string{"erc.what", record.Title})
+	}
+	if d := hub.PrimaryDate(record); d != nil {
+		meta = append(meta, [2]string{"erc.when", hub.FormatDate(d)})
+	}
+	return meta
+}
+
+// landingPage is the record's first URL identifier.
+func landingPage(record *hubv1.Record) string {
+	if id := hub.GetIdentifier(record, hubv1.IdentifierType_IDENTIFIER_TYPE_URL); id != nil {
+		return id.Value
+	}
+	return ""
+}
+
+// anvlEscaper percent-encodes the characters ANVL reserves in values.
+var anvlEscaper = strings.NewReplacer("%", "%25", "\n", "%0A", "\r", "%0D")
+
+// anvl writes metadata as EZID's ANVL: one "key: value" line each.
+func anvl(meta [][2]string) string {
+	var b strings.Builder
+	for _, kv := range meta {
+		fmt.Fprintf(&b, "%s: %s\n", kv[0], anvlEscaper.Replace(kv[1]))
+	}
+	return b.String()
+}
+
+// Noid mints ARKs from a Noid minter served over HTTP, which answers
+// "?mint+1" with "id: <identifier>". Minters whose template leaves out the
+// NAAN have it prefixed.
+type Noid struct {
+	URL        string
+	HTTPClient *http.Client
+	UserAgent  string
+
+	NAAN string
+}
+
+// NewNoid returns a minter for the Noid service at url.
+func NewNoid(url, naan string) *Noid {
+	return &Noid{
+		URL:        url,
+		HTTPClient: &http.Client{Timeout: 60 * time.Second},
+		UserAgent:  userAgent,
+		NAAN:       naan,
+	}
+}
+
+// Mint implements Minter. Noid knows nothing about the record.
+func (n *Noid) Mint(ctx context.Context, _ *hubv1.Record) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, n.URL+"?mint+1", nil)
+	if err != nil {
+		return "", err
+	}
+	if n.UserAgent != "" {
+		req.Header.Set("User-Agent", n.UserAgent)
+	}
+	status, body, err := send(n.HTTPClient, req)
+	if err != nil {
+		return "", err
+	}
+	if status != http.StatusOK {
+		return "", &Error{Status: status, Message: strings.TrimSpace(string(body))}
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), ":")
+		if !ok || strings.TrimSpace(key) != "id" {
+			continue
+		}
+		id := strings.TrimPrefix(strings.TrimSpace(value), "ark:/")
+		if id == "" {
+			break
+		}
+		if !strings.Contains(id, "/") {
+			id = n.NAAN + "/" + id
+		}
+		return "ark:/" + id, nil
+	}
+	return "", &Error{Status: status, Message: "no id in the minter's response"}
+}
+
+// send makes one request and reads the whole response.
+func send(client *http.Client, req *http.Request) (int, []byte, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, fmt.Errorf("reading response: %w", err)
+	}
+	return resp.StatusCode, body, nil
+}
+
+// MintRecords mints an ARK for each record that has none and adds it to
+// the record's identifiers, returning how many were minted. A record the
+// minter fails on is logged and left without one, unless the minter
+// refuses the credentials, which ends the run.
+func MintRecords(ctx context.Context, m Minter, records []*hubv1.Record) (int, error) {
+	minted := 0
+	for _, r := range records {
+		if hub.GetIdentifier(r, hubv1.IdentifierType_IDENTIFIER_TYPE_ARK) != nil {
+			continue
+		}
+		id, err := m.Mint(ctx, r)
+		var mintErr *Error
+		if errors.As(err, &mintErr) && (mintErr.Status == http.StatusUnauthorized || mintErr.Status == http.StatusForbidden) {
+			return minted, err
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return minted, ctx.Err()
+			}
+			slog.Warn("ARK not minted", "title", r.Title, "err", err)
+			continue
+		}
+		r.Identifiers = append(r.Identifiers, hub.NewIdentifier(id, hubv1.IdentifierType_IDENTIFIER_TYPE_ARK))
+		minted++
+	}
+	return minted, nil
+}
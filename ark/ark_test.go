@@ -0,0 +1,144 @@
+package ark
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+// ezid fakes the shoulder endpoint, keeping the last request body.
+type ezid struct {
+	path, body string
+	minted     int
+}
+
+func (e *ezid) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, pass, _ := r.BasicAuth()
+	if user != "lehigh" || pass != "secret" {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, "error: unauthorized")
+		return
+	}
+	body, _ := io.ReadAll(r.Body)
+	e.path, e.body = r.URL.Path, string(body)
+	if !strings.Contains(e.body, "erc.what:") {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, "error: bad request - no title")
+		return
+	}
+	e.minted++
+	w.WriteHeader(http.StatusCreated)
+	fmt.Fprintf(w, "success: ark:/99999/fk4%04d\n", e.minted)
+}
+
+func ezidMinter(t *testing.T) (*EZID, *ezid) {
+	t.Helper()
+	api := &ezid{}
+	srv := httptest.NewServer(api)
+	t.Cleanup(srv.Close)
+	m := NewEZID(srv.URL+"/", "99999", "fk4")
+	m.Username, m.Password = "lehigh", "secret"
+	return m, api
+}
+
+func TestEZIDMint(t *testing.T) {
+	m, api := ezidMinter(t)
+	record := &hubv1.Record{
+		Title:        "Letters, 1871-1874:\nvolume 1",
+		Contributors: []*hubv1.Contributor{{Name: "Josiah Carberry", ParsedName: &hubv1.ParsedName{Given: "Josiah", Family: "Carberry"}}},
+		Dates:        []*hubv1.DateValue{hub.NewDateFromYear(1874, hubv1.DateType_DATE_TYPE_CREATED)},
+		Identifiers:  []*hubv1.Identifier{hub.NewIdentifier("https://preserve.lehigh.edu/node/1", hubv1.IdentifierType_IDENTIFIER_TYPE_URL)},
+	}
+	id, err := m.Mint(context.Background(), record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != "ark:/99999/fk40001" {
+		t.Errorf("minted %q", id)
+	}
+	if api.path != "/shoulder/ark:/99999/fk4" {
+		t.Errorf("posted to %s", api.path)
+	}
+	want := "_profile: erc\n" +
+		"_target: https://preserve.lehigh.edu/node/1\n" +
+		"erc.who: Carberry, Josiah\n" +
+		"erc.what: Letters, 1871-1874:%0Avolume 1\n" +
+		"erc.when: 1874\n"
+	if api.body != want {
+		t.Errorf("sent:\n%s\nwant:\n%s", api.body, want)
+	}
+
+	if _, err := m.Mint(context.Background(), &hubv1.Record{}); err == nil || !strings.Contains(err.Error(), "no title") {
+		t.Errorf("untitled record: got %v", err)
+	}
+}
+
+func TestNoidMint(t *testing.T) {
+	var response string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.RawQuery != "mint+1" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		fmt.Fprint(w, response)
+	}))
+	t.Cleanup(srv.Close)
+	m := NewNoid(srv.URL+"/nd/noidu_lehigh", "13030")
+
+	for _, tt := range []struct{ response, want string }{
+		{"id: 13030/tf5p30086k\n\n", "ark:/13030/tf5p30086k"},
+		{"id: tf5p30086k\n", "ark:/13030/tf5p30086k"},
+		{"id: ark:/13030/tf5p30086k\n", "ark:/13030/tf5p30086k"},
+	} {
+		response = tt.response
+		got, err := m.Mint(context.Background(), nil)
+		if err != nil || got != tt.want {
+			t.Errorf("%q: got %q, %v; want %q", tt.response, got, err, tt.want)
+		}
+	}
+
+	response = "error: minter exhausted\n"
+	if _, err := m.Mint(context.Background(), nil); err == nil {
+		t.Error("response without an id: want an error")
+	}
+}
+
+func TestMintRecords(t *testing.T) {
+	m, _ := ezidMinter(t)
+	records := []*hubv1.Record{
+		{Title: "First"},
+		{Title: "Has one", Identifiers: []*hubv1.Identifier{hub.NewIdentifier("ark:/99999/fk4old", hubv1.IdentifierType_IDENTIFIER_TYPE_ARK)}},
+		{},
+		{Title: "Last"},
+	}
+	minted, err := MintRecords(context.Background(), m, records)
+	if err != nil || minted != 2 {
+		t.Fatalf("got %d, %v; want 2 minted", minted, err)
+	}
+	for i, want := range []string{"ark:/99999/fk40001", "ark:/99999/fk4old", "", "ark:/99999/fk40002"} {
+		var got string
+		if id := hub.GetIdentifier(records[i], hubv1.IdentifierType_IDENTIFIER_TYPE_ARK); id != nil {
+			got = id.Value
+		}
+		if got != want {
+			t.Errorf("record %d: got ARK %q, want %q", i, got, want)
+		}
+	}
+	if n := len(records[1].Identifiers); n != 1 {
+		t.Errorf("record with an ARK got %d identifiers", n)
+	}
+
+	m.Password = "wrong"
+	var mintErr *Error
+	if _, err := MintRecords(context.Background(), m, []*hubv1.Record{{Title: "First"}}); !errors.As(err, &mintErr) || mintErr.Status != http.StatusUnauthorized {
+		t.Errorf("bad credentials: got %v", err)
+	}
+}
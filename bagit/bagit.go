@@ -0,0 +1,289 @@
+// Package bagit writes BagIt bags (RFC 8493), the packaging digital
+// preservation services accept for deposit.
+//
+// A bag is a directory holding the payload under data/, a payload manifest
+// per checksum algorithm (manifest-sha256.txt) listing every payload file's
+// checksum, bagit.txt declaring the version, bag-info.txt with metadata
+// about the bag, and tag manifests (tagmanifest-sha256.txt) covering the
+// other tag files. Create starts a bag, payload files are added with
+// Bag.Create and AddFile (or written at Path), and Finish writes the
+// manifests and tag files.
+package bagit
+
+import (
+	"bufio"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Version is the BagIt version of the bags written.
+const Version = "1.0"
+
+// DefaultAlgorithm is the checksum algorithm used when none is given.
+const DefaultAlgorithm = "sha256"
+
+// algorithms are the supported checksum algorithms, by their BagIt names.
+var algorithms = map[string]func() hash.Hash{
+	"md5":    md5.New,
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+	"sha512": sha512.New,
+}
+
+// Field is a bag-info.txt metadata element. Labels may repeat.
+type Field struct {
+	Label string
+	Value string
+}
+
+// Bag is a bag being written.
+type Bag struct {
+	dir        string
+	algorithms []string
+}
+
+// CheckDir reports whether a bag can be created in dir: it must not exist
+// or be empty.
+func CheckDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	switch {
+	case err == nil && len(entries) > 0:
+		return fmt.Errorf("bag directory %s is not empty", dir)
+	case err != nil && !os.IsNotExist(err):
+		return err
+	}
+	return nil
+}
+
+// Create starts a bag in dir, which must not exist or be empty, with
+// manifests for the named checksum algorithms (md5, sha1, sha256, or
+// sha512; DefaultAlgorithm when none are given).
+func Create(dir string, algs ...string) (*Bag, error) {
+	if len(algs) == 0 {
+		algs = []string{DefaultAlgorithm}
+	}
+	for _, alg := range algs {
+		if _, ok := algorithms[alg]; !ok {
+			return nil, fmt.Errorf("unknown checksum algorithm %q (want md5, sha1, sha256, or sha512)", alg)
+		}
+	}
+
+	if err := CheckDir(dir); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "data"), 0o755); err != nil {
+		return nil, err
+	}
+	return &Bag{dir: dir, algorithms: algs}, nil
+}
+
+// Dir returns the bag's directory.
+func (b *Bag) Dir() string {
+	return b.dir
+}
+
+// Path returns the path of a payload file, named by its slash-separated
+// path under data/.
+func (b *Bag) Path(name string) (string, error) {
+	clean := path.Clean("/" + name)[1:]
+	if clean == "" || clean != name {
+		return "", fmt.Errorf("invalid payload file name %q", name)
+	}
+	return filepath.Join(b.dir, "data", filepath.FromSlash(clean)), nil
+}
+
+// Create creates a payload file, named by its slash-separated path under
+// data/.
+func (b *Bag) Create(name string) (*os.File, error) {
+	p, err := b.Path(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return nil, err
+	}
+	return os.OpenFile(p, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+}
+
+// AddFile copies the file at src into the payload as name.
+func (b *Bag) AddFile(name, src string) (err error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := b.Create(name)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := out.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+	_, err = io.Copy(out, in)
+	return err
+}
+
+// Discard removes the bag's directory, for a bag that can't be finished.
+func (b *Bag) Discard() error {
+	return os.RemoveAll(b.dir)
+}
+
+// Finish writes the payload manifests, bagit.txt, bag-info.txt with the
+// given fields, and the tag manifests. Bagging-Date and Payload-Oxum are
+// added to the fields unless they are given.
+func (b *Bag) Finish(info []Field) error {
+	payload, err := b.checksums("data")
+	if err != nil {
+		return fmt.Errorf("checksumming payload: %w", err)
+	}
+
+	tagFiles := []string{"bagit.txt", "bag-info.txt"}
+	for _, alg := range b.algorithms {
+		name := "manifest-" + alg + ".txt"
+		if err := b.writeManifest(name, payload.sums[alg]); err != nil {
+			return err
+		}
+		tagFiles = append(tagFiles, name)
+	}
+
+	bagitTxt := "BagIt-Version: " + Version + "\nTag-File-Character-Encoding: UTF-8\n"
+	if err := os.WriteFile(filepath.Join(b.dir, "bagit.txt"), []byte(bagitTxt), 0o644); err != nil {
+		return err
+	}
+
+	if !hasField(info, "Bagging-Date") {
+		info = append(info, Field{"Bagging-Date", time.Now().Format(time.DateOnly)})
+	}
+	if !hasField(info, "Payload-Oxum") {
+		info = append(info, Field{"Payload-Oxum", fmt.Sprintf("%d.%d", payload.bytes, payload.files)})
+	}
+	var bi strings.Builder
+	for _, f := range info {
+		fmt.Fprintf(&bi, "%s: %s\n", f.Label, strings.Join(strings.Fields(f.Value), " "))
+	}
+	if err := os.WriteFile(filepath.Join(b.dir, "bag-info.txt"), []byte(bi.String()), 0o644); err != nil {
+		return err
+	}
+
+	for _, alg := range b.algorithms {
+		sums := make(map[string]string, len(tagFiles))
+		for _, name := range tagFiles {
+			sum, _, err := checksum(filepath.Join(b.dir, name), alg)
+			if err != nil {
+				return err
+			}
+			sums[name] = sum
+		}
+		if err := b.writeManifest("tagmanifest-"+alg+".txt", sums); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tree holds the checksums of a directory tree's files, by algorithm and
+// then by slash-separated path relative to the bag, with their total size.
+type tree struct {
+	sums  map[string]map[string]string
+	bytes int64
+	files int
+}
+
+// checksums checksums every file under the bag's dir subdirectory.
+func (b *Bag) checksums(dir string) (*tree, error) {
+	s := &tree{sums: make(map[string]map[string]string)}
+	for _, alg := range b.algorithms {
+		s.sums[alg] = make(map[string]string)
+	}
+	root := filepath.Join(b.dir, dir)
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(b.dir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		for _, alg := range b.algorithms {
+			sum, size, err := checksum(p, alg)
+			if err != nil {
+				return err
+			}
+			s.sums[alg][rel] = sum
+			if alg == b.algorithms[0] {
+				s.bytes += size
+				s.files++
+			}
+		}
+		return nil
+	})
+	return s, err
+}
+
+// writeManifest writes a manifest of checksums by path, sorted by path.
+func (b *Bag) writeManifest(name string, sums map[string]string) (err error) {
+	f, err := os.Create(filepath.Join(b.dir, name))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := f.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	paths := make([]string, 0, len(sums))
+	for p := range sums {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	w := bufio.NewWriter(f)
+	for _, p := range paths {
+		fmt.Fprintf(w, "%s  %s\n", sums[p], encodePath(p))
+	}
+	return w.Flush()
+}
+
+// encodePath percent-encodes the characters RFC 8493 doesn't allow in
+// manifest paths as is.
+var encodePath = strings.NewReplacer("%", "%25", "\n", "%0A", "\r", "%0D").Replace
+
+// checksum returns the hex checksum of a file and its size.
+func checksum(p, alg string) (string, int64, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return "", 0, err
+	}
+	defer f.Close()
+	h := algorithms[alg]()
+	n, err := io.Copy(h, f)
+	if err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(h.Sum(nil)), n, nil
+}
+
+func hasField(info []Field, label string) bool {
+	for _, f := range info {
+		if strings.EqualFold(f.Label, label) {
+			return true
+		}
+	}
+	return false
+}
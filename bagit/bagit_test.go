@@ -0,0 +1,82 @@
+package bagit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestBag(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "thesis.pdf")
+	if err := os.WriteFile(src, []byte("%PDF"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	dir := filepath.Join(t.TempDir(), "bag")
+	bag, err := Create(dir, "sha256", "md5")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	f, err := bag.Create("records.xml")
+	if err != nil {
+		t.Fatalf("Create payload: %v", err)
+	}
+	f.WriteString("<records/>")
+	f.Close()
+	if err := bag.AddFile("files/thesis.pdf", src); err != nil {
+		t.Fatalf("AddFile: %v", err)
+	}
+	if _, err := bag.Create("../escape.txt"); err == nil {
+		t.Error("expected an error for a name outside data/")
+	}
+	if err := bag.Finish([]Field{{"Source-Organization", "Lehigh University"}, {"Bagging-Date", "2026-01-02"}}); err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+
+	read := func(name string) string {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+
+	if got := read("bagit.txt"); got != "BagIt-Version: 1.0\nTag-File-Character-Encoding: UTF-8\n" {
+		t.Errorf("bagit.txt = %q", got)
+	}
+	wantManifest := sha256Hex("%PDF") + "  data/files/thesis.pdf\n" + sha256Hex("<records/>") + "  data/records.xml\n"
+	if got := read("manifest-sha256.txt"); got != wantManifest {
+		t.Errorf("manifest-sha256.txt = %q, want %q", got, wantManifest)
+	}
+	if got := read("manifest-md5.txt"); strings.Count(got, "\n") != 2 {
+		t.Errorf("manifest-md5.txt = %q", got)
+	}
+	if got, want := read("bag-info.txt"), "Source-Organization: Lehigh University\nBagging-Date: 2026-01-02\nPayload-Oxum: 14.2\n"; got != want {
+		t.Errorf("bag-info.txt = %q, want %q", got, want)
+	}
+
+	tags := read("tagmanifest-sha256.txt")
+	for _, name := range []string{"bagit.txt", "bag-info.txt", "manifest-sha256.txt", "manifest-md5.txt"} {
+		if want := sha256Hex(read(name)) + "  " + name + "\n"; !strings.Contains(tags, want) {
+			t.Errorf("tagmanifest-sha256.txt missing %q:\n%s", want, tags)
+		}
+	}
+	if strings.Contains(tags, "tagmanifest") {
+		t.Errorf("tag manifest lists tag manifests:\n%s", tags)
+	}
+
+	if _, err := Create(dir); err == nil {
+		t.Error("expected an error creating a bag in a non-empty directory")
+	}
+	if _, err := Create(filepath.Join(t.TempDir(), "x"), "crc32"); err == nil {
+		t.Error("expected an error for an unknown algorithm")
+	}
+}
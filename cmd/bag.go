@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/lehigh-university-libraries/crosswalk/bagit"
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	"github.com/lehigh-university-libraries/crosswalk/format/premis"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+)
+
+// packageBagIt packages convert output as a BagIt bag.
+const packageBagIt = "bagit"
+
+// checkPackage validates the --package options before any work is done.
+func checkPackage() error {
+	switch packageFormat {
+	case "":
+		return nil
+	case packageBagIt:
+		if outputFile == "" {
+			return fmt.Errorf("--package bagit requires --output, the bag directory")
+		}
+		if err := bagit.CheckDir(outputFile); err != nil {
+			return err
+		}
+		_, err := userBagInfo()
+		return err
+	}
+	return fmt.Errorf("unknown package %q (want %s)", packageFormat, packageBagIt)
+}
+
+// bagOutput is the bag convert output is written into.
+type bagOutput struct {
+	bag *bagit.Bag
+
+	// payload is the serialized output's name under data/
+	payload string
+}
+
+// startBag creates the --output bag directory, naming the serialized
+// output after it with the target format's extension.
+func startBag(serializer format.Serializer) (*bagOutput, error) {
+	bag, err := bagit.Create(outputFile, bagChecksums...)
+	if err != nil {
+		return nil, fmt.Errorf("creating bag: %w", err)
+	}
+	name := filepath.Base(filepath.Clean(outputFile))
+	if exts := serializer.Extensions(); len(exts) > 0 {
+		name += "." + exts[0]
+	}
+	return &bagOutput{bag: bag, payload: name}, nil
+}
+
+// outputPath returns the path the serialized output is written to.
+func (b *bagOutput) outputPath() string {
+	p, _ := b.bag.Path(b.payload)
+	return p
+}
+
+// premisPath returns the path in the payload for the --premis file.
+func (b *bagOutput) premisPath() (string, error) {
+	return b.bag.Path(filepath.Base(premisFile))
+}
+
+// stageFiles copies the records' local files into the payload under
+// files/, pointing each file's path at its copy so the serialized output
+// refers to the bagged files. Remote files are left as they are; local
+// files that can't be read are left out with a warning.
+func (b *bagOutput) stageFiles(records []*hubv1.Record) {
+	staged := make(map[string]string) // source path to payload name
+	used := make(map[string]bool)
+	for _, r := range records {
+		for _, file := range r.Files {
+			if file.Path == "" || strings.Contains(file.Path, "://") {
+				continue
+			}
+			if name, ok := staged[file.Path]; ok {
+				file.Path = name
+				continue
+			}
+			base := path.Base(filepath.ToSlash(file.Name))
+			if file.Name == "" {
+				base = filepath.Base(file.Path)
+			}
+			name := "files/" + base
+			for n := 2; used[name]; n++ {
+				name = fmt.Sprintf("files/%d-%s", n, base)
+			}
+			if err := b.bag.AddFile(name, file.Path); err != nil {
+				slog.Warn("leaving file out of bag: not readable", "title", r.Title, "path", file.Path, "err", err)
+				continue
+			}
+			used[name] = true
+			staged[file.Path] = name
+			file.Path = name
+		}
+	}
+}
+
+// finish writes the bag's manifests and bag-info.txt, describing the batch
+// and the software that made it, with the --bag-info fields in place of
+// the defaults they name.
+func (b *bagOutput) finish(records []*hubv1.Record, fromFormat, toFormat string) error {
+	info := []bagit.Field{
+		{Label: "Bag-Software-Agent", Value: "crosswalk " + premis.AgentVersion + " <https://github.com/lehigh-university-libraries/crosswalk>"},
+		{Label: "External-Description", Value: fmt.Sprintf("%d records converted from %s to %s", len(records), fromFormat, toFormat)},
+	}
+	if provider != "" {
+		info = append([]bagit.Field{{Label: "Source-Organization", Value: provider}}, info...)
+	}
+	if inputFile != "" {
+		info = append(info, bagit.Field{Label: "Internal-Sender-Identifier", Value: filepath.Base(inputFile)})
+	}
+
+	user, err := userBagInfo()
+	if err != nil {
+		return err
+	}
+	overridden := make(map[string]bool)
+	for _, f := range user {
+		overridden[strings.ToLower(f.Label)] = true
+	}
+	fields := make([]bagit.Field, 0, len(info)+len(user))
+	for _, f := range info {
+		if !overridden[strings.ToLower(f.Label)] {
+			fields = append(fields, f)
+		}
+	}
+	fields = append(fields, user...)
+
+	if err := b.bag.Finish(fields); err != nil {
+		return fmt.Errorf("finishing bag: %w", err)
+	}
+	return nil
+}
+
+// userBagInfo parses the --bag-info fields, given as Label=Value.
+func userBagInfo() ([]bagit.Field, error) {
+	fields := make([]bagit.Field, 0, len(bagInfo))
+	for _, s := range bagInfo {
+		label, value, ok := strings.Cut(s, "=")
+		label = strings.TrimSpace(label)
+		if !ok || label == "" || strings.ContainsAny(label, ":\r\n") {
+			return nil, fmt.Errorf("invalid --bag-info %q (want Label=Value)", s)
+		}
+		fields = append(fields, bagit.Field{Label: label, Value: strings.TrimSpace(value)})
+	}
+	return fields, nil
+}
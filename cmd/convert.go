@@ -1,16 +1,27 @@
 package cmd
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 
+	"github.com/lehigh-university-libraries/crosswalk/ark"
+	"github.com/lehigh-university-libraries/crosswalk/enrich"
 	"github.com/lehigh-university-libraries/crosswalk/format"
 	csvfmt "github.com/lehigh-university-libraries/crosswalk/format/csv"
 	"github.com/lehigh-university-libraries/crosswalk/format/drupal"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/helpers/lang"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
 	"github.com/lehigh-university-libraries/crosswalk/mapping"
 	"github.com/lehigh-university-libraries/crosswalk/profile"
 	spokeregistry "github.com/lehigh-university-libraries/crosswalk/spoke/registry"
@@ -20,11 +31,33 @@ import (
 	_ "github.com/lehigh-university-libraries/crosswalk/format/bibtex"
 	_ "github.com/lehigh-university-libraries/crosswalk/format/crossref"
 	_ "github.com/lehigh-university-libraries/crosswalk/format/csl"
+	_ "github.com/lehigh-university-libraries/crosswalk/format/darwincore"
 	_ "github.com/lehigh-university-libraries/crosswalk/format/datacite"
+	_ "github.com/lehigh-university-libraries/crosswalk/format/dspace_saf"
 	_ "github.com/lehigh-university-libraries/crosswalk/format/dublincore"
+	_ "github.com/lehigh-university-libraries/crosswalk/format/ead"
+	_ "github.com/lehigh-university-libraries/crosswalk/format/hubjsonl"
+	_ "github.com/lehigh-university-libraries/crosswalk/format/iiif"
+	_ "github.com/lehigh-university-libraries/crosswalk/format/lido"
+	_ "github.com/lehigh-university-libraries/crosswalk/format/marc"
 	_ "github.com/lehigh-university-libraries/crosswalk/format/mods"
+	_ "github.com/lehigh-university-libraries/crosswalk/format/ocfl"
+	_ "github.com/lehigh-university-libraries/crosswalk/format/onix"
+	_ "github.com/lehigh-university-libraries/crosswalk/format/openaire"
+	_ "github.com/lehigh-university-libraries/crosswalk/format/openalex"
+	_ "github.com/lehigh-university-libraries/crosswalk/format/orcid"
+	_ "github.com/lehigh-university-libraries/crosswalk/format/parquet"
+	_ "github.com/lehigh-university-libraries/crosswalk/format/pbcore"
+	_ "github.com/lehigh-university-libraries/crosswalk/format/premis"
 	_ "github.com/lehigh-university-libraries/crosswalk/format/proquest"
+	_ "github.com/lehigh-university-libraries/crosswalk/format/rdf"
+	_ "github.com/lehigh-university-libraries/crosswalk/format/ris"
 	_ "github.com/lehigh-university-libraries/crosswalk/format/schemaorg"
+	_ "github.com/lehigh-university-libraries/crosswalk/format/scholix"
+	_ "github.com/lehigh-university-libraries/crosswalk/format/solr"
+	_ "github.com/lehigh-university-libraries/crosswalk/format/tei"
+	_ "github.com/lehigh-university-libraries/crosswalk/format/wikidata"
+	_ "github.com/lehigh-university-libraries/crosswalk/format/zenodo"
 
 	// Register spoke field registries for use as default profiles
 	_ "github.com/lehigh-university-libraries/crosswalk/spoke/islandora/v1"
@@ -32,17 +65,57 @@ import (
 )
 
 var (
-	inputFile     string
-	outputFile    string
-	profileName   string
-	profileFile   string
-	taxonomyFile  string
-	columns       []string
-	multiValueSep string
-	stripHTML     bool
-	pretty        bool
-	baseURL       string
-	enrichDepth   int
+	inputFile      string
+	outputFile     string
+	profileName    string
+	profileFile    string
+	taxonomyFile   string
+	columns        []string
+	staticColumns  map[string]string
+	templateCols   []string
+	multiValueSep  string
+	stripHTML      bool
+	pretty         bool
+	baseURL        string
+	enrichDepth    int
+	allowEmpty     bool
+	dateStyle      string
+	dateMinPrec    string
+	dateMaxPrec    string
+	languageCodes  string
+	maxLengths     map[string]int
+	lengthMode     string
+	postProcess    string
+	provider       string
+	crossmarkDOI   string
+	wbConfigFile   string
+	drupalConfig   string
+	bundle         string
+	fieldMap       string
+	imageService   string
+	manifestBase   string
+	variant        string
+	lossless       bool
+	workers        int
+	skipErrors     bool
+	errorReport    string
+	enrichWith     []string
+	mailto         string
+	reconcileFund  bool
+	typedRelation  string
+	provenanceFile string
+	premisFile     string
+	packageFormat  string
+	bagInfo        []string
+	bagChecksums   []string
+	preserveSource bool
+	arxivTaxonomy  string
+	keepIDs        bool
+	mintScheme     string
+	naan           string
+	shoulder       string
+	minterKind     string
+	minterURL      string
 )
 
 var convertCmd = &cobra.Command{
@@ -56,6 +129,12 @@ Arguments:
 
 Input defaults to stdin, output defaults to stdout.
 
+Exit codes:
+  0  success
+  1  failed
+  2  no records parsed (or the target format has no valid empty document)
+  3  output written, but warnings were logged
+
 Examples:
   # Convert Drupal JSON to CSV (stdin to stdout)
   cat export.json | crosswalk convert drupal csv
@@ -69,8 +148,57 @@ Examples:
   # With taxonomy resolution
   crosswalk convert drupal csv -i data.json --taxonomy-file terms.json
 
+  # Offline: resolve every term from a full export (tid,vid,name,uri)
+  crosswalk convert drupal csv -i data.json --taxonomy-file terms.csv
+
   # Enrich entity references from live Drupal site
-  crosswalk convert drupal csv -i data.json --base-url https://example.com`,
+  crosswalk convert drupal csv -i data.json --base-url https://example.com
+
+  # Refuse to drop funders, relations, or any other populated field
+  crosswalk convert datacite csv -i dc.xml --lossless
+
+  # Convert a large export on 8 goroutines (output order is unchanged)
+  crosswalk convert drupal csv -i export.json -o output.csv --workers 8
+
+  # Fill missing funders, license, ISSN, and publisher from Crossref
+  crosswalk convert datacite csv -i dc.xml --enrich crossref \
+    --mailto metadata@example.edu
+
+  # Add Funder Registry DOIs to bare funder names before a Crossref deposit
+  crosswalk convert datacite crossref -i dc.xml --reconcile-funders \
+    --mailto metadata@example.edu
+
+  # Link subjects without URIs to LCSH, LCNAF, AAT, or TGN headings
+  crosswalk convert drupal islandora-workbench -i export.json -o input.csv \
+    --enrich authorities
+
+  # Mint an EZID ARK for each record without one ($EZID_USER, $EZID_PASSWORD)
+  crosswalk convert drupal islandora-workbench -i export.json -o input.csv \
+    --mint ark --naan 12345 --shoulder x6
+
+  # Or draw them from the library's own Noid minter
+  crosswalk convert csv mods -i items.csv --mint ark --naan 12345 \
+    --minter noid --minter-url https://noid.example.edu/nd/noidu_lehigh
+
+  # Keep going past malformed records, listing them in errors.jsonl
+  crosswalk convert marc csv -i dump.mrc -o output.csv \
+    --skip-errors --error-report errors.jsonl
+
+  # Drupal to Drupal migration that keeps fields the hub doesn't map
+  crosswalk convert drupal drupal -i export.json -o import.json --preserve-source
+
+  # Record parsing, enrichment, and dropped fields per record for review
+  crosswalk convert datacite islandora-workbench -i dc.xml -o input.csv \
+    --enrich crossref --provenance provenance.jsonl
+
+  # Workbench CSV for a site whose config uses its own columns and ";"
+  crosswalk convert drupal islandora-workbench -i export.json -o input.csv \
+    --columns id,title,field_linked_agent,field_subject --separator ';' \
+    --typed-relation id
+
+  # Workbench CSV plus a config.yml checked against the site's config/sync
+  crosswalk convert datacite islandora-workbench -i dc.xml -o input.csv \
+    --workbench-config config.yml --drupal-config ./config/sync`,
 	Args: cobra.ExactArgs(2),
 	RunE: runConvert,
 }
@@ -80,18 +208,98 @@ func init() {
 	convertCmd.Flags().StringVarP(&outputFile, "output", "o", "", "Output file (default: stdout)")
 	convertCmd.Flags().StringVarP(&profileName, "profile", "p", "", "Mapping profile name (e.g., islandora)")
 	convertCmd.Flags().StringVar(&profileFile, "profile-file", "", "Custom profile YAML file")
-	convertCmd.Flags().StringVar(&taxonomyFile, "taxonomy-file", "", "Taxonomy term resolution file (JSON)")
-	convertCmd.Flags().StringSliceVarP(&columns, "columns", "c", nil, "CSV columns to output")
-	convertCmd.Flags().StringVar(&multiValueSep, "separator", "|", "Multi-value field separator")
+	convertCmd.Flags().StringVar(&arxivTaxonomy, "arxiv-taxonomy", "", "JSON file of arXiv category labels by code, replacing and adding to the built-in taxonomy (arxiv)")
+	convertCmd.Flags().StringVar(&taxonomyFile, "taxonomy-file", "", "Taxonomy term resolution file: JSON, or a CSV term export with tid, vid, name, and uri columns")
+	convertCmd.Flags().StringSliceVarP(&columns, "columns", "c", nil, "Columns to output, in order (csv, islandora-workbench; hub fields for parquet)")
+	convertCmd.Flags().StringToStringVar(&staticColumns, "static-column", nil, "Columns with one value in every row, e.g. model=Digital Document (csv)")
+	convertCmd.Flags().StringArrayVar(&templateCols, "template-column", nil, "Column rendered from a Go template over the hub record, e.g. 'citation={{.Title}} ({{column . \"date\"}})'; repeatable (csv)")
+	convertCmd.Flags().StringVar(&multiValueSep, "separator", "|", "Multi-value field separator (the Workbench subdelimiter for islandora-workbench)")
 	convertCmd.Flags().BoolVar(&stripHTML, "strip-html", true, "Strip HTML from text fields")
 	convertCmd.Flags().BoolVar(&pretty, "pretty", false, "Pretty-print JSON output")
 	convertCmd.Flags().StringVar(&baseURL, "base-url", "", "Drupal site base URL for enriching entity references")
 	convertCmd.Flags().IntVar(&enrichDepth, "enrich-depth", 2, "Maximum depth for recursive entity enrichment")
+	convertCmd.Flags().StringVar(&provider, "provider", "", "Organization credited as link provider (scholix) or record source (lido)")
+	convertCmd.Flags().StringVar(&crossmarkDOI, "crossmark-policy", "", "DOI of your Crossmark policy page; copyright assertions are written to Crossref deposits only when it is set (crossref)")
+	convertCmd.Flags().StringVar(&dateStyle, "date-style", "", "Date rendering style: iso, long, year (default: each format's native style)")
+	convertCmd.Flags().StringVar(&dateMinPrec, "date-min-precision", "", "Pad dates to at least this precision: year, month, day")
+	convertCmd.Flags().StringVar(&dateMaxPrec, "date-max-precision", "", "Truncate dates to at most this precision: year, month, day")
+	convertCmd.Flags().StringVar(&languageCodes, "language-codes", "", "ISO 639 code set for languages: iso639-1, iso639-2b, iso639-2t, iso639-3 (default: each format's native set)")
+	convertCmd.Flags().StringToIntVar(&maxLengths, "max-length", nil, "Per-field length limits, e.g. abstract=5000,title=255 (overrides the target format's defaults)")
+	convertCmd.Flags().StringVar(&lengthMode, "on-overlength", "truncate", "What to do with values over a length limit: truncate (logged as a warning) or fail")
+	convertCmd.Flags().StringVar(&postProcess, "post-process", "", "Post-processor config YAML (default: postprocess.yaml in the config directory, if present)")
+	convertCmd.Flags().StringVar(&wbConfigFile, "workbench-config", "", "Also write an Islandora Workbench config.yml template to this file (islandora-workbench target only)")
+	convertCmd.Flags().StringVar(&drupalConfig, "drupal-config", "", "Drupal config/sync directory used to check columns and media types in the Workbench config")
+	convertCmd.Flags().StringVar(&bundle, "bundle", "", "Drupal content type for the Workbench config (default: islandora_object)")
+	convertCmd.Flags().StringVar(&fieldMap, "field-map", "", "YAML file mapping output fields to hub field paths: Solr fields (solr) or an ontology profile (rdf)")
+	convertCmd.Flags().StringVar(&imageService, "image-service", "", "Base URL of the IIIF Image API service serving records' images by file name (iiif)")
+	convertCmd.Flags().StringVar(&manifestBase, "manifest-base", "", "Base URL manifests are published under, for records without a URL of their own (iiif)")
+	convertCmd.Flags().StringVar(&variant, "variant", "", "Output variant for formats with more than one (dublincore: simple, oai_dc, qualified; wikidata: quickstatements, wikibase; parquet: json, exploded; rdf: turtle, ntriples; bibtex: bibtex, biblatex; datacite: xml, json; arxiv: record, atom; openaire: oaire, oai_dc)")
+	convertCmd.Flags().StringVar(&typedRelation, "typed-relation", "", "How islandora-workbench writes field_linked_agent: name (relators:aut:person:Name, default) or id (relators:aut:123, the agent's term ID)")
+	convertCmd.Flags().BoolVar(&keepIDs, "keep-identifiers", false, "Leave DOIs, ISBNs, ISSNs, ORCIDs, and arXiv IDs as the source wrote them instead of rewriting them in canonical form")
+	convertCmd.Flags().StringVar(&mintScheme, "mint", "", "Mint a persistent identifier for each record without one: ark")
+	convertCmd.Flags().StringVar(&naan, "naan", "", "Name Assigning Authority Number ARKs are minted under (--mint ark)")
+	convertCmd.Flags().StringVar(&shoulder, "shoulder", "", "EZID shoulder ARKs are minted on, e.g. fk4 (--mint ark)")
+	convertCmd.Flags().StringVar(&minterKind, "minter", "ezid", "ARK minting service: ezid (account in $EZID_USER and $EZID_PASSWORD) or noid")
+	convertCmd.Flags().StringVar(&minterURL, "minter-url", "", "Minting service URL (default: "+ark.EZIDURL+" for ezid; required for noid)")
+	convertCmd.Flags().BoolVar(&preserveSource, "preserve-source", false, "Keep each record's original source on the record, so converting back to the same format (drupal) replays fields the hub doesn't carry")
+	convertCmd.Flags().BoolVar(&lossless, "lossless", false, "Fail before writing if the target format cannot represent fields present in the input")
+	convertCmd.Flags().BoolVar(&allowEmpty, "allow-empty", false, "Write an empty document instead of failing when no records parse")
+	convertCmd.Flags().BoolVar(&skipErrors, "skip-errors", false, "Skip records that fail to parse, logging each as a warning, instead of stopping")
+	convertCmd.Flags().StringVar(&errorReport, "error-report", "", "Write each record skipped by --skip-errors to this file as a JSON line (source, index, id, offset, error)")
+	convertCmd.Flags().StringVar(&provenanceFile, "provenance", "", "Write each record's audit trail (parser, profile, enrichment and post-processing steps, fields the target drops) to this file as a JSON line")
+	convertCmd.Flags().StringVar(&premisFile, "premis", "", "Write a PREMIS event for each record, describing its crosswalk, to this file once the output is written (with --package bagit, into the bag's payload)")
+	convertCmd.Flags().StringVar(&packageFormat, "package", "", "Package the output, with the local files records refer to, for deposit: bagit writes a BagIt bag to the --output directory")
+	convertCmd.Flags().StringArrayVar(&bagInfo, "bag-info", nil, "bag-info.txt field as Label=Value, replacing a default of the same label (repeatable)")
+	convertCmd.Flags().StringSliceVar(&bagChecksums, "bag-checksum", []string{"sha256"}, "Checksum algorithms for the bag manifests (md5, sha1, sha256, sha512)")
+	convertCmd.Flags().StringSliceVar(&enrichWith, "enrich", nil, "Fill missing fields from external services after parsing (available: "+strings.Join(enrich.Names(), ", ")+")")
+	convertCmd.Flags().BoolVar(&reconcileFund, "reconcile-funders", false, "Give funders with only a name their Crossref Funder Registry DOI, when the name matches exactly (same as --enrich crossref-funders)")
+	convertCmd.Flags().StringVar(&mailto, "mailto", "", "Contact address sent to enrichment services (puts Crossref requests in the faster polite pool)")
+	convertCmd.Flags().IntVar(&workers, "workers", 1, "Records to parse and serialize concurrently (output keeps input order)")
 }
 
 func runConvert(cmd *cobra.Command, args []string) (err error) {
 	fromFormat := args[0]
 	toFormat := args[1]
+	warningCount.Store(0)
+
+	if workers < 1 {
+		return fmt.Errorf("--workers must be at least 1, got %d", workers)
+	}
+	if errorReport != "" && !skipErrors {
+		return fmt.Errorf("--error-report requires --skip-errors")
+	}
+	if err := checkPackage(); err != nil {
+		return err
+	}
+	enrichNames := enrichWith
+	if reconcileFund && !slices.Contains(enrichNames, "crossref-funders") {
+		enrichNames = append(slices.Clone(enrichNames), "crossref-funders")
+	}
+	enrichers, err := buildEnrichers(enrichNames, enrich.Options{Mailto: mailto})
+	if err != nil {
+		return err
+	}
+	minter, err := buildMinter()
+	if err != nil {
+		return err
+	}
+
+	dates, err := dateOptions()
+	if err != nil {
+		return err
+	}
+	languages, err := lang.ParseCodeSet(languageCodes)
+	if err != nil {
+		return fmt.Errorf("--language-codes: %w", err)
+	}
+	lengths, overLength, err := lengthPolicies()
+	if err != nil {
+		return err
+	}
+	templates, err := templateColumns()
+	if err != nil {
+		return err
+	}
 
 	// Determine input source
 	var input io.Reader
@@ -123,23 +331,6 @@ func runConvert(cmd *cobra.Command, args []string) (err error) {
 		input = enrichedInput
 	}
 
-	// Determine output destination
-	var output io.Writer
-	if outputFile != "" {
-		f, err := os.Create(outputFile)
-		if err != nil {
-			return fmt.Errorf("creating output file: %w", err)
-		}
-		defer func() {
-			if cerr := f.Close(); cerr != nil && err == nil {
-				err = fmt.Errorf("closing output file: %w", cerr)
-			}
-		}()
-		output = f
-	} else {
-		output = os.Stdout
-	}
-
 	// Get parser
 	parser, err := format.GetParser(fromFormat)
 	if err != nil {
@@ -169,6 +360,55 @@ func runConvert(cmd *cobra.Command, args []string) (err error) {
 		fmt.Fprintf(os.Stderr, "Loaded %d taxonomy terms, %d nodes\n", store.TermCount(), store.NodeCount())
 	}
 
+	// Serialization options
+	serializeOpts := &format.SerializeOptions{
+		Profile:             profile,
+		Columns:             columns,
+		StaticColumns:       staticColumns,
+		TemplateColumns:     templates,
+		MultiValueSeparator: multiValueSep,
+		IncludeHeader:       true,
+		Pretty:              pretty,
+		Dates:               dates,
+		Languages:           languages,
+		Lengths:             lengths,
+		OverLength:          overLength,
+		Provider:            provider,
+		CrossmarkPolicy:     crossmarkDOI,
+		DrupalConfig:        drupalConfig,
+		Bundle:              bundle,
+		FieldMap:            fieldMap,
+		ImageService:        imageService,
+		ManifestBase:        manifestBase,
+		Variant:             variant,
+		TypedRelation:       typedRelation,
+		Workers:             workers,
+	}
+	if outputFile != "" {
+		serializeOpts.OutputName = filepath.Base(outputFile)
+	}
+	if wbConfigFile != "" && toFormat != "islandora-workbench" {
+		return fmt.Errorf("--workbench-config requires the islandora-workbench target format")
+	}
+
+	if len(serializeOpts.Columns) == 0 && toFormat == "csv" {
+		serializeOpts.Columns = csvfmt.DefaultColumns()
+	}
+
+	// Load institution-specific post-processors
+	pipeline, err := loadPipeline(postProcess)
+	if err != nil {
+		return err
+	}
+
+	// Same-format conversions rewrite the document directly when the format
+	// supports it, skipping the hub round trip. Enrichers, minting, post-processors,
+	// and the provenance trail operate on hub records, so they force the
+	// full path.
+	if normalizer, ok := serializer.(format.Normalizer); ok && fromFormat == toFormat && pipeline.Len() == 0 && len(enrichers) == 0 && minter == nil && !tracking() && packageFormat == "" && !normalizer.NeedsHub(serializeOpts) {
+		return normalizeInput(cmd, normalizer, input, serializeOpts)
+	}
+
 	// Parse input
 	parseOpts := &format.ParseOptions{
 		Profile:          profile,
@@ -176,6 +416,37 @@ func runConvert(cmd *cobra.Command, args []string) (err error) {
 		StripHTML:        stripHTML,
 		SourceName:       inputName,
 		BaseURL:          baseURL,
+		Workers:          workers,
+		PreserveSource:   preserveSource,
+		ArxivTaxonomy:    arxivTaxonomy,
+		KeepIdentifiers:  keepIDs,
+	}
+
+	// Bad records are skipped rather than ending the conversion, in the
+	// formats that can read past one
+	var skipper *recordSkipper
+	if skipErrors {
+		skipper = &recordSkipper{source: inputName}
+		if errorReport != "" {
+			f, ferr := os.Create(errorReport)
+			if ferr != nil {
+				return fmt.Errorf("creating error report: %w", ferr)
+			}
+			defer func() {
+				if cerr := f.Close(); cerr != nil && err == nil {
+					err = fmt.Errorf("closing error report: %w", cerr)
+				}
+			}()
+			skipper.report = json.NewEncoder(f)
+		}
+		parseOpts.OnRecordError = skipper.skip
+	}
+
+	// With no step that needs the whole batch, records go from the parser
+	// to a streaming serializer one at a time, so a large export is never
+	// held in memory
+	if streamer, ok := serializer.(format.StreamSerializer); ok && len(enrichers) == 0 && pipeline.Len() == 0 && minter == nil && !lossless && !tracking() && packageFormat == "" && wbConfigFile == "" && !readsMembershipPaths(serializeOpts) {
+		return streamRecords(cmd, parser, input, parseOpts, streamer, serializeOpts, skipper)
 	}
 
 	records, err := parser.Parse(input, parseOpts)
@@ -184,42 +455,264 @@ func runConvert(cmd *cobra.Command, args []string) (err error) {
 	}
 
 	fmt.Fprintf(os.Stderr, "Parsed %d records\n", len(records))
+	recordParse(records, parser.Name(), profile)
+	if skipper != nil && skipper.skipped > 0 {
+		fmt.Fprintf(os.Stderr, "Skipped %d records that failed to parse\n", skipper.skipped)
+	}
 
-	// Serialize output
-	serializeOpts := &format.SerializeOptions{
-		Profile:             profile,
-		Columns:             columns,
-		MultiValueSeparator: multiValueSep,
-		IncludeHeader:       true,
-		Pretty:              pretty,
+	// Nothing parsed: refuse to write unless explicitly allowed, so an empty
+	// harvest doesn't silently replace a previous export
+	if len(records) == 0 && !allowEmpty {
+		cmd.SilenceUsage = true
+		return &ExitError{
+			Code: ExitNoRecords,
+			Err:  fmt.Errorf("no records parsed from %s (use --allow-empty to write an empty %s document)", inputName, toFormat),
+		}
 	}
 
-	if len(serializeOpts.Columns) == 0 && toFormat == "csv" {
-		serializeOpts.Columns = csvfmt.DefaultColumns()
+	if err := runEnrichers(cmd.Context(), enrichers, records); err != nil {
+		return err
+	}
+
+	// Resolve ancestor collections across the whole batch
+	hub.ComputeMembershipPaths(records)
+
+	if pipeline.Len() > 0 {
+		err := trackStep(records, hub.StagePostProcess, strings.Join(pipeline.Names(), ", "), func() error {
+			return pipeline.Run(records)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// Deposit workflows can't afford to drop data silently; check the
+	// target can carry everything before any output is written
+	if lossless {
+		if err := format.CheckLossless(serializer, records, serializeOpts); err != nil {
+			cmd.SilenceUsage = true
+			return err
+		}
+	}
+
+	// Workbench config template alongside the CSV
+	if wbConfigFile != "" {
+		f, ferr := os.Create(wbConfigFile)
+		if ferr != nil {
+			return fmt.Errorf("creating workbench config file: %w", ferr)
+		}
+		defer func() {
+			if cerr := f.Close(); cerr != nil && err == nil {
+				err = fmt.Errorf("closing workbench config file: %w", cerr)
+			}
+		}()
+		serializeOpts.ExtraWriters = map[string]io.Writer{"config": f}
+	}
+
+	// The provenance file and the bag are created before minting, so
+	// that a path that can't be written fails while nothing has been
+	// registered
+	var provenance io.Writer
+	if provenanceFile != "" {
+		f, ferr := os.Create(provenanceFile)
+		if ferr != nil {
+			return fmt.Errorf("creating provenance file: %w", ferr)
+		}
+		defer func() {
+			if cerr := f.Close(); cerr != nil && err == nil {
+				err = fmt.Errorf("closing provenance file: %w", cerr)
+			}
+		}()
+		provenance = f
+	}
+	output := outputFile
+	var bag *bagOutput
+	if packageFormat == packageBagIt {
+		if bag, err = startBag(serializer); err != nil {
+			return err
+		}
+		serializeOpts.OutputName = bag.payload
+		output = bag.outputPath()
+	}
+
+	// ARKs can't be taken back once registered, so they're minted after
+	// every check that can abort, and listed if the output then isn't
+	// written
+	wrote := false
+	defer func() {
+		if !wrote && bag != nil {
+			bag.bag.Discard()
+		}
+	}()
+	if minter != nil {
+		minted, merr := mintARKs(cmd.Context(), minter, records)
+		defer func() {
+			if !wrote {
+				reportUnwrittenARKs(minted)
+			}
+		}()
+		if merr != nil {
+			return merr
+		}
+	}
+
+	// The audit trail is written before the output, so a curator can see
+	// what happened even when serialization fails
+	recordSerialize(records, serializer, serializeOpts)
+	if provenance != nil {
+		if err := writeProvenance(provenance, records); err != nil {
+			return err
+		}
+	}
+
+	// Bag the files the output refers to, for deposit
+	if bag != nil {
+		bag.stageFiles(records)
+	}
+
+	err = writeRecordsTo(cmd, output, serializer, records, serializeOpts)
+	wrote = outputWritten(err)
+	if !wrote {
+		return err
+	}
+
+	// PREMIS events describe output that exists, so they follow it
+	if premisFile != "" {
+		path := premisFile
+		if bag != nil {
+			p, perr := bag.premisPath()
+			if perr != nil {
+				return perr
+			}
+			path = p
+		}
+		if perr := writePremis(path, records); perr != nil {
+			return perr
+		}
+	}
+	if bag != nil {
+		if berr := bag.finish(records, fromFormat, toFormat); berr != nil {
+			return berr
+		}
+		fmt.Fprintf(os.Stderr, "Wrote bag %s\n", outputFile)
+	}
+	return err
+}
+
+// outputWritten reports whether writeRecords wrote the output: it
+// succeeded, or only reports warnings.
+func outputWritten(err error) bool {
+	var exitErr *ExitError
+	return err == nil || errors.As(err, &exitErr) && exitErr.Code == ExitWarnings
+}
+
+// writeRecords serializes records to the --output file or stdout.
+func writeRecords(cmd *cobra.Command, serializer format.Serializer, records []*hubv1.Record, opts *format.SerializeOptions) error {
+	return writeRecordsTo(cmd, outputFile, serializer, records, opts)
+}
+
+// writeRecordsTo serializes records to the named file, or stdout when the
+// name is empty. Empty output is serialized to a buffer first so formats
+// without a valid empty document don't leave a truncated output file
+// behind.
+func writeRecordsTo(cmd *cobra.Command, path string, serializer format.Serializer, records []*hubv1.Record, opts *format.SerializeOptions) (err error) {
+	var empty bytes.Buffer
+	if len(records) == 0 {
+		if err := serializer.Serialize(&empty, records, opts); err != nil {
+			cmd.SilenceUsage = true
+			if errors.Is(err, format.ErrEmptyDocument) {
+				return &ExitError{Code: ExitNoRecords, Err: fmt.Errorf("serializing output: %w", err)}
+			}
+			return fmt.Errorf("serializing output: %w", err)
+		}
 	}
 
-	if err := serializer.Serialize(output, records, serializeOpts); err != nil {
+	output, closeOutput, err := openOutput(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := closeOutput(); cerr != nil && err == nil {
+			err = fmt.Errorf("closing output file: %w", cerr)
+		}
+	}()
+
+	if len(records) == 0 {
+		if _, err := output.Write(empty.Bytes()); err != nil {
+			return fmt.Errorf("writing output: %w", err)
+		}
+	} else if err := serializer.Serialize(output, records, opts); err != nil {
 		return fmt.Errorf("serializing output: %w", err)
 	}
 
+	if n := warningCount.Load(); n > 0 {
+		cmd.SilenceUsage = true
+		return &ExitError{Code: ExitWarnings, Err: fmt.Errorf("completed with %d warning(s)", n)}
+	}
+
+	return nil
+}
+
+// normalizeInput is the same-format fast path: the format rewrites its own
+// document without parsing into hub records.
+func normalizeInput(cmd *cobra.Command, normalizer format.Normalizer, input io.Reader, opts *format.SerializeOptions) (err error) {
+	output, closeOutput, err := openOutput(outputFile)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := closeOutput(); cerr != nil && err == nil {
+			err = fmt.Errorf("closing output file: %w", cerr)
+		}
+	}()
+
+	fmt.Fprintf(os.Stderr, "Normalizing %s without hub conversion\n", normalizer.Name())
+	if err := normalizer.Normalize(input, output, opts); err != nil {
+		return fmt.Errorf("normalizing input: %w", err)
+	}
+
+	if n := warningCount.Load(); n > 0 {
+		cmd.SilenceUsage = true
+		return &ExitError{Code: ExitWarnings, Err: fmt.Errorf("completed with %d warning(s)", n)}
+	}
 	return nil
 }
 
+// openOutput returns the named output file, or stdout when the name is
+// empty, and a function that closes it.
+func openOutput(path string) (io.Writer, func() error, error) {
+	if path == "" {
+		return os.Stdout, func() error { return nil }, nil
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("creating output file: %w", err)
+	}
+	return f, f.Close, nil
+}
+
 func loadProfile(fromFormat string) (*mapping.Profile, error) {
+	return resolveProfile(fromFormat, profileName, profileFile, inputFile)
+}
+
+// resolveProfile finds the mapping profile for a source format. An explicit
+// profile file wins, then a named user or embedded profile, then a user
+// profile auto-discovered from the input, then the spoke-generated default.
+func resolveProfile(fromFormat, name, file, input string) (*mapping.Profile, error) {
 	// Load from file if specified
-	if profileFile != "" {
-		return mapping.LoadProfile(profileFile)
+	if file != "" {
+		return mapping.LoadProfile(file)
 	}
 
 	// Load from user profiles by name first
-	if profileName != "" {
+	if name != "" {
 		// Try user profile in ~/.crosswalk/profiles/
-		if profile.Exists(profileName) {
-			p, err := profile.Load(profileName)
+		if profile.Exists(name) {
+			p, err := profile.Load(name)
 			if err != nil {
 				return nil, fmt.Errorf("loading user profile: %w", err)
 			}
-			return convertUserProfile(p), nil
+			return convertUserProfile(p)
 		}
 
 		// Fall back to embedded profiles
@@ -228,19 +721,19 @@ func loadProfile(fromFormat string) (*mapping.Profile, error) {
 			return nil, err
 		}
 
-		mp, ok := registry.Get(profileName)
+		mp, ok := registry.Get(name)
 		if !ok {
-			return nil, fmt.Errorf("unknown profile: %s (not found in ~/.crosswalk/profiles/ or embedded profiles)", profileName)
+			return nil, fmt.Errorf("unknown profile: %s (not found in ~/.crosswalk/profiles/ or embedded profiles)", name)
 		}
 		return mp, nil
 	}
 
 	// Try auto-discovery from user profiles based on input file
-	if inputFile != "" {
-		p, err := autoDiscoverProfile(fromFormat, inputFile)
+	if input != "" {
+		p, err := autoDiscoverProfile(fromFormat, input)
 		if err == nil && p != nil {
 			fmt.Fprintf(os.Stderr, "Auto-discovered profile: %s\n", p.Name)
-			return convertUserProfile(p), nil
+			return convertUserProfile(p)
 		}
 	}
 
@@ -295,8 +788,16 @@ func enrichDrupalInput(input io.Reader) (io.Reader, error) {
 	return strings.NewReader(string(enrichedData)), nil
 }
 
-// convertUserProfile converts a user profile.Profile to mapping.Profile.
-func convertUserProfile(p *profile.Profile) *mapping.Profile {
+// convertUserProfile converts a user profile.Profile to mapping.Profile,
+// checking its transform directives and templates compile, and merges it
+// over the profiles it extends and includes.
+func convertUserProfile(p *profile.Profile) (*mapping.Profile, error) {
+	return convertUserProfileChain(p, nil)
+}
+
+// convertUserProfileChain is convertUserProfile for a profile reached
+// through the extends and includes in chain, to catch cycles.
+func convertUserProfileChain(p *profile.Profile, chain []string) (*mapping.Profile, error) {
 	mp := &mapping.Profile{
 		Name:        p.Name,
 		Format:      p.Format,
@@ -311,6 +812,18 @@ func convertUserProfile(p *profile.Profile) *mapping.Profile {
 	}
 
 	for source, fm := range p.Fields {
+		var fieldRules []mapping.MappingRule
+		for _, r := range fm.Rules {
+			fieldRules = append(fieldRules, mapping.MappingRule{
+				When:         r.When,
+				IR:           r.Hub,
+				Type:         r.Type,
+				DateType:     r.DateType,
+				RelationType: r.RelationType,
+				Vocabulary:   r.Vocabulary,
+				Skip:         r.Skip,
+			})
+		}
 		mp.Fields[source] = mapping.FieldMapping{
 			IR:           fm.Hub, // Hub field maps to IR in the mapping package
 			Type:         fm.Type,
@@ -323,8 +836,135 @@ func convertUserProfile(p *profile.Profile) *mapping.Profile {
 			Vocabulary:   fm.Vocabulary,
 			MultiValue:   fm.MultiValue,
 			Delimiter:    fm.Delimiter,
+			MaxLength:    fm.MaxLength,
+			Transform:    fm.Transform,
+			Template:     fm.Template,
+			Rules:        fieldRules,
+		}
+	}
+
+	if err := mp.Validate(); err != nil {
+		return nil, fmt.Errorf("profile %s: %w", p.Name, err)
+	}
+	if p.Extends == "" && len(p.Include) == 0 {
+		return mp, nil
+	}
+
+	chain = append(chain, p.Name)
+	var base *mapping.Profile
+	if p.Extends != "" {
+		var err error
+		if base, err = loadBaseProfile(p.Extends, chain); err != nil {
+			return nil, fmt.Errorf("profile %s: extends %s: %w", p.Name, p.Extends, err)
+		}
+	}
+	for _, ref := range p.Include {
+		included, err := loadBaseProfile(ref, chain)
+		if err != nil {
+			return nil, fmt.Errorf("profile %s: include %s: %w", p.Name, ref, err)
+		}
+		if base == nil {
+			base = included
+		} else {
+			base = mapping.MergeProfiles(base, included)
 		}
 	}
+	return mapping.MergeProfiles(base, mp), nil
+}
 
-	return mp
+// loadBaseProfile loads a profile a user profile extends or includes: a
+// saved profile by name (with or without .yaml), a YAML file in either
+// profile layout, or an embedded profile.
+func loadBaseProfile(ref string, chain []string) (*mapping.Profile, error) {
+	name := strings.TrimSuffix(ref, ".yaml")
+	if slices.Contains(chain, name) {
+		return nil, fmt.Errorf("profile %s extends or includes itself", name)
+	}
+	if profile.Exists(name) {
+		p, err := profile.Load(name)
+		if err != nil {
+			return nil, err
+		}
+		return convertUserProfileChain(p, chain)
+	}
+	if _, err := os.Stat(ref); err == nil {
+		return loadProfileFile(ref, chain)
+	}
+	registry, err := mapping.NewProfileRegistry()
+	if err != nil {
+		return nil, err
+	}
+	if mp, ok := registry.Get(name); ok {
+		return mp, nil
+	}
+	return nil, fmt.Errorf("unknown profile %q (not a saved or embedded profile, or a file)", ref)
+}
+
+// loadProfileFile loads a profile from a YAML file in either the user
+// (hub:) or mapping (ir:) profile layout.
+func loadProfileFile(path string, chain []string) (*mapping.Profile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var up profile.Profile
+	if err := yaml.Unmarshal(data, &up); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	for _, fm := range up.Fields {
+		if fm.Hub != "" {
+			if up.Name == "" {
+				up.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+			}
+			return convertUserProfileChain(&up, chain)
+		}
+	}
+	return mapping.LoadProfile(path)
+}
+
+// dateOptions builds the serializer date options from the --date-* flags.
+func dateOptions() (format.DateOptions, error) {
+	var opts format.DateOptions
+	var err error
+	if opts.Style, err = format.ParseDateStyle(dateStyle); err != nil {
+		return opts, fmt.Errorf("--date-style: %w", err)
+	}
+	if opts.MinPrecision, err = format.ParseDatePrecision(dateMinPrec); err != nil {
+		return opts, fmt.Errorf("--date-min-precision: %w", err)
+	}
+	if opts.MaxPrecision, err = format.ParseDatePrecision(dateMaxPrec); err != nil {
+		return opts, fmt.Errorf("--date-max-precision: %w", err)
+	}
+	return opts, nil
+}
+
+// templateColumns builds the template columns from --template-column
+// values of the form name=template.
+func templateColumns() (map[string]string, error) {
+	if len(templateCols) == 0 {
+		return nil, nil
+	}
+	templates := make(map[string]string, len(templateCols))
+	for _, v := range templateCols {
+		name, text, ok := strings.Cut(v, "=")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("--template-column %q: want name=template", v)
+		}
+		templates[strings.TrimSpace(name)] = text
+	}
+	return templates, nil
+}
+
+// lengthPolicies builds per-field length overrides from --max-length and
+// the over-length mode from --on-overlength.
+func lengthPolicies() (map[string]format.LengthPolicy, format.LengthMode, error) {
+	mode, err := format.ParseLengthMode(lengthMode)
+	if err != nil {
+		return nil, "", fmt.Errorf("--on-overlength: %w", err)
+	}
+	policies := make(map[string]format.LengthPolicy, len(maxLengths))
+	for field, n := range maxLengths {
+		policies[field] = format.LengthPolicy{Max: n, Mode: mode}
+	}
+	return policies, mode, nil
 }
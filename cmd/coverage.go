@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+// auditCoverageCmd reports source fields that the profile does not map.
+var auditCoverageCmd = &cobra.Command{
+	Use:   "coverage <format> <input-file>",
+	Short: "Report source fields that are not mapped by the profile",
+	Long: `Parses a source export and reports every source field that carried a
+non-empty value but has no mapping in the active profile, along with how
+many records it appeared in.
+
+Reports can be written as text, JSON, or JUnit XML so a migration pipeline
+can fail when a new field shows up that nobody has mapped yet.
+
+Example:
+  crosswalk audit coverage drupal export.json --profile my-site
+  crosswalk audit coverage drupal export.json --report junit -o coverage.xml --fail-on-unmapped
+  crosswalk audit coverage csv records.csv --ignore internal_notes,batch_id`,
+	Args: cobra.ExactArgs(2),
+	RunE: runAuditCoverage,
+}
+
+func init() {
+	auditCmd.AddCommand(auditCoverageCmd)
+
+	auditCoverageCmd.Flags().StringP("profile", "p", "", "Mapping profile name")
+	auditCoverageCmd.Flags().String("profile-file", "", "Custom profile YAML file")
+	auditCoverageCmd.Flags().String("report", "text", "Report format: text, json, or junit")
+	auditCoverageCmd.Flags().StringP("output", "o", "", "Output file (default: stdout)")
+	auditCoverageCmd.Flags().StringSlice("ignore", nil, "Source fields to leave out of the report")
+	auditCoverageCmd.Flags().Bool("fail-on-unmapped", false, "Exit non-zero when any unmapped field is found")
+}
+
+func runAuditCoverage(cmd *cobra.Command, args []string) (err error) {
+	formatName := args[0]
+	inputPath := args[1]
+
+	name, _ := cmd.Flags().GetString("profile")
+	file, _ := cmd.Flags().GetString("profile-file")
+	reportFormat, _ := cmd.Flags().GetString("report")
+	outputPath, _ := cmd.Flags().GetString("output")
+	ignore, _ := cmd.Flags().GetStringSlice("ignore")
+	failOnUnmapped, _ := cmd.Flags().GetBool("fail-on-unmapped")
+
+	parser, err := format.GetParser(formatName)
+	if err != nil {
+		return fmt.Errorf("unknown format: %s: %w", formatName, err)
+	}
+
+	mp, err := resolveProfile(formatName, name, file, inputPath)
+	if err != nil {
+		return fmt.Errorf("loading profile: %w", err)
+	}
+
+	f, err := os.Open(inputPath)
+	if err != nil {
+		return fmt.Errorf("opening input file: %w", err)
+	}
+	defer func() {
+		if cerr := f.Close(); cerr != nil && err == nil {
+			err = fmt.Errorf("closing input file: %w", cerr)
+		}
+	}()
+
+	parseOpts := format.NewParseOptions()
+	parseOpts.Profile = mp
+	parseOpts.SourceName = inputPath
+
+	records, err := parser.Parse(f, parseOpts)
+	if err != nil {
+		return fmt.Errorf("parsing input: %w", err)
+	}
+
+	report := hub.AuditCoverage(records, ignore)
+	report.Format = formatName
+	report.Source = inputPath
+	if mp != nil {
+		report.Profile = mp.Name
+	}
+
+	var output []byte
+	switch strings.ToLower(reportFormat) {
+	case "json":
+		output, err = json.MarshalIndent(report, "", "  ")
+	case "junit":
+		output, err = report.JUnit()
+	case "text", "":
+		output = []byte(formatCoverageReport(report))
+	default:
+		return fmt.Errorf("unknown report format %q (want text, json, or junit)", reportFormat)
+	}
+	if err != nil {
+		return fmt.Errorf("marshaling report: %w", err)
+	}
+
+	if outputPath != "" {
+		if err := os.WriteFile(outputPath, output, 0644); err != nil {
+			return fmt.Errorf("writing report: %w", err)
+		}
+	} else {
+		fmt.Println(string(output))
+	}
+
+	if failOnUnmapped && len(report.UnmappedFields) > 0 {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%d unmapped source field(s) found", len(report.UnmappedFields))
+	}
+	return nil
+}
+
+func formatCoverageReport(report *hub.CoverageReport) string {
+	var sb strings.Builder
+
+	sb.WriteString("=== Field Mapping Coverage Report ===\n\n")
+	fmt.Fprintf(&sb, "Source: %s (%s)\n", report.Source, report.Format)
+	if report.Profile != "" {
+		fmt.Fprintf(&sb, "Profile: %s\n", report.Profile)
+	}
+	fmt.Fprintf(&sb, "Total records: %d\n\n", report.TotalRecords)
+
+	if len(report.UnmappedFields) == 0 {
+		sb.WriteString("✓ Every non-empty source field is mapped\n")
+		return sb.String()
+	}
+
+	sb.WriteString("❌ UNMAPPED SOURCE FIELDS:\n")
+	for _, uf := range report.UnmappedFields {
+		fmt.Fprintf(&sb, "  • %s: %d records (%.1f%%)\n", uf.Field, uf.Count, uf.Percentage)
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,295 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+	"github.com/lehigh-university-libraries/crosswalk/sword"
+)
+
+var depositCmd = &cobra.Command{
+	Use:   "deposit <from>",
+	Short: "Deposit records into a repository over SWORD v2",
+	Long: `Deposit records into a repository over SWORD v2, one item per record.
+
+Reads the server's service document from --target and deposits into the
+collection named by --collection (its title or href), which may be left out
+when the service document lists only one. Each record is sent in a package
+the collection accepts:
+
+  mets  a METS DSpace SIP zip: mets.xml with a MODS description, and the
+        record's local files
+  dc    an Atom entry of DC Terms elements, metadata without files
+
+The default, auto, sends METS packages to collections that accept them and
+Atom entries otherwise.
+
+The identifiers in each deposit receipt (the item's splash page, any
+dcterms:identifier such as a handle, and the edit IRI) are added to the
+record, and the deposited records are written in the --to format, hub JSONL
+by default, so a later run can find the items again.
+
+The password may also be given in the SWORD_PASSWORD environment variable.
+A record that fails to deposit is reported as a warning (exit code 3) and
+written without new identifiers.
+
+Examples:
+  crosswalk deposit islandora-workbench -i input.csv \
+    --protocol sword2 --target https://dspace.example.edu/swordv2/servicedocument \
+    --collection "Electronic Theses" --user depositor@example.edu -o deposited.jsonl`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDeposit,
+}
+
+// Packaging choices for --packaging.
+const (
+	depositPackagingAuto = "auto"
+	depositPackagingMETS = "mets"
+	depositPackagingDC   = "dc"
+)
+
+func init() {
+	rootCmd.AddCommand(depositCmd)
+
+	depositCmd.Flags().StringVarP(&inputFile, "input", "i", "", "Input file (default: stdin)")
+	depositCmd.Flags().StringVarP(&outputFile, "output", "o", "", "Output file for the deposited records (default: stdout)")
+	depositCmd.Flags().StringVarP(&profileName, "profile", "p", "", "Mapping profile name (e.g., islandora)")
+	depositCmd.Flags().StringVar(&profileFile, "profile-file", "", "Custom profile YAML file")
+	depositCmd.Flags().String("protocol", "sword2", "Deposit protocol (sword2)")
+	depositCmd.Flags().String("target", "", "Service document URL")
+	depositCmd.Flags().String("collection", "", "Collection to deposit into, by title or href (default: the only one)")
+	depositCmd.Flags().String("packaging", depositPackagingAuto, "Package to send: auto, mets, or dc")
+	depositCmd.Flags().String("user", "", "User name for HTTP basic authentication")
+	depositCmd.Flags().String("password", "", "Password for HTTP basic authentication (default: $SWORD_PASSWORD)")
+	depositCmd.Flags().String("on-behalf-of", "", "User a mediated deposit is made for")
+	depositCmd.Flags().Bool("in-progress", false, "Leave items open for further deposits instead of submitting them")
+	depositCmd.Flags().String("to", "hub-jsonl", "Format of the deposited records written to --output")
+	_ = depositCmd.MarkFlagRequired("target")
+}
+
+func runDeposit(cmd *cobra.Command, args []string) error {
+	fromFormat := args[0]
+	protocol, _ := cmd.Flags().GetString("protocol")
+	target, _ := cmd.Flags().GetString("target")
+	collectionName, _ := cmd.Flags().GetString("collection")
+	packaging, _ := cmd.Flags().GetString("packaging")
+	user, _ := cmd.Flags().GetString("user")
+	password, _ := cmd.Flags().GetString("password")
+	onBehalfOf, _ := cmd.Flags().GetString("on-behalf-of")
+	inProgress, _ := cmd.Flags().GetBool("in-progress")
+	toFormat, _ := cmd.Flags().GetString("to")
+	warningCount.Store(0)
+
+	if protocol != "sword2" {
+		return fmt.Errorf("unsupported deposit protocol %q (want sword2)", protocol)
+	}
+	switch packaging {
+	case depositPackagingAuto, depositPackagingMETS, depositPackagingDC:
+	default:
+		return fmt.Errorf("unknown packaging %q (want auto, mets, or dc)", packaging)
+	}
+	if password == "" {
+		password = os.Getenv("SWORD_PASSWORD")
+	}
+
+	parser, err := format.GetParser(fromFormat)
+	if err != nil {
+		return fmt.Errorf("unknown source format %q: %w", fromFormat, err)
+	}
+	serializer, err := format.GetSerializer(toFormat)
+	if err != nil {
+		return fmt.Errorf("unknown target format %q: %w", toFormat, err)
+	}
+	profile, err := loadProfile(fromFormat)
+	if err != nil {
+		return fmt.Errorf("loading profile: %w", err)
+	}
+
+	var input io.Reader = os.Stdin
+	if inputFile != "" {
+		f, err := os.Open(inputFile)
+		if err != nil {
+			return fmt.Errorf("opening input file: %w", err)
+		}
+		defer f.Close()
+		input = f
+	}
+	records, err := parser.Parse(input, &format.ParseOptions{
+		Profile:    profile,
+		StripHTML:  true,
+		SourceName: inputFile,
+	})
+	if err != nil {
+		return fmt.Errorf("parsing input: %w", err)
+	}
+	if len(records) == 0 {
+		cmd.SilenceUsage = true
+		return &ExitError{Code: ExitNoRecords, Err: errors.New("no records to deposit")}
+	}
+
+	client := sword.NewClient(target)
+	client.Username = user
+	client.Password = password
+	client.OnBehalfOf = onBehalfOf
+	ctx := cmd.Context()
+
+	cmd.SilenceUsage = true
+	service, err := client.ServiceDocument(ctx)
+	if err != nil {
+		return fmt.Errorf("reading service document: %w", err)
+	}
+	collection, err := depositCollection(service, collectionName)
+	if err != nil {
+		return err
+	}
+	if packaging, err = depositPackaging(collection, packaging); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Depositing %d records to %s as %s\n", len(records), collection.Title, packaging)
+
+	deposited := 0
+	for i, r := range records {
+		d, err := depositPackage(r, i, packaging)
+		if err != nil {
+			slog.Warn("record not deposited: packaging failed", "title", r.Title, "err", err)
+			continue
+		}
+		d.InProgress = inProgress
+
+		receipt, err := client.Deposit(ctx, collection.Href, d)
+		var swordErr *sword.Error
+		if deposited == 0 && errors.As(err, &swordErr) &&
+			(swordErr.Status == http.StatusUnauthorized || swordErr.Status == http.StatusForbidden) {
+			// Every other record would be refused too
+			return fmt.Errorf("depositing to %s: %w", collection.Href, err)
+		}
+		if err != nil {
+			slog.Warn("record not deposited", "title", r.Title, "err", err)
+			continue
+		}
+
+		addReceiptIdentifiers(r, receipt)
+		deposited++
+		fmt.Fprintf(os.Stderr, "Deposited %s\n", receipt.EditIRI)
+	}
+	if deposited == 0 {
+		return fmt.Errorf("none of the %d records were deposited", len(records))
+	}
+	fmt.Fprintf(os.Stderr, "Deposited %d of %d records\n", deposited, len(records))
+
+	opts := &format.SerializeOptions{IncludeHeader: true}
+	if outputFile != "" {
+		opts.OutputName = filepath.Base(outputFile)
+	}
+	return writeRecords(cmd, serializer, records, opts)
+}
+
+// depositCollection finds the --collection in the service document by
+// title or href. Without one, the service document must list exactly one
+// collection.
+func depositCollection(service *sword.ServiceDocument, name string) (sword.Collection, error) {
+	collections := service.Collections()
+	if name == "" {
+		switch len(collections) {
+		case 0:
+			return sword.Collection{}, errors.New("the service document lists no collections")
+		case 1:
+			return collections[0], nil
+		}
+		return sword.Collection{}, fmt.Errorf("the service document lists %d collections; choose one with --collection:\n%s",
+			len(collections), collectionList(collections))
+	}
+	for _, c := range collections {
+		if c.Href == name || c.Title == name {
+			return c, nil
+		}
+	}
+	return sword.Collection{}, fmt.Errorf("no collection %q in the service document; it lists:\n%s", name, collectionList(collections))
+}
+
+func collectionList(collections []sword.Collection) string {
+	lines := make([]string, len(collections))
+	for i, c := range collections {
+		lines[i] = fmt.Sprintf("  %s <%s>", c.Title, c.Href)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// depositPackaging resolves --packaging against what the collection
+// accepts.
+func depositPackaging(c sword.Collection, packaging string) (string, error) {
+	mets := c.AcceptsPackaging(sword.PackagingMETSDSpaceSIP)
+	switch {
+	case packaging == depositPackagingMETS && !mets:
+		return "", fmt.Errorf("collection %s does not accept METS DSpace SIP packages (accepts %s)",
+			c.Title, strings.Join(c.AcceptPackaging, ", "))
+	case packaging == depositPackagingDC && !c.AcceptsEntries():
+		return "", fmt.Errorf("collection %s does not accept Atom entries", c.Title)
+	case packaging != depositPackagingAuto:
+		return packaging, nil
+	case mets:
+		return depositPackagingMETS, nil
+	case c.AcceptsEntries():
+		return depositPackagingDC, nil
+	}
+	return "", fmt.Errorf("collection %s accepts neither METS DSpace SIP packages nor Atom entries", c.Title)
+}
+
+// depositPackage builds the SWORD deposit for the i'th record.
+func depositPackage(r *hubv1.Record, i int, packaging string) (*sword.Deposit, error) {
+	if packaging == depositPackagingDC {
+		entry, err := sword.DCEntry(r)
+		if err != nil {
+			return nil, err
+		}
+		return &sword.Deposit{Content: entry, ContentType: sword.ContentTypeEntry}, nil
+	}
+	pkg, err := sword.METSPackage(r)
+	if err != nil {
+		return nil, err
+	}
+	return &sword.Deposit{
+		Content:     pkg,
+		ContentType: "application/zip",
+		Filename:    fmt.Sprintf("record-%d.zip", i+1),
+		Packaging:   sword.PackagingMETSDSpaceSIP,
+	}, nil
+}
+
+// addReceiptIdentifiers adds the identifiers a deposit receipt gives the
+// new item to the record: its dcterms:identifier values, its splash pages,
+// and the edit IRI later SWORD requests address it by.
+func addReceiptIdentifiers(r *hubv1.Record, receipt *sword.Receipt) {
+	values := append(append([]string{}, receipt.Identifiers...), receipt.Alternate...)
+	values = append(values, receipt.EditIRI)
+	for _, v := range values {
+		id := hub.NewIdentifier(v, hubv1.IdentifierType_IDENTIFIER_TYPE_UNSPECIFIED)
+		if id.Value == "" {
+			continue
+		}
+		if id.Type == hubv1.IdentifierType_IDENTIFIER_TYPE_UNSPECIFIED {
+			id.Type = hubv1.IdentifierType_IDENTIFIER_TYPE_LOCAL
+		}
+		known := false
+		for _, existing := range r.Identifiers {
+			if existing.Type == id.Type && existing.Value == id.Value {
+				known = true
+				break
+			}
+		}
+		if !known {
+			r.Identifiers = append(r.Identifiers, id)
+		}
+	}
+}
@@ -0,0 +1,251 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <a> <b>",
+	Short: "Compare the hub records in two files",
+	Long: `Parse two files, possibly in different formats, into hub records and
+print the differences field by field: changed titles, added contributors,
+dropped identifiers. Use it to check round-trip fidelity, e.g. that a
+Drupal export survives conversion to DataCite and back.
+
+Each file's format is detected from its extension and content unless
+--from-a or --from-b names it. Records are paired by position, or with
+--by-identifier by any identifier value they share.
+
+Lines start with ~ for a changed value, + for a value only b has, and -
+for a value only a has. source_info, which records the source format, is
+ignored unless --ignore is given.
+
+Exit codes:
+  0  no differences
+  1  failed
+  4  the files differ
+
+Examples:
+  crosswalk diff export.json roundtrip.json
+  crosswalk diff export.json datacite.xml --from-a drupal --from-b datacite
+  crosswalk diff before.csv after.csv --by-identifier --ignore source_info,extra`,
+	Args: cobra.ExactArgs(2),
+	RunE: runDiff,
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+
+	diffCmd.Flags().String("from-a", "", "Format of the first file (default: detected)")
+	diffCmd.Flags().String("from-b", "", "Format of the second file (default: detected)")
+	diffCmd.Flags().Bool("by-identifier", false, "Pair records that share an identifier value instead of by position")
+	diffCmd.Flags().StringSlice("ignore", []string{"source_info"}, "Hub fields to leave out of the comparison (proto paths, e.g. extra.nid)")
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	fromA, _ := cmd.Flags().GetString("from-a")
+	fromB, _ := cmd.Flags().GetString("from-b")
+	byIdentifier, _ := cmd.Flags().GetBool("by-identifier")
+	ignore, _ := cmd.Flags().GetStringSlice("ignore")
+
+	a, fromA, err := parseDiffInput(args[0], fromA)
+	if err != nil {
+		return err
+	}
+	b, fromB, err := parseDiffInput(args[1], fromB)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("--- %s (%s, %d records)\n", args[0], fromA, len(a))
+	fmt.Printf("+++ %s (%s, %d records)\n", args[1], fromB, len(b))
+
+	pairs := pairByPosition(a, b)
+	if byIdentifier {
+		pairs = pairByIdentifier(a, b)
+	}
+
+	differ := 0
+	for _, p := range pairs {
+		switch {
+		case p.b == nil:
+			differ++
+			fmt.Printf("\n%s: only in %s\n", p.label, args[0])
+		case p.a == nil:
+			differ++
+			fmt.Printf("\n%s: only in %s\n", p.label, args[1])
+		default:
+			diffs := hub.Diff(p.a, p.b, ignore...)
+			if len(diffs) == 0 {
+				continue
+			}
+			differ++
+			fmt.Printf("\n%s\n", p.label)
+			for _, d := range diffs {
+				fmt.Printf("  %s\n", d)
+			}
+		}
+	}
+
+	if differ == 0 {
+		fmt.Printf("\nNo differences in %d records\n", len(pairs))
+		return nil
+	}
+	fmt.Printf("\n%d of %d records differ\n", differ, len(pairs))
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	return &ExitError{Code: ExitDifferences, Err: fmt.Errorf("%s and %s differ", args[0], args[1])}
+}
+
+// parseDiffInput parses a file into hub records, detecting its format
+// when from is empty, and returns the format it used.
+func parseDiffInput(path, from string) ([]*hubv1.Record, string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, "", fmt.Errorf("reading %s: %w", path, err)
+	}
+	if from == "" {
+		if from = detectParser(path, data); from == "" {
+			return nil, "", fmt.Errorf("could not detect the format of %s (name it with --from-a or --from-b)", path)
+		}
+	}
+	parser, err := format.GetParser(from)
+	if err != nil {
+		return nil, "", fmt.Errorf("unknown format %q: %w", from, err)
+	}
+	profile, err := resolveProfile(from, "", "", path)
+	if err != nil {
+		return nil, "", fmt.Errorf("loading profile: %w", err)
+	}
+
+	records, err := parser.Parse(bytes.NewReader(data), &format.ParseOptions{
+		Profile:    profile,
+		StripHTML:  true,
+		SourceName: path,
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return records, from, nil
+}
+
+// detectParser names the format that can parse a file, judged by content
+// and then by extension, or returns "" if none can. Only formats with a
+// parser are considered, since several share extensions like .json.
+func detectParser(path string, data []byte) string {
+	var parsers []format.Format
+	for _, name := range format.List() {
+		if f, ok := format.Get(name); ok {
+			if _, ok := f.(format.Parser); ok {
+				parsers = append(parsers, f)
+			}
+		}
+	}
+
+	peek := bytes.TrimSpace(data)
+	for _, f := range parsers {
+		if f.CanParse(peek) {
+			return f.Name()
+		}
+	}
+	ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(path), "."))
+	for _, f := range parsers {
+		if slices.Contains(f.Extensions(), ext) {
+			return f.Name()
+		}
+	}
+	return ""
+}
+
+// recordPair is a record from each file to compare; either may be nil
+// when a record has no partner.
+type recordPair struct {
+	label string
+	a, b  *hubv1.Record
+}
+
+func pairByPosition(a, b []*hubv1.Record) []recordPair {
+	n := max(len(a), len(b))
+	pairs := make([]recordPair, n)
+	for i := range pairs {
+		p := &pairs[i]
+		if i < len(a) {
+			p.a = a[i]
+		}
+		if i < len(b) {
+			p.b = b[i]
+		}
+		p.label = recordLabel(i, p.a, p.b)
+	}
+	return pairs
+}
+
+// pairByIdentifier pairs each record in a with the first unpaired record
+// in b sharing an identifier value; the rest are listed unpaired, a's
+// first.
+func pairByIdentifier(a, b []*hubv1.Record) []recordPair {
+	index := make(map[string][]int)
+	for j, r := range b {
+		for _, id := range r.GetIdentifiers() {
+			if v := normalizedIdentifier(id.GetValue()); v != "" {
+				index[v] = append(index[v], j)
+			}
+		}
+	}
+
+	paired := make([]bool, len(b))
+	var pairs []recordPair
+	for i, r := range a {
+		p := recordPair{a: r}
+	search:
+		for _, id := range r.GetIdentifiers() {
+			for _, j := range index[normalizedIdentifier(id.GetValue())] {
+				if !paired[j] {
+					paired[j], p.b = true, b[j]
+					break search
+				}
+			}
+		}
+		p.label = recordLabel(i, p.a, p.b)
+		pairs = append(pairs, p)
+	}
+	for j, r := range b {
+		if !paired[j] {
+			pairs = append(pairs, recordPair{label: recordLabel(j, nil, r), b: r})
+		}
+	}
+	return pairs
+}
+
+// normalizedIdentifier compares identifiers without case or a DOI resolver
+// prefix, so "https://doi.org/10.1/X" pairs with "10.1/x".
+func normalizedIdentifier(v string) string {
+	v = strings.ToLower(strings.TrimSpace(v))
+	for _, prefix := range []string{"https://doi.org/", "http://doi.org/", "doi:"} {
+		v = strings.TrimPrefix(v, prefix)
+	}
+	return v
+}
+
+func recordLabel(i int, a, b *hubv1.Record) string {
+	label := fmt.Sprintf("Record %d", i+1)
+	title := a.GetTitle()
+	if title == "" {
+		title = b.GetTitle()
+	}
+	if title != "" {
+		label += ": " + truncate(title, 60)
+	}
+	return label
+}
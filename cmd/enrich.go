@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/lehigh-university-libraries/crosswalk/enrich"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+
+	// Register enrichers
+	_ "github.com/lehigh-university-libraries/crosswalk/enrich/authorities"
+	_ "github.com/lehigh-university-libraries/crosswalk/enrich/crossref"
+)
+
+// namedEnricher keeps an enricher's name for messages.
+type namedEnricher struct {
+	name string
+	enrich.Enricher
+}
+
+// buildEnrichers builds the enrichers named by --enrich, in order, so an
+// unknown name fails before any input is read.
+func buildEnrichers(names []string, opts enrich.Options) ([]namedEnricher, error) {
+	enrichers := make([]namedEnricher, 0, len(names))
+	for _, name := range names {
+		e, err := enrich.New(name, opts)
+		if err != nil {
+			return nil, err
+		}
+		enrichers = append(enrichers, namedEnricher{name: name, Enricher: e})
+	}
+	return enrichers, nil
+}
+
+// runEnrichers runs each enricher over the whole batch in turn.
+func runEnrichers(ctx context.Context, enrichers []namedEnricher, records []*hubv1.Record) error {
+	for _, e := range enrichers {
+		slog.Debug("enriching records", "enricher", e.name, "records", len(records))
+		err := trackStep(records, hub.StageEnrich, e.name, func() error {
+			return e.Enrich(ctx, records)
+		})
+		if err != nil {
+			return fmt.Errorf("enriching with %s: %w", e.name, err)
+		}
+	}
+	return nil
+}
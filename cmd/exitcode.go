@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+)
+
+// Exit codes returned by the CLI.
+const (
+	// ExitOK means the command completed without warnings.
+	ExitOK = 0
+	// ExitFailed means the command failed; no usable output was produced.
+	ExitFailed = 1
+	// ExitNoRecords means the input parsed but contained zero records.
+	ExitNoRecords = 2
+	// ExitWarnings means output was written but warnings were logged.
+	ExitWarnings = 3
+	// ExitDifferences means diff found the inputs differ, or spoke diff
+	// found a spoke drifted from its config.
+	ExitDifferences = 4
+	// ExitFixity means verify-files found files missing or not matching
+	// their checksums.
+	ExitFixity = 5
+)
+
+// ExitError carries a specific process exit code alongside an error.
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+// warningCount counts log records at WARN or above since the last reset, so
+// commands can report "completed with warnings".
+var warningCount atomic.Int64
+
+// countingHandler wraps a slog.Handler and counts warnings as they are logged.
+type countingHandler struct {
+	slog.Handler
+}
+
+func (h countingHandler) Handle(ctx context.Context, r slog.Record) error {
+	if r.Level >= slog.LevelWarn {
+		warningCount.Add(1)
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h countingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return countingHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h countingHandler) WithGroup(name string) slog.Handler {
+	return countingHandler{h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,307 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	csvfmt "github.com/lehigh-university-libraries/crosswalk/format/csv"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+	"github.com/lehigh-university-libraries/crosswalk/oaipmh"
+)
+
+var harvestCmd = &cobra.Command{
+	Use:   "harvest <base-url> <to>",
+	Short: "Harvest records from an OAI-PMH repository",
+	Long: `Harvest records from an OAI-PMH repository and convert them to another format.
+
+Pages through ListRecords responses, following resumption tokens, and parses
+each page with the format plugin for the metadataPrefix. Records are written
+to the target format once the harvest completes.
+
+Metadata prefixes: ` + strings.Join(harvestPrefixNames(), ", ") + `
+Use --from-format to parse any other prefix with a format plugin that reads
+OAI-PMH responses.
+
+With --state-file, a harvest records the repository's response date, and the
+next harvest with the same state file asks only for records changed since
+then. An explicit --from overrides the state file. The state file is only
+updated after the output has been written.
+
+Exit codes are the same as for convert; an incremental harvest with no new
+records exits 2 unless --allow-empty is given.
+
+Examples:
+  # Harvest a set as Dublin Core and write Workbench CSV
+  crosswalk harvest https://example.edu/oai islandora-workbench \
+    --set theses -o input.csv
+
+  # DataCite metadata changed during 2024
+  crosswalk harvest https://oai.datacite.org/oai csv \
+    --metadata-prefix oai_datacite --from 2024-01-01 --until 2024-12-31
+
+  # Nightly incremental harvest
+  crosswalk harvest https://oaipmh.arxiv.org/oai mods \
+    --metadata-prefix arXiv --set cs --state-file arxiv-cs.json -o cs.xml`,
+	Args: cobra.ExactArgs(2),
+	RunE: runHarvest,
+}
+
+// harvestPrefixes maps metadataPrefix values to the format plugin that
+// parses them.
+var harvestPrefixes = map[string]string{
+	"oai_dc":       "dublincore",
+	"oai_datacite": "datacite",
+	"datacite":     "datacite",
+	"arXiv":        "arxiv",
+	"marc21":       "marc",
+	"marcxml":      "marc",
+}
+
+// harvestState is the --state-file contents: the harvest it belongs to and
+// where the next incremental harvest starts.
+type harvestState struct {
+	BaseURL        string `json:"base_url"`
+	MetadataPrefix string `json:"metadata_prefix"`
+	Set            string `json:"set,omitempty"`
+	// ResponseDate is the response date of the last successful harvest.
+	ResponseDate string `json:"response_date"`
+	Records      int    `json:"records"`
+}
+
+func init() {
+	rootCmd.AddCommand(harvestCmd)
+
+	harvestCmd.Flags().String("metadata-prefix", "oai_dc", "Metadata format to request")
+	harvestCmd.Flags().String("set", "", "Set to harvest (default: the whole repository)")
+	harvestCmd.Flags().String("from", "", "Harvest records changed on or after this datestamp")
+	harvestCmd.Flags().String("until", "", "Harvest records changed on or before this datestamp")
+	harvestCmd.Flags().String("state-file", "", "JSON file recording the last harvest, for incremental harvesting")
+	harvestCmd.Flags().String("from-format", "", "Format plugin that parses the records (default: chosen from the metadata prefix)")
+	harvestCmd.Flags().Duration("delay", 0, "Pause between page requests")
+	harvestCmd.Flags().StringVar(&arxivTaxonomy, "arxiv-taxonomy", "", "JSON file of arXiv category labels by code, replacing and adding to the built-in taxonomy")
+
+	// Output flags shared with convert
+	harvestCmd.Flags().StringVarP(&outputFile, "output", "o", "", "Output file (default: stdout)")
+	harvestCmd.Flags().StringVarP(&profileName, "profile", "p", "", "Mapping profile name (e.g., islandora)")
+	harvestCmd.Flags().StringVar(&profileFile, "profile-file", "", "Custom profile YAML file")
+	harvestCmd.Flags().StringSliceVarP(&columns, "columns", "c", nil, "CSV columns to output")
+	harvestCmd.Flags().StringToStringVar(&staticColumns, "static-column", nil, "CSV columns with one value in every row, e.g. model=Digital Document")
+	harvestCmd.Flags().StringArrayVar(&templateCols, "template-column", nil, "CSV column rendered from a Go template over the hub record (name=template); repeatable")
+	harvestCmd.Flags().StringVar(&multiValueSep, "separator", "|", "Multi-value field separator")
+	harvestCmd.Flags().BoolVar(&stripHTML, "strip-html", true, "Strip HTML from text fields")
+	harvestCmd.Flags().BoolVar(&pretty, "pretty", false, "Pretty-print JSON output")
+	harvestCmd.Flags().StringVar(&variant, "variant", "", "Output variant for formats with more than one")
+	harvestCmd.Flags().StringVar(&postProcess, "post-process", "", "Post-processor config YAML (default: postprocess.yaml in the config directory, if present)")
+	harvestCmd.Flags().BoolVar(&allowEmpty, "allow-empty", false, "Write an empty document instead of failing when no records are harvested")
+}
+
+func runHarvest(cmd *cobra.Command, args []string) error {
+	baseURL := args[0]
+	toFormat := args[1]
+	prefix, _ := cmd.Flags().GetString("metadata-prefix")
+	set, _ := cmd.Flags().GetString("set")
+	from, _ := cmd.Flags().GetString("from")
+	until, _ := cmd.Flags().GetString("until")
+	stateFile, _ := cmd.Flags().GetString("state-file")
+	fromFormat, _ := cmd.Flags().GetString("from-format")
+	delay, _ := cmd.Flags().GetDuration("delay")
+	warningCount.Store(0)
+
+	if fromFormat == "" {
+		var ok bool
+		if fromFormat, ok = harvestPrefixes[prefix]; !ok {
+			return fmt.Errorf("no format plugin for metadata prefix %q (use --from-format, or one of %s)",
+				prefix, strings.Join(harvestPrefixNames(), ", "))
+		}
+	}
+	parser, err := format.GetParser(fromFormat)
+	if err != nil {
+		return fmt.Errorf("unknown source format %q: %w", fromFormat, err)
+	}
+	serializer, err := format.GetSerializer(toFormat)
+	if err != nil {
+		return fmt.Errorf("unknown target format %q: %w", toFormat, err)
+	}
+	profile, err := resolveProfile(fromFormat, profileName, profileFile, "")
+	if err != nil {
+		return fmt.Errorf("loading profile: %w", err)
+	}
+	pipeline, err := loadPipeline(postProcess)
+	if err != nil {
+		return err
+	}
+
+	client := oaipmh.NewClient(baseURL)
+	client.Delay = delay
+	ctx := cmd.Context()
+
+	// Resume from the state file unless --from says otherwise
+	state, err := loadHarvestState(stateFile)
+	if err != nil {
+		return err
+	}
+	if state != nil {
+		if state.BaseURL != baseURL || state.MetadataPrefix != prefix || state.Set != set {
+			return fmt.Errorf("state file %s is for a different harvest (%s, %s, set %q)",
+				stateFile, state.BaseURL, state.MetadataPrefix, state.Set)
+		}
+		if from == "" && state.ResponseDate != "" {
+			identify, err := client.Identify(ctx)
+			if err != nil {
+				return fmt.Errorf("identifying repository: %w", err)
+			}
+			from = oaipmh.Datestamp(state.ResponseDate, identify.Granularity)
+			fmt.Fprintf(os.Stderr, "Harvesting records changed since %s\n", from)
+		}
+	}
+
+	parseOpts := &format.ParseOptions{
+		Profile:       profile,
+		StripHTML:     stripHTML,
+		SourceName:    baseURL,
+		ArxivTaxonomy: arxivTaxonomy,
+	}
+
+	var records []*hubv1.Record
+	pages := 0
+	req := oaipmh.Request{MetadataPrefix: prefix, Set: set, From: from, Until: until}
+	summary, err := client.ListRecords(ctx, req, func(page *oaipmh.Page) error {
+		pages++
+		// Pages of only deleted records have nothing to parse, and parsers
+		// reject documents without records
+		if page.Records > 0 {
+			parsed, err := parser.Parse(bytes.NewReader(page.Body), parseOpts)
+			if err != nil {
+				return fmt.Errorf("parsing page %d: %w", pages, err)
+			}
+			records = append(records, parsed...)
+		}
+		if page.CompleteListSize > 0 {
+			fmt.Fprintf(os.Stderr, "Harvested %d of %d records\n", len(records), page.CompleteListSize)
+		} else {
+			fmt.Fprintf(os.Stderr, "Harvested %d records\n", len(records))
+		}
+		return nil
+	})
+	if err != nil {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("harvesting %s: %w", baseURL, err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Parsed %d records from %d pages (%d deleted)\n", len(records), summary.Pages, summary.Deleted)
+
+	next := &harvestState{
+		BaseURL:        baseURL,
+		MetadataPrefix: prefix,
+		Set:            set,
+		ResponseDate:   summary.ResponseDate,
+		Records:        len(records),
+	}
+	if next.ResponseDate == "" && state != nil {
+		next.ResponseDate = state.ResponseDate
+	}
+
+	// Nothing new is still a successful incremental harvest, so the state
+	// moves forward even though no output is written
+	if len(records) == 0 && !allowEmpty {
+		if err := saveHarvestState(stateFile, next); err != nil {
+			return err
+		}
+		cmd.SilenceUsage = true
+		return &ExitError{
+			Code: ExitNoRecords,
+			Err:  fmt.Errorf("no records harvested from %s (use --allow-empty to write an empty %s document)", baseURL, toFormat),
+		}
+	}
+
+	hub.ComputeMembershipPaths(records)
+	if err := pipeline.Run(records); err != nil {
+		return err
+	}
+
+	templates, err := templateColumns()
+	if err != nil {
+		return err
+	}
+	serializeOpts := &format.SerializeOptions{
+		Profile:             profile,
+		Columns:             columns,
+		StaticColumns:       staticColumns,
+		TemplateColumns:     templates,
+		MultiValueSeparator: multiValueSep,
+		IncludeHeader:       true,
+		Pretty:              pretty,
+		Variant:             variant,
+	}
+	if outputFile != "" {
+		serializeOpts.OutputName = filepath.Base(outputFile)
+	}
+	if len(serializeOpts.Columns) == 0 && toFormat == "csv" {
+		serializeOpts.Columns = csvfmt.DefaultColumns()
+	}
+
+	// Warnings still mean the output was written, so the state advances
+	err = writeRecords(cmd, serializer, records, serializeOpts)
+	var exitErr *ExitError
+	if err != nil && !(errors.As(err, &exitErr) && exitErr.Code == ExitWarnings) {
+		return err
+	}
+	if serr := saveHarvestState(stateFile, next); serr != nil {
+		return serr
+	}
+	return err
+}
+
+// loadHarvestState reads the state file. A missing file, or no file at
+// all, means a full harvest.
+func loadHarvestState(path string) (*harvestState, error) {
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading state file: %w", err)
+	}
+	state := &harvestState{}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("parsing state file %s: %w", path, err)
+	}
+	return state, nil
+}
+
+// saveHarvestState writes the state file, if one was given.
+func saveHarvestState(path string, state *harvestState) error {
+	if path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding state file: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("writing state file: %w", err)
+	}
+	return nil
+}
+
+func harvestPrefixNames() []string {
+	names := make([]string, 0, len(harvestPrefixes))
+	for name := range harvestPrefixes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
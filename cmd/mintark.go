@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/lehigh-university-libraries/crosswalk/ark"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+// buildMinter builds the ARK minter --mint asks for, or nil without one, so
+// a missing NAAN or account fails before any input is read.
+func buildMinter() (ark.Minter, error) {
+	switch mintScheme {
+	case "":
+		return nil, nil
+	case "ark":
+	default:
+		return nil, fmt.Errorf("--mint: unknown identifier scheme %q (want ark)", mintScheme)
+	}
+	if naan == "" {
+		return nil, errors.New("--mint ark requires --naan")
+	}
+
+	switch minterKind {
+	case "ezid":
+		if shoulder == "" {
+			return nil, errors.New("--mint ark with EZID requires --shoulder (e.g. fk4)")
+		}
+		m := ark.NewEZID(cmp.Or(minterURL, ark.EZIDURL), naan, shoulder)
+		m.Username = os.Getenv("EZID_USER")
+		m.Password = os.Getenv("EZID_PASSWORD")
+		if m.Username == "" {
+			return nil, errors.New("--mint ark with EZID requires an account in $EZID_USER and $EZID_PASSWORD")
+		}
+		return m, nil
+	case "noid":
+		if minterURL == "" {
+			return nil, errors.New("--minter noid requires --minter-url")
+		}
+		return ark.NewNoid(minterURL, naan), nil
+	}
+	return nil, fmt.Errorf("--minter: unknown minter %q (want ezid or noid)", minterKind)
+}
+
+// mintARKs gives each record without an ARK a new one, returning the
+// records that got one.
+func mintARKs(ctx context.Context, m ark.Minter, records []*hubv1.Record) ([]*hubv1.Record, error) {
+	var without []*hubv1.Record
+	for _, r := range records {
+		if hub.GetIdentifier(r, hubv1.IdentifierType_IDENTIFIER_TYPE_ARK) == nil {
+			without = append(without, r)
+		}
+	}
+	err := trackStep(records, hub.StageEnrich, "mint-ark", func() error {
+		_, err := ark.MintRecords(ctx, m, without)
+		return err
+	})
+
+	var minted []*hubv1.Record
+	for _, r := range without {
+		if hub.GetIdentifier(r, hubv1.IdentifierType_IDENTIFIER_TYPE_ARK) != nil {
+			minted = append(minted, r)
+		}
+	}
+	if err != nil {
+		return minted, fmt.Errorf("minting ARKs: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Minted %d ARKs\n", len(minted))
+	return minted, nil
+}
+
+// reportUnwrittenARKs lists ARKs minted for output that was never written,
+// so they can be reassigned or withdrawn rather than orphaned.
+func reportUnwrittenARKs(minted []*hubv1.Record) {
+	if len(minted) == 0 {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Warning: %d ARKs were minted but the output was not written:\n", len(minted))
+	for _, r := range minted {
+		id := hub.GetIdentifier(r, hubv1.IdentifierType_IDENTIFIER_TYPE_ARK)
+		fmt.Fprintf(os.Stderr, "  %s\t%s\n", id.Value, r.Title)
+	}
+}
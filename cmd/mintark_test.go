@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// mintConvert sets up convert to read a one-record CSV and mint ARKs from
+// a fake Noid minter, returning the temp directory and the count of ARKs
+// the minter has handed out.
+func mintConvert(t *testing.T) (string, *atomic.Int32) {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+
+	var mints atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "id: 12345/b%d\n", mints.Add(1))
+	}))
+	t.Cleanup(srv.Close)
+
+	input := filepath.Join(dir, "in.csv")
+	if err := os.WriteFile(input, []byte("title\nCanal Lock Survey\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	saved := []struct {
+		v   *string
+		old string
+	}{{&inputFile, inputFile}, {&outputFile, outputFile}, {&mintScheme, mintScheme}, {&naan, naan}, {&minterKind, minterKind}, {&minterURL, minterURL}, {&packageFormat, packageFormat}, {&provenanceFile, provenanceFile}}
+	t.Cleanup(func() {
+		for _, s := range saved {
+			*s.v = s.old
+		}
+	})
+	inputFile, outputFile = input, filepath.Join(dir, "out.csv")
+	mintScheme, naan, minterKind, minterURL = "ark", "12345", "noid", srv.URL
+	convertCmd.SetContext(context.Background())
+	return dir, &mints
+}
+
+func TestConvertMintsBeforeWriting(t *testing.T) {
+	_, mints := mintConvert(t)
+	if err := runConvert(convertCmd, []string{"csv", "csv"}); err != nil {
+		t.Fatal(err)
+	}
+	if mints.Load() != 1 {
+		t.Errorf("minted %d ARKs, want 1", mints.Load())
+	}
+	out, err := os.ReadFile(outputFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(out), "ark:/12345/b1") {
+		t.Errorf("output missing the minted ARK:\n%s", out)
+	}
+}
+
+func TestConvertDoesNotMintWhenOutputCannotBeWritten(t *testing.T) {
+	t.Run("bag directory not empty", func(t *testing.T) {
+		dir, mints := mintConvert(t)
+		bagDir := filepath.Join(dir, "bag")
+		if err := os.MkdirAll(bagDir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(bagDir, "leftover"), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		packageFormat, outputFile = packageBagIt, bagDir
+
+		err := runConvert(convertCmd, []string{"csv", "csv"})
+		if err == nil || !strings.Contains(err.Error(), "not empty") {
+			t.Errorf("got %v, want a bag directory error", err)
+		}
+		if mints.Load() != 0 {
+			t.Errorf("minted %d ARKs for output that can't be written", mints.Load())
+		}
+	})
+
+	t.Run("provenance file", func(t *testing.T) {
+		dir, mints := mintConvert(t)
+		provenanceFile = filepath.Join(dir, "missing", "provenance.jsonl")
+
+		err := runConvert(convertCmd, []string{"csv", "csv"})
+		if err == nil || !strings.Contains(err.Error(), "provenance") {
+			t.Errorf("got %v, want a provenance file error", err)
+		}
+		if mints.Load() != 0 {
+			t.Errorf("minted %d ARKs for output that can't be written", mints.Load())
+		}
+	})
+}
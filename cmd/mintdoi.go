@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	"github.com/lehigh-university-libraries/crosswalk/format/datacite"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+var mintDOICmd = &cobra.Command{
+	Use:   "mint-doi <from>",
+	Short: "Register DOIs for records with DataCite",
+	Long: `Register or update a DOI for each record through the DataCite REST API,
+sending the record's DataCite metadata.
+
+A record that already has a DOI updates it, or creates it when DataCite
+doesn't know it yet. Any other record is given a new DOI under --prefix,
+its suffix chosen by DataCite. --state moves each DOI to:
+
+  draft       changeable and deletable, not resolving (the default)
+  registered  resolving, but not listed in DataCite's search
+  findable    resolving and listed
+
+Registered and findable DOIs resolve to the record's URL, which they must
+have.
+
+The minted DOIs are added to the records, and the records are written in
+the --to format, hub JSONL by default. Without --api, DOIs are registered
+with DataCite's test API; pass --api ` + datacite.APIURL + ` for real DOIs.
+
+The repository ID, password, and prefix may also be given in the
+DATACITE_USER, DATACITE_PASSWORD, and DATACITE_PREFIX environment variables.
+A record that fails to register is reported as a warning (exit code 3) and
+written without a new DOI.
+
+Examples:
+  # Draft DOIs on the test API, to check the metadata
+  crosswalk mint-doi islandora-workbench -i input.csv \
+    --user LEHIGH.PRESERVE --prefix 10.80000 -o minted.jsonl
+
+  # Findable DOIs for the same records
+  crosswalk mint-doi islandora-workbench -i input.csv --state findable \
+    --api ` + datacite.APIURL + ` --user LEHIGH.PRESERVE --prefix 10.80000 -o minted.jsonl`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMintDOI,
+}
+
+func init() {
+	rootCmd.AddCommand(mintDOICmd)
+
+	mintDOICmd.Flags().StringVarP(&inputFile, "input", "i", "", "Input file (default: stdin)")
+	mintDOICmd.Flags().StringVarP(&outputFile, "output", "o", "", "Output file for the records with their DOIs (default: stdout)")
+	mintDOICmd.Flags().StringVarP(&profileName, "profile", "p", "", "Mapping profile name (e.g., islandora)")
+	mintDOICmd.Flags().StringVar(&profileFile, "profile-file", "", "Custom profile YAML file")
+	mintDOICmd.Flags().String("api", datacite.TestAPIURL, "DataCite REST API URL")
+	mintDOICmd.Flags().String("state", datacite.StateDraft, "DOI state: draft, registered, or findable")
+	mintDOICmd.Flags().String("prefix", "", "DOI prefix new DOIs are minted under (default: $DATACITE_PREFIX)")
+	mintDOICmd.Flags().String("user", "", "Repository ID (default: $DATACITE_USER)")
+	mintDOICmd.Flags().String("password", "", "Repository password (default: $DATACITE_PASSWORD)")
+	mintDOICmd.Flags().String("to", "hub-jsonl", "Format of the records written to --output")
+}
+
+func runMintDOI(cmd *cobra.Command, args []string) error {
+	fromFormat := args[0]
+	api, _ := cmd.Flags().GetString("api")
+	state, _ := cmd.Flags().GetString("state")
+	prefix, _ := cmd.Flags().GetString("prefix")
+	user, _ := cmd.Flags().GetString("user")
+	password, _ := cmd.Flags().GetString("password")
+	toFormat, _ := cmd.Flags().GetString("to")
+	warningCount.Store(0)
+
+	switch state {
+	case datacite.StateDraft, datacite.StateRegistered, datacite.StateFindable:
+	default:
+		return fmt.Errorf("unknown DOI state %q (want draft, registered, or findable)", state)
+	}
+	if prefix == "" {
+		prefix = os.Getenv("DATACITE_PREFIX")
+	}
+	if user == "" {
+		user = os.Getenv("DATACITE_USER")
+	}
+	if password == "" {
+		password = os.Getenv("DATACITE_PASSWORD")
+	}
+	if user == "" {
+		return errors.New("a repository ID is required (--user or $DATACITE_USER)")
+	}
+
+	parser, err := format.GetParser(fromFormat)
+	if err != nil {
+		return fmt.Errorf("unknown source format %q: %w", fromFormat, err)
+	}
+	serializer, err := format.GetSerializer(toFormat)
+	if err != nil {
+		return fmt.Errorf("unknown target format %q: %w", toFormat, err)
+	}
+	profile, err := loadProfile(fromFormat)
+	if err != nil {
+		return fmt.Errorf("loading profile: %w", err)
+	}
+
+	var input io.Reader = os.Stdin
+	if inputFile != "" {
+		f, err := os.Open(inputFile)
+		if err != nil {
+			return fmt.Errorf("opening input file: %w", err)
+		}
+		defer f.Close()
+		input = f
+	}
+	records, err := parser.Parse(input, &format.ParseOptions{
+		Profile:    profile,
+		StripHTML:  true,
+		SourceName: inputFile,
+	})
+	if err != nil {
+		return fmt.Errorf("parsing input: %w", err)
+	}
+	if len(records) == 0 {
+		cmd.SilenceUsage = true
+		return &ExitError{Code: ExitNoRecords, Err: errors.New("no records to register")}
+	}
+
+	client := datacite.NewClient(api)
+	client.Username = user
+	client.Password = password
+	client.Prefix = prefix
+	ctx := cmd.Context()
+
+	cmd.SilenceUsage = true
+	registered := 0
+	for _, r := range records {
+		reg, err := client.Register(ctx, r, state)
+		var apiErr *datacite.Error
+		if registered == 0 && errors.As(err, &apiErr) &&
+			(apiErr.Status == http.StatusUnauthorized || apiErr.Status == http.StatusForbidden) {
+			// Every other record would be refused too
+			return fmt.Errorf("registering with %s: %w", api, err)
+		}
+		if err != nil {
+			slog.Warn("DOI not registered", "title", r.Title, "err", err)
+			continue
+		}
+
+		addDOI(r, reg.DOI)
+		registered++
+		verb := "Updated"
+		if reg.Created {
+			verb = "Minted"
+		}
+		fmt.Fprintf(os.Stderr, "%s %s (%s)\n", verb, reg.DOI, reg.State)
+	}
+	if registered == 0 {
+		return fmt.Errorf("none of the %d records were registered", len(records))
+	}
+	fmt.Fprintf(os.Stderr, "Registered %d of %d DOIs with %s\n", registered, len(records), api)
+
+	opts := &format.SerializeOptions{IncludeHeader: true}
+	if outputFile != "" {
+		opts.OutputName = filepath.Base(outputFile)
+	}
+	return writeRecords(cmd, serializer, records, opts)
+}
+
+// addDOI adds the DOI to the record's identifiers unless it already has it.
+func addDOI(r *hubv1.Record, doi string) {
+	id := hub.NewIdentifier(doi, hubv1.IdentifierType_IDENTIFIER_TYPE_DOI)
+	for _, existing := range r.Identifiers {
+		if existing.Type == id.Type && existing.Value == id.Value {
+			return
+		}
+	}
+	r.Identifiers = append(r.Identifiers, id)
+}
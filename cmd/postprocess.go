@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+	"github.com/lehigh-university-libraries/crosswalk/profile"
+)
+
+// postProcessFile is the post-processor config looked up in the config
+// directory when --post-process is not given.
+const postProcessFile = "postprocess.yaml"
+
+// postProcessConfig lists the post-processors to run after parsing, in
+// order. Names refer to processors registered with hub.RegisterPostProcessor.
+//
+//	post_processors:
+//	  - name: map_values
+//	    config:
+//	      field: affiliations
+//	      values:
+//	        Lehigh Univ.: Lehigh University
+type postProcessConfig struct {
+	PostProcessors []struct {
+		Name   string         `yaml:"name"`
+		Config map[string]any `yaml:"config,omitempty"`
+	} `yaml:"post_processors"`
+}
+
+// loadPipeline builds the post-processor pipeline from a config file. With
+// no path, it uses postprocess.yaml in the config directory if present.
+func loadPipeline(path string) (*hub.Pipeline, error) {
+	explicit := path != ""
+	if !explicit {
+		dir, err := profile.ConfigDir()
+		if err != nil {
+			return nil, nil
+		}
+		path = filepath.Join(dir, postProcessFile)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !explicit && errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading post-processor config: %w", err)
+	}
+
+	var cfg postProcessConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parsing post-processor config %s: %w", path, err)
+	}
+
+	pipeline := &hub.Pipeline{}
+	for _, pp := range cfg.PostProcessors {
+		fn, err := hub.DefaultPostProcessors().Build(pp.Name, pp.Config)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		pipeline.Add(pp.Name, fn)
+	}
+	return pipeline, nil
+}
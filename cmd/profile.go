@@ -1,13 +1,20 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
+	"path/filepath"
+	"strings"
 	"text/tabwriter"
 
 	"github.com/spf13/cobra"
 
+	"github.com/lehigh-university-libraries/crosswalk/mapping"
 	"github.com/lehigh-university-libraries/crosswalk/profile"
+	spokeregistry "github.com/lehigh-university-libraries/crosswalk/spoke/registry"
 )
 
 var profileCmd = &cobra.Command{
@@ -33,7 +40,13 @@ Examples:
   crosswalk profile show my-site
 
   # Delete a profile
-  crosswalk profile delete my-site`,
+  crosswalk profile delete my-site
+
+  # Check a profile against the Drupal spoke
+  crosswalk profile lint myprofile.yaml --spoke drupal
+
+  # Draft a profile from sample data
+  crosswalk profile generate drupal export.json -o my-site.yaml`,
 }
 
 var profileListCmd = &cobra.Command{
@@ -85,12 +98,58 @@ For CSV:
 	RunE: runProfileCreate,
 }
 
+var profileLintCmd = &cobra.Command{
+	Use:   "lint <file|name>",
+	Short: "Check a profile against a spoke's fields",
+	Long: `Check a mapping profile for mistakes before converting with it.
+
+The profile is a YAML file, in either the user profile (hub:) or mapping
+profile (ir:) layout, or the name of a saved or embedded profile. It is
+checked against the field registry of a spoke, by default the one for the
+profile's format. Errors:
+
+  - source fields the spoke doesn't have
+  - hub fields that don't exist
+  - fields ranked by priority for the same hub field that tie
+  - EDTF fields mapped to dates without parser: edtf
+
+Warnings name the hub fields the spoke carries that no field maps to.
+Exits 1 on errors and 3 on warnings alone.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runProfileLint,
+}
+
+var profileGenerateCmd = &cobra.Command{
+	Use:   "generate <format> <sample>",
+	Short: "Draft a profile from sample data",
+	Long: `Draft a starter mapping profile from a sample input file.
+
+Each source field gets a best-guess hub field, from the same heuristics
+the spoke generator uses. Guesses the heuristics are unsure of, such as
+fields kept as Extra entries, are marked with a "# REVIEW" comment.
+
+Formats:
+  drupal  Drupal entity JSON; field types are read from the values
+  csv     CSV; column names from the header, multi-value columns from the
+          first row
+  mods    MODS XML; fields are element paths like originInfo/dateIssued
+
+Examples:
+  crosswalk profile generate drupal export.json -o my-site.yaml
+  crosswalk profile generate csv sample.csv --name my-template`,
+	Args: cobra.ExactArgs(2),
+	RunE: runProfileGenerate,
+}
+
 var (
-	profileFromConfig string
-	profileFromFile   string
-	profileBundle     string
-	profileAutoMap    bool
-	profileForce      bool
+	profileGenerateName   string
+	profileGenerateOutput string
+	profileLintSpoke      string
+	profileFromConfig     string
+	profileFromFile       string
+	profileBundle         string
+	profileAutoMap        bool
+	profileForce          bool
 )
 
 func init() {
@@ -100,12 +159,19 @@ func init() {
 	profileCmd.AddCommand(profileShowCmd)
 	profileCmd.AddCommand(profileDeleteCmd)
 	profileCmd.AddCommand(profileCreateCmd)
+	profileCmd.AddCommand(profileLintCmd)
+	profileCmd.AddCommand(profileGenerateCmd)
 
 	profileCreateCmd.Flags().StringVar(&profileFromConfig, "from-config", "", "Path to Drupal config/sync directory")
 	profileCreateCmd.Flags().StringVar(&profileFromFile, "from-file", "", "Path to sample CSV file")
 	profileCreateCmd.Flags().StringVar(&profileBundle, "bundle", "", "Drupal bundle/content type (e.g., islandora_object)")
 	profileCreateCmd.Flags().BoolVar(&profileAutoMap, "auto", false, "Auto-generate mappings without interactive prompts")
 	profileCreateCmd.Flags().BoolVarP(&profileForce, "force", "f", false, "Overwrite existing profile")
+
+	profileGenerateCmd.Flags().StringVar(&profileGenerateName, "name", "", "Profile name (default: the sample file's name)")
+	profileGenerateCmd.Flags().StringVarP(&profileGenerateOutput, "output", "o", "", "Output file (default: stdout)")
+
+	profileLintCmd.Flags().StringVar(&profileLintSpoke, "spoke", "", "Spoke to check source fields against (default: the profile's format)")
 }
 
 func runProfileList(cmd *cobra.Command, args []string) error {
@@ -301,6 +367,106 @@ func runProfileCreate(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runProfileGenerate(cmd *cobra.Command, args []string) error {
+	format, path := args[0], args[1]
+	name := profileGenerateName
+	if name == "" {
+		name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var g *profile.Generated
+	switch format {
+	case "drupal":
+		data, err := io.ReadAll(f)
+		if err != nil {
+			return err
+		}
+		g, err = profile.GenerateFromDrupal(name, data)
+		if err != nil {
+			return err
+		}
+	case "csv":
+		if g, err = profile.GenerateFromCSV(name, f); err != nil {
+			return err
+		}
+	case "mods":
+		if g, err = profile.GenerateFromMODS(name, f); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown format: %s (use 'drupal', 'csv', or 'mods')", format)
+	}
+
+	data, err := g.YAML()
+	if err != nil {
+		return err
+	}
+	if profileGenerateOutput == "" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	if err := os.WriteFile(profileGenerateOutput, data, 0644); err != nil {
+		return fmt.Errorf("writing profile: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Generated %d fields, %d to review: %s\n", len(g.Fields), len(g.Review), profileGenerateOutput)
+	return nil
+}
+
+func runProfileLint(cmd *cobra.Command, args []string) error {
+	mp, err := loadLintProfile(args[0])
+	if err != nil {
+		return err
+	}
+
+	source := profileLintSpoke
+	if source == "" {
+		source = mp.Format
+	}
+	sources, ok := spokeregistry.SourceFields(source)
+	if !ok {
+		if profileLintSpoke != "" {
+			return fmt.Errorf("no spoke registered for %q (have: %s)", profileLintSpoke, strings.Join(spokeregistry.Formats(), ", "))
+		}
+		fmt.Fprintf(os.Stderr, "No spoke registered for %q; skipping source field checks\n", source)
+	}
+
+	findings := mp.Lint(source, sources)
+	errs := 0
+	for _, f := range findings {
+		fmt.Println(f)
+		if f.Level == mapping.LintError {
+			errs++
+		}
+	}
+	warns := len(findings) - errs
+
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	switch {
+	case errs > 0:
+		return fmt.Errorf("%s: %d error(s), %d warning(s)", mp.Name, errs, warns)
+	case warns > 0:
+		return &ExitError{Code: ExitWarnings, Err: fmt.Errorf("%s: %d warning(s)", mp.Name, warns)}
+	}
+	fmt.Printf("%s: no problems found\n", mp.Name)
+	return nil
+}
+
+// loadLintProfile loads a profile from a file, in the user or mapping
+// profile layout, or by name.
+func loadLintProfile(arg string) (*mapping.Profile, error) {
+	if _, err := os.Stat(arg); errors.Is(err, fs.ErrNotExist) {
+		return resolveProfile("", arg, "", "")
+	}
+	return loadProfileFile(arg, nil)
+}
+
 func readCSVColumns(path string) ([]string, []string, error) {
 	// Reuse the profile package's function
 	f, err := os.Open(path)
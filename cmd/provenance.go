@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	"github.com/lehigh-university-libraries/crosswalk/format/premis"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+	"github.com/lehigh-university-libraries/crosswalk/mapping"
+)
+
+// provenanceLine is one line of the --provenance file: a record's source
+// and the transformations applied to it.
+type provenanceLine struct {
+	Index      int             `json:"index"`
+	Title      string          `json:"title,omitempty"`
+	SourceInfo json.RawMessage `json:"source_info"`
+}
+
+// tracking reports whether transformation steps are recorded on records,
+// for the --provenance file or the --premis events.
+func tracking() bool {
+	return provenanceFile != "" || premisFile != ""
+}
+
+// recordParse fills in what the parser left out of each record's source
+// info and records the parse step.
+func recordParse(records []*hubv1.Record, parser string, profile *mapping.Profile) {
+	if !tracking() {
+		return
+	}
+	now := timestamppb.Now()
+	for _, r := range records {
+		if r.SourceInfo == nil {
+			r.SourceInfo = &hubv1.SourceInfo{}
+		}
+		si := r.SourceInfo
+		if si.Format == "" {
+			si.Format = parser
+		}
+		if si.Profile == "" && profile != nil {
+			si.Profile = profile.Name
+		}
+		if si.ParsedAt == nil {
+			si.ParsedAt = now
+		}
+		hub.AddStep(r, &hubv1.TransformationStep{Stage: hub.StageParse, Name: parser, At: si.ParsedAt})
+	}
+}
+
+// trackStep runs fn over the batch and, when tracking, records a step
+// on each record listing the fields fn changed.
+func trackStep(records []*hubv1.Record, stage, name string, fn func() error) error {
+	if !tracking() {
+		return fn()
+	}
+	before := make([]*hubv1.Record, len(records))
+	for i, r := range records {
+		before[i] = proto.Clone(r).(*hubv1.Record)
+	}
+	start := timestamppb.Now()
+	if err := fn(); err != nil {
+		return err
+	}
+	for i, r := range records {
+		hub.AddStep(r, &hubv1.TransformationStep{
+			Stage:         stage,
+			Name:          name,
+			At:            start,
+			ChangedFields: hub.ChangedFields(before[i], r),
+		})
+	}
+	return nil
+}
+
+// recordSerialize records the serialize step, warning about the fields the
+// target format drops from each record.
+func recordSerialize(records []*hubv1.Record, serializer format.Serializer, opts *format.SerializeOptions) {
+	if !tracking() {
+		return
+	}
+	now := timestamppb.Now()
+	for _, r := range records {
+		step := &hubv1.TransformationStep{Stage: hub.StageSerialize, Name: serializer.Name(), At: now}
+		dropped, ok := format.DroppedFields(serializer, r, opts)
+		if !ok {
+			step.Warnings = []string{serializer.Name() + " does not declare which fields it writes; some may be dropped"}
+		}
+		for _, field := range dropped {
+			step.Warnings = append(step.Warnings, fmt.Sprintf("%s not written by %s", field, serializer.Name()))
+		}
+		hub.AddStep(r, step)
+	}
+}
+
+// writeProvenance writes each record's source info, with its steps, to the
+// --provenance file as a JSON line.
+func writeProvenance(w io.Writer, records []*hubv1.Record) error {
+	enc := json.NewEncoder(w)
+	marshal := protojson.MarshalOptions{UseProtoNames: true}
+	for i, r := range records {
+		// The preserved source is for serializers, not curators
+		info := proto.Clone(r.GetSourceInfo()).(*hubv1.SourceInfo)
+		info.Payload = nil
+		si, err := marshal.Marshal(info)
+		if err != nil {
+			return fmt.Errorf("encoding provenance for record %d: %w", i, err)
+		}
+		if err := enc.Encode(provenanceLine{Index: i, Title: r.Title, SourceInfo: si}); err != nil {
+			return fmt.Errorf("writing provenance file: %w", err)
+		}
+	}
+	return nil
+}
+
+// writePremis writes a PREMIS event for each record to the --premis file.
+func writePremis(path string, records []*hubv1.Record) (err error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("creating PREMIS file: %w", err)
+	}
+	defer func() {
+		if cerr := f.Close(); cerr != nil && err == nil {
+			err = fmt.Errorf("closing PREMIS file: %w", cerr)
+		}
+	}()
+	if err := (&premis.Format{}).Serialize(f, records, nil); err != nil {
+		return fmt.Errorf("writing PREMIS file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	"github.com/lehigh-university-libraries/crosswalk/format/drupal"
+	"github.com/lehigh-university-libraries/crosswalk/mapping"
+	spokeregistry "github.com/lehigh-university-libraries/crosswalk/spoke/registry"
+)
+
+var pushCmd = &cobra.Command{
+	Use:   "push <from> <site-url>",
+	Short: "Create or update Drupal nodes through JSON:API",
+	Long: `Write records straight to a Drupal site through its JSON:API module,
+without a Workbench CSV round trip.
+
+A record that names an existing node, by a uuid or nid field or an nid
+identifier, updates that node with PATCH, sending only the fields the
+record fills. Any other record creates a node of --bundle. Taxonomy
+references are looked up by ID, or by name in the field's vocabulary, and
+terms that don't exist yet are created.
+
+Records map to Drupal fields with the Islandora spoke, which also gives
+each field's cardinality and reference target; --drupal-profile names
+another profile, by name or YAML file.
+
+Each write is reported on one JSON line: the method, URL, node UUID, and
+terms created. With --dry-run nothing is written; each line carries the
+JSON:API document that would be sent instead. A record that fails is
+reported as a warning (exit code 3).
+
+The password may also be given in the DRUPAL_PASSWORD environment variable.
+
+Examples:
+  # Preview the writes for a batch of title and subject fixes
+  crosswalk push csv https://islandora.example.edu -i fixes.csv \
+    --user admin --dry-run
+
+  # Send them, pausing between requests to spare the site
+  crosswalk push csv https://islandora.example.edu -i fixes.csv \
+    --user admin --delay 500ms -o push-log.jsonl`,
+	Args: cobra.ExactArgs(2),
+	RunE: runPush,
+}
+
+func init() {
+	rootCmd.AddCommand(pushCmd)
+
+	pushCmd.Flags().StringVarP(&inputFile, "input", "i", "", "Input file (default: stdin)")
+	pushCmd.Flags().StringVarP(&outputFile, "output", "o", "", "File for the JSON lines reporting each write (default: stdout)")
+	pushCmd.Flags().StringVarP(&profileName, "profile", "p", "", "Mapping profile name for the input (e.g., islandora)")
+	pushCmd.Flags().StringVar(&profileFile, "profile-file", "", "Custom profile YAML file for the input")
+	pushCmd.Flags().String("drupal-profile", "", "Profile mapping hub fields to the site's fields, by name or YAML file (default: the Islandora spoke)")
+	pushCmd.Flags().String("bundle", "islandora_object", "Content type of new nodes")
+	pushCmd.Flags().Bool("dry-run", false, "Report the writes without sending them")
+	pushCmd.Flags().Duration("delay", 0, "Least time between requests")
+	pushCmd.Flags().String("user", "", "User name for HTTP basic authentication")
+	pushCmd.Flags().String("password", "", "Password for HTTP basic authentication (default: $DRUPAL_PASSWORD)")
+}
+
+func runPush(cmd *cobra.Command, args []string) (err error) {
+	fromFormat, siteURL := args[0], args[1]
+	drupalProfile, _ := cmd.Flags().GetString("drupal-profile")
+	bundle, _ := cmd.Flags().GetString("bundle")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	delay, _ := cmd.Flags().GetDuration("delay")
+	user, _ := cmd.Flags().GetString("user")
+	password, _ := cmd.Flags().GetString("password")
+	warningCount.Store(0)
+
+	if password == "" {
+		password = os.Getenv("DRUPAL_PASSWORD")
+	}
+
+	parser, err := format.GetParser(fromFormat)
+	if err != nil {
+		return fmt.Errorf("unknown source format %q: %w", fromFormat, err)
+	}
+	profile, err := loadProfile(fromFormat)
+	if err != nil {
+		return fmt.Errorf("loading profile: %w", err)
+	}
+	client := drupal.NewJSONAPIClient(siteURL)
+	client.Username = user
+	client.Password = password
+	client.Bundle = bundle
+	client.DryRun = dryRun
+	client.Delay = delay
+	client.Fields, _ = spokeregistry.DrupalFields("islandora")
+	if client.Profile, err = pushProfile(drupalProfile); err != nil {
+		return fmt.Errorf("loading Drupal profile: %w", err)
+	}
+
+	var input io.Reader = os.Stdin
+	if inputFile != "" {
+		f, err := os.Open(inputFile)
+		if err != nil {
+			return fmt.Errorf("opening input file: %w", err)
+		}
+		defer f.Close()
+		input = f
+	}
+	records, err := parser.Parse(input, &format.ParseOptions{
+		Profile:    profile,
+		StripHTML:  true,
+		SourceName: inputFile,
+	})
+	if err != nil {
+		return fmt.Errorf("parsing input: %w", err)
+	}
+	if len(records) == 0 {
+		cmd.SilenceUsage = true
+		return &ExitError{Code: ExitNoRecords, Err: errors.New("no records to push")}
+	}
+
+	output, closeOutput, err := openOutput(outputFile)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := closeOutput(); cerr != nil && err == nil {
+			err = fmt.Errorf("closing output file: %w", cerr)
+		}
+	}()
+
+	cmd.SilenceUsage = true
+	enc := json.NewEncoder(output)
+	pushed := 0
+	for _, r := range records {
+		result, err := client.Push(cmd.Context(), r)
+		if err != nil {
+			slog.Warn("record not pushed", "title", r.Title, "err", err)
+			continue
+		}
+		pushed++
+		if err := enc.Encode(result); err != nil {
+			return fmt.Errorf("writing output: %w", err)
+		}
+	}
+	if pushed == 0 {
+		return fmt.Errorf("none of the %d records were pushed", len(records))
+	}
+	if dryRun {
+		fmt.Fprintf(os.Stderr, "Dry run: %d of %d records would be pushed to %s\n", pushed, len(records), siteURL)
+	} else {
+		fmt.Fprintf(os.Stderr, "Pushed %d of %d records to %s\n", pushed, len(records), siteURL)
+	}
+
+	if n := warningCount.Load(); n > 0 {
+		return &ExitError{Code: ExitWarnings, Err: fmt.Errorf("completed with %d warning(s)", n)}
+	}
+	return nil
+}
+
+// pushProfile loads the --drupal-profile, a YAML file or a profile name,
+// defaulting to the Islandora spoke.
+func pushProfile(ref string) (*mapping.Profile, error) {
+	if ref == "" {
+		if p, ok := spokeregistry.ProfileFrom("islandora"); ok {
+			return p, nil
+		}
+		return nil, nil
+	}
+	if _, err := os.Stat(ref); err == nil {
+		return mapping.LoadProfile(ref)
+	}
+	return resolveProfile("drupal", ref, "", "")
+}
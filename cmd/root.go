@@ -2,6 +2,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
@@ -35,7 +36,7 @@ func setupLogger() {
 		Level: level,
 	}
 
-	handler := slog.NewTextHandler(os.Stderr, opts)
+	handler := countingHandler{slog.NewTextHandler(os.Stderr, opts)}
 	logger := slog.New(handler)
 
 	slog.SetDefault(logger)
@@ -56,11 +57,16 @@ Examples:
   crosswalk validate drupal -i data.json`,
 }
 
-// Execute runs the root command.
+// Execute runs the root command. Errors carrying an ExitError use its code;
+// all other errors exit with ExitFailed.
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+		var exitErr *ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.Code)
+		}
+		os.Exit(ExitFailed)
 	}
 }
 
@@ -0,0 +1,282 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	csvfmt "github.com/lehigh-university-libraries/crosswalk/format/csv"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+const scrapeUserAgent = "crosswalk-scrape (https://github.com/lehigh-university-libraries/crosswalk)"
+
+var scrapeCmd = &cobra.Command{
+	Use:   "scrape <to> [page...]",
+	Short: "Harvest schema.org metadata embedded in web pages",
+	Long: `Harvest the schema.org JSON-LD embedded in HTML pages and convert it to another format.
+
+Pages are URLs, fetched over HTTP, or saved HTML files. They come from the
+arguments, from --urls (a file listing one page per line), and from
+--sitemap (a sitemap.xml URL or file, following sitemap indexes). Each
+page's <script type="application/ld+json"> elements are read as one
+schema.org graph, and every record gets the page's canonical URL as a URL
+identifier.
+
+Pages that can't be fetched or embed no schema.org works are logged as
+warnings and skipped; exit codes are the same as for convert.
+
+Examples:
+  # A partner site's item pages, from its sitemap, to MODS
+  crosswalk scrape mods --sitemap https://partner.example.org/sitemap.xml -o records.xml
+
+  # A list of URLs, politely, to Workbench CSV
+  crosswalk scrape islandora-workbench --urls pages.txt --delay 1s -o input.csv
+
+  # Pages saved earlier
+  crosswalk scrape csv saved/*.html`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runScrape,
+}
+
+func init() {
+	rootCmd.AddCommand(scrapeCmd)
+
+	scrapeCmd.Flags().String("urls", "", "File listing pages to harvest, one URL or HTML file per line (# starts a comment)")
+	scrapeCmd.Flags().String("sitemap", "", "Sitemap URL or file listing the pages to harvest")
+	scrapeCmd.Flags().Duration("delay", 0, "Pause between page requests")
+
+	// Output flags shared with convert
+	scrapeCmd.Flags().StringVarP(&outputFile, "output", "o", "", "Output file (default: stdout)")
+	scrapeCmd.Flags().StringVarP(&profileName, "profile", "p", "", "Mapping profile name (e.g., islandora)")
+	scrapeCmd.Flags().StringVar(&profileFile, "profile-file", "", "Custom profile YAML file")
+	scrapeCmd.Flags().StringSliceVarP(&columns, "columns", "c", nil, "CSV columns to output")
+	scrapeCmd.Flags().StringToStringVar(&staticColumns, "static-column", nil, "CSV columns with one value in every row, e.g. model=Digital Document")
+	scrapeCmd.Flags().StringArrayVar(&templateCols, "template-column", nil, "CSV column rendered from a Go template over the hub record (name=template); repeatable")
+	scrapeCmd.Flags().StringVar(&multiValueSep, "separator", "|", "Multi-value field separator")
+	scrapeCmd.Flags().BoolVar(&pretty, "pretty", false, "Pretty-print JSON output")
+	scrapeCmd.Flags().StringVar(&variant, "variant", "", "Output variant for formats with more than one")
+	scrapeCmd.Flags().StringVar(&postProcess, "post-process", "", "Post-processor config YAML (default: postprocess.yaml in the config directory, if present)")
+	scrapeCmd.Flags().BoolVar(&allowEmpty, "allow-empty", false, "Write an empty document instead of failing when no records are harvested")
+}
+
+func runScrape(cmd *cobra.Command, args []string) error {
+	toFormat := args[0]
+	urlsFile, _ := cmd.Flags().GetString("urls")
+	sitemap, _ := cmd.Flags().GetString("sitemap")
+	delay, _ := cmd.Flags().GetDuration("delay")
+	warningCount.Store(0)
+
+	parser, err := format.GetParser("schemaorg")
+	if err != nil {
+		return err
+	}
+	serializer, err := format.GetSerializer(toFormat)
+	if err != nil {
+		return fmt.Errorf("unknown target format %q: %w", toFormat, err)
+	}
+	profile, err := resolveProfile("schemaorg", profileName, profileFile, "")
+	if err != nil {
+		return fmt.Errorf("loading profile: %w", err)
+	}
+	pipeline, err := loadPipeline(postProcess)
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{Timeout: 60 * time.Second}
+	ctx := cmd.Context()
+
+	pages := args[1:]
+	if urlsFile != "" {
+		listed, err := readPageList(urlsFile)
+		if err != nil {
+			return err
+		}
+		pages = append(pages, listed...)
+	}
+	if sitemap != "" {
+		listed, err := sitemapPages(ctx, client, sitemap, 0)
+		if err != nil {
+			return fmt.Errorf("reading sitemap: %w", err)
+		}
+		pages = append(pages, listed...)
+	}
+	if len(pages) == 0 {
+		return fmt.Errorf("no pages to harvest (give pages as arguments, --urls, or --sitemap)")
+	}
+
+	var records []*hubv1.Record
+	fetched := 0
+	for i, page := range pages {
+		remote := isHTTPURL(page)
+		if remote && fetched > 0 && delay > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(delay):
+			}
+		}
+		body, err := readPage(ctx, client, page)
+		if remote {
+			fetched++
+		}
+		if err != nil {
+			slog.Warn("page skipped", "page", page, "err", err)
+			continue
+		}
+		parsed, err := parser.Parse(bytes.NewReader(body), &format.ParseOptions{
+			Profile:    profile,
+			StripHTML:  true,
+			SourceName: page,
+		})
+		if err != nil {
+			slog.Warn("page skipped", "page", page, "err", err)
+			continue
+		}
+		records = append(records, parsed...)
+		fmt.Fprintf(os.Stderr, "Harvested %d records from %d of %d pages\n", len(records), i+1, len(pages))
+	}
+
+	if len(records) == 0 && !allowEmpty {
+		cmd.SilenceUsage = true
+		return &ExitError{
+			Code: ExitNoRecords,
+			Err:  fmt.Errorf("no records harvested from %d pages (use --allow-empty to write an empty %s document)", len(pages), toFormat),
+		}
+	}
+
+	hub.ComputeMembershipPaths(records)
+	if err := pipeline.Run(records); err != nil {
+		return err
+	}
+
+	templates, err := templateColumns()
+	if err != nil {
+		return err
+	}
+	serializeOpts := &format.SerializeOptions{
+		Profile:             profile,
+		Columns:             columns,
+		StaticColumns:       staticColumns,
+		TemplateColumns:     templates,
+		MultiValueSeparator: multiValueSep,
+		IncludeHeader:       true,
+		Pretty:              pretty,
+		Variant:             variant,
+	}
+	if outputFile != "" {
+		serializeOpts.OutputName = filepath.Base(outputFile)
+	}
+	if len(serializeOpts.Columns) == 0 && toFormat == "csv" {
+		serializeOpts.Columns = csvfmt.DefaultColumns()
+	}
+
+	return writeRecords(cmd, serializer, records, serializeOpts)
+}
+
+// readPageList reads a --urls file: one page per line, skipping blank lines
+// and # comments.
+func readPageList(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading page list: %w", err)
+	}
+	defer f.Close()
+
+	var pages []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && !strings.HasPrefix(line, "#") {
+			pages = append(pages, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading page list: %w", err)
+	}
+	return pages, nil
+}
+
+// xmlSitemap is a sitemap or sitemap index; an index lists sitemaps in
+// place of pages.
+type xmlSitemap struct {
+	URLs     []string `xml:"url>loc"`
+	Sitemaps []string `xml:"sitemap>loc"`
+}
+
+// maxSitemapDepth bounds how many sitemap indexes deep sitemapPages follows.
+const maxSitemapDepth = 3
+
+// sitemapPages returns the pages a sitemap lists, following the sitemaps
+// an index lists.
+func sitemapPages(ctx context.Context, client *http.Client, location string, depth int) ([]string, error) {
+	body, err := readPage(ctx, client, location)
+	if err != nil {
+		return nil, err
+	}
+	var sm xmlSitemap
+	if err := xml.Unmarshal(body, &sm); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", location, err)
+	}
+
+	var pages []string
+	for _, loc := range sm.URLs {
+		if loc = strings.TrimSpace(loc); loc != "" {
+			pages = append(pages, loc)
+		}
+	}
+	for _, loc := range sm.Sitemaps {
+		if depth >= maxSitemapDepth {
+			return nil, fmt.Errorf("sitemap indexes nested more than %d deep at %s", maxSitemapDepth, location)
+		}
+		nested, err := sitemapPages(ctx, client, strings.TrimSpace(loc), depth+1)
+		if err != nil {
+			return nil, err
+		}
+		pages = append(pages, nested...)
+	}
+	return pages, nil
+}
+
+// readPage fetches an http(s) URL or reads a local file.
+func readPage(ctx context.Context, client *http.Client, page string) ([]byte, error) {
+	if !isHTTPURL(page) {
+		return os.ReadFile(page)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, page, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", scrapeUserAgent)
+	req.Header.Set("Accept", "text/html, application/xhtml+xml, application/xml;q=0.9, */*;q=0.8")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// isHTTPURL reports whether s is an http or https URL rather than a path.
+func isHTTPURL(s string) bool {
+	return strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://")
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	"github.com/lehigh-university-libraries/crosswalk/mapping"
+	"github.com/lehigh-university-libraries/crosswalk/server"
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve conversions over HTTP",
+	Long: `Run crosswalk as an HTTP service, so sites and CI pipelines can convert
+metadata without shelling out.
+
+Endpoints:
+  POST /convert?from=<format>&to=<format>   convert the request body
+  GET  /formats                             list formats and capabilities
+  GET  /healthz                             liveness check
+
+/convert accepts the source document as the request body and returns the
+converted document. Optional query parameters mirror convert's flags:
+variant, profile, columns, separator, pretty, strip_html, lossless, and
+allow_empty. Without from, the source format is detected from the body.
+Errors are returned as JSON: 400 for bad parameters, 413 for oversized
+bodies, and 422 for input that can't be converted.
+
+Post-processors from --post-process (or postprocess.yaml in the config
+directory) run on every conversion.
+
+Examples:
+  crosswalk serve --addr :8080
+
+  curl --data-binary @export.json \
+    'http://localhost:8080/convert?from=drupal&to=datacite'`,
+	Args: cobra.NoArgs,
+	RunE: runServe,
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+
+	serveCmd.Flags().String("addr", ":8080", "Address to listen on")
+	serveCmd.Flags().Int64("max-body", server.DefaultMaxBodyBytes, "Maximum request body size in bytes")
+	serveCmd.Flags().StringVar(&postProcess, "post-process", "", "Post-processor config YAML (default: postprocess.yaml in the config directory, if present)")
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	addr, _ := cmd.Flags().GetString("addr")
+	maxBody, _ := cmd.Flags().GetInt64("max-body")
+
+	pipeline, err := loadPipeline(postProcess)
+	if err != nil {
+		return err
+	}
+
+	s := server.New(format.DefaultRegistry)
+	s.Pipeline = pipeline
+	s.MaxBodyBytes = maxBody
+	s.Profile = func(from, name string) (*mapping.Profile, error) {
+		return resolveProfile(from, name, "", "")
+	}
+
+	httpServer := &http.Server{
+		Addr:              addr,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	// Finish in-flight conversions on Ctrl-C or SIGTERM
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		_ = httpServer.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Fprintf(os.Stderr, "Listening on %s\n", addr)
+	cmd.SilenceUsage = true
+	if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("serving: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+)
+
+// errorReportLine is one line of the --error-report file.
+type errorReportLine struct {
+	Source string `json:"source"`
+	Index  int    `json:"index"`
+	ID     string `json:"id,omitempty"`
+	Offset int64  `json:"offset"`
+	Error  string `json:"error"`
+}
+
+// recordSkipper handles records that fail to parse under --skip-errors:
+// each is logged as a warning and, with --error-report, written to the
+// report as a JSON line.
+type recordSkipper struct {
+	source  string
+	report  *json.Encoder
+	skipped int
+}
+
+// skip is a format.ParseOptions.OnRecordError callback.
+func (s *recordSkipper) skip(e *format.RecordError) error {
+	s.skipped++
+	slog.Warn("skipping record that failed to parse", "source", s.source, "index", e.Index, "id", e.ID, "offset", e.Offset, "err", e.Err)
+	if s.report == nil {
+		return nil
+	}
+	err := s.report.Encode(errorReportLine{
+		Source: s.source,
+		Index:  e.Index,
+		ID:     e.ID,
+		Offset: e.Offset,
+		Error:  e.Err.Error(),
+	})
+	if err != nil {
+		return fmt.Errorf("writing error report: %w", err)
+	}
+	return nil
+}
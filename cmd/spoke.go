@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"text/tabwriter"
 
+	"github.com/lehigh-university-libraries/crosswalk/profile"
 	"github.com/lehigh-university-libraries/crosswalk/spoke"
 	"github.com/spf13/cobra"
 )
@@ -29,7 +31,11 @@ Examples:
   crosswalk spoke list
 
   # Show spoke info
-  crosswalk spoke show islandora`,
+  crosswalk spoke show islandora
+
+  # Check a spoke against its Drupal config
+  crosswalk spoke diff drupal islandora --bundle islandora_object \
+    --from-config ./islandora-starter-site/config/sync`,
 }
 
 var spokeListCmd = &cobra.Command{
@@ -46,9 +52,11 @@ var spokeShowCmd = &cobra.Command{
 }
 
 var spokeCreateCmd = &cobra.Command{
-	Use:   "create <format> <name>",
-	Short: "Create a new spoke from configuration",
-	Long: `Create a new spoke from Drupal configuration.
+	Use:     "create <format> <name> | --from csv <sample> [name]",
+	Aliases: []string{"generate"},
+	Short:   "Create a new spoke from configuration",
+	Long: `Create a new spoke from Drupal configuration, a DSpace metadata registry,
+an XML schema, or a sample spreadsheet.
 
 For Drupal (generates a .proto file):
   crosswalk spoke create drupal islandora --bundle islandora_object \
@@ -66,25 +74,143 @@ For Islandora Workbench:
   starting point for sites running standard Islandora without customized field
   configurations.
 
+For DSpace:
+  crosswalk spoke create dspace dspace --from-registry ./config/registries
+
+  Reads a DSpace metadata schema registry export (dublin-core-types.xml
+  and the like, or a directory of them) and generates a .proto file with an
+  Item message holding a repeated string field per schema.element.qualifier,
+  mapped to the hub from its element and qualifier: dc.contributor.author
+  to an author, dc.date.issued to an issued date. Use it to migrate DSpace
+  repositories, e.g. to Islandora.
+
+For an XML schema:
+  crosswalk spoke create xsd etdms --from-xsd ./etdms-1.1.xsd --root thesis
+
+  Generates a message per complexType, the root element's first (the
+  schema's first top-level element unless --root names another), with a
+  field per element and attribute. Elements that may occur more than once
+  are repeated fields and those that must occur are marked required;
+  simple types become the matching proto scalars, with enumerated values
+  noted in the field comment. Local includes and imports are followed.
+  Fields carry the XML names, attributes, and text content the protoxml
+  codec reads, so the compiled message decodes the schema's documents;
+  map its fields to the hub with --interactive or by hand.
+
+For a spreadsheet:
+  crosswalk spoke generate --from csv vendor-sample.csv
+
+  Infers a field per column from a sample CSV: its name from the header,
+  whether it holds lists (values separated by "|" or ";"), its type (int64,
+  double, bool, or string), and its hub mapping from the column name or,
+  failing that, its values (dates, DOIs, URLs). Alongside the .proto, a
+  CSV profile with the same mapping is saved to the profiles directory,
+  where convert --from csv finds it by the file's columns, so a one-off
+  vendor spreadsheet converts without a hand-written profile. The spoke
+  is named after the sample file unless a name follows it.
+
+Several Bundles:
+  crosswalk spoke create drupal lehigh \
+    --bundle islandora_object,publication_issue --from-config ./config/sync
+
+  With more than one --bundle, the spoke has a single message named after
+  the spoke covering every bundle's fields, so one generated package handles
+  the whole content model. Its bundle field says which bundle a node is, and
+  fields only some bundles have are marked with them.
+
 Interactive Mode:
   crosswalk spoke create drupal islandora --bundle islandora_object \
     --from-config ./config/sync --interactive
 
-  With --interactive (-i), you'll be prompted to map each field to Hub targets.
+  With --interactive (-i), you map each field to a Hub target in a
+  full-screen mapper, starting from the mappings of the existing proto or
+  the generator's guesses. Type to search targets; select fields with space,
+  or those named like the current one with s, to map them all at once; x
+  skips, a accepts a guess, u undoes, / searches fields, and w reviews the
+  mappings before writing. Without a terminal, each field is prompted for
+  in turn.
+
+Mapping Sessions:
+  crosswalk spoke create drupal islandora --bundle islandora_object \
+    --from-config ./config/sync --interactive --mappings islandora.yaml
+
+  With --interactive, --mappings saves the decisions made, including the
+  fields skipped and extra keys the proto doesn't record, to a YAML file to
+  version and review, starting from it when it exists. Without
+  --interactive, the file is replayed, as in CI: fields it doesn't list are
+  mapped automatically and noted, and fields it lists that the spoke lacks
+  are an error.
 
 The generated Drupal proto is placed in spoke/<name>/v1/<name>.proto and
-can be compiled with 'make generate'.`,
-	Args: cobra.ExactArgs(2),
+can be compiled with 'make generate'.
+
+One Step:
+  crosswalk spoke generate drupal lehigh --bundle islandora_object \
+    --from-config ./config/sync --compile
+
+  With --compile, the Go bindings are generated too, with buf (or protoc
+  and protoc-gen-go when buf isn't installed), after writing a buf.yaml and
+  buf.gen.yaml if the module has none, and the spoke is registered with the
+  CLI in cmd/spoke_<name>_v1_gen.go. Rebuild crosswalk to use it.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if spokeFrom != "" {
+			return cobra.RangeArgs(1, 2)(cmd, args)
+		}
+		return cobra.ExactArgs(2)(cmd, args)
+	},
 	RunE: runSpokeCreate,
 }
 
+var spokeDiffCmd = &cobra.Command{
+	Use:   "diff <format> <name>",
+	Short: "Compare a spoke with the Drupal config it was generated from",
+	Long: `Re-read the Drupal configuration and compare it with an existing spoke's
+proto, reporting fields added to, removed from, or retyped on the site
+since the spoke was generated, so config changes don't drift silently.
+
+The format, name, --bundle, and --from-config (--from-registry for a
+DSpace spoke, --from-xsd and --root for an XML one) are those the spoke
+was created with. Fields are matched by
+name in the spoke's main message.
+
+Lines start with + for a field only the config has, - for one only the
+proto has, and ~ for a retyped one. Each added field gets a suggested
+number no field in the proto has used or reserved: the one a fresh
+generation gives it when free, otherwise the next after the highest. A
+retyped field keeps its number when the old and new types share a wire
+encoding, and otherwise gets a new one. Removed fields, and the old
+numbers of moved ones, are listed as reserved statements to add so they
+are never reused.
+
+Exit codes:
+  0  the spoke matches the config
+  1  failed
+  4  the spoke and config differ
+
+Examples:
+  crosswalk spoke diff drupal islandora --bundle islandora_object \
+    --from-config ./config/sync
+  crosswalk spoke diff drupal lehigh --bundle islandora_object,publication_issue \
+    --from-config ./config/sync --proto ./spoke/lehigh/v1/lehigh.proto`,
+	Args: cobra.ExactArgs(2),
+	RunE: runSpokeDiff,
+}
+
 var (
 	spokeFromConfig   string
-	spokeBundle       string
+	spokeFromRegistry string
+	spokeFromXSD      string
+	spokeRoot         string
+	spokeFrom         string
+	spokeSample       string
+	spokeBundles      []string
 	spokeOutput       string
 	spokeInteractive  bool
+	spokeMappings     string
 	spokeForceReplace bool
 	spokeNoHub        bool
+	spokeCompile      bool
+	spokeProto        string
 )
 
 func init() {
@@ -93,13 +219,27 @@ func init() {
 	spokeCmd.AddCommand(spokeListCmd)
 	spokeCmd.AddCommand(spokeShowCmd)
 	spokeCmd.AddCommand(spokeCreateCmd)
+	spokeCmd.AddCommand(spokeDiffCmd)
 
 	spokeCreateCmd.Flags().StringVar(&spokeFromConfig, "from-config", "", "Path to Drupal config/sync directory")
-	spokeCreateCmd.Flags().StringVar(&spokeBundle, "bundle", "", "Drupal bundle/content type to generate (e.g., islandora_object)")
+	spokeCreateCmd.Flags().StringVar(&spokeFrom, "from", "", "Generate from a sample file given as the argument: csv")
+	spokeCreateCmd.Flags().StringVar(&spokeFromRegistry, "from-registry", "", "Path to a DSpace metadata registry export, or a directory of them")
+	spokeCreateCmd.Flags().StringVar(&spokeFromXSD, "from-xsd", "", "Path to an XML schema (.xsd)")
+	spokeCreateCmd.Flags().StringVar(&spokeRoot, "root", "", "Root element of an XML schema (default: its first top-level element)")
+	spokeCreateCmd.Flags().StringSliceVar(&spokeBundles, "bundle", nil, "Drupal bundle/content type to generate (e.g., islandora_object); several make one union message")
 	spokeCreateCmd.Flags().StringVarP(&spokeOutput, "output", "o", "", "Output path (default: spoke/<name>/v1/<name>.proto)")
 	spokeCreateCmd.Flags().BoolVarP(&spokeInteractive, "interactive", "i", false, "Interactively prompt for Hub field mappings")
+	spokeCreateCmd.Flags().StringVar(&spokeMappings, "mappings", "", "Mapping session YAML to replay, or with --interactive to start from and save to")
 	spokeCreateCmd.Flags().BoolVarP(&spokeForceReplace, "force", "f", false, "Overwrite existing spoke (reads existing mappings for autofill)")
 	spokeCreateCmd.Flags().BoolVar(&spokeNoHub, "no-hub", false, "Skip hub.v1 annotations (generate plain proto only)")
+	spokeCreateCmd.Flags().BoolVar(&spokeCompile, "compile", false, "Also generate the Go bindings with buf or protoc and register the spoke with the CLI")
+
+	spokeDiffCmd.Flags().StringVar(&spokeFromConfig, "from-config", "", "Path to Drupal config/sync directory")
+	spokeDiffCmd.Flags().StringVar(&spokeFromRegistry, "from-registry", "", "Path to the DSpace metadata registry the spoke was generated from")
+	spokeDiffCmd.Flags().StringVar(&spokeFromXSD, "from-xsd", "", "Path to the XML schema the spoke was generated from")
+	spokeDiffCmd.Flags().StringVar(&spokeRoot, "root", "", "Root element the spoke was generated with")
+	spokeDiffCmd.Flags().StringSliceVar(&spokeBundles, "bundle", nil, "Drupal bundle/content type the spoke was generated from; several for a union spoke")
+	spokeDiffCmd.Flags().StringVar(&spokeProto, "proto", "", "Existing proto to compare (default: spoke/<name>/v1/<name>.proto)")
 }
 
 func runSpokeList(cmd *cobra.Command, args []string) error {
@@ -173,8 +313,19 @@ func runSpokeShow(cmd *cobra.Command, args []string) error {
 }
 
 func runSpokeCreate(cmd *cobra.Command, args []string) error {
-	format := args[0]
-	name := args[1]
+	var format, name string
+	switch spokeFrom {
+	case "":
+		format, name = args[0], args[1]
+	case "csv":
+		format, spokeSample = spokeFrom, args[0]
+		name = spokeName(strings.TrimSuffix(filepath.Base(args[0]), filepath.Ext(args[0])))
+		if len(args) == 2 {
+			name = args[1]
+		}
+	default:
+		return fmt.Errorf("unknown --from: %s (use 'csv')", spokeFrom)
+	}
 
 	outputPath := spokeOutput
 	if outputPath == "" {
@@ -190,25 +341,19 @@ func runSpokeCreate(cmd *cobra.Command, args []string) error {
 		fmt.Printf("Regenerating spoke from %s (existing mappings will be used as defaults)\n", outputPath)
 	}
 
-	var proto *spoke.ProtoFile
-	var err error
+	proto, err := generateSpoke(format, name)
+	if err != nil {
+		return err
+	}
 
-	switch format {
-	case "drupal", "islandora-workbench":
-		if spokeFromConfig == "" {
-			return fmt.Errorf("--from-config is required for %s spokes", format)
+	var csvProfile *profile.Generated
+	if format == "csv" {
+		if csvProfile, err = profile.FromCSVSpoke(name, proto); err != nil {
+			return err
 		}
-		if spokeBundle == "" {
-			return fmt.Errorf("--bundle is required for %s spokes (e.g., --bundle islandora_object)", format)
-		}
-		proto, err = spoke.GenerateDrupalSpoke(name, spokeBundle, spokeFromConfig)
-		if err != nil {
-			return fmt.Errorf("generating %s spoke: %w", format, err)
+		if profile.Exists(name) && !spokeForceReplace {
+			return fmt.Errorf("profile %q already exists; use --force to replace it", name)
 		}
-		proto.FormatName = format
-
-	default:
-		return fmt.Errorf("unknown format: %s (use 'drupal' or 'islandora-workbench')", format)
 	}
 
 	// Apply Hub mappings unless --no-hub is set
@@ -219,9 +364,35 @@ func runSpokeCreate(cmd *cobra.Command, args []string) error {
 			if autofillPath == "" {
 				autofillPath = outputPath // Will just return empty mappings if doesn't exist
 			}
-			if err := spoke.ApplyInteractiveMappings(proto, autofillPath); err != nil {
+			var session *spoke.MappingSession
+			if spokeMappings != "" {
+				if session, err = spoke.LoadMappingSession(spokeMappings); err != nil && !os.IsNotExist(err) {
+					return err
+				}
+			}
+			if err := spoke.ApplyInteractiveMappings(proto, autofillPath, session); err != nil {
 				return fmt.Errorf("interactive mapping: %w", err)
 			}
+			if spokeMappings != "" {
+				if err := spoke.NewMappingSession(proto).Save(spokeMappings, name); err != nil {
+					return err
+				}
+				fmt.Printf("Saved mappings to %s\n", spokeMappings)
+			}
+		} else if spokeMappings != "" {
+			// Replay a saved mapping session
+			session, err := spoke.LoadMappingSession(spokeMappings)
+			if err != nil {
+				return fmt.Errorf("reading mappings: %w", err)
+			}
+			unmentioned, err := spoke.ApplyMappingSession(proto, session)
+			if err != nil {
+				return err
+			}
+			if len(unmentioned) > 0 {
+				fmt.Fprintf(os.Stderr, "Note: mapped automatically, not being in %s: %s\n",
+					spokeMappings, strings.Join(unmentioned, ", "))
+			}
 		} else {
 			// Auto mode: use RDF predicates and field name heuristics
 			spoke.ApplyAutoMappings(proto)
@@ -238,6 +409,33 @@ func runSpokeCreate(cmd *cobra.Command, args []string) error {
 	if proto.UseHubOptions {
 		fmt.Printf("Hub mappings: enabled\n")
 	}
+	if csvProfile != nil {
+		path, err := saveGeneratedProfile(csvProfile)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Profile: %s (%d columns, %d to review)\n", path, len(csvProfile.Fields), len(csvProfile.Review))
+	}
+
+	if spokeCompile {
+		compiled, err := spoke.Compile(cmd.Context(), outputPath)
+		if err != nil {
+			return fmt.Errorf("compiling spoke: %w", err)
+		}
+		for _, f := range compiled.BufConfig {
+			fmt.Printf("Wrote %s\n", filepath.Join(compiled.Root, f))
+		}
+		fmt.Printf("Go code: generated with %s under %s\n", compiled.Generator, filepath.Join(compiled.Root, "gen", "go"))
+		if compiled.Registration == "" {
+			fmt.Printf("\nImport the spoke's package from your command to register %s.\n", proto.FormatName)
+			return nil
+		}
+		fmt.Printf("Registered: %s\n", compiled.Registration)
+		fmt.Printf("\nRebuild crosswalk to use it:\n")
+		fmt.Printf("  make build\n")
+		return nil
+	}
+
 	fmt.Printf("\nGenerate Go code with:\n")
 	fmt.Printf("  make generate\n")
 	fmt.Printf("\nOr manually:\n")
@@ -245,3 +443,199 @@ func runSpokeCreate(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// generateSpoke generates the proto for a format from --from-config and
+// --bundle, --from-registry for DSpace, --from-xsd for an XML schema, or
+// the sample for csv.
+func generateSpoke(format, name string) (*spoke.ProtoFile, error) {
+	var proto *spoke.ProtoFile
+	var err error
+
+	switch format {
+	case "drupal", "islandora-workbench":
+		if spokeFromConfig == "" {
+			return nil, fmt.Errorf("--from-config is required for %s spokes", format)
+		}
+		switch len(spokeBundles) {
+		case 0:
+			return nil, fmt.Errorf("--bundle is required for %s spokes (e.g., --bundle islandora_object)", format)
+		case 1:
+			proto, err = spoke.GenerateDrupalSpoke(name, spokeBundles[0], spokeFromConfig)
+		default:
+			proto, err = spoke.GenerateDrupalUnionSpoke(name, spokeBundles, spokeFromConfig)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("generating %s spoke: %w", format, err)
+		}
+		proto.FormatName = format
+
+	case "csv":
+		if spokeSample == "" {
+			return nil, fmt.Errorf("csv spokes are generated from a sample: --from csv <sample.csv>")
+		}
+		f, err := os.Open(spokeSample)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		if proto, err = spoke.GenerateCSVSpoke(name, f); err != nil {
+			return nil, fmt.Errorf("generating csv spoke: %w", err)
+		}
+		// Registered under its own name: as "csv" its mapping would
+		// replace the default for every spreadsheet
+		proto.FormatName = name
+
+	case "dspace":
+		if spokeFromRegistry == "" {
+			return nil, fmt.Errorf("--from-registry is required for dspace spokes")
+		}
+		proto, err = spoke.GenerateDSpaceSpoke(name, spokeFromRegistry)
+		if err != nil {
+			return nil, fmt.Errorf("generating dspace spoke: %w", err)
+		}
+		proto.FormatName = format
+
+	case "xsd":
+		if spokeFromXSD == "" {
+			return nil, fmt.Errorf("--from-xsd is required for xsd spokes")
+		}
+		// No FormatName: an XML spoke has no field metadata to register,
+		// its XML annotations are what protoxml reads
+		proto, err = spoke.GenerateXSDSpoke(name, spokeFromXSD, spokeRoot)
+		if err != nil {
+			return nil, fmt.Errorf("generating xsd spoke: %w", err)
+		}
+
+	default:
+		return nil, fmt.Errorf("unknown format: %s (use 'drupal', 'islandora-workbench', 'dspace', or 'xsd', or --from csv)", format)
+	}
+	return proto, nil
+}
+
+func runSpokeDiff(cmd *cobra.Command, args []string) error {
+	format := args[0]
+	name := args[1]
+
+	protoPath := spokeProto
+	if protoPath == "" {
+		protoPath = filepath.Join("spoke", name, "v1", name+".proto")
+	}
+	if _, err := os.Stat(protoPath); err != nil {
+		return fmt.Errorf("spoke %q not found at %s", name, protoPath)
+	}
+
+	proto, err := generateSpoke(format, name)
+	if err != nil {
+		return err
+	}
+	diff, err := spoke.DiffSpoke(protoPath, proto)
+	if err != nil {
+		return fmt.Errorf("comparing spoke: %w", err)
+	}
+
+	source := fmt.Sprintf("%s (%s)", spokeFromConfig, strings.Join(spokeBundles, ", "))
+	switch format {
+	case "dspace":
+		source = spokeFromRegistry
+	case "xsd":
+		source = spokeFromXSD
+	}
+	fmt.Printf("--- %s\n", protoPath)
+	fmt.Printf("+++ %s\n", source)
+	if diff.Empty() {
+		fmt.Printf("\nmessage %s matches the config\n", diff.Message)
+		return nil
+	}
+
+	fmt.Printf("\nmessage %s\n", diff.Message)
+	for _, f := range diff.Added {
+		fmt.Printf("  + %s %s = %d;%s\n", f.NewType, f.Name, f.Suggested, drupalTypeNote(f.NewDrupalType))
+	}
+	for _, f := range diff.Removed {
+		fmt.Printf("  - %s %s = %d;%s\n", f.OldType, f.Name, f.Number, drupalTypeNote(f.OldDrupalType))
+	}
+	for _, f := range diff.Retyped {
+		fmt.Printf("  ~ %s = %d: %s -> %s", f.Name, f.Number, typeWithDrupal(f.OldType, f.OldDrupalType), typeWithDrupal(f.NewType, f.NewDrupalType))
+		switch {
+		case f.OldType == f.NewType:
+			fmt.Printf(" (proto type unchanged)\n")
+		case f.Compatible:
+			fmt.Printf(" (wire compatible, keeps its number)\n")
+		default:
+			fmt.Printf(" (incompatible: renumber to %d)\n", f.Suggested)
+		}
+	}
+
+	if numbers, names := diff.Reserve(); len(numbers) > 0 {
+		fmt.Printf("\nReserve in message %s so they aren't reused:\n", diff.Message)
+		fmt.Printf("  reserved %s;\n", joinInts(numbers))
+		if len(names) > 0 {
+			fmt.Printf("  reserved \"%s\";\n", strings.Join(names, `", "`))
+		}
+	}
+
+	fmt.Printf("\n%d added, %d removed, %d retyped\n", len(diff.Added), len(diff.Removed), len(diff.Retyped))
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	return &ExitError{Code: ExitDifferences, Err: fmt.Errorf("%s differs from %s", protoPath, source)}
+}
+
+// drupalTypeNote is a field's Drupal type as a trailing proto comment.
+func drupalTypeNote(drupalType string) string {
+	if drupalType == "" {
+		return ""
+	}
+	return " // [drupal:" + drupalType + "]"
+}
+
+// typeWithDrupal is a proto type followed by its Drupal type, if any.
+func typeWithDrupal(protoType, drupalType string) string {
+	if drupalType == "" {
+		return protoType
+	}
+	return fmt.Sprintf("%s [drupal:%s]", protoType, drupalType)
+}
+
+// joinInts joins numbers with commas.
+func joinInts(numbers []int) string {
+	s := make([]string, len(numbers))
+	for i, n := range numbers {
+		s[i] = strconv.Itoa(n)
+	}
+	return strings.Join(s, ", ")
+}
+
+// spokeName turns a file name into a spoke name: lowercase letters,
+// digits, and underscores.
+func spokeName(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case b.Len() > 0 && !strings.HasSuffix(b.String(), "_"):
+			b.WriteByte('_')
+		}
+	}
+	return strings.TrimSuffix(b.String(), "_")
+}
+
+// saveGeneratedProfile writes a generated profile, with its review
+// comments, to the profiles directory and returns its path.
+func saveGeneratedProfile(g *profile.Generated) (string, error) {
+	if err := profile.EnsureProfilesDir(); err != nil {
+		return "", fmt.Errorf("creating profiles directory: %w", err)
+	}
+	path, err := profile.ProfilePath(g.Name)
+	if err != nil {
+		return "", err
+	}
+	data, err := g.YAML()
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", fmt.Errorf("writing profile: %w", err)
+	}
+	return path, nil
+}
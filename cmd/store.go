@@ -0,0 +1,222 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	"github.com/lehigh-university-libraries/crosswalk/store/sqlite"
+)
+
+var storeCmd = &cobra.Command{
+	Use:   "store",
+	Short: "Stage hub records in a SQLite database for QA",
+	Long: `Load hub records into a SQLite database, check them with SQL, and
+serialize the batch once it passes.
+
+Records go in the records table, with their contributors, identifiers, and
+subjects in tables of their own keyed by record_id. Each load is a batch,
+named with --batch (default: the input file's name). Delete rows from the
+records table to drop records from a batch; the full record is kept in
+record_json, which is what export reads.
+
+Examples:
+  crosswalk store load batch.db drupal -i export.json
+  crosswalk store query batch.db "SELECT title FROM records WHERE date_issued IS NULL"
+  crosswalk store export batch.db islandora-workbench -o input.csv`,
+}
+
+var storeLoadCmd = &cobra.Command{
+	Use:   "load <db> <format>",
+	Short: "Parse input and add its records to the database as a batch",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runStoreLoad,
+}
+
+var storeQueryCmd = &cobra.Command{
+	Use:   "query <db> <sql>",
+	Short: "Run a SQL query and print the result as a table",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runStoreQuery,
+}
+
+var storeExportCmd = &cobra.Command{
+	Use:   "export <db> <format>",
+	Short: "Serialize the records of a batch",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runStoreExport,
+}
+
+var storeBatchesCmd = &cobra.Command{
+	Use:   "batches <db>",
+	Short: "List the batches in the database",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runStoreBatches,
+}
+
+func init() {
+	rootCmd.AddCommand(storeCmd)
+	storeCmd.AddCommand(storeLoadCmd, storeQueryCmd, storeExportCmd, storeBatchesCmd)
+
+	storeLoadCmd.Flags().StringVarP(&inputFile, "input", "i", "", "Input file (default: stdin)")
+	storeLoadCmd.Flags().StringVarP(&profileName, "profile", "p", "", "Mapping profile name (e.g., islandora)")
+	storeLoadCmd.Flags().StringVar(&profileFile, "profile-file", "", "Custom profile YAML file")
+	storeLoadCmd.Flags().String("batch", "", "Batch name (default: the input file's name, or stdin)")
+	storeLoadCmd.Flags().Bool("replace", false, "Delete the batch's existing records first")
+
+	storeExportCmd.Flags().StringVarP(&outputFile, "output", "o", "", "Output file (default: stdout)")
+	storeExportCmd.Flags().String("batch", "", "Batch to export (default: every record)")
+	storeExportCmd.Flags().StringSliceVarP(&columns, "columns", "c", nil, "Columns to output, in order (csv, islandora-workbench)")
+	storeExportCmd.Flags().StringVar(&multiValueSep, "separator", "|", "Multi-value field separator")
+	storeExportCmd.Flags().StringVar(&variant, "variant", "", "Output variant for formats with more than one")
+}
+
+func runStoreLoad(cmd *cobra.Command, args []string) error {
+	dbPath, fromFormat := args[0], args[1]
+	batch, _ := cmd.Flags().GetString("batch")
+	replace, _ := cmd.Flags().GetBool("replace")
+	if batch == "" {
+		batch = "stdin"
+		if inputFile != "" {
+			batch = filepath.Base(inputFile)
+		}
+	}
+
+	parser, err := format.GetParser(fromFormat)
+	if err != nil {
+		return fmt.Errorf("unknown source format %q: %w", fromFormat, err)
+	}
+	profile, err := loadProfile(fromFormat)
+	if err != nil {
+		return fmt.Errorf("loading profile: %w", err)
+	}
+
+	var input io.Reader = os.Stdin
+	if inputFile != "" {
+		f, err := os.Open(inputFile)
+		if err != nil {
+			return fmt.Errorf("opening input file: %w", err)
+		}
+		defer f.Close()
+		input = f
+	}
+	records, err := parser.Parse(input, &format.ParseOptions{
+		Profile:    profile,
+		StripHTML:  true,
+		SourceName: inputFile,
+	})
+	if err != nil {
+		return fmt.Errorf("parsing input: %w", err)
+	}
+
+	store, err := sqlite.Open(dbPath)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	if replace {
+		n, err := store.DeleteBatch(cmd.Context(), batch)
+		if err != nil {
+			return fmt.Errorf("replacing batch %s: %w", batch, err)
+		}
+		if n > 0 {
+			fmt.Fprintf(os.Stderr, "Deleted %d records from batch %s\n", n, batch)
+		}
+	}
+	if _, err := store.Add(cmd.Context(), batch, records); err != nil {
+		return fmt.Errorf("storing records: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Loaded %d records into batch %s\n", len(records), batch)
+	return nil
+}
+
+func runStoreQuery(cmd *cobra.Command, args []string) error {
+	store, err := sqlite.Open(args[0])
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	columns, rows, err := store.Query(cmd.Context(), args[1])
+	if err != nil {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("query: %w", err)
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(columns, "\t"))
+	for _, row := range rows {
+		for i, v := range row {
+			row[i] = strings.ReplaceAll(v, "\t", " ")
+		}
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "(%d rows)\n", len(rows))
+	return nil
+}
+
+func runStoreExport(cmd *cobra.Command, args []string) error {
+	dbPath, toFormat := args[0], args[1]
+	batch, _ := cmd.Flags().GetString("batch")
+
+	serializer, err := format.GetSerializer(toFormat)
+	if err != nil {
+		return fmt.Errorf("unknown target format %q: %w", toFormat, err)
+	}
+
+	store, err := sqlite.Open(dbPath)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	records, err := store.Records(cmd.Context(), batch)
+	if err != nil {
+		return fmt.Errorf("reading records: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Read %d records\n", len(records))
+
+	opts := &format.SerializeOptions{
+		Columns:             columns,
+		MultiValueSeparator: multiValueSep,
+		IncludeHeader:       true,
+		Variant:             variant,
+	}
+	if outputFile != "" {
+		opts.OutputName = filepath.Base(outputFile)
+	}
+	return writeRecords(cmd, serializer, records, opts)
+}
+
+func runStoreBatches(cmd *cobra.Command, args []string) error {
+	store, err := sqlite.Open(args[0])
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	batches, err := store.Batches(cmd.Context())
+	if err != nil {
+		return err
+	}
+	names := make([]string, 0, len(batches))
+	for name := range batches {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Printf("%s\t%d records\n", name, batches[name])
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+)
+
+// streamRecords converts without holding the input in memory: each record
+// goes from the parser to the serializer as it is read. A parse error part
+// way through leaves the records before it in the output.
+func streamRecords(cmd *cobra.Command, parser format.Parser, input io.Reader, parseOpts *format.ParseOptions, serializer format.StreamSerializer, opts *format.SerializeOptions, skipper *recordSkipper) (err error) {
+	output := &deferredOutput{path: outputFile, started: allowEmpty}
+	defer func() {
+		if cerr := output.Close(); cerr != nil && err == nil {
+			err = fmt.Errorf("closing output file: %w", cerr)
+		}
+	}()
+
+	parsed := 0
+	var parseErr error
+	err = serializer.SerializeStream(output, opts, func(write func(*hubv1.Record) error) error {
+		var writeErr error
+		perr := format.ParseEach(parser, input, parseOpts, func(record *hubv1.Record) error {
+			parsed++
+			output.started = true
+			writeErr = write(record)
+			return writeErr
+		})
+		if writeErr == nil {
+			parseErr = perr
+		}
+		return perr
+	})
+	if parseErr != nil {
+		return fmt.Errorf("parsing input: %w", parseErr)
+	}
+
+	fmt.Fprintf(os.Stderr, "Parsed %d records\n", parsed)
+	if skipper != nil && skipper.skipped > 0 {
+		fmt.Fprintf(os.Stderr, "Skipped %d records that failed to parse\n", skipper.skipped)
+	}
+	if parsed == 0 && !allowEmpty {
+		cmd.SilenceUsage = true
+		return &ExitError{
+			Code: ExitNoRecords,
+			Err:  fmt.Errorf("no records parsed from %s (use --allow-empty to write an empty %s document)", parseOpts.SourceName, serializer.Name()),
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("serializing output: %w", err)
+	}
+
+	if n := warningCount.Load(); n > 0 {
+		cmd.SilenceUsage = true
+		return &ExitError{Code: ExitWarnings, Err: fmt.Errorf("completed with %d warning(s)", n)}
+	}
+	return nil
+}
+
+// deferredOutput opens the output file (or stdout) only once the stream
+// has started, holding back anything written before then, such as a CSV
+// header. An input with no records leaves no output behind, as on the
+// batch path.
+type deferredOutput struct {
+	path    string
+	started bool
+	held    bytes.Buffer
+	w       io.Writer
+	close   func() error
+}
+
+func (o *deferredOutput) Write(p []byte) (int, error) {
+	if o.w == nil {
+		if !o.started {
+			return o.held.Write(p)
+		}
+		if err := o.open(); err != nil {
+			return 0, err
+		}
+	}
+	return o.w.Write(p)
+}
+
+// Close writes out a started stream that never wrote anything, then closes
+// the output.
+func (o *deferredOutput) Close() error {
+	if o.w == nil {
+		if !o.started {
+			return nil
+		}
+		if err := o.open(); err != nil {
+			return err
+		}
+	}
+	return o.close()
+}
+
+func (o *deferredOutput) open() error {
+	w, closeOutput, err := openOutput(o.path)
+	if err != nil {
+		return err
+	}
+	o.w, o.close = w, closeOutput
+	if _, err := o.w.Write(o.held.Bytes()); err != nil {
+		return fmt.Errorf("writing output: %w", err)
+	}
+	return nil
+}
+
+// readsMembershipPaths reports whether the output shows records' membership
+// paths, which are resolved across the whole batch and so rule out
+// streaming.
+func readsMembershipPaths(opts *format.SerializeOptions) bool {
+	if slices.Contains(opts.Columns, "membership_path") {
+		return true
+	}
+	for _, tmpl := range opts.TemplateColumns {
+		if strings.Contains(tmpl, "MembershipPath") || strings.Contains(tmpl, "membership_path") {
+			return true
+		}
+	}
+	return false
+}
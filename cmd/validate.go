@@ -1,24 +1,31 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 
 	"github.com/lehigh-university-libraries/crosswalk/format"
 	"github.com/lehigh-university-libraries/crosswalk/format/drupal"
 	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
 	"github.com/lehigh-university-libraries/crosswalk/hub"
 	"github.com/lehigh-university-libraries/crosswalk/mapping"
+	"github.com/lehigh-university-libraries/crosswalk/profile"
 )
 
 var (
-	validateInput       string
-	validateProfileName string
-	validateTaxonomy    string
-	validateVerbose     bool
+	validateInput        string
+	validateProfileName  string
+	validateTaxonomy     string
+	validateVerbose      bool
+	validateCompleteness string
 )
 
 var validateCmd = &cobra.Command{
@@ -29,6 +36,26 @@ var validateCmd = &cobra.Command{
 This command parses the input and reports any issues found without
 producing output. Useful for checking data quality before conversion.
 
+With --profile, each record is also checked against a completeness
+profile, and pass or fail is printed per record with the reasons. Built-in
+profiles: datacite-mandatory, crossref-deposit, islandora-minimum, default,
+and strict; a name without its suffix, like datacite, also works. Other
+profiles are YAML files, given by path or saved as validation/<name>.yaml
+in the config directory:
+
+  name: thesis-deposit
+  required:
+    - title
+    - contributors
+    - dates.issued|dates.created
+  conditions:
+    - when: resource_type.type
+      is: [dissertation, thesis]
+      require: [degree_info.institution]
+  constraints:
+    - field: language
+      pattern: ^[a-z]{3}$
+
 Arguments:
   format  Input format (drupal, csv)
 
@@ -37,6 +64,7 @@ Input defaults to stdin.
 Examples:
   crosswalk validate drupal -i input.json
   crosswalk validate drupal -i input.json --verbose
+  crosswalk validate datacite -i datacite.xml --profile datacite
   cat data.json | crosswalk validate drupal`,
 	Args: cobra.ExactArgs(1),
 	RunE: runValidate,
@@ -44,8 +72,9 @@ Examples:
 
 func init() {
 	validateCmd.Flags().StringVarP(&validateInput, "input", "i", "", "Input file (default: stdin)")
-	validateCmd.Flags().StringVarP(&validateProfileName, "profile", "p", "", "Mapping profile name")
-	validateCmd.Flags().StringVar(&validateTaxonomy, "taxonomy-file", "", "Taxonomy term resolution file")
+	validateCmd.Flags().StringVar(&validateCompleteness, "profile", "", "Completeness profile to check records against (built-in name or YAML file)")
+	validateCmd.Flags().StringVarP(&validateProfileName, "mapping-profile", "p", "", "Mapping profile name")
+	validateCmd.Flags().StringVar(&validateTaxonomy, "taxonomy-file", "", "Taxonomy term resolution file: JSON, or a CSV term export with tid, vid, name, and uri columns")
 	validateCmd.Flags().BoolVarP(&validateVerbose, "verbose", "v", false, "Show detailed information")
 }
 
@@ -79,6 +108,13 @@ func runValidate(cmd *cobra.Command, args []string) (err error) {
 		return fmt.Errorf("unknown format %q: %w", fromFormat, err)
 	}
 
+	var completeness *hub.ValidationProfile
+	if validateCompleteness != "" {
+		if completeness, err = loadValidationProfile(validateCompleteness); err != nil {
+			return err
+		}
+	}
+
 	// Load profile
 	var profile *mapping.Profile
 	if validateProfileName != "" {
@@ -118,6 +154,13 @@ func runValidate(cmd *cobra.Command, args []string) (err error) {
 
 	fmt.Printf("✓ Valid: parsed %d records from %s\n", len(records), inputName)
 
+	if completeness != nil {
+		if failed := checkCompleteness(records, completeness); failed > 0 {
+			cmd.SilenceUsage = true
+			return fmt.Errorf("%d of %d records failed %s", failed, len(records), completeness.Name)
+		}
+	}
+
 	if validateVerbose {
 		fmt.Println("\nRecord summary:")
 		for i, r := range records {
@@ -139,6 +182,70 @@ func runValidate(cmd *cobra.Command, args []string) (err error) {
 	return nil
 }
 
+// checkCompleteness prints pass or fail for each record with the reasons,
+// then a summary, and returns how many records failed.
+func checkCompleteness(records []*hubv1.Record, profile *hub.ValidationProfile) int {
+	fmt.Printf("\nChecking against %s:\n", profile.Name)
+	failed := 0
+	for i, r := range records {
+		label := fmt.Sprintf("Record %d", i+1)
+		if r.Title != "" {
+			label += ": " + truncate(r.Title, 60)
+		}
+
+		result := hub.Validate(r, profile)
+		if result.IsValid() {
+			fmt.Printf("  ✓ PASS %s\n", label)
+		} else {
+			failed++
+			fmt.Printf("  ✗ FAIL %s\n", label)
+		}
+		for _, e := range result.Errors {
+			fmt.Printf("      - %s\n", e.Message)
+		}
+		for _, w := range result.Warnings {
+			fmt.Printf("      ! %s: %s\n", w.Field, w.Message)
+		}
+	}
+	fmt.Printf("\n%d of %d records passed %s\n", len(records)-failed, len(records), profile.Name)
+	return failed
+}
+
+// loadValidationProfile finds a completeness profile: a YAML file path, a
+// built-in profile, or validation/<name>.yaml in the config directory.
+func loadValidationProfile(name string) (*hub.ValidationProfile, error) {
+	path := name
+	if !strings.HasSuffix(name, ".yaml") && !strings.HasSuffix(name, ".yml") {
+		if p, ok := hub.GetValidationProfile(name); ok {
+			return p, nil
+		}
+		dir, err := profile.ConfigDir()
+		if err != nil {
+			return nil, err
+		}
+		path = filepath.Join(dir, "validation", name+".yaml")
+		if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("unknown completeness profile %q (built-in: %s)", name, strings.Join(hub.ValidationProfiles(), ", "))
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading completeness profile: %w", err)
+	}
+	var p hub.ValidationProfile
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("parsing completeness profile %s: %w", path, err)
+	}
+	if p.Name == "" {
+		p.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	if err := p.Compile(); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
 func truncate(s string, max int) string {
 	if len(s) <= max {
 		return s
@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/lehigh-university-libraries/crosswalk/fixity"
+)
+
+var verifyFilesCmd = &cobra.Command{
+	Use:   "verify-files <file>",
+	Short: "Check the files records refer to against their checksums",
+	Long: `Parse a file into hub records and check every file the records refer
+to: local files are read and remote ones fetched, and their md5, sha1,
+sha256, and sha512 checksums and sizes compared with the records'. A file
+the record gives no checksum for is only checked to exist (local files
+are stat'd, remote ones requested with HEAD), and for its size when the
+record has one.
+
+Run it on an Islandora Workbench sheet before ingest to catch files that
+are missing or changed since their checksums were taken. Relative paths
+are resolved against --base-dir, the sheet's directory by default; point
+it at Workbench's input_dir when that is somewhere else.
+
+The file's format is detected from its extension and content unless
+--from names it. Each missing or mismatched file is printed; --verbose
+prints every file, and --json writes one JSON object per file instead.
+
+Exit codes:
+  0  every file was found and matched
+  1  failed
+  2  no records parsed
+  5  files are missing or don't match their checksums
+
+Examples:
+  crosswalk verify-files input.csv --from islandora-workbench
+  crosswalk verify-files records.jsonl --base-dir /mnt/ingest --workers 4
+  crosswalk verify-files input.csv --json > fixity.jsonl`,
+	Args: cobra.ExactArgs(1),
+	RunE: runVerifyFiles,
+}
+
+func init() {
+	rootCmd.AddCommand(verifyFilesCmd)
+
+	verifyFilesCmd.Flags().String("from", "", "Format of the file (default: detected)")
+	verifyFilesCmd.Flags().String("base-dir", "", "Directory relative file paths are resolved against (default: the file's directory)")
+	verifyFilesCmd.Flags().Int("workers", 1, "Files to check concurrently")
+	verifyFilesCmd.Flags().BoolP("verbose", "v", false, "Print every file, not only the ones with problems")
+	verifyFilesCmd.Flags().Bool("json", false, "Write a JSON line per file to stdout")
+}
+
+func runVerifyFiles(cmd *cobra.Command, args []string) error {
+	from, _ := cmd.Flags().GetString("from")
+	baseDir, _ := cmd.Flags().GetString("base-dir")
+	nWorkers, _ := cmd.Flags().GetInt("workers")
+	verbose, _ := cmd.Flags().GetBool("verbose")
+	asJSON, _ := cmd.Flags().GetBool("json")
+
+	if nWorkers < 1 {
+		return fmt.Errorf("--workers must be at least 1, got %d", nWorkers)
+	}
+	records, from, err := parseDiffInput(args[0], from)
+	if err != nil {
+		return err
+	}
+	cmd.SilenceUsage = true
+	if len(records) == 0 {
+		return &ExitError{Code: ExitNoRecords, Err: fmt.Errorf("no records parsed from %s", args[0])}
+	}
+	if baseDir == "" {
+		baseDir = filepath.Dir(args[0])
+	}
+
+	v := fixity.NewVerifier(baseDir)
+	v.Workers = nWorkers
+	results := v.VerifyRecords(cmd.Context(), records)
+
+	failed := 0
+	enc := json.NewEncoder(os.Stdout)
+	for _, res := range results {
+		if !res.OK() {
+			failed++
+		}
+		switch {
+		case asJSON:
+			if err := enc.Encode(res); err != nil {
+				return err
+			}
+		case verbose || !res.OK():
+			fmt.Println(verifyLine(res))
+		}
+	}
+
+	if failed > 0 {
+		cmd.SilenceErrors = true
+		return &ExitError{Code: ExitFixity, Err: fmt.Errorf("%d of %d files in %s are missing or don't match their checksums", failed, len(results), args[0])}
+	}
+	fmt.Fprintf(os.Stderr, "Checked %d files in %d %s records: all present and matching\n", len(results), len(records), from)
+	return nil
+}
+
+// verifyLine describes a result on one line: status, record, path, and
+// what went wrong.
+func verifyLine(res fixity.Result) string {
+	label := fmt.Sprintf("record %d", res.Record+1)
+	if res.Title != "" {
+		label += fmt.Sprintf(" (%s)", res.Title)
+	}
+	line := fmt.Sprintf("%-11s %s: %s", res.Status, label, res.Path)
+	if len(res.Mismatches) > 0 {
+		line += ": " + strings.Join(res.Mismatches, "; ")
+	}
+	if res.Error != "" {
+		line += ": " + res.Error
+	}
+	return line
+}
@@ -0,0 +1,197 @@
+// Package authorities links subjects to authority records. For subjects
+// without a URI it looks the heading up in Library of Congress (id.loc.gov)
+// or Getty (vocab.getty.edu) vocabularies and, on an exact label match,
+// sets the subject's URI and vocabulary. Answers, including misses, are
+// cached on disk so repeated runs over a corpus don't query again.
+// It registers itself as the "authorities" enricher.
+package authorities
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lehigh-university-libraries/crosswalk/enrich"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+)
+
+// Default service endpoints.
+const (
+	DefaultLCBaseURL   = "https://id.loc.gov"
+	DefaultGettySPARQL = "https://vocab.getty.edu/sparql.json"
+)
+
+// Reconciler looks up subject headings in LC and Getty vocabularies.
+type Reconciler struct {
+	LCBaseURL   string
+	GettySPARQL string
+	HTTPClient  *http.Client
+	UserAgent   string
+
+	// Interval is the minimum gap between requests to either service.
+	Interval time.Duration
+	// Cache keeps answers between runs; nil disables it.
+	Cache *Cache
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+// NewReconciler returns a reconciler using the public services and the
+// disk cache in the config directory. A mailto address is added to the
+// User-Agent so the services can reach whoever runs a large job.
+func NewReconciler(mailto string) (*Reconciler, error) {
+	cache, err := DefaultCache()
+	if err != nil {
+		return nil, err
+	}
+	r := &Reconciler{
+		LCBaseURL:   DefaultLCBaseURL,
+		GettySPARQL: DefaultGettySPARQL,
+		HTTPClient: &http.Client{
+			Timeout: 30 * time.Second,
+			// id.loc.gov answers label lookups with a redirect whose
+			// headers carry the match; the record itself isn't needed
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		UserAgent: "crosswalk (https://github.com/lehigh-university-libraries/crosswalk)",
+		Interval:  200 * time.Millisecond,
+		Cache:     cache,
+	}
+	if mailto != "" {
+		r.UserAgent = fmt.Sprintf("crosswalk (https://github.com/lehigh-university-libraries/crosswalk; mailto:%s)", mailto)
+	}
+	return r, nil
+}
+
+func init() {
+	enrich.Register("authorities", func(opts enrich.Options) (enrich.Enricher, error) {
+		return NewReconciler(opts.Mailto)
+	})
+}
+
+// candidates lists the vocabularies a subject is looked up in, in order.
+// A subject that names its vocabulary is only looked up there; one that
+// doesn't is tried against the vocabularies that suit its type. Other
+// vocabularies (MeSH, FAST, keywords, local terms) are left alone.
+func candidates(s *hubv1.Subject) []hubv1.SubjectVocabulary {
+	switch s.Vocabulary {
+	case hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LCSH,
+		hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LCNAF,
+		hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_AAT,
+		hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_GETTY_TGN:
+		return []hubv1.SubjectVocabulary{s.Vocabulary}
+	case hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_UNSPECIFIED:
+	default:
+		return nil
+	}
+
+	switch s.Type {
+	case hubv1.SubjectType_SUBJECT_TYPE_NAME:
+		return []hubv1.SubjectVocabulary{hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LCNAF}
+	case hubv1.SubjectType_SUBJECT_TYPE_GEOGRAPHIC:
+		return []hubv1.SubjectVocabulary{
+			hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LCSH,
+			hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_GETTY_TGN,
+		}
+	default:
+		return []hubv1.SubjectVocabulary{
+			hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LCSH,
+			hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_AAT,
+		}
+	}
+}
+
+// Enrich links each record's subjects that lack a URI. A failed lookup is
+// logged as a warning and skipped; only a cancelled context ends the run.
+func (r *Reconciler) Enrich(ctx context.Context, records []*hubv1.Record) error {
+	for _, record := range records {
+		for _, subject := range record.Subjects {
+			label := strings.TrimSpace(subject.Value)
+			if subject.Uri != "" || label == "" {
+				continue
+			}
+			for _, vocab := range candidates(subject) {
+				uri, err := r.Lookup(ctx, vocab, label)
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				if err != nil {
+					slog.Warn("authority lookup failed", "subject", label, "vocabulary", vocab, "err", err)
+					break
+				}
+				if uri == "" {
+					continue
+				}
+				subject.Uri = uri
+				subject.Vocabulary = vocab
+				slog.Debug("linked subject", "subject", label, "uri", uri)
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// Lookup returns the URI of the authority in vocab whose label is label,
+// or "" when there is no single exact match.
+func (r *Reconciler) Lookup(ctx context.Context, vocab hubv1.SubjectVocabulary, label string) (string, error) {
+	key := vocab.String() + "\x00" + strings.ToLower(label)
+	if uri, ok := r.Cache.Get(key); ok {
+		return uri, nil
+	}
+
+	var uri string
+	var err error
+	switch vocab {
+	case hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LCSH:
+		uri, err = r.lookupLC(ctx, "subjects", label)
+	case hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LCNAF:
+		uri, err = r.lookupLC(ctx, "names", label)
+	case hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_AAT:
+		uri, err = r.lookupGetty(ctx, "aat", label)
+	case hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_GETTY_TGN:
+		uri, err = r.lookupGetty(ctx, "tgn", label)
+	default:
+		return "", fmt.Errorf("no authority service for %s", vocab)
+	}
+	if err != nil {
+		return "", err
+	}
+	if err := r.Cache.Put(key, uri); err != nil {
+		slog.Debug("caching authority lookup failed", "err", err)
+	}
+	return uri, nil
+}
+
+// do sends a request once the rate limit allows.
+func (r *Reconciler) do(ctx context.Context, req *http.Request) (*http.Response, error) {
+	r.mu.Lock()
+	now := time.Now()
+	at := r.next
+	if at.Before(now) {
+		at = now
+	}
+	r.next = at.Add(r.Interval)
+	r.mu.Unlock()
+
+	if d := time.Until(at); d > 0 {
+		t := time.NewTimer(d)
+		defer t.Stop()
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-t.C:
+		}
+	}
+	if r.UserAgent != "" {
+		req.Header.Set("User-Agent", r.UserAgent)
+	}
+	return r.HTTPClient.Do(req)
+}
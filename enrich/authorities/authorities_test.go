@@ -0,0 +1,127 @@
+package authorities
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+)
+
+// services fakes id.loc.gov's label service and the Getty SPARQL endpoint,
+// counting the requests they receive.
+func services(t *testing.T, requests *int) *httptest.Server {
+	t.Helper()
+	lc := map[string]string{
+		"/authorities/subjects/label/Geology":        "http://id.loc.gov/authorities/subjects/sh85054037",
+		"/authorities/names/label/Lehigh University": "http://id.loc.gov/authorities/names/n79059014",
+	}
+	getty := map[string][]string{
+		`"Daguerreotypes"@en`: {"http://vocab.getty.edu/aat/300127181"},
+		`"Bethlehem"@en`:      {"http://vocab.getty.edu/tgn/7013520", "http://vocab.getty.edu/tgn/1004004"},
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		if r.URL.Path == "/sparql.json" {
+			query := r.URL.Query().Get("query")
+			var bindings []string
+			for literal, uris := range getty {
+				if strings.Contains(query, literal) {
+					for _, uri := range uris {
+						bindings = append(bindings, fmt.Sprintf(`{"s":{"type":"uri","value":%q}}`, uri))
+					}
+				}
+			}
+			fmt.Fprintf(w, `{"head":{"vars":["s"]},"results":{"bindings":[%s]}}`, strings.Join(bindings, ","))
+			return
+		}
+		if uri, ok := lc[r.URL.Path]; ok {
+			w.Header().Set("X-Uri", uri)
+			w.Header().Set("Location", uri)
+			w.WriteHeader(http.StatusFound)
+			return
+		}
+		http.NotFound(w, r)
+	}))
+}
+
+func testReconciler(t *testing.T, baseURL, cacheDir string) *Reconciler {
+	t.Helper()
+	r := &Reconciler{
+		LCBaseURL:   baseURL,
+		GettySPARQL: baseURL + "/sparql.json",
+		HTTPClient: &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		}},
+		Cache: &Cache{Dir: cacheDir},
+	}
+	return r
+}
+
+func subjects() []*hubv1.Subject {
+	return []*hubv1.Subject{
+		{Value: "Geology"},
+		{Value: "Lehigh University", Type: hubv1.SubjectType_SUBJECT_TYPE_NAME},
+		{Value: "Daguerreotypes"},
+		{Value: "Bethlehem", Type: hubv1.SubjectType_SUBJECT_TYPE_GEOGRAPHIC}, // ambiguous in TGN
+		{Value: "sediment transport", Vocabulary: hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_KEYWORDS},
+		{Value: "Rocks", Uri: "http://example.edu/terms/rocks"},
+	}
+}
+
+func TestEnrichLinksSubjects(t *testing.T) {
+	requests := 0
+	srv := services(t, &requests)
+	defer srv.Close()
+	cacheDir := t.TempDir()
+
+	got := subjects()
+	record := &hubv1.Record{Subjects: got}
+	if err := testReconciler(t, srv.URL, cacheDir).Enrich(context.Background(), []*hubv1.Record{record}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		uri   string
+		vocab hubv1.SubjectVocabulary
+	}{
+		{"http://id.loc.gov/authorities/subjects/sh85054037", hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LCSH},
+		{"http://id.loc.gov/authorities/names/n79059014", hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LCNAF},
+		{"http://vocab.getty.edu/aat/300127181", hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_AAT},
+		{"", hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_UNSPECIFIED},
+		{"", hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_KEYWORDS},
+		{"http://example.edu/terms/rocks", hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_UNSPECIFIED},
+	}
+	for i, s := range got {
+		if s.Uri != want[i].uri || s.Vocabulary != want[i].vocab {
+			t.Errorf("%s: got %q (%s), want %q (%s)", s.Value, s.Uri, s.Vocabulary, want[i].uri, want[i].vocab)
+		}
+	}
+	// LCSH for Geology, LCNAF for the name, LCSH then AAT for
+	// Daguerreotypes, LCSH then TGN for the place
+	if requests != 6 {
+		t.Errorf("expected 6 requests, got %d", requests)
+	}
+
+	// A second run over the same corpus is answered from the cache
+	requests = 0
+	again := &hubv1.Record{Subjects: subjects()}
+	if err := testReconciler(t, srv.URL, cacheDir).Enrich(context.Background(), []*hubv1.Record{again}); err != nil {
+		t.Fatal(err)
+	}
+	if requests != 0 {
+		t.Errorf("expected cached answers, got %d requests", requests)
+	}
+	if again.Subjects[2].Uri != "http://vocab.getty.edu/aat/300127181" {
+		t.Errorf("cached match: got %q", again.Subjects[2].Uri)
+	}
+}
+
+func TestSPARQLLiteral(t *testing.T) {
+	if got := sparqlLiteral(`Say "cheese" \ smile`); got != `"Say \"cheese\" \\ smile"@en` {
+		t.Errorf("got %s", got)
+	}
+}
@@ -0,0 +1,84 @@
+package authorities
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/lehigh-university-libraries/crosswalk/profile"
+)
+
+const cacheVersion = "v1"
+
+// Cache stores lookup answers as one JSON file per heading. Misses are
+// stored too, since most free-text subjects have no authority and asking
+// again on every run is what the cache is for.
+type Cache struct {
+	Dir string
+	// TTL is how long an answer is trusted; headings change rarely.
+	TTL time.Duration
+}
+
+// DefaultCache returns the cache under the config directory, creating it.
+func DefaultCache() (*Cache, error) {
+	configDir, err := profile.ConfigDir()
+	if err != nil {
+		return nil, fmt.Errorf("getting config dir: %w", err)
+	}
+	dir := filepath.Join(configDir, "cache", "authorities", cacheVersion)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("creating cache dir: %w", err)
+	}
+	return &Cache{Dir: dir, TTL: 30 * 24 * time.Hour}, nil
+}
+
+type cacheEntry struct {
+	Key string `json:"key"`
+	URI string `json:"uri"` // empty for no match
+}
+
+func (c *Cache) path(key string) string {
+	hash := md5.Sum([]byte(key))
+	return filepath.Join(c.Dir, hex.EncodeToString(hash[:])+".json")
+}
+
+// Get returns the cached answer for key, if there is a fresh one.
+func (c *Cache) Get(key string) (uri string, ok bool) {
+	if c == nil {
+		return "", false
+	}
+	path := c.path(key)
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", false
+	}
+	if c.TTL > 0 && time.Since(info.ModTime()) > c.TTL {
+		os.Remove(path)
+		return "", false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", false
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Key != key {
+		return "", false
+	}
+	return entry.URI, true
+}
+
+// Put stores the answer for key.
+func (c *Cache) Put(key, uri string) error {
+	if c == nil {
+		return nil
+	}
+	data, err := json.Marshal(cacheEntry{Key: key, URI: uri})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(c.path(key), data, 0644)
+}
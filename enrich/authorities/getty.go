@@ -0,0 +1,74 @@
+package authorities
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// gettyQuery finds concepts in a Getty vocabulary with a preferred or
+// alternate English label exactly equal to the search term. Two rows are
+// enough to tell a unique match from an ambiguous one, which place names
+// like "Bethlehem" often are.
+const gettyQuery = `PREFIX skos: <http://www.w3.org/2004/02/skos/core#>
+PREFIX xl: <http://www.w3.org/2008/05/skos-xl#>
+SELECT DISTINCT ?s WHERE {
+  ?s skos:inScheme <http://vocab.getty.edu/%s/> ;
+     xl:prefLabel|xl:altLabel ?label .
+  ?label xl:literalForm %s .
+} LIMIT 2`
+
+// lookupGetty queries the Getty SPARQL endpoint for an exact label in
+// scheme ("aat" or "tgn").
+func (r *Reconciler) lookupGetty(ctx context.Context, scheme, label string) (string, error) {
+	u, err := url.Parse(r.GettySPARQL)
+	if err != nil {
+		return "", fmt.Errorf("invalid Getty SPARQL URL: %w", err)
+	}
+	u.RawQuery = url.Values{"query": {fmt.Sprintf(gettyQuery, scheme, sparqlLiteral(label))}}.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "application/sparql-results+json")
+	resp, err := r.do(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return "", fmt.Errorf("reading response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status %s from Getty SPARQL endpoint", resp.Status)
+	}
+
+	var results struct {
+		Results struct {
+			Bindings []struct {
+				S struct {
+					Value string `json:"value"`
+				} `json:"s"`
+			} `json:"bindings"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal(body, &results); err != nil {
+		return "", fmt.Errorf("decoding Getty SPARQL response: %w", err)
+	}
+	if b := results.Results.Bindings; len(b) == 1 {
+		return b[0].S.Value, nil
+	}
+	return "", nil
+}
+
+// sparqlLiteral quotes s as an English SPARQL string literal.
+func sparqlLiteral(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`)
+	return `"` + r.Replace(s) + `"@en`
+}
@@ -0,0 +1,43 @@
+package authorities
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// lookupLC asks id.loc.gov's known-label service for an exact heading in
+// scheme ("subjects" or "names"). A match redirects to the authority and
+// names it in the X-Uri header; no match is a 404.
+func (r *Reconciler) lookupLC(ctx context.Context, scheme, label string) (string, error) {
+	u, err := url.Parse(r.LCBaseURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid id.loc.gov base URL: %w", err)
+	}
+	u = u.JoinPath("authorities", scheme, "label", label)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, u.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := r.do(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return "", nil
+	case resp.StatusCode >= 300 && resp.StatusCode < 400, resp.StatusCode == http.StatusOK:
+		if uri := resp.Header.Get("X-Uri"); uri != "" {
+			return uri, nil
+		}
+		return "", fmt.Errorf("id.loc.gov response for %q has no X-Uri header", label)
+	default:
+		return "", fmt.Errorf("unexpected status %s from %s", resp.Status, u.Redacted())
+	}
+}
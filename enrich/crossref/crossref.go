@@ -0,0 +1,363 @@
+// Package crossref enriches hub records from the Crossref REST API
+// (https://api.crossref.org). The "crossref" enricher fills funders,
+// license, ISSN, publisher, and the reference count of records with a DOI
+// when they lack them; "crossref-funders" reconciles free-text funder
+// names against the Crossref Funder Registry.
+package crossref
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lehigh-university-libraries/crosswalk/enrich"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+// DefaultBaseURL is the Crossref REST API.
+const DefaultBaseURL = "https://api.crossref.org"
+
+// Request intervals for Crossref's public and polite pools. Clients that
+// identify themselves with a mailto address may make requests twice as
+// often.
+const (
+	PublicInterval = 200 * time.Millisecond
+	PoliteInterval = 100 * time.Millisecond
+)
+
+// ErrNotFound is returned by Work for a DOI Crossref doesn't know, such as
+// one registered with DataCite.
+var ErrNotFound = errors.New("DOI not found in Crossref")
+
+// Client looks up works in the Crossref REST API. It is safe for
+// concurrent use; requests are spaced by Interval across all callers.
+type Client struct {
+	BaseURL    string
+	HTTPClient *http.Client
+	UserAgent  string
+
+	// Mailto is sent with every request, which puts the client in
+	// Crossref's polite pool.
+	Mailto string
+	// Interval is the minimum gap between requests. A longer limit in the
+	// API's X-Rate-Limit headers replaces it.
+	Interval time.Duration
+	// Retries is how many times a 429 Too Many Requests or 503 Service
+	// Unavailable is retried, waiting for the Retry-After the API asks for.
+	Retries int
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+// NewClient returns a client for the Crossref REST API. A mailto address
+// selects the polite pool and its shorter request interval.
+func NewClient(mailto string) *Client {
+	c := &Client{
+		BaseURL:    DefaultBaseURL,
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+		UserAgent:  "crosswalk (https://github.com/lehigh-university-libraries/crosswalk)",
+		Mailto:     mailto,
+		Interval:   PublicInterval,
+		Retries:    3,
+	}
+	if mailto != "" {
+		c.UserAgent = fmt.Sprintf("crosswalk (https://github.com/lehigh-university-libraries/crosswalk; mailto:%s)", mailto)
+		c.Interval = PoliteInterval
+	}
+	return c
+}
+
+func init() {
+	enrich.Register("crossref", func(opts enrich.Options) (enrich.Enricher, error) {
+		return NewClient(opts.Mailto), nil
+	})
+}
+
+// Work is the part of a Crossref works record the enricher reads.
+type Work struct {
+	DOI       string   `json:"DOI"`
+	Publisher string   `json:"publisher"`
+	ISSN      []string `json:"ISSN"`
+	ISSNType  []struct {
+		Value string `json:"value"`
+		Type  string `json:"type"` // print or electronic
+	} `json:"issn-type"`
+	License []struct {
+		URL            string `json:"URL"`
+		ContentVersion string `json:"content-version"` // vor, am, tdm, or unspecified
+	} `json:"license"`
+	Funder []struct {
+		Name  string   `json:"name"`
+		DOI   string   `json:"DOI"`
+		Award []string `json:"award"`
+	} `json:"funder"`
+	ReferencesCount int `json:"references-count"`
+}
+
+// Enrich looks up each record's DOI and fills the fields it lacks. Records
+// without a DOI are left alone, as are DOIs Crossref doesn't know; other
+// failed lookups are logged as warnings. Only a cancelled context ends the
+// run early.
+func (c *Client) Enrich(ctx context.Context, records []*hubv1.Record) error {
+	for _, record := range records {
+		id := hub.GetDOI(record)
+		if id == nil {
+			continue
+		}
+		doi := hub.NormalizeIdentifier(id.Value, hubv1.IdentifierType_IDENTIFIER_TYPE_DOI)
+		if doi == "" {
+			continue
+		}
+
+		work, err := c.Work(ctx, doi)
+		switch {
+		case ctx.Err() != nil:
+			return ctx.Err()
+		case errors.Is(err, ErrNotFound):
+			slog.Debug("DOI not in Crossref", "doi", doi)
+			continue
+		case err != nil:
+			slog.Warn("Crossref lookup failed", "doi", doi, "err", err)
+			continue
+		}
+		if filled := Fill(record, work); len(filled) > 0 {
+			slog.Debug("enriched from Crossref", "doi", doi, "fields", filled)
+		}
+	}
+	return nil
+}
+
+// Work fetches the works record for a DOI.
+func (c *Client) Work(ctx context.Context, doi string) (*Work, error) {
+	work := &Work{}
+	if err := c.getMessage(ctx, url.Values{}, work, "works", doi); err != nil {
+		return nil, err
+	}
+	return work, nil
+}
+
+// getMessage fetches an API path and decodes the message of its
+// {"status": "ok", "message": ...} envelope into v.
+func (c *Client) getMessage(ctx context.Context, params url.Values, v any, path ...string) error {
+	u, err := url.Parse(c.BaseURL)
+	if err != nil {
+		return fmt.Errorf("invalid base URL: %w", err)
+	}
+	u = u.JoinPath(path...)
+	if c.Mailto != "" {
+		params.Set("mailto", c.Mailto)
+	}
+	u.RawQuery = params.Encode()
+
+	body, err := c.get(ctx, u)
+	if err != nil {
+		return err
+	}
+	var resp struct {
+		Status  string          `json:"status"`
+		Message json.RawMessage `json:"message"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return fmt.Errorf("decoding Crossref response: %w", err)
+	}
+	if resp.Status != "ok" || len(resp.Message) == 0 {
+		return fmt.Errorf("unexpected Crossref response status %q", resp.Status)
+	}
+	if err := json.Unmarshal(resp.Message, v); err != nil {
+		return fmt.Errorf("decoding Crossref response: %w", err)
+	}
+	return nil
+}
+
+// get issues one request once the rate limit allows, retrying while the
+// API answers 429 or 503.
+func (c *Client) get(ctx context.Context, u *url.URL) ([]byte, error) {
+	for attempt := 0; ; attempt++ {
+		if err := c.wait(ctx); err != nil {
+			return nil, err
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", "application/json")
+		if c.UserAgent != "" {
+			req.Header.Set("User-Agent", c.UserAgent)
+		}
+
+		resp, err := c.HTTPClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("reading response: %w", err)
+		}
+		c.adjustRate(resp.Header)
+
+		switch {
+		case resp.StatusCode == http.StatusOK:
+			return body, nil
+		case resp.StatusCode == http.StatusNotFound:
+			return nil, ErrNotFound
+		case (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable) && attempt < c.Retries:
+			if err := sleep(ctx, retryAfter(resp.Header.Get("Retry-After"))); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("unexpected status %s from %s", resp.Status, u.Redacted())
+		}
+	}
+}
+
+// wait reserves the next request slot and sleeps until it comes.
+func (c *Client) wait(ctx context.Context) error {
+	c.mu.Lock()
+	now := time.Now()
+	at := c.next
+	if at.Before(now) {
+		at = now
+	}
+	c.next = at.Add(c.Interval)
+	c.mu.Unlock()
+	return sleep(ctx, time.Until(at))
+}
+
+// adjustRate lengthens Interval to honour the limit the API reports, e.g.
+// X-Rate-Limit-Limit: 5 with X-Rate-Limit-Interval: 1s. It never shortens
+// it, so a configured interval stays a floor.
+func (c *Client) adjustRate(h http.Header) {
+	limit, err := strconv.Atoi(strings.TrimSpace(h.Get("X-Rate-Limit-Limit")))
+	if err != nil || limit <= 0 {
+		return
+	}
+	window, err := time.ParseDuration(strings.TrimSpace(h.Get("X-Rate-Limit-Interval")))
+	if err != nil || window <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if d := window / time.Duration(limit); d > c.Interval {
+		c.Interval = d
+	}
+}
+
+// Fill copies values from a Crossref work into the record's empty fields
+// and returns the fields it filled.
+func Fill(record *hubv1.Record, work *Work) []string {
+	var filled []string
+
+	if record.Publisher == "" && work.Publisher != "" {
+		record.Publisher = strings.TrimSpace(work.Publisher)
+		filled = append(filled, "publisher")
+	}
+
+	if record.GetPublication().GetIssn() == "" {
+		if issn := workISSN(work); issn != "" {
+			if record.Publication == nil {
+				record.Publication = &hubv1.PublicationDetails{}
+			}
+			record.Publication.Issn = issn
+			filled = append(filled, "publication.issn")
+		}
+	}
+
+	if len(record.Rights) == 0 {
+		if uri := workLicense(work); uri != "" {
+			record.Rights = append(record.Rights, hub.NewRightsFromURI(uri))
+			filled = append(filled, "rights")
+		}
+	}
+
+	if len(record.Funders) == 0 {
+		for _, f := range work.Funder {
+			if f.Name == "" && f.DOI == "" {
+				continue
+			}
+			funder := &hubv1.Funder{Name: f.Name, AwardNumbers: f.Award}
+			if f.DOI != "" {
+				funder.Identifier = f.DOI
+				funder.IdentifierType = FunderIDType
+			}
+			record.Funders = append(record.Funders, funder)
+		}
+		if len(record.Funders) > 0 {
+			filled = append(filled, "funders")
+		}
+	}
+
+	if _, ok := hub.GetExtra(record, "references_count"); !ok && work.ReferencesCount > 0 {
+		hub.SetExtra(record, "references_count", work.ReferencesCount)
+		filled = append(filled, "extra.references_count")
+	}
+
+	return filled
+}
+
+// workISSN picks the print ISSN, then the electronic one, then any.
+func workISSN(work *Work) string {
+	for _, want := range []string{"print", "electronic"} {
+		for _, t := range work.ISSNType {
+			if t.Type == want && t.Value != "" {
+				return t.Value
+			}
+		}
+	}
+	if len(work.ISSN) > 0 {
+		return work.ISSN[0]
+	}
+	return ""
+}
+
+// workLicense picks the license of the version of record, then any license
+// other than a text-mining one, which says nothing about reading rights.
+func workLicense(work *Work) string {
+	var fallback string
+	for _, l := range work.License {
+		switch {
+		case l.URL == "":
+		case l.ContentVersion == "vor":
+			return l.URL
+		case l.ContentVersion != "tdm" && fallback == "":
+			fallback = l.URL
+		}
+	}
+	return fallback
+}
+
+// retryAfter reads a Retry-After header given in seconds, defaulting to
+// ten seconds and capping long waits at five minutes.
+func retryAfter(header string) time.Duration {
+	seconds, err := strconv.Atoi(strings.TrimSpace(header))
+	if err != nil || seconds < 0 {
+		return 10 * time.Second
+	}
+	return min(time.Duration(seconds)*time.Second, 5*time.Minute)
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
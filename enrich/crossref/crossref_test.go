@@ -0,0 +1,164 @@
+package crossref
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+const workJSON = `{"status":"ok","message-type":"work","message":{
+  "DOI":"10.1234/abcd",
+  "publisher":"Elsevier BV",
+  "ISSN":["0022-1694","1879-2707"],
+  "issn-type":[{"value":"1879-2707","type":"electronic"},{"value":"0022-1694","type":"print"}],
+  "license":[
+    {"URL":"https://www.elsevier.com/tdm/userlicense/1.0/","content-version":"tdm"},
+    {"URL":"http://creativecommons.org/licenses/by/4.0/","content-version":"vor"}
+  ],
+  "funder":[{"name":"National Science Foundation","DOI":"10.13039/100000001","award":["EAR-1234567"]}],
+  "references-count":42
+}}`
+
+// api serves workJSON for 10.1234/abcd and 404 for any other DOI, after
+// answering the first request with 429 when throttle is set.
+func api(t *testing.T, throttle bool, queries *[]string) *httptest.Server {
+	t.Helper()
+	var requests atomic.Int32
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*queries = append(*queries, r.URL.Path+"?"+r.URL.RawQuery+" "+r.UserAgent())
+		if requests.Add(1) == 1 && throttle {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		if r.URL.Path != "/works/10.1234/abcd" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, workJSON)
+	}))
+}
+
+func testClient(baseURL, mailto string) *Client {
+	c := NewClient(mailto)
+	c.BaseURL = baseURL
+	c.Interval = time.Millisecond
+	return c
+}
+
+func TestEnrichFillsMissingFields(t *testing.T) {
+	var queries []string
+	srv := api(t, true, &queries)
+	defer srv.Close()
+
+	record := &hubv1.Record{
+		Title: "Sediment transport",
+		Identifiers: []*hubv1.Identifier{
+			{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_DOI, Value: "https://doi.org/10.1234/abcd"},
+		},
+	}
+	noDOI := &hubv1.Record{Title: "No DOI"}
+	if err := testClient(srv.URL, "").Enrich(context.Background(), []*hubv1.Record{record, noDOI}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(queries) != 2 {
+		t.Fatalf("expected a throttled request and its retry, got %v", queries)
+	}
+	if record.Publisher != "Elsevier BV" {
+		t.Errorf("publisher: got %q", record.Publisher)
+	}
+	if got := record.GetPublication().GetIssn(); got != "0022-1694" {
+		t.Errorf("issn: got %q, want the print ISSN", got)
+	}
+	if len(record.Rights) != 1 || record.Rights[0].Uri != "http://creativecommons.org/licenses/by/4.0/" {
+		t.Errorf("rights: got %v, want the version of record license", record.Rights)
+	}
+	if len(record.Funders) != 1 {
+		t.Fatalf("funders: got %v", record.Funders)
+	}
+	f := record.Funders[0]
+	if f.Name != "National Science Foundation" || f.Identifier != "10.13039/100000001" || f.IdentifierType != "Crossref Funder ID" || strings.Join(f.AwardNumbers, ",") != "EAR-1234567" {
+		t.Errorf("funder: got %v", f)
+	}
+	if got, _ := hub.GetExtra(record, "references_count"); got != float64(42) {
+		t.Errorf("references_count: got %v", got)
+	}
+	if noDOI.Publisher != "" || noDOI.Publication != nil {
+		t.Errorf("record without a DOI was changed: %v", noDOI)
+	}
+}
+
+func TestEnrichKeepsExistingValues(t *testing.T) {
+	var queries []string
+	srv := api(t, false, &queries)
+	defer srv.Close()
+
+	record := &hubv1.Record{
+		Publisher:   "Lehigh University",
+		Publication: &hubv1.PublicationDetails{Title: "Journal of Hydrology", Issn: "1111-2222"},
+		Rights:      []*hubv1.Rights{{Statement: "In Copyright"}},
+		Identifiers: []*hubv1.Identifier{
+			{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_DOI, Value: "10.1234/abcd"},
+		},
+	}
+	if err := testClient(srv.URL, "").Enrich(context.Background(), []*hubv1.Record{record}); err != nil {
+		t.Fatal(err)
+	}
+	if record.Publisher != "Lehigh University" || record.Publication.Issn != "1111-2222" || len(record.Rights) != 1 || record.Rights[0].Uri != "" {
+		t.Errorf("existing values were overwritten: %v", record)
+	}
+	if len(record.Funders) != 1 {
+		t.Errorf("expected the missing funders to be filled, got %v", record.Funders)
+	}
+}
+
+func TestEnrichPolitePool(t *testing.T) {
+	var queries []string
+	srv := api(t, false, &queries)
+	defer srv.Close()
+
+	record := &hubv1.Record{Identifiers: []*hubv1.Identifier{
+		{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_DOI, Value: "10.5555/datacite-only"},
+	}}
+	if err := testClient(srv.URL, "metadata@example.edu").Enrich(context.Background(), []*hubv1.Record{record}); err != nil {
+		t.Fatal(err)
+	}
+	if len(queries) != 1 {
+		t.Fatalf("expected one request, got %v", queries)
+	}
+	if !strings.Contains(queries[0], "mailto=metadata%40example.edu") || !strings.Contains(queries[0], "mailto:metadata@example.edu") {
+		t.Errorf("mailto missing from query or user agent: %s", queries[0])
+	}
+	if record.Publisher != "" {
+		t.Errorf("a DOI unknown to Crossref should leave the record alone: %v", record)
+	}
+	if NewClient("metadata@example.edu").Interval != PoliteInterval || NewClient("").Interval != PublicInterval {
+		t.Error("mailto should select the polite pool interval")
+	}
+}
+
+func TestAdjustRate(t *testing.T) {
+	c := testClient("", "")
+	h := http.Header{}
+	h.Set("X-Rate-Limit-Limit", "5")
+	h.Set("X-Rate-Limit-Interval", "1s")
+	c.adjustRate(h)
+	if c.Interval != 200*time.Millisecond {
+		t.Errorf("interval: got %v, want 200ms", c.Interval)
+	}
+
+	h.Set("X-Rate-Limit-Limit", "50")
+	c.adjustRate(h)
+	if c.Interval != 200*time.Millisecond {
+		t.Errorf("a higher limit should not shorten the interval, got %v", c.Interval)
+	}
+}
@@ -0,0 +1,166 @@
+package crossref
+
+import (
+	"context"
+	"log/slog"
+	"net/url"
+	"strings"
+	"unicode"
+
+	"github.com/lehigh-university-libraries/crosswalk/enrich"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+)
+
+// FunderIDType is the identifier type recorded for Funder Registry DOIs,
+// matching DataCite's funderIdentifierType vocabulary.
+const FunderIDType = "Crossref Funder ID"
+
+// FunderDOIPrefix is the DOI prefix of the Crossref Funder Registry.
+const FunderDOIPrefix = "10.13039/"
+
+func init() {
+	enrich.Register("crossref-funders", func(opts enrich.Options) (enrich.Enricher, error) {
+		return NewFunderReconciler(NewClient(opts.Mailto)), nil
+	})
+}
+
+// RegistryFunder is a Crossref Funder Registry entry.
+type RegistryFunder struct {
+	ID       string   `json:"id"`
+	Name     string   `json:"name"`
+	AltNames []string `json:"alt-names"`
+	Location string   `json:"location"`
+}
+
+// DOI returns the funder's Funder Registry DOI, e.g. 10.13039/100000001.
+func (f *RegistryFunder) DOI() string {
+	return FunderDOIPrefix + f.ID
+}
+
+// SearchFunders queries the Funder Registry for funders matching name.
+func (c *Client) SearchFunders(ctx context.Context, name string) ([]RegistryFunder, error) {
+	var message struct {
+		Items []RegistryFunder `json:"items"`
+	}
+	params := url.Values{"query": {name}, "rows": {"20"}}
+	if err := c.getMessage(ctx, params, &message, "funders"); err != nil {
+		return nil, err
+	}
+	return message.Items, nil
+}
+
+// FunderReconciler gives funders that have a name but no identifier their
+// Funder Registry DOI. It accepts a registry entry only when the name
+// matches its name or one of its alternate names exactly, ignoring case and
+// punctuation; close or ambiguous matches are logged and left for a person
+// to resolve.
+type FunderReconciler struct {
+	Client *Client
+
+	// matches caches lookups by normalized name; nil means no match.
+	matches map[string]*RegistryFunder
+}
+
+// NewFunderReconciler returns a reconciler that queries through c.
+func NewFunderReconciler(c *Client) *FunderReconciler {
+	return &FunderReconciler{Client: c, matches: make(map[string]*RegistryFunder)}
+}
+
+// Enrich reconciles each record's unidentified funders. A failed lookup is
+// logged as a warning and skipped; only a cancelled context ends the run.
+func (r *FunderReconciler) Enrich(ctx context.Context, records []*hubv1.Record) error {
+	for _, record := range records {
+		for _, funder := range record.Funders {
+			if funder.Identifier != "" || strings.TrimSpace(funder.Name) == "" {
+				continue
+			}
+			match, err := r.Match(ctx, funder.Name)
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if err != nil {
+				slog.Warn("Funder Registry lookup failed", "funder", funder.Name, "err", err)
+				continue
+			}
+			if match == nil {
+				continue
+			}
+			funder.Identifier = match.DOI()
+			funder.IdentifierType = FunderIDType
+			slog.Debug("reconciled funder", "funder", funder.Name, "registry_name", match.Name, "doi", funder.Identifier)
+		}
+	}
+	return nil
+}
+
+// Match returns the registry funder whose name or alternate name is name,
+// or nil when there is none or more than one.
+func (r *FunderReconciler) Match(ctx context.Context, name string) (*RegistryFunder, error) {
+	key := normalizeFunderName(name)
+	if match, ok := r.matches[key]; ok {
+		return match, nil
+	}
+
+	candidates, err := r.Client.SearchFunders(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	var match *RegistryFunder
+	ambiguous := false
+	for i := range candidates {
+		c := &candidates[i]
+		if !funderNameMatches(key, c) {
+			continue
+		}
+		if match != nil && match.ID != c.ID {
+			ambiguous = true
+			break
+		}
+		match = c
+	}
+
+	switch {
+	case ambiguous:
+		slog.Warn("funder name matches more than one Funder Registry entry", "funder", name)
+		match = nil
+	case match == nil:
+		slog.Warn("no Funder Registry entry matches funder name", "funder", name)
+	}
+	r.matches[key] = match
+	return match, nil
+}
+
+func funderNameMatches(key string, f *RegistryFunder) bool {
+	if normalizeFunderName(f.Name) == key {
+		return true
+	}
+	for _, alt := range f.AltNames {
+		if normalizeFunderName(alt) == key {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeFunderName lowercases a name and reduces punctuation and runs
+// of space to single spaces, so "U.S. Department of Energy" matches
+// "US Department of Energy" and a leading "The" is ignored.
+func normalizeFunderName(name string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r == '.' || r == '\'' || r == '’':
+			// Dropped so initialisms and possessives compare equal
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if space && b.Len() > 0 {
+				b.WriteByte(' ')
+			}
+			space = false
+			b.WriteRune(r)
+		default:
+			space = true
+		}
+	}
+	return strings.TrimPrefix(b.String(), "the ")
+}
@@ -0,0 +1,75 @@
+package crossref
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+)
+
+const fundersJSON = `{"status":"ok","message-type":"funder-list","message":{"items":[
+  {"id":"100000001","name":"National Science Foundation","alt-names":["NSF","US National Science Foundation"],"location":"United States"},
+  {"id":"501100001711","name":"Schweizerischer Nationalfonds zur Förderung der Wissenschaftlichen Forschung","alt-names":["Swiss National Science Foundation","SNSF"],"location":"Switzerland"},
+  {"id":"100000015","name":"U.S. Department of Energy","alt-names":["DOE"],"location":"United States"},
+  {"id":"501100000038","name":"Natural Sciences and Engineering Research Council of Canada","alt-names":["NSF"],"location":"Canada"}
+]}}`
+
+func TestReconcileFunders(t *testing.T) {
+	searches := map[string]int{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/funders" {
+			http.NotFound(w, r)
+			return
+		}
+		searches[r.URL.Query().Get("query")]++
+		fmt.Fprint(w, fundersJSON)
+	}))
+	defer srv.Close()
+
+	funders := []*hubv1.Funder{
+		{Name: "National Science Foundation", AwardNumbers: []string{"EAR-1234567"}},
+		{Name: "US Department of Energy"},
+		{Name: "NSF"},                    // two registry entries use this alternate name
+		{Name: "Lehigh Valley Research"}, // no match
+		{Name: "Existing", Identifier: "https://ror.org/021nxhr62", IdentifierType: "ROR"},
+	}
+	records := []*hubv1.Record{
+		{Funders: funders},
+		{Funders: []*hubv1.Funder{{Name: "national science foundation"}}},
+	}
+	if err := NewFunderReconciler(testClient(srv.URL, "")).Enrich(context.Background(), records); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"10.13039/100000001", "10.13039/100000015", "", "", "https://ror.org/021nxhr62"}
+	for i, f := range funders {
+		if f.Identifier != want[i] {
+			t.Errorf("%s: got identifier %q, want %q", f.Name, f.Identifier, want[i])
+		}
+	}
+	if funders[0].IdentifierType != FunderIDType || funders[4].IdentifierType != "ROR" {
+		t.Errorf("identifier types: got %q and %q", funders[0].IdentifierType, funders[4].IdentifierType)
+	}
+	if got := records[1].Funders[0].Identifier; got != "10.13039/100000001" {
+		t.Errorf("second record: got %q", got)
+	}
+	if searches["national science foundation"] != 0 || searches["National Science Foundation"] != 1 {
+		t.Errorf("expected names differing only in case to share one lookup, got %v", searches)
+	}
+}
+
+func TestNormalizeFunderName(t *testing.T) {
+	for in, want := range map[string]string{
+		"U.S. Department of Energy":              "us department of energy",
+		"The Andrew W. Mellon Foundation":        "andrew w mellon foundation",
+		"Institute of Museum & Library Services": "institute of museum library services",
+		"  Wellcome   Trust ":                    "wellcome trust",
+	} {
+		if got := normalizeFunderName(in); got != want {
+			t.Errorf("normalizeFunderName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+// Package enrich fills gaps in parsed hub records from external services,
+// such as the Crossref REST API. Enrichers run between parsing and
+// serialization and only add values a record is missing; they never
+// overwrite what the source said.
+package enrich
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+)
+
+// Enricher adds missing metadata to hub records in place.
+type Enricher interface {
+	// Enrich fills what it can in each record. A lookup that fails for
+	// one record is logged and skipped; an error ends the run.
+	Enrich(ctx context.Context, records []*hubv1.Record) error
+}
+
+// Options configure an enricher built by name.
+type Options struct {
+	// Mailto is a contact address sent to services that offer better
+	// service to identified clients, like Crossref's polite pool.
+	Mailto string
+}
+
+// Factory builds an enricher.
+type Factory func(opts Options) (Enricher, error)
+
+var (
+	mu        sync.RWMutex
+	factories = make(map[string]Factory)
+)
+
+// Register makes an enricher available by name, replacing any enricher
+// already registered under it.
+func Register(name string, f Factory) {
+	mu.Lock()
+	defer mu.Unlock()
+	factories[strings.ToLower(name)] = f
+}
+
+// New builds the enricher registered under name.
+func New(name string, opts Options) (Enricher, error) {
+	mu.RLock()
+	f, ok := factories[strings.ToLower(name)]
+	mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown enricher %q (available: %s)", name, strings.Join(Names(), ", "))
+	}
+	return f(opts)
+}
+
+// Names lists the registered enrichers in sorted order.
+func Names() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	names := make([]string, 0, len(factories))
+	for name := range factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
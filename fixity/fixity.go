@@ -0,0 +1,252 @@
+// Package fixity checks the files hub records refer to against the
+// checksums and sizes the records give for them. Local files are read
+// from disk and remote ones fetched over HTTP; a file with no checksum is
+// only checked to exist, and for its size when the record has one.
+package fixity
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+// Statuses of a checked file.
+const (
+	// StatusOK means every checksum, and the size, matched.
+	StatusOK = "ok"
+	// StatusPresent means the file exists but the record gives no checksum
+	// to verify it against.
+	StatusPresent = "present"
+	// StatusMismatch means a checksum or the size did not match.
+	StatusMismatch = "mismatch"
+	// StatusMissing means the file could not be opened or fetched.
+	StatusMissing = "missing"
+	// StatusUnsupported means the record's only checksums use algorithms
+	// this package can't compute.
+	StatusUnsupported = "unsupported"
+)
+
+// algorithms are the checksum algorithms verified, by hub name.
+var algorithms = map[string]func() hash.Hash{
+	"md5":    md5.New,
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+	"sha512": sha512.New,
+}
+
+// Result is the outcome of checking one file.
+type Result struct {
+	Record int    `json:"record"` // Index of the record in the batch
+	Title  string `json:"title,omitempty"`
+	Path   string `json:"path"`
+	Status string `json:"status"`
+	// Mismatches lists each value that differed, e.g.
+	// "sha256: expected 9f86…, got 2c26…" or "size: expected 10, got 12".
+	Mismatches []string `json:"mismatches,omitempty"`
+	Error      string   `json:"error,omitempty"`
+}
+
+// OK reports whether the file was found and nothing about it contradicts
+// the record.
+func (r Result) OK() bool {
+	return r.Status == StatusOK || r.Status == StatusPresent
+}
+
+// Verifier checks files. Relative local paths are resolved against
+// BaseDir, as Islandora Workbench resolves them against its input_dir.
+type Verifier struct {
+	BaseDir    string
+	HTTPClient *http.Client
+	UserAgent  string
+	// Workers is the number of files checked at once (default 1).
+	Workers int
+}
+
+// NewVerifier returns a verifier resolving relative paths against baseDir.
+func NewVerifier(baseDir string) *Verifier {
+	return &Verifier{
+		BaseDir:    baseDir,
+		HTTPClient: &http.Client{Timeout: 10 * time.Minute},
+		UserAgent:  "crosswalk-verify-files (https://github.com/lehigh-university-libraries/crosswalk)",
+		Workers:    1,
+	}
+}
+
+// VerifyRecords checks every file of every record, returning a result per
+// file in record order. Files without a path (physical carriers) are
+// skipped.
+func (v *Verifier) VerifyRecords(ctx context.Context, records []*hubv1.Record) []Result {
+	type job struct {
+		record int
+		title  string
+		file   *hubv1.File
+	}
+	var jobs []job
+	for i, r := range records {
+		for _, f := range r.Files {
+			if f.Path != "" {
+				jobs = append(jobs, job{i, r.Title, f})
+			}
+		}
+	}
+
+	results := make([]Result, len(jobs))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for range max(v.Workers, 1) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				res := v.Verify(ctx, jobs[i].file)
+				res.Record, res.Title = jobs[i].record, jobs[i].title
+				results[i] = res
+			}
+		}()
+	}
+	for i := range jobs {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+	return results
+}
+
+// Verify checks one file, computing all of its supported checksums in a
+// single read.
+func (v *Verifier) Verify(ctx context.Context, f *hubv1.File) Result {
+	res := Result{Path: f.Path}
+
+	hashes := make(map[string]hash.Hash)
+	for _, c := range f.Checksums {
+		alg := hub.NormalizeChecksumAlgorithm(c.Algorithm)
+		if newHash, ok := algorithms[alg]; ok && c.Value != "" {
+			hashes[alg] = newHash()
+		}
+	}
+
+	body, err := v.open(ctx, f.Path, len(hashes) == 0)
+	if err != nil {
+		res.Status, res.Error = StatusMissing, err.Error()
+		return res
+	}
+	defer body.Close()
+
+	writers := make([]io.Writer, 0, len(hashes))
+	for _, h := range hashes {
+		writers = append(writers, h)
+	}
+	size, err := io.Copy(io.MultiWriter(writers...), body)
+	if err != nil {
+		res.Status, res.Error = StatusMissing, fmt.Sprintf("reading: %v", err)
+		return res
+	}
+	if body.sized {
+		size = body.size
+	}
+
+	if f.SizeBytes > 0 && size >= 0 && size != f.SizeBytes {
+		res.Mismatches = append(res.Mismatches, fmt.Sprintf("size: expected %d, got %d", f.SizeBytes, size))
+	}
+	for _, c := range f.Checksums {
+		alg := hub.NormalizeChecksumAlgorithm(c.Algorithm)
+		h, ok := hashes[alg]
+		if !ok {
+			continue
+		}
+		got := hex.EncodeToString(h.Sum(nil))
+		if want := strings.ToLower(strings.TrimSpace(c.Value)); got != want {
+			res.Mismatches = append(res.Mismatches, fmt.Sprintf("%s: expected %s, got %s", alg, want, got))
+		}
+	}
+
+	switch {
+	case len(res.Mismatches) > 0:
+		res.Status = StatusMismatch
+	case len(hashes) > 0:
+		res.Status = StatusOK
+	case len(f.Checksums) > 0:
+		res.Status = StatusUnsupported
+		res.Error = "no supported checksum algorithm (want md5, sha1, sha256, or sha512)"
+	default:
+		res.Status = StatusPresent
+	}
+	return res
+}
+
+// content is an opened file. When sized, nothing is read and size is the
+// length the file system or server reported, -1 if it reported none.
+type content struct {
+	io.Reader
+	close func() error
+	sized bool
+	size  int64
+}
+
+func (c content) Close() error { return c.close() }
+
+// open opens a local or remote file. With statOnly, nothing is read: a
+// local file is stat'd and a remote one requested with HEAD.
+func (v *Verifier) open(ctx context.Context, p string, statOnly bool) (content, error) {
+	if strings.HasPrefix(p, "http://") || strings.HasPrefix(p, "https://") {
+		return v.fetch(ctx, p, statOnly)
+	}
+	p = strings.TrimPrefix(p, "file://")
+	if !filepath.IsAbs(p) && v.BaseDir != "" {
+		p = filepath.Join(v.BaseDir, p)
+	}
+	if statOnly {
+		info, err := os.Stat(p)
+		if err != nil {
+			return content{}, err
+		}
+		if info.IsDir() {
+			return content{}, fmt.Errorf("%s is a directory", p)
+		}
+		return content{Reader: strings.NewReader(""), close: func() error { return nil }, sized: true, size: info.Size()}, nil
+	}
+	file, err := os.Open(p)
+	if err != nil {
+		return content{}, err
+	}
+	return content{Reader: file, close: file.Close}, nil
+}
+
+// fetch requests a remote file.
+func (v *Verifier) fetch(ctx context.Context, url string, head bool) (content, error) {
+	method := http.MethodGet
+	if head {
+		method = http.MethodHead
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return content{}, err
+	}
+	if v.UserAgent != "" {
+		req.Header.Set("User-Agent", v.UserAgent)
+	}
+	resp, err := v.HTTPClient.Do(req)
+	if err != nil {
+		return content{}, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return content{}, fmt.Errorf("%s returned %s", url, resp.Status)
+	}
+	return content{Reader: resp.Body, close: resp.Body.Close, sized: head, size: resp.ContentLength}, nil
+}
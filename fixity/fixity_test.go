@@ -0,0 +1,126 @@
+package fixity
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+// Digests of "hello\n"
+const (
+	helloMD5    = "b1946ac92492d2347c6235b4d2611184"
+	helloSHA256 = "5891b5b522d5df086d0ff0b110fbd9d21bb4fc7163af34d08286a2e846f6be03"
+)
+
+func file(path string, size int64, sums ...string) *hubv1.File {
+	f := &hubv1.File{Path: path, SizeBytes: size}
+	for i := 0; i+1 < len(sums); i += 2 {
+		hub.SetChecksum(f, sums[i], sums[i+1])
+	}
+	return f
+}
+
+func TestVerifyLocal(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "hello.txt"), []byte("hello\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	v := NewVerifier(dir)
+	abs := filepath.Join(dir, "hello.txt")
+
+	tests := []struct {
+		name     string
+		file     *hubv1.File
+		status   string
+		mismatch string
+	}{
+		{"matching md5 and sha256", file("hello.txt", 6, "MD5", helloMD5, "SHA-256", strings.ToUpper(helloSHA256)), StatusOK, ""},
+		{"absolute path", file(abs, 0, "md5", helloMD5), StatusOK, ""},
+		{"file URL", file("file://"+abs, 0, "md5", helloMD5), StatusOK, ""},
+		{"wrong sha256", file("hello.txt", 0, "md5", helloMD5, "sha256", strings.Repeat("0", 64)), StatusMismatch, "sha256: expected 0000"},
+		{"wrong size", file("hello.txt", 7, "md5", helloMD5), StatusMismatch, "size: expected 7, got 6"},
+		{"no checksum, right size", file("hello.txt", 6), StatusPresent, ""},
+		{"no checksum, wrong size", file("hello.txt", 5), StatusMismatch, "size: expected 5, got 6"},
+		{"unknown algorithm", file("hello.txt", 0, "crc32", "363a3020"), StatusUnsupported, ""},
+		{"missing", file("gone.txt", 0, "md5", helloMD5), StatusMissing, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := v.Verify(context.Background(), tt.file)
+			if res.Status != tt.status {
+				t.Fatalf("status %q, want %q (%v %s)", res.Status, tt.status, res.Mismatches, res.Error)
+			}
+			if tt.mismatch != "" && (len(res.Mismatches) != 1 || !strings.HasPrefix(res.Mismatches[0], tt.mismatch)) {
+				t.Errorf("mismatches %q, want %q", res.Mismatches, tt.mismatch)
+			}
+		})
+	}
+}
+
+func TestVerifyRemote(t *testing.T) {
+	var methods []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		if r.URL.Path != "/hello.txt" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte("hello\n"))
+	}))
+	t.Cleanup(srv.Close)
+	v := NewVerifier("")
+
+	if res := v.Verify(context.Background(), file(srv.URL+"/hello.txt", 6, "sha256", helloSHA256)); res.Status != StatusOK {
+		t.Errorf("GET: got %+v", res)
+	}
+	if res := v.Verify(context.Background(), file(srv.URL+"/hello.txt", 6)); res.Status != StatusPresent {
+		t.Errorf("HEAD: got %+v", res)
+	}
+	if res := v.Verify(context.Background(), file(srv.URL+"/gone.txt", 0)); res.Status != StatusMissing || !strings.Contains(res.Error, "404") {
+		t.Errorf("not found: got %+v", res)
+	}
+	if got := strings.Join(methods, ","); got != "GET,HEAD,HEAD" {
+		t.Errorf("requests %s; files without checksums should only be HEAD", got)
+	}
+}
+
+func TestVerifyRecords(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.txt", "b.txt", "c.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("hello\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	records := []*hubv1.Record{
+		{Title: "First", Files: []*hubv1.File{file("a.txt", 0, "md5", helloMD5), {Name: "Reel 1"}}},
+		{Title: "Second", Files: []*hubv1.File{file("b.txt", 0, "md5", helloSHA256[:32]), file("c.txt", 0)}},
+	}
+	v := NewVerifier(dir)
+	v.Workers = 3
+	results := v.VerifyRecords(context.Background(), records)
+
+	want := []struct {
+		record int
+		path   string
+		status string
+	}{{0, "a.txt", StatusOK}, {1, "b.txt", StatusMismatch}, {1, "c.txt", StatusPresent}}
+	if len(results) != len(want) {
+		t.Fatalf("got %d results, want %d", len(results), len(want))
+	}
+	for i, w := range want {
+		r := results[i]
+		if r.Record != w.record || r.Path != w.path || r.Status != w.status {
+			t.Errorf("result %d: got record %d %s %s, want record %d %s %s", i, r.Record, r.Path, r.Status, w.record, w.path, w.status)
+		}
+	}
+	if results[1].OK() || !results[2].OK() {
+		t.Error("OK: want false for a mismatch and true for a present file")
+	}
+}
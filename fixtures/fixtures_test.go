@@ -0,0 +1,131 @@
+package fixtures
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func readSample(t *testing.T, source string) []byte {
+	t.Helper()
+	b, err := os.ReadFile(filepath.Join("testdata", source+".xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestClean(t *testing.T) {
+	tests := []struct {
+		source string
+		want   []string
+		gone   []string
+	}{
+		{
+			source: "arxiv",
+			want: []string{
+				`<link href="https://arxiv.org/api/query" rel="self"`,
+				"\n  <id>https://arxiv.org/api/fixture</id>\n",
+				"\n  <updated>" + Timestamp + "</updated>\n",
+				// Entry values are metadata and stay as they are
+				"<id>http://arxiv.org/abs/math/0301001v1</id>",
+				"<updated>2003-01-01T07:44:13Z</updated>",
+			},
+			gone: []string{"5c2O8oLJm4P8gYKn3dvTpLPJ0ZY", "2025-11-17", "max_results%3D10"},
+		},
+		{
+			source: "crossref",
+			want: []string{
+				`<crm-item name="last-update" type="date">` + Timestamp + `</crm-item>`,
+				`<crm-item name="citedby-count" type="number">0</crm-item>`,
+				"<query_result>\n<head/>",
+				`<crm-item name="publisher-name" type="string">American Psychological Association (APA)</crm-item>`,
+			},
+			gone: []string{"2025-10-04", "187", "doi_batch_id"},
+		},
+		{
+			source: "datacite",
+			want:   []string{`<date dateType="Updated">2016-09-19</date>`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			source, ok := Sources[tt.source]
+			if !ok {
+				t.Fatalf("no source %q", tt.source)
+			}
+			got := string(source.Clean(readSample(t, tt.source)))
+			for _, w := range tt.want {
+				if !strings.Contains(got, w) {
+					t.Errorf("expected %q in:\n%s", w, got)
+				}
+			}
+			for _, g := range tt.gone {
+				if strings.Contains(got, g) {
+					t.Errorf("expected %q to be replaced in:\n%s", g, got)
+				}
+			}
+			if !strings.HasSuffix(got, ">\n") || strings.HasSuffix(got, "\n\n") {
+				t.Errorf("expected exactly one trailing newline, got %q", got[len(got)-3:])
+			}
+			if again := string(source.Clean([]byte(got))); again != got {
+				t.Error("cleaning a cleaned fixture changed it")
+			}
+		})
+	}
+}
+
+func TestFetch(t *testing.T) {
+	var gotPath, gotAccept string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotAccept = r.URL.EscapedPath(), r.Header.Get("Accept")
+		if strings.Contains(r.URL.Path, "missing") {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte("<resource/>"))
+	}))
+	defer srv.Close()
+
+	source := Source{URL: srv.URL + "/dois/%s", Accept: "application/vnd.datacite.datacite+xml"}
+	body, err := source.Fetch(srv.Client(), "10.5438/0012 a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(body) != "<resource/>" {
+		t.Errorf("body: got %q", body)
+	}
+	if gotPath != "/dois/10.5438/0012%20a" {
+		t.Errorf("path: got %q, want the slash kept and the space escaped", gotPath)
+	}
+	if gotAccept != source.Accept {
+		t.Errorf("Accept: got %q", gotAccept)
+	}
+
+	if _, err := source.Fetch(srv.Client(), "missing"); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("got %v, want an unexpected status error", err)
+	}
+}
+
+func TestFileName(t *testing.T) {
+	for id, want := range map[string]string{
+		"math/0301001": "math_0301001",
+		"10.5438/0012": "10.5438_0012",
+		"2511.11447":   "2511.11447",
+		`doi:10.1\a/b`: "doi_10.1_a_b",
+	} {
+		if got := FileName(id); got != want {
+			t.Errorf("FileName(%q): got %q, want %q", id, got, want)
+		}
+	}
+}
+
+func TestSourceNames(t *testing.T) {
+	if got := SourceNames(); !slices.Equal(got, []string{"arxiv", "crossref", "datacite"}) {
+		t.Errorf("got %v", got)
+	}
+}
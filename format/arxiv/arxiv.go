@@ -10,14 +10,24 @@ import (
 // Version is the arXiv schema version this implementation targets.
 const Version = "1.0"
 
+// Output variants, selected with SerializeOptions.Variant. VariantRecord,
+// the default, writes arXivRecord documents (XSD 1.0); VariantAtom writes
+// a feed in the arXiv API's Atom format, with arxiv:primary_category,
+// categories, and abstract and PDF links.
+const (
+	VariantRecord = "record"
+	VariantAtom   = "atom"
+)
+
 // Format implements the arXiv metadata format.
 type Format struct{}
 
 // Ensure Format implements the interfaces
 var (
-	_ format.Format     = (*Format)(nil)
-	_ format.Parser     = (*Format)(nil)
-	_ format.Serializer = (*Format)(nil)
+	_ format.Format         = (*Format)(nil)
+	_ format.Parser         = (*Format)(nil)
+	_ format.Serializer     = (*Format)(nil)
+	_ format.FieldDescriber = (*Format)(nil)
 )
 
 // Name returns the format identifier.
@@ -35,6 +45,17 @@ func (f *Format) Extensions() []string {
 	return []string{"xml"}
 }
 
+// writtenFields are the hub record fields Serialize writes.
+var writtenFields = []string{
+	"title", "abstract", "contributors", "dates", "subjects", "identifiers",
+	"notes", "files", "relations.part_of",
+}
+
+// WrittenFields returns the hub record fields Serialize writes.
+func (f *Format) WrittenFields(opts *format.SerializeOptions) []string {
+	return writtenFields
+}
+
 // CanParse returns true if the input looks like arXiv XML.
 func (f *Format) CanParse(peek []byte) bool {
 	peek = bytes.TrimSpace(peek)
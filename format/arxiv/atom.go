@@ -0,0 +1,205 @@
+package arxiv
+
+import (
+	"cmp"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	arxivv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/spoke/arxiv/v1_0"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+// Namespaces of an arXiv API response.
+const (
+	atomNamespace       = "http://www.w3.org/2005/Atom"
+	arxivAtomNamespace  = "http://arxiv.org/schemas/atom"
+	openSearchNamespace = "http://a9.com/-/spec/opensearch/1.1/"
+)
+
+// writeAtom writes records as an arXiv API query response: an Atom feed
+// with an entry per record.
+func writeAtom(w io.Writer, records []*hubv1.Record, opts *format.SerializeOptions) error {
+	feed := &XMLAtomOutFeed{
+		Xmlns:           atomNamespace,
+		XmlnsArxiv:      arxivAtomNamespace,
+		XmlnsOpenSearch: openSearchNamespace,
+		ID:              "urn:crosswalk:arxiv:" + cmp.Or(opts.OutputName, "feed"),
+		Title:           "arXiv records",
+		TotalResults:    len(records),
+		ItemsPerPage:    len(records),
+	}
+
+	for i, record := range records {
+		spokeRecord, err := hubToSpoke(record)
+		if err != nil {
+			return fmt.Errorf("converting record %d to spoke: %w", i, err)
+		}
+		entry := spokeToAtom(spokeRecord, record)
+		// RFC 3339 UTC timestamps sort as strings
+		if entry.Updated > feed.Updated {
+			feed.Updated = entry.Updated
+		}
+		feed.Entries = append(feed.Entries, entry)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	if opts.Pretty {
+		encoder.Indent("", "  ")
+	}
+	if err := encoder.Encode(feed); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// spokeToAtom converts a spoke record to an Atom entry. The record supplies
+// what the arXivRecord has no place for: its landing page, PDF, and last
+// modified date.
+func spokeToAtom(spoke *arxivv1.Record, record *hubv1.Record) *XMLAtomOutEntry {
+	entry := &XMLAtomOutEntry{
+		Published: spoke.Date,
+		Updated:   spoke.Date,
+		Title:     spoke.Title,
+		Summary:   strings.Join(spoke.Abstract, "\n\n"),
+		Comment:   strings.Join(spoke.Comments, "; "),
+		PrimaryCategory: &XMLAtomCategory{
+			Term:   spoke.Primary,
+			Scheme: arxivAtomNamespace,
+		},
+	}
+	if modified := hub.GetDate(record, hubv1.DateType_DATE_TYPE_MODIFIED); modified != nil && modified.Year > 0 {
+		entry.Updated = formatArxivDate(modified)
+	}
+
+	// arXiv entries are identified by their versioned abstract page; works
+	// from elsewhere by their own landing page
+	var absURL, pdfURL string
+	if hasArxivID(record) {
+		absURL = "http://arxiv.org/abs/" + spoke.Identifier
+		pdfURL = "http://arxiv.org/pdf/" + spoke.Identifier
+		entry.ID = absURL + "v" + strconv.Itoa(int(spoke.Version))
+	}
+	landing := landingPage(record)
+	entry.ID = cmp.Or(entry.ID, landing, "http://arxiv.org/abs/"+spoke.Identifier)
+	pdfURL = cmp.Or(hub.GetExtraString(record, "pdf_url"), pdfFile(record), pdfURL)
+
+	if href := cmp.Or(landing, absURL); href != "" {
+		entry.Links = append(entry.Links, XMLAtomLink{Href: href, Rel: "alternate", Type: "text/html"})
+	}
+	if pdfURL != "" {
+		entry.Links = append(entry.Links, XMLAtomLink{Href: pdfURL, Rel: "related", Type: "application/pdf", Title: "pdf"})
+	}
+
+	// Authors, with the institutions their affiliation references name
+	if spoke.Authorship != nil {
+		institutions := make(map[string]string)
+		for _, aff := range spoke.Authorship.Affiliations {
+			institutions[strconv.Itoa(int(aff.Affid))] = aff.Institution
+		}
+		for _, a := range spoke.Authorship.Authors {
+			name := strings.TrimSpace(a.Beforekey + " " + a.Keyname)
+			if a.Afterkey != "" {
+				name += " " + a.Afterkey
+			}
+			author := XMLAtomOutAuthor{Name: name}
+			if inst := institutions[a.Affref]; inst != "" {
+				author.Affiliation = append(author.Affiliation, inst)
+			}
+			entry.Authors = append(entry.Authors, author)
+		}
+	}
+
+	if alt := spoke.Alternate; alt != nil {
+		if len(alt.Doi) > 0 {
+			entry.DOI = alt.Doi[0]
+			entry.Links = append(entry.Links, XMLAtomLink{Href: "https://doi.org/" + alt.Doi[0], Rel: "related", Title: "doi"})
+		}
+		entry.JournalRef = strings.Join(alt.JournalRef, "; ")
+	}
+
+	// The primary category is listed among the categories too
+	for _, term := range append([]string{spoke.Primary}, spoke.Cross...) {
+		entry.Categories = append(entry.Categories, XMLAtomCategory{Term: term, Scheme: arxivAtomNamespace})
+	}
+
+	return entry
+}
+
+// hasArxivID reports whether the record has an arXiv identifier.
+func hasArxivID(record *hubv1.Record) bool {
+	for _, id := range record.Identifiers {
+		if id.Type == hubv1.IdentifierType_IDENTIFIER_TYPE_ARXIV && id.Value != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// landingPage returns the record's first URL identifier.
+func landingPage(record *hubv1.Record) string {
+	for _, id := range record.Identifiers {
+		if id.Type == hubv1.IdentifierType_IDENTIFIER_TYPE_URL {
+			return id.Value
+		}
+	}
+	return ""
+}
+
+// pdfFile returns the URL of the record's PDF file, if it has one online.
+func pdfFile(record *hubv1.Record) string {
+	for _, f := range record.Files {
+		if f.MimeType == "application/pdf" && (strings.HasPrefix(f.Path, "http://") || strings.HasPrefix(f.Path, "https://")) {
+			return f.Path
+		}
+	}
+	return ""
+}
+
+// XML types for writing the Atom feed. The arXiv extension elements carry
+// the prefix the feed declares.
+
+// XMLAtomOutFeed is an arXiv API response feed.
+type XMLAtomOutFeed struct {
+	XMLName         xml.Name           `xml:"feed"`
+	Xmlns           string             `xml:"xmlns,attr"`
+	XmlnsArxiv      string             `xml:"xmlns:arxiv,attr"`
+	XmlnsOpenSearch string             `xml:"xmlns:opensearch,attr"`
+	ID              string             `xml:"id"`
+	Title           string             `xml:"title"`
+	Updated         string             `xml:"updated"`
+	TotalResults    int                `xml:"opensearch:totalResults"`
+	StartIndex      int                `xml:"opensearch:startIndex"`
+	ItemsPerPage    int                `xml:"opensearch:itemsPerPage"`
+	Entries         []*XMLAtomOutEntry `xml:"entry"`
+}
+
+// XMLAtomOutEntry is one work in the feed.
+type XMLAtomOutEntry struct {
+	ID              string             `xml:"id"`
+	Updated         string             `xml:"updated"`
+	Published       string             `xml:"published"`
+	Title           string             `xml:"title"`
+	Summary         string             `xml:"summary"`
+	Authors         []XMLAtomOutAuthor `xml:"author"`
+	DOI             string             `xml:"arxiv:doi,omitempty"`
+	Comment         string             `xml:"arxiv:comment,omitempty"`
+	JournalRef      string             `xml:"arxiv:journal_ref,omitempty"`
+	Links           []XMLAtomLink      `xml:"link"`
+	PrimaryCategory *XMLAtomCategory   `xml:"arxiv:primary_category"`
+	Categories      []XMLAtomCategory  `xml:"category"`
+}
+
+// XMLAtomOutAuthor is an entry author.
+type XMLAtomOutAuthor struct {
+	Name        string   `xml:"name"`
+	Affiliation []string `xml:"arxiv:affiliation,omitempty"`
+}
@@ -1,5 +1,6 @@
-// gen-categories fetches the arXiv category taxonomy page and generates
-// a Go source file mapping category codes to human-readable labels.
+// gen-categories fetches the arXiv category taxonomy page and writes the
+// JSON file, embedded in the arxiv package, mapping category codes to
+// human-readable labels.
 //
 // Usage:
 //
@@ -8,19 +9,19 @@
 package main
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"regexp"
-	"sort"
-	"strings"
 	"time"
 )
 
 const (
 	taxonomyURL = "https://arxiv.org/category_taxonomy"
-	outputFile  = "format/arxiv/categories.go"
+	outputFile  = "format/arxiv/categories.json"
 )
 
 var categoryRe = regexp.MustCompile(`<h4>([a-zA-Z\-]+(?:\.[a-zA-Z\-]+)?) <span>\(([^)]+)\)</span></h4>`)
@@ -50,7 +51,7 @@ func main() {
 		os.Exit(1)
 	}
 
-	if err := writeGoFile(cats); err != nil {
+	if err := writeJSONFile(cats); err != nil {
 		fmt.Fprintf(os.Stderr, "writing output: %v\n", err)
 		os.Exit(1)
 	}
@@ -80,28 +81,14 @@ func extractCategories(html string) map[string]string {
 	return cats
 }
 
-func writeGoFile(cats map[string]string) error {
-	keys := make([]string, 0, len(cats))
-	for k := range cats {
-		keys = append(keys, k)
+func writeJSONFile(cats map[string]string) error {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	// Maps encode with their keys sorted, so regenerating gives small diffs
+	if err := enc.Encode(cats); err != nil {
+		return err
 	}
-	sort.Strings(keys)
-
-	var b strings.Builder
-	b.WriteString("// Code generated by gen-categories; DO NOT EDIT.\n")
-	b.WriteString("//go:generate go run ./cmd/gen-categories\n\n")
-	b.WriteString("package arxiv\n\n")
-	b.WriteString("// categoryLabels maps arXiv category codes to human-readable labels.\n")
-	b.WriteString("var categoryLabels = map[string]string{\n")
-	for _, k := range keys {
-		fmt.Fprintf(&b, "\t%q: %q,\n", k, cats[k])
-	}
-	b.WriteString("}\n\n")
-	b.WriteString("// CategoryLabel returns the human-readable label for an arXiv category code.\n")
-	b.WriteString("// Returns an empty string if the code is unknown.\n")
-	b.WriteString("func CategoryLabel(code string) string {\n")
-	b.WriteString("\treturn categoryLabels[code]\n")
-	b.WriteString("}\n")
-
-	return os.WriteFile(outputFile, []byte(b.String()), 0o644)
+	return os.WriteFile(outputFile, b.Bytes(), 0o644)
 }
@@ -11,6 +11,7 @@ import (
 
 	"github.com/lehigh-university-libraries/crosswalk/format"
 	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/helpers"
 	"github.com/lehigh-university-libraries/crosswalk/hub"
 )
 
@@ -19,7 +20,19 @@ import (
 //   - arXivRecord (XSD 1.0 schema)
 //   - OAI-PMH arXiv format (http://arxiv.org/OAI/arXiv/)
 //   - Atom API format (http://arxiv.org/schemas/atom)
-func (f *Format) Parse(r io.Reader, _ *format.ParseOptions) ([]*hubv1.Record, error) {
+//
+// Category codes are labeled from opts.ArxivTaxonomy when it names a file,
+// and from the built-in taxonomy otherwise.
+func (f *Format) Parse(r io.Reader, opts *format.ParseOptions) ([]*hubv1.Record, error) {
+	var taxonomyFile string
+	if opts != nil {
+		taxonomyFile = opts.ArxivTaxonomy
+	}
+	t, err := taxonomy(taxonomyFile)
+	if err != nil {
+		return nil, fmt.Errorf("loading arXiv taxonomy: %w", err)
+	}
+
 	data, err := io.ReadAll(r)
 	if err != nil {
 		return nil, fmt.Errorf("reading input: %w", err)
@@ -32,14 +45,14 @@ func (f *Format) Parse(r io.Reader, _ *format.ParseOptions) ([]*hubv1.Record, er
 	// Detect which variant and dispatch
 	if bytes.Contains(data, []byte("http://arxiv.org/schemas/atom")) ||
 		(bytes.Contains(data, []byte("<feed")) && bytes.Contains(data, []byte("<entry"))) {
-		return parseAtom(data)
+		return parseAtom(data, t)
 	}
 
 	if bytes.Contains(data, []byte("http://arxiv.org/OAI/arXiv/")) {
-		return parseOAI(data)
+		return parseOAI(data, t)
 	}
 
-	return parseXSDRecord(data)
+	return parseXSDRecord(data, t)
 }
 
 // ---------------------------------------------------------------------------
@@ -76,18 +89,19 @@ type XMLAtomAuthor struct {
 
 // XMLAtomCategory represents an Atom category.
 type XMLAtomCategory struct {
-	Term string `xml:"term,attr"`
+	Term   string `xml:"term,attr"`
+	Scheme string `xml:"scheme,attr,omitempty"`
 }
 
 // XMLAtomLink represents an Atom link.
 type XMLAtomLink struct {
 	Href  string `xml:"href,attr"`
-	Rel   string `xml:"rel,attr"`
-	Type  string `xml:"type,attr"`
-	Title string `xml:"title,attr"`
+	Rel   string `xml:"rel,attr,omitempty"`
+	Type  string `xml:"type,attr,omitempty"`
+	Title string `xml:"title,attr,omitempty"`
 }
 
-func parseAtom(data []byte) ([]*hubv1.Record, error) {
+func parseAtom(data []byte, t Taxonomy) ([]*hubv1.Record, error) {
 	var feed XMLAtomFeed
 	if err := xml.Unmarshal(data, &feed); err != nil {
 		return nil, fmt.Errorf("parsing Atom XML: %w", err)
@@ -99,7 +113,7 @@ func parseAtom(data []byte) ([]*hubv1.Record, error) {
 
 	var records []*hubv1.Record
 	for i, entry := range feed.Entries {
-		record, err := atomEntryToHub(&entry)
+		record, err := atomEntryToHub(&entry, t)
 		if err != nil {
 			return nil, fmt.Errorf("converting entry %d: %w", i, err)
 		}
@@ -108,7 +122,7 @@ func parseAtom(data []byte) ([]*hubv1.Record, error) {
 	return records, nil
 }
 
-func atomEntryToHub(entry *XMLAtomEntry) (*hubv1.Record, error) {
+func atomEntryToHub(entry *XMLAtomEntry, t Taxonomy) (*hubv1.Record, error) {
 	record := &hubv1.Record{
 		Title: strings.TrimSpace(entry.Title),
 		ResourceType: &hubv1.ResourceType{
@@ -134,7 +148,7 @@ func atomEntryToHub(entry *XMLAtomEntry) (*hubv1.Record, error) {
 	// Categories as subjects; first is primary
 	for _, cat := range entry.Categories {
 		if cat.Term != "" {
-			record.Subjects = append(record.Subjects, enrichSubject(cat.Term))
+			record.Subjects = append(record.Subjects, enrichSubject(cat.Term, t))
 		}
 	}
 
@@ -155,7 +169,11 @@ func atomEntryToHub(entry *XMLAtomEntry) (*hubv1.Record, error) {
 			Role:       "author",
 			Type:       hubv1.ContributorType_CONTRIBUTOR_TYPE_PERSON,
 			Name:       name,
-			ParsedName: parseFullName(name),
+			ParsedName: helpers.ParseName(name),
+		}
+		if c.ParsedName == nil {
+			// Collaborations ("ATLAS Collaboration") are listed as authors
+			c.Type = hubv1.ContributorType_CONTRIBUTOR_TYPE_ORGANIZATION
 		}
 		for _, aff := range author.Affiliation {
 			if aff != "" {
@@ -223,24 +241,6 @@ func extractArxivID(rawURL string) string {
 	return strings.TrimSpace(id)
 }
 
-// parseFullName splits "Given Family" into parsed name components.
-func parseFullName(name string) *hubv1.ParsedName {
-	parts := strings.Fields(name)
-	pn := &hubv1.ParsedName{FullName: name}
-	switch len(parts) {
-	case 0:
-		// nothing
-	case 1:
-		pn.Family = parts[0]
-		pn.Normalized = parts[0]
-	default:
-		pn.Family = parts[len(parts)-1]
-		pn.Given = strings.Join(parts[:len(parts)-1], " ")
-		pn.Normalized = pn.Family + ", " + pn.Given
-	}
-	return pn
-}
-
 // ---------------------------------------------------------------------------
 // OAI-PMH format: <arXiv xmlns="http://arxiv.org/OAI/arXiv/">
 // ---------------------------------------------------------------------------
@@ -262,6 +262,9 @@ type XMLOAIArXiv struct {
 	License    string        `xml:"license"`
 	Abstract   string        `xml:"abstract"`
 	Proxy      string        `xml:"proxy"`
+
+	// Header is the enclosing OAI-PMH record header, if any
+	Header *format.OAIHeader `xml:"-"`
 }
 
 // XMLOAIAuthors is a wrapper for OAI author elements.
@@ -277,9 +280,10 @@ type XMLOAIAuthor struct {
 	Affiliations []string `xml:"affiliation"`
 }
 
-func parseOAI(data []byte) ([]*hubv1.Record, error) {
+func parseOAI(data []byte, t Taxonomy) ([]*hubv1.Record, error) {
 	decoder := xml.NewDecoder(bytes.NewReader(data))
 	var oaiRecords []*XMLOAIArXiv
+	var header *format.OAIHeader
 
 	for {
 		tok, err := decoder.Token()
@@ -293,13 +297,20 @@ func parseOAI(data []byte) ([]*hubv1.Record, error) {
 		if !ok {
 			continue
 		}
-		// Match <arXiv> element (local name only, namespace varies)
-		if start.Name.Local == "arXiv" {
-			var rec XMLOAIArXiv
+		// Match <header> and <arXiv> elements (local name only, namespace varies)
+		switch start.Name.Local {
+		case "header":
+			header = &format.OAIHeader{}
+			if err := decoder.DecodeElement(header, &start); err != nil {
+				return nil, fmt.Errorf("decoding OAI header: %w", err)
+			}
+		case "arXiv":
+			rec := XMLOAIArXiv{Header: header}
 			if err := decoder.DecodeElement(&rec, &start); err != nil {
 				return nil, fmt.Errorf("decoding arXiv OAI element: %w", err)
 			}
 			oaiRecords = append(oaiRecords, &rec)
+			header = nil
 		}
 	}
 
@@ -309,7 +320,7 @@ func parseOAI(data []byte) ([]*hubv1.Record, error) {
 
 	var records []*hubv1.Record
 	for i, oai := range oaiRecords {
-		record, err := oaiToHub(oai)
+		record, err := oaiToHub(oai, t)
 		if err != nil {
 			return nil, fmt.Errorf("converting OAI record %d: %w", i, err)
 		}
@@ -318,7 +329,7 @@ func parseOAI(data []byte) ([]*hubv1.Record, error) {
 	return records, nil
 }
 
-func oaiToHub(oai *XMLOAIArXiv) (*hubv1.Record, error) {
+func oaiToHub(oai *XMLOAIArXiv, t Taxonomy) (*hubv1.Record, error) {
 	record := &hubv1.Record{
 		Title: strings.TrimSpace(oai.Title),
 		ResourceType: &hubv1.ResourceType{
@@ -338,7 +349,7 @@ func oaiToHub(oai *XMLOAIArXiv) (*hubv1.Record, error) {
 	// Categories (space-separated, first is primary)
 	if oai.Categories != "" {
 		for _, cat := range strings.Fields(oai.Categories) {
-			record.Subjects = append(record.Subjects, enrichSubject(cat))
+			record.Subjects = append(record.Subjects, enrichSubject(cat, t))
 		}
 	}
 
@@ -448,6 +459,7 @@ func oaiToHub(oai *XMLOAIArXiv) (*hubv1.Record, error) {
 		Format:        "arxiv",
 		FormatVersion: Version,
 		SourceId:      oai.ID,
+		Oai:           oai.Header.ToHub(),
 	}
 
 	return record, nil
@@ -471,7 +483,7 @@ func splitClassification(s string) []string {
 // arXivRecord XSD 1.0 format (original schema)
 // ---------------------------------------------------------------------------
 
-func parseXSDRecord(data []byte) ([]*hubv1.Record, error) {
+func parseXSDRecord(data []byte, t Taxonomy) ([]*hubv1.Record, error) {
 	xmlRecords, err := extractArXivRecords(data)
 	if err != nil {
 		return nil, err
@@ -483,7 +495,7 @@ func parseXSDRecord(data []byte) ([]*hubv1.Record, error) {
 
 	var records []*hubv1.Record
 	for i, xmlRec := range xmlRecords {
-		record, err := xmlToHub(xmlRec)
+		record, err := xmlToHub(xmlRec, t)
 		if err != nil {
 			return nil, fmt.Errorf("converting record %d: %w", i, err)
 		}
@@ -493,10 +505,12 @@ func parseXSDRecord(data []byte) ([]*hubv1.Record, error) {
 	return records, nil
 }
 
-// extractArXivRecords finds all arXivRecord elements in the XML.
+// extractArXivRecords finds all arXivRecord elements in the XML, attaching
+// any OAI-PMH header that precedes each one.
 func extractArXivRecords(data []byte) ([]*XMLRecord, error) {
 	decoder := xml.NewDecoder(bytes.NewReader(data))
 	var records []*XMLRecord
+	var header *format.OAIHeader
 
 	for {
 		tok, err := decoder.Token()
@@ -512,12 +526,19 @@ func extractArXivRecords(data []byte) ([]*XMLRecord, error) {
 			continue
 		}
 
-		if start.Name.Local == "arXivRecord" {
-			var rec XMLRecord
+		switch start.Name.Local {
+		case "header":
+			header = &format.OAIHeader{}
+			if err := decoder.DecodeElement(header, &start); err != nil {
+				return nil, fmt.Errorf("decoding OAI header: %w", err)
+			}
+		case "arXivRecord":
+			rec := XMLRecord{Header: header}
 			if err := decoder.DecodeElement(&rec, &start); err != nil {
 				return nil, fmt.Errorf("decoding arXivRecord: %w", err)
 			}
 			records = append(records, &rec)
+			header = nil
 		}
 	}
 
@@ -525,7 +546,7 @@ func extractArXivRecords(data []byte) ([]*XMLRecord, error) {
 }
 
 // xmlToHub converts a parsed arXiv XML record to a hub record.
-func xmlToHub(xmlRec *XMLRecord) (*hubv1.Record, error) {
+func xmlToHub(xmlRec *XMLRecord, t Taxonomy) (*hubv1.Record, error) {
 	record := &hubv1.Record{
 		Title: strings.TrimSpace(xmlRec.Title),
 		ResourceType: &hubv1.ResourceType{
@@ -544,12 +565,12 @@ func xmlToHub(xmlRec *XMLRecord) (*hubv1.Record, error) {
 
 	// Primary classification
 	if xmlRec.Primary != "" {
-		record.Subjects = append(record.Subjects, enrichSubject(xmlRec.Primary))
+		record.Subjects = append(record.Subjects, enrichSubject(xmlRec.Primary, t))
 	}
 
 	// Cross-listed classifications
 	for _, cross := range xmlRec.Cross {
-		record.Subjects = append(record.Subjects, enrichSubject(cross))
+		record.Subjects = append(record.Subjects, enrichSubject(cross, t))
 	}
 
 	// Submission date
@@ -637,11 +658,15 @@ func xmlToHub(xmlRec *XMLRecord) (*hubv1.Record, error) {
 		if xmlRec.Source.Type != "" {
 			hub.SetExtra(record, "source_type", xmlRec.Source.Type)
 		}
-		if xmlRec.Source.Size > 0 {
-			hub.SetExtra(record, "source_size", float64(xmlRec.Source.Size))
-		}
-		if xmlRec.Source.MD5 != "" {
-			hub.SetExtra(record, "source_md5", xmlRec.Source.MD5)
+		// The submission source becomes a file so its size and MD5 travel
+		// with the record as fixity information.
+		if xmlRec.Source.Size > 0 || xmlRec.Source.MD5 != "" {
+			file := &hubv1.File{
+				Role:      "source",
+				SizeBytes: xmlRec.Source.Size,
+			}
+			hub.SetChecksum(file, "md5", xmlRec.Source.MD5)
+			record.Files = append(record.Files, file)
 		}
 	}
 
@@ -650,6 +675,7 @@ func xmlToHub(xmlRec *XMLRecord) (*hubv1.Record, error) {
 		Format:        "arxiv",
 		FormatVersion: Version,
 		SourceId:      xmlRec.Identifier,
+		Oai:           xmlRec.Header.ToHub(),
 	}
 
 	return record, nil
@@ -772,14 +798,14 @@ func buildDisplayName(pn *hubv1.ParsedName) string {
 }
 
 // enrichSubject builds a Subject for an arXiv category code, enriching it with
-// a human-readable label when available. The code is preserved in SourceId.
-func enrichSubject(code string) *hubv1.Subject {
+// its label in t when it has one. The code is preserved in SourceId.
+func enrichSubject(code string, t Taxonomy) *hubv1.Subject {
 	s := &hubv1.Subject{
 		Value:      code,
 		SourceId:   code,
 		Vocabulary: hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_ARXIV,
 	}
-	if label := CategoryLabel(code); label != "" {
+	if label := t.Label(code); label != "" {
 		s.Value = label
 	}
 	return s
@@ -2,9 +2,12 @@ package arxiv
 
 import (
 	"bytes"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/lehigh-university-libraries/crosswalk/format"
 	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
 	"github.com/lehigh-university-libraries/crosswalk/hub"
 )
@@ -226,8 +229,13 @@ func TestParseBareRecord(t *testing.T) {
 	if v := hub.GetExtraString(r, "source_type"); v != "tex" {
 		t.Errorf("source_type: got %q", v)
 	}
-	if v := hub.GetExtraString(r, "source_md5"); v != "abcdef01234567890abcdef012345678" {
-		t.Errorf("source_md5: got %q", v)
+	// Source file fixity
+	src := hub.GetFileByRole(r, "source")
+	if src == nil {
+		t.Fatal("Expected source file")
+	}
+	if v := hub.GetChecksum(src, "md5"); v != "abcdef01234567890abcdef012345678" {
+		t.Errorf("source md5: got %q", v)
 	}
 
 	// Source info
@@ -338,6 +346,10 @@ func TestParseOAIPMHWrapped(t *testing.T) {
 	if !foundDOI {
 		t.Error("DOI not found in OAI-PMH wrapped record")
 	}
+
+	if oai := r.SourceInfo.GetOai(); oai.GetDatestamp() != "2025-05-20" || len(oai.GetSetSpecs()) != 1 {
+		t.Errorf("OAI header: got %v", oai)
+	}
 }
 
 func TestParseOAIFormat(t *testing.T) {
@@ -468,7 +480,25 @@ func TestParseOAIFormat(t *testing.T) {
 
 	// Source info
 	if r.SourceInfo == nil || r.SourceInfo.SourceId != "2511.11447" {
-		t.Errorf("SourceInfo: got %v", r.SourceInfo)
+		t.Fatalf("SourceInfo: got %v", r.SourceInfo)
+	}
+
+	// OAI header
+	oai := r.SourceInfo.Oai
+	if oai == nil {
+		t.Fatal("SourceInfo.Oai not set")
+	}
+	if oai.Identifier != "oai:arXiv.org:2511.11447" {
+		t.Errorf("OAI identifier: got %q", oai.Identifier)
+	}
+	if oai.Datestamp != "2025-11-18" {
+		t.Errorf("OAI datestamp: got %q", oai.Datestamp)
+	}
+	if len(oai.SetSpecs) != 2 || oai.SetSpecs[0] != "cs:cs:DL" || oai.SetSpecs[1] != "cs:cs:IR" {
+		t.Errorf("OAI setSpecs: got %v", oai.SetSpecs)
+	}
+	if oai.Deleted {
+		t.Error("OAI header should not be deleted")
 	}
 }
 
@@ -946,6 +976,90 @@ func TestRoundTrip(t *testing.T) {
 	}
 }
 
+func TestSerializeAtom(t *testing.T) {
+	original := &hubv1.Record{
+		Title:    "Atom Feed Test Paper",
+		Abstract: "Testing the Atom feed variant.",
+		Identifiers: []*hubv1.Identifier{
+			{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_ARXIV, Value: "2511.99999"},
+			{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_DOI, Value: "10.9999/atom"},
+		},
+		Subjects: []*hubv1.Subject{
+			{Value: "cs.SE", Vocabulary: hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_ARXIV},
+			{Value: "cs.PL", Vocabulary: hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_ARXIV},
+		},
+		Dates: []*hubv1.DateValue{
+			{Type: hubv1.DateType_DATE_TYPE_SUBMITTED, Year: 2025, Month: 6, Day: 15},
+		},
+		Contributors: []*hubv1.Contributor{
+			{
+				Name:        "Alice Test",
+				Role:        "author",
+				Affiliation: "Test University",
+				ParsedName:  &hubv1.ParsedName{Given: "Alice", Family: "Test"},
+			},
+		},
+	}
+
+	f := &Format{}
+	var buf bytes.Buffer
+	if err := f.Serialize(&buf, []*hubv1.Record{original}, &format.SerializeOptions{Variant: VariantAtom}); err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		`<feed xmlns="http://www.w3.org/2005/Atom" xmlns:arxiv="http://arxiv.org/schemas/atom"`,
+		`<id>http://arxiv.org/abs/2511.99999v1</id>`,
+		`<published>2025-06-15T00:00:00Z</published>`,
+		`<arxiv:primary_category term="cs.SE" scheme="http://arxiv.org/schemas/atom"></arxiv:primary_category>`,
+		`<category term="cs.PL" scheme="http://arxiv.org/schemas/atom"></category>`,
+		`<link href="http://arxiv.org/abs/2511.99999" rel="alternate" type="text/html"></link>`,
+		`<link href="http://arxiv.org/pdf/2511.99999" rel="related" type="application/pdf" title="pdf"></link>`,
+		`<link href="https://doi.org/10.9999/atom" rel="related" title="doi"></link>`,
+		`<arxiv:doi>10.9999/atom</arxiv:doi>`,
+		`<arxiv:affiliation>Test University</arxiv:affiliation>`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %s\n%s", want, out)
+		}
+	}
+
+	// The feed parses back through the Atom API reader
+	records, err := f.Parse(&buf, nil)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("Expected 1 record, got %d", len(records))
+	}
+	parsed := records[0]
+	if parsed.Title != original.Title {
+		t.Errorf("Title: got %q", parsed.Title)
+	}
+	if parsed.Abstract != original.Abstract {
+		t.Errorf("Abstract: got %q", parsed.Abstract)
+	}
+	if parsed.SourceInfo == nil || parsed.SourceInfo.SourceId != "2511.99999" {
+		t.Errorf("SourceInfo: got %v", parsed.SourceInfo)
+	}
+	if v := hub.GetExtraString(parsed, "pdf_url"); v != "http://arxiv.org/pdf/2511.99999" {
+		t.Errorf("pdf_url: got %q", v)
+	}
+	if len(parsed.Contributors) != 1 || parsed.Contributors[0].Name != "Alice Test" {
+		t.Errorf("Contributors: got %v", parsed.Contributors)
+	}
+}
+
+func TestSerializeUnknownVariant(t *testing.T) {
+	f := &Format{}
+	var buf bytes.Buffer
+	err := f.Serialize(&buf, nil, &format.SerializeOptions{Variant: "rss"})
+	if err == nil || !strings.Contains(err.Error(), "unknown arxiv variant") {
+		t.Errorf("expected unknown variant error, got %v", err)
+	}
+}
+
 func TestExtractArxivID(t *testing.T) {
 	tests := []struct {
 		input string
@@ -965,3 +1079,57 @@ func TestExtractArxivID(t *testing.T) {
 		}
 	}
 }
+
+func TestParseCustomTaxonomy(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "taxonomy.json")
+	taxonomy := `{"cs.XX": "Experimental Computing", "cs.DL": "Digital Libraries and Archives"}`
+	if err := os.WriteFile(path, []byte(taxonomy), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	input := `<arXivRecord xmlns="http://arxiv.org/schemas/arXivRecord/v1">
+  <identifier>2511.00001</identifier>
+  <primary>cs.XX</primary>
+  <cross>cs.DL</cross>
+  <cross>cs.IR</cross>
+  <title>Taxonomy Test</title>
+</arXivRecord>`
+
+	f := &Format{}
+	records, err := f.Parse(strings.NewReader(input), &format.ParseOptions{ArxivTaxonomy: path})
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	// New and renamed categories come from the file, the rest from the
+	// built-in taxonomy
+	want := map[string]string{
+		"cs.XX": "Experimental Computing",
+		"cs.DL": "Digital Libraries and Archives",
+		"cs.IR": "Information Retrieval",
+	}
+	for _, s := range records[0].Subjects {
+		if got := s.Value; got != want[s.SourceId] {
+			t.Errorf("Subject %q label: got %q, want %q", s.SourceId, got, want[s.SourceId])
+		}
+		delete(want, s.SourceId)
+	}
+	for code := range want {
+		t.Errorf("Category %q not found", code)
+	}
+
+	// The built-in taxonomy is untouched
+	if got := CategoryLabel("cs.DL"); got != "Digital Libraries" {
+		t.Errorf("CategoryLabel(cs.DL): got %q", got)
+	}
+}
+
+func TestLoadTaxonomyRejectsBlankLabels(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "taxonomy.json")
+	if err := os.WriteFile(path, []byte(`{"cs.XX": ""}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadTaxonomy(path); err == nil || !strings.Contains(err.Error(), "no label") {
+		t.Errorf("expected blank label error, got %v", err)
+	}
+}
@@ -13,10 +13,25 @@ import (
 	"github.com/lehigh-university-libraries/crosswalk/hub"
 )
 
-// Serialize writes hub records as arXiv metadata XML.
+// Serialize writes hub records as arXiv metadata XML, or as an arXiv API
+// Atom feed with VariantAtom.
 func (f *Format) Serialize(w io.Writer, records []*hubv1.Record, opts *format.SerializeOptions) error {
-	// opts reserved for future use (e.g., pretty print, encoding options)
-	_ = opts
+	if opts == nil {
+		opts = format.NewSerializeOptions()
+	}
+	switch opts.Variant {
+	case "", VariantRecord, VariantAtom:
+	default:
+		return fmt.Errorf("unknown arxiv variant %q (want %s or %s)", opts.Variant, VariantRecord, VariantAtom)
+	}
+
+	if len(records) == 0 {
+		return fmt.Errorf("arXiv requires an identifier and title: %w", format.ErrEmptyDocument)
+	}
+
+	if opts.Variant == VariantAtom {
+		return writeAtom(w, records, opts)
+	}
 
 	for i, record := range records {
 		// Step 1: Convert hub record to spoke proto struct
@@ -119,9 +134,58 @@ func hubToSpoke(record *hubv1.Record) (*arxivv1.Record, error) {
 		arxiv.Comments = record.Notes
 	}
 
+	// Submission source file with its MD5 fixity value
+	if src := hub.GetFileByRole(record, "source"); src != nil {
+		arxiv.Source = &arxivv1.Source{
+			Type: sourceTypeFromString(hub.GetExtraString(record, "source_type")),
+			Size: src.SizeBytes,
+			Md5:  hub.GetChecksum(src, "md5"),
+		}
+	}
+
 	return arxiv, nil
 }
 
+// sourceTypeFromString maps an arXiv source type token to the spoke enum.
+func sourceTypeFromString(s string) arxivv1.SourceType {
+	switch strings.ToLower(s) {
+	case "tex":
+		return arxivv1.SourceType_SOURCE_TYPE_TEX
+	case "encrypted-tex":
+		return arxivv1.SourceType_SOURCE_TYPE_ENCRYPTED_TEX
+	case "ps":
+		return arxivv1.SourceType_SOURCE_TYPE_PS
+	case "pdf":
+		return arxivv1.SourceType_SOURCE_TYPE_PDF
+	case "html":
+		return arxivv1.SourceType_SOURCE_TYPE_HTML
+	case "ignore":
+		return arxivv1.SourceType_SOURCE_TYPE_IGNORE
+	default:
+		return arxivv1.SourceType_SOURCE_TYPE_UNSPECIFIED
+	}
+}
+
+// sourceTypeString maps the spoke source type enum back to the arXiv token.
+func sourceTypeString(t arxivv1.SourceType) string {
+	switch t {
+	case arxivv1.SourceType_SOURCE_TYPE_TEX:
+		return "tex"
+	case arxivv1.SourceType_SOURCE_TYPE_ENCRYPTED_TEX:
+		return "encrypted-tex"
+	case arxivv1.SourceType_SOURCE_TYPE_PS:
+		return "ps"
+	case arxivv1.SourceType_SOURCE_TYPE_PDF:
+		return "pdf"
+	case arxivv1.SourceType_SOURCE_TYPE_HTML:
+		return "html"
+	case arxivv1.SourceType_SOURCE_TYPE_IGNORE:
+		return "ignore"
+	default:
+		return ""
+	}
+}
+
 // formatArxivDate formats a date for arXiv (ISO 8601 with Z suffix).
 func formatArxivDate(d *hubv1.DateValue) string {
 	if d.Year == 0 {
@@ -334,6 +398,15 @@ func spokeToXML(spoke *arxivv1.Record) *XMLRecord {
 		xmlRec.Classification = append(xmlRec.Classification, xmlCls)
 	}
 
+	// Source file
+	if spoke.Source != nil {
+		xmlRec.Source = &XMLSource{
+			Type: sourceTypeString(spoke.Source.Type),
+			Size: spoke.Source.Size,
+			MD5:  spoke.Source.Md5,
+		}
+	}
+
 	// Alternate identifiers
 	if spoke.Alternate != nil {
 		xmlRec.Alternate = &XMLAlternate{
@@ -370,6 +443,9 @@ type XMLRecord struct {
 	Comments       []string            `xml:"comments,omitempty"`
 	Abstract       []string            `xml:"abstract,omitempty"`
 	Attic          []XMLAttic          `xml:"attic,omitempty"`
+
+	// Header is the enclosing OAI-PMH header when parsed from a harvest
+	Header *format.OAIHeader `xml:"-"`
 }
 
 // XMLSubmitter represents submitter information.
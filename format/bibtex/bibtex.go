@@ -10,13 +10,23 @@ import (
 // Version documents the BibTeX specification this implementation targets.
 const Version = "bibtex-1988+biblatex"
 
+// Output variants, selected with SerializeOptions.Variant. BibTeX (the
+// default) writes entry types and fields as it always has; biblatex maps
+// types onto @online, @thesis, @dataset, and @software and adds the date,
+// urldate, and location fields.
+const (
+	VariantBibTeX   = "bibtex"
+	VariantBibLaTeX = "biblatex"
+)
+
 // Format implements the BibTeX format.
 type Format struct{}
 
 // Ensure Format implements the interfaces
 var (
-	_ format.Format     = (*Format)(nil)
-	_ format.Serializer = (*Format)(nil)
+	_ format.Format         = (*Format)(nil)
+	_ format.Serializer     = (*Format)(nil)
+	_ format.FieldDescriber = (*Format)(nil)
 )
 
 // Name returns the format identifier.
@@ -34,6 +44,18 @@ func (f *Format) Extensions() []string {
 	return []string{"bib", "bibtex"}
 }
 
+// writtenFields are the hub record fields Serialize writes.
+var writtenFields = []string{
+	"title", "abstract", "contributors", "dates", "resource_type", "subjects",
+	"language", "publisher", "place_published", "identifiers", "notes",
+	"edition", "degree_info", "relations.part_of", "relations.in_series",
+}
+
+// WrittenFields returns the hub record fields Serialize writes.
+func (f *Format) WrittenFields(opts *format.SerializeOptions) []string {
+	return writtenFields
+}
+
 // CanParse returns true if the input looks like BibTeX.
 func (f *Format) CanParse(peek []byte) bool {
 	peek = bytes.TrimSpace(peek)
@@ -13,15 +13,24 @@ import (
 
 // Serialize writes hub records as BibTeX entries.
 func (f *Format) Serialize(w io.Writer, records []*hubv1.Record, opts *format.SerializeOptions) error {
-	// opts reserved for future use (e.g., encoding options)
-	_ = opts
+	if opts == nil {
+		opts = format.NewSerializeOptions()
+	}
+	switch opts.Variant {
+	case "", VariantBibTeX, VariantBibLaTeX:
+	default:
+		return fmt.Errorf("unknown bibtex variant %q (want %s or %s)", opts.Variant, VariantBibTeX, VariantBibLaTeX)
+	}
 
 	for i, record := range records {
 		// Step 1: Convert hub record to spoke proto struct
-		spokeEntry, err := hubToSpoke(record)
+		spokeEntry, err := hubToSpoke(record, opts.Dates)
 		if err != nil {
 			return fmt.Errorf("converting record %d to spoke: %w", i, err)
 		}
+		if opts.Variant == VariantBibLaTeX {
+			toBiblatex(spokeEntry, record, opts.Dates)
+		}
 
 		// Step 2: Serialize spoke struct to BibTeX text
 		bibtexText := spokeToBibtex(spokeEntry)
@@ -40,7 +49,7 @@ func (f *Format) Serialize(w io.Writer, records []*hubv1.Record, opts *format.Se
 }
 
 // hubToSpoke converts a hub record to the BibTeX spoke proto struct.
-func hubToSpoke(record *hubv1.Record) (*bibtexv1.Entry, error) {
+func hubToSpoke(record *hubv1.Record, dates format.DateOptions) (*bibtexv1.Entry, error) {
 	entry := &bibtexv1.Entry{
 		Title:    record.Title,
 		Abstract: record.Abstract,
@@ -121,11 +130,12 @@ func hubToSpoke(record *hubv1.Record) (*bibtexv1.Entry, error) {
 	// Dates
 	for _, d := range record.Dates {
 		if d.Type == hubv1.DateType_DATE_TYPE_ISSUED || d.Type == hubv1.DateType_DATE_TYPE_PUBLISHED {
-			if d.Year > 0 {
-				entry.Year = fmt.Sprintf("%d", d.Year)
+			year, month, _ := format.ClampDate(d, dates)
+			if year > 0 {
+				entry.Year = fmt.Sprintf("%d", year)
 			}
-			if d.Month > 0 {
-				entry.Month = monthToString(int(d.Month))
+			if month > 0 {
+				entry.Month = monthToString(int(month))
 			}
 			break
 		}
@@ -211,6 +221,46 @@ func mapResourceTypeToBibtex(rt *hubv1.ResourceType) bibtexv1.EntryType {
 	}
 }
 
+// toBiblatex rewrites an entry with BibLaTeX's types and fields: theses
+// become @thesis with a type, the issued date is written in full as date
+// in place of year and month, the captured date becomes urldate, and the
+// address becomes location.
+func toBiblatex(entry *bibtexv1.Entry, record *hubv1.Record, dates format.DateOptions) {
+	switch entry.EntryType {
+	case bibtexv1.EntryType_ENTRY_TYPE_PHDTHESIS, bibtexv1.EntryType_ENTRY_TYPE_MASTERSTHESIS:
+		if entry.Type == "" {
+			entry.Type = "phdthesis"
+			if entry.EntryType == bibtexv1.EntryType_ENTRY_TYPE_MASTERSTHESIS {
+				entry.Type = "mathesis"
+			}
+		}
+		entry.EntryType = bibtexv1.EntryType_ENTRY_TYPE_THESIS
+		if entry.Institution == "" {
+			entry.Institution, entry.School = entry.School, ""
+		}
+	}
+
+	// Dates are always ISO 8601 in BibLaTeX
+	dates.Style = format.DateStyleISO
+	for _, d := range record.Dates {
+		switch d.Type {
+		case hubv1.DateType_DATE_TYPE_ISSUED, hubv1.DateType_DATE_TYPE_PUBLISHED:
+			if entry.Date == "" && d.Year != 0 {
+				entry.Date = format.FormatDate(d, dates)
+				entry.Year, entry.Month = "", ""
+			}
+		case hubv1.DateType_DATE_TYPE_CAPTURED:
+			if entry.Urldate == "" && d.Year != 0 {
+				entry.Urldate = format.FormatDate(d, dates)
+			}
+		}
+	}
+
+	if entry.Location == "" {
+		entry.Location, entry.Address = entry.Address, ""
+	}
+}
+
 func contributorRoleCode(c *hubv1.Contributor) string {
 	if c == nil {
 		return ""
@@ -297,7 +347,10 @@ func spokeToBibtex(entry *bibtexv1.Entry) string {
 		fmt.Fprintf(&sb, "  editor = {%s},\n", editors)
 	}
 
-	// Year and month
+	// Date, or year and month
+	if entry.Date != "" {
+		fmt.Fprintf(&sb, "  date = {%s},\n", entry.Date)
+	}
 	if entry.Year != "" {
 		fmt.Fprintf(&sb, "  year = {%s},\n", entry.Year)
 	}
@@ -320,6 +373,9 @@ func spokeToBibtex(entry *bibtexv1.Entry) string {
 	if entry.Address != "" {
 		fmt.Fprintf(&sb, "  address = {%s},\n", escapeBibtex(entry.Address))
 	}
+	if entry.Location != "" {
+		fmt.Fprintf(&sb, "  location = {%s},\n", escapeBibtex(entry.Location))
+	}
 
 	// Volume/number/pages
 	if entry.Volume != "" {
@@ -364,6 +420,9 @@ func spokeToBibtex(entry *bibtexv1.Entry) string {
 	if entry.Url != "" {
 		fmt.Fprintf(&sb, "  url = {%s},\n", entry.Url)
 	}
+	if entry.Urldate != "" {
+		fmt.Fprintf(&sb, "  urldate = {%s},\n", entry.Urldate)
+	}
 
 	// Eprint (arXiv)
 	if entry.Eprint != "" {
@@ -378,7 +437,7 @@ func spokeToBibtex(entry *bibtexv1.Entry) string {
 
 	// Keywords
 	if len(entry.Keywords) > 0 {
-		fmt.Fprintf(&sb, "  keywords = {%s},\n", strings.Join(entry.Keywords, ", "))
+		fmt.Fprintf(&sb, "  keywords = {%s},\n", escapeBibtex(strings.Join(entry.Keywords, ", ")))
 	}
 
 	// Abstract
@@ -476,13 +535,22 @@ func formatPerson(p *bibtexv1.Person) string {
 	return ""
 }
 
+// latexEscaper escapes the characters LaTeX treats as special. Braces are
+// escaped too, so a stray brace can't unbalance the field's delimiters.
+var latexEscaper = strings.NewReplacer(
+	`\`, `\textbackslash{}`,
+	"{", `\{`,
+	"}", `\}`,
+	"&", `\&`,
+	"%", `\%`,
+	"$", `\$`,
+	"#", `\#`,
+	"_", `\_`,
+	"~", `\textasciitilde{}`,
+	"^", `\textasciicircum{}`,
+)
+
 // escapeBibtex escapes special characters for BibTeX.
 func escapeBibtex(s string) string {
-	// BibTeX special characters that need escaping
-	s = strings.ReplaceAll(s, "&", "\\&")
-	s = strings.ReplaceAll(s, "%", "\\%")
-	s = strings.ReplaceAll(s, "$", "\\$")
-	s = strings.ReplaceAll(s, "#", "\\#")
-	s = strings.ReplaceAll(s, "_", "\\_")
-	return s
+	return latexEscaper.Replace(s)
 }
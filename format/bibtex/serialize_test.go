@@ -1,8 +1,12 @@
 package bibtex
 
 import (
+	"bytes"
+	"strings"
 	"testing"
 
+	"github.com/lehigh-university-libraries/crosswalk/format"
+
 	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
 )
 
@@ -31,7 +35,7 @@ func TestHubToSpoke_RelatorsExcludeThesisAdvisorFromAuthor(t *testing.T) {
 		},
 	}
 
-	entry, err := hubToSpoke(record)
+	entry, err := hubToSpoke(record, format.DateOptions{})
 	if err != nil {
 		t.Fatalf("hubToSpoke failed: %v", err)
 	}
@@ -46,3 +50,117 @@ func TestHubToSpoke_RelatorsExcludeThesisAdvisorFromAuthor(t *testing.T) {
 		t.Fatalf("editor count = %d, want 0", len(entry.Editor))
 	}
 }
+
+func TestSerialize(t *testing.T) {
+	record := &hubv1.Record{
+		Title:        "Costs & Benefits of {Braces}: 50% off_road",
+		ResourceType: &hubv1.ResourceType{Type: hubv1.ResourceTypeValue_RESOURCE_TYPE_DISSERTATION},
+		Contributors: []*hubv1.Contributor{
+			{Name: "Alex Rivera", Role: "author", ParsedName: &hubv1.ParsedName{Given: "Alex", Family: "Rivera"}},
+			{Name: "Sam O'Neil", Role: "author", ParsedName: &hubv1.ParsedName{Given: "Sam", Family: "O'Neil", Suffix: "Jr."}},
+			{Name: "Lehigh Lab", Role: "author"},
+		},
+		Identifiers: []*hubv1.Identifier{
+			{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_DOI, Value: "10.1234/x_y"},
+			{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_URL, Value: "https://example.edu/~rivera/thesis"},
+		},
+		Subjects: []*hubv1.Subject{
+			{Value: "C#", Vocabulary: hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_KEYWORDS},
+		},
+		Notes: []string{`Path C:\data`, "~draft^2"},
+	}
+
+	var buf bytes.Buffer
+	if err := (&Format{}).Serialize(&buf, []*hubv1.Record{record}, nil); err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"@phdthesis{",
+		`title = {Costs \& Benefits of \{Braces\}: 50\% off\_road},`,
+		"author = {Rivera, Alex and O'Neil, Sam, Jr. and Lehigh Lab},",
+		"doi = {10.1234/x_y},",
+		"url = {https://example.edu/~rivera/thesis},",
+		`keywords = {C\#},`,
+		`note = {Path C:\textbackslash{}data; \textasciitilde{}draft\textasciicircum{}2},`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestSerializeVariants(t *testing.T) {
+	record := &hubv1.Record{
+		Title:          "Dark Matter Halos",
+		ResourceType:   &hubv1.ResourceType{Type: hubv1.ResourceTypeValue_RESOURCE_TYPE_THESIS},
+		PlacePublished: "Bethlehem, PA",
+		DegreeInfo:     &hubv1.DegreeInfo{Institution: "Lehigh University"},
+		Dates: []*hubv1.DateValue{
+			{Type: hubv1.DateType_DATE_TYPE_ISSUED, Year: 2024, Month: 5, Day: 20},
+			{Type: hubv1.DateType_DATE_TYPE_CAPTURED, Year: 2025, Month: 1, Day: 3},
+		},
+		Identifiers: []*hubv1.Identifier{
+			{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_ARXIV, Value: "2405.01234"},
+		},
+	}
+	dataset := &hubv1.Record{
+		Title:        "Halo Catalog",
+		ResourceType: &hubv1.ResourceType{Type: hubv1.ResourceTypeValue_RESOURCE_TYPE_DATASET},
+	}
+
+	tests := []struct {
+		variant string
+		want    []string
+		notWant []string
+	}{
+		{
+			variant: "",
+			want: []string{
+				"@mastersthesis{", "year = {2024},", "month = may,",
+				"address = {Bethlehem, PA},", "school = {Lehigh University},",
+				"@dataset{",
+			},
+			notWant: []string{"date = ", "urldate"},
+		},
+		{
+			variant: VariantBibLaTeX,
+			want: []string{
+				"@thesis{", "type = {mathesis},", "institution = {Lehigh University},",
+				"date = {2024-05-20},", "urldate = {2025-01-03},",
+				"location = {Bethlehem, PA},",
+				"eprint = {2405.01234},", "eprinttype = {arxiv},",
+				"@dataset{",
+			},
+			notWant: []string{"year = ", "month = ", "address = ", "school = "},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.variant, func(t *testing.T) {
+			var buf bytes.Buffer
+			opts := format.NewSerializeOptions()
+			opts.Variant = tt.variant
+			if err := (&Format{}).Serialize(&buf, []*hubv1.Record{record, dataset}, opts); err != nil {
+				t.Fatalf("Serialize failed: %v", err)
+			}
+			out := buf.String()
+			for _, want := range tt.want {
+				if !strings.Contains(out, want) {
+					t.Errorf("output missing %q:\n%s", want, out)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(out, notWant) {
+					t.Errorf("output has %q:\n%s", notWant, out)
+				}
+			}
+		})
+	}
+
+	opts := format.NewSerializeOptions()
+	opts.Variant = "bibtex8"
+	if err := (&Format{}).Serialize(&bytes.Buffer{}, []*hubv1.Record{record}, opts); err == nil {
+		t.Error("expected an error for an unknown variant")
+	}
+}
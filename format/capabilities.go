@@ -0,0 +1,145 @@
+package format
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+)
+
+// FieldDescriber is a serializer that declares which hub record fields it
+// writes. Lossless conversions use the declaration to refuse a target
+// that would drop data present in the source records.
+type FieldDescriber interface {
+	Serializer
+
+	// WrittenFields returns the hub record fields the serializer writes
+	// with the given options, as proto field names (e.g. "funders").
+	// Relations are declared per type, as "relations.part_of", or as
+	// "relations" when every type is written. Formats driven by columns or
+	// profiles answer from opts.
+	WrittenFields(opts *SerializeOptions) []string
+}
+
+// untrackedFields describe where a record came from or are derived from
+// other records, so no target is expected to carry them.
+var untrackedFields = map[string]bool{
+	"extra":           true,
+	"source_info":     true,
+	"membership_path": true,
+}
+
+// FieldLoss is a hub field, populated in the input, that the target
+// format does not write.
+type FieldLoss struct {
+	Field   string
+	Records int
+}
+
+// LossError reports the hub fields a conversion would discard.
+type LossError struct {
+	Format string
+	Losses []FieldLoss
+}
+
+func (e *LossError) Error() string {
+	parts := make([]string, 0, len(e.Losses))
+	for _, l := range e.Losses {
+		noun := "records"
+		if l.Records == 1 {
+			noun = "record"
+		}
+		parts = append(parts, fmt.Sprintf("%s (%d %s)", l.Field, l.Records, noun))
+	}
+	return fmt.Sprintf("%s cannot represent fields present in the input: %s", e.Format, strings.Join(parts, ", "))
+}
+
+// PresentFields counts, per hub record field, how many records populate
+// it. Set but empty messages are not counted. Relations are counted per
+// type under keys like "relations.part_of".
+func PresentFields(records []*hubv1.Record) map[string]int {
+	counts := make(map[string]int)
+	for _, r := range records {
+		r.ProtoReflect().Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+			if fd.Kind() == protoreflect.MessageKind && !fd.IsList() && proto.Size(v.Message().Interface()) == 0 {
+				return true
+			}
+			counts[string(fd.Name())]++
+			return true
+		})
+		for key := range relationKeys(r) {
+			counts[key]++
+		}
+	}
+	delete(counts, "relations")
+	return counts
+}
+
+// relationKeys returns the "relations.<type>" keys a record populates.
+func relationKeys(r *hubv1.Record) map[string]bool {
+	keys := make(map[string]bool)
+	for _, rel := range r.Relations {
+		name := strings.TrimPrefix(rel.Type.String(), "RELATION_TYPE_")
+		keys["relations."+strings.ToLower(name)] = true
+	}
+	return keys
+}
+
+// CheckLossless returns a *LossError when records populate hub fields the
+// serializer does not write. Serializers that don't declare their fields
+// can't promise anything, so they fail the check too.
+func CheckLossless(s Serializer, records []*hubv1.Record, opts *SerializeOptions) error {
+	fd, ok := s.(FieldDescriber)
+	if !ok {
+		return fmt.Errorf("format %s does not declare which fields it writes, so lossless conversion cannot be guaranteed", s.Name())
+	}
+
+	written := writtenSet(fd, opts)
+	var losses []FieldLoss
+	for field, n := range PresentFields(records) {
+		if drops(written, field) {
+			losses = append(losses, FieldLoss{Field: field, Records: n})
+		}
+	}
+	if len(losses) == 0 {
+		return nil
+	}
+	sort.Slice(losses, func(i, j int) bool { return losses[i].Field < losses[j].Field })
+	return &LossError{Format: s.Name(), Losses: losses}
+}
+
+// DroppedFields returns, sorted, the hub fields a record populates that the
+// serializer does not write. ok is false when the serializer doesn't
+// declare its fields, so what it drops is unknown.
+func DroppedFields(s Serializer, record *hubv1.Record, opts *SerializeOptions) (fields []string, ok bool) {
+	fd, ok := s.(FieldDescriber)
+	if !ok {
+		return nil, false
+	}
+	written := writtenSet(fd, opts)
+	for field := range PresentFields([]*hubv1.Record{record}) {
+		if drops(written, field) {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+	return fields, true
+}
+
+func writtenSet(fd FieldDescriber, opts *SerializeOptions) map[string]bool {
+	written := make(map[string]bool)
+	for _, f := range fd.WrittenFields(opts) {
+		written[f] = true
+	}
+	return written
+}
+
+// drops reports whether a present field, or relation type, goes unwritten.
+func drops(written map[string]bool, field string) bool {
+	parent, _, _ := strings.Cut(field, ".")
+	return !written[field] && !written[parent] && !untrackedFields[field]
+}
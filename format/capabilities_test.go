@@ -0,0 +1,146 @@
+package format_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+)
+
+func depositRecords() []*hubv1.Record {
+	return []*hubv1.Record{
+		{
+			Title:   "Grant-funded dataset",
+			Funders: []*hubv1.Funder{{Name: "National Science Foundation", AwardNumbers: []string{"1234567"}}},
+			Relations: []*hubv1.Relation{
+				{Type: hubv1.RelationType_RELATION_TYPE_PART_OF, TargetTitle: "Lab collection"},
+				{Type: hubv1.RelationType_RELATION_TYPE_IS_SUPPLEMENT_TO, TargetUri: "https://doi.org/10.1234/article"},
+			},
+		},
+		{
+			Title:   "Second dataset",
+			Funders: []*hubv1.Funder{{Name: "National Science Foundation"}},
+		},
+	}
+}
+
+func TestPresentFields(t *testing.T) {
+	records := depositRecords()
+	records = append(records, &hubv1.Record{Title: "Empty degree", DegreeInfo: &hubv1.DegreeInfo{}})
+
+	got := format.PresentFields(records)
+	want := map[string]int{
+		"title":                      3,
+		"funders":                    2,
+		"relations.part_of":          1,
+		"relations.is_supplement_to": 1,
+	}
+	if len(got) != len(want) {
+		t.Errorf("PresentFields: got %v, want %v", got, want)
+	}
+	for field, n := range want {
+		if got[field] != n {
+			t.Errorf("%s: got %d records, want %d", field, got[field], n)
+		}
+	}
+}
+
+func TestCheckLossless(t *testing.T) {
+	records := depositRecords()
+
+	tests := []struct {
+		format string
+		losses []string
+	}{
+		{"scholix", []string{"funders", "relations.part_of"}},
+		{"bibtex", []string{"funders", "relations.is_supplement_to"}},
+		{"dublincore", []string{"funders"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			s, err := format.GetSerializer(tt.format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = format.CheckLossless(s, records, format.NewSerializeOptions())
+			var lossErr *format.LossError
+			if !errors.As(err, &lossErr) {
+				t.Fatalf("expected a LossError, got %v", err)
+			}
+			var fields []string
+			for _, l := range lossErr.Losses {
+				fields = append(fields, l.Field)
+			}
+			if strings.Join(fields, ",") != strings.Join(tt.losses, ",") {
+				t.Errorf("losses: got %v, want %v", fields, tt.losses)
+			}
+		})
+	}
+
+	datacite, _ := format.GetSerializer("datacite")
+	if err := format.CheckLossless(datacite, records, nil); err != nil {
+		t.Errorf("datacite carries funders and both relation types, got %v", err)
+	}
+
+	s, _ := format.GetSerializer("bibtex")
+	err := format.CheckLossless(s, records, nil)
+	if msg := err.Error(); !strings.Contains(msg, "funders (2 records)") || !strings.Contains(msg, "relations.is_supplement_to (1 record)") {
+		t.Errorf("error should list fields at risk with counts, got %q", msg)
+	}
+
+	if err := format.CheckLossless(s, []*hubv1.Record{{Title: "Only a title"}}, nil); err != nil {
+		t.Errorf("expected no loss for a title-only record, got %v", err)
+	}
+}
+
+func TestCheckLosslessCSVColumns(t *testing.T) {
+	s, _ := format.GetSerializer("csv")
+	records := []*hubv1.Record{{Title: "Thesis", Abstract: "Summary"}}
+
+	opts := format.NewSerializeOptions()
+	opts.Columns = []string{"title"}
+	if err := format.CheckLossless(s, records, opts); err == nil || !strings.Contains(err.Error(), "abstract") {
+		t.Errorf("expected abstract to be at risk with only a title column, got %v", err)
+	}
+
+	opts.Columns = []string{"title", "abstract"}
+	if err := format.CheckLossless(s, records, opts); err != nil {
+		t.Errorf("expected no loss, got %v", err)
+	}
+}
+
+// undeclared is a serializer that doesn't describe its fields.
+type undeclared struct{}
+
+func (undeclared) Name() string              { return "undeclared" }
+func (undeclared) Description() string       { return "" }
+func (undeclared) Extensions() []string      { return nil }
+func (undeclared) CanParse(peek []byte) bool { return false }
+func (undeclared) Serialize(w io.Writer, records []*hubv1.Record, opts *format.SerializeOptions) error {
+	return nil
+}
+
+func TestCheckLosslessUndeclared(t *testing.T) {
+	err := format.CheckLossless(undeclared{}, []*hubv1.Record{{Title: "x"}}, nil)
+	if err == nil || !strings.Contains(err.Error(), "does not declare") {
+		t.Errorf("expected an undeclared-fields error, got %v", err)
+	}
+}
+
+func TestDroppedFields(t *testing.T) {
+	s, _ := format.GetSerializer("bibtex")
+	got, ok := format.DroppedFields(s, depositRecords()[0], nil)
+	if !ok {
+		t.Fatal("bibtex declares its fields")
+	}
+	if want := "funders,relations.is_supplement_to"; strings.Join(got, ",") != want {
+		t.Errorf("DroppedFields: got %v, want %s", got, want)
+	}
+
+	if _, ok := format.DroppedFields(undeclared{}, depositRecords()[0], nil); ok {
+		t.Error("an undeclared serializer's drops should be unknown")
+	}
+}
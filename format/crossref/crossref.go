@@ -15,9 +15,10 @@ type Format struct{}
 
 // Ensure Format implements the interfaces
 var (
-	_ format.Format     = (*Format)(nil)
-	_ format.Parser     = (*Format)(nil)
-	_ format.Serializer = (*Format)(nil)
+	_ format.Format         = (*Format)(nil)
+	_ format.Parser         = (*Format)(nil)
+	_ format.Serializer     = (*Format)(nil)
+	_ format.FieldDescriber = (*Format)(nil)
 )
 
 // Name returns the format identifier.
@@ -35,6 +36,19 @@ func (f *Format) Extensions() []string {
 	return []string{"xml"}
 }
 
+// writtenFields are the hub record fields Serialize writes for every
+// content type. Publisher, edition, and degree details only have a place
+// in book and dissertation deposits, so they aren't promised.
+var writtenFields = []string{
+	"title", "alt_title", "abstract", "contributors", "dates", "resource_type",
+	"identifiers", "rights", "copyright_statement", "rights_holder",
+}
+
+// WrittenFields returns the hub record fields Serialize writes.
+func (f *Format) WrittenFields(opts *format.SerializeOptions) []string {
+	return writtenFields
+}
+
 // CanParse returns true if the input looks like CrossRef deposit XML.
 func (f *Format) CanParse(peek []byte) bool {
 	peek = bytes.TrimSpace(peek)
@@ -3,12 +3,15 @@ package crossref
 import (
 	"fmt"
 	"io"
+	"slices"
 	"strings"
 
 	"github.com/lehigh-university-libraries/crosswalk/format"
 	"github.com/lehigh-university-libraries/crosswalk/format/protoxml"
 	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
 	crossrefv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/spoke/crossref/v5_3_1"
+	"github.com/lehigh-university-libraries/crosswalk/helpers"
+	"github.com/lehigh-university-libraries/crosswalk/helpers/place"
 	"github.com/lehigh-university-libraries/crosswalk/hub"
 )
 
@@ -213,6 +216,10 @@ func journalArticleToHub(article *crossrefv1.JournalArticle) *hubv1.Record {
 
 	addPages(rec, article.GetPages())
 
+	addCitations(rec, article.GetCitationList())
+	addLicenses(rec, article.GetLicense())
+	addLicenses(rec, article.GetAccessIndicators().GetLicenseRef())
+
 	return rec
 }
 
@@ -242,12 +249,16 @@ func bookMetadataToHub(bm *crossrefv1.BookMetadata) *hubv1.Record {
 	if pub := bm.GetPublisher(); pub != nil {
 		rec.Publisher = pub.GetPublisherName()
 		rec.PlacePublished = pub.GetPublisherPlace()
+		rec.PublicationPlace = place.Parse(rec.PlacePublished)
 	}
 
 	if bm.GetEditionNumber() != "" {
 		rec.Edition = bm.GetEditionNumber()
 	}
 
+	addCitations(rec, bm.GetCitationList())
+	addLicenses(rec, bm.GetAccessIndicators().GetLicenseRef())
+
 	return rec
 }
 
@@ -267,6 +278,9 @@ func contentItemToHub(item *crossrefv1.ContentItem) *hubv1.Record {
 
 	addPages(rec, item.GetPages())
 
+	addCitations(rec, item.GetCitationList())
+	addLicenses(rec, item.GetAccessIndicators().GetLicenseRef())
+
 	return rec
 }
 
@@ -286,6 +300,9 @@ func conferencePaperToHub(paper *crossrefv1.ConferencePaper) *hubv1.Record {
 
 	addPages(rec, paper.GetPages())
 
+	addCitations(rec, paper.GetCitationList())
+	addLicenses(rec, paper.GetAccessIndicators().GetLicenseRef())
+
 	return rec
 }
 
@@ -304,6 +321,9 @@ func datasetToHub(ds *crossrefv1.Dataset) *hubv1.Record {
 	rec.Dates = appendPublicationDate(rec.Dates, ds.GetPublicationDate(), hubv1.DateType_DATE_TYPE_ISSUED)
 	rec.Identifiers = appendDoiIdentifier(rec.Identifiers, ds.GetDoiData())
 
+	addCitations(rec, ds.GetCitationList())
+	addLicenses(rec, ds.GetAccessIndicators().GetLicenseRef())
+
 	return rec
 }
 
@@ -342,6 +362,9 @@ func dissertationToHub(diss *crossrefv1.Dissertation) *hubv1.Record {
 		rec.DegreeInfo.DegreeName = diss.GetDegree()
 	}
 
+	addCitations(rec, diss.GetCitationList())
+	addLicenses(rec, diss.GetAccessIndicators().GetLicenseRef())
+
 	return rec
 }
 
@@ -367,6 +390,9 @@ func postedContentToHub(pc *crossrefv1.PostedContent) *hubv1.Record {
 		})
 	}
 
+	addCitations(rec, pc.GetCitationList())
+	addLicenses(rec, pc.GetAccessIndicators().GetLicenseRef())
+
 	return rec
 }
 
@@ -388,6 +414,9 @@ func peerReviewToHub(pr *crossrefv1.PeerReview) *hubv1.Record {
 		hub.SetExtra(rec, "review_stage", pr.GetStage())
 	}
 
+	addCitations(rec, pr.GetCitationList())
+	addLicenses(rec, pr.GetAccessIndicators().GetLicenseRef())
+
 	return rec
 }
 
@@ -416,8 +445,12 @@ func addJournalMetadata(rec *hubv1.Record, jm *crossrefv1.JournalMetadata) {
 
 // addIssueMetadata enriches a record with issue-level metadata.
 func addIssueMetadata(rec *hubv1.Record, issue *crossrefv1.JournalIssue) {
-	if issue.GetVolume() != "" {
-		hub.SetExtra(rec, "volume", issue.GetVolume())
+	volume := issue.GetJournalVolume().GetVolume()
+	if volume == "" {
+		volume = issue.GetVolume()
+	}
+	if volume != "" {
+		hub.SetExtra(rec, "volume", volume)
 	}
 	if issue.GetIssue() != "" {
 		hub.SetExtra(rec, "issue", issue.GetIssue())
@@ -448,20 +481,31 @@ func extractContributors(contribs *crossrefv1.Contributors) []*hubv1.Contributor
 	}
 	for _, org := range contribs.GetOrganization() {
 		c := &hubv1.Contributor{
-			Name: org.GetName(),
-			Role: org.GetContributorRole(),
-			Type: hubv1.ContributorType_CONTRIBUTOR_TYPE_ORGANIZATION,
+			Name:     org.GetName(),
+			Role:     org.GetContributorRole(),
+			RoleCode: roleCode(org.GetContributorRole()),
+			Type:     hubv1.ContributorType_CONTRIBUTOR_TYPE_ORGANIZATION,
 		}
 		result = append(result, c)
 	}
 	return result
 }
 
+// roleCode returns the relator for a Crossref contributor_role ("editor" is
+// relators:edt), or an empty string for roles without one ("chair").
+func roleCode(role string) string {
+	if code := helpers.RelatorCode(role); code != "" {
+		return "relators:" + code
+	}
+	return ""
+}
+
 // personNameToContributor converts a PersonName to a hub Contributor.
 func personNameToContributor(pn *crossrefv1.PersonName) *hubv1.Contributor {
 	c := &hubv1.Contributor{
-		Role: pn.GetContributorRole(),
-		Type: hubv1.ContributorType_CONTRIBUTOR_TYPE_PERSON,
+		Role:     pn.GetContributorRole(),
+		RoleCode: roleCode(pn.GetContributorRole()),
+		Type:     hubv1.ContributorType_CONTRIBUTOR_TYPE_PERSON,
 	}
 
 	given := pn.GetGivenName()
@@ -571,6 +615,49 @@ func appendDoiIdentifier(ids []*hubv1.Identifier, dd *crossrefv1.DoiData) []*hub
 	})
 }
 
+// addCitations adds a record's reference list as relations to the works
+// it cites. A citation is identified by its DOI when it has one and
+// titled by the cited article or journal; an unstructured citation is
+// kept as the relation's description, or as its title when there's
+// nothing else to go on.
+func addCitations(rec *hubv1.Record, cl *crossrefv1.CitationList) {
+	for _, c := range cl.GetCitation() {
+		rel := &hubv1.Relation{
+			Type:        hubv1.RelationType_RELATION_TYPE_CITES,
+			TargetTitle: c.GetArticleTitle(),
+			SourceId:    c.GetKey(),
+			Description: c.GetUnstructuredCitation(),
+		}
+		if rel.TargetTitle == "" {
+			rel.TargetTitle = c.GetJournalTitle()
+		}
+		if doi := c.GetDoi(); doi != "" {
+			rel.TargetId = doi
+			rel.TargetIdType = hubv1.IdentifierType_IDENTIFIER_TYPE_DOI
+		}
+		if rel.TargetTitle == "" && rel.TargetId == "" {
+			rel.TargetTitle, rel.Description = rel.Description, ""
+		}
+		if rel.TargetTitle == "" && rel.TargetId == "" {
+			continue
+		}
+		rec.Relations = append(rec.Relations, rel)
+	}
+}
+
+// addLicenses adds license references to a record's rights. Crossref
+// often gives the same license for each version of the content (vor, am,
+// tdm); it's added once.
+func addLicenses(rec *hubv1.Record, licenses []*crossrefv1.License) {
+	for _, l := range licenses {
+		uri := strings.TrimSpace(l.GetLicenseRef())
+		if uri == "" || slices.ContainsFunc(rec.Rights, func(r *hubv1.Rights) bool { return r.Uri == uri }) {
+			continue
+		}
+		rec.Rights = append(rec.Rights, hub.NewRightsFromURI(uri))
+	}
+}
+
 // addPages adds page information to a record's extra fields.
 func addPages(rec *hubv1.Record, pages *crossrefv1.Pages) {
 	if pages == nil {
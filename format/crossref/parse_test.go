@@ -305,3 +305,76 @@ func TestParseDissertation(t *testing.T) {
 		t.Errorf("SourceInfo.SourceId: got %q", r.SourceInfo.SourceId)
 	}
 }
+
+func TestParseCitationsAndLicenses(t *testing.T) {
+	input := `<?xml version="1.0" encoding="UTF-8"?>
+<doi_batch xmlns="http://www.crossref.org/schema/5.3.1" version="5.3.1"
+  xmlns:ai="http://www.crossref.org/AccessIndicators.xsd"
+  xmlns:fr="http://www.crossref.org/fundref.xsd">
+  <body>
+    <journal>
+      <journal_metadata><full_title>Journal of Testing</full_title></journal_metadata>
+      <journal_article>
+        <titles><title>Open Citations</title></titles>
+        <fr:program name="fundref">
+          <fr:assertion name="funder_name">National Science Foundation</fr:assertion>
+        </fr:program>
+        <ai:program name="AccessIndicators">
+          <ai:free_to_read/>
+          <ai:license_ref applies_to="vor" start_date="2024-01-01">https://creativecommons.org/licenses/by/4.0/</ai:license_ref>
+          <ai:license_ref applies_to="am">https://creativecommons.org/licenses/by/4.0/</ai:license_ref>
+          <ai:license_ref applies_to="tdm">https://www.crossref.org/tdm-license</ai:license_ref>
+        </ai:program>
+        <doi_data><doi>10.1234/open.2024.1</doi></doi_data>
+        <citation_list>
+          <citation key="ref1">
+            <journal_title>Nature</journal_title>
+            <first_page>12</first_page>
+            <cYear>2019</cYear>
+            <doi>10.1038/s41586-019-0001-1</doi>
+          </citation>
+          <citation key="ref2">
+            <unstructured_citation>Smith, J. (2020). A book about tests. Example Press.</unstructured_citation>
+          </citation>
+        </citation_list>
+      </journal_article>
+    </journal>
+  </body>
+</doi_batch>`
+
+	records, err := (&Format{}).Parse(strings.NewReader(input), nil)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("expected 1 record, got %d", len(records))
+	}
+	r := records[0]
+
+	if len(r.Rights) != 2 {
+		t.Fatalf("Rights: got %v, want one entry per distinct license", r.Rights)
+	}
+	if r.Rights[0].Uri != "https://creativecommons.org/licenses/by/4.0/" || r.Rights[0].Statement != "CC BY" {
+		t.Errorf("Rights[0]: got %v", r.Rights[0])
+	}
+	if r.Rights[1].Uri != "https://www.crossref.org/tdm-license" {
+		t.Errorf("Rights[1]: got %v", r.Rights[1])
+	}
+
+	var cites []*hubv1.Relation
+	for _, rel := range r.Relations {
+		if rel.Type == hubv1.RelationType_RELATION_TYPE_CITES {
+			cites = append(cites, rel)
+		}
+	}
+	if len(cites) != 2 {
+		t.Fatalf("expected 2 citations, got %v", cites)
+	}
+	if c := cites[0]; c.TargetId != "10.1038/s41586-019-0001-1" || c.TargetIdType != hubv1.IdentifierType_IDENTIFIER_TYPE_DOI ||
+		c.TargetTitle != "Nature" || c.SourceId != "ref1" {
+		t.Errorf("first citation: got %v", c)
+	}
+	if c := cites[1]; c.TargetTitle != "Smith, J. (2020). A book about tests. Example Press." || c.TargetId != "" {
+		t.Errorf("unstructured citation: got %v", c)
+	}
+}
@@ -1,15 +1,23 @@
 package crossref
 
 import (
+	"cmp"
 	"encoding/xml"
 	"fmt"
 	"io"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
+	"google.golang.org/protobuf/proto"
+
 	"github.com/lehigh-university-libraries/crosswalk/format"
 	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
 	crossrefv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/spoke/crossref/v5_3_1"
+	"github.com/lehigh-university-libraries/crosswalk/helpers"
+	"github.com/lehigh-university-libraries/crosswalk/helpers/place"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
 )
 
 // Serialize writes hub records as CrossRef deposit XML.
@@ -18,6 +26,10 @@ func (f *Format) Serialize(w io.Writer, records []*hubv1.Record, opts *format.Se
 		opts = format.NewSerializeOptions()
 	}
 
+	if len(records) == 0 {
+		return fmt.Errorf("CrossRef deposits require at least one work: %w", format.ErrEmptyDocument)
+	}
+
 	// Step 1: Convert hub records to spoke proto struct
 	spokeDeposit, err := hubToSpoke(records, opts)
 	if err != nil {
@@ -25,7 +37,7 @@ func (f *Format) Serialize(w io.Writer, records []*hubv1.Record, opts *format.Se
 	}
 
 	// Step 2: Convert spoke struct to XML-marshalable types
-	xmlDeposit := spokeToXML(spokeDeposit)
+	xmlDeposit := spokeToXML(spokeDeposit, opts.CrossmarkPolicy)
 
 	// Step 3: Marshal to XML
 	if _, err := w.Write([]byte(xml.Header)); err != nil {
@@ -40,6 +52,11 @@ func (f *Format) Serialize(w io.Writer, records []*hubv1.Record, opts *format.Se
 	return encoder.Encode(xmlDeposit)
 }
 
+// abstractPolicy is the default abstract limit. Crossref publishes no hard
+// maximum, but abstracts past a few thousand words are almost always full
+// text captured by mistake, and Crossref's consumers display far less.
+var abstractPolicy = format.LengthPolicy{Max: 20000, Mode: format.LengthTruncate}
+
 // hubToSpoke converts hub records to the CrossRef spoke proto struct.
 func hubToSpoke(records []*hubv1.Record, opts *format.SerializeOptions) (*crossrefv1.Deposit, error) {
 	deposit := &crossrefv1.Deposit{
@@ -61,14 +78,29 @@ func hubToSpoke(records []*hubv1.Record, opts *format.SerializeOptions) (*crossr
 			continue
 		}
 
+		abstract, err := format.ApplyLength(record.Title, "abstract", record.Abstract, opts.LengthPolicy("abstract", abstractPolicy))
+		if err != nil {
+			return nil, err
+		}
+		if abstract != record.Abstract {
+			record = proto.Clone(record).(*hubv1.Record)
+			record.Abstract = abstract
+		}
+
 		switch record.ResourceType.Type {
 		case hubv1.ResourceTypeValue_RESOURCE_TYPE_DISSERTATION,
 			hubv1.ResourceTypeValue_RESOURCE_TYPE_THESIS:
 			deposit.Body.Dissertation = append(deposit.Body.Dissertation, buildDissertation(record))
 
-		case hubv1.ResourceTypeValue_RESOURCE_TYPE_ARTICLE,
-			hubv1.ResourceTypeValue_RESOURCE_TYPE_PREPRINT:
-			// Create a posted content record for preprints/articles without journal context
+		case hubv1.ResourceTypeValue_RESOURCE_TYPE_ARTICLE:
+			if pub := journalContext(record); pub != nil {
+				deposit.Body.Journal = append(deposit.Body.Journal, buildJournal(record, pub))
+			} else {
+				// Without a journal to deposit it under, an article is posted content
+				deposit.Body.PostedContent = append(deposit.Body.PostedContent, buildPostedContent(record))
+			}
+
+		case hubv1.ResourceTypeValue_RESOURCE_TYPE_PREPRINT:
 			deposit.Body.PostedContent = append(deposit.Body.PostedContent, buildPostedContent(record))
 
 		case hubv1.ResourceTypeValue_RESOURCE_TYPE_DATASET:
@@ -86,15 +118,120 @@ func hubToSpoke(records []*hubv1.Record, opts *format.SerializeOptions) (*crossr
 	return deposit, nil
 }
 
+// journalContext returns the journal an article was published in. Details
+// missing from the record's publication details are taken from a part-of
+// relation, ISSN identifiers, and the volume, issue, and page extras a
+// parsed Crossref article carries. It returns nil when there's no journal
+// title, which Crossref requires.
+func journalContext(record *hubv1.Record) *hubv1.PublicationDetails {
+	pub := &hubv1.PublicationDetails{}
+	if record.Publication != nil {
+		pub = proto.Clone(record.Publication).(*hubv1.PublicationDetails)
+	}
+
+	if pub.Title == "" {
+		for _, rel := range hub.GetRelationsByType(record, hubv1.RelationType_RELATION_TYPE_PART_OF) {
+			if rel.TargetTitle != "" {
+				pub.Title = rel.TargetTitle
+				break
+			}
+		}
+	}
+	if pub.Title == "" {
+		return nil
+	}
+
+	if pub.Volume == "" {
+		pub.Volume = hub.GetExtraString(record, "volume")
+	}
+	if pub.Issue == "" {
+		pub.Issue = hub.GetExtraString(record, "issue")
+	}
+	if pub.Pages == "" {
+		pub.Pages = hub.GetExtraString(record, "first_page")
+		if last := hub.GetExtraString(record, "last_page"); last != "" && pub.Pages != "" {
+			pub.Pages += "-" + last
+		}
+	}
+	return pub
+}
+
+// buildJournal deposits an article under its journal: the journal's title
+// and ISSNs, the volume and issue, and the article itself.
+func buildJournal(record *hubv1.Record, pub *hubv1.PublicationDetails) *crossrefv1.Journal {
+	jm := &crossrefv1.JournalMetadata{FullTitle: pub.Title}
+
+	// The first ISSN is taken as the print ISSN, a second as the electronic
+	var issns []string
+	for _, issn := range []string{pub.Issn, pub.LIssn} {
+		if issn != "" && !slices.Contains(issns, issn) {
+			issns = append(issns, issn)
+		}
+	}
+	for _, id := range record.Identifiers {
+		if id.Type == hubv1.IdentifierType_IDENTIFIER_TYPE_ISSN && !slices.Contains(issns, id.Value) {
+			issns = append(issns, id.Value)
+		}
+	}
+	if len(issns) > 0 {
+		jm.IssnPrint = issns[0]
+	}
+	if len(issns) > 1 {
+		jm.IssnElectronic = issns[1]
+	}
+
+	article := &crossrefv1.JournalArticle{
+		Titles:           buildTitles(record),
+		Contributors:     buildContributors(record.Contributors),
+		Abstract:         record.Abstract,
+		DoiData:          buildDoiData(record),
+		Program:          buildFunding(record),
+		AccessIndicators: buildAccessIndicators(record),
+	}
+
+	for _, d := range record.Dates {
+		if d.Type == hubv1.DateType_DATE_TYPE_ISSUED || d.Type == hubv1.DateType_DATE_TYPE_PUBLISHED {
+			article.PublicationDate = buildPublicationDate(d)
+			break
+		}
+	}
+
+	if first, last := helpers.SplitPages(pub.Pages); first != "" {
+		article.Pages = &crossrefv1.Pages{FirstPage: first, LastPage: last}
+	}
+
+	journal := &crossrefv1.Journal{
+		JournalMetadata: jm,
+		JournalArticle:  []*crossrefv1.JournalArticle{article},
+	}
+	if pub.Volume != "" || pub.Issue != "" {
+		issue := &crossrefv1.JournalIssue{
+			PublicationDate: article.PublicationDate,
+			Issue:           pub.Issue,
+		}
+		if pub.Volume != "" {
+			issue.JournalVolume = &crossrefv1.JournalVolume{Volume: pub.Volume}
+		}
+		journal.JournalIssue = append(journal.JournalIssue, issue)
+	}
+
+	return journal
+}
+
 func buildDissertation(record *hubv1.Record) *crossrefv1.Dissertation {
 	diss := &crossrefv1.Dissertation{
 		Titles:   buildTitles(record),
 		Abstract: record.Abstract,
 	}
 
-	// Author (first contributor)
-	if len(record.Contributors) > 0 {
-		diss.PersonName = buildPersonName(record.Contributors[0], "author", "first")
+	// Author: the first contributor who isn't an advisor or committee
+	// member. Schema 5.3.1 has no contributors element for dissertations,
+	// so those aren't deposited.
+	for _, c := range record.Contributors {
+		if hub.DegreeRoleOf(c) == hubv1.DegreeRole_DEGREE_ROLE_UNSPECIFIED {
+			diss.PersonName = buildPersonName(c, "author", "first")
+			break
+		}
 	}
 
 	// Approval date
@@ -115,16 +252,22 @@ func buildDissertation(record *hubv1.Record) *crossrefv1.Dissertation {
 
 	// DOI
 	diss.DoiData = buildDoiData(record)
+	diss.Assertion = buildAssertions(record)
+	diss.Program = buildFunding(record)
+	diss.AccessIndicators = buildAccessIndicators(record)
 
 	return diss
 }
 
 func buildPostedContent(record *hubv1.Record) *crossrefv1.PostedContent {
 	pc := &crossrefv1.PostedContent{
-		Titles:       buildTitles(record),
-		Contributors: buildContributors(record.Contributors),
-		Abstract:     record.Abstract,
-		DoiData:      buildDoiData(record),
+		Titles:           buildTitles(record),
+		Contributors:     buildContributors(record.Contributors),
+		Abstract:         record.Abstract,
+		DoiData:          buildDoiData(record),
+		Assertion:        buildAssertions(record),
+		Program:          buildFunding(record),
+		AccessIndicators: buildAccessIndicators(record),
 	}
 
 	// Type
@@ -147,9 +290,12 @@ func buildPostedContent(record *hubv1.Record) *crossrefv1.PostedContent {
 
 func buildDataset(record *hubv1.Record) *crossrefv1.Dataset {
 	ds := &crossrefv1.Dataset{
-		Titles:       buildTitles(record),
-		Contributors: buildContributors(record.Contributors),
-		DoiData:      buildDoiData(record),
+		Titles:           buildTitles(record),
+		Contributors:     buildContributors(record.Contributors),
+		DoiData:          buildDoiData(record),
+		Assertion:        buildAssertions(record),
+		Program:          buildFunding(record),
+		AccessIndicators: buildAccessIndicators(record),
 	}
 
 	// Publication date
@@ -167,10 +313,13 @@ func buildBook(record *hubv1.Record) *crossrefv1.Book {
 	book := &crossrefv1.Book{
 		BookType: "monograph",
 		BookMetadata: &crossrefv1.BookMetadata{
-			Titles:        buildTitles(record),
-			Contributors:  buildContributors(record.Contributors),
-			DoiData:       buildDoiData(record),
-			EditionNumber: record.Edition,
+			Titles:           buildTitles(record),
+			Contributors:     buildContributors(record.Contributors),
+			DoiData:          buildDoiData(record),
+			EditionNumber:    record.Edition,
+			Assertion:        buildAssertions(record),
+			Program:          buildFunding(record),
+			AccessIndicators: buildAccessIndicators(record),
 		},
 	}
 
@@ -182,11 +331,15 @@ func buildBook(record *hubv1.Record) *crossrefv1.Book {
 		}
 	}
 
-	// Publisher
+	// Publisher, with the place in its normalized form when it resolves
 	if record.Publisher != "" {
+		publisherPlace := record.PlacePublished
+		if p := place.Published(record); p != nil {
+			publisherPlace = p.Name
+		}
 		book.BookMetadata.Publisher = &crossrefv1.Publisher{
 			PublisherName:  record.Publisher,
-			PublisherPlace: record.PlacePublished,
+			PublisherPlace: publisherPlace,
 		}
 	}
 
@@ -213,23 +366,37 @@ func buildTitles(record *hubv1.Record) *crossrefv1.Titles {
 func buildContributors(contributors []*hubv1.Contributor) *crossrefv1.Contributors {
 	result := &crossrefv1.Contributors{}
 
-	for i, c := range contributors {
+	for _, c := range contributors {
+		// Crossref has no contributor role for advisors or committee members
+		if hub.DegreeRoleOf(c) != hubv1.DegreeRole_DEGREE_ROLE_UNSPECIFIED {
+			continue
+		}
 		sequence := "additional"
-		if i == 0 {
+		if len(result.PersonName) == 0 {
 			sequence = "first"
 		}
 
-		role := "author"
-		if strings.ToLower(c.Role) == "editor" || strings.ToLower(c.Role) == "edt" {
-			role = "editor"
-		}
-
-		result.PersonName = append(result.PersonName, buildPersonName(c, role, sequence))
+		result.PersonName = append(result.PersonName, buildPersonName(c, contributorRole(c), sequence))
 	}
 
 	return result
 }
 
+// contributorRole maps a contributor's relator, from its role code or role
+// label, to a Crossref contributor_role, defaulting to author.
+func contributorRole(c *hubv1.Contributor) string {
+	switch cmp.Or(helpers.RelatorCode(c.RoleCode), helpers.RelatorCode(c.Role)) {
+	case "edt", "edc":
+		return "editor"
+	case "trl":
+		return "translator"
+	case "rev":
+		return "reviewer"
+	default:
+		return "author"
+	}
+}
+
 func buildPersonName(c *hubv1.Contributor, role, sequence string) *crossrefv1.PersonName {
 	pn := &crossrefv1.PersonName{
 		ContributorRole: role,
@@ -294,8 +461,76 @@ func buildDoiData(record *hubv1.Record) *crossrefv1.DoiData {
 	return doiData
 }
 
+// buildFunding maps funders to FundRef funding information. Crossref only
+// accepts Funder Registry DOIs as funder identifiers, so other identifiers
+// (ROR, ISNI) are left for the funder name to be matched on.
+func buildFunding(record *hubv1.Record) []*crossrefv1.FundingInfo {
+	var program []*crossrefv1.FundingInfo
+	for _, f := range record.Funders {
+		fi := &crossrefv1.FundingInfo{
+			Name:        strings.TrimSpace(f.Name),
+			AwardNumber: f.AwardNumbers,
+		}
+		if doi := hub.NormalizeIdentifier(f.Identifier, hubv1.IdentifierType_IDENTIFIER_TYPE_DOI); strings.HasPrefix(doi, "10.") {
+			fi.FunderIdentifier = "https://doi.org/" + doi
+		}
+		if fi.Name == "" && fi.FunderIdentifier == "" {
+			continue
+		}
+		program = append(program, fi)
+	}
+	return program
+}
+
+// buildAccessIndicators lists the record's license URIs in the
+// AccessIndicators program, where Crossref's metadata consumers look for
+// them.
+func buildAccessIndicators(record *hubv1.Record) *crossrefv1.AccessIndicators {
+	var licenses []*crossrefv1.License
+	for _, r := range record.Rights {
+		uri := strings.TrimSpace(r.Uri)
+		if uri == "" || slices.ContainsFunc(licenses, func(l *crossrefv1.License) bool { return l.LicenseRef == uri }) {
+			continue
+		}
+		licenses = append(licenses, &crossrefv1.License{LicenseRef: uri})
+	}
+	if len(licenses) == 0 {
+		return nil
+	}
+	return &crossrefv1.AccessIndicators{Name: "AccessIndicators", LicenseRef: licenses}
+}
+
+// buildAssertions maps copyright information to Crossmark assertions in
+// the copyright_and_licensing group. License URIs go in the
+// AccessIndicators program instead.
+func buildAssertions(record *hubv1.Record) []*crossrefv1.Assertion {
+	var assertions []*crossrefv1.Assertion
+	add := func(name, label, value string) {
+		if value == "" {
+			return
+		}
+		assertions = append(assertions, &crossrefv1.Assertion{
+			Name:       name,
+			Label:      label,
+			GroupName:  "copyright_and_licensing",
+			GroupLabel: "Copyright & Licensing",
+			Value:      value,
+		})
+	}
+
+	add("copyright_statement", "Copyright Statement", record.CopyrightStatement)
+	add("copyright_holder", "Copyright Holder", hub.RightsHolder(record))
+	if year := hub.CopyrightYear(record); year > 0 {
+		add("copyright_year", "Copyright Year", strconv.Itoa(int(year)))
+	}
+
+	return assertions
+}
+
 // spokeToXML converts spoke proto structs to XML-marshalable types.
-func spokeToXML(spoke *crossrefv1.Deposit) *XMLDeposit {
+// Crossmark assertions are written only under the depositor's Crossmark
+// policy DOI, which the schema requires.
+func spokeToXML(spoke *crossrefv1.Deposit, crossmarkPolicy string) *XMLDeposit {
 	deposit := &XMLDeposit{
 		XMLNS:     "http://www.crossref.org/schema/5.3.1",
 		XSI:       "http://www.w3.org/2001/XMLSchema-instance",
@@ -319,34 +554,108 @@ func spokeToXML(spoke *crossrefv1.Deposit) *XMLDeposit {
 
 	deposit.Body = &XMLBody{}
 
+	// Journals
+	for _, j := range spoke.Body.Journal {
+		deposit.Body.Journal = append(deposit.Body.Journal, journalToXML(j))
+	}
+
 	// Dissertations
 	for _, diss := range spoke.Body.Dissertation {
-		xmlDiss := dissertationToXML(diss)
+		xmlDiss := dissertationToXML(diss, crossmarkPolicy)
 		deposit.Body.Dissertation = append(deposit.Body.Dissertation, xmlDiss)
 	}
 
 	// Posted content
 	for _, pc := range spoke.Body.PostedContent {
-		xmlPC := postedContentToXML(pc)
+		xmlPC := postedContentToXML(pc, crossmarkPolicy)
 		deposit.Body.PostedContent = append(deposit.Body.PostedContent, xmlPC)
 	}
 
 	// Datasets
 	for _, ds := range spoke.Body.Dataset {
-		xmlDS := datasetToXML(ds)
+		xmlDS := datasetToXML(ds, crossmarkPolicy)
 		deposit.Body.Dataset = append(deposit.Body.Dataset, xmlDS)
 	}
 
 	// Books
 	for _, book := range spoke.Body.Book {
-		xmlBook := bookToXML(book)
+		xmlBook := bookToXML(book, crossmarkPolicy)
 		deposit.Body.Book = append(deposit.Body.Book, xmlBook)
 	}
 
 	return deposit
 }
 
-func dissertationToXML(diss *crossrefv1.Dissertation) *XMLDissertation {
+func journalToXML(j *crossrefv1.Journal) *XMLJournal {
+	xmlJ := &XMLJournal{}
+
+	if jm := j.JournalMetadata; jm != nil {
+		xmlJ.JournalMetadata = &XMLJournalMetadata{FullTitle: jm.FullTitle}
+		if jm.IssnPrint != "" {
+			xmlJ.JournalMetadata.ISSN = append(xmlJ.JournalMetadata.ISSN, &XMLISSN{MediaType: "print", Value: jm.IssnPrint})
+		}
+		if jm.IssnElectronic != "" {
+			xmlJ.JournalMetadata.ISSN = append(xmlJ.JournalMetadata.ISSN, &XMLISSN{MediaType: "electronic", Value: jm.IssnElectronic})
+		}
+	}
+
+	for _, issue := range j.JournalIssue {
+		xmlIssue := &XMLJournalIssue{Issue: issue.Issue}
+		if issue.PublicationDate != nil {
+			xmlIssue.PublicationDate = publicationDateToXML(issue.PublicationDate)
+		}
+		if issue.JournalVolume != nil {
+			xmlIssue.JournalVolume = &XMLJournalVolume{Volume: issue.JournalVolume.Volume}
+		}
+		xmlJ.JournalIssue = append(xmlJ.JournalIssue, xmlIssue)
+	}
+
+	for _, article := range j.JournalArticle {
+		xmlJ.JournalArticle = append(xmlJ.JournalArticle, journalArticleToXML(article))
+	}
+
+	return xmlJ
+}
+
+func journalArticleToXML(article *crossrefv1.JournalArticle) *XMLJournalArticle {
+	xmlArticle := &XMLJournalArticle{
+		PublicationType: "full_text",
+	}
+
+	if article.Titles != nil {
+		xmlArticle.Titles = titlesToXML(article.Titles)
+	}
+
+	if article.Contributors != nil {
+		xmlArticle.Contributors = contributorsToXML(article.Contributors)
+	}
+
+	if article.Abstract != "" {
+		xmlArticle.Abstract = &XMLAbstract{Content: article.Abstract}
+	}
+
+	if article.PublicationDate != nil {
+		xmlArticle.PublicationDate = publicationDateToXML(article.PublicationDate)
+	}
+
+	if article.Pages != nil {
+		xmlArticle.Pages = &XMLPages{
+			FirstPage: article.Pages.FirstPage,
+			LastPage:  article.Pages.LastPage,
+		}
+	}
+
+	xmlArticle.FundRef = fundingToXML(article.Program)
+	xmlArticle.AccessIndicators = accessIndicatorsToXML(article.AccessIndicators)
+
+	if article.DoiData != nil && article.DoiData.Doi != "" {
+		xmlArticle.DoiData = doiDataToXML(article.DoiData)
+	}
+
+	return xmlArticle
+}
+
+func dissertationToXML(diss *crossrefv1.Dissertation, crossmarkPolicy string) *XMLDissertation {
 	xmlDiss := &XMLDissertation{
 		Degree: diss.Degree,
 	}
@@ -373,6 +682,10 @@ func dissertationToXML(diss *crossrefv1.Dissertation) *XMLDissertation {
 		xmlDiss.Abstract = &XMLAbstract{Content: diss.Abstract}
 	}
 
+	xmlDiss.Crossmark = crossmarkToXML(diss.Assertion, crossmarkPolicy)
+	xmlDiss.FundRef = fundingToXML(diss.Program)
+	xmlDiss.AccessIndicators = accessIndicatorsToXML(diss.AccessIndicators)
+
 	if diss.DoiData != nil && diss.DoiData.Doi != "" {
 		xmlDiss.DoiData = doiDataToXML(diss.DoiData)
 	}
@@ -380,7 +693,7 @@ func dissertationToXML(diss *crossrefv1.Dissertation) *XMLDissertation {
 	return xmlDiss
 }
 
-func postedContentToXML(pc *crossrefv1.PostedContent) *XMLPostedContent {
+func postedContentToXML(pc *crossrefv1.PostedContent, crossmarkPolicy string) *XMLPostedContent {
 	xmlPC := &XMLPostedContent{
 		Type: pc.Type,
 	}
@@ -401,6 +714,10 @@ func postedContentToXML(pc *crossrefv1.PostedContent) *XMLPostedContent {
 		xmlPC.Abstract = &XMLAbstract{Content: pc.Abstract}
 	}
 
+	xmlPC.Crossmark = crossmarkToXML(pc.Assertion, crossmarkPolicy)
+	xmlPC.FundRef = fundingToXML(pc.Program)
+	xmlPC.AccessIndicators = accessIndicatorsToXML(pc.AccessIndicators)
+
 	if pc.DoiData != nil && pc.DoiData.Doi != "" {
 		xmlPC.DoiData = doiDataToXML(pc.DoiData)
 	}
@@ -408,7 +725,7 @@ func postedContentToXML(pc *crossrefv1.PostedContent) *XMLPostedContent {
 	return xmlPC
 }
 
-func datasetToXML(ds *crossrefv1.Dataset) *XMLDataset {
+func datasetToXML(ds *crossrefv1.Dataset, crossmarkPolicy string) *XMLDataset {
 	xmlDS := &XMLDataset{
 		DatasetType: "record",
 	}
@@ -425,6 +742,10 @@ func datasetToXML(ds *crossrefv1.Dataset) *XMLDataset {
 		xmlDS.DatabaseDate = publicationDateToXML(ds.PublicationDate)
 	}
 
+	xmlDS.Crossmark = crossmarkToXML(ds.Assertion, crossmarkPolicy)
+	xmlDS.FundRef = fundingToXML(ds.Program)
+	xmlDS.AccessIndicators = accessIndicatorsToXML(ds.AccessIndicators)
+
 	if ds.DoiData != nil && ds.DoiData.Doi != "" {
 		xmlDS.DoiData = doiDataToXML(ds.DoiData)
 	}
@@ -432,7 +753,7 @@ func datasetToXML(ds *crossrefv1.Dataset) *XMLDataset {
 	return xmlDS
 }
 
-func bookToXML(book *crossrefv1.Book) *XMLBook {
+func bookToXML(book *crossrefv1.Book, crossmarkPolicy string) *XMLBook {
 	xmlBook := &XMLBook{
 		BookType: book.BookType,
 	}
@@ -463,6 +784,10 @@ func bookToXML(book *crossrefv1.Book) *XMLBook {
 			}
 		}
 
+		xmlBook.BookMetadata.Crossmark = crossmarkToXML(book.BookMetadata.Assertion, crossmarkPolicy)
+		xmlBook.BookMetadata.FundRef = fundingToXML(book.BookMetadata.Program)
+		xmlBook.BookMetadata.AccessIndicators = accessIndicatorsToXML(book.BookMetadata.AccessIndicators)
+
 		if book.BookMetadata.DoiData != nil && book.BookMetadata.DoiData.Doi != "" {
 			xmlBook.BookMetadata.DoiData = doiDataToXML(book.BookMetadata.DoiData)
 		}
@@ -471,6 +796,74 @@ func bookToXML(book *crossrefv1.Book) *XMLBook {
 	return xmlBook
 }
 
+// crossmarkToXML writes assertions as Crossmark custom metadata under the
+// policy DOI. Without assertions or a policy there is no crossmark.
+func crossmarkToXML(assertions []*crossrefv1.Assertion, policy string) *XMLCrossmark {
+	if len(assertions) == 0 || policy == "" {
+		return nil
+	}
+	crossmark := &XMLCrossmark{Policy: policy}
+	for _, a := range assertions {
+		crossmark.Assertions = append(crossmark.Assertions, &XMLAssertion{
+			Name:       a.Name,
+			Label:      a.Label,
+			GroupName:  a.GroupName,
+			GroupLabel: a.GroupLabel,
+			Value:      a.Value,
+		})
+	}
+	return crossmark
+}
+
+// fundingToXML writes funding information as a FundRef program: a
+// fundgroup per funder, holding the funder's name with its identifier
+// nested inside, and its award numbers.
+func fundingToXML(program []*crossrefv1.FundingInfo) *XMLFundRef {
+	if len(program) == 0 {
+		return nil
+	}
+	fundRef := &XMLFundRef{
+		XMLNS: "http://www.crossref.org/fundref.xsd",
+		Name:  "fundref",
+	}
+	for _, fi := range program {
+		group := &XMLFundAssertion{Name: "fundgroup"}
+		var id *XMLFundAssertion
+		if fi.FunderIdentifier != "" {
+			id = &XMLFundAssertion{Name: "funder_identifier", Value: fi.FunderIdentifier}
+		}
+		switch {
+		case fi.Name != "":
+			name := &XMLFundAssertion{Name: "funder_name", Value: fi.Name}
+			if id != nil {
+				name.Assertions = append(name.Assertions, id)
+			}
+			group.Assertions = append(group.Assertions, name)
+		case id != nil:
+			group.Assertions = append(group.Assertions, id)
+		}
+		for _, award := range fi.AwardNumber {
+			group.Assertions = append(group.Assertions, &XMLFundAssertion{Name: "award_number", Value: award})
+		}
+		fundRef.Assertions = append(fundRef.Assertions, group)
+	}
+	return fundRef
+}
+
+func accessIndicatorsToXML(ai *crossrefv1.AccessIndicators) *XMLAccessIndicators {
+	if ai == nil {
+		return nil
+	}
+	result := &XMLAccessIndicators{
+		XMLNS: "http://www.crossref.org/AccessIndicators.xsd",
+		Name:  ai.Name,
+	}
+	for _, l := range ai.LicenseRef {
+		result.LicenseRef = append(result.LicenseRef, l.LicenseRef)
+	}
+	return result
+}
+
 func titlesToXML(titles *crossrefv1.Titles) *XMLTitles {
 	return &XMLTitles{
 		Title:    titles.Title,
@@ -540,37 +933,113 @@ type XMLDepositor struct {
 }
 
 type XMLBody struct {
+	Journal       []*XMLJournal       `xml:"journal,omitempty"`
 	Dissertation  []*XMLDissertation  `xml:"dissertation,omitempty"`
 	PostedContent []*XMLPostedContent `xml:"posted_content,omitempty"`
 	Dataset       []*XMLDataset       `xml:"database>dataset,omitempty"`
 	Book          []*XMLBook          `xml:"book,omitempty"`
 }
 
+type XMLJournal struct {
+	JournalMetadata *XMLJournalMetadata  `xml:"journal_metadata,omitempty"`
+	JournalIssue    []*XMLJournalIssue   `xml:"journal_issue,omitempty"`
+	JournalArticle  []*XMLJournalArticle `xml:"journal_article,omitempty"`
+}
+
+type XMLJournalMetadata struct {
+	FullTitle string     `xml:"full_title"`
+	ISSN      []*XMLISSN `xml:"issn,omitempty"`
+}
+
+type XMLISSN struct {
+	MediaType string `xml:"media_type,attr,omitempty"`
+	Value     string `xml:",chardata"`
+}
+
+type XMLJournalIssue struct {
+	PublicationDate *XMLPublicationDate `xml:"publication_date,omitempty"`
+	JournalVolume   *XMLJournalVolume   `xml:"journal_volume,omitempty"`
+	Issue           string              `xml:"issue,omitempty"`
+}
+
+type XMLJournalVolume struct {
+	Volume string `xml:"volume"`
+}
+
+type XMLJournalArticle struct {
+	PublicationType  string               `xml:"publication_type,attr"`
+	Titles           *XMLTitles           `xml:"titles,omitempty"`
+	Contributors     *XMLContributors     `xml:"contributors,omitempty"`
+	Abstract         *XMLAbstract         `xml:"abstract,omitempty"`
+	PublicationDate  *XMLPublicationDate  `xml:"publication_date,omitempty"`
+	Pages            *XMLPages            `xml:"pages,omitempty"`
+	FundRef          *XMLFundRef          `xml:"fr:program,omitempty"`
+	AccessIndicators *XMLAccessIndicators `xml:"ai:program,omitempty"`
+	DoiData          *XMLDoiData          `xml:"doi_data,omitempty"`
+}
+
+type XMLPages struct {
+	FirstPage string `xml:"first_page"`
+	LastPage  string `xml:"last_page,omitempty"`
+}
+
+// XMLFundRef is the FundRef program, declaring its own namespace prefix.
+type XMLFundRef struct {
+	XMLNS      string              `xml:"xmlns:fr,attr"`
+	Name       string              `xml:"name,attr"`
+	Assertions []*XMLFundAssertion `xml:"fr:assertion"`
+}
+
+// XMLFundAssertion is a FundRef assertion. A funder_name assertion holds
+// its funder_identifier after the name.
+type XMLFundAssertion struct {
+	Name       string              `xml:"name,attr"`
+	Value      string              `xml:",chardata"`
+	Assertions []*XMLFundAssertion `xml:"fr:assertion,omitempty"`
+}
+
+// XMLAccessIndicators is the AccessIndicators program, declaring its own
+// namespace prefix.
+type XMLAccessIndicators struct {
+	XMLNS      string   `xml:"xmlns:ai,attr"`
+	Name       string   `xml:"name,attr"`
+	LicenseRef []string `xml:"ai:license_ref"`
+}
+
 type XMLDissertation struct {
-	Titles       *XMLTitles          `xml:"titles,omitempty"`
-	PersonName   *XMLPersonName      `xml:"person_name,omitempty"`
-	ApprovalDate *XMLPublicationDate `xml:"approval_date,omitempty"`
-	Institution  *XMLInstitution     `xml:"institution,omitempty"`
-	Degree       string              `xml:"degree,omitempty"`
-	Abstract     *XMLAbstract        `xml:"abstract,omitempty"`
-	DoiData      *XMLDoiData         `xml:"doi_data,omitempty"`
+	Titles           *XMLTitles           `xml:"titles,omitempty"`
+	PersonName       *XMLPersonName       `xml:"person_name,omitempty"`
+	ApprovalDate     *XMLPublicationDate  `xml:"approval_date,omitempty"`
+	Institution      *XMLInstitution      `xml:"institution,omitempty"`
+	Degree           string               `xml:"degree,omitempty"`
+	Abstract         *XMLAbstract         `xml:"abstract,omitempty"`
+	Crossmark        *XMLCrossmark        `xml:"crossmark,omitempty"`
+	FundRef          *XMLFundRef          `xml:"fr:program,omitempty"`
+	AccessIndicators *XMLAccessIndicators `xml:"ai:program,omitempty"`
+	DoiData          *XMLDoiData          `xml:"doi_data,omitempty"`
 }
 
 type XMLPostedContent struct {
-	Type         string              `xml:"type,attr"`
-	Titles       *XMLTitles          `xml:"titles,omitempty"`
-	Contributors *XMLContributors    `xml:"contributors,omitempty"`
-	PostedDate   *XMLPublicationDate `xml:"posted_date,omitempty"`
-	Abstract     *XMLAbstract        `xml:"abstract,omitempty"`
-	DoiData      *XMLDoiData         `xml:"doi_data,omitempty"`
+	Type             string               `xml:"type,attr"`
+	Titles           *XMLTitles           `xml:"titles,omitempty"`
+	Contributors     *XMLContributors     `xml:"contributors,omitempty"`
+	PostedDate       *XMLPublicationDate  `xml:"posted_date,omitempty"`
+	Abstract         *XMLAbstract         `xml:"abstract,omitempty"`
+	Crossmark        *XMLCrossmark        `xml:"crossmark,omitempty"`
+	FundRef          *XMLFundRef          `xml:"fr:program,omitempty"`
+	AccessIndicators *XMLAccessIndicators `xml:"ai:program,omitempty"`
+	DoiData          *XMLDoiData          `xml:"doi_data,omitempty"`
 }
 
 type XMLDataset struct {
-	DatasetType  string              `xml:"dataset_type,attr"`
-	Titles       *XMLTitles          `xml:"titles,omitempty"`
-	Contributors *XMLContributors    `xml:"contributors,omitempty"`
-	DatabaseDate *XMLPublicationDate `xml:"database_date>publication_date,omitempty"`
-	DoiData      *XMLDoiData         `xml:"doi_data,omitempty"`
+	DatasetType      string               `xml:"dataset_type,attr"`
+	Titles           *XMLTitles           `xml:"titles,omitempty"`
+	Contributors     *XMLContributors     `xml:"contributors,omitempty"`
+	DatabaseDate     *XMLPublicationDate  `xml:"database_date>publication_date,omitempty"`
+	Crossmark        *XMLCrossmark        `xml:"crossmark,omitempty"`
+	FundRef          *XMLFundRef          `xml:"fr:program,omitempty"`
+	AccessIndicators *XMLAccessIndicators `xml:"ai:program,omitempty"`
+	DoiData          *XMLDoiData          `xml:"doi_data,omitempty"`
 }
 
 type XMLBook struct {
@@ -579,14 +1048,17 @@ type XMLBook struct {
 }
 
 type XMLBookMetadata struct {
-	Titles          *XMLTitles          `xml:"titles,omitempty"`
-	Contributors    *XMLContributors    `xml:"contributors,omitempty"`
-	PublicationDate *XMLPublicationDate `xml:"publication_date,omitempty"`
-	IsbnPrint       string              `xml:"isbn,omitempty"`
-	IsbnElectronic  string              `xml:"noisbn,omitempty"`
-	Publisher       *XMLPublisher       `xml:"publisher,omitempty"`
-	EditionNumber   string              `xml:"edition_number,omitempty"`
-	DoiData         *XMLDoiData         `xml:"doi_data,omitempty"`
+	Titles           *XMLTitles           `xml:"titles,omitempty"`
+	Contributors     *XMLContributors     `xml:"contributors,omitempty"`
+	PublicationDate  *XMLPublicationDate  `xml:"publication_date,omitempty"`
+	IsbnPrint        string               `xml:"isbn,omitempty"`
+	IsbnElectronic   string               `xml:"noisbn,omitempty"`
+	Publisher        *XMLPublisher        `xml:"publisher,omitempty"`
+	EditionNumber    string               `xml:"edition_number,omitempty"`
+	Crossmark        *XMLCrossmark        `xml:"crossmark,omitempty"`
+	FundRef          *XMLFundRef          `xml:"fr:program,omitempty"`
+	AccessIndicators *XMLAccessIndicators `xml:"ai:program,omitempty"`
+	DoiData          *XMLDoiData          `xml:"doi_data,omitempty"`
 }
 
 type XMLTitles struct {
@@ -631,3 +1103,18 @@ type XMLDoiData struct {
 type XMLAbstract struct {
 	Content string `xml:",chardata"`
 }
+
+// XMLCrossmark is the Crossmark block, whose policy DOI the schema
+// requires before any custom metadata.
+type XMLCrossmark struct {
+	Policy     string          `xml:"crossmark_policy"`
+	Assertions []*XMLAssertion `xml:"custom_metadata>assertion"`
+}
+
+type XMLAssertion struct {
+	Name       string `xml:"name,attr"`
+	Label      string `xml:"label,attr,omitempty"`
+	GroupName  string `xml:"group_name,attr,omitempty"`
+	GroupLabel string `xml:"group_label,attr,omitempty"`
+	Value      string `xml:",chardata"`
+}
@@ -0,0 +1,295 @@
+package crossref
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+func TestSerializeJournalArticle(t *testing.T) {
+	record := &hubv1.Record{
+		Title:        "Bethlehem Steel and the Lehigh Valley",
+		ResourceType: &hubv1.ResourceType{Type: hubv1.ResourceTypeValue_RESOURCE_TYPE_ARTICLE},
+		Contributors: []*hubv1.Contributor{
+			{Name: "Smith, Alice", ParsedName: &hubv1.ParsedName{Given: "Alice", Family: "Smith"}, Role: "author"},
+		},
+		Dates: []*hubv1.DateValue{
+			{Type: hubv1.DateType_DATE_TYPE_ISSUED, Year: 2024, Month: 5, Precision: hubv1.DatePrecision_DATE_PRECISION_MONTH},
+		},
+		Identifiers: []*hubv1.Identifier{
+			hub.NewIdentifier("10.1234/steel.2024.7", hubv1.IdentifierType_IDENTIFIER_TYPE_DOI),
+			hub.NewIdentifier("https://preserve.example.edu/node/7", hubv1.IdentifierType_IDENTIFIER_TYPE_URL),
+			hub.NewIdentifier("2345-6789", hubv1.IdentifierType_IDENTIFIER_TYPE_ISSN),
+		},
+		Publication: &hubv1.PublicationDetails{
+			Title:  "Pennsylvania History",
+			Volume: "91",
+			Issue:  "2",
+			Pages:  "123–145",
+			Issn:   "1234-5678",
+		},
+		Rights: []*hubv1.Rights{hub.NewRightsFromURI("https://creativecommons.org/licenses/by/4.0/")},
+	}
+
+	f := &Format{}
+	var buf bytes.Buffer
+	if err := f.Serialize(&buf, []*hubv1.Record{record}, nil); err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		`<journal><journal_metadata><full_title>Pennsylvania History</full_title>`,
+		`<issn media_type="print">1234-5678</issn><issn media_type="electronic">2345-6789</issn>`,
+		`<journal_volume><volume>91</volume></journal_volume><issue>2</issue>`,
+		`<journal_article publication_type="full_text">`,
+		`<pages><first_page>123</first_page><last_page>145</last_page></pages>`,
+		`<ai:license_ref>https://creativecommons.org/licenses/by/4.0/</ai:license_ref>`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %s:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "<posted_content") {
+		t.Errorf("article with a journal written as posted content:\n%s", out)
+	}
+
+	records, err := f.Parse(strings.NewReader(out), nil)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("expected 1 record, got %d", len(records))
+	}
+	r := records[0]
+	if r.Title != record.Title {
+		t.Errorf("Title: got %q", r.Title)
+	}
+	if rels := hub.GetRelationsByType(r, hubv1.RelationType_RELATION_TYPE_PART_OF); len(rels) != 1 || rels[0].TargetTitle != "Pennsylvania History" {
+		t.Errorf("journal relation: got %v", rels)
+	}
+	for key, want := range map[string]string{"volume": "91", "issue": "2", "first_page": "123", "last_page": "145"} {
+		if got := hub.GetExtraString(r, key); got != want {
+			t.Errorf("extra %s: got %q, want %q", key, got, want)
+		}
+	}
+	if len(r.Rights) != 1 || r.Rights[0].Uri != "https://creativecommons.org/licenses/by/4.0/" {
+		t.Errorf("Rights: got %v", r.Rights)
+	}
+
+	// Serializing the parsed record again finds the journal in the
+	// relation and extras
+	buf.Reset()
+	if err := f.Serialize(&buf, records, nil); err != nil {
+		t.Fatalf("Serialize of parsed record failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), `<journal_volume><volume>91</volume></journal_volume>`) {
+		t.Errorf("round trip lost the journal issue:\n%s", buf.String())
+	}
+}
+
+func TestSerializeArticleWithoutJournal(t *testing.T) {
+	record := &hubv1.Record{
+		Title:        "Working Paper on Canal Freight",
+		ResourceType: &hubv1.ResourceType{Type: hubv1.ResourceTypeValue_RESOURCE_TYPE_ARTICLE},
+		Identifiers: []*hubv1.Identifier{
+			hub.NewIdentifier("10.1234/canal", hubv1.IdentifierType_IDENTIFIER_TYPE_DOI),
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := (&Format{}).Serialize(&buf, []*hubv1.Record{record}, nil); err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), `<posted_content type="other">`) || strings.Contains(buf.String(), "<journal>") {
+		t.Errorf("article without a journal should be posted content:\n%s", buf.String())
+	}
+}
+
+func TestSerializeFundingAndLicenses(t *testing.T) {
+	record := &hubv1.Record{
+		Title:        "Corrosion in Riveted Bridge Members",
+		ResourceType: &hubv1.ResourceType{Type: hubv1.ResourceTypeValue_RESOURCE_TYPE_DISSERTATION},
+		Identifiers: []*hubv1.Identifier{
+			hub.NewIdentifier("10.1234/etd.2024.3", hubv1.IdentifierType_IDENTIFIER_TYPE_DOI),
+		},
+		Funders: []*hubv1.Funder{
+			{Name: "National Science Foundation", Identifier: "https://doi.org/10.13039/100000001", AwardNumbers: []string{"CMMI-1234567"}},
+			{Name: "Lehigh University", Identifier: "https://ror.org/012afjb06", IdentifierType: "ROR"},
+			{AwardNumbers: []string{"orphan"}},
+		},
+		Rights: []*hubv1.Rights{
+			hub.NewRightsFromURI("https://creativecommons.org/licenses/by/4.0/"),
+			hub.NewRightsFromURI("https://creativecommons.org/licenses/by/4.0/"),
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := (&Format{}).Serialize(&buf, []*hubv1.Record{record}, nil); err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		`<fr:program xmlns:fr="http://www.crossref.org/fundref.xsd" name="fundref">`,
+		`<fr:assertion name="fundgroup"><fr:assertion name="funder_name">National Science Foundation<fr:assertion name="funder_identifier">https://doi.org/10.13039/100000001</fr:assertion></fr:assertion><fr:assertion name="award_number">CMMI-1234567</fr:assertion></fr:assertion>`,
+		`<fr:assertion name="fundgroup"><fr:assertion name="funder_name">Lehigh University</fr:assertion></fr:assertion>`,
+		`<ai:program xmlns:ai="http://www.crossref.org/AccessIndicators.xsd" name="AccessIndicators"><ai:license_ref>https://creativecommons.org/licenses/by/4.0/</ai:license_ref></ai:program>`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %s:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "orphan") {
+		t.Errorf("funder without a name or DOI was written:\n%s", out)
+	}
+	if strings.Count(out, "<ai:license_ref>") != 1 {
+		t.Errorf("duplicate license written:\n%s", out)
+	}
+
+	if _, err := (&Format{}).Parse(strings.NewReader(out), nil); err != nil {
+		t.Errorf("Parse of serialized funding failed: %v", err)
+	}
+}
+
+func TestSerializeCrossmark(t *testing.T) {
+	bare := &hubv1.Record{
+		Title:        "Corrosion in Riveted Bridge Members",
+		ResourceType: &hubv1.ResourceType{Type: hubv1.ResourceTypeValue_RESOURCE_TYPE_DISSERTATION},
+	}
+	rights := &hubv1.Record{
+		Title:              "Corrosion in Riveted Bridge Members",
+		ResourceType:       &hubv1.ResourceType{Type: hubv1.ResourceTypeValue_RESOURCE_TYPE_DATASET},
+		CopyrightStatement: "Copyright 2024 Lehigh University",
+		Rights:             []*hubv1.Rights{hub.NewRightsFromURI("https://creativecommons.org/licenses/by/4.0/")},
+	}
+	serialize := func(record *hubv1.Record, policy string) string {
+		t.Helper()
+		var buf bytes.Buffer
+		opts := format.NewSerializeOptions()
+		opts.CrossmarkPolicy = policy
+		if err := (&Format{}).Serialize(&buf, []*hubv1.Record{record}, opts); err != nil {
+			t.Fatalf("Serialize failed: %v", err)
+		}
+		return buf.String()
+	}
+
+	// Without assertions, or without a policy to put them under, there is
+	// no crossmark
+	for _, out := range []string{serialize(bare, ""), serialize(bare, "10.5555/policy"), serialize(rights, "")} {
+		if strings.Contains(out, "crossmark") || strings.Contains(out, "custom_metadata") {
+			t.Errorf("unexpected crossmark:\n%s", out)
+		}
+	}
+
+	out := serialize(rights, "10.5555/policy")
+	want := `<crossmark><crossmark_policy>10.5555/policy</crossmark_policy><custom_metadata><assertion name="copyright_statement" label="Copyright Statement" group_name="copyright_and_licensing" group_label="Copyright &amp; Licensing">Copyright 2024 Lehigh University</assertion></custom_metadata></crossmark>`
+	if !strings.Contains(out, want) {
+		t.Errorf("output missing %s:\n%s", want, out)
+	}
+	// Licenses are in the AccessIndicators program only
+	if strings.Contains(out, `name="license"`) {
+		t.Errorf("license repeated as a crossmark assertion:\n%s", out)
+	}
+}
+
+func TestSerializeDissertationAuthor(t *testing.T) {
+	record := &hubv1.Record{
+		Title:        "Corrosion in Riveted Bridge Members",
+		ResourceType: &hubv1.ResourceType{Type: hubv1.ResourceTypeValue_RESOURCE_TYPE_DISSERTATION},
+		Contributors: []*hubv1.Contributor{
+			{Name: "Huang, Wei-Min", ParsedName: &hubv1.ParsedName{Given: "Wei-Min", Family: "Huang"}, RoleCode: "relators:ths", Role: "Thesis advisor"},
+			{Name: "Okafor, Ngozi", ParsedName: &hubv1.ParsedName{Given: "Ngozi", Family: "Okafor"}, DegreeRole: hubv1.DegreeRole_DEGREE_ROLE_COMMITTEE_MEMBER},
+			{Name: "Qin, Tian", ParsedName: &hubv1.ParsedName{Given: "Tian", Family: "Qin"}, Role: "author"},
+		},
+		Identifiers: []*hubv1.Identifier{
+			hub.NewIdentifier("10.1234/etd.2024.3", hubv1.IdentifierType_IDENTIFIER_TYPE_DOI),
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := (&Format{}).Serialize(&buf, []*hubv1.Record{record}, nil); err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+	out := buf.String()
+
+	if !strings.Contains(out, `<person_name contributor_role="author" sequence="first"><given_name>Tian</given_name><surname>Qin</surname></person_name>`) {
+		t.Errorf("dissertation author missing:\n%s", out)
+	}
+	if strings.Contains(out, "Huang") || strings.Contains(out, "Okafor") {
+		t.Errorf("advisor or committee member deposited as an author:\n%s", out)
+	}
+}
+
+func TestSerializeContributorRoles(t *testing.T) {
+	record := &hubv1.Record{
+		Title:        "Letters from the Moravian Archives",
+		ResourceType: &hubv1.ResourceType{Type: hubv1.ResourceTypeValue_RESOURCE_TYPE_BOOK},
+		Contributors: []*hubv1.Contributor{
+			{Name: "Smith, Alice", ParsedName: &hubv1.ParsedName{Given: "Alice", Family: "Smith"}, RoleCode: "relators:edt"},
+			{Name: "Weber, Karl", ParsedName: &hubv1.ParsedName{Given: "Karl", Family: "Weber"}, Role: "Translators."},
+			{Name: "Jones, Bo", ParsedName: &hubv1.ParsedName{Given: "Bo", Family: "Jones"}, Role: "Photographer"},
+		},
+		Identifiers: []*hubv1.Identifier{
+			hub.NewIdentifier("10.1234/moravian", hubv1.IdentifierType_IDENTIFIER_TYPE_DOI),
+		},
+	}
+
+	f := &Format{}
+	var buf bytes.Buffer
+	if err := f.Serialize(&buf, []*hubv1.Record{record}, nil); err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		`<person_name contributor_role="editor" sequence="first"><given_name>Alice</given_name>`,
+		`<person_name contributor_role="translator" sequence="additional"><given_name>Karl</given_name>`,
+		`<person_name contributor_role="author" sequence="additional"><given_name>Bo</given_name>`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %s:\n%s", want, out)
+		}
+	}
+
+	records, err := f.Parse(strings.NewReader(out), nil)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	got := records[0].Contributors
+	if len(got) != 3 || got[0].RoleCode != "relators:edt" || got[1].RoleCode != "relators:trl" || got[2].RoleCode != "relators:aut" {
+		t.Errorf("role codes: got %v", got)
+	}
+}
+
+func TestSerializePublisherPlace(t *testing.T) {
+	record := &hubv1.Record{
+		Title:          "Letters from the Moravian Archives",
+		ResourceType:   &hubv1.ResourceType{Type: hubv1.ResourceTypeValue_RESOURCE_TYPE_BOOK},
+		Publisher:      "Moravian Publication Office",
+		PlacePublished: "[Bethlehem, Pa.] :",
+		Identifiers: []*hubv1.Identifier{
+			hub.NewIdentifier("10.1234/moravian", hubv1.IdentifierType_IDENTIFIER_TYPE_DOI),
+		},
+	}
+
+	f := &Format{}
+	var buf bytes.Buffer
+	if err := f.Serialize(&buf, []*hubv1.Record{record}, nil); err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), `<publisher_place>Bethlehem, Pennsylvania</publisher_place>`) {
+		t.Errorf("publisher place not normalized:\n%s", buf.String())
+	}
+
+	records, err := f.Parse(strings.NewReader(buf.String()), nil)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if p := records[0].PublicationPlace; p == nil || p.RegionCode != "US-PA" {
+		t.Errorf("PublicationPlace: got %v", p)
+	}
+}
@@ -15,8 +15,9 @@ type Format struct{}
 
 // Ensure Format implements the interfaces
 var (
-	_ format.Format     = (*Format)(nil)
-	_ format.Serializer = (*Format)(nil)
+	_ format.Format         = (*Format)(nil)
+	_ format.Serializer     = (*Format)(nil)
+	_ format.FieldDescriber = (*Format)(nil)
 )
 
 // Name returns the format identifier.
@@ -34,6 +35,18 @@ func (f *Format) Extensions() []string {
 	return []string{"json", "csl"}
 }
 
+// writtenFields are the hub record fields Serialize writes.
+var writtenFields = []string{
+	"title", "abstract", "contributors", "dates", "resource_type", "language",
+	"publisher", "place_published", "publication", "identifiers", "notes",
+	"edition", "relations.part_of",
+}
+
+// WrittenFields returns the hub record fields Serialize writes.
+func (f *Format) WrittenFields(opts *format.SerializeOptions) []string {
+	return writtenFields
+}
+
 // CanParse returns true if the input looks like CSL-JSON.
 func (f *Format) CanParse(peek []byte) bool {
 	peek = bytes.TrimSpace(peek)
@@ -0,0 +1,75 @@
+package csv
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/helpers"
+)
+
+var (
+	// US-style numeric date: 3/15/1999
+	usDateRegex = regexp.MustCompile(`^(\d{1,2})/(\d{1,2})/(\d{4})$`)
+
+	// A four-digit year anywhere in the value: "1999.0", "[1999]", "March 1999"
+	yearRegex = regexp.MustCompile(`\b(\d{4})\b`)
+
+	// Cataloger shorthand for an approximate date: "c. 1999", "ca 1999", "circa 1999"
+	circaRegex = regexp.MustCompile(`(?i)^\[?\s*(c|ca|circa)\.?\s*\d`)
+)
+
+// coerceDate parses a date cell. Values EDTF doesn't cover, as spreadsheets
+// and hand-entered data often have them, fall back to the year they name:
+// "1999.0" from a spreadsheet's number column, "[1999]", "c. 1999" (as an
+// approximate year), and US-style "3/15/1999" (as a day). Returns nil when
+// no year can be found.
+func coerceDate(value string, dateType hubv1.DateType) *hubv1.DateValue {
+	if m := usDateRegex.FindStringSubmatch(value); m != nil {
+		month, _ := strconv.Atoi(m[1])
+		day, _ := strconv.Atoi(m[2])
+		year, _ := strconv.Atoi(m[3])
+		if month >= 1 && month <= 12 && day >= 1 && day <= 31 {
+			return &hubv1.DateValue{
+				Type:      dateType,
+				Raw:       value,
+				Year:      int32(year),
+				Month:     int32(month),
+				Day:       int32(day),
+				Precision: hubv1.DatePrecision_DATE_PRECISION_DAY,
+			}
+		}
+	}
+
+	if date, _ := helpers.ParseEDTF(value, dateType); date.Year > 0 {
+		return date
+	}
+
+	m := yearRegex.FindStringSubmatch(value)
+	if m == nil {
+		return nil
+	}
+	year, _ := strconv.Atoi(m[1])
+	date := &hubv1.DateValue{
+		Type:      dateType,
+		Raw:       value,
+		Year:      int32(year),
+		Precision: hubv1.DatePrecision_DATE_PRECISION_YEAR,
+	}
+	if circaRegex.MatchString(value) {
+		date.Qualifier = hubv1.DateQualifier_DATE_QUALIFIER_APPROXIMATE
+	}
+	return date
+}
+
+// contributorSeparator returns the separator between the contributors of a
+// cell. Crosswalk writes JSON contributors joined by " ; "; anything else is
+// split on bare semicolons, so hand-entered "Last, First; Last, First" lists
+// come apart too.
+func contributorSeparator(value string) string {
+	if strings.HasPrefix(value, "{") {
+		return " ; "
+	}
+	return ";"
+}
@@ -0,0 +1,76 @@
+package csv
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/lehigh-university-libraries/crosswalk/helpers"
+	"github.com/lehigh-university-libraries/crosswalk/mapping"
+)
+
+// profileColumns returns the profile mapping of each column the profile
+// maps, by column index.
+func profileColumns(header []string, profile *mapping.Profile) map[int]mapping.FieldMapping {
+	if profile == nil {
+		return nil
+	}
+	cols := make(map[int]mapping.FieldMapping)
+	for i, col := range header {
+		if m, ok := profileField(profile, col, i); ok {
+			cols[i] = m
+		}
+	}
+	return cols
+}
+
+// profileField finds the profile mapping for the column named col at index
+// i. Profiles name columns by header, ignoring case, or by 1-based position
+// as "#3" for files whose headers are missing or unwieldy.
+func profileField(profile *mapping.Profile, col string, i int) (mapping.FieldMapping, bool) {
+	col = strings.TrimSpace(col)
+	if m, ok := profile.Fields[strings.ToLower(col)]; ok {
+		return m, true
+	}
+	for name, m := range profile.Fields {
+		if strings.EqualFold(name, col) {
+			return m, true
+		}
+	}
+	m, ok := profile.Fields["#"+strconv.Itoa(i+1)]
+	return m, ok
+}
+
+// columnIR returns the hub field a column's values go to. CSV carries the
+// kind of date, identifier, subject, or relation in the hub field name (e.g.
+// Dates.issued); a mapping may give it instead with date_type, type,
+// vocabulary, or relation_type.
+func columnIR(m mapping.FieldMapping) string {
+	base, subtype := mapping.IRFieldName(m.IR)
+	if subtype != "" {
+		return m.IR
+	}
+	switch base {
+	case "Dates":
+		subtype = m.DateType
+	case "Identifiers":
+		subtype = m.Type
+	case "Subjects":
+		subtype = m.Vocabulary
+	case "Relations":
+		subtype = m.RelationType
+	}
+	if subtype == "" {
+		return base
+	}
+	return base + "." + subtype
+}
+
+// columnRole returns the relator code a contributor column gives its
+// contributors that don't name their own role, from the mapping's type
+// (e.g., "author" or "aut").
+func columnRole(m mapping.FieldMapping) string {
+	if base, _ := mapping.IRFieldName(m.IR); base != "Contributors" || m.Type == "" {
+		return ""
+	}
+	return "relators:" + helpers.RoleToCode(m.Type)
+}
@@ -5,6 +5,7 @@ import (
 	"bytes"
 
 	"github.com/lehigh-university-libraries/crosswalk/format"
+	"github.com/lehigh-university-libraries/crosswalk/mapping"
 )
 
 // Format implements the CSV format.
@@ -12,9 +13,12 @@ type Format struct{}
 
 // Ensure Format implements the interfaces
 var (
-	_ format.Format     = (*Format)(nil)
-	_ format.Parser     = (*Format)(nil)
-	_ format.Serializer = (*Format)(nil)
+	_ format.Format           = (*Format)(nil)
+	_ format.Parser           = (*Format)(nil)
+	_ format.StreamParser     = (*Format)(nil)
+	_ format.Serializer       = (*Format)(nil)
+	_ format.StreamSerializer = (*Format)(nil)
+	_ format.FieldDescriber   = (*Format)(nil)
 )
 
 // Name returns the format identifier.
@@ -32,6 +36,65 @@ func (f *Format) Extensions() []string {
 	return []string{"csv", "tsv"}
 }
 
+// columnFields maps output columns to the hub record field they carry.
+// Drupal bookkeeping columns (nid, uuid, status, ...) carry none.
+var columnFields = map[string]string{
+	"title":                "title",
+	"alt_title":            "alt_title",
+	"contributors":         "contributors",
+	"date_issued":          "dates",
+	"date_created":         "dates",
+	"date":                 "dates",
+	"resource_type":        "resource_type",
+	"genre":                "genres",
+	"language":             "language",
+	"rights":               "rights",
+	"rights_label":         "rights",
+	"abstract":             "abstract",
+	"description":          "description",
+	"identifiers":          "identifiers",
+	"doi":                  "identifiers",
+	"subjects":             "subjects",
+	"keywords":             "subjects",
+	"lcsh_subjects":        "subjects",
+	"publisher":            "publisher",
+	"place_published":      "place_published",
+	"member_of":            "relations.member_of",
+	"member_of_id":         "relations.member_of",
+	"membership_path":      "membership_path",
+	"degree_name":          "degree_info",
+	"degree_level":         "degree_info",
+	"department":           "degree_info",
+	"institution":          "degree_info",
+	"notes":                "notes",
+	"physical_description": "physical_desc",
+	"table_of_contents":    "table_of_contents",
+	"source":               "source",
+	"digital_origin":       "digital_origin",
+}
+
+// WrittenFields returns the hub record fields the output columns carry,
+// using the default columns when opts selects none.
+func (f *Format) WrittenFields(opts *format.SerializeOptions) []string {
+	var columns []string
+	if opts != nil {
+		columns = opts.Columns
+	}
+	if len(columns) == 0 {
+		columns = mapping.DefaultCSVColumns()
+	}
+
+	var fields []string
+	seen := make(map[string]bool)
+	for _, col := range columns {
+		if field, ok := columnFields[col]; ok && !seen[field] {
+			seen[field] = true
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
 // CanParse returns true if the input looks like CSV data.
 func (f *Format) CanParse(peek []byte) bool {
 	peek = bytes.TrimSpace(peek)
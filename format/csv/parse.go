@@ -16,6 +16,21 @@ import (
 
 // Parse reads CSV and returns hub records.
 func (f *Format) Parse(r io.Reader, opts *format.ParseOptions) ([]*hubv1.Record, error) {
+	var records []*hubv1.Record
+	err := f.ParseStream(r, opts, func(record *hubv1.Record) error {
+		records = append(records, record)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// ParseStream reads CSV a row at a time, calling fn with each row's hub
+// record. With opts.Workers above one, rows are converted concurrently and
+// handed to fn in input order.
+func (f *Format) ParseStream(r io.Reader, opts *format.ParseOptions, fn func(*hubv1.Record) error) error {
 	if opts == nil {
 		opts = format.NewParseOptions()
 	}
@@ -24,52 +39,77 @@ func (f *Format) Parse(r io.Reader, opts *format.ParseOptions) ([]*hubv1.Record,
 	reader.FieldsPerRecord = -1 // Allow variable number of fields
 	reader.LazyQuotes = true
 
-	// Read all rows
-	rows, err := reader.ReadAll()
-	if err != nil {
-		return nil, fmt.Errorf("parsing CSV: %w", err)
+	// First row is header
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil
 	}
-
-	if len(rows) == 0 {
-		return nil, nil
+	if err != nil {
+		return fmt.Errorf("parsing CSV: %w", err)
 	}
-
-	// First row is header
-	header := rows[0]
-	columnMap := buildColumnMap(header, opts.Profile)
-
 	// Get multi-value separator
 	sep := "|"
 	if opts.Profile != nil {
 		sep = opts.Profile.GetMultiValueSeparator()
 	}
 
-	// Parse data rows
-	records := make([]*hubv1.Record, 0, len(rows)-1)
-	for i := 1; i < len(rows); i++ {
-		record, err := rowToRecord(rows[i], header, columnMap, sep, opts)
-		if err != nil {
-			continue // Skip invalid rows
-		}
-		records = append(records, record)
+	transform, err := opts.Profile.RowTransform(header, sep)
+	if err != nil {
+		return err
+	}
+	if transform != nil {
+		header = transform.Header()
 	}
+	columnMap := buildColumnMap(header, opts.Profile)
+	cols := profileColumns(header, opts.Profile)
 
-	return records, nil
+	// Parse data rows
+	return helpers.MapOrdered(opts.Workers,
+		func(emit func([]string) error) error {
+			for {
+				row, err := reader.Read()
+				if err == io.EOF {
+					return nil
+				}
+				if err != nil {
+					return fmt.Errorf("parsing CSV: %w", err)
+				}
+				if err := emit(row); err != nil {
+					return err
+				}
+			}
+		},
+		func(row []string) (*hubv1.Record, error) {
+			row, err := transform.Apply(row)
+			if err != nil {
+				return nil, err
+			}
+			record, err := rowToRecord(row, header, columnMap, cols, sep, opts)
+			if err != nil {
+				return nil, nil // Skip invalid rows
+			}
+			return record, nil
+		},
+		func(record *hubv1.Record) error {
+			if record == nil {
+				return nil
+			}
+			return fn(record)
+		})
 }
 
 func buildColumnMap(header []string, profile *mapping.Profile) map[int]string {
 	colMap := make(map[int]string)
 
 	for i, col := range header {
-		col = strings.ToLower(strings.TrimSpace(col))
-
 		// Try profile mapping first
 		if profile != nil {
-			if m, ok := profile.Fields[col]; ok {
-				colMap[i] = m.IR
+			if m, ok := profileField(profile, col, i); ok {
+				colMap[i] = columnIR(m)
 				continue
 			}
 		}
+		col = strings.ToLower(strings.TrimSpace(col))
 
 		// Default mappings
 		defaultMap := map[string]string{
@@ -105,6 +145,7 @@ func buildColumnMap(header []string, profile *mapping.Profile) map[int]string {
 			"publication_place": "PlacePublished",
 			"member_of":         "Relations.member_of",
 			"collection":        "Relations.member_of",
+			"membership_path":   "MembershipPath",
 			"degree_name":       "DegreeInfo.DegreeName",
 			"degree_level":      "DegreeInfo.DegreeLevel",
 			"department":        "DegreeInfo.Department",
@@ -123,8 +164,9 @@ func buildColumnMap(header []string, profile *mapping.Profile) map[int]string {
 	return colMap
 }
 
-func rowToRecord(row []string, header []string, colMap map[int]string, sep string, opts *format.ParseOptions) (*hubv1.Record, error) {
+func rowToRecord(row []string, header []string, colMap map[int]string, cols map[int]mapping.FieldMapping, sep string, opts *format.ParseOptions) (*hubv1.Record, error) {
 	record := &hubv1.Record{}
+	var unmapped []string
 
 	for i, value := range row {
 		if i >= len(header) {
@@ -138,117 +180,161 @@ func rowToRecord(row []string, header []string, colMap map[int]string, sep strin
 
 		irField, ok := colMap[i]
 		if !ok {
+			unmapped = append(unmapped, strings.TrimSpace(header[i]))
 			continue
 		}
 
-		parts := strings.SplitN(irField, ".", 2)
-		base := parts[0]
-		subtype := ""
-		if len(parts) > 1 {
-			subtype = parts[1]
+		m := cols[i]
+		colSep := sep
+		if m.Delimiter != "" {
+			colSep = m.Delimiter
 		}
+		if len(m.Rules) > 0 {
+			for _, route := range routeCell(m, strings.ToLower(strings.TrimSpace(header[i])), value, colSep, row, header) {
+				setColumn(record, route.ir, route.value, colSep, m, opts)
+			}
+			continue
+		}
+		setColumn(record, irField, value, colSep, m, opts)
+	}
 
-		switch base {
-		case "Title":
-			record.Title = value
+	if len(unmapped) > 0 {
+		record.SourceInfo = &hubv1.SourceInfo{
+			Format:         "csv",
+			UnmappedFields: unmapped,
+		}
+		if opts.Profile != nil {
+			record.SourceInfo.Profile = opts.Profile.Name
+		}
+	}
 
-		case "AltTitle":
-			record.AltTitle = splitMultiValue(value, sep)
+	return record, nil
+}
 
-		case "Abstract":
-			record.Abstract = cleanValue(value, opts)
+// setColumn sets the hub field a column maps to from one cell, given the
+// column's profile mapping, if any.
+func setColumn(record *hubv1.Record, irField, value, sep string, m mapping.FieldMapping, opts *format.ParseOptions) {
+	parts := strings.SplitN(irField, ".", 2)
+	base := parts[0]
+	subtype := ""
+	if len(parts) > 1 {
+		subtype = parts[1]
+	}
 
-		case "Description":
-			record.Description = cleanValue(value, opts)
+	switch base {
+	case "Title":
+		record.Title = value
 
-		case "Contributors":
-			// Contributors always use " ; " as multi-value separator to match serialization
-			entries := splitMultiValue(value, " ; ")
-			for _, entry := range entries {
-				if c := parseContributor(entry); c != nil {
-					record.Contributors = append(record.Contributors, c)
-				}
+	case "AltTitle":
+		record.AltTitle = splitMultiValue(value, sep)
+
+	case "Abstract":
+		record.Abstract = cleanValue(value, opts)
+
+	case "Description":
+		record.Description = cleanValue(value, opts)
+
+	case "Contributors":
+		contribSep := contributorSeparator(value)
+		if m.Delimiter != "" {
+			contribSep = m.Delimiter
+		}
+		role := columnRole(m)
+		for _, entry := range splitMultiValue(value, contribSep) {
+			c := parseContributor(entry)
+			if c == nil {
+				continue
 			}
+			if c.RoleCode == "" && role != "" {
+				c.RoleCode = role
+				c.Role = helpers.RelatorLabel(role)
+			}
+			record.Contributors = append(record.Contributors, c)
+		}
 
-		case "Dates":
-			dateType := dateTypeFromString(subtype)
-			for _, v := range splitMultiValue(value, sep) {
-				date, _ := helpers.ParseEDTF(v, dateType)
-				if date.Year > 0 {
-					record.Dates = append(record.Dates, date)
-				}
+	case "Dates":
+		dateType := dateTypeFromString(subtype)
+		for _, v := range splitMultiValue(value, sep) {
+			if date := coerceDate(v, dateType); date != nil {
+				record.Dates = append(record.Dates, date)
 			}
+		}
 
-		case "ResourceType":
-			record.ResourceType = hub.NewResourceType(value, "")
+	case "ResourceType":
+		record.ResourceType = hub.NewResourceType(value, "")
 
-		case "Genre":
-			for _, g := range splitMultiValue(value, sep) {
-				record.Genres = append(record.Genres, &hubv1.Subject{Value: g, Vocabulary: hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_GENRE})
-			}
+	case "Genre":
+		for _, g := range splitMultiValue(value, sep) {
+			record.Genres = append(record.Genres, &hubv1.Subject{Value: g, Vocabulary: hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_GENRE})
+		}
 
-		case "Language":
-			record.Language = value
+	case "Language":
+		record.Language = value
 
-		case "Rights":
-			for _, v := range splitMultiValue(value, sep) {
-				record.Rights = append(record.Rights, hub.NewRightsFromURI(v))
-			}
+	case "Rights":
+		for _, v := range splitMultiValue(value, sep) {
+			record.Rights = append(record.Rights, hub.NewRightsFromURI(v))
+		}
 
-		case "Subjects":
-			vocab := subjectVocabularyFromString(subtype)
-			for _, v := range splitMultiValue(value, sep) {
-				record.Subjects = append(record.Subjects, &hubv1.Subject{
-					Value:      v,
-					Vocabulary: vocab,
-				})
-			}
+	case "Subjects":
+		vocab := subjectVocabularyFromString(subtype)
+		for _, v := range splitMultiValue(value, sep) {
+			record.Subjects = append(record.Subjects, &hubv1.Subject{
+				Value:      v,
+				Vocabulary: vocab,
+			})
+		}
 
-		case "Identifiers":
-			idType := identifierTypeFromString(subtype)
-			for _, v := range splitMultiValue(value, sep) {
-				record.Identifiers = append(record.Identifiers, hub.NewIdentifier(v, idType))
-			}
+	case "Identifiers":
+		idType := identifierTypeFromString(subtype)
+		for _, v := range splitMultiValue(value, sep) {
+			record.Identifiers = append(record.Identifiers, hub.NewIdentifier(v, idType))
+		}
 
-		case "Publisher":
-			record.Publisher = value
+	case "Publisher":
+		record.Publisher = value
 
-		case "PlacePublished":
-			record.PlacePublished = value
+	case "PlacePublished":
+		record.PlacePublished = value
 
-		case "Relations":
-			relType := hub.NormalizeRelationType(subtype)
-			for _, v := range splitMultiValue(value, sep) {
-				record.Relations = append(record.Relations, &hubv1.Relation{
-					Type:        relType,
-					TargetTitle: v,
-				})
-			}
+	case "Relations":
+		relType := hub.NormalizeRelationType(subtype)
+		for _, v := range splitMultiValue(value, sep) {
+			record.Relations = append(record.Relations, &hubv1.Relation{
+				Type:        relType,
+				TargetTitle: v,
+			})
+		}
 
-		case "Notes":
-			record.Notes = append(record.Notes, splitMultiValue(value, sep)...)
+	case "MembershipPath":
+		for _, v := range splitMultiValue(value, " > ") {
+			record.MembershipPath = append(record.MembershipPath, &hubv1.Relation{
+				Type:        hubv1.RelationType_RELATION_TYPE_MEMBER_OF,
+				TargetTitle: v,
+			})
+		}
 
-		case "DegreeInfo":
-			if record.DegreeInfo == nil {
-				record.DegreeInfo = &hubv1.DegreeInfo{}
-			}
-			switch subtype {
-			case "DegreeName":
-				record.DegreeInfo.DegreeName = value
-			case "DegreeLevel":
-				record.DegreeInfo.DegreeLevel = value
-			case "Department":
-				record.DegreeInfo.Department = value
-			case "Institution":
-				record.DegreeInfo.Institution = value
-			}
+	case "Notes":
+		record.Notes = append(record.Notes, splitMultiValue(value, sep)...)
 
-		case "Extra":
-			hub.SetExtra(record, subtype, value)
+	case "DegreeInfo":
+		if record.DegreeInfo == nil {
+			record.DegreeInfo = &hubv1.DegreeInfo{}
+		}
+		switch subtype {
+		case "DegreeName":
+			record.DegreeInfo.DegreeName = value
+		case "DegreeLevel":
+			record.DegreeInfo.DegreeLevel = value
+		case "Department":
+			record.DegreeInfo.Department = value
+		case "Institution":
+			record.DegreeInfo.Institution = value
 		}
-	}
 
-	return record, nil
+	case "Extra":
+		hub.SetExtra(record, subtype, value)
+	}
 }
 
 func dateTypeFromString(s string) hubv1.DateType {
@@ -331,6 +417,12 @@ func identifierTypeFromString(s string) hubv1.IdentifierType {
 		return hubv1.IdentifierType_IDENTIFIER_TYPE_UUID
 	case "isni":
 		return hubv1.IdentifierType_IDENTIFIER_TYPE_ISNI
+	case "ark":
+		return hubv1.IdentifierType_IDENTIFIER_TYPE_ARK
+	case "urn":
+		return hubv1.IdentifierType_IDENTIFIER_TYPE_URN
+	case "purl":
+		return hubv1.IdentifierType_IDENTIFIER_TYPE_PURL
 	default:
 		return hubv1.IdentifierType_IDENTIFIER_TYPE_UNSPECIFIED
 	}
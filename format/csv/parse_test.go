@@ -1,10 +1,16 @@
 package csv
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
 	"strings"
 	"testing"
 
+	"github.com/lehigh-university-libraries/crosswalk/format"
 	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+	"github.com/lehigh-university-libraries/crosswalk/mapping"
 )
 
 func TestParseNamePrefix(t *testing.T) {
@@ -232,3 +238,310 @@ func TestRoundTrip(t *testing.T) {
 		})
 	}
 }
+
+func TestParseStream(t *testing.T) {
+	input := "title,date_issued\nFirst,2001\nSecond,2002\nThird,2003\n"
+
+	var titles []string
+	stop := errors.New("stop")
+	err := (&Format{}).ParseStream(strings.NewReader(input), nil, func(r *hubv1.Record) error {
+		titles = append(titles, r.Title)
+		if len(titles) == 2 {
+			return stop
+		}
+		return nil
+	})
+	if !errors.Is(err, stop) {
+		t.Fatalf("expected the callback's error, got %v", err)
+	}
+	if strings.Join(titles, ",") != "First,Second" {
+		t.Errorf("titles: got %v", titles)
+	}
+
+	records, err := (&Format{}).Parse(strings.NewReader(input), nil)
+	if err != nil || len(records) != 3 || records[2].Title != "Third" {
+		t.Errorf("Parse should return every row: %v, %v", records, err)
+	}
+}
+
+func TestSerializeStream(t *testing.T) {
+	input := "title,date_issued\nFirst,2001\nSecond,2002\nThird,2003\n"
+	opts := &format.SerializeOptions{Columns: []string{"title", "date_issued"}, IncludeHeader: true, Workers: 4}
+
+	var streamed bytes.Buffer
+	err := (&Format{}).SerializeStream(&streamed, opts, func(write func(*hubv1.Record) error) error {
+		return (&Format{}).ParseStream(strings.NewReader(input), nil, write)
+	})
+	if err != nil {
+		t.Fatalf("SerializeStream failed: %v", err)
+	}
+	if streamed.String() != input {
+		t.Errorf("streamed output:\n%s\nwant:\n%s", streamed.String(), input)
+	}
+
+	stop := errors.New("stop")
+	err = (&Format{}).SerializeStream(&bytes.Buffer{}, opts, func(func(*hubv1.Record) error) error { return stop })
+	if !errors.Is(err, stop) {
+		t.Errorf("expected the producer's error, got %v", err)
+	}
+}
+
+func TestWorkersKeepOrder(t *testing.T) {
+	var input strings.Builder
+	input.WriteString("title\n")
+	var want []string
+	for i := range 200 {
+		title := fmt.Sprintf("Record %d", i)
+		want = append(want, title)
+		fmt.Fprintf(&input, "%s\n", title)
+	}
+
+	records, err := (&Format{}).Parse(strings.NewReader(input.String()), &format.ParseOptions{Workers: 8})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, r := range records {
+		got = append(got, r.Title)
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("parsed titles out of order: %v", got)
+	}
+
+	var out bytes.Buffer
+	err = (&Format{}).Serialize(&out, records, &format.SerializeOptions{
+		Columns:       []string{"title"},
+		IncludeHeader: true,
+		Workers:       8,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != input.String() {
+		t.Errorf("serialized rows out of order:\n%s", out.String())
+	}
+}
+
+func TestParseProfileTransforms(t *testing.T) {
+	profile, err := mapping.LoadProfileFromString(`
+name: cleanup
+fields:
+  title:
+    ir: Title
+    transform: "s/^Digitized: //"
+  keywords:
+    ir: Subjects.keywords
+    transform: ["split:;", lowercase]
+  citation:
+    ir: Notes
+    template: "Vol. {{.volume}}({{.issue}})"
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := format.NewParseOptions()
+	opts.Profile = profile
+
+	input := "title,keywords,volume,issue\nDigitized: Annual report,Budget; FINANCE,12,3\n"
+	records, err := (&Format{}).Parse(strings.NewReader(input), opts)
+	if err != nil || len(records) != 1 {
+		t.Fatalf("Parse: %v, %v", records, err)
+	}
+	r := records[0]
+	if r.Title != "Annual report" {
+		t.Errorf("title: got %q", r.Title)
+	}
+	var keywords []string
+	for _, s := range r.Subjects {
+		keywords = append(keywords, s.Value)
+	}
+	if strings.Join(keywords, ",") != "budget,finance" {
+		t.Errorf("keywords: got %v", keywords)
+	}
+	if len(r.Notes) != 1 || r.Notes[0] != "Vol. 12(3)" {
+		t.Errorf("notes: got %v", r.Notes)
+	}
+}
+
+func TestParseConditionalMapping(t *testing.T) {
+	profile, err := mapping.LoadProfileFromString(`
+name: conditional
+fields:
+  identifier:
+    ir: Identifiers
+    rules:
+      - when: {matches: "^10\\."}
+        ir: Identifiers.doi
+  genre:
+    ir: Genre
+    rules:
+      - when: {in: [Dataset, Software]}
+        ir: ResourceType
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := format.NewParseOptions()
+	opts.Profile = profile
+
+	input := "title,identifier,genre\nSurvey,10.1234/abcd|lehigh-42,Dataset|Oral histories\n"
+	records, err := (&Format{}).Parse(strings.NewReader(input), opts)
+	if err != nil || len(records) != 1 {
+		t.Fatalf("Parse: %v, %v", records, err)
+	}
+	r := records[0]
+	if len(r.Identifiers) != 2 || r.Identifiers[0].Type != hubv1.IdentifierType_IDENTIFIER_TYPE_DOI || r.Identifiers[0].Value != "10.1234/abcd" {
+		t.Errorf("identifiers: got %v", r.Identifiers)
+	}
+	if r.GetResourceType().GetType() != hubv1.ResourceTypeValue_RESOURCE_TYPE_DATASET {
+		t.Errorf("resource type: got %v, want dataset", r.GetResourceType())
+	}
+	if len(r.Genres) != 1 || r.Genres[0].Value != "Oral histories" {
+		t.Errorf("genres: got %v, want only Oral histories", r.Genres)
+	}
+}
+
+func TestParseHeaderProfile(t *testing.T) {
+	profile, err := mapping.LoadProfileFromString(`
+name: headers
+fields:
+  Item Title:
+    ir: Title
+  "#2":
+    ir: Dates
+    date_type: created
+  authors:
+    ir: Contributors
+    type: author
+  ids:
+    ir: Identifiers
+    type: isbn
+    delimiter: ";"
+  tags:
+    ir: Subjects
+    vocabulary: keywords
+    delimiter: ","
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := format.NewParseOptions()
+	opts.Profile = profile
+
+	input := "ITEM TITLE,Year Made,Authors,IDs,Tags\n" +
+		`Field notes,1999.0,"Smith, Jane; relators:edt:person:Doe, John",9780000000001; 9780000000002,"maps, surveys"` + "\n"
+	records, err := (&Format{}).Parse(strings.NewReader(input), opts)
+	if err != nil || len(records) != 1 {
+		t.Fatalf("Parse: %v, %v", records, err)
+	}
+	r := records[0]
+	if r.Title != "Field notes" {
+		t.Errorf("title: got %q", r.Title)
+	}
+	if len(r.Dates) != 1 || r.Dates[0].Year != 1999 || r.Dates[0].Type != hubv1.DateType_DATE_TYPE_CREATED {
+		t.Errorf("dates: got %v, want 1999 created", r.Dates)
+	}
+	if len(r.Contributors) != 2 {
+		t.Fatalf("contributors: got %v, want 2", r.Contributors)
+	}
+	if c := r.Contributors[0]; c.Name != "Smith, Jane" || c.RoleCode != "relators:aut" {
+		t.Errorf("contributor 0: got %q %q, want Smith, Jane as relators:aut", c.Name, c.RoleCode)
+	}
+	if c := r.Contributors[1]; c.Name != "Doe, John" || c.RoleCode != "relators:edt" {
+		t.Errorf("contributor 1: got %q %q, want Doe, John keeping relators:edt", c.Name, c.RoleCode)
+	}
+	if len(r.Identifiers) != 2 || r.Identifiers[1].Type != hubv1.IdentifierType_IDENTIFIER_TYPE_ISBN {
+		t.Errorf("identifiers: got %v, want 2 ISBNs", r.Identifiers)
+	}
+	if len(r.Subjects) != 2 || r.Subjects[1].Value != "surveys" || r.Subjects[1].Vocabulary != hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_KEYWORDS {
+		t.Errorf("subjects: got %v, want 2 keywords", r.Subjects)
+	}
+}
+
+func TestCoerceDate(t *testing.T) {
+	tests := []struct {
+		input     string
+		year      int32
+		month     int32
+		day       int32
+		qualifier hubv1.DateQualifier
+	}{
+		{"1999", 1999, 0, 0, hubv1.DateQualifier_DATE_QUALIFIER_UNSPECIFIED},
+		{"1999-03-15", 1999, 3, 15, hubv1.DateQualifier_DATE_QUALIFIER_UNSPECIFIED},
+		{"1999.0", 1999, 0, 0, hubv1.DateQualifier_DATE_QUALIFIER_UNSPECIFIED},
+		{"[1999]", 1999, 0, 0, hubv1.DateQualifier_DATE_QUALIFIER_UNSPECIFIED},
+		{"c. 1999", 1999, 0, 0, hubv1.DateQualifier_DATE_QUALIFIER_APPROXIMATE},
+		{"circa 1850", 1850, 0, 0, hubv1.DateQualifier_DATE_QUALIFIER_APPROXIMATE},
+		{"3/15/1999", 1999, 3, 15, hubv1.DateQualifier_DATE_QUALIFIER_UNSPECIFIED},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			d := coerceDate(tt.input, hubv1.DateType_DATE_TYPE_ISSUED)
+			if d == nil {
+				t.Fatal("got nil")
+			}
+			if d.Year != tt.year || d.Month != tt.month || d.Day != tt.day || d.Qualifier != tt.qualifier {
+				t.Errorf("got %d-%d-%d %v, want %d-%d-%d %v", d.Year, d.Month, d.Day, d.Qualifier, tt.year, tt.month, tt.day, tt.qualifier)
+			}
+		})
+	}
+	if d := coerceDate("unknown", hubv1.DateType_DATE_TYPE_ISSUED); d != nil {
+		t.Errorf("unknown: got %v, want nil", d)
+	}
+}
+
+func TestSerializeStaticAndTemplateColumns(t *testing.T) {
+	records := []*hubv1.Record{{
+		Title:    "Field notes",
+		AltTitle: []string{"Notes", "Journal"},
+		Dates:    []*hubv1.DateValue{{Type: hubv1.DateType_DATE_TYPE_ISSUED, Year: 1999, Precision: hubv1.DatePrecision_DATE_PRECISION_YEAR}},
+	}}
+
+	var out bytes.Buffer
+	err := (&Format{}).Serialize(&out, records, &format.SerializeOptions{
+		Columns:       []string{"model", "title"},
+		StaticColumns: map[string]string{"model": "Digital Document", "published": "1"},
+		TemplateColumns: map[string]string{
+			"label": `{{.Title}} ({{column . "date_issued"}})`,
+			"alts":  `{{join .AltTitle "; "}}`,
+		},
+		IncludeHeader: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "model,title,alts,label,published\n" +
+		"Digital Document,Field notes,Notes; Journal,Field notes (1999),1\n"
+	if out.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", out.String(), want)
+	}
+
+	err = (&Format{}).Serialize(&out, records, &format.SerializeOptions{
+		TemplateColumns: map[string]string{"label": "{{.Title"},
+	})
+	if err == nil {
+		t.Error("want error for a malformed template")
+	}
+}
+
+func TestMembershipPathRoundTrip(t *testing.T) {
+	input := "title,membership_path\nPhoto,University Archives > Photographs > 1960s\n"
+	records, err := (&Format{}).Parse(strings.NewReader(input), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// As convert does: a record with no parent relation keeps its column
+	hub.ComputeMembershipPaths(records)
+
+	var out bytes.Buffer
+	err = (&Format{}).Serialize(&out, records, &format.SerializeOptions{
+		Columns:       []string{"title", "membership_path"},
+		IncludeHeader: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != input {
+		t.Errorf("got:\n%s\nwant:\n%s", out.String(), input)
+	}
+}
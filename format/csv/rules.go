@@ -0,0 +1,47 @@
+package csv
+
+import (
+	"strings"
+
+	"github.com/lehigh-university-libraries/crosswalk/mapping"
+)
+
+// cellRoute is the part of a cell's values that maps to one hub field.
+type cellRoute struct {
+	ir    string
+	value string
+}
+
+// routeCell splits a cell's values by the hub field each is routed to by
+// the column's rules, keeping their order and rejoining each group with
+// sep. Rules can test any column of the row by its lowercased name. Only a
+// rule's ir applies, since CSV columns carry their vocabulary or date type
+// in the hub field name (e.g. Identifiers.doi).
+func routeCell(m mapping.FieldMapping, column, cell, sep string, row, header []string) []cellRoute {
+	fields := make(map[string]string, len(header))
+	for i, col := range header {
+		if i < len(row) {
+			fields[strings.ToLower(strings.TrimSpace(col))] = strings.TrimSpace(row[i])
+		}
+	}
+
+	var routes []cellRoute
+	for _, v := range splitMultiValue(cell, sep) {
+		routed, ok := m.Route(column, v, fields)
+		if !ok || routed.IR == "" {
+			continue
+		}
+		found := false
+		for i := range routes {
+			if routes[i].ir == routed.IR {
+				routes[i].value += sep + v
+				found = true
+				break
+			}
+		}
+		if !found {
+			routes = append(routes, cellRoute{routed.IR, v})
+		}
+	}
+	return routes
+}
@@ -8,12 +8,25 @@ import (
 
 	"github.com/lehigh-university-libraries/crosswalk/format"
 	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/helpers"
 	"github.com/lehigh-university-libraries/crosswalk/hub"
 	"github.com/lehigh-university-libraries/crosswalk/mapping"
 )
 
 // Serialize writes hub records as CSV.
 func (f *Format) Serialize(w io.Writer, records []*hubv1.Record, opts *format.SerializeOptions) error {
+	return f.SerializeStream(w, opts, func(fn func(*hubv1.Record) error) error {
+		for _, record := range records {
+			if err := fn(record); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// SerializeStream writes hub records as CSV as each hands them over.
+func (f *Format) SerializeStream(w io.Writer, opts *format.SerializeOptions, each func(fn func(*hubv1.Record) error) error) error {
 	if opts == nil {
 		opts = format.NewSerializeOptions()
 	}
@@ -28,38 +41,31 @@ func (f *Format) Serialize(w io.Writer, records []*hubv1.Record, opts *format.Se
 		columns = mapping.DefaultCSVColumns()
 	}
 
+	renderer, err := newRowRenderer(columns, opts, sep)
+	if err != nil {
+		return err
+	}
+
 	writer := csv.NewWriter(w)
 	defer writer.Flush()
 
 	// Write header
 	if opts.IncludeHeader {
-		if err := writer.Write(columns); err != nil {
+		if err := writer.Write(renderer.columns); err != nil {
 			return err
 		}
 	}
 
-	// Write records
-	for _, record := range records {
-		row := recordToRow(record, columns, sep)
-		if err := writer.Write(row); err != nil {
-			return err
-		}
+	// Write records, rendering rows on opts.Workers goroutines
+	err = helpers.MapOrdered(opts.Workers, each, renderer.row, writer.Write)
+	if err != nil {
+		return err
 	}
 
 	return writer.Error()
 }
 
-func recordToRow(record *hubv1.Record, columns []string, sep string) []string {
-	row := make([]string, len(columns))
-
-	for i, col := range columns {
-		row[i] = getColumnValue(record, col, sep)
-	}
-
-	return row
-}
-
-func getColumnValue(record *hubv1.Record, column string, sep string) string {
+func getColumnValue(record *hubv1.Record, column string, sep string, dates format.DateOptions) string {
 	switch column {
 	case "title":
 		return record.Title
@@ -76,20 +82,20 @@ func getColumnValue(record *hubv1.Record, column string, sep string) string {
 
 	case "date_issued":
 		if d := hub.GetDateIssued(record); d != nil {
-			return hub.DateString(d)
+			return dateValue(d, dates)
 		}
 		return ""
 
 	case "date_created":
 		if d := hub.GetDateCreated(record); d != nil {
-			return hub.DateString(d)
+			return dateValue(d, dates)
 		}
 		return ""
 
 	case "date":
 		// Primary date
 		if d := hub.PrimaryDate(record); d != nil {
-			return hub.DateString(d)
+			return dateValue(d, dates)
 		}
 		return ""
 
@@ -183,6 +189,9 @@ func getColumnValue(record *hubv1.Record, column string, sep string) string {
 		}
 		return strings.Join(titles, sep)
 
+	case "membership_path":
+		return strings.Join(hub.MembershipTitles(record), " > ")
+
 	case "member_of_id":
 		rels := hub.GetMemberOf(record)
 		ids := make([]string, 0, len(rels))
@@ -264,6 +273,15 @@ func getColumnValue(record *hubv1.Record, column string, sep string) string {
 	}
 }
 
+// dateValue renders a date cell. Without explicit date options the raw
+// source value is kept, as before.
+func dateValue(d *hubv1.DateValue, dates format.DateOptions) string {
+	if dates == (format.DateOptions{}) {
+		return hub.DateString(d)
+	}
+	return format.FormatDate(d, dates)
+}
+
 // DefaultColumns returns the standard column set for CSV output.
 func DefaultColumns() []string {
 	return mapping.DefaultCSVColumns()
@@ -0,0 +1,88 @@
+package csv
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+)
+
+// rowRenderer renders records as rows of the output's columns.
+type rowRenderer struct {
+	columns   []string
+	static    map[string]string
+	templates map[string]*template.Template
+	sep       string
+	dates     format.DateOptions
+}
+
+// newRowRenderer returns a renderer for the columns and the options'
+// static and template columns, which follow columns when it doesn't list
+// them. Templates render over the hub record, with two helpers: column
+// renders one of the built-in columns ({{column . "date_issued"}}) and
+// join joins a list ({{join .AltTitle "; "}}).
+func newRowRenderer(columns []string, opts *format.SerializeOptions, sep string) (*rowRenderer, error) {
+	r := &rowRenderer{
+		columns: slices.Clone(columns),
+		static:  opts.StaticColumns,
+		sep:     sep,
+		dates:   opts.Dates,
+	}
+
+	var extra []string
+	for name := range opts.StaticColumns {
+		if _, ok := opts.TemplateColumns[name]; ok {
+			return nil, fmt.Errorf("column %q is both static and a template", name)
+		}
+		extra = append(extra, name)
+	}
+	funcs := template.FuncMap{
+		"column": func(record *hubv1.Record, name string) string {
+			return getColumnValue(record, name, sep, opts.Dates)
+		},
+		"join": strings.Join,
+	}
+	for name, text := range opts.TemplateColumns {
+		tmpl, err := template.New(name).Funcs(funcs).Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("template column %q: %w", name, err)
+		}
+		if r.templates == nil {
+			r.templates = make(map[string]*template.Template)
+		}
+		r.templates[name] = tmpl
+		extra = append(extra, name)
+	}
+	slices.Sort(extra)
+	for _, name := range extra {
+		if !slices.Contains(r.columns, name) {
+			r.columns = append(r.columns, name)
+		}
+	}
+	return r, nil
+}
+
+// row renders the record's values for each column.
+func (r *rowRenderer) row(record *hubv1.Record) ([]string, error) {
+	row := make([]string, len(r.columns))
+	for i, col := range r.columns {
+		if v, ok := r.static[col]; ok {
+			row[i] = v
+			continue
+		}
+		if tmpl, ok := r.templates[col]; ok {
+			var buf bytes.Buffer
+			if err := tmpl.Execute(&buf, record); err != nil {
+				return nil, fmt.Errorf("template column %q: %w", col, err)
+			}
+			row[i] = buf.String()
+			continue
+		}
+		row[i] = getColumnValue(record, col, r.sep, r.dates)
+	}
+	return row, nil
+}
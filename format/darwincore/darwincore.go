@@ -0,0 +1,78 @@
+// Package darwincore provides a parser for Darwin Core occurrence data:
+// Darwin Core Archives and simple Darwin Core term files.
+//
+// An archive is a zip with a meta.xml descriptor naming its core data
+// file and the term in each column; only occurrence cores are read. An
+// archive without a meta.xml, or a CSV or tab-separated file read on its
+// own, has a header row of terms instead. Each occurrence becomes a hub
+// record.
+//
+// The mapping covers:
+//   - scientificName (with scientificNameID or taxonID as its URI),
+//     family, and genus as subjects, and vernacularName as keywords; the
+//     title is the scientific name with the catalog number
+//   - country, stateProvince, county, municipality, and locality as the
+//     hierarchical geographic location, higherGeography (or the region)
+//     as a geographic subject, and decimalLatitude and decimalLongitude
+//     as the "coordinates" extra
+//   - recordedBy as collectors and identifiedBy as identifiers
+//   - eventDate (or year, month, and day) as the date collected, and
+//     modified
+//   - institutionCode, collectionCode, and catalogNumber as a holding,
+//     occurrenceID and catalogNumber as identifiers, and datasetName as
+//     the collection the record is a member of
+//   - license, rightsHolder, accessRights, and informationWithheld
+//   - habitat, occurrenceRemarks, and fieldNotes as notes, and
+//     preparations as the physical description
+//   - associatedMedia, and the rows of a multimedia extension (GBIF
+//     Simple Multimedia or Audubon Core), as files
+//   - basisOfRecord as the resource type
+package darwincore
+
+import (
+	"bytes"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+)
+
+// Format implements the Darwin Core format.
+type Format struct{}
+
+// Ensure Format implements the interfaces
+var (
+	_ format.Format = (*Format)(nil)
+	_ format.Parser = (*Format)(nil)
+)
+
+// Name returns the format identifier.
+func (f *Format) Name() string {
+	return "darwincore"
+}
+
+// Description returns a human-readable format description.
+func (f *Format) Description() string {
+	return "Darwin Core Archives (occurrence core) and Darwin Core term files"
+}
+
+// Extensions returns file extensions associated with this format.
+func (f *Format) Extensions() []string {
+	return []string{"dwca"}
+}
+
+// CanParse returns true if the input looks like a Darwin Core Archive, or
+// a term file whose header has a scientific name and an occurrence's
+// identifying terms.
+func (f *Format) CanParse(peek []byte) bool {
+	if bytes.HasPrefix(peek, zipMagic) {
+		return bytes.Contains(peek, []byte("meta.xml")) || bytes.Contains(peek, []byte("occurrence."))
+	}
+	header, _, _ := bytes.Cut(peek, []byte("\n"))
+	return bytes.Contains(header, []byte("scientificName")) &&
+		(bytes.Contains(header, []byte("occurrenceID")) ||
+			bytes.Contains(header, []byte("catalogNumber")) ||
+			bytes.Contains(header, []byte("basisOfRecord")))
+}
+
+func init() {
+	format.Register(&Format{})
+}
@@ -0,0 +1,366 @@
+package darwincore
+
+import (
+	"cmp"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/helpers"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+// occurrenceToRecord maps an occurrence, with its multimedia rows, to a
+// hub record.
+func occurrenceToRecord(occ Terms, media []Terms) *hubv1.Record {
+	record := hub.NewRecord()
+
+	mapTaxon(record, occ)
+	record.Title = title(occ)
+
+	// Who collected and identified the specimen
+	for _, name := range splitList(occ["recordedBy"]) {
+		record.Contributors = append(record.Contributors, agent(name, "col"))
+	}
+	for _, name := range splitList(occ["identifiedBy"]) {
+		c := agent(name, "")
+		c.Role = "Identifier"
+		record.Contributors = append(record.Contributors, c)
+	}
+
+	mapPlace(record, occ)
+	mapDates(record, occ)
+
+	// Where the specimen is held
+	if occ["institutionCode"] != "" || occ["catalogNumber"] != "" {
+		record.Holdings = append(record.Holdings, &hubv1.Holding{
+			Institution:      occ["institutionCode"],
+			Sublocation:      occ["collectionCode"],
+			CallNumber:       occ["catalogNumber"],
+			CallNumberScheme: "local",
+		})
+	}
+
+	if id := occ["occurrenceID"]; id != "" {
+		idType := hub.DetectIdentifierType(id)
+		if idType == hubv1.IdentifierType_IDENTIFIER_TYPE_UNSPECIFIED {
+			idType = hubv1.IdentifierType_IDENTIFIER_TYPE_LOCAL
+		}
+		record.Identifiers = append(record.Identifiers, hub.NewIdentifier(id, idType))
+	}
+	if catalog := occ["catalogNumber"]; catalog != "" {
+		record.Identifiers = append(record.Identifiers,
+			hub.NewIdentifier(catalog, hubv1.IdentifierType_IDENTIFIER_TYPE_CALL_NUMBER))
+	}
+
+	if name := occ["datasetName"]; name != "" || occ["datasetID"] != "" {
+		rel := hub.NewRelation(hubv1.RelationType_RELATION_TYPE_MEMBER_OF, name)
+		rel.TargetId = occ["datasetID"]
+		record.Relations = append(record.Relations, rel)
+	}
+
+	// Rights and access
+	if license := occ["license"]; license != "" {
+		if strings.Contains(license, "://") {
+			record.Rights = append(record.Rights, hub.NewRightsFromURI(license))
+		} else {
+			record.Rights = append(record.Rights, &hubv1.Rights{Statement: license})
+		}
+	}
+	record.RightsHolder = occ["rightsHolder"]
+	record.AccessCondition = occ["accessRights"]
+	record.IsPublic = occ["informationWithheld"] == ""
+	if withheld := occ["informationWithheld"]; withheld != "" {
+		record.Notes = append(record.Notes, "Information withheld: "+withheld)
+	}
+
+	record.Language = occ["language"]
+	record.PhysicalDesc = occ["preparations"]
+	if habitat := occ["habitat"]; habitat != "" {
+		record.Notes = append(record.Notes, "Habitat: "+habitat)
+	}
+	for _, term := range []string{"occurrenceRemarks", "fieldNotes"} {
+		if v := occ[term]; v != "" {
+			record.Notes = append(record.Notes, v)
+		}
+	}
+	record.Description = description(occ)
+
+	// Specimen images
+	for _, url := range splitList(occ["associatedMedia"]) {
+		record.Files = append(record.Files, &hubv1.File{Path: url, Name: path.Base(url), Role: hub.FileRoleOriginal})
+	}
+	for _, m := range media {
+		url := cmp.Or(m["accessURI"], m["identifier"])
+		if url == "" || hasFile(record, url) {
+			continue
+		}
+		record.Files = append(record.Files, &hubv1.File{
+			Path:        url,
+			Name:        path.Base(url),
+			MimeType:    m["format"],
+			Description: cmp.Or(m["title"], m["description"]),
+			Role:        hub.FileRoleOriginal,
+		})
+	}
+
+	record.ResourceType = resourceType(occ["basisOfRecord"])
+	record.SourceInfo = &hubv1.SourceInfo{
+		Format:   "darwincore",
+		SourceId: cmp.Or(occ["occurrenceID"], occ["id"], occ["catalogNumber"]),
+	}
+	return record
+}
+
+// title names the occurrence by its taxon and catalog number, e.g.
+// "Quercus alba L. (LEH 012345)".
+func title(occ Terms) string {
+	name := cmp.Or(occ["scientificName"], lowestTaxon(occ), occ["vernacularName"])
+	catalog := strings.TrimSpace(occ["institutionCode"] + " " + occ["catalogNumber"])
+	if occ["catalogNumber"] == "" {
+		catalog = ""
+	}
+	switch {
+	case name != "" && catalog != "":
+		return fmt.Sprintf("%s (%s)", name, catalog)
+	case name != "":
+		return name
+	case catalog != "":
+		return catalog
+	}
+	return occ["occurrenceID"]
+}
+
+// description summarizes where and when the occurrence was recorded, and
+// by whom.
+func description(occ Terms) string {
+	var parts []string
+	if kind := occ["basisOfRecord"]; kind != "" {
+		parts = append(parts, splitCamel(kind))
+	}
+	if by := occ["recordedBy"]; by != "" {
+		parts = append(parts, "collected by "+strings.Join(splitList(by), ", "))
+	}
+	if place := placeName(occ); place != "" {
+		parts = append(parts, "at "+place)
+	}
+	if date := cmp.Or(occ["eventDate"], occ["verbatimEventDate"]); date != "" {
+		parts = append(parts, "on "+date)
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	text := strings.Join(parts, " ")
+	return strings.ToUpper(text[:1]) + text[1:] + "."
+}
+
+// taxonRanks are the ranks of the higher classification, highest first.
+var taxonRanks = []string{"kingdom", "phylum", "class", "order", "family", "genus"}
+
+// lowestTaxon returns the most specific taxon named in the higher
+// classification.
+func lowestTaxon(occ Terms) string {
+	for i := len(taxonRanks) - 1; i >= 0; i-- {
+		if v := occ[taxonRanks[i]]; v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// mapTaxon adds the scientific name, with its identifier, the family and
+// genus, and the common name as subjects.
+func mapTaxon(record *hubv1.Record, occ Terms) {
+	if name := occ["scientificName"]; name != "" {
+		s := &hubv1.Subject{
+			Value:      name,
+			Vocabulary: hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LOCAL,
+			Type:       hubv1.SubjectType_SUBJECT_TYPE_TOPIC,
+			SourceId:   occ["taxonID"],
+		}
+		for _, id := range []string{occ["scientificNameID"], occ["taxonID"]} {
+			if strings.HasPrefix(id, "http://") || strings.HasPrefix(id, "https://") || strings.HasPrefix(id, "urn:lsid:") {
+				s.Uri = id
+				break
+			}
+		}
+		record.Subjects = append(record.Subjects, s)
+	}
+	for _, rank := range []string{"family", "genus"} {
+		if v := occ[rank]; v != "" && v != occ["scientificName"] {
+			record.Subjects = append(record.Subjects, &hubv1.Subject{
+				Value:      v,
+				Vocabulary: hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LOCAL,
+				Type:       hubv1.SubjectType_SUBJECT_TYPE_TOPIC,
+			})
+		}
+	}
+	for _, name := range splitList(occ["vernacularName"]) {
+		record.Subjects = append(record.Subjects, &hubv1.Subject{
+			Value:      name,
+			Vocabulary: hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_KEYWORDS,
+			Type:       hubv1.SubjectType_SUBJECT_TYPE_TOPIC,
+		})
+	}
+}
+
+// mapPlace sets where the occurrence was recorded: the named places as
+// the record's geographic location and a geographic subject, and the
+// decimal coordinates as the "coordinates" extra ("lat,lng").
+func mapPlace(record *hubv1.Record, occ Terms) {
+	geo := &hubv1.HierarchicalGeographic{
+		Country: cmp.Or(occ["country"], occ["countryCode"]),
+		State:   occ["stateProvince"],
+		County:  occ["county"],
+		City:    occ["municipality"],
+		Area:    cmp.Or(occ["locality"], occ["verbatimLocality"]),
+	}
+	if geo.Country != "" || geo.State != "" || geo.County != "" || geo.City != "" || geo.Area != "" {
+		record.Geographic = geo
+	}
+	if place := cmp.Or(occ["higherGeography"], regionName(occ)); place != "" {
+		record.Subjects = append(record.Subjects, &hubv1.Subject{
+			Value:      place,
+			Vocabulary: hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LOCAL,
+			Type:       hubv1.SubjectType_SUBJECT_TYPE_GEOGRAPHIC,
+			SourceId:   occ["locationID"],
+		})
+	}
+
+	lat, errLat := strconv.ParseFloat(occ["decimalLatitude"], 64)
+	lon, errLon := strconv.ParseFloat(occ["decimalLongitude"], 64)
+	if errLat == nil && errLon == nil && lat >= -90 && lat <= 90 && lon >= -180 && lon <= 180 {
+		hub.SetExtra(record, "coordinates", strconv.FormatFloat(lat, 'f', -1, 64)+","+strconv.FormatFloat(lon, 'f', -1, 64))
+		for _, term := range []string{"geodeticDatum", "coordinateUncertaintyInMeters"} {
+			if v := occ[term]; v != "" {
+				hub.SetExtra(record, term, v)
+			}
+		}
+	}
+}
+
+// regionName joins the administrative places, narrowest first, as in
+// "Northampton County, Pennsylvania, United States".
+func regionName(occ Terms) string {
+	var parts []string
+	for _, term := range []string{"county", "stateProvince", "country"} {
+		if v := occ[term]; v != "" {
+			parts = append(parts, v)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// placeName joins the locality with its region.
+func placeName(occ Terms) string {
+	var parts []string
+	for _, v := range []string{cmp.Or(occ["locality"], occ["verbatimLocality"]), occ["municipality"], regionName(occ)} {
+		if v != "" {
+			parts = append(parts, v)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// mapDates sets the date collected from eventDate, or from the year,
+// month, and day terms, and the date modified.
+func mapDates(record *hubv1.Record, occ Terms) {
+	collected := occ["eventDate"]
+	if collected == "" && occ["year"] != "" {
+		collected = occ["year"]
+		if month, err := strconv.Atoi(occ["month"]); err == nil {
+			collected += fmt.Sprintf("-%02d", month)
+			if day, err := strconv.Atoi(occ["day"]); err == nil {
+				collected += fmt.Sprintf("-%02d", day)
+			}
+		}
+	}
+	if collected != "" {
+		if dv, err := helpers.ParseEDTF(collected, hubv1.DateType_DATE_TYPE_COLLECTED); err == nil {
+			record.Dates = append(record.Dates, dv)
+		} else {
+			record.Dates = append(record.Dates, &hubv1.DateValue{Type: hubv1.DateType_DATE_TYPE_COLLECTED, Raw: collected})
+		}
+	} else if verbatim := occ["verbatimEventDate"]; verbatim != "" {
+		record.Dates = append(record.Dates, &hubv1.DateValue{Type: hubv1.DateType_DATE_TYPE_COLLECTED, Raw: verbatim})
+	}
+
+	if modified := occ["modified"]; modified != "" {
+		if dv, err := helpers.ParseEDTF(modified, hubv1.DateType_DATE_TYPE_MODIFIED); err == nil {
+			record.Dates = append(record.Dates, dv)
+		}
+	}
+}
+
+// agent returns a person contributor, in a MARC relator role if code is
+// given. Names are given as written, often "Surname, Initials".
+func agent(name, code string) *hubv1.Contributor {
+	c := &hubv1.Contributor{
+		Name:       name,
+		Type:       hubv1.ContributorType_CONTRIBUTOR_TYPE_PERSON,
+		ParsedName: helpers.ParseName(name),
+	}
+	if code != "" {
+		c.RoleCode = "relators:" + code
+		c.Role = helpers.RelatorLabel(code)
+	}
+	return c
+}
+
+// resourceType maps basisOfRecord: specimens are physical objects,
+// observations data, and citations of material text.
+func resourceType(basis string) *hubv1.ResourceType {
+	rt := &hubv1.ResourceType{
+		Type:       hubv1.ResourceTypeValue_RESOURCE_TYPE_OBJECT,
+		Original:   basis,
+		Vocabulary: "dwc",
+	}
+	switch strings.ToLower(basis) {
+	case "humanobservation", "machineobservation", "occurrence", "event":
+		rt.Type = hubv1.ResourceTypeValue_RESOURCE_TYPE_DATASET
+	case "materialcitation":
+		rt.Type = hubv1.ResourceTypeValue_RESOURCE_TYPE_TEXT
+	}
+	return rt
+}
+
+// splitList splits a Darwin Core list, whose values are separated by
+// " | " (or, in older data, by semicolons).
+func splitList(s string) []string {
+	sep := "|"
+	if !strings.Contains(s, "|") && strings.Contains(s, ";") {
+		sep = ";"
+	}
+	var values []string
+	for v := range strings.SplitSeq(s, sep) {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// splitCamel spells out a basisOfRecord: "PreservedSpecimen" is
+// "preserved specimen".
+func splitCamel(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		if i > 0 && r >= 'A' && r <= 'Z' {
+			b.WriteByte(' ')
+		}
+		b.WriteRune(r)
+	}
+	return strings.ToLower(b.String())
+}
+
+// hasFile reports whether the record already has a file at url.
+func hasFile(record *hubv1.Record, url string) bool {
+	for _, f := range record.Files {
+		if f.Path == url {
+			return true
+		}
+	}
+	return false
+}
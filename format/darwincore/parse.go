@@ -0,0 +1,296 @@
+package darwincore
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+)
+
+// zipMagic begins every zip file.
+var zipMagic = []byte("PK\x03\x04")
+
+// Terms is a row's values by term name (e.g. "scientificName"), without
+// the term's namespace.
+type Terms map[string]string
+
+// Parse reads a Darwin Core Archive (a zip with a meta.xml descriptor) or
+// a Darwin Core term file (CSV or tab-separated text with a header row of
+// terms) and returns a record per occurrence.
+func (f *Format) Parse(r io.Reader, opts *format.ParseOptions) ([]*hubv1.Record, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("reading Darwin Core input: %w", err)
+	}
+
+	var occurrences []Terms
+	media := map[string][]Terms{}
+	if bytes.HasPrefix(data, zipMagic) {
+		occurrences, media, err = readArchive(data)
+	} else {
+		occurrences, err = readTermFile(data)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]*hubv1.Record, 0, len(occurrences))
+	for _, occ := range occurrences {
+		records = append(records, occurrenceToRecord(occ, media[occ["id"]]))
+	}
+	return records, nil
+}
+
+// readArchive reads the occurrence core of a Darwin Core Archive, and its
+// multimedia extension rows by core id. An archive without a meta.xml
+// holds a single term file.
+func readArchive(data []byte) ([]Terms, map[string][]Terms, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading Darwin Core archive: %w", err)
+	}
+
+	// Files are located relative to meta.xml, which may sit in a folder
+	files := map[string]*zip.File{}
+	var meta *zip.File
+	var dataFiles []*zip.File
+	for _, zf := range zr.File {
+		if zf.FileInfo().IsDir() {
+			continue
+		}
+		files[zf.Name] = zf
+		switch strings.ToLower(path.Ext(zf.Name)) {
+		case ".txt", ".csv", ".tsv", ".tab":
+			dataFiles = append(dataFiles, zf)
+		}
+		if path.Base(zf.Name) == "meta.xml" && (meta == nil || len(zf.Name) < len(meta.Name)) {
+			meta = zf
+		}
+	}
+
+	if meta == nil {
+		if len(dataFiles) != 1 {
+			return nil, nil, fmt.Errorf("reading Darwin Core archive: no meta.xml, and %d data files instead of one", len(dataFiles))
+		}
+		text, err := readZipFile(dataFiles[0])
+		if err != nil {
+			return nil, nil, err
+		}
+		occurrences, err := readTermFile(text)
+		return occurrences, nil, err
+	}
+
+	text, err := readZipFile(meta)
+	if err != nil {
+		return nil, nil, err
+	}
+	var archive Archive
+	if err := xml.Unmarshal(text, &archive); err != nil {
+		return nil, nil, fmt.Errorf("parsing meta.xml: %w", err)
+	}
+	if archive.Core == nil {
+		return nil, nil, fmt.Errorf("parsing meta.xml: no core")
+	}
+	if archive.Core.RowType != rowTypeOccurrence {
+		return nil, nil, fmt.Errorf("unsupported core row type %q: only occurrence cores are read", archive.Core.RowType)
+	}
+
+	dir := path.Dir(meta.Name)
+	occurrences, err := readFileSet(files, dir, archive.Core, archive.Core.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	media := map[string][]Terms{}
+	for i := range archive.Extensions {
+		ext := &archive.Extensions[i]
+		if ext.RowType != rowTypeGBIFMultimedia && ext.RowType != rowTypeACMultimedia {
+			continue
+		}
+		rows, err := readFileSet(files, dir, ext, ext.CoreID)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, row := range rows {
+			media[row["id"]] = append(media[row["id"]], row)
+		}
+	}
+	return occurrences, media, nil
+}
+
+// readFileSet reads the rows of a file set's data files as terms. The
+// column at id, if any, is kept as the "id" term.
+func readFileSet(files map[string]*zip.File, dir string, fs *FileSet, id *Index) ([]Terms, error) {
+	var result []Terms
+	for _, loc := range fs.Locations {
+		zf := files[path.Join(dir, strings.TrimSpace(loc))]
+		if zf == nil {
+			return nil, fmt.Errorf("reading Darwin Core archive: %s is not in the archive", loc)
+		}
+		raw, err := readZipFile(zf)
+		if err != nil {
+			return nil, err
+		}
+		text, err := decode(raw, fs.Encoding)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", loc, err)
+		}
+		rows, err := splitRows(text, fs.delimiter(), fs.quote())
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", loc, err)
+		}
+		if fs.IgnoreHeaderLines < len(rows) {
+			rows = rows[fs.IgnoreHeaderLines:]
+		} else {
+			rows = nil
+		}
+
+		for _, row := range rows {
+			terms := Terms{}
+			for _, field := range fs.Fields {
+				name := termName(field.Term)
+				if i, ok := field.column(); ok && i < len(row) && strings.TrimSpace(row[i]) != "" {
+					terms[name] = strings.TrimSpace(row[i])
+				} else if field.Default != "" {
+					terms[name] = field.Default
+				}
+			}
+			if id != nil && id.Index < len(row) {
+				terms["id"] = strings.TrimSpace(row[id.Index])
+			}
+			result = append(result, terms)
+		}
+	}
+	return result, nil
+}
+
+// readTermFile reads a term file: a header row of terms (plain, prefixed
+// as "dwc:scientificName", or as URIs) over rows of values, separated by
+// tabs if the header has any and by commas otherwise.
+func readTermFile(data []byte) ([]Terms, error) {
+	text, err := decode(data, "UTF-8")
+	if err != nil {
+		return nil, err
+	}
+	text = strings.TrimPrefix(text, "\ufeff")
+	header, _, _ := strings.Cut(text, "\n")
+	delim, quote := ",", `"`
+	if strings.Contains(header, "\t") {
+		delim, quote = "\t", ""
+	}
+
+	rows, err := splitRows(text, delim, quote)
+	if err != nil {
+		return nil, fmt.Errorf("parsing Darwin Core text: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	names := make([]string, len(rows[0]))
+	for i, term := range rows[0] {
+		names[i] = termName(term)
+	}
+	var result []Terms
+	for _, row := range rows[1:] {
+		terms := Terms{}
+		for i, value := range row {
+			if value = strings.TrimSpace(value); value != "" && i < len(names) && names[i] != "" {
+				terms[names[i]] = value
+			}
+		}
+		result = append(result, terms)
+	}
+	return result, nil
+}
+
+// splitRows splits delimited text into rows of fields. Fields are quoted
+// CSV-style when quote is '"'; without a quote character, quotes are
+// literal and no field spans lines.
+func splitRows(text, delim, quote string) ([][]string, error) {
+	comma, size := utf8.DecodeRuneInString(delim)
+	if size == 0 || size != len(delim) {
+		return nil, fmt.Errorf("unsupported field delimiter %q", delim)
+	}
+
+	switch quote {
+	case `"`:
+		reader := csv.NewReader(strings.NewReader(text))
+		reader.Comma = comma
+		reader.FieldsPerRecord = -1
+		reader.LazyQuotes = true
+		var rows [][]string
+		for {
+			row, err := reader.Read()
+			if errors.Is(err, io.EOF) {
+				return rows, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			rows = append(rows, row)
+		}
+	case "":
+		var rows [][]string
+		for line := range strings.Lines(text) {
+			line = strings.TrimRight(line, "\r\n")
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			rows = append(rows, strings.Split(line, delim))
+		}
+		return rows, nil
+	default:
+		return nil, fmt.Errorf("unsupported field enclosure %q", quote)
+	}
+}
+
+// decode returns data as text, read as UTF-8 or ISO-8859-1.
+func decode(data []byte, encoding string) (string, error) {
+	switch strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(encoding), "_", "-")) {
+	case "", "UTF-8", "UTF8":
+		return string(data), nil
+	case "ISO-8859-1", "LATIN1", "LATIN-1":
+		runes := make([]rune, len(data))
+		for i, b := range data {
+			runes[i] = rune(b)
+		}
+		return string(runes), nil
+	default:
+		return "", fmt.Errorf("unsupported encoding %q", encoding)
+	}
+}
+
+// readZipFile returns the contents of an archive member.
+func readZipFile(zf *zip.File) ([]byte, error) {
+	rc, err := zf.Open()
+	if err != nil {
+		return nil, fmt.Errorf("reading Darwin Core archive: %w", err)
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", zf.Name, err)
+	}
+	return data, nil
+}
+
+// termName returns a term without its namespace:
+// "http://rs.tdwg.org/dwc/terms/scientificName" and "dwc:scientificName"
+// are both "scientificName".
+func termName(term string) string {
+	term = strings.TrimSpace(term)
+	if i := strings.LastIndexAny(term, "/#:"); i >= 0 {
+		term = term[i+1:]
+	}
+	return term
+}
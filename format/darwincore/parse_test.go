@@ -0,0 +1,238 @@
+package darwincore
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+// zipArchive zips the files of testdata/archive under prefix, replacing
+// those named in replace.
+func zipArchive(t *testing.T, prefix string, replace map[string]string) []byte {
+	t.Helper()
+	entries, err := os.ReadDir("testdata/archive")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, e := range entries {
+		data, err := os.ReadFile(filepath.Join("testdata/archive", e.Name()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if r, ok := replace[e.Name()]; ok {
+			data = []byte(r)
+		}
+		w, err := zw.Create(prefix + e.Name())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write(data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func parse(t *testing.T, data []byte) []*hubv1.Record {
+	t.Helper()
+	records, err := (&Format{}).Parse(bytes.NewReader(data), nil)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	return records
+}
+
+func TestParseArchive(t *testing.T) {
+	records := parse(t, zipArchive(t, "", nil))
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+	record := records[0]
+
+	if record.Title != "Quercus alba L. (LEH 012345)" {
+		t.Errorf("Title: got %q", record.Title)
+	}
+	if len(record.Subjects) != 4 {
+		t.Fatalf("expected 4 subjects, got %v", record.Subjects)
+	}
+	if s := record.Subjects[0]; s.Value != "Quercus alba L." || s.Type != hubv1.SubjectType_SUBJECT_TYPE_TOPIC {
+		t.Errorf("scientific name: got %v", s)
+	}
+	if s := record.Subjects[1]; s.Value != "Fagaceae" {
+		t.Errorf("family: got %v", s)
+	}
+	if s := record.Subjects[3]; s.Value != "Northampton, Pennsylvania, United States" || s.Type != hubv1.SubjectType_SUBJECT_TYPE_GEOGRAPHIC {
+		t.Errorf("geographic subject: got %v", s)
+	}
+
+	geo := record.Geographic
+	if geo == nil || geo.Country != "United States" || geo.State != "Pennsylvania" || geo.County != "Northampton" ||
+		geo.Area != `South Mountain, "Sayre Park" trail` {
+		t.Errorf("Geographic: got %v", geo)
+	}
+	if got := hub.GetExtraString(record, "coordinates"); got != "40.6023,-75.3776" {
+		t.Errorf("coordinates: got %q", got)
+	}
+
+	if len(record.Contributors) != 2 || record.Contributors[1].Name != "Doe, A." || record.Contributors[1].RoleCode != "relators:col" {
+		t.Errorf("collectors: got %v", record.Contributors)
+	}
+	if d := hub.GetDate(record, hubv1.DateType_DATE_TYPE_COLLECTED); d == nil || d.Year != 1932 || d.Month != 5 || d.Day != 14 {
+		t.Errorf("collected: got %v", d)
+	}
+
+	if len(record.Holdings) != 1 || record.Holdings[0].Institution != "LEH" || record.Holdings[0].Sublocation != "Herbarium" ||
+		record.Holdings[0].CallNumber != "012345" {
+		t.Errorf("Holdings: got %v", record.Holdings)
+	}
+	if id := hub.GetIdentifier(record, hubv1.IdentifierType_IDENTIFIER_TYPE_URN); id == nil || id.Value != "urn:catalog:LEH:Herbarium:012345" {
+		t.Errorf("occurrenceID: got %v", record.Identifiers)
+	}
+	if rels := hub.GetRelationsByType(record, hubv1.RelationType_RELATION_TYPE_MEMBER_OF); len(rels) != 1 ||
+		rels[0].TargetTitle != "Lehigh University Herbarium" {
+		t.Errorf("dataset: got %v", rels)
+	}
+	if len(record.Rights) != 1 || record.Rights[0].Uri != "http://creativecommons.org/publicdomain/zero/1.0/" {
+		t.Errorf("default license: got %v", record.Rights)
+	}
+	if len(record.Notes) != 1 || record.Notes[0] != "Habitat: Dry oak woods" {
+		t.Errorf("Notes: got %v", record.Notes)
+	}
+	if !strings.HasPrefix(record.Description, "Preserved specimen collected by Smith, J., Doe, A. at South Mountain") {
+		t.Errorf("Description: got %q", record.Description)
+	}
+
+	if len(record.Files) != 1 || record.Files[0].Path != "https://images.lehigh.edu/herbarium/012345.jpg" ||
+		record.Files[0].MimeType != "image/jpeg" || record.Files[0].Description != "Herbarium sheet" {
+		t.Errorf("Files: got %v", record.Files)
+	}
+	if record.ResourceType.Type != hubv1.ResourceTypeValue_RESOURCE_TYPE_OBJECT || record.ResourceType.Original != "PreservedSpecimen" {
+		t.Errorf("ResourceType: got %v", record.ResourceType)
+	}
+	if si := record.SourceInfo; si.Format != "darwincore" || si.SourceId != "urn:catalog:LEH:Herbarium:012345" {
+		t.Errorf("SourceInfo: got %v", si)
+	}
+
+	second := records[1]
+	if len(second.Rights) != 1 || second.Rights[0].Uri != "http://creativecommons.org/licenses/by/4.0/" {
+		t.Errorf("license should override the default: got %v", second.Rights)
+	}
+	if _, ok := hub.GetExtra(second, "coordinates"); ok || len(second.Files) != 0 {
+		t.Errorf("second occurrence has no coordinates or media: got %v", second)
+	}
+	if second.SourceInfo.SourceId != "2" {
+		t.Errorf("core id as source ID: got %q", second.SourceInfo.SourceId)
+	}
+}
+
+func TestParseArchiveLayouts(t *testing.T) {
+	t.Run("in a folder", func(t *testing.T) {
+		if records := parse(t, zipArchive(t, "dwca-herbarium/", nil)); len(records) != 2 {
+			t.Errorf("expected 2 records, got %d", len(records))
+		}
+	})
+
+	t.Run("without meta.xml", func(t *testing.T) {
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		w, _ := zw.Create("occurrence.txt")
+		w.Write([]byte("occurrenceID\tscientificName\nx1\tQuercus rubra L.\n"))
+		zw.Close()
+
+		records := parse(t, buf.Bytes())
+		if len(records) != 1 || records[0].Title != "Quercus rubra L." {
+			t.Errorf("got %v", records)
+		}
+	})
+}
+
+func TestParseTermFile(t *testing.T) {
+	data, err := os.ReadFile("testdata/occurrences.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	records := parse(t, data)
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+
+	record := records[0]
+	if record.Title != "Trillium erectum L. (LEH 000077)" {
+		t.Errorf("Title: got %q", record.Title)
+	}
+	if kw := hub.GetKeywords(record); len(kw) != 2 || kw[1].Value != "wakerobin" {
+		t.Errorf("vernacular names: got %v", kw)
+	}
+	if len(record.Contributors) != 2 || record.Contributors[1].Role != "Identifier" {
+		t.Errorf("identifiedBy: got %v", record.Contributors)
+	}
+	if d := hub.GetDate(record, hubv1.DateType_DATE_TYPE_COLLECTED); d == nil || d.Year != 1951 || d.Month != 4 || d.Day != 3 {
+		t.Errorf("collected from year, month, day: got %v", d)
+	}
+	if record.Geographic == nil || record.Geographic.Country != "US" {
+		t.Errorf("countryCode: got %v", record.Geographic)
+	}
+	if id := hub.GetIdentifier(record, hubv1.IdentifierType_IDENTIFIER_TYPE_URL); id == nil {
+		t.Errorf("occurrenceID URL: got %v", record.Identifiers)
+	}
+	if len(record.Files) != 1 || record.Files[0].Name != "000077.jpg" {
+		t.Errorf("associatedMedia: got %v", record.Files)
+	}
+
+	observation := records[1]
+	if observation.ResourceType.Type != hubv1.ResourceTypeValue_RESOURCE_TYPE_DATASET {
+		t.Errorf("observation type: got %v", observation.ResourceType)
+	}
+	if _, ok := hub.GetExtra(observation, "coordinates"); ok {
+		t.Error("out-of-range latitude should be dropped")
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := map[string][]byte{
+		"taxon core": zipArchive(t, "", map[string]string{
+			"meta.xml": `<archive><core rowType="http://rs.tdwg.org/dwc/terms/Taxon"><files><location>taxon.txt</location></files></core></archive>`,
+		}),
+		"missing data file": zipArchive(t, "", map[string]string{
+			"meta.xml": `<archive><core rowType="http://rs.tdwg.org/dwc/terms/Occurrence"><files><location>missing.txt</location></files></core></archive>`,
+		}),
+		"unsupported encoding": zipArchive(t, "", map[string]string{
+			"meta.xml": `<archive><core encoding="UTF-16" rowType="http://rs.tdwg.org/dwc/terms/Occurrence"><files><location>occurrence.txt</location></files></core></archive>`,
+		}),
+	}
+	for name, input := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := (&Format{}).Parse(bytes.NewReader(input), nil); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestCanParse(t *testing.T) {
+	f := &Format{}
+	if !f.CanParse(zipArchive(t, "", nil)) {
+		t.Error("expected CanParse for a Darwin Core Archive")
+	}
+	data, err := os.ReadFile("testdata/occurrences.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !f.CanParse(data) {
+		t.Error("expected CanParse for a term file")
+	}
+	if f.CanParse([]byte("title,contributors\nA Thesis,Smith\n")) {
+		t.Error("unexpected CanParse for CSV metadata")
+	}
+}
@@ -0,0 +1,80 @@
+package darwincore
+
+import (
+	"encoding/xml"
+	"strconv"
+	"strings"
+)
+
+// Archive is the meta.xml descriptor of a Darwin Core Archive: the core
+// data file and the extension files whose rows refer to its rows.
+type Archive struct {
+	XMLName    xml.Name  `xml:"archive"`
+	Metadata   string    `xml:"metadata,attr"`
+	Core       *FileSet  `xml:"core"`
+	Extensions []FileSet `xml:"extension"`
+}
+
+// FileSet describes a data file: where it is, how its text is delimited,
+// and which term each column holds.
+type FileSet struct {
+	RowType            string   `xml:"rowType,attr"`
+	Encoding           string   `xml:"encoding,attr"`
+	FieldsTerminatedBy *string  `xml:"fieldsTerminatedBy,attr"`
+	LinesTerminatedBy  string   `xml:"linesTerminatedBy,attr"`
+	FieldsEnclosedBy   *string  `xml:"fieldsEnclosedBy,attr"`
+	IgnoreHeaderLines  int      `xml:"ignoreHeaderLines,attr"`
+	Locations          []string `xml:"files>location"`
+	ID                 *Index   `xml:"id"`
+	CoreID             *Index   `xml:"coreid"`
+	Fields             []Field  `xml:"field"`
+}
+
+// Index is the column of a core row's id, or of an extension row's
+// reference to it.
+type Index struct {
+	Index int `xml:"index,attr"`
+}
+
+// Field maps a column to a term. A field without an index gives every
+// row the same value, its default.
+type Field struct {
+	Index   string `xml:"index,attr"`
+	Term    string `xml:"term,attr"`
+	Default string `xml:"default,attr"`
+}
+
+// column returns the field's column, or false for a constant field.
+func (f Field) column() (int, bool) {
+	i, err := strconv.Atoi(strings.TrimSpace(f.Index))
+	return i, err == nil && i >= 0
+}
+
+// Row types read from an archive.
+const (
+	rowTypeOccurrence     = "http://rs.tdwg.org/dwc/terms/Occurrence"
+	rowTypeGBIFMultimedia = "http://rs.gbif.org/terms/1.0/Multimedia"
+	rowTypeACMultimedia   = "http://rs.tdwg.org/ac/terms/Multimedia"
+)
+
+// delimiter returns the field delimiter, "," when unset.
+func (fs *FileSet) delimiter() string {
+	if fs.FieldsTerminatedBy == nil {
+		return ","
+	}
+	return unescape(*fs.FieldsTerminatedBy)
+}
+
+// quote returns the field enclosure, '"' when unset, or empty for none.
+func (fs *FileSet) quote() string {
+	if fs.FieldsEnclosedBy == nil {
+		return `"`
+	}
+	return unescape(*fs.FieldsEnclosedBy)
+}
+
+// unescape reads the backslash escapes meta.xml attributes use for
+// delimiters.
+func unescape(s string) string {
+	return strings.NewReplacer(`\t`, "\t", `\n`, "\n", `\r`, "\r").Replace(s)
+}
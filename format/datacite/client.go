@@ -0,0 +1,206 @@
+package datacite
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+)
+
+// DataCite REST API endpoints. The test API registers DOIs under test
+// prefixes that never resolve.
+const (
+	APIURL     = "https://api.datacite.org"
+	TestAPIURL = "https://api.test.datacite.org"
+)
+
+// DOI states. A draft DOI can be changed or deleted and doesn't resolve;
+// a registered one resolves but isn't listed in DataCite's search; a
+// findable one is both.
+const (
+	StateDraft      = "draft"
+	StateRegistered = "registered"
+	StateFindable   = "findable"
+)
+
+const apiMediaType = "application/vnd.api+json"
+
+// stateEvents are the events that move a DOI into each state.
+var stateEvents = map[string]string{
+	StateDraft:      "",
+	StateRegistered: "register",
+	StateFindable:   "publish",
+}
+
+// Client registers DOIs with the DataCite REST API. A record that already
+// has a DOI is created or updated under it with PUT; any other record is
+// given a new DOI under Prefix, its suffix chosen by DataCite.
+type Client struct {
+	BaseURL    string
+	HTTPClient *http.Client
+	UserAgent  string
+
+	// Username and Password are the repository ID (e.g. LEHIGH.PRESERVE)
+	// and its password, sent with HTTP basic authentication.
+	Username string
+	Password string
+
+	// Prefix is the DOI prefix new DOIs are minted under (e.g. 10.5438).
+	Prefix string
+}
+
+// NewClient returns a client for the DataCite REST API at baseURL.
+func NewClient(baseURL string) *Client {
+	return &Client{
+		BaseURL:    strings.TrimSuffix(baseURL, "/"),
+		HTTPClient: &http.Client{Timeout: 60 * time.Second},
+		UserAgent:  "crosswalk-mint-doi (https://github.com/lehigh-university-libraries/crosswalk)",
+	}
+}
+
+// Registration describes a DOI DataCite created or updated.
+type Registration struct {
+	DOI   string `json:"doi"`
+	State string `json:"state"`
+	URL   string `json:"url,omitempty"`
+	// Created is true when the DOI is new, false when it was updated.
+	Created bool `json:"created"`
+}
+
+// Error is a failed API request, with the errors DataCite sent.
+type Error struct {
+	Status   int
+	Messages []string
+}
+
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("DataCite returned %d %s", e.Status, http.StatusText(e.Status))
+	if len(e.Messages) > 0 {
+		msg += ": " + strings.Join(e.Messages, "; ")
+	}
+	return msg
+}
+
+// Register creates or updates the record's DOI and moves it to state. A
+// DOI that leaves the draft state must have a landing page, the record's
+// URL.
+func (c *Client) Register(ctx context.Context, record *hubv1.Record, state string) (*Registration, error) {
+	event, ok := stateEvents[state]
+	if !ok {
+		return nil, fmt.Errorf("unknown DOI state %q (want %s, %s, or %s)", state, StateDraft, StateRegistered, StateFindable)
+	}
+	spoke, err := hubToSpoke(record, format.NewSerializeOptions())
+	if err != nil {
+		return nil, err
+	}
+	attrs := spokeToJSON(spoke, landingPage(record))
+	attrs.Event = event
+	if state != StateDraft && attrs.URL == "" {
+		return nil, fmt.Errorf("a %s DOI needs a landing page; the record has no URL", state)
+	}
+
+	method, target := http.MethodPost, c.BaseURL+"/dois"
+	if attrs.DOI != "" {
+		method, target = http.MethodPut, c.BaseURL+"/dois/"+attrs.DOI
+	} else if c.Prefix != "" {
+		attrs.Prefix = c.Prefix
+	} else {
+		return nil, errors.New("the record has no DOI and no prefix is configured to mint one under")
+	}
+
+	doc, err := json.Marshal(map[string]any{"data": JSONDOI{ID: attrs.DOI, Type: "dois", Attributes: attrs}})
+	if err != nil {
+		return nil, err
+	}
+	status, body, err := c.do(ctx, method, target, doc)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK && status != http.StatusCreated {
+		return nil, responseError(status, body)
+	}
+
+	var written struct {
+		Data struct {
+			ID         string `json:"id"`
+			Attributes struct {
+				DOI   string `json:"doi"`
+				State string `json:"state"`
+				URL   string `json:"url"`
+			} `json:"attributes"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &written); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+	reg := &Registration{
+		DOI:     written.Data.Attributes.DOI,
+		State:   written.Data.Attributes.State,
+		URL:     written.Data.Attributes.URL,
+		Created: status == http.StatusCreated,
+	}
+	if reg.DOI == "" {
+		reg.DOI = written.Data.ID
+	}
+	if reg.DOI == "" {
+		return nil, errors.New("DataCite's response names no DOI")
+	}
+	return reg, nil
+}
+
+// do sends one request to the API.
+func (c *Client) do(ctx context.Context, method, target string, body []byte) (int, []byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, target, bytes.NewReader(body))
+	if err != nil {
+		return 0, nil, err
+	}
+	req.Header.Set("Accept", apiMediaType)
+	req.Header.Set("Content-Type", apiMediaType)
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+	if c.Username != "" {
+		req.SetBasicAuth(c.Username, c.Password)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, fmt.Errorf("reading response: %w", err)
+	}
+	return resp.StatusCode, data, nil
+}
+
+// responseError reads the JSON:API errors DataCite sends with a failed
+// request.
+func responseError(status int, body []byte) error {
+	var doc struct {
+		Errors []struct {
+			Source string `json:"source"`
+			Title  string `json:"title"`
+		} `json:"errors"`
+	}
+	e := &Error{Status: status}
+	if err := json.Unmarshal(body, &doc); err == nil {
+		for _, de := range doc.Errors {
+			msg := de.Title
+			if de.Source != "" {
+				msg = de.Source + ": " + msg
+			}
+			e.Messages = append(e.Messages, msg)
+		}
+	}
+	return e
+}
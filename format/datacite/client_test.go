@@ -0,0 +1,147 @@
+package datacite
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+// fabrica fakes the /dois endpoint, keeping the last request it received.
+type fabrica struct {
+	method, path string
+	attrs        JSONAttributes
+}
+
+func (f *fabrica) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, pass, _ := r.BasicAuth()
+	if user != "LEHIGH.TEST" || pass != "secret" {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"errors":[{"status":"401","title":"Bad credentials."}]}`)
+		return
+	}
+	body, _ := io.ReadAll(r.Body)
+	var doc struct {
+		Data JSONDOI `json:"data"`
+	}
+	json.Unmarshal(body, &doc)
+	f.method, f.path, f.attrs = r.Method, r.URL.Path, doc.Data.Attributes
+
+	a := doc.Data.Attributes
+	if len(a.Titles) == 0 {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		fmt.Fprint(w, `{"errors":[{"source":"titles","title":"Title is required"}]}`)
+		return
+	}
+	doi, state := a.DOI, StateDraft
+	switch a.Event {
+	case "publish":
+		state = StateFindable
+	case "register":
+		state = StateRegistered
+	}
+	if r.Method == http.MethodPost {
+		doi = a.Prefix + "/abcd-1234"
+		w.WriteHeader(http.StatusCreated)
+	}
+	fmt.Fprintf(w, `{"data":{"id":%q,"type":"dois","attributes":{"doi":%q,"state":%q,"url":%q}}}`, doi, doi, state, a.URL)
+}
+
+func fabricaClient(t *testing.T) (*Client, *fabrica) {
+	t.Helper()
+	api := &fabrica{}
+	srv := httptest.NewServer(api)
+	t.Cleanup(srv.Close)
+	c := NewClient(srv.URL + "/")
+	c.Username, c.Password, c.Prefix = "LEHIGH.TEST", "secret", "10.80000"
+	return c, api
+}
+
+func mintRecord() *hubv1.Record {
+	return &hubv1.Record{
+		Title:     "Steel Town Oral Histories",
+		Publisher: "Lehigh University Libraries",
+		Dates: []*hubv1.DateValue{
+			{Type: hubv1.DateType_DATE_TYPE_ISSUED, Year: 2023, Precision: hubv1.DatePrecision_DATE_PRECISION_YEAR},
+		},
+		Identifiers: []*hubv1.Identifier{
+			hub.NewIdentifier("https://preserve.example.edu/node/12", hubv1.IdentifierType_IDENTIFIER_TYPE_URL),
+		},
+	}
+}
+
+func TestRegisterMintsUnderPrefix(t *testing.T) {
+	c, api := fabricaClient(t)
+	reg, err := c.Register(context.Background(), mintRecord(), StateFindable)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if api.method != http.MethodPost || api.path != "/dois" {
+		t.Errorf("request = %s %s, want POST /dois", api.method, api.path)
+	}
+	if api.attrs.Prefix != "10.80000" || api.attrs.Event != "publish" || api.attrs.URL != "https://preserve.example.edu/node/12" {
+		t.Errorf("attributes = %+v", api.attrs)
+	}
+	if reg.DOI != "10.80000/abcd-1234" || reg.State != StateFindable || !reg.Created {
+		t.Errorf("registration = %+v", reg)
+	}
+}
+
+func TestRegisterUpdatesExistingDOI(t *testing.T) {
+	c, api := fabricaClient(t)
+	r := mintRecord()
+	r.Identifiers = append(r.Identifiers, hub.NewIdentifier("10.80000/lehigh-12", hubv1.IdentifierType_IDENTIFIER_TYPE_DOI))
+	reg, err := c.Register(context.Background(), r, StateDraft)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if api.method != http.MethodPut || api.path != "/dois/10.80000/lehigh-12" {
+		t.Errorf("request = %s %s, want PUT of the record's DOI", api.method, api.path)
+	}
+	if api.attrs.Event != "" || api.attrs.Prefix != "" {
+		t.Errorf("a draft update sent event %q, prefix %q", api.attrs.Event, api.attrs.Prefix)
+	}
+	if reg.DOI != "10.80000/lehigh-12" || reg.State != StateDraft || reg.Created {
+		t.Errorf("registration = %+v", reg)
+	}
+}
+
+func TestRegisterErrors(t *testing.T) {
+	c, _ := fabricaClient(t)
+	ctx := context.Background()
+
+	r := mintRecord()
+	r.Identifiers = nil
+	if _, err := c.Register(ctx, r, StateFindable); err == nil || !strings.Contains(err.Error(), "landing page") {
+		t.Errorf("findable without a URL: err = %v", err)
+	}
+	if _, err := c.Register(ctx, mintRecord(), "public"); err == nil || !strings.Contains(err.Error(), "unknown DOI state") {
+		t.Errorf("bad state: err = %v", err)
+	}
+
+	r = mintRecord()
+	r.Title = ""
+	_, err := c.Register(ctx, r, StateDraft)
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.Status != http.StatusUnprocessableEntity || apiErr.Messages[0] != "titles: Title is required" {
+		t.Errorf("rejected metadata: err = %v", err)
+	}
+
+	c.Password = "wrong"
+	if _, err := c.Register(ctx, mintRecord(), StateDraft); !errors.As(err, &apiErr) || apiErr.Status != http.StatusUnauthorized {
+		t.Errorf("bad credentials: err = %v", err)
+	}
+
+	c.Password, c.Prefix = "secret", ""
+	if _, err := c.Register(ctx, mintRecord(), StateDraft); err == nil || !strings.Contains(err.Error(), "no prefix") {
+		t.Errorf("no DOI or prefix: err = %v", err)
+	}
+}
@@ -1,4 +1,5 @@
-// Package datacite provides a format plugin for DataCite metadata.
+// Package datacite provides a format plugin for DataCite metadata, as
+// kernel-4 XML or as the JSON the DataCite REST API takes and returns.
 package datacite
 
 import (
@@ -10,14 +11,23 @@ import (
 // Version documents the DataCite specification this implementation targets.
 const Version = "4.6"
 
+// Output variants. VariantXML, the default, writes kernel-4 XML;
+// VariantJSON writes a REST API document whose data holds a "dois"
+// resource, ready to POST or PUT to /dois.
+const (
+	VariantXML  = "xml"
+	VariantJSON = "json"
+)
+
 // Format implements the DataCite format.
 type Format struct{}
 
 // Ensure Format implements the interfaces
 var (
-	_ format.Format     = (*Format)(nil)
-	_ format.Parser     = (*Format)(nil)
-	_ format.Serializer = (*Format)(nil)
+	_ format.Format         = (*Format)(nil)
+	_ format.Parser         = (*Format)(nil)
+	_ format.Serializer     = (*Format)(nil)
+	_ format.FieldDescriber = (*Format)(nil)
 )
 
 // Name returns the format identifier.
@@ -35,13 +45,42 @@ func (f *Format) Extensions() []string {
 	return []string{"xml"}
 }
 
-// CanParse returns true if the input looks like DataCite XML.
+// writtenFields are the hub record fields Serialize writes.
+var writtenFields = []string{
+	"title", "titles", "alt_title", "abstract", "abstracts",
+	"contributors", "dates", "resource_type",
+	"subjects", "language", "publisher", "rights", "identifiers", "funders",
+	"copyright_statement", "distributions",
+	"relations.part_of", "relations.has_part", "relations.references",
+	"relations.cites", "relations.is_cited_by", "relations.version_of",
+	"relations.has_version", "relations.supplements",
+	"relations.is_supplement_to", "relations.supplemented_by",
+	"relations.same_as", "relations.identical_to", "relations.derived_from",
+	"relations.source_of", "relations.requires", "relations.required_by",
+	"relations.replaces", "relations.is_replaced_by", "relations.documents",
+	"relations.is_documented_by", "relations.describes",
+	"relations.is_described_by", "relations.reviews",
+}
+
+// WrittenFields returns the hub record fields Serialize writes.
+func (f *Format) WrittenFields(opts *format.SerializeOptions) []string {
+	return writtenFields
+}
+
+// CanParse returns true if the input looks like DataCite XML or REST API
+// JSON.
 func (f *Format) CanParse(peek []byte) bool {
 	peek = bytes.TrimSpace(peek)
 	if len(peek) == 0 {
 		return false
 	}
 
+	if peek[0] == '{' || peek[0] == '[' {
+		return bytes.Contains(peek, []byte(`"type":"dois"`)) ||
+			bytes.Contains(peek, []byte(`"type": "dois"`)) ||
+			(bytes.Contains(peek, []byte(`"resourceTypeGeneral"`)) && bytes.Contains(peek, []byte(`"publicationYear"`)))
+	}
+
 	if peek[0] != '<' {
 		return false
 	}
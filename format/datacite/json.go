@@ -0,0 +1,595 @@
+package datacite
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	dcv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/spoke/datacite/v4_6"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+// schemaVersion is the kernel the REST API payloads declare.
+const schemaVersion = "http://datacite.org/schema/kernel-4"
+
+// JSON types for the DataCite REST API, where a DOI is a JSON:API resource
+// of type "dois" whose attributes hold the metadata with camelCase keys.
+
+// JSONDocument is a REST API request or response body: one DOI, or a
+// page of them.
+type JSONDocument struct {
+	Data json.RawMessage `json:"data"`
+}
+
+// JSONDOI is one DOI resource.
+type JSONDOI struct {
+	ID         string         `json:"id,omitempty"`
+	Type       string         `json:"type"`
+	Attributes JSONAttributes `json:"attributes"`
+}
+
+// JSONAttributes is a DOI's metadata.
+type JSONAttributes struct {
+	DOI                  string                    `json:"doi,omitempty"`
+	Prefix               string                    `json:"prefix,omitempty"`
+	Event                string                    `json:"event,omitempty"`
+	Creators             []JSONCreator             `json:"creators,omitempty"`
+	Titles               []JSONTitle               `json:"titles,omitempty"`
+	Publisher            JSONPublisher             `json:"publisher,omitempty"`
+	PublicationYear      JSONYear                  `json:"publicationYear,omitempty"`
+	Types                *JSONTypes                `json:"types,omitempty"`
+	Subjects             []JSONSubject             `json:"subjects,omitempty"`
+	Contributors         []JSONCreator             `json:"contributors,omitempty"`
+	Dates                []JSONDate                `json:"dates,omitempty"`
+	Language             string                    `json:"language,omitempty"`
+	Identifiers          []JSONIdentifier          `json:"identifiers,omitempty"`
+	AlternateIdentifiers []JSONAlternateIdentifier `json:"alternateIdentifiers,omitempty"`
+	RelatedIdentifiers   []JSONRelatedIdentifier   `json:"relatedIdentifiers,omitempty"`
+	Sizes                []string                  `json:"sizes,omitempty"`
+	Formats              []string                  `json:"formats,omitempty"`
+	Version              string                    `json:"version,omitempty"`
+	RightsList           []JSONRights              `json:"rightsList,omitempty"`
+	Descriptions         []JSONDescription         `json:"descriptions,omitempty"`
+	FundingReferences    []JSONFundingReference    `json:"fundingReferences,omitempty"`
+	GeoLocations         []JSONGeoLocation         `json:"geoLocations,omitempty"`
+	RelatedItems         []JSONRelatedItem         `json:"relatedItems,omitempty"`
+	URL                  string                    `json:"url,omitempty"`
+	SchemaVersion        string                    `json:"schemaVersion,omitempty"`
+}
+
+// JSONCreator is a creator, or a contributor when ContributorType is set.
+type JSONCreator struct {
+	Name            string               `json:"name,omitempty"`
+	NameType        string               `json:"nameType,omitempty"`
+	GivenName       string               `json:"givenName,omitempty"`
+	FamilyName      string               `json:"familyName,omitempty"`
+	ContributorType string               `json:"contributorType,omitempty"`
+	NameIdentifiers []JSONNameIdentifier `json:"nameIdentifiers,omitempty"`
+	Affiliation     []JSONAffiliation    `json:"affiliation,omitempty"`
+}
+
+type JSONNameIdentifier struct {
+	NameIdentifier       string `json:"nameIdentifier"`
+	NameIdentifierScheme string `json:"nameIdentifierScheme,omitempty"`
+	SchemeURI            string `json:"schemeUri,omitempty"`
+}
+
+// JSONAffiliation is an affiliation. The API gives a bare name unless the
+// request asks for affiliation=true; both are read.
+type JSONAffiliation struct {
+	Name                        string `json:"name"`
+	AffiliationIdentifier       string `json:"affiliationIdentifier,omitempty"`
+	AffiliationIdentifierScheme string `json:"affiliationIdentifierScheme,omitempty"`
+}
+
+func (a *JSONAffiliation) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		return json.Unmarshal(data, &a.Name)
+	}
+	type plain JSONAffiliation
+	return json.Unmarshal(data, (*plain)(a))
+}
+
+// JSONPublisher is the publisher's name. The API gives an object when the
+// request asks for publisher=true; both are read, and a name is written.
+type JSONPublisher string
+
+func (p *JSONPublisher) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '{' {
+		var obj struct {
+			Name string `json:"name"`
+		}
+		if err := json.Unmarshal(data, &obj); err != nil {
+			return err
+		}
+		*p = JSONPublisher(obj.Name)
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	*p = JSONPublisher(s)
+	return nil
+}
+
+// JSONYear is the publication year, which the API writes as a number or a
+// string.
+type JSONYear int32
+
+func (y *JSONYear) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "" || s == "null" {
+		*y = 0
+		return nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return fmt.Errorf("publicationYear %s: %w", data, err)
+	}
+	*y = JSONYear(n)
+	return nil
+}
+
+type JSONTitle struct {
+	Title     string `json:"title"`
+	TitleType string `json:"titleType,omitempty"`
+	Lang      string `json:"lang,omitempty"`
+}
+
+type JSONTypes struct {
+	ResourceTypeGeneral string `json:"resourceTypeGeneral,omitempty"`
+	ResourceType        string `json:"resourceType,omitempty"`
+}
+
+type JSONSubject struct {
+	Subject       string `json:"subject"`
+	SubjectScheme string `json:"subjectScheme,omitempty"`
+	SchemeURI     string `json:"schemeUri,omitempty"`
+	ValueURI      string `json:"valueUri,omitempty"`
+}
+
+type JSONDate struct {
+	Date     string `json:"date"`
+	DateType string `json:"dateType,omitempty"`
+}
+
+type JSONIdentifier struct {
+	Identifier     string `json:"identifier"`
+	IdentifierType string `json:"identifierType,omitempty"`
+}
+
+type JSONAlternateIdentifier struct {
+	AlternateIdentifier     string `json:"alternateIdentifier"`
+	AlternateIdentifierType string `json:"alternateIdentifierType,omitempty"`
+}
+
+type JSONRelatedIdentifier struct {
+	RelatedIdentifier     string `json:"relatedIdentifier"`
+	RelatedIdentifierType string `json:"relatedIdentifierType,omitempty"`
+	RelationType          string `json:"relationType"`
+}
+
+type JSONRights struct {
+	Rights    string `json:"rights,omitempty"`
+	RightsURI string `json:"rightsUri,omitempty"`
+}
+
+type JSONDescription struct {
+	Description     string `json:"description"`
+	DescriptionType string `json:"descriptionType,omitempty"`
+	Lang            string `json:"lang,omitempty"`
+}
+
+type JSONFundingReference struct {
+	FunderName           string `json:"funderName"`
+	FunderIdentifier     string `json:"funderIdentifier,omitempty"`
+	FunderIdentifierType string `json:"funderIdentifierType,omitempty"`
+	AwardNumber          string `json:"awardNumber,omitempty"`
+	AwardTitle           string `json:"awardTitle,omitempty"`
+}
+
+type JSONGeoLocation struct {
+	GeoLocationPlace string                `json:"geoLocationPlace,omitempty"`
+	GeoLocationPoint *JSONGeoLocationPoint `json:"geoLocationPoint,omitempty"`
+}
+
+type JSONGeoLocationPoint struct {
+	PointLongitude JSONCoordinate `json:"pointLongitude"`
+	PointLatitude  JSONCoordinate `json:"pointLatitude"`
+}
+
+// JSONCoordinate is a latitude or longitude, which the API writes as a
+// number or a string.
+type JSONCoordinate float64
+
+func (c *JSONCoordinate) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "" || s == "null" {
+		*c = 0
+		return nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return fmt.Errorf("coordinate %s: %w", data, err)
+	}
+	*c = JSONCoordinate(f)
+	return nil
+}
+
+type JSONRelatedItem struct {
+	RelationType          string                     `json:"relationType"`
+	RelatedItemType       string                     `json:"relatedItemType"`
+	RelatedItemIdentifier *JSONRelatedItemIdentifier `json:"relatedItemIdentifier,omitempty"`
+	Titles                []JSONTitle                `json:"titles,omitempty"`
+	Volume                string                     `json:"volume,omitempty"`
+	Issue                 string                     `json:"issue,omitempty"`
+	FirstPage             string                     `json:"firstPage,omitempty"`
+	LastPage              string                     `json:"lastPage,omitempty"`
+}
+
+type JSONRelatedItemIdentifier struct {
+	RelatedItemIdentifier     string `json:"relatedItemIdentifier"`
+	RelatedItemIdentifierType string `json:"relatedItemIdentifierType,omitempty"`
+}
+
+// isJSON reports whether the input is JSON rather than XML.
+func isJSON(data []byte) bool {
+	data = bytes.TrimSpace(data)
+	return len(data) > 0 && (data[0] == '{' || data[0] == '[')
+}
+
+// parseJSON reads REST API JSON: a {"data": ...} document holding one DOI
+// or a list of them, a bare DOI resource, or bare attributes.
+func parseJSON(data []byte) ([]*hubv1.Record, error) {
+	dois, err := extractDOIs(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(dois) == 0 {
+		return nil, fmt.Errorf("no DataCite DOIs found in input")
+	}
+
+	records := make([]*hubv1.Record, 0, len(dois))
+	for i, doi := range dois {
+		attrs := &doi.Attributes
+		if attrs.DOI == "" && strings.HasPrefix(doi.ID, "10.") {
+			attrs.DOI = doi.ID
+		}
+		record, err := xmlResourceToHub(jsonToResource(attrs))
+		if err != nil {
+			return nil, fmt.Errorf("converting record %d: %w", i, err)
+		}
+		if attrs.URL != "" && !hasIdentifier(record, attrs.URL) {
+			record.Identifiers = append(record.Identifiers, hub.NewIdentifier(attrs.URL, hubv1.IdentifierType_IDENTIFIER_TYPE_URL))
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// extractDOIs finds the DOI resources in a REST API payload.
+func extractDOIs(data []byte) ([]JSONDOI, error) {
+	data = bytes.TrimSpace(data)
+	if data[0] == '[' {
+		var list []JSONDOI
+		if err := json.Unmarshal(data, &list); err != nil {
+			return nil, fmt.Errorf("parsing JSON: %w", err)
+		}
+		return list, nil
+	}
+
+	var probe struct {
+		Data       json.RawMessage `json:"data"`
+		Attributes json.RawMessage `json:"attributes"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, fmt.Errorf("parsing JSON: %w", err)
+	}
+	switch {
+	case len(probe.Data) > 0 && bytes.TrimSpace(probe.Data)[0] == '[':
+		var list []JSONDOI
+		if err := json.Unmarshal(probe.Data, &list); err != nil {
+			return nil, fmt.Errorf("parsing DOI list: %w", err)
+		}
+		return list, nil
+	case len(probe.Data) > 0 && string(probe.Data) != "null":
+		var doi JSONDOI
+		if err := json.Unmarshal(probe.Data, &doi); err != nil {
+			return nil, fmt.Errorf("parsing DOI: %w", err)
+		}
+		return []JSONDOI{doi}, nil
+	case len(probe.Attributes) > 0:
+		var doi JSONDOI
+		if err := json.Unmarshal(data, &doi); err != nil {
+			return nil, fmt.Errorf("parsing DOI: %w", err)
+		}
+		return []JSONDOI{doi}, nil
+	}
+
+	var attrs JSONAttributes
+	if err := json.Unmarshal(data, &attrs); err != nil {
+		return nil, fmt.Errorf("parsing DOI attributes: %w", err)
+	}
+	if attrs.DOI == "" && len(attrs.Titles) == 0 {
+		return nil, nil
+	}
+	return []JSONDOI{{Type: "dois", Attributes: attrs}}, nil
+}
+
+// jsonToResource restates REST API attributes as the parsed XML resource,
+// so both syntaxes map to the hub the same way.
+func jsonToResource(a *JSONAttributes) *XMLParseResource {
+	res := &XMLParseResource{
+		Publisher:       string(a.Publisher),
+		PublicationYear: int32(a.PublicationYear),
+		Language:        a.Language,
+		Version:         a.Version,
+	}
+	if a.DOI != "" {
+		res.Identifier = &XMLIdentifier{IdentifierType: "DOI", Value: a.DOI}
+	}
+	for _, c := range a.Creators {
+		res.Creators = append(res.Creators, XMLParseCreator{
+			CreatorName:     XMLCreatorName{NameType: nameType(c.NameType), Value: c.Name},
+			GivenName:       c.GivenName,
+			FamilyName:      c.FamilyName,
+			NameIdentifiers: jsonNameIdentifiers(c.NameIdentifiers),
+			Affiliations:    jsonAffiliations(c.Affiliation),
+		})
+	}
+	for _, c := range a.Contributors {
+		res.Contributors = append(res.Contributors, XMLParseContributor{
+			ContributorType: c.ContributorType,
+			ContributorName: XMLCreatorName{NameType: nameType(c.NameType), Value: c.Name},
+			GivenName:       c.GivenName,
+			FamilyName:      c.FamilyName,
+			NameIdentifiers: jsonNameIdentifiers(c.NameIdentifiers),
+			Affiliations:    jsonAffiliations(c.Affiliation),
+		})
+	}
+	for _, t := range a.Titles {
+		res.Titles = append(res.Titles, XMLTitle{TitleType: t.TitleType, Lang: t.Lang, Value: t.Title})
+	}
+	if a.Types != nil && (a.Types.ResourceTypeGeneral != "" || a.Types.ResourceType != "") {
+		res.ResourceType = &XMLResourceType{
+			ResourceTypeGeneral: a.Types.ResourceTypeGeneral,
+			Value:               a.Types.ResourceType,
+		}
+	}
+	for _, s := range a.Subjects {
+		res.Subjects = append(res.Subjects, XMLParseSubject{
+			Value:         s.Subject,
+			SubjectScheme: s.SubjectScheme,
+			SchemeURI:     s.SchemeURI,
+			ValueURI:      s.ValueURI,
+		})
+	}
+	for _, d := range a.Dates {
+		res.Dates = append(res.Dates, XMLParseDate{Value: d.Date, DateType: d.DateType})
+	}
+	for _, id := range a.Identifiers {
+		if strings.EqualFold(id.IdentifierType, "DOI") && strings.EqualFold(id.Identifier, a.DOI) {
+			continue
+		}
+		res.AlternateIdentifiers = append(res.AlternateIdentifiers, XMLAlternateIdentifier{
+			AlternateIdentifierType: id.IdentifierType,
+			Value:                   id.Identifier,
+		})
+	}
+	for _, id := range a.AlternateIdentifiers {
+		res.AlternateIdentifiers = append(res.AlternateIdentifiers, XMLAlternateIdentifier{
+			AlternateIdentifierType: id.AlternateIdentifierType,
+			Value:                   id.AlternateIdentifier,
+		})
+	}
+	for _, r := range a.RelatedIdentifiers {
+		res.RelatedIdentifiers = append(res.RelatedIdentifiers, XMLRelatedIdentifier{
+			RelatedIdentifierType: r.RelatedIdentifierType,
+			RelationType:          r.RelationType,
+			Value:                 r.RelatedIdentifier,
+		})
+	}
+	for _, r := range a.RightsList {
+		res.RightsList = append(res.RightsList, XMLParseRights{Value: r.Rights, RightsURI: r.RightsURI})
+	}
+	for _, d := range a.Descriptions {
+		res.Descriptions = append(res.Descriptions, XMLDescription{
+			DescriptionType: d.DescriptionType,
+			Lang:            d.Lang,
+			Value:           d.Description,
+		})
+	}
+	for _, f := range a.FundingReferences {
+		res.FundingReferences = append(res.FundingReferences, XMLParseFundingRef{
+			FunderName:           f.FunderName,
+			FunderIdentifier:     f.FunderIdentifier,
+			FunderIdentifierType: f.FunderIdentifierType,
+			AwardNumber:          f.AwardNumber,
+			AwardTitle:           f.AwardTitle,
+		})
+	}
+	return res
+}
+
+// nameType spells the JSON nameType values as the XML does; the API
+// writes "Personal" and "Organizational" in both, but older records omit
+// the suffix.
+func nameType(s string) string {
+	switch strings.ToLower(s) {
+	case "organization", "organizational":
+		return "Organizational"
+	case "person", "personal":
+		return "Personal"
+	}
+	return s
+}
+
+func jsonNameIdentifiers(ids []JSONNameIdentifier) []XMLNameIdentifier {
+	var out []XMLNameIdentifier
+	for _, ni := range ids {
+		out = append(out, XMLNameIdentifier{
+			NameIdentifierScheme: ni.NameIdentifierScheme,
+			SchemeURI:            ni.SchemeURI,
+			Value:                ni.NameIdentifier,
+		})
+	}
+	return out
+}
+
+func jsonAffiliations(affs []JSONAffiliation) []XMLAffiliation {
+	var out []XMLAffiliation
+	for _, a := range affs {
+		out = append(out, XMLAffiliation{Value: a.Name})
+	}
+	return out
+}
+
+// hasIdentifier reports whether the record already has an identifier with
+// this value.
+func hasIdentifier(record *hubv1.Record, value string) bool {
+	for _, id := range record.Identifiers {
+		if id.Value == value {
+			return true
+		}
+	}
+	return false
+}
+
+// writeJSON writes the spoke resources as a REST API document: one DOI as
+// the data object, more as a data array.
+func writeJSON(w io.Writer, resources []*dcv1.Resource, urls []string, pretty bool) error {
+	dois := make([]JSONDOI, len(resources))
+	for i, res := range resources {
+		dois[i] = JSONDOI{Type: "dois", Attributes: spokeToJSON(res, urls[i])}
+	}
+
+	var doc any = map[string]any{"data": dois}
+	if len(dois) == 1 {
+		doc = map[string]any{"data": dois[0]}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	if pretty {
+		enc.SetIndent("", "  ")
+	}
+	return enc.Encode(doc)
+}
+
+// spokeToJSON converts a spoke resource to REST API attributes. url is the
+// landing page the DOI resolves to.
+func spokeToJSON(spoke *dcv1.Resource, url string) JSONAttributes {
+	a := JSONAttributes{
+		Publisher:       JSONPublisher(spoke.Publisher),
+		PublicationYear: JSONYear(spoke.PublicationYear),
+		Language:        spoke.Language,
+		Version:         spoke.Version,
+		Sizes:           spoke.Sizes,
+		Formats:         spoke.Formats,
+		URL:             url,
+		SchemaVersion:   schemaVersion,
+	}
+	if spoke.Identifier != nil {
+		a.DOI = spoke.Identifier.Value
+	}
+	for _, c := range spoke.Creators {
+		creator := JSONCreator{
+			Name:       c.Name,
+			NameType:   c.NameType,
+			GivenName:  c.GivenName,
+			FamilyName: c.FamilyName,
+		}
+		for _, ni := range c.NameIdentifiers {
+			creator.NameIdentifiers = append(creator.NameIdentifiers, JSONNameIdentifier{
+				NameIdentifier:       ni.Value,
+				NameIdentifierScheme: ni.NameIdentifierScheme,
+				SchemeURI:            ni.SchemeUri,
+			})
+		}
+		for _, aff := range c.Affiliations {
+			creator.Affiliation = append(creator.Affiliation, JSONAffiliation{Name: aff.Name})
+		}
+		a.Creators = append(a.Creators, creator)
+	}
+	for _, t := range spoke.Titles {
+		a.Titles = append(a.Titles, JSONTitle{Title: t.Value, TitleType: titleTypeToString(t.TitleType), Lang: t.Lang})
+	}
+	if spoke.ResourceType != nil {
+		a.Types = &JSONTypes{
+			ResourceTypeGeneral: resourceTypeGeneralToString(spoke.ResourceType.ResourceTypeGeneral),
+			ResourceType:        spoke.ResourceType.Value,
+		}
+	}
+	for _, s := range spoke.Subjects {
+		a.Subjects = append(a.Subjects, JSONSubject{Subject: s.Value})
+	}
+	for _, d := range spoke.Dates {
+		a.Dates = append(a.Dates, JSONDate{Date: d.Value, DateType: dateTypeToString(d.DateType)})
+	}
+	for _, d := range spoke.Descriptions {
+		a.Descriptions = append(a.Descriptions, JSONDescription{
+			Description:     d.Value,
+			DescriptionType: descriptionTypeToString(d.DescriptionType),
+			Lang:            d.Lang,
+		})
+	}
+	for _, r := range spoke.RightsList {
+		a.RightsList = append(a.RightsList, JSONRights{Rights: r.Value, RightsURI: r.RightsUri})
+	}
+	for _, f := range spoke.FundingReferences {
+		a.FundingReferences = append(a.FundingReferences, JSONFundingReference{
+			FunderName:           f.FunderName,
+			FunderIdentifier:     f.FunderIdentifier,
+			FunderIdentifierType: f.FunderIdentifierType,
+			AwardNumber:          f.AwardNumber,
+		})
+	}
+	for _, id := range spoke.AlternateIdentifiers {
+		a.Identifiers = append(a.Identifiers, JSONIdentifier{
+			Identifier:     id.Value,
+			IdentifierType: id.AlternateIdentifierType,
+		})
+	}
+	for _, r := range spoke.RelatedIdentifiers {
+		a.RelatedIdentifiers = append(a.RelatedIdentifiers, JSONRelatedIdentifier{
+			RelatedIdentifier:     r.Value,
+			RelatedIdentifierType: relatedIdentifierTypeToString(r.RelatedIdentifierType),
+			RelationType:          relationTypeToString(r.RelationType),
+		})
+	}
+	for _, g := range spoke.GeoLocations {
+		loc := JSONGeoLocation{GeoLocationPlace: g.GeoLocationPlace}
+		if p := g.GeoLocationPoint; p != nil {
+			loc.GeoLocationPoint = &JSONGeoLocationPoint{
+				PointLongitude: JSONCoordinate(p.PointLongitude),
+				PointLatitude:  JSONCoordinate(p.PointLatitude),
+			}
+		}
+		a.GeoLocations = append(a.GeoLocations, loc)
+	}
+	for _, ri := range spoke.RelatedItems {
+		item := JSONRelatedItem{
+			RelationType:    relationTypeToString(ri.RelationType),
+			RelatedItemType: resourceTypeGeneralToString(ri.RelatedItemType),
+			Volume:          ri.Volume,
+			Issue:           ri.Issue,
+			FirstPage:       ri.FirstPage,
+			LastPage:        ri.LastPage,
+		}
+		if id := ri.RelatedItemIdentifier; id != nil {
+			item.RelatedItemIdentifier = &JSONRelatedItemIdentifier{
+				RelatedItemIdentifier:     id.Value,
+				RelatedItemIdentifierType: id.RelatedItemIdentifierType,
+			}
+		}
+		for _, t := range ri.Titles {
+			item.Titles = append(item.Titles, JSONTitle{Title: t.Value})
+		}
+		a.RelatedItems = append(a.RelatedItems, item)
+	}
+	return a
+}
@@ -0,0 +1,197 @@
+package datacite
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+)
+
+const restDOI = `{
+  "data": {
+    "id": "10.5438/0012",
+    "type": "dois",
+    "attributes": {
+      "doi": "10.5438/0012",
+      "creators": [{
+        "name": "Doe, Jane",
+        "nameType": "Personal",
+        "givenName": "Jane",
+        "familyName": "Doe",
+        "nameIdentifiers": [{"nameIdentifier": "https://orcid.org/0000-0002-1825-0097", "nameIdentifierScheme": "ORCID", "schemeUri": "https://orcid.org"}],
+        "affiliation": ["Lehigh University"]
+      }],
+      "titles": [{"title": "Steel Town Oral Histories", "lang": "en"}],
+      "publisher": {"name": "Lehigh University Libraries"},
+      "publicationYear": "2023",
+      "types": {"resourceTypeGeneral": "Sound", "resourceType": "Oral history"},
+      "subjects": [{"subject": "Steel industry", "subjectScheme": "LCSH"}],
+      "contributors": [{"name": "Smith, John", "nameType": "Personal", "contributorType": "Editor"}],
+      "dates": [{"date": "2023-04-01", "dateType": "Issued"}],
+      "language": "en",
+      "relatedIdentifiers": [{"relatedIdentifier": "10.5438/0001", "relatedIdentifierType": "DOI", "relationType": "IsPartOf"}],
+      "rightsList": [{"rights": "Creative Commons Attribution 4.0", "rightsUri": "https://creativecommons.org/licenses/by/4.0/"}],
+      "descriptions": [{"description": "Interviews with former steelworkers.", "descriptionType": "Abstract"}],
+      "url": "https://preserve.example.edu/node/12",
+      "schemaVersion": "http://datacite.org/schema/kernel-4"
+    }
+  }
+}`
+
+func TestParseJSON(t *testing.T) {
+	records, err := (&Format{}).Parse(strings.NewReader(restDOI), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("got %d records, want 1", len(records))
+	}
+	r := records[0]
+
+	if r.Title != "Steel Town Oral Histories" {
+		t.Errorf("title = %q", r.Title)
+	}
+	if r.Publisher != "Lehigh University Libraries" {
+		t.Errorf("publisher = %q, want the name from the publisher object", r.Publisher)
+	}
+	if r.Abstract != "Interviews with former steelworkers." {
+		t.Errorf("abstract = %q", r.Abstract)
+	}
+	if r.ResourceType == nil || r.ResourceType.Type != hubv1.ResourceTypeValue_RESOURCE_TYPE_AUDIO {
+		t.Errorf("resource type = %v", r.ResourceType)
+	}
+	if len(r.Contributors) != 2 {
+		t.Fatalf("got %d contributors, want creator and editor", len(r.Contributors))
+	}
+	if c := r.Contributors[0]; c.Name != "Doe, Jane" || len(c.Affiliations) != 1 || c.Affiliations[0].Name != "Lehigh University" {
+		t.Errorf("creator = %+v", c)
+	}
+
+	ids := make(map[hubv1.IdentifierType]string)
+	for _, id := range r.Identifiers {
+		ids[id.Type] = id.Value
+	}
+	if ids[hubv1.IdentifierType_IDENTIFIER_TYPE_DOI] != "10.5438/0012" {
+		t.Errorf("DOI = %q", ids[hubv1.IdentifierType_IDENTIFIER_TYPE_DOI])
+	}
+	if ids[hubv1.IdentifierType_IDENTIFIER_TYPE_URL] != "https://preserve.example.edu/node/12" {
+		t.Errorf("URL = %q", ids[hubv1.IdentifierType_IDENTIFIER_TYPE_URL])
+	}
+	if len(r.Subjects) != 1 || r.Subjects[0].Value != "Steel industry" {
+		t.Errorf("subjects = %v", r.Subjects)
+	}
+	if len(r.Rights) != 1 || r.Rights[0].Uri != "https://creativecommons.org/licenses/by/4.0/" {
+		t.Errorf("rights = %v", r.Rights)
+	}
+}
+
+func TestParseJSONList(t *testing.T) {
+	input := `{"data": [
+  {"id": "10.5438/0001", "type": "dois", "attributes": {"doi": "10.5438/0001", "titles": [{"title": "First"}], "publicationYear": 2020}},
+  {"id": "10.5438/0002", "type": "dois", "attributes": {"titles": [{"title": "Second"}], "publicationYear": 2021}}
+]}`
+	records, err := (&Format{}).Parse(strings.NewReader(input), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 || records[0].Title != "First" || records[1].Title != "Second" {
+		t.Fatalf("records = %v", records)
+	}
+	var doi string
+	for _, id := range records[1].Identifiers {
+		if id.Type == hubv1.IdentifierType_IDENTIFIER_TYPE_DOI {
+			doi = id.Value
+		}
+	}
+	if doi != "10.5438/0002" {
+		t.Errorf("second DOI = %q, want it taken from the resource id", doi)
+	}
+}
+
+func TestSerializeJSON(t *testing.T) {
+	records, err := (&Format{}).Parse(strings.NewReader(restDOI), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := format.NewSerializeOptions()
+	opts.Variant = VariantJSON
+	var buf bytes.Buffer
+	if err := (&Format{}).Serialize(&buf, records, opts); err != nil {
+		t.Fatal(err)
+	}
+
+	var doc struct {
+		Data JSONDOI `json:"data"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("%v:\n%s", err, buf.String())
+	}
+	if doc.Data.Type != "dois" {
+		t.Errorf("type = %q", doc.Data.Type)
+	}
+	a := doc.Data.Attributes
+	if a.DOI != "10.5438/0012" || a.URL != "https://preserve.example.edu/node/12" {
+		t.Errorf("doi = %q, url = %q", a.DOI, a.URL)
+	}
+	if a.PublicationYear != 2023 || a.Publisher != "Lehigh University Libraries" {
+		t.Errorf("publicationYear = %d, publisher = %q", a.PublicationYear, a.Publisher)
+	}
+	if a.Types == nil || a.Types.ResourceTypeGeneral != "Sound" {
+		t.Errorf("types = %+v", a.Types)
+	}
+	if len(a.Creators) != 1 || a.Creators[0].FamilyName != "Doe" || len(a.Creators[0].NameIdentifiers) != 1 {
+		t.Errorf("creators = %+v", a.Creators)
+	}
+	if len(a.Titles) != 1 || a.Titles[0].Title != "Steel Town Oral Histories" {
+		t.Errorf("titles = %+v", a.Titles)
+	}
+	if a.SchemaVersion != schemaVersion {
+		t.Errorf("schemaVersion = %q", a.SchemaVersion)
+	}
+	for _, key := range []string{`"publicationYear":2023`, `"resourceTypeGeneral":"Sound"`, `"rightsUri":`} {
+		if !strings.Contains(buf.String(), key) {
+			t.Errorf("output missing %s:\n%s", key, buf.String())
+		}
+	}
+}
+
+func TestSerializeJSONMany(t *testing.T) {
+	records := []*hubv1.Record{{Title: "One"}, {Title: "Two"}}
+	opts := format.NewSerializeOptions()
+	opts.Variant = VariantJSON
+	var buf bytes.Buffer
+	if err := (&Format{}).Serialize(&buf, records, opts); err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		Data []JSONDOI `json:"data"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("%v:\n%s", err, buf.String())
+	}
+	if len(doc.Data) != 2 {
+		t.Errorf("got %d DOIs, want a data array of 2", len(doc.Data))
+	}
+}
+
+func TestSerializeUnknownVariant(t *testing.T) {
+	opts := format.NewSerializeOptions()
+	opts.Variant = "yaml"
+	err := (&Format{}).Serialize(&bytes.Buffer{}, []*hubv1.Record{{Title: "x"}}, opts)
+	if err == nil || !strings.Contains(err.Error(), "unknown datacite variant") {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestCanParseJSON(t *testing.T) {
+	f := &Format{}
+	if !f.CanParse([]byte(restDOI)) {
+		t.Error("CanParse rejected a REST API document")
+	}
+	if f.CanParse([]byte(`{"type": "article-journal", "title": "x"}`)) {
+		t.Error("CanParse accepted CSL JSON")
+	}
+}
@@ -11,9 +11,10 @@ import (
 
 	"github.com/lehigh-university-libraries/crosswalk/format"
 	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
 )
 
-// Parse reads DataCite XML and returns hub records.
+// Parse reads DataCite XML or REST API JSON and returns hub records.
 // Handles both bare <resource> elements and OAI-PMH wrapped responses.
 func (f *Format) Parse(r io.Reader, _ *format.ParseOptions) ([]*hubv1.Record, error) {
 	data, err := io.ReadAll(r)
@@ -21,6 +22,10 @@ func (f *Format) Parse(r io.Reader, _ *format.ParseOptions) ([]*hubv1.Record, er
 		return nil, fmt.Errorf("reading input: %w", err)
 	}
 
+	if isJSON(data) {
+		return parseJSON(data)
+	}
+
 	xmlResources, err := extractResources(data)
 	if err != nil {
 		return nil, err
@@ -43,10 +48,12 @@ func (f *Format) Parse(r io.Reader, _ *format.ParseOptions) ([]*hubv1.Record, er
 }
 
 // extractResources finds all <resource> elements in the XML.
-// Works for both bare resource documents and OAI-PMH wrapped responses.
+// Works for both bare resource documents and OAI-PMH wrapped responses;
+// each resource keeps the OAI header that precedes it.
 func extractResources(data []byte) ([]*XMLParseResource, error) {
 	decoder := xml.NewDecoder(bytes.NewReader(data))
 	var resources []*XMLParseResource
+	var header *format.OAIHeader
 
 	for {
 		tok, err := decoder.Token()
@@ -62,12 +69,19 @@ func extractResources(data []byte) ([]*XMLParseResource, error) {
 			continue
 		}
 
-		if start.Name.Local == "resource" {
-			var res XMLParseResource
+		switch start.Name.Local {
+		case "header":
+			header = &format.OAIHeader{}
+			if err := decoder.DecodeElement(header, &start); err != nil {
+				return nil, fmt.Errorf("decoding OAI header: %w", err)
+			}
+		case "resource":
+			res := XMLParseResource{Header: header}
 			if err := decoder.DecodeElement(&res, &start); err != nil {
 				return nil, fmt.Errorf("decoding resource: %w", err)
 			}
 			resources = append(resources, &res)
+			header = nil
 		}
 	}
 
@@ -79,6 +93,7 @@ func xmlResourceToHub(xmlRes *XMLParseResource) (*hubv1.Record, error) {
 	record := &hubv1.Record{
 		Publisher: xmlRes.Publisher,
 		Language:  xmlRes.Language,
+		Version:   strings.TrimSpace(xmlRes.Version),
 	}
 
 	// Identifier (DOI)
@@ -89,7 +104,9 @@ func xmlResourceToHub(xmlRes *XMLParseResource) (*hubv1.Record, error) {
 		})
 	}
 
-	// Titles: first title becomes primary title
+	// Titles: first title becomes primary title; translated titles tagged
+	// with a language are the title in that language
+	titleLang := ""
 	for i, t := range xmlRes.Titles {
 		val := strings.TrimSpace(t.Value)
 		if val == "" {
@@ -97,8 +114,15 @@ func xmlResourceToHub(xmlRes *XMLParseResource) (*hubv1.Record, error) {
 		}
 		if i == 0 && t.TitleType == "" {
 			record.Title = val
+			titleLang = t.Lang
 		} else if t.TitleType == "" && record.Title == "" {
 			record.Title = val
+			titleLang = t.Lang
+		} else if t.TitleType == "TranslatedTitle" && t.Lang != "" {
+			if len(record.Titles) == 0 && record.Title != "" {
+				hub.AddTitle(record, record.Title, titleLang)
+			}
+			hub.AddTitle(record, val, t.Lang)
 		} else {
 			record.AltTitle = append(record.AltTitle, val)
 		}
@@ -117,6 +141,9 @@ func xmlResourceToHub(xmlRes *XMLParseResource) (*hubv1.Record, error) {
 		contributor := contributorToHub(c)
 		if contributor != nil {
 			record.Contributors = append(record.Contributors, contributor)
+			if contributor.Role == "rights_holder" && record.RightsHolder == "" {
+				record.RightsHolder = contributor.Name
+			}
 		}
 	}
 
@@ -197,12 +224,19 @@ func xmlResourceToHub(xmlRes *XMLParseResource) (*hubv1.Record, error) {
 		if r.RightsURI != "" {
 			right.Uri = r.RightsURI
 		}
+		// A bare "© 2024 ..." entry is a copyright notice, not a license
+		if right.Uri == "" && hub.IsCopyrightStatement(right.Statement) && record.CopyrightStatement == "" {
+			record.CopyrightStatement = right.Statement
+			continue
+		}
 		if right.Statement != "" || right.Uri != "" {
 			record.Rights = append(record.Rights, right)
 		}
 	}
 
-	// Descriptions -> abstract (first Abstract type) and notes (others)
+	// Descriptions -> abstract (first Abstract type), abstracts in other
+	// languages, and notes (others)
+	abstractLang := ""
 	for _, d := range xmlRes.Descriptions {
 		val := strings.TrimSpace(d.Value)
 		if val == "" {
@@ -210,6 +244,12 @@ func xmlResourceToHub(xmlRes *XMLParseResource) (*hubv1.Record, error) {
 		}
 		if d.DescriptionType == "Abstract" && record.Abstract == "" {
 			record.Abstract = val
+			abstractLang = d.Lang
+		} else if d.DescriptionType == "Abstract" && d.Lang != "" && !hub.SameLanguage(d.Lang, abstractLang) {
+			if len(record.Abstracts) == 0 {
+				hub.AddAbstract(record, record.Abstract, abstractLang)
+			}
+			hub.AddAbstract(record, val, d.Lang)
 		} else {
 			record.Notes = append(record.Notes, val)
 		}
@@ -240,6 +280,7 @@ func xmlResourceToHub(xmlRes *XMLParseResource) (*hubv1.Record, error) {
 		Format:        "datacite",
 		FormatVersion: Version,
 		SourceId:      sourceID,
+		Oai:           xmlRes.Header.ToHub(),
 	}
 
 	return record, nil
@@ -368,6 +409,26 @@ func parseDate(d XMLParseDate) *hubv1.DateValue {
 		Raw:  raw,
 	}
 
+	// RKMS-ISO8601 range; an empty end is open
+	if start, end, ok := strings.Cut(raw, "/"); ok {
+		s, e := parseDate(XMLParseDate{Value: start}), parseDate(XMLParseDate{Value: end})
+		if s == nil && e == nil {
+			return dv
+		}
+		dv.IsRange = true
+		if s == nil {
+			dv.StartBound = hubv1.DateBound_DATE_BOUND_OPEN
+		} else {
+			dv.Year, dv.Month, dv.Day, dv.Precision = s.Year, s.Month, s.Day, s.Precision
+		}
+		if e == nil {
+			dv.EndBound = hubv1.DateBound_DATE_BOUND_OPEN
+		} else {
+			dv.EndYear, dv.EndMonth, dv.EndDay, dv.EndPrecision = e.Year, e.Month, e.Day, e.Precision
+		}
+		return dv
+	}
+
 	// Try RFC3339
 	if t, err := time.Parse(time.RFC3339, raw); err == nil {
 		dv.Year = int32(t.Year())
@@ -459,6 +520,8 @@ func parseDateType(s string) hubv1.DateType {
 		return hubv1.DateType_DATE_TYPE_ACCEPTED
 	case "Available":
 		return hubv1.DateType_DATE_TYPE_AVAILABLE
+	case "Collected":
+		return hubv1.DateType_DATE_TYPE_COLLECTED
 	case "Copyrighted":
 		return hubv1.DateType_DATE_TYPE_COPYRIGHT
 	case "Created":
@@ -469,6 +532,8 @@ func parseDateType(s string) hubv1.DateType {
 		return hubv1.DateType_DATE_TYPE_SUBMITTED
 	case "Updated":
 		return hubv1.DateType_DATE_TYPE_UPDATED
+	case "Valid":
+		return hubv1.DateType_DATE_TYPE_VALID
 	default:
 		return hubv1.DateType_DATE_TYPE_OTHER
 	}
@@ -585,6 +650,14 @@ func parseIdentifierType(s string) hubv1.IdentifierType {
 		return hubv1.IdentifierType_IDENTIFIER_TYPE_ARXIV
 	case "PMID":
 		return hubv1.IdentifierType_IDENTIFIER_TYPE_PMID
+	case "PMCID":
+		return hubv1.IdentifierType_IDENTIFIER_TYPE_PMCID
+	case "ARK":
+		return hubv1.IdentifierType_IDENTIFIER_TYPE_ARK
+	case "URN":
+		return hubv1.IdentifierType_IDENTIFIER_TYPE_URN
+	case "PURL":
+		return hubv1.IdentifierType_IDENTIFIER_TYPE_PURL
 	default:
 		return hubv1.IdentifierType_IDENTIFIER_TYPE_UNSPECIFIED
 	}
@@ -627,6 +700,9 @@ type XMLParseResource struct {
 	Descriptions         []XMLDescription         `xml:"descriptions>description"`
 	FundingReferences    []XMLParseFundingRef     `xml:"fundingReferences>fundingReference"`
 	Version              string                   `xml:"version"`
+
+	// Header is the enclosing OAI-PMH record header, if any
+	Header *format.OAIHeader `xml:"-"`
 }
 
 // XMLParseCreator extends XMLCreator with full parsing support.
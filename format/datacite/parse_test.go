@@ -1,10 +1,13 @@
 package datacite
 
 import (
+	"regexp"
 	"strings"
 	"testing"
 
+	"github.com/lehigh-university-libraries/crosswalk/format"
 	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
 )
 
 func TestParseDataCiteRecord(t *testing.T) {
@@ -297,6 +300,11 @@ func TestParseOAIPMHWrapped(t *testing.T) {
   <responseDate>2024-01-01T00:00:00Z</responseDate>
   <GetRecord>
     <record>
+      <header status="deleted">
+        <identifier>oai:example.org:10.5072/example</identifier>
+        <datestamp>2024-01-01T12:00:00Z</datestamp>
+        <setSpec>example.repo</setSpec>
+      </header>
       <metadata>
         <resource xmlns="http://datacite.org/schema/kernel-4">
           <identifier identifierType="DOI">10.5072/example</identifier>
@@ -337,6 +345,23 @@ func TestParseOAIPMHWrapped(t *testing.T) {
 	if r.ResourceType == nil || r.ResourceType.Type != hubv1.ResourceTypeValue_RESOURCE_TYPE_TEXT {
 		t.Errorf("ResourceType: got %v", r.ResourceType)
 	}
+
+	oai := r.SourceInfo.GetOai()
+	if oai == nil {
+		t.Fatal("SourceInfo.Oai not set")
+	}
+	if oai.Identifier != "oai:example.org:10.5072/example" {
+		t.Errorf("OAI identifier: got %q", oai.Identifier)
+	}
+	if oai.Datestamp != "2024-01-01T12:00:00Z" {
+		t.Errorf("OAI datestamp: got %q", oai.Datestamp)
+	}
+	if len(oai.SetSpecs) != 1 || oai.SetSpecs[0] != "example.repo" {
+		t.Errorf("OAI setSpecs: got %v", oai.SetSpecs)
+	}
+	if !oai.Deleted {
+		t.Error("OAI header status=deleted not captured")
+	}
 }
 
 func TestParseMultipleRecords(t *testing.T) {
@@ -509,3 +534,226 @@ func TestParseMinimalRecord(t *testing.T) {
 		t.Errorf("ResourceType: got %v", r.ResourceType)
 	}
 }
+
+func TestParallelTitlesRoundTrip(t *testing.T) {
+	input := `<resource xmlns="http://datacite.org/schema/kernel-4">
+  <identifier identifierType="DOI">10.1234/oral</identifier>
+  <titles>
+    <title xml:lang="en">Oral histories of the Lehigh Valley</title>
+    <title titleType="TranslatedTitle" xml:lang="es">Historias orales del valle de Lehigh</title>
+  </titles>
+  <descriptions>
+    <description descriptionType="Abstract" xml:lang="en">Interviews with residents.</description>
+    <description descriptionType="Abstract" xml:lang="es">Entrevistas con residentes.</description>
+  </descriptions>
+</resource>`
+
+	f := &Format{}
+	records, err := f.Parse(strings.NewReader(input), nil)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	rec := records[0]
+	if rec.Title != "Oral histories of the Lehigh Valley" || rec.Abstract != "Interviews with residents." {
+		t.Errorf("Title, Abstract: got %q, %q", rec.Title, rec.Abstract)
+	}
+	if len(rec.Titles) != 2 || rec.Titles[0].Language != "en" || rec.Titles[1].Language != "es" {
+		t.Errorf("Titles: got %v", rec.Titles)
+	}
+	if len(rec.Abstracts) != 2 || rec.Abstracts[1].Value != "Entrevistas con residentes." {
+		t.Errorf("Abstracts: got %v", rec.Abstracts)
+	}
+	if len(rec.AltTitle) != 0 || len(rec.Notes) != 0 {
+		t.Errorf("translations leaked into alt titles or notes: %v, %v", rec.AltTitle, rec.Notes)
+	}
+
+	var buf strings.Builder
+	if err := f.Serialize(&buf, records, nil); err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		`<title xml:lang="en">Oral histories of the Lehigh Valley</title>`,
+		`<title titleType="TranslatedTitle" xml:lang="es">Historias orales del valle de Lehigh</title>`,
+		`<description descriptionType="Abstract" xml:lang="es">Entrevistas con residentes.</description>`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %s:\n%s", want, out)
+		}
+	}
+}
+
+func TestDateRangeRoundTrip(t *testing.T) {
+	input := `<resource xmlns="http://datacite.org/schema/kernel-4">
+  <identifier identifierType="DOI">10.1234/sensors</identifier>
+  <titles>
+    <title>Saucon Creek sensor readings</title>
+  </titles>
+  <dates>
+    <date dateType="Collected">2019-04/2020-10</date>
+    <date dateType="Valid">2021/</date>
+  </dates>
+</resource>`
+
+	f := &Format{}
+	records, err := f.Parse(strings.NewReader(input), nil)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	dates := records[0].Dates
+	if len(dates) != 2 {
+		t.Fatalf("expected 2 dates, got %v", dates)
+	}
+	if d := dates[0]; !d.IsRange || d.Year != 2019 || d.Month != 4 || d.EndYear != 2020 || d.EndMonth != 10 {
+		t.Errorf("collected: got %v", d)
+	}
+	if d := dates[1]; d.Year != 2021 || d.EndBound != hubv1.DateBound_DATE_BOUND_OPEN {
+		t.Errorf("valid: got %v", d)
+	}
+
+	var buf strings.Builder
+	if err := f.Serialize(&buf, records, nil); err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+	for _, want := range []string{
+		`<date dateType="Collected">2019-04/2020-10</date>`,
+		`<date dateType="Valid">2021/</date>`,
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("output missing %s:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestIssuedDateRoundTrip(t *testing.T) {
+	input := `<resource xmlns="http://datacite.org/schema/kernel-4">
+  <identifier identifierType="DOI">10.1234/issued</identifier>
+  <titles>
+    <title>Saucon Creek survey</title>
+  </titles>
+  <publicationYear>2021</publicationYear>
+  <dates>
+    <date dateType="Issued">2021-05-03</date>
+  </dates>
+</resource>`
+
+	f := &Format{}
+	records, err := f.Parse(strings.NewReader(input), nil)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	var buf strings.Builder
+	if err := f.Serialize(&buf, records, nil); err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "<publicationYear>2021</publicationYear>") {
+		t.Errorf("output missing publicationYear:\n%s", out)
+	}
+	// The year from publicationYear isn't written again as a date
+	if n := strings.Count(out, `dateType="Issued"`); n != 1 || !strings.Contains(out, `<date dateType="Issued">2021-05-03</date>`) {
+		t.Errorf("expected one Issued date of 2021-05-03, got %d:\n%s", n, out)
+	}
+}
+
+func TestSerializeLanguageCode(t *testing.T) {
+	rec := &hubv1.Record{Title: "Oral histories", Language: "Spanish"}
+	var buf strings.Builder
+	if err := (&Format{}).Serialize(&buf, []*hubv1.Record{rec}, nil); err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "<language>es</language>") {
+		t.Errorf("language not written as ISO 639-1:\n%s", buf.String())
+	}
+}
+
+func TestSerializeRelatedIdentifierByID(t *testing.T) {
+	rec := &hubv1.Record{
+		Title: "Open Citations",
+		Relations: []*hubv1.Relation{
+			{Type: hubv1.RelationType_RELATION_TYPE_CITES, TargetId: "10.1038/s41586-019-0001-1", TargetIdType: hubv1.IdentifierType_IDENTIFIER_TYPE_DOI},
+			{Type: hubv1.RelationType_RELATION_TYPE_PART_OF, TargetTitle: "Journal of Testing"},
+		},
+	}
+	var buf strings.Builder
+	if err := (&Format{}).Serialize(&buf, []*hubv1.Record{rec}, nil); err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+	want := `<relatedIdentifier relatedIdentifierType="DOI" relationType="Cites">10.1038/s41586-019-0001-1</relatedIdentifier>`
+	if !strings.Contains(buf.String(), want) {
+		t.Errorf("output missing %s:\n%s", want, buf.String())
+	}
+	if strings.Count(buf.String(), "<relatedIdentifier ") != 1 {
+		t.Errorf("a relation without an identifier was written:\n%s", buf.String())
+	}
+}
+
+func TestSerializeOmitsEmptyWrappers(t *testing.T) {
+	rec := &hubv1.Record{
+		Title:        "Canal Lock Survey",
+		ResourceType: &hubv1.ResourceType{Type: hubv1.ResourceTypeValue_RESOURCE_TYPE_DATASET},
+	}
+	var buf strings.Builder
+	if err := (&Format{}).Serialize(&buf, []*hubv1.Record{rec}, nil); err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+	for _, notWant := range []string{"<dates", "<sizes", "<formats", "<geoLocations", "<relatedItems"} {
+		if strings.Contains(buf.String(), notWant) {
+			t.Errorf("output has an empty %s>:\n%s", notWant, buf.String())
+		}
+	}
+}
+
+func TestSerializeGeoLocationSizesAndRelatedItem(t *testing.T) {
+	rec := &hubv1.Record{
+		Title:        "Canal Lock Survey",
+		ResourceType: &hubv1.ResourceType{Type: hubv1.ResourceTypeValue_RESOURCE_TYPE_ARTICLE},
+		Version:      "2.1",
+		PageCount:    23,
+		PhysicalDesc: "1 online resource",
+		Geographic:   &hubv1.HierarchicalGeographic{City: "Bethlehem", State: "Pennsylvania", Country: "United States"},
+		Files: []*hubv1.File{
+			{Name: "survey.pdf", MimeType: "application/pdf", SizeBytes: 2048, Role: "original"},
+			{Name: "thumb.jpg", MimeType: "image/jpeg", SizeBytes: 10, Role: "thumbnail"},
+		},
+		Publication: &hubv1.PublicationDetails{Title: "Canal History", Volume: "12", Issue: "3", Pages: "45-67", Issn: "1234-5678"},
+	}
+	hub.SetExtra(rec, "coordinates", "40.6259, -75.3705")
+
+	var buf strings.Builder
+	if err := (&Format{}).Serialize(&buf, []*hubv1.Record{rec}, nil); err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+	out := regexp.MustCompile(`>\s+<`).ReplaceAllString(buf.String(), "><")
+	for _, want := range []string{
+		`<version>2.1</version>`,
+		`<sizes><size>23 pages</size><size>1 online resource</size><size>2048 bytes</size></sizes>`,
+		`<formats><format>application/pdf</format></formats>`,
+		`<geoLocation><geoLocationPlace>Bethlehem, Pennsylvania, United States</geoLocationPlace><geoLocationPoint><pointLongitude>-75.3705</pointLongitude><pointLatitude>40.6259</pointLatitude></geoLocationPoint></geoLocation>`,
+		`<relatedItem relationType="IsPublishedIn" relatedItemType="Journal"><relatedItemIdentifier relatedItemIdentifierType="ISSN">1234-5678</relatedItemIdentifier><titles><title>Canal History</title></titles><volume>12</volume><issue>3</issue><firstPage>45</firstPage><lastPage>67</lastPage></relatedItem>`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %s:\n%s", want, out)
+		}
+	}
+
+	buf.Reset()
+	if err := (&Format{}).Serialize(&buf, []*hubv1.Record{rec}, &format.SerializeOptions{Variant: VariantJSON}); err != nil {
+		t.Fatalf("Serialize JSON failed: %v", err)
+	}
+	for _, want := range []string{
+		`"geoLocationPoint":{"pointLongitude":-75.3705,"pointLatitude":40.6259}`,
+		`"relatedItemIdentifier":{"relatedItemIdentifier":"1234-5678","relatedItemIdentifierType":"ISSN"}`,
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("JSON missing %s:\n%s", want, buf.String())
+		}
+	}
+	records, err := (&Format{}).Parse(strings.NewReader(buf.String()), nil)
+	if err != nil {
+		t.Fatalf("Parse of serialized JSON failed: %v", err)
+	}
+	if records[0].Version != "2.1" {
+		t.Errorf("Version: got %q", records[0].Version)
+	}
+}
@@ -1,22 +1,53 @@
 package datacite
 
 import (
+	"cmp"
 	"encoding/xml"
 	"fmt"
 	"io"
+	"slices"
+	"strings"
 
 	"github.com/lehigh-university-libraries/crosswalk/format"
 	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
 	dcv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/spoke/datacite/v4_6"
+	"github.com/lehigh-university-libraries/crosswalk/helpers"
+	"github.com/lehigh-university-libraries/crosswalk/helpers/lang"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
 )
 
-// Serialize writes hub records as DataCite XML.
+// Serialize writes hub records as DataCite XML, or as a REST API JSON
+// document with VariantJSON.
 func (f *Format) Serialize(w io.Writer, records []*hubv1.Record, opts *format.SerializeOptions) error {
-	// opts reserved for future use (e.g., pretty print, encoding options)
-	_ = opts
+	if opts == nil {
+		opts = format.NewSerializeOptions()
+	}
+	switch opts.Variant {
+	case "", VariantXML, VariantJSON:
+	default:
+		return fmt.Errorf("unknown datacite variant %q (want %s or %s)", opts.Variant, VariantXML, VariantJSON)
+	}
+
+	if len(records) == 0 {
+		return fmt.Errorf("DataCite requires at least one resource: %w", format.ErrEmptyDocument)
+	}
+
+	if opts.Variant == VariantJSON {
+		resources := make([]*dcv1.Resource, len(records))
+		urls := make([]string, len(records))
+		for i, record := range records {
+			spokeResource, err := hubToSpoke(record, opts)
+			if err != nil {
+				return fmt.Errorf("converting record %d to spoke: %w", i, err)
+			}
+			resources[i] = spokeResource
+			urls[i] = landingPage(record)
+		}
+		return writeJSON(w, resources, urls, opts.Pretty)
+	}
 
 	for i, record := range records {
-		spokeResource, err := hubToSpoke(record)
+		spokeResource, err := hubToSpoke(record, opts)
 		if err != nil {
 			return fmt.Errorf("converting record %d to spoke: %w", i, err)
 		}
@@ -45,11 +76,22 @@ func (f *Format) Serialize(w io.Writer, records []*hubv1.Record, opts *format.Se
 	return nil
 }
 
+// landingPage returns the record's first URL, which the DOI resolves to.
+func landingPage(record *hubv1.Record) string {
+	for _, id := range record.Identifiers {
+		if id.Type == hubv1.IdentifierType_IDENTIFIER_TYPE_URL {
+			return id.Value
+		}
+	}
+	return ""
+}
+
 // hubToSpoke converts a hub record to the DataCite spoke proto struct.
-func hubToSpoke(record *hubv1.Record) (*dcv1.Resource, error) {
+func hubToSpoke(record *hubv1.Record, opts *format.SerializeOptions) (*dcv1.Resource, error) {
 	resource := &dcv1.Resource{
 		Publisher: record.Publisher,
-		Language:  record.Language,
+		Language:  lang.Normalize(record.Language, cmp.Or(opts.Languages, lang.ISO6391)),
+		Version:   record.Version,
 	}
 
 	// DOI identifier
@@ -63,9 +105,18 @@ func hubToSpoke(record *hubv1.Record) (*dcv1.Resource, error) {
 		}
 	}
 
-	// Titles
-	if record.Title != "" {
-		resource.Titles = []*dcv1.Title{{Value: record.Title}}
+	// Titles. Titles given in other languages follow the main title as
+	// translated titles, each tagged with its language.
+	tagged := len(record.Titles) > 0
+	for i, t := range hub.Titles(record) {
+		title := &dcv1.Title{Value: t.Value}
+		if tagged {
+			title.Lang = t.Language
+			if i > 0 {
+				title.TitleType = dcv1.TitleType_TITLE_TYPE_TRANSLATED_TITLE
+			}
+		}
+		resource.Titles = append(resource.Titles, title)
 	}
 	for _, alt := range record.AltTitle {
 		resource.Titles = append(resource.Titles, &dcv1.Title{
@@ -124,6 +175,16 @@ func hubToSpoke(record *hubv1.Record) (*dcv1.Resource, error) {
 		}
 	}
 
+	// Dates, with ranges as RKMS-ISO8601 intervals
+	for _, d := range record.Dates {
+		if coveredYear(d, record.Dates) {
+			continue
+		}
+		if value := rkmsDate(d); value != "" {
+			resource.Dates = append(resource.Dates, &dcv1.Date{Value: value, DateType: mapDateType(d.Type)})
+		}
+	}
+
 	// Resource type
 	if record.ResourceType != nil {
 		resource.ResourceType = &dcv1.ResourceType{
@@ -138,11 +199,16 @@ func hubToSpoke(record *hubv1.Record) (*dcv1.Resource, error) {
 	}
 
 	// Descriptions
-	if record.Abstract != "" {
-		resource.Descriptions = []*dcv1.Description{{
-			Value:           record.Abstract,
+	tagged = len(record.Abstracts) > 0
+	for _, a := range hub.Abstracts(record) {
+		description := &dcv1.Description{
+			Value:           a.Value,
 			DescriptionType: dcv1.DescriptionType_DESCRIPTION_TYPE_ABSTRACT,
-		}}
+		}
+		if tagged {
+			description.Lang = a.Language
+		}
+		resource.Descriptions = append(resource.Descriptions, description)
 	}
 
 	// Rights
@@ -153,6 +219,38 @@ func hubToSpoke(record *hubv1.Record) (*dcv1.Resource, error) {
 		}
 		resource.RightsList = append(resource.RightsList, right)
 	}
+	if record.CopyrightStatement != "" {
+		resource.RightsList = append(resource.RightsList, &dcv1.Rights{Value: record.CopyrightStatement})
+	}
+
+	// Sizes from the physical description, then sizes and formats from
+	// dataset distributions or, for other works, their original files
+	if record.PageCount > 0 {
+		resource.Sizes = append(resource.Sizes, fmt.Sprintf("%d pages", record.PageCount))
+	}
+	for _, size := range []string{record.PhysicalDesc, record.Dimensions, record.Duration} {
+		if size = strings.TrimSpace(size); size != "" && !slices.Contains(resource.Sizes, size) {
+			resource.Sizes = append(resource.Sizes, size)
+		}
+	}
+	dists := hub.Distributions(record)
+	if len(dists) == 0 {
+		for _, f := range record.Files {
+			if f.Role == "" || f.Role == hub.FileRoleOriginal {
+				dists = append(dists, hub.NewDistributionFromFile(f))
+			}
+		}
+	}
+	for _, d := range dists {
+		if d.SizeBytes > 0 {
+			resource.Sizes = append(resource.Sizes, fmt.Sprintf("%d bytes", d.SizeBytes))
+		}
+		if d.Format != "" && !slices.Contains(resource.Formats, d.Format) {
+			resource.Formats = append(resource.Formats, d.Format)
+		}
+	}
+
+	resource.GeoLocations = geoLocations(record)
 
 	// Funders
 	for _, f := range record.Funders {
@@ -184,13 +282,147 @@ func hubToSpoke(record *hubv1.Record) (*dcv1.Resource, error) {
 				Value:        rel.TargetUri,
 				RelationType: mapRelationType(rel.Type),
 			})
+			continue
+		}
+		// Relations identified by a DOI or the like, such as Crossref citations
+		if idType := mapRelatedIdentifierType(rel.TargetIdType); rel.TargetId != "" && idType != dcv1.RelatedIdentifierType_RELATED_IDENTIFIER_TYPE_UNSPECIFIED {
+			resource.RelatedIdentifiers = append(resource.RelatedIdentifiers, &dcv1.RelatedIdentifier{
+				Value:                 rel.TargetId,
+				RelatedIdentifierType: idType,
+				RelationType:          mapRelationType(rel.Type),
+			})
 		}
 	}
 
+	if item := publishedIn(record); item != nil {
+		resource.RelatedItems = append(resource.RelatedItems, item)
+	}
+
 	return resource, nil
 }
 
+// geoLocations describes where the work is about: the place named by its
+// hierarchical geographic subject, and the point in its "coordinates"
+// extra.
+func geoLocations(record *hubv1.Record) []*dcv1.GeoLocation {
+	loc := &dcv1.GeoLocation{GeoLocationPlace: hub.PlaceName(record.Geographic)}
+	if lat, lon, ok := hub.GetCoordinates(record); ok {
+		loc.GeoLocationPoint = &dcv1.GeoLocationPoint{PointLatitude: lat, PointLongitude: lon}
+	}
+	if loc.GeoLocationPlace == "" && loc.GeoLocationPoint == nil {
+		return nil
+	}
+	return []*dcv1.GeoLocation{loc}
+}
+
+// publishedIn describes the journal or book the work appeared in as a
+// related item, giving the full citation DataCite recommends over a bare
+// related identifier.
+func publishedIn(record *hubv1.Record) *dcv1.RelatedItem {
+	pub := record.Publication
+	if pub == nil || (pub.Title == "" && pub.Issn == "" && pub.LIssn == "") {
+		return nil
+	}
+
+	item := &dcv1.RelatedItem{
+		RelationType:    dcv1.RelationType_RELATION_TYPE_IS_PUBLISHED_IN,
+		RelatedItemType: dcv1.ResourceTypeGeneral_RESOURCE_TYPE_GENERAL_JOURNAL,
+		Volume:          pub.Volume,
+		Issue:           pub.Issue,
+	}
+	if record.ResourceType != nil && record.ResourceType.Type == hubv1.ResourceTypeValue_RESOURCE_TYPE_BOOK_CHAPTER {
+		item.RelatedItemType = dcv1.ResourceTypeGeneral_RESOURCE_TYPE_GENERAL_BOOK
+	}
+	if pub.Title != "" {
+		item.Titles = []*dcv1.Title{{Value: pub.Title}}
+	}
+	if issn := cmp.Or(pub.Issn, pub.LIssn); issn != "" {
+		item.RelatedItemIdentifier = &dcv1.RelatedItemIdentifier{Value: issn, RelatedItemIdentifierType: "ISSN"}
+	}
+	item.FirstPage, item.LastPage = helpers.SplitPages(pub.Pages)
+	return item
+}
+
+// mapRelatedIdentifierType maps a hub identifier type to the DataCite
+// related identifier type, or unspecified when DataCite has none.
+func mapRelatedIdentifierType(t hubv1.IdentifierType) dcv1.RelatedIdentifierType {
+	switch t {
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_DOI:
+		return dcv1.RelatedIdentifierType_RELATED_IDENTIFIER_TYPE_DOI
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_URL:
+		return dcv1.RelatedIdentifierType_RELATED_IDENTIFIER_TYPE_URL
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_HANDLE:
+		return dcv1.RelatedIdentifierType_RELATED_IDENTIFIER_TYPE_HANDLE
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_ISBN:
+		return dcv1.RelatedIdentifierType_RELATED_IDENTIFIER_TYPE_ISBN
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_ISSN:
+		return dcv1.RelatedIdentifierType_RELATED_IDENTIFIER_TYPE_ISSN
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_PMID:
+		return dcv1.RelatedIdentifierType_RELATED_IDENTIFIER_TYPE_PMID
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_ARXIV:
+		return dcv1.RelatedIdentifierType_RELATED_IDENTIFIER_TYPE_ARXIV
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_ARK:
+		return dcv1.RelatedIdentifierType_RELATED_IDENTIFIER_TYPE_ARK
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_URN:
+		return dcv1.RelatedIdentifierType_RELATED_IDENTIFIER_TYPE_URN
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_PURL:
+		return dcv1.RelatedIdentifierType_RELATED_IDENTIFIER_TYPE_PURL
+	}
+	return dcv1.RelatedIdentifierType_RELATED_IDENTIFIER_TYPE_UNSPECIFIED
+}
+
 // mapResourceType maps hub resource type to DataCite general type.
+// rkmsDate writes a date, or a range as an RKMS-ISO8601 interval whose
+// open or unknown ends are empty ("2004-03/2005", "2004/"). Dates without
+// a parsed year are left out.
+func rkmsDate(d *hubv1.DateValue) string {
+	if d.Year == 0 && d.StartBound == hubv1.DateBound_DATE_BOUND_UNSPECIFIED {
+		return ""
+	}
+	return format.FormatInterval(d, format.DateOptions{}, "")
+}
+
+// coveredYear reports whether d is a bare year that a fuller date of the
+// same DataCite type already gives, like the year parsed from
+// publicationYear next to an Issued date of 2021-05-03.
+func coveredYear(d *hubv1.DateValue, dates []*hubv1.DateValue) bool {
+	if d.Month != 0 || d.IsRange || d.StartBound != hubv1.DateBound_DATE_BOUND_UNSPECIFIED {
+		return false
+	}
+	for _, other := range dates {
+		if other != d && other.Year == d.Year && other.Month != 0 && mapDateType(other.Type) == mapDateType(d.Type) {
+			return true
+		}
+	}
+	return false
+}
+
+// mapDateType maps a hub date type to a DataCite dateType.
+func mapDateType(t hubv1.DateType) dcv1.DateType {
+	switch t {
+	case hubv1.DateType_DATE_TYPE_ISSUED, hubv1.DateType_DATE_TYPE_PUBLISHED:
+		return dcv1.DateType_DATE_TYPE_ISSUED
+	case hubv1.DateType_DATE_TYPE_CREATED:
+		return dcv1.DateType_DATE_TYPE_CREATED
+	case hubv1.DateType_DATE_TYPE_ACCEPTED:
+		return dcv1.DateType_DATE_TYPE_ACCEPTED
+	case hubv1.DateType_DATE_TYPE_AVAILABLE:
+		return dcv1.DateType_DATE_TYPE_AVAILABLE
+	case hubv1.DateType_DATE_TYPE_COPYRIGHT:
+		return dcv1.DateType_DATE_TYPE_COPYRIGHTED
+	case hubv1.DateType_DATE_TYPE_SUBMITTED:
+		return dcv1.DateType_DATE_TYPE_SUBMITTED
+	case hubv1.DateType_DATE_TYPE_UPDATED, hubv1.DateType_DATE_TYPE_MODIFIED:
+		return dcv1.DateType_DATE_TYPE_UPDATED
+	case hubv1.DateType_DATE_TYPE_COLLECTED:
+		return dcv1.DateType_DATE_TYPE_COLLECTED
+	case hubv1.DateType_DATE_TYPE_VALID:
+		return dcv1.DateType_DATE_TYPE_VALID
+	default:
+		return dcv1.DateType_DATE_TYPE_OTHER
+	}
+}
+
 func mapResourceType(rt hubv1.ResourceTypeValue) dcv1.ResourceTypeGeneral {
 	switch rt {
 	case hubv1.ResourceTypeValue_RESOURCE_TYPE_ARTICLE,
@@ -237,6 +469,40 @@ func mapRelationType(rt hubv1.RelationType) dcv1.RelationType {
 		return dcv1.RelationType_RELATION_TYPE_IS_CITED_BY
 	case hubv1.RelationType_RELATION_TYPE_VERSION_OF:
 		return dcv1.RelationType_RELATION_TYPE_IS_VERSION_OF
+	case hubv1.RelationType_RELATION_TYPE_HAS_VERSION:
+		return dcv1.RelationType_RELATION_TYPE_HAS_VERSION
+	case hubv1.RelationType_RELATION_TYPE_CITES:
+		return dcv1.RelationType_RELATION_TYPE_CITES
+	case hubv1.RelationType_RELATION_TYPE_SUPPLEMENTS,
+		hubv1.RelationType_RELATION_TYPE_IS_SUPPLEMENT_TO:
+		return dcv1.RelationType_RELATION_TYPE_IS_SUPPLEMENT_TO
+	case hubv1.RelationType_RELATION_TYPE_SUPPLEMENTED_BY:
+		return dcv1.RelationType_RELATION_TYPE_IS_SUPPLEMENTED_BY
+	case hubv1.RelationType_RELATION_TYPE_SAME_AS,
+		hubv1.RelationType_RELATION_TYPE_IDENTICAL_TO:
+		return dcv1.RelationType_RELATION_TYPE_IS_IDENTICAL_TO
+	case hubv1.RelationType_RELATION_TYPE_DERIVED_FROM:
+		return dcv1.RelationType_RELATION_TYPE_IS_DERIVED_FROM
+	case hubv1.RelationType_RELATION_TYPE_SOURCE_OF:
+		return dcv1.RelationType_RELATION_TYPE_IS_SOURCE_OF
+	case hubv1.RelationType_RELATION_TYPE_REQUIRES:
+		return dcv1.RelationType_RELATION_TYPE_REQUIRES
+	case hubv1.RelationType_RELATION_TYPE_REQUIRED_BY:
+		return dcv1.RelationType_RELATION_TYPE_IS_REQUIRED_BY
+	case hubv1.RelationType_RELATION_TYPE_REPLACES:
+		return dcv1.RelationType_RELATION_TYPE_OBSOLETES
+	case hubv1.RelationType_RELATION_TYPE_IS_REPLACED_BY:
+		return dcv1.RelationType_RELATION_TYPE_IS_OBSOLETED_BY
+	case hubv1.RelationType_RELATION_TYPE_DOCUMENTS:
+		return dcv1.RelationType_RELATION_TYPE_DOCUMENTS
+	case hubv1.RelationType_RELATION_TYPE_IS_DOCUMENTED_BY:
+		return dcv1.RelationType_RELATION_TYPE_IS_DOCUMENTED_BY
+	case hubv1.RelationType_RELATION_TYPE_DESCRIBES:
+		return dcv1.RelationType_RELATION_TYPE_DESCRIBES
+	case hubv1.RelationType_RELATION_TYPE_IS_DESCRIBED_BY:
+		return dcv1.RelationType_RELATION_TYPE_IS_DESCRIBED_BY
+	case hubv1.RelationType_RELATION_TYPE_REVIEWS:
+		return dcv1.RelationType_RELATION_TYPE_REVIEWS
 	default:
 		return dcv1.RelationType_RELATION_TYPE_UNSPECIFIED
 	}
@@ -257,6 +523,14 @@ func identifierTypeToString(t hubv1.IdentifierType) string {
 		return "arXiv"
 	case hubv1.IdentifierType_IDENTIFIER_TYPE_PMID:
 		return "PMID"
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_PMCID:
+		return "PMCID"
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_ARK:
+		return "ARK"
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_URN:
+		return "URN"
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_PURL:
+		return "PURL"
 	default:
 		return "Other"
 	}
@@ -310,6 +584,7 @@ func spokeToXML(spoke *dcv1.Resource) *XMLResource {
 	for _, t := range spoke.Titles {
 		xmlRes.Titles = append(xmlRes.Titles, XMLTitle{
 			TitleType: titleTypeToString(t.TitleType),
+			Lang:      t.Lang,
 			Value:     t.Value,
 		})
 	}
@@ -319,6 +594,14 @@ func spokeToXML(spoke *dcv1.Resource) *XMLResource {
 		xmlRes.Subjects = append(xmlRes.Subjects, XMLSubject{Value: s.Value})
 	}
 
+	// Dates
+	if len(spoke.Dates) > 0 {
+		xmlRes.Dates = &XMLDates{}
+	}
+	for _, d := range spoke.Dates {
+		xmlRes.Dates.Dates = append(xmlRes.Dates.Dates, XMLDate{DateType: dateTypeToString(d.DateType), Value: d.Value})
+	}
+
 	// Resource type
 	if spoke.ResourceType != nil {
 		xmlRes.ResourceType = &XMLResourceType{
@@ -331,6 +614,7 @@ func spokeToXML(spoke *dcv1.Resource) *XMLResource {
 	for _, d := range spoke.Descriptions {
 		xmlRes.Descriptions = append(xmlRes.Descriptions, XMLDescription{
 			DescriptionType: descriptionTypeToString(d.DescriptionType),
+			Lang:            d.Lang,
 			Value:           d.Value,
 		})
 	}
@@ -343,6 +627,14 @@ func spokeToXML(spoke *dcv1.Resource) *XMLResource {
 		})
 	}
 
+	// Sizes and formats, without empty wrappers
+	if len(spoke.Sizes) > 0 {
+		xmlRes.Sizes = &XMLSizes{Sizes: spoke.Sizes}
+	}
+	if len(spoke.Formats) > 0 {
+		xmlRes.Formats = &XMLFormats{Formats: spoke.Formats}
+	}
+
 	// Funding references
 	for _, f := range spoke.FundingReferences {
 		xmlRes.FundingReferences = append(xmlRes.FundingReferences, XMLFundingReference{
@@ -368,9 +660,70 @@ func spokeToXML(spoke *dcv1.Resource) *XMLResource {
 		})
 	}
 
+	// Geolocations
+	if len(spoke.GeoLocations) > 0 {
+		xmlRes.GeoLocations = &XMLGeoLocations{}
+	}
+	for _, g := range spoke.GeoLocations {
+		loc := XMLGeoLocation{Place: g.GeoLocationPlace}
+		if p := g.GeoLocationPoint; p != nil {
+			loc.Point = &XMLGeoLocationPoint{Longitude: p.PointLongitude, Latitude: p.PointLatitude}
+		}
+		xmlRes.GeoLocations.GeoLocations = append(xmlRes.GeoLocations.GeoLocations, loc)
+	}
+
+	// Related items
+	if len(spoke.RelatedItems) > 0 {
+		xmlRes.RelatedItems = &XMLRelatedItems{}
+	}
+	for _, ri := range spoke.RelatedItems {
+		item := XMLRelatedItem{
+			RelationType:    relationTypeToString(ri.RelationType),
+			RelatedItemType: resourceTypeGeneralToString(ri.RelatedItemType),
+			Volume:          ri.Volume,
+			Issue:           ri.Issue,
+			FirstPage:       ri.FirstPage,
+			LastPage:        ri.LastPage,
+		}
+		if id := ri.RelatedItemIdentifier; id != nil {
+			item.Identifier = &XMLRelatedItemIdentifier{Type: id.RelatedItemIdentifierType, Value: id.Value}
+		}
+		for _, t := range ri.Titles {
+			item.Titles = append(item.Titles, XMLTitle{Value: t.Value})
+		}
+		xmlRes.RelatedItems.RelatedItems = append(xmlRes.RelatedItems.RelatedItems, item)
+	}
+
 	return xmlRes
 }
 
+func dateTypeToString(dt dcv1.DateType) string {
+	switch dt {
+	case dcv1.DateType_DATE_TYPE_ACCEPTED:
+		return "Accepted"
+	case dcv1.DateType_DATE_TYPE_AVAILABLE:
+		return "Available"
+	case dcv1.DateType_DATE_TYPE_COPYRIGHTED:
+		return "Copyrighted"
+	case dcv1.DateType_DATE_TYPE_COLLECTED:
+		return "Collected"
+	case dcv1.DateType_DATE_TYPE_CREATED:
+		return "Created"
+	case dcv1.DateType_DATE_TYPE_ISSUED:
+		return "Issued"
+	case dcv1.DateType_DATE_TYPE_SUBMITTED:
+		return "Submitted"
+	case dcv1.DateType_DATE_TYPE_UPDATED:
+		return "Updated"
+	case dcv1.DateType_DATE_TYPE_VALID:
+		return "Valid"
+	case dcv1.DateType_DATE_TYPE_WITHDRAWN:
+		return "Withdrawn"
+	default:
+		return "Other"
+	}
+}
+
 func titleTypeToString(tt dcv1.TitleType) string {
 	switch tt {
 	case dcv1.TitleType_TITLE_TYPE_ALTERNATIVE_TITLE:
@@ -398,6 +751,8 @@ func resourceTypeGeneralToString(rt dcv1.ResourceTypeGeneral) string {
 		return "Dissertation"
 	case dcv1.ResourceTypeGeneral_RESOURCE_TYPE_GENERAL_IMAGE:
 		return "Image"
+	case dcv1.ResourceTypeGeneral_RESOURCE_TYPE_GENERAL_JOURNAL:
+		return "Journal"
 	case dcv1.ResourceTypeGeneral_RESOURCE_TYPE_GENERAL_JOURNAL_ARTICLE:
 		return "JournalArticle"
 	case dcv1.ResourceTypeGeneral_RESOURCE_TYPE_GENERAL_REPORT:
@@ -440,6 +795,40 @@ func relationTypeToString(rt dcv1.RelationType) string {
 		return "References"
 	case dcv1.RelationType_RELATION_TYPE_IS_VERSION_OF:
 		return "IsVersionOf"
+	case dcv1.RelationType_RELATION_TYPE_HAS_VERSION:
+		return "HasVersion"
+	case dcv1.RelationType_RELATION_TYPE_CITES:
+		return "Cites"
+	case dcv1.RelationType_RELATION_TYPE_IS_SUPPLEMENT_TO:
+		return "IsSupplementTo"
+	case dcv1.RelationType_RELATION_TYPE_IS_SUPPLEMENTED_BY:
+		return "IsSupplementedBy"
+	case dcv1.RelationType_RELATION_TYPE_IS_IDENTICAL_TO:
+		return "IsIdenticalTo"
+	case dcv1.RelationType_RELATION_TYPE_IS_DERIVED_FROM:
+		return "IsDerivedFrom"
+	case dcv1.RelationType_RELATION_TYPE_IS_SOURCE_OF:
+		return "IsSourceOf"
+	case dcv1.RelationType_RELATION_TYPE_REQUIRES:
+		return "Requires"
+	case dcv1.RelationType_RELATION_TYPE_IS_REQUIRED_BY:
+		return "IsRequiredBy"
+	case dcv1.RelationType_RELATION_TYPE_OBSOLETES:
+		return "Obsoletes"
+	case dcv1.RelationType_RELATION_TYPE_IS_OBSOLETED_BY:
+		return "IsObsoletedBy"
+	case dcv1.RelationType_RELATION_TYPE_DOCUMENTS:
+		return "Documents"
+	case dcv1.RelationType_RELATION_TYPE_IS_DOCUMENTED_BY:
+		return "IsDocumentedBy"
+	case dcv1.RelationType_RELATION_TYPE_DESCRIBES:
+		return "Describes"
+	case dcv1.RelationType_RELATION_TYPE_IS_DESCRIBED_BY:
+		return "IsDescribedBy"
+	case dcv1.RelationType_RELATION_TYPE_REVIEWS:
+		return "Reviews"
+	case dcv1.RelationType_RELATION_TYPE_IS_PUBLISHED_IN:
+		return "IsPublishedIn"
 	default:
 		return "IsRelatedTo"
 	}
@@ -465,6 +854,8 @@ func relatedIdentifierTypeToString(t dcv1.RelatedIdentifierType) string {
 		return "PMID"
 	case dcv1.RelatedIdentifierType_RELATED_IDENTIFIER_TYPE_URN:
 		return "URN"
+	case dcv1.RelatedIdentifierType_RELATED_IDENTIFIER_TYPE_PURL:
+		return "PURL"
 	default:
 		return "URL"
 	}
@@ -484,13 +875,38 @@ type XMLResource struct {
 	PublicationYear      int32                    `xml:"publicationYear"`
 	ResourceType         *XMLResourceType         `xml:"resourceType,omitempty"`
 	Subjects             []XMLSubject             `xml:"subjects>subject,omitempty"`
+	Dates                *XMLDates                `xml:"dates,omitempty"`
 	Language             string                   `xml:"language,omitempty"`
 	AlternateIdentifiers []XMLAlternateIdentifier `xml:"alternateIdentifiers>alternateIdentifier,omitempty"`
 	RelatedIdentifiers   []XMLRelatedIdentifier   `xml:"relatedIdentifiers>relatedIdentifier,omitempty"`
 	RightsList           []XMLRights              `xml:"rightsList>rights,omitempty"`
+	Sizes                *XMLSizes                `xml:"sizes,omitempty"`
+	Formats              *XMLFormats              `xml:"formats,omitempty"`
 	Descriptions         []XMLDescription         `xml:"descriptions>description,omitempty"`
 	FundingReferences    []XMLFundingReference    `xml:"fundingReferences>fundingReference,omitempty"`
 	Version              string                   `xml:"version,omitempty"`
+	GeoLocations         *XMLGeoLocations         `xml:"geoLocations,omitempty"`
+	RelatedItems         *XMLRelatedItems         `xml:"relatedItems,omitempty"`
+}
+
+type XMLDates struct {
+	Dates []XMLDate `xml:"date"`
+}
+
+type XMLSizes struct {
+	Sizes []string `xml:"size"`
+}
+
+type XMLFormats struct {
+	Formats []string `xml:"format"`
+}
+
+type XMLGeoLocations struct {
+	GeoLocations []XMLGeoLocation `xml:"geoLocation"`
+}
+
+type XMLRelatedItems struct {
+	RelatedItems []XMLRelatedItem `xml:"relatedItem"`
 }
 
 type XMLIdentifier struct {
@@ -523,6 +939,7 @@ type XMLAffiliation struct {
 
 type XMLTitle struct {
 	TitleType string `xml:"titleType,attr,omitempty"`
+	Lang      string `xml:"http://www.w3.org/XML/1998/namespace lang,attr,omitempty"`
 	Value     string `xml:",chardata"`
 }
 
@@ -535,8 +952,14 @@ type XMLResourceType struct {
 	Value               string `xml:",chardata"`
 }
 
+type XMLDate struct {
+	DateType string `xml:"dateType,attr"`
+	Value    string `xml:",chardata"`
+}
+
 type XMLDescription struct {
 	DescriptionType string `xml:"descriptionType,attr"`
+	Lang            string `xml:"http://www.w3.org/XML/1998/namespace lang,attr,omitempty"`
 	Value           string `xml:",chardata"`
 }
 
@@ -560,3 +983,29 @@ type XMLRelatedIdentifier struct {
 	RelationType          string `xml:"relationType,attr"`
 	Value                 string `xml:",chardata"`
 }
+
+type XMLGeoLocation struct {
+	Place string               `xml:"geoLocationPlace,omitempty"`
+	Point *XMLGeoLocationPoint `xml:"geoLocationPoint,omitempty"`
+}
+
+type XMLGeoLocationPoint struct {
+	Longitude float64 `xml:"pointLongitude"`
+	Latitude  float64 `xml:"pointLatitude"`
+}
+
+type XMLRelatedItem struct {
+	RelationType    string                    `xml:"relationType,attr"`
+	RelatedItemType string                    `xml:"relatedItemType,attr"`
+	Identifier      *XMLRelatedItemIdentifier `xml:"relatedItemIdentifier,omitempty"`
+	Titles          []XMLTitle                `xml:"titles>title,omitempty"`
+	Volume          string                    `xml:"volume,omitempty"`
+	Issue           string                    `xml:"issue,omitempty"`
+	FirstPage       string                    `xml:"firstPage,omitempty"`
+	LastPage        string                    `xml:"lastPage,omitempty"`
+}
+
+type XMLRelatedItemIdentifier struct {
+	Type  string `xml:"relatedItemIdentifierType,attr"`
+	Value string `xml:",chardata"`
+}
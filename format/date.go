@@ -0,0 +1,178 @@
+package format
+
+import (
+	"fmt"
+	"time"
+
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+// DateStyle selects how serializers render dates.
+type DateStyle string
+
+const (
+	// DateStyleISO renders ISO 8601 dates: "2024-03-01", "2024-03", "2024".
+	DateStyleISO DateStyle = "iso"
+	// DateStyleLong renders English prose dates: "March 1, 2024", "March 2024".
+	DateStyleLong DateStyle = "long"
+	// DateStyleYear renders only the year: "2024".
+	DateStyleYear DateStyle = "year"
+)
+
+// DateOptions controls date rendering. The zero value keeps each
+// serializer's native output.
+type DateOptions struct {
+	// Style is the rendering style (default: DateStyleISO)
+	Style DateStyle
+
+	// MinPrecision pads coarser dates, e.g. DAY renders "2024" as
+	// "2024-01-01" for targets that require full dates.
+	MinPrecision hubv1.DatePrecision
+
+	// MaxPrecision truncates finer dates, e.g. YEAR renders "2024-03-01"
+	// as "2024".
+	MaxPrecision hubv1.DatePrecision
+}
+
+// ParseDateStyle parses a style name, accepting "" as the default.
+func ParseDateStyle(s string) (DateStyle, error) {
+	switch DateStyle(s) {
+	case "":
+		return "", nil
+	case DateStyleISO, DateStyleLong, DateStyleYear:
+		return DateStyle(s), nil
+	}
+	return "", fmt.Errorf("unknown date style %q (want iso, long, or year)", s)
+}
+
+// ParseDatePrecision parses a precision name ("year", "month", "day").
+func ParseDatePrecision(s string) (hubv1.DatePrecision, error) {
+	switch s {
+	case "":
+		return hubv1.DatePrecision_DATE_PRECISION_UNSPECIFIED, nil
+	case "year":
+		return hubv1.DatePrecision_DATE_PRECISION_YEAR, nil
+	case "month":
+		return hubv1.DatePrecision_DATE_PRECISION_MONTH, nil
+	case "day":
+		return hubv1.DatePrecision_DATE_PRECISION_DAY, nil
+	}
+	return 0, fmt.Errorf("unknown date precision %q (want year, month, or day)", s)
+}
+
+// FormatDate renders a hub date according to the options. Dates without a
+// year fall back to their raw value.
+func FormatDate(d *hubv1.DateValue, opts DateOptions) string {
+	if d.Year == 0 {
+		return d.Raw
+	}
+
+	year, month, day := ClampDate(d, opts)
+	style := opts.Style
+	if style == "" {
+		style = DateStyleISO
+	}
+
+	switch style {
+	case DateStyleYear:
+		return fmt.Sprintf("%04d", year)
+	case DateStyleLong:
+		switch {
+		case month == 0:
+			return fmt.Sprintf("%d", year)
+		case day == 0:
+			return fmt.Sprintf("%s %d", time.Month(month), year)
+		default:
+			return fmt.Sprintf("%s %d, %d", time.Month(month), day, year)
+		}
+	default:
+		switch {
+		case month == 0:
+			return fmt.Sprintf("%04d", year)
+		case day == 0:
+			return fmt.Sprintf("%04d-%02d", year, month)
+		default:
+			return fmt.Sprintf("%04d-%02d-%02d", year, month, day)
+		}
+	}
+}
+
+// FormatInterval renders a date range as an ISO 8601 interval of its ends
+// rendered by FormatDate ("1990/2000-05"), writing open for an open end:
+// ".." in ISO 8601-2 and EDTF, or nothing in RKMS-ISO8601. An unknown end
+// is left empty. Dates that are not ranges render as FormatDate does.
+func FormatInterval(d *hubv1.DateValue, opts DateOptions, open string) string {
+	if !hub.IsInterval(d) {
+		return FormatDate(d, opts)
+	}
+
+	var start, end string
+	switch d.StartBound {
+	case hubv1.DateBound_DATE_BOUND_OPEN:
+		start = open
+	case hubv1.DateBound_DATE_BOUND_UNSPECIFIED:
+		if d.Year == 0 {
+			return d.Raw
+		}
+		start = FormatDate(&hubv1.DateValue{Year: d.Year, Month: d.Month, Day: d.Day}, opts)
+	}
+	switch d.EndBound {
+	case hubv1.DateBound_DATE_BOUND_OPEN:
+		end = open
+	case hubv1.DateBound_DATE_BOUND_UNSPECIFIED:
+		end = FormatDate(&hubv1.DateValue{Year: d.EndYear, Month: d.EndMonth, Day: d.EndDay}, opts)
+	}
+	return start + "/" + end
+}
+
+// ClampDate returns the date's year, month, and day after applying the
+// precision floor and ceiling. Month and day are 0 when not rendered.
+func ClampDate(d *hubv1.DateValue, opts DateOptions) (year, month, day int32) {
+	year, month, day = d.Year, d.Month, d.Day
+	if month == 0 {
+		day = 0
+	}
+
+	if limit := precisionRank(opts.MaxPrecision); limit > 0 {
+		if limit < precisionRank(hubv1.DatePrecision_DATE_PRECISION_DAY) {
+			day = 0
+		}
+		if limit < precisionRank(hubv1.DatePrecision_DATE_PRECISION_MONTH) {
+			month = 0
+		}
+	}
+
+	if floor := precisionRank(opts.MinPrecision); floor > 0 {
+		if floor >= precisionRank(hubv1.DatePrecision_DATE_PRECISION_MONTH) && month == 0 {
+			month = 1
+		}
+		if floor >= precisionRank(hubv1.DatePrecision_DATE_PRECISION_DAY) && day == 0 {
+			day = 1
+		}
+	}
+
+	return year, month, day
+}
+
+// precisionRank orders precisions from coarsest to finest; the enum values
+// themselves are not ordered (DECADE, CENTURY, and SEASON were added last).
+func precisionRank(p hubv1.DatePrecision) int {
+	switch p {
+	case hubv1.DatePrecision_DATE_PRECISION_CENTURY:
+		return 1
+	case hubv1.DatePrecision_DATE_PRECISION_DECADE:
+		return 2
+	case hubv1.DatePrecision_DATE_PRECISION_YEAR:
+		return 3
+	case hubv1.DatePrecision_DATE_PRECISION_SEASON:
+		return 4
+	case hubv1.DatePrecision_DATE_PRECISION_MONTH:
+		return 5
+	case hubv1.DatePrecision_DATE_PRECISION_DAY:
+		return 6
+	case hubv1.DatePrecision_DATE_PRECISION_TIME:
+		return 7
+	}
+	return 0
+}
@@ -0,0 +1,35 @@
+package format_test
+
+import (
+	"testing"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+)
+
+func TestFormatDate(t *testing.T) {
+	day := &hubv1.DateValue{Year: 2024, Month: 3, Day: 1}
+	year := &hubv1.DateValue{Year: 2024}
+	tests := []struct {
+		name string
+		date *hubv1.DateValue
+		opts format.DateOptions
+		want string
+	}{
+		{"default iso", day, format.DateOptions{}, "2024-03-01"},
+		{"long", day, format.DateOptions{Style: format.DateStyleLong}, "March 1, 2024"},
+		{"year style", day, format.DateOptions{Style: format.DateStyleYear}, "2024"},
+		{"max month", day, format.DateOptions{MaxPrecision: hubv1.DatePrecision_DATE_PRECISION_MONTH}, "2024-03"},
+		{"max year long", day, format.DateOptions{Style: format.DateStyleLong, MaxPrecision: hubv1.DatePrecision_DATE_PRECISION_YEAR}, "2024"},
+		{"min day pads", year, format.DateOptions{MinPrecision: hubv1.DatePrecision_DATE_PRECISION_DAY}, "2024-01-01"},
+		{"min month pads", year, format.DateOptions{MinPrecision: hubv1.DatePrecision_DATE_PRECISION_MONTH}, "2024-01"},
+		{"raw without year", &hubv1.DateValue{Raw: "circa 1900"}, format.DateOptions{}, "circa 1900"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := format.FormatDate(tt.date, tt.opts); got != tt.want {
+				t.Errorf("FormatDate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
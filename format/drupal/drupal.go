@@ -2,8 +2,10 @@ package drupal
 
 import (
 	"bytes"
+	"sort"
 
 	"github.com/lehigh-university-libraries/crosswalk/format"
+	"github.com/lehigh-university-libraries/crosswalk/mapping"
 )
 
 // Format implements the Drupal entity JSON format.
@@ -11,9 +13,11 @@ type Format struct{}
 
 // Ensure Format implements the interfaces
 var (
-	_ format.Format     = (*Format)(nil)
-	_ format.Parser     = (*Format)(nil)
-	_ format.Serializer = (*Format)(nil)
+	_ format.Format         = (*Format)(nil)
+	_ format.Parser         = (*Format)(nil)
+	_ format.StreamParser   = (*Format)(nil)
+	_ format.Serializer     = (*Format)(nil)
+	_ format.FieldDescriber = (*Format)(nil)
 )
 
 // Name returns the format identifier.
@@ -31,6 +35,53 @@ func (f *Format) Extensions() []string {
 	return []string{"json"}
 }
 
+// irFields maps the profile IR fields Serialize handles to hub record
+// field names. DegreeInfo is matched on its base, so any DegreeInfo.*
+// mapping counts.
+var irFields = map[string]string{
+	"Title":          "title",
+	"AltTitle":       "alt_title",
+	"Abstract":       "abstract",
+	"Description":    "description",
+	"Contributors":   "contributors",
+	"Dates":          "dates",
+	"ResourceType":   "resource_type",
+	"Genre":          "genres",
+	"Language":       "language",
+	"Rights":         "rights",
+	"Subjects":       "subjects",
+	"Publisher":      "publisher",
+	"PlacePublished": "place_published",
+	"Relations":      "relations",
+	"Identifiers":    "identifiers",
+	"Notes":          "notes",
+	"DegreeInfo":     "degree_info",
+}
+
+// WrittenFields returns the hub record fields the profile maps to Drupal
+// fields, using the default profile when opts has none.
+func (f *Format) WrittenFields(opts *format.SerializeOptions) []string {
+	var profile *mapping.Profile
+	if opts != nil {
+		profile = opts.Profile
+	}
+	if profile == nil {
+		profile = defaultProfile()
+	}
+
+	var fields []string
+	seen := make(map[string]bool)
+	for _, m := range profile.Fields {
+		base, _ := mapping.IRFieldName(m.IR)
+		if field, ok := irFields[base]; ok && !seen[field] {
+			seen[field] = true
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
 // CanParse returns true if the input looks like Drupal entity JSON.
 func (f *Format) CanParse(peek []byte) bool {
 	peek = bytes.TrimSpace(peek)
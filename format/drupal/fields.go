@@ -257,6 +257,26 @@ func (fv *FieldValue) GetModelExternalURI() (string, bool) {
 	return "", false
 }
 
+// GetExternalURI returns the field_external_uri of an enriched taxonomy
+// term, such as the PCDM use URI of an Islandora Media Use term.
+func (fv *FieldValue) GetExternalURI() (string, bool) {
+	if len(fv.Entity) == 0 {
+		return "", false
+	}
+
+	var term map[string]json.RawMessage
+	if err := json.Unmarshal(fv.Entity, &term); err != nil {
+		return "", false
+	}
+	var links []struct {
+		URI string `json:"uri"`
+	}
+	if err := json.Unmarshal(term["field_external_uri"], &links); err == nil && len(links) > 0 && links[0].URI != "" {
+		return links[0].URI, true
+	}
+	return "", false
+}
+
 // GetNodeResourceType returns the content type/bundle from enriched node data.
 // Returns the bundle name (e.g., "islandora_object", "article") and true if found.
 func (fv *FieldValue) GetNodeResourceType() (string, bool) {
@@ -0,0 +1,580 @@
+package drupal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+	"github.com/lehigh-university-libraries/crosswalk/mapping"
+	spokeregistry "github.com/lehigh-university-libraries/crosswalk/spoke/registry"
+)
+
+const jsonAPIMediaType = "application/vnd.api+json"
+
+// readOnlyFields are base fields Drupal sets itself, which JSON:API
+// refuses to write.
+var readOnlyFields = map[string]bool{
+	"nid":                           true,
+	"uuid":                          true,
+	"vid":                           true,
+	"type":                          true,
+	"changed":                       true,
+	"default_langcode":              true,
+	"revision_timestamp":            true,
+	"revision_uid":                  true,
+	"revision_translation_affected": true,
+}
+
+// singleBaseFields are base fields holding one value, which no spoke
+// describes.
+var singleBaseFields = map[string]bool{
+	"title":    true,
+	"status":   true,
+	"created":  true,
+	"langcode": true,
+	"promote":  true,
+	"sticky":   true,
+}
+
+// entityPaths are the REST paths of the entity types a numeric target_id
+// is looked up at.
+var entityPaths = map[string]string{
+	"taxonomy_term": "taxonomy/term",
+	"node":          "node",
+	"media":         "media",
+}
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// JSONAPIClient writes hub records to a Drupal site through its JSON:API
+// module. A record whose node the site already has (by uuid or nid) is
+// updated with PATCH, sending only the fields the record fills; any other
+// record creates a node. Taxonomy references by name are looked up in
+// their vocabulary and created when missing.
+type JSONAPIClient struct {
+	BaseURL    string
+	HTTPClient *http.Client
+	UserAgent  string
+	Username   string
+	Password   string
+
+	// Profile maps hub fields to the site's Drupal fields (default: the
+	// drupal format's default profile).
+	Profile *mapping.Profile
+	// Fields describes the site's fields by name: how many values each
+	// holds and what a reference field points at. Fields it doesn't
+	// describe fall back to the profile's hints.
+	Fields map[string]spokeregistry.FieldMeta
+	// Bundle is the node type records without one are created as.
+	Bundle string
+
+	// DryRun sends lookups but no writes: Push returns the document it
+	// would send, and terms it would create are reported without an ID.
+	DryRun bool
+	// Delay is the least time between requests.
+	Delay time.Duration
+	// Retries is how many times a 429 or 503 response is retried, waiting
+	// for the Retry-After the site asks for.
+	Retries int
+
+	last  time.Time
+	refs  map[string]*jsonAPIRef // REST path or vocabulary/name to resource
+	terms map[string]bool        // vocabulary/name of terms made by this client
+}
+
+// NewJSONAPIClient returns a client for the Drupal site at baseURL.
+func NewJSONAPIClient(baseURL string) *JSONAPIClient {
+	return &JSONAPIClient{
+		BaseURL:    strings.TrimSuffix(baseURL, "/"),
+		HTTPClient: &http.Client{Timeout: 60 * time.Second},
+		UserAgent:  "crosswalk-push (https://github.com/lehigh-university-libraries/crosswalk)",
+		Bundle:     "islandora_object",
+		Retries:    3,
+		refs:       make(map[string]*jsonAPIRef),
+		terms:      make(map[string]bool),
+	}
+}
+
+// PushResult describes the write Push made, or would make in a dry run.
+type PushResult struct {
+	Title  string `json:"title,omitempty"`
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	// ID is the node's UUID; a dry-run create has none.
+	ID     string `json:"id,omitempty"`
+	Status int    `json:"status,omitempty"`
+	// NewTerms are the terms created for the record, as vocabulary/name.
+	NewTerms []string `json:"new_terms,omitempty"`
+	// Document is the JSON:API document a dry run would send.
+	Document json.RawMessage `json:"document,omitempty"`
+}
+
+// jsonAPIRef is a JSON:API resource identifier.
+type jsonAPIRef struct {
+	Type string         `json:"type"`
+	ID   string         `json:"id"`
+	Meta map[string]any `json:"meta,omitempty"`
+}
+
+// jsonAPIError is a JSON:API error response.
+type jsonAPIError struct {
+	Errors []struct {
+		Status string `json:"status"`
+		Title  string `json:"title"`
+		Detail string `json:"detail"`
+	} `json:"errors"`
+}
+
+// Push creates or updates the record's node.
+func (c *JSONAPIClient) Push(ctx context.Context, record *hubv1.Record) (*PushResult, error) {
+	profile := c.Profile
+	if profile == nil {
+		profile = defaultProfile()
+	}
+	entity, err := recordToEntity(record, profile, format.NewSerializeOptions())
+	if err != nil {
+		return nil, err
+	}
+	nameContributors(entity, record, profile)
+	raw, err := json.Marshal(entity)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+
+	result := &PushResult{Title: record.Title}
+	bundle := c.Bundle
+	if b := firstValue(fields["type"], "target_id"); b != "" {
+		bundle = b
+	}
+	id, err := c.nodeID(ctx, record, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	attributes := make(map[string]any)
+	relationships := make(map[string]any)
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		if readOnlyFields[name] {
+			continue
+		}
+		var items []map[string]any
+		if err := json.Unmarshal(fields[name], &items); err != nil || len(items) == 0 {
+			slog.Debug("skipping field that isn't a list of field items", "field", name)
+			continue
+		}
+		meta, described := c.Fields[name]
+		fm := profile.Fields[name]
+		single := singleBaseFields[name] || described && meta.Cardinality == 1 ||
+			!described && !fm.MultiValue && len(items) == 1
+
+		targetType, bundleHint := meta.TargetType, meta.TargetBundle
+		if targetType == "" {
+			targetType = fm.Resolve
+		}
+		if fm.Vocabulary != "" {
+			bundleHint = fm.Vocabulary
+		}
+
+		if targetType == "" {
+			values := make([]any, 0, len(items))
+			for _, item := range items {
+				if v, ok := item["value"]; ok && len(item) == 1 {
+					values = append(values, v)
+				} else {
+					values = append(values, item)
+				}
+			}
+			if single {
+				attributes[name] = values[0]
+			} else {
+				attributes[name] = values
+			}
+			continue
+		}
+
+		var refs []*jsonAPIRef
+		for _, item := range items {
+			ref, err := c.reference(ctx, result, name, targetType, bundleHint, item["target_id"])
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+			if ref == nil {
+				continue
+			}
+			if rel, ok := item["rel_type"].(string); ok && rel != "" {
+				ref = &jsonAPIRef{Type: ref.Type, ID: ref.ID, Meta: map[string]any{"rel_type": rel}}
+			}
+			refs = append(refs, ref)
+		}
+		switch {
+		case len(refs) == 0:
+		case single:
+			relationships[name] = map[string]any{"data": refs[0]}
+		default:
+			relationships[name] = map[string]any{"data": refs}
+		}
+	}
+
+	// Nodes need a title, whichever field the profile writes it to
+	if _, ok := attributes["title"]; !ok && record.Title != "" {
+		attributes["title"] = record.Title
+	}
+
+	data := map[string]any{"type": "node--" + bundle, "attributes": attributes}
+	if len(relationships) > 0 {
+		data["relationships"] = relationships
+	}
+	result.Method, result.URL = http.MethodPost, c.BaseURL+"/jsonapi/node/"+bundle
+	if id != "" {
+		data["id"] = id
+		result.Method, result.URL, result.ID = http.MethodPatch, result.URL+"/"+id, id
+	}
+	doc, err := json.Marshal(map[string]any{"data": data})
+	if err != nil {
+		return nil, err
+	}
+	if c.DryRun {
+		result.Document = doc
+		return result, nil
+	}
+
+	status, created, err := c.write(ctx, result.Method, result.URL, doc)
+	if err != nil {
+		return nil, err
+	}
+	result.Status = status
+	if created.ID != "" {
+		result.ID = created.ID
+	}
+	return result, nil
+}
+
+// nameContributors gives contributors without a term ID their name as the
+// target, so reference finds or adds their term in the agent vocabulary.
+// The serializer leaves them without a target, since a name is no term ID
+// in a Drupal JSON export.
+func nameContributors(entity map[string]any, record *hubv1.Record, profile *mapping.Profile) {
+	sources := sourceFieldsByIR(profile)["Contributors"]
+	if len(sources) == 0 {
+		return
+	}
+	items, _ := entity[sources[0].SourceField].([]map[string]any)
+	for i, item := range items {
+		if _, ok := item["target_id"]; !ok && i < len(record.Contributors) && record.Contributors[i].Name != "" {
+			item["target_id"] = record.Contributors[i].Name
+		}
+	}
+}
+
+// nodeID returns the UUID of the record's existing node: its uuid, or the
+// node with its nid. A record with neither is a new node.
+func (c *JSONAPIClient) nodeID(ctx context.Context, record *hubv1.Record, fields map[string]json.RawMessage) (string, error) {
+	extra := hub.GetExtraFields(record)
+	uuid := scalarString(extra["uuid"])
+	if uuid == "" {
+		uuid = firstValue(fields["uuid"], "value")
+	}
+	if uuid != "" {
+		return uuid, nil
+	}
+
+	nid := scalarString(extra["nid"])
+	if nid == "" {
+		nid = firstValue(fields["nid"], "value")
+	}
+	if nid == "" {
+		for _, id := range record.Identifiers {
+			if id.Type == hubv1.IdentifierType_IDENTIFIER_TYPE_NID {
+				nid = id.Value
+				break
+			}
+		}
+	}
+	if nid == "" {
+		return "", nil
+	}
+	ref, err := c.lookupID(ctx, "node", nid)
+	if err != nil {
+		return "", fmt.Errorf("finding node %s: %w", nid, err)
+	}
+	return ref.ID, nil
+}
+
+// reference resolves a field item's target_id to a resource identifier:
+// numeric IDs are looked up, UUIDs are used as they are, and names are
+// found in, or added to, the field's vocabulary. Targets that can't be
+// resolved are left out with a warning.
+func (c *JSONAPIClient) reference(ctx context.Context, result *PushResult, field, targetType, bundle string, target any) (*jsonAPIRef, error) {
+	value := strings.TrimSpace(scalarString(target))
+	switch {
+	case value == "":
+		return nil, nil
+	case isNumeric(value):
+		return c.lookupID(ctx, targetType, value)
+	case uuidPattern.MatchString(value) && bundle != "":
+		return &jsonAPIRef{Type: targetType + "--" + bundle, ID: value}, nil
+	case targetType != "taxonomy_term":
+		slog.Warn("leaving out reference: only taxonomy terms can be referenced by name", "field", field, "title", result.Title, "value", value)
+		return nil, nil
+	case bundle == "":
+		slog.Warn("leaving out term: no vocabulary for the field (set vocabulary in the profile)", "field", field, "title", result.Title, "value", value)
+		return nil, nil
+	}
+	return c.term(ctx, result, bundle, value)
+}
+
+// lookupID finds an entity's UUID and bundle from its numeric ID, with
+// the site's REST endpoint for the entity.
+func (c *JSONAPIClient) lookupID(ctx context.Context, entityType, id string) (*jsonAPIRef, error) {
+	path, ok := entityPaths[entityType]
+	if !ok {
+		return nil, fmt.Errorf("can't look up %s %s by ID", entityType, id)
+	}
+	key := path + "/" + id
+	if ref, ok := c.refs[key]; ok {
+		return ref, nil
+	}
+
+	body, err := c.get(ctx, c.BaseURL+"/"+key+"?_format=json", "application/json")
+	if err != nil {
+		return nil, err
+	}
+	var entity map[string]json.RawMessage
+	if err := json.Unmarshal(body, &entity); err != nil {
+		return nil, fmt.Errorf("decoding %s %s: %w", entityType, id, err)
+	}
+	uuid := firstValue(entity["uuid"], "value")
+	bundle := firstValue(entity["vid"], "target_id")
+	if bundle == "" {
+		bundle = firstValue(entity["type"], "target_id")
+	}
+	if bundle == "" {
+		bundle = firstValue(entity["bundle"], "target_id")
+	}
+	if uuid == "" || bundle == "" {
+		return nil, fmt.Errorf("%s %s has no uuid or bundle", entityType, id)
+	}
+	ref := &jsonAPIRef{Type: entityType + "--" + bundle, ID: uuid}
+	c.refs[key] = ref
+	return ref, nil
+}
+
+// term finds the vocabulary's term with the name, creating it when there
+// is none. A dry run reports the term it would create, without an ID.
+func (c *JSONAPIClient) term(ctx context.Context, result *PushResult, vocabulary, name string) (*jsonAPIRef, error) {
+	key := vocabulary + "/" + name
+	if ref, ok := c.refs[key]; ok {
+		if c.terms[key] && !slices.Contains(result.NewTerms, key) {
+			result.NewTerms = append(result.NewTerms, key)
+		}
+		return ref, nil
+	}
+
+	collection := c.BaseURL + "/jsonapi/taxonomy_term/" + vocabulary
+	query := url.Values{"filter[name]": {name}}
+	body, err := c.get(ctx, collection+"?"+query.Encode(), jsonAPIMediaType)
+	if err != nil {
+		return nil, fmt.Errorf("looking up term %q in %s: %w", name, vocabulary, err)
+	}
+	var found struct {
+		Data []struct {
+			ID         string `json:"id"`
+			Attributes struct {
+				Name string `json:"name"`
+			} `json:"attributes"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &found); err != nil {
+		return nil, fmt.Errorf("decoding terms in %s: %w", vocabulary, err)
+	}
+
+	ref := &jsonAPIRef{Type: "taxonomy_term--" + vocabulary}
+	for _, t := range found.Data {
+		if strings.EqualFold(t.Attributes.Name, name) {
+			ref.ID = t.ID
+			break
+		}
+	}
+	if ref.ID == "" {
+		if !c.DryRun {
+			doc, err := json.Marshal(map[string]any{"data": map[string]any{
+				"type":       ref.Type,
+				"attributes": map[string]any{"name": name},
+			}})
+			if err != nil {
+				return nil, err
+			}
+			_, created, err := c.write(ctx, http.MethodPost, collection, doc)
+			if err != nil {
+				return nil, fmt.Errorf("creating term %q in %s: %w", name, vocabulary, err)
+			}
+			ref.ID = created.ID
+		}
+		c.terms[key] = true
+		result.NewTerms = append(result.NewTerms, key)
+	}
+	c.refs[key] = ref
+	return ref, nil
+}
+
+// get fetches a URL, failing on any status but 200.
+func (c *JSONAPIClient) get(ctx context.Context, target, accept string) ([]byte, error) {
+	status, body, err := c.do(ctx, http.MethodGet, target, accept, nil)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, responseError(status, body)
+	}
+	return body, nil
+}
+
+// write sends a JSON:API document and returns the status and the
+// resource identifier of the entity written.
+func (c *JSONAPIClient) write(ctx context.Context, method, target string, doc []byte) (int, jsonAPIRef, error) {
+	status, body, err := c.do(ctx, method, target, jsonAPIMediaType, doc)
+	if err != nil {
+		return 0, jsonAPIRef{}, err
+	}
+	if status != http.StatusOK && status != http.StatusCreated {
+		return status, jsonAPIRef{}, responseError(status, body)
+	}
+	var written struct {
+		Data jsonAPIRef `json:"data"`
+	}
+	if err := json.Unmarshal(body, &written); err != nil {
+		return status, jsonAPIRef{}, fmt.Errorf("decoding response: %w", err)
+	}
+	return status, written.Data, nil
+}
+
+// do sends one request, keeping Delay between requests and retrying while
+// the site answers 429 Too Many Requests or 503 Service Unavailable.
+func (c *JSONAPIClient) do(ctx context.Context, method, target, accept string, body []byte) (int, []byte, error) {
+	for attempt := 0; ; attempt++ {
+		if err := pause(ctx, c.Delay-time.Since(c.last)); err != nil {
+			return 0, nil, err
+		}
+		c.last = time.Now()
+
+		req, err := http.NewRequestWithContext(ctx, method, target, bytes.NewReader(body))
+		if err != nil {
+			return 0, nil, err
+		}
+		req.Header.Set("Accept", accept)
+		if body != nil {
+			req.Header.Set("Content-Type", jsonAPIMediaType)
+		}
+		if c.UserAgent != "" {
+			req.Header.Set("User-Agent", c.UserAgent)
+		}
+		if c.Username != "" {
+			req.SetBasicAuth(c.Username, c.Password)
+		}
+
+		resp, err := c.HTTPClient.Do(req)
+		if err != nil {
+			return 0, nil, err
+		}
+		data, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return 0, nil, fmt.Errorf("reading response: %w", err)
+		}
+
+		throttled := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable
+		if throttled && attempt < c.Retries {
+			wait := 10 * time.Second
+			if seconds, err := strconv.Atoi(strings.TrimSpace(resp.Header.Get("Retry-After"))); err == nil && seconds >= 0 {
+				wait = min(time.Duration(seconds)*time.Second, 5*time.Minute)
+			}
+			slog.Debug("site is throttling requests", "status", resp.StatusCode, "wait", wait)
+			if err := pause(ctx, wait); err != nil {
+				return 0, nil, err
+			}
+			continue
+		}
+		return resp.StatusCode, data, nil
+	}
+}
+
+// responseError describes a failed request, with the JSON:API errors the
+// site sent.
+func responseError(status int, body []byte) error {
+	var doc jsonAPIError
+	if err := json.Unmarshal(body, &doc); err == nil && len(doc.Errors) > 0 {
+		msgs := make([]string, 0, len(doc.Errors))
+		for _, e := range doc.Errors {
+			msg := e.Title
+			if e.Detail != "" {
+				msg += ": " + e.Detail
+			}
+			msgs = append(msgs, msg)
+		}
+		return fmt.Errorf("status %d: %s", status, strings.Join(msgs, "; "))
+	}
+	return fmt.Errorf("status %d", status)
+}
+
+// firstValue returns the first field item's property as a string, from a
+// raw Drupal field value.
+func firstValue(raw json.RawMessage, property string) string {
+	var items []map[string]any
+	if err := json.Unmarshal(raw, &items); err != nil || len(items) == 0 {
+		return ""
+	}
+	return scalarString(items[0][property])
+}
+
+// scalarString returns a decoded JSON string or number as a string.
+func scalarString(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return ""
+}
+
+func isNumeric(s string) bool {
+	_, err := strconv.ParseUint(s, 10, 64)
+	return err == nil
+}
+
+// pause waits for d or until ctx is done.
+func pause(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,237 @@
+package drupal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+	"github.com/lehigh-university-libraries/crosswalk/mapping"
+	spokeregistry "github.com/lehigh-university-libraries/crosswalk/spoke/registry"
+)
+
+const existingNode = "0b2c3d4e-5f60-4718-8a9b-0c1d2e3f4a5b"
+
+// drupalSite fakes the REST and JSON:API endpoints a push uses and keeps
+// the writes it receives, by method and path.
+type drupalSite struct {
+	mu     sync.Mutex
+	writes map[string]json.RawMessage
+}
+
+func (s *drupalSite) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/taxonomy/term/12":
+		fmt.Fprint(w, `{"uuid":[{"value":"agent-uuid"}],"vid":[{"target_id":"person"}]}`)
+	case r.Method == http.MethodGet && r.URL.Path == "/node/7":
+		fmt.Fprint(w, `{"uuid":[{"value":"`+existingNode+`"}],"type":[{"target_id":"islandora_object"}]}`)
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/jsonapi/taxonomy_term/"):
+		if r.URL.Query().Get("filter[name]") == "Geology" {
+			fmt.Fprint(w, `{"data":[{"type":"taxonomy_term--subject","id":"geology-uuid","attributes":{"name":"Geology"}}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"data":[]}`)
+	case r.Method == http.MethodPost || r.Method == http.MethodPatch:
+		if r.Header.Get("Content-Type") != jsonAPIMediaType {
+			w.WriteHeader(http.StatusUnsupportedMediaType)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		s.writes[r.Method+" "+r.URL.Path] = body
+		var doc struct {
+			Data jsonAPIRef `json:"data"`
+		}
+		json.Unmarshal(body, &doc)
+		if doc.Data.ID == "" {
+			doc.Data.ID = "created-" + strings.TrimPrefix(r.URL.Path, "/jsonapi/")
+			w.WriteHeader(http.StatusCreated)
+		}
+		json.NewEncoder(w).Encode(map[string]any{"data": doc.Data})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"errors":[{"status":"404","title":"Not Found","detail":"No route"}]}`)
+	}
+}
+
+func pushClient(t *testing.T) (*JSONAPIClient, *drupalSite) {
+	t.Helper()
+	site := &drupalSite{writes: make(map[string]json.RawMessage)}
+	srv := httptest.NewServer(site)
+	t.Cleanup(srv.Close)
+
+	c := NewJSONAPIClient(srv.URL + "/")
+	c.Profile = &mapping.Profile{Fields: map[string]mapping.FieldMapping{
+		"title":              {IR: "Title"},
+		"field_abstract":     {IR: "Abstract"},
+		"field_note":         {IR: "Notes"},
+		"field_linked_agent": {IR: "Contributors", Type: "typed_relation", Resolve: "taxonomy_term"},
+		"field_subject":      {IR: "Subjects", Resolve: "taxonomy_term"},
+	}}
+	c.Fields = map[string]spokeregistry.FieldMeta{
+		"field_abstract":     {DrupalField: "field_abstract", Cardinality: 1},
+		"field_note":         {DrupalField: "field_note", Cardinality: -1},
+		"field_linked_agent": {DrupalField: "field_linked_agent", TargetType: "taxonomy_term", TargetBundle: "person", Cardinality: -1},
+		"field_subject":      {DrupalField: "field_subject", TargetType: "taxonomy_term", TargetBundle: "subject", Cardinality: -1},
+	}
+	return c, site
+}
+
+func pushRecord() *hubv1.Record {
+	return &hubv1.Record{
+		Title:    "Glacial deposits",
+		Abstract: "Till and outwash.",
+		Notes:    []string{"Digitized 2024"},
+		Contributors: []*hubv1.Contributor{
+			{Name: "Doe, Jane", SourceId: "12", Role: "author"},
+		},
+		Subjects: []*hubv1.Subject{{Value: "Geology"}, {Value: "Glaciers"}},
+	}
+}
+
+func TestPushCreatesNode(t *testing.T) {
+	c, site := pushClient(t)
+	result, err := c.Push(context.Background(), pushRecord())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Method != http.MethodPost || !strings.HasSuffix(result.URL, "/jsonapi/node/islandora_object") {
+		t.Errorf("got %s %s, want a POST to the bundle", result.Method, result.URL)
+	}
+	if result.ID != "created-node/islandora_object" || result.Status != http.StatusCreated {
+		t.Errorf("ID = %q, status %d", result.ID, result.Status)
+	}
+	if strings.Join(result.NewTerms, ",") != "subject/Glaciers" {
+		t.Errorf("NewTerms = %v", result.NewTerms)
+	}
+	if _, ok := site.writes["POST /jsonapi/taxonomy_term/subject"]; !ok {
+		t.Error("the missing term was not created")
+	}
+
+	var doc struct {
+		Data struct {
+			Type          string                     `json:"type"`
+			Attributes    map[string]json.RawMessage `json:"attributes"`
+			Relationships map[string]struct {
+				Data json.RawMessage `json:"data"`
+			} `json:"relationships"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(site.writes["POST /jsonapi/node/islandora_object"], &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc.Data.Type != "node--islandora_object" {
+		t.Errorf("type = %q", doc.Data.Type)
+	}
+	want := map[string]string{
+		"title":          `"Glacial deposits"`,
+		"field_abstract": `"Till and outwash."`,
+		"field_note":     `["Digitized 2024"]`,
+	}
+	for field, value := range want {
+		if got := string(doc.Data.Attributes[field]); got != value {
+			t.Errorf("%s = %s, want %s", field, got, value)
+		}
+	}
+	if got := string(doc.Data.Relationships["field_linked_agent"].Data); got != `[{"type":"taxonomy_term--person","id":"agent-uuid","meta":{"rel_type":"relators:aut"}}]` {
+		t.Errorf("field_linked_agent = %s", got)
+	}
+	if got := string(doc.Data.Relationships["field_subject"].Data); got != `[{"type":"taxonomy_term--subject","id":"geology-uuid"},{"type":"taxonomy_term--subject","id":"created-taxonomy_term/subject"}]` {
+		t.Errorf("field_subject = %s", got)
+	}
+}
+
+func TestPushContributorByName(t *testing.T) {
+	c, site := pushClient(t)
+	record := pushRecord()
+	record.Contributors = []*hubv1.Contributor{{Name: "Roe, Richard", Role: "author"}}
+
+	// The export leaves a contributor without a term ID untargeted
+	entity, err := recordToEntity(record, c.Profile, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if item := entity["field_linked_agent"].([]map[string]any)[0]; item["target_id"] != nil {
+		t.Errorf("export target_id = %v, want none", item["target_id"])
+	}
+
+	result, err := c.Push(context.Background(), record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Contains(result.NewTerms, "person/Roe, Richard") {
+		t.Errorf("NewTerms = %v, want the contributor's term", result.NewTerms)
+	}
+	var doc struct {
+		Data struct {
+			Relationships map[string]struct {
+				Data json.RawMessage `json:"data"`
+			} `json:"relationships"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(site.writes["POST /jsonapi/node/islandora_object"], &doc); err != nil {
+		t.Fatal(err)
+	}
+	if got := string(doc.Data.Relationships["field_linked_agent"].Data); got != `[{"type":"taxonomy_term--person","id":"created-taxonomy_term/person","meta":{"rel_type":"relators:aut"}}]` {
+		t.Errorf("field_linked_agent = %s", got)
+	}
+}
+
+func TestPushUpdatesNodeByNID(t *testing.T) {
+	c, site := pushClient(t)
+	record := &hubv1.Record{Title: "Retitled"}
+	hub.SetExtra(record, "nid", "7")
+
+	result, err := c.Push(context.Background(), record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Method != http.MethodPatch || result.ID != existingNode {
+		t.Errorf("got %s of %q, want a PATCH of the existing node", result.Method, result.ID)
+	}
+	body := string(site.writes["PATCH /jsonapi/node/islandora_object/"+existingNode])
+	if !strings.Contains(body, `"id":"`+existingNode+`"`) || !strings.Contains(body, `"title":"Retitled"`) {
+		t.Errorf("PATCH body = %s", body)
+	}
+	if strings.Contains(body, "field_") {
+		t.Errorf("an update should send only the fields the record fills: %s", body)
+	}
+}
+
+func TestPushDryRun(t *testing.T) {
+	c, site := pushClient(t)
+	c.DryRun = true
+	result, err := c.Push(context.Background(), pushRecord())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(site.writes) != 0 {
+		t.Errorf("a dry run wrote to the site: %v", site.writes)
+	}
+	if result.ID != "" || len(result.Document) == 0 {
+		t.Errorf("expected the document without an ID, got %+v", result)
+	}
+	if strings.Join(result.NewTerms, ",") != "subject/Glaciers" {
+		t.Errorf("NewTerms = %v, want the term a real push would create", result.NewTerms)
+	}
+}
+
+func TestPushReportsSiteErrors(t *testing.T) {
+	c, _ := pushClient(t)
+	record := &hubv1.Record{Title: "Gone"}
+	hub.SetExtra(record, "nid", "404")
+
+	_, err := c.Push(context.Background(), record)
+	if err == nil || !strings.Contains(err.Error(), "Not Found: No route") {
+		t.Errorf("expected the site's JSON:API error, got %v", err)
+	}
+}
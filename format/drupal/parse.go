@@ -1,10 +1,13 @@
 package drupal
 
 import (
+	"bufio"
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
 	"log/slog"
+	"sort"
 	"strings"
 
 	"github.com/lehigh-university-libraries/crosswalk/format"
@@ -12,57 +15,152 @@ import (
 	"github.com/lehigh-university-libraries/crosswalk/helpers"
 	"github.com/lehigh-university-libraries/crosswalk/hub"
 	"github.com/lehigh-university-libraries/crosswalk/mapping"
+	"github.com/lehigh-university-libraries/crosswalk/value"
 )
 
 // Parse reads Drupal JSON and returns hub records.
 func (f *Format) Parse(r io.Reader, opts *format.ParseOptions) ([]*hubv1.Record, error) {
+	var records []*hubv1.Record
+	err := f.ParseStream(r, opts, func(record *hubv1.Record) error {
+		records = append(records, record)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// ParseStream reads a Drupal JSON entity, or an array of them, decoding
+// one array element at a time. With opts.Workers above one, entities are
+// converted concurrently and handed to fn in input order. An entity that
+// isn't a JSON object or fails to convert is a format.RecordError, which
+// opts.OnRecordError may skip.
+func (f *Format) ParseStream(r io.Reader, opts *format.ParseOptions, fn func(*hubv1.Record) error) error {
 	if opts == nil {
 		opts = format.NewParseOptions()
 	}
+	return helpers.MapOrdered(opts.Workers,
+		func(emit func(rawEntity) error) error {
+			return decodeEntities(r, emit)
+		},
+		func(e rawEntity) (convertedEntity, error) {
+			record, err := e.convert(opts)
+			return convertedEntity{record, err}, nil
+		},
+		func(c convertedEntity) error {
+			if c.err == nil {
+				return fn(c.record)
+			}
+			if opts.OnRecordError == nil {
+				return c.err
+			}
+			return opts.OnRecordError(c.err)
+		})
+}
+
+// rawEntity is an undecoded entity and where it sits in the input.
+type rawEntity struct {
+	index  int
+	offset int64
+	raw    json.RawMessage
+}
 
-	data, err := io.ReadAll(r)
+// convertedEntity is the outcome of converting one rawEntity.
+type convertedEntity struct {
+	record *hubv1.Record
+	err    *format.RecordError
+}
+
+// convert decodes and converts the entity, reporting failures as
+// RecordErrors identified by the entity's nid (or uuid).
+func (e rawEntity) convert(opts *format.ParseOptions) (*hubv1.Record, *format.RecordError) {
+	var entity DrupalEntity
+	if err := json.Unmarshal(e.raw, &entity); err != nil {
+		return nil, &format.RecordError{Index: e.index, Offset: e.offset, Err: fmt.Errorf("decoding entity: %w", err)}
+	}
+	record, err := convertEntity(entity, opts)
 	if err != nil {
-		return nil, fmt.Errorf("reading input: %w", err)
+		id := value.FromArrayText(entity["nid"])
+		if id == "" {
+			id = value.FromArrayText(entity["uuid"])
+		}
+		return nil, &format.RecordError{Index: e.index, ID: id, Offset: e.offset, Err: fmt.Errorf("converting entity: %w", err)}
+	}
+	if opts.PreserveSource {
+		if err := format.PreserveSource(record, "drupal", e.raw); err != nil {
+			return nil, &format.RecordError{Index: e.index, Offset: e.offset, Err: err}
+		}
 	}
+	return record, nil
+}
 
-	data = trimBOM(data)
+// decodeEntities reads a single entity or an array of them, calling emit
+// with each as it is read. Entities are decoded later, so one malformed
+// entity doesn't stop the rest being read.
+func decodeEntities(r io.Reader, emit func(rawEntity) error) error {
+	br := bufio.NewReader(r)
+	var base int64
+	if bom, _ := br.Peek(len(utf8BOM)); bytes.Equal(bom, utf8BOM) {
+		_, _ = br.Discard(len(utf8BOM))
+		base = int64(len(utf8BOM))
+	}
 
 	// Determine if input is a single entity or array
-	var entities []DrupalEntity
-
-	data = skipWhitespace(data)
-	if len(data) == 0 {
-		return nil, nil
+	first, skipped, err := peekNonSpace(br)
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("reading input: %w", err)
+	}
+	base += int64(skipped)
+
+	decoder := json.NewDecoder(br)
+	// next reads the next JSON value and the offset it starts at
+	next := func() (json.RawMessage, int64, error) {
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			return nil, 0, err
+		}
+		return raw, base + decoder.InputOffset() - int64(len(raw)), nil
 	}
 
-	switch data[0] {
+	switch first {
 	case '[':
 		// Array of entities
-		if err := json.Unmarshal(data, &entities); err != nil {
-			return nil, fmt.Errorf("parsing JSON array: %w", err)
+		if _, err := decoder.Token(); err != nil {
+			return fmt.Errorf("parsing JSON array: %w", err)
+		}
+		for i := 0; decoder.More(); i++ {
+			raw, offset, err := next()
+			if err != nil {
+				return fmt.Errorf("parsing JSON array: %w", err)
+			}
+			if err := emit(rawEntity{i, offset, raw}); err != nil {
+				return err
+			}
+		}
+		if _, err := decoder.Token(); err != nil {
+			return fmt.Errorf("parsing JSON array: %w", err)
 		}
 	case '{':
 		// Single entity
-		var single DrupalEntity
-		if err := json.Unmarshal(data, &single); err != nil {
-			return nil, fmt.Errorf("parsing JSON object: %w", err)
+		raw, offset, err := next()
+		if err != nil {
+			return fmt.Errorf("parsing JSON object: %w", err)
+		}
+		if err := emit(rawEntity{0, offset, raw}); err != nil {
+			return err
 		}
-		entities = []DrupalEntity{single}
 	default:
-		return nil, fmt.Errorf("invalid JSON: expected { or [")
+		return fmt.Errorf("invalid JSON: expected { or [")
 	}
 
-	// Convert entities to hub records
-	records := make([]*hubv1.Record, 0, len(entities))
-	for i, entity := range entities {
-		record, err := convertEntity(entity, opts)
-		if err != nil {
-			return nil, fmt.Errorf("converting entity %d: %w", i, err)
-		}
-		records = append(records, record)
+	if _, err := decoder.Token(); err != io.EOF {
+		return fmt.Errorf("invalid JSON: unexpected data after the top-level value")
 	}
-
-	return records, nil
+	return nil
 }
 
 func convertEntity(entity DrupalEntity, opts *format.ParseOptions) (*hubv1.Record, error) {
@@ -72,48 +170,93 @@ func convertEntity(entity DrupalEntity, opts *format.ParseOptions) (*hubv1.Recor
 	// profile (which may not enumerate every Drupal field) is active.
 	// An explicit profile overrides the defaults field-by-field.
 	profile := mapping.MergeProfiles(defaultProfile(), opts.Profile)
+	if err := applyTransforms(entity, profile); err != nil {
+		return nil, err
+	}
 
 	// Track which hub fields have been set with their priorities
 	priorities := make(map[string]int)
 
+	// Non-empty source fields with no mapping, reported in SourceInfo
+	var unmapped []string
+
+	// Conditional rules may test any of the entity's fields
+	var fields map[string]string
+	if hasRules(profile) {
+		fields = make(map[string]string, len(entity))
+		for name, raw := range entity {
+			fields[name], _ = ExtractString(raw)
+		}
+	}
+
 	// Process each field in the entity
 	for fieldName, rawValue := range entity {
 		fieldMapping, ok := profile.Fields[fieldName]
 		if !ok {
-			// Unknown field - might store in Extra later
+			if !isEmptyValue(rawValue) {
+				unmapped = append(unmapped, fieldName)
+			}
 			continue
 		}
 
-		// Check priority - only skip if a value was actually set at that priority.
-		// Use IR+Type as the key so that fields targeting the same IR base but
-		// different logical sub-types (e.g. Publication/related_item vs
-		// Publication/part_detail) don't block each other.
-		priorityKey := fieldMapping.IR
-		if fieldMapping.Type != "" {
-			priorityKey = fieldMapping.IR + "/" + fieldMapping.Type
-		}
-		currentPriority, hasPriority := priorities[priorityKey]
-		if hasPriority && fieldMapping.Priority <= currentPriority {
-			continue
+		for _, route := range routeField(fieldName, rawValue, fieldMapping, fields, opts) {
+			fieldMapping, rawValue := route.mapping, route.raw
+
+			// Check priority - only skip if a value was actually set at that priority.
+			// Use IR+Type as the key so that fields targeting the same IR base but
+			// different logical sub-types (e.g. Publication/related_item vs
+			// Publication/part_detail) don't block each other.
+			priorityKey := fieldMapping.PriorityKey()
+			currentPriority, hasPriority := priorities[priorityKey]
+			if hasPriority && fieldMapping.Priority <= currentPriority {
+				continue
+			}
+
+			// Process field based on its type and target
+			// processField returns true if a value was actually set
+			valueSet, err := processField(record, fieldName, rawValue, fieldMapping, opts)
+			if err != nil {
+				// Log error but continue processing
+				continue
+			}
+
+			// Only update priority if a value was actually set
+			if valueSet {
+				priorities[priorityKey] = fieldMapping.Priority
+			}
 		}
+	}
 
-		// Process field based on its type and target
-		// processField returns true if a value was actually set
-		valueSet, err := processField(record, fieldName, rawValue, fieldMapping, opts)
-		if err != nil {
-			// Log error but continue processing
-			continue
+	// A media entity's use applies to its files, so it waits until every
+	// file field has been read
+	for fieldName, rawValue := range entity {
+		if profile.Fields[fieldName].IR == "FileRole" {
+			applyFileRole(record, rawValue, opts)
 		}
+	}
 
-		// Only update priority if a value was actually set
-		if valueSet {
-			priorities[priorityKey] = fieldMapping.Priority
+	if len(unmapped) > 0 {
+		sort.Strings(unmapped)
+		record.SourceInfo = &hubv1.SourceInfo{
+			Format:         "drupal",
+			Profile:        profile.Name,
+			UnmappedFields: unmapped,
 		}
 	}
 
 	return record, nil
 }
 
+// isEmptyValue reports whether a raw Drupal field value carries no data
+// (null, an empty string, or an empty list/object).
+func isEmptyValue(raw json.RawMessage) bool {
+	switch strings.TrimSpace(string(raw)) {
+	case "", "null", `""`, "[]", "{}":
+		return true
+	}
+	return false
+}
+
 func processField(record *hubv1.Record, fieldName string, rawValue json.RawMessage, fieldMapping mapping.FieldMapping, opts *format.ParseOptions) (bool, error) {
 	base, subfield := mapping.IRFieldName(fieldMapping.IR)
 
@@ -121,7 +264,7 @@ func processField(record *hubv1.Record, fieldName string, rawValue json.RawMessa
 	case "Title":
 		val, _ := ExtractString(rawValue)
 		if val != "" {
-			record.Title = cleanText(val, opts)
+			record.Title = opts.CleanText(val)
 			return true, nil
 		}
 		return false, nil
@@ -129,7 +272,7 @@ func processField(record *hubv1.Record, fieldName string, rawValue json.RawMessa
 	case "AltTitle":
 		val, _ := ExtractString(rawValue)
 		if val != "" {
-			record.AltTitle = append(record.AltTitle, cleanText(val, opts))
+			record.AltTitle = append(record.AltTitle, opts.CleanText(val))
 			return true, nil
 		}
 		return false, nil
@@ -137,7 +280,7 @@ func processField(record *hubv1.Record, fieldName string, rawValue json.RawMessa
 	case "Abstract":
 		val, _ := ExtractFormattedText(rawValue, true)
 		if val != "" {
-			record.Abstract = cleanText(val, opts)
+			record.Abstract = opts.CleanText(val)
 			return true, nil
 		}
 		return false, nil
@@ -145,7 +288,7 @@ func processField(record *hubv1.Record, fieldName string, rawValue json.RawMessa
 	case "Description":
 		val, _ := ExtractFormattedText(rawValue, true)
 		if val != "" {
-			record.Description = cleanText(val, opts)
+			record.Description = opts.CleanText(val)
 			return true, nil
 		}
 		return false, nil
@@ -168,6 +311,27 @@ func processField(record *hubv1.Record, fieldName string, rawValue json.RawMessa
 	case "Rights":
 		return processRights(record, rawValue, fieldMapping, opts)
 
+	case "RightsHolder":
+		var val string
+		if fieldMapping.Resolve != "" {
+			val = resolveEntityRef(rawValue, fieldMapping, opts)
+		} else {
+			val, _ = ExtractString(rawValue)
+		}
+		if val != "" {
+			record.RightsHolder = opts.CleanText(val)
+			return true, nil
+		}
+		return false, nil
+
+	case "CopyrightStatement":
+		val, _ := ExtractFormattedText(rawValue, true)
+		if val != "" {
+			record.CopyrightStatement = opts.CleanText(val)
+			return true, nil
+		}
+		return false, nil
+
 	case "Subjects":
 		return processSubjects(record, rawValue, fieldMapping, opts)
 
@@ -183,7 +347,7 @@ func processField(record *hubv1.Record, fieldName string, rawValue json.RawMessa
 	case "Publisher":
 		val, _ := ExtractString(rawValue)
 		if val != "" {
-			record.Publisher = cleanText(val, opts)
+			record.Publisher = opts.CleanText(val)
 			return true, nil
 		}
 		return false, nil
@@ -191,7 +355,7 @@ func processField(record *hubv1.Record, fieldName string, rawValue json.RawMessa
 	case "PlacePublished":
 		val, _ := ExtractString(rawValue)
 		if val != "" {
-			record.PlacePublished = cleanText(val, opts)
+			record.PlacePublished = opts.CleanText(val)
 			return true, nil
 		}
 		return false, nil
@@ -199,7 +363,7 @@ func processField(record *hubv1.Record, fieldName string, rawValue json.RawMessa
 	case "PhysicalDesc":
 		val, _ := ExtractString(rawValue)
 		if val != "" {
-			record.PhysicalDesc = cleanText(val, opts)
+			record.PhysicalDesc = opts.CleanText(val)
 			return true, nil
 		}
 		return false, nil
@@ -208,7 +372,7 @@ func processField(record *hubv1.Record, fieldName string, rawValue json.RawMessa
 		vals, _ := ExtractStrings(rawValue)
 		if len(vals) > 0 {
 			for _, v := range vals {
-				record.Notes = append(record.Notes, cleanText(v, opts))
+				record.Notes = append(record.Notes, opts.CleanText(v))
 			}
 			return true, nil
 		}
@@ -217,7 +381,7 @@ func processField(record *hubv1.Record, fieldName string, rawValue json.RawMessa
 	case "TableOfContents":
 		val, _ := ExtractFormattedText(rawValue, true)
 		if val != "" {
-			record.TableOfContents = cleanText(val, opts)
+			record.TableOfContents = opts.CleanText(val)
 			return true, nil
 		}
 		return false, nil
@@ -225,7 +389,7 @@ func processField(record *hubv1.Record, fieldName string, rawValue json.RawMessa
 	case "Source":
 		val, _ := ExtractString(rawValue)
 		if val != "" {
-			record.Source = cleanText(val, opts)
+			record.Source = opts.CleanText(val)
 			return true, nil
 		}
 		return false, nil
@@ -241,6 +405,18 @@ func processField(record *hubv1.Record, fieldName string, rawValue json.RawMessa
 	case "DegreeInfo":
 		return processDegreeInfo(record, subfield, rawValue, fieldMapping, opts)
 
+	case "Holdings":
+		return processHoldings(record, subfield, rawValue, fieldMapping, opts)
+
+	case "ArchivalLocation":
+		return processArchivalLocation(record, subfield, rawValue, opts)
+
+	case "Files":
+		return processFiles(record, subfield, rawValue)
+
+	case "Distributions":
+		return processDistributions(record, rawValue)
+
 	case "Extra":
 		return processExtra(record, subfield, rawValue, fieldMapping, opts)
 	}
@@ -263,10 +439,15 @@ func processContributors(record *hubv1.Record, rawValue json.RawMessage, fieldMa
 			SourceId: ref.GetTargetID(),
 		}
 
-		// Get role from rel_type
+		// Get role from rel_type; thesis advisors and committee members
+		// get their degree role too
 		if ref.RelType != "" {
 			contrib.RoleCode = ref.RelType
 			contrib.Role = helpers.RelatorLabel(ref.RelType)
+			if code := helpers.RelatorCode(ref.RelType); code != "" {
+				contrib.RoleCode = "relators:" + code
+			}
+			contrib.DegreeRole = hub.DegreeRoleOf(contrib)
 		}
 
 		// Try to resolve the name from enriched data first
@@ -358,6 +539,11 @@ func processResourceType(record *hubv1.Record, rawValue json.RawMessage, fieldMa
 				}
 				return true, nil
 			}
+		} else if uri := resolveTermURI(ref.GetTargetID(), opts); uri != "" {
+			if rt, matched := resourceTypeFromGenreAuthorityURI(uri); matched {
+				record.ResourceType = &hubv1.ResourceType{Type: rt, Original: uri}
+				return true, nil
+			}
 		}
 	}
 
@@ -420,6 +606,13 @@ func processGenre(record *hubv1.Record, rawValue json.RawMessage, fieldMapping m
 					Vocabulary: link.Source,
 				}
 			}
+		} else if uri := resolveTermURI(ref.GetTargetID(), opts); uri != "" {
+			// A preloaded term export carries the same authority links
+			genre.Uri = uri
+			if rt, matched := resourceTypeFromGenreAuthorityURI(uri); matched &&
+				(record.ResourceType == nil || record.ResourceType.Type == hubv1.ResourceTypeValue_RESOURCE_TYPE_UNSPECIFIED) {
+				record.ResourceType = &hubv1.ResourceType{Type: rt, Original: uri}
+			}
 		}
 
 		record.Genres = append(record.Genres, genre)
@@ -673,6 +866,8 @@ func processSubjects(record *hubv1.Record, rawValue json.RawMessage, fieldMappin
 					if authorityVocab := authoritySourceToVocabulary(link.Source); authorityVocab != hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_UNSPECIFIED {
 						subject.Vocabulary = authorityVocab
 					}
+				} else {
+					subject.Uri = resolveTermURI(ref.GetTargetID(), opts)
 				}
 
 				record.Subjects = append(record.Subjects, subject)
@@ -686,7 +881,7 @@ func processSubjects(record *hubv1.Record, rawValue json.RawMessage, fieldMappin
 	vals, _ := ExtractStrings(rawValue)
 	for _, val := range vals {
 		record.Subjects = append(record.Subjects, &hubv1.Subject{
-			Value:      cleanText(val, opts),
+			Value:      opts.CleanText(val),
 			Vocabulary: vocab,
 		})
 		added = true
@@ -978,6 +1173,12 @@ func identifierTypeFromString(s string) hubv1.IdentifierType {
 		return hubv1.IdentifierType_IDENTIFIER_TYPE_UUID
 	case "isni":
 		return hubv1.IdentifierType_IDENTIFIER_TYPE_ISNI
+	case "ark":
+		return hubv1.IdentifierType_IDENTIFIER_TYPE_ARK
+	case "urn":
+		return hubv1.IdentifierType_IDENTIFIER_TYPE_URN
+	case "purl":
+		return hubv1.IdentifierType_IDENTIFIER_TYPE_PURL
 	case "report-number":
 		return hubv1.IdentifierType_IDENTIFIER_TYPE_REPORT_NUMBER
 	case "call-number":
@@ -1019,6 +1220,196 @@ func processDegreeInfo(record *hubv1.Record, subfield string, rawValue json.RawM
 	return true, nil
 }
 
+// processHoldings fills holding subfields (e.g. "Holdings.CallNumber").
+// Values are matched to holdings by position, so parallel call number and
+// location fields describe the same copy. Without a subfield the value is
+// a call number.
+func processHoldings(record *hubv1.Record, subfield string, rawValue json.RawMessage, fieldMapping mapping.FieldMapping, opts *format.ParseOptions) (bool, error) {
+	var vals []string
+	if fieldMapping.Resolve != "" {
+		if val := resolveEntityRef(rawValue, fieldMapping, opts); val != "" {
+			vals = []string{val}
+		}
+	} else {
+		vals, _ = ExtractStrings(rawValue)
+	}
+
+	found := false
+	for i, val := range vals {
+		val = opts.CleanText(val)
+		if val == "" {
+			continue
+		}
+		for len(record.Holdings) <= i {
+			record.Holdings = append(record.Holdings, &hubv1.Holding{})
+		}
+		h := record.Holdings[i]
+		switch subfield {
+		case "Institution":
+			h.Institution = val
+		case "Sublocation":
+			h.Sublocation = val
+		case "CallNumberScheme":
+			h.CallNumberScheme = val
+		default:
+			h.CallNumber = val
+		}
+		found = true
+	}
+	return found, nil
+}
+
+// processArchivalLocation fills the archival location. Without a subfield
+// the value is a combined string such as "Box 3, Folder 12".
+func processArchivalLocation(record *hubv1.Record, subfield string, rawValue json.RawMessage, opts *format.ParseOptions) (bool, error) {
+	val, _ := ExtractString(rawValue)
+	val = opts.CleanText(val)
+	if val == "" {
+		return false, nil
+	}
+
+	if record.ArchivalLocation == nil {
+		record.ArchivalLocation = &hubv1.ArchivalLocation{}
+	}
+	loc := record.ArchivalLocation
+
+	switch subfield {
+	case "Collection":
+		loc.Collection = val
+	case "Series":
+		loc.Series = val
+	case "Box":
+		loc.Box = val
+	case "Folder":
+		loc.Folder = val
+	default:
+		parsed, _ := hub.ParseBoxFolder(val)
+		loc.Box = parsed.Box
+		loc.Folder = parsed.Folder
+	}
+
+	return true, nil
+}
+
+// checksumFields are the file entity base fields added by the Drupal filehash module.
+var checksumFields = []string{"md5", "sha1", "sha256", "sha512"}
+
+// processFiles converts file/image field references into hub files. File
+// entity details (name, MIME type, size, checksums) come from enriched
+// _entity data when available. The optional subfield sets the file role
+// (e.g. "Files.thumbnail").
+func processFiles(record *hubv1.Record, role string, rawValue json.RawMessage) (bool, error) {
+	files, err := extractFiles(rawValue, role)
+	if err != nil {
+		return false, err
+	}
+	record.Files = append(record.Files, files...)
+	return len(files) > 0, nil
+}
+
+// applyFileRole sets the role of files without one from an Islandora Media
+// Use term, identified by its PCDM use URI or, failing that, its name.
+func applyFileRole(record *hubv1.Record, rawValue json.RawMessage, opts *format.ParseOptions) {
+	refs, err := ExtractEntityRefs(rawValue)
+	if err != nil || len(refs) == 0 {
+		return
+	}
+	ref := refs[0]
+	var uses []string
+	if uri, ok := ref.GetExternalURI(); ok {
+		uses = append(uses, uri)
+	}
+	if name, ok := ref.GetResolvedName(); ok {
+		uses = append(uses, name)
+	}
+	if opts.TaxonomyResolver != nil {
+		if name, ok := opts.TaxonomyResolver.Resolve(ref.GetTargetID(), ""); ok {
+			uses = append(uses, name)
+		}
+	}
+	uses = append(uses, resolveTermURI(ref.GetTargetID(), opts))
+
+	for _, use := range uses {
+		if role := hub.FileRoleFromMediaUse(use); role != "" {
+			for _, f := range record.Files {
+				if f.Role == "" {
+					f.Role = role
+				}
+			}
+			return
+		}
+	}
+}
+
+// processDistributions converts dataset file field references (CSV, XLSX,
+// README, ...) into hub distributions.
+func processDistributions(record *hubv1.Record, rawValue json.RawMessage) (bool, error) {
+	files, err := extractFiles(rawValue, "")
+	if err != nil {
+		return false, err
+	}
+	for _, f := range files {
+		record.Distributions = append(record.Distributions, hub.NewDistributionFromFile(f))
+	}
+	return len(files) > 0, nil
+}
+
+// extractFiles reads file entity references, skipping ones with neither a
+// URL nor a filename.
+func extractFiles(rawValue json.RawMessage, role string) ([]*hubv1.File, error) {
+	refs, err := ExtractEntityRefs(rawValue)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []*hubv1.File
+	for _, ref := range refs {
+		file := &hubv1.File{
+			Path: ref.TargetURL,
+			Role: role,
+		}
+
+		if len(ref.Entity) > 0 {
+			var entity DrupalEntity
+			if err := json.Unmarshal(ref.Entity, &entity); err == nil {
+				file.Name, _ = ExtractString(entity["filename"])
+				file.MimeType, _ = ExtractString(entity["filemime"])
+				if size, _ := ExtractInt(entity["filesize"]); size > 0 {
+					file.SizeBytes = int64(size)
+				}
+				if file.Path == "" {
+					file.Path = fileEntityURL(entity["uri"])
+				}
+				for _, algo := range checksumFields {
+					if v, _ := ExtractString(entity[algo]); v != "" {
+						hub.SetChecksum(file, algo, v)
+					}
+				}
+			}
+		}
+
+		if file.Path == "" && file.Name == "" {
+			continue
+		}
+		files = append(files, file)
+	}
+
+	return files, nil
+}
+
+// fileEntityURL returns the public URL of a file entity's uri field,
+// falling back to the stream wrapper URI (e.g. "public://...").
+func fileEntityURL(raw json.RawMessage) string {
+	links, err := ExtractLinks(raw)
+	if err != nil || len(links) == 0 {
+		return ""
+	}
+	if links[0].TargetURL != "" {
+		return links[0].TargetURL
+	}
+	return value.Text(links[0].Value)
+}
+
 func processExtra(record *hubv1.Record, subfield string, rawValue json.RawMessage, fieldMapping mapping.FieldMapping, opts *format.ParseOptions) (bool, error) {
 	// Try to extract as various types
 	if val, err := ExtractString(rawValue); err == nil && val != "" {
@@ -1048,6 +1439,17 @@ func processExtra(record *hubv1.Record, subfield string, rawValue json.RawMessag
 
 // Helper functions
 
+// resolveTermURI returns the authority URI a preloaded taxonomy export
+// records for a term, or "" when the resolver has none.
+func resolveTermURI(termID string, opts *format.ParseOptions) string {
+	if r, ok := opts.TaxonomyResolver.(format.TaxonomyURIResolver); ok {
+		if uri, ok := r.ResolveURI(termID); ok {
+			return uri
+		}
+	}
+	return ""
+}
+
 func resolveEntityRef(rawValue json.RawMessage, fieldMapping mapping.FieldMapping, opts *format.ParseOptions) string {
 	refs, err := ExtractEntityRefs(rawValue)
 	if err != nil || len(refs) == 0 {
@@ -1078,26 +1480,25 @@ func resolveEntityRef(rawValue json.RawMessage, fieldMapping mapping.FieldMappin
 	return targetID
 }
 
-func cleanText(s string, opts *format.ParseOptions) string {
-	if opts.StripHTML {
-		return helpers.CleanText(s)
-	}
-	return strings.TrimSpace(s)
-}
-
-func trimBOM(data []byte) []byte {
-	// UTF-8 BOM
-	if len(data) >= 3 && data[0] == 0xEF && data[1] == 0xBB && data[2] == 0xBF {
-		return data[3:]
-	}
-	return data
-}
+// utf8BOM is the byte order mark some Drupal exports start with.
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
 
-func skipWhitespace(data []byte) []byte {
-	for len(data) > 0 && (data[0] == ' ' || data[0] == '\t' || data[0] == '\n' || data[0] == '\r') {
-		data = data[1:]
+// peekNonSpace skips leading JSON whitespace and returns the next byte
+// without consuming it, along with how many bytes it skipped.
+func peekNonSpace(br *bufio.Reader) (b byte, skipped int, err error) {
+	for {
+		p, err := br.Peek(1)
+		if err != nil {
+			return 0, skipped, err
+		}
+		switch p[0] {
+		case ' ', '\t', '\n', '\r':
+			_, _ = br.Discard(1)
+			skipped++
+		default:
+			return p[0], skipped, nil
+		}
 	}
-	return data
 }
 
 func defaultProfile() *mapping.Profile {
@@ -1130,6 +1531,15 @@ func defaultProfile() *mapping.Profile {
 			"field_degree_name":       {IR: "DegreeInfo.DegreeName"},
 			"field_degree_level":      {IR: "DegreeInfo.DegreeLevel"},
 			"field_department_name":   {IR: "DegreeInfo.Department", Resolve: "taxonomy_term"},
+			"field_call_number":       {IR: "Holdings.CallNumber"},
+			"field_physical_location": {IR: "Holdings.Institution"},
+			"field_box_folder":        {IR: "ArchivalLocation"},
+			"field_media_file":        {IR: "Files"},
+			"field_media_image":       {IR: "Files"},
+			"field_media_document":    {IR: "Files"},
+			"field_media_audio_file":  {IR: "Files"},
+			"field_media_video_file":  {IR: "Files"},
+			"field_media_use":         {IR: "FileRole", Resolve: "taxonomy_term"},
 			"nid":                     {IR: "Extra.nid"},
 			"uuid":                    {IR: "Extra.uuid"},
 			"created":                 {IR: "Extra.created"},
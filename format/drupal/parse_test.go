@@ -1,6 +1,10 @@
 package drupal
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"strings"
 	"testing"
 
@@ -218,3 +222,418 @@ func TestDefaultProfile_FieldKeywordsResolvesTermLabels(t *testing.T) {
 		t.Fatalf("subjects[1].Value = %q, want %q", r.Subjects[1].Value, "charge transport")
 	}
 }
+
+func TestParseRecordsUnmappedFieldsInSourceInfo(t *testing.T) {
+	input := `{
+		"title": [{"value": "Coverage test"}],
+		"field_new_thing": [{"value": "something"}],
+		"field_empty_thing": [],
+		"field_null_thing": null
+	}`
+
+	f := &Format{}
+	records, err := f.Parse(strings.NewReader(input), format.NewParseOptions())
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("expected 1 record, got %d", len(records))
+	}
+
+	got := records[0].GetSourceInfo().GetUnmappedFields()
+	if len(got) != 1 || got[0] != "field_new_thing" {
+		t.Fatalf("UnmappedFields = %v, want [field_new_thing]", got)
+	}
+	if records[0].SourceInfo.Format != "drupal" {
+		t.Errorf("SourceInfo.Format = %q, want %q", records[0].SourceInfo.Format, "drupal")
+	}
+}
+
+func TestPreserveSourceRoundTrip(t *testing.T) {
+	input := `{
+		"nid": [{"value": 42}],
+		"title": [{"value": "Preserved"}],
+		"field_language": [{"target_id": "eng"}],
+		"field_new_thing": [{"value": "something", "format": "basic_html"}]
+	}`
+
+	roundTrip := func(preserve bool) map[string]any {
+		t.Helper()
+		opts := format.NewParseOptions()
+		opts.PreserveSource = preserve
+		f := &Format{}
+		records, err := f.Parse(strings.NewReader(input), opts)
+		if err != nil {
+			t.Fatalf("Parse failed: %v", err)
+		}
+		// Hub edits made after parsing still reach the output
+		records[0].Language = "fre"
+
+		var buf bytes.Buffer
+		if err := f.Serialize(&buf, records, nil); err != nil {
+			t.Fatalf("Serialize failed: %v", err)
+		}
+		var entity map[string]any
+		if err := json.Unmarshal(buf.Bytes(), &entity); err != nil {
+			t.Fatalf("decoding output: %v", err)
+		}
+		return entity
+	}
+
+	entity := roundTrip(true)
+	if got := fmt.Sprint(entity["field_new_thing"]); got != "[map[format:basic_html value:something]]" {
+		t.Errorf("field_new_thing = %s, want the source value replayed", got)
+	}
+	if got := fmt.Sprint(entity["nid"]); got != "[map[value:42]]" {
+		t.Errorf("nid = %s, want the source value replayed", got)
+	}
+	if got := fmt.Sprint(entity["field_language"]); got != "[map[target_id:fre]]" {
+		t.Errorf("field_language = %s, want the hub value", got)
+	}
+
+	if _, ok := roundTrip(false)["field_new_thing"]; ok {
+		t.Error("unmapped fields should only be replayed with PreserveSource")
+	}
+}
+
+func TestParseProfileTransforms(t *testing.T) {
+	input := `{
+		"title": [{"value": "Digitized: Annual report"}],
+		"field_keywords": [{"value": "Budget; FINANCE"}],
+		"field_volume": [{"value": "12"}],
+		"field_issue": [{"value": "3"}]
+	}`
+	profile := &mapping.Profile{Name: "cleanup", Fields: map[string]mapping.FieldMapping{
+		"title":          {IR: "Title", Transform: mapping.Transforms{"s/^Digitized: //"}},
+		"field_keywords": {IR: "Subjects", Vocabulary: "keywords", Transform: mapping.Transforms{"split:;", "lowercase"}},
+		"field_citation": {IR: "Notes", Template: "Vol. {{.field_volume}}({{.field_issue}})"},
+	}}
+
+	opts := format.NewParseOptions()
+	opts.Profile = profile
+	records, err := (&Format{}).Parse(strings.NewReader(input), opts)
+	if err != nil || len(records) != 1 {
+		t.Fatalf("Parse: %v, %v", records, err)
+	}
+	r := records[0]
+	if r.Title != "Annual report" {
+		t.Errorf("title: got %q", r.Title)
+	}
+	var keywords []string
+	for _, s := range r.Subjects {
+		keywords = append(keywords, s.Value)
+	}
+	if strings.Join(keywords, ",") != "budget,finance" {
+		t.Errorf("keywords: got %v", keywords)
+	}
+	if len(r.Notes) != 1 || r.Notes[0] != "Vol. 12(3)" {
+		t.Errorf("notes: got %v", r.Notes)
+	}
+}
+
+func TestParseConditionalMapping(t *testing.T) {
+	input := `{
+		"title": [{"value": "Survey data"}],
+		"field_identifier": [{"value": "10.1234/abcd"}, {"value": "lehigh-42"}, {"value": "TEMP"}],
+		"field_genre": [
+			{"target_id": 1, "_entity": {"name": [{"value": "Dataset"}]}},
+			{"target_id": 2, "_entity": {"name": [{"value": "Oral histories"}]}}
+		]
+	}`
+	profile, err := mapping.LoadProfileFromString(`
+name: conditional
+fields:
+  field_identifier:
+    ir: Identifiers
+    type: local
+    rules:
+      - when: {matches: "^10\\."}
+        type: doi
+      - when: {equals: TEMP}
+        skip: true
+  field_genre:
+    ir: Genre
+    resolve: taxonomy_term
+    rules:
+      - when: {in: [Dataset, Software]}
+        ir: ResourceType
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opts := format.NewParseOptions()
+	opts.Profile = profile
+	records, err := (&Format{}).Parse(strings.NewReader(input), opts)
+	if err != nil || len(records) != 1 {
+		t.Fatalf("Parse: %v, %v", records, err)
+	}
+	r := records[0]
+
+	var ids []string
+	for _, id := range r.Identifiers {
+		ids = append(ids, id.Type.String()+"="+id.Value)
+	}
+	if want := "IDENTIFIER_TYPE_DOI=10.1234/abcd,IDENTIFIER_TYPE_LOCAL=lehigh-42"; strings.Join(ids, ",") != want {
+		t.Errorf("identifiers: got %v, want %s", ids, want)
+	}
+
+	if r.GetResourceType().GetType() != hubv1.ResourceTypeValue_RESOURCE_TYPE_DATASET {
+		t.Errorf("resource type: got %v, want dataset", r.GetResourceType())
+	}
+	if len(r.Genres) != 1 || r.Genres[0].Value != "Oral histories" {
+		t.Errorf("genres: got %v, want only Oral histories", r.Genres)
+	}
+}
+
+func TestParseDatasetFilesAsDistributions(t *testing.T) {
+	input := `{
+		"title": [{"value": "Stream gauge readings"}],
+		"field_dataset_files": [
+			{
+				"target_id": 11,
+				"target_type": "file",
+				"url": "https://example.com/files/readings.csv",
+				"_entity": {
+					"filename": [{"value": "readings.csv"}],
+					"filemime": [{"value": "text/csv"}],
+					"filesize": [{"value": 2048}],
+					"sha256": [{"value": "ABC123"}]
+				}
+			},
+			{
+				"target_id": 12,
+				"target_type": "file",
+				"url": "https://example.com/files/README.txt"
+			}
+		]
+	}`
+
+	opts := format.NewParseOptions()
+	opts.Profile = &mapping.Profile{
+		Fields: map[string]mapping.FieldMapping{
+			"field_dataset_files": {IR: "Distributions"},
+		},
+	}
+	records, err := (&Format{}).Parse(strings.NewReader(input), opts)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	dists := records[0].Distributions
+	if len(dists) != 2 {
+		t.Fatalf("distributions count = %d, want 2", len(dists))
+	}
+	if dists[0].Url != "https://example.com/files/readings.csv" || dists[0].Format != "text/csv" || dists[0].SizeBytes != 2048 {
+		t.Errorf("distributions[0] = %v", dists[0])
+	}
+	if dists[0].Checksums[0].Value != "abc123" {
+		t.Errorf("sha256 = %q, want lowercase abc123", dists[0].Checksums[0].Value)
+	}
+	if len(records[0].Files) != 0 {
+		t.Errorf("dataset files should not also become hub files, got %d", len(records[0].Files))
+	}
+}
+
+// An Islandora media entity's Media Use term sets the role of its file.
+func TestParseMediaUse(t *testing.T) {
+	input := `[{
+		"name": [{"value": "page.tif"}],
+		"field_media_use": [{
+			"target_id": 17,
+			"target_type": "taxonomy_term",
+			"_entity": {
+				"name": [{"value": "Service File"}],
+				"field_external_uri": [{"uri": "http://pcdm.org/use#ServiceFile"}]
+			}
+		}],
+		"field_media_image": [{
+			"target_id": 5,
+			"target_type": "file",
+			"url": "https://example.com/files/page.jpg",
+			"_entity": {"filemime": [{"value": "image/jpeg"}]}
+		}]
+	}, {
+		"field_media_file": [{"target_id": 6, "target_type": "file", "url": "https://example.com/files/page.tif"}],
+		"field_media_use": [{"target_id": 18, "target_type": "taxonomy_term", "_entity": {"name": [{"value": "Original File"}]}}]
+	}]`
+
+	records, err := (&Format{}).Parse(strings.NewReader(input), format.NewParseOptions())
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	for i, want := range []string{"service", "original"} {
+		files := records[i].Files
+		if len(files) != 1 || files[0].Role != want {
+			t.Errorf("record %d files = %v, want one %s file", i, files, want)
+		}
+	}
+	if records[0].Files[0].MimeType != "image/jpeg" {
+		t.Errorf("mime type = %q", records[0].Files[0].MimeType)
+	}
+}
+
+func TestDefaultProfile_HoldingsAndBoxFolder(t *testing.T) {
+	input := `{
+		"title": [{"value": "Letter to the Board of Trustees"}],
+		"field_identifier": [{"value": "ms0012-0034", "attr0": "local"}],
+		"field_call_number": [{"value": "LD3171 .L5 1890"}, {"value": "LD3171 .L5 1890 c.2"}],
+		"field_physical_location": [{"value": "Special Collections"}],
+		"field_box_folder": [{"value": "Box 3, Folder 12"}]
+	}`
+
+	records, err := (&Format{}).Parse(strings.NewReader(input), format.NewParseOptions())
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	r := records[0]
+	if len(r.Holdings) != 2 {
+		t.Fatalf("holdings count = %d, want 2", len(r.Holdings))
+	}
+	if r.Holdings[0].CallNumber != "LD3171 .L5 1890" || r.Holdings[0].Institution != "Special Collections" {
+		t.Errorf("holdings[0] = %v", r.Holdings[0])
+	}
+	if r.Holdings[1].CallNumber != "LD3171 .L5 1890 c.2" {
+		t.Errorf("holdings[1].CallNumber = %q", r.Holdings[1].CallNumber)
+	}
+	for _, id := range r.Identifiers {
+		if strings.HasPrefix(id.Value, "LD3171") {
+			t.Errorf("call number leaked into identifiers: %v", id)
+		}
+	}
+	if loc := r.ArchivalLocation; loc == nil || loc.Box != "3" || loc.Folder != "12" {
+		t.Errorf("ArchivalLocation = %v, want box 3 folder 12", loc)
+	}
+}
+
+func TestParseStream(t *testing.T) {
+	input := "\xef\xbb\xbf  [\n" +
+		`{"title": [{"value": "First"}]},` +
+		`{"title": [{"value": "Second"}]},` +
+		`{"title": [{"value": "Third"}]}` + "\n]\n"
+
+	var titles []string
+	stop := errors.New("stop")
+	err := (&Format{}).ParseStream(strings.NewReader(input), nil, func(r *hubv1.Record) error {
+		titles = append(titles, r.Title)
+		if len(titles) == 2 {
+			return stop
+		}
+		return nil
+	})
+	if !errors.Is(err, stop) {
+		t.Fatalf("expected the callback's error, got %v", err)
+	}
+	if strings.Join(titles, ",") != "First,Second" {
+		t.Errorf("titles: got %v", titles)
+	}
+
+	records, err := (&Format{}).Parse(strings.NewReader(input), nil)
+	if err != nil || len(records) != 3 || records[2].Title != "Third" {
+		t.Errorf("Parse should return every entity: %v, %v", records, err)
+	}
+}
+
+func TestParseStreamRejectsMalformedJSON(t *testing.T) {
+	for name, input := range map[string]string{
+		"unterminated array": `[{"title": [{"value": "First"}]}`,
+		"trailing data":      `{"title": [{"value": "First"}]} {}`,
+		"not JSON":           `title,date`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := (&Format{}).Parse(strings.NewReader(input), nil); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestParseWorkersKeepOrder(t *testing.T) {
+	var entities, want []string
+	for i := range 200 {
+		title := fmt.Sprintf("Record %d", i)
+		want = append(want, title)
+		entities = append(entities, fmt.Sprintf(`{"title": [{"value": %q}]}`, title))
+	}
+	input := "[" + strings.Join(entities, ",") + "]"
+
+	opts := format.NewParseOptions()
+	opts.Workers = 8
+	records, err := (&Format{}).Parse(strings.NewReader(input), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, r := range records {
+		got = append(got, r.Title)
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("titles out of order: %v", got)
+	}
+}
+
+func TestParseSkipsBadEntities(t *testing.T) {
+	input := `[{"title": [{"value": "First"}]}, "oops", {"title": [{"value": "Third"}]}]`
+
+	var skipped []*format.RecordError
+	opts := format.NewParseOptions()
+	opts.OnRecordError = func(e *format.RecordError) error {
+		skipped = append(skipped, e)
+		return nil
+	}
+	records, err := (&Format{}).Parse(strings.NewReader(input), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 || records[1].Title != "Third" {
+		t.Errorf("expected the good entities on either side, got %v", records)
+	}
+	if len(skipped) != 1 || skipped[0].Index != 1 || skipped[0].Offset != int64(strings.Index(input, `"oops"`)) {
+		t.Fatalf("expected entity 1 reported at its offset, got %+v", skipped)
+	}
+
+	// Without a callback the bad entity stops parsing
+	if _, err := (&Format{}).Parse(strings.NewReader(input), nil); err == nil || !strings.Contains(err.Error(), "record 1") {
+		t.Errorf("expected a record 1 error, got %v", err)
+	}
+}
+
+func TestParseLinkedAgentDegreeRoles(t *testing.T) {
+	input := `{
+		"title": [{"value": "Sediment Transport in Urban Streams"}],
+		"field_linked_agent": [
+			{"target_id": 1, "rel_type": "relators:aut", "_entity": {"name": [{"value": "Qin, Tian"}]}},
+			{"target_id": 2, "rel_type": "relators:ths", "_entity": {"name": [{"value": "Huang, Wei-Min"}]}},
+			{"target_id": 3, "rel_type": "relators:dgc", "_entity": {"name": [{"value": "Okafor, Ngozi"}]}}
+		]
+	}`
+
+	p := &mapping.Profile{
+		Name:   "test",
+		Format: "drupal",
+		Fields: map[string]mapping.FieldMapping{
+			"title":              {IR: "Title"},
+			"field_linked_agent": {IR: "Contributors", Type: "typed_relation", RoleField: "rel_type", Resolve: "taxonomy_term"},
+		},
+	}
+
+	records, err := (&Format{}).Parse(strings.NewReader(input), &format.ParseOptions{Profile: p})
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	contributors := records[0].Contributors
+	if len(contributors) != 3 {
+		t.Fatalf("expected 3 contributors, got %v", contributors)
+	}
+	want := []hubv1.DegreeRole{
+		hubv1.DegreeRole_DEGREE_ROLE_UNSPECIFIED,
+		hubv1.DegreeRole_DEGREE_ROLE_ADVISOR,
+		hubv1.DegreeRole_DEGREE_ROLE_COMMITTEE_MEMBER,
+	}
+	for i, c := range contributors {
+		if c.DegreeRole != want[i] {
+			t.Errorf("%s: DegreeRole = %v, want %v", c.Name, c.DegreeRole, want[i])
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package drupal
+
+import (
+	"encoding/json"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	"github.com/lehigh-university-libraries/crosswalk/mapping"
+	"github.com/lehigh-university-libraries/crosswalk/value"
+)
+
+// fieldRoute is the part of a field's items that maps one way.
+type fieldRoute struct {
+	mapping mapping.FieldMapping
+	raw     json.RawMessage
+}
+
+// hasRules reports whether any of the profile's fields map conditionally.
+func hasRules(profile *mapping.Profile) bool {
+	for _, m := range profile.Fields {
+		if len(m.Rules) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// routeField splits a field's items by the mapping each is routed to by
+// the field's rules, keeping their order. Items a rule skips are dropped.
+// A field without rules is one route.
+func routeField(name string, raw json.RawMessage, m mapping.FieldMapping, fields map[string]string, opts *format.ParseOptions) []fieldRoute {
+	if len(m.Rules) == 0 {
+		return []fieldRoute{{m, raw}}
+	}
+	var items []json.RawMessage
+	if err := json.Unmarshal(raw, &items); err != nil {
+		routed, ok := m.Route(name, value.FromArrayText(raw), fields)
+		if !ok {
+			return nil
+		}
+		return []fieldRoute{{routed, raw}}
+	}
+
+	type group struct {
+		mapping mapping.FieldMapping
+		items   []json.RawMessage
+	}
+	var groups []*group
+	for _, item := range items {
+		routed, ok := m.Route(name, itemText(item, m, opts), fields)
+		if !ok {
+			continue
+		}
+		var g *group
+		for _, existing := range groups {
+			if sameRoute(existing.mapping, routed) {
+				g = existing
+				break
+			}
+		}
+		if g == nil {
+			g = &group{mapping: routed}
+			groups = append(groups, g)
+		}
+		g.items = append(g.items, item)
+	}
+
+	routes := make([]fieldRoute, 0, len(groups))
+	for _, g := range groups {
+		raw, err := json.Marshal(g.items)
+		if err != nil {
+			continue
+		}
+		routes = append(routes, fieldRoute{g.mapping, raw})
+	}
+	return routes
+}
+
+// itemText is the text a rule tests for one field item: its value, or for
+// a reference the referenced entity's name when known, else its ID.
+func itemText(item json.RawMessage, m mapping.FieldMapping, opts *format.ParseOptions) string {
+	var fv FieldValue
+	if err := json.Unmarshal(item, &fv); err != nil {
+		return ""
+	}
+	if text := value.Text(fv.Value); text != "" {
+		return text
+	}
+	if name, ok := fv.GetResolvedName(); ok {
+		return name
+	}
+	id := fv.GetTargetID()
+	if opts.TaxonomyResolver != nil && id != "" {
+		if m.Resolve == "node" {
+			if name, ok := opts.TaxonomyResolver.ResolveNode(id); ok {
+				return name
+			}
+		} else if name, ok := opts.TaxonomyResolver.Resolve(id, ""); ok {
+			return name
+		}
+	}
+	if fv.URI != "" {
+		return fv.URI
+	}
+	return id
+}
+
+func sameRoute(a, b mapping.FieldMapping) bool {
+	return a.IR == b.IR && a.Type == b.Type && a.DateType == b.DateType &&
+		a.RelationType == b.RelationType && a.Vocabulary == b.Vocabulary
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"sort"
 
 	"github.com/lehigh-university-libraries/crosswalk/format"
 	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
@@ -44,22 +45,42 @@ func (f *Format) Serialize(w io.Writer, records []*hubv1.Record, opts *format.Se
 	return encoder.Encode(entities)
 }
 
-func recordToEntity(record *hubv1.Record, profile *mapping.Profile, opts *format.SerializeOptions) (map[string]any, error) {
-	entity := make(map[string]any)
+// sourceField is a profile field and the mapping that carries a hub field
+// into it.
+type sourceField struct {
+	SourceField string
+	Mapping     mapping.FieldMapping
+}
 
-	// Build reverse mapping: hub field -> source fields
-	irToSource := make(map[string][]struct {
-		SourceField string
-		Mapping     mapping.FieldMapping
+// sourceFieldsByIR maps each hub field to the profile fields that carry it.
+// Where several fields map to one hub field, the first is the highest
+// priority, then the first by name, so output doesn't depend on map order.
+func sourceFieldsByIR(profile *mapping.Profile) map[string][]sourceField {
+	sourceNames := make([]string, 0, len(profile.Fields))
+	for source := range profile.Fields {
+		sourceNames = append(sourceNames, source)
+	}
+	sort.Slice(sourceNames, func(i, j int) bool {
+		pi, pj := profile.Fields[sourceNames[i]].Priority, profile.Fields[sourceNames[j]].Priority
+		if pi != pj {
+			return pi > pj
+		}
+		return sourceNames[i] < sourceNames[j]
 	})
-
-	for source, fieldMapping := range profile.Fields {
+	irToSource := make(map[string][]sourceField)
+	for _, source := range sourceNames {
+		fieldMapping := profile.Fields[source]
 		base, _ := mapping.IRFieldName(fieldMapping.IR)
-		irToSource[base] = append(irToSource[base], struct {
-			SourceField string
-			Mapping     mapping.FieldMapping
-		}{source, fieldMapping})
+		irToSource[base] = append(irToSource[base], sourceField{source, fieldMapping})
 	}
+	return irToSource
+}
+
+func recordToEntity(record *hubv1.Record, profile *mapping.Profile, opts *format.SerializeOptions) (map[string]any, error) {
+	entity := make(map[string]any)
+
+	// Build reverse mapping: hub field -> source fields
+	irToSource := sourceFieldsByIR(profile)
 
 	// Title
 	if record.Title != "" {
@@ -102,10 +123,15 @@ func recordToEntity(record *hubv1.Record, profile *mapping.Profile, opts *format
 				if c.SourceId != "" {
 					contrib["target_id"] = c.SourceId
 				}
-				if c.RoleCode != "" {
+				switch {
+				case c.RoleCode != "":
 					contrib["rel_type"] = c.RoleCode
-				} else if c.Role != "" {
+				case c.Role != "":
 					contrib["rel_type"] = "relators:" + helpers.RoleToCode(c.Role)
+				case c.DegreeRole == hubv1.DegreeRole_DEGREE_ROLE_ADVISOR:
+					contrib["rel_type"] = "relators:ths"
+				case c.DegreeRole == hubv1.DegreeRole_DEGREE_ROLE_COMMITTEE_MEMBER:
+					contrib["rel_type"] = "relators:dgc"
 				}
 				contrib["target_type"] = "taxonomy_term"
 				contribs = append(contribs, contrib)
@@ -309,5 +335,30 @@ func recordToEntity(record *hubv1.Record, profile *mapping.Profile, opts *format
 		}
 	}
 
+	if err := replaySource(entity, record); err != nil {
+		return nil, err
+	}
+
 	return entity, nil
 }
+
+// replaySource copies back the fields of the record's preserved Drupal
+// entity that the serializer didn't write: unmapped fields, and fields
+// whose hub values this serializer doesn't write or writes to another
+// field. Values from the hub record win where both exist.
+func replaySource(entity map[string]any, record *hubv1.Record) error {
+	raw, err := format.PreservedSource(record, "drupal")
+	if err != nil || raw == nil {
+		return err
+	}
+	var source map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &source); err != nil {
+		return fmt.Errorf("decoding preserved entity: %w", err)
+	}
+	for name, value := range source {
+		if _, ok := entity[name]; !ok {
+			entity[name] = value
+		}
+	}
+	return nil
+}
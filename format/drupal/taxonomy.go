@@ -1,9 +1,15 @@
 package drupal
 
 import (
+	"bytes"
+	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"strings"
 )
 
 // TaxonomyStore holds taxonomy term mappings for resolution.
@@ -16,6 +22,9 @@ type TaxonomyStore struct {
 
 	// vocabularies maps term IDs to vocabulary names
 	vocabularies map[string]string
+
+	// uris maps term IDs to authority URIs
+	uris map[string]string
 }
 
 // NewTaxonomyStore creates an empty taxonomy store.
@@ -24,17 +33,25 @@ func NewTaxonomyStore() *TaxonomyStore {
 		terms:        make(map[string]string),
 		nodes:        make(map[string]string),
 		vocabularies: make(map[string]string),
+		uris:         make(map[string]string),
 	}
 }
 
-// LoadTaxonomyFile loads taxonomy terms from a JSON file.
+// LoadTaxonomyFile loads taxonomy terms from a JSON or CSV file.
 // The file can be in various formats - we try to detect and parse it.
+// A full term export (tid, vid, name, uri) lets a large conversion resolve
+// every reference offline, without --base-url.
 func LoadTaxonomyFile(path string) (*TaxonomyStore, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("reading taxonomy file: %w", err)
 	}
 
+	trimmed := bytes.TrimSpace(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")))
+	if strings.EqualFold(filepath.Ext(path), ".csv") || (len(trimmed) > 0 && trimmed[0] != '[' && trimmed[0] != '{') {
+		return LoadTaxonomyCSV(bytes.NewReader(data))
+	}
+
 	store := NewTaxonomyStore()
 
 	// Try parsing as array of objects with tid and name
@@ -47,6 +64,8 @@ func LoadTaxonomyFile(path string) (*TaxonomyStore, error) {
 		Label      string `json:"label"`
 		Vocabulary string `json:"vocabulary"`
 		Vid        string `json:"vid"`
+		URI        string `json:"uri"`
+		URL        string `json:"url"`
 		Type       string `json:"type"` // "taxonomy_term" or "node"
 	}
 
@@ -66,6 +85,9 @@ func LoadTaxonomyFile(path string) (*TaxonomyStore, error) {
 				if vocab := extractName(item.Vocabulary, item.Vid, ""); vocab != "" {
 					store.vocabularies[id] = vocab
 				}
+				if uri := extractName(item.URI, item.URL, ""); uri != "" {
+					store.uris[id] = uri
+				}
 			}
 		}
 		return store, nil
@@ -98,6 +120,76 @@ func LoadTaxonomyFile(path string) (*TaxonomyStore, error) {
 	return nil, fmt.Errorf("could not parse taxonomy file format")
 }
 
+// taxonomyColumns maps the header names a term export may use to the
+// store's fields; the first column found for each field wins.
+var taxonomyColumns = map[string][]string{
+	"id":    {"tid", "term_id", "id", "nid"},
+	"name":  {"name", "label", "title"},
+	"vocab": {"vid", "vocabulary"},
+	"uri":   {"uri", "url", "authority_uri", "field_authority_link"},
+	"type":  {"type", "entity_type"},
+}
+
+// LoadTaxonomyCSV loads taxonomy terms from a CSV export with a header
+// row, e.g. "tid,vid,name,uri". Rows whose type column says "node" are
+// loaded as node titles.
+func LoadTaxonomyCSV(r io.Reader) (*TaxonomyStore, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading taxonomy CSV header: %w", err)
+	}
+
+	col := make(map[string]int)
+	for field, names := range taxonomyColumns {
+		col[field] = -1
+	search:
+		for _, name := range names {
+			for i, h := range header {
+				if strings.EqualFold(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")), name) {
+					col[field] = i
+					break search
+				}
+			}
+		}
+	}
+	if col["id"] < 0 || col["name"] < 0 {
+		return nil, fmt.Errorf("taxonomy CSV needs an ID column (tid) and a name column (name), got %s", strings.Join(header, ","))
+	}
+
+	get := func(row []string, field string) string {
+		if i := col[field]; i >= 0 && i < len(row) {
+			return strings.TrimSpace(row[i])
+		}
+		return ""
+	}
+
+	store := NewTaxonomyStore()
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading taxonomy CSV: %w", err)
+		}
+		id, name := get(row, "id"), get(row, "name")
+		if id == "" || name == "" {
+			continue
+		}
+		if get(row, "type") == "node" {
+			store.nodes[id] = name
+			continue
+		}
+		store.AddTerm(id, name, get(row, "vocab"))
+		if uri := get(row, "uri"); uri != "" {
+			store.uris[id] = uri
+		}
+	}
+	return store, nil
+}
+
 // Resolve returns the term name for a taxonomy term ID.
 func (ts *TaxonomyStore) Resolve(termID string, vocabulary string) (string, bool) {
 	if ts == nil || ts.terms == nil {
@@ -116,6 +208,15 @@ func (ts *TaxonomyStore) ResolveNode(nodeID string) (string, bool) {
 	return name, ok
 }
 
+// ResolveURI returns the authority URI for a taxonomy term ID.
+func (ts *TaxonomyStore) ResolveURI(termID string) (string, bool) {
+	if ts == nil || ts.uris == nil {
+		return "", false
+	}
+	uri, ok := ts.uris[termID]
+	return uri, ok
+}
+
 // AddTerm adds a term to the store.
 func (ts *TaxonomyStore) AddTerm(id, name, vocabulary string) {
 	ts.terms[id] = name
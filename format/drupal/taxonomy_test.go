@@ -0,0 +1,86 @@
+package drupal
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/mapping"
+)
+
+const termExport = "\ufefftid,vid,name,uri\n" +
+	"12,subject,Geology,http://id.loc.gov/authorities/subjects/sh85054037\n" +
+	"34,genre,Theses,http://vocab.getty.edu/page/aat/300028028\n" +
+	"56,subject,\"Rivers, Pennsylvania\",\n" +
+	"78,,,\n"
+
+func TestLoadTaxonomyFileCSV(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "terms.csv")
+	if err := os.WriteFile(path, []byte(termExport), 0644); err != nil {
+		t.Fatal(err)
+	}
+	store, err := LoadTaxonomyFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if store.TermCount() != 3 {
+		t.Errorf("terms: got %d, want 3 (rows without a name are skipped)", store.TermCount())
+	}
+	if name, _ := store.Resolve("56", ""); name != "Rivers, Pennsylvania" {
+		t.Errorf("name: got %q", name)
+	}
+	if vocab, _ := store.GetVocabulary("34"); vocab != "genre" {
+		t.Errorf("vocabulary: got %q", vocab)
+	}
+	if uri, _ := store.ResolveURI("12"); uri != "http://id.loc.gov/authorities/subjects/sh85054037" {
+		t.Errorf("uri: got %q", uri)
+	}
+	if _, ok := store.ResolveURI("56"); ok {
+		t.Error("expected no URI for a term exported without one")
+	}
+
+	if _, err := LoadTaxonomyCSV(strings.NewReader("id,label_x\n1,a\n")); err == nil {
+		t.Error("expected an error for a CSV without a name column")
+	}
+}
+
+// A preloaded export resolves names, authority URIs, and the genre's
+// resource type without the _entity data --base-url would fetch.
+func TestParseWithPreloadedTaxonomy(t *testing.T) {
+	store, err := LoadTaxonomyCSV(strings.NewReader(termExport))
+	if err != nil {
+		t.Fatal(err)
+	}
+	input := `{
+		"title": [{"value": "Test"}],
+		"field_subject": [{"target_id": 12, "target_type": "taxonomy_term"}],
+		"field_genre": [{"target_id": 34, "target_type": "taxonomy_term"}]
+	}`
+	p := &mapping.Profile{
+		Name:   "test",
+		Format: "drupal",
+		Fields: map[string]mapping.FieldMapping{
+			"title":         {IR: "Title"},
+			"field_subject": {IR: "Subjects", Resolve: "taxonomy_term", Vocabulary: "lcsh"},
+			"field_genre":   {IR: "Genre", Resolve: "taxonomy_term"},
+		},
+	}
+
+	records, err := (&Format{}).Parse(strings.NewReader(input), &format.ParseOptions{Profile: p, TaxonomyResolver: store})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := records[0]
+	if len(r.Subjects) != 1 || r.Subjects[0].Value != "Geology" || r.Subjects[0].Uri != "http://id.loc.gov/authorities/subjects/sh85054037" {
+		t.Errorf("subjects: got %v", r.Subjects)
+	}
+	if len(r.Genres) != 1 || r.Genres[0].Value != "Theses" || r.Genres[0].Uri != "http://vocab.getty.edu/page/aat/300028028" {
+		t.Errorf("genres: got %v", r.Genres)
+	}
+	if r.ResourceType.GetType() != hubv1.ResourceTypeValue_RESOURCE_TYPE_THESIS {
+		t.Errorf("resource type: got %v, want THESIS", r.ResourceType)
+	}
+}
@@ -0,0 +1,100 @@
+package drupal
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/lehigh-university-libraries/crosswalk/mapping"
+)
+
+// applyTransforms rewrites the entity's fields by the profile's templates
+// and transform directives, before they are mapped. Templates see the
+// entity's fields as they arrived, by name.
+func applyTransforms(entity DrupalEntity, profile *mapping.Profile) error {
+	transforms := make(map[string]*mapping.FieldTransform)
+	templated := false
+	for name, m := range profile.Fields {
+		ft, err := m.CompileTransform()
+		if err != nil {
+			return fmt.Errorf("field %s: %w", name, err)
+		}
+		if ft != nil {
+			transforms[name] = ft
+			templated = templated || ft.HasTemplate()
+		}
+	}
+	if len(transforms) == 0 {
+		return nil
+	}
+
+	var fields map[string]string
+	if templated {
+		fields = make(map[string]string, len(entity))
+		for name, raw := range entity {
+			fields[name], _ = ExtractString(raw)
+		}
+	}
+
+	for name, ft := range transforms {
+		if ft.HasTemplate() {
+			values, err := ft.Apply(nil, fields)
+			if err != nil {
+				return fmt.Errorf("field %s: %w", name, err)
+			}
+			items := make([]map[string]any, 0, len(values))
+			for _, v := range values {
+				items = append(items, map[string]any{"value": v})
+			}
+			raw, err := json.Marshal(items)
+			if err != nil {
+				return fmt.Errorf("field %s: %w", name, err)
+			}
+			entity[name] = raw
+			continue
+		}
+		raw, ok := entity[name]
+		if !ok {
+			continue
+		}
+		rewritten, err := transformItems(raw, ft)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", name, err)
+		}
+		entity[name] = rewritten
+	}
+	return nil
+}
+
+// transformItems applies a transform to the "value" of each item of a
+// field. An item split into several values is repeated once per value.
+// References and other items without a text value pass through unchanged.
+func transformItems(raw json.RawMessage, ft *mapping.FieldTransform) (json.RawMessage, error) {
+	var items []map[string]any
+	if err := json.Unmarshal(raw, &items); err != nil {
+		return raw, nil
+	}
+	out := make([]map[string]any, 0, len(items))
+	for _, item := range items {
+		text, ok := item["value"].(string)
+		if !ok {
+			out = append(out, item)
+			continue
+		}
+		values, err := ft.Apply([]string{text}, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range values {
+			copied := make(map[string]any, len(item))
+			for k, x := range item {
+				copied[k] = x
+			}
+			copied["value"] = v
+			// Drupal's rendered copy of the value would win over the
+			// transformed one
+			delete(copied, "processed")
+			out = append(out, copied)
+		}
+	}
+	return json.Marshal(out)
+}
@@ -0,0 +1,55 @@
+// Package dspace_saf implements the DSpace Simple Archive Format (SAF),
+// the directory layout DSpace's batch import reads.
+//
+// The serializer writes the archive as a ZIP file, which DSpace 7+
+// imports directly (dspace import -a -z) and which unzips to the SAF
+// directory tree. Each record becomes an item folder holding:
+//   - dublin_core.xml, the item's dc schema metadata
+//   - metadata_dcterms.xml, metadata_oaire.xml, and metadata_thesis.xml,
+//     written only when the record has values in those schemas. The
+//     thesis (ETD-MS) schema must be registered in DSpace before import.
+//   - contents, listing the item's bitstreams and their bundles
+//   - handle, when the record has a Handle identifier to keep
+//
+// Files with a local path are copied into the item folder. Files that
+// can't be read (remote URLs, missing paths) are left out of contents,
+// since DSpace rejects an item whose listed bitstreams are missing.
+package dspace_saf
+
+import (
+	"github.com/lehigh-university-libraries/crosswalk/format"
+)
+
+// Format implements the DSpace Simple Archive Format.
+type Format struct{}
+
+// Ensure Format implements the interfaces
+var (
+	_ format.Format         = (*Format)(nil)
+	_ format.Serializer     = (*Format)(nil)
+	_ format.FieldDescriber = (*Format)(nil)
+)
+
+// Name returns the format identifier.
+func (f *Format) Name() string {
+	return "dspace-saf"
+}
+
+// Description returns a human-readable format description.
+func (f *Format) Description() string {
+	return "DSpace Simple Archive Format (zipped item folders)"
+}
+
+// Extensions returns file extensions associated with this format.
+func (f *Format) Extensions() []string {
+	return []string{"zip"}
+}
+
+// CanParse returns false; SAF is an output-only format.
+func (f *Format) CanParse(_ []byte) bool {
+	return false
+}
+
+func init() {
+	format.Register(&Format{})
+}
@@ -0,0 +1,464 @@
+package dspace_saf
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/helpers"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+// writtenFields are the hub record fields Serialize writes.
+var writtenFields = []string{
+	"title", "alt_title", "abstract", "contributors", "dates",
+	"resource_type", "genres", "subjects", "language", "publisher",
+	"publication", "rights", "access_condition", "identifiers",
+	"description", "physical_desc", "notes", "table_of_contents", "source",
+	"preferred_citation", "files", "relations", "degree_info", "funders",
+	"rights_holder", "copyright_statement",
+}
+
+// WrittenFields returns the hub record fields Serialize writes.
+func (f *Format) WrittenFields(_ *format.SerializeOptions) []string {
+	return writtenFields
+}
+
+// dspaceTypes maps hub resource types to the dc.type values of DSpace's
+// default submission forms.
+var dspaceTypes = map[hubv1.ResourceTypeValue]string{
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_ARTICLE:               "Article",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_NEWSPAPER_ARTICLE:     "Article",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_BOOK:                  "Book",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_BOOK_CHAPTER:          "Book chapter",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_DATASET:               "Dataset",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_THESIS:                "Thesis",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_DISSERTATION:          "Thesis",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_PREPRINT:              "Preprint",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_PRESENTATION:          "Presentation",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_SOFTWARE:              "Software",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_REPORT:                "Technical Report",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_TECHNICAL_REPORT:      "Technical Report",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_WORKING_PAPER:         "Working Paper",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_IMAGE:                 "Image",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_MAP:                   "Map",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_VIDEO:                 "Video",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_AUDIO:                 "Recording, oral",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_CONFERENCE_PAPER:      "Article",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_CONFERENCE_PROCEEDING: "Book",
+}
+
+// dublinCore is a dublin_core.xml or metadata_<schema>.xml file.
+type dublinCore struct {
+	XMLName xml.Name  `xml:"dublin_core"`
+	Schema  string    `xml:"schema,attr"`
+	Values  []dcValue `xml:"dcvalue"`
+}
+
+// dcValue is one metadata value. DSpace spells an absent qualifier "none".
+type dcValue struct {
+	Element   string `xml:"element,attr"`
+	Qualifier string `xml:"qualifier,attr"`
+	Value     string `xml:",chardata"`
+}
+
+// item collects an item folder's metadata by schema.
+type item struct {
+	schemas map[string]*dublinCore
+}
+
+// add appends a value to schema.element.qualifier; empty values are
+// skipped.
+func (it *item) add(schema, element, qualifier, value string) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return
+	}
+	if qualifier == "" {
+		qualifier = "none"
+	}
+	dc, ok := it.schemas[schema]
+	if !ok {
+		dc = &dublinCore{Schema: schema}
+		it.schemas[schema] = dc
+	}
+	dc.Values = append(dc.Values, dcValue{Element: element, Qualifier: qualifier, Value: value})
+}
+
+// Serialize writes hub records as a zipped Simple Archive, one item
+// folder per record.
+func (f *Format) Serialize(w io.Writer, records []*hubv1.Record, opts *format.SerializeOptions) error {
+	if opts == nil {
+		opts = format.NewSerializeOptions()
+	}
+	if len(records) == 0 {
+		return fmt.Errorf("a Simple Archive needs at least one item: %w", format.ErrEmptyDocument)
+	}
+
+	zw := zip.NewWriter(w)
+	for i, record := range records {
+		dir := fmt.Sprintf("item_%03d", i)
+		if err := writeItem(zw, dir, record, opts); err != nil {
+			return fmt.Errorf("writing record %d: %w", i, err)
+		}
+	}
+	return zw.Close()
+}
+
+// writeItem writes one record's item folder.
+func writeItem(zw *zip.Writer, dir string, record *hubv1.Record, opts *format.SerializeOptions) error {
+	it := hubToItem(record, opts)
+
+	schemas := make([]string, 0, len(it.schemas))
+	for s := range it.schemas {
+		schemas = append(schemas, s)
+	}
+	sort.Strings(schemas)
+	for _, s := range schemas {
+		name := "metadata_" + s + ".xml"
+		if s == "dc" {
+			name = "dublin_core.xml"
+		}
+		out, err := xml.MarshalIndent(it.schemas[s], "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshaling %s: %w", name, err)
+		}
+		if err := writeEntry(zw, path.Join(dir, name), []byte(xml.Header+string(out)+"\n")); err != nil {
+			return err
+		}
+	}
+
+	contents, err := writeFiles(zw, dir, record)
+	if err != nil {
+		return err
+	}
+	if err := writeEntry(zw, path.Join(dir, "contents"), []byte(contents)); err != nil {
+		return err
+	}
+
+	if h := hub.GetIdentifier(record, hubv1.IdentifierType_IDENTIFIER_TYPE_HANDLE); h != nil {
+		handle := hub.NormalizeIdentifier(h.Value, h.Type)
+		if err := writeEntry(zw, path.Join(dir, "handle"), []byte(handle+"\n")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeFiles copies the record's readable local files into the item
+// folder and returns the contents file listing them.
+func writeFiles(zw *zip.Writer, dir string, record *hubv1.Record) (string, error) {
+	var b strings.Builder
+	primary := hub.PrimaryFile(record)
+	seen := make(map[string]bool)
+	for _, file := range record.Files {
+		data, err := os.ReadFile(file.Path)
+		if err != nil {
+			slog.Warn("leaving file out of Simple Archive item: not readable",
+				"title", record.Title, "path", file.Path, "err", err)
+			continue
+		}
+
+		name := file.Name
+		if name == "" {
+			name = filepath.Base(file.Path)
+		}
+		name = strings.NewReplacer("/", "_", "\\", "_", "\t", " ").Replace(name)
+		if seen[name] {
+			slog.Warn("leaving file out of Simple Archive item: duplicate name",
+				"title", record.Title, "name", name)
+			continue
+		}
+		seen[name] = true
+
+		if err := writeEntry(zw, path.Join(dir, name), data); err != nil {
+			return "", err
+		}
+		b.WriteString(name + "\tbundle:" + bundle(file))
+		if file == primary {
+			b.WriteString("\tprimary:true")
+		}
+		if file.Description != "" {
+			b.WriteString("\tdescription:" + strings.Join(strings.Fields(file.Description), " "))
+		}
+		b.WriteString("\n")
+	}
+	return b.String(), nil
+}
+
+// bundle returns the DSpace bundle for a file role.
+func bundle(file *hubv1.File) string {
+	switch file.Role {
+	case "thumbnail":
+		return "THUMBNAIL"
+	case "license":
+		return "LICENSE"
+	default:
+		return "ORIGINAL"
+	}
+}
+
+// writeEntry adds a file to the archive.
+func writeEntry(zw *zip.Writer, name string, data []byte) error {
+	fw, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate})
+	if err != nil {
+		return err
+	}
+	_, err = fw.Write(data)
+	return err
+}
+
+// hubToItem maps a hub record to SAF metadata, using the dc fields of
+// DSpace's default registry, plus dcterms, oaire (journal citation and
+// awards), and thesis (degree) where dc has no field.
+func hubToItem(record *hubv1.Record, opts *format.SerializeOptions) *item {
+	it := &item{schemas: make(map[string]*dublinCore)}
+
+	it.add("dc", "title", "", record.Title)
+	for _, alt := range record.AltTitle {
+		it.add("dc", "title", "alternative", alt)
+	}
+
+	for _, c := range record.Contributors {
+		it.add("dc", "contributor", contributorQualifier(c), contributorName(c))
+	}
+
+	for _, d := range record.Dates {
+		it.add("dc", "date", dateQualifier(d.Type), format.FormatDate(d, opts.Dates))
+	}
+
+	if rt := record.ResourceType; rt != nil {
+		if t, ok := dspaceTypes[rt.Type]; ok {
+			it.add("dc", "type", "", t)
+		} else {
+			it.add("dc", "type", "", rt.Original)
+		}
+	}
+	for _, g := range record.Genres {
+		it.add("dc", "type", "", g.Value)
+	}
+
+	for _, s := range record.Subjects {
+		switch s.Type {
+		case hubv1.SubjectType_SUBJECT_TYPE_GEOGRAPHIC:
+			it.add("dc", "coverage", "spatial", s.Value)
+		case hubv1.SubjectType_SUBJECT_TYPE_TEMPORAL:
+			it.add("dc", "coverage", "temporal", s.Value)
+		default:
+			it.add("dc", "subject", subjectQualifier(s.Vocabulary), s.Value)
+		}
+	}
+
+	it.add("dc", "language", "iso", record.Language)
+	it.add("dc", "publisher", "", record.Publisher)
+
+	it.add("dc", "description", "abstract", record.Abstract)
+	it.add("dc", "description", "", record.Description)
+	for _, n := range record.Notes {
+		it.add("dc", "description", "", n)
+	}
+	it.add("dc", "description", "tableofcontents", record.TableOfContents)
+	for _, fu := range record.Funders {
+		it.add("dc", "description", "sponsorship", fu.Name)
+		for _, award := range fu.AwardNumbers {
+			it.add("oaire", "awardNumber", "", award)
+		}
+		it.add("oaire", "awardTitle", "", fu.AwardTitle)
+		it.add("oaire", "awardURI", "", fu.AwardUri)
+	}
+	it.add("dc", "format", "extent", record.PhysicalDesc)
+	it.add("dc", "source", "", record.Source)
+
+	for _, id := range record.Identifiers {
+		it.add("dc", "identifier", identifierQualifier(id.Type), identifierValue(id))
+	}
+	it.add("dc", "identifier", "citation", record.PreferredCitation)
+
+	if pub := record.Publication; pub != nil {
+		it.add("dc", "relation", "ispartof", pub.Title)
+		it.add("dc", "identifier", "issn", pub.Issn)
+		it.add("oaire", "citation", "volume", pub.Volume)
+		it.add("oaire", "citation", "issue", pub.Issue)
+		sp, ep := helpers.SplitPages(pub.Pages)
+		it.add("oaire", "citation", "startPage", sp)
+		it.add("oaire", "citation", "endPage", ep)
+	}
+	for _, rel := range record.Relations {
+		value := rel.TargetUri
+		if value == "" {
+			value = rel.TargetTitle
+		}
+		if value == "" {
+			value = rel.TargetId
+		}
+		if record.Publication.GetTitle() != "" && value == record.Publication.GetTitle() {
+			continue
+		}
+		it.add("dc", "relation", relationQualifier(rel.Type), value)
+	}
+
+	for _, r := range record.Rights {
+		it.add("dc", "rights", "", r.Statement)
+		it.add("dc", "rights", "uri", r.Uri)
+		if r.Holder != record.RightsHolder {
+			it.add("dcterms", "rightsHolder", "", r.Holder)
+		}
+	}
+	it.add("dc", "rights", "", record.CopyrightStatement)
+	it.add("dcterms", "rightsHolder", "", record.RightsHolder)
+	it.add("dcterms", "accessRights", "", record.AccessCondition)
+
+	if deg := record.DegreeInfo; deg != nil {
+		it.add("thesis", "degree", "name", deg.DegreeName)
+		it.add("thesis", "degree", "level", deg.DegreeLevel)
+		it.add("thesis", "degree", "discipline", deg.Department)
+		it.add("thesis", "degree", "grantor", deg.Institution)
+	}
+
+	return it
+}
+
+// contributorQualifier returns the dc.contributor qualifier for a
+// contributor's role. Contributors without a role are authors.
+func contributorQualifier(c *hubv1.Contributor) string {
+	code := helpers.NormalizeRole(c.RoleCode)
+	if code == "" {
+		code = helpers.NormalizeRole(c.Role)
+	}
+	switch code {
+	case "", "aut", "cre":
+		return "author"
+	case "edt":
+		return "editor"
+	case "ths":
+		return "advisor"
+	case "ill":
+		return "illustrator"
+	default:
+		return "other"
+	}
+}
+
+// contributorName returns the inverted "Last, First" form DSpace uses for
+// people; organization names are written as-is.
+func contributorName(c *hubv1.Contributor) string {
+	if c.Type == hubv1.ContributorType_CONTRIBUTOR_TYPE_ORGANIZATION {
+		return c.Name
+	}
+	if c.ParsedName == nil && c.Name != "" && c.Type == hubv1.ContributorType_CONTRIBUTOR_TYPE_PERSON {
+		return helpers.FormatNameInverted(c.Name)
+	}
+	return hub.InvertedName(c)
+}
+
+// dateQualifier returns the dc.date qualifier for a hub date type.
+func dateQualifier(t hubv1.DateType) string {
+	switch t {
+	case hubv1.DateType_DATE_TYPE_ISSUED, hubv1.DateType_DATE_TYPE_PUBLISHED:
+		return "issued"
+	case hubv1.DateType_DATE_TYPE_CREATED:
+		return "created"
+	case hubv1.DateType_DATE_TYPE_COPYRIGHT:
+		return "copyright"
+	case hubv1.DateType_DATE_TYPE_AVAILABLE:
+		return "available"
+	case hubv1.DateType_DATE_TYPE_SUBMITTED:
+		return "submitted"
+	case hubv1.DateType_DATE_TYPE_UPDATED, hubv1.DateType_DATE_TYPE_MODIFIED:
+		return "updated"
+	default:
+		return ""
+	}
+}
+
+// subjectQualifier returns the dc.subject qualifier for a vocabulary.
+func subjectQualifier(v hubv1.SubjectVocabulary) string {
+	switch v {
+	case hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LCSH:
+		return "lcsh"
+	case hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_MESH:
+		return "mesh"
+	case hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_DDC:
+		return "ddc"
+	case hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LCC:
+		return "lcc"
+	case hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_KEYWORDS,
+		hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_UNSPECIFIED:
+		return ""
+	default:
+		return "other"
+	}
+}
+
+// identifierQualifier returns the dc.identifier qualifier for a hub
+// identifier type.
+func identifierQualifier(t hubv1.IdentifierType) string {
+	switch t {
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_DOI:
+		return "doi"
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_ISBN:
+		return "isbn"
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_ISSN:
+		return "issn"
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_URL,
+		hubv1.IdentifierType_IDENTIFIER_TYPE_HANDLE:
+		return "uri"
+	default:
+		return "other"
+	}
+}
+
+// identifierValue returns an identifier as DSpace stores it: DOIs bare,
+// and Handles as resolver URLs.
+func identifierValue(id *hubv1.Identifier) string {
+	switch id.Type {
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_DOI:
+		return hub.NormalizeIdentifier(id.Value, id.Type)
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_HANDLE:
+		return "https://hdl.handle.net/" + hub.NormalizeIdentifier(id.Value, id.Type)
+	}
+	return id.Value
+}
+
+// relationQualifier returns the dc.relation qualifier for a hub relation
+// type, or "" for plain dc.relation.
+func relationQualifier(t hubv1.RelationType) string {
+	switch t {
+	case hubv1.RelationType_RELATION_TYPE_PART_OF,
+		hubv1.RelationType_RELATION_TYPE_MEMBER_OF:
+		return "ispartof"
+	case hubv1.RelationType_RELATION_TYPE_IN_SERIES:
+		return "ispartofseries"
+	case hubv1.RelationType_RELATION_TYPE_HAS_PART,
+		hubv1.RelationType_RELATION_TYPE_HAS_MEMBER:
+		return "haspart"
+	case hubv1.RelationType_RELATION_TYPE_VERSION_OF:
+		return "isversionof"
+	case hubv1.RelationType_RELATION_TYPE_HAS_VERSION:
+		return "hasversion"
+	case hubv1.RelationType_RELATION_TYPE_REPLACES:
+		return "replaces"
+	case hubv1.RelationType_RELATION_TYPE_IS_REPLACED_BY:
+		return "isreplacedby"
+	case hubv1.RelationType_RELATION_TYPE_FORMAT_OF:
+		return "isformatof"
+	case hubv1.RelationType_RELATION_TYPE_IS_CITED_BY:
+		return "isreferencedby"
+	case hubv1.RelationType_RELATION_TYPE_DERIVED_FROM,
+		hubv1.RelationType_RELATION_TYPE_BASED_ON:
+		return "isbasedon"
+	case hubv1.RelationType_RELATION_TYPE_REQUIRES:
+		return "requires"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,227 @@
+package dspace_saf
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+)
+
+// readArchive returns the archive's entries by name.
+func readArchive(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("invalid zip: %v", err)
+	}
+	entries := make(map[string]string)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		entries[f.Name] = string(b)
+	}
+	return entries
+}
+
+// values returns "element.qualifier=value" strings from a metadata file.
+func values(t *testing.T, doc string) []string {
+	t.Helper()
+	var dc dublinCore
+	if err := xml.Unmarshal([]byte(doc), &dc); err != nil {
+		t.Fatalf("invalid metadata XML: %v\n%s", err, doc)
+	}
+	out := make([]string, 0, len(dc.Values))
+	for _, v := range dc.Values {
+		out = append(out, v.Element+"."+v.Qualifier+"="+v.Value)
+	}
+	return out
+}
+
+func contains(list []string, want string) bool {
+	for _, v := range list {
+		if v == want {
+			return true
+		}
+	}
+	return false
+}
+
+func TestSerialize(t *testing.T) {
+	dir := t.TempDir()
+	pdf := filepath.Join(dir, "thesis.pdf")
+	if err := os.WriteFile(pdf, []byte("%PDF-1.7"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	thesis := &hubv1.Record{
+		Title:    "Thin film growth",
+		AltTitle: []string{"Growing thin films"},
+		Abstract: "We grow films.",
+		Contributors: []*hubv1.Contributor{
+			{Name: "Min Lee", Type: hubv1.ContributorType_CONTRIBUTOR_TYPE_PERSON, RoleCode: "relators:aut"},
+			{Name: "Ada Advisor", Type: hubv1.ContributorType_CONTRIBUTOR_TYPE_PERSON, Role: "Thesis advisor"},
+		},
+		Dates: []*hubv1.DateValue{
+			{Type: hubv1.DateType_DATE_TYPE_ISSUED, Year: 2021, Month: 5},
+		},
+		ResourceType: &hubv1.ResourceType{Type: hubv1.ResourceTypeValue_RESOURCE_TYPE_DISSERTATION},
+		Subjects: []*hubv1.Subject{
+			{Value: "Thin films", Vocabulary: hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LCSH},
+			{Value: "epitaxy", Vocabulary: hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_KEYWORDS},
+			{Value: "Bethlehem (Pa.)", Type: hubv1.SubjectType_SUBJECT_TYPE_GEOGRAPHIC},
+		},
+		Language: "en",
+		Identifiers: []*hubv1.Identifier{
+			{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_DOI, Value: "https://doi.org/10.1234/etd.99"},
+			{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_HANDLE, Value: "hdl:1234/5678"},
+		},
+		Rights:          []*hubv1.Rights{{Statement: "In Copyright", Uri: "http://rightsstatements.org/vocab/InC/1.0/"}},
+		RightsHolder:    "Min Lee",
+		AccessCondition: "Embargoed until 2023",
+		DegreeInfo:      &hubv1.DegreeInfo{DegreeName: "Ph.D.", Institution: "Lehigh University", Department: "Physics"},
+		Funders:         []*hubv1.Funder{{Name: "National Science Foundation", AwardNumbers: []string{"DMR-123"}}},
+		Files: []*hubv1.File{
+			{Path: pdf, Role: "original", Description: "Full text"},
+			{Path: "https://example.edu/remote.jpg", Role: "thumbnail"},
+		},
+	}
+	article := &hubv1.Record{
+		Title: "Charge transport",
+		Contributors: []*hubv1.Contributor{
+			{Name: "ATLAS Collaboration", Type: hubv1.ContributorType_CONTRIBUTOR_TYPE_PERSON, RoleCode: "relators:aut"},
+		},
+		ResourceType: &hubv1.ResourceType{Type: hubv1.ResourceTypeValue_RESOURCE_TYPE_ARTICLE},
+		Publication:  &hubv1.PublicationDetails{Title: "Journal of Applied Physics", Volume: "125", Issue: "19", Pages: "195501-195510", Issn: "0021-8979"},
+		Relations: []*hubv1.Relation{
+			{Type: hubv1.RelationType_RELATION_TYPE_PART_OF, TargetTitle: "Journal of Applied Physics"},
+			{Type: hubv1.RelationType_RELATION_TYPE_IN_SERIES, TargetTitle: "Physics Reports"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := (&Format{}).Serialize(&buf, []*hubv1.Record{thesis, article}, nil); err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+	entries := readArchive(t, buf.Bytes())
+
+	var names []string
+	for name := range entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	want := []string{
+		"item_000/contents",
+		"item_000/dublin_core.xml",
+		"item_000/handle",
+		"item_000/metadata_dcterms.xml",
+		"item_000/metadata_oaire.xml",
+		"item_000/metadata_thesis.xml",
+		"item_000/thesis.pdf",
+		"item_001/contents",
+		"item_001/dublin_core.xml",
+		"item_001/metadata_oaire.xml",
+	}
+	if strings.Join(names, "\n") != strings.Join(want, "\n") {
+		t.Errorf("archive entries:\ngot  %v\nwant %v", names, want)
+	}
+
+	dc := values(t, entries["item_000/dublin_core.xml"])
+	for _, v := range []string{
+		"title.none=Thin film growth",
+		"title.alternative=Growing thin films",
+		"contributor.author=Lee, Min",
+		"contributor.advisor=Advisor, Ada",
+		"date.issued=2021-05",
+		"type.none=Thesis",
+		"subject.lcsh=Thin films",
+		"subject.none=epitaxy",
+		"coverage.spatial=Bethlehem (Pa.)",
+		"language.iso=en",
+		"description.abstract=We grow films.",
+		"description.sponsorship=National Science Foundation",
+		"identifier.doi=10.1234/etd.99",
+		"identifier.uri=https://hdl.handle.net/1234/5678",
+		"rights.none=In Copyright",
+		"rights.uri=http://rightsstatements.org/vocab/InC/1.0/",
+	} {
+		if !contains(dc, v) {
+			t.Errorf("dublin_core.xml missing %q\n%v", v, dc)
+		}
+	}
+
+	terms := values(t, entries["item_000/metadata_dcterms.xml"])
+	if !contains(terms, "rightsHolder.none=Min Lee") || !contains(terms, "accessRights.none=Embargoed until 2023") {
+		t.Errorf("metadata_dcterms.xml: got %v", terms)
+	}
+	degree := values(t, entries["item_000/metadata_thesis.xml"])
+	if !contains(degree, "degree.name=Ph.D.") || !contains(degree, "degree.grantor=Lehigh University") || !contains(degree, "degree.discipline=Physics") {
+		t.Errorf("metadata_thesis.xml: got %v", degree)
+	}
+	if !strings.Contains(entries["item_000/metadata_thesis.xml"], `schema="thesis"`) {
+		t.Errorf("metadata_thesis.xml should declare its schema:\n%s", entries["item_000/metadata_thesis.xml"])
+	}
+	if got := entries["item_000/contents"]; got != "thesis.pdf\tbundle:ORIGINAL\tprimary:true\tdescription:Full text\n" {
+		t.Errorf("contents: got %q", got)
+	}
+	if got := entries["item_000/handle"]; got != "1234/5678\n" {
+		t.Errorf("handle: got %q", got)
+	}
+
+	dc = values(t, entries["item_001/dublin_core.xml"])
+	for _, v := range []string{
+		"type.none=Article",
+		"contributor.author=ATLAS Collaboration",
+		"relation.ispartof=Journal of Applied Physics",
+		"relation.ispartofseries=Physics Reports",
+		"identifier.issn=0021-8979",
+	} {
+		if !contains(dc, v) {
+			t.Errorf("article dublin_core.xml missing %q\n%v", v, dc)
+		}
+	}
+	n := 0
+	for _, v := range dc {
+		if v == "relation.ispartof=Journal of Applied Physics" {
+			n++
+		}
+	}
+	if n != 1 {
+		t.Errorf("journal title should be written once, got %d times", n)
+	}
+	oaire := values(t, entries["item_001/metadata_oaire.xml"])
+	for _, v := range []string{"citation.volume=125", "citation.issue=19", "citation.startPage=195501", "citation.endPage=195510"} {
+		if !contains(oaire, v) {
+			t.Errorf("metadata_oaire.xml missing %q\n%v", v, oaire)
+		}
+	}
+	if entries["item_001/contents"] != "" {
+		t.Errorf("expected empty contents, got %q", entries["item_001/contents"])
+	}
+}
+
+func TestSerializeEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	err := (&Format{}).Serialize(&buf, nil, nil)
+	if !errors.Is(err, format.ErrEmptyDocument) {
+		t.Fatalf("err = %v, want ErrEmptyDocument", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected no output, got %d bytes", buf.Len())
+	}
+}
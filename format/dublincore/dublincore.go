@@ -10,14 +10,25 @@ import (
 // Version documents the Dublin Core specification this implementation targets.
 const Version = "2020-01-20"
 
+// Output variants, selected with SerializeOptions.Variant. Simple DC (the
+// default) writes a <metadata> element; oai_dc wraps the same elements in
+// the OAI-PMH <oai_dc:dc> container; qualified adds dcterms refinements.
+const (
+	VariantSimple    = "simple"
+	VariantOAIDC     = "oai_dc"
+	VariantQualified = "qualified"
+)
+
 // Format implements the Dublin Core format.
 type Format struct{}
 
 // Ensure Format implements the interfaces
 var (
-	_ format.Format     = (*Format)(nil)
-	_ format.Parser     = (*Format)(nil)
-	_ format.Serializer = (*Format)(nil)
+	_ format.Format         = (*Format)(nil)
+	_ format.Parser         = (*Format)(nil)
+	_ format.StreamParser   = (*Format)(nil)
+	_ format.Serializer     = (*Format)(nil)
+	_ format.FieldDescriber = (*Format)(nil)
 )
 
 // Name returns the format identifier.
@@ -35,6 +46,28 @@ func (f *Format) Extensions() []string {
 	return []string{"xml", "dc"}
 }
 
+// writtenFields are the hub record fields every variant writes. Relations
+// of any type fall back to dc:relation.
+var writtenFields = []string{
+	"title", "contributors", "publisher", "subjects", "abstract", "dates",
+	"language", "identifiers", "resource_type", "rights", "relations",
+}
+
+// qualifiedFields are the additional fields only dcterms refinements carry.
+var qualifiedFields = []string{
+	"alt_title", "table_of_contents", "physical_desc", "access_condition",
+	"rights_holder", "preferred_citation",
+}
+
+// WrittenFields returns the hub record fields Serialize writes for the
+// selected variant.
+func (f *Format) WrittenFields(opts *format.SerializeOptions) []string {
+	if opts != nil && opts.Variant == VariantQualified {
+		return append(append([]string{}, writtenFields...), qualifiedFields...)
+	}
+	return writtenFields
+}
+
 // CanParse returns true if the input looks like Dublin Core XML.
 func (f *Format) CanParse(peek []byte) bool {
 	peek = bytes.TrimSpace(peek)
@@ -0,0 +1,48 @@
+package dublincore
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	dcv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/spoke/dublincore/v20200120"
+	"github.com/lehigh-university-libraries/crosswalk/hub/convert"
+)
+
+// NeedsHub reports whether a dublincore → dublincore conversion must go
+// through the hub. Date rendering options are applied by hubToSpoke, so
+// they need the full path; everything else is a spoke-level cleanup.
+func (f *Format) NeedsHub(opts *format.SerializeOptions) bool {
+	return opts != nil && opts.Dates != (format.DateOptions{})
+}
+
+// Normalize rewrites Dublin Core XML directly on the spoke messages, so
+// repeated and language-tagged elements the hub flattens survive intact.
+func (f *Format) Normalize(r io.Reader, w io.Writer, opts *format.SerializeOptions) error {
+	var variant string
+	if opts != nil {
+		variant = opts.Variant
+	}
+	if err := checkVariant(variant); err != nil {
+		return err
+	}
+
+	records, err := readRecords(r)
+	if err != nil {
+		return fmt.Errorf("parsing dublin core XML: %w", err)
+	}
+
+	if len(records) == 0 {
+		return fmt.Errorf("no Dublin Core metadata elements found in input")
+	}
+
+	conv := convert.NewConverter()
+	spokes := make([]*dcv1.Record, 0, len(records))
+	for _, rec := range records {
+		// Validation errors are non-fatal here, as they are in Parse
+		_ = conv.Normalize(rec.record)
+		spokes = append(spokes, rec.record)
+	}
+
+	return writeSpokes(w, spokes, variant)
+}
@@ -1,6 +1,7 @@
 package dublincore
 
 import (
+	"encoding/xml"
 	"fmt"
 	"io"
 	"strings"
@@ -10,47 +11,218 @@ import (
 	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
 	dcv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/spoke/dublincore/v20200120"
 	"github.com/lehigh-university-libraries/crosswalk/hub/convert"
-	"google.golang.org/protobuf/proto"
 )
 
 // Parse reads Dublin Core XML and returns hub records.
-// It handles bare <metadata> elements, multiple records in a single document,
-// and OAI-PMH wrapped responses where <metadata> appears inside wrapper elements.
-func (f *Format) Parse(r io.Reader, _ *format.ParseOptions) ([]*hubv1.Record, error) {
-	spokes, err := protoxml.UnmarshalAll(r, func() proto.Message { return &dcv1.Record{} })
+// It handles bare <metadata> elements, oai_dc <oai_dc:dc> and <qualifieddc>
+// roots, multiple records in a single document, and OAI-PMH responses, where
+// each record's header is kept in SourceInfo.
+func (f *Format) Parse(r io.Reader, opts *format.ParseOptions) ([]*hubv1.Record, error) {
+	var records []*hubv1.Record
+	err := f.ParseStream(r, opts, func(record *hubv1.Record) error {
+		records = append(records, record)
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("parsing dublin core XML: %w", err)
-	}
-
-	if len(spokes) == 0 {
-		return nil, fmt.Errorf("no Dublin Core metadata elements found in input")
+		return nil, err
 	}
+	return records, nil
+}
 
+// ParseStream reads Dublin Core XML as Parse does, calling fn with each
+// record as it is decoded, so a large OAI-PMH ListRecords response is
+// never held in memory at once. A record that fails to convert is a
+// format.RecordError, which opts.OnRecordError may skip.
+func (f *Format) ParseStream(r io.Reader, opts *format.ParseOptions, fn func(*hubv1.Record) error) error {
 	conv := convert.NewConverter()
-	records := make([]*hubv1.Record, 0, len(spokes))
-
-	for i, spoke := range spokes {
-		dcRecord := spoke.(*dcv1.Record)
-
-		result, err := conv.ToHub(spoke)
+	n, index := 0, 0
+	var stopErr error
+	err := scanRecords(r, func(spoke spokeRecord) error {
+		index++
+		result, err := conv.ToHub(spoke.record)
 		if err != nil {
-			return nil, fmt.Errorf("converting record %d to hub: %w", i, err)
+			e := &format.RecordError{Index: index - 1, Offset: spoke.offset, Err: fmt.Errorf("converting to hub: %w", err)}
+			if spoke.header != nil {
+				e.ID = strings.TrimSpace(spoke.header.Identifier)
+			}
+			if opts == nil || opts.OnRecordError == nil {
+				stopErr = e
+			} else {
+				stopErr = opts.OnRecordError(e)
+			}
+			return stopErr
 		}
+		n++
 
 		// The generic converter does not extract scalar values from repeated
 		// message types (e.g., repeated LocalizedString → title). Patch these
 		// fields directly from the spoke proto.
-		applyLocalizedFields(result.Record, dcRecord)
+		applyLocalizedFields(result.Record, spoke.record)
+		applyQualifiedTerms(result.Record, spoke.record)
 
 		result.Record.SourceInfo = &hubv1.SourceInfo{
 			Format:        "dublincore",
 			FormatVersion: Version,
+			Oai:           spoke.header.ToHub(),
 		}
 
-		records = append(records, result.Record)
+		stopErr = fn(result.Record)
+		return stopErr
+	})
+	if err != nil {
+		// Conversion errors and errors from fn are not parse errors
+		if stopErr != nil {
+			return stopErr
+		}
+		return fmt.Errorf("parsing dublin core XML: %w", err)
 	}
 
-	return records, nil
+	if index == 0 {
+		return fmt.Errorf("no Dublin Core metadata elements found in input")
+	}
+	return nil
+}
+
+// XML namespaces that locate Dublin Core records in a document.
+const (
+	oaiNamespace   = "http://www.openarchives.org/OAI/2.0/"
+	oaiDCNamespace = "http://www.openarchives.org/OAI/2.0/oai_dc/"
+)
+
+// spokeRecord is a decoded DC record, the OAI-PMH header preceding it,
+// and the byte offset of its element in the input.
+type spokeRecord struct {
+	record *dcv1.Record
+	header *format.OAIHeader
+	offset int64
+}
+
+// readRecords finds every Dublin Core record in the XML, attaching any
+// OAI-PMH header that precedes each one.
+func readRecords(r io.Reader) ([]spokeRecord, error) {
+	var records []spokeRecord
+	err := scanRecords(r, func(rec spokeRecord) error {
+		records = append(records, rec)
+		return nil
+	})
+	return records, err
+}
+
+// scanRecords finds each Dublin Core record in the XML, attaching any
+// OAI-PMH header that precedes it, and calls fn with it. Deleted records
+// have a header but no metadata, so they produce no record.
+func scanRecords(r io.Reader, fn func(spokeRecord) error) error {
+	decoder := xml.NewDecoder(r)
+	var header *format.OAIHeader
+	n := 0
+
+	for {
+		offset := decoder.InputOffset()
+		tok, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("parsing XML: %w", err)
+		}
+
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		var dc *dcv1.Record
+		switch {
+		case start.Name.Space == oaiNamespace && start.Name.Local == "record":
+			header = nil
+		case start.Name.Space == oaiNamespace && start.Name.Local == "header":
+			header = &format.OAIHeader{}
+			if err := decoder.DecodeElement(header, &start); err != nil {
+				return fmt.Errorf("decoding OAI header: %w", err)
+			}
+		case start.Name.Local == "metadata":
+			dc, err = decodeMetadata(decoder, start)
+		case isRecordElement(start.Name):
+			dc = &dcv1.Record{}
+			err = protoxml.UnmarshalStart(decoder, &start, dc)
+		}
+		if err != nil {
+			return fmt.Errorf("decoding record %d: %w", n, err)
+		}
+		if dc != nil {
+			n++
+			if err := fn(spokeRecord{record: dc, header: header, offset: offset}); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// isRecordElement reports whether name is a DC record root other than
+// <metadata>: the oai_dc <oai_dc:dc> container or a <qualifieddc> root.
+func isRecordElement(name xml.Name) bool {
+	return (name.Local == "dc" && name.Space == oaiDCNamespace) || name.Local == "qualifieddc"
+}
+
+// decodeMetadata decodes a <metadata> element. Bare DC documents put the
+// dc: elements directly inside it, while OAI-PMH wraps an <oai_dc:dc> or
+// <qualifieddc> root, so the first child decides which element is decoded.
+func decodeMetadata(decoder *xml.Decoder, start xml.StartElement) (*dcv1.Record, error) {
+	tokens := []xml.Token{xml.CopyToken(start)}
+	for depth := 1; depth > 0; {
+		tok, err := decoder.Token()
+		if err != nil {
+			return nil, fmt.Errorf("parsing XML: %w", err)
+		}
+		switch tok.(type) {
+		case xml.StartElement:
+			depth++
+		case xml.EndElement:
+			depth--
+		}
+		tokens = append(tokens, xml.CopyToken(tok))
+	}
+
+	for i, tok := range tokens[1:] {
+		if child, ok := tok.(xml.StartElement); ok {
+			if isRecordElement(child.Name) {
+				tokens = tokens[i+1:]
+			}
+			break
+		}
+	}
+
+	// Replay the chosen element through a fresh decoder so protoxml can
+	// read it like any other record
+	replay := xml.NewTokenDecoder(&tokenReplay{tokens: tokens})
+	tok, err := replay.Token()
+	if err != nil {
+		return nil, err
+	}
+	root := tok.(xml.StartElement)
+
+	dc := &dcv1.Record{}
+	if err := protoxml.UnmarshalStart(replay, &root, dc); err != nil {
+		return nil, err
+	}
+	return dc, nil
+}
+
+// tokenReplay is an xml.TokenReader over previously read tokens.
+type tokenReplay struct {
+	tokens []xml.Token
+}
+
+// Token returns the next buffered token, or io.EOF when none remain.
+func (t *tokenReplay) Token() (xml.Token, error) {
+	if len(t.tokens) == 0 {
+		return nil, io.EOF
+	}
+	tok := t.tokens[0]
+	t.tokens = t.tokens[1:]
+	return tok, nil
 }
 
 // applyLocalizedFields patches hub record fields that the generic converter
@@ -104,15 +276,14 @@ func applyLocalizedFields(record *hubv1.Record, dc *dcv1.Record) {
 		}
 	}
 
-	// Dates from dc:date (repeated Date message)
-	if len(dc.Date) > 0 {
-		record.Dates = make([]*hubv1.DateValue, 0, len(dc.Date))
-		for _, d := range dc.Date {
-			if d.Value != "" {
-				record.Dates = append(record.Dates, &hubv1.DateValue{
-					Raw: d.Value,
-				})
-			}
+	// Dates from dc:date (repeated Date message). The converter's own
+	// dcterms dates are replaced too, so they aren't added twice below.
+	record.Dates = nil
+	for _, d := range dc.Date {
+		if d.Value != "" {
+			record.Dates = append(record.Dates, &hubv1.DateValue{
+				Raw: d.Value,
+			})
 		}
 	}
 
@@ -159,3 +330,111 @@ func appendQualifiedDate(record *hubv1.Record, value string, dateType hubv1.Date
 		Raw:  value,
 	})
 }
+
+// applyQualifiedTerms maps relations, rights, and the dcterms refinements
+// the generic converter either stringifies or leaves in Extra.
+func applyQualifiedTerms(record *hubv1.Record, dc *dcv1.Record) {
+	// Relations: plain dc:relation plus each dcterms refinement
+	record.Relations = nil
+	for _, rel := range dc.Relation {
+		appendRelation(record, rel.Value, hubv1.RelationType_RELATION_TYPE_RELATED_TO)
+	}
+	refinements := []struct {
+		values       []string
+		relationType hubv1.RelationType
+	}{
+		{dc.IsPartOf, hubv1.RelationType_RELATION_TYPE_PART_OF},
+		{dc.HasPart, hubv1.RelationType_RELATION_TYPE_HAS_PART},
+		{dc.IsVersionOf, hubv1.RelationType_RELATION_TYPE_VERSION_OF},
+		{dc.HasVersion, hubv1.RelationType_RELATION_TYPE_HAS_VERSION},
+		{dc.IsReplacedBy, hubv1.RelationType_RELATION_TYPE_IS_REPLACED_BY},
+		{dc.Replaces, hubv1.RelationType_RELATION_TYPE_REPLACES},
+		{dc.IsFormatOf, hubv1.RelationType_RELATION_TYPE_FORMAT_OF},
+		{dc.HasFormat, hubv1.RelationType_RELATION_TYPE_HAS_FORMAT},
+		{dc.References, hubv1.RelationType_RELATION_TYPE_REFERENCES},
+		{dc.IsReferencedBy, hubv1.RelationType_RELATION_TYPE_IS_CITED_BY},
+		{dc.Requires, hubv1.RelationType_RELATION_TYPE_RELATED_TO},
+		{dc.IsRequiredBy, hubv1.RelationType_RELATION_TYPE_RELATED_TO},
+	}
+	for _, r := range refinements {
+		for _, v := range r.values {
+			appendRelation(record, v, r.relationType)
+		}
+	}
+
+	// Rights: dc:rights statements (or URIs) and dcterms:license URIs
+	record.Rights = nil
+	for _, r := range dc.Rights {
+		appendRights(record, r.Statement)
+		if r.Uri != "" {
+			appendRights(record, r.Uri)
+		}
+	}
+	for _, l := range dc.License {
+		appendRights(record, l)
+	}
+	if len(dc.AccessRights) > 0 {
+		record.AccessCondition = strings.TrimSpace(dc.AccessRights[0])
+	}
+	if len(dc.RightsHolder) > 0 {
+		record.RightsHolder = strings.TrimSpace(dc.RightsHolder[0].Name)
+	}
+
+	if len(dc.Extent) > 0 {
+		record.PhysicalDesc = strings.TrimSpace(strings.Join(dc.Extent, "; "))
+	}
+	if len(dc.TableOfContents) > 0 {
+		record.TableOfContents = localizedValue(dc.TableOfContents)
+	}
+	if v := strings.TrimSpace(dc.BibliographicCitation); v != "" {
+		record.PreferredCitation = v
+	}
+
+	// Drop the Extra entries the fields above replace
+	if record.Extra != nil {
+		for _, key := range []string{
+			"rights", "license", "access_rights", "rights_holder", "extent",
+			"table_of_contents", "bibliographic_citation", "alternative",
+		} {
+			delete(record.Extra.Fields, key)
+		}
+		if len(record.Extra.Fields) == 0 {
+			record.Extra = nil
+		}
+	}
+}
+
+// appendRelation adds a relation, treating URL values as the target URI and
+// anything else as the target title.
+func appendRelation(record *hubv1.Record, value string, relationType hubv1.RelationType) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return
+	}
+	rel := &hubv1.Relation{Type: relationType}
+	if isURL(value) {
+		rel.TargetUri = value
+	} else {
+		rel.TargetTitle = value
+	}
+	record.Relations = append(record.Relations, rel)
+}
+
+// appendRights adds a rights entry, treating URL values (license and
+// rights statement URIs) as the URI and anything else as the statement.
+func appendRights(record *hubv1.Record, value string) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return
+	}
+	if isURL(value) {
+		record.Rights = append(record.Rights, &hubv1.Rights{Uri: value})
+	} else {
+		record.Rights = append(record.Rights, &hubv1.Rights{Statement: value})
+	}
+}
+
+// isURL reports whether value is an http(s) URL.
+func isURL(value string) bool {
+	return strings.HasPrefix(value, "http://") || strings.HasPrefix(value, "https://")
+}
@@ -1,8 +1,13 @@
 package dublincore
 
 import (
+	"bytes"
+	"errors"
 	"strings"
 	"testing"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
 )
 
 func TestParseSingleRecord(t *testing.T) {
@@ -247,3 +252,226 @@ func TestParseNoRecords(t *testing.T) {
 		t.Error("Expected error when no DC records found")
 	}
 }
+
+func TestNormalizeKeepsRepeatedElements(t *testing.T) {
+	input := `<?xml version="1.0" encoding="UTF-8"?>
+<metadata xmlns:dc="http://purl.org/dc/elements/1.1/">
+  <dc:title>  Understanding Dublin Core  </dc:title>
+  <dc:title>Comprendre Dublin Core</dc:title>
+</metadata>`
+
+	f := &Format{}
+	if f.NeedsHub(format.NewSerializeOptions()) {
+		t.Fatal("NeedsHub() = true for default options")
+	}
+
+	var buf bytes.Buffer
+	if err := f.Normalize(strings.NewReader(input), &buf, nil); err != nil {
+		t.Fatalf("Normalize() error: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"<dc:title>Understanding Dublin Core</dc:title>", "<dc:title>Comprendre Dublin Core</dc:title>"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %s:\n%s", want, out)
+		}
+	}
+}
+
+func TestParseOAIDC(t *testing.T) {
+	input := `<?xml version="1.0" encoding="UTF-8"?>
+<OAI-PMH xmlns="http://www.openarchives.org/OAI/2.0/">
+  <ListRecords>
+    <record>
+      <header>
+        <identifier>oai:preserve.lehigh.edu:etd-1001</identifier>
+        <datestamp>2024-05-02</datestamp>
+        <setSpec>etd</setSpec>
+      </header>
+      <metadata>
+        <oai_dc:dc xmlns:oai_dc="http://www.openarchives.org/OAI/2.0/oai_dc/"
+                   xmlns:dc="http://purl.org/dc/elements/1.1/">
+          <dc:title>Harvested Thesis</dc:title>
+          <dc:creator>Lee, Ana</dc:creator>
+          <dc:rights>https://rightsstatements.org/vocab/InC/1.0/</dc:rights>
+          <dc:relation>Lehigh ETD Collection</dc:relation>
+        </oai_dc:dc>
+      </metadata>
+    </record>
+    <record>
+      <header status="deleted">
+        <identifier>oai:preserve.lehigh.edu:etd-1002</identifier>
+        <datestamp>2024-05-03</datestamp>
+      </header>
+    </record>
+    <record>
+      <header>
+        <identifier>oai:preserve.lehigh.edu:etd-1003</identifier>
+        <datestamp>2024-05-04</datestamp>
+      </header>
+      <metadata>
+        <oai_dc:dc xmlns:oai_dc="http://www.openarchives.org/OAI/2.0/oai_dc/"
+                   xmlns:dc="http://purl.org/dc/elements/1.1/">
+          <dc:title>Second Thesis</dc:title>
+        </oai_dc:dc>
+      </metadata>
+    </record>
+  </ListRecords>
+</OAI-PMH>`
+
+	records, err := (&Format{}).Parse(strings.NewReader(input), nil)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	// The deleted record has no metadata and yields no record
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 records, got %d", len(records))
+	}
+
+	r := records[0]
+	if r.Title != "Harvested Thesis" {
+		t.Errorf("Title: got %q", r.Title)
+	}
+	if len(r.Contributors) != 1 || r.Contributors[0].Name != "Lee, Ana" {
+		t.Errorf("Contributors: got %v", r.Contributors)
+	}
+	if len(r.Rights) != 1 || r.Rights[0].Uri != "https://rightsstatements.org/vocab/InC/1.0/" {
+		t.Errorf("Rights: got %v", r.Rights)
+	}
+	if len(r.Relations) != 1 || r.Relations[0].TargetTitle != "Lehigh ETD Collection" {
+		t.Errorf("Relations: got %v", r.Relations)
+	}
+
+	oai := r.SourceInfo.GetOai()
+	if oai.GetIdentifier() != "oai:preserve.lehigh.edu:etd-1001" || oai.GetDatestamp() != "2024-05-02" {
+		t.Errorf("OAI header: got %v", oai)
+	}
+	if len(oai.GetSetSpecs()) != 1 || oai.GetSetSpecs()[0] != "etd" {
+		t.Errorf("OAI setSpecs: got %v", oai.GetSetSpecs())
+	}
+
+	if records[1].Title != "Second Thesis" {
+		t.Errorf("Record 1 title: got %q", records[1].Title)
+	}
+	if got := records[1].SourceInfo.GetOai().GetIdentifier(); got != "oai:preserve.lehigh.edu:etd-1003" {
+		t.Errorf("Record 1 OAI identifier: got %q", got)
+	}
+}
+
+func TestParseBareOAIDC(t *testing.T) {
+	input := `<oai_dc:dc xmlns:oai_dc="http://www.openarchives.org/OAI/2.0/oai_dc/"
+           xmlns:dc="http://purl.org/dc/elements/1.1/">
+  <dc:title>Standalone oai_dc</dc:title>
+</oai_dc:dc>`
+
+	records, err := (&Format{}).Parse(strings.NewReader(input), nil)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(records) != 1 || records[0].Title != "Standalone oai_dc" {
+		t.Fatalf("got %v", records)
+	}
+	if records[0].SourceInfo.GetOai() != nil {
+		t.Errorf("OAI header: got %v, want none", records[0].SourceInfo.GetOai())
+	}
+}
+
+func TestParseQualifiedDC(t *testing.T) {
+	input := `<qualifieddc xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:dcterms="http://purl.org/dc/terms/">
+  <dcterms:title>Qualified Record</dcterms:title>
+  <dcterms:alternative>A Subtitle</dcterms:alternative>
+  <dcterms:abstract>The abstract.</dcterms:abstract>
+  <dcterms:tableOfContents>Introduction -- Methods</dcterms:tableOfContents>
+  <dcterms:created>2019</dcterms:created>
+  <dcterms:issued>2020-05</dcterms:issued>
+  <dcterms:extent>xii, 150 pages</dcterms:extent>
+  <dcterms:isPartOf>Lehigh Review</dcterms:isPartOf>
+  <dcterms:hasVersion>https://example.org/items/2</dcterms:hasVersion>
+  <dcterms:license>https://creativecommons.org/licenses/by/4.0/</dcterms:license>
+  <dcterms:accessRights>Open access</dcterms:accessRights>
+  <dcterms:rightsHolder>Lehigh University</dcterms:rightsHolder>
+  <dcterms:bibliographicCitation>Qualified Record (2020).</dcterms:bibliographicCitation>
+</qualifieddc>`
+
+	records, err := (&Format{}).Parse(strings.NewReader(input), nil)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("Expected 1 record, got %d", len(records))
+	}
+	r := records[0]
+
+	if r.Title != "Qualified Record" {
+		t.Errorf("Title: got %q", r.Title)
+	}
+	if len(r.AltTitle) != 1 || r.AltTitle[0] != "A Subtitle" {
+		t.Errorf("AltTitle: got %v", r.AltTitle)
+	}
+	if r.Abstract != "The abstract." || r.TableOfContents != "Introduction -- Methods" {
+		t.Errorf("Abstract/TOC: got %q / %q", r.Abstract, r.TableOfContents)
+	}
+	if r.PhysicalDesc != "xii, 150 pages" {
+		t.Errorf("PhysicalDesc: got %q", r.PhysicalDesc)
+	}
+	if r.AccessCondition != "Open access" || r.RightsHolder != "Lehigh University" {
+		t.Errorf("AccessCondition/RightsHolder: got %q / %q", r.AccessCondition, r.RightsHolder)
+	}
+	if r.PreferredCitation != "Qualified Record (2020)." {
+		t.Errorf("PreferredCitation: got %q", r.PreferredCitation)
+	}
+	if len(r.Rights) != 1 || r.Rights[0].Uri != "https://creativecommons.org/licenses/by/4.0/" {
+		t.Errorf("Rights: got %v", r.Rights)
+	}
+
+	// Each refined date appears once
+	if len(r.Dates) != 2 {
+		t.Fatalf("Expected 2 dates, got %d: %v", len(r.Dates), r.Dates)
+	}
+	if r.Dates[0].Type != hubv1.DateType_DATE_TYPE_ISSUED || r.Dates[1].Type != hubv1.DateType_DATE_TYPE_CREATED {
+		t.Errorf("Dates: got %v", r.Dates)
+	}
+
+	if len(r.Relations) != 2 {
+		t.Fatalf("Expected 2 relations, got %d: %v", len(r.Relations), r.Relations)
+	}
+	if r.Relations[0].Type != hubv1.RelationType_RELATION_TYPE_PART_OF || r.Relations[0].TargetTitle != "Lehigh Review" {
+		t.Errorf("Relation 0: got %v", r.Relations[0])
+	}
+	if r.Relations[1].Type != hubv1.RelationType_RELATION_TYPE_HAS_VERSION || r.Relations[1].TargetUri != "https://example.org/items/2" {
+		t.Errorf("Relation 1: got %v", r.Relations[1])
+	}
+
+	for _, key := range []string{"extent", "license", "alternative", "table_of_contents"} {
+		if _, ok := r.Extra.GetFields()[key]; ok {
+			t.Errorf("Extra still has %q", key)
+		}
+	}
+}
+
+func TestParseStream(t *testing.T) {
+	var input strings.Builder
+	input.WriteString(`<OAI-PMH xmlns="http://www.openarchives.org/OAI/2.0/"><ListRecords>`)
+	for _, title := range []string{"First", "Second", "Third"} {
+		input.WriteString(`<record><header><identifier>oai:example.org:` + title + `</identifier></header>` +
+			`<metadata><oai_dc:dc xmlns:oai_dc="http://www.openarchives.org/OAI/2.0/oai_dc/" xmlns:dc="http://purl.org/dc/elements/1.1/">` +
+			`<dc:title>` + title + `</dc:title></oai_dc:dc></metadata></record>`)
+	}
+	input.WriteString(`</ListRecords></OAI-PMH>`)
+
+	var ids []string
+	stop := errors.New("stop")
+	err := (&Format{}).ParseStream(strings.NewReader(input.String()), nil, func(r *hubv1.Record) error {
+		ids = append(ids, r.GetSourceInfo().GetOai().GetIdentifier())
+		if len(ids) == 2 {
+			return stop
+		}
+		return nil
+	})
+	if err != stop {
+		t.Fatalf("expected the callback's error unwrapped, got %v", err)
+	}
+	if strings.Join(ids, ",") != "oai:example.org:First,oai:example.org:Second" {
+		t.Errorf("identifiers: got %v", ids)
+	}
+}
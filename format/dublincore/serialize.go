@@ -12,18 +12,46 @@ import (
 
 // Serialize writes hub records as Dublin Core XML.
 func (f *Format) Serialize(w io.Writer, records []*hubv1.Record, opts *format.SerializeOptions) error {
-	// opts reserved for future use (e.g., pretty print, encoding options)
-	_ = opts
+	if opts == nil {
+		opts = format.NewSerializeOptions()
+	}
+	if err := checkVariant(opts.Variant); err != nil {
+		return err
+	}
+
+	// An empty <metadata> element is valid: every DC element is optional
+	if len(records) == 0 {
+		records = []*hubv1.Record{{}}
+	}
 
+	// Step 1: Convert hub records to spoke proto structs
+	spokes := make([]*dcv1.Record, 0, len(records))
 	for i, record := range records {
-		// Step 1: Convert hub record to spoke proto struct
-		spokeRecord, err := hubToSpoke(record)
+		spokeRecord, err := hubToSpoke(record, opts)
 		if err != nil {
 			return fmt.Errorf("converting record %d to spoke: %w", i, err)
 		}
+		spokes = append(spokes, spokeRecord)
+	}
+
+	return writeSpokes(w, spokes, opts.Variant)
+}
+
+// checkVariant rejects output variants this format doesn't know.
+func checkVariant(variant string) error {
+	switch variant {
+	case "", VariantSimple, VariantOAIDC, VariantQualified:
+		return nil
+	}
+	return fmt.Errorf("unknown dublincore variant %q (want %s, %s, or %s)", variant, VariantSimple, VariantOAIDC, VariantQualified)
+}
 
+// writeSpokes marshals spoke records as consecutive <metadata> elements, or
+// <oai_dc:dc> elements for the oai_dc variant.
+func writeSpokes(w io.Writer, spokes []*dcv1.Record, variant string) error {
+	for i, spokeRecord := range spokes {
 		// Step 2: Convert spoke proto to XML-marshalable struct
-		xmlRecord := spokeToXML(spokeRecord)
+		xmlRecord := spokeToXML(spokeRecord, variant)
 
 		// Step 3: Marshal to XML
 		output, err := xml.MarshalIndent(xmlRecord, "", "  ")
@@ -49,8 +77,9 @@ func (f *Format) Serialize(w io.Writer, records []*hubv1.Record, opts *format.Se
 }
 
 // hubToSpoke converts a hub record to the Dublin Core spoke proto struct.
-func hubToSpoke(record *hubv1.Record) (*dcv1.Record, error) {
+func hubToSpoke(record *hubv1.Record, opts *format.SerializeOptions) (*dcv1.Record, error) {
 	dc := &dcv1.Record{}
+	qualified := opts.Variant == VariantQualified
 
 	// Title
 	if record.Title != "" {
@@ -80,15 +109,27 @@ func hubToSpoke(record *hubv1.Record) (*dcv1.Record, error) {
 
 	// Description/Abstract
 	if record.Abstract != "" {
-		dc.Description = []*dcv1.LocalizedString{{Value: record.Abstract}}
+		if qualified {
+			dc.Abstract = []*dcv1.LocalizedString{{Value: record.Abstract}}
+		} else {
+			dc.Description = []*dcv1.LocalizedString{{Value: record.Abstract}}
+		}
 	}
 
-	// Dates
+	// Dates. Qualified DC gives the first date of each refined type its
+	// dcterms element; the rest stay dc:date.
 	for _, d := range record.Dates {
-		dateStr := formatDate(d)
-		if dateStr != "" {
-			dc.Date = append(dc.Date, &dcv1.Date{Value: dateStr})
+		dateStr := format.FormatDate(d, opts.Dates)
+		if dateStr == "" {
+			continue
 		}
+		if qualified {
+			if term := dateRefinement(dc, d.Type); term != nil && *term == "" {
+				*term = dateStr
+				continue
+			}
+		}
+		dc.Date = append(dc.Date, &dcv1.Date{Value: dateStr})
 	}
 
 	// Language
@@ -113,8 +154,15 @@ func hubToSpoke(record *hubv1.Record) (*dcv1.Record, error) {
 		dc.Type = []*dcv1.TypeValue{{Value: typeValue}}
 	}
 
-	// Rights
+	// Rights. Qualified DC moves license URIs to dcterms:license.
 	for _, r := range record.Rights {
+		if qualified && r.Uri != "" {
+			dc.License = append(dc.License, r.Uri)
+			if r.Statement == "" {
+				continue
+			}
+			r = &hubv1.Rights{Statement: r.Statement}
+		}
 		rights := &dcv1.Rights{}
 		if r.Uri != "" {
 			rights.Uri = r.Uri
@@ -131,26 +179,94 @@ func hubToSpoke(record *hubv1.Record) (*dcv1.Record, error) {
 		if relValue == "" {
 			relValue = rel.TargetTitle
 		}
-		if relValue != "" {
-			dc.Relation = append(dc.Relation, &dcv1.Relation{Value: relValue})
+		if relValue == "" {
+			continue
 		}
+		if qualified {
+			if term := relationRefinement(dc, rel.Type); term != nil {
+				*term = append(*term, relValue)
+				continue
+			}
+		}
+		dc.Relation = append(dc.Relation, &dcv1.Relation{Value: relValue})
+	}
+
+	if qualified {
+		applyRefinements(dc, record)
 	}
 
 	return dc, nil
 }
 
-// formatDate formats a date value to ISO 8601 string.
-func formatDate(d *hubv1.DateValue) string {
-	if d.Year == 0 {
-		return d.Raw
+// applyRefinements sets the dcterms elements that have no simple DC
+// equivalent.
+func applyRefinements(dc *dcv1.Record, record *hubv1.Record) {
+	for _, alt := range record.AltTitle {
+		dc.Alternative = append(dc.Alternative, &dcv1.LocalizedString{Value: alt})
+	}
+	if record.TableOfContents != "" {
+		dc.TableOfContents = []*dcv1.LocalizedString{{Value: record.TableOfContents}}
+	}
+	if record.PhysicalDesc != "" {
+		dc.Extent = []string{record.PhysicalDesc}
 	}
-	if d.Month == 0 {
-		return fmt.Sprintf("%04d", d.Year)
+	if record.AccessCondition != "" {
+		dc.AccessRights = []string{record.AccessCondition}
 	}
-	if d.Day == 0 {
-		return fmt.Sprintf("%04d-%02d", d.Year, d.Month)
+	if record.RightsHolder != "" {
+		dc.RightsHolder = []*dcv1.Agent{{Name: record.RightsHolder}}
+	}
+	dc.BibliographicCitation = record.PreferredCitation
+}
+
+// dateRefinement returns the dcterms date element for a hub date type, or
+// nil when the type has none.
+func dateRefinement(dc *dcv1.Record, t hubv1.DateType) *string {
+	switch t {
+	case hubv1.DateType_DATE_TYPE_ISSUED:
+		return &dc.Issued
+	case hubv1.DateType_DATE_TYPE_CREATED:
+		return &dc.Created
+	case hubv1.DateType_DATE_TYPE_UPDATED:
+		return &dc.Modified
+	case hubv1.DateType_DATE_TYPE_AVAILABLE:
+		return &dc.Available
+	default:
+		return nil
+	}
+}
+
+// relationRefinement returns the dcterms relation element for a hub
+// relation type, or nil when plain dc:relation is the closest fit.
+func relationRefinement(dc *dcv1.Record, t hubv1.RelationType) *[]string {
+	switch t {
+	case hubv1.RelationType_RELATION_TYPE_PART_OF,
+		hubv1.RelationType_RELATION_TYPE_MEMBER_OF,
+		hubv1.RelationType_RELATION_TYPE_IN_SERIES:
+		return &dc.IsPartOf
+	case hubv1.RelationType_RELATION_TYPE_HAS_PART,
+		hubv1.RelationType_RELATION_TYPE_HAS_MEMBER:
+		return &dc.HasPart
+	case hubv1.RelationType_RELATION_TYPE_VERSION_OF:
+		return &dc.IsVersionOf
+	case hubv1.RelationType_RELATION_TYPE_HAS_VERSION:
+		return &dc.HasVersion
+	case hubv1.RelationType_RELATION_TYPE_REPLACES:
+		return &dc.Replaces
+	case hubv1.RelationType_RELATION_TYPE_IS_REPLACED_BY:
+		return &dc.IsReplacedBy
+	case hubv1.RelationType_RELATION_TYPE_FORMAT_OF:
+		return &dc.IsFormatOf
+	case hubv1.RelationType_RELATION_TYPE_HAS_FORMAT:
+		return &dc.HasFormat
+	case hubv1.RelationType_RELATION_TYPE_REFERENCES,
+		hubv1.RelationType_RELATION_TYPE_CITES:
+		return &dc.References
+	case hubv1.RelationType_RELATION_TYPE_IS_CITED_BY:
+		return &dc.IsReferencedBy
+	default:
+		return nil
 	}
-	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
 }
 
 // identifierTypeToScheme maps hub identifier type to DC scheme.
@@ -166,6 +282,12 @@ func identifierTypeToScheme(t hubv1.IdentifierType) string {
 		return "URI"
 	case hubv1.IdentifierType_IDENTIFIER_TYPE_HANDLE:
 		return "HDL"
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_ARK:
+		return "ARK"
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_URN:
+		return "URN"
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_PURL:
+		return "URI"
 	default:
 		return ""
 	}
@@ -199,10 +321,21 @@ func resourceTypeToString(rt hubv1.ResourceTypeValue) string {
 }
 
 // spokeToXML converts a spoke proto struct to an XML-marshalable struct.
-func spokeToXML(spoke *dcv1.Record) *XMLRecord {
+// The oai_dc schema allows only the 15 simple elements, so that variant
+// drops any dcterms refinements.
+func spokeToXML(spoke *dcv1.Record, variant string) *XMLRecord {
 	xmlRec := &XMLRecord{
-		XmlnsDC:      "http://purl.org/dc/elements/1.1/",
-		XmlnsDCTerms: "http://purl.org/dc/terms/",
+		XMLName: xml.Name{Local: "metadata"},
+		XmlnsDC: "http://purl.org/dc/elements/1.1/",
+	}
+	if variant == VariantOAIDC {
+		xmlRec.XMLName = xml.Name{Local: "oai_dc:dc"}
+		xmlRec.XmlnsOAIDC = oaiDCNamespace
+		xmlRec.XmlnsXSI = "http://www.w3.org/2001/XMLSchema-instance"
+		xmlRec.SchemaLocation = oaiDCNamespace + " http://www.openarchives.org/OAI/2.0/oai_dc.xsd"
+	} else {
+		xmlRec.XmlnsDCTerms = "http://purl.org/dc/terms/"
+		xmlRec.XMLTerms = spokeTermsToXML(spoke)
 	}
 
 	for _, t := range spoke.Title {
@@ -260,13 +393,55 @@ func spokeToXML(spoke *dcv1.Record) *XMLRecord {
 	return xmlRec
 }
 
+// spokeTermsToXML collects the dcterms refinements set on a spoke record.
+func spokeTermsToXML(spoke *dcv1.Record) XMLTerms {
+	terms := XMLTerms{
+		Created:               spoke.Created,
+		Issued:                spoke.Issued,
+		Modified:              spoke.Modified,
+		Available:             spoke.Available,
+		Extent:                spoke.Extent,
+		IsPartOf:              spoke.IsPartOf,
+		HasPart:               spoke.HasPart,
+		IsVersionOf:           spoke.IsVersionOf,
+		HasVersion:            spoke.HasVersion,
+		IsReplacedBy:          spoke.IsReplacedBy,
+		Replaces:              spoke.Replaces,
+		IsFormatOf:            spoke.IsFormatOf,
+		HasFormat:             spoke.HasFormat,
+		References:            spoke.References,
+		IsReferencedBy:        spoke.IsReferencedBy,
+		Requires:              spoke.Requires,
+		IsRequiredBy:          spoke.IsRequiredBy,
+		License:               spoke.License,
+		AccessRights:          spoke.AccessRights,
+		BibliographicCitation: spoke.BibliographicCitation,
+	}
+	for _, a := range spoke.Alternative {
+		terms.Alternative = append(terms.Alternative, a.Value)
+	}
+	for _, a := range spoke.Abstract {
+		terms.Abstract = append(terms.Abstract, a.Value)
+	}
+	for _, t := range spoke.TableOfContents {
+		terms.TableOfContents = append(terms.TableOfContents, t.Value)
+	}
+	for _, h := range spoke.RightsHolder {
+		terms.RightsHolder = append(terms.RightsHolder, h.Name)
+	}
+	return terms
+}
+
 // XML types for Dublin Core marshaling.
 
 // XMLRecord represents the root Dublin Core record element.
 type XMLRecord struct {
-	XMLName      xml.Name `xml:"metadata"`
-	XmlnsDC      string   `xml:"xmlns:dc,attr"`
-	XmlnsDCTerms string   `xml:"xmlns:dcterms,attr"`
+	XMLName        xml.Name
+	XmlnsOAIDC     string `xml:"xmlns:oai_dc,attr,omitempty"`
+	XmlnsDC        string `xml:"xmlns:dc,attr"`
+	XmlnsDCTerms   string `xml:"xmlns:dcterms,attr,omitempty"`
+	XmlnsXSI       string `xml:"xmlns:xsi,attr,omitempty"`
+	SchemaLocation string `xml:"xsi:schemaLocation,attr,omitempty"`
 
 	Title       []string `xml:"dc:title,omitempty"`
 	Creator     []string `xml:"dc:creator,omitempty"`
@@ -283,4 +458,35 @@ type XMLRecord struct {
 	Relation    []string `xml:"dc:relation,omitempty"`
 	Coverage    []string `xml:"dc:coverage,omitempty"`
 	Rights      []string `xml:"dc:rights,omitempty"`
+
+	XMLTerms
+}
+
+// XMLTerms holds the qualified dcterms elements, written after the simple
+// ones.
+type XMLTerms struct {
+	Alternative           []string `xml:"dcterms:alternative,omitempty"`
+	Abstract              []string `xml:"dcterms:abstract,omitempty"`
+	TableOfContents       []string `xml:"dcterms:tableOfContents,omitempty"`
+	Created               string   `xml:"dcterms:created,omitempty"`
+	Issued                string   `xml:"dcterms:issued,omitempty"`
+	Modified              string   `xml:"dcterms:modified,omitempty"`
+	Available             string   `xml:"dcterms:available,omitempty"`
+	Extent                []string `xml:"dcterms:extent,omitempty"`
+	IsPartOf              []string `xml:"dcterms:isPartOf,omitempty"`
+	HasPart               []string `xml:"dcterms:hasPart,omitempty"`
+	IsVersionOf           []string `xml:"dcterms:isVersionOf,omitempty"`
+	HasVersion            []string `xml:"dcterms:hasVersion,omitempty"`
+	IsReplacedBy          []string `xml:"dcterms:isReplacedBy,omitempty"`
+	Replaces              []string `xml:"dcterms:replaces,omitempty"`
+	IsFormatOf            []string `xml:"dcterms:isFormatOf,omitempty"`
+	HasFormat             []string `xml:"dcterms:hasFormat,omitempty"`
+	References            []string `xml:"dcterms:references,omitempty"`
+	IsReferencedBy        []string `xml:"dcterms:isReferencedBy,omitempty"`
+	Requires              []string `xml:"dcterms:requires,omitempty"`
+	IsRequiredBy          []string `xml:"dcterms:isRequiredBy,omitempty"`
+	License               []string `xml:"dcterms:license,omitempty"`
+	AccessRights          []string `xml:"dcterms:accessRights,omitempty"`
+	RightsHolder          []string `xml:"dcterms:rightsHolder,omitempty"`
+	BibliographicCitation string   `xml:"dcterms:bibliographicCitation,omitempty"`
 }
@@ -0,0 +1,136 @@
+package dublincore
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+)
+
+func variantRecord() *hubv1.Record {
+	return &hubv1.Record{
+		Title:    "Variant Test",
+		AltTitle: []string{"Another Title"},
+		Abstract: "An abstract.",
+		Contributors: []*hubv1.Contributor{
+			{Name: "Lee, Ana", Role: "author"},
+		},
+		Dates: []*hubv1.DateValue{
+			{Type: hubv1.DateType_DATE_TYPE_ISSUED, Year: 2020, Month: 5, Precision: hubv1.DatePrecision_DATE_PRECISION_MONTH},
+			{Type: hubv1.DateType_DATE_TYPE_CAPTURED, Year: 2019, Precision: hubv1.DatePrecision_DATE_PRECISION_YEAR},
+		},
+		PhysicalDesc: "150 pages",
+		Rights: []*hubv1.Rights{
+			{Uri: "https://creativecommons.org/licenses/by/4.0/"},
+		},
+		Relations: []*hubv1.Relation{
+			{Type: hubv1.RelationType_RELATION_TYPE_PART_OF, TargetTitle: "Lehigh Review"},
+			{Type: hubv1.RelationType_RELATION_TYPE_SUPPLEMENTS, TargetUri: "https://example.org/data"},
+		},
+	}
+}
+
+func serializeVariant(t *testing.T, variant string) string {
+	t.Helper()
+	opts := format.NewSerializeOptions()
+	opts.Variant = variant
+
+	var buf bytes.Buffer
+	if err := (&Format{}).Serialize(&buf, []*hubv1.Record{variantRecord()}, opts); err != nil {
+		t.Fatalf("Serialize(%q) failed: %v", variant, err)
+	}
+	return buf.String()
+}
+
+func TestSerializeSimple(t *testing.T) {
+	out := serializeVariant(t, "")
+
+	for _, want := range []string{
+		"<metadata ",
+		"<dc:description>An abstract.</dc:description>",
+		"<dc:date>2020-05</dc:date>",
+		"<dc:relation>Lehigh Review</dc:relation>",
+		"<dc:rights>https://creativecommons.org/licenses/by/4.0/</dc:rights>",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %s:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "<dcterms:") {
+		t.Errorf("simple output has dcterms elements:\n%s", out)
+	}
+}
+
+func TestSerializeOAIDC(t *testing.T) {
+	out := serializeVariant(t, VariantOAIDC)
+
+	for _, want := range []string{
+		`<oai_dc:dc xmlns:oai_dc="http://www.openarchives.org/OAI/2.0/oai_dc/" xmlns:dc="http://purl.org/dc/elements/1.1/"`,
+		`xsi:schemaLocation="http://www.openarchives.org/OAI/2.0/oai_dc/ http://www.openarchives.org/OAI/2.0/oai_dc.xsd"`,
+		"<dc:title>Variant Test</dc:title>",
+		"</oai_dc:dc>",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %s:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "dcterms") {
+		t.Errorf("oai_dc output mentions dcterms:\n%s", out)
+	}
+
+	// The output parses back as oai_dc
+	records, err := (&Format{}).Parse(strings.NewReader(out), nil)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(records) != 1 || records[0].Title != "Variant Test" {
+		t.Errorf("round trip: got %v", records)
+	}
+}
+
+func TestSerializeQualified(t *testing.T) {
+	out := serializeVariant(t, VariantQualified)
+
+	for _, want := range []string{
+		"<dcterms:alternative>Another Title</dcterms:alternative>",
+		"<dcterms:abstract>An abstract.</dcterms:abstract>",
+		"<dcterms:issued>2020-05</dcterms:issued>",
+		"<dc:date>2019</dc:date>",
+		"<dcterms:extent>150 pages</dcterms:extent>",
+		"<dcterms:isPartOf>Lehigh Review</dcterms:isPartOf>",
+		"<dc:relation>https://example.org/data</dc:relation>",
+		"<dcterms:license>https://creativecommons.org/licenses/by/4.0/</dcterms:license>",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %s:\n%s", want, out)
+		}
+	}
+	for _, unwanted := range []string{"<dc:description>", "<dc:rights>", "<dc:date>2020-05</dc:date>"} {
+		if strings.Contains(out, unwanted) {
+			t.Errorf("output has %s:\n%s", unwanted, out)
+		}
+	}
+
+	// Refinements survive a round trip
+	records, err := (&Format{}).Parse(strings.NewReader(out), nil)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	r := records[0]
+	if r.Abstract != "An abstract." || r.PhysicalDesc != "150 pages" || len(r.AltTitle) != 1 {
+		t.Errorf("round trip: abstract %q, extent %q, alt titles %v", r.Abstract, r.PhysicalDesc, r.AltTitle)
+	}
+	if len(r.Relations) != 2 || r.Relations[1].Type != hubv1.RelationType_RELATION_TYPE_PART_OF {
+		t.Errorf("round trip relations: got %v", r.Relations)
+	}
+}
+
+func TestSerializeUnknownVariant(t *testing.T) {
+	opts := format.NewSerializeOptions()
+	opts.Variant = "rdf"
+	if err := (&Format{}).Serialize(&bytes.Buffer{}, []*hubv1.Record{variantRecord()}, opts); err == nil {
+		t.Error("expected an error for an unknown variant")
+	}
+}
@@ -0,0 +1,65 @@
+// Package ead provides a parser for Encoded Archival Description (EAD)
+// finding aids, in both EAD2002 and EAD3.
+//
+// A finding aid becomes one hub record for the collection (archdesc) and
+// one per component (<c> or <c01>…<c12>), in document order. Each
+// component has a member_of relation to its parent, whose target ID is
+// the parent's local identifier, so hierarchies survive conversion and
+// membership paths can be computed across the batch. The same keys are
+// kept in the id and parent_id extra fields for Islandora Workbench
+// hierarchical ingest.
+//
+// The mapping covers, for each unit's did:
+//   - unittitle, unitid (as a holding call number), and unitdate or EAD3
+//     unitdatestructured (normalized dates are parsed as EDTF)
+//   - abstract, physdesc and physdescstructured, and langmaterial
+//   - origination names as creators, and repository as the holding
+//     institution
+//   - box and folder containers as the archival location
+//
+// and scopecontent, bioghist, accessrestrict, userestrict, controlaccess
+// headings (subjects and genres), and dao links (files). Bulk dates are
+// kept in the ead_bulk_date extra field.
+package ead
+
+import (
+	"bytes"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+)
+
+// Format implements the EAD finding aid format.
+type Format struct{}
+
+// Ensure Format implements the interfaces
+var (
+	_ format.Format = (*Format)(nil)
+	_ format.Parser = (*Format)(nil)
+)
+
+// Name returns the format identifier.
+func (f *Format) Name() string {
+	return "ead"
+}
+
+// Description returns a human-readable format description.
+func (f *Format) Description() string {
+	return "Encoded Archival Description finding aids (EAD2002, EAD3)"
+}
+
+// Extensions returns file extensions associated with this format.
+func (f *Format) Extensions() []string {
+	return []string{"xml"}
+}
+
+// CanParse returns true if the input looks like an EAD document.
+func (f *Format) CanParse(peek []byte) bool {
+	return bytes.Contains(peek, []byte("urn:isbn:1-931666-22-9")) ||
+		bytes.Contains(peek, []byte("ead3.archivists.org")) ||
+		bytes.Contains(peek, []byte("<ead>")) ||
+		bytes.Contains(peek, []byte("<ead "))
+}
+
+func init() {
+	format.Register(&Format{})
+}
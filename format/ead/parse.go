@@ -0,0 +1,427 @@
+package ead
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/helpers"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+// aggregateLevels are description levels that gather other units. Records
+// at these levels are collections; everything else is archival material.
+var aggregateLevels = map[string]bool{
+	"collection": true,
+	"fonds":      true,
+	"subfonds":   true,
+	"recordgrp":  true,
+	"subgrp":     true,
+	"series":     true,
+	"subseries":  true,
+}
+
+// vocabularies maps controlaccess source attributes to hub vocabularies.
+var vocabularies = map[string]hubv1.SubjectVocabulary{
+	"lcsh":     hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LCSH,
+	"lcnaf":    hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LCNAF,
+	"naf":      hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LCNAF,
+	"aat":      hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_AAT,
+	"fast":     hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_FAST,
+	"mesh":     hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_MESH,
+	"tgn":      hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_GETTY_TGN,
+	"gmgpc":    hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_GENRE,
+	"lcgft":    hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_GENRE,
+	"local":    hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LOCAL,
+	"ingest":   hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LOCAL,
+	"keywords": hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_KEYWORDS,
+}
+
+// Parse reads an EAD finding aid and returns a record for the collection
+// followed by a record per component, in document order.
+func (f *Format) Parse(r io.Reader, _ *format.ParseOptions) ([]*hubv1.Record, error) {
+	var doc EAD
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("parsing EAD XML: %w", err)
+	}
+
+	p := &parser{version: "ead2002"}
+	if doc.Control != nil || strings.Contains(doc.XMLName.Space, "ead3") {
+		p.version = "ead3"
+	}
+	if doc.Header != nil {
+		p.eadID = strings.TrimSpace(string(doc.Header.EADID))
+	}
+	if doc.Control != nil {
+		p.eadID = strings.TrimSpace(string(doc.Control.RecordID))
+	}
+
+	ad := &doc.ArchDesc
+	if ad.Level == "" && len(ad.Did.UnitTitle) == 0 && ad.Dsc == nil {
+		return nil, fmt.Errorf("no archdesc found in EAD document")
+	}
+
+	p.rootKey = p.eadID
+	if p.rootKey == "" {
+		p.rootKey = first(ad.Did.UnitID)
+	}
+	if p.rootKey == "" {
+		p.rootKey = "ead"
+	}
+	p.repository = first(ad.Did.Repository)
+
+	root := p.describe(&ad.Description, ad.Level, ad.OtherLevel, p.rootKey)
+	p.collection = root.Title
+	if root.ResourceType.Original == "" {
+		// A finding aid describes a collection even when archdesc doesn't say so
+		root.ResourceType.Type = hubv1.ResourceTypeValue_RESOURCE_TYPE_COLLECTION
+	}
+	if ad.Dsc != nil {
+		p.walk(ad.Dsc.Children, root, p.rootKey, "", "")
+	}
+	return p.records, nil
+}
+
+// parser carries finding-aid-wide context while walking components.
+type parser struct {
+	version    string
+	eadID      string
+	rootKey    string
+	repository string
+	collection string
+	records    []*hubv1.Record
+}
+
+// walk emits a record for each component in children, then recurses into
+// its own components. path numbers components by position, giving those
+// without an id attribute a stable key under the collection's. series is
+// the title of the enclosing series, if any.
+func (p *parser) walk(children []Component, parent *hubv1.Record, parentKey, path, series string) {
+	n := 0
+	for i := range children {
+		c := &children[i]
+		if !isComponent(c.XMLName.Local) {
+			continue
+		}
+		n++
+		cpath := strconv.Itoa(n)
+		if path != "" {
+			cpath = path + "." + cpath
+		}
+		key := strings.TrimSpace(c.ID)
+		if key == "" {
+			key = p.rootKey + "-" + cpath
+		}
+
+		rec := p.describe(&c.Description, c.Level, c.OtherLevel, key)
+		rel := hub.NewRelation(hubv1.RelationType_RELATION_TYPE_MEMBER_OF, parent.Title)
+		rel.TargetId = parentKey
+		rel.TargetIdType = hubv1.IdentifierType_IDENTIFIER_TYPE_LOCAL
+		rel.TargetResourceType = parent.ResourceType.Type
+		rec.Relations = append(rec.Relations, rel)
+		hub.SetExtra(rec, "parent_id", parentKey)
+
+		if p.collection != "" || series != "" {
+			if rec.ArchivalLocation == nil {
+				rec.ArchivalLocation = &hubv1.ArchivalLocation{}
+			}
+			rec.ArchivalLocation.Collection = p.collection
+			rec.ArchivalLocation.Series = series
+		}
+
+		childSeries := series
+		if strings.EqualFold(c.Level, "series") {
+			childSeries = rec.Title
+		}
+		p.walk(c.Children, rec, key, cpath, childSeries)
+	}
+}
+
+// isComponent reports whether an element name is <c> or <c01>…<c12>.
+func isComponent(local string) bool {
+	if local == "c" {
+		return true
+	}
+	if len(local) != 3 || local[0] != 'c' {
+		return false
+	}
+	n, err := strconv.Atoi(local[1:])
+	return err == nil && n >= 1 && n <= 12
+}
+
+// describe maps the descriptive elements of an archdesc or component to a
+// hub record and appends it to the parser's records.
+func (p *parser) describe(desc *Description, level, otherLevel, key string) *hubv1.Record {
+	did := &desc.Did
+	record := &hubv1.Record{
+		Title: first(did.UnitTitle),
+	}
+
+	// DACS allows a date in place of a title
+	if record.Title == "" {
+		for _, d := range did.UnitDate {
+			if v := strings.TrimSpace(d.Value); v != "" {
+				record.Title = v
+				break
+			}
+		}
+	}
+
+	record.ResourceType = resourceType(level, otherLevel)
+	record.Identifiers = append(record.Identifiers,
+		hub.NewIdentifier(key, hubv1.IdentifierType_IDENTIFIER_TYPE_LOCAL))
+	hub.SetExtra(record, "id", key)
+
+	if unitID := first(did.UnitID); unitID != "" {
+		record.Holdings = append(record.Holdings, &hubv1.Holding{
+			Institution:      p.repository,
+			CallNumber:       unitID,
+			CallNumberScheme: "local",
+		})
+	}
+
+	unitDates(record, did)
+
+	for _, o := range did.Origination {
+		for _, n := range o.Names {
+			if c := creator(n); c != nil {
+				record.Contributors = append(record.Contributors, c)
+			}
+		}
+	}
+
+	// did/abstract is the summary; scopecontent stands in when it's missing
+	scope := joined(desc.ScopeContent, "\n\n")
+	if abstract := joined(did.Abstract, "\n\n"); abstract != "" {
+		record.Abstract = abstract
+		record.Description = scope
+	} else {
+		record.Abstract = scope
+	}
+	for _, b := range desc.BiogHist {
+		if b != "" {
+			record.Notes = append(record.Notes, string(b))
+		}
+	}
+
+	record.PhysicalDesc = joined(append(append([]Text{}, did.PhysDesc...), did.PhysDescStructured...), "; ")
+	record.Language = languageCode(did.LangMaterial)
+
+	record.AccessCondition = joined(desc.AccessRestrict, "\n\n")
+	if use := joined(desc.UseRestrict, "\n\n"); use != "" {
+		record.Rights = append(record.Rights, &hubv1.Rights{Statement: use})
+	}
+
+	for _, ca := range desc.ControlAccess {
+		for _, h := range ca.Headings {
+			mapHeading(record, h)
+		}
+	}
+
+	for _, c := range did.Container {
+		v := strings.TrimSpace(c.Value)
+		if v == "" {
+			continue
+		}
+		if record.ArchivalLocation == nil {
+			record.ArchivalLocation = &hubv1.ArchivalLocation{}
+		}
+		switch c.Kind() {
+		case "box":
+			record.ArchivalLocation.Box = v
+		case "folder":
+			record.ArchivalLocation.Folder = v
+		}
+	}
+
+	for _, d := range append(append([]DAO{}, did.DAO...), desc.DAO...) {
+		if d.Href == "" {
+			continue
+		}
+		record.Files = append(record.Files, &hubv1.File{
+			Path: d.Href,
+			Name: d.Title,
+		})
+	}
+
+	record.SourceInfo = &hubv1.SourceInfo{
+		Format:        "ead",
+		FormatVersion: p.version,
+		SourceId:      key,
+	}
+
+	p.records = append(p.records, record)
+	return record
+}
+
+// resourceType maps an EAD description level to a hub resource type,
+// keeping the level (or otherlevel) as the original value.
+func resourceType(level, otherLevel string) *hubv1.ResourceType {
+	level = strings.ToLower(strings.TrimSpace(level))
+	original := level
+	if level == "otherlevel" && otherLevel != "" {
+		original = otherLevel
+	}
+
+	rt := &hubv1.ResourceType{
+		Type:       hubv1.ResourceTypeValue_RESOURCE_TYPE_ARCHIVAL_MATERIAL,
+		Original:   original,
+		Vocabulary: "ead",
+	}
+	if aggregateLevels[level] {
+		rt.Type = hubv1.ResourceTypeValue_RESOURCE_TYPE_COLLECTION
+	}
+	return rt
+}
+
+// unitDates maps unitdate and unitdatestructured to creation dates. Bulk
+// dates aren't the span of the unit, so they are kept in the ead_bulk_date
+// extra field instead.
+func unitDates(record *hubv1.Record, did *Did) {
+	add := func(normal, display, kind string) {
+		if strings.EqualFold(kind, "bulk") {
+			if display == "" {
+				display = normal
+			}
+			if display != "" {
+				hub.SetExtra(record, "ead_bulk_date", display)
+			}
+			return
+		}
+		if dv := unitDate(normal, display); dv != nil {
+			record.Dates = append(record.Dates, dv)
+		}
+	}
+
+	for _, d := range did.UnitDate {
+		kind := d.Type
+		if kind == "" {
+			kind = d.UnitDateType
+		}
+		add(d.Normal, strings.TrimSpace(d.Value), kind)
+	}
+	for _, d := range did.UnitDateStructured {
+		var normal, display string
+		switch {
+		case d.DateRange != nil:
+			from, to := d.DateRange.FromDate, d.DateRange.ToDate
+			normal = from.StandardDate + "/" + to.StandardDate
+			display = strings.TrimSpace(from.Value) + "-" + strings.TrimSpace(to.Value)
+			if from.StandardDate == "" || to.StandardDate == "" {
+				normal = ""
+			}
+		case d.DateSingle != nil:
+			normal = d.DateSingle.StandardDate
+			display = strings.TrimSpace(d.DateSingle.Value)
+		}
+		add(normal, strings.Trim(display, "-"), d.UnitDateType)
+	}
+}
+
+// unitDate parses a normalized date, falling back to the display text
+// alone when there is no usable normal form.
+func unitDate(normal, display string) *hubv1.DateValue {
+	dateType := hubv1.DateType_DATE_TYPE_CREATED
+	if normal != "" {
+		if dv, err := helpers.ParseEDTF(normal, dateType); err == nil {
+			if display != "" {
+				dv.Raw = display
+			}
+			return dv
+		}
+	}
+	if display == "" {
+		return nil
+	}
+	return &hubv1.DateValue{Type: dateType, Raw: display}
+}
+
+// creator maps an origination name to a contributor.
+func creator(n Name) *hubv1.Contributor {
+	name := n.Text()
+	if name == "" {
+		return nil
+	}
+
+	// EAD2002 has role and EAD3 relator, either a relator code or a label
+	role := strings.TrimSpace(n.Role)
+	if role == "" {
+		role = strings.TrimSpace(n.Relator)
+	}
+	code := "cre"
+	if role != "" {
+		code = helpers.NormalizeRole(role)
+	}
+
+	c := &hubv1.Contributor{Name: name, Role: role}
+	if _, ok := helpers.MARCRelators[code]; ok {
+		c.Role = helpers.RelatorLabel(code)
+		c.RoleCode = "relators:" + code
+	}
+
+	switch n.XMLName.Local {
+	case "persname":
+		c.Type = hubv1.ContributorType_CONTRIBUTOR_TYPE_PERSON
+		c.ParsedName = helpers.ParseName(name)
+	case "corpname":
+		c.Type = hubv1.ContributorType_CONTRIBUTOR_TYPE_ORGANIZATION
+	}
+	return c
+}
+
+// mapHeading adds a controlaccess heading to the record's subjects, or to
+// its genres for genreform.
+func mapHeading(record *hubv1.Record, h Name) {
+	value := h.Text()
+	if value == "" {
+		return
+	}
+
+	s := &hubv1.Subject{
+		Value:      value,
+		Vocabulary: vocabularies[strings.ToLower(h.Source)],
+	}
+	if auth := h.Authority(); strings.HasPrefix(auth, "http") {
+		s.Uri = auth
+	} else if auth != "" {
+		s.SourceId = auth
+	}
+
+	switch h.XMLName.Local {
+	case "genreform":
+		s.Type = hubv1.SubjectType_SUBJECT_TYPE_GENRE
+		record.Genres = append(record.Genres, s)
+		return
+	case "persname", "corpname", "famname", "name":
+		s.Type = hubv1.SubjectType_SUBJECT_TYPE_NAME
+	case "geogname":
+		s.Type = hubv1.SubjectType_SUBJECT_TYPE_GEOGRAPHIC
+	case "title":
+		s.Type = hubv1.SubjectType_SUBJECT_TYPE_TITLE
+	case "subject", "occupation", "function":
+		s.Type = hubv1.SubjectType_SUBJECT_TYPE_TOPIC
+	default:
+		return
+	}
+	record.Subjects = append(record.Subjects, s)
+}
+
+// languageCode returns the first language code of the materials.
+func languageCode(lms []LangMaterial) string {
+	for _, lm := range lms {
+		langs := lm.Languages
+		for _, set := range lm.LanguageSets {
+			langs = append(langs, set.Languages...)
+		}
+		for _, l := range langs {
+			if code := strings.TrimSpace(l.LangCode); code != "" {
+				return code
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,228 @@
+package ead
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+func parseFile(t *testing.T, path string) []*hubv1.Record {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	records, err := (&Format{}).Parse(f, nil)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	return records
+}
+
+func TestParseEAD2002(t *testing.T) {
+	records := parseFile(t, "testdata/ead2002.xml")
+	if len(records) != 4 {
+		t.Fatalf("expected collection and 3 components, got %d records", len(records))
+	}
+
+	coll := records[0]
+	if coll.Title != "Bethlehem Steel Corporation photographs" {
+		t.Errorf("Title: got %q", coll.Title)
+	}
+	if coll.ResourceType.Type != hubv1.ResourceTypeValue_RESOURCE_TYPE_COLLECTION || coll.ResourceType.Original != "collection" {
+		t.Errorf("ResourceType: got %v", coll.ResourceType)
+	}
+	if id := hub.GetIdentifier(coll, hubv1.IdentifierType_IDENTIFIER_TYPE_LOCAL); id == nil || id.Value != "SC-0042" {
+		t.Errorf("local identifier: got %v", id)
+	}
+	if len(coll.Holdings) != 1 || coll.Holdings[0].CallNumber != "SC MS 0042" ||
+		coll.Holdings[0].Institution != "Lehigh University Special Collections" {
+		t.Errorf("Holdings: got %v", coll.Holdings)
+	}
+	if coll.Abstract != "Photographs of plant operations in Bethlehem, Pennsylvania." {
+		t.Errorf("Abstract: got %q", coll.Abstract)
+	}
+	if coll.Description != "The collection documents the Bethlehem plant.\n\nIt includes aerial views." {
+		t.Errorf("Description: got %q", coll.Description)
+	}
+	if len(coll.Notes) != 1 || coll.Notes[0] != "Founded in 1857." {
+		t.Errorf("Notes: got %v", coll.Notes)
+	}
+	if coll.PhysicalDesc != "12 linear feet" || coll.Language != "eng" {
+		t.Errorf("PhysicalDesc/Language: got %q, %q", coll.PhysicalDesc, coll.Language)
+	}
+	if coll.AccessCondition != "Open for research." || len(coll.Rights) != 1 ||
+		coll.Rights[0].Statement != "Permission to publish is required." {
+		t.Errorf("access/use: got %q, %v", coll.AccessCondition, coll.Rights)
+	}
+
+	if len(coll.Dates) != 1 {
+		t.Fatalf("expected only the inclusive date, got %v", coll.Dates)
+	}
+	if d := coll.Dates[0]; d.Type != hubv1.DateType_DATE_TYPE_CREATED || !d.IsRange || d.Year != 1900 || d.EndYear != 1950 || d.Raw != "1900-1950" {
+		t.Errorf("inclusive date: got %v", d)
+	}
+	if got := hub.GetExtraString(coll, "ead_bulk_date"); got != "bulk 1920-1935" {
+		t.Errorf("ead_bulk_date: got %q", got)
+	}
+
+	if len(coll.Contributors) != 1 {
+		t.Fatalf("expected 1 creator, got %v", coll.Contributors)
+	}
+	if c := coll.Contributors[0]; c.Type != hubv1.ContributorType_CONTRIBUTOR_TYPE_ORGANIZATION || c.RoleCode != "relators:pht" {
+		t.Errorf("creator: got %v", c)
+	}
+
+	if len(coll.Subjects) != 2 {
+		t.Fatalf("expected 2 subjects, got %v", coll.Subjects)
+	}
+	if s := coll.Subjects[0]; s.Vocabulary != hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LCSH ||
+		s.Uri != "http://id.loc.gov/authorities/subjects/sh85127625" || s.Type != hubv1.SubjectType_SUBJECT_TYPE_TOPIC {
+		t.Errorf("topical subject: got %v", s)
+	}
+	if coll.Subjects[1].Type != hubv1.SubjectType_SUBJECT_TYPE_GEOGRAPHIC {
+		t.Errorf("geographic subject: got %v", coll.Subjects[1])
+	}
+	if len(coll.Genres) != 1 || coll.Genres[0].Vocabulary != hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_AAT {
+		t.Errorf("Genres: got %v", coll.Genres)
+	}
+	if got := coll.SourceInfo.GetFormatVersion(); got != "ead2002" {
+		t.Errorf("FormatVersion: got %q", got)
+	}
+
+	series := records[1]
+	if series.ResourceType.Type != hubv1.ResourceTypeValue_RESOURCE_TYPE_COLLECTION || hub.GetExtraString(series, "id") != "ref1" {
+		t.Errorf("series: got type %v, id %q", series.ResourceType, hub.GetExtraString(series, "id"))
+	}
+
+	file := records[2]
+	if file.Title != "Blast furnaces" || file.ResourceType.Type != hubv1.ResourceTypeValue_RESOURCE_TYPE_ARCHIVAL_MATERIAL {
+		t.Errorf("file: got %q, %v", file.Title, file.ResourceType)
+	}
+	loc := file.ArchivalLocation
+	if loc == nil || loc.Collection != coll.Title || loc.Series != "Plant Operations" || loc.Box != "1" || loc.Folder != "3" {
+		t.Errorf("ArchivalLocation: got %v", loc)
+	}
+	if len(file.Files) != 1 || file.Files[0].Path != "https://digital.example.edu/blast.jpg" || file.Files[0].Name != "Blast furnace view" {
+		t.Errorf("Files: got %v", file.Files)
+	}
+
+	untitled := records[3]
+	if untitled.Title != "circa 1945" || len(untitled.Dates) != 1 || untitled.Dates[0].Raw != "circa 1945" {
+		t.Errorf("untitled item: got %q, %v", untitled.Title, untitled.Dates)
+	}
+	if got := hub.GetExtraString(untitled, "id"); got != "SC-0042-2" {
+		t.Errorf("generated key: got %q", got)
+	}
+}
+
+func TestParseHierarchy(t *testing.T) {
+	records := parseFile(t, "testdata/ead2002.xml")
+
+	wantParents := map[string]string{
+		"ref1":        "SC-0042",
+		"SC-0042-1.1": "ref1",
+		"SC-0042-2":   "SC-0042",
+	}
+	if rels := hub.GetMemberOf(records[0]); len(rels) != 0 {
+		t.Errorf("collection should not be a member of anything, got %v", rels)
+	}
+	for _, r := range records[1:] {
+		id := hub.GetExtraString(r, "id")
+		rels := hub.GetMemberOf(r)
+		if len(rels) != 1 || rels[0].TargetId != wantParents[id] {
+			t.Errorf("%s member_of: got %v, want %s", id, rels, wantParents[id])
+		}
+		if got := hub.GetExtraString(r, "parent_id"); got != wantParents[id] {
+			t.Errorf("%s parent_id: got %q", id, got)
+		}
+	}
+
+	hub.ComputeMembershipPaths(records)
+	got := hub.MembershipTitles(records[2])
+	want := []string{"Bethlehem Steel Corporation photographs", "Plant Operations"}
+	if strings.Join(got, " > ") != strings.Join(want, " > ") {
+		t.Errorf("membership path: got %v, want %v", got, want)
+	}
+}
+
+func TestParseEAD3(t *testing.T) {
+	records := parseFile(t, "testdata/ead3.xml")
+	if len(records) != 3 {
+		t.Fatalf("expected 3 records, got %d", len(records))
+	}
+
+	coll := records[0]
+	if got := coll.SourceInfo.GetFormatVersion(); got != "ead3" {
+		t.Errorf("FormatVersion: got %q", got)
+	}
+	if hub.GetExtraString(coll, "id") != "lehigh-ua-007" || coll.ResourceType.Original != "recordgrp" {
+		t.Errorf("collection: got id %q, type %v", hub.GetExtraString(coll, "id"), coll.ResourceType)
+	}
+	if d := coll.Dates; len(d) != 1 || !d[0].IsRange || d[0].Year != 1894 || d[0].EndYear != 2020 || d[0].Raw != "1894-2020" {
+		t.Errorf("structured date range: got %v", d)
+	}
+	if coll.PhysicalDesc != "4 linear feet" || coll.Language != "eng" {
+		t.Errorf("PhysicalDesc/Language: got %q, %q", coll.PhysicalDesc, coll.Language)
+	}
+	if len(coll.Contributors) != 1 || coll.Contributors[0].Name != "Smith, Jane" || coll.Contributors[0].RoleCode != "relators:com" ||
+		coll.Contributors[0].ParsedName.GetFamily() != "Smith" {
+		t.Errorf("Contributors: got %v", coll.Contributors)
+	}
+	if len(coll.Subjects) != 1 || coll.Subjects[0].Value != "Student newspapers and periodicals--Pennsylvania" {
+		t.Errorf("Subjects: got %v", coll.Subjects)
+	}
+
+	vol := records[2]
+	if vol.ResourceType.Original != "volume" || vol.ResourceType.Type != hubv1.ResourceTypeValue_RESOURCE_TYPE_ARCHIVAL_MATERIAL {
+		t.Errorf("otherlevel: got %v", vol.ResourceType)
+	}
+	if d := vol.Dates; len(d) != 1 || d[0].Year != 1894 || d[0].Month != 1 {
+		t.Errorf("single date: got %v", d)
+	}
+	if vol.ArchivalLocation.GetBox() != "2" || vol.ArchivalLocation.GetSeries() != "Issues" {
+		t.Errorf("ArchivalLocation: got %v", vol.ArchivalLocation)
+	}
+	if len(vol.Files) != 1 || vol.Files[0].Path != "https://digital.example.edu/bw/v1.pdf" {
+		t.Errorf("Files: got %v", vol.Files)
+	}
+	if rels := hub.GetMemberOf(vol); len(rels) != 1 || rels[0].TargetId != "aspace_s1" || rels[0].TargetTitle != "Issues" {
+		t.Errorf("member_of: got %v", rels)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := map[string]string{
+		"empty":       "",
+		"not ead":     `<mods xmlns="http://www.loc.gov/mods/v3"><titleInfo><title>x</title></titleInfo></mods>`,
+		"no archdesc": `<ead xmlns="urn:isbn:1-931666-22-9"><eadheader><eadid>x</eadid></eadheader></ead>`,
+	}
+	for name, input := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := (&Format{}).Parse(strings.NewReader(input), nil); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestCanParse(t *testing.T) {
+	f := &Format{}
+	for _, path := range []string{"testdata/ead2002.xml", "testdata/ead3.xml"} {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !f.CanParse(data) {
+			t.Errorf("expected CanParse for %s", path)
+		}
+	}
+	if f.CanParse([]byte(`<mods xmlns="http://www.loc.gov/mods/v3"/>`)) {
+		t.Error("unexpected CanParse for MODS")
+	}
+}
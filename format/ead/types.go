@@ -0,0 +1,272 @@
+package ead
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+// EAD is a finding aid. Elements are matched by local name so EAD2002
+// (urn:isbn:1-931666-22-9) and EAD3 (http://ead3.archivists.org/schema/)
+// documents decode into the same structs.
+type EAD struct {
+	XMLName  xml.Name `xml:"ead"`
+	Header   *Header  `xml:"eadheader"` // EAD2002
+	Control  *Control `xml:"control"`   // EAD3
+	ArchDesc ArchDesc `xml:"archdesc"`
+}
+
+// Header is the EAD2002 eadheader.
+type Header struct {
+	EADID Text `xml:"eadid"`
+}
+
+// Control is the EAD3 control section.
+type Control struct {
+	RecordID Text `xml:"recordid"`
+}
+
+// ArchDesc describes the collection as a whole.
+type ArchDesc struct {
+	Level      string `xml:"level,attr"`
+	OtherLevel string `xml:"otherlevel,attr"`
+	Description
+	Dsc *Dsc `xml:"dsc"`
+}
+
+// Dsc holds the top-level components of the collection.
+type Dsc struct {
+	Children []Component `xml:",any"`
+}
+
+// Component is a <c> or numbered <c01>…<c12> element. Children holds
+// every unmatched child element; only components are kept when walking.
+type Component struct {
+	XMLName    xml.Name
+	ID         string `xml:"id,attr"`
+	Level      string `xml:"level,attr"`
+	OtherLevel string `xml:"otherlevel,attr"`
+	Description
+	Children []Component `xml:",any"`
+}
+
+// Description is the descriptive content shared by archdesc and
+// components.
+type Description struct {
+	Did            Did             `xml:"did"`
+	ScopeContent   []Text          `xml:"scopecontent"`
+	BiogHist       []Text          `xml:"bioghist"`
+	AccessRestrict []Text          `xml:"accessrestrict"`
+	UseRestrict    []Text          `xml:"userestrict"`
+	ControlAccess  []ControlAccess `xml:"controlaccess"`
+	DAO            []DAO           `xml:"dao"`
+}
+
+// Did is the descriptive identification of a unit.
+type Did struct {
+	UnitTitle          []Text               `xml:"unittitle"`
+	UnitID             []Text               `xml:"unitid"`
+	UnitDate           []UnitDate           `xml:"unitdate"`
+	UnitDateStructured []UnitDateStructured `xml:"unitdatestructured"` // EAD3
+	Abstract           []Text               `xml:"abstract"`
+	PhysDesc           []Text               `xml:"physdesc"`
+	PhysDescStructured []Text               `xml:"physdescstructured"` // EAD3
+	LangMaterial       []LangMaterial       `xml:"langmaterial"`
+	Origination        []Origination        `xml:"origination"`
+	Repository         []Text               `xml:"repository"`
+	Container          []Container          `xml:"container"`
+	DAO                []DAO                `xml:"dao"`
+}
+
+// UnitDate is a free-text date with an optional normalized (ISO 8601)
+// form in the normal attribute.
+type UnitDate struct {
+	Normal       string `xml:"normal,attr"`
+	Type         string `xml:"type,attr"`         // EAD2002
+	UnitDateType string `xml:"unitdatetype,attr"` // EAD3
+	Value        string `xml:",chardata"`
+}
+
+// UnitDateStructured is an EAD3 date given as a single date or a range.
+type UnitDateStructured struct {
+	UnitDateType string        `xml:"unitdatetype,attr"`
+	DateSingle   *StandardDate `xml:"datesingle"`
+	DateRange    *DateRange    `xml:"daterange"`
+}
+
+// StandardDate is an EAD3 date with its ISO 8601 form in standarddate.
+type StandardDate struct {
+	StandardDate string `xml:"standarddate,attr"`
+	Value        string `xml:",chardata"`
+}
+
+// DateRange is an EAD3 date range.
+type DateRange struct {
+	FromDate StandardDate `xml:"fromdate"`
+	ToDate   StandardDate `xml:"todate"`
+}
+
+// LangMaterial lists the languages of the materials.
+type LangMaterial struct {
+	Languages    []Language `xml:"language"`
+	LanguageSets []struct {
+		Languages []Language `xml:"language"`
+	} `xml:"languageset"` // EAD3
+}
+
+// Language is a language with its ISO 639-2b code.
+type Language struct {
+	LangCode string `xml:"langcode,attr"`
+}
+
+// Origination names the creators of the materials.
+type Origination struct {
+	Label string `xml:"label,attr"`
+	Names []Name `xml:",any"`
+}
+
+// Name is a persname, corpname, famname, or other access point. EAD3
+// splits the value into <part> elements.
+type Name struct {
+	XMLName        xml.Name
+	Source         string `xml:"source,attr"`
+	Role           string `xml:"role,attr"`
+	Relator        string `xml:"relator,attr"` // EAD3
+	AuthFileNumber string `xml:"authfilenumber,attr"`
+	Identifier     string `xml:"identifier,attr"` // EAD3
+	Parts          []Text `xml:"part"`
+	Value          string `xml:",chardata"`
+}
+
+// Text returns the name's value, joining EAD3 parts with the "--"
+// subdivision separator subject headings use.
+func (n Name) Text() string {
+	if len(n.Parts) > 0 {
+		parts := make([]string, 0, len(n.Parts))
+		for _, p := range n.Parts {
+			if p != "" {
+				parts = append(parts, string(p))
+			}
+		}
+		return strings.Join(parts, "--")
+	}
+	return strings.Join(strings.Fields(n.Value), " ")
+}
+
+// Authority returns the authority record URI or number, if any.
+func (n Name) Authority() string {
+	if n.Identifier != "" {
+		return n.Identifier
+	}
+	return n.AuthFileNumber
+}
+
+// ControlAccess groups controlled access headings.
+type ControlAccess struct {
+	Headings []Name `xml:",any"`
+}
+
+// Container is a physical container such as a box or folder.
+type Container struct {
+	Type      string `xml:"type,attr"`      // EAD2002
+	LocalType string `xml:"localtype,attr"` // EAD3
+	Value     string `xml:",chardata"`
+}
+
+// Kind returns the lowercased container type.
+func (c Container) Kind() string {
+	if c.LocalType != "" {
+		return strings.ToLower(c.LocalType)
+	}
+	return strings.ToLower(c.Type)
+}
+
+// DAO is a link to a digital surrogate. The href attribute is matched
+// with or without the xlink namespace.
+type DAO struct {
+	Href  string `xml:"href,attr"`
+	Title string `xml:"title,attr"`
+	Role  string `xml:"role,attr"`
+}
+
+// Text is element content with inline markup (emph, title, p, …)
+// flattened to plain text. Headings and addresses are dropped, and
+// paragraphs are kept apart with a blank line.
+type Text string
+
+// paragraphBreak marks the end of a <p> while collecting text; it cannot
+// appear in XML character data.
+const paragraphBreak = "\x00"
+
+// inlineElements format text within a line, so no space is added around
+// them (e.g. "<emph>W</emph>ork").
+var inlineElements = map[string]bool{
+	"emph": true, "title": true, "ref": true, "extref": true, "abbr": true,
+	"expan": true, "foreign": true, "quote": true, "num": true, "date": true,
+}
+
+// UnmarshalXML collects the character data beneath the element.
+func (t *Text) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var b strings.Builder
+	depth := 0
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch tok := tok.(type) {
+		case xml.CharData:
+			b.Write(tok)
+		case xml.StartElement:
+			switch tok.Name.Local {
+			case "head", "address":
+				if err := d.Skip(); err != nil {
+					return err
+				}
+				continue
+			}
+			depth++
+			if !inlineElements[tok.Name.Local] {
+				b.WriteByte(' ')
+			}
+		case xml.EndElement:
+			if depth == 0 {
+				var paras []string
+				for _, p := range strings.Split(b.String(), paragraphBreak) {
+					if p = strings.Join(strings.Fields(p), " "); p != "" {
+						paras = append(paras, p)
+					}
+				}
+				*t = Text(strings.Join(paras, "\n\n"))
+				return nil
+			}
+			depth--
+			switch {
+			case tok.Name.Local == "p":
+				b.WriteString(paragraphBreak)
+			case !inlineElements[tok.Name.Local]:
+				b.WriteByte(' ')
+			}
+		}
+	}
+}
+
+// first returns the first non-empty text.
+func first(texts []Text) string {
+	for _, t := range texts {
+		if t != "" {
+			return string(t)
+		}
+	}
+	return ""
+}
+
+// joined returns the non-empty texts separated by sep.
+func joined(texts []Text, sep string) string {
+	var parts []string
+	for _, t := range texts {
+		if t != "" {
+			parts = append(parts, string(t))
+		}
+	}
+	return strings.Join(parts, sep)
+}
@@ -0,0 +1,141 @@
+package format_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	_ "github.com/lehigh-university-libraries/crosswalk/format/arxiv"
+	_ "github.com/lehigh-university-libraries/crosswalk/format/bibtex"
+	_ "github.com/lehigh-university-libraries/crosswalk/format/crossref"
+	_ "github.com/lehigh-university-libraries/crosswalk/format/csl"
+	_ "github.com/lehigh-university-libraries/crosswalk/format/csv"
+	_ "github.com/lehigh-university-libraries/crosswalk/format/datacite"
+	_ "github.com/lehigh-university-libraries/crosswalk/format/drupal"
+	_ "github.com/lehigh-university-libraries/crosswalk/format/dspace_saf"
+	_ "github.com/lehigh-university-libraries/crosswalk/format/dublincore"
+	_ "github.com/lehigh-university-libraries/crosswalk/format/hubjsonl"
+	_ "github.com/lehigh-university-libraries/crosswalk/format/iiif"
+	_ "github.com/lehigh-university-libraries/crosswalk/format/islandora_workbench"
+	_ "github.com/lehigh-university-libraries/crosswalk/format/lido"
+	_ "github.com/lehigh-university-libraries/crosswalk/format/marc"
+	_ "github.com/lehigh-university-libraries/crosswalk/format/mods"
+	_ "github.com/lehigh-university-libraries/crosswalk/format/ocfl"
+	_ "github.com/lehigh-university-libraries/crosswalk/format/openaire"
+	_ "github.com/lehigh-university-libraries/crosswalk/format/parquet"
+	_ "github.com/lehigh-university-libraries/crosswalk/format/pbcore"
+	_ "github.com/lehigh-university-libraries/crosswalk/format/premis"
+	_ "github.com/lehigh-university-libraries/crosswalk/format/proquest"
+	_ "github.com/lehigh-university-libraries/crosswalk/format/rdf"
+	_ "github.com/lehigh-university-libraries/crosswalk/format/ris"
+	_ "github.com/lehigh-university-libraries/crosswalk/format/schemaorg"
+	_ "github.com/lehigh-university-libraries/crosswalk/format/scholix"
+	_ "github.com/lehigh-university-libraries/crosswalk/format/solr"
+	_ "github.com/lehigh-university-libraries/crosswalk/format/wikidata"
+	_ "github.com/lehigh-university-libraries/crosswalk/format/zenodo"
+)
+
+// TestSerializeEmpty checks that every serializer either writes a
+// well-formed empty document or reports ErrEmptyDocument.
+func TestSerializeEmpty(t *testing.T) {
+	tests := []struct {
+		format string
+		kind   string // "json", "xml", "text", "parquet", or "unsupported"
+	}{
+		{"arxiv", "unsupported"},
+		{"bibtex", "text"},
+		{"crossref", "unsupported"},
+		{"csl", "json"},
+		{"csv", "text"},
+		{"datacite", "unsupported"},
+		{"drupal", "json"},
+		{"dspace-saf", "unsupported"},
+		{"dublincore", "xml"},
+		{"hub-jsonl", "text"},
+		{"iiif", "json"},
+		{"islandora-workbench", "text"},
+		{"lido", "unsupported"},
+		{"marc", "xml"},
+		{"mods", "xml"},
+		{"ocfl", "unsupported"},
+		{"openaire", "unsupported"},
+		{"parquet", "parquet"},
+		{"pbcore", "unsupported"},
+		{"premis", "xml"},
+		{"proquest", "unsupported"},
+		{"rdf", "text"},
+		{"ris", "text"},
+		{"scholix", "json"},
+		{"schemaorg", "json"},
+		{"solr", "json"},
+		{"wikidata", "text"},
+		{"zenodo", "json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			s, err := format.GetSerializer(tt.format)
+			if err != nil {
+				t.Fatalf("GetSerializer: %v", err)
+			}
+
+			var buf bytes.Buffer
+			opts := format.NewSerializeOptions()
+			opts.IncludeHeader = true
+			err = s.Serialize(&buf, nil, opts)
+
+			if tt.kind == "unsupported" {
+				if !errors.Is(err, format.ErrEmptyDocument) {
+					t.Fatalf("err = %v, want ErrEmptyDocument", err)
+				}
+				if buf.Len() != 0 {
+					t.Errorf("expected no output, got %q", buf.String())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Serialize: %v", err)
+			}
+
+			switch tt.kind {
+			case "json":
+				var v []any
+				if err := json.Unmarshal(buf.Bytes(), &v); err != nil || len(v) != 0 {
+					t.Errorf("expected empty JSON array, got %q (%v)", buf.String(), err)
+				}
+			case "xml":
+				dec := xml.NewDecoder(&buf)
+				root := ""
+				for {
+					tok, err := dec.Token()
+					if err == io.EOF {
+						break
+					}
+					if err != nil {
+						t.Fatalf("invalid XML: %v", err)
+					}
+					if se, ok := tok.(xml.StartElement); ok && root == "" {
+						root = se.Name.Local
+					}
+				}
+				if root == "" {
+					t.Error("expected a root element")
+				}
+			case "parquet":
+				data := buf.Bytes()
+				if len(data) < 12 || !bytes.HasPrefix(data, []byte("PAR1")) || !bytes.HasSuffix(data, []byte("PAR1")) {
+					t.Errorf("expected a Parquet file, got % x", data)
+				}
+			case "text":
+				if strings.Contains(buf.String(), "\n\n") {
+					t.Errorf("unexpected blank lines in %q", buf.String())
+				}
+			}
+		})
+	}
+}
@@ -2,9 +2,14 @@
 package format
 
 import (
+	"errors"
+	"fmt"
 	"io"
+	"strings"
 
 	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/helpers"
+	"github.com/lehigh-university-libraries/crosswalk/helpers/lang"
 	"github.com/lehigh-university-libraries/crosswalk/mapping"
 )
 
@@ -32,15 +37,107 @@ type Parser interface {
 	Parse(r io.Reader, opts *ParseOptions) ([]*hubv1.Record, error)
 }
 
+// StreamParser is a parser that can hand records over one at a time as it
+// reads them, so very large inputs (a full repository export, a MARC dump)
+// are never held in memory at once. Parse on the same format returns the
+// same records in the same order.
+type StreamParser interface {
+	Parser
+
+	// ParseStream reads input and calls fn with each record in order. An
+	// error returned by fn stops parsing and is returned unchanged.
+	ParseStream(r io.Reader, opts *ParseOptions, fn func(*hubv1.Record) error) error
+}
+
+// ParseEach calls fn with each record p parses from r, streaming when p is
+// a StreamParser and parsing the whole input first otherwise.
+func ParseEach(p Parser, r io.Reader, opts *ParseOptions, fn func(*hubv1.Record) error) error {
+	if sp, ok := p.(StreamParser); ok {
+		return sp.ParseStream(r, opts, fn)
+	}
+	records, err := p.Parse(r, opts)
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Serializer is a format that can write IR records to output.
 type Serializer interface {
 	Format
 
 	// Serialize writes IR records to the output.
 	// Options is format-specific configuration.
+	// With zero records, it writes a valid empty document, or returns
+	// ErrEmptyDocument if the format has no valid empty form.
 	Serialize(w io.Writer, records []*hubv1.Record, opts *SerializeOptions) error
 }
 
+// StreamSerializer is a serializer that can write records as they arrive
+// instead of from a slice, so a conversion between streaming formats never
+// holds the whole input in memory. Serialize on the same format writes the
+// same output for the same records.
+type StreamSerializer interface {
+	Serializer
+
+	// SerializeStream writes the records each hands over, in order: each
+	// calls its argument once per record. An error returned by each stops
+	// serializing and is returned unchanged.
+	SerializeStream(w io.Writer, opts *SerializeOptions, each func(fn func(*hubv1.Record) error) error) error
+}
+
+// Normalizer is a format that can rewrite its own documents without
+// converting through the hub. Same-format conversions (e.g. dublincore to
+// dublincore) use it as a fast path that avoids the double conversion and
+// keeps elements the hub cannot represent.
+type Normalizer interface {
+	Format
+
+	// NeedsHub reports whether the options ask for transformations (such
+	// as date rendering) that only the hub path performs.
+	NeedsHub(opts *SerializeOptions) bool
+
+	// Normalize reads a document and writes it back with field-level
+	// cleanup applied.
+	Normalize(r io.Reader, w io.Writer, opts *SerializeOptions) error
+}
+
+// ErrEmptyDocument is returned by serializers whose schema requires at least
+// one record (e.g. a DataCite resource or a CrossRef deposit body).
+var ErrEmptyDocument = errors.New("format cannot represent an empty document")
+
+// RecordError is a failure confined to one input record, which a parser
+// can skip past to read the rest of the input.
+type RecordError struct {
+	// Index is the record's position in the input, counting from 0
+	Index int
+
+	// ID is the record's source identifier (a node ID, an 001, an OAI
+	// identifier), or empty when the record failed before it was known
+	ID string
+
+	// Offset is the byte offset of the record in the input, or -1 if the
+	// format can't tell
+	Offset int64
+
+	// Err is what went wrong
+	Err error
+}
+
+func (e *RecordError) Error() string {
+	if e.ID != "" {
+		return fmt.Sprintf("record %d (%s): %v", e.Index, e.ID, e.Err)
+	}
+	return fmt.Sprintf("record %d: %v", e.Index, e.Err)
+}
+
+func (e *RecordError) Unwrap() error { return e.Err }
+
 // ParseOptions contains options for parsing.
 type ParseOptions struct {
 	// Profile is the mapping profile to use
@@ -61,6 +158,34 @@ type ParseOptions struct {
 	// BaseURL is the base URL for the source system (e.g., "https://preserve.lehigh.edu")
 	// Used to construct full URLs for relations and other references.
 	BaseURL string
+
+	// Workers is how many goroutines convert records at once. Records are
+	// still returned in input order. Zero or one converts sequentially;
+	// formats that don't convert records independently ignore it.
+	Workers int
+
+	// OnRecordError, if set, is called in input order with each record
+	// that fails on its own. Returning nil skips the record and parsing
+	// continues; an error stops parsing and is returned unchanged. When
+	// nil, the first failed record stops parsing. Formats that can't read
+	// past a bad record ignore it.
+	OnRecordError func(*RecordError) error
+
+	// PreserveSource keeps each record's source payload in
+	// SourceInfo.payload (see PreserveSource), so serializing back to the
+	// same format can replay what the hub doesn't carry. Formats that
+	// can't isolate one record's source ignore it.
+	PreserveSource bool
+
+	// ArxivTaxonomy is a JSON file of arXiv category labels by code,
+	// replacing and adding to the built-in taxonomy (arxiv)
+	ArxivTaxonomy string
+
+	// KeepIdentifiers leaves identifiers as the source wrote them. By
+	// default parsers from a Registry rewrite DOIs, ISBNs, ISSNs, ORCIDs,
+	// and arXiv IDs in canonical form (see idnorm); invalid ones are kept
+	// either way and reported by hub.Validate.
+	KeepIdentifiers bool
 }
 
 // SerializeOptions contains options for serialization.
@@ -71,6 +196,16 @@ type SerializeOptions struct {
 	// Columns specifies which columns to include (for tabular formats)
 	Columns []string
 
+	// StaticColumns are columns tabular formats write with the same value
+	// in every row (e.g. "model": "Digital Document"), by column name.
+	// Columns places them; those it doesn't list follow the rest.
+	StaticColumns map[string]string
+
+	// TemplateColumns are columns tabular formats render for each record
+	// from a Go template over the hub record (e.g. "{{.Title}}"), by
+	// column name, placed like StaticColumns
+	TemplateColumns map[string]string
+
 	// MultiValueSeparator is the delimiter for multi-value fields
 	MultiValueSeparator string
 
@@ -80,9 +215,75 @@ type SerializeOptions struct {
 	// Pretty enables pretty-printing (for JSON/XML formats)
 	Pretty bool
 
+	// Dates controls date rendering (style and precision floor/ceiling).
+	// The zero value keeps each serializer's native output.
+	Dates DateOptions
+
+	// Languages is the ISO 639 code set languages are written in. The zero
+	// value keeps each serializer's native set (e.g. 639-1 for DataCite,
+	// 639-2/B for MODS).
+	Languages lang.CodeSet
+
+	// Lengths overrides a serializer's per-field length policies, keyed by
+	// the serializer's field name (e.g. "abstract", or a Workbench column)
+	Lengths map[string]LengthPolicy
+
+	// OverLength, if set, replaces the mode of the serializer's default
+	// length policies (e.g. LengthFail to reject instead of truncate)
+	OverLength LengthMode
+
+	// Provider names the organization publishing the output, for formats
+	// that record provenance (e.g. the Scholix LinkProvider)
+	Provider string
+
+	// CrossmarkPolicy is the DOI of the depositor's Crossmark policy page.
+	// Crossref deposits carry copyright assertions only when it is set.
+	CrossmarkPolicy string
+
+	// OutputName is the file name of the primary output, for extra outputs
+	// that refer to it (e.g. input_csv in a generated Workbench config)
+	OutputName string
+
+	// DrupalConfig is a Drupal config/sync directory describing the target
+	// site, for formats that generate ingest configuration
+	DrupalConfig string
+
+	// FieldMap is a file mapping output fields to hub field paths, for
+	// formats with configurable field names (e.g. Solr documents)
+	FieldMap string
+
+	// Bundle is the Drupal content type records are ingested as
+	Bundle string
+
+	// ImageService is the base URL of a IIIF Image API service (e.g.
+	// https://iiif.example.edu/iiif/3) serving records' images by file
+	// name, for formats that reference images (IIIF manifests)
+	ImageService string
+
+	// ManifestBase is the base URL IIIF manifests are published under, for
+	// records without a URL of their own
+	ManifestBase string
+
+	// Variant selects a flavor of the output for formats that have more
+	// than one (e.g. "oai_dc" or "qualified" Dublin Core). Empty means the
+	// format's default.
+	Variant string
+
+	// TypedRelation selects how typed relation values (a relator role and
+	// the agent it links) are written by formats that have them: "name"
+	// writes relators:aut:person:Smith, Jane and "id" writes the agent's
+	// term ID, relators:aut:123. Empty means the format's default.
+	TypedRelation string
+
+	// Workers is how many goroutines render records at once. Output is
+	// still written in input order. Zero or one renders sequentially;
+	// formats that don't render records independently ignore it.
+	Workers int
+
 	// ExtraWriters holds additional output writers for formats that produce
 	// more than one output file. Keys are format-specific names.
-	// Example: the islandora-workbench format writes an agents CSV to ExtraWriters["agents"].
+	// Example: the islandora-workbench format writes an agents CSV to ExtraWriters["agents"]
+	// and a config.yml template to ExtraWriters["config"].
 	ExtraWriters map[string]io.Writer
 }
 
@@ -95,6 +296,13 @@ type TaxonomyResolver interface {
 	ResolveNode(nodeID string) (string, bool)
 }
 
+// TaxonomyURIResolver is implemented by resolvers that also know each
+// term's authority URI, such as one loaded from a full taxonomy export.
+type TaxonomyURIResolver interface {
+	// ResolveURI returns the authority URI for a taxonomy term ID.
+	ResolveURI(termID string) (string, bool)
+}
+
 // NewParseOptions creates ParseOptions with defaults.
 func NewParseOptions() *ParseOptions {
 	return &ParseOptions{
@@ -102,6 +310,15 @@ func NewParseOptions() *ParseOptions {
 	}
 }
 
+// CleanText trims a parsed text value, also stripping HTML and collapsing
+// whitespace when StripHTML is set.
+func (o *ParseOptions) CleanText(s string) string {
+	if o != nil && o.StripHTML {
+		return helpers.CleanText(s)
+	}
+	return strings.TrimSpace(s)
+}
+
 // NewSerializeOptions creates SerializeOptions with defaults.
 func NewSerializeOptions() *SerializeOptions {
 	return &SerializeOptions{
@@ -0,0 +1,72 @@
+package format_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+)
+
+// streamStub parses one record per input line, and counts how it was used.
+type streamStub struct {
+	stubFormat
+	streamed *bool
+}
+
+func (s streamStub) ParseStream(r io.Reader, opts *format.ParseOptions, fn func(*hubv1.Record) error) error {
+	*s.streamed = true
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	for _, line := range strings.Fields(string(data)) {
+		if err := fn(&hubv1.Record{Title: line}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestParseEach(t *testing.T) {
+	var streamed bool
+	var titles []string
+	collect := func(r *hubv1.Record) error {
+		titles = append(titles, r.Title)
+		return nil
+	}
+
+	if err := format.ParseEach(streamStub{stubFormat{name: "s"}, &streamed}, strings.NewReader("a b"), nil, collect); err != nil {
+		t.Fatal(err)
+	}
+	if !streamed || strings.Join(titles, ",") != "a,b" {
+		t.Errorf("StreamParser: streamed=%v, titles=%v", streamed, titles)
+	}
+
+	// Parsers without ParseStream fall back to Parse
+	titles = nil
+	if err := format.ParseEach(stubFormat{name: "plain"}, strings.NewReader(""), nil, collect); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(titles, ",") != "plain" {
+		t.Errorf("Parser fallback: titles=%v", titles)
+	}
+
+	stop := errors.New("stop")
+	err := format.ParseEach(stubFormat{name: "plain"}, strings.NewReader(""), nil, func(*hubv1.Record) error { return stop })
+	if !errors.Is(err, stop) {
+		t.Errorf("expected the callback's error, got %v", err)
+	}
+}
+
+func TestParseOptionsCleanText(t *testing.T) {
+	in := "  <p>Sediment   <em>transport</em></p>\n"
+	if got := format.NewParseOptions().CleanText(in); got != "Sediment transport" {
+		t.Errorf("StripHTML: got %q", got)
+	}
+	if got := (&format.ParseOptions{}).CleanText(in); got != "<p>Sediment   <em>transport</em></p>" {
+		t.Errorf("without StripHTML: got %q", got)
+	}
+}
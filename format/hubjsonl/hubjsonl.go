@@ -0,0 +1,75 @@
+// Package hubjsonl provides a format plugin for hub records as JSON Lines:
+// one hub Record per line, in protojson with the proto field names.
+//
+// Nothing is mapped, so the format carries every hub field, source info
+// included. Pipelines use it to checkpoint the hub representation between
+// steps, or to hand it to other tools:
+//
+//	crosswalk convert drupal hub-jsonl -i export.json | other-tool |
+//	  crosswalk convert hub-jsonl datacite
+package hubjsonl
+
+import (
+	"bytes"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+)
+
+// Format implements the hub JSON Lines format.
+type Format struct{}
+
+// Ensure Format implements the interfaces
+var (
+	_ format.Format         = (*Format)(nil)
+	_ format.StreamParser   = (*Format)(nil)
+	_ format.Serializer     = (*Format)(nil)
+	_ format.FieldDescriber = (*Format)(nil)
+)
+
+// Name returns the format identifier.
+func (f *Format) Name() string {
+	return "hub-jsonl"
+}
+
+// Description returns a human-readable format description.
+func (f *Format) Description() string {
+	return "Hub records as JSON Lines (one protojson record per line)"
+}
+
+// Extensions returns file extensions associated with this format.
+func (f *Format) Extensions() []string {
+	return []string{"jsonl", "ndjson"}
+}
+
+// WrittenFields returns the hub record fields Serialize writes: all of
+// them.
+func (f *Format) WrittenFields(opts *format.SerializeOptions) []string {
+	fields := (&hubv1.Record{}).ProtoReflect().Descriptor().Fields()
+	names := make([]string, 0, fields.Len())
+	for i := range fields.Len() {
+		names = append(names, string(fields.Get(i).Name()))
+	}
+	return names
+}
+
+// CanParse returns true if the first line of the input is a complete hub
+// record. Other JSON, such as a Drupal entity, has fields a hub record
+// doesn't and is rejected.
+func (f *Format) CanParse(peek []byte) bool {
+	peek = bytes.TrimPrefix(peek, utf8BOM)
+	peek = bytes.TrimLeft(peek, "\r\n\t ")
+	end := bytes.IndexByte(peek, '\n')
+	if end < 0 || len(peek) == 0 || peek[0] != '{' {
+		return false
+	}
+	return protojson.Unmarshal(bytes.TrimSpace(peek[:end]), &hubv1.Record{}) == nil
+}
+
+var utf8BOM = []byte("\xef\xbb\xbf")
+
+func init() {
+	format.Register(&Format{})
+}
@@ -0,0 +1,102 @@
+package hubjsonl
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+func TestRoundTrip(t *testing.T) {
+	records := []*hubv1.Record{
+		{
+			Title: "Charge transport in organic semiconductors",
+			Contributors: []*hubv1.Contributor{{
+				Name:     "Smith, Jane",
+				RoleCode: "relators:aut",
+			}},
+			Dates: []*hubv1.DateValue{{
+				Type:      hubv1.DateType_DATE_TYPE_ISSUED,
+				Year:      2019,
+				Precision: hubv1.DatePrecision_DATE_PRECISION_YEAR,
+			}},
+			Identifiers: []*hubv1.Identifier{hub.NewIdentifier("10.1063/1.5094040", hubv1.IdentifierType_IDENTIFIER_TYPE_DOI)},
+			SourceInfo:  &hubv1.SourceInfo{Format: "drupal", UnmappedFields: []string{"field_weight"}},
+		},
+		{Title: "Thin film growth\nand annealing"},
+	}
+
+	var buf bytes.Buffer
+	if err := (&Format{}).Serialize(&buf, records, &format.SerializeOptions{Workers: 4}); err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(buf.String(), "\n"); n != len(records) {
+		t.Fatalf("got %d lines, want %d:\n%s", n, len(records), buf.String())
+	}
+	if !strings.Contains(buf.String(), `"source_info":`) {
+		t.Errorf("expected proto field names, got %s", buf.String())
+	}
+	if !(&Format{}).CanParse(buf.Bytes()) {
+		t.Error("CanParse rejected serialized output")
+	}
+
+	got, err := (&Format{}).Parse(&buf, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(records) {
+		t.Fatalf("got %d records, want %d", len(got), len(records))
+	}
+	for i := range records {
+		if !proto.Equal(got[i], records[i]) {
+			t.Errorf("record %d: got %v, want %v", i, got[i], records[i])
+		}
+	}
+}
+
+func TestParseSkipsBadLines(t *testing.T) {
+	input := `{"title":"First"}` + "\n\n" + `{"nid":[{"value":1}]}` + "\n" + `{"title":"Third"}`
+
+	if _, err := (&Format{}).Parse(strings.NewReader(input), nil); err == nil {
+		t.Fatal("want error for a line that isn't a hub record")
+	}
+
+	var skipped []*format.RecordError
+	opts := format.NewParseOptions()
+	opts.OnRecordError = func(e *format.RecordError) error {
+		skipped = append(skipped, e)
+		return nil
+	}
+	records, err := (&Format{}).Parse(strings.NewReader(input), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 || records[1].Title != "Third" {
+		t.Errorf("got %v, want First and Third", records)
+	}
+	if len(skipped) != 1 || skipped[0].Index != 1 || skipped[0].Offset != 19 {
+		t.Errorf("skipped: got %+v, want record 1 at offset 19", skipped)
+	}
+}
+
+func TestCanParse(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{`{"title":"First","resource_type":{"type":"RESOURCE_TYPE_ARTICLE"}}` + "\n", true},
+		{`{"nid":[{"value":1}],"title":[{"value":"First"}]}` + "\n", false},
+		{`[{"title":"First"}]` + "\n", false},
+		{"title,date\n", false},
+	}
+	for _, tt := range tests {
+		if got := (&Format{}).CanParse([]byte(tt.input)); got != tt.want {
+			t.Errorf("CanParse(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package hubjsonl
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/helpers"
+)
+
+// Parse reads hub JSON Lines and returns hub records.
+func (f *Format) Parse(r io.Reader, opts *format.ParseOptions) ([]*hubv1.Record, error) {
+	var records []*hubv1.Record
+	err := f.ParseStream(r, opts, func(record *hubv1.Record) error {
+		records = append(records, record)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// ParseStream reads hub JSON Lines one line at a time, skipping blank
+// lines. With opts.Workers above one, lines are decoded concurrently and
+// handed to fn in input order. A line that isn't a hub record is a
+// format.RecordError, which opts.OnRecordError may skip.
+func (f *Format) ParseStream(r io.Reader, opts *format.ParseOptions, fn func(*hubv1.Record) error) error {
+	if opts == nil {
+		opts = format.NewParseOptions()
+	}
+	return helpers.MapOrdered(opts.Workers,
+		func(emit func(rawLine) error) error {
+			return readLines(r, emit)
+		},
+		func(l rawLine) (decodedLine, error) {
+			record, err := l.decode()
+			return decodedLine{record, err}, nil
+		},
+		func(d decodedLine) error {
+			if d.err == nil {
+				return fn(d.record)
+			}
+			if opts.OnRecordError == nil {
+				return d.err
+			}
+			return opts.OnRecordError(d.err)
+		})
+}
+
+// rawLine is an undecoded record line and where it sits in the input.
+type rawLine struct {
+	index  int
+	offset int64
+	data   []byte
+}
+
+// decodedLine is the outcome of decoding one rawLine.
+type decodedLine struct {
+	record *hubv1.Record
+	err    *format.RecordError
+}
+
+// decode unmarshals the line as a hub record, reporting failures as
+// RecordErrors.
+func (l rawLine) decode() (*hubv1.Record, *format.RecordError) {
+	record := &hubv1.Record{}
+	if err := protojson.Unmarshal(l.data, record); err != nil {
+		return nil, &format.RecordError{Index: l.index, Offset: l.offset, Err: fmt.Errorf("decoding hub record: %w", err)}
+	}
+	return record, nil
+}
+
+// readLines calls emit with each non-blank line of r. Lines may be any
+// length.
+func readLines(r io.Reader, emit func(rawLine) error) error {
+	br := bufio.NewReader(r)
+	var offset int64
+	if bom, _ := br.Peek(len(utf8BOM)); bytes.Equal(bom, utf8BOM) {
+		_, _ = br.Discard(len(utf8BOM))
+		offset = int64(len(utf8BOM))
+	}
+
+	index := 0
+	for {
+		line, err := br.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("reading input: %w", err)
+		}
+		start := offset
+		offset += int64(len(line))
+		if data := bytes.TrimSpace(line); len(data) > 0 {
+			if err := emit(rawLine{index: index, offset: start, data: data}); err != nil {
+				return err
+			}
+			index++
+		}
+		if err != nil {
+			return nil
+		}
+	}
+}
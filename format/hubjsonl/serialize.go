@@ -0,0 +1,59 @@
+package hubjsonl
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/helpers"
+)
+
+// marshal writes proto field names, matching the hub's JSON schema.
+var marshal = protojson.MarshalOptions{UseProtoNames: true}
+
+// Serialize writes each hub record as one line of JSON. Zero records write
+// nothing, which is an empty JSON Lines document.
+func (f *Format) Serialize(w io.Writer, records []*hubv1.Record, opts *format.SerializeOptions) error {
+	if opts == nil {
+		opts = format.NewSerializeOptions()
+	}
+
+	bw := bufio.NewWriter(w)
+	err := helpers.MapOrdered(opts.Workers,
+		func(emit func(int) error) error {
+			for i := range records {
+				if err := emit(i); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		func(i int) ([]byte, error) {
+			data, err := marshal.Marshal(records[i])
+			if err != nil {
+				return nil, fmt.Errorf("encoding record %d: %w", i, err)
+			}
+			// protojson varies its whitespace from build to build;
+			// compacting keeps the output stable and on one line
+			var line bytes.Buffer
+			if err := json.Compact(&line, data); err != nil {
+				return nil, fmt.Errorf("encoding record %d: %w", i, err)
+			}
+			line.WriteByte('\n')
+			return line.Bytes(), nil
+		},
+		func(line []byte) error {
+			_, err := bw.Write(line)
+			return err
+		})
+	if err != nil {
+		return err
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,50 @@
+package format
+
+import (
+	"io"
+
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/helpers/idnorm"
+)
+
+// identifierParser canonicalizes the identifiers of the records its parser
+// returns (see idnorm), unless ParseOptions.KeepIdentifiers is set.
+// Registries hand out parsers wrapped in it, so every parse path applies
+// the same forms without each format calling idnorm itself.
+type identifierParser struct {
+	Parser
+}
+
+func (p identifierParser) Parse(r io.Reader, opts *ParseOptions) ([]*hubv1.Record, error) {
+	records, err := p.Parser.Parse(r, opts)
+	if opts == nil || !opts.KeepIdentifiers {
+		for _, record := range records {
+			idnorm.NormalizeRecord(record)
+		}
+	}
+	return records, err
+}
+
+// identifierStreamParser is identifierParser for a StreamParser.
+type identifierStreamParser struct {
+	identifierParser
+	stream StreamParser
+}
+
+func (p identifierStreamParser) ParseStream(r io.Reader, opts *ParseOptions, fn func(*hubv1.Record) error) error {
+	if opts != nil && opts.KeepIdentifiers {
+		return p.stream.ParseStream(r, opts, fn)
+	}
+	return p.stream.ParseStream(r, opts, func(record *hubv1.Record) error {
+		idnorm.NormalizeRecord(record)
+		return fn(record)
+	})
+}
+
+// normalizingParser wraps p so its records' identifiers are canonicalized.
+func normalizingParser(p Parser) Parser {
+	if sp, ok := p.(StreamParser); ok {
+		return identifierStreamParser{identifierParser{p}, sp}
+	}
+	return identifierParser{p}
+}
@@ -0,0 +1,66 @@
+package format_test
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+)
+
+// idFormat parses one record with a DOI in URL form, streaming.
+type idFormat struct{ stubFormat }
+
+func (idFormat) record() *hubv1.Record {
+	return &hubv1.Record{Identifiers: []*hubv1.Identifier{
+		{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_DOI, Value: "https://doi.org/10.1234/ABC"},
+	}}
+}
+
+func (f idFormat) Parse(r io.Reader, opts *format.ParseOptions) ([]*hubv1.Record, error) {
+	return []*hubv1.Record{f.record()}, nil
+}
+
+func (f idFormat) ParseStream(r io.Reader, opts *format.ParseOptions, fn func(*hubv1.Record) error) error {
+	return fn(f.record())
+}
+
+func TestRegistryParsersNormalizeIdentifiers(t *testing.T) {
+	r := format.NewRegistry()
+	r.Register(idFormat{stubFormat{name: "ids", ext: "ids"}})
+	p, err := r.GetParser("ids")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		opts *format.ParseOptions
+		want string
+	}{
+		{nil, "10.1234/abc"},
+		{format.NewParseOptions(), "10.1234/abc"},
+		{&format.ParseOptions{KeepIdentifiers: true}, "https://doi.org/10.1234/ABC"},
+	} {
+		records, err := p.Parse(strings.NewReader(""), tt.opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := records[0].Identifiers[0].Value; got != tt.want {
+			t.Errorf("Parse: got %q, want %q", got, tt.want)
+		}
+
+		err = format.ParseEach(p, strings.NewReader(""), tt.opts, func(record *hubv1.Record) error {
+			if got := record.Identifiers[0].Value; got != tt.want {
+				t.Errorf("ParseStream: got %q, want %q", got, tt.want)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, ok := p.(format.StreamParser); !ok {
+		t.Error("wrapped parser no longer streams")
+	}
+}
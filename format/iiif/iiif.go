@@ -0,0 +1,59 @@
+// Package iiif provides a serializer that writes hub records as IIIF
+// Presentation 3 manifests, for viewers such as Mirador and Universal
+// Viewer.
+//
+// The manifest's label, summary, metadata, requiredStatement, and rights
+// come from the record's descriptive fields. Each of the record's files
+// becomes a canvas painted with the file. Canvases are stubs: the hub
+// doesn't carry image dimensions or durations, so viewers take them from
+// the image service, or a later pass fills them in.
+//
+// Images are referenced through the IIIF Image API service that
+// SerializeOptions.ImageService names, by file name; a base URL ending in
+// /2 is described as a version 2 service, as Islandora's Cantaloupe
+// serves. Without a service, and for thumbnails, a file is referenced by
+// its URL, or its name when its path is local. Files that aren't images,
+// video, or sound, such as PDFs, are listed as renderings.
+//
+// A manifest's ID is the record's URL plus /manifest, as Islandora
+// publishes them, or SerializeOptions.ManifestBase plus the record's local
+// identifier.
+package iiif
+
+import (
+	"github.com/lehigh-university-libraries/crosswalk/format"
+)
+
+// Format implements IIIF Presentation 3 manifests.
+type Format struct{}
+
+// Ensure Format implements the interfaces
+var (
+	_ format.Format         = (*Format)(nil)
+	_ format.Serializer     = (*Format)(nil)
+	_ format.FieldDescriber = (*Format)(nil)
+)
+
+// Name returns the format identifier.
+func (f *Format) Name() string {
+	return "iiif"
+}
+
+// Description returns a human-readable format description.
+func (f *Format) Description() string {
+	return "IIIF Presentation 3 manifests"
+}
+
+// Extensions returns file extensions associated with this format.
+func (f *Format) Extensions() []string {
+	return []string{"iiif"}
+}
+
+// CanParse returns false; IIIF manifests are an output format only.
+func (f *Format) CanParse(_ []byte) bool {
+	return false
+}
+
+func init() {
+	format.Register(&Format{})
+}
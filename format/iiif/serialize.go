@@ -0,0 +1,415 @@
+package iiif
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/helpers"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+const presentationContext = "http://iiif.io/api/presentation/3/context.json"
+
+// writtenFields are the hub record fields Serialize writes.
+var writtenFields = []string{
+	"title", "titles", "abstract", "abstracts", "contributors", "dates",
+	"resource_type", "genres", "subjects", "publisher", "language",
+	"identifiers", "rights", "rights_holder", "copyright_statement", "files",
+}
+
+// WrittenFields returns the hub record fields Serialize writes.
+func (f *Format) WrittenFields(_ *format.SerializeOptions) []string {
+	return writtenFields
+}
+
+// localIDTypes are the identifiers that name a manifest under
+// SerializeOptions.ManifestBase, in order of preference.
+var localIDTypes = []hubv1.IdentifierType{
+	hubv1.IdentifierType_IDENTIFIER_TYPE_LOCAL,
+	hubv1.IdentifierType_IDENTIFIER_TYPE_PID,
+	hubv1.IdentifierType_IDENTIFIER_TYPE_NID,
+	hubv1.IdentifierType_IDENTIFIER_TYPE_UUID,
+}
+
+// languageMap is a IIIF language map: values by language tag, or "none".
+type languageMap map[string][]string
+
+// metadataEntry is a label and value pair, as in metadata and
+// requiredStatement.
+type metadataEntry struct {
+	Label languageMap `json:"label"`
+	Value languageMap `json:"value"`
+}
+
+type manifest struct {
+	Context           string          `json:"@context"`
+	ID                string          `json:"id"`
+	Type              string          `json:"type"`
+	Label             languageMap     `json:"label"`
+	Summary           languageMap     `json:"summary,omitempty"`
+	Metadata          []metadataEntry `json:"metadata,omitempty"`
+	RequiredStatement *metadataEntry  `json:"requiredStatement,omitempty"`
+	Rights            string          `json:"rights,omitempty"`
+	Thumbnail         []resource      `json:"thumbnail,omitempty"`
+	Rendering         []resource      `json:"rendering,omitempty"`
+	Items             []canvas        `json:"items"`
+}
+
+type canvas struct {
+	ID    string           `json:"id"`
+	Type  string           `json:"type"`
+	Label languageMap      `json:"label,omitempty"`
+	Items []annotationPage `json:"items"`
+}
+
+type annotationPage struct {
+	ID    string       `json:"id"`
+	Type  string       `json:"type"`
+	Items []annotation `json:"items"`
+}
+
+type annotation struct {
+	ID         string   `json:"id"`
+	Type       string   `json:"type"`
+	Motivation string   `json:"motivation"`
+	Body       resource `json:"body"`
+	Target     string   `json:"target"`
+}
+
+// resource is a content resource: an image, video, sound, or document.
+type resource struct {
+	ID      string      `json:"id"`
+	Type    string      `json:"type"`
+	Label   languageMap `json:"label,omitempty"`
+	Format  string      `json:"format,omitempty"`
+	Service []service   `json:"service,omitempty"`
+}
+
+// service is an image service reference. Version 2 services keep their
+// JSON-LD @id and @type keys inside a Presentation 3 manifest.
+type service struct {
+	ID         string `json:"id,omitempty"`
+	Type       string `json:"type,omitempty"`
+	LegacyID   string `json:"@id,omitempty"`
+	LegacyType string `json:"@type,omitempty"`
+	Profile    string `json:"profile"`
+}
+
+// Serialize writes hub records as IIIF manifests: one manifest object for
+// a single record, otherwise an array of them.
+func (f *Format) Serialize(w io.Writer, records []*hubv1.Record, opts *format.SerializeOptions) error {
+	if opts == nil {
+		opts = format.NewSerializeOptions()
+	}
+
+	manifests := make([]manifest, 0, len(records))
+	for i, record := range records {
+		manifests = append(manifests, recordToManifest(record, i, opts))
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	if opts.Pretty {
+		encoder.SetIndent("", "  ")
+	}
+	if len(manifests) == 1 {
+		return encoder.Encode(manifests[0])
+	}
+	return encoder.Encode(manifests)
+}
+
+// recordToManifest builds the manifest for the i'th record of the batch.
+func recordToManifest(record *hubv1.Record, i int, opts *format.SerializeOptions) manifest {
+	id := manifestID(record, i, opts.ManifestBase)
+	m := manifest{
+		Context: presentationContext,
+		ID:      id,
+		Type:    "Manifest",
+		Label:   localizedMap(hub.Titles(record)),
+		Summary: localizedMap(hub.Abstracts(record)),
+		Items:   []canvas{},
+	}
+	if len(m.Label) == 0 {
+		m.Label = languageMap{"none": {fmt.Sprintf("Record %d", i+1)}}
+	}
+	m.Metadata = metadata(record, opts)
+	m.RequiredStatement, m.Rights = rights(record)
+
+	base := strings.TrimSuffix(id, "/manifest")
+	for _, file := range record.Files {
+		mimeType := fileMimeType(file)
+		body := resource{
+			ID:     fileURL(file),
+			Type:   resourceType(mimeType),
+			Label:  languageMap{"none": {fileName(file)}},
+			Format: mimeType,
+		}
+		if body.Type == "Image" && opts.ImageService != "" && file.Role != hub.FileRoleThumbnail {
+			body = imageResource(file, opts.ImageService)
+		}
+
+		switch {
+		case file.Role == hub.FileRoleThumbnail:
+			m.Thumbnail = append(m.Thumbnail, body)
+		case body.Type == "Text" || body.Type == "Dataset":
+			m.Rendering = append(m.Rendering, body)
+		default:
+			n := len(m.Items) + 1
+			canvasID := fmt.Sprintf("%s/canvas/%d", base, n)
+			body.Label = nil
+			m.Items = append(m.Items, canvas{
+				ID:    canvasID,
+				Type:  "Canvas",
+				Label: languageMap{"none": {fileName(file)}},
+				Items: []annotationPage{{
+					ID:   canvasID + "/page",
+					Type: "AnnotationPage",
+					Items: []annotation{{
+						ID:         canvasID + "/page/annotation",
+						Type:       "Annotation",
+						Motivation: "painting",
+						Body:       body,
+						Target:     canvasID,
+					}},
+				}},
+			})
+		}
+	}
+	return m
+}
+
+// manifestID returns the record's URL plus /manifest, or the manifest's
+// URL under base, named by the record's local identifier.
+func manifestID(record *hubv1.Record, i int, base string) string {
+	if id := hub.GetIdentifier(record, hubv1.IdentifierType_IDENTIFIER_TYPE_URL); id != nil {
+		if u, err := url.Parse(id.Value); err == nil && (u.Scheme == "http" || u.Scheme == "https") {
+			return strings.TrimRight(id.Value, "/") + "/manifest"
+		}
+	}
+
+	name := ""
+	for _, t := range localIDTypes {
+		if id := hub.GetIdentifier(record, t); id != nil && strings.TrimSpace(id.Value) != "" {
+			name = strings.TrimSpace(id.Value)
+			break
+		}
+	}
+	if name == "" {
+		name = strings.TrimSpace(record.GetSourceInfo().GetSourceId())
+	}
+	if name == "" {
+		name = fmt.Sprintf("record-%d", i+1)
+	}
+	if base == "" {
+		slog.Warn("manifest ID is not a URL: the record has none and no manifest base is set",
+			"title", record.Title, "id", name)
+		return url.PathEscape(name) + "/manifest"
+	}
+	return strings.TrimRight(base, "/") + "/" + url.PathEscape(name) + "/manifest"
+}
+
+// localizedMap returns values as a language map; untagged values go under
+// "none".
+func localizedMap(values []*hubv1.LocalizedString) languageMap {
+	m := languageMap{}
+	for _, v := range values {
+		if v.Value == "" {
+			continue
+		}
+		lang := v.Language
+		if lang == "" {
+			lang = "none"
+		}
+		m[lang] = append(m[lang], v.Value)
+	}
+	if len(m) == 0 {
+		return nil
+	}
+	return m
+}
+
+// metadata returns the record's descriptive metadata as label and value
+// pairs, in display order.
+func metadata(record *hubv1.Record, opts *format.SerializeOptions) []metadataEntry {
+	var entries []metadataEntry
+	add := func(label string, values ...string) {
+		var kept []string
+		for _, v := range values {
+			if v = strings.TrimSpace(v); v != "" {
+				kept = append(kept, v)
+			}
+		}
+		if len(kept) > 0 {
+			entries = append(entries, metadataEntry{
+				Label: languageMap{"en": {label}},
+				Value: languageMap{"none": kept},
+			})
+		}
+	}
+
+	var contributors []string
+	for _, c := range record.Contributors {
+		name := hub.DisplayName(c)
+		role := c.Role
+		if role == "" && c.RoleCode != "" {
+			role = helpers.CodeToRole(strings.TrimPrefix(c.RoleCode, "relators:"))
+		}
+		if role != "" && name != "" {
+			name += " (" + role + ")"
+		}
+		contributors = append(contributors, name)
+	}
+	add("Contributor", contributors...)
+
+	for _, d := range record.Dates {
+		add(dateLabel(d.Type), format.FormatDate(d, opts.Dates))
+	}
+	if record.ResourceType != nil {
+		add("Type", hub.ResourceTypeString(record.ResourceType))
+	}
+	var genres, subjects []string
+	for _, g := range record.Genres {
+		genres = append(genres, g.Value)
+	}
+	for _, s := range record.Subjects {
+		subjects = append(subjects, s.Value)
+	}
+	add("Genre", genres...)
+	add("Subject", subjects...)
+	add("Publisher", record.Publisher)
+	add("Language", record.Language)
+
+	var ids []string
+	for _, id := range record.Identifiers {
+		ids = append(ids, id.Value)
+	}
+	add("Identifier", ids...)
+	return entries
+}
+
+// dateLabel names a date by its type.
+func dateLabel(t hubv1.DateType) string {
+	switch t {
+	case hubv1.DateType_DATE_TYPE_ISSUED:
+		return "Date issued"
+	case hubv1.DateType_DATE_TYPE_CREATED:
+		return "Date created"
+	}
+	return "Date"
+}
+
+// rights returns the statement viewers must display and the rights URI.
+// The URI is kept only when it is a Creative Commons license or a
+// RightsStatements.org statement, the values IIIF allows.
+func rights(record *hubv1.Record) (*metadataEntry, string) {
+	var statements []string
+	uri := ""
+	for _, r := range record.Rights {
+		if r.Statement != "" {
+			statements = append(statements, r.Statement)
+		}
+		if uri == "" && isRightsURI(r.Uri) {
+			uri = strings.Replace(r.Uri, "https://rightsstatements.org", "http://rightsstatements.org", 1)
+		}
+	}
+	if record.CopyrightStatement != "" {
+		statements = append(statements, record.CopyrightStatement)
+	} else if record.RightsHolder != "" {
+		statements = append(statements, "© "+record.RightsHolder)
+	}
+	if len(statements) == 0 {
+		return nil, uri
+	}
+	return &metadataEntry{
+		Label: languageMap{"en": {"Rights"}},
+		Value: languageMap{"none": statements},
+	}, uri
+}
+
+func isRightsURI(uri string) bool {
+	for _, prefix := range []string{
+		"http://creativecommons.org/", "https://creativecommons.org/",
+		"http://rightsstatements.org/", "https://rightsstatements.org/",
+	} {
+		if strings.HasPrefix(uri, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// imageResource returns the image of a file served by the image service
+// at base, named by the file name.
+func imageResource(file *hubv1.File, base string) resource {
+	base = strings.TrimRight(base, "/")
+	serviceID := base + "/" + url.PathEscape(fileName(file))
+	if strings.HasSuffix(base, "/2") {
+		return resource{
+			ID:      serviceID + "/full/full/0/default.jpg",
+			Type:    "Image",
+			Format:  "image/jpeg",
+			Service: []service{{LegacyID: serviceID, LegacyType: "ImageService2", Profile: "level2"}},
+		}
+	}
+	return resource{
+		ID:      serviceID + "/full/max/0/default.jpg",
+		Type:    "Image",
+		Format:  "image/jpeg",
+		Service: []service{{ID: serviceID, Type: "ImageService3", Profile: "level2"}},
+	}
+}
+
+// fileName returns the file's name, or the last element of its path.
+func fileName(file *hubv1.File) string {
+	if file.Name != "" {
+		return file.Name
+	}
+	return path.Base(strings.ReplaceAll(file.Path, "\\", "/"))
+}
+
+// fileURL returns the file's path when it is a URL, or its name.
+func fileURL(file *hubv1.File) string {
+	if u, err := url.Parse(file.Path); err == nil && (u.Scheme == "http" || u.Scheme == "https") {
+		return file.Path
+	}
+	return url.PathEscape(fileName(file))
+}
+
+// fileMimeType returns the file's media type, guessed from its name when
+// the record doesn't give one.
+func fileMimeType(file *hubv1.File) string {
+	if file.MimeType != "" {
+		return file.MimeType
+	}
+	t, _, _ := strings.Cut(mime.TypeByExtension(path.Ext(fileName(file))), ";")
+	return t
+}
+
+// resourceType returns the IIIF type of a media type.
+func resourceType(mimeType string) string {
+	major, _, _ := strings.Cut(mimeType, "/")
+	switch major {
+	case "image":
+		return "Image"
+	case "video":
+		return "Video"
+	case "audio":
+		return "Sound"
+	case "text":
+		return "Text"
+	}
+	switch mimeType {
+	case "application/pdf", "application/epub+zip", "application/msword",
+		"application/vnd.openxmlformats-officedocument.wordprocessingml.document":
+		return "Text"
+	}
+	return "Dataset"
+}
@@ -0,0 +1,158 @@
+package iiif
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+func testRecord() *hubv1.Record {
+	return &hubv1.Record{
+		Title:    "Bethlehem Steel blast furnaces",
+		Abstract: "Photographs of the furnaces at night.",
+		Language: "en",
+		Contributors: []*hubv1.Contributor{
+			{Name: "Doe, Jane", RoleCode: "relators:pht"},
+		},
+		Dates: []*hubv1.DateValue{
+			{Type: hubv1.DateType_DATE_TYPE_CREATED, Year: 1952, Precision: hubv1.DatePrecision_DATE_PRECISION_YEAR},
+		},
+		Subjects: []*hubv1.Subject{{Value: "Steel industry"}},
+		Identifiers: []*hubv1.Identifier{
+			hub.NewIdentifier("https://preserve.example.edu/node/12", hubv1.IdentifierType_IDENTIFIER_TYPE_URL),
+		},
+		Rights: []*hubv1.Rights{{
+			Statement: "In Copyright - Educational Use Permitted",
+			Uri:       "https://rightsstatements.org/vocab/InC-EDU/1.0/",
+		}},
+		Files: []*hubv1.File{
+			{Path: "/data/furnace-01.tif", MimeType: "image/tiff"},
+			{Path: "/data/furnace-02.jp2"},
+			{Path: "/data/finding-aid.pdf"},
+			{Path: "https://preserve.example.edu/thumb.jpg", Role: hub.FileRoleThumbnail},
+		},
+	}
+}
+
+func serialize(t *testing.T, records []*hubv1.Record, opts *format.SerializeOptions) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := (&Format{}).Serialize(&buf, records, opts); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func TestSerializeManifest(t *testing.T) {
+	opts := format.NewSerializeOptions()
+	opts.ImageService = "https://iiif.example.edu/iiif/3/"
+	var m manifest
+	if err := json.Unmarshal([]byte(serialize(t, []*hubv1.Record{testRecord()}, opts)), &m); err != nil {
+		t.Fatal(err)
+	}
+
+	if m.Context != presentationContext || m.Type != "Manifest" {
+		t.Errorf("@context = %q, type = %q", m.Context, m.Type)
+	}
+	if m.ID != "https://preserve.example.edu/node/12/manifest" {
+		t.Errorf("id = %q, want the record URL plus /manifest", m.ID)
+	}
+	if got := m.Label["en"]; len(got) != 1 || got[0] != "Bethlehem Steel blast furnaces" {
+		t.Errorf("label = %v", m.Label)
+	}
+	if got := m.Summary["en"]; len(got) != 1 {
+		t.Errorf("summary = %v", m.Summary)
+	}
+	if m.Rights != "http://rightsstatements.org/vocab/InC-EDU/1.0/" {
+		t.Errorf("rights = %q", m.Rights)
+	}
+	if m.RequiredStatement == nil || m.RequiredStatement.Value["none"][0] != "In Copyright - Educational Use Permitted" {
+		t.Errorf("requiredStatement = %+v", m.RequiredStatement)
+	}
+
+	metadata := make(map[string]string)
+	for _, e := range m.Metadata {
+		metadata[e.Label["en"][0]] = strings.Join(e.Value["none"], "; ")
+	}
+	want := map[string]string{
+		"Contributor":  "Doe, Jane (Photographer)",
+		"Date created": "1952",
+		"Subject":      "Steel industry",
+		"Identifier":   "https://preserve.example.edu/node/12",
+	}
+	for label, value := range want {
+		if metadata[label] != value {
+			t.Errorf("metadata %s = %q, want %q", label, metadata[label], value)
+		}
+	}
+
+	if len(m.Items) != 2 {
+		t.Fatalf("got %d canvases, want one per image", len(m.Items))
+	}
+	c := m.Items[0]
+	if c.ID != "https://preserve.example.edu/node/12/canvas/1" || c.Label["none"][0] != "furnace-01.tif" {
+		t.Errorf("canvas = %+v", c)
+	}
+	a := c.Items[0].Items[0]
+	if a.Motivation != "painting" || a.Target != c.ID {
+		t.Errorf("annotation = %+v", a)
+	}
+	if a.Body.ID != "https://iiif.example.edu/iiif/3/furnace-01.tif/full/max/0/default.jpg" {
+		t.Errorf("image = %q", a.Body.ID)
+	}
+	if s := a.Body.Service; len(s) != 1 || s[0].ID != "https://iiif.example.edu/iiif/3/furnace-01.tif" || s[0].Type != "ImageService3" {
+		t.Errorf("service = %+v", s)
+	}
+	if len(m.Rendering) != 1 || m.Rendering[0].Type != "Text" || m.Rendering[0].Format != "application/pdf" {
+		t.Errorf("rendering = %+v", m.Rendering)
+	}
+	if len(m.Thumbnail) != 1 || m.Thumbnail[0].ID != "https://preserve.example.edu/thumb.jpg" {
+		t.Errorf("thumbnail = %+v", m.Thumbnail)
+	}
+}
+
+func TestImageService2(t *testing.T) {
+	opts := format.NewSerializeOptions()
+	opts.ImageService = "https://islandora.example.edu/cantaloupe/iiif/2"
+	out := serialize(t, []*hubv1.Record{testRecord()}, opts)
+	for _, want := range []string{
+		`"@id":"https://islandora.example.edu/cantaloupe/iiif/2/furnace-01.tif"`,
+		`"@type":"ImageService2"`,
+		`"id":"https://islandora.example.edu/cantaloupe/iiif/2/furnace-01.tif/full/full/0/default.jpg"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %s:\n%s", want, out)
+		}
+	}
+}
+
+func TestManifestBase(t *testing.T) {
+	records := []*hubv1.Record{
+		{Title: "Local", Identifiers: []*hubv1.Identifier{hub.NewIdentifier("lehigh:42", hubv1.IdentifierType_IDENTIFIER_TYPE_LOCAL)}},
+		{Title: "Bare"},
+	}
+	opts := format.NewSerializeOptions()
+	opts.ManifestBase = "https://iiif.example.edu/manifests/"
+
+	var ms []manifest
+	if err := json.Unmarshal([]byte(serialize(t, records, opts)), &ms); err != nil {
+		t.Fatal(err)
+	}
+	if len(ms) != 2 {
+		t.Fatalf("got %d manifests, want an array of 2", len(ms))
+	}
+	if ms[0].ID != "https://iiif.example.edu/manifests/lehigh:42/manifest" {
+		t.Errorf("first id = %q", ms[0].ID)
+	}
+	if ms[1].ID != "https://iiif.example.edu/manifests/record-2/manifest" {
+		t.Errorf("second id = %q", ms[1].ID)
+	}
+	if ms[1].Items == nil {
+		t.Error("a manifest without files should still have an items array")
+	}
+}
@@ -0,0 +1,189 @@
+package islandora_workbench
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log/slog"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+	"github.com/lehigh-university-libraries/crosswalk/profile"
+)
+
+// defaultContentType is the Islandora Starter Site repository item bundle.
+const defaultContentType = "islandora_object"
+
+// reservedColumns are Workbench CSV columns that are not Drupal fields, so
+// they are never checked against the target bundle.
+var reservedColumns = map[string]bool{
+	"id":             true,
+	"parent_id":      true,
+	"node_id":        true,
+	"file":           true,
+	"checksum":       true,
+	"media_use_tid":  true,
+	"image_alt_text": true,
+	"service":        true,
+	"preservation":   true,
+	"thumbnail":      true,
+	"transcript":     true,
+	"extracted_text": true,
+	"title":          true,
+	"url_alias":      true,
+}
+
+// configHeader precedes the generated settings. Connection details can't
+// be derived from the records, so they are placeholders.
+const configHeader = `# Islandora Workbench configuration generated by crosswalk.
+# Set host and username for your site, and supply the password through the
+# ISLANDORA_WORKBENCH_PASSWORD environment variable.
+`
+
+// workbenchConfig is the subset of Workbench settings derived from the
+// output columns and, when available, the site's Drupal config/sync.
+type workbenchConfig struct {
+	Task             string                `yaml:"task"`
+	Host             string                `yaml:"host"`
+	Username         string                `yaml:"username"`
+	InputDir         string                `yaml:"input_dir"`
+	InputCSV         string                `yaml:"input_csv"`
+	ContentType      string                `yaml:"content_type"`
+	IDField          string                `yaml:"id_field,omitempty"`
+	Subdelimiter     string                `yaml:"subdelimiter"`
+	NodesOnly        bool                  `yaml:"nodes_only,omitempty"`
+	MediaUseTID      string                `yaml:"media_use_tid,omitempty"`
+	FixityAlgorithm  string                `yaml:"fixity_algorithm,omitempty"`
+	ValidateFixity   bool                  `yaml:"validate_fixity_during_ingest,omitempty"`
+	MediaTypes       []map[string][]string `yaml:"media_types,omitempty"`
+	AdditionalFiles  []map[string]string   `yaml:"additional_files,omitempty"`
+	IgnoreCSVColumns []string              `yaml:"ignore_csv_columns,omitempty"`
+}
+
+// writeConfig writes a Workbench config.yml template for a CSV with the
+// given columns.
+//
+// The task is "update" when rows are keyed by node_id, otherwise "create".
+// Without a file column the config sets nodes_only; with one it sets the
+// fixity algorithm used by the checksum column and, when opts.DrupalConfig
+// is set, the site's media types by file extension. Additional file columns
+// are listed under additional_files with their media use. Columns that are
+// not fields on the target bundle are logged and added to ignore_csv_columns,
+// since Workbench otherwise refuses the whole CSV.
+func writeConfig(w io.Writer, columns []string, records []*hubv1.Record, opts *format.SerializeOptions) error {
+	cfg := workbenchConfig{
+		Task:         "create",
+		Host:         "http://localhost:8000",
+		Username:     "admin",
+		InputDir:     ".",
+		InputCSV:     opts.OutputName,
+		ContentType:  opts.Bundle,
+		IDField:      "id",
+		Subdelimiter: subdelimiter(opts),
+	}
+	if cfg.InputCSV == "" {
+		cfg.InputCSV = "metadata.csv"
+	}
+	if cfg.ContentType == "" {
+		cfg.ContentType = defaultContentType
+	}
+
+	has := make(map[string]bool, len(columns))
+	for _, col := range columns {
+		has[col] = true
+	}
+	if has["node_id"] && !has["id"] {
+		cfg.Task = "update"
+		cfg.IDField = ""
+	} else if !has["id"] && len(records) > 0 {
+		slog.Warn("workbench create task needs an id column, taken from the record extra \"id\"", "input_csv", cfg.InputCSV)
+	}
+
+	if has["file"] {
+		cfg.MediaUseTID = "http://pcdm.org/use#OriginalFile"
+		if has["checksum"] {
+			algo := fixityAlgorithm(records)
+			cfg.FixityAlgorithm = algo
+			cfg.ValidateFixity = algo != ""
+		}
+	} else if cfg.Task == "create" {
+		cfg.NodesOnly = true
+	}
+	for _, mc := range mediaColumns[1:] {
+		if has[mc.column] {
+			cfg.AdditionalFiles = append(cfg.AdditionalFiles, map[string]string{mc.column: mc.use})
+		}
+	}
+
+	if opts.DrupalConfig != "" {
+		fields, err := profile.BundleFields(opts.DrupalConfig, cfg.ContentType)
+		if err != nil {
+			return fmt.Errorf("reading Drupal config: %w", err)
+		}
+		onBundle := make(map[string]bool, len(fields))
+		for _, f := range fields {
+			onBundle[f.FieldName] = true
+		}
+		for _, col := range columns {
+			if !reservedColumns[col] && !onBundle[col] {
+				slog.Warn("column is not a field on the target bundle, ignoring it in workbench config", "column", col, "bundle", cfg.ContentType)
+				cfg.IgnoreCSVColumns = append(cfg.IgnoreCSVColumns, col)
+			}
+		}
+
+		if has["file"] {
+			mediaTypes, err := profile.MediaFileExtensions(opts.DrupalConfig)
+			if err != nil {
+				return fmt.Errorf("reading Drupal media types: %w", err)
+			}
+			names := make([]string, 0, len(mediaTypes))
+			for name := range mediaTypes {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				cfg.MediaTypes = append(cfg.MediaTypes, map[string][]string{name: mediaTypes[name]})
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(configHeader)
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(cfg); err != nil {
+		return fmt.Errorf("encoding config: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return fmt.Errorf("encoding config: %w", err)
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// fixityAlgorithm returns the checksum algorithm shared by every record's
+// primary file. Workbench takes a single fixity_algorithm per task, so
+// mixed algorithms are logged and no algorithm is returned.
+func fixityAlgorithm(records []*hubv1.Record) string {
+	var algo string
+	for _, record := range records {
+		file := hub.PrimaryFile(record)
+		if file == nil {
+			continue
+		}
+		a, _ := workbenchChecksum(file)
+		if a == "" {
+			continue
+		}
+		if algo != "" && a != algo {
+			slog.Warn("records mix checksum algorithms, leaving fixity_algorithm unset in workbench config", "first", algo, "other", a)
+			return ""
+		}
+		algo = a
+	}
+	return algo
+}
@@ -0,0 +1,153 @@
+package islandora_workbench
+
+import (
+	"path"
+	"strings"
+
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+// mediaColumn is a Workbench column holding a file to ingest as media.
+type mediaColumn struct {
+	column string // CSV column
+	role   string // hub File role
+	use    string // PCDM media use the file is ingested with
+}
+
+// mediaColumns lists the file column first, then the additional_files
+// columns of the Islandora Starter Site's Workbench configs.
+var mediaColumns = []mediaColumn{
+	newMediaColumn("file", hub.FileRoleOriginal),
+	newMediaColumn("service", hub.FileRoleService),
+	newMediaColumn("preservation", hub.FileRolePreservation),
+	newMediaColumn("thumbnail", hub.FileRoleThumbnail),
+	newMediaColumn("transcript", hub.FileRoleTranscript),
+	newMediaColumn("extracted_text", hub.FileRoleExtractedText),
+}
+
+func newMediaColumn(column, role string) mediaColumn {
+	return mediaColumn{column: column, role: role, use: hub.MediaUseURI(role)}
+}
+
+// mediaColumnFor returns the media column for a file role.
+func mediaColumnFor(role string) (mediaColumn, bool) {
+	for _, m := range mediaColumns {
+		if m.role == role {
+			return m, true
+		}
+	}
+	return mediaColumn{}, false
+}
+
+// rowMedia gathers a row's media columns; the file and its checksum, media
+// use, and alt text arrive in separate columns in any order.
+type rowMedia struct {
+	file, checksum, use, altText string
+	additional                   []*hubv1.File
+}
+
+// set records one media column value; subtype is the column's hub field
+// subtype ("" for file, checksum, media_use, alt_text, or a file role).
+func (m *rowMedia) set(subtype, value string) {
+	switch subtype {
+	case "":
+		m.file = value
+	case "checksum":
+		m.checksum = value
+	case "media_use":
+		m.use = value
+	case "alt_text":
+		m.altText = value
+	default:
+		m.additional = append(m.additional, &hubv1.File{Path: value, Name: path.Base(value), Role: subtype})
+	}
+}
+
+// apply adds the row's files to the record, the file column's first.
+// Values that have no place on a File (a media use given as a term ID, a
+// checksum of unknown algorithm, or details for a row without a file, as
+// in update tasks) are kept in Extra under their column names.
+func (m *rowMedia) apply(record *hubv1.Record) {
+	if m.file == "" {
+		for column, v := range map[string]string{"checksum": m.checksum, "media_use_tid": m.use, "image_alt_text": m.altText} {
+			if v != "" {
+				hub.SetExtra(record, column, v)
+			}
+		}
+		record.Files = append(record.Files, m.additional...)
+		return
+	}
+
+	file := &hubv1.File{Path: m.file, Name: path.Base(m.file), Role: hub.FileRoleOriginal, Description: m.altText}
+	if m.use != "" {
+		if role := hub.FileRoleFromMediaUse(m.use); role != "" {
+			file.Role = role
+		} else {
+			hub.SetExtra(record, "media_use_tid", m.use)
+		}
+	}
+	if m.checksum != "" {
+		if algo := checksumAlgorithm(m.checksum); algo != "" {
+			hub.SetChecksum(file, algo, m.checksum)
+		} else {
+			hub.SetExtra(record, "checksum", m.checksum)
+		}
+	}
+	record.Files = append(append(record.Files, file), m.additional...)
+}
+
+// checksumAlgorithm names the algorithm of a hex digest by its length,
+// since the CSV leaves it to the config's fixity_algorithm.
+func checksumAlgorithm(digest string) string {
+	for _, r := range digest {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+			return ""
+		}
+	}
+	switch len(digest) {
+	case 32:
+		return "md5"
+	case 40:
+		return "sha1"
+	case 64:
+		return "sha256"
+	case 128:
+		return "sha512"
+	}
+	return ""
+}
+
+// mediaToColumns writes the record's files to the media columns.
+func mediaToColumns(record *hubv1.Record, cols map[string]string) {
+	primary := hub.PrimaryFile(record)
+	if primary != nil {
+		cols["file"] = primary.Path
+		_, cols["checksum"] = workbenchChecksum(primary)
+		cols["image_alt_text"] = primary.Description
+		// Workbench ingests the file as an original file unless told
+		// otherwise
+		if primary.Role != "" && primary.Role != hub.FileRoleOriginal {
+			cols["media_use_tid"] = hub.MediaUseURI(primary.Role)
+		}
+	} else {
+		cols["file"] = hub.GetExtraString(record, "file")
+		cols["image_alt_text"] = hub.GetExtraString(record, "image_alt_text")
+	}
+	if cols["checksum"] == "" {
+		cols["checksum"] = hub.GetExtraString(record, "checksum")
+	}
+	if use := hub.GetExtraString(record, "media_use_tid"); use != "" {
+		cols["media_use_tid"] = use
+	}
+
+	for _, f := range record.Files {
+		if f == primary {
+			continue
+		}
+		// One file per additional_files column; the first of a role wins
+		if mc, ok := mediaColumnFor(f.Role); ok && mc.column != "file" && cols[mc.column] == "" {
+			cols[mc.column] = f.Path
+		}
+	}
+}
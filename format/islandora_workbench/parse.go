@@ -38,11 +38,22 @@ func (f *Format) Parse(r io.Reader, opts *format.ParseOptions) ([]*hubv1.Record,
 	}
 
 	header := rows[0]
+	transform, err := opts.Profile.RowTransform(header, sep)
+	if err != nil {
+		return nil, err
+	}
+	if transform != nil {
+		header = transform.Header()
+	}
 	colMap := buildWorkbenchColumnMap(header, opts.Profile)
 
 	records := make([]*hubv1.Record, 0, len(rows)-1)
 	for i := 1; i < len(rows); i++ {
-		if record := workbenchRowToRecord(rows[i], header, colMap, opts); record != nil {
+		row, err := transform.Apply(rows[i])
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", i, err)
+		}
+		if record := workbenchRowToRecord(row, header, colMap, opts); record != nil {
 			records = append(records, record)
 		}
 	}
@@ -80,14 +91,21 @@ func buildWorkbenchColumnMap(header []string, p *mapping.Profile) map[int]string
 func defaultWorkbenchColumnMap() map[string]string {
 	return map[string]string{
 		// Reserved Workbench columns
-		"id":             "Extra.id",
-		"parent_id":      "Extra.parent_id",
-		"node_id":        "Extra.node_id",
-		"file":           "Extra.file",
-		"url_alias":      "Extra.url_alias",
-		"image_alt_text": "Extra.image_alt_text",
-		"checksum":       "Extra.checksum",
-		"media_use_tid":  "Extra.media_use_tid",
+		"id":        "Extra.id",
+		"parent_id": "Extra.parent_id",
+		"node_id":   "Extra.node_id",
+		"url_alias": "Extra.url_alias",
+
+		// Media
+		"file":           "Files",
+		"checksum":       "Files.checksum",
+		"media_use_tid":  "Files.media_use",
+		"image_alt_text": "Files.alt_text",
+		"service":        "Files.service",
+		"preservation":   "Files.preservation",
+		"thumbnail":      "Files.thumbnail",
+		"transcript":     "Files.transcript",
+		"extracted_text": "Files.extracted_text",
 
 		// Core
 		"title":            "Title",
@@ -143,6 +161,11 @@ func defaultWorkbenchColumnMap() map[string]string {
 		"field_degree_level":    "DegreeInfo.DegreeLevel",
 		"field_department_name": "DegreeInfo.Department",
 
+		// Physical holdings
+		"field_call_number":       "Holdings.CallNumber",
+		"field_physical_location": "Holdings.Institution",
+		"field_box_folder":        "ArchivalLocation",
+
 		// Publishing
 		"field_publisher":       "Publisher",
 		"field_place_published": "PlacePublished",
@@ -158,6 +181,7 @@ func defaultWorkbenchColumnMap() map[string]string {
 // workbenchRowToRecord converts a single CSV row into a hub Record.
 func workbenchRowToRecord(row []string, header []string, colMap map[int]string, opts *format.ParseOptions) *hubv1.Record {
 	record := &hubv1.Record{}
+	var media rowMedia
 
 	for i, value := range row {
 		if i >= len(header) {
@@ -217,7 +241,7 @@ func workbenchRowToRecord(row []string, header []string, colMap map[int]string,
 			dateType := workbenchDateType(subtype)
 			for _, v := range splitPipe(value) {
 				date, _ := helpers.ParseEDTF(v, dateType)
-				if date.Year > 0 {
+				if date.Year > 0 || hub.IsInterval(date) {
 					record.Dates = append(record.Dates, date)
 				}
 			}
@@ -322,10 +346,30 @@ func workbenchRowToRecord(row []string, header []string, colMap map[int]string,
 				record.DegreeInfo.Institution = value
 			}
 
+		case "Holdings":
+			for i, v := range splitPipe(value) {
+				for len(record.Holdings) <= i {
+					record.Holdings = append(record.Holdings, &hubv1.Holding{})
+				}
+				switch subtype {
+				case "Institution":
+					record.Holdings[i].Institution = v
+				default:
+					record.Holdings[i].CallNumber = v
+				}
+			}
+
+		case "ArchivalLocation":
+			record.ArchivalLocation, _ = hub.ParseBoxFolder(value)
+
+		case "Files":
+			media.set(subtype, value)
+
 		case "Extra":
 			hub.SetExtra(record, subtype, value)
 		}
 	}
+	media.apply(record)
 
 	return record
 }
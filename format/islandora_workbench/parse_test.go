@@ -1,11 +1,13 @@
 package islandora_workbench
 
 import (
+	"encoding/csv"
 	"strings"
 	"testing"
 
 	"github.com/lehigh-university-libraries/crosswalk/format"
 	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
 )
 
 func TestParseWorkbenchLinkedAgent(t *testing.T) {
@@ -260,3 +262,113 @@ func TestParse_RoundTrip(t *testing.T) {
 		t.Errorf("Rights = %v", p.Rights)
 	}
 }
+
+func TestParse_DateIntervalRoundTrip(t *testing.T) {
+	record := &hubv1.Record{
+		Title: "Lehigh Valley Railroad timetables",
+		Dates: []*hubv1.DateValue{
+			{Type: hubv1.DateType_DATE_TYPE_ISSUED, Year: 1890, Qualifier: hubv1.DateQualifier_DATE_QUALIFIER_APPROXIMATE, EndYear: 1895, IsRange: true},
+			{Type: hubv1.DateType_DATE_TYPE_CREATED, EndYear: 1888, EndMonth: 4, EndPrecision: hubv1.DatePrecision_DATE_PRECISION_MONTH, StartBound: hubv1.DateBound_DATE_BOUND_OPEN, IsRange: true},
+		},
+	}
+
+	var buf strings.Builder
+	f := &Format{}
+	serOpts := format.NewSerializeOptions()
+	serOpts.IncludeHeader = true
+	if err := f.Serialize(&buf, []*hubv1.Record{record}, serOpts); err != nil {
+		t.Fatalf("Serialize error: %v", err)
+	}
+	for _, want := range []string{"1890~/1895", "../1888-04"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("output missing %s:\n%s", want, buf.String())
+		}
+	}
+
+	parsed, err := f.Parse(strings.NewReader(buf.String()), format.NewParseOptions())
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	dates := parsed[0].Dates
+	if len(dates) != 2 {
+		t.Fatalf("expected 2 dates, got %v", dates)
+	}
+	if d := dates[0]; !d.IsRange || d.Year != 1890 || d.EndYear != 1895 || d.Qualifier != hubv1.DateQualifier_DATE_QUALIFIER_APPROXIMATE {
+		t.Errorf("issued: got %v", d)
+	}
+	if d := dates[1]; d.StartBound != hubv1.DateBound_DATE_BOUND_OPEN || d.EndYear != 1888 || d.EndMonth != 4 {
+		t.Errorf("created: got %v", d)
+	}
+}
+
+func TestParse_MediaColumns(t *testing.T) {
+	csvInput := "id,file,checksum,media_use_tid,image_alt_text,thumbnail,title\n" +
+		"1,scans/page.tif,0cc175b9c0f1b6a831c399e269772661,http://pcdm.org/use#PreservationMasterFile,A scanned page,scans/page.jpg,Scanned\n" +
+		"2,letter.pdf,not-a-digest,17,,,Letter\n"
+
+	f := &Format{}
+	records, err := f.Parse(strings.NewReader(csvInput), nil)
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+
+	r := records[0]
+	if len(r.Files) != 2 {
+		t.Fatalf("expected 2 files, got %d", len(r.Files))
+	}
+	file := r.Files[0]
+	if file.Path != "scans/page.tif" || file.Name != "page.tif" || file.Role != "preservation" || file.Description != "A scanned page" {
+		t.Errorf("file = %v", file)
+	}
+	if got := hub.GetChecksum(file, "md5"); got != "0cc175b9c0f1b6a831c399e269772661" {
+		t.Errorf("md5 = %q", got)
+	}
+	if r.Files[1].Path != "scans/page.jpg" || r.Files[1].Role != "thumbnail" {
+		t.Errorf("thumbnail = %v", r.Files[1])
+	}
+
+	// A term ID media use and a checksum of unknown algorithm stay in Extra
+	r = records[1]
+	if len(r.Files) != 1 || r.Files[0].Role != "original" {
+		t.Errorf("files = %v", r.Files)
+	}
+	if got := hub.GetExtraString(r, "media_use_tid"); got != "17" {
+		t.Errorf("media_use_tid = %q", got)
+	}
+	if got := hub.GetExtraString(r, "checksum"); got != "not-a-digest" {
+		t.Errorf("checksum = %q", got)
+	}
+
+	// Serializing gives the media columns back
+	var buf strings.Builder
+	serOpts := format.NewSerializeOptions()
+	serOpts.IncludeHeader = true
+	if err := f.Serialize(&buf, records, serOpts); err != nil {
+		t.Fatalf("Serialize error: %v", err)
+	}
+	rows, err := csv.NewReader(strings.NewReader(buf.String())).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string][]string{
+		"file":           {"scans/page.tif", "letter.pdf"},
+		"checksum":       {"0cc175b9c0f1b6a831c399e269772661", "not-a-digest"},
+		"media_use_tid":  {"http://pcdm.org/use#PreservationMasterFile", "17"},
+		"image_alt_text": {"A scanned page", ""},
+		"thumbnail":      {"scans/page.jpg", ""},
+	}
+	for i, col := range rows[0] {
+		if w, ok := want[col]; ok {
+			if rows[1][i] != w[0] || rows[2][i] != w[1] {
+				t.Errorf("%s = %q, %q; want %q, %q", col, rows[1][i], rows[2][i], w[0], w[1])
+			}
+			delete(want, col)
+		}
+	}
+	if len(want) > 0 {
+		t.Errorf("missing columns: %v", want)
+	}
+}
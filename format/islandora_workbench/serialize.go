@@ -1,6 +1,7 @@
 package islandora_workbench
 
 import (
+	"cmp"
 	"encoding/csv"
 	"fmt"
 	"io"
@@ -8,11 +9,19 @@ import (
 
 	"github.com/lehigh-university-libraries/crosswalk/format"
 	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/helpers"
 	"github.com/lehigh-university-libraries/crosswalk/hub"
 )
 
+// sep is Workbench's default subdelimiter for multi-value cells.
 const sep = "|"
 
+// Typed relation styles for field_linked_agent.
+const (
+	typedRelationName = "name" // relators:aut:person:Smith, Jane
+	typedRelationID   = "id"   // relators:aut:123, the agent term's ID
+)
+
 // workbenchRow holds a serialized record's column values and any associated agent rows.
 type workbenchRow struct {
 	cols   map[string]string
@@ -26,6 +35,14 @@ var columnOrder = []string{
 	"parent_id",
 	"node_id",
 	"file",
+	"checksum",
+	"media_use_tid",
+	"image_alt_text",
+	"service",
+	"preservation",
+	"thumbnail",
+	"transcript",
+	"extracted_text",
 	"title",
 	"field_model",
 	"field_language",
@@ -39,6 +56,9 @@ var columnOrder = []string{
 	"field_genre",
 	"field_identifier",
 	"field_extent",
+	"field_call_number",
+	"field_physical_location",
+	"field_box_folder",
 	"field_note",
 	"field_member_of",
 	"field_part_detail",
@@ -52,16 +72,29 @@ var columnOrder = []string{
 // metadata for contributors is written there. Islandora Workbench uses this
 // to create or update person/corporate_body taxonomy terms with ORCIDs,
 // emails, statuses, and institutional relationships.
+//
+// If opts.ExtraWriters["config"] is set, a matching Workbench config.yml
+// template is written there (see writeConfig).
 func (f *Format) Serialize(w io.Writer, records []*hubv1.Record, opts *format.SerializeOptions) error {
 	if opts == nil {
 		opts = format.NewSerializeOptions()
 	}
+	switch opts.TypedRelation {
+	case "", typedRelationName, typedRelationID:
+	default:
+		return fmt.Errorf("unknown typed relation style %q (want %s or %s)", opts.TypedRelation, typedRelationName, typedRelationID)
+	}
+	delim := subdelimiter(opts)
 
 	allRows := make([]workbenchRow, 0, len(records))
 	colSeen := make(map[string]bool)
+	policies := columnPolicies(opts)
 
 	for _, record := range records {
-		cols, agents := recordToColumns(record)
+		cols, agents := recordToColumns(record, delim, opts.TypedRelation)
+		if err := limitColumns(record.Title, cols, policies, delim); err != nil {
+			return fmt.Errorf("record %q: %w", record.Title, err)
+		}
 		for col, val := range cols {
 			if val != "" {
 				colSeen[col] = true
@@ -70,7 +103,18 @@ func (f *Format) Serialize(w io.Writer, records []*hubv1.Record, opts *format.Se
 		allRows = append(allRows, workbenchRow{cols: cols, agents: agents})
 	}
 
+	// Workbench needs at least id and title to treat the file as a valid CSV
+	if len(records) == 0 {
+		colSeen["id"] = true
+		colSeen["title"] = true
+	}
+
+	// A fixed column set matches a site's Workbench config; columns it
+	// leaves out are dropped and ones no record fills are written empty
 	columns := orderedColumns(colSeen)
+	if len(opts.Columns) > 0 {
+		columns = opts.Columns
+	}
 
 	mainWriter := csv.NewWriter(w)
 
@@ -101,12 +145,77 @@ func (f *Format) Serialize(w io.Writer, records []*hubv1.Record, opts *format.Se
 		}
 	}
 
+	if configW, ok := opts.ExtraWriters["config"]; ok {
+		if err := writeConfig(configW, columns, records, opts); err != nil {
+			return fmt.Errorf("writing workbench config: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// defaultLengths holds limits Drupal enforces regardless of site config.
+var defaultLengths = map[string]format.LengthPolicy{
+	// Node titles are a 255-character base field
+	"title": {Max: 255, Mode: format.LengthTruncate},
+}
+
+// columnPolicies merges the default limits, the profile's max_length values
+// from Drupal field storage config, and the caller's overrides.
+func columnPolicies(opts *format.SerializeOptions) map[string]format.LengthPolicy {
+	defaults := make(map[string]format.LengthPolicy)
+	for col, p := range defaultLengths {
+		defaults[col] = p
+	}
+	if opts.Profile != nil {
+		for col, fm := range opts.Profile.Fields {
+			if fm.MaxLength > 0 {
+				defaults[col] = format.LengthPolicy{Max: fm.MaxLength, Mode: format.LengthTruncate}
+			}
+		}
+	}
+	for col := range opts.Lengths {
+		if _, ok := defaults[col]; !ok {
+			defaults[col] = format.LengthPolicy{}
+		}
+	}
+
+	policies := make(map[string]format.LengthPolicy, len(defaults))
+	for col, def := range defaults {
+		policies[col] = opts.LengthPolicy(col, def)
+	}
+	return policies
+}
+
+// limitColumns applies length policies to each value of a multi-value
+// column. Drupal's max_length only exists on plain string fields, so
+// structured JSON values (attr0, part detail, related item) are left alone.
+func limitColumns(title string, cols map[string]string, policies map[string]format.LengthPolicy, delim string) error {
+	for col, p := range policies {
+		val := cols[col]
+		if val == "" || p.Max <= 0 {
+			continue
+		}
+		values := strings.Split(val, delim)
+		for i, v := range values {
+			if strings.HasPrefix(v, "{") {
+				continue
+			}
+			limited, err := format.ApplyLength(title, col, v, p)
+			if err != nil {
+				return err
+			}
+			values[i] = limited
+		}
+		cols[col] = strings.Join(values, delim)
+	}
 	return nil
 }
 
 // recordToColumns converts a hub record to a map of workbench column values
 // and a slice of agent rows (one per contributor with extended metadata).
-func recordToColumns(record *hubv1.Record) (map[string]string, [][]string) {
+// Multi-value cells are joined with delim.
+func recordToColumns(record *hubv1.Record, delim, typedRelation string) (map[string]string, [][]string) {
 	cols := make(map[string]string)
 	var agents [][]string
 
@@ -121,6 +230,14 @@ func recordToColumns(record *hubv1.Record) (map[string]string, [][]string) {
 		cols["parent_id"] = parentID
 	}
 
+	// Primary file and its fixity value, then the additional files. Workbench
+	// verifies the checksum using the algorithm set by its fixity_algorithm
+	// config option.
+	mediaToColumns(record, cols)
+	if url := hub.GetExtraString(record, "url_alias"); url != "" {
+		cols["url_alias"] = url
+	}
+
 	cols["title"] = record.Title
 
 	if model := islandoraModel(record.ResourceType); model != "" {
@@ -133,12 +250,12 @@ func recordToColumns(record *hubv1.Record) (map[string]string, [][]string) {
 	if len(record.Contributors) > 0 {
 		linkedAgents := make([]string, 0, len(record.Contributors))
 		for _, c := range record.Contributors {
-			linkedAgents = append(linkedAgents, serializeLinkedAgent(c))
+			linkedAgents = append(linkedAgents, linkedAgent(c, typedRelation))
 			if needsAgentRow(c) {
 				agents = append(agents, toAgentRow(c))
 			}
 		}
-		cols["field_linked_agent"] = strings.Join(linkedAgents, sep)
+		cols["field_linked_agent"] = strings.Join(linkedAgents, delim)
 	}
 
 	// Dates (EDTF format)
@@ -158,10 +275,10 @@ func recordToColumns(record *hubv1.Record) (map[string]string, [][]string) {
 		}
 	}
 	if len(issuedDates) > 0 {
-		cols["field_edtf_date_issued"] = strings.Join(issuedDates, sep)
+		cols["field_edtf_date_issued"] = strings.Join(issuedDates, delim)
 	}
 	if len(createdDates) > 0 {
-		cols["field_edtf_date_created"] = strings.Join(createdDates, sep)
+		cols["field_edtf_date_created"] = strings.Join(createdDates, delim)
 	}
 
 	// Abstract and description both go to field_abstract with an attr0 attribute
@@ -173,7 +290,7 @@ func recordToColumns(record *hubv1.Record) (map[string]string, [][]string) {
 		abstracts = append(abstracts, attrValue(record.Description, "description"))
 	}
 	if len(abstracts) > 0 {
-		cols["field_abstract"] = strings.Join(abstracts, sep)
+		cols["field_abstract"] = strings.Join(abstracts, delim)
 	}
 
 	// Rights → field_rights (URI form preferred)
@@ -186,7 +303,7 @@ func recordToColumns(record *hubv1.Record) (map[string]string, [][]string) {
 			}
 		}
 		if len(rights) > 0 {
-			cols["field_rights"] = strings.Join(rights, sep)
+			cols["field_rights"] = strings.Join(rights, delim)
 		}
 	}
 
@@ -199,7 +316,7 @@ func recordToColumns(record *hubv1.Record) (map[string]string, [][]string) {
 			}
 		}
 		if len(subjects) > 0 {
-			cols["field_subject"] = strings.Join(subjects, sep)
+			cols["field_subject"] = strings.Join(subjects, delim)
 		}
 	}
 
@@ -212,7 +329,7 @@ func recordToColumns(record *hubv1.Record) (map[string]string, [][]string) {
 			}
 		}
 		if len(genres) > 0 {
-			cols["field_genre"] = strings.Join(genres, sep)
+			cols["field_genre"] = strings.Join(genres, delim)
 		}
 	}
 
@@ -225,9 +342,28 @@ func recordToColumns(record *hubv1.Record) (map[string]string, [][]string) {
 			}
 		}
 		if len(ids) > 0 {
-			cols["field_identifier"] = strings.Join(ids, sep)
+			cols["field_identifier"] = strings.Join(ids, delim)
+		}
+	}
+
+	// Holdings → field_call_number and field_physical_location. Call
+	// numbers stay out of field_identifier: they locate a copy.
+	var callNumbers, locations []string
+	for _, h := range record.Holdings {
+		if h.CallNumber != "" {
+			callNumbers = append(callNumbers, h.CallNumber)
+		}
+		if h.Institution != "" {
+			locations = append(locations, h.Institution)
 		}
 	}
+	if len(callNumbers) > 0 {
+		cols["field_call_number"] = strings.Join(callNumbers, delim)
+	}
+	if len(locations) > 0 {
+		cols["field_physical_location"] = strings.Join(locations, delim)
+	}
+	cols["field_box_folder"] = hub.BoxFolderString(record.ArchivalLocation)
 
 	// Physical description → field_extent
 	var extents []string
@@ -244,7 +380,7 @@ func recordToColumns(record *hubv1.Record) (map[string]string, [][]string) {
 		extents = append(extents, attrValue(fmt.Sprintf("%d", record.PageCount), "page"))
 	}
 	if len(extents) > 0 {
-		cols["field_extent"] = strings.Join(extents, sep)
+		cols["field_extent"] = strings.Join(extents, delim)
 	}
 
 	// Notes → field_note
@@ -256,15 +392,20 @@ func recordToColumns(record *hubv1.Record) (map[string]string, [][]string) {
 			}
 		}
 		if len(notes) > 0 {
-			cols["field_note"] = strings.Join(notes, sep)
+			cols["field_note"] = strings.Join(notes, delim)
 		}
 	}
 
-	// Relations → field_member_of
+	// Relations → field_member_of. A parent in the same CSV is linked by
+	// parent_id; Workbench fills in its node ID once it has been created.
 	memberOf := hub.GetMemberOf(record)
 	if len(memberOf) > 0 {
+		parentID := hub.GetExtraString(record, "parent_id")
 		vals := make([]string, 0, len(memberOf))
 		for _, rel := range memberOf {
+			if parentID != "" && rel.TargetId == parentID {
+				continue
+			}
 			if rel.TargetId != "" {
 				vals = append(vals, rel.TargetId)
 			} else if rel.TargetTitle != "" {
@@ -272,7 +413,7 @@ func recordToColumns(record *hubv1.Record) (map[string]string, [][]string) {
 			}
 		}
 		if len(vals) > 0 {
-			cols["field_member_of"] = strings.Join(vals, sep)
+			cols["field_member_of"] = strings.Join(vals, delim)
 		}
 	}
 
@@ -289,7 +430,7 @@ func recordToColumns(record *hubv1.Record) (map[string]string, [][]string) {
 			parts = append(parts, partDetail(record.Publication.Pages, "page"))
 		}
 		if len(parts) > 0 {
-			cols["field_part_detail"] = strings.Join(parts, sep)
+			cols["field_part_detail"] = strings.Join(parts, delim)
 		}
 
 		// Journal title → field_related_item
@@ -303,6 +444,17 @@ func recordToColumns(record *hubv1.Record) (map[string]string, [][]string) {
 	return cols, agents
 }
 
+// workbenchChecksum returns the file checksum, and its algorithm, for the
+// first algorithm Islandora Workbench supports, in md5, sha1, sha256 order.
+func workbenchChecksum(file *hubv1.File) (algo, value string) {
+	for _, algo := range []string{"md5", "sha1", "sha256"} {
+		if v := hub.GetChecksum(file, algo); v != "" {
+			return algo, v
+		}
+	}
+	return "", ""
+}
+
 // orderedColumns returns the columns that have data, in canonical order,
 // with any unrecognised columns appended alphabetically at the end.
 func orderedColumns(seen map[string]bool) []string {
@@ -354,6 +506,40 @@ func writeAgentsCSV(w io.Writer, rows []workbenchRow) error {
 	return writer.Error()
 }
 
+// subdelimiter returns the delimiter for multi-value cells, Workbench's
+// subdelimiter setting.
+func subdelimiter(opts *format.SerializeOptions) string {
+	if opts.MultiValueSeparator != "" {
+		return opts.MultiValueSeparator
+	}
+	return sep
+}
+
+// linkedAgent formats a contributor for the field_linked_agent column in
+// the given typed relation style. The id style needs the agent's term ID,
+// so contributors without a source ID fall back to the name style.
+func linkedAgent(c *hubv1.Contributor, style string) string {
+	if style == typedRelationID && c.SourceId != "" {
+		return fmt.Sprintf("%s:%s", agentRole(c), c.SourceId)
+	}
+	return serializeLinkedAgent(c)
+}
+
+// agentRole returns the contributor's relator, from its role code or role
+// label, defaulting to author. Role codes outside the relator vocabulary are
+// kept as they are.
+func agentRole(c *hubv1.Contributor) string {
+	code := cmp.Or(helpers.RelatorCode(c.RoleCode), helpers.RelatorCode(c.Role))
+	switch {
+	case code != "":
+		return "relators:" + code
+	case c.RoleCode != "":
+		return c.RoleCode
+	default:
+		return "relators:aut"
+	}
+}
+
 // serializeLinkedAgent formats a contributor for the field_linked_agent column.
 // Format: "relators:cre:person:Name" or "relators:cre:person:Name - Institution"
 func serializeLinkedAgent(c *hubv1.Contributor) string {
@@ -369,12 +555,7 @@ func serializeLinkedAgent(c *hubv1.Contributor) string {
 		name = fmt.Sprintf("%s - %s", name, institution)
 	}
 
-	roleCode := c.RoleCode
-	if roleCode == "" {
-		roleCode = "relators:aut"
-	}
-
-	return fmt.Sprintf("%s:%s:%s", roleCode, typePart, name)
+	return fmt.Sprintf("%s:%s:%s", agentRole(c), typePart, name)
 }
 
 // needsAgentRow returns true when this contributor has metadata beyond just a name
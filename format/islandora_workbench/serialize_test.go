@@ -3,10 +3,15 @@ package islandora_workbench
 import (
 	"bytes"
 	"encoding/csv"
+	"errors"
 	"io"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"gopkg.in/yaml.v3"
+
 	"github.com/lehigh-university-libraries/crosswalk/format"
 	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
 	"github.com/lehigh-university-libraries/crosswalk/hub"
@@ -54,6 +59,24 @@ func TestSerializeLinkedAgent(t *testing.T) {
 			},
 			want: "relators:aut:person:Smith, Jane",
 		},
+		{
+			name: "role label without a code",
+			c: &hubv1.Contributor{
+				Name: "Smith, Jane",
+				Role: "Thesis adviser",
+				Type: hubv1.ContributorType_CONTRIBUTOR_TYPE_PERSON,
+			},
+			want: "relators:ths:person:Smith, Jane",
+		},
+		{
+			name: "relator URI",
+			c: &hubv1.Contributor{
+				Name:     "Smith, Jane",
+				RoleCode: "http://id.loc.gov/vocabulary/relators/edt",
+				Type:     hubv1.ContributorType_CONTRIBUTOR_TYPE_PERSON,
+			},
+			want: "relators:edt:person:Smith, Jane",
+		},
 	}
 
 	for _, tt := range tests {
@@ -236,6 +259,56 @@ func TestSerialize_MainCSV(t *testing.T) {
 	}
 }
 
+func TestSerialize_ColumnSetAndSubdelimiter(t *testing.T) {
+	record := &hubv1.Record{
+		Title:    "A Study of Something",
+		Language: "en",
+		Contributors: []*hubv1.Contributor{
+			{Name: "Qin, Tian", RoleCode: "relators:cre", SourceId: "123"},
+			{Name: "Huang, Wei-Min", RoleCode: "relators:ths"}, // no term ID
+		},
+		Subjects: []*hubv1.Subject{{Value: "Geology"}, {Value: "Rivers"}},
+	}
+	hub.SetExtra(record, "id", "1")
+
+	var buf, configBuf bytes.Buffer
+	opts := format.NewSerializeOptions()
+	opts.Columns = []string{"id", "field_subject", "title", "field_linked_agent", "field_note"}
+	opts.MultiValueSeparator = ";"
+	opts.TypedRelation = "id"
+	opts.ExtraWriters = map[string]io.Writer{"config": &configBuf}
+	if err := (&Format{}).Serialize(&buf, []*hubv1.Record{record}, opts); err != nil {
+		t.Fatalf("Serialize error: %v", err)
+	}
+
+	rows := parseCSV(t, buf.String())
+	if got := strings.Join(rows[0], ","); got != "id,field_subject,title,field_linked_agent,field_note" {
+		t.Errorf("header = %s, want the given columns in order", got)
+	}
+	want := []string{"1", "Geology;Rivers", "A Study of Something", "relators:cre:123;relators:ths:person:Huang, Wei-Min", ""}
+	if strings.Join(rows[1], "\x00") != strings.Join(want, "\x00") {
+		t.Errorf("row = %q, want %q", rows[1], want)
+	}
+
+	var cfg workbenchConfig
+	if err := yaml.Unmarshal(configBuf.Bytes(), &cfg); err != nil {
+		t.Fatalf("config is not valid YAML: %v", err)
+	}
+	if cfg.Subdelimiter != ";" {
+		t.Errorf("subdelimiter = %q, want ;", cfg.Subdelimiter)
+	}
+
+	// Language has no column, so a lossless conversion must refuse
+	if got := strings.Join((&Format{}).WrittenFields(opts), ","); got != "subjects,title,contributors,notes" {
+		t.Errorf("written fields = %s", got)
+	}
+
+	opts.TypedRelation = "uuid"
+	if err := (&Format{}).Serialize(io.Discard, []*hubv1.Record{record}, opts); err == nil {
+		t.Error("expected an error for an unknown typed relation style")
+	}
+}
+
 func TestSerialize_AgentsCSV(t *testing.T) {
 	record := &hubv1.Record{
 		Title: "A Thesis",
@@ -298,6 +371,227 @@ func TestSerialize_AgentsCSV(t *testing.T) {
 	}
 }
 
+func TestSerialize_FileChecksum(t *testing.T) {
+	file := &hubv1.File{Path: "/data/thesis.pdf", Role: "original"}
+	hub.SetChecksum(file, "SHA-256", "ABC123")
+	hub.SetChecksum(file, "md5", "d41d8cd98f00b204e9800998ecf8427e")
+	record := &hubv1.Record{
+		Title: "A Thesis",
+		Files: []*hubv1.File{
+			{Path: "/data/thumb.jpg", Role: "thumbnail"},
+			file,
+		},
+	}
+
+	var buf bytes.Buffer
+	opts := format.NewSerializeOptions()
+	opts.IncludeHeader = true
+	if err := (&Format{}).Serialize(&buf, []*hubv1.Record{record}, opts); err != nil {
+		t.Fatalf("Serialize error: %v", err)
+	}
+
+	rows := parseCSV(t, buf.String())
+	got := make(map[string]string)
+	for i, h := range rows[0] {
+		got[h] = rows[1][i]
+	}
+	if got["file"] != "/data/thesis.pdf" {
+		t.Errorf("file = %q, want primary file path", got["file"])
+	}
+	if got["checksum"] != "d41d8cd98f00b204e9800998ecf8427e" {
+		t.Errorf("checksum = %q, want md5 value", got["checksum"])
+	}
+}
+
+func TestSerialize_Holdings(t *testing.T) {
+	record := &hubv1.Record{
+		Title: "Letter to the Board of Trustees",
+		Identifiers: []*hubv1.Identifier{
+			{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_LOCAL, Value: "ms0012-0034"},
+		},
+		Holdings: []*hubv1.Holding{
+			{Institution: "Special Collections", CallNumber: "LD3171 .L5 1890"},
+		},
+		ArchivalLocation: &hubv1.ArchivalLocation{Box: "3", Folder: "12"},
+	}
+
+	var buf bytes.Buffer
+	opts := format.NewSerializeOptions()
+	opts.IncludeHeader = true
+	if err := (&Format{}).Serialize(&buf, []*hubv1.Record{record}, opts); err != nil {
+		t.Fatalf("Serialize error: %v", err)
+	}
+
+	rows := parseCSV(t, buf.String())
+	got := make(map[string]string)
+	for i, h := range rows[0] {
+		got[h] = rows[1][i]
+	}
+	if got["field_call_number"] != "LD3171 .L5 1890" {
+		t.Errorf("field_call_number = %q", got["field_call_number"])
+	}
+	if got["field_physical_location"] != "Special Collections" {
+		t.Errorf("field_physical_location = %q", got["field_physical_location"])
+	}
+	if got["field_box_folder"] != "Box 3, Folder 12" {
+		t.Errorf("field_box_folder = %q", got["field_box_folder"])
+	}
+	if strings.Contains(got["field_identifier"], "LD3171") {
+		t.Errorf("call number leaked into field_identifier: %q", got["field_identifier"])
+	}
+
+	parsed, err := (&Format{}).Parse(strings.NewReader(buf.String()), format.NewParseOptions())
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if len(parsed[0].Holdings) != 1 || parsed[0].Holdings[0].CallNumber != "LD3171 .L5 1890" {
+		t.Errorf("round-trip holdings = %v", parsed[0].Holdings)
+	}
+	if loc := parsed[0].ArchivalLocation; loc == nil || loc.Box != "3" || loc.Folder != "12" {
+		t.Errorf("round-trip ArchivalLocation = %v", loc)
+	}
+}
+
+func TestSerialize_ParentID(t *testing.T) {
+	child := &hubv1.Record{
+		Title: "Blast furnaces",
+		Relations: []*hubv1.Relation{
+			{Type: hubv1.RelationType_RELATION_TYPE_MEMBER_OF, TargetId: "ref1", TargetTitle: "Plant Operations"},
+			{Type: hubv1.RelationType_RELATION_TYPE_MEMBER_OF, TargetId: "42"},
+		},
+	}
+	hub.SetExtra(child, "id", "ref2")
+	hub.SetExtra(child, "parent_id", "ref1")
+
+	var buf bytes.Buffer
+	opts := format.NewSerializeOptions()
+	opts.IncludeHeader = true
+	if err := (&Format{}).Serialize(&buf, []*hubv1.Record{child}, opts); err != nil {
+		t.Fatalf("Serialize error: %v", err)
+	}
+
+	rows := parseCSV(t, buf.String())
+	got := make(map[string]string)
+	for i, h := range rows[0] {
+		got[h] = rows[1][i]
+	}
+	if got["parent_id"] != "ref1" {
+		t.Errorf("parent_id = %q", got["parent_id"])
+	}
+	if got["field_member_of"] != "42" {
+		t.Errorf("field_member_of = %q, want only the node outside the CSV", got["field_member_of"])
+	}
+}
+
+func TestSerialize_WorkbenchConfig(t *testing.T) {
+	configDir := t.TempDir()
+	writeConfigFile(t, configDir, "field.field.node.islandora_object.field_model.yml",
+		"field_name: field_model\nentity_type: node\nbundle: islandora_object\nfield_type: entity_reference\n")
+	writeConfigFile(t, configDir, "media.type.document.yml",
+		"id: document\nsource_configuration:\n  source_field: field_media_document\n")
+	writeConfigFile(t, configDir, "field.field.media.document.field_media_document.yml",
+		"field_name: field_media_document\nentity_type: media\nbundle: document\nsettings:\n  file_extensions: 'pdf txt'\n")
+
+	file := &hubv1.File{Path: "thesis.pdf", Role: "original"}
+	hub.SetChecksum(file, "md5", "d41d8cd98f00b204e9800998ecf8427e")
+	record := &hubv1.Record{
+		Title:        "A Thesis",
+		Language:     "en",
+		ResourceType: &hubv1.ResourceType{Type: hubv1.ResourceTypeValue_RESOURCE_TYPE_THESIS},
+		Files:        []*hubv1.File{file},
+	}
+	hub.SetExtra(record, "id", "1")
+
+	var mainBuf, configBuf bytes.Buffer
+	opts := format.NewSerializeOptions()
+	opts.OutputName = "theses.csv"
+	opts.DrupalConfig = configDir
+	opts.ExtraWriters = map[string]io.Writer{"config": &configBuf}
+	if err := (&Format{}).Serialize(&mainBuf, []*hubv1.Record{record}, opts); err != nil {
+		t.Fatalf("Serialize error: %v", err)
+	}
+
+	var cfg workbenchConfig
+	if err := yaml.Unmarshal(configBuf.Bytes(), &cfg); err != nil {
+		t.Fatalf("config is not valid YAML: %v\n%s", err, configBuf.String())
+	}
+	if cfg.Task != "create" || cfg.InputCSV != "theses.csv" || cfg.ContentType != "islandora_object" {
+		t.Errorf("task/input_csv/content_type = %q/%q/%q", cfg.Task, cfg.InputCSV, cfg.ContentType)
+	}
+	if cfg.NodesOnly {
+		t.Error("nodes_only set although the CSV has a file column")
+	}
+	if cfg.FixityAlgorithm != "md5" {
+		t.Errorf("fixity_algorithm = %q, want md5", cfg.FixityAlgorithm)
+	}
+	if len(cfg.MediaTypes) != 1 || strings.Join(cfg.MediaTypes[0]["document"], " ") != "pdf txt" {
+		t.Errorf("media_types = %v", cfg.MediaTypes)
+	}
+	// field_language is not configured on the bundle
+	if len(cfg.IgnoreCSVColumns) != 1 || cfg.IgnoreCSVColumns[0] != "field_language" {
+		t.Errorf("ignore_csv_columns = %v, want [field_language]", cfg.IgnoreCSVColumns)
+	}
+}
+
+func TestSerialize_WorkbenchConfigUpdate(t *testing.T) {
+	record := &hubv1.Record{Title: "A Thesis"}
+	hub.SetExtra(record, "node_id", "42")
+
+	var mainBuf, configBuf bytes.Buffer
+	opts := format.NewSerializeOptions()
+	opts.ExtraWriters = map[string]io.Writer{"config": &configBuf}
+	if err := (&Format{}).Serialize(&mainBuf, []*hubv1.Record{record}, opts); err != nil {
+		t.Fatalf("Serialize error: %v", err)
+	}
+
+	var cfg workbenchConfig
+	if err := yaml.Unmarshal(configBuf.Bytes(), &cfg); err != nil {
+		t.Fatalf("config is not valid YAML: %v", err)
+	}
+	if cfg.Task != "update" || cfg.IDField != "" || cfg.NodesOnly {
+		t.Errorf("task/id_field/nodes_only = %q/%q/%v, want update with no id_field", cfg.Task, cfg.IDField, cfg.NodesOnly)
+	}
+}
+
+func writeConfigFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSerialize_LengthPolicies(t *testing.T) {
+	record := &hubv1.Record{
+		Title: strings.Repeat("word ", 60),
+		Notes: []string{"short note"},
+	}
+	hub.SetExtra(record, "id", "1")
+
+	var buf bytes.Buffer
+	opts := format.NewSerializeOptions()
+	if err := (&Format{}).Serialize(&buf, []*hubv1.Record{record}, opts); err != nil {
+		t.Fatalf("Serialize error: %v", err)
+	}
+	rows := parseCSV(t, buf.String())
+	got := make(map[string]string)
+	for i, h := range rows[0] {
+		got[h] = rows[1][i]
+	}
+	if n := len([]rune(got["title"])); n > 255 {
+		t.Errorf("title is %d characters, want at most 255", n)
+	}
+	if !strings.HasSuffix(got["title"], "word"+format.Ellipsis) {
+		t.Errorf("title = %q, want truncation at a word boundary", got["title"])
+	}
+
+	opts.OverLength = format.LengthFail
+	err := (&Format{}).Serialize(io.Discard, []*hubv1.Record{record}, opts)
+	var lengthErr *format.LengthError
+	if !errors.As(err, &lengthErr) || lengthErr.Field != "title" {
+		t.Errorf("Serialize error = %v, want title LengthError", err)
+	}
+}
+
 func TestIslandoraModel(t *testing.T) {
 	tests := []struct {
 		rt   hubv1.ResourceTypeValue
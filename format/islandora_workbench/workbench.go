@@ -4,10 +4,15 @@
 // content via CSV files. This format serializes hub records to Workbench CSV.
 //
 // Key characteristics:
-//   - Multi-value separator is "|" (pipe)
+//   - Multi-value separator is "|" (pipe) by default; any subdelimiter
+//     can be set through SerializeOptions.MultiValueSeparator
+//   - SerializeOptions.Columns fixes the column set and order, to match a
+//     site's existing Workbench config
 //   - Column names are Drupal field names (e.g., field_linked_agent)
 //   - Contributors with extra metadata (orcid, email, status, institution)
 //     generate rows in a separate agents CSV, written to ExtraWriters["agents"]
+//   - A config.yml template matching the CSV columns can be written to
+//     ExtraWriters["config"]
 //   - Reserved columns: id, parent_id, node_id, file, title, url_alias, etc.
 package islandora_workbench
 
@@ -29,6 +34,65 @@ func (f *Format) Description() string { return "Islandora Workbench CSV import f
 // Extensions returns file extensions associated with this format.
 func (f *Format) Extensions() []string { return []string{"csv"} }
 
+// writtenFields are the hub record fields Serialize writes.
+var writtenFields = []string{
+	"title", "abstract", "contributors", "dates", "resource_type", "genres",
+	"subjects", "language", "publication", "rights", "identifiers",
+	"description", "physical_desc", "page_count", "dimensions", "duration",
+	"notes", "archival_location", "holdings", "files", "relations.member_of",
+}
+
+// columnFields maps each column to the hub record fields it carries.
+var columnFields = map[string][]string{
+	"file":                    {"files"},
+	"checksum":                {"files"},
+	"media_use_tid":           {"files"},
+	"image_alt_text":          {"files"},
+	"service":                 {"files"},
+	"preservation":            {"files"},
+	"thumbnail":               {"files"},
+	"transcript":              {"files"},
+	"extracted_text":          {"files"},
+	"title":                   {"title"},
+	"field_model":             {"resource_type"},
+	"field_language":          {"language"},
+	"field_linked_agent":      {"contributors"},
+	"field_edtf_date_issued":  {"dates"},
+	"field_edtf_date_created": {"dates"},
+	"field_abstract":          {"abstract", "description"},
+	"field_rights":            {"rights"},
+	"field_subject":           {"subjects"},
+	"field_genre":             {"genres"},
+	"field_identifier":        {"identifiers"},
+	"field_extent":            {"physical_desc", "page_count", "dimensions", "duration"},
+	"field_call_number":       {"holdings"},
+	"field_physical_location": {"holdings"},
+	"field_box_folder":        {"archival_location"},
+	"field_note":              {"notes"},
+	"field_member_of":         {"relations.member_of"},
+	"field_part_detail":       {"publication"},
+	"field_related_item":      {"publication"},
+}
+
+// WrittenFields returns the hub record fields Serialize writes, narrowed
+// to those carried by opts.Columns when a column set is given.
+func (f *Format) WrittenFields(opts *format.SerializeOptions) []string {
+	if opts == nil || len(opts.Columns) == 0 {
+		return writtenFields
+	}
+	var fields []string
+	seen := make(map[string]bool)
+	for _, col := range opts.Columns {
+		for _, field := range columnFields[col] {
+			if !seen[field] {
+				seen[field] = true
+				fields = append(fields, field)
+			}
+		}
+	}
+	return fields
+}
+
 // CanParse returns true if the content looks like a Workbench CSV file.
 // Workbench CSVs have "id" or "node_id" as the first column.
 func (f *Format) CanParse(peek []byte) bool {
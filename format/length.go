@@ -0,0 +1,113 @@
+package format
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// LengthMode selects what happens to a value longer than its limit.
+type LengthMode string
+
+const (
+	// LengthTruncate shortens the value at a word boundary and appends an
+	// ellipsis. Each truncation is logged as a warning.
+	LengthTruncate LengthMode = "truncate"
+	// LengthFail rejects the record with a *LengthError.
+	LengthFail LengthMode = "fail"
+)
+
+// Ellipsis is appended to truncated values.
+const Ellipsis = "…"
+
+// LengthPolicy limits a text field to Max characters (runes). A zero Max
+// means unlimited.
+type LengthPolicy struct {
+	Max  int
+	Mode LengthMode
+}
+
+// LengthError reports a value that exceeds a LengthFail policy.
+type LengthError struct {
+	Field  string
+	Length int
+	Max    int
+}
+
+func (e *LengthError) Error() string {
+	return fmt.Sprintf("%s is %d characters, limit is %d", e.Field, e.Length, e.Max)
+}
+
+// ParseLengthMode parses a mode name, accepting "" as LengthTruncate.
+func ParseLengthMode(s string) (LengthMode, error) {
+	switch LengthMode(s) {
+	case "", LengthTruncate:
+		return LengthTruncate, nil
+	case LengthFail:
+		return LengthFail, nil
+	}
+	return "", fmt.Errorf("unknown length mode %q (want truncate or fail)", s)
+}
+
+// LengthPolicy returns the policy for a field: the caller's override from
+// Lengths if set, otherwise the serializer's default with OverLength
+// applied.
+func (o *SerializeOptions) LengthPolicy(field string, def LengthPolicy) LengthPolicy {
+	if o == nil {
+		return def
+	}
+	if p, ok := o.Lengths[field]; ok {
+		return p
+	}
+	if o.OverLength != "" {
+		def.Mode = o.OverLength
+	}
+	return def
+}
+
+// ApplyLength enforces a policy on one value. The record label (usually its
+// title) identifies the record in the warning logged for a truncation.
+func ApplyLength(record, field, value string, p LengthPolicy) (string, error) {
+	n := utf8.RuneCountInString(value)
+	if p.Max <= 0 || n <= p.Max {
+		return value, nil
+	}
+	if p.Mode == LengthFail {
+		return value, &LengthError{Field: field, Length: n, Max: p.Max}
+	}
+
+	slog.Warn("truncated value to length limit",
+		"title", record, "field", field, "length", n, "max", p.Max)
+	return TruncateText(value, p.Max), nil
+}
+
+// TruncateText shortens s to at most max runes, including the ellipsis,
+// cutting at the last word boundary that fits. A single word longer than
+// the limit is cut mid-word.
+func TruncateText(s string, max int) string {
+	if utf8.RuneCountInString(s) <= max {
+		return s
+	}
+	room := max - utf8.RuneCountInString(Ellipsis)
+	if room <= 0 {
+		return string([]rune(Ellipsis)[:max])
+	}
+
+	runes := []rune(s)
+	cut := room
+	for i := room; i > 0; i-- {
+		if unicode.IsSpace(runes[i]) {
+			cut = i
+			break
+		}
+	}
+	head := strings.TrimRightFunc(string(runes[:cut]), func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r)
+	})
+	if head == "" {
+		head = string(runes[:room])
+	}
+	return head + Ellipsis
+}
@@ -0,0 +1,48 @@
+package format_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+)
+
+func TestTruncateText(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		max  int
+		want string
+	}{
+		{"fits", "short abstract", 20, "short abstract"},
+		{"word boundary", "The quick brown fox jumps", 16, "The quick brown…"},
+		{"drops trailing punctuation", "Results, methods, and data", 19, "Results, methods…"},
+		{"single long word", "Supercalifragilistic", 8, "Superca…"},
+		{"counts runes", "Über die Natur der Dinge", 10, "Über die…"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := format.TruncateText(tt.in, tt.max); got != tt.want {
+				t.Errorf("TruncateText(%q, %d) = %q, want %q", tt.in, tt.max, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyLength(t *testing.T) {
+	opts := &format.SerializeOptions{
+		Lengths: map[string]format.LengthPolicy{"abstract": {Max: 5, Mode: format.LengthFail}},
+	}
+	def := format.LengthPolicy{Max: 100, Mode: format.LengthTruncate}
+
+	_, err := format.ApplyLength("A Record", "abstract", "too long for five", opts.LengthPolicy("abstract", def))
+	var lengthErr *format.LengthError
+	if !errors.As(err, &lengthErr) || lengthErr.Max != 5 {
+		t.Errorf("override: error = %v, want LengthError with max 5", err)
+	}
+
+	got, err := format.ApplyLength("A Record", "title", "fits the default", opts.LengthPolicy("title", def))
+	if err != nil || got != "fits the default" {
+		t.Errorf("default: got %q, %v; want value unchanged", got, err)
+	}
+}
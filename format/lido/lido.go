@@ -0,0 +1,72 @@
+// Package lido provides a serializer for LIDO 1.0 (Lightweight
+// Information Describing Objects), the harvesting schema museum
+// collection management systems ingest.
+//
+// Each record becomes a lido:lido in a lidoWrap. The resource type gives
+// the object/work type, as a Getty AAT concept where there is one, and
+// genres become classifications. The record's dates become events:
+// creation (production, for physical objects), with the contributors;
+// publication, with the publisher and place; and collection, with the
+// collectors and the geographic location and coordinates. Holdings, or
+// failing those the record's source ID, give the repository sets, and
+// --provider names the record source, falling back to the holding
+// institution and then the publisher.
+//
+// LIDO requires a record ID and a title; records without an identifier,
+// a source ID, or a title fail to serialize.
+package lido
+
+import (
+	"github.com/lehigh-university-libraries/crosswalk/format"
+)
+
+// Version is the LIDO schema version this implementation targets.
+const Version = "1.0"
+
+// Format implements the LIDO format.
+type Format struct{}
+
+// Ensure Format implements the interfaces
+var (
+	_ format.Format         = (*Format)(nil)
+	_ format.Serializer     = (*Format)(nil)
+	_ format.FieldDescriber = (*Format)(nil)
+)
+
+// Name returns the format identifier.
+func (f *Format) Name() string {
+	return "lido"
+}
+
+// Description returns a human-readable format description.
+func (f *Format) Description() string {
+	return "LIDO " + Version + " museum object records"
+}
+
+// Extensions returns file extensions associated with this format.
+func (f *Format) Extensions() []string {
+	return []string{"xml", "lido"}
+}
+
+// writtenFields are the hub record fields Serialize writes.
+var writtenFields = []string{
+	"title", "titles", "alt_title", "abstract", "abstracts", "description",
+	"contributors", "dates", "resource_type", "genres", "subjects",
+	"language", "publisher", "place_published", "edition", "rights",
+	"rights_holder", "copyright_statement", "identifiers", "physical_desc",
+	"dimensions", "holdings", "geographic", "files", "relations",
+}
+
+// WrittenFields returns the hub record fields Serialize writes.
+func (f *Format) WrittenFields(opts *format.SerializeOptions) []string {
+	return writtenFields
+}
+
+// CanParse returns false; LIDO is output only.
+func (f *Format) CanParse(peek []byte) bool {
+	return false
+}
+
+func init() {
+	format.Register(&Format{})
+}
@@ -0,0 +1,605 @@
+package lido
+
+import (
+	"cmp"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/helpers"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+// defaultSource names the record source when neither --provider, a
+// holding institution, nor a publisher does.
+const defaultSource = "Crosswalk"
+
+// relators is the base of MARC relator URIs, used for actor roles.
+const relators = "http://id.loc.gov/vocabulary/relators/"
+
+// Serialize writes records as lido:lido elements in a lidoWrap.
+func (f *Format) Serialize(w io.Writer, records []*hubv1.Record, opts *format.SerializeOptions) error {
+	if opts == nil {
+		opts = format.NewSerializeOptions()
+	}
+	if len(records) == 0 {
+		return fmt.Errorf("LIDO requires at least one record: %w", format.ErrEmptyDocument)
+	}
+
+	wrap := XMLWrap{
+		XmlnsLIDO:         Namespace,
+		XmlnsGML:          NamespaceGML,
+		XmlnsXSI:          NamespaceXSI,
+		XSISchemaLocation: schemaLocation,
+	}
+	for i, record := range records {
+		lido, err := recordToLIDO(record, opts)
+		if err != nil {
+			return fmt.Errorf("record %d: %w", i, err)
+		}
+		wrap.Records = append(wrap.Records, *lido)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	output, err := xml.MarshalIndent(wrap, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling LIDO: %w", err)
+	}
+	if _, err := w.Write(output); err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, "\n")
+	return err
+}
+
+// recordToLIDO builds the LIDO record of a hub record.
+func recordToLIDO(record *hubv1.Record, opts *format.SerializeOptions) (*XMLLIDO, error) {
+	recID, ok := recordID(record)
+	if !ok {
+		return nil, fmt.Errorf("no identifier or source ID for the mandatory lidoRecID")
+	}
+	titles := titleSets(record)
+	if len(titles) == 0 {
+		return nil, fmt.Errorf("no title for the mandatory titleSet")
+	}
+
+	typ := resourceType(record)
+	category := categoryInformation.concept(crm, "CIDOC-CRM")
+	if isPhysical(typ) {
+		category = categoryObject.concept(crm, "CIDOC-CRM")
+	}
+	lang := cmp.Or(record.Language, "en")
+
+	lido := &XMLLIDO{
+		RecID:        recID,
+		PublishedIDs: publishedIDs(record),
+		Category:     &category,
+		Descriptive: XMLDescriptiveMetadata{
+			Lang: lang,
+			Classification: XMLClassificationWrap{
+				WorkTypes: []XMLConcept{objectWorkType(record)},
+			},
+			Identification: XMLIdentificationWrap{Titles: titles},
+		},
+		Administrative: XMLAdministrativeMetadata{
+			Lang:   lang,
+			Record: recordWrap(record, recID, opts),
+		},
+	}
+
+	desc := &lido.Descriptive
+	if c := genres(record); len(c) > 0 {
+		desc.Classification.Classifications = &XMLClassificationsWrap{Classifications: c}
+	}
+	if sets := repositorySets(record, opts); len(sets) > 0 {
+		desc.Identification.Repositories = &XMLRepositoryWrap{Sets: sets}
+	}
+	if record.Edition != "" {
+		desc.Identification.Editions = &XMLEditionWrap{Editions: []string{record.Edition}}
+	}
+	if sets := descriptions(record); len(sets) > 0 {
+		desc.Identification.Descriptions = &XMLDescriptionWrap{Sets: sets}
+	}
+	var measurements []string
+	for _, m := range []string{record.Dimensions, record.PhysicalDesc} {
+		if m != "" {
+			measurements = append(measurements, m)
+		}
+	}
+	if len(measurements) > 0 {
+		desc.Identification.Measurements = &XMLMeasurementsWrap{Displays: measurements}
+	}
+	if sets := events(record, typ); len(sets) > 0 {
+		desc.Events = &XMLEventWrap{Sets: sets}
+	}
+	subjects, related := subjectSets(record), relatedWorks(record)
+	if len(subjects) > 0 || len(related) > 0 {
+		desc.Relations = &XMLRelationWrap{}
+		if len(subjects) > 0 {
+			desc.Relations.Subjects = &XMLSubjectWrap{Sets: subjects}
+		}
+		if len(related) > 0 {
+			desc.Relations.RelatedWorks = &XMLRelatedWorksWrap{Sets: related}
+		}
+	}
+
+	admin := &lido.Administrative
+	if sets := rightsSets(record); len(sets) > 0 {
+		admin.Rights = &XMLRightsWorkWrap{Sets: sets}
+	}
+	if sets := resourceSets(record); len(sets) > 0 {
+		admin.Resources = &XMLResourceWrap{Sets: sets}
+	}
+	return lido, nil
+}
+
+// resourceType returns the record's resource type, if any.
+func resourceType(record *hubv1.Record) hubv1.ResourceTypeValue {
+	if record.ResourceType == nil {
+		return hubv1.ResourceTypeValue_RESOURCE_TYPE_UNSPECIFIED
+	}
+	return record.ResourceType.Type
+}
+
+// recordID identifies the LIDO record: by the ID of the record it was
+// crosswalked from where there is one, else by its first identifier.
+func recordID(record *hubv1.Record) (XMLID, bool) {
+	if si := record.SourceInfo; si != nil && si.SourceId != "" {
+		return XMLID{Type: "local", Source: si.Format, Value: si.SourceId}, true
+	}
+	for _, id := range record.Identifiers {
+		if id.Value != "" {
+			return XMLID{Type: identifierType(id.Type), Value: id.Value}, true
+		}
+	}
+	return XMLID{}, false
+}
+
+// identifierType names an identifier type the way LIDO's type attributes
+// do.
+func identifierType(t hubv1.IdentifierType) string {
+	switch t {
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_URL:
+		return "URI"
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_CALL_NUMBER:
+		return "inventory number"
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_UNSPECIFIED:
+		return ""
+	}
+	return strings.ToLower(strings.TrimPrefix(t.String(), "IDENTIFIER_TYPE_"))
+}
+
+// publishedIDs are the record's persistent identifiers: its DOIs,
+// handles, and URLs.
+func publishedIDs(record *hubv1.Record) []XMLID {
+	var ids []XMLID
+	for _, id := range record.Identifiers {
+		switch id.Type {
+		case hubv1.IdentifierType_IDENTIFIER_TYPE_DOI,
+			hubv1.IdentifierType_IDENTIFIER_TYPE_HANDLE,
+			hubv1.IdentifierType_IDENTIFIER_TYPE_URL:
+			ids = append(ids, XMLID{Type: identifierType(id.Type), Value: id.Value})
+		}
+	}
+	return ids
+}
+
+// objectWorkType gives the record's resource type as an AAT concept, or
+// as the type the source gave where there is no AAT mapping.
+func objectWorkType(record *hubv1.Record) XMLConcept {
+	if wt, ok := workTypes[resourceType(record)]; ok {
+		c := wt.concept(aat, "AAT")
+		if record.ResourceType.Original != "" && !strings.EqualFold(record.ResourceType.Original, wt.term) {
+			c.Terms = append(c.Terms, XMLTerm{Value: record.ResourceType.Original})
+		}
+		return c
+	}
+	term := "not specified"
+	if rt := record.ResourceType; rt != nil && rt.Original != "" {
+		term = rt.Original
+	}
+	return XMLConcept{Terms: []XMLTerm{{Value: term}}}
+}
+
+// genres classifies the record by its genres.
+func genres(record *hubv1.Record) []XMLClassification {
+	var out []XMLClassification
+	for _, g := range record.Genres {
+		if g.Value == "" {
+			continue
+		}
+		c := XMLClassification{Type: "genre", XMLConcept: XMLConcept{Terms: []XMLTerm{{Value: g.Value}}}}
+		if g.Uri != "" {
+			c.ConceptIDs = []XMLID{{Type: "URI", Value: g.Uri}}
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+// titleSets gives the preferred title, with its translations, then each
+// alternative title.
+func titleSets(record *hubv1.Record) []XMLNameSet {
+	if record.Title == "" {
+		return nil
+	}
+	preferred := XMLNameSet{Values: []XMLAppellation{{Pref: "preferred", Value: record.Title}}}
+	for _, t := range record.Titles {
+		if t.Value != "" && t.Value != record.Title {
+			preferred.Values = append(preferred.Values, XMLAppellation{Pref: "preferred", Lang: t.Language, Value: t.Value})
+		}
+	}
+	sets := []XMLNameSet{preferred}
+	for _, alt := range record.AltTitle {
+		if alt != "" {
+			sets = append(sets, XMLNameSet{Values: []XMLAppellation{{Pref: "alternate", Value: alt}}})
+		}
+	}
+	return sets
+}
+
+// repositorySets gives where the object is held: each holding, with its
+// call number as the inventory number. Without holdings, the record's
+// source ID is the work ID in the provider's repository.
+func repositorySets(record *hubv1.Record, opts *format.SerializeOptions) []XMLRepositorySet {
+	var sets []XMLRepositorySet
+	for _, h := range record.Holdings {
+		set := XMLRepositorySet{Type: "current"}
+		if h.Institution != "" {
+			set.Name = legalBody(h.Institution)
+		}
+		if h.CallNumber != "" {
+			set.WorkIDs = []XMLID{{Type: "inventory number", Value: h.CallNumber}}
+		}
+		if h.Sublocation != "" {
+			set.Location = &XMLPlace{Names: []XMLNameSet{{Values: []XMLAppellation{{Value: h.Sublocation}}}}}
+		}
+		sets = append(sets, set)
+	}
+	if len(sets) > 0 {
+		return sets
+	}
+	if si := record.SourceInfo; si != nil && si.SourceId != "" {
+		set := XMLRepositorySet{
+			Type:    "current",
+			WorkIDs: []XMLID{{Type: "local", Source: si.Format, Value: si.SourceId}},
+		}
+		if opts.Provider != "" {
+			set.Name = legalBody(opts.Provider)
+		}
+		sets = append(sets, set)
+	}
+	return sets
+}
+
+// legalBody names an organization.
+func legalBody(name string) *XMLLegalBody {
+	return &XMLLegalBody{Name: XMLNameSet{Values: []XMLAppellation{{Value: name}}}}
+}
+
+// descriptions gives the abstracts, in their languages, and the
+// description.
+func descriptions(record *hubv1.Record) []XMLDescription {
+	var out []XMLDescription
+	abstract := XMLDescription{Type: "abstract"}
+	if record.Abstract != "" {
+		abstract.Notes = append(abstract.Notes, XMLTerm{Value: record.Abstract})
+	}
+	for _, a := range record.Abstracts {
+		if a.Value != "" && a.Value != record.Abstract {
+			abstract.Notes = append(abstract.Notes, XMLTerm{Lang: a.Language, Value: a.Value})
+		}
+	}
+	if len(abstract.Notes) > 0 {
+		out = append(out, abstract)
+	}
+	if record.Description != "" {
+		out = append(out, XMLDescription{Type: "description", Notes: []XMLTerm{{Value: record.Description}}})
+	}
+	return out
+}
+
+// events gives the record's history: its creation (or production), with
+// the contributors; its publication, with the publisher and place; and
+// its collection, with the collectors and the place collected.
+func events(record *hubv1.Record, typ hubv1.ResourceTypeValue) []XMLEventSet {
+	creationType := eventCreation
+	if isPhysical(typ) {
+		creationType = eventProduction
+	}
+	creation := XMLEvent{Type: creationType.concept(lidoTerms, "LIDO-Terminology")}
+	publication := XMLEvent{Type: eventPublication.concept(lidoTerms, "LIDO-Terminology")}
+	collecting := XMLEvent{Type: eventCollecting.concept(lidoTerms, "LIDO-Terminology")}
+
+	for _, c := range record.Contributors {
+		if c.Name == "" {
+			continue
+		}
+		switch helpers.RelatorCodeFromURI(c.RoleCode) {
+		case "pbl":
+			publication.Actors = append(publication.Actors, eventActor(c))
+		case "col":
+			collecting.Actors = append(collecting.Actors, eventActor(c))
+		default:
+			creation.Actors = append(creation.Actors, eventActor(c))
+		}
+	}
+	if record.Publisher != "" {
+		publication.Actors = append(publication.Actors, XMLEventActor{ActorInRole: XMLActorRole{
+			Actor: XMLActor{Type: "corporation", Names: XMLNameSet{Values: []XMLAppellation{{Value: record.Publisher}}}},
+			Role:  roleConcept("pbl", ""),
+		}})
+	}
+	if record.PlacePublished != "" {
+		publication.Places = []XMLPlaceWrap{{Place: XMLPlace{Names: []XMLNameSet{{Values: []XMLAppellation{{Value: record.PlacePublished}}}}}}}
+	}
+
+	creation.Date = eventDate(hub.GetDate(record, hubv1.DateType_DATE_TYPE_CREATED))
+	publication.Date = eventDate(cmp.Or(hub.GetDate(record, hubv1.DateType_DATE_TYPE_ISSUED), hub.GetDate(record, hubv1.DateType_DATE_TYPE_PUBLISHED)))
+	collecting.Date = eventDate(hub.GetDate(record, hubv1.DateType_DATE_TYPE_COLLECTED))
+	if collecting.Date != nil || len(collecting.Actors) > 0 {
+		if place := collectedPlace(record); place != nil {
+			collecting.Places = []XMLPlaceWrap{{Place: *place}}
+		}
+	}
+
+	var sets []XMLEventSet
+	for _, e := range []XMLEvent{creation, publication, collecting} {
+		if len(e.Actors) > 0 || e.Date != nil || len(e.Places) > 0 {
+			sets = append(sets, XMLEventSet{Event: e})
+		}
+	}
+	return sets
+}
+
+// collectedPlace is the record's hierarchical geographic location and
+// its coordinates.
+func collectedPlace(record *hubv1.Record) *XMLPlace {
+	place := &XMLPlace{}
+	if name := hub.PlaceName(record.Geographic); name != "" {
+		place.Names = []XMLNameSet{{Values: []XMLAppellation{{Value: name}}}}
+	}
+	if lat, lon, ok := hub.GetCoordinates(record); ok {
+		place.GML = &XMLGML{Point: XMLGMLPoint{Pos: strconv.FormatFloat(lat, 'f', -1, 64) + " " + strconv.FormatFloat(lon, 'f', -1, 64)}}
+	}
+	if len(place.Names) == 0 && place.GML == nil {
+		return nil
+	}
+	return place
+}
+
+// eventActor gives a contributor in their role.
+func eventActor(c *hubv1.Contributor) XMLEventActor {
+	return XMLEventActor{ActorInRole: XMLActorRole{
+		Actor: actor(c),
+		Role:  roleConcept(helpers.RelatorCodeFromURI(c.RoleCode), c.Role),
+	}}
+}
+
+// actor gives a contributor as a person or organization, identified by
+// ORCID or authority URI.
+func actor(c *hubv1.Contributor) XMLActor {
+	a := XMLActor{Type: "person", Names: XMLNameSet{Values: []XMLAppellation{{Pref: "preferred", Value: c.Name}}}}
+	if c.Type == hubv1.ContributorType_CONTRIBUTOR_TYPE_ORGANIZATION {
+		a.Type = "corporation"
+	}
+	for _, id := range c.Identifiers {
+		switch id.Type {
+		case hubv1.IdentifierType_IDENTIFIER_TYPE_ORCID:
+			a.IDs = append(a.IDs, XMLID{Type: "URI", Source: "ORCID", Value: hub.IdentifierURI(id)})
+		case hubv1.IdentifierType_IDENTIFIER_TYPE_ISNI:
+			a.IDs = append(a.IDs, XMLID{Type: "URI", Source: "ISNI", Value: "https://isni.org/isni/" + strings.ReplaceAll(id.Value, " ", "")})
+		case hubv1.IdentifierType_IDENTIFIER_TYPE_URL:
+			a.IDs = append(a.IDs, XMLID{Type: "URI", Value: id.Value})
+		}
+	}
+	return a
+}
+
+// roleConcept gives a role as a MARC relator concept where its code is
+// known, else as the role's own term.
+func roleConcept(code, role string) *XMLConcept {
+	if _, ok := helpers.MARCRelators[code]; ok {
+		return &XMLConcept{
+			ConceptIDs: []XMLID{{Type: "URI", Source: "MARC Relator", Value: relators + code}},
+			Terms:      []XMLTerm{{Lang: "en", Value: cmp.Or(role, helpers.RelatorLabel(code))}},
+		}
+	}
+	if role == "" {
+		return nil
+	}
+	return &XMLConcept{Terms: []XMLTerm{{Value: role}}}
+}
+
+// eventDate gives a date as displayed, with its earliest and latest
+// dates where the date was parsed.
+func eventDate(d *hubv1.DateValue) *XMLEventDate {
+	if d == nil {
+		return nil
+	}
+	ed := &XMLEventDate{Display: hub.DateString(d)}
+	if d.Year != 0 {
+		earliest := isoDate(d.Year, d.Month, d.Day)
+		ed.Date = &XMLDateRange{Earliest: earliest, Latest: earliest}
+		if d.IsRange && d.EndYear != 0 {
+			ed.Date.Latest = isoDate(d.EndYear, d.EndMonth, d.EndDay)
+		}
+	}
+	if ed.Display == "" && ed.Date == nil {
+		return nil
+	}
+	return ed
+}
+
+// isoDate formats a date to the precision it has.
+func isoDate(year, month, day int32) string {
+	switch {
+	case month == 0:
+		return fmt.Sprintf("%04d", year)
+	case day == 0:
+		return fmt.Sprintf("%04d-%02d", year, month)
+	}
+	return fmt.Sprintf("%04d-%02d-%02d", year, month, day)
+}
+
+// subjectSets gives each subject as a concept, actor, place, or date.
+func subjectSets(record *hubv1.Record) []XMLSubjectSet {
+	var sets []XMLSubjectSet
+	for _, s := range record.Subjects {
+		if s.Value == "" {
+			continue
+		}
+		var subject XMLSubject
+		switch s.Type {
+		case hubv1.SubjectType_SUBJECT_TYPE_NAME:
+			a := XMLActor{Names: XMLNameSet{Values: []XMLAppellation{{Value: s.Value}}}}
+			if s.Uri != "" {
+				a.IDs = []XMLID{{Type: "URI", Value: s.Uri}}
+			}
+			subject.Actors = []XMLActorWrap{{Actor: a}}
+		case hubv1.SubjectType_SUBJECT_TYPE_GEOGRAPHIC:
+			p := XMLPlace{Names: []XMLNameSet{{Values: []XMLAppellation{{Value: s.Value}}}}}
+			if s.Uri != "" {
+				p.IDs = []XMLID{{Type: "URI", Value: s.Uri}}
+			}
+			subject.Places = []XMLPlaceWrap{{Place: p}}
+		case hubv1.SubjectType_SUBJECT_TYPE_TEMPORAL:
+			subject.Dates = []XMLEventDate{{Display: s.Value}}
+		default:
+			c := XMLConcept{Terms: []XMLTerm{{Value: s.Value}}}
+			if s.Uri != "" {
+				c.ConceptIDs = []XMLID{{Type: "URI", Value: s.Uri}}
+			}
+			subject.Concepts = []XMLConcept{c}
+		}
+		sets = append(sets, XMLSubjectSet{Subject: subject})
+	}
+	return sets
+}
+
+// relatedWorks gives each related work, by title and identifier, with
+// the relation's type.
+func relatedWorks(record *hubv1.Record) []XMLRelatedWorkSet {
+	var sets []XMLRelatedWorkSet
+	for _, rel := range record.Relations {
+		if rel.TargetTitle == "" && rel.TargetId == "" && rel.TargetUri == "" {
+			continue
+		}
+		work := XMLRelatedWork{Display: rel.TargetTitle}
+		if rel.TargetUri != "" || rel.TargetId != "" {
+			work.Object = &XMLObject{WebResource: rel.TargetUri}
+			if rel.TargetId != "" {
+				work.Object.IDs = []XMLID{{Type: identifierType(rel.TargetIdType), Value: rel.TargetId}}
+			}
+		}
+		set := XMLRelatedWorkSet{Work: work}
+		if rel.Type != hubv1.RelationType_RELATION_TYPE_UNSPECIFIED {
+			term := strings.ReplaceAll(strings.ToLower(strings.TrimPrefix(rel.Type.String(), "RELATION_TYPE_")), "_", " ")
+			set.RelType = &XMLConcept{Terms: []XMLTerm{{Lang: "en", Value: term}}}
+		}
+		sets = append(sets, set)
+	}
+	return sets
+}
+
+// rightsSets gives the rights in the object, its rights holder, and its
+// copyright statement as the credit line.
+func rightsSets(record *hubv1.Record) []XMLRightsSet {
+	var sets []XMLRightsSet
+	for _, r := range record.Rights {
+		label := cmp.Or(r.License, r.Statement)
+		if r.Uri != "" {
+			label = cmp.Or(label, hub.LabelForRightsURI(r.Uri))
+		}
+		if label == "" && r.Uri == "" {
+			continue
+		}
+		c := &XMLConcept{}
+		if r.Uri != "" {
+			c.ConceptIDs = []XMLID{{Type: "URI", Value: r.Uri}}
+		}
+		if label != "" {
+			c.Terms = []XMLTerm{{Value: label}}
+		}
+		set := XMLRightsSet{Type: c}
+		if r.Holder != "" {
+			set.Holder = legalBody(r.Holder)
+		}
+		sets = append(sets, set)
+	}
+	if record.RightsHolder != "" || record.CopyrightStatement != "" {
+		set := XMLRightsSet{CreditLine: record.CopyrightStatement}
+		if record.RightsHolder != "" {
+			set.Holder = legalBody(record.RightsHolder)
+		}
+		sets = append(sets, set)
+	}
+	return sets
+}
+
+// recordWrap describes the LIDO record itself: it has the lidoRecID as
+// its ID, and the provider, holding institution, or publisher as its
+// source.
+func recordWrap(record *hubv1.Record, recID XMLID, opts *format.SerializeOptions) XMLRecordWrap {
+	recordType := "item"
+	if resourceType(record) == hubv1.ResourceTypeValue_RESOURCE_TYPE_COLLECTION {
+		recordType = "collection"
+	}
+	var institution string
+	if len(record.Holdings) > 0 {
+		institution = record.Holdings[0].Institution
+	}
+	wrap := XMLRecordWrap{
+		IDs:    []XMLID{recID},
+		Type:   XMLConcept{Terms: []XMLTerm{{Lang: "en", Value: recordType}}},
+		Source: *legalBody(cmp.Or(opts.Provider, institution, record.Publisher, defaultSource)),
+	}
+
+	var info XMLRecordInfo
+	if id := hub.GetIdentifier(record, hubv1.IdentifierType_IDENTIFIER_TYPE_URL); id != nil {
+		info.Link = id.Value
+	}
+	modified := cmp.Or(hub.GetDate(record, hubv1.DateType_DATE_TYPE_MODIFIED), hub.GetDate(record, hubv1.DateType_DATE_TYPE_UPDATED))
+	if modified != nil && modified.Year != 0 {
+		info.MetadataDate = &XMLMetadataDate{Type: "modified", Value: isoDate(modified.Year, modified.Month, modified.Day)}
+	}
+	if info.Link != "" || info.MetadataDate != nil {
+		wrap.InfoSets = []XMLRecordInfo{info}
+	}
+	return wrap
+}
+
+// resourceSets gives each file as a resource: originals as masters and
+// thumbnails as thumbs.
+func resourceSets(record *hubv1.Record) []XMLResourceSet {
+	var sets []XMLResourceSet
+	for _, file := range record.Files {
+		if file.Path == "" {
+			continue
+		}
+		repType := ""
+		switch file.Role {
+		case "", hub.FileRoleOriginal, hub.FileRolePreservation:
+			repType = "image_master"
+		case hub.FileRoleThumbnail:
+			repType = "image_thumb"
+		case hub.FileRoleService:
+			repType = "image_preview"
+		}
+		set := XMLResourceSet{Representations: []XMLRepresentation{{
+			Type: repType,
+			Link: XMLLinkFile{Format: file.MimeType, Value: file.Path},
+		}}}
+		if file.Description != "" {
+			set.Descriptions = []XMLTerm{{Value: file.Description}}
+		}
+		sets = append(sets, set)
+	}
+	return sets
+}
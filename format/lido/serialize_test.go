@@ -0,0 +1,194 @@
+package lido
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+func specimen() *hubv1.Record {
+	record := &hubv1.Record{
+		Title:    "Quercus alba L. (LEH 012345)",
+		AltTitle: []string{"White oak"},
+		Contributors: []*hubv1.Contributor{
+			{Name: "Smith, J.", Role: "Collector", RoleCode: "relators:col"},
+			{
+				Name: "Ana Nguyen", Role: "Photographer", RoleCode: "relators:pht",
+				Identifiers: []*hubv1.Identifier{{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_ORCID, Value: "0000-0002-1825-0097"}},
+			},
+		},
+		Dates: []*hubv1.DateValue{
+			{Type: hubv1.DateType_DATE_TYPE_COLLECTED, Year: 1932, Month: 5, Day: 14, Precision: hubv1.DatePrecision_DATE_PRECISION_DAY},
+			{Type: hubv1.DateType_DATE_TYPE_CREATED, Raw: "ca. 1930-1935", Year: 1930, EndYear: 1935, IsRange: true},
+		},
+		ResourceType: &hubv1.ResourceType{Type: hubv1.ResourceTypeValue_RESOURCE_TYPE_OBJECT, Original: "PreservedSpecimen"},
+		Genres:       []*hubv1.Subject{{Value: "herbarium specimens", Uri: "http://vocab.getty.edu/aat/300375721"}},
+		Subjects: []*hubv1.Subject{
+			{Value: "Fagaceae", Type: hubv1.SubjectType_SUBJECT_TYPE_TOPIC},
+			{Value: "Northampton, Pennsylvania", Type: hubv1.SubjectType_SUBJECT_TYPE_GEOGRAPHIC},
+		},
+		Geographic:   &hubv1.HierarchicalGeographic{Country: "United States", State: "Pennsylvania", County: "Northampton"},
+		Holdings:     []*hubv1.Holding{{Institution: "LEH", Sublocation: "Herbarium", CallNumber: "012345"}},
+		Description:  "Preserved specimen collected on South Mountain.",
+		PhysicalDesc: "sheet",
+		Rights:       []*hubv1.Rights{{Uri: "http://creativecommons.org/publicdomain/zero/1.0/"}},
+		RightsHolder: "Lehigh University",
+		Files: []*hubv1.File{
+			{Path: "https://images.lehigh.edu/herbarium/012345.jpg", MimeType: "image/jpeg", Description: "Herbarium sheet"},
+		},
+		Relations: []*hubv1.Relation{
+			{Type: hubv1.RelationType_RELATION_TYPE_MEMBER_OF, TargetTitle: "Lehigh University Herbarium"},
+		},
+		SourceInfo: &hubv1.SourceInfo{Format: "darwincore", SourceId: "urn:catalog:LEH:Herbarium:012345"},
+	}
+	hub.SetExtra(record, "coordinates", "40.6023,-75.3776")
+	return record
+}
+
+func serialize(t *testing.T, records ...*hubv1.Record) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := (&Format{}).Serialize(&buf, records, nil); err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+	dec := xml.NewDecoder(bytes.NewReader(buf.Bytes()))
+	for {
+		if _, err := dec.Token(); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("output is not well-formed: %v", err)
+		}
+	}
+	return buf.String()
+}
+
+func toLIDO(t *testing.T, record *hubv1.Record, opts *format.SerializeOptions) *XMLLIDO {
+	t.Helper()
+	lido, err := recordToLIDO(record, opts)
+	if err != nil {
+		t.Fatalf("recordToLIDO failed: %v", err)
+	}
+	return lido
+}
+
+func TestSerializeObject(t *testing.T) {
+	out := serialize(t, specimen())
+
+	for _, want := range []string{
+		`<lido:lidoWrap xmlns:lido="http://www.lido-schema.org"`,
+		`<lido:lidoRecID lido:type="local" lido:source="darwincore">urn:catalog:LEH:Herbarium:012345</lido:lidoRecID>`,
+		`<lido:conceptID lido:type="URI" lido:source="CIDOC-CRM">http://www.cidoc-crm.org/crm-concepts/E22</lido:conceptID>`,
+		`<lido:term>PreservedSpecimen</lido:term>`,
+		`<lido:classification lido:type="genre">`,
+		`<lido:appellationValue lido:pref="preferred">Quercus alba L. (LEH 012345)</lido:appellationValue>`,
+		`<lido:appellationValue lido:pref="alternate">White oak</lido:appellationValue>`,
+		`<lido:repositorySet lido:type="current">`,
+		`<lido:workID lido:type="inventory number">012345</lido:workID>`,
+		`<lido:displayObjectMeasurements>sheet</lido:displayObjectMeasurements>`,
+		`<lido:term xml:lang="en">Production</lido:term>`,
+		`<lido:term xml:lang="en">Collecting</lido:term>`,
+		`<lido:displayDate>ca. 1930-1935</lido:displayDate>`,
+		`<lido:latestDate>1935</lido:latestDate>`,
+		`<lido:earliestDate>1932-05-14</lido:earliestDate>`,
+		`<gml:pos>40.6023 -75.3776</gml:pos>`,
+		`<lido:appellationValue>Northampton, Pennsylvania, United States</lido:appellationValue>`,
+		`<lido:actorID lido:type="URI" lido:source="ORCID">https://orcid.org/0000-0002-1825-0097</lido:actorID>`,
+		`<lido:conceptID lido:type="URI" lido:source="MARC Relator">http://id.loc.gov/vocabulary/relators/col</lido:conceptID>`,
+		`<lido:displayObject>Lehigh University Herbarium</lido:displayObject>`,
+		`<lido:term xml:lang="en">member of</lido:term>`,
+		`<lido:legalBodyName>`,
+		`<lido:linkResource lido:formatResource="image/jpeg">https://images.lehigh.edu/herbarium/012345.jpg</lido:linkResource>`,
+		`<lido:resourceRepresentation lido:type="image_master">`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %s", want)
+		}
+	}
+	if strings.Contains(out, "></lido:") {
+		t.Errorf("LIDO rejects empty elements:\n%s", out)
+	}
+}
+
+func TestSerializeEvents(t *testing.T) {
+	record := &hubv1.Record{
+		Title:          "Bethlehem Steel annual report",
+		Publisher:      "Bethlehem Steel Corporation",
+		PlacePublished: "Bethlehem, Pa.",
+		Contributors:   []*hubv1.Contributor{{Name: "Grace, Eugene G.", Role: "Author", RoleCode: "relators:aut"}},
+		Dates: []*hubv1.DateValue{
+			{Type: hubv1.DateType_DATE_TYPE_ISSUED, Year: 1925, Precision: hubv1.DatePrecision_DATE_PRECISION_YEAR},
+		},
+		ResourceType: &hubv1.ResourceType{Type: hubv1.ResourceTypeValue_RESOURCE_TYPE_REPORT},
+		Identifiers:  []*hubv1.Identifier{{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_HANDLE, Value: "20.500.12345/7"}},
+	}
+	lido := toLIDO(t, record, format.NewSerializeOptions())
+
+	if lido.RecID.Value != "20.500.12345/7" || lido.RecID.Type != "handle" {
+		t.Errorf("identifier as lidoRecID: got %+v", lido.RecID)
+	}
+	if wt := lido.Descriptive.Classification.WorkTypes[0]; len(wt.ConceptIDs) != 1 || wt.ConceptIDs[0].Value != aat+"300027267" {
+		t.Errorf("AAT work type: got %+v", wt)
+	}
+
+	if lido.Descriptive.Events == nil || len(lido.Descriptive.Events.Sets) != 2 {
+		t.Fatalf("expected creation and publication events, got %+v", lido.Descriptive.Events)
+	}
+	events := lido.Descriptive.Events.Sets
+	if creation := events[0].Event; creation.Type.Terms[0].Value != "Creation" || len(creation.Actors) != 1 || creation.Date != nil {
+		t.Errorf("creation: got %+v", creation)
+	}
+	publication := events[1].Event
+	if len(publication.Actors) != 1 || publication.Actors[0].ActorInRole.Actor.Type != "corporation" ||
+		publication.Date == nil || publication.Date.Date.Earliest != "1925" ||
+		len(publication.Places) != 1 {
+		t.Errorf("publication: got %+v", publication)
+	}
+	if src := lido.Administrative.Record.Source.Name.Values[0].Value; src != "Bethlehem Steel Corporation" {
+		t.Errorf("publisher as record source: got %q", src)
+	}
+	if lido.Descriptive.Identification.Repositories != nil {
+		t.Errorf("no repository without holdings or a source ID: got %+v", lido.Descriptive.Identification.Repositories)
+	}
+}
+
+func TestSerializeRepositoryFromSource(t *testing.T) {
+	record := specimen()
+	record.Holdings = nil
+	opts := format.NewSerializeOptions()
+	opts.Provider = "Lehigh University Herbarium"
+	lido := toLIDO(t, record, opts)
+
+	repos := lido.Descriptive.Identification.Repositories.Sets
+	if len(repos) != 1 || repos[0].Name == nil || repos[0].Name.Name.Values[0].Value != "Lehigh University Herbarium" ||
+		len(repos[0].WorkIDs) != 1 || repos[0].WorkIDs[0].Value != "urn:catalog:LEH:Herbarium:012345" {
+		t.Errorf("source ID repository: got %+v", repos)
+	}
+	if src := lido.Administrative.Record.Source.Name.Values[0].Value; src != "Lehigh University Herbarium" {
+		t.Errorf("provider as record source: got %q", src)
+	}
+}
+
+func TestSerializeErrors(t *testing.T) {
+	if err := (&Format{}).Serialize(&bytes.Buffer{}, nil, nil); !errors.Is(err, format.ErrEmptyDocument) {
+		t.Errorf("empty input: got %v", err)
+	}
+
+	noID := specimen()
+	noID.SourceInfo = nil
+	if err := (&Format{}).Serialize(&bytes.Buffer{}, []*hubv1.Record{noID}, nil); err == nil || !strings.Contains(err.Error(), "lidoRecID") {
+		t.Errorf("missing record ID: got %v", err)
+	}
+
+	noTitle := specimen()
+	noTitle.Title = ""
+	if err := (&Format{}).Serialize(&bytes.Buffer{}, []*hubv1.Record{noTitle}, nil); err == nil || !strings.Contains(err.Error(), "title") {
+		t.Errorf("missing title: got %v", err)
+	}
+}
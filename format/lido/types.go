@@ -0,0 +1,337 @@
+package lido
+
+import "encoding/xml"
+
+// Namespaces and schema location of LIDO 1.0.
+const (
+	Namespace    = "http://www.lido-schema.org"
+	NamespaceGML = "http://www.opengis.net/gml"
+	NamespaceXSI = "http://www.w3.org/2001/XMLSchema-instance"
+
+	schemaLocation = Namespace + " http://www.lido-schema.org/schema/v1.0/lido-v1.0.xsd"
+)
+
+// XMLWrap is a lidoWrap: the records of a batch. The elements carry the
+// prefixes the root declares, in the order the schema requires.
+type XMLWrap struct {
+	XMLName           xml.Name  `xml:"lido:lidoWrap"`
+	XmlnsLIDO         string    `xml:"xmlns:lido,attr"`
+	XmlnsGML          string    `xml:"xmlns:gml,attr"`
+	XmlnsXSI          string    `xml:"xmlns:xsi,attr"`
+	XSISchemaLocation string    `xml:"xsi:schemaLocation,attr"`
+	Records           []XMLLIDO `xml:"lido:lido"`
+}
+
+// XMLLIDO is a LIDO record describing one object or work.
+type XMLLIDO struct {
+	RecID          XMLID                     `xml:"lido:lidoRecID"`
+	PublishedIDs   []XMLID                   `xml:"lido:objectPublishedID,omitempty"`
+	Category       *XMLConcept               `xml:"lido:category,omitempty"`
+	Descriptive    XMLDescriptiveMetadata    `xml:"lido:descriptiveMetadata"`
+	Administrative XMLAdministrativeMetadata `xml:"lido:administrativeMetadata"`
+}
+
+// XMLID is an identifier with its type and the source that assigned it.
+type XMLID struct {
+	Type   string `xml:"lido:type,attr,omitempty"`
+	Source string `xml:"lido:source,attr,omitempty"`
+	Value  string `xml:",chardata"`
+}
+
+// XMLConcept is a term from a vocabulary, with the concept's identifier.
+type XMLConcept struct {
+	ConceptIDs []XMLID   `xml:"lido:conceptID,omitempty"`
+	Terms      []XMLTerm `xml:"lido:term,omitempty"`
+}
+
+// XMLTerm is a term, in a language.
+type XMLTerm struct {
+	Lang  string `xml:"xml:lang,attr,omitempty"`
+	Value string `xml:",chardata"`
+}
+
+// XMLAppellation is a name or title, marked preferred or alternate.
+type XMLAppellation struct {
+	Pref  string `xml:"lido:pref,attr,omitempty"`
+	Lang  string `xml:"xml:lang,attr,omitempty"`
+	Value string `xml:",chardata"`
+}
+
+// XMLNameSet is a set of appellations for one thing.
+type XMLNameSet struct {
+	Values []XMLAppellation `xml:"lido:appellationValue"`
+}
+
+// XMLLegalBody is an organization, by name.
+type XMLLegalBody struct {
+	Name XMLNameSet `xml:"lido:legalBodyName"`
+}
+
+// XMLDescriptiveMetadata describes the object: what it is, what it is
+// called and where it is held, what happened to it, and what it is about.
+type XMLDescriptiveMetadata struct {
+	Lang           string                `xml:"xml:lang,attr"`
+	Classification XMLClassificationWrap `xml:"lido:objectClassificationWrap"`
+	Identification XMLIdentificationWrap `xml:"lido:objectIdentificationWrap"`
+	Events         *XMLEventWrap         `xml:"lido:eventWrap,omitempty"`
+	Relations      *XMLRelationWrap      `xml:"lido:objectRelationWrap,omitempty"`
+}
+
+// XMLClassificationWrap gives the object's work types, and other
+// classifications such as genre.
+//
+// Optional wraps are pointers to their own types throughout: encoding/xml
+// writes the parents of an empty a>b,omitempty field, and LIDO rejects
+// empty wraps.
+type XMLClassificationWrap struct {
+	WorkTypes       []XMLConcept            `xml:"lido:objectWorkTypeWrap>lido:objectWorkType"`
+	Classifications *XMLClassificationsWrap `xml:"lido:classificationWrap,omitempty"`
+}
+
+// XMLClassificationsWrap holds the classifications.
+type XMLClassificationsWrap struct {
+	Classifications []XMLClassification `xml:"lido:classification"`
+}
+
+// XMLClassification is a typed classification concept.
+type XMLClassification struct {
+	Type string `xml:"lido:type,attr,omitempty"`
+	XMLConcept
+}
+
+// XMLIdentificationWrap identifies the object: its titles, where it is
+// held, its edition, a description, and its measurements.
+type XMLIdentificationWrap struct {
+	Titles       []XMLNameSet         `xml:"lido:titleWrap>lido:titleSet"`
+	Repositories *XMLRepositoryWrap   `xml:"lido:repositoryWrap,omitempty"`
+	Editions     *XMLEditionWrap      `xml:"lido:displayStateEditionWrap,omitempty"`
+	Descriptions *XMLDescriptionWrap  `xml:"lido:objectDescriptionWrap,omitempty"`
+	Measurements *XMLMeasurementsWrap `xml:"lido:objectMeasurementsWrap,omitempty"`
+}
+
+// XMLRepositoryWrap holds the repository sets.
+type XMLRepositoryWrap struct {
+	Sets []XMLRepositorySet `xml:"lido:repositorySet"`
+}
+
+// XMLEditionWrap holds the edition statements.
+type XMLEditionWrap struct {
+	Editions []string `xml:"lido:displayEdition"`
+}
+
+// XMLDescriptionWrap holds the description sets.
+type XMLDescriptionWrap struct {
+	Sets []XMLDescription `xml:"lido:objectDescriptionSet"`
+}
+
+// XMLMeasurementsWrap holds the object's measurements, as displayed.
+type XMLMeasurementsWrap struct {
+	Displays []string `xml:"lido:objectMeasurementsSet>lido:displayObjectMeasurements"`
+}
+
+// XMLRepositorySet is a repository holding the object, with the number
+// it is held under.
+type XMLRepositorySet struct {
+	Type     string        `xml:"lido:type,attr,omitempty"`
+	Name     *XMLLegalBody `xml:"lido:repositoryName,omitempty"`
+	WorkIDs  []XMLID       `xml:"lido:workID,omitempty"`
+	Location *XMLPlace     `xml:"lido:repositoryLocation,omitempty"`
+}
+
+// XMLDescription is a descriptive note.
+type XMLDescription struct {
+	Type  string    `xml:"lido:type,attr,omitempty"`
+	Notes []XMLTerm `xml:"lido:descriptiveNoteValue"`
+}
+
+// XMLEventWrap holds the event sets.
+type XMLEventWrap struct {
+	Sets []XMLEventSet `xml:"lido:eventSet"`
+}
+
+// XMLEventSet is an event in the object's history: its production,
+// publication, or collection.
+type XMLEventSet struct {
+	Event XMLEvent `xml:"lido:event"`
+}
+
+// XMLEvent is an event, with who took part, when, and where.
+type XMLEvent struct {
+	Type   XMLConcept      `xml:"lido:eventType"`
+	Actors []XMLEventActor `xml:"lido:eventActor,omitempty"`
+	Date   *XMLEventDate   `xml:"lido:eventDate,omitempty"`
+	Places []XMLPlaceWrap  `xml:"lido:eventPlace,omitempty"`
+}
+
+// XMLEventActor holds an actor in an event.
+type XMLEventActor struct {
+	ActorInRole XMLActorRole `xml:"lido:actorInRole"`
+}
+
+// XMLActorRole is an actor in an event, in a role.
+type XMLActorRole struct {
+	Actor XMLActor    `xml:"lido:actor"`
+	Role  *XMLConcept `xml:"lido:roleActor,omitempty"`
+}
+
+// XMLActor is a person or organization.
+type XMLActor struct {
+	Type  string     `xml:"lido:type,attr,omitempty"`
+	IDs   []XMLID    `xml:"lido:actorID,omitempty"`
+	Names XMLNameSet `xml:"lido:nameActorSet"`
+}
+
+// XMLEventDate is a date as displayed, with its earliest and latest
+// days.
+type XMLEventDate struct {
+	Display string        `xml:"lido:displayDate,omitempty"`
+	Date    *XMLDateRange `xml:"lido:date,omitempty"`
+}
+
+// XMLDateRange is the earliest and latest dates of an event.
+type XMLDateRange struct {
+	Earliest string `xml:"lido:earliestDate"`
+	Latest   string `xml:"lido:latestDate"`
+}
+
+// XMLPlace is a place, by name and, if known, by its coordinates.
+type XMLPlace struct {
+	IDs   []XMLID      `xml:"lido:placeID,omitempty"`
+	Names []XMLNameSet `xml:"lido:namePlaceSet,omitempty"`
+	GML   *XMLGML      `xml:"lido:gml,omitempty"`
+}
+
+// XMLGML holds a place's GML geometry.
+type XMLGML struct {
+	Point XMLGMLPoint `xml:"gml:Point"`
+}
+
+// XMLGMLPoint is a GML point, as "latitude longitude".
+type XMLGMLPoint struct {
+	Pos string `xml:"gml:pos"`
+}
+
+// XMLRelationWrap gives what the object is about and the works it is
+// related to.
+type XMLRelationWrap struct {
+	Subjects     *XMLSubjectWrap      `xml:"lido:subjectWrap,omitempty"`
+	RelatedWorks *XMLRelatedWorksWrap `xml:"lido:relatedWorksWrap,omitempty"`
+}
+
+// XMLSubjectWrap holds the subject sets.
+type XMLSubjectWrap struct {
+	Sets []XMLSubjectSet `xml:"lido:subjectSet"`
+}
+
+// XMLRelatedWorksWrap holds the related work sets.
+type XMLRelatedWorksWrap struct {
+	Sets []XMLRelatedWorkSet `xml:"lido:relatedWorkSet"`
+}
+
+// XMLSubjectSet is a subject: a concept, actor, date, or place.
+type XMLSubjectSet struct {
+	Subject XMLSubject `xml:"lido:subject"`
+}
+
+// XMLSubject holds the one kind of subject a subject set describes.
+type XMLSubject struct {
+	Concepts []XMLConcept   `xml:"lido:subjectConcept,omitempty"`
+	Actors   []XMLActorWrap `xml:"lido:subjectActor,omitempty"`
+	Dates    []XMLEventDate `xml:"lido:subjectDate,omitempty"`
+	Places   []XMLPlaceWrap `xml:"lido:subjectPlace,omitempty"`
+}
+
+// XMLActorWrap holds one actor.
+type XMLActorWrap struct {
+	Actor XMLActor `xml:"lido:actor"`
+}
+
+// XMLPlaceWrap holds one place.
+type XMLPlaceWrap struct {
+	Place XMLPlace `xml:"lido:place"`
+}
+
+// XMLRelatedWorkSet is a related work and how it is related.
+type XMLRelatedWorkSet struct {
+	Work    XMLRelatedWork `xml:"lido:relatedWork"`
+	RelType *XMLConcept    `xml:"lido:relatedWorkRelType,omitempty"`
+}
+
+// XMLRelatedWork names a related work and identifies it.
+type XMLRelatedWork struct {
+	Display string     `xml:"lido:displayObject,omitempty"`
+	Object  *XMLObject `xml:"lido:object,omitempty"`
+}
+
+// XMLObject identifies a work, by web resource or ID.
+type XMLObject struct {
+	WebResource string  `xml:"lido:objectWebResource,omitempty"`
+	IDs         []XMLID `xml:"lido:objectID,omitempty"`
+}
+
+// XMLAdministrativeMetadata gives the rights in the object, the record's
+// own provenance, and digital resources showing the object.
+type XMLAdministrativeMetadata struct {
+	Lang      string             `xml:"xml:lang,attr"`
+	Rights    *XMLRightsWorkWrap `xml:"lido:rightsWorkWrap,omitempty"`
+	Record    XMLRecordWrap      `xml:"lido:recordWrap"`
+	Resources *XMLResourceWrap   `xml:"lido:resourceWrap,omitempty"`
+}
+
+// XMLRightsWorkWrap holds the rights sets.
+type XMLRightsWorkWrap struct {
+	Sets []XMLRightsSet `xml:"lido:rightsWorkSet"`
+}
+
+// XMLResourceWrap holds the resource sets.
+type XMLResourceWrap struct {
+	Sets []XMLResourceSet `xml:"lido:resourceSet"`
+}
+
+// XMLRightsSet is a right in the object or a resource, and who holds it.
+type XMLRightsSet struct {
+	Type       *XMLConcept   `xml:"lido:rightsType,omitempty"`
+	Holder     *XMLLegalBody `xml:"lido:rightsHolder,omitempty"`
+	CreditLine string        `xml:"lido:creditLine,omitempty"`
+}
+
+// XMLRecordWrap describes the LIDO record: its ID, type, source, and
+// where it is published.
+type XMLRecordWrap struct {
+	IDs      []XMLID         `xml:"lido:recordID"`
+	Type     XMLConcept      `xml:"lido:recordType"`
+	Source   XMLLegalBody    `xml:"lido:recordSource"`
+	InfoSets []XMLRecordInfo `xml:"lido:recordInfoSet,omitempty"`
+}
+
+// XMLRecordInfo links to the record as published, and dates it.
+type XMLRecordInfo struct {
+	Link         string           `xml:"lido:recordInfoLink,omitempty"`
+	MetadataDate *XMLMetadataDate `xml:"lido:recordMetadataDate,omitempty"`
+}
+
+// XMLMetadataDate is a typed date of the record.
+type XMLMetadataDate struct {
+	Type  string `xml:"lido:type,attr,omitempty"`
+	Value string `xml:",chardata"`
+}
+
+// XMLResourceSet is a digital resource showing the object.
+type XMLResourceSet struct {
+	Representations []XMLRepresentation `xml:"lido:resourceRepresentation"`
+	Type            *XMLConcept         `xml:"lido:resourceType,omitempty"`
+	Descriptions    []XMLTerm           `xml:"lido:resourceDescription,omitempty"`
+	Rights          []XMLRightsSet      `xml:"lido:rightsResource,omitempty"`
+}
+
+// XMLRepresentation is a resource file, by its role (e.g. image_master).
+type XMLRepresentation struct {
+	Type string      `xml:"lido:type,attr,omitempty"`
+	Link XMLLinkFile `xml:"lido:linkResource"`
+}
+
+// XMLLinkFile is the URL of a resource file, with its MIME type.
+type XMLLinkFile struct {
+	Format string `xml:"lido:formatResource,attr,omitempty"`
+	Value  string `xml:",chardata"`
+}
@@ -0,0 +1,78 @@
+package lido
+
+import (
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+)
+
+// aat is the base of Getty AAT concept URIs.
+const aat = "http://vocab.getty.edu/aat/"
+
+// workType is an object/work type: an AAT concept and its preferred term.
+type workType struct {
+	id   string
+	term string
+}
+
+// workTypes map resource types to AAT object/work types.
+var workTypes = map[hubv1.ResourceTypeValue]workType{
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_ARTICLE:           {"300048715", "articles"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_BOOK:              {"300028051", "books"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_BOOK_CHAPTER:      {"300311699", "chapters (document divisions)"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_DATASET:           {"300312038", "datasets"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_DISSERTATION:      {"300028029", "dissertations"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_THESIS:            {"300028028", "theses"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_IMAGE:             {"300264387", "images (object genre)"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_JOURNAL:           {"300215390", "journals (periodicals)"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_PERIODICAL:        {"300026657", "periodicals"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_NEWSPAPER:         {"300026656", "newspapers"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_REPORT:            {"300027267", "reports"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_TECHNICAL_REPORT:  {"300027323", "technical reports"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_POSTER:            {"300027221", "posters"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_PRESENTATION:      {"300258677", "presentations (communicative events)"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_SOFTWARE:          {"300028566", "software"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_VIDEO:             {"300028682", "video recordings"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_AUDIO:             {"300028633", "sound recordings"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_MAP:               {"300028094", "maps (documents)"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_COLLECTION:        {"300025976", "collections (object groupings)"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_ARCHIVAL_MATERIAL: {"300379505", "archival materials"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_MANUSCRIPT:        {"300028569", "manuscripts (documents)"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_TEXT:              {"300263751", "texts (documents)"},
+}
+
+// LIDO event types, from the LIDO terminology.
+const lidoTerms = "http://terminology.lido-schema.org/"
+
+var (
+	eventCreation    = workType{"lido00012", "Creation"}
+	eventProduction  = workType{"lido00007", "Production"}
+	eventPublication = workType{"lido00228", "Publication"}
+	eventCollecting  = workType{"lido00010", "Collecting"}
+)
+
+// CIDOC-CRM classes LIDO records fall into.
+const crm = "http://www.cidoc-crm.org/crm-concepts/"
+
+var (
+	categoryObject      = workType{"E22", "Man-Made Object"}
+	categoryInformation = workType{"E73", "Information Object"}
+)
+
+// isPhysical reports whether a resource type is a physical object rather
+// than a work, which LIDO records as produced rather than created.
+func isPhysical(t hubv1.ResourceTypeValue) bool {
+	switch t {
+	case hubv1.ResourceTypeValue_RESOURCE_TYPE_OBJECT,
+		hubv1.ResourceTypeValue_RESOURCE_TYPE_ARCHIVAL_MATERIAL,
+		hubv1.ResourceTypeValue_RESOURCE_TYPE_MANUSCRIPT:
+		return true
+	}
+	return false
+}
+
+// concept returns a term with its concept ID under base.
+func (w workType) concept(base, source string) XMLConcept {
+	return XMLConcept{
+		ConceptIDs: []XMLID{{Type: "URI", Source: source, Value: base + w.id}},
+		Terms:      []XMLTerm{{Lang: "en", Value: w.term}},
+	}
+}
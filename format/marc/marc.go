@@ -0,0 +1,86 @@
+// Package marc provides a format plugin for MARC 21 bibliographic records.
+//
+// Parsing accepts both ISO 2709 binary (.mrc) and MARCXML, including
+// <collection> wrappers and OAI-PMH envelopes. Serialization writes MARCXML.
+//
+// The mapping covers what scholarly workflows need from catalog records:
+//   - Leader/06-07 and 008 for resource type, dates, and language
+//   - 1xx/7xx for contributors, with relator codes ($4) and terms ($e/$j)
+//   - 6xx for subjects, with the vocabulary taken from the second
+//     indicator, $2, or the $0 authority URI
+//   - 245/246 titles, 250 edition, 260/264 imprint, 300 extent
+//   - 020/022/024/050/856 identifiers, 490/773/830 relations
+//   - 500/502/505/506/520/540 notes, degree, contents, and rights
+package marc
+
+import (
+	"bytes"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+)
+
+// Version documents the MARC specification this implementation targets.
+const Version = "21"
+
+// Namespace is the MARCXML slim schema namespace.
+const Namespace = "http://www.loc.gov/MARC21/slim"
+
+// Format implements the MARC 21 format.
+type Format struct{}
+
+// Ensure Format implements the interfaces
+var (
+	_ format.Format         = (*Format)(nil)
+	_ format.Parser         = (*Format)(nil)
+	_ format.StreamParser   = (*Format)(nil)
+	_ format.Serializer     = (*Format)(nil)
+	_ format.FieldDescriber = (*Format)(nil)
+)
+
+// Name returns the format identifier.
+func (f *Format) Name() string {
+	return "marc"
+}
+
+// Description returns a human-readable format description.
+func (f *Format) Description() string {
+	return "MARC " + Version + " bibliographic records (ISO 2709 binary and MARCXML)"
+}
+
+// Extensions returns file extensions associated with this format.
+func (f *Format) Extensions() []string {
+	return []string{"mrc", "marc", "xml"}
+}
+
+// writtenFields are the hub record fields Serialize writes.
+var writtenFields = []string{
+	"title", "alt_title", "abstract", "contributors", "dates",
+	"resource_type", "genres", "subjects", "language", "publisher",
+	"place_published", "rights", "access_condition", "identifiers",
+	"physical_desc", "notes", "table_of_contents", "edition", "degree_info",
+	"relations.in_series", "relations.part_of",
+}
+
+// WrittenFields returns the hub record fields Serialize writes.
+func (f *Format) WrittenFields(opts *format.SerializeOptions) []string {
+	return writtenFields
+}
+
+// CanParse returns true if the input looks like MARCXML or an ISO 2709
+// record.
+func (f *Format) CanParse(peek []byte) bool {
+	if isISO2709(peek) {
+		return true
+	}
+
+	peek = bytes.TrimSpace(peek)
+	if len(peek) == 0 || peek[0] != '<' {
+		return false
+	}
+	return bytes.Contains(peek, []byte("loc.gov/MARC21/slim")) ||
+		(bytes.Contains(peek, []byte("<record")) && bytes.Contains(peek, []byte("<leader")))
+}
+
+func init() {
+	format.Register(&Format{})
+}
@@ -0,0 +1,788 @@
+package marc
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/helpers"
+	"github.com/lehigh-university-libraries/crosswalk/helpers/place"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+// yearRegex finds a four-digit year in imprint dates like "c2019." or "[2019?]".
+var yearRegex = regexp.MustCompile(`\b(1[0-9]{3}|20[0-9]{2})\b`)
+
+// Parse reads MARC 21 records, as ISO 2709 binary or MARCXML, and returns
+// hub records.
+func (f *Format) Parse(r io.Reader, opts *format.ParseOptions) ([]*hubv1.Record, error) {
+	var records []*hubv1.Record
+	err := f.ParseStream(r, opts, func(record *hubv1.Record) error {
+		records = append(records, record)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// ParseStream reads MARC 21 records, as ISO 2709 binary or MARCXML, and
+// calls fn with each record's hub record as it is decoded. An ISO 2709
+// record that doesn't decode is a format.RecordError, which
+// opts.OnRecordError may skip.
+func (f *Format) ParseStream(r io.Reader, opts *format.ParseOptions, fn func(*hubv1.Record) error) error {
+	br := bufio.NewReader(r)
+	skipped, err := skipSpace(br)
+	if err != nil && err != io.EOF {
+		return fmt.Errorf("reading input: %w", err)
+	}
+
+	// Errors from fn and OnRecordError are returned as they are, not as
+	// parse errors
+	n := 0
+	var fnErr error
+	emit := func(rec *Record) error {
+		n++
+		fnErr = fn(marcToHub(rec))
+		return fnErr
+	}
+	bad := func(e *format.RecordError) error {
+		if opts == nil || opts.OnRecordError == nil {
+			return e
+		}
+		n++
+		fnErr = opts.OnRecordError(e)
+		return fnErr
+	}
+
+	peek, _ := br.Peek(leaderLength)
+	if isISO2709(peek) {
+		if err := scanISO2709(br, int64(skipped), emit, bad); err != nil {
+			if fnErr != nil {
+				return fnErr
+			}
+			return fmt.Errorf("parsing ISO 2709: %w", err)
+		}
+	} else if err := scanMARCXML(br, emit); err != nil {
+		if fnErr != nil {
+			return fnErr
+		}
+		return fmt.Errorf("parsing MARCXML: %w", err)
+	}
+
+	if n == 0 {
+		return fmt.Errorf("no MARC records found in input")
+	}
+	return nil
+}
+
+// marcToHub converts a MARC bibliographic record to a hub record.
+func marcToHub(rec *Record) *hubv1.Record {
+	record := &hubv1.Record{}
+	f008 := rec.Control("008")
+
+	// Title (245) and variant titles (246)
+	if df, ok := rec.Field("245"); ok {
+		record.Title = joinTitle(df)
+	}
+	for _, df := range rec.Fields("246") {
+		if alt := trimISBD(df.Sub("a")); alt != "" {
+			record.AltTitle = append(record.AltTitle, alt)
+		}
+	}
+
+	// Contributors: main entry first, then added entries
+	for _, df := range rec.Fields("100", "110", "111", "700", "710", "711") {
+		if c := fieldToContributor(df); c != nil {
+			record.Contributors = append(record.Contributors, c)
+		}
+	}
+
+	_, hasDissertationNote := rec.Field("502")
+	record.ResourceType = leaderResourceType(rec.Leader, f008, hasDissertationNote)
+
+	// Imprint: prefer 264 with second indicator 1 (publication), fall back to 260
+	var imprintDate string
+	for _, df := range rec.Fields("264", "260") {
+		if df.Tag == "264" && df.Ind2 != "1" {
+			continue
+		}
+		if record.Publisher == "" {
+			record.Publisher = trimISBD(df.Sub("b"))
+		}
+		if record.PlacePublished == "" {
+			record.PlacePublished = trimISBD(df.Sub("a"))
+		}
+		if imprintDate == "" {
+			imprintDate = df.Sub("c")
+		}
+	}
+
+	// Place: the imprint names the city, 008/15-17 codes the country
+	var placeCode string
+	if len(f008) >= 18 {
+		placeCode = f008[15:18]
+	}
+	record.PublicationPlace = place.Resolve(record.PlacePublished, placeCode)
+
+	// Dates: 008 is coded; the imprint is only a fallback
+	record.Dates = fixedFieldDates(f008)
+	if len(record.Dates) == 0 {
+		if m := yearRegex.FindString(imprintDate); m != "" {
+			year, _ := strconv.Atoi(m)
+			record.Dates = append(record.Dates, hub.NewDateFromYear(int32(year), hubv1.DateType_DATE_TYPE_ISSUED))
+		}
+	}
+	if !hasDateType(record.Dates, hubv1.DateType_DATE_TYPE_COPYRIGHT) {
+		for _, df := range rec.Fields("264") {
+			if df.Ind2 != "4" {
+				continue
+			}
+			if m := yearRegex.FindString(df.Sub("c")); m != "" {
+				year, _ := strconv.Atoi(m)
+				record.Dates = append(record.Dates, hub.NewDateFromYear(int32(year), hubv1.DateType_DATE_TYPE_COPYRIGHT))
+				break
+			}
+		}
+	}
+
+	// Language: 008/35-37, then 041 $a
+	if len(f008) >= 38 {
+		if lang := strings.TrimSpace(f008[35:38]); isLanguageCode(lang) {
+			record.Language = lang
+		}
+	}
+	if record.Language == "" {
+		for _, df := range rec.Fields("041") {
+			if lang := df.Sub("a"); lang != "" {
+				record.Language = lang
+				break
+			}
+		}
+	}
+
+	if df, ok := rec.Field("250"); ok {
+		record.Edition = trimISBD(df.Sub("a"))
+	}
+	if df, ok := rec.Field("300"); ok {
+		record.PhysicalDesc = trimISBD(strings.Join(df.Subs("abcefg"), " "))
+	}
+
+	// Notes, contents, and abstract
+	for _, df := range rec.Fields("500") {
+		if note := strings.TrimSpace(df.Sub("a")); note != "" {
+			record.Notes = append(record.Notes, note)
+		}
+	}
+	for _, df := range rec.Fields("505") {
+		if toc := strings.TrimSpace(strings.Join(df.Subs("agrt"), " ")); toc != "" && record.TableOfContents == "" {
+			record.TableOfContents = toc
+		}
+	}
+	for _, df := range rec.Fields("520") {
+		abstract := strings.TrimSpace(df.Sub("a"))
+		if abstract == "" {
+			continue
+		}
+		if record.Abstract == "" {
+			record.Abstract = abstract
+		} else {
+			record.Notes = append(record.Notes, abstract)
+		}
+	}
+
+	// Dissertation note
+	if df, ok := rec.Field("502"); ok {
+		if df.Sub("b") != "" || df.Sub("c") != "" {
+			record.DegreeInfo = &hubv1.DegreeInfo{
+				DegreeName:  trimISBD(df.Sub("b")),
+				Institution: trimISBD(df.Sub("c")),
+			}
+			if m := yearRegex.FindString(df.Sub("d")); m != "" {
+				year, _ := strconv.Atoi(m)
+				record.DegreeInfo.Date = hub.NewDateFromYear(int32(year), hubv1.DateType_DATE_TYPE_ISSUED)
+			}
+		} else if note := strings.TrimSpace(df.Sub("a")); note != "" {
+			record.Notes = append(record.Notes, note)
+		}
+	}
+
+	// Rights and access
+	for _, df := range rec.Fields("540") {
+		rights := &hubv1.Rights{
+			Statement: strings.TrimSpace(df.Sub("a")),
+			Uri:       strings.TrimSpace(df.Sub("u")),
+		}
+		if rights.Statement != "" || rights.Uri != "" {
+			record.Rights = append(record.Rights, rights)
+		}
+	}
+	for _, df := range rec.Fields("506") {
+		if ac := strings.TrimSpace(df.Sub("a")); ac != "" && record.AccessCondition == "" {
+			record.AccessCondition = ac
+		}
+	}
+
+	record.Subjects, record.Genres = fieldsToSubjects(rec)
+	record.Identifiers = fieldsToIdentifiers(rec)
+	record.Relations = fieldsToRelations(rec)
+
+	record.SourceInfo = &hubv1.SourceInfo{
+		Format:        "marc",
+		FormatVersion: Version,
+		SourceId:      strings.TrimSpace(rec.Control("001")),
+		Oai:           rec.Header.ToHub(),
+	}
+
+	return record
+}
+
+// joinTitle builds the title from 245 $a, $b (remainder), $n and $p (part
+// number and name), dropping ISBD punctuation between them.
+func joinTitle(df DataField) string {
+	title := trimISBD(df.Sub("a"))
+	if sub := trimISBD(df.Sub("b")); sub != "" {
+		title += ": " + sub
+	}
+	for _, part := range df.Subs("np") {
+		if part = trimISBD(part); part != "" {
+			title += ". " + part
+		}
+	}
+	return title
+}
+
+// fieldToContributor converts a 1xx/7xx name field to a hub contributor.
+// X00 fields are personal names; X10 and X11 are corporate and meeting names.
+func fieldToContributor(df DataField) *hubv1.Contributor {
+	c := &hubv1.Contributor{}
+
+	switch df.Tag[1:] {
+	case "00":
+		c.Type = hubv1.ContributorType_CONTRIBUTOR_TYPE_PERSON
+		c.Name = trimISBD(strings.Join(df.Subs("abc"), " "))
+		c.ParsedName = helpers.ParseName(trimISBD(df.Sub("a")))
+	case "10":
+		c.Type = hubv1.ContributorType_CONTRIBUTOR_TYPE_ORGANIZATION
+		var parts []string
+		for _, p := range df.Subs("ab") {
+			if p = trimISBD(p); p != "" {
+				parts = append(parts, p)
+			}
+		}
+		c.Name = strings.Join(parts, ". ")
+	default:
+		c.Type = hubv1.ContributorType_CONTRIBUTOR_TYPE_ORGANIZATION
+		c.Name = trimISBD(df.Sub("a"))
+	}
+	if c.Name == "" {
+		return nil
+	}
+
+	// Relator code ($4) wins over the relator term ($e, or $j for meetings)
+	term := df.Sub("e")
+	if df.Tag[1:] == "11" {
+		term = df.Sub("j")
+	}
+	term = trimISBD(term)
+	if code := helpers.NormalizeRole(df.Sub("4")); code != "" {
+		setRelator(c, code, term)
+	} else if term != "" {
+		setRelator(c, helpers.NormalizeRole(term), term)
+	}
+
+	if aff := trimISBD(df.Sub("u")); aff != "" {
+		c.Affiliation = aff
+		c.Affiliations = []*hubv1.Affiliation{{Name: aff}}
+	}
+
+	for _, uri := range df.Subs("01") {
+		if strings.HasPrefix(uri, "http") {
+			c.AuthorityUri = uri
+			break
+		}
+	}
+
+	return c
+}
+
+// setRelator sets the contributor role from a normalized relator code,
+// keeping the original term when the code isn't a known relator.
+func setRelator(c *hubv1.Contributor, code, term string) {
+	if _, ok := helpers.MARCRelators[code]; ok {
+		c.RoleCode = "relators:" + code
+		c.Role = helpers.RelatorLabel(code)
+		return
+	}
+	if term != "" {
+		c.Role = term
+	} else {
+		c.Role = code
+	}
+}
+
+// leaderResourceType maps Leader/06 (type of record) and Leader/07
+// (bibliographic level) to a hub resource type. A 502 dissertation note
+// or 008/24-27 nature of contents "m" turns a monograph into a thesis.
+func leaderResourceType(leader, f008 string, hasDissertationNote bool) *hubv1.ResourceType {
+	if len(leader) < 8 {
+		return nil
+	}
+	typeOfRecord, level := leader[6], leader[7]
+
+	rt := hubv1.ResourceTypeValue_RESOURCE_TYPE_OTHER
+	switch typeOfRecord {
+	case 'a':
+		switch level {
+		case 'a':
+			rt = hubv1.ResourceTypeValue_RESOURCE_TYPE_BOOK_CHAPTER
+		case 'b':
+			rt = hubv1.ResourceTypeValue_RESOURCE_TYPE_ARTICLE
+		case 's', 'i':
+			rt = hubv1.ResourceTypeValue_RESOURCE_TYPE_PERIODICAL
+		case 'c':
+			rt = hubv1.ResourceTypeValue_RESOURCE_TYPE_COLLECTION
+		default:
+			rt = hubv1.ResourceTypeValue_RESOURCE_TYPE_BOOK
+			natureOfContents := ""
+			if len(f008) >= 28 {
+				natureOfContents = f008[24:28]
+			}
+			if hasDissertationNote || strings.Contains(natureOfContents, "m") {
+				rt = hubv1.ResourceTypeValue_RESOURCE_TYPE_THESIS
+			}
+		}
+	case 't':
+		rt = hubv1.ResourceTypeValue_RESOURCE_TYPE_MANUSCRIPT
+	case 'e', 'f':
+		rt = hubv1.ResourceTypeValue_RESOURCE_TYPE_MAP
+	case 'g':
+		rt = hubv1.ResourceTypeValue_RESOURCE_TYPE_VIDEO
+	case 'i', 'j':
+		rt = hubv1.ResourceTypeValue_RESOURCE_TYPE_AUDIO
+	case 'k':
+		rt = hubv1.ResourceTypeValue_RESOURCE_TYPE_IMAGE
+	case 'm':
+		rt = hubv1.ResourceTypeValue_RESOURCE_TYPE_SOFTWARE
+		// 008/26 type of computer file "a" is numeric data
+		if len(f008) > 26 && f008[26] == 'a' {
+			rt = hubv1.ResourceTypeValue_RESOURCE_TYPE_DATASET
+		}
+	case 'o', 'r':
+		rt = hubv1.ResourceTypeValue_RESOURCE_TYPE_OBJECT
+	case 'p':
+		rt = hubv1.ResourceTypeValue_RESOURCE_TYPE_ARCHIVAL_MATERIAL
+	}
+
+	return &hubv1.ResourceType{
+		Type:       rt,
+		Original:   leader[6:8],
+		Vocabulary: "marc-leader",
+	}
+}
+
+// fixedFieldDates reads 008/06 (type of date) with Date1 (07-10) and Date2
+// (11-14). Unknown digits ("199u", "19uu") lower the precision.
+func fixedFieldDates(f008 string) []*hubv1.DateValue {
+	if len(f008) < 15 {
+		return nil
+	}
+	dateType := f008[6]
+	d1, ok1 := fixedYear(f008[7:11])
+	d2, ok2 := fixedYear(f008[11:15])
+	if !ok1 {
+		return nil
+	}
+
+	switch dateType {
+	case 's', 'r', 'c', 'u':
+		d1.Type = hubv1.DateType_DATE_TYPE_ISSUED
+		return []*hubv1.DateValue{d1}
+	case 'e':
+		// Date2 holds the month and day of a detailed date
+		d1.Type = hubv1.DateType_DATE_TYPE_ISSUED
+		if month, err := strconv.Atoi(f008[11:13]); err == nil && month >= 1 && month <= 12 {
+			d1.Month = int32(month)
+			d1.Precision = hubv1.DatePrecision_DATE_PRECISION_MONTH
+			if day, err := strconv.Atoi(f008[13:15]); err == nil && day >= 1 && day <= 31 {
+				d1.Day = int32(day)
+				d1.Precision = hubv1.DatePrecision_DATE_PRECISION_DAY
+			}
+		}
+		return []*hubv1.DateValue{d1}
+	case 't':
+		d1.Type = hubv1.DateType_DATE_TYPE_ISSUED
+		dates := []*hubv1.DateValue{d1}
+		if ok2 {
+			d2.Type = hubv1.DateType_DATE_TYPE_COPYRIGHT
+			dates = append(dates, d2)
+		}
+		return dates
+	case 'i', 'k':
+		// Inclusive and bulk dates of a collection
+		d1.Type = hubv1.DateType_DATE_TYPE_CREATED
+	case 'm', 'd', 'q':
+		d1.Type = hubv1.DateType_DATE_TYPE_ISSUED
+	default:
+		return nil
+	}
+
+	if ok2 && d2.Year != 9999 {
+		d1.IsRange = true
+		d1.EndYear = d2.Year
+	}
+	if dateType == 'q' {
+		d1.Qualifier = hubv1.DateQualifier_DATE_QUALIFIER_UNCERTAIN
+		if d1.IsRange {
+			d1.EndQualifier = hubv1.DateQualifier_DATE_QUALIFIER_UNCERTAIN
+		}
+	}
+	return []*hubv1.DateValue{d1}
+}
+
+// fixedYear parses a four-character 008 year, where "u" marks an unknown
+// digit. "1990" is a year, "199u" a decade, and "19uu" a century.
+func fixedYear(s string) (*hubv1.DateValue, bool) {
+	known := strings.TrimRight(s, "u")
+	if len(known) < 2 {
+		return nil, false
+	}
+	n, err := strconv.Atoi(known)
+	if err != nil {
+		return nil, false
+	}
+
+	d := &hubv1.DateValue{}
+	switch len(known) {
+	case 4:
+		d.Year = int32(n)
+		d.Precision = hubv1.DatePrecision_DATE_PRECISION_YEAR
+	case 3:
+		d.Year = int32(n * 10)
+		d.Precision = hubv1.DatePrecision_DATE_PRECISION_DECADE
+	case 2:
+		d.Year = int32(n * 100)
+		d.Precision = hubv1.DatePrecision_DATE_PRECISION_CENTURY
+	}
+	return d, true
+}
+
+// hasDateType reports whether dates contains a date of the given type.
+func hasDateType(dates []*hubv1.DateValue, t hubv1.DateType) bool {
+	for _, d := range dates {
+		if d.Type == t {
+			return true
+		}
+	}
+	return false
+}
+
+// isLanguageCode reports whether s is a MARC language code (three
+// lowercase letters), excluding the fill and "undetermined" values.
+func isLanguageCode(s string) bool {
+	if len(s) != 3 || s == "und" || s == "zxx" {
+		return false
+	}
+	for _, r := range s {
+		if r < 'a' || r > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+// subjectTypes maps 6xx tags to hub subject types.
+var subjectTypes = map[string]hubv1.SubjectType{
+	"600": hubv1.SubjectType_SUBJECT_TYPE_NAME,
+	"610": hubv1.SubjectType_SUBJECT_TYPE_NAME,
+	"611": hubv1.SubjectType_SUBJECT_TYPE_NAME,
+	"630": hubv1.SubjectType_SUBJECT_TYPE_TITLE,
+	"648": hubv1.SubjectType_SUBJECT_TYPE_TEMPORAL,
+	"650": hubv1.SubjectType_SUBJECT_TYPE_TOPIC,
+	"651": hubv1.SubjectType_SUBJECT_TYPE_GEOGRAPHIC,
+	"655": hubv1.SubjectType_SUBJECT_TYPE_GENRE,
+}
+
+// fieldsToSubjects converts 6xx fields to subjects, and 655 to genres.
+// 653 uncontrolled terms become keywords, one per $a.
+func fieldsToSubjects(rec *Record) (subjects, genres []*hubv1.Subject) {
+	for _, df := range rec.DataFields {
+		if df.Tag == "653" {
+			for _, kw := range df.Subs("a") {
+				if kw = trimISBD(kw); kw != "" {
+					subjects = append(subjects, &hubv1.Subject{
+						Value:      kw,
+						Vocabulary: hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_KEYWORDS,
+					})
+				}
+			}
+			continue
+		}
+
+		subjectType, ok := subjectTypes[df.Tag]
+		if !ok {
+			continue
+		}
+		value := subjectHeading(df)
+		if value == "" {
+			continue
+		}
+
+		s := &hubv1.Subject{
+			Value: value,
+			Type:  subjectType,
+		}
+		for _, uri := range df.Subs("01") {
+			if strings.HasPrefix(uri, "http") {
+				s.Uri = uri
+				break
+			}
+		}
+		s.Vocabulary = subjectVocabulary(df.Ind2, df.Sub("2"), s.Uri)
+
+		if df.Tag == "655" {
+			genres = append(genres, s)
+		} else {
+			subjects = append(subjects, s)
+		}
+	}
+	return subjects, genres
+}
+
+// subjectHeading joins a 6xx heading with "--" before each subdivision
+// ($v form, $x general, $y chronological, $z geographic).
+func subjectHeading(df DataField) string {
+	var parts []string
+	var current []string
+	flush := func() {
+		if len(current) > 0 {
+			if p := trimISBD(strings.Join(current, " ")); p != "" {
+				parts = append(parts, p)
+			}
+			current = nil
+		}
+	}
+
+	for _, sf := range df.Subfields {
+		switch {
+		case strings.Contains("vxyz", sf.Code):
+			flush()
+			current = append(current, sf.Value)
+		case strings.Contains("abcdfgklmnopqrst", sf.Code):
+			current = append(current, sf.Value)
+		}
+	}
+	flush()
+
+	return strings.Join(parts, "--")
+}
+
+// sourceVocabularies maps 6xx $2 source codes to hub vocabularies.
+var sourceVocabularies = map[string]hubv1.SubjectVocabulary{
+	"lcsh":    hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LCSH,
+	"lcshac":  hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LCSH,
+	"mesh":    hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_MESH,
+	"fast":    hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_FAST,
+	"aat":     hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_AAT,
+	"tgn":     hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_GETTY_TGN,
+	"naf":     hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LCNAF,
+	"lcnaf":   hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LCNAF,
+	"lcgft":   hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_GENRE,
+	"gsafd":   hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_GENRE,
+	"ddc":     hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_DDC,
+	"lcc":     hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LCC,
+	"msc":     hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_MSC,
+	"acm":     hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_ACM,
+	"pacs":    hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_PACS,
+	"bisacsh": hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_BISAC,
+	"thema":   hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_THEMA,
+	"local":   hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LOCAL,
+}
+
+// uriVocabularies maps authority URI prefixes to hub vocabularies, for
+// headings whose indicator and $2 don't name a known source.
+var uriVocabularies = []struct {
+	prefix string
+	vocab  hubv1.SubjectVocabulary
+}{
+	{"id.loc.gov/authorities/subjects", hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LCSH},
+	{"id.loc.gov/authorities/names", hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LCNAF},
+	{"id.loc.gov/authorities/genreForms", hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_GENRE},
+	{"id.worldcat.org/fast", hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_FAST},
+	{"id.nlm.nih.gov/mesh", hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_MESH},
+	{"vocab.getty.edu/aat", hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_AAT},
+	{"vocab.getty.edu/tgn", hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_GETTY_TGN},
+}
+
+// subjectVocabulary detects a heading's vocabulary from the second
+// indicator (0/1 LCSH, 2 MeSH, 7 source in $2), then the authority URI.
+func subjectVocabulary(ind2, source, uri string) hubv1.SubjectVocabulary {
+	switch ind2 {
+	case "0", "1":
+		return hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LCSH
+	case "2":
+		return hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_MESH
+	case "7":
+		if v, ok := sourceVocabularies[strings.ToLower(strings.TrimSpace(source))]; ok {
+			return v
+		}
+	}
+	for _, u := range uriVocabularies {
+		if strings.Contains(uri, u.prefix) {
+			return u.vocab
+		}
+	}
+	return hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_UNSPECIFIED
+}
+
+// fieldsToIdentifiers collects standard numbers (020, 022, 024), the LC
+// call number (050), and electronic locations (856), without duplicates.
+func fieldsToIdentifiers(rec *Record) []*hubv1.Identifier {
+	var ids []*hubv1.Identifier
+	seen := make(map[string]bool)
+	add := func(value string, t hubv1.IdentifierType) {
+		if value = strings.TrimSpace(value); value == "" {
+			return
+		}
+		// A DOI in 024 is often repeated as a doi.org link in 856
+		id := hub.NewIdentifier(value, t)
+		key := id.Type.String() + "|" + strings.ToLower(id.Value)
+		if !seen[key] {
+			seen[key] = true
+			ids = append(ids, id)
+		}
+	}
+
+	for _, df := range rec.DataFields {
+		switch df.Tag {
+		case "020":
+			// "9780262033848 (hardcover : alk. paper)" keeps only the number
+			if fields := strings.Fields(df.Sub("a")); len(fields) > 0 {
+				add(fields[0], hubv1.IdentifierType_IDENTIFIER_TYPE_ISBN)
+			}
+		case "022":
+			add(df.Sub("a"), hubv1.IdentifierType_IDENTIFIER_TYPE_ISSN)
+		case "024":
+			t := hubv1.IdentifierType_IDENTIFIER_TYPE_UNSPECIFIED
+			value := df.Sub("a")
+			if df.Ind1 == "7" {
+				switch strings.ToLower(df.Sub("2")) {
+				case "doi":
+					t = hubv1.IdentifierType_IDENTIFIER_TYPE_DOI
+				case "hdl", "handle":
+					t = hubv1.IdentifierType_IDENTIFIER_TYPE_HANDLE
+				case "uri":
+					t = hubv1.IdentifierType_IDENTIFIER_TYPE_URL
+					if hub.DetectIdentifierType(value) == hubv1.IdentifierType_IDENTIFIER_TYPE_PURL {
+						t = hubv1.IdentifierType_IDENTIFIER_TYPE_PURL
+					}
+				case "isni":
+					t = hubv1.IdentifierType_IDENTIFIER_TYPE_ISNI
+				case "ark":
+					t = hubv1.IdentifierType_IDENTIFIER_TYPE_ARK
+				case "urn":
+					t = hubv1.IdentifierType_IDENTIFIER_TYPE_URN
+				}
+			}
+			if t == hubv1.IdentifierType_IDENTIFIER_TYPE_UNSPECIFIED && hub.DetectIdentifierType(value) == hubv1.IdentifierType_IDENTIFIER_TYPE_UNSPECIFIED {
+				t = hubv1.IdentifierType_IDENTIFIER_TYPE_LOCAL
+			}
+			add(value, t)
+		case "050":
+			add(strings.Join(df.Subs("ab"), " "), hubv1.IdentifierType_IDENTIFIER_TYPE_CALL_NUMBER)
+		case "856":
+			// Second indicator 2 is a related resource, not the item itself
+			if df.Ind2 == "2" {
+				continue
+			}
+			for _, u := range df.Subs("u") {
+				add(u, hubv1.IdentifierType_IDENTIFIER_TYPE_UNSPECIFIED)
+			}
+		}
+	}
+	return ids
+}
+
+// fieldsToRelations maps series statements (490, 830) and the host item
+// entry (773) to relations.
+func fieldsToRelations(rec *Record) []*hubv1.Relation {
+	var rels []*hubv1.Relation
+	seenSeries := make(map[string]bool)
+
+	for _, df := range rec.DataFields {
+		switch df.Tag {
+		case "490", "830":
+			title := trimISBD(df.Sub("a"))
+			if title == "" || seenSeries[strings.ToLower(title)] {
+				continue
+			}
+			seenSeries[strings.ToLower(title)] = true
+			rel := &hubv1.Relation{
+				Type:        hubv1.RelationType_RELATION_TYPE_IN_SERIES,
+				TargetTitle: title,
+			}
+			if issn := strings.TrimSpace(df.Sub("x")); issn != "" {
+				rel.TargetId = issn
+				rel.TargetIdType = hubv1.IdentifierType_IDENTIFIER_TYPE_ISSN
+			}
+			rels = append(rels, rel)
+		case "773":
+			title := trimISBD(df.Sub("t"))
+			if title == "" {
+				continue
+			}
+			rel := &hubv1.Relation{
+				Type:        hubv1.RelationType_RELATION_TYPE_PART_OF,
+				TargetTitle: title,
+				Description: strings.TrimSpace(df.Sub("g")),
+			}
+			if issn := strings.TrimSpace(df.Sub("x")); issn != "" {
+				rel.TargetId = issn
+				rel.TargetIdType = hubv1.IdentifierType_IDENTIFIER_TYPE_ISSN
+			}
+			rels = append(rels, rel)
+		}
+	}
+	return rels
+}
+
+// abbreviations keep their trailing period when ISBD punctuation is removed.
+var abbreviations = map[string]bool{
+	"inc.": true, "co.": true, "ltd.": true, "corp.": true, "jr.": true,
+	"sr.": true, "ed.": true, "etc.": true, "dept.": true, "univ.": true,
+}
+
+// trimISBD removes the ISBD punctuation catalogers put at the end of
+// subfields (" /", " :", " ;", ",", and a closing period) while keeping
+// initials and common abbreviations intact.
+func trimISBD(s string) string {
+	s = strings.TrimSpace(s)
+	for {
+		trimmed := strings.TrimRight(s, " /:;,=")
+		if strings.HasSuffix(trimmed, ".") {
+			fields := strings.Fields(trimmed)
+			last := fields[len(fields)-1]
+			// "A." is an initial; abbreviations are listed
+			if len([]rune(last)) > 2 && !abbreviations[strings.ToLower(last)] && !strings.HasSuffix(trimmed, "..") {
+				trimmed = strings.TrimSuffix(trimmed, ".")
+			}
+		}
+		trimmed = strings.TrimSpace(trimmed)
+		if trimmed == s {
+			break
+		}
+		s = trimmed
+	}
+
+	// A fully bracketed value was supplied by the cataloger: "[New York]"
+	if strings.HasPrefix(s, "[") && strings.HasSuffix(s, "]") && strings.Count(s, "[") == 1 {
+		s = strings.TrimSpace(s[1 : len(s)-1])
+	}
+	return s
+}
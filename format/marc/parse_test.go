@@ -0,0 +1,523 @@
+package marc
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+)
+
+const thesisMARCXML = `<?xml version="1.0" encoding="UTF-8"?>
+<collection xmlns="http://www.loc.gov/MARC21/slim">
+  <record>
+    <leader>01234nam a2200289 i 4500</leader>
+    <controlfield tag="001">991234567</controlfield>
+    <controlfield tag="008">190612s2019    pau     om    000 0 eng d</controlfield>
+    <datafield tag="024" ind1="7" ind2=" ">
+      <subfield code="a">10.1234/etd.5678</subfield>
+      <subfield code="2">doi</subfield>
+    </datafield>
+    <datafield tag="100" ind1="1" ind2=" ">
+      <subfield code="a">Qin, Tian,</subfield>
+      <subfield code="e">author.</subfield>
+      <subfield code="u">Lehigh University</subfield>
+    </datafield>
+    <datafield tag="245" ind1="1" ind2="0">
+      <subfield code="a">Deep learning for metadata :</subfield>
+      <subfield code="b">a case study /</subfield>
+      <subfield code="c">Tian Qin.</subfield>
+    </datafield>
+    <datafield tag="264" ind1=" " ind2="1">
+      <subfield code="a">Bethlehem, Pennsylvania :</subfield>
+      <subfield code="b">Lehigh University,</subfield>
+      <subfield code="c">2019.</subfield>
+    </datafield>
+    <datafield tag="264" ind1=" " ind2="4">
+      <subfield code="c">©2018</subfield>
+    </datafield>
+    <datafield tag="300" ind1=" " ind2=" ">
+      <subfield code="a">1 online resource (xii, 150 pages) :</subfield>
+      <subfield code="b">illustrations</subfield>
+    </datafield>
+    <datafield tag="502" ind1=" " ind2=" ">
+      <subfield code="b">Ph. D.</subfield>
+      <subfield code="c">Lehigh University</subfield>
+      <subfield code="d">2019.</subfield>
+    </datafield>
+    <datafield tag="520" ind1="3" ind2=" ">
+      <subfield code="a">An abstract about metadata.</subfield>
+    </datafield>
+    <datafield tag="650" ind1=" " ind2="0">
+      <subfield code="a">Machine learning</subfield>
+      <subfield code="x">Research</subfield>
+      <subfield code="z">Pennsylvania.</subfield>
+    </datafield>
+    <datafield tag="650" ind1=" " ind2="7">
+      <subfield code="a">Metadata.</subfield>
+      <subfield code="2">fast</subfield>
+      <subfield code="0">http://id.worldcat.org/fast/1017603</subfield>
+    </datafield>
+    <datafield tag="650" ind1=" " ind2="4">
+      <subfield code="a">Digital libraries.</subfield>
+      <subfield code="0">http://id.loc.gov/authorities/subjects/sh95008857</subfield>
+    </datafield>
+    <datafield tag="653" ind1=" " ind2=" ">
+      <subfield code="a">crosswalks</subfield>
+    </datafield>
+    <datafield tag="655" ind1=" " ind2="7">
+      <subfield code="a">Academic theses.</subfield>
+      <subfield code="2">lcgft</subfield>
+    </datafield>
+    <datafield tag="700" ind1="1" ind2=" ">
+      <subfield code="a">Huang, Wei-Min,</subfield>
+      <subfield code="e">degree supervisor.</subfield>
+      <subfield code="4">ths</subfield>
+    </datafield>
+    <datafield tag="710" ind1="2" ind2=" ">
+      <subfield code="a">Lehigh University.</subfield>
+      <subfield code="b">Department of Computer Science,</subfield>
+      <subfield code="e">degree granting institution.</subfield>
+    </datafield>
+    <datafield tag="856" ind1="4" ind2="0">
+      <subfield code="u">https://doi.org/10.1234/etd.5678</subfield>
+    </datafield>
+  </record>
+</collection>`
+
+func TestParseMARCXML(t *testing.T) {
+	records, err := (&Format{}).Parse(strings.NewReader(thesisMARCXML), nil)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("Expected 1 record, got %d", len(records))
+	}
+	r := records[0]
+
+	if r.Title != "Deep learning for metadata: a case study" {
+		t.Errorf("Title: got %q", r.Title)
+	}
+	if r.ResourceType.GetType() != hubv1.ResourceTypeValue_RESOURCE_TYPE_THESIS {
+		t.Errorf("ResourceType: got %v", r.ResourceType)
+	}
+	if r.Publisher != "Lehigh University" || r.PlacePublished != "Bethlehem, Pennsylvania" {
+		t.Errorf("Imprint: got %q / %q", r.PlacePublished, r.Publisher)
+	}
+	if r.Language != "eng" {
+		t.Errorf("Language: got %q", r.Language)
+	}
+	if r.Abstract != "An abstract about metadata." {
+		t.Errorf("Abstract: got %q", r.Abstract)
+	}
+	if r.PhysicalDesc != "1 online resource (xii, 150 pages) : illustrations" {
+		t.Errorf("PhysicalDesc: got %q", r.PhysicalDesc)
+	}
+	if r.DegreeInfo.GetDegreeName() != "Ph. D." || r.DegreeInfo.GetInstitution() != "Lehigh University" || r.DegreeInfo.GetDate().GetYear() != 2019 {
+		t.Errorf("DegreeInfo: got %v", r.DegreeInfo)
+	}
+	if r.SourceInfo.GetSourceId() != "991234567" {
+		t.Errorf("SourceId: got %q", r.SourceInfo.GetSourceId())
+	}
+
+	// Dates: 008 Date1 issued, 264 _4 copyright
+	if len(r.Dates) != 2 {
+		t.Fatalf("Expected 2 dates, got %d: %v", len(r.Dates), r.Dates)
+	}
+	if r.Dates[0].Type != hubv1.DateType_DATE_TYPE_ISSUED || r.Dates[0].Year != 2019 {
+		t.Errorf("Date 0: got %v", r.Dates[0])
+	}
+	if r.Dates[1].Type != hubv1.DateType_DATE_TYPE_COPYRIGHT || r.Dates[1].Year != 2018 {
+		t.Errorf("Date 1: got %v", r.Dates[1])
+	}
+
+	// Contributors with relators from $e and $4
+	if len(r.Contributors) != 3 {
+		t.Fatalf("Expected 3 contributors, got %d", len(r.Contributors))
+	}
+	author := r.Contributors[0]
+	if author.Name != "Qin, Tian" || author.RoleCode != "relators:aut" || author.ParsedName.GetFamily() != "Qin" {
+		t.Errorf("Author: got %q %q %v", author.Name, author.RoleCode, author.ParsedName)
+	}
+	if author.Affiliation != "Lehigh University" {
+		t.Errorf("Author affiliation: got %q", author.Affiliation)
+	}
+	if c := r.Contributors[1]; c.RoleCode != "relators:ths" || c.Role != "Thesis advisor" {
+		t.Errorf("Advisor role: got %q %q", c.RoleCode, c.Role)
+	}
+	if c := r.Contributors[2]; c.Type != hubv1.ContributorType_CONTRIBUTOR_TYPE_ORGANIZATION ||
+		c.Name != "Lehigh University. Department of Computer Science" || c.Role != "Degree granting institution" ||
+		c.RoleCode != "relators:dgg" {
+		t.Errorf("Corporate name: got %v %q %q %q", c.Type, c.Name, c.Role, c.RoleCode)
+	}
+
+	// Subjects: indicator, $2, and URI vocabulary detection
+	want := []struct {
+		value string
+		vocab hubv1.SubjectVocabulary
+	}{
+		{"Machine learning--Research--Pennsylvania", hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LCSH},
+		{"Metadata", hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_FAST},
+		{"Digital libraries", hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LCSH},
+		{"crosswalks", hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_KEYWORDS},
+	}
+	if len(r.Subjects) != len(want) {
+		t.Fatalf("Expected %d subjects, got %d: %v", len(want), len(r.Subjects), r.Subjects)
+	}
+	for i, w := range want {
+		if r.Subjects[i].Value != w.value || r.Subjects[i].Vocabulary != w.vocab {
+			t.Errorf("Subject %d: got %q %v, want %q %v", i, r.Subjects[i].Value, r.Subjects[i].Vocabulary, w.value, w.vocab)
+		}
+	}
+	if len(r.Genres) != 1 || r.Genres[0].Value != "Academic theses" || r.Genres[0].Vocabulary != hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_GENRE {
+		t.Errorf("Genres: got %v", r.Genres)
+	}
+
+	// The 856 doi.org link duplicates the 024 DOI
+	if len(r.Identifiers) != 1 || r.Identifiers[0].Type != hubv1.IdentifierType_IDENTIFIER_TYPE_DOI || r.Identifiers[0].Value != "10.1234/etd.5678" {
+		t.Errorf("Identifiers: got %v", r.Identifiers)
+	}
+}
+
+// iso2709 encodes fields as a UTF-8 ISO 2709 record. Tags below 010 are
+// control fields; data fields are "ind1 ind2" followed by "$a..." subfields.
+func iso2709(typeAndLevel string, fields [][2]string) []byte {
+	var directory, data bytes.Buffer
+	for _, f := range fields {
+		value := strings.ReplaceAll(f[1], "$", "\x1f") + "\x1e"
+		fmt.Fprintf(&directory, "%s%04d%05d", f[0], len(value), data.Len())
+		data.WriteString(value)
+	}
+	directory.WriteByte(0x1e)
+
+	base := 24 + directory.Len()
+	length := base + data.Len() + 1
+	leader := fmt.Sprintf("%05dn%s a22%05d i 4500", length, typeAndLevel, base)
+	return append([]byte(leader+directory.String()+data.String()), 0x1d)
+}
+
+func TestParseISO2709(t *testing.T) {
+	var input bytes.Buffer
+	input.Write(iso2709("am", [][2]string{
+		{"001", "ocm00012345"},
+		{"008", "850101s1985    nyu           000 0 eng  "},
+		{"020", "  $a0262033844 (hardcover)"},
+		{"100", "1 $aKnuth, Donald E.,$eauthor."},
+		{"245", "14$aThe art of programming /$cDonald E. Knuth."},
+		{"260", "  $aNew York :$bAcademic Press,$c1985."},
+		{"490", "0 $aComputer science series"},
+		{"600", "10$aKnuth, Donald E."},
+		{"650", " 2$aAlgorithms."},
+	}))
+	input.WriteString("\n")
+	input.Write(iso2709("gm", [][2]string{
+		{"008", "990101q19501959xx                  und d"},
+		{"245", "00$aHome movies"},
+	}))
+
+	f := &Format{}
+	if !f.CanParse(input.Bytes()) {
+		t.Fatal("CanParse should accept ISO 2709")
+	}
+	records, err := f.Parse(bytes.NewReader(input.Bytes()), nil)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 records, got %d", len(records))
+	}
+
+	book := records[0]
+	if book.Title != "The art of programming" {
+		t.Errorf("Title: got %q", book.Title)
+	}
+	if book.ResourceType.GetType() != hubv1.ResourceTypeValue_RESOURCE_TYPE_BOOK {
+		t.Errorf("ResourceType: got %v", book.ResourceType)
+	}
+	if book.Publisher != "Academic Press" || book.PlacePublished != "New York" {
+		t.Errorf("Imprint: got %q / %q", book.PlacePublished, book.Publisher)
+	}
+	if len(book.Contributors) != 1 || book.Contributors[0].Name != "Knuth, Donald E." {
+		t.Errorf("Contributors: got %v", book.Contributors)
+	}
+	if len(book.Identifiers) != 1 || book.Identifiers[0].Value != "0262033844" {
+		t.Errorf("Identifiers: got %v", book.Identifiers)
+	}
+	if len(book.Relations) != 1 || book.Relations[0].Type != hubv1.RelationType_RELATION_TYPE_IN_SERIES {
+		t.Errorf("Relations: got %v", book.Relations)
+	}
+	if len(book.Subjects) != 2 || book.Subjects[0].Type != hubv1.SubjectType_SUBJECT_TYPE_NAME ||
+		book.Subjects[1].Vocabulary != hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_MESH {
+		t.Errorf("Subjects: got %v", book.Subjects)
+	}
+
+	// Questionable date range, no language
+	film := records[1]
+	if film.ResourceType.GetType() != hubv1.ResourceTypeValue_RESOURCE_TYPE_VIDEO {
+		t.Errorf("ResourceType: got %v", film.ResourceType)
+	}
+	if len(film.Dates) != 1 {
+		t.Fatalf("Expected 1 date, got %v", film.Dates)
+	}
+	d := film.Dates[0]
+	if d.Year != 1950 || !d.IsRange || d.EndYear != 1959 || d.Qualifier != hubv1.DateQualifier_DATE_QUALIFIER_UNCERTAIN {
+		t.Errorf("Date: got %v", d)
+	}
+	if film.Language != "" {
+		t.Errorf("Language: got %q, want none for und", film.Language)
+	}
+}
+
+func TestFixedYear(t *testing.T) {
+	tests := []struct {
+		in        string
+		year      int32
+		precision hubv1.DatePrecision
+		ok        bool
+	}{
+		{"2019", 2019, hubv1.DatePrecision_DATE_PRECISION_YEAR, true},
+		{"199u", 1990, hubv1.DatePrecision_DATE_PRECISION_DECADE, true},
+		{"19uu", 1900, hubv1.DatePrecision_DATE_PRECISION_CENTURY, true},
+		{"uuuu", 0, 0, false},
+		{"    ", 0, 0, false},
+	}
+	for _, tt := range tests {
+		d, ok := fixedYear(tt.in)
+		if ok != tt.ok {
+			t.Errorf("fixedYear(%q) ok = %v, want %v", tt.in, ok, tt.ok)
+			continue
+		}
+		if ok && (d.Year != tt.year || d.Precision != tt.precision) {
+			t.Errorf("fixedYear(%q) = %d %v, want %d %v", tt.in, d.Year, d.Precision, tt.year, tt.precision)
+		}
+	}
+}
+
+func TestTrimISBD(t *testing.T) {
+	tests := map[string]string{
+		"Deep learning :":        "Deep learning",
+		"a case study /":         "a case study",
+		"Lehigh University,":     "Lehigh University",
+		"Metadata.":              "Metadata",
+		"Knuth, Donald E.":       "Knuth, Donald E.",
+		"Wiley & Sons, Inc.":     "Wiley & Sons, Inc.",
+		"[New York] :":           "New York",
+		"Pennsylvania. --":       "Pennsylvania. --",
+		"Ph. D.":                 "Ph. D.",
+		"History and criticism.": "History and criticism",
+	}
+	for in, want := range tests {
+		if got := trimISBD(in); got != want {
+			t.Errorf("trimISBD(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestParseOAIWrapped(t *testing.T) {
+	input := `<OAI-PMH xmlns="http://www.openarchives.org/OAI/2.0/">
+  <ListRecords>
+    <record>
+      <header>
+        <identifier>oai:catalog.example.edu:991234567</identifier>
+        <datestamp>2024-03-01</datestamp>
+      </header>
+      <metadata>
+        <marc:record xmlns:marc="http://www.loc.gov/MARC21/slim">
+          <marc:leader>00000nam a2200000 i 4500</marc:leader>
+          <marc:datafield tag="245" ind1="0" ind2="0">
+            <marc:subfield code="a">Harvested title</marc:subfield>
+          </marc:datafield>
+        </marc:record>
+      </metadata>
+    </record>
+  </ListRecords>
+</OAI-PMH>`
+
+	records, err := (&Format{}).Parse(strings.NewReader(input), nil)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(records) != 1 || records[0].Title != "Harvested title" {
+		t.Fatalf("got %v", records)
+	}
+	if oai := records[0].SourceInfo.GetOai(); oai.GetIdentifier() != "oai:catalog.example.edu:991234567" {
+		t.Errorf("OAI header: got %v", oai)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	f := &Format{}
+	original, err := f.Parse(strings.NewReader(thesisMARCXML), nil)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := f.Serialize(&buf, original, format.NewSerializeOptions()); err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+	if !f.CanParse(buf.Bytes()) {
+		t.Fatalf("CanParse rejected serialized output:\n%s", buf.String())
+	}
+
+	reparsed, err := f.Parse(&buf, nil)
+	if err != nil {
+		t.Fatalf("Re-parse failed: %v", err)
+	}
+	a, b := original[0], reparsed[0]
+
+	if a.Title != b.Title {
+		t.Errorf("Title: %q -> %q", a.Title, b.Title)
+	}
+	if b.ResourceType.GetType() != hubv1.ResourceTypeValue_RESOURCE_TYPE_THESIS {
+		t.Errorf("ResourceType: got %v", b.ResourceType)
+	}
+	if a.Publisher != b.Publisher || a.PlacePublished != b.PlacePublished || a.Language != b.Language {
+		t.Errorf("Imprint/language changed: %q %q %q -> %q %q %q", a.PlacePublished, a.Publisher, a.Language, b.PlacePublished, b.Publisher, b.Language)
+	}
+	if len(b.Dates) != len(a.Dates) || b.Dates[0].Year != 2019 {
+		t.Errorf("Dates: %v -> %v", a.Dates, b.Dates)
+	}
+	if len(b.Contributors) != len(a.Contributors) {
+		t.Fatalf("Contributors: %d -> %d", len(a.Contributors), len(b.Contributors))
+	}
+	for i := range a.Contributors {
+		if a.Contributors[i].Name != b.Contributors[i].Name || a.Contributors[i].RoleCode != b.Contributors[i].RoleCode {
+			t.Errorf("Contributor %d: %q %q -> %q %q", i, a.Contributors[i].Name, a.Contributors[i].RoleCode, b.Contributors[i].Name, b.Contributors[i].RoleCode)
+		}
+	}
+	if len(b.Subjects) != len(a.Subjects) {
+		t.Fatalf("Subjects: %v -> %v", a.Subjects, b.Subjects)
+	}
+	for i := range a.Subjects {
+		if a.Subjects[i].Value != b.Subjects[i].Value || a.Subjects[i].Vocabulary != b.Subjects[i].Vocabulary {
+			t.Errorf("Subject %d: %v -> %v", i, a.Subjects[i], b.Subjects[i])
+		}
+	}
+	if len(b.Identifiers) != 1 || b.Identifiers[0].Value != "10.1234/etd.5678" {
+		t.Errorf("Identifiers: got %v", b.Identifiers)
+	}
+	if b.DegreeInfo.GetInstitution() != "Lehigh University" {
+		t.Errorf("DegreeInfo: got %v", b.DegreeInfo)
+	}
+}
+
+func TestSerializeLanguage(t *testing.T) {
+	for language, want := range map[string]string{
+		"English":            "eng",
+		"fr":                 "fre",
+		"deu":                "ger",
+		"Pennsylvania Dutch": "und",
+	} {
+		rec := hubToMARC(&hubv1.Record{Title: "Hymnal", Language: language}, format.NewSerializeOptions())
+		var f008 string
+		for _, cf := range rec.ControlFields {
+			if cf.Tag == "008" {
+				f008 = cf.Value
+			}
+		}
+		if len(f008) < 38 || f008[35:38] != want {
+			t.Errorf("%q: 008 = %q, want language %s", language, f008, want)
+		}
+	}
+}
+
+func TestParseStream(t *testing.T) {
+	var input bytes.Buffer
+	for _, title := range []string{"First", "Second", "Third"} {
+		input.Write(iso2709("am", [][2]string{{"245", "00$a" + title}}))
+		input.WriteString("\n")
+	}
+
+	var titles []string
+	stop := errors.New("stop")
+	err := (&Format{}).ParseStream(bytes.NewReader(input.Bytes()), nil, func(r *hubv1.Record) error {
+		titles = append(titles, r.Title)
+		if len(titles) == 2 {
+			return stop
+		}
+		return nil
+	})
+	if err != stop {
+		t.Fatalf("expected the callback's error unwrapped, got %v", err)
+	}
+	if strings.Join(titles, ",") != "First,Second" {
+		t.Errorf("titles: got %v", titles)
+	}
+
+	truncated := input.Bytes()[:input.Len()-10]
+	if _, err := (&Format{}).Parse(bytes.NewReader(truncated), nil); err == nil || !strings.Contains(err.Error(), "record 2") {
+		t.Errorf("expected a truncated record error, got %v", err)
+	}
+}
+
+func TestParseSkipsBadRecords(t *testing.T) {
+	var input bytes.Buffer
+	var offsets []int
+	for _, title := range []string{"First", "Second", "Third"} {
+		offsets = append(offsets, input.Len())
+		input.Write(iso2709("am", [][2]string{{"245", "00$a" + title}}))
+	}
+	// Break the second record's base address; its length still holds
+	copy(input.Bytes()[offsets[1]+12:], "xxxxx")
+
+	var skipped []*format.RecordError
+	records, err := (&Format{}).Parse(bytes.NewReader(input.Bytes()), &format.ParseOptions{
+		OnRecordError: func(e *format.RecordError) error {
+			skipped = append(skipped, e)
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 || records[0].Title != "First" || records[1].Title != "Third" {
+		t.Errorf("expected the good records on either side, got %v", records)
+	}
+	if len(skipped) != 1 || skipped[0].Index != 1 || skipped[0].Offset != int64(offsets[1]) {
+		t.Fatalf("expected record 1 reported at its offset, got %+v", skipped)
+	}
+
+	stop := errors.New("stop")
+	_, err = (&Format{}).Parse(bytes.NewReader(input.Bytes()), &format.ParseOptions{
+		OnRecordError: func(*format.RecordError) error { return stop },
+	})
+	if err != stop {
+		t.Errorf("expected the callback's error unwrapped, got %v", err)
+	}
+}
+
+func TestPlaceOfPublication(t *testing.T) {
+	for placePublished, want := range map[string]string{
+		"Bethlehem, Pa.": "pau",
+		"Paris":          "fr ",
+		"[S.l.]":         "xx ",
+	} {
+		rec := hubToMARC(&hubv1.Record{Title: "Hymnal", PlacePublished: placePublished}, format.NewSerializeOptions())
+		var f008 string
+		for _, cf := range rec.ControlFields {
+			if cf.Tag == "008" {
+				f008 = cf.Value
+			}
+		}
+		if len(f008) < 18 || f008[15:18] != want {
+			t.Errorf("%q: 008 = %q, want place %q", placePublished, f008, want)
+		}
+	}
+
+	// The 008 code stands in for an imprint place that doesn't resolve
+	records, err := (&Format{}).Parse(bytes.NewReader(iso2709("am", [][2]string{
+		{"008", "850101s1985    pau           000 0 eng  "},
+		{"245", "00$aHymnal"},
+		{"264", " 1$aBethlehem :$bMoravian Publication Office,$c1985."},
+	})), nil)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if p := records[0].PublicationPlace; p == nil || p.Region != "Pennsylvania" || p.MarcCountry != "pau" {
+		t.Errorf("PublicationPlace: got %v", p)
+	}
+}
@@ -0,0 +1,307 @@
+package marc
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+)
+
+// ISO 2709 structural characters.
+const (
+	subfieldDelimiter = 0x1F
+	fieldTerminator   = 0x1E
+	leaderLength      = 24
+	directoryEntryLen = 12
+)
+
+// Record is a MARC record as modeled by MARCXML: a leader followed by
+// control fields (00X) and data fields with indicators and subfields.
+type Record struct {
+	XMLName       xml.Name       `xml:"record"`
+	Leader        string         `xml:"leader"`
+	ControlFields []ControlField `xml:"controlfield"`
+	DataFields    []DataField    `xml:"datafield"`
+
+	// Header is the enclosing OAI-PMH header when parsed from a harvest
+	Header *format.OAIHeader `xml:"-"`
+}
+
+// ControlField is a 00X field with a single unstructured value.
+type ControlField struct {
+	Tag   string `xml:"tag,attr"`
+	Value string `xml:",chardata"`
+}
+
+// DataField is a variable field with two indicators and coded subfields.
+type DataField struct {
+	Tag       string     `xml:"tag,attr"`
+	Ind1      string     `xml:"ind1,attr"`
+	Ind2      string     `xml:"ind2,attr"`
+	Subfields []Subfield `xml:"subfield"`
+}
+
+// Subfield is a single coded value within a data field.
+type Subfield struct {
+	Code  string `xml:"code,attr"`
+	Value string `xml:",chardata"`
+}
+
+// collection is the MARCXML wrapper for multiple records.
+type collection struct {
+	XMLName xml.Name  `xml:"collection"`
+	Xmlns   string    `xml:"xmlns,attr"`
+	Records []*Record `xml:"record"`
+}
+
+// Control returns the value of the first control field with the tag.
+func (r *Record) Control(tag string) string {
+	for _, cf := range r.ControlFields {
+		if cf.Tag == tag {
+			return cf.Value
+		}
+	}
+	return ""
+}
+
+// Fields returns the data fields with any of the given tags, in record order.
+func (r *Record) Fields(tags ...string) []DataField {
+	var result []DataField
+	for _, df := range r.DataFields {
+		for _, tag := range tags {
+			if df.Tag == tag {
+				result = append(result, df)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// Field returns the first data field with the tag.
+func (r *Record) Field(tag string) (DataField, bool) {
+	for _, df := range r.DataFields {
+		if df.Tag == tag {
+			return df, true
+		}
+	}
+	return DataField{}, false
+}
+
+// Sub returns the first value of the subfield with the code.
+func (df DataField) Sub(code string) string {
+	for _, sf := range df.Subfields {
+		if sf.Code == code {
+			return sf.Value
+		}
+	}
+	return ""
+}
+
+// Subs returns the values of subfields whose code is any byte in codes.
+func (df DataField) Subs(codes string) []string {
+	var result []string
+	for _, sf := range df.Subfields {
+		if sf.Code != "" && strings.Contains(codes, sf.Code) {
+			result = append(result, sf.Value)
+		}
+	}
+	return result
+}
+
+// isISO2709 reports whether data starts with a plausible ISO 2709 leader:
+// a five-digit record length and the "4500" entry map.
+func isISO2709(data []byte) bool {
+	if len(data) < leaderLength {
+		return false
+	}
+	for _, b := range data[:5] {
+		if b < '0' || b > '9' {
+			return false
+		}
+	}
+	return string(data[20:24]) == "4500"
+}
+
+// scanISO2709 decodes a stream of ISO 2709 records starting at byte offset
+// in the input, calling fn with each one as it is read. A record whose
+// length is readable but whose contents don't decode is handed to bad as a
+// RecordError instead; scanning continues if bad returns nil.
+func scanISO2709(br *bufio.Reader, offset int64, fn func(*Record) error, bad func(*format.RecordError) error) error {
+	for n := 0; ; n++ {
+		// Tolerate line breaks between records, which some exports add
+		skipped, err := skipSpace(br)
+		offset += int64(skipped)
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		leader, err := br.Peek(leaderLength)
+		if len(leader) < leaderLength {
+			if err == io.EOF {
+				return fmt.Errorf("record %d: truncated leader", n)
+			}
+			return err
+		}
+
+		length, err := strconv.Atoi(string(leader[:5]))
+		if err != nil || length < leaderLength {
+			return fmt.Errorf("record %d: invalid record length %q", n, leader[:5])
+		}
+		raw := make([]byte, length)
+		if _, err := io.ReadFull(br, raw); err == io.ErrUnexpectedEOF {
+			return fmt.Errorf("record %d: invalid record length %q", n, raw[:5])
+		} else if err != nil {
+			return err
+		}
+
+		rec, err := decodeISO2709Record(raw)
+		if err != nil {
+			err = bad(&format.RecordError{Index: n, Offset: offset, Err: err})
+		} else {
+			err = fn(rec)
+		}
+		if err != nil {
+			return err
+		}
+		offset += int64(length)
+	}
+}
+
+// skipSpace discards whitespace up to the next record and returns how
+// many bytes it discarded.
+func skipSpace(br *bufio.Reader) (int, error) {
+	for n := 0; ; n++ {
+		b, err := br.ReadByte()
+		if err != nil {
+			return n, err
+		}
+		if !strings.ContainsRune("\r\n\t ", rune(b)) {
+			return n, br.UnreadByte()
+		}
+	}
+}
+
+// decodeISO2709Record decodes one ISO 2709 record: leader, directory, and
+// the variable fields the directory points to.
+func decodeISO2709Record(raw []byte) (*Record, error) {
+	leader := string(raw[:leaderLength])
+	base, err := strconv.Atoi(strings.TrimSpace(leader[12:17]))
+	if err != nil || base <= leaderLength || base > len(raw) {
+		return nil, fmt.Errorf("invalid base address %q", leader[12:17])
+	}
+
+	// Leader/09 "a" declares UCS/Unicode; anything else is MARC-8, which
+	// is only decoded for its ASCII subset
+	unicode := leader[9] == 'a'
+
+	rec := &Record{Leader: leader}
+	directory := raw[leaderLength : base-1]
+	for len(directory) >= directoryEntryLen {
+		entry := directory[:directoryEntryLen]
+		directory = directory[directoryEntryLen:]
+
+		tag := string(entry[:3])
+		fieldLen, err1 := strconv.Atoi(string(entry[3:7]))
+		start, err2 := strconv.Atoi(string(entry[7:12]))
+		if err1 != nil || err2 != nil || base+start+fieldLen > len(raw) {
+			return nil, fmt.Errorf("invalid directory entry %q", entry)
+		}
+
+		field := bytes.TrimSuffix(raw[base+start:base+start+fieldLen], []byte{fieldTerminator})
+		if isControlTag(tag) {
+			rec.ControlFields = append(rec.ControlFields, ControlField{Tag: tag, Value: decodeText(field, unicode, tag)})
+			continue
+		}
+
+		df := DataField{Tag: tag, Ind1: " ", Ind2: " "}
+		if len(field) >= 2 {
+			df.Ind1, df.Ind2 = string(field[0]), string(field[1])
+			field = field[2:]
+		}
+		for _, sf := range bytes.Split(field, []byte{subfieldDelimiter}) {
+			if len(sf) == 0 {
+				continue
+			}
+			df.Subfields = append(df.Subfields, Subfield{
+				Code:  string(sf[0]),
+				Value: decodeText(sf[1:], unicode, tag),
+			})
+		}
+		rec.DataFields = append(rec.DataFields, df)
+	}
+
+	return rec, nil
+}
+
+// decodeText converts field bytes to a string. MARC-8 records keep their
+// ASCII text; other bytes are replaced and logged, since transliterating
+// MARC-8 character sets is out of scope.
+func decodeText(b []byte, unicode bool, tag string) string {
+	if utf8.Valid(b) {
+		return string(b)
+	}
+	if unicode {
+		slog.Warn("invalid UTF-8 in MARC field", "tag", tag)
+	} else {
+		slog.Warn("MARC-8 characters outside ASCII replaced; convert the file to UTF-8 for full fidelity", "tag", tag)
+	}
+	return strings.ToValidUTF8(string(b), "�")
+}
+
+// isControlTag reports whether tag is a control field (001-009).
+func isControlTag(tag string) bool {
+	return strings.HasPrefix(tag, "00")
+}
+
+// scanMARCXML finds each MARC <record> element in the XML, attaching any
+// OAI-PMH header that precedes it, and calls fn with it. OAI-PMH also has
+// a <record> element, so only records in the MARCXML namespace (or none)
+// match.
+func scanMARCXML(r io.Reader, fn func(*Record) error) error {
+	decoder := xml.NewDecoder(r)
+	var header *format.OAIHeader
+
+	for {
+		tok, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("parsing XML: %w", err)
+		}
+
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case start.Name.Local == "header" && start.Name.Space != Namespace:
+			header = &format.OAIHeader{}
+			if err := decoder.DecodeElement(header, &start); err != nil {
+				return fmt.Errorf("decoding OAI header: %w", err)
+			}
+		case start.Name.Local == "record" && (start.Name.Space == Namespace || start.Name.Space == ""):
+			rec := &Record{Header: header}
+			if err := decoder.DecodeElement(rec, &start); err != nil {
+				return fmt.Errorf("decoding MARC record: %w", err)
+			}
+			header = nil
+			if err := fn(rec); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,463 @@
+package marc
+
+import (
+	"cmp"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/helpers"
+	"github.com/lehigh-university-libraries/crosswalk/helpers/lang"
+	"github.com/lehigh-university-libraries/crosswalk/helpers/place"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+// Serialize writes hub records as a MARCXML <collection>.
+func (f *Format) Serialize(w io.Writer, records []*hubv1.Record, opts *format.SerializeOptions) error {
+	if opts == nil {
+		opts = format.NewSerializeOptions()
+	}
+
+	// An empty <collection> is schema-valid
+	coll := collection{Xmlns: Namespace}
+	for _, record := range records {
+		coll.Records = append(coll.Records, hubToMARC(record, opts))
+	}
+
+	output, err := xml.MarshalIndent(coll, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling MARCXML: %w", err)
+	}
+
+	if _, err := w.Write([]byte(xml.Header)); err != nil {
+		return err
+	}
+	if _, err := w.Write(output); err != nil {
+		return err
+	}
+	_, err = w.Write([]byte("\n"))
+	return err
+}
+
+// hubToMARC converts a hub record to a MARC bibliographic record. Fields
+// are emitted in tag order without ISBD punctuation (Leader/18 blank).
+func hubToMARC(record *hubv1.Record, opts *format.SerializeOptions) *Record {
+	rec := &Record{}
+	issued := primaryDate(record)
+
+	typeOfRecord, level := marcTypeAndLevel(record)
+	rec.Leader = "00000n" + typeOfRecord + level + " a2200000   4500"
+
+	if record.SourceInfo != nil && record.SourceInfo.SourceId != "" {
+		rec.ControlFields = append(rec.ControlFields, ControlField{Tag: "001", Value: record.SourceInfo.SourceId})
+	}
+	rec.ControlFields = append(rec.ControlFields, ControlField{Tag: "008", Value: fixedField008(record, issued)})
+
+	add := func(tag, ind1, ind2 string, subfields ...Subfield) {
+		var kept []Subfield
+		for _, sf := range subfields {
+			if sf.Value != "" {
+				kept = append(kept, sf)
+			}
+		}
+		if len(kept) > 0 {
+			rec.DataFields = append(rec.DataFields, DataField{Tag: tag, Ind1: ind1, Ind2: ind2, Subfields: kept})
+		}
+	}
+
+	// Standard numbers and call number
+	for _, id := range record.Identifiers {
+		switch id.Type {
+		case hubv1.IdentifierType_IDENTIFIER_TYPE_ISBN:
+			add("020", " ", " ", Subfield{"a", id.Value})
+		case hubv1.IdentifierType_IDENTIFIER_TYPE_ISSN:
+			add("022", " ", " ", Subfield{"a", id.Value})
+		case hubv1.IdentifierType_IDENTIFIER_TYPE_DOI:
+			add("024", "7", " ", Subfield{"a", id.Value}, Subfield{"2", "doi"})
+		case hubv1.IdentifierType_IDENTIFIER_TYPE_HANDLE:
+			add("024", "7", " ", Subfield{"a", id.Value}, Subfield{"2", "hdl"})
+		case hubv1.IdentifierType_IDENTIFIER_TYPE_ISNI:
+			add("024", "7", " ", Subfield{"a", id.Value}, Subfield{"2", "isni"})
+		case hubv1.IdentifierType_IDENTIFIER_TYPE_ARK:
+			add("024", "7", " ", Subfield{"a", id.Value}, Subfield{"2", "ark"})
+		case hubv1.IdentifierType_IDENTIFIER_TYPE_URN:
+			add("024", "7", " ", Subfield{"a", id.Value}, Subfield{"2", "urn"})
+		case hubv1.IdentifierType_IDENTIFIER_TYPE_PURL:
+			add("024", "7", " ", Subfield{"a", id.Value}, Subfield{"2", "uri"})
+		}
+	}
+	for _, id := range record.Identifiers {
+		if id.Type == hubv1.IdentifierType_IDENTIFIER_TYPE_CALL_NUMBER {
+			add("050", " ", "4", Subfield{"a", id.Value})
+		}
+	}
+
+	// Main entry is the first creator; everyone else is an added entry
+	mainEntry := -1
+	for i, c := range record.Contributors {
+		if code := contributorRelator(c); code == "" || code == "aut" || code == "cre" {
+			mainEntry = i
+			break
+		}
+	}
+	if mainEntry >= 0 {
+		rec.DataFields = append(rec.DataFields, contributorField(record.Contributors[mainEntry], "1"))
+	}
+
+	// Title: split "Title: Subtitle" back into $a and $b
+	if record.Title != "" {
+		ind1 := "0"
+		if mainEntry >= 0 {
+			ind1 = "1"
+		}
+		title, subtitle, _ := strings.Cut(record.Title, ": ")
+		add("245", ind1, "0", Subfield{"a", title}, Subfield{"b", subtitle})
+	}
+	for _, alt := range record.AltTitle {
+		add("246", "3", " ", Subfield{"a", alt})
+	}
+	add("250", " ", " ", Subfield{"a", record.Edition})
+
+	// Imprint and copyright
+	if record.Publisher != "" || record.PlacePublished != "" || issued != nil {
+		var date string
+		if issued != nil {
+			date = hub.DateString(issued)
+			if opts.Dates != (format.DateOptions{}) {
+				date = format.FormatDate(issued, opts.Dates)
+			}
+		}
+		add("264", " ", "1", Subfield{"a", record.PlacePublished}, Subfield{"b", record.Publisher}, Subfield{"c", date})
+	}
+	for _, d := range record.Dates {
+		if d.Type == hubv1.DateType_DATE_TYPE_COPYRIGHT && d.Year > 0 {
+			add("264", " ", "4", Subfield{"c", fmt.Sprintf("©%d", d.Year)})
+			break
+		}
+	}
+
+	add("300", " ", " ", Subfield{"a", record.PhysicalDesc})
+
+	// Series
+	for _, rel := range record.Relations {
+		if rel.Type == hubv1.RelationType_RELATION_TYPE_IN_SERIES {
+			issn := ""
+			if rel.TargetIdType == hubv1.IdentifierType_IDENTIFIER_TYPE_ISSN {
+				issn = rel.TargetId
+			}
+			add("490", "0", " ", Subfield{"a", rel.TargetTitle}, Subfield{"x", issn})
+		}
+	}
+
+	// Notes
+	for _, note := range record.Notes {
+		add("500", " ", " ", Subfield{"a", note})
+	}
+	if di := record.DegreeInfo; di != nil {
+		var year string
+		if di.Date != nil && di.Date.Year > 0 {
+			year = fmt.Sprintf("%d", di.Date.Year)
+		}
+		add("502", " ", " ", Subfield{"b", di.DegreeName}, Subfield{"c", di.Institution}, Subfield{"d", year})
+	}
+	add("505", "0", " ", Subfield{"a", record.TableOfContents})
+	add("506", " ", " ", Subfield{"a", record.AccessCondition})
+	add("520", " ", " ", Subfield{"a", record.Abstract})
+	for _, r := range record.Rights {
+		statement := r.Statement
+		if statement == "" {
+			statement = r.License
+		}
+		add("540", " ", " ", Subfield{"a", statement}, Subfield{"u", r.Uri})
+	}
+	if record.Language != "" && marcLanguage(record.Language) == "" {
+		add("546", " ", " ", Subfield{"a", record.Language})
+	}
+
+	// Subjects, keywords, and genres
+	for _, s := range record.Subjects {
+		if s.Vocabulary == hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_KEYWORDS {
+			add("653", " ", " ", Subfield{"a", s.Value})
+			continue
+		}
+		if f, ok := subjectField(s, subjectTag(s.Type)); ok {
+			rec.DataFields = append(rec.DataFields, f)
+		}
+	}
+	for _, g := range record.Genres {
+		if f, ok := subjectField(g, "655"); ok {
+			rec.DataFields = append(rec.DataFields, f)
+		}
+	}
+
+	// Added entries
+	for i, c := range record.Contributors {
+		if i != mainEntry {
+			rec.DataFields = append(rec.DataFields, contributorField(c, "7"))
+		}
+	}
+
+	// Host item
+	for _, rel := range record.Relations {
+		if rel.Type == hubv1.RelationType_RELATION_TYPE_PART_OF && rel.TargetTitle != "" {
+			issn := ""
+			if rel.TargetIdType == hubv1.IdentifierType_IDENTIFIER_TYPE_ISSN {
+				issn = rel.TargetId
+			}
+			add("773", "0", " ", Subfield{"t", rel.TargetTitle}, Subfield{"g", rel.Description}, Subfield{"x", issn})
+		}
+	}
+
+	// Electronic locations
+	for _, id := range record.Identifiers {
+		if id.Type == hubv1.IdentifierType_IDENTIFIER_TYPE_URL {
+			add("856", "4", "0", Subfield{"u", id.Value})
+		}
+	}
+
+	return rec
+}
+
+// primaryDate returns the issued date, or the first date when there is none.
+func primaryDate(record *hubv1.Record) *hubv1.DateValue {
+	for _, d := range record.Dates {
+		if d.Type == hubv1.DateType_DATE_TYPE_ISSUED || d.Type == hubv1.DateType_DATE_TYPE_PUBLISHED {
+			return d
+		}
+	}
+	if len(record.Dates) > 0 {
+		return record.Dates[0]
+	}
+	return nil
+}
+
+// marcTypeAndLevel maps a hub resource type to Leader/06 (type of record)
+// and Leader/07 (bibliographic level).
+func marcTypeAndLevel(record *hubv1.Record) (string, string) {
+	if record.ResourceType == nil {
+		return "a", "m"
+	}
+	switch record.ResourceType.Type {
+	case hubv1.ResourceTypeValue_RESOURCE_TYPE_ARTICLE, hubv1.ResourceTypeValue_RESOURCE_TYPE_NEWSPAPER_ARTICLE:
+		return "a", "b"
+	case hubv1.ResourceTypeValue_RESOURCE_TYPE_BOOK_CHAPTER, hubv1.ResourceTypeValue_RESOURCE_TYPE_CONFERENCE_PAPER:
+		return "a", "a"
+	case hubv1.ResourceTypeValue_RESOURCE_TYPE_JOURNAL, hubv1.ResourceTypeValue_RESOURCE_TYPE_PERIODICAL,
+		hubv1.ResourceTypeValue_RESOURCE_TYPE_NEWSPAPER:
+		return "a", "s"
+	case hubv1.ResourceTypeValue_RESOURCE_TYPE_MANUSCRIPT:
+		return "t", "m"
+	case hubv1.ResourceTypeValue_RESOURCE_TYPE_MAP:
+		return "e", "m"
+	case hubv1.ResourceTypeValue_RESOURCE_TYPE_VIDEO:
+		return "g", "m"
+	case hubv1.ResourceTypeValue_RESOURCE_TYPE_AUDIO:
+		return "i", "m"
+	case hubv1.ResourceTypeValue_RESOURCE_TYPE_IMAGE:
+		return "k", "m"
+	case hubv1.ResourceTypeValue_RESOURCE_TYPE_SOFTWARE, hubv1.ResourceTypeValue_RESOURCE_TYPE_DATASET:
+		return "m", "m"
+	case hubv1.ResourceTypeValue_RESOURCE_TYPE_OBJECT:
+		return "r", "m"
+	case hubv1.ResourceTypeValue_RESOURCE_TYPE_COLLECTION, hubv1.ResourceTypeValue_RESOURCE_TYPE_ARCHIVAL_MATERIAL:
+		return "p", "c"
+	default:
+		return "a", "m"
+	}
+}
+
+// marcLanguage returns the MARC language code, the ISO 639-2/B code, for
+// a language name or code, or "" if it names no language.
+func marcLanguage(value string) string {
+	if l, ok := lang.Lookup(value); ok {
+		return l.Code(lang.ISO6392B)
+	}
+	if isLanguageCode(value) {
+		return value
+	}
+	return ""
+}
+
+// fixedField008 builds the 40-character 008. Positions crosswalk can't
+// know are filled with "|" (no attempt to code).
+func fixedField008(record *hubv1.Record, issued *hubv1.DateValue) string {
+	f := []byte(strings.Repeat("|", 40))
+	copy(f[0:6], "||||||")
+
+	dateType, date1, date2 := byte('n'), "uuuu", "uuuu"
+	if issued != nil && issued.Year > 0 {
+		dateType, date1, date2 = 's', fixedYearString(issued), "    "
+		if issued.IsRange && issued.EndYear > 0 {
+			dateType, date2 = 'm', fmt.Sprintf("%04d", issued.EndYear)
+		}
+		if issued.Qualifier == hubv1.DateQualifier_DATE_QUALIFIER_UNCERTAIN && issued.IsRange {
+			dateType = 'q'
+		}
+	}
+	f[6] = dateType
+	copy(f[7:11], date1)
+	copy(f[11:15], date2)
+	copy(f[15:18], "xx ")
+	if p := place.Published(record); p != nil && p.MarcCountry != "" {
+		copy(f[15:18], fmt.Sprintf("%-3s", p.MarcCountry))
+	}
+
+	// Books: nature of contents "m" marks a thesis
+	if record.ResourceType != nil {
+		switch record.ResourceType.Type {
+		case hubv1.ResourceTypeValue_RESOURCE_TYPE_THESIS, hubv1.ResourceTypeValue_RESOURCE_TYPE_DISSERTATION:
+			copy(f[24:28], "m   ")
+		case hubv1.ResourceTypeValue_RESOURCE_TYPE_DATASET:
+			f[26] = 'a'
+		}
+	}
+
+	copy(f[35:38], cmp.Or(marcLanguage(record.Language), "und"))
+	f[38] = ' '
+	f[39] = 'd'
+	return string(f)
+}
+
+// fixedYearString renders a year for 008 Date1, using "u" for the unknown
+// digits of decade and century precision.
+func fixedYearString(d *hubv1.DateValue) string {
+	y := fmt.Sprintf("%04d", d.Year)
+	switch d.Precision {
+	case hubv1.DatePrecision_DATE_PRECISION_DECADE:
+		return y[:3] + "u"
+	case hubv1.DatePrecision_DATE_PRECISION_CENTURY:
+		return y[:2] + "uu"
+	}
+	return y
+}
+
+// contributorRelator returns the contributor's MARC relator code.
+func contributorRelator(c *hubv1.Contributor) string {
+	if code := helpers.NormalizeRole(c.RoleCode); code != "" {
+		return code
+	}
+	return helpers.NormalizeRole(c.Role)
+}
+
+// contributorField builds a 1xx ("1") or 7xx ("7") name field.
+func contributorField(c *hubv1.Contributor, prefix string) DataField {
+	df := DataField{Ind1: " ", Ind2: " "}
+	if c.Type == hubv1.ContributorType_CONTRIBUTOR_TYPE_ORGANIZATION {
+		df.Tag = prefix + "10"
+		df.Ind1 = "2"
+		df.Subfields = append(df.Subfields, Subfield{"a", c.Name})
+	} else {
+		df.Tag = prefix + "00"
+		name := hub.InvertedName(c)
+		// First indicator: 1 is "Surname, Forename", 0 a forename or direct order
+		df.Ind1 = "0"
+		if strings.Contains(name, ",") {
+			df.Ind1 = "1"
+		}
+		df.Subfields = append(df.Subfields, Subfield{"a", name})
+	}
+
+	if code := contributorRelator(c); code != "" {
+		if _, ok := helpers.MARCRelators[code]; ok {
+			df.Subfields = append(df.Subfields,
+				Subfield{"e", strings.ToLower(helpers.RelatorLabel(code))},
+				Subfield{"4", code})
+		} else {
+			df.Subfields = append(df.Subfields, Subfield{"e", c.Role})
+		}
+	}
+	if aff := contributorAffiliation(c); aff != "" {
+		df.Subfields = append(df.Subfields, Subfield{"u", aff})
+	}
+	if c.AuthorityUri != "" {
+		df.Subfields = append(df.Subfields, Subfield{"0", c.AuthorityUri})
+	}
+	return df
+}
+
+// contributorAffiliation returns the contributor's primary affiliation.
+func contributorAffiliation(c *hubv1.Contributor) string {
+	if len(c.Affiliations) > 0 {
+		return c.Affiliations[0].Name
+	}
+	return c.Affiliation
+}
+
+// subjectTag maps a hub subject type to its 6xx tag.
+func subjectTag(t hubv1.SubjectType) string {
+	switch t {
+	case hubv1.SubjectType_SUBJECT_TYPE_NAME:
+		return "600"
+	case hubv1.SubjectType_SUBJECT_TYPE_TITLE:
+		return "630"
+	case hubv1.SubjectType_SUBJECT_TYPE_TEMPORAL:
+		return "648"
+	case hubv1.SubjectType_SUBJECT_TYPE_GEOGRAPHIC:
+		return "651"
+	case hubv1.SubjectType_SUBJECT_TYPE_GENRE:
+		return "655"
+	default:
+		return "650"
+	}
+}
+
+// vocabularySources maps hub vocabularies to the 6xx $2 source code used
+// with second indicator 7.
+var vocabularySources = map[hubv1.SubjectVocabulary]string{
+	hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_FAST:      "fast",
+	hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_AAT:       "aat",
+	hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_GETTY_TGN: "tgn",
+	hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LCNAF:     "naf",
+	hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_GENRE:     "lcgft",
+	hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_DDC:       "ddc",
+	hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LCC:       "lcc",
+	hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_MSC:       "msc",
+	hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_ACM:       "acm",
+	hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_PACS:      "pacs",
+	hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_BISAC:     "bisacsh",
+	hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_THEMA:     "thema",
+	hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LOCAL:     "local",
+}
+
+// subjectField builds a 6xx field. A "--" heading is split into $a and
+// $x subdivisions; the vocabulary sets the second indicator and $2.
+func subjectField(s *hubv1.Subject, tag string) (DataField, bool) {
+	parts := strings.Split(s.Value, "--")
+	if strings.TrimSpace(parts[0]) == "" {
+		return DataField{}, false
+	}
+
+	ind1 := " "
+	if tag == "600" {
+		ind1 = "1"
+	}
+	df := DataField{Tag: tag, Ind1: ind1, Ind2: "4"}
+	for i, p := range parts {
+		code := "x"
+		if i == 0 {
+			code = "a"
+		}
+		if p = strings.TrimSpace(p); p != "" {
+			df.Subfields = append(df.Subfields, Subfield{code, p})
+		}
+	}
+
+	switch s.Vocabulary {
+	case hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LCSH:
+		df.Ind2 = "0"
+	case hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_MESH:
+		df.Ind2 = "2"
+	default:
+		if source, ok := vocabularySources[s.Vocabulary]; ok {
+			df.Ind2 = "7"
+			df.Subfields = append(df.Subfields, Subfield{"2", source})
+		}
+	}
+	if s.Uri != "" {
+		df.Subfields = append(df.Subfields, Subfield{"0", s.Uri})
+	}
+	return df, true
+}
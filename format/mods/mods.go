@@ -15,9 +15,10 @@ type Format struct{}
 
 // Ensure Format implements the interfaces
 var (
-	_ format.Format     = (*Format)(nil)
-	_ format.Parser     = (*Format)(nil)
-	_ format.Serializer = (*Format)(nil)
+	_ format.Format         = (*Format)(nil)
+	_ format.Parser         = (*Format)(nil)
+	_ format.Serializer     = (*Format)(nil)
+	_ format.FieldDescriber = (*Format)(nil)
 )
 
 // Name returns the format identifier.
@@ -35,6 +36,22 @@ func (f *Format) Extensions() []string {
 	return []string{"xml", "mods"}
 }
 
+// writtenFields are the hub record fields Serialize writes.
+var writtenFields = []string{
+	"title", "titles", "alt_title", "abstract", "abstracts",
+	"contributors", "dates",
+	"resource_type", "genres", "subjects", "language", "publisher",
+	"place_published", "rights", "identifiers", "notes", "edition",
+	"archival_location", "holdings", "relations.part_of",
+	"relations.has_part", "relations.in_series", "relations.version_of",
+	"relations.references",
+}
+
+// WrittenFields returns the hub record fields Serialize writes.
+func (f *Format) WrittenFields(opts *format.SerializeOptions) []string {
+	return writtenFields
+}
+
 // CanParse returns true if the input looks like MODS XML.
 func (f *Format) CanParse(peek []byte) bool {
 	peek = bytes.TrimSpace(peek)
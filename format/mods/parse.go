@@ -10,6 +10,9 @@ import (
 	"github.com/lehigh-university-libraries/crosswalk/format/protoxml"
 	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
 	modsv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/spoke/mods/v3_8"
+	"github.com/lehigh-university-libraries/crosswalk/helpers"
+	"github.com/lehigh-university-libraries/crosswalk/helpers/place"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
 )
 
 // Parse reads MODS XML and returns hub records.
@@ -53,11 +56,20 @@ func spokeToHub(spoke *modsv1.Record) *hubv1.Record {
 	record := &hubv1.Record{}
 
 	// Title: use the first titleInfo with no type as the primary title.
+	// Translated titles tagged with a language are the title in that
+	// language.
+	titleLang := ""
 	for _, ti := range spoke.TitleInfo {
 		if ti.Type == modsv1.TitleType_TITLE_TYPE_UNSPECIFIED && record.Title == "" {
 			record.Title = ti.Title
+			titleLang = ti.Lang
 		} else if ti.Type == modsv1.TitleType_TITLE_TYPE_ALTERNATIVE {
 			record.AltTitle = append(record.AltTitle, ti.Title)
+		} else if ti.Type == modsv1.TitleType_TITLE_TYPE_TRANSLATED && ti.Lang != "" {
+			if len(record.Titles) == 0 && record.Title != "" {
+				hub.AddTitle(record, record.Title, titleLang)
+			}
+			hub.AddTitle(record, ti.Title, ti.Lang)
 		}
 	}
 
@@ -77,55 +89,43 @@ func spokeToHub(spoke *modsv1.Record) *hubv1.Record {
 	}
 
 	// Origin info: publisher, place, dates.
+	var placeCode string
 	for _, oi := range spoke.OriginInfo {
 		if len(oi.Publisher) > 0 && record.Publisher == "" {
 			record.Publisher = oi.Publisher[0]
 		}
-		for _, place := range oi.Place {
-			for _, pt := range place.PlaceTerm {
-				if pt.Value != "" && record.PlacePublished == "" {
+		for _, p := range oi.Place {
+			for _, pt := range p.PlaceTerm {
+				switch {
+				case pt.Value == "":
+				case pt.Type == modsv1.PlaceTermType_PLACE_TERM_TYPE_CODE:
+					if pt.Authority == "marccountry" && placeCode == "" {
+						placeCode = pt.Value
+					}
+				case record.PlacePublished == "":
 					record.PlacePublished = pt.Value
 				}
 			}
 		}
-		for _, d := range oi.DateIssued {
-			if d.Value != "" {
-				record.Dates = append(record.Dates, &hubv1.DateValue{
-					Type: hubv1.DateType_DATE_TYPE_ISSUED,
-					Raw:  d.Value,
-				})
-			}
-		}
-		for _, d := range oi.DateCreated {
-			if d.Value != "" {
-				record.Dates = append(record.Dates, &hubv1.DateValue{
-					Type: hubv1.DateType_DATE_TYPE_CREATED,
-					Raw:  d.Value,
-				})
-			}
-		}
-		for _, d := range oi.CopyrightDate {
-			if d.Value != "" {
-				record.Dates = append(record.Dates, &hubv1.DateValue{
-					Type: hubv1.DateType_DATE_TYPE_COPYRIGHT,
-					Raw:  d.Value,
-				})
-			}
-		}
-		for _, d := range oi.DateModified {
-			if d.Value != "" {
-				record.Dates = append(record.Dates, &hubv1.DateValue{
-					Type: hubv1.DateType_DATE_TYPE_MODIFIED,
-					Raw:  d.Value,
-				})
-			}
-		}
+		record.Dates = append(record.Dates, originDates(oi.DateIssued, hubv1.DateType_DATE_TYPE_ISSUED)...)
+		record.Dates = append(record.Dates, originDates(oi.DateCreated, hubv1.DateType_DATE_TYPE_CREATED)...)
+		record.Dates = append(record.Dates, originDates(oi.CopyrightDate, hubv1.DateType_DATE_TYPE_COPYRIGHT)...)
+		record.Dates = append(record.Dates, originDates(oi.DateModified, hubv1.DateType_DATE_TYPE_MODIFIED)...)
 	}
+	record.PublicationPlace = place.Resolve(record.PlacePublished, placeCode)
 
-	// Abstract: use the first abstract value.
+	// Abstract: use the first abstract value; abstracts tagged with other
+	// languages are the abstract in those languages.
+	abstractLang := ""
 	for _, a := range spoke.Abstract {
 		if a.Value != "" && record.Abstract == "" {
 			record.Abstract = a.Value
+			abstractLang = a.Lang
+		} else if a.Value != "" && a.Lang != "" && !hub.SameLanguage(a.Lang, abstractLang) {
+			if len(record.Abstracts) == 0 {
+				hub.AddAbstract(record, record.Abstract, abstractLang)
+			}
+			hub.AddAbstract(record, a.Value, a.Lang)
 		}
 	}
 
@@ -174,6 +174,12 @@ func spokeToHub(spoke *modsv1.Record) *hubv1.Record {
 		}
 	}
 
+	// Locations mapped to holdings. A box/folder shelf locator is an
+	// archival location rather than a call number.
+	for _, loc := range spoke.Location {
+		locationToHub(record, loc)
+	}
+
 	// Notes.
 	for _, n := range spoke.Note {
 		if n.Value != "" {
@@ -215,6 +221,92 @@ func spokeToHub(spoke *modsv1.Record) *hubv1.Record {
 	return record
 }
 
+// locationToHub adds a MODS location's physical holding to the record.
+// Locations that only carry URLs are skipped.
+func locationToHub(record *hubv1.Record, loc *modsv1.Location) {
+	h := &hubv1.Holding{}
+	for _, pl := range loc.PhysicalLocation {
+		if pl.Value != "" && h.Institution == "" {
+			h.Institution = pl.Value
+		}
+	}
+	for _, sl := range loc.ShelfLocator {
+		if bf, ok := hub.ParseBoxFolder(sl); ok {
+			if record.ArchivalLocation == nil {
+				record.ArchivalLocation = bf
+			}
+		} else if sl != "" && h.CallNumber == "" {
+			h.CallNumber = sl
+		}
+	}
+	for _, hs := range loc.HoldingSimple {
+		for _, ci := range hs.CopyInformation {
+			if ci.Sublocation != "" && h.Sublocation == "" {
+				h.Sublocation = ci.Sublocation
+			}
+			if ci.ShelfLocator != "" && h.CallNumber == "" {
+				h.CallNumber = ci.ShelfLocator
+			}
+		}
+	}
+	if h.Institution != "" || h.CallNumber != "" || h.Sublocation != "" {
+		record.Holdings = append(record.Holdings, h)
+	}
+}
+
+// originDates converts MODS date elements to hub dates. A start point and
+// the end point after it are one range, and a point without its partner
+// is a range with an unknown end. Other dates keep their value as given.
+func originDates(elements []*modsv1.DateElement, dateType hubv1.DateType) []*hubv1.DateValue {
+	var dates []*hubv1.DateValue
+	var start *hubv1.DateValue
+	for _, d := range elements {
+		if d.Value == "" {
+			continue
+		}
+		switch d.Point {
+		case modsv1.DatePoint_DATE_POINT_START:
+			start = pointDate(d, dateType)
+			start.IsRange = true
+			start.EndBound = hubv1.DateBound_DATE_BOUND_UNKNOWN
+			start.Raw = d.Value + "/"
+			dates = append(dates, start)
+		case modsv1.DatePoint_DATE_POINT_END:
+			end := pointDate(d, dateType)
+			if start == nil {
+				start = &hubv1.DateValue{
+					Type:       dateType,
+					Raw:        "/",
+					IsRange:    true,
+					StartBound: hubv1.DateBound_DATE_BOUND_UNKNOWN,
+				}
+				dates = append(dates, start)
+			}
+			start.EndBound = hubv1.DateBound_DATE_BOUND_UNSPECIFIED
+			start.EndYear, start.EndMonth, start.EndDay = end.Year, end.Month, end.Day
+			start.EndPrecision = end.Precision
+			start.EndQualifier = end.Qualifier
+			start.Raw += d.Value
+			start = nil
+		default:
+			dates = append(dates, &hubv1.DateValue{Type: dateType, Raw: d.Value})
+		}
+	}
+	return dates
+}
+
+// pointDate parses the date of a start or end point, with its qualifier.
+func pointDate(d *modsv1.DateElement, dateType hubv1.DateType) *hubv1.DateValue {
+	dv, _ := helpers.ParseEDTF(d.Value, dateType)
+	switch d.Qualifier {
+	case modsv1.DateQualifier_DATE_QUALIFIER_APPROXIMATE:
+		dv.Qualifier = hubv1.DateQualifier_DATE_QUALIFIER_APPROXIMATE
+	case modsv1.DateQualifier_DATE_QUALIFIER_QUESTIONABLE:
+		dv.Qualifier = hubv1.DateQualifier_DATE_QUALIFIER_UNCERTAIN
+	}
+	return dv
+}
+
 // nameToContributor converts a MODS Name to a hub Contributor.
 func nameToContributor(name *modsv1.Name) *hubv1.Contributor {
 	c := &hubv1.Contributor{}
@@ -305,6 +397,16 @@ func mapIdentifierType(modsType string) hubv1.IdentifierType {
 		return hubv1.IdentifierType_IDENTIFIER_TYPE_HANDLE
 	case "orcid":
 		return hubv1.IdentifierType_IDENTIFIER_TYPE_ORCID
+	case "ark":
+		return hubv1.IdentifierType_IDENTIFIER_TYPE_ARK
+	case "urn":
+		return hubv1.IdentifierType_IDENTIFIER_TYPE_URN
+	case "purl":
+		return hubv1.IdentifierType_IDENTIFIER_TYPE_PURL
+	case "pmid":
+		return hubv1.IdentifierType_IDENTIFIER_TYPE_PMID
+	case "pmcid":
+		return hubv1.IdentifierType_IDENTIFIER_TYPE_PMCID
 	case "local":
 		return hubv1.IdentifierType_IDENTIFIER_TYPE_LOCAL
 	default:
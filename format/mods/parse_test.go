@@ -4,7 +4,9 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/lehigh-university-libraries/crosswalk/format"
 	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/helpers/lang"
 )
 
 func TestParseSingleRecord(t *testing.T) {
@@ -211,3 +213,255 @@ func TestParseInterfaceCompliance(t *testing.T) {
 		t.Errorf("Name: got %q", f.Name())
 	}
 }
+
+func TestLocationRoundTrip(t *testing.T) {
+	input := `<mods xmlns="http://www.loc.gov/mods/v3" version="3.8">
+  <titleInfo>
+    <title>Letter to the Board of Trustees</title>
+  </titleInfo>
+  <identifier type="local">ms0012-0034</identifier>
+  <location>
+    <physicalLocation>Lehigh University Special Collections</physicalLocation>
+    <shelfLocator>LD3171 .L5 1890</shelfLocator>
+    <shelfLocator>Box 3, Folder 12</shelfLocator>
+    <holdingSimple>
+      <copyInformation>
+        <subLocation>Linderman Library</subLocation>
+      </copyInformation>
+    </holdingSimple>
+  </location>
+</mods>`
+
+	f := &Format{}
+	records, err := f.Parse(strings.NewReader(input), nil)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	rec := records[0]
+
+	if len(rec.Holdings) != 1 {
+		t.Fatalf("expected 1 holding, got %d", len(rec.Holdings))
+	}
+	h := rec.Holdings[0]
+	if h.Institution != "Lehigh University Special Collections" {
+		t.Errorf("Institution: got %q", h.Institution)
+	}
+	if h.CallNumber != "LD3171 .L5 1890" {
+		t.Errorf("CallNumber: got %q", h.CallNumber)
+	}
+	if h.Sublocation != "Linderman Library" {
+		t.Errorf("Sublocation: got %q", h.Sublocation)
+	}
+	if rec.ArchivalLocation == nil || rec.ArchivalLocation.Box != "3" || rec.ArchivalLocation.Folder != "12" {
+		t.Errorf("ArchivalLocation: got %v", rec.ArchivalLocation)
+	}
+	if len(rec.Identifiers) != 1 {
+		t.Errorf("call number leaked into identifiers: %v", rec.Identifiers)
+	}
+
+	var buf strings.Builder
+	if err := f.Serialize(&buf, records, nil); err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"<physicalLocation>Lehigh University Special Collections</physicalLocation>",
+		"<shelfLocator>LD3171 .L5 1890</shelfLocator>",
+		"<shelfLocator>Box 3, Folder 12</shelfLocator>",
+		"<subLocation>Linderman Library</subLocation>",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %s\n%s", want, out)
+		}
+	}
+
+	// A location without copy information has no holdingSimple
+	records[0].Holdings[0].Sublocation = ""
+	buf.Reset()
+	if err := f.Serialize(&buf, records, nil); err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+	if strings.Contains(buf.String(), "holdingSimple") {
+		t.Errorf("output has an empty holdingSimple:\n%s", buf.String())
+	}
+}
+
+func TestParallelTitlesRoundTrip(t *testing.T) {
+	input := `<mods xmlns="http://www.loc.gov/mods/v3" version="3.8">
+  <titleInfo xml:lang="en">
+    <title>Oral histories of the Lehigh Valley</title>
+  </titleInfo>
+  <titleInfo type="translated" xml:lang="es">
+    <title>Historias orales del valle de Lehigh</title>
+  </titleInfo>
+  <abstract xml:lang="en">Interviews with residents.</abstract>
+  <abstract xml:lang="es">Entrevistas con residentes.</abstract>
+</mods>`
+
+	f := &Format{}
+	records, err := f.Parse(strings.NewReader(input), nil)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	rec := records[0]
+	if rec.Title != "Oral histories of the Lehigh Valley" {
+		t.Errorf("Title: got %q", rec.Title)
+	}
+	if len(rec.Titles) != 2 || rec.Titles[1].Language != "es" || rec.Titles[1].Value != "Historias orales del valle de Lehigh" {
+		t.Errorf("Titles: got %v", rec.Titles)
+	}
+	if len(rec.Abstracts) != 2 || rec.Abstracts[0].Language != "en" || rec.Abstracts[1].Value != "Entrevistas con residentes." {
+		t.Errorf("Abstracts: got %v", rec.Abstracts)
+	}
+	if len(rec.AltTitle) != 0 {
+		t.Errorf("translated title leaked into alt titles: %v", rec.AltTitle)
+	}
+
+	var buf strings.Builder
+	if err := f.Serialize(&buf, records, nil); err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		`<titleInfo xml:lang="en">`,
+		`<titleInfo type="translated" xml:lang="es">`,
+		`<abstract xml:lang="es">Entrevistas con residentes.</abstract>`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %s:\n%s", want, out)
+		}
+	}
+}
+
+func TestDateRangeRoundTrip(t *testing.T) {
+	input := `<mods xmlns="http://www.loc.gov/mods/v3" version="3.8">
+  <titleInfo>
+    <title>Bethlehem Steel photographs</title>
+  </titleInfo>
+  <originInfo>
+    <dateCreated point="start" qualifier="approximate">1920</dateCreated>
+    <dateCreated point="end">1935-06</dateCreated>
+    <dateIssued point="end">1950</dateIssued>
+  </originInfo>
+</mods>`
+
+	f := &Format{}
+	records, err := f.Parse(strings.NewReader(input), nil)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	dates := records[0].Dates
+	if len(dates) != 2 {
+		t.Fatalf("expected 2 dates, got %v", dates)
+	}
+	created := dates[1]
+	if created.Type != hubv1.DateType_DATE_TYPE_CREATED || !created.IsRange || created.Year != 1920 ||
+		created.Qualifier != hubv1.DateQualifier_DATE_QUALIFIER_APPROXIMATE || created.EndYear != 1935 || created.EndMonth != 6 {
+		t.Errorf("created: got %v", created)
+	}
+	if created.Raw != "1920/1935-06" {
+		t.Errorf("created Raw: got %q", created.Raw)
+	}
+	if issued := dates[0]; issued.StartBound != hubv1.DateBound_DATE_BOUND_UNKNOWN || issued.EndYear != 1950 {
+		t.Errorf("end point alone: got %v", issued)
+	}
+
+	var buf strings.Builder
+	if err := f.Serialize(&buf, records, nil); err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		`<dateCreated point="start" qualifier="approximate">1920</dateCreated>`,
+		`<dateCreated point="end">1935-06</dateCreated>`,
+		`<dateIssued point="end">1950</dateIssued>`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %s:\n%s", want, out)
+		}
+	}
+}
+
+func TestSerializeLanguageCode(t *testing.T) {
+	for _, tt := range []struct {
+		language string
+		opts     *format.SerializeOptions
+		want     string
+	}{
+		{"English", nil, `<languageTerm type="code" authority="iso639-2b">eng</languageTerm>`},
+		{"de", nil, `<languageTerm type="code" authority="iso639-2b">ger</languageTerm>`},
+		{"de", &format.SerializeOptions{Languages: lang.ISO6392T}, `<languageTerm type="code" authority="iso639-2t">deu</languageTerm>`},
+		{"Pennsylvania Dutch dialect", nil, `<languageTerm type="text">Pennsylvania Dutch dialect</languageTerm>`},
+	} {
+		var buf strings.Builder
+		record := &hubv1.Record{Title: "Hymnal", Language: tt.language}
+		if err := (&Format{}).Serialize(&buf, []*hubv1.Record{record}, tt.opts); err != nil {
+			t.Fatalf("Serialize failed: %v", err)
+		}
+		if !strings.Contains(buf.String(), tt.want) {
+			t.Errorf("%q: output missing %s:\n%s", tt.language, tt.want, buf.String())
+		}
+	}
+}
+
+func TestPlacePublishedRoundTrip(t *testing.T) {
+	record := &hubv1.Record{Title: "Hymnal", Publisher: "Moravian Publication Office", PlacePublished: "Bethlehem, Pa."}
+	var buf strings.Builder
+	if err := (&Format{}).Serialize(&buf, []*hubv1.Record{record}, nil); err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+	for _, want := range []string{
+		`<placeTerm type="code" authority="marccountry">pau</placeTerm>`,
+		`<placeTerm type="text">Bethlehem, Pa.</placeTerm>`,
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("output missing %s:\n%s", want, buf.String())
+		}
+	}
+
+	records, err := (&Format{}).Parse(strings.NewReader(buf.String()), nil)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	r := records[0]
+	if r.PlacePublished != "Bethlehem, Pa." {
+		t.Errorf("PlacePublished: got %q", r.PlacePublished)
+	}
+	if p := r.PublicationPlace; p == nil || p.Name != "Bethlehem, Pennsylvania" || p.MarcCountry != "pau" {
+		t.Errorf("PublicationPlace: got %v", p)
+	}
+}
+
+func TestPersistentIdentifierRoundTrip(t *testing.T) {
+	record := &hubv1.Record{
+		Title: "Letters",
+		Identifiers: []*hubv1.Identifier{
+			{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_ARK, Value: "ark:/13030/tf5p30086k"},
+			{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_URN, Value: "urn:nbn:de:101:1-2019020710"},
+			{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_PURL, Value: "https://purl.org/dc/terms/"},
+		},
+	}
+	var buf strings.Builder
+	if err := (&Format{}).Serialize(&buf, []*hubv1.Record{record}, nil); err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+	for _, want := range []string{
+		`<identifier type="ark">ark:/13030/tf5p30086k</identifier>`,
+		`<identifier type="urn">urn:nbn:de:101:1-2019020710</identifier>`,
+		`<identifier type="purl">https://purl.org/dc/terms/</identifier>`,
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("output missing %s:\n%s", want, buf.String())
+		}
+	}
+
+	records, err := (&Format{}).Parse(strings.NewReader(buf.String()), nil)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	for i, id := range records[0].Identifiers {
+		if want := record.Identifiers[i]; id.Type != want.Type || id.Value != want.Value {
+			t.Errorf("identifier %d: got %v %q, want %v %q", i, id.Type, id.Value, want.Type, want.Value)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package mods
 
 import (
+	"cmp"
 	"encoding/xml"
 	"fmt"
 	"io"
@@ -8,15 +9,24 @@ import (
 	"github.com/lehigh-university-libraries/crosswalk/format"
 	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
 	modsv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/spoke/mods/v3_8"
+	"github.com/lehigh-university-libraries/crosswalk/helpers/lang"
+	"github.com/lehigh-university-libraries/crosswalk/helpers/place"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
 )
 
 // Serialize writes hub records as MODS XML.
 func (f *Format) Serialize(w io.Writer, records []*hubv1.Record, opts *format.SerializeOptions) error {
-	// opts reserved for future use (e.g., pretty print, encoding options)
-	_ = opts
+	if opts == nil {
+		opts = format.NewSerializeOptions()
+	}
+
+	// An empty <mods> element is schema-valid
+	if len(records) == 0 {
+		records = []*hubv1.Record{{}}
+	}
 
 	for i, record := range records {
-		spokeRecord, err := hubToSpoke(record)
+		spokeRecord, err := hubToSpoke(record, opts)
 		if err != nil {
 			return fmt.Errorf("converting record %d to spoke: %w", i, err)
 		}
@@ -46,12 +56,21 @@ func (f *Format) Serialize(w io.Writer, records []*hubv1.Record, opts *format.Se
 }
 
 // hubToSpoke converts a hub record to the MODS spoke proto struct.
-func hubToSpoke(record *hubv1.Record) (*modsv1.Record, error) {
+func hubToSpoke(record *hubv1.Record, opts *format.SerializeOptions) (*modsv1.Record, error) {
 	mods := &modsv1.Record{}
 
-	// Title
-	if record.Title != "" {
-		mods.TitleInfo = []*modsv1.TitleInfo{{Title: record.Title}}
+	// Title. Titles given in other languages follow the main title as
+	// translated titles, each tagged with its language.
+	tagged := len(record.Titles) > 0
+	for i, t := range hub.Titles(record) {
+		titleInfo := &modsv1.TitleInfo{Title: t.Value}
+		if tagged {
+			titleInfo.Lang = t.Language
+			if i > 0 {
+				titleInfo.Type = modsv1.TitleType_TITLE_TYPE_TRANSLATED
+			}
+		}
+		mods.TitleInfo = append(mods.TitleInfo, titleInfo)
 	}
 	for _, alt := range record.AltTitle {
 		mods.TitleInfo = append(mods.TitleInfo, &modsv1.TitleInfo{
@@ -124,10 +143,18 @@ func hubToSpoke(record *hubv1.Record) (*modsv1.Record, error) {
 		originInfo.Publisher = []string{record.Publisher}
 		hasOriginInfo = true
 	}
+	// Place: the MARC country code when it resolves, then the place as
+	// transcribed
+	if p := place.Published(record); p != nil && p.MarcCountry != "" {
+		originInfo.Place = append(originInfo.Place, &modsv1.Place{
+			PlaceTerm: []*modsv1.PlaceTerm{{Type: modsv1.PlaceTermType_PLACE_TERM_TYPE_CODE, Authority: "marccountry", Value: p.MarcCountry}},
+		})
+		hasOriginInfo = true
+	}
 	if record.PlacePublished != "" {
-		originInfo.Place = []*modsv1.Place{{
-			PlaceTerm: []*modsv1.PlaceTerm{{Value: record.PlacePublished}},
-		}}
+		originInfo.Place = append(originInfo.Place, &modsv1.Place{
+			PlaceTerm: []*modsv1.PlaceTerm{{Type: modsv1.PlaceTermType_PLACE_TERM_TYPE_TEXT, Value: record.PlacePublished}},
+		})
 		hasOriginInfo = true
 	}
 	if record.Edition != "" {
@@ -136,15 +163,15 @@ func hubToSpoke(record *hubv1.Record) (*modsv1.Record, error) {
 	}
 
 	for _, d := range record.Dates {
-		dateStr := formatDate(d)
-		if dateStr != "" {
+		elements := dateElements(d, opts.Dates)
+		if len(elements) > 0 {
 			switch d.Type {
 			case hubv1.DateType_DATE_TYPE_ISSUED, hubv1.DateType_DATE_TYPE_PUBLISHED:
-				originInfo.DateIssued = []*modsv1.DateElement{{Value: dateStr}}
+				originInfo.DateIssued = elements
 			case hubv1.DateType_DATE_TYPE_CREATED:
-				originInfo.DateCreated = []*modsv1.DateElement{{Value: dateStr}}
+				originInfo.DateCreated = elements
 			case hubv1.DateType_DATE_TYPE_COPYRIGHT:
-				originInfo.CopyrightDate = []*modsv1.DateElement{{Value: dateStr}}
+				originInfo.CopyrightDate = elements
 			}
 			hasOriginInfo = true
 		}
@@ -154,16 +181,24 @@ func hubToSpoke(record *hubv1.Record) (*modsv1.Record, error) {
 		mods.OriginInfo = []*modsv1.OriginInfo{originInfo}
 	}
 
-	// Language
+	// Language, as a code when it names a known language
 	if record.Language != "" {
-		mods.Language = []*modsv1.Language{{
-			LanguageTerm: []*modsv1.LanguageTerm{{Value: record.Language}},
-		}}
+		term := &modsv1.LanguageTerm{Type: modsv1.LanguageTermType_LANGUAGE_TERM_TYPE_TEXT, Value: record.Language}
+		if l, ok := lang.Lookup(record.Language); ok {
+			set := cmp.Or(opts.Languages, lang.ISO6392B)
+			term = &modsv1.LanguageTerm{Type: modsv1.LanguageTermType_LANGUAGE_TERM_TYPE_CODE, Authority: string(set), Value: l.Code(set)}
+		}
+		mods.Language = []*modsv1.Language{{LanguageTerm: []*modsv1.LanguageTerm{term}}}
 	}
 
 	// Abstract
-	if record.Abstract != "" {
-		mods.Abstract = []*modsv1.Abstract{{Value: record.Abstract}}
+	tagged = len(record.Abstracts) > 0
+	for _, a := range hub.Abstracts(record) {
+		abstract := &modsv1.Abstract{Value: a.Value}
+		if tagged {
+			abstract.Lang = a.Language
+		}
+		mods.Abstract = append(mods.Abstract, abstract)
 	}
 
 	// Notes
@@ -186,6 +221,9 @@ func hubToSpoke(record *hubv1.Record) (*modsv1.Record, error) {
 		})
 	}
 
+	// Holdings and box/folder
+	mods.Location = holdingsToLocations(record)
+
 	// Relations
 	for _, rel := range record.Relations {
 		relatedItem := &modsv1.RelatedItem{
@@ -223,17 +261,76 @@ func hubToSpoke(record *hubv1.Record) (*modsv1.Record, error) {
 	return mods, nil
 }
 
-func formatDate(d *hubv1.DateValue) string {
-	if d.Year == 0 {
-		return d.Raw
+// holdingsToLocations converts hub holdings to MODS locations, one per
+// holding. Box and folder become a shelfLocator on the first location.
+func holdingsToLocations(record *hubv1.Record) []*modsv1.Location {
+	var locs []*modsv1.Location
+	for _, h := range record.Holdings {
+		loc := &modsv1.Location{}
+		if h.Institution != "" {
+			loc.PhysicalLocation = []*modsv1.PhysicalLocation{{Value: h.Institution}}
+		}
+		if h.CallNumber != "" {
+			loc.ShelfLocator = []string{h.CallNumber}
+		}
+		if h.Sublocation != "" {
+			loc.HoldingSimple = []*modsv1.Holding{{
+				CopyInformation: []*modsv1.CopyInformation{{Sublocation: h.Sublocation}},
+			}}
+		}
+		if len(loc.PhysicalLocation) > 0 || len(loc.ShelfLocator) > 0 || len(loc.HoldingSimple) > 0 {
+			locs = append(locs, loc)
+		}
 	}
-	if d.Month == 0 {
-		return fmt.Sprintf("%04d", d.Year)
+	if bf := hub.BoxFolderString(record.ArchivalLocation); bf != "" {
+		if len(locs) == 0 {
+			locs = append(locs, &modsv1.Location{})
+		}
+		locs[0].ShelfLocator = append(locs[0].ShelfLocator, bf)
 	}
-	if d.Day == 0 {
-		return fmt.Sprintf("%04d-%02d", d.Year, d.Month)
+	return locs
+}
+
+// dateElements converts a hub date to MODS date elements. A range is a
+// start and an end point, either of which is left out where that end is
+// open or unknown.
+func dateElements(d *hubv1.DateValue, opts format.DateOptions) []*modsv1.DateElement {
+	if !hub.IsInterval(d) {
+		if dateStr := format.FormatDate(d, opts); dateStr != "" {
+			return []*modsv1.DateElement{{Value: dateStr}}
+		}
+		return nil
+	}
+
+	var elements []*modsv1.DateElement
+	if d.StartBound == hubv1.DateBound_DATE_BOUND_UNSPECIFIED && d.Year != 0 {
+		elements = append(elements, &modsv1.DateElement{
+			Value:     format.FormatDate(&hubv1.DateValue{Year: d.Year, Month: d.Month, Day: d.Day}, opts),
+			Point:     modsv1.DatePoint_DATE_POINT_START,
+			Qualifier: qualifierToMODS(d.Qualifier),
+		})
 	}
-	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+	if d.EndBound == hubv1.DateBound_DATE_BOUND_UNSPECIFIED {
+		elements = append(elements, &modsv1.DateElement{
+			Value:     format.FormatDate(&hubv1.DateValue{Year: d.EndYear, Month: d.EndMonth, Day: d.EndDay}, opts),
+			Point:     modsv1.DatePoint_DATE_POINT_END,
+			Qualifier: qualifierToMODS(d.EndQualifier),
+		})
+	}
+	return elements
+}
+
+// qualifierToMODS maps a hub date qualifier to a MODS one; MODS has no
+// qualifier for dates both approximate and uncertain, which are
+// questionable.
+func qualifierToMODS(q hubv1.DateQualifier) modsv1.DateQualifier {
+	switch q {
+	case hubv1.DateQualifier_DATE_QUALIFIER_APPROXIMATE:
+		return modsv1.DateQualifier_DATE_QUALIFIER_APPROXIMATE
+	case hubv1.DateQualifier_DATE_QUALIFIER_UNCERTAIN, hubv1.DateQualifier_DATE_QUALIFIER_BOTH:
+		return modsv1.DateQualifier_DATE_QUALIFIER_QUESTIONABLE
+	}
+	return modsv1.DateQualifier_DATE_QUALIFIER_UNSPECIFIED
 }
 
 func mapResourceTypeToMODS(rt hubv1.ResourceTypeValue) string {
@@ -281,6 +378,16 @@ func identifierTypeToMODS(t hubv1.IdentifierType) string {
 		return "hdl"
 	case hubv1.IdentifierType_IDENTIFIER_TYPE_ORCID:
 		return "orcid"
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_ARK:
+		return "ark"
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_URN:
+		return "urn"
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_PURL:
+		return "purl"
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_PMID:
+		return "pmid"
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_PMCID:
+		return "pmcid"
 	case hubv1.IdentifierType_IDENTIFIER_TYPE_LOCAL:
 		return "local"
 	default:
@@ -319,6 +426,7 @@ func spokeToXML(spoke *modsv1.Record) *XMLMods {
 	for _, t := range spoke.TitleInfo {
 		xmlMods.TitleInfo = append(xmlMods.TitleInfo, XMLTitleInfo{
 			Type:  titleTypeToString(t.Type),
+			Lang:  t.Lang,
 			Title: t.Title,
 		})
 	}
@@ -362,20 +470,14 @@ func spokeToXML(spoke *modsv1.Record) *XMLMods {
 		for _, p := range o.Place {
 			for _, pt := range p.PlaceTerm {
 				xmlOrigin.Places = append(xmlOrigin.Places, XMLPlace{
-					PlaceTerm: XMLPlaceTerm{Value: pt.Value},
+					PlaceTerm: XMLPlaceTerm{Type: placeTermTypeToString(pt.Type), Authority: pt.Authority, Value: pt.Value},
 				})
 			}
 		}
 		xmlOrigin.Publishers = o.Publisher
-		for _, d := range o.DateIssued {
-			xmlOrigin.DateIssued = append(xmlOrigin.DateIssued, d.Value)
-		}
-		for _, d := range o.DateCreated {
-			xmlOrigin.DateCreated = append(xmlOrigin.DateCreated, d.Value)
-		}
-		for _, d := range o.CopyrightDate {
-			xmlOrigin.CopyrightDates = append(xmlOrigin.CopyrightDates, d.Value)
-		}
+		xmlOrigin.DateIssued = xmlDates(o.DateIssued)
+		xmlOrigin.DateCreated = xmlDates(o.DateCreated)
+		xmlOrigin.CopyrightDates = xmlDates(o.CopyrightDate)
 		if o.Edition != "" {
 			xmlOrigin.Editions = []string{o.Edition}
 		}
@@ -386,14 +488,14 @@ func spokeToXML(spoke *modsv1.Record) *XMLMods {
 	for _, l := range spoke.Language {
 		for _, lt := range l.LanguageTerm {
 			xmlMods.Languages = append(xmlMods.Languages, XMLLanguage{
-				LanguageTerm: XMLLanguageTerm{Value: lt.Value},
+				LanguageTerm: XMLLanguageTerm{Type: languageTermTypeToString(lt.Type), Authority: lt.Authority, Value: lt.Value},
 			})
 		}
 	}
 
 	// Abstract
 	for _, a := range spoke.Abstract {
-		xmlMods.Abstracts = append(xmlMods.Abstracts, a.Value)
+		xmlMods.Abstracts = append(xmlMods.Abstracts, XMLAbstract{Lang: a.Lang, Value: a.Value})
 	}
 
 	// Notes
@@ -415,6 +517,29 @@ func spokeToXML(spoke *modsv1.Record) *XMLMods {
 		})
 	}
 
+	// Locations
+	for _, loc := range spoke.Location {
+		xmlLoc := XMLLocation{ShelfLocators: loc.ShelfLocator}
+		for _, pl := range loc.PhysicalLocation {
+			xmlLoc.PhysicalLocations = append(xmlLoc.PhysicalLocations, pl.Value)
+		}
+		for _, hs := range loc.HoldingSimple {
+			for _, ci := range hs.CopyInformation {
+				if ci.Sublocation == "" && ci.ShelfLocator == "" {
+					continue
+				}
+				if xmlLoc.HoldingSimple == nil {
+					xmlLoc.HoldingSimple = &XMLHoldingSimple{}
+				}
+				xmlLoc.HoldingSimple.CopyInformation = append(xmlLoc.HoldingSimple.CopyInformation, XMLCopyInformation{
+					Sublocation:  ci.Sublocation,
+					ShelfLocator: ci.ShelfLocator,
+				})
+			}
+		}
+		xmlMods.Locations = append(xmlMods.Locations, xmlLoc)
+	}
+
 	// Related items
 	for _, r := range spoke.RelatedItem {
 		xmlRelated := XMLRelatedItem{Type: relatedItemTypeToString(r.Type)}
@@ -444,6 +569,65 @@ func spokeToXML(spoke *modsv1.Record) *XMLMods {
 	return xmlMods
 }
 
+// xmlDates converts MODS date elements to XML.
+func xmlDates(elements []*modsv1.DateElement) []XMLDate {
+	var dates []XMLDate
+	for _, d := range elements {
+		dates = append(dates, XMLDate{
+			Point:     datePointToString(d.Point),
+			Qualifier: dateQualifierToString(d.Qualifier),
+			Value:     d.Value,
+		})
+	}
+	return dates
+}
+
+func placeTermTypeToString(t modsv1.PlaceTermType) string {
+	switch t {
+	case modsv1.PlaceTermType_PLACE_TERM_TYPE_CODE:
+		return "code"
+	case modsv1.PlaceTermType_PLACE_TERM_TYPE_TEXT:
+		return "text"
+	default:
+		return ""
+	}
+}
+
+func languageTermTypeToString(t modsv1.LanguageTermType) string {
+	switch t {
+	case modsv1.LanguageTermType_LANGUAGE_TERM_TYPE_CODE:
+		return "code"
+	case modsv1.LanguageTermType_LANGUAGE_TERM_TYPE_TEXT:
+		return "text"
+	default:
+		return ""
+	}
+}
+
+func datePointToString(p modsv1.DatePoint) string {
+	switch p {
+	case modsv1.DatePoint_DATE_POINT_START:
+		return "start"
+	case modsv1.DatePoint_DATE_POINT_END:
+		return "end"
+	default:
+		return ""
+	}
+}
+
+func dateQualifierToString(q modsv1.DateQualifier) string {
+	switch q {
+	case modsv1.DateQualifier_DATE_QUALIFIER_APPROXIMATE:
+		return "approximate"
+	case modsv1.DateQualifier_DATE_QUALIFIER_INFERRED:
+		return "inferred"
+	case modsv1.DateQualifier_DATE_QUALIFIER_QUESTIONABLE:
+		return "questionable"
+	default:
+		return ""
+	}
+}
+
 func titleTypeToString(t modsv1.TitleType) string {
 	switch t {
 	case modsv1.TitleType_TITLE_TYPE_ALTERNATIVE:
@@ -527,19 +711,26 @@ type XMLMods struct {
 	Genre             []string             `xml:"genre,omitempty"`
 	OriginInfo        []XMLOriginInfo      `xml:"originInfo,omitempty"`
 	Languages         []XMLLanguage        `xml:"language,omitempty"`
-	Abstracts         []string             `xml:"abstract,omitempty"`
+	Abstracts         []XMLAbstract        `xml:"abstract,omitempty"`
 	Notes             []string             `xml:"note,omitempty"`
 	Subjects          []XMLSubject         `xml:"subject,omitempty"`
 	Identifiers       []XMLIdentifier      `xml:"identifier,omitempty"`
+	Locations         []XMLLocation        `xml:"location,omitempty"`
 	RelatedItems      []XMLRelatedItem     `xml:"relatedItem,omitempty"`
 	AccessConditions  []XMLAccessCondition `xml:"accessCondition,omitempty"`
 }
 
 type XMLTitleInfo struct {
 	Type  string `xml:"type,attr,omitempty"`
+	Lang  string `xml:"http://www.w3.org/XML/1998/namespace lang,attr,omitempty"`
 	Title string `xml:"title"`
 }
 
+type XMLAbstract struct {
+	Lang  string `xml:"http://www.w3.org/XML/1998/namespace lang,attr,omitempty"`
+	Value string `xml:",chardata"`
+}
+
 type XMLName struct {
 	Type         string        `xml:"type,attr,omitempty"`
 	NameParts    []XMLNamePart `xml:"namePart,omitempty"`
@@ -564,18 +755,26 @@ type XMLRoleTerm struct {
 type XMLOriginInfo struct {
 	Places         []XMLPlace `xml:"place,omitempty"`
 	Publishers     []string   `xml:"publisher,omitempty"`
-	DateIssued     []string   `xml:"dateIssued,omitempty"`
-	DateCreated    []string   `xml:"dateCreated,omitempty"`
-	CopyrightDates []string   `xml:"copyrightDate,omitempty"`
+	DateIssued     []XMLDate  `xml:"dateIssued,omitempty"`
+	DateCreated    []XMLDate  `xml:"dateCreated,omitempty"`
+	CopyrightDates []XMLDate  `xml:"copyrightDate,omitempty"`
 	Editions       []string   `xml:"edition,omitempty"`
 }
 
+type XMLDate struct {
+	Point     string `xml:"point,attr,omitempty"`
+	Qualifier string `xml:"qualifier,attr,omitempty"`
+	Value     string `xml:",chardata"`
+}
+
 type XMLPlace struct {
 	PlaceTerm XMLPlaceTerm `xml:"placeTerm"`
 }
 
 type XMLPlaceTerm struct {
-	Value string `xml:",chardata"`
+	Type      string `xml:"type,attr,omitempty"`
+	Authority string `xml:"authority,attr,omitempty"`
+	Value     string `xml:",chardata"`
 }
 
 type XMLLanguage struct {
@@ -583,7 +782,9 @@ type XMLLanguage struct {
 }
 
 type XMLLanguageTerm struct {
-	Value string `xml:",chardata"`
+	Type      string `xml:"type,attr,omitempty"`
+	Authority string `xml:"authority,attr,omitempty"`
+	Value     string `xml:",chardata"`
 }
 
 type XMLSubject struct {
@@ -595,6 +796,23 @@ type XMLIdentifier struct {
 	Value string `xml:",chardata"`
 }
 
+type XMLLocation struct {
+	PhysicalLocations []string          `xml:"physicalLocation,omitempty"`
+	ShelfLocators     []string          `xml:"shelfLocator,omitempty"`
+	HoldingSimple     *XMLHoldingSimple `xml:"holdingSimple,omitempty"`
+}
+
+// XMLHoldingSimple is left nil when a location has no copy information,
+// so no empty holdingSimple is written.
+type XMLHoldingSimple struct {
+	CopyInformation []XMLCopyInformation `xml:"copyInformation"`
+}
+
+type XMLCopyInformation struct {
+	Sublocation  string `xml:"subLocation,omitempty"`
+	ShelfLocator string `xml:"shelfLocator,omitempty"`
+}
+
 type XMLRelatedItem struct {
 	Type        string          `xml:"type,attr,omitempty"`
 	TitleInfo   []XMLTitleInfo  `xml:"titleInfo,omitempty"`
@@ -0,0 +1,35 @@
+package format
+
+import (
+	"strings"
+
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+)
+
+// OAIHeader is the <header> element of an OAI-PMH record. Parsers that
+// accept OAI-wrapped input decode it while scanning for their metadata
+// element and attach it to the record that follows.
+type OAIHeader struct {
+	Status     string   `xml:"status,attr"`
+	Identifier string   `xml:"identifier"`
+	Datestamp  string   `xml:"datestamp"`
+	SetSpecs   []string `xml:"setSpec"`
+}
+
+// ToHub converts the header for SourceInfo.Oai. A nil header yields nil.
+func (h *OAIHeader) ToHub() *hubv1.OaiHeader {
+	if h == nil {
+		return nil
+	}
+	oai := &hubv1.OaiHeader{
+		Identifier: strings.TrimSpace(h.Identifier),
+		Datestamp:  strings.TrimSpace(h.Datestamp),
+		Deleted:    h.Status == "deleted",
+	}
+	for _, s := range h.SetSpecs {
+		if s = strings.TrimSpace(s); s != "" {
+			oai.SetSpecs = append(oai.SetSpecs, s)
+		}
+	}
+	return oai
+}
@@ -0,0 +1,77 @@
+// Package ocfl implements an OCFL storage root laid out the way Fedora 6
+// persists its resources, so a migration can hand crosswalk output to a
+// Fedora 6 repository (or any OCFL tool) without an HTTP ingest.
+//
+// The serializer writes the storage root as a ZIP file that unzips to:
+//   - 0=ocfl_1.1, ocfl_layout.json, and the config of the
+//     0004-hashed-n-tuple-storage-layout extension, Fedora's default
+//     layout (sha256, three tuples of three)
+//   - one OCFL object per record, with a single version, v1, whose
+//     inventory.json (sha512) is copied into the version directory as the
+//     specification asks
+//
+// Each object is a Fedora container, info:fedora/<id>, where the id is the
+// record's local, PID, UUID, or node identifier, or its source ID, or
+// record-<n>. Its content holds:
+//   - fcr-container.nt, the record's RDF description as N-Triples, made by
+//     the rdf format (SerializeOptions.FieldMap names its ontology profile)
+//     with the container as subject
+//   - .fcrepo/fcr-root.json, the Fedora resource headers
+//   - each readable local file, as a binary child of the container, with
+//     its headers and an empty description. A record with files is an
+//     archival group, so its binaries live in the same OCFL object.
+//
+// Files that can't be read (remote URLs, missing paths) are left out with
+// a warning.
+package ocfl
+
+import (
+	"slices"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	"github.com/lehigh-university-libraries/crosswalk/format/rdf"
+)
+
+// Format implements an OCFL storage root of Fedora 6 objects.
+type Format struct{}
+
+// Ensure Format implements the interfaces
+var (
+	_ format.Format         = (*Format)(nil)
+	_ format.Serializer     = (*Format)(nil)
+	_ format.FieldDescriber = (*Format)(nil)
+)
+
+// Name returns the format identifier.
+func (f *Format) Name() string {
+	return "ocfl"
+}
+
+// Description returns a human-readable format description.
+func (f *Format) Description() string {
+	return "OCFL storage root of Fedora 6 objects (zipped)"
+}
+
+// Extensions returns file extensions associated with this format.
+func (f *Format) Extensions() []string {
+	return []string{"zip"}
+}
+
+// WrittenFields returns the hub record fields the RDF description covers,
+// and files.
+func (f *Format) WrittenFields(opts *format.SerializeOptions) []string {
+	fields := (&rdf.Format{}).WrittenFields(opts)
+	if slices.Contains(fields, "files") {
+		return fields
+	}
+	return append(fields, "files")
+}
+
+// CanParse returns false; OCFL is an output-only format.
+func (f *Format) CanParse(_ []byte) bool {
+	return false
+}
+
+func init() {
+	format.Register(&Format{})
+}
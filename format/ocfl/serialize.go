@@ -0,0 +1,404 @@
+package ocfl
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/md5"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	"github.com/lehigh-university-libraries/crosswalk/format/rdf"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+const (
+	// layoutExtension is the storage layout Fedora 6 uses by default.
+	layoutExtension = "0004-hashed-n-tuple-storage-layout"
+
+	// fedoraRoot is the ID of the repository root, the parent of every
+	// object.
+	fedoraRoot = "info:fedora"
+
+	// agentName is the user of every version and the creator of every
+	// resource.
+	agentName = "crosswalk"
+
+	interactionContainer   = "http://www.w3.org/ns/ldp#BasicContainer"
+	interactionBinary      = "http://www.w3.org/ns/ldp#NonRDFSource"
+	interactionDescription = "http://fedora.info/definitions/v4/repository#NonRdfSourceDescription"
+)
+
+// now is the version and resource creation time; tests replace it.
+var now = time.Now
+
+// objectTypes are the identifiers that name an object, in order of
+// preference.
+var objectTypes = []hubv1.IdentifierType{
+	hubv1.IdentifierType_IDENTIFIER_TYPE_LOCAL,
+	hubv1.IdentifierType_IDENTIFIER_TYPE_PID,
+	hubv1.IdentifierType_IDENTIFIER_TYPE_UUID,
+	hubv1.IdentifierType_IDENTIFIER_TYPE_NID,
+}
+
+// layoutConfig is the config.json of the storage layout extension.
+type layoutConfig struct {
+	ExtensionName   string `json:"extensionName"`
+	DigestAlgorithm string `json:"digestAlgorithm"`
+	TupleSize       int    `json:"tupleSize"`
+	NumberOfTuples  int    `json:"numberOfTuples"`
+	ShortObjectRoot bool   `json:"shortObjectRoot"`
+}
+
+// inventory is an object's inventory.json. Maps are written with sorted
+// keys, so the same object always has the same inventory.
+type inventory struct {
+	ID              string              `json:"id"`
+	Type            string              `json:"type"`
+	DigestAlgorithm string              `json:"digestAlgorithm"`
+	Head            string              `json:"head"`
+	Manifest        map[string][]string `json:"manifest"`
+	Versions        map[string]version  `json:"versions"`
+}
+
+// version is one entry of an inventory's versions.
+type version struct {
+	Created string              `json:"created"`
+	State   map[string][]string `json:"state"`
+	Message string              `json:"message"`
+	User    user                `json:"user"`
+}
+
+type user struct {
+	Name string `json:"name"`
+}
+
+// headers are the Fedora resource headers kept in an object's .fcrepo
+// directory, one file per resource.
+type headers struct {
+	ID               string   `json:"id"`
+	Parent           string   `json:"parent"`
+	ArchivalGroupID  string   `json:"archivalGroupId,omitempty"`
+	StateToken       string   `json:"stateToken"`
+	InteractionModel string   `json:"interactionModel"`
+	MimeType         string   `json:"mimeType,omitempty"`
+	Filename         string   `json:"filename,omitempty"`
+	ContentSize      int64    `json:"contentSize,omitempty"`
+	Digests          []string `json:"digests,omitempty"`
+	CreatedDate      string   `json:"createdDate"`
+	CreatedBy        string   `json:"createdBy"`
+	LastModifiedDate string   `json:"lastModifiedDate"`
+	LastModifiedBy   string   `json:"lastModifiedBy"`
+	ArchivalGroup    bool     `json:"archivalGroup"`
+	ObjectRoot       bool     `json:"objectRoot"`
+	Deleted          bool     `json:"deleted"`
+	ContentPath      string   `json:"contentPath"`
+	HeadersVersion   string   `json:"headersVersion"`
+}
+
+// object collects the content files of an OCFL object, by logical path.
+type object struct {
+	id    string
+	paths []string
+	files map[string][]byte
+}
+
+func (o *object) add(logicalPath string, data []byte) {
+	o.paths = append(o.paths, logicalPath)
+	o.files[logicalPath] = data
+}
+
+// addHeaders adds a resource's headers, in .fcrepo/<name>.json.
+func (o *object) addHeaders(name string, h *headers) error {
+	data, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding headers of %s: %w", h.ID, err)
+	}
+	o.add(path.Join(".fcrepo", name+".json"), append(data, '\n'))
+	return nil
+}
+
+// Serialize writes hub records as a zipped OCFL storage root, one object
+// per record.
+func (f *Format) Serialize(w io.Writer, records []*hubv1.Record, opts *format.SerializeOptions) error {
+	if opts == nil {
+		opts = format.NewSerializeOptions()
+	}
+	if len(records) == 0 {
+		return fmt.Errorf("an OCFL storage root needs at least one object: %w", format.ErrEmptyDocument)
+	}
+
+	zw := zip.NewWriter(w)
+	if err := writeEntry(zw, "0=ocfl_1.1", []byte("ocfl_1.1\n")); err != nil {
+		return err
+	}
+	if err := writeJSON(zw, "ocfl_layout.json", map[string]string{
+		"extension":   layoutExtension,
+		"description": "Hashed N-tuple Storage Layout",
+	}); err != nil {
+		return err
+	}
+	if err := writeJSON(zw, path.Join("extensions", layoutExtension, "config.json"), layoutConfig{
+		ExtensionName:   layoutExtension,
+		DigestAlgorithm: "sha256",
+		TupleSize:       3,
+		NumberOfTuples:  3,
+	}); err != nil {
+		return err
+	}
+
+	created := now().UTC().Format(time.RFC3339)
+	rdfOpts := *opts
+	rdfOpts.Variant = rdf.VariantNTriples
+	used := make(map[string]bool)
+	for i, record := range records {
+		id := objectID(record, i, used)
+		obj, err := buildObject(id, record, &rdfOpts, created)
+		if err != nil {
+			return fmt.Errorf("building object for record %d: %w", i, err)
+		}
+		if err := writeObject(zw, obj, created); err != nil {
+			return fmt.Errorf("writing object for record %d: %w", i, err)
+		}
+	}
+	return zw.Close()
+}
+
+// objectID returns the Fedora ID of the i'th record's object, one the
+// batch hasn't used yet.
+func objectID(record *hubv1.Record, i int, used map[string]bool) string {
+	name := ""
+	for _, t := range objectTypes {
+		if id := hub.GetIdentifier(record, t); id != nil && strings.TrimSpace(id.Value) != "" {
+			name = strings.TrimSpace(id.Value)
+			break
+		}
+	}
+	if name == "" {
+		name = strings.TrimSpace(record.GetSourceInfo().GetSourceId())
+	}
+	if name == "" {
+		name = fmt.Sprintf("record-%d", i+1)
+	}
+
+	id := fedoraRoot + "/" + url.PathEscape(name)
+	if used[id] {
+		base := id
+		for n := 2; used[id]; n++ {
+			id = fmt.Sprintf("%s-%d", base, n)
+		}
+		slog.Warn("renaming OCFL object: ID already used in this batch",
+			"title", record.Title, "id", base, "renamed", id)
+	}
+	used[id] = true
+	return id
+}
+
+// objectRoot returns the storage root path of an object under the hashed
+// n-tuple layout: three three-character tuples of the ID's sha256, then
+// the whole digest.
+func objectRoot(id string) string {
+	sum := sha256.Sum256([]byte(id))
+	h := hex.EncodeToString(sum[:])
+	return path.Join(h[0:3], h[3:6], h[6:9], h)
+}
+
+// buildObject lays out a record's Fedora resources as the content of its
+// object.
+func buildObject(id string, record *hubv1.Record, opts *format.SerializeOptions, created string) (*object, error) {
+	obj := &object{id: id, files: make(map[string][]byte)}
+
+	var buf bytes.Buffer
+	if err := (&rdf.Format{}).Serialize(&buf, []*hubv1.Record{record}, opts); err != nil {
+		return nil, fmt.Errorf("describing record: %w", err)
+	}
+	triples := withSubject(buf.String(), "<"+id+">")
+	obj.add("fcr-container.nt", []byte(triples))
+
+	binaries, err := addBinaries(obj, record, created)
+	if err != nil {
+		return nil, err
+	}
+	root := resourceHeaders(id, fedoraRoot, interactionContainer, "fcr-container.nt", []byte(triples), created)
+	root.ObjectRoot = true
+	root.ArchivalGroup = binaries > 0
+	if err := obj.addHeaders("fcr-root", root); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// withSubject replaces the subject of each N-Triples line with subject.
+// The rdf format writes one subject per record, an IRI or blank node that
+// has no spaces.
+func withSubject(ntriples, subject string) string {
+	var b strings.Builder
+	for _, line := range strings.SplitAfter(ntriples, "\n") {
+		if _, rest, ok := strings.Cut(line, " "); ok {
+			b.WriteString(subject + " " + rest)
+		}
+	}
+	return b.String()
+}
+
+// addBinaries adds the record's readable local files as binaries of the
+// container, each with its headers and an empty description, and returns
+// how many were added.
+func addBinaries(obj *object, record *hubv1.Record, created string) (int, error) {
+	seen := make(map[string]bool)
+	n := 0
+	for _, file := range record.Files {
+		data, err := os.ReadFile(file.Path)
+		if err != nil {
+			slog.Warn("leaving file out of OCFL object: not readable",
+				"title", record.Title, "path", file.Path, "err", err)
+			continue
+		}
+
+		name := file.Name
+		if name == "" {
+			name = filepath.Base(file.Path)
+		}
+		name = strings.NewReplacer("/", "_", "\\", "_").Replace(name)
+		if strings.HasPrefix(name, ".") || strings.Contains(name, "~fcr-") || name == "fcr-container.nt" {
+			name = "_" + name
+		}
+		if seen[name] {
+			slog.Warn("leaving file out of OCFL object: duplicate name",
+				"title", record.Title, "name", name)
+			continue
+		}
+		seen[name] = true
+
+		binaryID := obj.id + "/" + url.PathEscape(name)
+		obj.add(name, data)
+		h := resourceHeaders(binaryID, obj.id, interactionBinary, name, data, created)
+		h.ArchivalGroupID = obj.id
+		h.MimeType = file.MimeType
+		if h.MimeType == "" {
+			h.MimeType = mime.TypeByExtension(path.Ext(name))
+		}
+		if h.MimeType == "" {
+			h.MimeType = "application/octet-stream"
+		}
+		h.Filename = name
+		h.ContentSize = int64(len(data))
+		sum := sha512.Sum512(data)
+		h.Digests = []string{"urn:sha-512:" + hex.EncodeToString(sum[:])}
+		if err := obj.addHeaders(name, h); err != nil {
+			return n, err
+		}
+
+		descPath := name + "~fcr-desc.nt"
+		obj.add(descPath, nil)
+		desc := resourceHeaders(binaryID+"/fcr:metadata", binaryID, interactionDescription, descPath, nil, created)
+		desc.ArchivalGroupID = obj.id
+		if err := obj.addHeaders(name+"~fcr-desc", desc); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, nil
+}
+
+// resourceHeaders returns the headers of a resource whose content is data,
+// at contentPath.
+func resourceHeaders(id, parent, interaction, contentPath string, data []byte, created string) *headers {
+	sum := md5.Sum(data)
+	return &headers{
+		ID:               id,
+		Parent:           parent,
+		StateToken:       strings.ToUpper(hex.EncodeToString(sum[:])),
+		InteractionModel: interaction,
+		CreatedDate:      created,
+		CreatedBy:        agentName,
+		LastModifiedDate: created,
+		LastModifiedBy:   agentName,
+		ContentPath:      contentPath,
+		HeadersVersion:   "1.0",
+	}
+}
+
+// writeObject writes an object's declaration, its v1 content, and its
+// inventory, at the root and in v1.
+func writeObject(zw *zip.Writer, obj *object, created string) error {
+	root := objectRoot(obj.id)
+	inv := inventory{
+		ID:              obj.id,
+		Type:            "https://ocfl.io/1.1/spec/#inventory",
+		DigestAlgorithm: "sha512",
+		Head:            "v1",
+		Manifest:        make(map[string][]string),
+	}
+	state := make(map[string][]string)
+	for _, p := range obj.paths {
+		sum := sha512.Sum512(obj.files[p])
+		digest := hex.EncodeToString(sum[:])
+		inv.Manifest[digest] = append(inv.Manifest[digest], path.Join("v1", "content", p))
+		state[digest] = append(state[digest], p)
+	}
+	inv.Versions = map[string]version{"v1": {
+		Created: created,
+		State:   state,
+		Message: "Created by crosswalk",
+		User:    user{Name: agentName},
+	}}
+
+	if err := writeEntry(zw, path.Join(root, "0=ocfl_object_1.1"), []byte("ocfl_object_1.1\n")); err != nil {
+		return err
+	}
+	for _, p := range obj.paths {
+		if err := writeEntry(zw, path.Join(root, "v1", "content", p), obj.files[p]); err != nil {
+			return err
+		}
+	}
+
+	data, err := json.MarshalIndent(inv, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding inventory: %w", err)
+	}
+	data = append(data, '\n')
+	sum := sha512.Sum512(data)
+	sidecar := []byte(hex.EncodeToString(sum[:]) + " inventory.json\n")
+	for _, dir := range []string{root, path.Join(root, "v1")} {
+		if err := writeEntry(zw, path.Join(dir, "inventory.json"), data); err != nil {
+			return err
+		}
+		if err := writeEntry(zw, path.Join(dir, "inventory.json.sha512"), sidecar); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeJSON adds an indented JSON file to the archive.
+func writeJSON(zw *zip.Writer, name string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding %s: %w", name, err)
+	}
+	return writeEntry(zw, name, append(data, '\n'))
+}
+
+// writeEntry adds a file to the archive.
+func writeEntry(zw *zip.Writer, name string, data []byte) error {
+	fw, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate})
+	if err != nil {
+		return err
+	}
+	_, err = fw.Write(data)
+	return err
+}
@@ -0,0 +1,191 @@
+package ocfl
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+// readArchive returns the archive's entries by name.
+func readArchive(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("invalid zip: %v", err)
+	}
+	entries := make(map[string]string)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		entries[f.Name] = string(b)
+	}
+	return entries
+}
+
+func sha512Hex(s string) string {
+	sum := sha512.Sum512([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestSerialize(t *testing.T) {
+	now = func() time.Time { return time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC) }
+	defer func() { now = time.Now }()
+
+	dir := t.TempDir()
+	pdf := filepath.Join(dir, "thesis.pdf")
+	if err := os.WriteFile(pdf, []byte("%PDF-1.7"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	thesis := &hubv1.Record{
+		Title: "Thin film growth",
+		Identifiers: []*hubv1.Identifier{
+			hub.NewIdentifier("10.1234/films", hubv1.IdentifierType_IDENTIFIER_TYPE_DOI),
+			hub.NewIdentifier("etd-42", hubv1.IdentifierType_IDENTIFIER_TYPE_LOCAL),
+		},
+		Files: []*hubv1.File{
+			{Path: pdf},
+			{Path: filepath.Join(dir, "missing.tif")},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := (&Format{}).Serialize(&buf, []*hubv1.Record{thesis, {Title: "No identifiers"}}, nil); err != nil {
+		t.Fatal(err)
+	}
+	entries := readArchive(t, buf.Bytes())
+
+	if entries["0=ocfl_1.1"] != "ocfl_1.1\n" {
+		t.Errorf("storage root declaration = %q", entries["0=ocfl_1.1"])
+	}
+	if !strings.Contains(entries["ocfl_layout.json"], layoutExtension) {
+		t.Errorf("ocfl_layout.json = %s", entries["ocfl_layout.json"])
+	}
+	if _, ok := entries["extensions/"+layoutExtension+"/config.json"]; !ok {
+		t.Error("missing the layout extension config")
+	}
+
+	id := "info:fedora/etd-42"
+	root := objectRoot(id)
+	sum := sha256.Sum256([]byte(id))
+	h := hex.EncodeToString(sum[:])
+	if want := h[0:3] + "/" + h[3:6] + "/" + h[6:9] + "/" + h; root != want {
+		t.Errorf("object root = %q, want %q", root, want)
+	}
+	if entries[root+"/0=ocfl_object_1.1"] != "ocfl_object_1.1\n" {
+		t.Errorf("missing the object declaration under %s", root)
+	}
+
+	content := root + "/v1/content/"
+	triples := entries[content+"fcr-container.nt"]
+	if !strings.Contains(triples, `<info:fedora/etd-42> <http://purl.org/dc/terms/title> "Thin film growth" .`) {
+		t.Errorf("fcr-container.nt should describe the container:\n%s", triples)
+	}
+	if strings.Contains(triples, "<https://doi.org/10.1234/films> <") {
+		t.Errorf("the DOI is still the subject:\n%s", triples)
+	}
+	if entries[content+"thesis.pdf"] != "%PDF-1.7" {
+		t.Error("the readable file was not copied")
+	}
+	if _, ok := entries[content+"missing.tif"]; ok {
+		t.Error("the unreadable file should be left out")
+	}
+
+	var rootHeaders, pdfHeaders headers
+	if err := json.Unmarshal([]byte(entries[content+".fcrepo/fcr-root.json"]), &rootHeaders); err != nil {
+		t.Fatal(err)
+	}
+	if rootHeaders.ID != id || rootHeaders.Parent != fedoraRoot || !rootHeaders.ArchivalGroup || !rootHeaders.ObjectRoot {
+		t.Errorf("root headers = %+v", rootHeaders)
+	}
+	if err := json.Unmarshal([]byte(entries[content+".fcrepo/thesis.pdf.json"]), &pdfHeaders); err != nil {
+		t.Fatal(err)
+	}
+	if pdfHeaders.ID != id+"/thesis.pdf" || pdfHeaders.ArchivalGroupID != id || pdfHeaders.MimeType != "application/pdf" ||
+		pdfHeaders.Digests[0] != "urn:sha-512:"+sha512Hex("%PDF-1.7") {
+		t.Errorf("binary headers = %+v", pdfHeaders)
+	}
+	if _, ok := entries[content+".fcrepo/thesis.pdf~fcr-desc.json"]; !ok {
+		t.Error("missing the binary description headers")
+	}
+
+	invData := entries[root+"/inventory.json"]
+	if entries[root+"/inventory.json.sha512"] != sha512Hex(invData)+" inventory.json\n" {
+		t.Error("inventory sidecar does not match the inventory")
+	}
+	if entries[root+"/v1/inventory.json"] != invData {
+		t.Error("the version directory should hold a copy of the inventory")
+	}
+	var inv inventory
+	if err := json.Unmarshal([]byte(invData), &inv); err != nil {
+		t.Fatal(err)
+	}
+	if inv.ID != id || inv.Head != "v1" || inv.DigestAlgorithm != "sha512" {
+		t.Errorf("inventory = %+v", inv)
+	}
+	v1 := inv.Versions["v1"]
+	if v1.Created != "2026-03-01T12:00:00Z" || v1.User.Name != agentName {
+		t.Errorf("version = %+v", v1)
+	}
+	for digest, paths := range inv.Manifest {
+		for _, p := range paths {
+			if sha512Hex(entries[root+"/"+p]) != digest {
+				t.Errorf("manifest digest of %s does not match its content", p)
+			}
+		}
+	}
+	if got := v1.State[sha512Hex("%PDF-1.7")]; len(got) != 1 || got[0] != "thesis.pdf" {
+		t.Errorf("state of the PDF = %v", got)
+	}
+
+	second := objectRoot("info:fedora/record-2")
+	var secondHeaders headers
+	if err := json.Unmarshal([]byte(entries[second+"/v1/content/.fcrepo/fcr-root.json"]), &secondHeaders); err != nil {
+		t.Fatalf("record without identifiers should be record-2: %v", err)
+	}
+	if secondHeaders.ArchivalGroup {
+		t.Error("an object without binaries should not be an archival group")
+	}
+}
+
+func TestObjectIDsAreUnique(t *testing.T) {
+	used := make(map[string]bool)
+	record := &hubv1.Record{Identifiers: []*hubv1.Identifier{
+		hub.NewIdentifier("box 1/folder 2", hubv1.IdentifierType_IDENTIFIER_TYPE_LOCAL),
+	}}
+	first := objectID(record, 0, used)
+	second := objectID(record, 1, used)
+	if first != "info:fedora/box%201%2Ffolder%202" {
+		t.Errorf("first = %q", first)
+	}
+	if second != first+"-2" {
+		t.Errorf("second = %q, want %q", second, first+"-2")
+	}
+}
+
+func TestSerializeEmpty(t *testing.T) {
+	err := (&Format{}).Serialize(io.Discard, nil, nil)
+	if !errors.Is(err, format.ErrEmptyDocument) {
+		t.Errorf("expected ErrEmptyDocument, got %v", err)
+	}
+}
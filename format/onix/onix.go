@@ -0,0 +1,62 @@
+// Package onix provides a parser for ONIX for Books 3.0 publisher feeds.
+//
+// Each Product in an ONIXMessage becomes a hub record. Only reference-tag
+// messages are read; short-tag messages (<ONIXmessage>, <product>) and
+// ONIX 2.1 are rejected with an error. Products with notification type 05
+// (delete) carry no description and are skipped.
+//
+// The mapping covers:
+//   - ProductIdentifier: ISBN-13 and ISBN-10 as ISBNs, DOIs, and
+//     proprietary IDs as local identifiers
+//   - TitleDetail: the distinctive title (type 01) with its subtitle, other
+//     title types as alternative titles, and Collection titles as series
+//   - Contributor: names, ISNI and ORCID name identifiers, biographical
+//     notes, and affiliations, with ONIX role codes (List 17) mapped to
+//     MARC relators
+//   - Subject: BISAC and Thema headings (with their codes as source IDs),
+//     keywords, Dewey, LCC, LCSH, and MeSH
+//   - TextContent: descriptions as the abstract and tables of contents
+//   - PublishingDetail: publisher, city of publication, and publication
+//     and availability dates
+//   - ProductForm, Language, Extent (page count), and edition
+package onix
+
+import (
+	"bytes"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+)
+
+// Format implements the ONIX for Books 3.0 format.
+type Format struct{}
+
+// Ensure Format implements the interfaces
+var (
+	_ format.Format = (*Format)(nil)
+	_ format.Parser = (*Format)(nil)
+)
+
+// Name returns the format identifier.
+func (f *Format) Name() string {
+	return "onix"
+}
+
+// Description returns a human-readable format description.
+func (f *Format) Description() string {
+	return "ONIX for Books 3.0 product records (reference tags)"
+}
+
+// Extensions returns file extensions associated with this format.
+func (f *Format) Extensions() []string {
+	return []string{"xml", "onix"}
+}
+
+// CanParse returns true if the input looks like an ONIX 3.0 message.
+func (f *Format) CanParse(peek []byte) bool {
+	return bytes.Contains(peek, []byte("ns.editeur.org/onix/3")) ||
+		bytes.Contains(peek, []byte("<ONIXMessage"))
+}
+
+func init() {
+	format.Register(&Format{})
+}
@@ -0,0 +1,443 @@
+package onix
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/helpers"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+	"google.golang.org/protobuf/proto"
+)
+
+// contributorRoles maps ONIX contributor role codes (List 17) to MARC
+// relator codes. Unlisted codes are kept as the role with no code.
+var contributorRoles = map[string]string{
+	"A01": "aut", // By (author)
+	"A02": "ctb", // With
+	"A06": "cmp", // By (composer)
+	"A07": "art", // By (artist)
+	"A08": "pht", // By (photographer)
+	"A09": "cre", // Created by
+	"A11": "dsr", // Designed by
+	"A12": "ill", // Illustrated by
+	"A13": "pht", // Photographs by
+	"A15": "aui", // Preface by
+	"A19": "aft", // Afterword by
+	"A20": "ann", // Notes by
+	"A21": "ann", // Commentaries by
+	"A22": "aft", // Epilogue by
+	"A23": "aui", // Foreword by
+	"A24": "aui", // Introduction by
+	"B01": "edt", // Edited by
+	"B05": "adp", // Adapted by
+	"B06": "trl", // Translated by
+	"B09": "edt", // Series edited by
+	"B11": "edt", // Editor-in-chief
+	"B12": "edt", // Guest editor
+	"B13": "edt", // Volume editor
+	"C01": "com", // Compiled by
+	"D01": "pro", // Producer
+	"D02": "drt", // Director
+	"E07": "nrt", // Read by
+	"Z99": "oth", // Other
+}
+
+// subjectSchemes maps ONIX subject scheme identifiers (List 27) to hub
+// vocabularies. Thema qualifiers (94–99) are Thema too.
+var subjectSchemes = map[string]hubv1.SubjectVocabulary{
+	"01": hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_DDC,
+	"02": hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_DDC,
+	"03": hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LCC,
+	"04": hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LCSH,
+	"06": hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_MESH,
+	"10": hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_BISAC,
+	"20": hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_KEYWORDS,
+	"93": hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_THEMA,
+	"94": hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_THEMA,
+	"95": hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_THEMA,
+	"96": hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_THEMA,
+	"97": hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_THEMA,
+	"98": hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_THEMA,
+	"99": hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_THEMA,
+}
+
+// Parse reads an ONIX 3.0 message and returns a record per product.
+func (f *Format) Parse(r io.Reader, opts *format.ParseOptions) ([]*hubv1.Record, error) {
+	if opts == nil {
+		opts = format.NewParseOptions()
+	}
+
+	var msg Message
+	if err := xml.NewDecoder(r).Decode(&msg); err != nil {
+		if strings.Contains(err.Error(), "<ONIXmessage>") {
+			return nil, fmt.Errorf("short-tag ONIX is not supported; convert the message to reference tags")
+		}
+		return nil, fmt.Errorf("parsing ONIX XML: %w", err)
+	}
+	if strings.HasPrefix(msg.Release, "2") {
+		return nil, fmt.Errorf("ONIX release %s is not supported; only ONIX 3.0 is", msg.Release)
+	}
+
+	var records []*hubv1.Record
+	for i := range msg.Products {
+		p := &msg.Products[i]
+		if p.NotificationType == "05" {
+			continue
+		}
+		records = append(records, parseProduct(p, msg.Release, opts))
+	}
+	return records, nil
+}
+
+func parseProduct(p *Product, release string, opts *format.ParseOptions) *hubv1.Record {
+	record := hub.NewRecord()
+	dd := &p.DescriptiveDetail
+
+	parseTitles(record, dd.TitleDetails)
+	for _, c := range dd.Collections {
+		if title := collectionTitle(c); title != "" {
+			record.Relations = append(record.Relations,
+				hub.NewRelation(hubv1.RelationType_RELATION_TYPE_IN_SERIES, title))
+		}
+	}
+
+	parseIdentifiers(record, p.ProductIdentifiers)
+
+	contributors := append([]Contributor(nil), dd.Contributors...)
+	sort.SliceStable(contributors, func(i, j int) bool {
+		a, _ := strconv.Atoi(contributors[i].SequenceNumber)
+		b, _ := strconv.Atoi(contributors[j].SequenceNumber)
+		return a < b
+	})
+	for _, c := range contributors {
+		record.Contributors = append(record.Contributors, parseContributors(c, opts)...)
+	}
+
+	parseSubjects(record, dd.Subjects)
+
+	for _, tc := range p.CollateralDetail.TextContents {
+		if len(tc.Texts) == 0 {
+			continue
+		}
+		text := opts.CleanText(tc.Texts[0].Value)
+		switch tc.TextType {
+		case "03", "02":
+			// Prefer the full description over the short one
+			if record.Abstract == "" || tc.TextType == "03" {
+				record.Abstract = text
+			}
+		case "04":
+			record.TableOfContents = text
+		}
+	}
+
+	parsePublishing(record, &p.PublishingDetail)
+
+	for _, l := range dd.Languages {
+		if l.LanguageRole == "01" && record.Language == "" {
+			record.Language = strings.TrimSpace(l.LanguageCode)
+		}
+	}
+	for _, e := range dd.Extents {
+		// Main content page count, or total numbered pages, in pages
+		if (e.ExtentType == "00" || e.ExtentType == "11") && e.ExtentUnit == "03" && record.PageCount == 0 {
+			if n, err := strconv.Atoi(strings.TrimSpace(e.ExtentValue)); err == nil {
+				record.PageCount = int32(n)
+			}
+		}
+	}
+	record.Edition = strings.TrimSpace(dd.EditionStatement)
+	if record.Edition == "" && dd.EditionNumber != "" {
+		record.Edition = strings.TrimSpace(dd.EditionNumber)
+	}
+
+	record.ResourceType = &hubv1.ResourceType{
+		Type:       productFormType(dd.ProductForm),
+		Original:   dd.ProductForm,
+		Vocabulary: "onix",
+	}
+
+	record.SourceInfo = &hubv1.SourceInfo{
+		Format:        "onix",
+		FormatVersion: release,
+		SourceId:      strings.TrimSpace(p.RecordReference),
+	}
+	return record
+}
+
+// parseTitles sets the title from the distinctive title (type 01) and
+// keeps other title types as alternative titles.
+func parseTitles(record *hubv1.Record, details []TitleDetail) {
+	for _, td := range details {
+		for _, te := range td.TitleElements {
+			if te.TitleElementLevel != "" && te.TitleElementLevel != "01" {
+				continue
+			}
+			title := te.Title()
+			if sub := strings.TrimSpace(te.Subtitle); sub != "" && title != "" {
+				title += ": " + sub
+			}
+			if title == "" {
+				continue
+			}
+			if td.TitleType == "01" && record.Title == "" {
+				record.Title = title
+			} else {
+				record.AltTitle = append(record.AltTitle, title)
+			}
+		}
+	}
+}
+
+// collectionTitle returns a collection's distinctive title.
+func collectionTitle(c Collection) string {
+	for _, td := range c.TitleDetails {
+		if td.TitleType != "01" {
+			continue
+		}
+		for _, te := range td.TitleElements {
+			if te.TitleElementLevel != "02" && te.TitleElementLevel != "" {
+				continue
+			}
+			return te.Title()
+		}
+	}
+	return ""
+}
+
+func parseIdentifiers(record *hubv1.Record, ids []ProductIdentifier) {
+	isbns := make(map[string]bool)
+	for _, id := range ids {
+		if id.ProductIDType == "15" || id.ProductIDType == "02" {
+			isbns[strings.TrimSpace(id.IDValue)] = true
+		}
+	}
+	for _, id := range ids {
+		value := strings.TrimSpace(id.IDValue)
+		if value == "" {
+			continue
+		}
+		switch id.ProductIDType {
+		case "15", "02":
+			record.Identifiers = append(record.Identifiers,
+				hub.NewIdentifier(value, hubv1.IdentifierType_IDENTIFIER_TYPE_ISBN))
+		case "06":
+			record.Identifiers = append(record.Identifiers,
+				hub.NewIdentifier(value, hubv1.IdentifierType_IDENTIFIER_TYPE_DOI))
+		case "03":
+			// A GTIN-13 is usually the ISBN-13 again
+			if !isbns[value] {
+				record.Identifiers = append(record.Identifiers,
+					hub.NewIdentifier(value, hubv1.IdentifierType_IDENTIFIER_TYPE_LOCAL))
+			}
+		case "01":
+			record.Identifiers = append(record.Identifiers,
+				hub.NewIdentifier(value, hubv1.IdentifierType_IDENTIFIER_TYPE_LOCAL))
+		}
+	}
+}
+
+// parseContributors returns a hub contributor for each of c's roles.
+func parseContributors(c Contributor, opts *format.ParseOptions) []*hubv1.Contributor {
+	base := &hubv1.Contributor{Type: hubv1.ContributorType_CONTRIBUTOR_TYPE_PERSON}
+	switch {
+	case c.CorporateName != "" || c.CorporateNameInverted != "":
+		base.Type = hubv1.ContributorType_CONTRIBUTOR_TYPE_ORGANIZATION
+		base.Name = strings.TrimSpace(c.CorporateName)
+		if base.Name == "" {
+			base.Name = strings.TrimSpace(c.CorporateNameInverted)
+		}
+	case c.KeyNames != "":
+		base.ParsedName = &hubv1.ParsedName{
+			Family: strings.TrimSpace(c.KeyNames),
+			Given:  strings.TrimSpace(c.NamesBeforeKey),
+			Suffix: strings.TrimSpace(c.SuffixToKey),
+		}
+		base.Name = hub.ParsedNameInverted(base.ParsedName)
+	case c.PersonNameInverted != "":
+		base.Name = strings.TrimSpace(c.PersonNameInverted)
+		base.ParsedName = helpers.ParseName(base.Name)
+	case c.PersonName != "":
+		base.ParsedName = helpers.ParseName(strings.TrimSpace(c.PersonName))
+		base.Name = hub.ParsedNameInverted(base.ParsedName)
+	default:
+		// Unnamed persons ("Anonymous", "Various") have nothing to carry over
+		return nil
+	}
+
+	for _, id := range c.NameIdentifiers {
+		value := strings.TrimSpace(id.IDValue)
+		switch id.NameIDType {
+		case "21":
+			value = strings.TrimPrefix(strings.TrimPrefix(value, "https://orcid.org/"), "http://orcid.org/")
+			base.Identifiers = append(base.Identifiers,
+				&hubv1.Identifier{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_ORCID, Value: value})
+		case "16":
+			base.Identifiers = append(base.Identifiers,
+				&hubv1.Identifier{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_ISNI, Value: value})
+		}
+	}
+	if len(c.BiographicalNotes) > 0 {
+		base.Description = opts.CleanText(c.BiographicalNotes[0])
+	}
+	for _, a := range c.ProfessionalAffiliation {
+		if name := strings.TrimSpace(a.Affiliation); name != "" {
+			base.Affiliations = append(base.Affiliations, &hubv1.Affiliation{Name: name})
+		}
+	}
+
+	roles := c.ContributorRoles
+	if len(roles) == 0 {
+		roles = []string{""}
+	}
+	out := make([]*hubv1.Contributor, 0, len(roles))
+	for i, role := range roles {
+		contrib := base
+		if i > 0 {
+			contrib = proto.Clone(base).(*hubv1.Contributor)
+		}
+		role = strings.TrimSpace(role)
+		if code, ok := contributorRoles[role]; ok {
+			contrib.RoleCode = "relators:" + code
+			contrib.Role = helpers.RelatorLabel(code)
+		} else {
+			contrib.Role = role
+		}
+		out = append(out, contrib)
+	}
+	return out
+}
+
+// parseSubjects maps subjects, main subjects first.
+func parseSubjects(record *hubv1.Record, subjects []Subject) {
+	sorted := append([]Subject(nil), subjects...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].MainSubject != nil && sorted[j].MainSubject == nil
+	})
+	for _, s := range sorted {
+		vocab, ok := subjectSchemes[s.SubjectSchemeIdentifier]
+		if !ok {
+			vocab = hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LOCAL
+		}
+		code := strings.TrimSpace(s.SubjectCode)
+
+		var headings []string
+		for _, h := range s.SubjectHeadingText {
+			if vocab == hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_KEYWORDS {
+				// Keywords are sent as one semicolon-separated heading
+				headings = append(headings, strings.Split(h, ";")...)
+			} else {
+				headings = append(headings, h)
+			}
+		}
+		if len(headings) == 0 && code != "" {
+			headings = []string{code}
+		}
+
+		for _, h := range headings {
+			h = strings.TrimSpace(h)
+			if h == "" {
+				continue
+			}
+			subject := &hubv1.Subject{
+				Value:      h,
+				Vocabulary: vocab,
+				Type:       hubv1.SubjectType_SUBJECT_TYPE_TOPIC,
+			}
+			if vocab != hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_KEYWORDS {
+				subject.SourceId = code
+			}
+			switch s.SubjectSchemeIdentifier {
+			case "94":
+				subject.Type = hubv1.SubjectType_SUBJECT_TYPE_GEOGRAPHIC
+			case "96":
+				subject.Type = hubv1.SubjectType_SUBJECT_TYPE_TEMPORAL
+			}
+			record.Subjects = append(record.Subjects, subject)
+		}
+	}
+}
+
+func parsePublishing(record *hubv1.Record, pd *PublishingDetail) {
+	for _, p := range pd.Publishers {
+		if (p.PublishingRole == "01" || p.PublishingRole == "") && record.Publisher == "" {
+			record.Publisher = strings.TrimSpace(p.PublisherName)
+		}
+	}
+	if record.Publisher == "" && len(pd.Imprints) > 0 {
+		record.Publisher = strings.TrimSpace(pd.Imprints[0].ImprintName)
+	}
+	if len(pd.CityOfPublication) > 0 {
+		record.PlacePublished = strings.TrimSpace(pd.CityOfPublication[0])
+	}
+
+	for _, d := range pd.PublishingDates {
+		var dateType hubv1.DateType
+		switch d.PublishingDateRole {
+		case "01":
+			dateType = hubv1.DateType_DATE_TYPE_ISSUED
+		case "02":
+			dateType = hubv1.DateType_DATE_TYPE_AVAILABLE
+		default:
+			continue
+		}
+		if dv := parseDate(d.Date, dateType); dv != nil {
+			record.Dates = append(record.Dates, dv)
+		}
+	}
+}
+
+// parseDate reads an ONIX date in format 00 (YYYYMMDD), 01 (YYYYMM), or
+// 05 (YYYY); the format is taken from the value's length when the
+// dateformat attribute is absent.
+func parseDate(d Date, dateType hubv1.DateType) *hubv1.DateValue {
+	value := strings.TrimSpace(d.Value)
+	year, err := strconv.Atoi(value[:min(4, len(value))])
+	if err != nil || len(value) < 4 {
+		return nil
+	}
+	var dv *hubv1.DateValue
+	switch {
+	case len(value) >= 8 && (d.Format == "" || d.Format == "00"):
+		month, err1 := strconv.Atoi(value[4:6])
+		day, err2 := strconv.Atoi(value[6:8])
+		if err1 != nil || err2 != nil {
+			return nil
+		}
+		dv = hub.NewDateFromYMD(int32(year), int32(month), int32(day), dateType)
+	case len(value) >= 6 && (d.Format == "" || d.Format == "01"):
+		month, err := strconv.Atoi(value[4:6])
+		if err != nil {
+			return nil
+		}
+		dv = hub.NewDateFromYearMonth(int32(year), int32(month), dateType)
+	default:
+		dv = hub.NewDateFromYear(int32(year), dateType)
+	}
+	dv.Raw = value
+	return dv
+}
+
+// productFormType maps an ONIX product form (List 150) to a resource type.
+func productFormType(form string) hubv1.ResourceTypeValue {
+	if form == "" {
+		return hubv1.ResourceTypeValue_RESOURCE_TYPE_UNSPECIFIED
+	}
+	switch form[0] {
+	case 'B', 'E':
+		return hubv1.ResourceTypeValue_RESOURCE_TYPE_BOOK
+	case 'A':
+		return hubv1.ResourceTypeValue_RESOURCE_TYPE_AUDIO
+	case 'V':
+		return hubv1.ResourceTypeValue_RESOURCE_TYPE_VIDEO
+	case 'C':
+		return hubv1.ResourceTypeValue_RESOURCE_TYPE_MAP
+	}
+	return hubv1.ResourceTypeValue_RESOURCE_TYPE_OTHER
+}
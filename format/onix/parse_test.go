@@ -0,0 +1,171 @@
+package onix
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+func parseFile(t *testing.T, path string, opts *format.ParseOptions) []*hubv1.Record {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	records, err := (&Format{}).Parse(f, opts)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	return records
+}
+
+func TestParse(t *testing.T) {
+	records := parseFile(t, "testdata/onix3.xml", nil)
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records (delete notification skipped), got %d", len(records))
+	}
+
+	book := records[0]
+	if book.Title != "The Steel City: Bethlehem and Its Furnaces" {
+		t.Errorf("Title: got %q", book.Title)
+	}
+	if len(book.AltTitle) != 1 || book.AltTitle[0] != "Steel City" {
+		t.Errorf("AltTitle: got %v", book.AltTitle)
+	}
+	if rels := hub.GetRelationsByType(book, hubv1.RelationType_RELATION_TYPE_IN_SERIES); len(rels) != 1 ||
+		rels[0].TargetTitle != "Perspectives on Industrial History" {
+		t.Errorf("series: got %v", rels)
+	}
+
+	if id := hub.GetIdentifier(book, hubv1.IdentifierType_IDENTIFIER_TYPE_ISBN); id == nil || id.Value != "9781611463521" {
+		t.Errorf("ISBN: got %v", id)
+	}
+	if id := hub.GetDOI(book); id == nil || id.Value != "10.5555/lup.3521" {
+		t.Errorf("DOI: got %v", id)
+	}
+	if len(book.Identifiers) != 3 {
+		t.Errorf("GTIN duplicating the ISBN should be dropped, got %v", book.Identifiers)
+	}
+
+	if len(book.Contributors) != 4 {
+		t.Fatalf("expected 4 contributor roles, got %v", book.Contributors)
+	}
+	author := book.Contributors[0]
+	if author.Name != "Smith, Jane Q." || author.RoleCode != "relators:aut" || author.ParsedName.GetFamily() != "Smith" {
+		t.Errorf("author: got %v", author)
+	}
+	if author.Description != "Jane Smith teaches history." {
+		t.Errorf("biographical note: got %q", author.Description)
+	}
+	if len(author.Identifiers) != 2 || author.Identifiers[0].Value != "0000-0002-1825-0097" ||
+		author.Identifiers[1].Type != hubv1.IdentifierType_IDENTIFIER_TYPE_ISNI {
+		t.Errorf("name identifiers: got %v", author.Identifiers)
+	}
+	if len(author.Affiliations) != 1 || author.Affiliations[0].Name != "Lehigh University" {
+		t.Errorf("Affiliations: got %v", author.Affiliations)
+	}
+	if illus := book.Contributors[1]; illus.Name != author.Name || illus.RoleCode != "relators:ill" {
+		t.Errorf("second role: got %v", illus)
+	}
+	if trl := book.Contributors[2]; trl.Name != "Curie, Marie" || trl.RoleCode != "relators:trl" {
+		t.Errorf("translator: got %v", trl)
+	}
+	if org := book.Contributors[3]; org.Type != hubv1.ContributorType_CONTRIBUTOR_TYPE_ORGANIZATION || org.RoleCode != "relators:cre" {
+		t.Errorf("corporate contributor: got %v", org)
+	}
+
+	if len(book.Subjects) != 6 {
+		t.Fatalf("expected 6 subjects, got %v", book.Subjects)
+	}
+	if s := book.Subjects[0]; s.Vocabulary != hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_BISAC || s.SourceId != "HIS036060" ||
+		!strings.HasPrefix(s.Value, "HISTORY / United States / State & Local") {
+		t.Errorf("main BISAC subject should come first: got %v", s)
+	}
+	if s := book.Subjects[1]; s.Vocabulary != hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_THEMA || s.Value != "History of the Americas" || s.SourceId != "NHK" {
+		t.Errorf("Thema subject: got %v", s)
+	}
+	if s := book.Subjects[2]; s.Vocabulary != hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_THEMA || s.Type != hubv1.SubjectType_SUBJECT_TYPE_GEOGRAPHIC {
+		t.Errorf("Thema place qualifier: got %v", s)
+	}
+	if kw := hub.GetKeywords(book); len(kw) != 3 || kw[1].Value != "blast furnaces" {
+		t.Errorf("keywords: got %v", kw)
+	}
+
+	if book.Abstract != "How a river town became the Steel City." {
+		t.Errorf("Abstract: got %q", book.Abstract)
+	}
+	if !strings.Contains(book.TableOfContents, "Furnaces") || strings.Contains(book.TableOfContents, "<li>") {
+		t.Errorf("TableOfContents: got %q", book.TableOfContents)
+	}
+	if book.Publisher != "Lehigh University Press" || book.PlacePublished != "Bethlehem, PA" {
+		t.Errorf("Publisher/Place: got %q, %q", book.Publisher, book.PlacePublished)
+	}
+	if d := hub.GetDateIssued(book); d == nil || d.Year != 2024 || d.Month != 3 || d.Day != 15 {
+		t.Errorf("issued: got %v", d)
+	}
+	if d := hub.GetDate(book, hubv1.DateType_DATE_TYPE_AVAILABLE); d == nil || d.Month != 4 || d.Day != 0 {
+		t.Errorf("available: got %v", d)
+	}
+	if book.Language != "eng" || book.PageCount != 312 || book.Edition != "Second edition, revised" {
+		t.Errorf("Language/PageCount/Edition: got %q, %d, %q", book.Language, book.PageCount, book.Edition)
+	}
+	if book.ResourceType.Type != hubv1.ResourceTypeValue_RESOURCE_TYPE_BOOK || book.ResourceType.Original != "BB" {
+		t.Errorf("ResourceType: got %v", book.ResourceType)
+	}
+	if si := book.SourceInfo; si.Format != "onix" || si.FormatVersion != "3.0" || si.SourceId != "com.lehighpress.9781611463521" {
+		t.Errorf("SourceInfo: got %v", si)
+	}
+
+	audio := records[1]
+	if audio.ResourceType.Type != hubv1.ResourceTypeValue_RESOURCE_TYPE_AUDIO {
+		t.Errorf("audiobook type: got %v", audio.ResourceType)
+	}
+	if len(audio.Contributors) != 1 || audio.Contributors[0].Name != "Jones, Bob" || audio.Contributors[0].RoleCode != "relators:nrt" {
+		t.Errorf("narrator: got %v", audio.Contributors)
+	}
+	if d := hub.GetDateIssued(audio); d == nil || d.Year != 2023 || d.Month != 0 {
+		t.Errorf("year-only date: got %v", d)
+	}
+}
+
+func TestParseKeepHTML(t *testing.T) {
+	records := parseFile(t, "testdata/onix3.xml", &format.ParseOptions{})
+	if got := records[0].Abstract; !strings.Contains(got, "<strong>the Steel City</strong>") {
+		t.Errorf("Abstract should keep markup: got %q", got)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := map[string]string{
+		"empty":     "",
+		"short tag": `<ONIXmessage release="3.0" xmlns="http://ns.editeur.org/onix/3.0/short"><product><a001>x</a001></product></ONIXmessage>`,
+		"onix 2.1":  `<ONIXMessage release="2.1"><Product><RecordReference>x</RecordReference></Product></ONIXMessage>`,
+	}
+	for name, input := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := (&Format{}).Parse(strings.NewReader(input), nil); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestCanParse(t *testing.T) {
+	f := &Format{}
+	data, err := os.ReadFile("testdata/onix3.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !f.CanParse(data) {
+		t.Error("expected CanParse for ONIX 3.0")
+	}
+	if f.CanParse([]byte(`<mods xmlns="http://www.loc.gov/mods/v3"/>`)) {
+		t.Error("unexpected CanParse for MODS")
+	}
+}
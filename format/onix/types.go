@@ -0,0 +1,224 @@
+package onix
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+// Message is an ONIX for Books 3.0 message using reference tags.
+type Message struct {
+	XMLName  xml.Name  `xml:"ONIXMessage"`
+	Release  string    `xml:"release,attr"`
+	Products []Product `xml:"Product"`
+}
+
+// Product is one ONIX product record (block 1–4 composites used here).
+type Product struct {
+	RecordReference    string              `xml:"RecordReference"`
+	NotificationType   string              `xml:"NotificationType"`
+	ProductIdentifiers []ProductIdentifier `xml:"ProductIdentifier"`
+	DescriptiveDetail  DescriptiveDetail   `xml:"DescriptiveDetail"`
+	CollateralDetail   CollateralDetail    `xml:"CollateralDetail"`
+	PublishingDetail   PublishingDetail    `xml:"PublishingDetail"`
+}
+
+// ProductIdentifier is a product ID such as an ISBN-13 (List 5).
+type ProductIdentifier struct {
+	ProductIDType string `xml:"ProductIDType"`
+	IDTypeName    string `xml:"IDTypeName"`
+	IDValue       string `xml:"IDValue"`
+}
+
+// DescriptiveDetail is block 1 of a product record.
+type DescriptiveDetail struct {
+	ProductComposition string        `xml:"ProductComposition"`
+	ProductForm        string        `xml:"ProductForm"`
+	Collections        []Collection  `xml:"Collection"`
+	TitleDetails       []TitleDetail `xml:"TitleDetail"`
+	Contributors       []Contributor `xml:"Contributor"`
+	EditionNumber      string        `xml:"EditionNumber"`
+	EditionStatement   string        `xml:"EditionStatement"`
+	Languages          []Language    `xml:"Language"`
+	Extents            []Extent      `xml:"Extent"`
+	Subjects           []Subject     `xml:"Subject"`
+}
+
+// Collection is a series or set the product belongs to.
+type Collection struct {
+	CollectionType string        `xml:"CollectionType"`
+	TitleDetails   []TitleDetail `xml:"TitleDetail"`
+}
+
+// TitleDetail is a title of a given type (List 15); 01 is the distinctive
+// title of the product.
+type TitleDetail struct {
+	TitleType     string         `xml:"TitleType"`
+	TitleElements []TitleElement `xml:"TitleElement"`
+}
+
+// TitleElement is one level of a title (List 149): 01 product, 02
+// collection, 03 subcollection.
+type TitleElement struct {
+	TitleElementLevel  string `xml:"TitleElementLevel"`
+	PartNumber         string `xml:"PartNumber"`
+	TitleText          string `xml:"TitleText"`
+	TitlePrefix        string `xml:"TitlePrefix"`
+	TitleWithoutPrefix string `xml:"TitleWithoutPrefix"`
+	Subtitle           string `xml:"Subtitle"`
+}
+
+// Title returns the element's full title, reassembling TitlePrefix and
+// TitleWithoutPrefix when TitleText is absent.
+func (e TitleElement) Title() string {
+	title := strings.TrimSpace(e.TitleText)
+	if title == "" {
+		title = strings.TrimSpace(strings.TrimSpace(e.TitlePrefix) + " " + strings.TrimSpace(e.TitleWithoutPrefix))
+	}
+	return title
+}
+
+// Contributor is a person or corporate body with one or more roles
+// (List 17).
+type Contributor struct {
+	SequenceNumber          string                    `xml:"SequenceNumber"`
+	ContributorRoles        []string                  `xml:"ContributorRole"`
+	NameIdentifiers         []NameIdentifier          `xml:"NameIdentifier"`
+	PersonName              string                    `xml:"PersonName"`
+	PersonNameInverted      string                    `xml:"PersonNameInverted"`
+	NamesBeforeKey          string                    `xml:"NamesBeforeKey"`
+	KeyNames                string                    `xml:"KeyNames"`
+	SuffixToKey             string                    `xml:"SuffixToKey"`
+	CorporateName           string                    `xml:"CorporateName"`
+	CorporateNameInverted   string                    `xml:"CorporateNameInverted"`
+	BiographicalNotes       []string                  `xml:"BiographicalNote"`
+	ProfessionalAffiliation []ProfessionalAffiliation `xml:"ProfessionalAffiliation"`
+	UnnamedPersons          string                    `xml:"UnnamedPersons"`
+}
+
+// NameIdentifier is a party identifier (List 44), e.g. ISNI or ORCID.
+type NameIdentifier struct {
+	NameIDType string `xml:"NameIDType"`
+	IDTypeName string `xml:"IDTypeName"`
+	IDValue    string `xml:"IDValue"`
+}
+
+// ProfessionalAffiliation is a contributor's position and employer.
+type ProfessionalAffiliation struct {
+	ProfessionalPosition string `xml:"ProfessionalPosition"`
+	Affiliation          string `xml:"Affiliation"`
+}
+
+// Language is a language of the product with its role (List 22).
+type Language struct {
+	LanguageRole string `xml:"LanguageRole"`
+	LanguageCode string `xml:"LanguageCode"`
+}
+
+// Extent is a measured extent such as page count (List 23, units List 24).
+type Extent struct {
+	ExtentType  string `xml:"ExtentType"`
+	ExtentValue string `xml:"ExtentValue"`
+	ExtentUnit  string `xml:"ExtentUnit"`
+}
+
+// Subject is a subject code or heading in a scheme (List 26).
+type Subject struct {
+	MainSubject             *struct{} `xml:"MainSubject"`
+	SubjectSchemeIdentifier string    `xml:"SubjectSchemeIdentifier"`
+	SubjectSchemeName       string    `xml:"SubjectSchemeName"`
+	SubjectCode             string    `xml:"SubjectCode"`
+	SubjectHeadingText      []string  `xml:"SubjectHeadingText"`
+}
+
+// CollateralDetail is block 2 of a product record.
+type CollateralDetail struct {
+	TextContents []TextContent `xml:"TextContent"`
+}
+
+// TextContent is a descriptive text of a given type (List 153).
+type TextContent struct {
+	TextType string `xml:"TextType"`
+	Texts    []Text `xml:"Text"`
+}
+
+// PublishingDetail is block 4 of a product record.
+type PublishingDetail struct {
+	Imprints          []Imprint        `xml:"Imprint"`
+	Publishers        []Publisher      `xml:"Publisher"`
+	CityOfPublication []string         `xml:"CityOfPublication"`
+	PublishingDates   []PublishingDate `xml:"PublishingDate"`
+}
+
+// Imprint is the brand under which the product is published.
+type Imprint struct {
+	ImprintName string `xml:"ImprintName"`
+}
+
+// Publisher is a publishing party with its role (List 45).
+type Publisher struct {
+	PublishingRole string `xml:"PublishingRole"`
+	PublisherName  string `xml:"PublisherName"`
+}
+
+// PublishingDate is a date with its role (List 163).
+type PublishingDate struct {
+	PublishingDateRole string `xml:"PublishingDateRole"`
+	Date               Date   `xml:"Date"`
+}
+
+// Date is an ONIX date, YYYYMMDD unless the dateformat attribute says
+// otherwise (List 55).
+type Date struct {
+	Format string `xml:"dateformat,attr"`
+	Value  string `xml:",chardata"`
+}
+
+// Text is ONIX text content. Texts with textformat 02 or 05 (HTML or
+// XHTML) carry markup either as child elements or escaped in the
+// character data; both are kept as HTML so the parser can strip it.
+type Text struct {
+	Format string
+	Value  string
+}
+
+// UnmarshalXML rebuilds the element's content, re-serializing any child
+// markup rather than dropping it.
+func (t *Text) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, attr := range start.Attr {
+		if attr.Name.Local == "textformat" {
+			t.Format = attr.Value
+		}
+	}
+	var b strings.Builder
+	depth := 0
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			depth++
+			b.WriteString("<" + tok.Name.Local)
+			for _, attr := range tok.Attr {
+				b.WriteString(" " + attr.Name.Local + `="`)
+				xml.EscapeText(&b, []byte(attr.Value))
+				b.WriteString(`"`)
+			}
+			b.WriteString(">")
+		case xml.EndElement:
+			if depth == 0 {
+				t.Value = strings.TrimSpace(b.String())
+				return nil
+			}
+			depth--
+			b.WriteString("</" + tok.Name.Local + ">")
+		case xml.CharData:
+			if depth == 0 {
+				b.Write(tok)
+			} else {
+				xml.EscapeText(&b, tok)
+			}
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package openaire
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+// now decides whether an availability date is still to come; tests
+// replace it.
+var now = time.Now
+
+// Access rights, named as Zenodo names them.
+const (
+	accessOpen       = "open"
+	accessEmbargoed  = "embargoed"
+	accessRestricted = "restricted"
+	accessClosed     = "closed"
+)
+
+// accessTerm is an access right in the COAR vocabulary (4.0) and the
+// info:eu-repo semantics (3.0).
+type accessTerm struct {
+	label  string
+	coar   string
+	eurepo string
+}
+
+var accessTerms = map[string]accessTerm{
+	accessOpen:       {"open access", "http://purl.org/coar/access_right/c_abf2", "info:eu-repo/semantics/openAccess"},
+	accessEmbargoed:  {"embargoed access", "http://purl.org/coar/access_right/c_f1cf", "info:eu-repo/semantics/embargoedAccess"},
+	accessRestricted: {"restricted access", "http://purl.org/coar/access_right/c_16ec", "info:eu-repo/semantics/restrictedAccess"},
+	accessClosed:     {"metadata only access", "http://purl.org/coar/access_right/c_14cb", "info:eu-repo/semantics/closedAccess"},
+}
+
+// accessRight returns the record's access right and, when it is under
+// embargo, the date the embargo ends.
+func accessRight(record *hubv1.Record) (string, *hubv1.DateValue) {
+	available := hub.GetDate(record, hubv1.DateType_DATE_TYPE_AVAILABLE)
+
+	if access := hub.GetExtraString(record, "zenodo_access_right"); accessTerms[access] != (accessTerm{}) {
+		if access == accessEmbargoed && available != nil && available.Year > 0 {
+			return access, available
+		}
+		return access, nil
+	}
+	switch {
+	case available != nil && available.Year > 0 && isFuture(available):
+		return accessEmbargoed, available
+	case (record.AccessCondition != "" || record.LocalRestriction != "") && !record.IsPublic:
+		return accessRestricted, nil
+	}
+	return accessOpen, nil
+}
+
+func isFuture(d *hubv1.DateValue) bool {
+	month, day := max(d.Month, 1), max(d.Day, 1)
+	t := time.Date(int(d.Year), time.Month(month), int(day), 0, 0, 0, 0, time.UTC)
+	return t.After(now())
+}
+
+// isoDate formats a date as ISO 8601 to the precision it has.
+func isoDate(d *hubv1.DateValue) string {
+	switch {
+	case d == nil || d.Year == 0:
+		return ""
+	case d.Month == 0:
+		return fmt.Sprintf("%04d", d.Year)
+	case d.Day == 0:
+		return fmt.Sprintf("%04d-%02d", d.Year, d.Month)
+	}
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// issued returns the record's publication date.
+func issued(record *hubv1.Record) *hubv1.DateValue {
+	for _, t := range []hubv1.DateType{hubv1.DateType_DATE_TYPE_ISSUED, hubv1.DateType_DATE_TYPE_PUBLISHED} {
+		if d := hub.GetDate(record, t); d != nil && d.Year > 0 {
+			return d
+		}
+	}
+	return nil
+}
+
+// openAIREFunder is a funder OpenAIRE links projects for, by its
+// Crossref Funder ID.
+type openAIREFunder struct {
+	code         string
+	jurisdiction string
+}
+
+// openAIREFunders are the funders whose awards get info:eu-repo project
+// identifiers. The European Research Council funds through the EC
+// framework programmes, so its projects are EC projects.
+var openAIREFunders = map[string]openAIREFunder{
+	"10.13039/501100000780": {"EC", "EU"},
+	"10.13039/501100000781": {"EC", "EU"},
+	"10.13039/100000001":    {"NSF", "US"},
+	"10.13039/100000002":    {"NIH", "US"},
+	"10.13039/100004440":    {"WT", ""},
+	"10.13039/501100001871": {"FCT", "PT"},
+	"10.13039/501100003246": {"NWO", "NL"},
+	"10.13039/501100001711": {"SNSF", "CH"},
+	"10.13039/501100002428": {"FWF", "AT"},
+	"10.13039/501100000923": {"ARC", "AU"},
+	"10.13039/501100000925": {"NHMRC", "AU"},
+	"10.13039/100014013":    {"UKRI", "GB"},
+}
+
+// openAIREFunderNames recognizes funders given without an identifier.
+var openAIREFunderNames = map[string]string{
+	"european commission":                    "10.13039/501100000780",
+	"european research council":              "10.13039/501100000781",
+	"national science foundation":            "10.13039/100000001",
+	"national institutes of health":          "10.13039/100000002",
+	"wellcome trust":                         "10.13039/100004440",
+	"fundação para a ciência e a tecnologia": "10.13039/501100001871",
+	"swiss national science foundation":      "10.13039/501100001711",
+	"austrian science fund":                  "10.13039/501100002428",
+	"australian research council":            "10.13039/501100000923",
+	"uk research and innovation":             "10.13039/100014013",
+}
+
+// funderDOI returns the funder's Crossref Funder ID, from its identifier
+// or, for funders OpenAIRE knows, its name.
+func funderDOI(f *hubv1.Funder) string {
+	if id := hub.NormalizeIdentifier(f.Identifier, hubv1.IdentifierType_IDENTIFIER_TYPE_DOI); strings.HasPrefix(id, "10.13039/") {
+		return id
+	}
+	if f.Identifier == "" {
+		return openAIREFunderNames[strings.ToLower(strings.TrimSpace(f.Name))]
+	}
+	return ""
+}
+
+// projectID returns the info:eu-repo project identifier of one of a
+// funder's awards: the award URI when it already is one, or one built for
+// funders OpenAIRE knows. The funding programme isn't recorded on the hub,
+// so that segment is left empty; OpenAIRE matches projects by funder and
+// grant number.
+func projectID(f *hubv1.Funder, award string) string {
+	if strings.HasPrefix(f.AwardUri, "info:eu-repo/grantAgreement/") {
+		return f.AwardUri
+	}
+	funder, ok := openAIREFunders[funderDOI(f)]
+	if !ok || award == "" {
+		return ""
+	}
+	parts := []string{"info:eu-repo/grantAgreement", funder.code, "", pathSegment(award)}
+	if f.AwardTitle != "" {
+		parts = append(parts, funder.jurisdiction, pathSegment(f.AwardTitle))
+	}
+	return strings.Join(parts, "/")
+}
+
+// pathSegment escapes the slashes in an info:eu-repo segment.
+func pathSegment(s string) string {
+	return strings.ReplaceAll(strings.TrimSpace(s), "/", "%2F")
+}
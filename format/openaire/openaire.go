@@ -0,0 +1,92 @@
+// Package openaire provides a serializer for metadata that validates
+// against the OpenAIRE Guidelines for Literature Repositories, so outputs
+// of EU-funded research can be harvested into the OpenAIRE graph.
+//
+// The default variant is the 4.0 application profile (oaire:resource,
+// mostly DataCite elements), with COAR access rights and resource types.
+// The oai_dc variant is the Dublin Core profile of the 3.0 guidelines,
+// with info:eu-repo semantics, which OpenAIRE still accepts.
+//
+// Both derive the same things the hub leaves implicit. A record is under
+// embargo when its available date is in the future, restricted when it has
+// an access condition or local restriction and isn't public, and open
+// otherwise; an access right kept from a Zenodo record (zenodo_access_right)
+// wins. Funders OpenAIRE knows (the European Commission, NSF, Wellcome and
+// others), recognized by Crossref Funder ID or name, give their awards
+// info:eu-repo/grantAgreement project identifiers.
+package openaire
+
+import (
+	"github.com/lehigh-university-libraries/crosswalk/format"
+)
+
+// Version is the OpenAIRE Guidelines for Literature Repositories version
+// this implementation targets.
+const Version = "4.0"
+
+// Output variants, selected with SerializeOptions.Variant. The OpenAIRE 4.0
+// application profile is the default.
+const (
+	VariantOAIRE = "oaire"
+	VariantOAIDC = "oai_dc"
+)
+
+// Format implements the OpenAIRE format.
+type Format struct{}
+
+// Ensure Format implements the interfaces
+var (
+	_ format.Format         = (*Format)(nil)
+	_ format.Serializer     = (*Format)(nil)
+	_ format.FieldDescriber = (*Format)(nil)
+)
+
+// Name returns the format identifier.
+func (f *Format) Name() string {
+	return "openaire"
+}
+
+// Description returns a human-readable format description.
+func (f *Format) Description() string {
+	return "OpenAIRE Guidelines for Literature Repositories " + Version
+}
+
+// Extensions returns file extensions associated with this format.
+func (f *Format) Extensions() []string {
+	return []string{"xml"}
+}
+
+// writtenFields are the hub record fields Serialize writes in the 4.0
+// profile.
+var writtenFields = []string{
+	"title", "titles", "alt_title", "abstract", "abstracts",
+	"contributors", "dates", "resource_type", "degree_info",
+	"subjects", "language", "publisher", "publication", "edition",
+	"rights", "access_condition", "local_restriction", "is_public",
+	"identifiers", "files", "funders",
+}
+
+// oaiDCFields are the hub record fields the oai_dc variant writes.
+var oaiDCFields = []string{
+	"title", "abstract", "contributors", "dates", "resource_type",
+	"degree_info", "subjects", "language", "publisher", "rights",
+	"access_condition", "local_restriction", "is_public",
+	"identifiers", "files", "funders",
+}
+
+// WrittenFields returns the hub record fields Serialize writes.
+func (f *Format) WrittenFields(opts *format.SerializeOptions) []string {
+	if opts != nil && opts.Variant == VariantOAIDC {
+		return oaiDCFields
+	}
+	return writtenFields
+}
+
+// CanParse returns false; OpenAIRE metadata is output only.
+func (f *Format) CanParse(peek []byte) bool {
+	return false
+}
+
+func init() {
+	format.Register(&Format{})
+}
@@ -0,0 +1,95 @@
+package openaire
+
+import (
+	"strings"
+
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+)
+
+// coarResourceType is the base of COAR resource type URIs.
+const coarResourceType = "http://purl.org/coar/resource_type/"
+
+// Resource type generals of the 4.0 profile.
+const (
+	generalLiterature = "literature"
+	generalDataset    = "dataset"
+	generalSoftware   = "software"
+	generalOther      = "other research product"
+)
+
+// openAIREType is a resource type in the COAR vocabulary (4.0) and the
+// info:eu-repo semantics (3.0).
+type openAIREType struct {
+	coar    string
+	label   string
+	general string
+	eurepo  string
+}
+
+var resourceTypes = map[hubv1.ResourceTypeValue]openAIREType{
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_ARTICLE:               {"c_6501", "journal article", generalLiterature, "article"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_BOOK:                  {"c_2f33", "book", generalLiterature, "book"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_BOOK_CHAPTER:          {"c_3248", "book part", generalLiterature, "bookPart"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_CONFERENCE_PAPER:      {"c_5794", "conference paper", generalLiterature, "conferenceObject"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_CONFERENCE_PROCEEDING: {"c_f744", "conference proceedings", generalLiterature, "conferenceObject"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_POSTER:                {"c_6670", "conference poster", generalLiterature, "conferenceObject"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_PRESENTATION:          {"c_c94f", "conference object", generalLiterature, "lecture"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_DATASET:               {"c_ddb1", "dataset", generalDataset, "other"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_DISSERTATION:          {"c_db06", "doctoral thesis", generalLiterature, "doctoralThesis"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_THESIS:                {"c_46ec", "thesis", generalLiterature, "masterThesis"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_REPORT:                {"c_93fc", "report", generalLiterature, "report"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_TECHNICAL_REPORT:      {"c_18gh", "technical report", generalLiterature, "report"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_WORKING_PAPER:         {"c_8042", "working paper", generalLiterature, "workingPaper"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_PREPRINT:              {"c_816b", "preprint", generalLiterature, "preprint"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_MANUSCRIPT:            {"c_0040", "manuscript", generalLiterature, "other"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_JOURNAL:               {"c_0640", "journal", generalLiterature, "other"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_PERIODICAL:            {"c_2659", "periodical", generalLiterature, "other"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_NEWSPAPER:             {"c_2fe3", "newspaper", generalLiterature, "other"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_NEWSPAPER_ARTICLE:     {"c_998f", "newspaper article", generalLiterature, "contributionToPeriodical"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_PATENT:                {"c_15cd", "patent", generalLiterature, "patent"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_PEER_REVIEW:           {"c_efa0", "review", generalLiterature, "review"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_TEXT:                  {"c_18cf", "text", generalLiterature, "other"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_SOFTWARE:              {"c_5ce6", "software", generalSoftware, "other"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_IMAGE:                 {"c_c513", "image", generalOther, "other"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_VIDEO:                 {"c_12ce", "video", generalOther, "other"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_AUDIO:                 {"c_18cc", "sound", generalOther, "other"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_MAP:                   {"c_12cd", "map", generalOther, "other"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_WEBPAGE:               {"c_7ad9", "website", generalOther, "other"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_INTERACTIVE:           {"c_e9a0", "interactive resource", generalOther, "other"},
+}
+
+// otherType is the type of anything without a closer COAR match.
+var otherType = openAIREType{"c_1843", "other", generalOther, "other"}
+
+// Theses by degree level, which the COAR and info:eu-repo types distinguish.
+var (
+	doctoralThesis = openAIREType{"c_db06", "doctoral thesis", generalLiterature, "doctoralThesis"}
+	masterThesis   = openAIREType{"c_bdcc", "master thesis", generalLiterature, "masterThesis"}
+	bachelorThesis = openAIREType{"c_7a1f", "bachelor thesis", generalLiterature, "bachelorThesis"}
+)
+
+// resourceType returns the OpenAIRE type of the record, using the degree
+// level to tell theses apart.
+func resourceType(record *hubv1.Record) openAIREType {
+	var value hubv1.ResourceTypeValue
+	if record.ResourceType != nil {
+		value = record.ResourceType.Type
+	}
+	if value == hubv1.ResourceTypeValue_RESOURCE_TYPE_THESIS || value == hubv1.ResourceTypeValue_RESOURCE_TYPE_DISSERTATION {
+		if record.DegreeInfo != nil {
+			level := strings.ToLower(record.DegreeInfo.DegreeLevel + " " + record.DegreeInfo.DegreeName)
+			switch {
+			case strings.Contains(level, "doctor") || strings.Contains(level, "ph.d") || strings.Contains(level, "phd"):
+				return doctoralThesis
+			case strings.Contains(level, "master"):
+				return masterThesis
+			case strings.Contains(level, "bachelor") || strings.Contains(level, "undergraduate"):
+				return bachelorThesis
+			}
+		}
+	}
+	if t, ok := resourceTypes[value]; ok {
+		return t
+	}
+	return otherType
+}
@@ -0,0 +1,456 @@
+package openaire
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/helpers"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+// Serialize writes records as consecutive oaire:resource elements, or
+// oai_dc:dc elements for the oai_dc variant, the way an OAI-PMH provider
+// puts each in a record's metadata.
+func (f *Format) Serialize(w io.Writer, records []*hubv1.Record, opts *format.SerializeOptions) error {
+	if opts == nil {
+		opts = format.NewSerializeOptions()
+	}
+	switch opts.Variant {
+	case "", VariantOAIRE, VariantOAIDC:
+	default:
+		return fmt.Errorf("unknown openaire variant %q (want %s or %s)", opts.Variant, VariantOAIRE, VariantOAIDC)
+	}
+
+	// Title, creator, and resource identifier are mandatory
+	if len(records) == 0 {
+		return fmt.Errorf("OpenAIRE requires at least one resource: %w", format.ErrEmptyDocument)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	for i, record := range records {
+		var v any
+		if opts.Variant == VariantOAIDC {
+			v = recordToOAIDC(record)
+		} else {
+			resource, err := recordToResource(record)
+			if err != nil {
+				return fmt.Errorf("record %d: %w", i, err)
+			}
+			v = resource
+		}
+		output, err := xml.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshaling record %d: %w", i, err)
+		}
+		if _, err := w.Write(output); err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, "\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordToResource builds the 4.0 application profile of a record.
+func recordToResource(record *hubv1.Record) (*XMLResource, error) {
+	identifier, alternates := identifiers(record)
+	if identifier.Value == "" {
+		return nil, fmt.Errorf("no DOI, handle, or URL for the mandatory resource identifier")
+	}
+
+	access, embargoEnd := accessRight(record)
+	term := accessTerms[access]
+	typ := resourceType(record)
+
+	res := &XMLResource{
+		XmlnsOAIRE:        NamespaceOAIRE,
+		XmlnsDataCite:     NamespaceDataCite,
+		XmlnsDC:           NamespaceDC,
+		XmlnsDCTerms:      NamespaceDCTerms,
+		XmlnsXSI:          NamespaceXSI,
+		XSISchemaLocation: schemaLocationOAIRE,
+		Language:          record.Language,
+		Publisher:         record.Publisher,
+		ResourceType:      XMLResourceType{Value: typ.label, ResourceTypeGeneral: typ.general, URI: coarResourceType + typ.coar},
+		Identifier:        identifier,
+		Rights:            XMLRights{Value: term.label, RightsURI: term.coar},
+		Formats:           formats(record),
+		CitationEdition:   record.Edition,
+	}
+
+	// Titles, with those in other languages tagged
+	tagged := len(record.Titles) > 0
+	for _, t := range hub.Titles(record) {
+		title := XMLTitle{Value: t.Value}
+		if tagged {
+			title.Lang = t.Language
+		}
+		res.Titles = append(res.Titles, title)
+	}
+	for _, alt := range record.AltTitle {
+		res.Titles = append(res.Titles, XMLTitle{Value: alt, TitleType: "AlternativeTitle"})
+	}
+
+	// Authors are creators; everyone else a contributor
+	var contributors []XMLContributor
+	for _, c := range record.Contributors {
+		name, given, family, ids, affs := contributorParts(c)
+		if contributorType := contributorType(c); contributorType != "" {
+			contributors = append(contributors, XMLContributor{
+				ContributorType: contributorType,
+				Name:            name,
+				GivenName:       given,
+				FamilyName:      family,
+				NameIdentifiers: ids,
+				Affiliations:    affs,
+			})
+			continue
+		}
+		res.Creators = append(res.Creators, XMLCreator{
+			Name:            name,
+			GivenName:       given,
+			FamilyName:      family,
+			NameIdentifiers: ids,
+			Affiliations:    affs,
+		})
+	}
+
+	// Dates: an embargo runs from acceptance (or publication) until the
+	// work is available
+	var dates []XMLDate
+	published := issued(record)
+	if embargoEnd != nil {
+		start := hub.GetDate(record, hubv1.DateType_DATE_TYPE_ACCEPTED)
+		if start == nil || start.Year == 0 {
+			start = published
+		}
+		if date := isoDate(start); date != "" {
+			dates = append(dates, XMLDate{Value: date, DateType: "Accepted"})
+		}
+		dates = append(dates, XMLDate{Value: isoDate(embargoEnd), DateType: "Available"})
+	} else if date := isoDate(hub.GetDate(record, hubv1.DateType_DATE_TYPE_AVAILABLE)); date != "" {
+		dates = append(dates, XMLDate{Value: date, DateType: "Available"})
+	}
+	if date := isoDate(published); date != "" {
+		dates = append(dates, XMLDate{Value: date, DateType: "Issued"})
+	}
+
+	// Abstracts
+	tagged = len(record.Abstracts) > 0
+	for _, a := range hub.Abstracts(record) {
+		description := XMLLangValue{Value: a.Value}
+		if tagged {
+			description.Lang = a.Language
+		}
+		res.Descriptions = append(res.Descriptions, description)
+	}
+
+	var subjects []XMLSubject
+	for _, s := range record.Subjects {
+		if s.Value != "" {
+			subjects = append(subjects, XMLSubject{Value: s.Value, ValueURI: s.Uri})
+		}
+	}
+
+	// Licenses apply once the work is available
+	for _, r := range record.Rights {
+		if text := hub.RightsString(r); text != "" {
+			res.LicenseConditions = append(res.LicenseConditions, XMLLicenseCondition{
+				Value:     text,
+				URI:       r.Uri,
+				StartDate: isoDate(cmpDate(embargoEnd, published)),
+			})
+		}
+	}
+
+	// Full text files online
+	for _, file := range record.Files {
+		if !strings.HasPrefix(file.Path, "http://") && !strings.HasPrefix(file.Path, "https://") {
+			continue
+		}
+		if file.Role != "" && file.Role != hub.FileRoleOriginal {
+			continue
+		}
+		res.Files = append(res.Files, XMLFile{
+			Value:           file.Path,
+			AccessRightsURI: term.coar,
+			MimeType:        file.MimeType,
+			ObjectType:      "fulltext",
+		})
+	}
+
+	if pub := record.Publication; pub != nil {
+		res.CitationTitle = pub.Title
+		res.CitationVolume = pub.Volume
+		res.CitationIssue = pub.Issue
+		res.CitationStartPage, res.CitationEndPage = helpers.SplitPages(pub.Pages)
+	}
+
+	// Wrappers only around something
+	if len(contributors) > 0 {
+		res.Contributors = &XMLContributors{Contributors: contributors}
+	}
+	if refs := fundingReferences(record); len(refs) > 0 {
+		res.FundingReferences = &XMLFundingReferences{FundingReferences: refs}
+	}
+	if len(alternates) > 0 {
+		res.AlternateIdentifiers = &XMLAlternateIdentifiers{AlternateIdentifiers: alternates}
+	}
+	if len(dates) > 0 {
+		res.Dates = &XMLDates{Dates: dates}
+	}
+	if len(subjects) > 0 {
+		res.Subjects = &XMLSubjects{Subjects: subjects}
+	}
+
+	return res, nil
+}
+
+// recordToOAIDC builds the 3.0 Dublin Core profile of a record.
+func recordToOAIDC(record *hubv1.Record) *XMLOAIDC {
+	access, embargoEnd := accessRight(record)
+	typ := resourceType(record)
+
+	dc := &XMLOAIDC{
+		XmlnsOAIDC:        NamespaceOAIDC,
+		XmlnsDC:           NamespaceDC,
+		XmlnsXSI:          NamespaceXSI,
+		XSISchemaLocation: schemaLocationOAIDC,
+		Types:             []string{"info:eu-repo/semantics/" + typ.eurepo},
+		Rights:            []string{accessTerms[access].eurepo},
+		Formats:           formats(record),
+	}
+	if record.Title != "" {
+		dc.Titles = append(dc.Titles, record.Title)
+	}
+	for _, c := range record.Contributors {
+		name := hub.InvertedName(c)
+		if contributorType(c) != "" {
+			dc.Contributors = append(dc.Contributors, name)
+		} else {
+			dc.Creators = append(dc.Creators, name)
+		}
+	}
+	for _, s := range record.Subjects {
+		dc.Subjects = append(dc.Subjects, s.Value)
+	}
+	if record.Abstract != "" {
+		dc.Descriptions = append(dc.Descriptions, record.Abstract)
+	}
+	if record.Publisher != "" {
+		dc.Publishers = append(dc.Publishers, record.Publisher)
+	}
+	if date := isoDate(issued(record)); date != "" {
+		dc.Dates = append(dc.Dates, date)
+	}
+	if embargoEnd != nil {
+		dc.Dates = append(dc.Dates, "info:eu-repo/date/embargoEnd/"+isoDate(embargoEnd))
+	}
+	for _, id := range record.Identifiers {
+		if _, ok := identifierTypes[id.Type]; ok && id.Value != "" {
+			dc.Identifiers = append(dc.Identifiers, hub.IdentifierURI(id))
+		}
+	}
+	if record.Language != "" {
+		dc.Languages = append(dc.Languages, record.Language)
+	}
+	for _, f := range record.Funders {
+		for _, award := range f.AwardNumbers {
+			if project := projectID(f, award); project != "" && !slices.Contains(dc.Relations, project) {
+				dc.Relations = append(dc.Relations, project)
+			}
+		}
+	}
+	for _, r := range record.Rights {
+		if text := cmpString(r.Uri, hub.RightsString(r)); text != "" {
+			dc.Rights = append(dc.Rights, text)
+		}
+	}
+	return dc
+}
+
+// identifiers returns the resource identifier, the first of a DOI, handle,
+// or URL, and the rest as alternate identifiers.
+func identifiers(record *hubv1.Record) (XMLIdentifier, []XMLTypedValue) {
+	var primary XMLIdentifier
+	var primaryID *hubv1.Identifier
+	for _, t := range []hubv1.IdentifierType{
+		hubv1.IdentifierType_IDENTIFIER_TYPE_DOI,
+		hubv1.IdentifierType_IDENTIFIER_TYPE_HANDLE,
+		hubv1.IdentifierType_IDENTIFIER_TYPE_URL,
+	} {
+		for _, id := range record.Identifiers {
+			if id.Type == t && id.Value != "" {
+				primaryID = id
+				primary = XMLIdentifier{Value: hub.IdentifierURI(id), IdentifierType: identifierTypes[t]}
+				break
+			}
+		}
+		if primaryID != nil {
+			break
+		}
+	}
+
+	var alternates []XMLTypedValue
+	for _, id := range record.Identifiers {
+		typ, ok := identifierTypes[id.Type]
+		if !ok || id == primaryID || id.Value == "" {
+			continue
+		}
+		alternates = append(alternates, XMLTypedValue{Value: id.Value, Type: typ})
+	}
+	return primary, alternates
+}
+
+// identifierTypes are the DataCite names of the identifiers written.
+var identifierTypes = map[hubv1.IdentifierType]string{
+	hubv1.IdentifierType_IDENTIFIER_TYPE_DOI:    "DOI",
+	hubv1.IdentifierType_IDENTIFIER_TYPE_HANDLE: "Handle",
+	hubv1.IdentifierType_IDENTIFIER_TYPE_URL:    "URL",
+	hubv1.IdentifierType_IDENTIFIER_TYPE_ISBN:   "ISBN",
+	hubv1.IdentifierType_IDENTIFIER_TYPE_ISSN:   "ISSN",
+	hubv1.IdentifierType_IDENTIFIER_TYPE_PMID:   "PMID",
+	hubv1.IdentifierType_IDENTIFIER_TYPE_PMCID:  "PMCID",
+	hubv1.IdentifierType_IDENTIFIER_TYPE_ARXIV:  "arXiv",
+	hubv1.IdentifierType_IDENTIFIER_TYPE_ARK:    "ARK",
+	hubv1.IdentifierType_IDENTIFIER_TYPE_URN:    "URN",
+	hubv1.IdentifierType_IDENTIFIER_TYPE_PURL:   "PURL",
+}
+
+// contributorParts returns what creators and contributors share: the name
+// and its type, ORCID, and affiliations.
+func contributorParts(c *hubv1.Contributor) (XMLName, string, string, []XMLNameIdentifier, []string) {
+	name := XMLName{Value: hub.InvertedName(c), NameType: "Personal"}
+	var given, family string
+	if c.Type == hubv1.ContributorType_CONTRIBUTOR_TYPE_ORGANIZATION {
+		name = XMLName{Value: c.Name, NameType: "Organizational"}
+	} else if c.ParsedName != nil {
+		given, family = c.ParsedName.Given, c.ParsedName.Family
+	}
+
+	var ids []XMLNameIdentifier
+	for _, id := range c.Identifiers {
+		if id.Type == hubv1.IdentifierType_IDENTIFIER_TYPE_ORCID {
+			ids = append(ids, XMLNameIdentifier{
+				Value:                hub.NormalizeIdentifier(id.Value, id.Type),
+				NameIdentifierScheme: "ORCID",
+				SchemeURI:            "https://orcid.org",
+			})
+		}
+	}
+
+	var affs []string
+	for _, aff := range c.Affiliations {
+		affs = append(affs, aff.Name)
+	}
+	if len(affs) == 0 && c.Affiliation != "" {
+		affs = []string{c.Affiliation}
+	}
+	return name, given, family, ids, affs
+}
+
+// contributorType returns the DataCite contributor type of a contributor,
+// or an empty string for authors.
+func contributorType(c *hubv1.Contributor) string {
+	if hub.DegreeRoleOf(c) != hubv1.DegreeRole_DEGREE_ROLE_UNSPECIFIED {
+		return "Supervisor"
+	}
+	switch strings.ToLower(strings.TrimSpace(c.Role)) {
+	case "", "author", "aut", "creator", "cre":
+		return ""
+	case "editor", "edt":
+		return "Editor"
+	case "sponsor", "spn", "funder", "fnd":
+		return "Sponsor"
+	case "contact person":
+		return "ContactPerson"
+	case "data curator":
+		return "DataCurator"
+	case "researcher", "res":
+		return "Researcher"
+	}
+	return "Other"
+}
+
+// fundingReferences lists each funder once per award, or once without
+// one, with the award's project identifier as its URI.
+func fundingReferences(record *hubv1.Record) []XMLFundingReference {
+	var refs []XMLFundingReference
+	for _, f := range record.Funders {
+		if f.Name == "" {
+			continue
+		}
+		ref := XMLFundingReference{FunderName: f.Name, AwardTitle: f.AwardTitle}
+		if id := funderIdentifier(f); id.Value != "" {
+			ref.FunderIdentifier = &id
+		}
+		if len(f.AwardNumbers) == 0 {
+			refs = append(refs, ref)
+			continue
+		}
+		for _, award := range f.AwardNumbers {
+			ref := ref
+			ref.AwardNumber = &XMLAwardNumber{Value: award, AwardURI: cmpString(projectID(f, award), f.AwardUri)}
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
+
+// funderIdentifier returns the funder's identifier with its type.
+func funderIdentifier(f *hubv1.Funder) XMLFunderIdentifier {
+	if doi := funderDOI(f); doi != "" {
+		return XMLFunderIdentifier{Value: "https://doi.org/" + doi, FunderIdentifierType: "Crossref Funder ID"}
+	}
+	id := strings.TrimSpace(f.Identifier)
+	switch {
+	case id == "":
+		return XMLFunderIdentifier{}
+	case strings.Contains(id, "ror.org/") || strings.EqualFold(f.IdentifierType, "ROR"):
+		return XMLFunderIdentifier{Value: id, FunderIdentifierType: "ROR"}
+	case strings.EqualFold(f.IdentifierType, "ISNI"):
+		return XMLFunderIdentifier{Value: id, FunderIdentifierType: "ISNI"}
+	case strings.EqualFold(f.IdentifierType, "GRID"):
+		return XMLFunderIdentifier{Value: id, FunderIdentifierType: "GRID"}
+	}
+	return XMLFunderIdentifier{Value: id, FunderIdentifierType: "Other"}
+}
+
+// formats returns the media types of the record's files, once each.
+func formats(record *hubv1.Record) []string {
+	var types []string
+	for _, file := range record.Files {
+		if file.MimeType != "" && !slices.Contains(types, file.MimeType) {
+			types = append(types, file.MimeType)
+		}
+	}
+	return types
+}
+
+// cmpDate returns the first date that is set.
+func cmpDate(dates ...*hubv1.DateValue) *hubv1.DateValue {
+	for _, d := range dates {
+		if d != nil && d.Year > 0 {
+			return d
+		}
+	}
+	return nil
+}
+
+// cmpString returns the first non-empty string.
+func cmpString(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,191 @@
+package openaire
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+func embargoedArticle() *hubv1.Record {
+	return &hubv1.Record{
+		Title:    "Sediment transport in the Lehigh River",
+		Abstract: "Measurements of suspended sediment after storms.",
+		Contributors: []*hubv1.Contributor{
+			{
+				Name:       "Ana Nguyen",
+				Role:       "author",
+				ParsedName: &hubv1.ParsedName{Given: "Ana", Family: "Nguyen"},
+				Identifiers: []*hubv1.Identifier{
+					{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_ORCID, Value: "0000-0002-1825-0097"},
+				},
+				Affiliation: "Lehigh University",
+			},
+			{Name: "Ben Ortiz", Role: "editor", ParsedName: &hubv1.ParsedName{Given: "Ben", Family: "Ortiz"}},
+		},
+		ResourceType: &hubv1.ResourceType{Type: hubv1.ResourceTypeValue_RESOURCE_TYPE_ARTICLE},
+		Dates: []*hubv1.DateValue{
+			{Type: hubv1.DateType_DATE_TYPE_ISSUED, Year: 2026, Month: 3, Day: 2},
+			{Type: hubv1.DateType_DATE_TYPE_AVAILABLE, Year: 2027, Month: 3, Day: 2},
+		},
+		Identifiers: []*hubv1.Identifier{
+			{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_URL, Value: "https://preserve.lehigh.edu/node/42"},
+			{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_DOI, Value: "10.1234/sediment"},
+		},
+		Funders: []*hubv1.Funder{
+			{Name: "European Commission", AwardNumbers: []string{"123456"}, AwardTitle: "RIVERS"},
+		},
+		Rights: []*hubv1.Rights{
+			{Uri: "http://creativecommons.org/licenses/by/4.0/"},
+		},
+		Publication: &hubv1.PublicationDetails{Title: "Journal of Hydrology", Volume: "12", Pages: "101-118"},
+	}
+}
+
+func serialize(t *testing.T, variant string, records ...*hubv1.Record) string {
+	t.Helper()
+	defer func(orig func() time.Time) { now = orig }(now)
+	now = func() time.Time { return time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC) }
+
+	opts := format.NewSerializeOptions()
+	opts.Variant = variant
+	var buf bytes.Buffer
+	if err := (&Format{}).Serialize(&buf, records, opts); err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+	return buf.String()
+}
+
+func TestSerializeEmbargoedArticle(t *testing.T) {
+	out := serialize(t, "", embargoedArticle())
+
+	for _, want := range []string{
+		`<oaire:resource xmlns:oaire="http://namespace.openaire.eu/schema/oaire/"`,
+		`<datacite:title>Sediment transport in the Lehigh River</datacite:title>`,
+		`<datacite:creatorName nameType="Personal">Nguyen, Ana</datacite:creatorName>`,
+		`<datacite:nameIdentifier nameIdentifierScheme="ORCID" schemeURI="https://orcid.org">0000-0002-1825-0097</datacite:nameIdentifier>`,
+		`<datacite:affiliation>Lehigh University</datacite:affiliation>`,
+		`<datacite:contributor contributorType="Editor">`,
+		`<oaire:funderIdentifier funderIdentifierType="Crossref Funder ID">https://doi.org/10.13039/501100000780</oaire:funderIdentifier>`,
+		`<oaire:awardNumber awardURI="info:eu-repo/grantAgreement/EC//123456/EU/RIVERS">123456</oaire:awardNumber>`,
+		`<datacite:alternateIdentifier alternateIdentifierType="URL">https://preserve.lehigh.edu/node/42</datacite:alternateIdentifier>`,
+		`<datacite:date dateType="Accepted">2026-03-02</datacite:date>`,
+		`<datacite:date dateType="Available">2027-03-02</datacite:date>`,
+		`<datacite:date dateType="Issued">2026-03-02</datacite:date>`,
+		`<oaire:resourceType resourceTypeGeneral="literature" uri="http://purl.org/coar/resource_type/c_6501">journal article</oaire:resourceType>`,
+		`<datacite:identifier identifierType="DOI">https://doi.org/10.1234/sediment</datacite:identifier>`,
+		`<datacite:rights rightsURI="http://purl.org/coar/access_right/c_f1cf">embargoed access</datacite:rights>`,
+		`<oaire:licenseCondition startDate="2027-03-02" uri="http://creativecommons.org/licenses/by/4.0/">`,
+		`<oaire:citationTitle>Journal of Hydrology</oaire:citationTitle>`,
+		`<oaire:citationStartPage>101</oaire:citationStartPage>`,
+		`<oaire:citationEndPage>118</oaire:citationEndPage>`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %s in:\n%s", want, out)
+		}
+	}
+}
+
+func TestSerializeOAIDC(t *testing.T) {
+	out := serialize(t, VariantOAIDC, embargoedArticle())
+
+	for _, want := range []string{
+		`<oai_dc:dc xmlns:oai_dc="http://www.openarchives.org/OAI/2.0/oai_dc/"`,
+		`<dc:creator>Nguyen, Ana</dc:creator>`,
+		`<dc:contributor>Ortiz, Ben</dc:contributor>`,
+		`<dc:date>2026-03-02</dc:date>`,
+		`<dc:date>info:eu-repo/date/embargoEnd/2027-03-02</dc:date>`,
+		`<dc:type>info:eu-repo/semantics/article</dc:type>`,
+		`<dc:identifier>https://doi.org/10.1234/sediment</dc:identifier>`,
+		`<dc:relation>info:eu-repo/grantAgreement/EC//123456/EU/RIVERS</dc:relation>`,
+		`<dc:rights>info:eu-repo/semantics/embargoedAccess</dc:rights>`,
+		`<dc:rights>http://creativecommons.org/licenses/by/4.0/</dc:rights>`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %s in:\n%s", want, out)
+		}
+	}
+}
+
+func TestAccessRight(t *testing.T) {
+	defer func(orig func() time.Time) { now = orig }(now)
+	now = func() time.Time { return time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC) }
+
+	lifted := embargoedArticle()
+	lifted.Dates[1].Year = 2026
+	lifted.Dates[1].Month = 9
+
+	restricted := embargoedArticle()
+	restricted.Dates = restricted.Dates[:1]
+	restricted.AccessCondition = "Lehigh users only"
+
+	zenodo := embargoedArticle()
+	hub.SetExtra(zenodo, "zenodo_access_right", "closed")
+
+	tests := []struct {
+		name   string
+		record *hubv1.Record
+		want   string
+	}{
+		{"future available date", embargoedArticle(), accessEmbargoed},
+		{"past available date", lifted, accessOpen},
+		{"access condition", restricted, accessRestricted},
+		{"zenodo access right", zenodo, accessClosed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := accessRight(tt.record); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDoctoralThesisType(t *testing.T) {
+	record := embargoedArticle()
+	record.ResourceType = &hubv1.ResourceType{Type: hubv1.ResourceTypeValue_RESOURCE_TYPE_THESIS}
+	record.DegreeInfo = &hubv1.DegreeInfo{DegreeName: "Doctor of Philosophy"}
+
+	if typ := resourceType(record); typ != doctoralThesis {
+		t.Errorf("got %v, want doctoral thesis", typ)
+	}
+}
+
+func TestSerializeMinimalRecord(t *testing.T) {
+	// A title and the mandatory identifier, nothing else
+	out := serialize(t, "", &hubv1.Record{
+		Title:       "Sediment transport in the Lehigh River",
+		Identifiers: []*hubv1.Identifier{{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_DOI, Value: "10.1234/sediment"}},
+	})
+	for _, wrapper := range []string{"datacite:contributors", "oaire:fundingReferences", "datacite:alternateIdentifiers", "datacite:dates", "datacite:subjects"} {
+		if strings.Contains(out, "<"+wrapper) {
+			t.Errorf("output has an empty %s:\n%s", wrapper, out)
+		}
+	}
+	if !strings.Contains(out, "<datacite:title>Sediment transport in the Lehigh River</datacite:title>") {
+		t.Errorf("output missing the title:\n%s", out)
+	}
+}
+
+func TestSerializeRequiresIdentifier(t *testing.T) {
+	record := embargoedArticle()
+	record.Identifiers = nil
+	err := (&Format{}).Serialize(&bytes.Buffer{}, []*hubv1.Record{record}, nil)
+	if err == nil || !strings.Contains(err.Error(), "resource identifier") {
+		t.Errorf("got %v, want missing identifier error", err)
+	}
+}
+
+func TestSerializeUnknownVariant(t *testing.T) {
+	opts := format.NewSerializeOptions()
+	opts.Variant = "mets"
+	err := (&Format{}).Serialize(&bytes.Buffer{}, []*hubv1.Record{embargoedArticle()}, opts)
+	if err == nil || errors.Is(err, format.ErrEmptyDocument) {
+		t.Errorf("got %v, want unknown variant error", err)
+	}
+}
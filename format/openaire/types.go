@@ -0,0 +1,220 @@
+package openaire
+
+import "encoding/xml"
+
+// Namespaces and schema locations of the two profiles.
+const (
+	NamespaceOAIRE    = "http://namespace.openaire.eu/schema/oaire/"
+	NamespaceDataCite = "http://datacite.org/schema/kernel-4"
+	NamespaceDC       = "http://purl.org/dc/elements/1.1/"
+	NamespaceDCTerms  = "http://purl.org/dc/terms/"
+	NamespaceOAIDC    = "http://www.openarchives.org/OAI/2.0/oai_dc/"
+	NamespaceXSI      = "http://www.w3.org/2001/XMLSchema-instance"
+
+	schemaLocationOAIRE = NamespaceOAIRE + " https://www.openaire.eu/schema/repo-lit/4.0/openaire.xsd"
+	schemaLocationOAIDC = NamespaceOAIDC + " http://www.openarchives.org/OAI/2.0/oai_dc.xsd"
+)
+
+// XMLResource is a record in the OpenAIRE 4.0 application profile. The
+// elements carry the prefixes the root declares, in the order the
+// guidelines list them.
+type XMLResource struct {
+	XMLName           xml.Name `xml:"oaire:resource"`
+	XmlnsOAIRE        string   `xml:"xmlns:oaire,attr"`
+	XmlnsDataCite     string   `xml:"xmlns:datacite,attr"`
+	XmlnsDC           string   `xml:"xmlns:dc,attr"`
+	XmlnsDCTerms      string   `xml:"xmlns:dcterms,attr"`
+	XmlnsXSI          string   `xml:"xmlns:xsi,attr"`
+	XSISchemaLocation string   `xml:"xsi:schemaLocation,attr"`
+
+	Titles               []XMLTitle               `xml:"datacite:titles>datacite:title"`
+	Creators             []XMLCreator             `xml:"datacite:creators>datacite:creator"`
+	Contributors         *XMLContributors         `xml:"datacite:contributors,omitempty"`
+	FundingReferences    *XMLFundingReferences    `xml:"oaire:fundingReferences,omitempty"`
+	AlternateIdentifiers *XMLAlternateIdentifiers `xml:"datacite:alternateIdentifiers,omitempty"`
+	Dates                *XMLDates                `xml:"datacite:dates,omitempty"`
+	Language             string                   `xml:"dc:language,omitempty"`
+	Publisher            string                   `xml:"dc:publisher,omitempty"`
+	ResourceType         XMLResourceType          `xml:"oaire:resourceType"`
+	Descriptions         []XMLLangValue           `xml:"dc:description,omitempty"`
+	Formats              []string                 `xml:"dc:format,omitempty"`
+	Identifier           XMLIdentifier            `xml:"datacite:identifier"`
+	Rights               XMLRights                `xml:"datacite:rights"`
+	Subjects             *XMLSubjects             `xml:"datacite:subjects,omitempty"`
+	LicenseConditions    []XMLLicenseCondition    `xml:"oaire:licenseCondition,omitempty"`
+	Files                []XMLFile                `xml:"oaire:file,omitempty"`
+	CitationTitle        string                   `xml:"oaire:citationTitle,omitempty"`
+	CitationVolume       string                   `xml:"oaire:citationVolume,omitempty"`
+	CitationIssue        string                   `xml:"oaire:citationIssue,omitempty"`
+	CitationStartPage    string                   `xml:"oaire:citationStartPage,omitempty"`
+	CitationEndPage      string                   `xml:"oaire:citationEndPage,omitempty"`
+	CitationEdition      string                   `xml:"oaire:citationEdition,omitempty"`
+}
+
+// The wrappers below each need at least one child, so they are left nil
+// when a record has nothing to put in them.
+
+// XMLContributors wraps the contributors.
+type XMLContributors struct {
+	Contributors []XMLContributor `xml:"datacite:contributor"`
+}
+
+// XMLFundingReferences wraps the funding references.
+type XMLFundingReferences struct {
+	FundingReferences []XMLFundingReference `xml:"oaire:fundingReference"`
+}
+
+// XMLAlternateIdentifiers wraps the alternate identifiers.
+type XMLAlternateIdentifiers struct {
+	AlternateIdentifiers []XMLTypedValue `xml:"datacite:alternateIdentifier"`
+}
+
+// XMLDates wraps the dates.
+type XMLDates struct {
+	Dates []XMLDate `xml:"datacite:date"`
+}
+
+// XMLSubjects wraps the subjects.
+type XMLSubjects struct {
+	Subjects []XMLSubject `xml:"datacite:subject"`
+}
+
+// XMLTitle is a title, with its type when it isn't the main title.
+type XMLTitle struct {
+	Value     string `xml:",chardata"`
+	Lang      string `xml:"xml:lang,attr,omitempty"`
+	TitleType string `xml:"titleType,attr,omitempty"`
+}
+
+// XMLCreator is an author of the work.
+type XMLCreator struct {
+	Name            XMLName             `xml:"datacite:creatorName"`
+	GivenName       string              `xml:"datacite:givenName,omitempty"`
+	FamilyName      string              `xml:"datacite:familyName,omitempty"`
+	NameIdentifiers []XMLNameIdentifier `xml:"datacite:nameIdentifier,omitempty"`
+	Affiliations    []string            `xml:"datacite:affiliation,omitempty"`
+}
+
+// XMLContributor is a contributor other than an author.
+type XMLContributor struct {
+	ContributorType string              `xml:"contributorType,attr"`
+	Name            XMLName             `xml:"datacite:contributorName"`
+	GivenName       string              `xml:"datacite:givenName,omitempty"`
+	FamilyName      string              `xml:"datacite:familyName,omitempty"`
+	NameIdentifiers []XMLNameIdentifier `xml:"datacite:nameIdentifier,omitempty"`
+	Affiliations    []string            `xml:"datacite:affiliation,omitempty"`
+}
+
+// XMLName is a creator or contributor name with its type.
+type XMLName struct {
+	Value    string `xml:",chardata"`
+	NameType string `xml:"nameType,attr,omitempty"`
+}
+
+// XMLNameIdentifier identifies a person, e.g. by ORCID.
+type XMLNameIdentifier struct {
+	Value                string `xml:",chardata"`
+	NameIdentifierScheme string `xml:"nameIdentifierScheme,attr"`
+	SchemeURI            string `xml:"schemeURI,attr,omitempty"`
+}
+
+// XMLFundingReference is a funder and, optionally, one of its awards.
+type XMLFundingReference struct {
+	FunderName       string               `xml:"oaire:funderName"`
+	FunderIdentifier *XMLFunderIdentifier `xml:"oaire:funderIdentifier,omitempty"`
+	AwardNumber      *XMLAwardNumber      `xml:"oaire:awardNumber,omitempty"`
+	AwardTitle       string               `xml:"oaire:awardTitle,omitempty"`
+}
+
+// XMLFunderIdentifier identifies a funder.
+type XMLFunderIdentifier struct {
+	Value                string `xml:",chardata"`
+	FunderIdentifierType string `xml:"funderIdentifierType,attr"`
+}
+
+// XMLAwardNumber is a grant number with the project's URI.
+type XMLAwardNumber struct {
+	Value    string `xml:",chardata"`
+	AwardURI string `xml:"awardURI,attr,omitempty"`
+}
+
+// XMLTypedValue is an identifier with its type.
+type XMLTypedValue struct {
+	Value string `xml:",chardata"`
+	Type  string `xml:"alternateIdentifierType,attr"`
+}
+
+// XMLDate is a date with its DataCite date type.
+type XMLDate struct {
+	Value    string `xml:",chardata"`
+	DateType string `xml:"dateType,attr"`
+}
+
+// XMLResourceType is a COAR resource type.
+type XMLResourceType struct {
+	Value               string `xml:",chardata"`
+	ResourceTypeGeneral string `xml:"resourceTypeGeneral,attr"`
+	URI                 string `xml:"uri,attr"`
+}
+
+// XMLLangValue is text tagged with its language.
+type XMLLangValue struct {
+	Value string `xml:",chardata"`
+	Lang  string `xml:"xml:lang,attr,omitempty"`
+}
+
+// XMLIdentifier is the resource identifier.
+type XMLIdentifier struct {
+	Value          string `xml:",chardata"`
+	IdentifierType string `xml:"identifierType,attr"`
+}
+
+// XMLRights is a COAR access right.
+type XMLRights struct {
+	Value     string `xml:",chardata"`
+	RightsURI string `xml:"rightsURI,attr"`
+}
+
+// XMLSubject is a subject term.
+type XMLSubject struct {
+	Value    string `xml:",chardata"`
+	ValueURI string `xml:"valueURI,attr,omitempty"`
+}
+
+// XMLLicenseCondition is a license and when it applies from.
+type XMLLicenseCondition struct {
+	Value     string `xml:",chardata"`
+	StartDate string `xml:"startDate,attr,omitempty"`
+	URI       string `xml:"uri,attr,omitempty"`
+}
+
+// XMLFile is a link to a full text file.
+type XMLFile struct {
+	Value           string `xml:",chardata"`
+	AccessRightsURI string `xml:"accessRightsURI,attr,omitempty"`
+	MimeType        string `xml:"mimeType,attr,omitempty"`
+	ObjectType      string `xml:"objectType,attr,omitempty"`
+}
+
+// XMLOAIDC is a record in the OpenAIRE 3.0 Dublin Core profile.
+type XMLOAIDC struct {
+	XMLName           xml.Name `xml:"oai_dc:dc"`
+	XmlnsOAIDC        string   `xml:"xmlns:oai_dc,attr"`
+	XmlnsDC           string   `xml:"xmlns:dc,attr"`
+	XmlnsXSI          string   `xml:"xmlns:xsi,attr"`
+	XSISchemaLocation string   `xml:"xsi:schemaLocation,attr"`
+
+	Titles       []string `xml:"dc:title"`
+	Creators     []string `xml:"dc:creator"`
+	Contributors []string `xml:"dc:contributor"`
+	Subjects     []string `xml:"dc:subject"`
+	Descriptions []string `xml:"dc:description"`
+	Publishers   []string `xml:"dc:publisher"`
+	Dates        []string `xml:"dc:date"`
+	Types        []string `xml:"dc:type"`
+	Formats      []string `xml:"dc:format"`
+	Identifiers  []string `xml:"dc:identifier"`
+	Languages    []string `xml:"dc:language"`
+	Relations    []string `xml:"dc:relation"`
+	Rights       []string `xml:"dc:rights"`
+}
@@ -0,0 +1,61 @@
+// Package openalex provides a parser for OpenAlex work JSON, as returned
+// by the OpenAlex API for a single work (/works/W...) or a page of search
+// results (/works?filter=...).
+//
+// The mapping covers:
+//   - title, type, language, publication date and year
+//   - authorships, with ORCIDs and institution affiliations (ROR IDs)
+//   - the primary location's source and biblio for Publication details
+//   - DOI, PMID, and PMCID identifiers
+//   - concepts and keywords as subjects, grants as funders
+//   - abstract_inverted_index, rebuilt into plain-text Abstract
+//
+// The OpenAlex ID and open access status are kept in the record's extra
+// fields as openalex_id, openalex_is_oa, openalex_oa_status, and
+// openalex_oa_url.
+package openalex
+
+import (
+	"bytes"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+)
+
+// Format implements the OpenAlex work format.
+type Format struct{}
+
+// Ensure Format implements the interfaces
+var (
+	_ format.Format = (*Format)(nil)
+	_ format.Parser = (*Format)(nil)
+)
+
+// Name returns the format identifier.
+func (f *Format) Name() string {
+	return "openalex"
+}
+
+// Description returns a human-readable format description.
+func (f *Format) Description() string {
+	return "OpenAlex work JSON (API responses)"
+}
+
+// Extensions returns file extensions associated with this format.
+func (f *Format) Extensions() []string {
+	return []string{"json"}
+}
+
+// CanParse returns true if the input looks like OpenAlex work JSON.
+func (f *Format) CanParse(peek []byte) bool {
+	peek = bytes.TrimSpace(peek)
+	if len(peek) == 0 || (peek[0] != '{' && peek[0] != '[') {
+		return false
+	}
+	return bytes.Contains(peek, []byte("openalex.org/W")) ||
+		bytes.Contains(peek, []byte(`"abstract_inverted_index"`)) ||
+		bytes.Contains(peek, []byte(`"authorships"`))
+}
+
+func init() {
+	format.Register(&Format{})
+}
@@ -0,0 +1,389 @@
+package openalex
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/helpers"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+// workTypes maps OpenAlex work types to hub resource types.
+var workTypes = map[string]hubv1.ResourceTypeValue{
+	"article":                 hubv1.ResourceTypeValue_RESOURCE_TYPE_ARTICLE,
+	"review":                  hubv1.ResourceTypeValue_RESOURCE_TYPE_ARTICLE,
+	"letter":                  hubv1.ResourceTypeValue_RESOURCE_TYPE_ARTICLE,
+	"editorial":               hubv1.ResourceTypeValue_RESOURCE_TYPE_ARTICLE,
+	"erratum":                 hubv1.ResourceTypeValue_RESOURCE_TYPE_ARTICLE,
+	"book":                    hubv1.ResourceTypeValue_RESOURCE_TYPE_BOOK,
+	"book-chapter":            hubv1.ResourceTypeValue_RESOURCE_TYPE_BOOK_CHAPTER,
+	"reference-entry":         hubv1.ResourceTypeValue_RESOURCE_TYPE_BOOK_CHAPTER,
+	"dataset":                 hubv1.ResourceTypeValue_RESOURCE_TYPE_DATASET,
+	"dissertation":            hubv1.ResourceTypeValue_RESOURCE_TYPE_DISSERTATION,
+	"preprint":                hubv1.ResourceTypeValue_RESOURCE_TYPE_PREPRINT,
+	"report":                  hubv1.ResourceTypeValue_RESOURCE_TYPE_REPORT,
+	"standard":                hubv1.ResourceTypeValue_RESOURCE_TYPE_STANDARD,
+	"peer-review":             hubv1.ResourceTypeValue_RESOURCE_TYPE_PEER_REVIEW,
+	"supplementary-materials": hubv1.ResourceTypeValue_RESOURCE_TYPE_DATASET,
+}
+
+// isoDate matches the YYYY-MM-DD publication_date OpenAlex reports.
+var isoDate = regexp.MustCompile(`^(\d{4})-(\d{2})-(\d{2})$`)
+
+// Parse reads OpenAlex work JSON and returns hub records. The input may be
+// a single work, an array of works, or an API list response whose works
+// are in "results".
+func (f *Format) Parse(r io.Reader, _ *format.ParseOptions) ([]*hubv1.Record, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("reading input: %w", err)
+	}
+
+	works, err := decodeWorks(data)
+	if err != nil {
+		return nil, fmt.Errorf("parsing OpenAlex JSON: %w", err)
+	}
+	if len(works) == 0 {
+		return nil, fmt.Errorf("no OpenAlex works found in input")
+	}
+
+	records := make([]*hubv1.Record, 0, len(works))
+	for _, w := range works {
+		records = append(records, workToHub(w))
+	}
+	return records, nil
+}
+
+// decodeWorks decodes a single work, an array of works, or a list
+// response.
+func decodeWorks(data []byte) ([]*Work, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	if data[0] == '[' {
+		var works []*Work
+		if err := json.Unmarshal(data, &works); err != nil {
+			return nil, err
+		}
+		return works, nil
+	}
+
+	var probe map[string]json.RawMessage
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, err
+	}
+	if _, ok := probe["results"]; ok {
+		var list ListResponse
+		if err := json.Unmarshal(data, &list); err != nil {
+			return nil, err
+		}
+		return list.Results, nil
+	}
+	if _, ok := probe["id"]; !ok {
+		return nil, fmt.Errorf("expected a work with an \"id\" or a list response with \"results\"")
+	}
+
+	var w Work
+	if err := json.Unmarshal(data, &w); err != nil {
+		return nil, err
+	}
+	return []*Work{&w}, nil
+}
+
+// workToHub converts an OpenAlex work to a hub record.
+func workToHub(w *Work) *hubv1.Record {
+	record := &hubv1.Record{
+		Title:    w.Title,
+		Language: w.Language,
+		Abstract: reconstructAbstract(w.AbstractInvertedIndex),
+	}
+	if record.Title == "" {
+		record.Title = w.DisplayName
+	}
+
+	if w.Type != "" {
+		record.ResourceType = &hubv1.ResourceType{
+			Type:       workTypes[w.Type],
+			Original:   w.Type,
+			Vocabulary: "openalex",
+		}
+		if record.ResourceType.Type == hubv1.ResourceTypeValue_RESOURCE_TYPE_UNSPECIFIED {
+			record.ResourceType.Type = hubv1.ResourceTypeValue_RESOURCE_TYPE_OTHER
+		}
+	}
+
+	for _, a := range w.Authorships {
+		if c := authorshipToContributor(a); c != nil {
+			record.Contributors = append(record.Contributors, c)
+		}
+	}
+
+	if d := publicationDate(w); d != nil {
+		record.Dates = append(record.Dates, d)
+	}
+
+	if loc := w.PrimaryLocation; loc != nil && loc.Source != nil {
+		record.Publisher = loc.Source.HostOrganizationName
+	}
+	record.Publication = publicationDetails(w)
+	record.Identifiers = identifiers(w)
+	record.Subjects = subjects(w)
+
+	for _, g := range w.Grants {
+		if g.FunderDisplayName == "" {
+			continue
+		}
+		funder := &hubv1.Funder{Name: g.FunderDisplayName}
+		if g.Funder != "" {
+			funder.Identifier = g.Funder
+			funder.IdentifierType = "OpenAlex"
+		}
+		if g.AwardID != "" {
+			funder.AwardNumbers = append(funder.AwardNumbers, g.AwardID)
+		}
+		record.Funders = append(record.Funders, funder)
+	}
+
+	if w.ID != "" {
+		hub.SetExtra(record, "openalex_id", w.ID)
+	}
+	if oa := w.OpenAccess; oa != nil {
+		hub.SetExtra(record, "openalex_is_oa", oa.IsOA)
+		if oa.OAStatus != "" {
+			hub.SetExtra(record, "openalex_oa_status", oa.OAStatus)
+		}
+		if oa.OAURL != "" {
+			hub.SetExtra(record, "openalex_oa_url", oa.OAURL)
+		}
+	}
+
+	record.SourceInfo = &hubv1.SourceInfo{
+		Format:   "openalex",
+		SourceId: w.ID,
+	}
+
+	return record
+}
+
+// reconstructAbstract rebuilds abstract text from OpenAlex's inverted
+// index, which maps each word to the positions it appears at. OpenAlex
+// ships abstracts this way for licensing reasons.
+func reconstructAbstract(index map[string][]int) string {
+	if len(index) == 0 {
+		return ""
+	}
+
+	size := 0
+	for _, positions := range index {
+		for _, p := range positions {
+			if p+1 > size {
+				size = p + 1
+			}
+		}
+	}
+
+	words := make([]string, size)
+	for word, positions := range index {
+		for _, p := range positions {
+			if p >= 0 {
+				words[p] = word
+			}
+		}
+	}
+
+	// Positions can have gaps when OpenAlex drops a token; skip them
+	// rather than leaving double spaces
+	out := words[:0]
+	for _, word := range words {
+		if word != "" {
+			out = append(out, word)
+		}
+	}
+	return strings.Join(out, " ")
+}
+
+// authorshipToContributor converts an authorship to an author contributor,
+// with the institutions as affiliations. Raw affiliation strings stand in
+// when OpenAlex couldn't match an institution.
+func authorshipToContributor(a Authorship) *hubv1.Contributor {
+	name := a.Author.DisplayName
+	if name == "" {
+		name = a.RawAuthorName
+	}
+	if name == "" {
+		return nil
+	}
+
+	c := &hubv1.Contributor{
+		Name:       name,
+		ParsedName: helpers.ParseName(name),
+		Role:       helpers.RelatorLabel("aut"),
+		RoleCode:   "relators:aut",
+		Type:       hubv1.ContributorType_CONTRIBUTOR_TYPE_PERSON,
+		SourceId:   a.Author.ID,
+	}
+	if a.Author.ORCID != "" {
+		c.Identifiers = append(c.Identifiers, hub.NewIdentifier(a.Author.ORCID, hubv1.IdentifierType_IDENTIFIER_TYPE_ORCID))
+	}
+
+	for _, inst := range a.Institutions {
+		if inst.DisplayName == "" {
+			continue
+		}
+		aff := &hubv1.Affiliation{Name: inst.DisplayName}
+		if inst.ROR != "" {
+			aff.Identifier = inst.ROR
+			aff.IdentifierType = "ROR"
+		}
+		c.Affiliations = append(c.Affiliations, aff)
+	}
+	if len(c.Affiliations) == 0 {
+		for _, raw := range a.RawAffiliationStrings {
+			if raw = strings.TrimSpace(raw); raw != "" {
+				c.Affiliations = append(c.Affiliations, &hubv1.Affiliation{Name: raw})
+			}
+		}
+	}
+	if len(c.Affiliations) > 0 {
+		c.Affiliation = c.Affiliations[0].Name
+	}
+	return c
+}
+
+// publicationDate returns the issued date from publication_date, falling
+// back to publication_year.
+func publicationDate(w *Work) *hubv1.DateValue {
+	if m := isoDate.FindStringSubmatch(w.PublicationDate); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		month, _ := strconv.Atoi(m[2])
+		day, _ := strconv.Atoi(m[3])
+		d := hub.NewDateFromYMD(int32(year), int32(month), int32(day), hubv1.DateType_DATE_TYPE_ISSUED)
+		d.Raw = w.PublicationDate
+		return d
+	}
+	if w.PublicationYear > 0 {
+		d := hub.NewDateFromYear(int32(w.PublicationYear), hubv1.DateType_DATE_TYPE_ISSUED)
+		d.Raw = strconv.Itoa(w.PublicationYear)
+		return d
+	}
+	return nil
+}
+
+// publicationDetails builds the container from the primary location's
+// source and the biblio. Repository sources aren't containers, so only
+// their volume and pages, if any, are kept.
+func publicationDetails(w *Work) *hubv1.PublicationDetails {
+	pub := &hubv1.PublicationDetails{
+		Volume: w.Biblio.Volume,
+		Issue:  w.Biblio.Issue,
+		Pages:  joinPages(w.Biblio.FirstPage, w.Biblio.LastPage),
+	}
+	if loc := w.PrimaryLocation; loc != nil && loc.Source != nil && loc.Source.Type != "repository" {
+		src := loc.Source
+		pub.Title = src.DisplayName
+		pub.LIssn = src.ISSNL
+		pub.Issn = src.ISSNL
+		if len(src.ISSN) > 0 {
+			pub.Issn = src.ISSN[0]
+		}
+	}
+	if pub.Title == "" && pub.Volume == "" && pub.Issue == "" && pub.Pages == "" {
+		return nil
+	}
+	return pub
+}
+
+// joinPages combines the first and last pages.
+func joinPages(first, last string) string {
+	switch {
+	case first == "":
+		return last
+	case last == "" || last == first:
+		return first
+	default:
+		return first + "-" + last
+	}
+}
+
+// identifiers collects the DOI, PMID, and PMCID. OpenAlex reports each
+// as a URL; NewIdentifier strips the doi.org prefix, and the PubMed
+// prefixes are stripped here.
+func identifiers(w *Work) []*hubv1.Identifier {
+	var ids []*hubv1.Identifier
+	doi := w.DOI
+	if doi == "" {
+		doi = w.IDs.DOI
+	}
+	if doi != "" {
+		ids = append(ids, hub.NewIdentifier(doi, hubv1.IdentifierType_IDENTIFIER_TYPE_DOI))
+	}
+	if pmid := lastPathSegment(w.IDs.PMID); pmid != "" {
+		ids = append(ids, &hubv1.Identifier{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_PMID, Value: pmid})
+	}
+	if pmcid := lastPathSegment(w.IDs.PMCID); pmcid != "" {
+		ids = append(ids, &hubv1.Identifier{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_PMCID, Value: pmcid})
+	}
+	return ids
+}
+
+// lastPathSegment returns the final segment of a URL such as
+// "https://pubmed.ncbi.nlm.nih.gov/31234567", or s itself if it has none.
+func lastPathSegment(s string) string {
+	s = strings.TrimRight(strings.TrimSpace(s), "/")
+	if i := strings.LastIndex(s, "/"); i >= 0 {
+		return s[i+1:]
+	}
+	return s
+}
+
+// subjects returns keywords, then concepts, highest scoring first.
+// Concepts link to Wikidata where OpenAlex gives one, keeping the
+// OpenAlex concept ID as the source ID.
+func subjects(w *Work) []*hubv1.Subject {
+	var out []*hubv1.Subject
+	seen := make(map[string]bool)
+
+	keywords := append([]Keyword(nil), w.Keywords...)
+	sort.SliceStable(keywords, func(i, j int) bool { return keywords[i].Score > keywords[j].Score })
+	for _, k := range keywords {
+		if k.DisplayName == "" || seen[strings.ToLower(k.DisplayName)] {
+			continue
+		}
+		seen[strings.ToLower(k.DisplayName)] = true
+		out = append(out, &hubv1.Subject{
+			Value:      k.DisplayName,
+			Vocabulary: hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_KEYWORDS,
+			Uri:        k.ID,
+		})
+	}
+
+	concepts := append([]Concept(nil), w.Concepts...)
+	sort.SliceStable(concepts, func(i, j int) bool { return concepts[i].Score > concepts[j].Score })
+	for _, c := range concepts {
+		if c.DisplayName == "" || seen[strings.ToLower(c.DisplayName)] {
+			continue
+		}
+		seen[strings.ToLower(c.DisplayName)] = true
+		subject := &hubv1.Subject{
+			Value:      c.DisplayName,
+			Vocabulary: hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LOCAL,
+			Uri:        c.Wikidata,
+			SourceId:   c.ID,
+		}
+		if subject.Uri == "" {
+			subject.Uri = c.ID
+		}
+		out = append(out, subject)
+	}
+	return out
+}
@@ -0,0 +1,175 @@
+package openalex
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+func parseFile(t *testing.T, path string) []*hubv1.Record {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	records, err := (&Format{}).Parse(f, nil)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	return records
+}
+
+func TestParseListResponse(t *testing.T) {
+	records := parseFile(t, "testdata/works.json")
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+
+	r := records[0]
+	if !strings.HasPrefix(r.Title, "The state of OA") {
+		t.Errorf("Title: got %q", r.Title)
+	}
+	if r.ResourceType.Type != hubv1.ResourceTypeValue_RESOURCE_TYPE_ARTICLE || r.ResourceType.Original != "article" {
+		t.Errorf("ResourceType: got %v", r.ResourceType)
+	}
+	if want := "Despite growing interest in Open Access (OA) to scholarly literature, there is unclear in data."; r.Abstract != want {
+		t.Errorf("Abstract:\n got %q\nwant %q", r.Abstract, want)
+	}
+	if d := hub.GetDateIssued(r); d == nil || d.Year != 2018 || d.Month != 2 || d.Day != 13 {
+		t.Errorf("Date issued: got %v", d)
+	}
+	if r.Language != "en" {
+		t.Errorf("Language: got %q", r.Language)
+	}
+
+	if len(r.Contributors) != 3 {
+		t.Fatalf("expected 3 contributors, got %d", len(r.Contributors))
+	}
+	first := r.Contributors[0]
+	if first.ParsedName.GetFamily() != "Piwowar" || first.RoleCode != "relators:aut" {
+		t.Errorf("first author: got %v", first)
+	}
+	if len(first.Identifiers) != 1 || first.Identifiers[0].Value != "0000-0003-1613-5981" {
+		t.Errorf("ORCID: got %v", first.Identifiers)
+	}
+	if len(first.Affiliations) != 1 || first.Affiliations[0].Name != "Impactstory, Sanford, NC, USA" {
+		t.Errorf("raw affiliation fallback: got %v", first.Affiliations)
+	}
+	last := r.Contributors[2]
+	if len(last.Affiliations) != 2 || last.Affiliations[0].Identifier != "https://ror.org/03c4mmv16" || last.Affiliation != "University of Ottawa" {
+		t.Errorf("institution affiliations: got %v", last.Affiliations)
+	}
+
+	pub := r.Publication
+	if pub.GetTitle() != "PeerJ" || pub.GetVolume() != "6" || pub.GetPages() != "e4375" || pub.GetLIssn() != "2167-8359" {
+		t.Errorf("Publication: got %v", pub)
+	}
+	if r.Publisher != "PeerJ, Inc." {
+		t.Errorf("Publisher: got %q", r.Publisher)
+	}
+
+	if doi := hub.GetDOI(r); doi == nil || doi.Value != "10.7717/peerj.4375" {
+		t.Errorf("DOI: got %v", doi)
+	}
+	if pmid := hub.GetIdentifier(r, hubv1.IdentifierType_IDENTIFIER_TYPE_PMID); pmid == nil || pmid.Value != "29456894" {
+		t.Errorf("PMID: got %v", pmid)
+	}
+
+	var subjects []string
+	for _, s := range r.Subjects {
+		subjects = append(subjects, s.Value)
+	}
+	if got := strings.Join(subjects, "|"); got != "Open Access|Data science|Computer science" {
+		t.Errorf("Subjects: got %q", got)
+	}
+	if s := r.Subjects[1]; s.Uri != "https://www.wikidata.org/wiki/Q2374463" || s.SourceId != "https://openalex.org/C2522767166" {
+		t.Errorf("concept subject: got %v", s)
+	}
+
+	if len(r.Funders) != 1 || r.Funders[0].Name != "Alfred P. Sloan Foundation" || r.Funders[0].AwardNumbers[0] != "G-2016-7230" {
+		t.Errorf("Funders: got %v", r.Funders)
+	}
+
+	if got := hub.GetExtraString(r, "openalex_oa_status"); got != "gold" {
+		t.Errorf("openalex_oa_status: got %q", got)
+	}
+	if got, _ := hub.GetExtra(r, "openalex_is_oa"); got != true {
+		t.Errorf("openalex_is_oa: got %v", got)
+	}
+	if r.SourceInfo.GetSourceId() != "https://openalex.org/W2741809807" {
+		t.Errorf("SourceInfo: got %v", r.SourceInfo)
+	}
+
+	thesis := records[1]
+	if thesis.Title != "Thin film growth on oxide substrates" {
+		t.Errorf("display_name fallback: got %q", thesis.Title)
+	}
+	if d := hub.GetDateIssued(thesis); d == nil || d.Year != 2021 || d.Precision != hubv1.DatePrecision_DATE_PRECISION_YEAR {
+		t.Errorf("publication_year fallback: got %v", d)
+	}
+	if thesis.Publication != nil {
+		t.Errorf("a repository is not a container, got %v", thesis.Publication)
+	}
+	if thesis.Abstract != "" || len(thesis.Identifiers) != 0 {
+		t.Errorf("expected no abstract or identifiers, got %q, %v", thesis.Abstract, thesis.Identifiers)
+	}
+}
+
+func TestParseSingleWorkAndArray(t *testing.T) {
+	work := `{"id": "https://openalex.org/W1", "title": "One", "type": "book-chapter"}`
+	for name, input := range map[string]string{
+		"single": work,
+		"array":  "[" + work + "]",
+	} {
+		t.Run(name, func(t *testing.T) {
+			records, err := (&Format{}).Parse(strings.NewReader(input), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(records) != 1 || records[0].Title != "One" ||
+				records[0].ResourceType.Type != hubv1.ResourceTypeValue_RESOURCE_TYPE_BOOK_CHAPTER {
+				t.Errorf("got %v", records)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for name, input := range map[string]string{
+		"empty":         "",
+		"empty results": `{"meta": {}, "results": []}`,
+		"not a work":    `{"title": "no id"}`,
+		"invalid":       `{"id": `,
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := (&Format{}).Parse(strings.NewReader(input), nil); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestReconstructAbstract(t *testing.T) {
+	index := map[string][]int{"the": {0, 3}, "cat": {1}, "saw": {2}, "dog": {5}}
+	if got := reconstructAbstract(index); got != "the cat saw the dog" {
+		t.Errorf("got %q", got)
+	}
+	if got := reconstructAbstract(nil); got != "" {
+		t.Errorf("nil index: got %q", got)
+	}
+}
+
+func TestCanParse(t *testing.T) {
+	f := &Format{}
+	if !f.CanParse([]byte(`{"id": "https://openalex.org/W2741809807"}`)) {
+		t.Error("expected CanParse for an OpenAlex work")
+	}
+	if f.CanParse([]byte(`{"nid": [{"value": 1}]}`)) {
+		t.Error("unexpected CanParse for Drupal JSON")
+	}
+}
@@ -0,0 +1,123 @@
+package openalex
+
+// ListResponse is a page of works from the OpenAlex API.
+type ListResponse struct {
+	Meta    map[string]any `json:"meta"`
+	Results []*Work        `json:"results"`
+}
+
+// Work is an OpenAlex work. Only the fields crosswalk maps are decoded.
+type Work struct {
+	ID                    string           `json:"id"`
+	DOI                   string           `json:"doi"`
+	Title                 string           `json:"title"`
+	DisplayName           string           `json:"display_name"`
+	PublicationYear       int              `json:"publication_year"`
+	PublicationDate       string           `json:"publication_date"`
+	Type                  string           `json:"type"`
+	Language              string           `json:"language"`
+	IDs                   IDs              `json:"ids"`
+	PrimaryLocation       *Location        `json:"primary_location"`
+	OpenAccess            *OpenAccess      `json:"open_access"`
+	Authorships           []Authorship     `json:"authorships"`
+	Biblio                Biblio           `json:"biblio"`
+	Concepts              []Concept        `json:"concepts"`
+	Keywords              []Keyword        `json:"keywords"`
+	Grants                []Grant          `json:"grants"`
+	AbstractInvertedIndex map[string][]int `json:"abstract_inverted_index"`
+}
+
+// IDs holds the work's identifiers in other systems, as URLs.
+type IDs struct {
+	OpenAlex string `json:"openalex"`
+	DOI      string `json:"doi"`
+	MAG      string `json:"mag"`
+	PMID     string `json:"pmid"`
+	PMCID    string `json:"pmcid"`
+}
+
+// Location is a place the work is hosted, such as a journal or repository.
+type Location struct {
+	IsOA           bool    `json:"is_oa"`
+	LandingPageURL string  `json:"landing_page_url"`
+	PDFURL         string  `json:"pdf_url"`
+	License        string  `json:"license"`
+	Version        string  `json:"version"`
+	Source         *Source `json:"source"`
+}
+
+// Source is the journal, repository, or conference hosting a location.
+type Source struct {
+	ID                   string   `json:"id"`
+	DisplayName          string   `json:"display_name"`
+	ISSNL                string   `json:"issn_l"`
+	ISSN                 []string `json:"issn"`
+	HostOrganizationName string   `json:"host_organization_name"`
+	Type                 string   `json:"type"`
+}
+
+// OpenAccess summarizes the work's open access status.
+type OpenAccess struct {
+	IsOA     bool   `json:"is_oa"`
+	OAStatus string `json:"oa_status"`
+	OAURL    string `json:"oa_url"`
+}
+
+// Authorship is an author's credit on a work, with the institutions
+// listed for them on that work.
+type Authorship struct {
+	AuthorPosition        string        `json:"author_position"`
+	Author                Author        `json:"author"`
+	Institutions          []Institution `json:"institutions"`
+	RawAuthorName         string        `json:"raw_author_name"`
+	RawAffiliationStrings []string      `json:"raw_affiliation_strings"`
+	IsCorresponding       bool          `json:"is_corresponding"`
+}
+
+// Author is an OpenAlex author.
+type Author struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"display_name"`
+	ORCID       string `json:"orcid"`
+}
+
+// Institution is an OpenAlex institution.
+type Institution struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"display_name"`
+	ROR         string `json:"ror"`
+	CountryCode string `json:"country_code"`
+	Type        string `json:"type"`
+}
+
+// Biblio holds the volume, issue, and page range.
+type Biblio struct {
+	Volume    string `json:"volume"`
+	Issue     string `json:"issue"`
+	FirstPage string `json:"first_page"`
+	LastPage  string `json:"last_page"`
+}
+
+// Concept is an OpenAlex concept tagged on a work, with the model's
+// confidence score.
+type Concept struct {
+	ID          string  `json:"id"`
+	Wikidata    string  `json:"wikidata"`
+	DisplayName string  `json:"display_name"`
+	Level       int     `json:"level"`
+	Score       float64 `json:"score"`
+}
+
+// Keyword is a keyword OpenAlex extracted for a work.
+type Keyword struct {
+	ID          string  `json:"id"`
+	DisplayName string  `json:"display_name"`
+	Score       float64 `json:"score"`
+}
+
+// Grant is a funding award credited on a work.
+type Grant struct {
+	Funder            string `json:"funder"`
+	FunderDisplayName string `json:"funder_display_name"`
+	AwardID           string `json:"award_id"`
+}
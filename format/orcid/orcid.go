@@ -0,0 +1,74 @@
+// Package orcid provides a parser for ORCID works from the ORCID 3.0 API,
+// so a researcher's works can be pulled into hub records.
+//
+// Both the XML and JSON representations are read:
+//   - a single work (work:work, /v3.0/{orcid}/work/{put-code})
+//   - bulk work results (bulk:bulk, /v3.0/{orcid}/works/{put-codes})
+//   - the works summary (activities:works, /v3.0/{orcid}/works), whose
+//     groups hold one work-summary per source; only the first, preferred
+//     version of each work is kept
+//   - a full record (record:record, /v3.0/{orcid}/record), whose works
+//     summary is read the same way
+//
+// The mapping covers title, subtitle and translated title, type,
+// publication date, journal title, short description, language, country,
+// external identifiers, URL, contributors with ORCID iDs and roles, and
+// formatted citations. Work summaries carry no contributors; when the
+// input is a full record, the profile owner is added as the author of
+// each work that lists none.
+//
+// The put-code, the profile's ORCID iD, and the name of the source that
+// added the work are kept in the orcid_put_code, orcid_id, and
+// orcid_source extra fields; a BibTeX citation, if any, in orcid_bibtex.
+package orcid
+
+import (
+	"bytes"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+)
+
+// Format implements the ORCID works format.
+type Format struct{}
+
+// Ensure Format implements the interfaces
+var (
+	_ format.Format = (*Format)(nil)
+	_ format.Parser = (*Format)(nil)
+)
+
+// Name returns the format identifier.
+func (f *Format) Name() string {
+	return "orcid"
+}
+
+// Description returns a human-readable format description.
+func (f *Format) Description() string {
+	return "ORCID works XML/JSON (3.0 API)"
+}
+
+// Extensions returns file extensions associated with this format.
+func (f *Format) Extensions() []string {
+	return []string{"xml", "json"}
+}
+
+// CanParse returns true if the input looks like ORCID work XML or JSON.
+func (f *Format) CanParse(peek []byte) bool {
+	peek = bytes.TrimSpace(peek)
+	if len(peek) == 0 {
+		return false
+	}
+	switch peek[0] {
+	case '<':
+		return bytes.Contains(peek, []byte("http://www.orcid.org/ns/work"))
+	case '{', '[':
+		return bytes.Contains(peek, []byte(`"put-code"`)) ||
+			bytes.Contains(peek, []byte(`"work-summary"`)) ||
+			bytes.Contains(peek, []byte(`"orcid-identifier"`))
+	}
+	return false
+}
+
+func init() {
+	format.Register(&Format{})
+}
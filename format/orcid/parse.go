@@ -0,0 +1,526 @@
+package orcid
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/helpers"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+// workTypes maps ORCID 3.0 work types to hub resource types.
+var workTypes = map[string]hubv1.ResourceTypeValue{
+	"journal-article":                hubv1.ResourceTypeValue_RESOURCE_TYPE_ARTICLE,
+	"magazine-article":               hubv1.ResourceTypeValue_RESOURCE_TYPE_ARTICLE,
+	"newsletter-article":             hubv1.ResourceTypeValue_RESOURCE_TYPE_ARTICLE,
+	"review":                         hubv1.ResourceTypeValue_RESOURCE_TYPE_ARTICLE,
+	"book-review":                    hubv1.ResourceTypeValue_RESOURCE_TYPE_ARTICLE,
+	"newspaper-article":              hubv1.ResourceTypeValue_RESOURCE_TYPE_NEWSPAPER_ARTICLE,
+	"journal-issue":                  hubv1.ResourceTypeValue_RESOURCE_TYPE_JOURNAL,
+	"book":                           hubv1.ResourceTypeValue_RESOURCE_TYPE_BOOK,
+	"edited-book":                    hubv1.ResourceTypeValue_RESOURCE_TYPE_BOOK,
+	"manual":                         hubv1.ResourceTypeValue_RESOURCE_TYPE_BOOK,
+	"book-chapter":                   hubv1.ResourceTypeValue_RESOURCE_TYPE_BOOK_CHAPTER,
+	"dictionary-entry":               hubv1.ResourceTypeValue_RESOURCE_TYPE_BOOK_CHAPTER,
+	"encyclopedia-entry":             hubv1.ResourceTypeValue_RESOURCE_TYPE_BOOK_CHAPTER,
+	"conference-paper":               hubv1.ResourceTypeValue_RESOURCE_TYPE_CONFERENCE_PAPER,
+	"conference-abstract":            hubv1.ResourceTypeValue_RESOURCE_TYPE_CONFERENCE_PAPER,
+	"conference-poster":              hubv1.ResourceTypeValue_RESOURCE_TYPE_POSTER,
+	"conference-proceedings":         hubv1.ResourceTypeValue_RESOURCE_TYPE_CONFERENCE_PROCEEDING,
+	"conference-output":              hubv1.ResourceTypeValue_RESOURCE_TYPE_CONFERENCE_PAPER,
+	"conference-presentation":        hubv1.ResourceTypeValue_RESOURCE_TYPE_PRESENTATION,
+	"lecture-speech":                 hubv1.ResourceTypeValue_RESOURCE_TYPE_PRESENTATION,
+	"data-set":                       hubv1.ResourceTypeValue_RESOURCE_TYPE_DATASET,
+	"dissertation-thesis":            hubv1.ResourceTypeValue_RESOURCE_TYPE_DISSERTATION,
+	"dissertation":                   hubv1.ResourceTypeValue_RESOURCE_TYPE_DISSERTATION,
+	"supervised-student-publication": hubv1.ResourceTypeValue_RESOURCE_TYPE_THESIS,
+	"preprint":                       hubv1.ResourceTypeValue_RESOURCE_TYPE_PREPRINT,
+	"working-paper":                  hubv1.ResourceTypeValue_RESOURCE_TYPE_WORKING_PAPER,
+	"report":                         hubv1.ResourceTypeValue_RESOURCE_TYPE_REPORT,
+	"standards-and-policy":           hubv1.ResourceTypeValue_RESOURCE_TYPE_STANDARD,
+	"technical-standard":             hubv1.ResourceTypeValue_RESOURCE_TYPE_STANDARD,
+	"patent":                         hubv1.ResourceTypeValue_RESOURCE_TYPE_PATENT,
+	"software":                       hubv1.ResourceTypeValue_RESOURCE_TYPE_SOFTWARE,
+	"research-tool":                  hubv1.ResourceTypeValue_RESOURCE_TYPE_SOFTWARE,
+	"website":                        hubv1.ResourceTypeValue_RESOURCE_TYPE_WEBPAGE,
+	"online-resource":                hubv1.ResourceTypeValue_RESOURCE_TYPE_WEBPAGE,
+	"image":                          hubv1.ResourceTypeValue_RESOURCE_TYPE_IMAGE,
+	"moving-image":                   hubv1.ResourceTypeValue_RESOURCE_TYPE_VIDEO,
+	"sound":                          hubv1.ResourceTypeValue_RESOURCE_TYPE_AUDIO,
+	"cartographic-material":          hubv1.ResourceTypeValue_RESOURCE_TYPE_MAP,
+	"physical-object":                hubv1.ResourceTypeValue_RESOURCE_TYPE_OBJECT,
+	"annotation":                     hubv1.ResourceTypeValue_RESOURCE_TYPE_TEXT,
+	"translation":                    hubv1.ResourceTypeValue_RESOURCE_TYPE_TEXT,
+}
+
+// idTypes maps ORCID external identifier types to hub identifier types.
+var idTypes = map[string]hubv1.IdentifierType{
+	"doi":    hubv1.IdentifierType_IDENTIFIER_TYPE_DOI,
+	"isbn":   hubv1.IdentifierType_IDENTIFIER_TYPE_ISBN,
+	"issn":   hubv1.IdentifierType_IDENTIFIER_TYPE_ISSN,
+	"pmid":   hubv1.IdentifierType_IDENTIFIER_TYPE_PMID,
+	"pmc":    hubv1.IdentifierType_IDENTIFIER_TYPE_PMCID,
+	"arxiv":  hubv1.IdentifierType_IDENTIFIER_TYPE_ARXIV,
+	"handle": hubv1.IdentifierType_IDENTIFIER_TYPE_HANDLE,
+	"uri":    hubv1.IdentifierType_IDENTIFIER_TYPE_URL,
+	"isni":   hubv1.IdentifierType_IDENTIFIER_TYPE_ISNI,
+}
+
+// contributorRoles maps ORCID contributor roles to MARC relator codes.
+// Roles without a close relator keep the ORCID role as their label.
+var contributorRoles = map[string]string{
+	"author":                  "aut",
+	"editor":                  "edt",
+	"chair-or-translator":     "trl",
+	"principal-investigator":  "res",
+	"co-investigator":         "res",
+	"postdoctoral-researcher": "res",
+	"graduate-student":        "res",
+	"support-staff":           "ctb",
+}
+
+// orcidPath matches an ORCID iD at the start of a work's path, such as
+// "/0000-0002-1825-0097/work/733536".
+var orcidPath = regexp.MustCompile(`^/?(\d{4}-\d{4}-\d{4}-\d{3}[\dX])/`)
+
+// document is the works found in the input, with the profile owner's
+// name when the input is a full record.
+type document struct {
+	works []*Work
+	owner *PersonName
+}
+
+// Parse reads ORCID work XML or JSON and returns hub records, one per work.
+func (f *Format) Parse(r io.Reader, opts *format.ParseOptions) ([]*hubv1.Record, error) {
+	if opts == nil {
+		opts = format.NewParseOptions()
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("reading input: %w", err)
+	}
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil, fmt.Errorf("no ORCID works found in input")
+	}
+
+	var doc *document
+	if data[0] == '<' {
+		doc, err = decodeXML(data)
+		if err != nil {
+			return nil, fmt.Errorf("parsing ORCID XML: %w", err)
+		}
+	} else {
+		doc, err = decodeJSON(data)
+		if err != nil {
+			return nil, fmt.Errorf("parsing ORCID JSON: %w", err)
+		}
+	}
+	if len(doc.works) == 0 {
+		return nil, fmt.Errorf("no ORCID works found in input")
+	}
+
+	records := make([]*hubv1.Record, 0, len(doc.works))
+	for _, w := range doc.works {
+		records = append(records, workToHub(w, doc.owner, opts))
+	}
+	return records, nil
+}
+
+// decodeXML walks the document for work and work-summary elements, so a
+// work, bulk response, works summary, or full record are all read the
+// same way. Within a works summary group only the first work-summary is
+// kept.
+func decodeXML(data []byte) (*document, error) {
+	doc := &document{}
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	inGroup, taken := false, false
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch {
+			case t.Name.Space == activitiesNS && t.Name.Local == "group":
+				inGroup, taken = true, false
+			case t.Name.Space == workNS && (t.Name.Local == "work" || t.Name.Local == "work-summary"):
+				if inGroup && taken {
+					if err := dec.Skip(); err != nil {
+						return nil, err
+					}
+					continue
+				}
+				var w Work
+				if err := dec.DecodeElement(&w, &t); err != nil {
+					return nil, err
+				}
+				doc.works = append(doc.works, &w)
+				taken = inGroup
+			case t.Name.Space == personNS && t.Name.Local == "person":
+				var p Person
+				if err := dec.DecodeElement(&p, &t); err != nil {
+					return nil, err
+				}
+				doc.owner = p.Name
+			}
+		case xml.EndElement:
+			if t.Name.Space == activitiesNS && t.Name.Local == "group" {
+				inGroup = false
+			}
+		}
+	}
+	return doc, nil
+}
+
+// decodeJSON decodes a work, an array of works, a bulk response, a works
+// summary, or a full record.
+func decodeJSON(data []byte) (*document, error) {
+	if data[0] == '[' {
+		var works []*Work
+		if err := json.Unmarshal(data, &works); err != nil {
+			return nil, err
+		}
+		return &document{works: works}, nil
+	}
+
+	var probe map[string]json.RawMessage
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case probe["activities-summary"] != nil:
+		var rec Record
+		if err := json.Unmarshal(data, &rec); err != nil {
+			return nil, err
+		}
+		doc := &document{}
+		if rec.Activities != nil && rec.Activities.Works != nil {
+			doc.works = rec.Activities.Works.preferred()
+		}
+		if rec.Person != nil {
+			doc.owner = rec.Person.Name
+		}
+		return doc, nil
+	case probe["group"] != nil:
+		var works Works
+		if err := json.Unmarshal(data, &works); err != nil {
+			return nil, err
+		}
+		return &document{works: works.preferred()}, nil
+	case probe["bulk"] != nil:
+		var bulk Bulk
+		if err := json.Unmarshal(data, &bulk); err != nil {
+			return nil, err
+		}
+		doc := &document{}
+		for _, item := range bulk.Bulk {
+			// Failed put-codes come back as error entries with no work
+			if item.Work != nil {
+				doc.works = append(doc.works, item.Work)
+			}
+		}
+		return doc, nil
+	case probe["put-code"] != nil || probe["title"] != nil:
+		var w Work
+		if err := json.Unmarshal(data, &w); err != nil {
+			return nil, err
+		}
+		return &document{works: []*Work{&w}}, nil
+	}
+	return nil, fmt.Errorf("expected a work, a works summary with \"group\", a bulk response, or a record")
+}
+
+// preferred returns the first work-summary of each group, the version
+// ORCID displays.
+func (w *Works) preferred() []*Work {
+	var works []*Work
+	for _, g := range w.Groups {
+		if len(g.WorkSummaries) > 0 && g.WorkSummaries[0] != nil {
+			works = append(works, g.WorkSummaries[0])
+		}
+	}
+	return works
+}
+
+// workToHub converts an ORCID work to a hub record.
+func workToHub(w *Work, owner *PersonName, opts *format.ParseOptions) *hubv1.Record {
+	record := &hubv1.Record{
+		Abstract:       opts.CleanText(string(w.ShortDescription)),
+		Language:       strings.TrimSpace(string(w.LanguageCode)),
+		PlacePublished: strings.TrimSpace(string(w.Country)),
+	}
+
+	if t := w.Title; t != nil {
+		record.Title = opts.CleanText(string(t.Title))
+		if sub := opts.CleanText(string(t.Subtitle)); sub != "" && record.Title != "" {
+			record.Title += ": " + sub
+		}
+		if tt := opts.CleanText(string(t.TranslatedTitle)); tt != "" {
+			record.AltTitle = append(record.AltTitle, tt)
+		}
+	}
+
+	if w.Type != "" {
+		original := strings.ToLower(strings.TrimSpace(w.Type))
+		record.ResourceType = &hubv1.ResourceType{
+			Type:       workTypes[strings.ReplaceAll(original, "_", "-")],
+			Original:   original,
+			Vocabulary: "orcid",
+		}
+		if record.ResourceType.Type == hubv1.ResourceTypeValue_RESOURCE_TYPE_UNSPECIFIED {
+			record.ResourceType.Type = hubv1.ResourceTypeValue_RESOURCE_TYPE_OTHER
+		}
+	}
+
+	if d := publicationDate(w.PublicationDate); d != nil {
+		record.Dates = append(record.Dates, d)
+	}
+
+	if title := opts.CleanText(string(w.JournalTitle)); title != "" {
+		record.Publication = &hubv1.PublicationDetails{Title: title}
+	}
+
+	if w.ExternalIDs != nil {
+		for _, id := range w.ExternalIDs.ExternalID {
+			addExternalID(record, id)
+		}
+	}
+	if u := strings.TrimSpace(string(w.URL)); u != "" {
+		addIdentifier(record, urlIdentifier(u))
+	}
+
+	orcid := ""
+	if m := orcidPath.FindStringSubmatch(w.Path); m != nil {
+		orcid = m[1]
+	}
+
+	if w.Contributors != nil {
+		for _, c := range w.Contributors.Contributor {
+			if contrib := toContributor(c); contrib != nil {
+				record.Contributors = append(record.Contributors, contrib)
+			}
+		}
+	}
+	if len(record.Contributors) == 0 {
+		if c := ownerContributor(owner, orcid); c != nil {
+			record.Contributors = append(record.Contributors, c)
+		}
+	}
+
+	if c := w.Citation; c != nil {
+		value := strings.TrimSpace(c.CitationValue)
+		switch {
+		case value == "":
+		case strings.EqualFold(c.CitationType, "bibtex"):
+			hub.SetExtra(record, "orcid_bibtex", value)
+		case strings.HasPrefix(strings.ToLower(c.CitationType), "formatted-"):
+			record.PreferredCitation = value
+		}
+	}
+
+	if w.PutCode != 0 {
+		hub.SetExtra(record, "orcid_put_code", strconv.FormatInt(w.PutCode, 10))
+	}
+	if orcid != "" {
+		hub.SetExtra(record, "orcid_id", orcid)
+	}
+	if w.Source != nil && w.Source.SourceName != "" {
+		hub.SetExtra(record, "orcid_source", string(w.Source.SourceName))
+	}
+
+	record.SourceInfo = &hubv1.SourceInfo{
+		Format:   "orcid",
+		SourceId: w.Path,
+	}
+
+	return record
+}
+
+// addExternalID adds an external identifier by its relationship: the
+// work's own identifiers become record identifiers, a part-of ISSN or
+// ISBN describes the container, and part-of and version-of identifiers
+// become relations. Funding identifiers (funded-by) carry no funder name
+// and are skipped.
+func addExternalID(record *hubv1.Record, id ExternalID) {
+	value := strings.TrimSpace(id.Value)
+	if value == "" {
+		value = strings.TrimSpace(string(id.Normalized))
+	}
+	url := strings.TrimSpace(string(id.URL))
+	if value == "" && url == "" {
+		return
+	}
+	idType, known := idTypes[strings.ToLower(id.Type)]
+
+	switch strings.ToLower(id.Relationship) {
+	case "", "self":
+		switch {
+		case known && value != "":
+			addIdentifier(record, hub.NewIdentifier(value, idType))
+		case url != "":
+			// Identifiers hub has no type for are kept by their resolver URL
+			addIdentifier(record, urlIdentifier(url))
+		}
+	case "part-of":
+		if idType == hubv1.IdentifierType_IDENTIFIER_TYPE_ISSN {
+			if record.Publication == nil {
+				record.Publication = &hubv1.PublicationDetails{}
+			}
+			if record.Publication.Issn == "" {
+				record.Publication.Issn = value
+			}
+			return
+		}
+		record.Relations = append(record.Relations, externalRelation(hubv1.RelationType_RELATION_TYPE_PART_OF, value, url, idType))
+	case "version-of":
+		record.Relations = append(record.Relations, externalRelation(hubv1.RelationType_RELATION_TYPE_VERSION_OF, value, url, idType))
+	}
+}
+
+// externalRelation builds a relation to the resource an external
+// identifier names.
+func externalRelation(relType hubv1.RelationType, value, url string, idType hubv1.IdentifierType) *hubv1.Relation {
+	rel := &hubv1.Relation{Type: relType, TargetUri: url}
+	if value != "" && idType != hubv1.IdentifierType_IDENTIFIER_TYPE_UNSPECIFIED {
+		rel.TargetId = hub.NormalizeIdentifier(value, idType)
+		rel.TargetIdType = idType
+	} else if rel.TargetUri == "" {
+		rel.TargetTitle = value
+	}
+	return rel
+}
+
+// addIdentifier adds an identifier unless the record already has it.
+// ORCID often repeats the DOI as the work URL.
+func addIdentifier(record *hubv1.Record, id *hubv1.Identifier) {
+	for _, existing := range record.Identifiers {
+		if existing.Type == id.Type && strings.EqualFold(existing.Value, id.Value) {
+			return
+		}
+	}
+	record.Identifiers = append(record.Identifiers, id)
+}
+
+// urlIdentifier returns an identifier for a URL, as a DOI or handle when
+// the URL resolves one.
+func urlIdentifier(u string) *hubv1.Identifier {
+	switch t := hub.DetectIdentifierType(u); t {
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_DOI, hubv1.IdentifierType_IDENTIFIER_TYPE_HANDLE:
+		return hub.NewIdentifier(u, t)
+	}
+	return hub.NewIdentifier(u, hubv1.IdentifierType_IDENTIFIER_TYPE_URL)
+}
+
+// toContributor converts an ORCID contributor. Contributors without a
+// role are authors.
+func toContributor(c Contributor) *hubv1.Contributor {
+	name := strings.TrimSpace(string(c.CreditName))
+	if name == "" {
+		return nil
+	}
+
+	contrib := &hubv1.Contributor{
+		Name:       name,
+		ParsedName: helpers.ParseName(name),
+		Type:       hubv1.ContributorType_CONTRIBUTOR_TYPE_PERSON,
+	}
+
+	role := "author"
+	if c.Attributes != nil && c.Attributes.Role != "" {
+		role = strings.ToLower(strings.TrimSpace(c.Attributes.Role))
+	}
+	if code, ok := contributorRoles[role]; ok {
+		contrib.Role = helpers.RelatorLabel(code)
+		contrib.RoleCode = "relators:" + code
+	} else {
+		contrib.Role = role
+	}
+
+	if o := c.ContributorORCID; o != nil {
+		id := o.Path
+		if id == "" {
+			id = o.URI
+		}
+		if id != "" {
+			contrib.Identifiers = append(contrib.Identifiers, hub.NewIdentifier(id, hubv1.IdentifierType_IDENTIFIER_TYPE_ORCID))
+		}
+	}
+	return contrib
+}
+
+// ownerContributor returns the profile owner as an author, or nil when
+// the input carried no name for them.
+func ownerContributor(owner *PersonName, orcid string) *hubv1.Contributor {
+	if owner == nil {
+		return nil
+	}
+	given := strings.TrimSpace(string(owner.GivenNames))
+	family := strings.TrimSpace(string(owner.FamilyName))
+	name := strings.TrimSpace(string(owner.CreditName))
+	if name == "" {
+		name = strings.TrimSpace(given + " " + family)
+	}
+	if name == "" {
+		return nil
+	}
+
+	c := &hubv1.Contributor{
+		Name:     name,
+		Role:     helpers.RelatorLabel("aut"),
+		RoleCode: "relators:aut",
+		Type:     hubv1.ContributorType_CONTRIBUTOR_TYPE_PERSON,
+	}
+	if family != "" {
+		c.ParsedName = &hubv1.ParsedName{Given: given, Family: family, FullName: name}
+	} else {
+		c.ParsedName = helpers.ParseName(name)
+	}
+	if orcid != "" {
+		c.Identifiers = append(c.Identifiers, hub.NewIdentifier(orcid, hubv1.IdentifierType_IDENTIFIER_TYPE_ORCID))
+	}
+	return c
+}
+
+// publicationDate converts a fuzzy date to an issued date.
+func publicationDate(d *FuzzyDate) *hubv1.DateValue {
+	if d == nil {
+		return nil
+	}
+	year, _ := strconv.Atoi(strings.TrimSpace(string(d.Year)))
+	if year <= 0 {
+		return nil
+	}
+	month, _ := strconv.Atoi(strings.TrimSpace(string(d.Month)))
+	day, _ := strconv.Atoi(strings.TrimSpace(string(d.Day)))
+
+	var date *hubv1.DateValue
+	switch {
+	case month > 0 && day > 0:
+		date = hub.NewDateFromYMD(int32(year), int32(month), int32(day), hubv1.DateType_DATE_TYPE_ISSUED)
+		date.Raw = fmt.Sprintf("%04d-%02d-%02d", year, month, day)
+	case month > 0:
+		date = hub.NewDateFromYearMonth(int32(year), int32(month), hubv1.DateType_DATE_TYPE_ISSUED)
+		date.Raw = fmt.Sprintf("%04d-%02d", year, month)
+	default:
+		date = hub.NewDateFromYear(int32(year), hubv1.DateType_DATE_TYPE_ISSUED)
+		date.Raw = fmt.Sprintf("%04d", year)
+	}
+	return date
+}
@@ -0,0 +1,239 @@
+package orcid
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+func parseFile(t *testing.T, path string) []*hubv1.Record {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	records, err := (&Format{}).Parse(f, nil)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	return records
+}
+
+func TestParseWorkXML(t *testing.T) {
+	records := parseFile(t, "testdata/work.xml")
+	if len(records) != 1 {
+		t.Fatalf("expected 1 record, got %d", len(records))
+	}
+	r := records[0]
+
+	if r.Title != "Sediment transport in a regulated river: Evidence from the Lehigh Gap" {
+		t.Errorf("Title: got %q", r.Title)
+	}
+	if len(r.AltTitle) != 1 || r.AltTitle[0] != "Sedimenttransport in einem regulierten Fluss" {
+		t.Errorf("AltTitle: got %v", r.AltTitle)
+	}
+	if r.Abstract != "We measure bedload transport below a dam." {
+		t.Errorf("Abstract: got %q", r.Abstract)
+	}
+	if r.ResourceType.Type != hubv1.ResourceTypeValue_RESOURCE_TYPE_ARTICLE || r.ResourceType.Original != "journal-article" {
+		t.Errorf("ResourceType: got %v", r.ResourceType)
+	}
+	if d := hub.GetDateIssued(r); d == nil || d.Year != 2021 || d.Month != 3 || d.Day != 0 {
+		t.Errorf("Date issued: got %v", d)
+	}
+	if r.Language != "en" || r.PlacePublished != "US" {
+		t.Errorf("Language/PlacePublished: got %q, %q", r.Language, r.PlacePublished)
+	}
+	if r.Publication == nil || r.Publication.Title != "Journal of Hydrology" || r.Publication.Issn != "0022-1694" {
+		t.Errorf("Publication: got %v", r.Publication)
+	}
+
+	if doi := hub.GetDOI(r); doi == nil || doi.Value != "10.1016/j.jhydrol.2021.126012" {
+		t.Errorf("DOI: got %v", doi)
+	}
+	// The DOI, the Scopus EID by its URL; the work URL repeats the DOI
+	if len(r.Identifiers) != 2 || r.Identifiers[1].Type != hubv1.IdentifierType_IDENTIFIER_TYPE_URL ||
+		!strings.Contains(r.Identifiers[1].Value, "scopus.com") {
+		t.Errorf("Identifiers: got %v", r.Identifiers)
+	}
+	if rels := hub.GetRelationsByType(r, hubv1.RelationType_RELATION_TYPE_VERSION_OF); len(rels) != 1 ||
+		rels[0].TargetId != "2012.01234" || rels[0].TargetIdType != hubv1.IdentifierType_IDENTIFIER_TYPE_ARXIV {
+		t.Errorf("version-of: got %v", rels)
+	}
+	if len(r.Relations) != 1 || len(r.Funders) != 0 {
+		t.Errorf("funded-by should be skipped: got %v, %v", r.Relations, r.Funders)
+	}
+
+	if len(r.Contributors) != 3 {
+		t.Fatalf("expected 3 contributors, got %d", len(r.Contributors))
+	}
+	first := r.Contributors[0]
+	if first.Name != "Josiah Carberry" || first.RoleCode != "relators:aut" || first.ParsedName.GetFamily() != "Carberry" ||
+		len(first.Identifiers) != 1 || first.Identifiers[0].Value != "0000-0002-1825-0097" {
+		t.Errorf("first contributor: got %v", first)
+	}
+	if c := r.Contributors[1]; c.RoleCode != "relators:aut" {
+		t.Errorf("contributor without role: got %v", c)
+	}
+	if c := r.Contributors[2]; c.RoleCode != "" || c.Role != "co-inventor" {
+		t.Errorf("unmapped role: got %v", c)
+	}
+
+	if r.PreferredCitation != "" || !strings.HasPrefix(hub.GetExtraString(r, "orcid_bibtex"), "@article{") {
+		t.Errorf("citation: got %q, %q", r.PreferredCitation, hub.GetExtraString(r, "orcid_bibtex"))
+	}
+	if hub.GetExtraString(r, "orcid_put_code") != "733536" || hub.GetExtraString(r, "orcid_id") != "0000-0002-1825-0097" ||
+		hub.GetExtraString(r, "orcid_source") != "Crossref" {
+		t.Errorf("extras: got %v", r.Extra)
+	}
+	if r.SourceInfo.GetSourceId() != "/0000-0002-1825-0097/work/733536" {
+		t.Errorf("SourceInfo: got %v", r.SourceInfo)
+	}
+}
+
+func TestParseWorksSummaryJSON(t *testing.T) {
+	records := parseFile(t, "testdata/works.json")
+	if len(records) != 2 {
+		t.Fatalf("expected one record per group, got %d", len(records))
+	}
+
+	r := records[0]
+	if r.Title != "Sediment transport in a regulated river" {
+		t.Errorf("preferred summary: got %q", r.Title)
+	}
+	if d := hub.GetDateIssued(r); d == nil || d.Year != 2021 || d.Month != 3 {
+		t.Errorf("Date issued: got %v", d)
+	}
+	if len(r.Identifiers) != 1 || r.Identifiers[0].Type != hubv1.IdentifierType_IDENTIFIER_TYPE_DOI {
+		t.Errorf("Identifiers: got %v", r.Identifiers)
+	}
+	if r.Publication.GetTitle() != "Journal of Hydrology" {
+		t.Errorf("Publication: got %v", r.Publication)
+	}
+	if len(r.Contributors) != 0 {
+		t.Errorf("summaries have no contributors: got %v", r.Contributors)
+	}
+
+	sw := records[1]
+	if sw.ResourceType.Type != hubv1.ResourceTypeValue_RESOURCE_TYPE_SOFTWARE || sw.Publication != nil {
+		t.Errorf("software: got %v, %v", sw.ResourceType, sw.Publication)
+	}
+	if len(sw.Identifiers) != 1 || sw.Identifiers[0].Value != "https://github.com/lehigh/river-toolkit" {
+		t.Errorf("uri identifier: got %v", sw.Identifiers)
+	}
+	if d := hub.GetDateIssued(sw); d == nil || d.Year != 2019 || d.Month != 0 {
+		t.Errorf("year-only date: got %v", d)
+	}
+}
+
+func TestParseRecordAddsOwner(t *testing.T) {
+	tests := map[string]string{
+		"json": `{
+			"orcid-identifier": {"uri": "https://orcid.org/0000-0002-1825-0097", "path": "0000-0002-1825-0097"},
+			"person": {"name": {"given-names": {"value": "Josiah"}, "family-name": {"value": "Carberry"}, "credit-name": null}},
+			"activities-summary": {"works": {"group": [
+				{"work-summary": [{"put-code": 1, "title": {"title": {"value": "One"}}, "type": "book", "path": "/0000-0002-1825-0097/work/1"}]}
+			]}}
+		}`,
+		"xml": `<record:record xmlns:record="http://www.orcid.org/ns/record" xmlns:person="http://www.orcid.org/ns/person"
+				xmlns:personal-details="http://www.orcid.org/ns/personal-details" xmlns:activities="http://www.orcid.org/ns/activities"
+				xmlns:work="http://www.orcid.org/ns/work" xmlns:common="http://www.orcid.org/ns/common">
+			<person:person>
+				<person:name>
+					<personal-details:given-names>Josiah</personal-details:given-names>
+					<personal-details:family-name>Carberry</personal-details:family-name>
+				</person:name>
+			</person:person>
+			<activities:activities-summary>
+				<activities:works>
+					<activities:group>
+						<work:work-summary put-code="1" path="/0000-0002-1825-0097/work/1">
+							<work:title><common:title>One</common:title></work:title>
+							<work:type>book</work:type>
+						</work:work-summary>
+						<work:work-summary put-code="2" path="/0000-0002-1825-0097/work/2">
+							<work:title><common:title>One (duplicate)</common:title></work:title>
+						</work:work-summary>
+					</activities:group>
+				</activities:works>
+			</activities:activities-summary>
+		</record:record>`,
+	}
+	for name, input := range tests {
+		t.Run(name, func(t *testing.T) {
+			records, err := (&Format{}).Parse(strings.NewReader(input), nil)
+			if err != nil {
+				t.Fatalf("Parse failed: %v", err)
+			}
+			if len(records) != 1 {
+				t.Fatalf("expected 1 record, got %d", len(records))
+			}
+			r := records[0]
+			if r.Title != "One" || r.ResourceType.Type != hubv1.ResourceTypeValue_RESOURCE_TYPE_BOOK {
+				t.Errorf("work: got %q, %v", r.Title, r.ResourceType)
+			}
+			if len(r.Contributors) != 1 {
+				t.Fatalf("expected the owner as author, got %v", r.Contributors)
+			}
+			c := r.Contributors[0]
+			if c.Name != "Josiah Carberry" || c.ParsedName.GetFamily() != "Carberry" || c.RoleCode != "relators:aut" ||
+				len(c.Identifiers) != 1 || c.Identifiers[0].Value != "0000-0002-1825-0097" {
+				t.Errorf("owner: got %v", c)
+			}
+		})
+	}
+}
+
+func TestParseBulkJSON(t *testing.T) {
+	input := `{"bulk": [
+		{"work": {"put-code": 1, "title": {"title": {"value": "One"}}, "citation": {"citation-type": "formatted-apa", "citation-value": "Carberry, J. (2020). One."}}},
+		{"error": {"response-code": 404, "developer-message": "No entity found"}}
+	]}`
+	records, err := (&Format{}).Parse(strings.NewReader(input), nil)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("expected errors to be skipped, got %d records", len(records))
+	}
+	if records[0].PreferredCitation != "Carberry, J. (2020). One." {
+		t.Errorf("PreferredCitation: got %q", records[0].PreferredCitation)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := map[string]string{
+		"empty":        "",
+		"no works":     `{"group": []}`,
+		"unknown json": `{"id": "W1"}`,
+		"bad xml":      `<work:work xmlns:work="http://www.orcid.org/ns/work"><work:title>`,
+	}
+	for name, input := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := (&Format{}).Parse(strings.NewReader(input), nil); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestCanParse(t *testing.T) {
+	f := &Format{}
+	for _, path := range []string{"testdata/work.xml", "testdata/works.json"} {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !f.CanParse(data) {
+			t.Errorf("expected CanParse for %s", path)
+		}
+	}
+	if f.CanParse([]byte(`<mods xmlns="http://www.loc.gov/mods/v3"/>`)) {
+		t.Error("unexpected CanParse for MODS")
+	}
+}
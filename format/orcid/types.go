@@ -0,0 +1,172 @@
+package orcid
+
+import (
+	"encoding/json"
+)
+
+// XML namespaces of the ORCID 3.0 message schema.
+const (
+	workNS       = "http://www.orcid.org/ns/work"
+	activitiesNS = "http://www.orcid.org/ns/activities"
+	personNS     = "http://www.orcid.org/ns/person"
+)
+
+// The types below decode both the XML and JSON representations. Element
+// names match the JSON keys, and XML tags are left unqualified so the
+// work:, common:, and other prefixes all match.
+
+// Value is a text value. The JSON representation wraps most values in an
+// object ({"value": "..."}); the XML representation is the element text.
+type Value string
+
+// UnmarshalJSON accepts a {"value": ...} object, a bare string, or null.
+func (v *Value) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*v = Value(s)
+		return nil
+	}
+	var wrapped struct {
+		Value json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal(data, &wrapped); err != nil {
+		return err
+	}
+	if len(wrapped.Value) == 0 || string(wrapped.Value) == "null" {
+		*v = ""
+		return nil
+	}
+	if err := json.Unmarshal(wrapped.Value, &s); err == nil {
+		*v = Value(s)
+		return nil
+	}
+	// Some values, such as last-modified-date, are numbers
+	*v = Value(wrapped.Value)
+	return nil
+}
+
+// Record is a full ORCID record (/v3.0/{orcid}/record).
+type Record struct {
+	ORCIDIdentifier *ORCIDIdentifier `json:"orcid-identifier"`
+	Person          *Person          `json:"person"`
+	Activities      *struct {
+		Works *Works `json:"works"`
+	} `json:"activities-summary"`
+}
+
+// ORCIDIdentifier is a record's ORCID iD.
+type ORCIDIdentifier struct {
+	URI  string `xml:"uri" json:"uri"`
+	Path string `xml:"path" json:"path"`
+}
+
+// Person holds the profile owner's name.
+type Person struct {
+	Name *PersonName `xml:"name" json:"name"`
+}
+
+// PersonName is the profile owner's name, as given and family names and
+// the published (credit) name.
+type PersonName struct {
+	GivenNames Value `xml:"given-names" json:"given-names"`
+	FamilyName Value `xml:"family-name" json:"family-name"`
+	CreditName Value `xml:"credit-name" json:"credit-name"`
+}
+
+// Works is the works summary: works grouped by shared external
+// identifiers, with one work-summary per source in each group.
+type Works struct {
+	Groups []Group `json:"group"`
+}
+
+// Group is one work as reported by one or more sources.
+type Group struct {
+	WorkSummaries []*Work `json:"work-summary"`
+}
+
+// Bulk is the response to a bulk work request.
+type Bulk struct {
+	Bulk []struct {
+		Work *Work `json:"work"`
+	} `json:"bulk"`
+}
+
+// Work is an ORCID work or work summary. Summaries carry only the title,
+// type, publication date, journal title, external identifiers, and URL.
+type Work struct {
+	PutCode          int64         `xml:"put-code,attr" json:"put-code"`
+	Path             string        `xml:"path,attr" json:"path"`
+	Visibility       string        `xml:"visibility,attr" json:"visibility"`
+	Source           *Source       `xml:"source" json:"source"`
+	Title            *WorkTitle    `xml:"title" json:"title"`
+	JournalTitle     Value         `xml:"journal-title" json:"journal-title"`
+	ShortDescription Value         `xml:"short-description" json:"short-description"`
+	Citation         *Citation     `xml:"citation" json:"citation"`
+	Type             string        `xml:"type" json:"type"`
+	PublicationDate  *FuzzyDate    `xml:"publication-date" json:"publication-date"`
+	ExternalIDs      *ExternalIDs  `xml:"external-ids" json:"external-ids"`
+	URL              Value         `xml:"url" json:"url"`
+	Contributors     *Contributors `xml:"contributors" json:"contributors"`
+	LanguageCode     Value         `xml:"language-code" json:"language-code"`
+	Country          Value         `xml:"country" json:"country"`
+}
+
+// Source is the client or user that added the work.
+type Source struct {
+	SourceName Value `xml:"source-name" json:"source-name"`
+}
+
+// WorkTitle is a work's title, subtitle, and translated title.
+type WorkTitle struct {
+	Title           Value `xml:"title" json:"title"`
+	Subtitle        Value `xml:"subtitle" json:"subtitle"`
+	TranslatedTitle Value `xml:"translated-title" json:"translated-title"`
+}
+
+// Citation is a citation of the work in the given citation type, such as
+// "bibtex" or "formatted-apa".
+type Citation struct {
+	CitationType  string `xml:"citation-type" json:"citation-type"`
+	CitationValue string `xml:"citation-value" json:"citation-value"`
+}
+
+// FuzzyDate is a date with optional month and day.
+type FuzzyDate struct {
+	Year  Value `xml:"year" json:"year"`
+	Month Value `xml:"month" json:"month"`
+	Day   Value `xml:"day" json:"day"`
+}
+
+// ExternalIDs is a work's list of external identifiers.
+type ExternalIDs struct {
+	ExternalID []ExternalID `xml:"external-id" json:"external-id"`
+}
+
+// ExternalID is an identifier of the work ("self"), of a work it is part
+// of or a version of, or of a grant that funded it.
+type ExternalID struct {
+	Type         string `xml:"external-id-type" json:"external-id-type"`
+	Value        string `xml:"external-id-value" json:"external-id-value"`
+	Normalized   Value  `xml:"external-id-normalized" json:"external-id-normalized"`
+	URL          Value  `xml:"external-id-url" json:"external-id-url"`
+	Relationship string `xml:"external-id-relationship" json:"external-id-relationship"`
+}
+
+// Contributors is a work's list of contributors.
+type Contributors struct {
+	Contributor []Contributor `xml:"contributor" json:"contributor"`
+}
+
+// Contributor is a contributor to a work.
+type Contributor struct {
+	ContributorORCID *ORCIDIdentifier       `xml:"contributor-orcid" json:"contributor-orcid"`
+	CreditName       Value                  `xml:"credit-name" json:"credit-name"`
+	Attributes       *ContributorAttributes `xml:"contributor-attributes" json:"contributor-attributes"`
+}
+
+// ContributorAttributes holds a contributor's sequence ("first" or
+// "additional") and role.
+type ContributorAttributes struct {
+	Sequence string `xml:"contributor-sequence" json:"contributor-sequence"`
+	Role     string `xml:"contributor-role" json:"contributor-role"`
+}
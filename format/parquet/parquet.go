@@ -0,0 +1,73 @@
+// Package parquet provides a serializer that flattens hub records into an
+// Apache Parquet file, for loading converted metadata into analytics tools
+// such as DuckDB or pandas.
+//
+// Two layouts are written, selected with SerializeOptions.Variant:
+//   - json (the default): one row per record. Scalar hub fields are typed
+//     columns; repeated and structured fields (contributors, dates,
+//     subjects, ...) are JSON text columns holding their protojson.
+//   - exploded: one row per value. Each row names the record (its
+//     position and primary identifier), the hub field, the value's
+//     position within it, a display value and type (a role, vocabulary,
+//     or date type), and the value's JSON. Extra has a row per key, with
+//     the key as the type.
+//
+// SerializeOptions.Columns limits the json layout to the named hub fields,
+// in order, and the exploded layout to the named hub fields.
+//
+// The file has one row group, written uncompressed with PLAIN encoding.
+package parquet
+
+import (
+	"github.com/lehigh-university-libraries/crosswalk/format"
+)
+
+// Output variants, selected with SerializeOptions.Variant.
+const (
+	VariantJSON     = "json"
+	VariantExploded = "exploded"
+)
+
+// Format implements the Parquet format.
+type Format struct{}
+
+// Ensure Format implements the interfaces
+var (
+	_ format.Format         = (*Format)(nil)
+	_ format.Serializer     = (*Format)(nil)
+	_ format.FieldDescriber = (*Format)(nil)
+)
+
+// Name returns the format identifier.
+func (f *Format) Name() string {
+	return "parquet"
+}
+
+// Description returns a human-readable format description.
+func (f *Format) Description() string {
+	return "Apache Parquet table of hub records (for DuckDB, pandas, Spark)"
+}
+
+// Extensions returns file extensions associated with this format.
+func (f *Format) Extensions() []string {
+	return []string{"parquet"}
+}
+
+// WrittenFields returns the hub record fields Serialize writes: all of
+// them, or those named in opts.Columns.
+func (f *Format) WrittenFields(opts *format.SerializeOptions) []string {
+	var names []string
+	for _, fd := range recordFields(opts) {
+		names = append(names, string(fd.Name()))
+	}
+	return names
+}
+
+// CanParse returns false; Parquet is an output format only.
+func (f *Format) CanParse(peek []byte) bool {
+	return false
+}
+
+func init() {
+	format.Register(&Format{})
+}
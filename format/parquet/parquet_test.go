@@ -0,0 +1,322 @@
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+func testRecords(t *testing.T) []*hubv1.Record {
+	t.Helper()
+	extra, err := structpb.NewStruct(map[string]any{"nid": "42"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return []*hubv1.Record{
+		{
+			Title: "Field notes",
+			Contributors: []*hubv1.Contributor{
+				{Name: "Smith, Jane", RoleCode: "relators:aut"},
+				{Name: "Doe, John"},
+			},
+			Dates:       []*hubv1.DateValue{{Type: hubv1.DateType_DATE_TYPE_ISSUED, Year: 1999, Precision: hubv1.DatePrecision_DATE_PRECISION_YEAR}},
+			Identifiers: []*hubv1.Identifier{hub.NewIdentifier("10.1234/abcd", hubv1.IdentifierType_IDENTIFIER_TYPE_DOI)},
+			Notes:       []string{"First", "Second"},
+			IsPublic:    true,
+			Extra:       extra,
+			SourceInfo:  &hubv1.SourceInfo{Format: "drupal", Payload: []byte(`{"nid":[{"value":42}]}`)},
+		},
+		{Title: "Second record"},
+	}
+}
+
+// columnValues returns the named column's values.
+func columnValues(t *testing.T, columns []*column, name string) []any {
+	t.Helper()
+	for _, c := range columns {
+		if c.name == name {
+			return c.values
+		}
+	}
+	t.Fatalf("no column %s", name)
+	return nil
+}
+
+func TestJSONTable(t *testing.T) {
+	opts := &format.SerializeOptions{Columns: []string{"title", "notes", "is_public", "contributors"}}
+	columns, rows, err := jsonTable(testRecords(t), recordFields(opts))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rows != 2 || len(columns) != 4 {
+		t.Fatalf("got %d rows, %d columns; want 2, 4", rows, len(columns))
+	}
+	if got := columnValues(t, columns, "notes"); got[0] != `["First","Second"]` || got[1] != nil {
+		t.Errorf("notes: got %v", got)
+	}
+	if got := columnValues(t, columns, "is_public"); got[0] != true || got[1] != false {
+		t.Errorf("is_public: got %v", got)
+	}
+	contributors := columnValues(t, columns, "contributors")[0].(string)
+	if !strings.HasPrefix(contributors, `[{"name":"Smith, Jane","role_code":"relators:aut"}`) {
+		t.Errorf("contributors: got %s", contributors)
+	}
+}
+
+func TestExplodedTable(t *testing.T) {
+	opts := &format.SerializeOptions{Columns: []string{"title", "contributors", "dates", "extra"}}
+	columns, rows, err := explodedTable(testRecords(t), recordFields(opts))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		record int64
+		field  string
+		value  any
+		typ    any
+	}{
+		{0, "title", "Field notes", nil},
+		{0, "contributors", "Smith, Jane", "relators:aut"},
+		{0, "contributors", "Doe, John", nil},
+		{0, "dates", "1999", "issued"},
+		{0, "extra", "42", "nid"},
+		{1, "title", "Second record", nil},
+	}
+	if rows != len(want) {
+		t.Fatalf("got %d rows, want %d", rows, len(want))
+	}
+	records := columnValues(t, columns, "record")
+	ids := columnValues(t, columns, "record_id")
+	fields := columnValues(t, columns, "field")
+	values := columnValues(t, columns, "value")
+	types := columnValues(t, columns, "type")
+	for i, w := range want {
+		if records[i] != w.record || fields[i] != w.field || values[i] != w.value || types[i] != w.typ {
+			t.Errorf("row %d: got %v %v %v %v, want %v", i, records[i], fields[i], values[i], types[i], w)
+		}
+	}
+	if ids[0] != "10.1234/abcd" || ids[5] != nil {
+		t.Errorf("record_id: got %v", ids)
+	}
+}
+
+func TestSerialize(t *testing.T) {
+	for _, variant := range []string{"", VariantExploded} {
+		var buf bytes.Buffer
+		if err := (&Format{}).Serialize(&buf, testRecords(t), &format.SerializeOptions{Variant: variant}); err != nil {
+			t.Fatalf("%q: %v", variant, err)
+		}
+		data := buf.Bytes()
+		if !bytes.HasPrefix(data, []byte(magic)) || !bytes.HasSuffix(data, []byte(magic)) {
+			t.Fatalf("%q: missing PAR1 magic", variant)
+		}
+		footer := binary.LittleEndian.Uint32(data[len(data)-8:])
+		if int(footer) >= len(data)-12 {
+			t.Errorf("%q: footer length %d overruns %d-byte file", variant, footer, len(data))
+		}
+		if bytes.Contains(data, []byte(`"value":42`)) {
+			t.Errorf("%q: preserved source payload was written", variant)
+		}
+	}
+
+	err := (&Format{}).Serialize(&bytes.Buffer{}, nil, &format.SerializeOptions{Columns: []string{"titel"}})
+	if err == nil {
+		t.Error("want error for an unknown hub field")
+	}
+	err = (&Format{}).Serialize(&bytes.Buffer{}, nil, &format.SerializeOptions{Variant: "wide"})
+	if err == nil {
+		t.Error("want error for an unknown variant")
+	}
+}
+
+// thriftReader decodes the Thrift compact protocol into maps of field ID
+// to value, enough to read back what thriftWriter writes.
+type thriftReader struct {
+	buf []byte
+	err error
+}
+
+func (r *thriftReader) varint() uint64 {
+	v, n := binary.Uvarint(r.buf)
+	if n <= 0 {
+		r.err = errors.New("bad varint")
+		r.buf = nil
+		return 0
+	}
+	r.buf = r.buf[n:]
+	return v
+}
+
+func (r *thriftReader) zigzag() int64 {
+	v := r.varint()
+	return int64(v>>1) ^ -int64(v&1)
+}
+
+func (r *thriftReader) value(typ byte) any {
+	switch typ {
+	case thriftI32, thriftI64:
+		return r.zigzag()
+	case thriftBinary:
+		n := int(r.varint())
+		if n > len(r.buf) {
+			r.err = errors.New("binary overruns the buffer")
+			return ""
+		}
+		v := string(r.buf[:n])
+		r.buf = r.buf[n:]
+		return v
+	case thriftList:
+		if len(r.buf) == 0 {
+			r.err = errors.New("truncated list header")
+			return nil
+		}
+		header := r.buf[0]
+		r.buf = r.buf[1:]
+		n, elem := int(header>>4), header&0x0f
+		if n == 15 {
+			n = int(r.varint())
+		}
+		list := make([]any, n)
+		for i := range list {
+			list[i] = r.value(elem)
+		}
+		return list
+	case thriftStruct:
+		return r.readStruct()
+	}
+	r.err = fmt.Errorf("unexpected type %d", typ)
+	return nil
+}
+
+func (r *thriftReader) readStruct() map[int16]any {
+	fields := make(map[int16]any)
+	var last int16
+	for r.err == nil {
+		if len(r.buf) == 0 {
+			r.err = errors.New("truncated struct")
+			break
+		}
+		header := r.buf[0]
+		r.buf = r.buf[1:]
+		if header == 0 {
+			break
+		}
+		id := last + int16(header>>4)
+		if header>>4 == 0 {
+			id = int16(r.zigzag())
+		}
+		fields[id] = r.value(header & 0x0f)
+		last = id
+	}
+	return fields
+}
+
+func TestSerializeFooter(t *testing.T) {
+	records := testRecords(t)
+	opts := &format.SerializeOptions{Columns: []string{"title", "notes", "is_public"}}
+	var buf bytes.Buffer
+	if err := (&Format{}).Serialize(&buf, records, opts); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+	size := int(binary.LittleEndian.Uint32(data[len(data)-8:]))
+	footerStart := len(data) - 8 - size
+
+	r := &thriftReader{buf: data[footerStart : len(data)-8]}
+	meta := r.readStruct()
+	if r.err != nil || len(r.buf) != 0 {
+		t.Fatalf("footer: %v with %d bytes left over", r.err, len(r.buf))
+	}
+	if meta[3] != int64(len(records)) {
+		t.Errorf("num_rows: got %v, want %d", meta[3], len(records))
+	}
+
+	wantNames := []string{"title", "notes", "is_public"}
+	wantTypes := []int64{typeByteArray, typeByteArray, typeBoolean}
+	schema := meta[2].([]any)
+	if len(schema) != len(wantNames)+1 {
+		t.Fatalf("schema has %d elements, want %d", len(schema), len(wantNames)+1)
+	}
+	if root := schema[0].(map[int16]any); root[4] != "schema" || root[5] != int64(len(wantNames)) {
+		t.Errorf("schema root: got %v", root)
+	}
+	for i, name := range wantNames {
+		el := schema[i+1].(map[int16]any)
+		if el[4] != name || el[1] != wantTypes[i] || el[3] != int64(repetitionOptional) {
+			t.Errorf("schema element %d: got %v, want %s", i+1, el, name)
+		}
+	}
+
+	rowGroups := meta[4].([]any)
+	if len(rowGroups) != 1 {
+		t.Fatalf("got %d row groups, want 1", len(rowGroups))
+	}
+	group := rowGroups[0].(map[int16]any)
+	if group[3] != int64(len(records)) {
+		t.Errorf("row group num_rows: got %v", group[3])
+	}
+	chunks := group[1].([]any)
+	if len(chunks) != len(wantNames) {
+		t.Fatalf("got %d column chunks, want %d", len(chunks), len(wantNames))
+	}
+	offset, total := int64(len(magic)), int64(0)
+	for i, c := range chunks {
+		chunk := c.(map[int16]any)
+		md := chunk[3].(map[int16]any)
+		size := md[6].(int64)
+		if chunk[2] != offset || md[9] != offset {
+			t.Errorf("chunk %d: file offset %v, data page offset %v, want %d", i, chunk[2], md[9], offset)
+		}
+		if md[7] != size || md[5] != int64(len(records)) {
+			t.Errorf("chunk %d: sizes %v/%v, %v values", i, size, md[7], md[5])
+		}
+		if path := md[3].([]any); len(path) != 1 || path[0] != wantNames[i] {
+			t.Errorf("chunk %d: path %v, want %s", i, path, wantNames[i])
+		}
+
+		page := &thriftReader{buf: data[offset : offset+size]}
+		header := page.readStruct()
+		if page.err != nil || int64(len(page.buf)) != header[2].(int64) {
+			t.Errorf("chunk %d: page header %v (%v) with %d bytes of page left", i, header, page.err, len(page.buf))
+		} else if dp := header[5].(map[int16]any); dp[1] != int64(len(records)) {
+			t.Errorf("chunk %d: page holds %v values", i, dp[1])
+		}
+		offset += size
+		total += size
+	}
+	if offset != int64(footerStart) {
+		t.Errorf("column chunks end at %d, footer starts at %d", offset, footerStart)
+	}
+	if group[2] != total {
+		t.Errorf("row group total size: got %v, want %d", group[2], total)
+	}
+}
+
+func TestEncodePage(t *testing.T) {
+	page := encodePage(&column{kind: kindString, values: []any{"a", nil, nil, "bc"}})
+	want := []byte{
+		6, 0, 0, 0, // definition levels length
+		2, 1, // one defined
+		4, 0, // two null
+		2, 1, // one defined
+		1, 0, 0, 0, 'a',
+		2, 0, 0, 0, 'b', 'c',
+	}
+	if !bytes.Equal(page, want) {
+		t.Errorf("got % x, want % x", page, want)
+	}
+
+	page = encodePage(&column{kind: kindBool, values: []any{true, false, true}})
+	if got := page[len(page)-1]; got != 0b101 {
+		t.Errorf("booleans: got %08b, want 00000101", got)
+	}
+}
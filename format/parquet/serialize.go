@@ -0,0 +1,354 @@
+package parquet
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+// marshal writes proto field names, matching the hub's JSON schema.
+var marshal = protojson.MarshalOptions{UseProtoNames: true}
+
+// Serialize writes the records as a Parquet table in the layout opts.Variant
+// selects. Zero records write a table with no rows.
+func (f *Format) Serialize(w io.Writer, records []*hubv1.Record, opts *format.SerializeOptions) error {
+	if opts == nil {
+		opts = format.NewSerializeOptions()
+	}
+	switch opts.Variant {
+	case "", VariantJSON, VariantExploded:
+	default:
+		return fmt.Errorf("unknown parquet variant %q (want %s or %s)", opts.Variant, VariantJSON, VariantExploded)
+	}
+	fields := recordFields(opts)
+	for _, name := range opts.Columns {
+		if !slices.ContainsFunc(fields, func(fd protoreflect.FieldDescriptor) bool { return string(fd.Name()) == name }) {
+			return fmt.Errorf("unknown hub field %q", name)
+		}
+	}
+
+	// The preserved source is for serializers, not analysts
+	records = slices.Clone(records)
+	for i, r := range records {
+		if len(r.GetSourceInfo().GetPayload()) > 0 {
+			r = proto.Clone(r).(*hubv1.Record)
+			r.SourceInfo.Payload = nil
+			records[i] = r
+		}
+	}
+
+	var columns []*column
+	var rows int
+	var err error
+	if opts.Variant == VariantExploded {
+		columns, rows, err = explodedTable(records, fields)
+	} else {
+		columns, rows, err = jsonTable(records, fields)
+	}
+	if err != nil {
+		return err
+	}
+	return writeTable(w, columns, rows)
+}
+
+// recordFields returns the hub record fields to write: those opts.Columns
+// names, in its order, or every field in declaration order.
+func recordFields(opts *format.SerializeOptions) []protoreflect.FieldDescriptor {
+	all := (&hubv1.Record{}).ProtoReflect().Descriptor().Fields()
+	var fields []protoreflect.FieldDescriptor
+	if opts != nil && len(opts.Columns) > 0 {
+		for _, name := range opts.Columns {
+			if fd := all.ByName(protoreflect.Name(name)); fd != nil {
+				fields = append(fields, fd)
+			}
+		}
+		return fields
+	}
+	for i := range all.Len() {
+		fields = append(fields, all.Get(i))
+	}
+	return fields
+}
+
+// jsonTable lays out one row per record and one column per hub field.
+func jsonTable(records []*hubv1.Record, fields []protoreflect.FieldDescriptor) ([]*column, int, error) {
+	columns := make([]*column, len(fields))
+	for i, fd := range fields {
+		col := &column{name: string(fd.Name()), kind: kindString}
+		switch {
+		case fd.IsList() || fd.IsMap():
+		case fd.Kind() == protoreflect.BoolKind:
+			col.kind = kindBool
+		case fd.Kind() == protoreflect.Int32Kind || fd.Kind() == protoreflect.Int64Kind:
+			col.kind = kindInt64
+		}
+		columns[i] = col
+	}
+
+	for _, r := range records {
+		m := r.ProtoReflect()
+		for i, fd := range fields {
+			var v any
+			if m.Has(fd) || fd.Kind() == protoreflect.BoolKind && !fd.IsList() {
+				var err error
+				if v, err = cellValue(fd, m.Get(fd)); err != nil {
+					return nil, 0, fmt.Errorf("%s: %w", fd.Name(), err)
+				}
+			}
+			columns[i].values = append(columns[i].values, v)
+		}
+	}
+	return columns, len(records), nil
+}
+
+// cellValue returns a populated field's value for the json layout: the
+// value itself for scalars, or its JSON.
+func cellValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) (any, error) {
+	if fd.IsList() {
+		list := v.List()
+		items := make([]json.RawMessage, list.Len())
+		for i := range list.Len() {
+			data, err := elementJSON(fd, list.Get(i))
+			if err != nil {
+				return nil, err
+			}
+			items[i] = data
+		}
+		data, err := json.Marshal(items)
+		return string(data), err
+	}
+	switch fd.Kind() {
+	case protoreflect.MessageKind:
+		data, err := elementJSON(fd, v)
+		return string(data), err
+	case protoreflect.BoolKind:
+		return v.Bool(), nil
+	case protoreflect.Int32Kind, protoreflect.Int64Kind:
+		return v.Int(), nil
+	}
+	return nullable(scalarString(fd, v)), nil
+}
+
+// scalarString returns one scalar value of a field as text.
+func scalarString(fd protoreflect.FieldDescriptor, v protoreflect.Value) string {
+	switch fd.Kind() {
+	case protoreflect.EnumKind:
+		return enumName(fd, v.Enum())
+	case protoreflect.BoolKind:
+		return strconv.FormatBool(v.Bool())
+	case protoreflect.Int32Kind, protoreflect.Int64Kind:
+		return strconv.FormatInt(v.Int(), 10)
+	}
+	return v.String()
+}
+
+// structField returns the field's value when it is a google.protobuf.Struct,
+// as Extra is.
+func structField(fd protoreflect.FieldDescriptor, v protoreflect.Value) (*structpb.Struct, bool) {
+	if fd.Kind() != protoreflect.MessageKind || fd.IsList() {
+		return nil, false
+	}
+	s, ok := v.Message().Interface().(*structpb.Struct)
+	return s, ok
+}
+
+// elementJSON returns the compact JSON of one value of a field.
+func elementJSON(fd protoreflect.FieldDescriptor, v protoreflect.Value) (json.RawMessage, error) {
+	if fd.Kind() != protoreflect.MessageKind {
+		return json.Marshal(v.Interface())
+	}
+	data, err := marshal.Marshal(v.Message().Interface())
+	if err != nil {
+		return nil, err
+	}
+	return compact(data)
+}
+
+// compact strips the whitespace protojson varies from build to build, so
+// the output is stable.
+func compact(data []byte) (json.RawMessage, error) {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// explodedTable lays out one row per value of each hub field.
+func explodedTable(records []*hubv1.Record, fields []protoreflect.FieldDescriptor) ([]*column, int, error) {
+	var (
+		recordCol = &column{name: "record", kind: kindInt64}
+		idCol     = &column{name: "record_id", kind: kindString}
+		fieldCol  = &column{name: "field", kind: kindString}
+		posCol    = &column{name: "position", kind: kindInt64}
+		valueCol  = &column{name: "value", kind: kindString}
+		typeCol   = &column{name: "type", kind: kindString}
+		jsonCol   = &column{name: "json", kind: kindString}
+	)
+	rows := 0
+	add := func(record int, id any, field string, pos int, value, typ any, data json.RawMessage) {
+		recordCol.values = append(recordCol.values, int64(record))
+		idCol.values = append(idCol.values, id)
+		fieldCol.values = append(fieldCol.values, field)
+		posCol.values = append(posCol.values, int64(pos))
+		valueCol.values = append(valueCol.values, value)
+		typeCol.values = append(typeCol.values, typ)
+		if data != nil {
+			jsonCol.values = append(jsonCol.values, string(data))
+		} else {
+			jsonCol.values = append(jsonCol.values, nil)
+		}
+		rows++
+	}
+
+	for n, r := range records {
+		id := nullable(recordID(r))
+		m := r.ProtoReflect()
+		for _, fd := range fields {
+			if !m.Has(fd) {
+				continue
+			}
+			name := string(fd.Name())
+			v := m.Get(fd)
+
+			if extra, ok := structField(fd, v); ok {
+				keys := make([]string, 0, len(extra.Fields))
+				for k := range extra.Fields {
+					keys = append(keys, k)
+				}
+				sort.Strings(keys)
+				for i, k := range keys {
+					data, err := extra.Fields[k].MarshalJSON()
+					if err == nil {
+						data, err = compact(data)
+					}
+					if err != nil {
+						return nil, 0, fmt.Errorf("%s.%s: %w", name, k, err)
+					}
+					add(n, id, name, i, nullable(structString(extra.Fields[k])), k, data)
+				}
+				continue
+			}
+
+			var values []protoreflect.Value
+			if fd.IsList() {
+				for i := range v.List().Len() {
+					values = append(values, v.List().Get(i))
+				}
+			} else {
+				values = []protoreflect.Value{v}
+			}
+			for i, ev := range values {
+				if fd.Kind() != protoreflect.MessageKind {
+					add(n, id, name, i, nullable(scalarString(fd, ev)), nil, nil)
+					continue
+				}
+				data, err := elementJSON(fd, ev)
+				if err != nil {
+					return nil, 0, fmt.Errorf("%s: %w", name, err)
+				}
+				add(n, id, name, i, nullable(displayValue(ev.Message())), nullable(displayType(ev.Message())), data)
+			}
+		}
+	}
+
+	return []*column{recordCol, idCol, fieldCol, posCol, valueCol, typeCol, jsonCol}, rows, nil
+}
+
+// recordID returns the identifier rows of the exploded layout name their
+// record by: its DOI, or else its first identifier.
+func recordID(r *hubv1.Record) string {
+	if id := hub.GetIdentifier(r, hubv1.IdentifierType_IDENTIFIER_TYPE_DOI); id != nil {
+		return id.Value
+	}
+	if len(r.Identifiers) > 0 {
+		return r.Identifiers[0].Value
+	}
+	return ""
+}
+
+// Fields, in order of preference, giving a hub value's display value and
+// its type.
+var (
+	valueFields = []protoreflect.Name{"value", "name", "title", "target_title", "statement", "label", "uri", "url", "raw"}
+	typeFields  = []protoreflect.Name{"role_code", "type", "vocabulary", "role"}
+)
+
+// displayValue returns a readable value for a hub message.
+func displayValue(m protoreflect.Message) string {
+	if d, ok := m.Interface().(*hubv1.DateValue); ok {
+		return hub.DateString(d)
+	}
+	return firstField(m, valueFields)
+}
+
+// displayType returns the kind of a hub message's value: a role, a
+// vocabulary, a date or identifier type.
+func displayType(m protoreflect.Message) string {
+	return firstField(m, typeFields)
+}
+
+// firstField returns the first of the named fields the message has set, as
+// text. Nested messages are skipped.
+func firstField(m protoreflect.Message, names []protoreflect.Name) string {
+	fields := m.Descriptor().Fields()
+	for _, name := range names {
+		fd := fields.ByName(name)
+		if fd == nil || fd.IsList() || !m.Has(fd) {
+			continue
+		}
+		switch fd.Kind() {
+		case protoreflect.StringKind:
+			return m.Get(fd).String()
+		case protoreflect.EnumKind:
+			if s := enumName(fd, m.Get(fd).Enum()); s != "" {
+				return s
+			}
+		}
+	}
+	return ""
+}
+
+// enumName returns an enum value's name without the enum's prefix,
+// lowercased (DATE_TYPE_ISSUED is "issued"), or "" when unspecified.
+func enumName(fd protoreflect.FieldDescriptor, n protoreflect.EnumNumber) string {
+	values := fd.Enum().Values()
+	v := values.ByNumber(n)
+	if v == nil || n == 0 {
+		return ""
+	}
+	prefix := strings.TrimSuffix(string(values.ByNumber(0).Name()), "UNSPECIFIED")
+	return strings.ToLower(strings.TrimPrefix(string(v.Name()), prefix))
+}
+
+// structString returns an Extra value as text: strings as themselves,
+// anything else as JSON.
+func structString(v *structpb.Value) string {
+	if s, ok := v.GetKind().(*structpb.Value_StringValue); ok {
+		return s.StringValue
+	}
+	data, _ := v.MarshalJSON()
+	return string(data)
+}
+
+// nullable returns s, or nil for an empty string, as a column value.
+func nullable(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
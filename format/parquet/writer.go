@@ -0,0 +1,222 @@
+package parquet
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// columnKind is the type of a column's values.
+type columnKind int
+
+const (
+	kindString columnKind = iota
+	kindInt64
+	kindBool
+)
+
+// Parquet physical types, encodings, and other enum values from the
+// format's Thrift definition.
+const (
+	typeBoolean   = 0
+	typeInt64     = 2
+	typeByteArray = 6
+
+	encodingPlain = 0
+	encodingRLE   = 3
+
+	repetitionOptional = 1
+	convertedUTF8      = 0
+	pageTypeData       = 0
+	codecUncompressed  = 0
+)
+
+const magic = "PAR1"
+
+// column is one column of the output table. Values hold a string, int64,
+// or bool per row, matching kind, or nil for null.
+type column struct {
+	name   string
+	kind   columnKind
+	values []any
+}
+
+// physicalType returns the column's Parquet physical type.
+func (c *column) physicalType() int32 {
+	switch c.kind {
+	case kindInt64:
+		return typeInt64
+	case kindBool:
+		return typeBoolean
+	default:
+		return typeByteArray
+	}
+}
+
+// chunkInfo records where a column's data was written, for the footer.
+type chunkInfo struct {
+	offset int64
+	size   int64
+}
+
+// writeTable writes the columns as a Parquet file of one row group. Every
+// column is optional, so nulls need no placeholder values, and is written
+// as one uncompressed, PLAIN-encoded data page.
+func writeTable(w io.Writer, columns []*column, rows int) error {
+	cw := &countingWriter{w: w}
+	if _, err := io.WriteString(cw, magic); err != nil {
+		return err
+	}
+
+	chunks := make([]chunkInfo, len(columns))
+	for i, col := range columns {
+		if len(col.values) != rows {
+			return fmt.Errorf("column %s has %d values, want %d", col.name, len(col.values), rows)
+		}
+		page := encodePage(col)
+		header := newThriftWriter()
+		header.i32(1, pageTypeData)
+		header.i32(2, int32(len(page)))
+		header.i32(3, int32(len(page)))
+		header.structBegin(5)
+		header.i32(1, int32(rows))
+		header.i32(2, encodingPlain)
+		header.i32(3, encodingRLE)
+		header.i32(4, encodingRLE)
+		header.end()
+		header.end()
+
+		chunks[i].offset = cw.n
+		if _, err := cw.Write(header.buf); err != nil {
+			return err
+		}
+		if _, err := cw.Write(page); err != nil {
+			return err
+		}
+		chunks[i].size = cw.n - chunks[i].offset
+	}
+
+	footer := encodeFooter(columns, chunks, rows)
+	if _, err := cw.Write(footer); err != nil {
+		return err
+	}
+	if err := binary.Write(cw, binary.LittleEndian, uint32(len(footer))); err != nil {
+		return err
+	}
+	_, err := io.WriteString(cw, magic)
+	return err
+}
+
+// encodePage encodes a column's definition levels and non-null values.
+func encodePage(col *column) []byte {
+	levels := make([]byte, 0, 8)
+	for i := 0; i < len(col.values); {
+		defined := col.values[i] != nil
+		run := 1
+		for i+run < len(col.values) && (col.values[i+run] != nil) == defined {
+			run++
+		}
+		// RLE run header (run length, low bit 0), then the level in one byte
+		levels = binary.AppendUvarint(levels, uint64(run)<<1)
+		if defined {
+			levels = append(levels, 1)
+		} else {
+			levels = append(levels, 0)
+		}
+		i += run
+	}
+
+	page := binary.LittleEndian.AppendUint32(nil, uint32(len(levels)))
+	page = append(page, levels...)
+
+	var bits []byte
+	n := 0
+	for _, v := range col.values {
+		switch v := v.(type) {
+		case string:
+			page = binary.LittleEndian.AppendUint32(page, uint32(len(v)))
+			page = append(page, v...)
+		case int64:
+			page = binary.LittleEndian.AppendUint64(page, uint64(v))
+		case bool:
+			if n%8 == 0 {
+				bits = append(bits, 0)
+			}
+			if v {
+				bits[n/8] |= 1 << (n % 8)
+			}
+			n++
+		}
+	}
+	return append(page, bits...)
+}
+
+// encodeFooter encodes the file metadata: the schema, and the one row
+// group's column chunks.
+func encodeFooter(columns []*column, chunks []chunkInfo, rows int) []byte {
+	t := newThriftWriter()
+	t.i32(1, 1)
+
+	t.listBegin(2, thriftStruct, len(columns)+1)
+	t.elemBegin()
+	t.binary(4, "schema")
+	t.i32(5, int32(len(columns)))
+	t.end()
+	for _, col := range columns {
+		t.elemBegin()
+		t.i32(1, col.physicalType())
+		t.i32(3, repetitionOptional)
+		t.binary(4, col.name)
+		if col.kind == kindString {
+			t.i32(6, convertedUTF8)
+		}
+		t.end()
+	}
+
+	t.i64(3, int64(rows))
+
+	var total int64
+	for _, c := range chunks {
+		total += c.size
+	}
+	t.listBegin(4, thriftStruct, 1)
+	t.elemBegin()
+	t.listBegin(1, thriftStruct, len(columns))
+	for i, col := range columns {
+		t.elemBegin()
+		t.i64(2, chunks[i].offset)
+		t.structBegin(3)
+		t.i32(1, col.physicalType())
+		t.listBegin(2, thriftI32, 2)
+		t.listI32(encodingPlain)
+		t.listI32(encodingRLE)
+		t.listBegin(3, thriftBinary, 1)
+		t.rawBinary(col.name)
+		t.i32(4, codecUncompressed)
+		t.i64(5, int64(rows))
+		t.i64(6, chunks[i].size)
+		t.i64(7, chunks[i].size)
+		t.i64(9, chunks[i].offset)
+		t.end()
+		t.end()
+	}
+	t.i64(2, total)
+	t.i64(3, int64(rows))
+	t.end()
+
+	t.binary(6, "crosswalk")
+	t.end()
+	return t.buf
+}
+
+// countingWriter tracks the offset written so far.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,566 @@
+package pbcore
+
+import (
+	"cmp"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/helpers"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+// Parse reads a pbcoreCollection or pbcoreDescriptionDocument and returns
+// a record per description document.
+func (f *Format) Parse(r io.Reader, opts *format.ParseOptions) ([]*hubv1.Record, error) {
+	d := xml.NewDecoder(r)
+	var start xml.StartElement
+	for {
+		tok, err := d.Token()
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("no PBCore document found in input")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parsing PBCore XML: %w", err)
+		}
+		if s, ok := tok.(xml.StartElement); ok {
+			start = s
+			break
+		}
+	}
+
+	var docs []Document
+	switch start.Name.Local {
+	case "pbcoreCollection":
+		var c Collection
+		if err := d.DecodeElement(&c, &start); err != nil {
+			return nil, fmt.Errorf("parsing PBCore XML: %w", err)
+		}
+		docs = c.Documents
+	case "pbcoreDescriptionDocument":
+		var doc Document
+		if err := d.DecodeElement(&doc, &start); err != nil {
+			return nil, fmt.Errorf("parsing PBCore XML: %w", err)
+		}
+		docs = []Document{doc}
+	default:
+		return nil, fmt.Errorf("not a PBCore document: root element is <%s>", start.Name.Local)
+	}
+
+	records := make([]*hubv1.Record, 0, len(docs))
+	for i := range docs {
+		records = append(records, documentToRecord(&docs[i]))
+	}
+	return records, nil
+}
+
+// documentToRecord maps a description document to a hub record.
+func documentToRecord(doc *Document) *hubv1.Record {
+	record := &hubv1.Record{}
+
+	var sourceID string
+	for _, id := range doc.Identifiers {
+		value := strings.TrimSpace(id.Text)
+		if value == "" {
+			continue
+		}
+		if sourceID == "" {
+			sourceID = value
+		}
+		record.Identifiers = append(record.Identifiers, hub.NewIdentifier(value, identifierType(id.Source, value)))
+	}
+
+	mapTitles(record, doc.Titles)
+
+	for _, d := range doc.AssetDates {
+		if dv := parseDate(d.Text, assetDateType(d.Type)); dv != nil {
+			record.Dates = append(record.Dates, dv)
+		}
+	}
+
+	for _, s := range doc.Subjects {
+		if s.Text = strings.TrimSpace(s.Text); s.Text == "" {
+			continue
+		}
+		record.Subjects = append(record.Subjects, &hubv1.Subject{
+			Value:      s.Text,
+			Type:       subjectType(s.Type),
+			Vocabulary: vocabulary(s.Source),
+			Uri:        s.Ref,
+		})
+	}
+	for _, c := range doc.Coverages {
+		value := strings.TrimSpace(c.Coverage.Text)
+		if value == "" {
+			continue
+		}
+		typ := hubv1.SubjectType_SUBJECT_TYPE_GEOGRAPHIC
+		if strings.EqualFold(strings.TrimSpace(c.Type), "Temporal") {
+			typ = hubv1.SubjectType_SUBJECT_TYPE_TEMPORAL
+		}
+		record.Subjects = append(record.Subjects, &hubv1.Subject{
+			Value:      value,
+			Type:       typ,
+			Vocabulary: vocabulary(c.Coverage.Source),
+			Uri:        c.Coverage.Ref,
+		})
+	}
+	for _, g := range doc.Genres {
+		if g.Text = strings.TrimSpace(g.Text); g.Text != "" {
+			record.Genres = append(record.Genres, &hubv1.Subject{
+				Value:      g.Text,
+				Type:       hubv1.SubjectType_SUBJECT_TYPE_GENRE,
+				Vocabulary: vocabulary(g.Source),
+				Uri:        g.Ref,
+			})
+		}
+	}
+
+	for _, d := range doc.Descriptions {
+		text := strings.TrimSpace(d.Text)
+		switch {
+		case text == "":
+		case strings.EqualFold(d.Type, "Abstract"):
+			hub.AddAbstract(record, text, "")
+		case record.Description == "":
+			record.Description = text
+		default:
+			record.Notes = append(record.Notes, text)
+		}
+	}
+	if len(record.Abstracts) == 1 {
+		record.Abstracts = nil
+	}
+
+	for _, rel := range doc.Relations {
+		if r := relation(rel); r != nil {
+			record.Relations = append(record.Relations, r)
+		}
+	}
+
+	for _, c := range doc.Creators {
+		addContributor(record, c.Creator, c.Roles)
+	}
+	for _, c := range doc.Contributors {
+		addContributor(record, c.Contributor, c.Roles)
+	}
+	for _, p := range doc.Publishers {
+		name := strings.TrimSpace(p.Publisher.Text)
+		if name == "" {
+			continue
+		}
+		if record.Publisher == "" {
+			record.Publisher = name
+			continue
+		}
+		addContributor(record, p.Publisher, append(p.Roles, Value{Text: "publisher"}))
+	}
+
+	for _, rs := range doc.RightsSummaries {
+		summary, link := strings.TrimSpace(rs.Summary), strings.TrimSpace(rs.Link)
+		var rights *hubv1.Rights
+		switch {
+		case link != "":
+			rights = hub.NewRightsFromURI(link)
+			if summary != "" {
+				rights.Statement = summary
+			}
+		case summary != "":
+			rights = &hubv1.Rights{Statement: summary}
+		default:
+			continue
+		}
+		record.Rights = append(record.Rights, rights)
+	}
+
+	for _, a := range doc.Annotations {
+		if text := strings.TrimSpace(a.Text); text != "" {
+			record.Notes = append(record.Notes, text)
+		}
+	}
+
+	var mediaType string
+	for i := range doc.Instantiations {
+		inst := &doc.Instantiations[i]
+		file := instantiationToFile(inst)
+		if mediaType == "" {
+			mediaType = file.Media.MediaType
+		}
+		if record.Duration == "" {
+			record.Duration = file.Media.Duration
+		}
+		if record.Language == "" && len(inst.Languages) > 0 {
+			record.Language = strings.TrimSpace(inst.Languages[0])
+		}
+		record.Files = append(record.Files, file)
+	}
+
+	var assetType string
+	if len(doc.AssetTypes) > 0 {
+		assetType = strings.TrimSpace(doc.AssetTypes[0].Text)
+	}
+	record.ResourceType = hub.NewResourceType(assetType, "pbcore")
+	if record.ResourceType.Type == hubv1.ResourceTypeValue_RESOURCE_TYPE_UNSPECIFIED ||
+		record.ResourceType.Type == hubv1.ResourceTypeValue_RESOURCE_TYPE_OTHER {
+		if t, ok := mediaTypes[strings.ToLower(mediaType)]; ok {
+			record.ResourceType.Type = t
+		}
+	}
+
+	record.SourceInfo = &hubv1.SourceInfo{
+		Format:        "pbcore",
+		FormatVersion: Version,
+		SourceId:      sourceID,
+	}
+	return record
+}
+
+// identifierType reads an identifier's type from its source, or from the
+// value where the source is a local system's name.
+func identifierType(source, value string) hubv1.IdentifierType {
+	switch strings.ToLower(strings.TrimSpace(source)) {
+	case "doi":
+		return hubv1.IdentifierType_IDENTIFIER_TYPE_DOI
+	case "handle", "hdl":
+		return hubv1.IdentifierType_IDENTIFIER_TYPE_HANDLE
+	case "url", "uri":
+		return hubv1.IdentifierType_IDENTIFIER_TYPE_URL
+	case "isbn":
+		return hubv1.IdentifierType_IDENTIFIER_TYPE_ISBN
+	case "uuid":
+		return hubv1.IdentifierType_IDENTIFIER_TYPE_UUID
+	}
+	if t := hub.DetectIdentifierType(value); t != hubv1.IdentifierType_IDENTIFIER_TYPE_UNSPECIFIED {
+		return t
+	}
+	return hubv1.IdentifierType_IDENTIFIER_TYPE_LOCAL
+}
+
+// mapTitles sets the main title, and failing that a program, episode, or
+// segment title, as the title. Series titles are series relations and
+// other titles alternatives.
+func mapTitles(record *hubv1.Record, titles []Title) {
+	main := -1
+	for _, preferred := range []string{"main", "", "program", "episode", "segment"} {
+		for i, t := range titles {
+			if strings.EqualFold(strings.TrimSpace(t.Type), preferred) && strings.TrimSpace(t.Text) != "" {
+				main = i
+				break
+			}
+		}
+		if main >= 0 {
+			break
+		}
+	}
+
+	for i, t := range titles {
+		text := strings.TrimSpace(t.Text)
+		switch {
+		case text == "":
+		case i == main:
+			record.Title = text
+		case strings.EqualFold(strings.TrimSpace(t.Type), "series"):
+			record.Relations = append(record.Relations,
+				hub.NewRelation(hubv1.RelationType_RELATION_TYPE_IN_SERIES, text))
+		default:
+			record.AltTitle = append(record.AltTitle, text)
+		}
+	}
+	// A series title alone names the asset
+	if record.Title == "" {
+		for _, rel := range record.Relations {
+			if rel.Type == hubv1.RelationType_RELATION_TYPE_IN_SERIES {
+				record.Title = rel.TargetTitle
+				break
+			}
+		}
+	}
+}
+
+// assetDateType maps a dateType attribute to a hub date type. An untyped
+// asset date is the date the asset was issued (broadcast, for most).
+func assetDateType(t string) hubv1.DateType {
+	switch strings.ToLower(strings.TrimSpace(t)) {
+	case "", "broadcast", "aired", "issued", "published", "released", "release", "distributed":
+		return hubv1.DateType_DATE_TYPE_ISSUED
+	case "created", "creation", "produced", "production":
+		return hubv1.DateType_DATE_TYPE_CREATED
+	case "recorded", "captured", "digitized":
+		return hubv1.DateType_DATE_TYPE_CAPTURED
+	case "copyright":
+		return hubv1.DateType_DATE_TYPE_COPYRIGHT
+	case "available", "availablestart":
+		return hubv1.DateType_DATE_TYPE_AVAILABLE
+	case "revised", "modified":
+		return hubv1.DateType_DATE_TYPE_MODIFIED
+	}
+	return hubv1.DateType_DATE_TYPE_OTHER
+}
+
+// parseDate parses a date, keeping it as given when it is not EDTF.
+func parseDate(s string, dateType hubv1.DateType) *hubv1.DateValue {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil
+	}
+	dv, err := helpers.ParseEDTF(s, dateType)
+	if err != nil {
+		return &hubv1.DateValue{Type: dateType, Raw: s}
+	}
+	return dv
+}
+
+// subjectType maps a subjectType attribute to a hub subject type.
+func subjectType(t string) hubv1.SubjectType {
+	switch strings.ToLower(strings.TrimSpace(t)) {
+	case "entity", "personal", "person", "corporate", "organization", "name", "family":
+		return hubv1.SubjectType_SUBJECT_TYPE_NAME
+	case "place", "geographic", "geographical", "spatial":
+		return hubv1.SubjectType_SUBJECT_TYPE_GEOGRAPHIC
+	case "time", "temporal", "era":
+		return hubv1.SubjectType_SUBJECT_TYPE_TEMPORAL
+	case "genre", "form":
+		return hubv1.SubjectType_SUBJECT_TYPE_GENRE
+	case "title":
+		return hubv1.SubjectType_SUBJECT_TYPE_TITLE
+	}
+	return hubv1.SubjectType_SUBJECT_TYPE_TOPIC
+}
+
+// vocabularies maps source attributes to hub vocabularies.
+var vocabularies = map[string]hubv1.SubjectVocabulary{
+	"lcsh":     hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LCSH,
+	"lcnaf":    hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LCNAF,
+	"naf":      hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LCNAF,
+	"aat":      hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_AAT,
+	"fast":     hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_FAST,
+	"mesh":     hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_MESH,
+	"tgn":      hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_GETTY_TGN,
+	"lcgft":    hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_GENRE,
+	"local":    hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LOCAL,
+	"keywords": hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_KEYWORDS,
+}
+
+// vocabulary maps a source attribute to a hub vocabulary; terms from an
+// unknown source are local.
+func vocabulary(source string) hubv1.SubjectVocabulary {
+	source = strings.ToLower(strings.TrimSpace(source))
+	if v, ok := vocabularies[source]; ok {
+		return v
+	}
+	if source == "" {
+		return hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_UNSPECIFIED
+	}
+	return hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LOCAL
+}
+
+// relationTypes map PBCore relation types to hub relation types.
+var relationTypes = map[string]hubv1.RelationType{
+	"is part of":       hubv1.RelationType_RELATION_TYPE_PART_OF,
+	"has part":         hubv1.RelationType_RELATION_TYPE_HAS_PART,
+	"is version of":    hubv1.RelationType_RELATION_TYPE_VERSION_OF,
+	"has version":      hubv1.RelationType_RELATION_TYPE_HAS_VERSION,
+	"is format of":     hubv1.RelationType_RELATION_TYPE_FORMAT_OF,
+	"has format":       hubv1.RelationType_RELATION_TYPE_HAS_FORMAT,
+	"references":       hubv1.RelationType_RELATION_TYPE_REFERENCES,
+	"is referenced by": hubv1.RelationType_RELATION_TYPE_IS_CITED_BY,
+	"replaces":         hubv1.RelationType_RELATION_TYPE_REPLACES,
+	"is replaced by":   hubv1.RelationType_RELATION_TYPE_IS_REPLACED_BY,
+	"is derived from":  hubv1.RelationType_RELATION_TYPE_DERIVED_FROM,
+	"is source of":     hubv1.RelationType_RELATION_TYPE_SOURCE_OF,
+	"is member of":     hubv1.RelationType_RELATION_TYPE_MEMBER_OF,
+	"has member":       hubv1.RelationType_RELATION_TYPE_HAS_MEMBER,
+	"is related to":    hubv1.RelationType_RELATION_TYPE_RELATED_TO,
+}
+
+// relation maps a pbcoreRelation; the related asset is identified by a
+// URL, a detected identifier, or failing those by title.
+func relation(rel Relation) *hubv1.Relation {
+	target := strings.TrimSpace(rel.Identifier.Text)
+	if target == "" {
+		return nil
+	}
+	label := strings.ToLower(strings.TrimSpace(rel.Type.Text))
+	typ, ok := relationTypes[label]
+	if !ok {
+		typ = hub.NormalizeRelationType(strings.ReplaceAll(label, " ", ""))
+	}
+
+	r := &hubv1.Relation{Type: typ}
+	switch idType := hub.DetectIdentifierType(target); idType {
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_URL:
+		r.TargetUri = target
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_UNSPECIFIED:
+		if rel.Identifier.Source != "" {
+			r.TargetId = target
+			r.TargetIdType = hubv1.IdentifierType_IDENTIFIER_TYPE_LOCAL
+		} else {
+			r.TargetTitle = target
+		}
+	default:
+		r.TargetId = target
+		r.TargetIdType = idType
+	}
+	if rel.Identifier.Ref != "" && r.TargetUri == "" {
+		r.TargetUri = rel.Identifier.Ref
+	}
+	return r
+}
+
+// addContributor adds an agent in its first role, as a MARC relator
+// where the role is one.
+func addContributor(record *hubv1.Record, a Agent, roles []Value) {
+	name := strings.TrimSpace(a.Text)
+	if name == "" {
+		return
+	}
+	c := &hubv1.Contributor{
+		Name:         name,
+		ParsedName:   helpers.ParseName(name),
+		AuthorityUri: a.Ref,
+		Affiliation:  strings.TrimSpace(a.Affiliation),
+	}
+	var role string
+	for _, r := range roles {
+		if role = strings.TrimSpace(r.Text); role != "" {
+			break
+		}
+	}
+	if code := helpers.NormalizeRole(role); helpers.MARCRelators[code] != "" {
+		c.RoleCode = "relators:" + code
+		c.Role = helpers.RelatorLabel(code)
+	} else {
+		c.Role = role
+	}
+	record.Contributors = append(record.Contributors, c)
+}
+
+// mediaTypes map instantiation media types to resource types.
+var mediaTypes = map[string]hubv1.ResourceTypeValue{
+	"sound":        hubv1.ResourceTypeValue_RESOURCE_TYPE_AUDIO,
+	"audio":        hubv1.ResourceTypeValue_RESOURCE_TYPE_AUDIO,
+	"moving image": hubv1.ResourceTypeValue_RESOURCE_TYPE_VIDEO,
+	"video":        hubv1.ResourceTypeValue_RESOURCE_TYPE_VIDEO,
+	"static image": hubv1.ResourceTypeValue_RESOURCE_TYPE_IMAGE,
+	"text":         hubv1.ResourceTypeValue_RESOURCE_TYPE_TEXT,
+}
+
+// instantiationToFile maps an instantiation to a file with its media
+// info. A digital instantiation is located by its path; a physical one
+// keeps its location in the media info.
+func instantiationToFile(inst *Instantiation) *hubv1.File {
+	media := &hubv1.MediaInfo{
+		MediaType:            strings.TrimSpace(inst.MediaType),
+		Standard:             strings.TrimSpace(inst.Standard),
+		Duration:             strings.TrimSpace(inst.Duration),
+		Tracks:               strings.TrimSpace(inst.Tracks),
+		ChannelConfiguration: strings.TrimSpace(inst.ChannelConfiguration),
+		DataRate:             measure(inst.DataRate),
+		Colors:               strings.TrimSpace(inst.Colors),
+	}
+	for _, g := range inst.Generations {
+		if g = strings.TrimSpace(g); g != "" {
+			media.Generation = g
+			break
+		}
+	}
+	for _, t := range inst.EssenceTracks {
+		media.Standard = cmp.Or(media.Standard, strings.TrimSpace(t.Standard))
+		media.Encoding = cmp.Or(media.Encoding, strings.TrimSpace(t.Encoding))
+		media.DataRate = cmp.Or(media.DataRate, measure(t.DataRate))
+		media.FrameRate = cmp.Or(media.FrameRate, strings.TrimSpace(t.FrameRate))
+		media.SamplingRate = cmp.Or(media.SamplingRate, measure(t.SamplingRate))
+		media.BitDepth = cmp.Or(media.BitDepth, strings.TrimSpace(t.BitDepth))
+		media.FrameSize = cmp.Or(media.FrameSize, strings.TrimSpace(t.FrameSize))
+		media.AspectRatio = cmp.Or(media.AspectRatio, strings.TrimSpace(t.AspectRatio))
+		media.Duration = cmp.Or(media.Duration, strings.TrimSpace(t.Duration))
+	}
+
+	file := &hubv1.File{Media: media, Role: fileRole(media.Generation)}
+	location := strings.TrimSpace(inst.Location)
+	if inst.Digital != nil || inst.Physical == nil {
+		file.Path = location
+		if location != "" {
+			file.Name = path.Base(location)
+		}
+		if inst.Digital != nil {
+			file.MimeType = strings.TrimSpace(inst.Digital.Text)
+		}
+		file.SizeBytes = fileSize(inst.FileSize)
+	} else {
+		media.PhysicalFormat = strings.TrimSpace(inst.Physical.Text)
+		media.Location = location
+	}
+	if file.Name == "" {
+		for _, id := range inst.Identifiers {
+			if file.Name = strings.TrimSpace(id.Text); file.Name != "" {
+				break
+			}
+		}
+	}
+	for _, a := range inst.Annotations {
+		if text := strings.TrimSpace(a.Text); text != "" {
+			file.Description = text
+			break
+		}
+	}
+	return file
+}
+
+// fileRole gives the file role of a generation: preservation masters,
+// mezzanines, access copies, and originals.
+func fileRole(generation string) string {
+	g := strings.ToLower(generation)
+	switch {
+	case strings.Contains(g, "preservation"):
+		return hub.FileRolePreservation
+	case strings.Contains(g, "mezzanine"):
+		return hub.FileRoleIntermediate
+	case strings.Contains(g, "access"), strings.Contains(g, "proxy"):
+		return hub.FileRoleService
+	case strings.Contains(g, "original"):
+		return hub.FileRoleOriginal
+	}
+	return ""
+}
+
+// measure gives a value with its units, e.g. "48 kHz".
+func measure(m *Measure) string {
+	if m == nil {
+		return ""
+	}
+	value, units := strings.TrimSpace(m.Text), strings.TrimSpace(m.Units)
+	if value == "" || units == "" {
+		return value
+	}
+	return value + " " + units
+}
+
+// sizeUnits are the bytes in each unit a file size may be given in.
+var sizeUnits = map[string]float64{
+	"": 1, "b": 1, "byte": 1, "bytes": 1,
+	"kb": 1e3, "mb": 1e6, "gb": 1e9, "tb": 1e12,
+	"kib": 1 << 10, "mib": 1 << 20, "gib": 1 << 30, "tib": 1 << 40,
+}
+
+// fileSize reads an instantiation's file size in bytes.
+func fileSize(m *Measure) int64 {
+	if m == nil {
+		return 0
+	}
+	n, err := strconv.ParseFloat(strings.TrimSpace(m.Text), 64)
+	if err != nil || n < 0 {
+		return 0
+	}
+	unit, ok := sizeUnits[strings.ToLower(strings.TrimSpace(m.Units))]
+	if !ok {
+		return 0
+	}
+	return int64(n * unit)
+}
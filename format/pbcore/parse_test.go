@@ -0,0 +1,215 @@
+package pbcore
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+func parseFile(t *testing.T, path string) []*hubv1.Record {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	records, err := (&Format{}).Parse(f, nil)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	return records
+}
+
+func TestParse(t *testing.T) {
+	records := parseFile(t, "testdata/radio.xml")
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+	record := records[0]
+
+	if record.Title != "Reactions to the death of Dr. King" {
+		t.Errorf("Title: got %q", record.Title)
+	}
+	if len(record.AltTitle) != 1 || record.AltTitle[0] != "Forum, April 5, 1968" {
+		t.Errorf("AltTitle: got %v", record.AltTitle)
+	}
+	if rels := hub.GetRelationsByType(record, hubv1.RelationType_RELATION_TYPE_IN_SERIES); len(rels) != 1 ||
+		rels[0].TargetTitle != "Lehigh Valley Forum" {
+		t.Errorf("series: got %v", rels)
+	}
+	if rels := hub.GetRelationsByType(record, hubv1.RelationType_RELATION_TYPE_PART_OF); len(rels) != 1 ||
+		rels[0].TargetId != "wlvr-forum" {
+		t.Errorf("part of: got %v", rels)
+	}
+
+	if record.SourceInfo.GetSourceId() != "wlvr-1968-0405" || record.SourceInfo.GetFormatVersion() != Version {
+		t.Errorf("SourceInfo: got %v", record.SourceInfo)
+	}
+	if len(record.Identifiers) != 2 ||
+		record.Identifiers[0].Type != hubv1.IdentifierType_IDENTIFIER_TYPE_LOCAL ||
+		record.Identifiers[1].Type != hubv1.IdentifierType_IDENTIFIER_TYPE_HANDLE {
+		t.Errorf("Identifiers: got %v", record.Identifiers)
+	}
+
+	if len(record.Dates) != 2 ||
+		record.Dates[0].Type != hubv1.DateType_DATE_TYPE_ISSUED || record.Dates[0].Day != 5 ||
+		record.Dates[1].Type != hubv1.DateType_DATE_TYPE_CAPTURED {
+		t.Errorf("Dates: got %v", record.Dates)
+	}
+	if record.ResourceType.GetOriginal() != "Episode" || record.ResourceType.GetType() != hubv1.ResourceTypeValue_RESOURCE_TYPE_AUDIO {
+		t.Errorf("ResourceType: got %v", record.ResourceType)
+	}
+
+	if len(record.Subjects) != 4 {
+		t.Fatalf("expected 4 subjects, got %v", record.Subjects)
+	}
+	if s := record.Subjects[0]; s.Vocabulary != hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LCSH || s.Uri == "" {
+		t.Errorf("topic: got %v", s)
+	}
+	if s := record.Subjects[1]; s.Type != hubv1.SubjectType_SUBJECT_TYPE_NAME {
+		t.Errorf("entity: got %v", s)
+	}
+	if s := record.Subjects[2]; s.Type != hubv1.SubjectType_SUBJECT_TYPE_GEOGRAPHIC || s.Value != "Bethlehem (Pa.)" {
+		t.Errorf("spatial coverage: got %v", s)
+	}
+	if s := record.Subjects[3]; s.Type != hubv1.SubjectType_SUBJECT_TYPE_TEMPORAL {
+		t.Errorf("temporal coverage: got %v", s)
+	}
+	if len(record.Genres) != 1 || record.Genres[0].Value != "Call-in" {
+		t.Errorf("Genres: got %v", record.Genres)
+	}
+
+	if record.Abstract != "Students and faculty call in the morning after the assassination." {
+		t.Errorf("Abstract: got %q", record.Abstract)
+	}
+	if record.Description != "Call-in program hosted from the WLVR studio." {
+		t.Errorf("Description: got %q", record.Description)
+	}
+	if len(record.Notes) != 2 || record.Notes[1] != "Untranscribed" {
+		t.Errorf("Notes: got %v", record.Notes)
+	}
+
+	if len(record.Contributors) != 2 {
+		t.Fatalf("expected 2 contributors, got %v", record.Contributors)
+	}
+	producer := record.Contributors[0]
+	if producer.RoleCode != "relators:pro" || producer.Affiliation != "Lehigh University" ||
+		producer.AuthorityUri != "http://id.loc.gov/authorities/names/n00000001" {
+		t.Errorf("producer: got %v", producer)
+	}
+	if host := record.Contributors[1]; host.Role != "Host" || host.RoleCode != "relators:hst" {
+		t.Errorf("host: got %v", host)
+	}
+	if record.Publisher != "WLVR-FM" {
+		t.Errorf("Publisher: got %q", record.Publisher)
+	}
+
+	if len(record.Rights) != 2 || record.Rights[0].Statement == "" ||
+		record.Rights[1].Uri != "http://rightsstatements.org/vocab/InC-EDU/1.0/" {
+		t.Errorf("Rights: got %v", record.Rights)
+	}
+
+	if record.Duration != "00:58:30" || record.Language != "eng" {
+		t.Errorf("Duration and Language: got %q, %q", record.Duration, record.Language)
+	}
+}
+
+func TestParseInstantiations(t *testing.T) {
+	files := parseFile(t, "testdata/radio.xml")[0].Files
+	if len(files) != 3 {
+		t.Fatalf("expected 3 files, got %d", len(files))
+	}
+
+	tape := files[0]
+	if tape.Path != "" || tape.Name != "T-0412" || tape.Role != hub.FileRoleOriginal {
+		t.Errorf("tape: got %v", tape)
+	}
+	if m := tape.Media; m.GetPhysicalFormat() != "1/4 inch audio tape" || m.GetLocation() != "Vault B, shelf 12" ||
+		m.GetChannelConfiguration() != "Mono" || m.GetTracks() != "1 audio track" {
+		t.Errorf("tape media: got %v", m)
+	}
+	if tape.Description != `Reel box labeled "Forum 4/5"` {
+		t.Errorf("tape description: got %q", tape.Description)
+	}
+
+	master := files[1]
+	if master.Path != "https://media.lehigh.edu/wlvr/wlvr-1968-0405_pres.wav" || master.Name != "wlvr-1968-0405_pres.wav" ||
+		master.MimeType != "audio/x-wav" || master.SizeBytes != 1011000000 || master.Role != hub.FileRolePreservation {
+		t.Errorf("master: got %v", master)
+	}
+	if m := master.Media; m.GetStandard() != "Broadcast WAV" || m.GetDataRate() != "2304 kbps" ||
+		m.GetEncoding() != "PCM" || m.GetSamplingRate() != "96 kHz" || m.GetBitDepth() != "24 bit" ||
+		m.GetPhysicalFormat() != "" {
+		t.Errorf("master media: got %v", m)
+	}
+
+	if proxy := files[2]; proxy.SizeBytes != 28080000 || proxy.Role != hub.FileRoleService {
+		t.Errorf("proxy: got %v", proxy)
+	}
+}
+
+func TestParseMinimal(t *testing.T) {
+	record := parseFile(t, "testdata/radio.xml")[1]
+	if record.Title != "Election night coverage" || record.Description != "" {
+		t.Errorf("got title %q, description %q", record.Title, record.Description)
+	}
+	if record.ResourceType.GetType() != hubv1.ResourceTypeValue_RESOURCE_TYPE_AUDIO {
+		t.Errorf("media type as resource type: got %v", record.ResourceType)
+	}
+	if len(record.Files) != 1 || record.Files[0].Media.GetPhysicalFormat() != "Audio cassette" {
+		t.Errorf("Files: got %v", record.Files)
+	}
+}
+
+func TestParseDocument(t *testing.T) {
+	in := `<pbcoreDescriptionDocument xmlns="` + Namespace + `">
+  <pbcoreIdentifier source="DOI">10.1234/abcd</pbcoreIdentifier>
+  <pbcoreTitle titleType="Series">Music from Packer Chapel</pbcoreTitle>
+  <pbcoreDescription>Weekly organ recital.</pbcoreDescription>
+</pbcoreDescriptionDocument>`
+	records, err := (&Format{}).Parse(strings.NewReader(in), nil)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("expected 1 record, got %d", len(records))
+	}
+	record := records[0]
+	if record.Title != "Music from Packer Chapel" {
+		t.Errorf("series title alone as title: got %q", record.Title)
+	}
+	if len(record.Identifiers) != 1 || record.Identifiers[0].Type != hubv1.IdentifierType_IDENTIFIER_TYPE_DOI {
+		t.Errorf("Identifiers: got %v", record.Identifiers)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for name, in := range map[string]string{
+		"wrong root": `<mods xmlns="http://www.loc.gov/mods/v3"/>`,
+		"empty":      ``,
+		"malformed":  `<pbcoreCollection><pbcoreDescriptionDocument>`,
+	} {
+		if _, err := (&Format{}).Parse(strings.NewReader(in), nil); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestCanParse(t *testing.T) {
+	f := &Format{}
+	for _, in := range []string{
+		`<pbcoreCollection xmlns="` + Namespace + `">`,
+		`<pbcoreDescriptionDocument>`,
+	} {
+		if !f.CanParse([]byte(in)) {
+			t.Errorf("CanParse(%q) = false", in)
+		}
+	}
+	if f.CanParse([]byte(`<mods xmlns="http://www.loc.gov/mods/v3">`)) {
+		t.Error("CanParse accepted MODS")
+	}
+}
@@ -0,0 +1,89 @@
+// Package pbcore provides a format plugin for PBCore 2.1, the metadata
+// schema of public broadcasting and audiovisual archives.
+//
+// Parse reads a pbcoreCollection or a lone pbcoreDescriptionDocument and
+// returns a record per document. Serialize writes a pbcoreCollection.
+//
+// The mapping covers:
+//   - pbcoreTitle: the main (or program, episode, or segment) title as the
+//     title, series titles as series relations, and other titles as
+//     alternatives
+//   - pbcoreIdentifier, pbcoreAssetDate, pbcoreAssetType (as the original
+//     resource type), pbcoreGenre, and pbcoreSubject
+//   - pbcoreDescription: abstracts as the abstract, the first other
+//     description as the description, and the rest as notes
+//   - pbcoreCoverage as geographic and temporal subjects
+//   - pbcoreCreator and pbcoreContributor as contributors, and the first
+//     pbcorePublisher as the publisher
+//   - pbcoreRelation, pbcoreRightsSummary, and pbcoreAnnotation (as notes)
+//   - pbcoreInstantiation as files: a digital instantiation's location is
+//     the file path and its format the MIME type, a physical carrier is a
+//     file without a path, and the technical metadata of both, with that
+//     of their first audio and video essence tracks, is the file's media
+//     info. The generation gives the file role, and the first duration
+//     and language are the record's.
+//
+// Audience levels and ratings are not mapped. PBCore requires an
+// identifier, a title, and a description; records without an identifier
+// or a source ID, or without a title, fail to serialize, and a record
+// without a description is written with an empty one.
+package pbcore
+
+import (
+	"bytes"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+)
+
+// Version is the PBCore schema version this implementation targets.
+const Version = "2.1"
+
+// Format implements the PBCore format.
+type Format struct{}
+
+// Ensure Format implements the interfaces
+var (
+	_ format.Format         = (*Format)(nil)
+	_ format.Parser         = (*Format)(nil)
+	_ format.Serializer     = (*Format)(nil)
+	_ format.FieldDescriber = (*Format)(nil)
+)
+
+// Name returns the format identifier.
+func (f *Format) Name() string {
+	return "pbcore"
+}
+
+// Description returns a human-readable format description.
+func (f *Format) Description() string {
+	return "PBCore " + Version + " audiovisual metadata"
+}
+
+// Extensions returns file extensions associated with this format.
+func (f *Format) Extensions() []string {
+	return []string{"xml"}
+}
+
+// writtenFields are the hub record fields Serialize writes.
+var writtenFields = []string{
+	"title", "alt_title", "abstract", "description", "notes", "contributors",
+	"dates", "resource_type", "genres", "subjects", "language", "publisher",
+	"rights", "rights_holder", "copyright_statement", "identifiers",
+	"duration", "files", "relations",
+}
+
+// WrittenFields returns the hub record fields Serialize writes.
+func (f *Format) WrittenFields(opts *format.SerializeOptions) []string {
+	return writtenFields
+}
+
+// CanParse returns true if the input looks like PBCore XML.
+func (f *Format) CanParse(peek []byte) bool {
+	return bytes.Contains(peek, []byte("<pbcoreDescriptionDocument")) ||
+		bytes.Contains(peek, []byte("<pbcoreCollection")) ||
+		bytes.Contains(peek, []byte(Namespace))
+}
+
+func init() {
+	format.Register(&Format{})
+}
@@ -0,0 +1,377 @@
+package pbcore
+
+import (
+	"cmp"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"mime"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/helpers"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+// Serialize writes records as description documents in a
+// pbcoreCollection.
+func (f *Format) Serialize(w io.Writer, records []*hubv1.Record, opts *format.SerializeOptions) error {
+	if len(records) == 0 {
+		return fmt.Errorf("PBCore requires at least one description document: %w", format.ErrEmptyDocument)
+	}
+
+	collection := Collection{
+		Xmlns:             Namespace,
+		XmlnsXSI:          NamespaceXSI,
+		XSISchemaLocation: schemaLocation,
+	}
+	for i, record := range records {
+		doc, err := recordToDocument(record)
+		if err != nil {
+			return fmt.Errorf("record %d: %w", i, err)
+		}
+		collection.Documents = append(collection.Documents, *doc)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	output, err := xml.MarshalIndent(collection, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling PBCore: %w", err)
+	}
+	if _, err := w.Write(output); err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, "\n")
+	return err
+}
+
+// recordToDocument builds the description document of a record.
+func recordToDocument(record *hubv1.Record) (*Document, error) {
+	doc := &Document{}
+
+	for _, id := range record.Identifiers {
+		if id.Value != "" {
+			doc.Identifiers = append(doc.Identifiers, Identifier{Source: identifierSource(id.Type), Text: id.Value})
+		}
+	}
+	if len(doc.Identifiers) == 0 && record.SourceInfo != nil && record.SourceInfo.SourceId != "" {
+		doc.Identifiers = append(doc.Identifiers, Identifier{
+			Source: cmp.Or(record.SourceInfo.Format, "local"),
+			Text:   record.SourceInfo.SourceId,
+		})
+	}
+	if len(doc.Identifiers) == 0 {
+		return nil, fmt.Errorf("no identifier or source ID for the mandatory pbcoreIdentifier")
+	}
+	if record.Title == "" {
+		return nil, fmt.Errorf("no title for the mandatory pbcoreTitle")
+	}
+
+	if rt := record.ResourceType; rt != nil && rt.Original != "" && rt.Vocabulary == "pbcore" {
+		doc.AssetTypes = []Value{{Text: rt.Original}}
+	}
+	for _, d := range record.Dates {
+		if t, ok := dateTypes[d.Type]; ok {
+			if text := dateText(d); text != "" {
+				doc.AssetDates = append(doc.AssetDates, Date{Type: t, Text: text})
+			}
+		}
+	}
+
+	doc.Titles = []Title{{Type: "Main", Text: record.Title}}
+	for _, rel := range hub.GetRelationsByType(record, hubv1.RelationType_RELATION_TYPE_IN_SERIES) {
+		if rel.TargetTitle != "" {
+			doc.Titles = append(doc.Titles, Title{Type: "Series", Text: rel.TargetTitle})
+		}
+	}
+	for _, alt := range record.AltTitle {
+		if alt != "" {
+			doc.Titles = append(doc.Titles, Title{Type: "Alternative", Text: alt})
+		}
+	}
+
+	for _, s := range record.Subjects {
+		if s.Value == "" {
+			continue
+		}
+		switch s.Type {
+		case hubv1.SubjectType_SUBJECT_TYPE_GEOGRAPHIC, hubv1.SubjectType_SUBJECT_TYPE_TEMPORAL:
+			coverageType := "Spatial"
+			if s.Type == hubv1.SubjectType_SUBJECT_TYPE_TEMPORAL {
+				coverageType = "Temporal"
+			}
+			doc.Coverages = append(doc.Coverages, Coverage{
+				Coverage: Value{Source: sourceNames[s.Vocabulary], Ref: s.Uri, Text: s.Value},
+				Type:     coverageType,
+			})
+		default:
+			doc.Subjects = append(doc.Subjects, Subject{
+				Type:   subjectTypeNames[s.Type],
+				Source: sourceNames[s.Vocabulary],
+				Ref:    s.Uri,
+				Text:   s.Value,
+			})
+		}
+	}
+
+	if record.Abstract != "" {
+		doc.Descriptions = append(doc.Descriptions, Description{Type: "Abstract", Text: record.Abstract})
+	}
+	if record.Description != "" {
+		doc.Descriptions = append(doc.Descriptions, Description{Type: "Description", Text: record.Description})
+	}
+	// pbcoreDescription is mandatory, but may be empty
+	if len(doc.Descriptions) == 0 {
+		doc.Descriptions = []Description{{}}
+	}
+
+	for _, g := range record.Genres {
+		if g.Value != "" {
+			doc.Genres = append(doc.Genres, Value{Source: sourceNames[g.Vocabulary], Ref: g.Uri, Text: g.Value})
+		}
+	}
+
+	for _, rel := range record.Relations {
+		if rel.Type == hubv1.RelationType_RELATION_TYPE_IN_SERIES {
+			continue
+		}
+		target := cmp.Or(rel.TargetId, rel.TargetUri, rel.TargetTitle)
+		if target == "" {
+			continue
+		}
+		doc.Relations = append(doc.Relations, Relation{
+			Type:       Value{Text: relationLabel(rel.Type)},
+			Identifier: Value{Text: target},
+		})
+	}
+
+	for _, note := range record.Notes {
+		if note != "" {
+			doc.Annotations = append(doc.Annotations, Annotation{Text: note})
+		}
+	}
+
+	for _, c := range record.Contributors {
+		if c.Name == "" {
+			continue
+		}
+		agent := Agent{Ref: c.AuthorityUri, Affiliation: c.Affiliation, Text: c.Name}
+		var roles []Value
+		if role := cmp.Or(c.Role, helpers.RelatorLabel(helpers.RelatorCodeFromURI(c.RoleCode))); role != "" {
+			roles = []Value{{Text: role}}
+			if code := helpers.RelatorCodeFromURI(c.RoleCode); helpers.MARCRelators[code] != "" {
+				roles[0].Source = "MARC Relator"
+				roles[0].Ref = "http://id.loc.gov/vocabulary/relators/" + code
+			}
+		}
+		switch code := helpers.RelatorCodeFromURI(c.RoleCode); {
+		case code == "pbl":
+			doc.Publishers = append(doc.Publishers, Publisher{Publisher: agent, Roles: roles})
+		case helpers.IsCreatorRole(cmp.Or(code, c.Role)):
+			doc.Creators = append(doc.Creators, Creator{Creator: agent, Roles: roles})
+		default:
+			doc.Contributors = append(doc.Contributors, Contributor{Contributor: agent, Roles: roles})
+		}
+	}
+	if record.Publisher != "" {
+		doc.Publishers = append([]Publisher{{
+			Publisher: Agent{Text: record.Publisher},
+			Roles:     []Value{{Text: "Publisher"}},
+		}}, doc.Publishers...)
+	}
+
+	for _, r := range record.Rights {
+		rs := RightsSummary{Summary: r.Statement, Link: r.Uri}
+		if rs.Summary == "" && r.Uri == "" {
+			rs.Summary = r.License
+		}
+		if rs.Summary != "" || rs.Link != "" {
+			doc.RightsSummaries = append(doc.RightsSummaries, rs)
+		}
+	}
+	if s := cmp.Or(record.CopyrightStatement, record.RightsHolder); s != "" {
+		doc.RightsSummaries = append(doc.RightsSummaries, RightsSummary{Summary: s})
+	}
+
+	for _, file := range record.Files {
+		if inst := fileToInstantiation(file, record); inst != nil {
+			doc.Instantiations = append(doc.Instantiations, *inst)
+		}
+	}
+	return doc, nil
+}
+
+// identifierSource names the system that assigned an identifier.
+func identifierSource(t hubv1.IdentifierType) string {
+	switch t {
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_DOI:
+		return "DOI"
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_HANDLE:
+		return "Handle"
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_URL:
+		return "URI"
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_LOCAL, hubv1.IdentifierType_IDENTIFIER_TYPE_UNSPECIFIED:
+		return "local"
+	}
+	return strings.TrimPrefix(t.String(), "IDENTIFIER_TYPE_")
+}
+
+// dateTypes are the dateType attributes of the hub date types PBCore
+// records as asset dates.
+var dateTypes = map[hubv1.DateType]string{
+	hubv1.DateType_DATE_TYPE_ISSUED:    "issued",
+	hubv1.DateType_DATE_TYPE_PUBLISHED: "published",
+	hubv1.DateType_DATE_TYPE_CREATED:   "created",
+	hubv1.DateType_DATE_TYPE_CAPTURED:  "recorded",
+	hubv1.DateType_DATE_TYPE_COPYRIGHT: "copyright",
+	hubv1.DateType_DATE_TYPE_AVAILABLE: "available",
+	hubv1.DateType_DATE_TYPE_MODIFIED:  "revised",
+}
+
+// dateText gives a date in EDTF, or as given where it was not parsed.
+func dateText(d *hubv1.DateValue) string {
+	if d.Year != 0 {
+		return hub.FormatEDTF(d)
+	}
+	return d.Raw
+}
+
+// subjectTypeNames are the subjectType attributes of hub subject types.
+var subjectTypeNames = map[hubv1.SubjectType]string{
+	hubv1.SubjectType_SUBJECT_TYPE_TOPIC: "Topic",
+	hubv1.SubjectType_SUBJECT_TYPE_NAME:  "Entity",
+	hubv1.SubjectType_SUBJECT_TYPE_GENRE: "Genre",
+	hubv1.SubjectType_SUBJECT_TYPE_TITLE: "Title",
+}
+
+// sourceNames are the source attributes of hub vocabularies.
+var sourceNames = map[hubv1.SubjectVocabulary]string{
+	hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LCSH:      "LCSH",
+	hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LCNAF:     "LCNAF",
+	hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_AAT:       "AAT",
+	hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_FAST:      "FAST",
+	hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_MESH:      "MeSH",
+	hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_GETTY_TGN: "TGN",
+	hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_KEYWORDS:  "keywords",
+	hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LOCAL:     "local",
+}
+
+// relationLabel names a relation type in PBCore's terms, or failing that
+// in words.
+func relationLabel(t hubv1.RelationType) string {
+	for label, rt := range relationTypes {
+		if rt == t {
+			words := strings.Fields(label)
+			for i, w := range words {
+				words[i] = strings.ToUpper(w[:1]) + w[1:]
+			}
+			return strings.Join(words, " ")
+		}
+	}
+	return strings.ReplaceAll(strings.ToLower(strings.TrimPrefix(t.String(), "RELATION_TYPE_")), "_", " ")
+}
+
+// generations are the generations written for file roles when a file
+// has no media info giving one.
+var generations = map[string]string{
+	hub.FileRoleOriginal:     "Original",
+	hub.FileRolePreservation: "Preservation master",
+	hub.FileRoleIntermediate: "Mezzanine",
+	hub.FileRoleService:      "Access copy",
+}
+
+// fileToInstantiation builds an instantiation of a file: a digital one
+// for a file with a path, and a physical carrier for one without. Files
+// with neither a path nor media info are skipped.
+func fileToInstantiation(file *hubv1.File, record *hubv1.Record) *Instantiation {
+	media := file.Media
+	if file.Path == "" && media == nil {
+		return nil
+	}
+	if media == nil {
+		media = &hubv1.MediaInfo{}
+	}
+
+	inst := &Instantiation{
+		Standard:             media.Standard,
+		MediaType:            media.MediaType,
+		Duration:             cmp.Or(media.Duration, record.Duration),
+		Colors:               media.Colors,
+		Tracks:               media.Tracks,
+		ChannelConfiguration: media.ChannelConfiguration,
+		DataRate:             toMeasure(media.DataRate),
+	}
+	name := cmp.Or(file.Name, path.Base(file.Path))
+	if file.Path == "" {
+		name = file.Name
+	}
+	if name != "" {
+		inst.Identifiers = []Identifier{{Source: "local", Text: name}}
+	}
+
+	if file.Path != "" {
+		mimeType := file.MimeType
+		if mimeType == "" {
+			mimeType, _, _ = strings.Cut(mime.TypeByExtension(path.Ext(file.Path)), ";")
+		}
+		inst.Digital = &Value{Text: mimeType}
+		inst.Location = file.Path
+		if file.SizeBytes > 0 {
+			inst.FileSize = &Measure{Units: "bytes", Text: strconv.FormatInt(file.SizeBytes, 10)}
+		}
+	} else {
+		inst.Physical = &Value{Text: media.PhysicalFormat}
+		inst.Location = media.Location
+	}
+	if len(inst.Identifiers) == 0 {
+		inst.Identifiers = []Identifier{{Source: "local", Text: inst.Location}}
+	}
+
+	if g := cmp.Or(media.Generation, generations[file.Role]); g != "" {
+		inst.Generations = []string{g}
+	}
+	if record.Language != "" {
+		inst.Languages = []string{record.Language}
+	}
+
+	track := EssenceTrack{
+		Encoding:     media.Encoding,
+		FrameRate:    media.FrameRate,
+		SamplingRate: toMeasure(media.SamplingRate),
+		BitDepth:     media.BitDepth,
+		FrameSize:    media.FrameSize,
+		AspectRatio:  media.AspectRatio,
+	}
+	if track.Encoding != "" || track.FrameRate != "" || track.SamplingRate != nil ||
+		track.BitDepth != "" || track.FrameSize != "" || track.AspectRatio != "" {
+		switch strings.ToLower(media.MediaType) {
+		case "sound":
+			track.Type = "Audio"
+		case "moving image":
+			track.Type = "Video"
+		}
+		inst.EssenceTracks = []EssenceTrack{track}
+	}
+
+	if file.Description != "" {
+		inst.Annotations = []Annotation{{Text: file.Description}}
+	}
+	return inst
+}
+
+// toMeasure splits a value with units, e.g. "48 kHz", into a measure.
+func toMeasure(s string) *Measure {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil
+	}
+	if value, units, ok := strings.Cut(s, " "); ok {
+		return &Measure{Units: strings.TrimSpace(units), Text: value}
+	}
+	return &Measure{Text: s}
+}
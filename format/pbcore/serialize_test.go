@@ -0,0 +1,118 @@
+package pbcore
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+func serialize(t *testing.T, records ...*hubv1.Record) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := (&Format{}).Serialize(&buf, records, nil); err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+	return buf.String()
+}
+
+func TestSerializeRoundTrip(t *testing.T) {
+	in := parseFile(t, "testdata/radio.xml")
+	out := serialize(t, in...)
+
+	for _, want := range []string{
+		`<pbcoreCollection xmlns="http://www.pbcore.org/PBCore/PBCoreNamespace.html"`,
+		`<pbcoreAssetType>Episode</pbcoreAssetType>`,
+		`<pbcoreAssetDate dateType="issued">1968-04-05</pbcoreAssetDate>`,
+		`<pbcoreTitle titleType="Series">Lehigh Valley Forum</pbcoreTitle>`,
+		`<coverageType>Temporal</coverageType>`,
+		`<contributorRole source="MARC Relator" ref="http://id.loc.gov/vocabulary/relators/pro">Producer</contributorRole>`,
+		`<publisher>WLVR-FM</publisher>`,
+		`<rightsLink>http://rightsstatements.org/vocab/InC-EDU/1.0/</rightsLink>`,
+		`<instantiationPhysical>1/4 inch audio tape</instantiationPhysical>`,
+		`<essenceTrackSamplingRate unitsOfMeasure="kHz">96</essenceTrackSamplingRate>`,
+		`<pbcoreDescription></pbcoreDescription>`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %s", want)
+		}
+	}
+
+	back, err := (&Format{}).Parse(strings.NewReader(out), nil)
+	if err != nil {
+		t.Fatalf("re-parsing output failed: %v", err)
+	}
+	if len(back) != len(in) {
+		t.Fatalf("expected %d records, got %d", len(in), len(back))
+	}
+	got, want := back[0], in[0]
+	if got.Title != want.Title || got.Abstract != want.Abstract || got.Description != want.Description ||
+		got.Publisher != want.Publisher || got.Duration != want.Duration {
+		t.Errorf("record: got %v", got)
+	}
+	if len(got.Contributors) != len(want.Contributors) || len(got.Subjects) != len(want.Subjects) ||
+		len(got.Rights) != len(want.Rights) || len(got.Files) != len(want.Files) {
+		t.Errorf("repeated fields: got %v", got)
+	}
+	for i, file := range got.Files {
+		if file.Path != want.Files[i].Path || file.Role != want.Files[i].Role || file.SizeBytes != want.Files[i].SizeBytes ||
+			file.Media.GetSamplingRate() != want.Files[i].Media.GetSamplingRate() ||
+			file.Media.GetPhysicalFormat() != want.Files[i].Media.GetPhysicalFormat() {
+			t.Errorf("file %d: got %v, want %v", i, file, want.Files[i])
+		}
+	}
+}
+
+func TestSerializeFiles(t *testing.T) {
+	record := &hubv1.Record{
+		Title:       "Homecoming game, 1972",
+		Identifiers: []*hubv1.Identifier{hub.NewIdentifier("10.1234/homecoming", hubv1.IdentifierType_IDENTIFIER_TYPE_DOI)},
+		Duration:    "01:12:00",
+		Files: []*hubv1.File{
+			{Path: "homecoming.mov", Role: hub.FileRoleService},
+			{Path: "thumbnail.jpg", Role: hub.FileRoleThumbnail, Media: &hubv1.MediaInfo{FrameSize: "640x480"}},
+			{Name: "no path or media"},
+		},
+	}
+	doc, err := recordToDocument(record)
+	if err != nil {
+		t.Fatalf("recordToDocument failed: %v", err)
+	}
+	if doc.Identifiers[0].Source != "DOI" {
+		t.Errorf("identifier source: got %q", doc.Identifiers[0].Source)
+	}
+	if len(doc.Descriptions) != 1 || doc.Descriptions[0].Text != "" {
+		t.Errorf("empty mandatory description: got %v", doc.Descriptions)
+	}
+	if len(doc.Instantiations) != 2 {
+		t.Fatalf("expected 2 instantiations, got %d", len(doc.Instantiations))
+	}
+	mov := doc.Instantiations[0]
+	if mov.Digital == nil || mov.Digital.Text != "video/quicktime" || mov.Location != "homecoming.mov" ||
+		mov.Duration != "01:12:00" || len(mov.Generations) != 1 || mov.Generations[0] != "Access copy" {
+		t.Errorf("service file: got %+v", mov)
+	}
+	if jpg := doc.Instantiations[1]; len(jpg.EssenceTracks) != 1 || jpg.EssenceTracks[0].FrameSize != "640x480" {
+		t.Errorf("essence track: got %+v", jpg)
+	}
+}
+
+func TestSerializeErrors(t *testing.T) {
+	if err := (&Format{}).Serialize(&bytes.Buffer{}, nil, nil); !errors.Is(err, format.ErrEmptyDocument) {
+		t.Errorf("empty input: got %v", err)
+	}
+
+	noID := &hubv1.Record{Title: "Untitled reel"}
+	if err := (&Format{}).Serialize(&bytes.Buffer{}, []*hubv1.Record{noID}, nil); err == nil || !strings.Contains(err.Error(), "pbcoreIdentifier") {
+		t.Errorf("missing identifier: got %v", err)
+	}
+
+	noTitle := &hubv1.Record{SourceInfo: &hubv1.SourceInfo{Format: "csv", SourceId: "42"}}
+	if err := (&Format{}).Serialize(&bytes.Buffer{}, []*hubv1.Record{noTitle}, nil); err == nil || !strings.Contains(err.Error(), "pbcoreTitle") {
+		t.Errorf("missing title: got %v", err)
+	}
+}
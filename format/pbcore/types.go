@@ -0,0 +1,180 @@
+package pbcore
+
+import "encoding/xml"
+
+// Namespace and schema location of PBCore 2.1.
+const (
+	Namespace    = "http://www.pbcore.org/PBCore/PBCoreNamespace.html"
+	NamespaceXSI = "http://www.w3.org/2001/XMLSchema-instance"
+
+	schemaLocation = Namespace + " https://raw.githubusercontent.com/PBCore-AV-Metadata/PBCore_Schema/master/pbcore-2.1.xsd"
+)
+
+// Collection is a pbcoreCollection: the description documents of a batch.
+type Collection struct {
+	XMLName           xml.Name   `xml:"pbcoreCollection"`
+	Xmlns             string     `xml:"xmlns,attr,omitempty"`
+	XmlnsXSI          string     `xml:"xmlns:xsi,attr,omitempty"`
+	XSISchemaLocation string     `xml:"xsi:schemaLocation,attr,omitempty"`
+	Documents         []Document `xml:"pbcoreDescriptionDocument"`
+}
+
+// Document is a pbcoreDescriptionDocument describing one asset, with its
+// elements in the order the schema requires.
+type Document struct {
+	XMLName           xml.Name        `xml:"pbcoreDescriptionDocument"`
+	Xmlns             string          `xml:"xmlns,attr,omitempty"`
+	XmlnsXSI          string          `xml:"xmlns:xsi,attr,omitempty"`
+	XSISchemaLocation string          `xml:"xsi:schemaLocation,attr,omitempty"`
+	AssetTypes        []Value         `xml:"pbcoreAssetType"`
+	AssetDates        []Date          `xml:"pbcoreAssetDate"`
+	Identifiers       []Identifier    `xml:"pbcoreIdentifier"`
+	Titles            []Title         `xml:"pbcoreTitle"`
+	Subjects          []Subject       `xml:"pbcoreSubject"`
+	Descriptions      []Description   `xml:"pbcoreDescription"`
+	Genres            []Value         `xml:"pbcoreGenre"`
+	Relations         []Relation      `xml:"pbcoreRelation"`
+	Coverages         []Coverage      `xml:"pbcoreCoverage"`
+	AudienceLevels    []Value         `xml:"pbcoreAudienceLevel"`
+	AudienceRatings   []Value         `xml:"pbcoreAudienceRating"`
+	Annotations       []Annotation    `xml:"pbcoreAnnotation"`
+	Creators          []Creator       `xml:"pbcoreCreator"`
+	Contributors      []Contributor   `xml:"pbcoreContributor"`
+	Publishers        []Publisher     `xml:"pbcorePublisher"`
+	RightsSummaries   []RightsSummary `xml:"pbcoreRightsSummary"`
+	Instantiations    []Instantiation `xml:"pbcoreInstantiation"`
+}
+
+// Value is a term with the vocabulary it comes from: source names the
+// vocabulary and ref is the term's URI.
+type Value struct {
+	Source string `xml:"source,attr,omitempty"`
+	Ref    string `xml:"ref,attr,omitempty"`
+	Text   string `xml:",chardata"`
+}
+
+// Date is a date of the asset or an instantiation, by type (e.g.
+// "broadcast", "created").
+type Date struct {
+	Type string `xml:"dateType,attr,omitempty"`
+	Text string `xml:",chardata"`
+}
+
+// Identifier is an identifier, with the system that assigned it.
+type Identifier struct {
+	Source string `xml:"source,attr"`
+	Text   string `xml:",chardata"`
+}
+
+// Title is a title, by type (e.g. "Series", "Episode", "Alternative").
+type Title struct {
+	Type string `xml:"titleType,attr,omitempty"`
+	Text string `xml:",chardata"`
+}
+
+// Subject is a subject, by type (e.g. "Topic", "Entity").
+type Subject struct {
+	Type   string `xml:"subjectType,attr,omitempty"`
+	Source string `xml:"source,attr,omitempty"`
+	Ref    string `xml:"ref,attr,omitempty"`
+	Text   string `xml:",chardata"`
+}
+
+// Description is a description, by type (e.g. "Abstract", "Summary").
+type Description struct {
+	Type string `xml:"descriptionType,attr,omitempty"`
+	Text string `xml:",chardata"`
+}
+
+// Relation is a related asset and how it is related.
+type Relation struct {
+	Type       Value `xml:"pbcoreRelationType"`
+	Identifier Value `xml:"pbcoreRelationIdentifier"`
+}
+
+// Coverage is the place or time an asset is about.
+type Coverage struct {
+	Coverage Value  `xml:"coverage"`
+	Type     string `xml:"coverageType"`
+}
+
+// Annotation is a note, by type.
+type Annotation struct {
+	Type string `xml:"annotationType,attr,omitempty"`
+	Text string `xml:",chardata"`
+}
+
+// Agent is a person or organization, with its authority URI and
+// affiliation.
+type Agent struct {
+	Ref         string `xml:"ref,attr,omitempty"`
+	Affiliation string `xml:"affiliation,attr,omitempty"`
+	Text        string `xml:",chardata"`
+}
+
+// Creator is a creator in a role.
+type Creator struct {
+	Creator Agent   `xml:"creator"`
+	Roles   []Value `xml:"creatorRole"`
+}
+
+// Contributor is a contributor in a role.
+type Contributor struct {
+	Contributor Agent   `xml:"contributor"`
+	Roles       []Value `xml:"contributorRole"`
+}
+
+// Publisher is a publisher or distributor.
+type Publisher struct {
+	Publisher Agent   `xml:"publisher"`
+	Roles     []Value `xml:"publisherRole"`
+}
+
+// RightsSummary is a rights statement, a link to one, or both.
+type RightsSummary struct {
+	Summary string `xml:"rightsSummary,omitempty"`
+	Link    string `xml:"rightsLink,omitempty"`
+}
+
+// Measure is a value with its units (e.g. a file size in bytes).
+type Measure struct {
+	Units string `xml:"unitsOfMeasure,attr,omitempty"`
+	Text  string `xml:",chardata"`
+}
+
+// Instantiation is one copy of the asset: a digital file or a physical
+// carrier, with its technical metadata.
+type Instantiation struct {
+	Identifiers          []Identifier   `xml:"instantiationIdentifier"`
+	Dates                []Date         `xml:"instantiationDate"`
+	Physical             *Value         `xml:"instantiationPhysical"`
+	Digital              *Value         `xml:"instantiationDigital"`
+	Standard             string         `xml:"instantiationStandard,omitempty"`
+	Location             string         `xml:"instantiationLocation"`
+	MediaType            string         `xml:"instantiationMediaType,omitempty"`
+	Generations          []string       `xml:"instantiationGenerations"`
+	FileSize             *Measure       `xml:"instantiationFileSize"`
+	Duration             string         `xml:"instantiationDuration,omitempty"`
+	DataRate             *Measure       `xml:"instantiationDataRate"`
+	Colors               string         `xml:"instantiationColors,omitempty"`
+	Tracks               string         `xml:"instantiationTracks,omitempty"`
+	ChannelConfiguration string         `xml:"instantiationChannelConfiguration,omitempty"`
+	Languages            []string       `xml:"instantiationLanguage"`
+	EssenceTracks        []EssenceTrack `xml:"instantiationEssenceTrack"`
+	Annotations          []Annotation   `xml:"instantiationAnnotation"`
+}
+
+// EssenceTrack is one audio, video, or text track of an instantiation.
+type EssenceTrack struct {
+	Type         string   `xml:"essenceTrackType,omitempty"`
+	Standard     string   `xml:"essenceTrackStandard,omitempty"`
+	Encoding     string   `xml:"essenceTrackEncoding,omitempty"`
+	DataRate     *Measure `xml:"essenceTrackDataRate"`
+	FrameRate    string   `xml:"essenceTrackFrameRate,omitempty"`
+	SamplingRate *Measure `xml:"essenceTrackSamplingRate"`
+	BitDepth     string   `xml:"essenceTrackBitDepth,omitempty"`
+	FrameSize    string   `xml:"essenceTrackFrameSize,omitempty"`
+	AspectRatio  string   `xml:"essenceTrackAspectRatio,omitempty"`
+	Duration     string   `xml:"essenceTrackDuration,omitempty"`
+	Languages    []string `xml:"essenceTrackLanguage"`
+}
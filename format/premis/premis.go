@@ -0,0 +1,72 @@
+// Package premis provides a serializer that writes PREMIS 3 preservation
+// metadata describing the crosswalk itself: one metadata modification event
+// per record, linked to the record's identifier and to crosswalk as the
+// executing software agent, for preservation systems to attach to an
+// ingest SIP.
+//
+// Events are built from each record's source info: the source format and
+// profile, and the transformation steps the convert command records with
+// --provenance or --premis. Steps with warnings make the event's outcome a
+// warning rather than a success.
+package premis
+
+import (
+	"runtime/debug"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+)
+
+// Version is the PREMIS data dictionary version this implementation targets.
+const Version = "3.0"
+
+// Format implements the PREMIS event format.
+type Format struct{}
+
+// Ensure Format implements the interfaces
+var (
+	_ format.Format         = (*Format)(nil)
+	_ format.Serializer     = (*Format)(nil)
+	_ format.FieldDescriber = (*Format)(nil)
+)
+
+// Name returns the format identifier.
+func (f *Format) Name() string {
+	return "premis"
+}
+
+// Description returns a human-readable format description.
+func (f *Format) Description() string {
+	return "PREMIS " + Version + " events describing the crosswalk of each record"
+}
+
+// Extensions returns file extensions associated with this format.
+func (f *Format) Extensions() []string {
+	return []string{"premis"}
+}
+
+// WrittenFields returns the hub record fields Serialize writes: only the
+// identifier linking each event to its record.
+func (f *Format) WrittenFields(opts *format.SerializeOptions) []string {
+	return []string{"identifiers"}
+}
+
+// CanParse returns false; PREMIS events are output only.
+func (f *Format) CanParse(peek []byte) bool {
+	return false
+}
+
+// AgentVersion is the crosswalk version recorded on the software agent:
+// the module version crosswalk was built at, or "(devel)" for a local
+// build.
+var AgentVersion = buildVersion()
+
+func buildVersion() string {
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" {
+		return info.Main.Version
+	}
+	return "(devel)"
+}
+
+func init() {
+	format.Register(&Format{})
+}
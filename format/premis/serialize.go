@@ -0,0 +1,173 @@
+package premis
+
+import (
+	"crypto/rand"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+// agentName identifies crosswalk as the agent of every event.
+const agentName = "crosswalk"
+
+// now is the event time for records without recorded steps; tests replace it.
+var now = time.Now
+
+// Serialize writes a PREMIS document with an event for each record and the
+// crosswalk agent they link to.
+func (f *Format) Serialize(w io.Writer, records []*hubv1.Record, opts *format.SerializeOptions) error {
+	doc := Document{
+		Version: Version,
+		Agents: []Agent{{
+			IdentifierType:  "local",
+			IdentifierValue: agentName,
+			Name:            agentName,
+			Type:            Term{Value: "software", Authority: "agentType", AuthorityURI: vocabAgentType, ValueURI: vocabAgentType + "/sof"},
+			Version:         AgentVersion,
+		}},
+	}
+	for _, r := range records {
+		doc.Events = append(doc.Events, event(r))
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("encoding PREMIS: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// event describes the crosswalk of a record.
+func event(r *hubv1.Record) Event {
+	si := r.GetSourceInfo()
+	e := Event{
+		Identifier: Identifier{Type: "UUID", Value: newUUID()},
+		Type: Term{
+			Value:        eventTypeMetadata,
+			Authority:    "eventType",
+			AuthorityURI: vocabEventType,
+			ValueURI:     vocabEventType + "/" + eventTypeMetadataCode,
+		},
+		DateTime: eventTime(si).Format(time.RFC3339),
+		Detail:   detail(si),
+		LinkingAgents: []LinkingAgent{{
+			Type:  "local",
+			Value: agentName,
+			Role:  Term{Value: "executing program", Authority: "eventRelatedAgentRole", AuthorityURI: vocabAgentRole, ValueURI: vocabAgentRole + "/exe"},
+		}},
+	}
+
+	outcome := &OutcomeInformation{Outcome: Term{Value: OutcomeSuccess}}
+	for _, step := range si.GetSteps() {
+		for _, warning := range step.GetWarnings() {
+			outcome.Outcome.Value = OutcomeWarning
+			outcome.Details = append(outcome.Details, OutcomeDetail{Note: warning})
+		}
+	}
+	e.Outcome = outcome
+
+	if typ, value := objectIdentifier(r); value != "" {
+		e.LinkingObjects = []LinkingObject{{
+			Type:  typ,
+			Value: value,
+			Role:  Term{Value: "outcome", Authority: "eventRelatedObjectRole", AuthorityURI: vocabObjectRole, ValueURI: vocabObjectRole + "/out"},
+		}}
+	}
+	return e
+}
+
+// eventTime returns when the record was serialized, or the time of its
+// last recorded step, or now.
+func eventTime(si *hubv1.SourceInfo) time.Time {
+	steps := si.GetSteps()
+	for i := len(steps) - 1; i >= 0; i-- {
+		if at := steps[i].GetAt(); at != nil {
+			return at.AsTime().UTC()
+		}
+	}
+	if si.GetParsedAt() != nil {
+		return si.GetParsedAt().AsTime().UTC()
+	}
+	return now().UTC()
+}
+
+// detail summarizes the transformation: the source format and profile,
+// the target format, and the enrichment and post-processing steps.
+func detail(si *hubv1.SourceInfo) string {
+	var b strings.Builder
+	b.WriteString("Metadata crosswalk")
+	if si.GetFormat() != "" {
+		fmt.Fprintf(&b, " from %s", si.GetFormat())
+	}
+	if si.GetProfile() != "" {
+		fmt.Fprintf(&b, " (profile %s)", si.GetProfile())
+	}
+	var steps []string
+	for _, step := range si.GetSteps() {
+		switch step.GetStage() {
+		case hub.StageEnrich:
+			steps = append(steps, "enriched with "+step.GetName())
+		case hub.StagePostProcess:
+			steps = append(steps, "post-processed with "+step.GetName())
+		case hub.StageSerialize:
+			fmt.Fprintf(&b, " to %s", step.GetName())
+		}
+	}
+	for _, s := range steps {
+		b.WriteString("; " + s)
+	}
+	return b.String()
+}
+
+// objectTypes are the identifier types that name a record's metadata, in
+// order of preference.
+var objectTypes = []hubv1.IdentifierType{
+	hubv1.IdentifierType_IDENTIFIER_TYPE_DOI,
+	hubv1.IdentifierType_IDENTIFIER_TYPE_HANDLE,
+	hubv1.IdentifierType_IDENTIFIER_TYPE_URL,
+	hubv1.IdentifierType_IDENTIFIER_TYPE_LOCAL,
+}
+
+// objectIdentifier returns the type and value of the identifier linking an
+// event to its record: a persistent identifier if it has one, else its
+// source ID.
+func objectIdentifier(r *hubv1.Record) (string, string) {
+	for _, t := range objectTypes {
+		if id := hub.GetIdentifier(r, t); id != nil && id.Value != "" {
+			return identifierTypeName(t), id.Value
+		}
+	}
+	if len(r.Identifiers) > 0 && r.Identifiers[0].Value != "" {
+		return identifierTypeName(r.Identifiers[0].Type), r.Identifiers[0].Value
+	}
+	if id := r.GetSourceInfo().GetSourceId(); id != "" {
+		return "local", id
+	}
+	return "", ""
+}
+
+// identifierTypeName returns the short name of an identifier type, as in
+// "doi" or "handle".
+func identifierTypeName(t hubv1.IdentifierType) string {
+	return strings.ToLower(strings.TrimPrefix(t.String(), "IDENTIFIER_TYPE_"))
+}
+
+// newUUID returns a random (version 4) UUID.
+func newUUID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
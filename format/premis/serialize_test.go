@@ -0,0 +1,98 @@
+package premis
+
+import (
+	"bytes"
+	"encoding/xml"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+func TestSerialize(t *testing.T) {
+	parsed := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	serialized := parsed.Add(time.Minute)
+	records := []*hubv1.Record{
+		{
+			Title: "Crosswalked",
+			Identifiers: []*hubv1.Identifier{
+				{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_LOCAL, Value: "node-7"},
+				{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_DOI, Value: "10.1234/abc"},
+			},
+			SourceInfo: &hubv1.SourceInfo{
+				Format:  "drupal",
+				Profile: "islandora",
+				Steps: []*hubv1.TransformationStep{
+					{Stage: hub.StageParse, Name: "drupal", At: timestamppb.New(parsed)},
+					{Stage: hub.StageEnrich, Name: "crossref", At: timestamppb.New(parsed)},
+					{Stage: hub.StageSerialize, Name: "mods", At: timestamppb.New(serialized), Warnings: []string{"funders not written by mods"}},
+				},
+			},
+		},
+		{
+			Title:      "No provenance",
+			SourceInfo: &hubv1.SourceInfo{SourceId: "row-2"},
+		},
+	}
+
+	now = func() time.Time { return parsed }
+	defer func() { now = time.Now }()
+
+	var buf bytes.Buffer
+	if err := (&Format{}).Serialize(&buf, records, nil); err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+	var doc Document
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid XML: %v\n%s", err, buf.String())
+	}
+	if doc.XMLName.Space != Namespace || doc.Version != "3.0" {
+		t.Errorf("root = %v version %q", doc.XMLName, doc.Version)
+	}
+	if len(doc.Agents) != 1 || doc.Agents[0].Name != "crosswalk" || doc.Agents[0].Type.Value != "software" || doc.Agents[0].Version == "" {
+		t.Errorf("Agents = %+v", doc.Agents)
+	}
+	if len(doc.Events) != 2 {
+		t.Fatalf("got %d events, want 2", len(doc.Events))
+	}
+
+	uuid := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	e := doc.Events[0]
+	if !uuid.MatchString(e.Identifier.Value) {
+		t.Errorf("event identifier = %q, want a UUID", e.Identifier.Value)
+	}
+	if e.Type.Value != "metadata modification" || !strings.HasSuffix(e.Type.ValueURI, "/mem") {
+		t.Errorf("event type = %+v", e.Type)
+	}
+	if e.DateTime != "2026-03-01T12:01:00Z" {
+		t.Errorf("event time = %q, want the serialize step's", e.DateTime)
+	}
+	if want := "Metadata crosswalk from drupal (profile islandora) to mods; enriched with crossref"; e.Detail != want {
+		t.Errorf("detail = %q, want %q", e.Detail, want)
+	}
+	if e.Outcome == nil || e.Outcome.Outcome.Value != OutcomeWarning || len(e.Outcome.Details) != 1 {
+		t.Errorf("outcome = %+v, want a warning with its note", e.Outcome)
+	}
+	if len(e.LinkingAgents) != 1 || e.LinkingAgents[0].Value != "crosswalk" || e.LinkingAgents[0].Role.Value != "executing program" {
+		t.Errorf("linking agents = %+v", e.LinkingAgents)
+	}
+	if len(e.LinkingObjects) != 1 || e.LinkingObjects[0].Type != "doi" || e.LinkingObjects[0].Value != "10.1234/abc" {
+		t.Errorf("linking objects = %+v, want the DOI", e.LinkingObjects)
+	}
+
+	e = doc.Events[1]
+	if e.Outcome == nil || e.Outcome.Outcome.Value != OutcomeSuccess {
+		t.Errorf("outcome = %+v, want success", e.Outcome)
+	}
+	if e.DateTime != "2026-03-01T12:00:00Z" {
+		t.Errorf("event time = %q, want now", e.DateTime)
+	}
+	if len(e.LinkingObjects) != 1 || e.LinkingObjects[0].Type != "local" || e.LinkingObjects[0].Value != "row-2" {
+		t.Errorf("linking objects = %+v, want the source ID", e.LinkingObjects)
+	}
+}
@@ -0,0 +1,89 @@
+package premis
+
+import "encoding/xml"
+
+// Namespace is the PREMIS 3 XML namespace.
+const Namespace = "http://www.loc.gov/premis/v3"
+
+// Controlled vocabularies from id.loc.gov used in events and agents.
+const (
+	vocabEventType        = "http://id.loc.gov/vocabulary/preservation/eventType"
+	vocabAgentRole        = "http://id.loc.gov/vocabulary/preservation/eventRelatedAgentRole"
+	vocabObjectRole       = "http://id.loc.gov/vocabulary/preservation/eventRelatedObjectRole"
+	vocabAgentType        = "http://id.loc.gov/vocabulary/preservation/agentType"
+	eventTypeMetadata     = "metadata modification"
+	eventTypeMetadataCode = "mem"
+)
+
+// Event outcomes.
+const (
+	OutcomeSuccess = "success"
+	OutcomeWarning = "warning"
+)
+
+// Document is a PREMIS container of events and the agent they share.
+type Document struct {
+	XMLName xml.Name `xml:"http://www.loc.gov/premis/v3 premis"`
+	Version string   `xml:"version,attr"`
+	Events  []Event  `xml:"event"`
+	Agents  []Agent  `xml:"agent"`
+}
+
+// Event is a PREMIS event.
+type Event struct {
+	Identifier     Identifier          `xml:"eventIdentifier"`
+	Type           Term                `xml:"eventType"`
+	DateTime       string              `xml:"eventDateTime"`
+	Detail         string              `xml:"eventDetailInformation>eventDetail,omitempty"`
+	Outcome        *OutcomeInformation `xml:"eventOutcomeInformation,omitempty"`
+	LinkingAgents  []LinkingAgent      `xml:"linkingAgentIdentifier"`
+	LinkingObjects []LinkingObject     `xml:"linkingObjectIdentifier"`
+}
+
+// Identifier is an event identifier.
+type Identifier struct {
+	Type  string `xml:"eventIdentifierType"`
+	Value string `xml:"eventIdentifierValue"`
+}
+
+// Term is a value from a controlled vocabulary.
+type Term struct {
+	Value        string `xml:",chardata"`
+	Authority    string `xml:"authority,attr,omitempty"`
+	AuthorityURI string `xml:"authorityURI,attr,omitempty"`
+	ValueURI     string `xml:"valueURI,attr,omitempty"`
+}
+
+// OutcomeInformation is an event's outcome, with its details.
+type OutcomeInformation struct {
+	Outcome Term            `xml:"eventOutcome"`
+	Details []OutcomeDetail `xml:"eventOutcomeDetail"`
+}
+
+// OutcomeDetail is a note on an event's outcome.
+type OutcomeDetail struct {
+	Note string `xml:"eventOutcomeDetailNote"`
+}
+
+// LinkingAgent names an agent involved in an event.
+type LinkingAgent struct {
+	Type  string `xml:"linkingAgentIdentifierType"`
+	Value string `xml:"linkingAgentIdentifierValue"`
+	Role  Term   `xml:"linkingAgentRole"`
+}
+
+// LinkingObject names an object an event involved.
+type LinkingObject struct {
+	Type  string `xml:"linkingObjectIdentifierType"`
+	Value string `xml:"linkingObjectIdentifierValue"`
+	Role  Term   `xml:"linkingObjectRole"`
+}
+
+// Agent is a PREMIS agent.
+type Agent struct {
+	IdentifierType  string `xml:"agentIdentifier>agentIdentifierType"`
+	IdentifierValue string `xml:"agentIdentifier>agentIdentifierValue"`
+	Name            string `xml:"agentName"`
+	Type            Term   `xml:"agentType"`
+	Version         string `xml:"agentVersion,omitempty"`
+}
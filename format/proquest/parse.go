@@ -108,13 +108,27 @@ func authorToContributor(author *pqv1.Author) *hubv1.Contributor {
 
 // advisorToContributor converts a ProQuest Advisor to a hub Contributor.
 func advisorToContributor(advisor *pqv1.Advisor) *hubv1.Contributor {
+	return degreeContributor(advisor.Name, "advisor", "relators:ths", hubv1.DegreeRole_DEGREE_ROLE_ADVISOR)
+}
+
+// committeeMemberToContributor converts a ProQuest CommitteeMember to a hub
+// Contributor.
+func committeeMemberToContributor(member *pqv1.CommitteeMember) *hubv1.Contributor {
+	return degreeContributor(member.Name, "committee member", "relators:dgc", hubv1.DegreeRole_DEGREE_ROLE_COMMITTEE_MEMBER)
+}
+
+// degreeContributor builds the contributor for an advisor or committee
+// member, or nil when the name is empty.
+func degreeContributor(name *pqv1.Name, role, roleCode string, degreeRole hubv1.DegreeRole) *hubv1.Contributor {
 	c := &hubv1.Contributor{
-		Role: "advisor",
-		Type: hubv1.ContributorType_CONTRIBUTOR_TYPE_PERSON,
+		Role:       role,
+		RoleCode:   roleCode,
+		DegreeRole: degreeRole,
+		Type:       hubv1.ContributorType_CONTRIBUTOR_TYPE_PERSON,
 	}
 
-	if advisor.Name != nil {
-		c.ParsedName = nameToHub(advisor.Name)
+	if name != nil {
+		c.ParsedName = nameToHub(name)
 		c.Name = buildDisplayName(c.ParsedName)
 	}
 
@@ -167,13 +181,19 @@ func mapDescription(record *hubv1.Record, desc *pqv1.Description) {
 		}
 	}
 
-	// Advisors
+	// Advisors and committee members
 	for _, adv := range desc.Advisors {
 		c := advisorToContributor(adv)
 		if c != nil {
 			record.Contributors = append(record.Contributors, c)
 		}
 	}
+	for _, member := range desc.CommitteeMembers {
+		c := committeeMemberToContributor(member)
+		if c != nil {
+			record.Contributors = append(record.Contributors, c)
+		}
+	}
 
 	// Categorization
 	if desc.Categorization != nil {
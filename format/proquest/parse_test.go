@@ -33,6 +33,12 @@ func TestParse(t *testing.T) {
         <DISS_fname>Wei-Min</DISS_fname>
       </DISS_name>
     </DISS_advisor>
+    <DISS_cmte_member>
+      <DISS_name>
+        <DISS_surname>Okafor</DISS_surname>
+        <DISS_fname>Ngozi</DISS_fname>
+      </DISS_name>
+    </DISS_cmte_member>
     <DISS_categorization>
       <DISS_keyword>polymers</DISS_keyword>
       <DISS_keyword>networks</DISS_keyword>
@@ -115,18 +121,24 @@ func TestParse(t *testing.T) {
 		t.Error("Author Qin, Tian not found in contributors")
 	}
 
-	// Find the advisor
-	var foundAdvisor bool
+	// Find the advisor and committee member
+	var foundAdvisor, foundMember bool
 	for _, c := range r.Contributors {
-		if c.Role == "advisor" && c.ParsedName != nil {
+		if c.Role == "advisor" && c.DegreeRole == hubv1.DegreeRole_DEGREE_ROLE_ADVISOR && c.ParsedName != nil {
 			if c.ParsedName.Family == "Huang" && c.ParsedName.Given == "Wei-Min" {
 				foundAdvisor = true
 			}
 		}
+		if c.RoleCode == "relators:dgc" && c.DegreeRole == hubv1.DegreeRole_DEGREE_ROLE_COMMITTEE_MEMBER && c.Name == "Okafor, Ngozi" {
+			foundMember = true
+		}
 	}
 	if !foundAdvisor {
 		t.Error("Advisor Huang, Wei-Min not found in contributors")
 	}
+	if !foundMember {
+		t.Error("Committee member Okafor, Ngozi not found in contributors")
+	}
 
 	// Abstract should contain both paragraphs
 	if r.Abstract == "" {
@@ -237,3 +249,31 @@ func TestParseMultipleSubmissions(t *testing.T) {
 		t.Errorf("Record 1 title: got %q, want %q", records[1].Title, "Second Dissertation")
 	}
 }
+
+func TestSerializeCommitteeMembers(t *testing.T) {
+	record := &hubv1.Record{
+		Title: "Sediment Transport in Urban Streams",
+		Contributors: []*hubv1.Contributor{
+			{Name: "Qin, Tian", Role: "author", ParsedName: &hubv1.ParsedName{Family: "Qin", Given: "Tian"}},
+			{Name: "Huang, Wei-Min", RoleCode: "relators:ths", Role: "Thesis advisor", ParsedName: &hubv1.ParsedName{Family: "Huang", Given: "Wei-Min"}},
+			{Name: "Okafor, Ngozi", DegreeRole: hubv1.DegreeRole_DEGREE_ROLE_COMMITTEE_MEMBER, ParsedName: &hubv1.ParsedName{Family: "Okafor", Given: "Ngozi"}},
+		},
+	}
+
+	var buf strings.Builder
+	if err := (&Format{}).Serialize(&buf, []*hubv1.Record{record}, nil); err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"<DISS_advisor>\n      <DISS_name>\n        <DISS_surname>Huang</DISS_surname>",
+		"<DISS_cmte_member>\n      <DISS_name>\n        <DISS_surname>Okafor</DISS_surname>",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+	if strings.Count(out, "<DISS_author ") != 1 {
+		t.Errorf("expected only the author in DISS_authorship:\n%s", out)
+	}
+}
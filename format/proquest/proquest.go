@@ -15,9 +15,10 @@ type Format struct{}
 
 // Ensure Format implements the interfaces
 var (
-	_ format.Format     = (*Format)(nil)
-	_ format.Parser     = (*Format)(nil)
-	_ format.Serializer = (*Format)(nil)
+	_ format.Format         = (*Format)(nil)
+	_ format.Parser         = (*Format)(nil)
+	_ format.Serializer     = (*Format)(nil)
+	_ format.FieldDescriber = (*Format)(nil)
 )
 
 // Name returns the format identifier.
@@ -35,6 +36,17 @@ func (f *Format) Extensions() []string {
 	return []string{"xml"}
 }
 
+// writtenFields are the hub record fields Serialize writes.
+var writtenFields = []string{
+	"title", "abstract", "contributors", "dates", "subjects", "language",
+	"access_condition", "files", "degree_info",
+}
+
+// WrittenFields returns the hub record fields Serialize writes.
+func (f *Format) WrittenFields(opts *format.SerializeOptions) []string {
+	return writtenFields
+}
+
 // CanParse returns true if the input looks like ProQuest ETD XML.
 func (f *Format) CanParse(peek []byte) bool {
 	peek = bytes.TrimSpace(peek)
@@ -9,16 +9,22 @@ import (
 	"github.com/lehigh-university-libraries/crosswalk/format"
 	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
 	pqv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/spoke/proquest/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
 )
 
 // Serialize writes hub records as ProQuest ETD XML.
 func (f *Format) Serialize(w io.Writer, records []*hubv1.Record, opts *format.SerializeOptions) error {
-	// opts reserved for future use (e.g., pretty print, encoding options)
-	_ = opts
+	if opts == nil {
+		opts = format.NewSerializeOptions()
+	}
+
+	if len(records) == 0 {
+		return fmt.Errorf("ProQuest requires a DISS_submission: %w", format.ErrEmptyDocument)
+	}
 
 	for i, record := range records {
 		// Step 1: Convert hub record to spoke proto struct
-		spokeRecord, err := hubToSpoke(record)
+		spokeRecord, err := hubToSpoke(record, opts)
 		if err != nil {
 			return fmt.Errorf("converting record %d to spoke: %w", i, err)
 		}
@@ -50,7 +56,7 @@ func (f *Format) Serialize(w io.Writer, records []*hubv1.Record, opts *format.Se
 }
 
 // hubToSpoke converts a hub record to the ProQuest spoke proto struct.
-func hubToSpoke(record *hubv1.Record) (*pqv1.Submission, error) {
+func hubToSpoke(record *hubv1.Record, opts *format.SerializeOptions) (*pqv1.Submission, error) {
 	submission := &pqv1.Submission{
 		Authorship:  &pqv1.Authorship{},
 		Description: &pqv1.Description{},
@@ -89,16 +95,19 @@ func hubToSpoke(record *hubv1.Record) (*pqv1.Submission, error) {
 		submission.Description.PageCount = record.PageCount
 	}
 
-	// Contributors - separate authors from advisors
+	// Contributors - separate authors from advisors and committee members
 	for _, c := range record.Contributors {
-		role := strings.ToLower(c.Role)
 		name := contributorToName(c)
 
-		switch role {
-		case "advisor", "ths", "thesis advisor":
+		switch hub.DegreeRoleOf(c) {
+		case hubv1.DegreeRole_DEGREE_ROLE_ADVISOR:
 			submission.Description.Advisors = append(submission.Description.Advisors, &pqv1.Advisor{
 				Name: name,
 			})
+		case hubv1.DegreeRole_DEGREE_ROLE_COMMITTEE_MEMBER:
+			submission.Description.CommitteeMembers = append(submission.Description.CommitteeMembers, &pqv1.CommitteeMember{
+				Name: name,
+			})
 		default:
 			// Treat as author
 			author := &pqv1.Author{
@@ -135,7 +144,7 @@ func hubToSpoke(record *hubv1.Record) (*pqv1.Submission, error) {
 	// Dates
 	dates := &pqv1.Dates{}
 	for _, d := range record.Dates {
-		dateStr := formatDate(d)
+		dateStr := format.FormatDate(d, opts.Dates)
 		switch d.Type {
 		case hubv1.DateType_DATE_TYPE_ACCEPTED:
 			dates.AcceptDate = dateStr
@@ -188,20 +197,6 @@ func contributorToName(c *hubv1.Contributor) *pqv1.Name {
 	return name
 }
 
-// formatDate formats a date value to ISO 8601 string.
-func formatDate(d *hubv1.DateValue) string {
-	if d.Year == 0 {
-		return d.Raw
-	}
-	if d.Month == 0 {
-		return fmt.Sprintf("%04d", d.Year)
-	}
-	if d.Day == 0 {
-		return fmt.Sprintf("%04d-%02d", d.Year, d.Month)
-	}
-	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
-}
-
 // spokeToXML converts a spoke proto struct to an XML-marshalable struct.
 func spokeToXML(spoke *pqv1.Submission) *XMLSubmission {
 	xml := &XMLSubmission{}
@@ -264,6 +259,19 @@ func spokeToXML(spoke *pqv1.Submission) *XMLSubmission {
 			}
 		}
 
+		for _, member := range spoke.Description.CommitteeMembers {
+			if member.Name != nil {
+				xml.Description.CommitteeMembers = append(xml.Description.CommitteeMembers, XMLAdvisor{
+					Name: XMLName{
+						Surname: member.Name.Surname,
+						First:   member.Name.First,
+						Middle:  member.Name.Middle,
+						Suffix:  member.Name.Suffix,
+					},
+				})
+			}
+		}
+
 		if spoke.Description.Categorization != nil {
 			cat := spoke.Description.Categorization
 			xml.Description.Categorization = &XMLCategorization{
@@ -377,16 +385,17 @@ type XMLAddress struct {
 
 // XMLDescription represents DISS_description.
 type XMLDescription struct {
-	Title          string             `xml:"DISS_title,omitempty"`
-	Degree         string             `xml:"DISS_degree,omitempty"`
-	DegreeLevel    string             `xml:"DISS_degree_level,omitempty"`
-	Discipline     string             `xml:"DISS_discipline,omitempty"`
-	Institution    *XMLInstitution    `xml:"DISS_institution,omitempty"`
-	PageCount      int32              `xml:"DISS_page_count,omitempty"`
-	Department     string             `xml:"DISS_department,omitempty"`
-	Advisors       []XMLAdvisor       `xml:"DISS_advisor,omitempty"`
-	Categorization *XMLCategorization `xml:"DISS_categorization,omitempty"`
-	Dates          *XMLDates          `xml:"DISS_dates,omitempty"`
+	Title            string             `xml:"DISS_title,omitempty"`
+	Degree           string             `xml:"DISS_degree,omitempty"`
+	DegreeLevel      string             `xml:"DISS_degree_level,omitempty"`
+	Discipline       string             `xml:"DISS_discipline,omitempty"`
+	Institution      *XMLInstitution    `xml:"DISS_institution,omitempty"`
+	PageCount        int32              `xml:"DISS_page_count,omitempty"`
+	Department       string             `xml:"DISS_department,omitempty"`
+	Advisors         []XMLAdvisor       `xml:"DISS_advisor,omitempty"`
+	CommitteeMembers []XMLAdvisor       `xml:"DISS_cmte_member,omitempty"`
+	Categorization   *XMLCategorization `xml:"DISS_categorization,omitempty"`
+	Dates            *XMLDates          `xml:"DISS_dates,omitempty"`
 }
 
 // XMLInstitution represents DISS_institution.
@@ -395,7 +404,8 @@ type XMLInstitution struct {
 	Department string `xml:"DISS_inst_contact,omitempty"`
 }
 
-// XMLAdvisor represents DISS_advisor.
+// XMLAdvisor represents DISS_advisor, and DISS_cmte_member, which has the
+// same content.
 type XMLAdvisor struct {
 	Name XMLName `xml:"DISS_name"`
 }
@@ -74,6 +74,13 @@ func UnmarshalAll(r io.Reader, factory func() proto.Message) ([]proto.Message, e
 	return results, nil
 }
 
+// UnmarshalStart populates a proto message from the element whose start tag
+// was just read from decoder. Callers that scan for records themselves (e.g.
+// to pick up OAI-PMH headers along the way) use this to decode each one.
+func UnmarshalStart(decoder *xml.Decoder, start *xml.StartElement, msg proto.Message) error {
+	return unmarshalMessageElement(decoder, start, msg.ProtoReflect())
+}
+
 // unmarshalFromDecoder scans for the root element and unmarshals it into the message.
 func unmarshalFromDecoder(decoder *xml.Decoder, msg proto.Message, rootElement string) error {
 	msgRef := msg.ProtoReflect()
@@ -115,8 +122,10 @@ func unmarshalFromDecoder(decoder *xml.Decoder, msg proto.Message, rootElement s
 func unmarshalMessageElement(decoder *xml.Decoder, start *xml.StartElement, msgRef protoreflect.Message) error {
 	md := msgRef.Descriptor()
 
-	// Build lookup: xml name → field descriptor
-	fieldsByXMLName := buildFieldLookup(md)
+	// Build lookups: xml name → field descriptor, separately for attributes
+	// and elements since an element may share an attribute's name
+	attrsByXMLName := buildFieldLookup(md, true)
+	fieldsByXMLName := buildFieldLookup(md, false)
 
 	// Build namespace URI → prefix mapping from message options
 	nsURIToPrefix := buildNamespaceLookup(md)
@@ -129,10 +138,10 @@ func unmarshalMessageElement(decoder *xml.Decoder, start *xml.StartElement, msgR
 		}
 
 		attrName := resolveXMLName(attr.Name, nsURIToPrefix)
-		fd, ok := fieldsByXMLName[attrName]
+		fd, ok := attrsByXMLName[attrName]
 		if !ok && attr.Name.Space != "" {
 			// Try local name only
-			fd, ok = fieldsByXMLName[attr.Name.Local]
+			fd, ok = attrsByXMLName[attr.Name.Local]
 		}
 		if !ok {
 			continue
@@ -201,11 +210,19 @@ func unmarshalMessageElement(decoder *xml.Decoder, start *xml.StartElement, msgR
 
 // resolveXMLName converts an xml.Name (which has resolved namespace URIs)
 // back to the prefixed form used in proto annotations.
+// xmlNamespace is the namespace the decoder reports for the xml prefix.
+const xmlNamespace = "http://www.w3.org/XML/1998/namespace"
+
 func resolveXMLName(name xml.Name, nsURIToPrefix map[string]string) string {
 	if name.Space == "" {
 		return name.Local
 	}
 
+	// The xml prefix (xml:lang) is bound by definition, never declared
+	if name.Space == xmlNamespace {
+		return "xml:" + name.Local
+	}
+
 	if prefix, ok := nsURIToPrefix[name.Space]; ok {
 		return prefix + ":" + name.Local
 	}
@@ -274,14 +291,18 @@ func unmarshalMessageField(decoder *xml.Decoder, start *xml.StartElement, parent
 	return unmarshalMessageElement(decoder, start, childMsg)
 }
 
-// buildFieldLookup creates a map from XML element/attribute names to field descriptors.
-func buildFieldLookup(md protoreflect.MessageDescriptor) map[string]protoreflect.FieldDescriptor {
+// buildFieldLookup creates a map from XML attribute names (attrs) or element
+// names to field descriptors.
+func buildFieldLookup(md protoreflect.MessageDescriptor, attrs bool) map[string]protoreflect.FieldDescriptor {
 	lookup := make(map[string]protoreflect.FieldDescriptor)
 	fields := md.Fields()
 
 	for i := 0; i < fields.Len(); i++ {
 		fd := fields.Get(i)
 		fieldOpts := getFieldOptions(fd)
+		if (fieldOpts != nil && fieldOpts.XmlAttr) != attrs {
+			continue
+		}
 
 		xmlName := string(fd.Name())
 		if fieldOpts != nil {
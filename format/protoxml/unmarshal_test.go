@@ -4,10 +4,15 @@ import (
 	"testing"
 
 	"github.com/lehigh-university-libraries/crosswalk/format/protoxml"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
 	arxivv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/spoke/arxiv/v1_0"
 	dcv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/spoke/dublincore/v20200120"
 	pqv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/spoke/proquest/v1"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
 )
 
 func TestUnmarshalArXiv(t *testing.T) {
@@ -414,3 +419,39 @@ func TestMarshalUnmarshalRoundTrip(t *testing.T) {
 		t.Errorf("Abstract: got %v", parsed.Abstract)
 	}
 }
+
+func TestUnmarshalAttributeSharingElementName(t *testing.T) {
+	// A record whose version attribute and version element are different
+	// fields, as spokes generated from an XML schema have
+	attrOpts := &descriptorpb.FieldOptions{}
+	proto.SetExtension(attrOpts, hubv1.E_Field, &hubv1.FieldOptions{XmlName: "version", XmlAttr: true})
+	fdp := &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("versioned.proto"),
+		Package:    proto.String("test"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"hub/v1/options.proto"},
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("Record"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				{Name: proto.String("version"), Number: proto.Int32(1), Type: descriptorpb.FieldDescriptorProto_TYPE_INT32.Enum(), Label: descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()},
+				{Name: proto.String("version_attr"), Number: proto.Int32(2), Type: descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(), Label: descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(), Options: attrOpts},
+			},
+		}},
+	}
+	fd, err := protodesc.NewFile(fdp, protoregistry.GlobalFiles)
+	if err != nil {
+		t.Fatal(err)
+	}
+	md := fd.Messages().ByName("Record")
+	record := dynamicpb.NewMessage(md)
+
+	if err := protoxml.Unmarshal([]byte(`<Record version="1.0"><version>2</version></Record>`), record); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if got := record.Get(md.Fields().ByName("version")).Int(); got != 2 {
+		t.Errorf("version element: got %d, want 2", got)
+	}
+	if got := record.Get(md.Fields().ByName("version_attr")).String(); got != "1.0" {
+		t.Errorf("version attribute: got %q, want 1.0", got)
+	}
+}
@@ -0,0 +1,140 @@
+package rdf
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+//go:embed ontology.yaml
+var defaultOntologyYAML []byte
+
+// SpokeNone turns off spoke predicates when set as Ontology.Spoke.
+const SpokeNone = "none"
+
+// Ontology says which RDF terms describe a hub record.
+type Ontology struct {
+	// Prefixes maps the prefixes of compact IRIs (prefix:name) to their
+	// namespaces
+	Prefixes map[string]string `yaml:"prefixes"`
+
+	// Class is the rdf:type of every record
+	Class string `yaml:"class,omitempty"`
+
+	// Types maps resource types, by short name (e.g. "article"), to the
+	// class records of that type also get
+	Types map[string]string `yaml:"types,omitempty"`
+
+	// Spoke names the spoke whose RDF predicates fill in hub fields that
+	// Fields doesn't cover: empty for each record's source format, or
+	// SpokeNone for none
+	Spoke string `yaml:"spoke,omitempty"`
+
+	// Fields are the predicates written, with the hub values each holds
+	Fields []OntologyField `yaml:"fields"`
+}
+
+// OntologyField writes the values at a hub field path with a predicate.
+type OntologyField struct {
+	// Path is a hub field path, as hub.PathValues takes
+	Path string `yaml:"path"`
+
+	// Predicate is a compact IRI, such as dcterms:title
+	Predicate string `yaml:"predicate"`
+
+	// IRI writes values that are absolute IRIs as IRIs, not literals
+	IRI bool `yaml:"iri,omitempty"`
+}
+
+// DefaultOntology returns the ontology profile used without a profile file.
+func DefaultOntology() *Ontology {
+	o, err := parseOntology(defaultOntologyYAML, nil)
+	if err != nil {
+		panic(fmt.Sprintf("rdf: embedded ontology.yaml: %v", err))
+	}
+	return o
+}
+
+// LoadOntology reads an ontology profile file. Prefixes it leaves out are
+// taken from the default profile.
+func LoadOntology(path string) (*Ontology, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	o, err := parseOntology(data, DefaultOntology())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return o, nil
+}
+
+// parseOntology decodes an ontology profile, taking prefixes it leaves out
+// from base, and checks its paths against the hub schema and its terms
+// against its prefixes.
+func parseOntology(data []byte, base *Ontology) (*Ontology, error) {
+	var o Ontology
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&o); err != nil {
+		return nil, err
+	}
+	if o.Prefixes == nil {
+		o.Prefixes = make(map[string]string)
+	}
+	if base != nil {
+		for prefix, ns := range base.Prefixes {
+			if _, ok := o.Prefixes[prefix]; !ok {
+				o.Prefixes[prefix] = ns
+			}
+		}
+	}
+	if len(o.Fields) == 0 {
+		return nil, fmt.Errorf("no fields")
+	}
+
+	terms := []string{o.Class}
+	for _, class := range o.Types {
+		terms = append(terms, class)
+	}
+	for i, f := range o.Fields {
+		if _, err := hub.PathRepeated(f.Path); err != nil {
+			return nil, fmt.Errorf("fields[%d]: %w", i, err)
+		}
+		if f.Predicate == "" {
+			return nil, fmt.Errorf("fields[%d] (%s): no predicate", i, f.Path)
+		}
+		terms = append(terms, f.Predicate)
+	}
+	for _, term := range terms {
+		if term == "" {
+			continue
+		}
+		if _, err := o.expand(term); err != nil {
+			return nil, err
+		}
+	}
+	return &o, nil
+}
+
+// expand returns the full IRI of a compact IRI, or term itself when it is
+// already a full IRI (with "://", or a URN).
+func (o *Ontology) expand(term string) (string, error) {
+	prefix, name, ok := strings.Cut(term, ":")
+	if !ok {
+		return "", fmt.Errorf("%q is not a compact IRI (prefix:name)", term)
+	}
+	if ns, ok := o.Prefixes[prefix]; ok {
+		return ns + name, nil
+	}
+	if (strings.HasPrefix(name, "//") || prefix == "urn") && isAbsoluteIRI(term) {
+		return term, nil
+	}
+	return "", fmt.Errorf("%q: unknown prefix %s", term, prefix)
+}
@@ -0,0 +1,88 @@
+// Package rdf provides a serializer that writes hub records as RDF, in
+// Turtle or N-Triples, described with DC Terms, BIBO, and schema.org terms.
+//
+// The terms come from an ontology profile (see Ontology): a YAML file of
+// namespace prefixes, classes, and the predicate for each hub field path,
+// named with SerializeOptions.FieldMap. Without one, ontology.yaml in this
+// package is used. The RDF predicates spokes record from a source system's
+// RDF mapping (Drupal's rdf.mapping config) fill in the hub fields the
+// profile doesn't cover, so Islandora content keeps the predicates it was
+// described with.
+//
+// Each record is named by its DOI, handle, or URL, or by a blank node when
+// it has none. Values are plain literals, except those the profile marks
+// as IRIs.
+package rdf
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+)
+
+// Output variants, selected with SerializeOptions.Variant.
+const (
+	VariantTurtle   = "turtle"
+	VariantNTriples = "ntriples"
+)
+
+// Format implements the RDF format.
+type Format struct{}
+
+// Ensure Format implements the interfaces
+var (
+	_ format.Format         = (*Format)(nil)
+	_ format.Serializer     = (*Format)(nil)
+	_ format.FieldDescriber = (*Format)(nil)
+)
+
+// Name returns the format identifier.
+func (f *Format) Name() string {
+	return "rdf"
+}
+
+// Description returns a human-readable format description.
+func (f *Format) Description() string {
+	return "RDF as Turtle or N-Triples (DC Terms, BIBO, schema.org)"
+}
+
+// Extensions returns file extensions associated with this format.
+func (f *Format) Extensions() []string {
+	return []string{"ttl", "nt"}
+}
+
+// WrittenFields returns the hub record fields the ontology profile
+// describes. Spoke predicates can add others, depending on each record's
+// source.
+func (f *Format) WrittenFields(opts *format.SerializeOptions) []string {
+	o, err := ontology(opts)
+	if err != nil {
+		o = DefaultOntology()
+	}
+	seen := map[string]bool{"identifiers": true}
+	if len(o.Types) > 0 {
+		seen["resource_type"] = true
+	}
+	for _, field := range o.Fields {
+		for _, alt := range strings.Split(field.Path, "|") {
+			name, _, _ := strings.Cut(strings.TrimSpace(alt), ".")
+			seen[name] = true
+		}
+	}
+	fields := make([]string, 0, len(seen))
+	for name := range seen {
+		fields = append(fields, name)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// CanParse returns false; RDF is an output format only.
+func (f *Format) CanParse(peek []byte) bool {
+	return false
+}
+
+func init() {
+	format.Register(&Format{})
+}
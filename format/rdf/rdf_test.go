@@ -0,0 +1,134 @@
+package rdf
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+	_ "github.com/lehigh-university-libraries/crosswalk/spoke/islandora/v1"
+)
+
+func testRecords() []*hubv1.Record {
+	return []*hubv1.Record{
+		{
+			Title:        `Field "notes"` + "\nvolume 2",
+			Contributors: []*hubv1.Contributor{{Name: "Smith, Jane"}, {Name: "Doe, John"}},
+			Dates:        []*hubv1.DateValue{{Type: hubv1.DateType_DATE_TYPE_ISSUED, Year: 1999, Precision: hubv1.DatePrecision_DATE_PRECISION_YEAR}},
+			ResourceType: &hubv1.ResourceType{Type: hubv1.ResourceTypeValue_RESOURCE_TYPE_ARTICLE},
+			Identifiers:  []*hubv1.Identifier{hub.NewIdentifier("10.1234/abcd", hubv1.IdentifierType_IDENTIFIER_TYPE_DOI)},
+			Rights:       []*hubv1.Rights{{Statement: "CC BY", Uri: "https://creativecommons.org/licenses/by/4.0/"}},
+		},
+		{Title: "Second record"},
+	}
+}
+
+func serialize(t *testing.T, records []*hubv1.Record, opts *format.SerializeOptions) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := (&Format{}).Serialize(&buf, records, opts); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func TestSerializeTurtle(t *testing.T) {
+	got := serialize(t, testRecords(), nil)
+	want := `@prefix bibo: <http://purl.org/ontology/bibo/> .
+@prefix dcterms: <http://purl.org/dc/terms/> .
+
+<https://doi.org/10.1234/abcd>
+    a dcterms:BibliographicResource, bibo:AcademicArticle ;
+    dcterms:title "Field \"notes\"\nvolume 2" ;
+    dcterms:creator "Smith, Jane", "Doe, John" ;
+    dcterms:issued "1999" ;
+    dcterms:rights "CC BY" ;
+    dcterms:license <https://creativecommons.org/licenses/by/4.0/> ;
+    dcterms:identifier "10.1234/abcd" ;
+    bibo:doi "10.1234/abcd" .
+
+_:record2
+    a dcterms:BibliographicResource ;
+    dcterms:title "Second record" .
+`
+	if got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestSerializeNTriples(t *testing.T) {
+	got := serialize(t, testRecords(), &format.SerializeOptions{Variant: VariantNTriples})
+	lines := strings.Split(strings.TrimSuffix(got, "\n"), "\n")
+	if len(lines) != 12 {
+		t.Errorf("got %d triples, want 12:\n%s", len(lines), got)
+	}
+	for _, want := range []string{
+		`<https://doi.org/10.1234/abcd> <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://purl.org/ontology/bibo/AcademicArticle> .`,
+		`<https://doi.org/10.1234/abcd> <http://purl.org/dc/terms/creator> "Doe, John" .`,
+		`_:record2 <http://purl.org/dc/terms/title> "Second record" .`,
+	} {
+		if !strings.Contains(got, want+"\n") {
+			t.Errorf("missing %s", want)
+		}
+	}
+
+	if err := (&Format{}).Serialize(&bytes.Buffer{}, nil, &format.SerializeOptions{Variant: "rdfxml"}); err == nil {
+		t.Error("want error for an unknown variant")
+	}
+}
+
+func TestSpokePredicates(t *testing.T) {
+	records := []*hubv1.Record{{
+		Title: "Field notes",
+		Dates: []*hubv1.DateValue{
+			{Type: hubv1.DateType_DATE_TYPE_ISSUED, Year: 1999, Precision: hubv1.DatePrecision_DATE_PRECISION_YEAR},
+			{Type: hubv1.DateType_DATE_TYPE_CAPTURED, Year: 2020, Precision: hubv1.DatePrecision_DATE_PRECISION_YEAR},
+		},
+		SourceInfo: &hubv1.SourceInfo{Format: "drupal"},
+	}}
+
+	got := serialize(t, records, nil)
+	if !strings.Contains(got, `premis:creation "2020"`) {
+		t.Errorf("want the Drupal spoke's predicate for dates.captured:\n%s", got)
+	}
+	if strings.Count(got, `"1999"`) != 1 {
+		t.Errorf("want the profile's predicate alone for dates.issued:\n%s", got)
+	}
+
+	path := filepath.Join(t.TempDir(), "ontology.yaml")
+	data := "spoke: none\nfields:\n  - {path: title, predicate: schema:name}\n"
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	got = serialize(t, records, &format.SerializeOptions{FieldMap: path, Variant: VariantNTriples})
+	want := "_:record1 <http://schema.org/name> \"Field notes\" .\n"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestLoadOntologyErrors(t *testing.T) {
+	tests := map[string]string{
+		"unknown path":   "fields:\n  - {path: titel, predicate: dcterms:title}\n",
+		"unknown prefix": "fields:\n  - {path: title, predicate: dc:title}\n",
+		"no predicate":   "fields:\n  - {path: title}\n",
+		"bad class":      "class: Book\nfields:\n  - {path: title, predicate: dcterms:title}\n",
+		"unknown key":    "feilds:\n  - {path: title, predicate: dcterms:title}\n",
+		"no fields":      "class: bibo:Book\n",
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "ontology.yaml")
+			if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := LoadOntology(path); err == nil {
+				t.Error("want error")
+			}
+		})
+	}
+}
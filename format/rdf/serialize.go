@@ -0,0 +1,348 @@
+package rdf
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+	spokeregistry "github.com/lehigh-university-libraries/crosswalk/spoke/registry"
+)
+
+const rdfType = "http://www.w3.org/1999/02/22-rdf-syntax-ns#type"
+
+// term is the subject or object of a triple.
+type term struct {
+	kind  termKind
+	value string
+}
+
+type termKind int
+
+const (
+	literalTerm termKind = iota
+	iriTerm
+	blankTerm
+)
+
+// statement is a predicate and object of a record's description.
+type statement struct {
+	predicate string
+	object    term
+}
+
+// description is the statements about one record.
+type description struct {
+	subject    term
+	statements []statement
+}
+
+// Serialize writes the records as RDF in the syntax opts.Variant selects,
+// Turtle by default.
+func (f *Format) Serialize(w io.Writer, records []*hubv1.Record, opts *format.SerializeOptions) error {
+	if opts == nil {
+		opts = format.NewSerializeOptions()
+	}
+	switch opts.Variant {
+	case "", VariantTurtle, VariantNTriples:
+	default:
+		return fmt.Errorf("unknown rdf variant %q (want %s or %s)", opts.Variant, VariantTurtle, VariantNTriples)
+	}
+	o, err := ontology(opts)
+	if err != nil {
+		return fmt.Errorf("loading ontology profile: %w", err)
+	}
+
+	d := newDescriber(o)
+	descriptions := make([]description, len(records))
+	for i, r := range records {
+		descriptions[i] = d.describe(r, i)
+	}
+
+	bw := bufio.NewWriter(w)
+	if opts.Variant == VariantNTriples {
+		writeNTriples(bw, descriptions)
+	} else {
+		writeTurtle(bw, o, descriptions)
+	}
+	return bw.Flush()
+}
+
+// ontology returns the ontology profile opts.FieldMap names, or the
+// default.
+func ontology(opts *format.SerializeOptions) (*Ontology, error) {
+	if opts == nil || opts.FieldMap == "" {
+		return DefaultOntology(), nil
+	}
+	return LoadOntology(opts.FieldMap)
+}
+
+// describer builds record descriptions from an ontology profile.
+type describer struct {
+	ontology *Ontology
+
+	// covered holds the paths the profile's fields describe
+	covered map[string]bool
+
+	// spokes caches each spoke's extra fields, by spoke name
+	spokes map[string][]OntologyField
+}
+
+func newDescriber(o *Ontology) *describer {
+	d := &describer{ontology: o, covered: make(map[string]bool), spokes: make(map[string][]OntologyField)}
+	for _, f := range o.Fields {
+		for _, alt := range strings.Split(f.Path, "|") {
+			d.covered[strings.TrimSpace(alt)] = true
+		}
+	}
+	return d
+}
+
+// describe returns the statements about a record, the i'th of the batch,
+// with duplicates dropped.
+func (d *describer) describe(r *hubv1.Record, i int) description {
+	desc := description{subject: subject(r, i)}
+	seen := make(map[statement]bool)
+	add := func(predicate string, object term) {
+		s := statement{predicate, object}
+		if !seen[s] {
+			seen[s] = true
+			desc.statements = append(desc.statements, s)
+		}
+	}
+
+	o := d.ontology
+	if o.Class != "" {
+		class, _ := o.expand(o.Class)
+		add(rdfType, term{iriTerm, class})
+	}
+	for _, rt := range hub.PathValues(r, "resource_type.type") {
+		if c, ok := o.Types[rt]; ok {
+			class, _ := o.expand(c)
+			add(rdfType, term{iriTerm, class})
+		}
+	}
+
+	for _, f := range slices.Concat(o.Fields, d.spokeFields(r)) {
+		predicate, err := o.expand(f.Predicate)
+		if err != nil {
+			continue
+		}
+		for _, v := range hub.PathValues(r, f.Path) {
+			if f.IRI && isAbsoluteIRI(v) {
+				add(predicate, term{iriTerm, v})
+			} else {
+				add(predicate, term{literalTerm, v})
+			}
+		}
+	}
+	return desc
+}
+
+// spokeFields returns the fields from the RDF mapping of the spoke the
+// profile names, or of the record's source format, for the paths the
+// profile doesn't describe.
+func (d *describer) spokeFields(r *hubv1.Record) []OntologyField {
+	name := d.ontology.Spoke
+	if name == "" {
+		name = r.GetSourceInfo().GetFormat()
+	}
+	if name == "" || name == SpokeNone {
+		return nil
+	}
+	if fields, ok := d.spokes[name]; ok {
+		return fields
+	}
+
+	var fields []OntologyField
+	predicates, _ := spokeregistry.RDFPredicates(name)
+	paths := make([]string, 0, len(predicates))
+	for path := range predicates {
+		if !d.covered[path] {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		for _, p := range predicates[path] {
+			// Predicates in namespaces the profile doesn't know are skipped
+			if _, err := d.ontology.expand(p); err == nil {
+				fields = append(fields, OntologyField{Path: path, Predicate: p})
+			}
+		}
+	}
+	d.spokes[name] = fields
+	return fields
+}
+
+// subjectTypes are the identifier types that name a record, in order of
+// preference.
+var subjectTypes = []hubv1.IdentifierType{
+	hubv1.IdentifierType_IDENTIFIER_TYPE_DOI,
+	hubv1.IdentifierType_IDENTIFIER_TYPE_HANDLE,
+	hubv1.IdentifierType_IDENTIFIER_TYPE_URL,
+}
+
+// subject returns the IRI of the record's DOI, handle, or URL, or a blank
+// node for the i'th record of the batch.
+func subject(r *hubv1.Record, i int) term {
+	for _, t := range subjectTypes {
+		if id := hub.GetIdentifier(r, t); id != nil {
+			if iri := hub.IdentifierURI(id); isAbsoluteIRI(iri) {
+				return term{iriTerm, iri}
+			}
+		}
+	}
+	return term{blankTerm, fmt.Sprintf("record%d", i+1)}
+}
+
+// isAbsoluteIRI reports whether s is an absolute IRI that can be written
+// between angle brackets as is.
+func isAbsoluteIRI(s string) bool {
+	if strings.ContainsAny(s, " <>\"{}|^`\\") || strings.ContainsFunc(s, func(r rune) bool { return r < 0x20 }) {
+		return false
+	}
+	u, err := url.Parse(s)
+	return err == nil && u.Scheme != "" && (u.Host != "" || u.Opaque != "")
+}
+
+// writeNTriples writes one triple per line.
+func writeNTriples(w *bufio.Writer, descriptions []description) {
+	for _, d := range descriptions {
+		for _, s := range d.statements {
+			fmt.Fprintf(w, "%s <%s> %s .\n", ntriplesTerm(d.subject), s.predicate, ntriplesTerm(s.object))
+		}
+	}
+}
+
+func ntriplesTerm(t term) string {
+	switch t.kind {
+	case iriTerm:
+		return "<" + t.value + ">"
+	case blankTerm:
+		return "_:" + t.value
+	}
+	return quote(t.value)
+}
+
+// writeTurtle writes a prefix declaration for each prefix used, then each
+// record's description, grouping a predicate's objects on one line.
+// Records with nothing to say are left out.
+func writeTurtle(w *bufio.Writer, o *Ontology, descriptions []description) {
+	c := newCompactor(o)
+	var body bytes.Buffer
+	for _, d := range descriptions {
+		if len(d.statements) == 0 {
+			continue
+		}
+		if body.Len() > 0 {
+			body.WriteString("\n")
+		}
+		body.WriteString(c.term(d.subject))
+		for j := 0; j < len(d.statements); {
+			predicate := d.statements[j].predicate
+			var objects []string
+			for ; j < len(d.statements) && d.statements[j].predicate == predicate; j++ {
+				objects = append(objects, c.term(d.statements[j].object))
+			}
+			p := "a"
+			if predicate != rdfType {
+				p = c.iri(predicate)
+			}
+			sep := " ;"
+			if j == len(d.statements) {
+				sep = " ."
+			}
+			fmt.Fprintf(&body, "\n    %s %s%s", p, strings.Join(objects, ", "), sep)
+		}
+		body.WriteString("\n")
+	}
+
+	if len(c.used) > 0 {
+		prefixes := make([]string, 0, len(c.used))
+		for prefix := range c.used {
+			prefixes = append(prefixes, prefix)
+		}
+		sort.Strings(prefixes)
+		for _, prefix := range prefixes {
+			fmt.Fprintf(w, "@prefix %s: <%s> .\n", prefix, o.Prefixes[prefix])
+		}
+		w.WriteString("\n")
+	}
+	w.Write(body.Bytes())
+}
+
+// localName matches the local names written as prefix:name. Others are
+// written as full IRIs.
+var localName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// compactor abbreviates IRIs with the ontology's prefixes, recording which
+// it used.
+type compactor struct {
+	prefixes map[string]string
+	used     map[string]bool
+}
+
+func newCompactor(o *Ontology) *compactor {
+	return &compactor{prefixes: o.Prefixes, used: make(map[string]bool)}
+}
+
+func (c *compactor) term(t term) string {
+	if t.kind == iriTerm {
+		return c.iri(t.value)
+	}
+	return ntriplesTerm(t)
+}
+
+// iri returns iri as prefix:name under the longest matching namespace, or
+// as <iri>.
+func (c *compactor) iri(iri string) string {
+	best, bestNS := "", ""
+	for prefix, ns := range c.prefixes {
+		if ns != "" && strings.HasPrefix(iri, ns) && len(ns) > len(bestNS) && localName.MatchString(iri[len(ns):]) {
+			best, bestNS = prefix, ns
+		}
+	}
+	if bestNS == "" {
+		return "<" + iri + ">"
+	}
+	c.used[best] = true
+	return best + ":" + iri[len(bestNS):]
+}
+
+// quote writes s as a quoted literal, escaping as both N-Triples and
+// Turtle require.
+func quote(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(&b, `\u%04X`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
@@ -3,13 +3,21 @@ package format
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
 )
 
-// Registry holds registered formats.
+// Registry holds registered formats. It is safe for concurrent use, so
+// formats can be added, replaced, removed, or disabled while other
+// goroutines convert records.
 type Registry struct {
-	formats map[string]Format
+	mu       sync.RWMutex
+	formats  map[string]Format
+	disabled map[string]string
+	defaults map[string]*SerializeOptions
 }
 
 // DefaultRegistry is the global format registry.
@@ -18,39 +26,113 @@ var DefaultRegistry = NewRegistry()
 // NewRegistry creates a new format registry.
 func NewRegistry() *Registry {
 	return &Registry{
-		formats: make(map[string]Format),
+		formats:  make(map[string]Format),
+		disabled: make(map[string]string),
+		defaults: make(map[string]*SerializeOptions),
 	}
 }
 
-// Register adds a format to the registry.
+// Register adds a format to the registry, replacing any format already
+// registered under the same name.
 func (r *Registry) Register(f Format) {
-	r.formats[f.Name()] = f
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.formats[strings.ToLower(f.Name())] = f
 }
 
-// Get retrieves a format by name.
+// Unregister removes a format, along with its disabled state and option
+// defaults. It reports whether the format was registered.
+func (r *Registry) Unregister(name string) bool {
+	name = strings.ToLower(name)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, ok := r.formats[name]
+	delete(r.formats, name)
+	delete(r.disabled, name)
+	delete(r.defaults, name)
+	return ok
+}
+
+// Disable takes a registered format out of service without removing it.
+// Lookups and detection skip it until Enable is called; the reason is
+// reported to callers that ask for it by name.
+func (r *Registry) Disable(name, reason string) error {
+	name = strings.ToLower(name)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.formats[name]; !ok {
+		return fmt.Errorf("unknown format: %s", name)
+	}
+	r.disabled[name] = reason
+	return nil
+}
+
+// Enable puts a disabled format back in service.
+func (r *Registry) Enable(name string) error {
+	name = strings.ToLower(name)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.formats[name]; !ok {
+		return fmt.Errorf("unknown format: %s", name)
+	}
+	delete(r.disabled, name)
+	return nil
+}
+
+// Disabled returns the disabled formats and the reason each was disabled.
+func (r *Registry) Disabled() map[string]string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make(map[string]string, len(r.disabled))
+	for name, reason := range r.disabled {
+		out[name] = reason
+	}
+	return out
+}
+
+// Get retrieves an enabled format by name.
 func (r *Registry) Get(name string) (Format, bool) {
-	f, ok := r.formats[strings.ToLower(name)]
-	return f, ok
+	f, err := r.lookup(name)
+	return f, err == nil
 }
 
-// GetParser retrieves a parser by name.
-func (r *Registry) GetParser(name string) (Parser, error) {
-	f, ok := r.Get(name)
+// lookup returns an enabled format, or an error saying why there is none.
+func (r *Registry) lookup(name string) (Format, error) {
+	name = strings.ToLower(name)
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	f, ok := r.formats[name]
 	if !ok {
 		return nil, fmt.Errorf("unknown format: %s", name)
 	}
+	if reason, off := r.disabled[name]; off {
+		if reason == "" {
+			return nil, fmt.Errorf("format %s is disabled", name)
+		}
+		return nil, fmt.Errorf("format %s is disabled: %s", name, reason)
+	}
+	return f, nil
+}
+
+// GetParser retrieves a parser by name. The records it parses have their
+// identifiers canonicalized unless ParseOptions.KeepIdentifiers is set.
+func (r *Registry) GetParser(name string) (Parser, error) {
+	f, err := r.lookup(name)
+	if err != nil {
+		return nil, err
+	}
 	p, ok := f.(Parser)
 	if !ok {
 		return nil, fmt.Errorf("format %s does not support parsing", name)
 	}
-	return p, nil
+	return normalizingParser(p), nil
 }
 
 // GetSerializer retrieves a serializer by name.
 func (r *Registry) GetSerializer(name string) (Serializer, error) {
-	f, ok := r.Get(name)
-	if !ok {
-		return nil, fmt.Errorf("unknown format: %s", name)
+	f, err := r.lookup(name)
+	if err != nil {
+		return nil, err
 	}
 	s, ok := f.(Serializer)
 	if !ok {
@@ -59,20 +141,134 @@ func (r *Registry) GetSerializer(name string) (Serializer, error) {
 	return s, nil
 }
 
-// List returns all registered format names.
+// SetDefaults sets the serialize options a format starts from in Options.
+// The registry keeps its own copy; nil clears the defaults.
+func (r *Registry) SetDefaults(name string, opts *SerializeOptions) error {
+	name = strings.ToLower(name)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.formats[name]; !ok {
+		return fmt.Errorf("unknown format: %s", name)
+	}
+	if opts == nil {
+		delete(r.defaults, name)
+		return nil
+	}
+	r.defaults[name] = cloneSerializeOptions(opts)
+	return nil
+}
+
+// Options returns serialize options for one request: a copy of the
+// format's defaults (or NewSerializeOptions when it has none) with the
+// overrides applied in order. Overrides only touch the copy, so
+// concurrent requests can't see each other's settings.
+func (r *Registry) Options(name string, overrides ...func(*SerializeOptions)) *SerializeOptions {
+	r.mu.RLock()
+	base := r.defaults[strings.ToLower(name)]
+	r.mu.RUnlock()
+
+	opts := NewSerializeOptions()
+	if base != nil {
+		opts = cloneSerializeOptions(base)
+	}
+	for _, override := range overrides {
+		override(opts)
+	}
+	return opts
+}
+
+// cloneSerializeOptions copies opts deeply enough that changing the
+// copy's slices and maps leaves the original alone. The profile is
+// shared; profiles are read-only once loaded.
+func cloneSerializeOptions(opts *SerializeOptions) *SerializeOptions {
+	c := *opts
+	c.Columns = append([]string(nil), opts.Columns...)
+	if opts.StaticColumns != nil {
+		c.StaticColumns = make(map[string]string, len(opts.StaticColumns))
+		for k, v := range opts.StaticColumns {
+			c.StaticColumns[k] = v
+		}
+	}
+	if opts.TemplateColumns != nil {
+		c.TemplateColumns = make(map[string]string, len(opts.TemplateColumns))
+		for k, v := range opts.TemplateColumns {
+			c.TemplateColumns[k] = v
+		}
+	}
+	if opts.Lengths != nil {
+		c.Lengths = make(map[string]LengthPolicy, len(opts.Lengths))
+		for k, v := range opts.Lengths {
+			c.Lengths[k] = v
+		}
+	}
+	if opts.ExtraWriters != nil {
+		c.ExtraWriters = make(map[string]io.Writer, len(opts.ExtraWriters))
+		for k, v := range opts.ExtraWriters {
+			c.ExtraWriters[k] = v
+		}
+	}
+	return &c
+}
+
+// Clone returns an independent copy of the registry, so a long-running
+// request can keep a stable set of formats while the original changes.
+func (r *Registry) Clone() *Registry {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	c := NewRegistry()
+	for name, f := range r.formats {
+		c.formats[name] = f
+	}
+	for name, reason := range r.disabled {
+		c.disabled[name] = reason
+	}
+	for name, opts := range r.defaults {
+		c.defaults[name] = cloneSerializeOptions(opts)
+	}
+	return c
+}
+
+// List returns the names of all enabled formats, sorted.
 func (r *Registry) List() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
 	names := make([]string, 0, len(r.formats))
 	for name := range r.formats {
-		names = append(names, name)
+		if _, off := r.disabled[name]; !off {
+			names = append(names, name)
+		}
 	}
+	sort.Strings(names)
 	return names
 }
 
+// enabled returns the enabled formats in name order. Detection runs
+// plugin code, so it works from this snapshot rather than holding the
+// lock while a plugin runs.
+func (r *Registry) enabled() []Format {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.formats))
+	for name := range r.formats {
+		if _, off := r.disabled[name]; !off {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	formats := make([]Format, 0, len(names))
+	for _, name := range names {
+		formats = append(formats, r.formats[name])
+	}
+	return formats
+}
+
 // DetectFormat attempts to detect the format from file extension and/or content.
 func (r *Registry) DetectFormat(filename string, peek []byte) (Format, error) {
+	formats := r.enabled()
+
 	// Try by extension first
 	ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(filename), "."))
-	for _, f := range r.formats {
+	for _, f := range formats {
 		for _, fext := range f.Extensions() {
 			if ext == fext {
 				return f, nil
@@ -82,7 +278,7 @@ func (r *Registry) DetectFormat(filename string, peek []byte) (Format, error) {
 
 	// Try by content detection
 	if len(peek) > 0 {
-		for _, f := range r.formats {
+		for _, f := range formats {
 			if f.CanParse(peek) {
 				return f, nil
 			}
@@ -97,7 +293,7 @@ func (r *Registry) DetectFromContent(peek []byte) (Format, error) {
 	// Trim whitespace for detection
 	peek = bytes.TrimSpace(peek)
 
-	for _, f := range r.formats {
+	for _, f := range r.enabled() {
 		if f.CanParse(peek) {
 			return f, nil
 		}
@@ -111,6 +307,21 @@ func Register(f Format) {
 	DefaultRegistry.Register(f)
 }
 
+// Unregister removes a format from the default registry.
+func Unregister(name string) bool {
+	return DefaultRegistry.Unregister(name)
+}
+
+// Disable takes a format in the default registry out of service.
+func Disable(name, reason string) error {
+	return DefaultRegistry.Disable(name, reason)
+}
+
+// Enable puts a disabled format in the default registry back in service.
+func Enable(name string) error {
+	return DefaultRegistry.Enable(name)
+}
+
 // Get retrieves a format from the default registry.
 func Get(name string) (Format, bool) {
 	return DefaultRegistry.Get(name)
@@ -126,6 +337,11 @@ func GetSerializer(name string) (Serializer, error) {
 	return DefaultRegistry.GetSerializer(name)
 }
 
+// List returns the enabled formats in the default registry.
+func List() []string {
+	return DefaultRegistry.List()
+}
+
 // DetectFormat detects format using the default registry.
 func DetectFormat(filename string, peek []byte) (Format, error) {
 	return DefaultRegistry.DetectFormat(filename, peek)
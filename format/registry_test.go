@@ -0,0 +1,183 @@
+package format_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+)
+
+// stubFormat is a minimal parser and serializer for registry tests.
+type stubFormat struct {
+	name string
+	ext  string
+}
+
+func (s stubFormat) Name() string              { return s.name }
+func (s stubFormat) Description() string       { return "stub " + s.name }
+func (s stubFormat) Extensions() []string      { return []string{s.ext} }
+func (s stubFormat) CanParse(peek []byte) bool { return bytes.HasPrefix(peek, []byte(s.name+":")) }
+
+func (s stubFormat) Parse(r io.Reader, opts *format.ParseOptions) ([]*hubv1.Record, error) {
+	return []*hubv1.Record{{Title: s.name}}, nil
+}
+
+func (s stubFormat) Serialize(w io.Writer, records []*hubv1.Record, opts *format.SerializeOptions) error {
+	_, err := io.WriteString(w, s.name)
+	return err
+}
+
+func TestRegistryRegisterUnregister(t *testing.T) {
+	r := format.NewRegistry()
+	r.Register(stubFormat{name: "alpha", ext: "a"})
+	r.Register(stubFormat{name: "beta", ext: "b"})
+
+	if got := strings.Join(r.List(), ","); got != "alpha,beta" {
+		t.Errorf("List: got %q", got)
+	}
+	if _, err := r.GetParser("ALPHA"); err != nil {
+		t.Errorf("lookup should be case-insensitive: %v", err)
+	}
+
+	if !r.Unregister("alpha") {
+		t.Error("Unregister should report a registered format")
+	}
+	if r.Unregister("alpha") {
+		t.Error("Unregister should report a missing format")
+	}
+	if _, err := r.GetSerializer("alpha"); err == nil || !strings.Contains(err.Error(), "unknown format") {
+		t.Errorf("expected unknown format after Unregister, got %v", err)
+	}
+	if f, err := r.DetectFormat("x.a", nil); err == nil {
+		t.Errorf("unregistered format still detected: %v", f.Name())
+	}
+}
+
+func TestRegistryDisable(t *testing.T) {
+	r := format.NewRegistry()
+	r.Register(stubFormat{name: "alpha", ext: "a"})
+	r.Register(stubFormat{name: "beta", ext: "b"})
+
+	if err := r.Disable("missing", "x"); err == nil {
+		t.Error("expected an error disabling an unknown format")
+	}
+	if err := r.Disable("alpha", "panics on empty input"); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := r.GetParser("alpha")
+	if err == nil || !strings.Contains(err.Error(), "disabled: panics on empty input") {
+		t.Errorf("expected the disabled reason, got %v", err)
+	}
+	if _, ok := r.Get("alpha"); ok {
+		t.Error("Get should skip disabled formats")
+	}
+	if got := strings.Join(r.List(), ","); got != "beta" {
+		t.Errorf("List should skip disabled formats, got %q", got)
+	}
+	if _, err := r.DetectFromContent([]byte("alpha: data")); err == nil {
+		t.Error("detection should skip disabled formats")
+	}
+	if got := r.Disabled(); got["alpha"] != "panics on empty input" || len(got) != 1 {
+		t.Errorf("Disabled: got %v", got)
+	}
+
+	if err := r.Enable("alpha"); err != nil {
+		t.Fatal(err)
+	}
+	if f, err := r.DetectFromContent([]byte("alpha: data")); err != nil || f.Name() != "alpha" {
+		t.Errorf("expected alpha after Enable, got %v, %v", f, err)
+	}
+}
+
+func TestRegistryOptions(t *testing.T) {
+	r := format.NewRegistry()
+	r.Register(stubFormat{name: "alpha", ext: "a"})
+
+	if err := r.SetDefaults("alpha", &format.SerializeOptions{
+		Variant:         "qualified",
+		Columns:         []string{"title"},
+		StaticColumns:   map[string]string{"model": "Digital Document"},
+		TemplateColumns: map[string]string{"label": "{{.Title}}"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := r.Options("alpha", func(o *format.SerializeOptions) {
+		o.Pretty = true
+		o.Columns = append(o.Columns, "abstract")
+		o.StaticColumns["model"] = "Collection"
+		o.TemplateColumns["citation"] = "{{.Title}} ({{.Language}})"
+	})
+	if opts.Variant != "qualified" || !opts.Pretty || len(opts.Columns) != 2 {
+		t.Errorf("Options: got %+v", opts)
+	}
+
+	// One request's overrides don't leak into the defaults
+	again := r.Options("alpha")
+	if again.Pretty || len(again.Columns) != 1 || again.StaticColumns["model"] != "Digital Document" || len(again.TemplateColumns) != 1 {
+		t.Errorf("defaults were modified by a request: %+v", again)
+	}
+
+	if d := r.Options("beta"); d.MultiValueSeparator != "|" || !d.IncludeHeader {
+		t.Errorf("formats without defaults should start from NewSerializeOptions, got %+v", d)
+	}
+}
+
+func TestRegistryClone(t *testing.T) {
+	r := format.NewRegistry()
+	r.Register(stubFormat{name: "alpha", ext: "a"})
+	snapshot := r.Clone()
+
+	r.Unregister("alpha")
+	if _, err := snapshot.GetParser("alpha"); err != nil {
+		t.Errorf("snapshot should keep alpha: %v", err)
+	}
+}
+
+func TestRegistryConcurrentUse(t *testing.T) {
+	r := format.NewRegistry()
+	r.Register(stubFormat{name: "stable", ext: "s"})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			name := fmt.Sprintf("plugin%d", i)
+			for j := 0; j < 100; j++ {
+				r.Register(stubFormat{name: name, ext: name})
+				_ = r.Disable(name, "flaky")
+				_ = r.Enable(name)
+				_ = r.SetDefaults(name, &format.SerializeOptions{Variant: name})
+				r.Unregister(name)
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				p, err := r.GetParser("stable")
+				if err != nil {
+					t.Errorf("stable format went missing: %v", err)
+					return
+				}
+				if _, err := p.Parse(strings.NewReader(""), nil); err != nil {
+					t.Error(err)
+				}
+				r.List()
+				_, _ = r.DetectFormat("x.s", nil)
+				r.Options("stable", func(o *format.SerializeOptions) { o.Pretty = true })
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := strings.Join(r.List(), ","); got != "stable" {
+		t.Errorf("List after concurrent churn: got %q", got)
+	}
+}
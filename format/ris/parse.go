@@ -0,0 +1,303 @@
+package ris
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/helpers"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+// risTypes maps RIS reference types to hub resource types.
+var risTypes = map[string]hubv1.ResourceTypeValue{
+	"JOUR":    hubv1.ResourceTypeValue_RESOURCE_TYPE_ARTICLE,
+	"EJOUR":   hubv1.ResourceTypeValue_RESOURCE_TYPE_ARTICLE,
+	"JFULL":   hubv1.ResourceTypeValue_RESOURCE_TYPE_JOURNAL,
+	"MGZN":    hubv1.ResourceTypeValue_RESOURCE_TYPE_ARTICLE,
+	"NEWS":    hubv1.ResourceTypeValue_RESOURCE_TYPE_NEWSPAPER_ARTICLE,
+	"BOOK":    hubv1.ResourceTypeValue_RESOURCE_TYPE_BOOK,
+	"EBOOK":   hubv1.ResourceTypeValue_RESOURCE_TYPE_BOOK,
+	"EDBOOK":  hubv1.ResourceTypeValue_RESOURCE_TYPE_BOOK,
+	"CHAP":    hubv1.ResourceTypeValue_RESOURCE_TYPE_BOOK_CHAPTER,
+	"ECHAP":   hubv1.ResourceTypeValue_RESOURCE_TYPE_BOOK_CHAPTER,
+	"CONF":    hubv1.ResourceTypeValue_RESOURCE_TYPE_CONFERENCE_PROCEEDING,
+	"CPAPER":  hubv1.ResourceTypeValue_RESOURCE_TYPE_CONFERENCE_PAPER,
+	"THES":    hubv1.ResourceTypeValue_RESOURCE_TYPE_THESIS,
+	"RPRT":    hubv1.ResourceTypeValue_RESOURCE_TYPE_REPORT,
+	"DATA":    hubv1.ResourceTypeValue_RESOURCE_TYPE_DATASET,
+	"DBASE":   hubv1.ResourceTypeValue_RESOURCE_TYPE_DATASET,
+	"COMP":    hubv1.ResourceTypeValue_RESOURCE_TYPE_SOFTWARE,
+	"ELEC":    hubv1.ResourceTypeValue_RESOURCE_TYPE_WEBPAGE,
+	"WEB":     hubv1.ResourceTypeValue_RESOURCE_TYPE_WEBPAGE,
+	"BLOG":    hubv1.ResourceTypeValue_RESOURCE_TYPE_WEBPAGE,
+	"MAP":     hubv1.ResourceTypeValue_RESOURCE_TYPE_MAP,
+	"PAT":     hubv1.ResourceTypeValue_RESOURCE_TYPE_PATENT,
+	"STAND":   hubv1.ResourceTypeValue_RESOURCE_TYPE_STANDARD,
+	"VIDEO":   hubv1.ResourceTypeValue_RESOURCE_TYPE_VIDEO,
+	"MPCT":    hubv1.ResourceTypeValue_RESOURCE_TYPE_VIDEO,
+	"SOUND":   hubv1.ResourceTypeValue_RESOURCE_TYPE_AUDIO,
+	"ART":     hubv1.ResourceTypeValue_RESOURCE_TYPE_IMAGE,
+	"FIGURE":  hubv1.ResourceTypeValue_RESOURCE_TYPE_IMAGE,
+	"MANSCPT": hubv1.ResourceTypeValue_RESOURCE_TYPE_MANUSCRIPT,
+	"UNPB":    hubv1.ResourceTypeValue_RESOURCE_TYPE_PREPRINT,
+	"SLIDE":   hubv1.ResourceTypeValue_RESOURCE_TYPE_PRESENTATION,
+	"SER":     hubv1.ResourceTypeValue_RESOURCE_TYPE_PERIODICAL,
+	"GEN":     hubv1.ResourceTypeValue_RESOURCE_TYPE_OTHER,
+}
+
+// contributorTags maps RIS name tags to MARC relator codes. A2 is the
+// secondary author, which reference managers use for editors.
+var contributorTags = map[string]string{
+	"AU": "aut",
+	"A1": "aut",
+	"A2": "edt",
+	"ED": "edt",
+	"A3": "edt",
+	"A4": "ctb",
+}
+
+// mappedTags are the tags risToHub reads; anything else goes to extra.
+var mappedTags = map[string]bool{
+	"TY": true, "TI": true, "T1": true, "ST": true, "TT": true,
+	"AU": true, "A1": true, "A2": true, "ED": true, "A3": true, "A4": true,
+	"T2": true, "JF": true, "JO": true, "JA": true, "J2": true, "BT": true, "T3": true,
+	"VL": true, "IS": true, "SP": true, "EP": true, "SN": true,
+	"PY": true, "Y1": true, "DA": true,
+	"DO": true, "UR": true, "ID": true,
+	"AB": true, "N2": true, "KW": true, "N1": true,
+	"PB": true, "CY": true, "LA": true, "ET": true, "M3": true,
+}
+
+// risDate matches RIS dates: "2019", "2019///", "2019/05/12/" or ISO
+// "2019-05-12".
+var risDate = regexp.MustCompile(`^(\d{4})(?:[/-](\d{1,2})?(?:[/-](\d{1,2}))?)?`)
+
+// Parse reads RIS references and returns hub records.
+func (f *Format) Parse(r io.Reader, _ *format.ParseOptions) ([]*hubv1.Record, error) {
+	risRecords, err := readRecords(r)
+	if err != nil {
+		return nil, fmt.Errorf("parsing RIS: %w", err)
+	}
+	if len(risRecords) == 0 {
+		return nil, fmt.Errorf("no RIS references found in input")
+	}
+
+	records := make([]*hubv1.Record, 0, len(risRecords))
+	for _, rec := range risRecords {
+		records = append(records, risToHub(rec))
+	}
+	return records, nil
+}
+
+// risToHub converts an RIS reference to a hub record.
+func risToHub(rec *Record) *hubv1.Record {
+	record := &hubv1.Record{
+		Title:          rec.Get("TI", "T1"),
+		AltTitle:       rec.All("ST", "TT"),
+		Abstract:       rec.Get("AB", "N2"),
+		Publisher:      rec.Get("PB"),
+		PlacePublished: rec.Get("CY"),
+		Language:       rec.Get("LA"),
+		Edition:        rec.Get("ET"),
+		Notes:          rec.All("N1"),
+	}
+
+	ty := strings.ToUpper(rec.Type())
+	if ty != "" {
+		record.ResourceType = &hubv1.ResourceType{
+			Type:       risTypes[ty],
+			Original:   ty,
+			Vocabulary: "ris",
+		}
+		if record.ResourceType.Type == hubv1.ResourceTypeValue_RESOURCE_TYPE_UNSPECIFIED {
+			record.ResourceType.Type = hubv1.ResourceTypeValue_RESOURCE_TYPE_OTHER
+		}
+	}
+
+	for _, t := range rec.Tags {
+		if code, ok := contributorTags[t.Key]; ok {
+			record.Contributors = append(record.Contributors, nameToContributor(t.Value, code))
+		}
+	}
+
+	// Theses name the degree-granting institution as publisher and the
+	// degree in M3
+	if ty == "THES" {
+		record.DegreeInfo = &hubv1.DegreeInfo{
+			Institution: record.Publisher,
+			DegreeName:  rec.Get("M3"),
+		}
+		record.Publisher = ""
+	} else if m3 := rec.Get("M3"); m3 != "" {
+		record.Genres = append(record.Genres, &hubv1.Subject{Value: m3})
+	}
+
+	if d := parseDate(rec.Get("DA", "PY", "Y1")); d != nil {
+		record.Dates = append(record.Dates, d)
+	}
+
+	record.Publication = publicationDetails(rec)
+	record.Identifiers = identifiers(rec, record.Publication)
+
+	if series := rec.Get("T3"); series != "" {
+		record.Relations = append(record.Relations, hub.NewRelation(hubv1.RelationType_RELATION_TYPE_IN_SERIES, series))
+	}
+
+	for _, kw := range rec.All("KW") {
+		record.Subjects = append(record.Subjects, &hubv1.Subject{
+			Value:      kw,
+			Vocabulary: hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_KEYWORDS,
+		})
+	}
+
+	var unmapped []string
+	for _, t := range rec.Tags {
+		if mappedTags[t.Key] {
+			continue
+		}
+		key := "ris_" + strings.ToLower(t.Key)
+		if _, seen := hub.GetExtra(record, key); !seen {
+			unmapped = append(unmapped, t.Key)
+		}
+		hub.SetExtra(record, key, strings.Join(rec.All(t.Key), "; "))
+	}
+
+	record.SourceInfo = &hubv1.SourceInfo{
+		Format:         "ris",
+		FormatVersion:  Version,
+		SourceId:       rec.Get("ID"),
+		UnmappedFields: unmapped,
+	}
+
+	return record
+}
+
+// nameToContributor converts an RIS name ("Last, First, Suffix") to a
+// contributor. Names without a comma are treated as organizations, since
+// reference managers always invert personal names.
+func nameToContributor(name, code string) *hubv1.Contributor {
+	c := &hubv1.Contributor{
+		Name:     name,
+		RoleCode: "relators:" + code,
+		Role:     helpers.RelatorLabel(code),
+	}
+	if strings.Contains(name, ",") {
+		c.Type = hubv1.ContributorType_CONTRIBUTOR_TYPE_PERSON
+		c.ParsedName = helpers.ParseName(name)
+	} else {
+		c.Type = hubv1.ContributorType_CONTRIBUTOR_TYPE_ORGANIZATION
+	}
+	return c
+}
+
+// parseDate parses an RIS date, keeping the precision it was given at.
+func parseDate(s string) *hubv1.DateValue {
+	m := risDate.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return nil
+	}
+	year, _ := strconv.Atoi(m[1])
+	month, _ := strconv.Atoi(m[2])
+	day, _ := strconv.Atoi(m[3])
+
+	var d *hubv1.DateValue
+	switch {
+	case month >= 1 && month <= 12 && day >= 1 && day <= 31:
+		d = hub.NewDateFromYMD(int32(year), int32(month), int32(day), hubv1.DateType_DATE_TYPE_ISSUED)
+	case month >= 1 && month <= 12:
+		d = hub.NewDateFromYearMonth(int32(year), int32(month), hubv1.DateType_DATE_TYPE_ISSUED)
+	default:
+		d = hub.NewDateFromYear(int32(year), hubv1.DateType_DATE_TYPE_ISSUED)
+	}
+	d.Raw = s
+	return d
+}
+
+// publicationDetails builds the container title, volume, issue, pages,
+// and ISSN. It returns nil when the reference has none of them.
+func publicationDetails(rec *Record) *hubv1.PublicationDetails {
+	pub := &hubv1.PublicationDetails{
+		Title:  rec.Get("T2", "JF", "JO", "BT", "JA", "J2"),
+		Volume: rec.Get("VL"),
+		Issue:  rec.Get("IS"),
+		Pages:  joinPages(rec.Get("SP"), rec.Get("EP")),
+	}
+	for _, sn := range snValues(rec) {
+		if hub.DetectIdentifierType(sn) == hubv1.IdentifierType_IDENTIFIER_TYPE_ISSN && pub.Issn == "" {
+			pub.Issn = sn
+		}
+	}
+	if pub.Title == "" && pub.Volume == "" && pub.Issue == "" && pub.Pages == "" && pub.Issn == "" {
+		return nil
+	}
+	return pub
+}
+
+// joinPages combines start and end pages. Some exporters put the whole
+// range in SP.
+func joinPages(sp, ep string) string {
+	switch {
+	case sp == "":
+		return ep
+	case ep == "" || ep == sp:
+		return sp
+	default:
+		return sp + "-" + ep
+	}
+}
+
+// snValues returns SN values, splitting entries that hold several ISBNs
+// or ISSNs separated by semicolons or spaces.
+func snValues(rec *Record) []string {
+	var vals []string
+	for _, sn := range rec.All("SN") {
+		for _, v := range strings.FieldsFunc(sn, func(r rune) bool { return r == ';' || r == ' ' }) {
+			// Drop qualifiers such as "(pbk.)" or "(Print)"
+			if v = strings.TrimSpace(v); v != "" && !strings.HasPrefix(v, "(") {
+				vals = append(vals, v)
+			}
+		}
+	}
+	return vals
+}
+
+// identifiers collects DOI, URL, ISBN/ISSN, and reference ID identifiers.
+// A UR pointing at doi.org stands in for a missing DO tag.
+func identifiers(rec *Record, pub *hubv1.PublicationDetails) []*hubv1.Identifier {
+	var ids []*hubv1.Identifier
+	hasDOI := false
+	for _, do := range rec.All("DO") {
+		ids = append(ids, hub.NewIdentifier(do, hubv1.IdentifierType_IDENTIFIER_TYPE_DOI))
+		hasDOI = true
+	}
+	for _, ur := range rec.All("UR") {
+		if hub.DetectIdentifierType(ur) == hubv1.IdentifierType_IDENTIFIER_TYPE_DOI {
+			if !hasDOI {
+				ids = append(ids, hub.NewIdentifier(ur, hubv1.IdentifierType_IDENTIFIER_TYPE_DOI))
+				hasDOI = true
+			}
+			continue
+		}
+		ids = append(ids, &hubv1.Identifier{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_URL, Value: ur})
+	}
+	for _, sn := range snValues(rec) {
+		switch hub.DetectIdentifierType(sn) {
+		case hubv1.IdentifierType_IDENTIFIER_TYPE_ISBN:
+			ids = append(ids, &hubv1.Identifier{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_ISBN, Value: sn})
+		case hubv1.IdentifierType_IDENTIFIER_TYPE_ISSN:
+			// The container's ISSN already lives in Publication
+			if pub == nil || pub.Issn != sn {
+				ids = append(ids, &hubv1.Identifier{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_ISSN, Value: sn})
+			}
+		}
+	}
+	if id := rec.Get("ID"); id != "" {
+		ids = append(ids, &hubv1.Identifier{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_LOCAL, Value: id})
+	}
+	return ids
+}
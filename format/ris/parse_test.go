@@ -0,0 +1,240 @@
+package ris
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+const zoteroExport = "\ufeffTY  - JOUR\r\n" +
+	"TI  - Charge transport in organic semiconductors\r\n" +
+	"AU  - Smith, Jane A.\r\n" +
+	"AU  - Doe, John, Jr.\r\n" +
+	"AU  - Materials Genome Consortium\r\n" +
+	"A2  - Editor, Ed\r\n" +
+	"T2  - Journal of Applied Physics\r\n" +
+	"J2  - J. Appl. Phys.\r\n" +
+	"AB  - We measure charge transport\r\n" +
+	"  across several thin films.\r\n" +
+	"DA  - 2019/05/12/\r\n" +
+	"PY  - 2019\r\n" +
+	"VL  - 125\r\n" +
+	"IS  - 19\r\n" +
+	"SP  - 195501\r\n" +
+	"EP  - 195510\r\n" +
+	"SN  - 0021-8979\r\n" +
+	"DO  - 10.1063/1.5094040\r\n" +
+	"UR  - https://example.edu/papers/42\r\n" +
+	"KW  - organic semiconductors\r\n" +
+	"KW  - charge transport\r\n" +
+	"N1  - Open access version\r\n" +
+	"DB  - Zotero\r\n" +
+	"ER  - \r\n" +
+	"\r\n" +
+	"TY  - THES\r\n" +
+	"TI  - Thin film growth\r\n" +
+	"AU  - Lee, Min\r\n" +
+	"PY  - 2021///\r\n" +
+	"PB  - Lehigh University\r\n" +
+	"M3  - Ph.D.\r\n" +
+	"UR  - https://doi.org/10.1234/etd.99\r\n" +
+	"ER  -\r\n"
+
+func TestParseZoteroExport(t *testing.T) {
+	records, err := (&Format{}).Parse(strings.NewReader(zoteroExport), nil)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+
+	r := records[0]
+	if r.Title != "Charge transport in organic semiconductors" {
+		t.Errorf("Title: got %q", r.Title)
+	}
+	if r.ResourceType.Type != hubv1.ResourceTypeValue_RESOURCE_TYPE_ARTICLE {
+		t.Errorf("ResourceType: got %v", r.ResourceType.Type)
+	}
+	if r.Abstract != "We measure charge transport across several thin films." {
+		t.Errorf("Abstract continuation: got %q", r.Abstract)
+	}
+
+	if len(r.Contributors) != 4 {
+		t.Fatalf("expected 4 contributors, got %d", len(r.Contributors))
+	}
+	if pn := r.Contributors[0].ParsedName; pn.Family != "Smith" || pn.Given != "Jane" {
+		t.Errorf("Contributor 0 parsed name: got %v", pn)
+	}
+	if r.Contributors[2].Type != hubv1.ContributorType_CONTRIBUTOR_TYPE_ORGANIZATION {
+		t.Errorf("Contributor 2 should be an organization, got %v", r.Contributors[2].Type)
+	}
+	if r.Contributors[3].RoleCode != "relators:edt" {
+		t.Errorf("A2 should be an editor, got %q", r.Contributors[3].RoleCode)
+	}
+
+	pub := r.Publication
+	if pub == nil {
+		t.Fatal("expected publication details")
+	}
+	if pub.Title != "Journal of Applied Physics" || pub.Volume != "125" || pub.Issue != "19" ||
+		pub.Pages != "195501-195510" || pub.Issn != "0021-8979" {
+		t.Errorf("Publication: got %v", pub)
+	}
+
+	d := hub.GetDateIssued(r)
+	if d == nil || d.Year != 2019 || d.Month != 5 || d.Day != 12 {
+		t.Errorf("Date issued: got %v", d)
+	}
+
+	if doi := hub.GetDOI(r); doi == nil || doi.Value != "10.1063/1.5094040" {
+		t.Errorf("DOI: got %v", doi)
+	}
+	if url := hub.GetIdentifier(r, hubv1.IdentifierType_IDENTIFIER_TYPE_URL); url == nil || url.Value != "https://example.edu/papers/42" {
+		t.Errorf("URL: got %v", url)
+	}
+	if issn := hub.GetIdentifier(r, hubv1.IdentifierType_IDENTIFIER_TYPE_ISSN); issn != nil {
+		t.Errorf("journal ISSN should stay in Publication, got identifier %v", issn)
+	}
+
+	if len(r.Subjects) != 2 || r.Subjects[0].Vocabulary != hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_KEYWORDS {
+		t.Errorf("Subjects: got %v", r.Subjects)
+	}
+	if got := hub.GetExtraString(r, "ris_db"); got != "Zotero" {
+		t.Errorf("ris_db extra: got %q", got)
+	}
+	if got := r.SourceInfo.GetUnmappedFields(); len(got) != 1 || got[0] != "DB" {
+		t.Errorf("UnmappedFields: got %v", got)
+	}
+
+	thesis := records[1]
+	if thesis.DegreeInfo.GetInstitution() != "Lehigh University" || thesis.DegreeInfo.GetDegreeName() != "Ph.D." {
+		t.Errorf("DegreeInfo: got %v", thesis.DegreeInfo)
+	}
+	if thesis.Publisher != "" {
+		t.Errorf("thesis institution should not also be the publisher, got %q", thesis.Publisher)
+	}
+	if d := hub.GetDateIssued(thesis); d == nil || d.Year != 2021 || d.Precision != hubv1.DatePrecision_DATE_PRECISION_YEAR {
+		t.Errorf("thesis date: got %v", d)
+	}
+	if doi := hub.GetDOI(thesis); doi == nil || doi.Value != "10.1234/etd.99" {
+		t.Errorf("DOI from doi.org UR: got %v", doi)
+	}
+	if url := hub.GetIdentifier(thesis, hubv1.IdentifierType_IDENTIFIER_TYPE_URL); url != nil {
+		t.Errorf("doi.org UR should not also be a URL, got %v", url)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := map[string]string{
+		"empty":         "",
+		"not ris":       "@article{key, title={x}}",
+		"tag before TY": "TI  - Orphan\r\nER  - \r\n",
+	}
+	for name, input := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := (&Format{}).Parse(strings.NewReader(input), nil); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestCanParse(t *testing.T) {
+	f := &Format{}
+	if !f.CanParse([]byte(zoteroExport)) {
+		t.Error("expected CanParse for a Zotero export with BOM")
+	}
+	if !f.CanParse([]byte("\r\nTY  - BOOK\r\n")) {
+		t.Error("expected CanParse with leading blank line")
+	}
+	if f.CanParse([]byte("TITLE - not ris")) {
+		t.Error("unexpected CanParse for non-RIS input")
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	f := &Format{}
+	records, err := f.Parse(strings.NewReader(zoteroExport), nil)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := f.Serialize(&buf, records, format.NewSerializeOptions()); err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"TY  - JOUR\r\n",
+		"AU  - Smith, Jane A.\r\n",
+		"AU  - Materials Genome Consortium\r\n",
+		"ED  - Editor, Ed\r\n",
+		"PY  - 2019\r\n",
+		"DA  - 2019/05/12\r\n",
+		"T2  - Journal of Applied Physics\r\n",
+		"SP  - 195501\r\n",
+		"EP  - 195510\r\n",
+		"SN  - 0021-8979\r\n",
+		"DO  - 10.1063/1.5094040\r\n",
+		"DB  - Zotero\r\n",
+		"ER  - \r\n\r\nTY  - THES\r\n",
+		"PB  - Lehigh University\r\n",
+		"M3  - Ph.D.\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q\n%s", want, out)
+		}
+	}
+
+	again, err := f.Parse(strings.NewReader(out), nil)
+	if err != nil {
+		t.Fatalf("re-parse failed: %v", err)
+	}
+	if len(again) != 2 || again[0].Publication.GetPages() != "195501-195510" || len(again[0].Contributors) != 4 {
+		t.Errorf("round trip lost data: %v", again)
+	}
+}
+
+func TestSerializeFromOtherFormats(t *testing.T) {
+	record := &hubv1.Record{
+		Title:        "Proceedings chapter",
+		ResourceType: &hubv1.ResourceType{Type: hubv1.ResourceTypeValue_RESOURCE_TYPE_BOOK_CHAPTER},
+		Contributors: []*hubv1.Contributor{
+			{Name: "Alice Johnson", Type: hubv1.ContributorType_CONTRIBUTOR_TYPE_PERSON},
+			{Name: "Bob Ray", Role: "Translator", Type: hubv1.ContributorType_CONTRIBUTOR_TYPE_PERSON},
+			// A person-typed name that parses as an organization is kept as-is
+			{Name: "ATLAS Collaboration", Type: hubv1.ContributorType_CONTRIBUTOR_TYPE_PERSON},
+		},
+		Relations: []*hubv1.Relation{
+			{Type: hubv1.RelationType_RELATION_TYPE_PART_OF, TargetTitle: "Collected Essays"},
+		},
+		Identifiers: []*hubv1.Identifier{
+			{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_ISBN, Value: "978-0-12-345678-9"},
+			{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_DOI, Value: "https://doi.org/10.1234/chap"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := (&Format{}).Serialize(&buf, []*hubv1.Record{record}, nil); err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"TY  - CHAP\r\n",
+		"AU  - Johnson, Alice\r\n",
+		"A4  - Ray, Bob\r\n",
+		"AU  - ATLAS Collaboration\r\n",
+		"T2  - Collected Essays\r\n",
+		"SN  - 978-0-12-345678-9\r\n",
+		"DO  - 10.1234/chap\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package ris
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// tagLine matches an RIS line: a two-character tag, two spaces, a hyphen,
+// and an optional value. Some exporters drop the trailing space on empty
+// values such as "ER  -".
+var tagLine = regexp.MustCompile(`^([A-Z][A-Z0-9])  -(?: (.*))?$`)
+
+// Record is one RIS reference as an ordered list of tagged values.
+type Record struct {
+	Tags []Tag
+}
+
+// Tag is a single RIS tag and value.
+type Tag struct {
+	Key   string
+	Value string
+}
+
+// Type returns the TY reference type.
+func (r *Record) Type() string {
+	return r.Get("TY")
+}
+
+// Get returns the first value for any of the given tags, or "".
+func (r *Record) Get(keys ...string) string {
+	for _, key := range keys {
+		for _, t := range r.Tags {
+			if t.Key == key && t.Value != "" {
+				return t.Value
+			}
+		}
+	}
+	return ""
+}
+
+// All returns the values for the given tags, in the order they appear.
+func (r *Record) All(keys ...string) []string {
+	var vals []string
+	for _, t := range r.Tags {
+		for _, key := range keys {
+			if t.Key == key && t.Value != "" {
+				vals = append(vals, t.Value)
+				break
+			}
+		}
+	}
+	return vals
+}
+
+// Add appends a tag, skipping empty values.
+func (r *Record) Add(key, value string) {
+	if value = strings.TrimSpace(value); value != "" {
+		r.Tags = append(r.Tags, Tag{Key: key, Value: value})
+	}
+}
+
+// readRecords splits RIS input into records. Lines that don't start with
+// a tag continue the previous value, as wrapped abstracts do in some
+// exports. A record missing its ER line is closed at end of input.
+func readRecords(r io.Reader) ([]*Record, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+
+	var records []*Record
+	var cur *Record
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if lineNo == 1 {
+			line = strings.TrimPrefix(line, "\ufeff")
+		}
+
+		m := tagLine.FindStringSubmatch(line)
+		if m == nil {
+			text := strings.TrimSpace(line)
+			if text == "" {
+				continue
+			}
+			if cur == nil || len(cur.Tags) == 0 {
+				return nil, fmt.Errorf("line %d: expected an RIS tag, got %q", lineNo, line)
+			}
+			last := &cur.Tags[len(cur.Tags)-1]
+			last.Value = strings.TrimSpace(last.Value + " " + text)
+			continue
+		}
+
+		key, value := m[1], strings.TrimSpace(m[2])
+		switch key {
+		case "TY":
+			if cur != nil {
+				records = append(records, cur)
+			}
+			cur = &Record{}
+			cur.Add(key, value)
+		case "ER":
+			if cur != nil {
+				records = append(records, cur)
+				cur = nil
+			}
+		default:
+			if cur == nil {
+				return nil, fmt.Errorf("line %d: %s tag outside a TY/ER reference", lineNo, key)
+			}
+			cur.Add(key, value)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading input: %w", err)
+	}
+	if cur != nil {
+		records = append(records, cur)
+	}
+	return records, nil
+}
+
+// writeRecord writes a record with CRLF line endings, as the RIS
+// specification requires, closing it with an ER line.
+func writeRecord(w io.Writer, rec *Record) error {
+	var sb strings.Builder
+	for _, t := range rec.Tags {
+		fmt.Fprintf(&sb, "%s  - %s\r\n", t.Key, t.Value)
+	}
+	sb.WriteString("ER  - \r\n")
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
@@ -0,0 +1,81 @@
+// Package ris provides a format plugin for RIS, the tagged citation format
+// exported and imported by reference managers such as EndNote and Zotero.
+//
+// Each reference starts with a TY line and ends with an ER line; every
+// line in between is a two-character tag, two spaces, a hyphen, a space,
+// and the value. The mapping covers:
+//   - TY for resource type, TI/T1 for title, ST/TT for alternative titles
+//   - AU/A1 authors, A2/ED editors, A3/A4 other contributors
+//   - T2/JF/JO/JA, VL, IS, SP/EP, SN for the hub Publication details
+//   - PY/Y1/DA dates, DO/UR/SN/ID identifiers
+//   - AB, KW, N1, PB, CY, LA, ET, T3, M3
+//
+// Unmapped tags are kept in the record's extra fields as "ris_<tag>".
+package ris
+
+import (
+	"bytes"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+)
+
+// Version documents the RIS specification this implementation targets.
+const Version = "ris-2011"
+
+// Format implements the RIS format.
+type Format struct{}
+
+// Ensure Format implements the interfaces
+var (
+	_ format.Format         = (*Format)(nil)
+	_ format.Parser         = (*Format)(nil)
+	_ format.Serializer     = (*Format)(nil)
+	_ format.FieldDescriber = (*Format)(nil)
+)
+
+// Name returns the format identifier.
+func (f *Format) Name() string {
+	return "ris"
+}
+
+// Description returns a human-readable format description.
+func (f *Format) Description() string {
+	return "RIS citation format (EndNote, Zotero, Mendeley)"
+}
+
+// Extensions returns file extensions associated with this format.
+func (f *Format) Extensions() []string {
+	return []string{"ris"}
+}
+
+// writtenFields are the hub record fields Serialize writes.
+var writtenFields = []string{
+	"title", "alt_title", "abstract", "contributors", "dates",
+	"resource_type", "subjects", "language", "publisher", "place_published",
+	"publication", "identifiers", "notes", "edition", "degree_info",
+	"relations.part_of", "relations.in_series",
+}
+
+// WrittenFields returns the hub record fields Serialize writes.
+func (f *Format) WrittenFields(opts *format.SerializeOptions) []string {
+	return writtenFields
+}
+
+// CanParse returns true if the input starts with an RIS TY line.
+func (f *Format) CanParse(peek []byte) bool {
+	peek = bytes.TrimPrefix(peek, []byte("\xef\xbb\xbf"))
+	peek = bytes.TrimLeft(peek, "\r\n\t ")
+	return tagLine.Match(firstLine(peek)) && bytes.HasPrefix(peek, []byte("TY"))
+}
+
+// firstLine returns b up to the first line break.
+func firstLine(b []byte) []byte {
+	if i := bytes.IndexAny(b, "\r\n"); i >= 0 {
+		return b[:i]
+	}
+	return b
+}
+
+func init() {
+	format.Register(&Format{})
+}
@@ -0,0 +1,234 @@
+package ris
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/helpers"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+// hubTypes maps hub resource types to RIS reference types.
+var hubTypes = map[hubv1.ResourceTypeValue]string{
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_ARTICLE:               "JOUR",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_JOURNAL:               "JFULL",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_NEWSPAPER_ARTICLE:     "NEWS",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_BOOK:                  "BOOK",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_BOOK_CHAPTER:          "CHAP",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_CONFERENCE_PROCEEDING: "CONF",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_CONFERENCE_PAPER:      "CPAPER",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_THESIS:                "THES",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_DISSERTATION:          "THES",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_REPORT:                "RPRT",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_TECHNICAL_REPORT:      "RPRT",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_WORKING_PAPER:         "RPRT",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_PREPRINT:              "UNPB",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_DATASET:               "DATA",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_SOFTWARE:              "COMP",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_WEBPAGE:               "ELEC",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_MAP:                   "MAP",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_PATENT:                "PAT",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_STANDARD:              "STAND",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_VIDEO:                 "VIDEO",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_AUDIO:                 "SOUND",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_IMAGE:                 "ART",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_MANUSCRIPT:            "MANSCPT",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_PRESENTATION:          "SLIDE",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_PERIODICAL:            "SER",
+}
+
+// Serialize writes hub records as RIS references, separated by blank lines.
+func (f *Format) Serialize(w io.Writer, records []*hubv1.Record, opts *format.SerializeOptions) error {
+	if opts == nil {
+		opts = format.NewSerializeOptions()
+	}
+
+	for i, record := range records {
+		if i > 0 {
+			if _, err := io.WriteString(w, "\r\n"); err != nil {
+				return err
+			}
+		}
+		if err := writeRecord(w, hubToRIS(record, opts)); err != nil {
+			return fmt.Errorf("writing record %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// hubToRIS converts a hub record to an RIS reference.
+func hubToRIS(record *hubv1.Record, opts *format.SerializeOptions) *Record {
+	rec := &Record{}
+	ty := referenceType(record.ResourceType)
+	rec.Add("TY", ty)
+	rec.Add("TI", record.Title)
+	for _, alt := range record.AltTitle {
+		rec.Add("ST", alt)
+	}
+
+	// Authors, then editors, then everyone else
+	for _, tag := range []string{"AU", "ED", "A4"} {
+		for _, c := range record.Contributors {
+			if contributorTag(c) == tag {
+				rec.Add(tag, contributorName(c))
+			}
+		}
+	}
+
+	if d := primaryDate(record); d != nil {
+		year, month, day := format.ClampDate(d, opts.Dates)
+		if year > 0 {
+			rec.Add("PY", fmt.Sprintf("%04d", year))
+		}
+		switch {
+		case day > 0:
+			rec.Add("DA", fmt.Sprintf("%04d/%02d/%02d", year, month, day))
+		case month > 0:
+			rec.Add("DA", fmt.Sprintf("%04d/%02d", year, month))
+		}
+	}
+
+	// Container title, falling back to a part-of relation as BibTeX
+	// and MARC sources record it
+	pub := record.Publication
+	if pub != nil && pub.Title != "" {
+		rec.Add("T2", pub.Title)
+	} else if rels := hub.GetRelationsByType(record, hubv1.RelationType_RELATION_TYPE_PART_OF); len(rels) > 0 {
+		rec.Add("T2", rels[0].TargetTitle)
+	}
+	for _, rel := range hub.GetRelationsByType(record, hubv1.RelationType_RELATION_TYPE_IN_SERIES) {
+		rec.Add("T3", rel.TargetTitle)
+	}
+	if pub != nil {
+		rec.Add("VL", pub.Volume)
+		rec.Add("IS", pub.Issue)
+		sp, ep := helpers.SplitPages(pub.Pages)
+		rec.Add("SP", sp)
+		rec.Add("EP", ep)
+	}
+
+	// Theses carry the institution as publisher and the degree in M3
+	publisher := record.Publisher
+	if ty == "THES" && record.DegreeInfo != nil {
+		if publisher == "" {
+			publisher = record.DegreeInfo.Institution
+		}
+		rec.Add("M3", record.DegreeInfo.DegreeName)
+	}
+	rec.Add("PB", publisher)
+	rec.Add("CY", record.PlacePublished)
+	rec.Add("ET", record.Edition)
+	rec.Add("LA", record.Language)
+
+	if pub != nil {
+		rec.Add("SN", pub.Issn)
+	}
+	var localID string
+	for _, id := range record.Identifiers {
+		switch id.Type {
+		case hubv1.IdentifierType_IDENTIFIER_TYPE_DOI:
+			if rec.Get("DO") == "" {
+				rec.Add("DO", hub.NormalizeIdentifier(id.Value, id.Type))
+			}
+		case hubv1.IdentifierType_IDENTIFIER_TYPE_URL:
+			rec.Add("UR", id.Value)
+		case hubv1.IdentifierType_IDENTIFIER_TYPE_ISBN:
+			rec.Add("SN", id.Value)
+		case hubv1.IdentifierType_IDENTIFIER_TYPE_ISSN:
+			if pub == nil || pub.Issn != id.Value {
+				rec.Add("SN", id.Value)
+			}
+		case hubv1.IdentifierType_IDENTIFIER_TYPE_LOCAL:
+			if localID == "" {
+				localID = id.Value
+			}
+		}
+	}
+
+	for _, s := range record.Subjects {
+		rec.Add("KW", s.Value)
+	}
+	rec.Add("AB", record.Abstract)
+	for _, n := range record.Notes {
+		rec.Add("N1", n)
+	}
+	rec.Add("ID", localID)
+
+	// Tags kept from an RIS source, in a stable order
+	extra := hub.GetExtraFields(record)
+	keys := make([]string, 0, len(extra))
+	for k := range extra {
+		if strings.HasPrefix(k, "ris_") && len(k) == len("ris_")+2 {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if v, ok := extra[k].(string); ok {
+			rec.Add(strings.ToUpper(strings.TrimPrefix(k, "ris_")), v)
+		}
+	}
+
+	return rec
+}
+
+// referenceType returns the RIS type for a hub resource type, preferring
+// the original TY value when the record came from RIS.
+func referenceType(rt *hubv1.ResourceType) string {
+	if rt == nil {
+		return "GEN"
+	}
+	if rt.Vocabulary == "ris" && rt.Original != "" {
+		return rt.Original
+	}
+	if ty, ok := hubTypes[rt.Type]; ok {
+		return ty
+	}
+	return "GEN"
+}
+
+// contributorTag returns the RIS name tag for a contributor's role.
+// Contributors without a role are authors.
+func contributorTag(c *hubv1.Contributor) string {
+	code := helpers.NormalizeRole(c.RoleCode)
+	if code == "" {
+		code = helpers.NormalizeRole(c.Role)
+	}
+	switch code {
+	case "", "aut", "cre":
+		return "AU"
+	case "edt":
+		return "ED"
+	default:
+		return "A4"
+	}
+}
+
+// contributorName returns the name in the inverted "Last, First" form
+// RIS expects for people; organization names are written as-is.
+func contributorName(c *hubv1.Contributor) string {
+	if c.Type == hubv1.ContributorType_CONTRIBUTOR_TYPE_ORGANIZATION {
+		return c.Name
+	}
+	if c.ParsedName == nil && c.Name != "" && c.Type == hubv1.ContributorType_CONTRIBUTOR_TYPE_PERSON {
+		return helpers.FormatNameInverted(c.Name)
+	}
+	return hub.InvertedName(c)
+}
+
+// primaryDate returns the issued date, or the first date when there is none.
+func primaryDate(record *hubv1.Record) *hubv1.DateValue {
+	for _, d := range record.Dates {
+		if d.Type == hubv1.DateType_DATE_TYPE_ISSUED || d.Type == hubv1.DateType_DATE_TYPE_PUBLISHED {
+			return d
+		}
+	}
+	if len(record.Dates) > 0 {
+		return record.Dates[0]
+	}
+	return nil
+}
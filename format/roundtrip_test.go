@@ -2,6 +2,7 @@ package format_test
 
 import (
 	"bytes"
+	"strings"
 	"testing"
 
 	"github.com/lehigh-university-libraries/crosswalk/format"
@@ -206,3 +207,105 @@ func TestDrupalToCSVConversion(t *testing.T) {
 		t.Error("CSV should contain nid")
 	}
 }
+
+// richRecord populates most of the hub fields that formats declare.
+func richRecord() *hubv1.Record {
+	return &hubv1.Record{
+		Title:          "Charge Transport in Thin Films",
+		AltTitle:       []string{"Thin film transport"},
+		Abstract:       "We measure charge transport.",
+		Language:       "eng",
+		Publisher:      "Lehigh University",
+		PlacePublished: "Bethlehem, PA",
+		Edition:        "2nd",
+		ResourceType:   &hubv1.ResourceType{Type: hubv1.ResourceTypeValue_RESOURCE_TYPE_ARTICLE},
+		Contributors: []*hubv1.Contributor{
+			{Name: "Smith, Jane", Role: "author", RoleCode: "aut", Type: hubv1.ContributorType_CONTRIBUTOR_TYPE_PERSON,
+				ParsedName: &hubv1.ParsedName{Given: "Jane", Family: "Smith"}},
+		},
+		Dates: []*hubv1.DateValue{
+			{Type: hubv1.DateType_DATE_TYPE_ISSUED, Year: 2021, Month: 5, Day: 12, Precision: hubv1.DatePrecision_DATE_PRECISION_DAY, Raw: "2021-05-12"},
+		},
+		Subjects: []*hubv1.Subject{
+			{Value: "Semiconductors", Vocabulary: hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_KEYWORDS},
+		},
+		Identifiers: []*hubv1.Identifier{
+			{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_DOI, Value: "10.1234/thin.42"},
+		},
+		Rights: []*hubv1.Rights{
+			{Uri: "http://creativecommons.org/licenses/by/4.0/", Statement: "CC BY 4.0"},
+		},
+		Notes:       []string{"Open access version"},
+		Publication: &hubv1.PublicationDetails{Title: "Journal of Films", Volume: "12", Issue: "3", Pages: "1-10"},
+		Relations: []*hubv1.Relation{
+			{Type: hubv1.RelationType_RELATION_TYPE_IN_SERIES, TargetTitle: "Lehigh Physics Series"},
+		},
+	}
+}
+
+// TestRoundTripPreservesDeclaredFields serializes a rich record with every
+// format that both parses and declares its fields, parses the output back,
+// and checks that each declared field populated in the source survives.
+// Fields listed in skip are written, but in a form the format's parser
+// doesn't read back as the same hub field.
+func TestRoundTripPreservesDeclaredFields(t *testing.T) {
+	skip := map[string]map[string]bool{
+		// The subtitle is folded into the title; license assertions
+		// aren't parsed
+		"crossref": {"alt_title": true, "rights": true},
+		// Only relations to entities with a node ID are written
+		"drupal": {"relations.in_series": true},
+		// dc:type "Text" is too broad to map back; simple DC relations
+		// come back as related_to
+		"dublincore": {"resource_type": true, "relations.in_series": true},
+		// Edition and related item titles aren't parsed
+		"mods": {"edition": true, "relations.in_series": true},
+	}
+
+	names := []string{
+		"arxiv", "bibtex", "crossref", "csl", "csv", "datacite", "drupal",
+		"dublincore", "hub-jsonl", "islandora-workbench", "marc", "mods", "proquest", "ris",
+		"schemaorg", "scholix",
+	}
+	for _, name := range names {
+		s, err := format.GetSerializer(name)
+		if err != nil {
+			continue
+		}
+		describer, ok := s.(format.FieldDescriber)
+		if !ok {
+			continue
+		}
+		p, err := format.GetParser(name)
+		if err != nil {
+			continue
+		}
+
+		t.Run(name, func(t *testing.T) {
+			source := []*hubv1.Record{richRecord()}
+			opts := format.NewSerializeOptions()
+
+			var buf bytes.Buffer
+			if err := s.Serialize(&buf, source, opts); err != nil {
+				t.Fatalf("Serialize failed: %v", err)
+			}
+			parsed, err := p.Parse(bytes.NewReader(buf.Bytes()), format.NewParseOptions())
+			if err != nil {
+				t.Fatalf("Parse failed: %v\n%s", err, buf.String())
+			}
+
+			before := format.PresentFields(source)
+			after := format.PresentFields(parsed)
+			for _, field := range describer.WrittenFields(opts) {
+				for key := range before {
+					if key != field && !strings.HasPrefix(key, field+".") {
+						continue
+					}
+					if after[key] == 0 && !skip[name][key] {
+						t.Errorf("%s lost in round trip", key)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package schemaorg
+
+import (
+	"slices"
+	"strings"
+)
+
+// entityTypes are the schema.org types that describe the people, terms,
+// and structures around a work rather than a work to make a record of.
+var entityTypes = map[string]bool{
+	"Person": true, "Organization": true, "CollegeOrUniversity": true,
+	"EducationalOrganization": true, "ResearchOrganization": true,
+	"Corporation": true, "GovernmentOrganization": true, "Library": true,
+	"DefinedTerm": true, "DefinedTermSet": true, "CategoryCode": true,
+	"PropertyValue": true, "Place": true, "PostalAddress": true,
+	"ContactPoint": true, "Language": true, "Role": true,
+	"Grant": true, "MonetaryGrant": true, "Audience": true,
+	"BreadcrumbList": true, "ListItem": true, "ItemList": true,
+	"SearchAction": true, "EntryPoint": true, "ReadAction": true,
+}
+
+// frame returns the documents describing works in parsed JSON-LD: each
+// object of a top-level array, a lone object, or the root works of a
+// @graph, with node references ({"@id": "..."}) replaced by the graph
+// nodes they name. A root work is one no other node in the graph refers
+// to, so the WebPage and WebSite nodes that SEO plugins emit around an
+// article don't become records of their own.
+func frame(v any) []map[string]any {
+	switch t := v.(type) {
+	case []any:
+		var docs []map[string]any
+		for _, item := range t {
+			docs = append(docs, frame(item)...)
+		}
+		return docs
+	case map[string]any:
+		switch g := t["@graph"].(type) {
+		case []any:
+			return frameGraph(g)
+		case map[string]any:
+			return frameGraph([]any{g})
+		}
+		return []map[string]any{t}
+	}
+	return nil
+}
+
+// frameGraph returns the root works of a graph with references embedded.
+func frameGraph(graph []any) []map[string]any {
+	nodes := make(map[string]map[string]any)
+	var all []map[string]any
+	for _, item := range graph {
+		node, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		all = append(all, node)
+		if id := getString(node, "@id"); id != "" {
+			nodes[id] = node
+		}
+	}
+
+	referenced := make(map[string]bool)
+	for _, node := range all {
+		for key, val := range node {
+			if key != "@id" {
+				collectReferences(val, referenced)
+			}
+		}
+	}
+
+	var works, roots []map[string]any
+	for _, node := range all {
+		if !isWork(node) {
+			continue
+		}
+		works = append(works, node)
+		if id := getString(node, "@id"); id == "" || !referenced[id] {
+			roots = append(roots, node)
+		}
+	}
+	if len(roots) == 0 {
+		// Every work is referenced, as in a cycle; keep them all
+		roots = works
+	}
+
+	docs := make([]map[string]any, 0, len(roots))
+	for _, root := range roots {
+		seen := map[string]bool{getString(root, "@id"): true}
+		docs = append(docs, embed(root, nodes, seen).(map[string]any))
+	}
+	return docs
+}
+
+// collectReferences records the @id of every node reference in val.
+func collectReferences(val any, ids map[string]bool) {
+	switch v := val.(type) {
+	case map[string]any:
+		if id := getString(v, "@id"); id != "" {
+			ids[id] = true
+		}
+		for key, item := range v {
+			if key != "@id" {
+				collectReferences(item, ids)
+			}
+		}
+	case []any:
+		for _, item := range v {
+			collectReferences(item, ids)
+		}
+	}
+}
+
+// embed returns val with node references replaced by copies of the nodes
+// they name. References to a node already being embedded are left alone.
+func embed(val any, nodes map[string]map[string]any, seen map[string]bool) any {
+	switch v := val.(type) {
+	case map[string]any:
+		if id := getString(v, "@id"); id != "" && len(v) == 1 {
+			node, ok := nodes[id]
+			if !ok || seen[id] {
+				return v
+			}
+			seen[id] = true
+			defer delete(seen, id)
+			return embed(node, nodes, seen)
+		}
+		out := make(map[string]any, len(v))
+		for key, item := range v {
+			out[key] = embed(item, nodes, seen)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = embed(item, nodes, seen)
+		}
+		return out
+	}
+	return val
+}
+
+// isWork reports whether a node is typed as something other than an
+// entity around a work.
+func isWork(node map[string]any) bool {
+	types := schemaTypes(node)
+	return len(types) > 0 && slices.ContainsFunc(types, func(t string) bool { return !entityTypes[t] })
+}
+
+// schemaTypes returns a node's @type values, without a "schema:" or
+// schema.org IRI prefix.
+func schemaTypes(node map[string]any) []string {
+	var types []string
+	add := func(t string) {
+		for _, prefix := range []string{"https://schema.org/", "http://schema.org/", "schema:"} {
+			t = strings.TrimPrefix(t, prefix)
+		}
+		if t != "" {
+			types = append(types, t)
+		}
+	}
+	switch t := node["@type"].(type) {
+	case string:
+		add(t)
+	case []any:
+		for _, item := range t {
+			if s, ok := item.(string); ok {
+				add(s)
+			}
+		}
+	}
+	return types
+}
+
+// hasType reports whether a node has the schema.org type t.
+func hasType(node map[string]any, t string) bool {
+	return slices.Contains(schemaTypes(node), t)
+}
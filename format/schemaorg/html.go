@@ -0,0 +1,273 @@
+package schemaorg
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+)
+
+// pageTypes describe the web page embedding metadata rather than the work
+// it is about.
+var pageTypes = map[string]bool{
+	"WebSite": true, "WebPage": true, "ItemPage": true, "AboutPage": true,
+	"CollectionPage": true, "ProfilePage": true, "SearchResultsPage": true,
+}
+
+// htmlAttrRe matches an attribute of an HTML start tag, quoted or not.
+var htmlAttrRe = regexp.MustCompile(`([a-zA-Z_:][-a-zA-Z0-9_:.]*)\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'>]+))`)
+
+// parseHTML reads the schema.org JSON-LD embedded in an HTML page's
+// <script type="application/ld+json"> elements. The scripts are framed as
+// one graph, so a work described across several scripts by @id is one
+// record, and each record gets the page's URL as an identifier: its
+// canonical link, or the URL it was read from (opts.SourceName).
+func parseHTML(data []byte, opts *format.ParseOptions) ([]*hubv1.Record, error) {
+	scripts := jsonLDScripts(data)
+	if len(scripts) == 0 {
+		return nil, fmt.Errorf("no application/ld+json scripts in HTML page")
+	}
+
+	var graph []any
+	for i, script := range scripts {
+		var v any
+		if err := json.Unmarshal(script, &v); err != nil {
+			return nil, fmt.Errorf("parsing JSON-LD script %d: %w", i, err)
+		}
+		graph = append(graph, graphNodes(v)...)
+	}
+
+	var source string
+	if opts != nil {
+		source = opts.SourceName
+	}
+	page := pageURL(data, source)
+
+	docs := frameGraph(graph)
+	// The page itself is only worth a record when it describes nothing else
+	if works := slices.DeleteFunc(slices.Clone(docs), isPage); len(works) > 0 {
+		docs = works
+	}
+
+	var records []*hubv1.Record
+	for i, doc := range docs {
+		record, err := schemaOrgToRecord(doc)
+		if err != nil {
+			return nil, fmt.Errorf("converting document %d: %w", i, err)
+		}
+		if page != "" && !hasIdentifier(record, page) {
+			record.Identifiers = append(record.Identifiers, &hubv1.Identifier{
+				Type:  hubv1.IdentifierType_IDENTIFIER_TYPE_URL,
+				Value: page,
+			})
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// isHTML reports whether data, with leading whitespace removed, is markup
+// rather than JSON.
+func isHTML(data []byte) bool {
+	return len(data) > 0 && data[0] == '<'
+}
+
+// graphNodes returns the nodes of a parsed script: the members of its
+// @graph, the items of an array, or the object itself.
+func graphNodes(v any) []any {
+	switch t := v.(type) {
+	case []any:
+		var nodes []any
+		for _, item := range t {
+			nodes = append(nodes, graphNodes(item)...)
+		}
+		return nodes
+	case map[string]any:
+		switch g := t["@graph"].(type) {
+		case []any:
+			return g
+		case map[string]any:
+			return []any{g}
+		}
+		return []any{t}
+	}
+	return nil
+}
+
+// isPage reports whether a document is typed only as a web page or site.
+func isPage(doc map[string]any) bool {
+	types := schemaTypes(doc)
+	return len(types) > 0 && !slices.ContainsFunc(types, func(t string) bool { return !pageTypes[t] })
+}
+
+// jsonLDScripts returns the contents of the page's application/ld+json
+// script elements.
+func jsonLDScripts(data []byte) [][]byte {
+	lower := asciiLower(data)
+	var scripts [][]byte
+	for pos := 0; ; {
+		start := bytes.Index(lower[pos:], []byte("<script"))
+		if start < 0 {
+			break
+		}
+		start += pos
+		tagEnd := bytes.IndexByte(lower[start:], '>')
+		if tagEnd < 0 {
+			break
+		}
+		tagEnd += start
+		end := bytes.Index(lower[tagEnd:], []byte("</script"))
+		if end < 0 {
+			break
+		}
+		end += tagEnd
+		pos = end
+
+		attrs := htmlAttrs(data[start+len("<script") : tagEnd])
+		mediaType, _, _ := strings.Cut(attrs["type"], ";")
+		if !strings.EqualFold(strings.TrimSpace(mediaType), "application/ld+json") {
+			continue
+		}
+		// Scripts are raw text, so JSON-LD needs no entity decoding
+		if content := unwrapScript(data[tagEnd+1 : end]); len(content) > 0 {
+			scripts = append(scripts, content)
+		}
+	}
+	return scripts
+}
+
+// scriptWrappers are what some CMSs wrap script contents in: a CDATA
+// section or HTML comment, possibly behind JavaScript comments.
+var scriptWrappers = [][2]string{{"//", ""}, {"<![CDATA[", "]]>"}, {"<!--", "-->"}, {"", "//"}}
+
+// unwrapScript returns script contents without the wrappers around them.
+func unwrapScript(content []byte) []byte {
+	content = bytes.TrimSpace(content)
+	for {
+		before := len(content)
+		for _, w := range scriptWrappers {
+			if w[0] != "" {
+				content = bytes.TrimSpace(bytes.TrimPrefix(content, []byte(w[0])))
+			}
+			if w[1] != "" {
+				content = bytes.TrimSpace(bytes.TrimSuffix(content, []byte(w[1])))
+			}
+		}
+		if len(content) == before {
+			return content
+		}
+	}
+}
+
+// pageURL returns the page's canonical URL, from <link rel="canonical"> or
+// the og:url property, resolved against source; or source itself when it
+// is an http(s) URL.
+func pageURL(data []byte, source string) string {
+	base, err := url.Parse(source)
+	if err != nil || (base.Scheme != "http" && base.Scheme != "https") {
+		base = nil
+	}
+
+	var canonical string
+	for _, tag := range htmlTags(data, "link", "meta") {
+		attrs := htmlAttrs(tag)
+		switch {
+		case attrs["href"] != "" && slices.Contains(strings.Fields(strings.ToLower(attrs["rel"])), "canonical"):
+			canonical = attrs["href"]
+		case canonical == "" && attrs["property"] == "og:url":
+			canonical = attrs["content"]
+		}
+	}
+	if canonical != "" {
+		ref, err := url.Parse(canonical)
+		if err == nil && base != nil {
+			return base.ResolveReference(ref).String()
+		}
+		if err == nil && ref.IsAbs() {
+			return canonical
+		}
+	}
+	if base != nil {
+		return base.String()
+	}
+	return ""
+}
+
+// htmlTags returns the attribute text of the page's start tags with the
+// given names.
+func htmlTags(data []byte, names ...string) [][]byte {
+	lower := asciiLower(data)
+	var tags [][]byte
+	for pos := 0; ; {
+		start := bytes.IndexByte(lower[pos:], '<')
+		if start < 0 {
+			break
+		}
+		start += pos + 1
+		end := bytes.IndexByte(lower[start:], '>')
+		if end < 0 {
+			break
+		}
+		end += start
+		pos = end
+		for _, name := range names {
+			rest, ok := bytes.CutPrefix(lower[start:end], []byte(name))
+			if ok && len(rest) > 0 && (rest[0] == ' ' || rest[0] == '\t' || rest[0] == '\n' || rest[0] == '\r') {
+				tags = append(tags, data[start+len(name):end])
+			}
+		}
+	}
+	return tags
+}
+
+// htmlAttrs parses the attributes of a start tag, lowercasing names and
+// decoding character references in values.
+func htmlAttrs(tag []byte) map[string]string {
+	attrs := make(map[string]string)
+	for _, m := range htmlAttrRe.FindAllSubmatch(tag, -1) {
+		name := strings.ToLower(string(m[1]))
+		if _, ok := attrs[name]; ok {
+			continue
+		}
+		var value []byte
+		for _, v := range m[2:] {
+			if v != nil {
+				value = v
+				break
+			}
+		}
+		attrs[name] = html.UnescapeString(string(value))
+	}
+	return attrs
+}
+
+// asciiLower lowercases ASCII letters only, so offsets into the result
+// are offsets into data (Unicode case mapping can change byte lengths).
+func asciiLower(data []byte) []byte {
+	lower := make([]byte, len(data))
+	for i, c := range data {
+		if 'A' <= c && c <= 'Z' {
+			c += 'a' - 'A'
+		}
+		lower[i] = c
+	}
+	return lower
+}
+
+// hasIdentifier reports whether the record already has an identifier with
+// the value.
+func hasIdentifier(record *hubv1.Record, value string) bool {
+	for _, id := range record.Identifiers {
+		if id.Value == value {
+			return true
+		}
+	}
+	return false
+}
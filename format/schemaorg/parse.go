@@ -4,15 +4,21 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/lehigh-university-libraries/crosswalk/format"
 	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/helpers"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
 )
 
-// Parse reads schema.org JSON-LD and returns hub records.
-func (f *Format) Parse(r io.Reader, _ *format.ParseOptions) ([]*hubv1.Record, error) {
+// Parse reads schema.org JSON-LD and returns hub records: one per object
+// of an array, or per root work of a @graph document (see frame). HTML
+// pages are read for the JSON-LD they embed (see parseHTML).
+func (f *Format) Parse(r io.Reader, opts *format.ParseOptions) ([]*hubv1.Record, error) {
 	data, err := io.ReadAll(r)
 	if err != nil {
 		return nil, fmt.Errorf("reading input: %w", err)
@@ -25,36 +31,25 @@ func (f *Format) Parse(r io.Reader, _ *format.ParseOptions) ([]*hubv1.Record, er
 		return nil, nil
 	}
 
-	var records []*hubv1.Record
+	if isHTML(data) {
+		return parseHTML(data, opts)
+	}
+	if data[0] != '[' && data[0] != '{' {
+		return nil, fmt.Errorf("invalid JSON: expected {, [, or an HTML page")
+	}
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, fmt.Errorf("parsing JSON: %w", err)
+	}
 
-	// Parse based on first character
-	switch data[0] {
-	case '[':
-		var docs []map[string]any
-		if err := json.Unmarshal(data, &docs); err != nil {
-			return nil, fmt.Errorf("parsing JSON array: %w", err)
-		}
-		for i, doc := range docs {
-			record, err := schemaOrgToRecord(doc)
-			if err != nil {
-				return nil, fmt.Errorf("converting document %d: %w", i, err)
-			}
-			records = append(records, record)
-		}
-	case '{':
-		var doc map[string]any
-		if err := json.Unmarshal(data, &doc); err != nil {
-			return nil, fmt.Errorf("parsing JSON object: %w", err)
-		}
+	var records []*hubv1.Record
+	for i, doc := range frame(v) {
 		record, err := schemaOrgToRecord(doc)
 		if err != nil {
-			return nil, fmt.Errorf("converting document: %w", err)
+			return nil, fmt.Errorf("converting document %d: %w", i, err)
 		}
 		records = append(records, record)
-	default:
-		return nil, fmt.Errorf("invalid JSON: expected { or [")
 	}
-
 	return records, nil
 }
 
@@ -77,14 +72,18 @@ func schemaOrgToRecord(doc map[string]any) (*hubv1.Record, error) {
 	record := &hubv1.Record{}
 
 	// Get @type
-	schemaType := getString(doc, "@type")
-	record.ResourceType = mapSchemaTypeToResourceType(schemaType)
+	record.ResourceType = mapSchemaTypeToResourceType(primarySchemaType(doc))
 
-	// Core properties
+	// Core properties. Language maps and tagged values are resolved once
+	// the record language is known.
 	if name := getString(doc, "name"); name != "" {
 		record.Title = name
 	} else if headline := getString(doc, "headline"); headline != "" {
 		record.Title = headline
+	} else if titles := localizedValues(doc["name"]); len(titles) > 0 {
+		record.Titles = titles
+	} else {
+		record.Titles = localizedValues(doc["headline"])
 	}
 
 	if altTitle := getString(doc, "alternativeHeadline"); altTitle != "" {
@@ -93,6 +92,8 @@ func schemaOrgToRecord(doc map[string]any) (*hubv1.Record, error) {
 
 	if abstract := getString(doc, "abstract"); abstract != "" {
 		record.Abstract = abstract
+	} else {
+		record.Abstracts = localizedValues(doc["abstract"])
 	}
 
 	if desc := getString(doc, "description"); desc != "" {
@@ -125,6 +126,9 @@ func schemaOrgToRecord(doc map[string]any) (*hubv1.Record, error) {
 	if dateModified := getString(doc, "dateModified"); dateModified != "" {
 		record.Dates = append(record.Dates, parseDate(dateModified, hubv1.DateType_DATE_TYPE_MODIFIED))
 	}
+	if coverage := getString(doc, "temporalCoverage"); coverage != "" {
+		addTemporalCoverage(record, coverage)
+	}
 
 	// Language
 	if lang := doc["inLanguage"]; lang != nil {
@@ -140,42 +144,27 @@ func schemaOrgToRecord(doc map[string]any) (*hubv1.Record, error) {
 	}
 
 	// Genre
-	if genre := doc["genre"]; genre != nil {
-		switch g := genre.(type) {
-		case string:
-			record.Genres = []*hubv1.Subject{{Value: g, Vocabulary: hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_GENRE}}
-		case []any:
-			for _, item := range g {
-				if s, ok := item.(string); ok {
-					record.Genres = append(record.Genres, &hubv1.Subject{Value: s, Vocabulary: hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_GENRE})
-				}
-			}
-		}
-	}
+	record.Genres = parseDefinedTerms(doc["genre"], hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_GENRE, false)
 
-	// Keywords/Subjects
-	if keywords := doc["keywords"]; keywords != nil {
-		switch k := keywords.(type) {
-		case string:
-			// Split comma-separated keywords
-			for _, kw := range strings.Split(k, ",") {
-				kw = strings.TrimSpace(kw)
-				if kw != "" {
-					record.Subjects = append(record.Subjects, &hubv1.Subject{
-						Value:      kw,
-						Vocabulary: hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_KEYWORDS,
-					})
-				}
-			}
-		case []any:
-			for _, item := range k {
-				if s, ok := item.(string); ok {
-					record.Subjects = append(record.Subjects, &hubv1.Subject{
-						Value:      s,
-						Vocabulary: hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_KEYWORDS,
-					})
-				}
-			}
+	// Keywords and about, as plain strings (comma-separated keywords are
+	// split) or DefinedTerms
+	record.Subjects = append(record.Subjects, parseDefinedTerms(doc["keywords"], hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_KEYWORDS, true)...)
+	record.Subjects = append(record.Subjects, parseDefinedTerms(doc["about"], hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_KEYWORDS, false)...)
+
+	// Copyright
+	switch h := doc["copyrightHolder"].(type) {
+	case string:
+		record.RightsHolder = h
+	case map[string]any:
+		record.RightsHolder = getString(h, "name")
+	}
+	record.CopyrightStatement = getString(doc, "copyrightNotice")
+	switch y := doc["copyrightYear"].(type) {
+	case float64:
+		record.Dates = append(record.Dates, parseDate(strconv.Itoa(int(y)), hubv1.DateType_DATE_TYPE_COPYRIGHT))
+	case string:
+		if y != "" {
+			record.Dates = append(record.Dates, parseDate(y, hubv1.DateType_DATE_TYPE_COPYRIGHT))
 		}
 	}
 
@@ -239,6 +228,16 @@ func schemaOrgToRecord(doc map[string]any) (*hubv1.Record, error) {
 		}
 	}
 
+	// Dataset distributions
+	if dist := doc["distribution"]; dist != nil {
+		record.Distributions = parseDistributions(dist)
+	}
+
+	// Files
+	if media := doc["associatedMedia"]; media != nil {
+		record.Files = parseAssociatedMedia(media)
+	}
+
 	// Physical description
 	if pagination := getString(doc, "pagination"); pagination != "" {
 		record.PhysicalDesc = pagination
@@ -258,54 +257,222 @@ func schemaOrgToRecord(doc map[string]any) (*hubv1.Record, error) {
 		}
 	}
 
+	// The record's title and abstract are those in its language
+	if len(record.Titles) > 0 {
+		record.Title = hub.TitleIn(record, record.Language)
+		if record.Title == "" {
+			record.Title = record.Titles[0].Value
+		}
+	}
+	if len(record.Abstracts) > 0 {
+		record.Abstract = hub.AbstractIn(record, record.Language)
+		if record.Abstract == "" {
+			record.Abstract = record.Abstracts[0].Value
+		}
+	}
+
 	return record, nil
 }
 
+// localizedValues reads a language map ({"en": "...", "es": "..."}), a
+// value object ({"@value": "...", "@language": "en"}), or an array of
+// value objects. Plain strings are left to getString.
+func localizedValues(val any) []*hubv1.LocalizedString {
+	var values []*hubv1.LocalizedString
+	switch v := val.(type) {
+	case map[string]any:
+		if text, ok := v["@value"].(string); ok {
+			lang, _ := v["@language"].(string)
+			return []*hubv1.LocalizedString{{Value: text, Language: lang}}
+		}
+		langs := make([]string, 0, len(v))
+		for lang := range v {
+			langs = append(langs, lang)
+		}
+		sort.Strings(langs)
+		for _, lang := range langs {
+			tag := lang
+			if tag == "@none" {
+				tag = ""
+			}
+			switch text := v[lang].(type) {
+			case string:
+				values = append(values, &hubv1.LocalizedString{Value: text, Language: tag})
+			case []any:
+				for _, item := range text {
+					if s, ok := item.(string); ok {
+						values = append(values, &hubv1.LocalizedString{Value: s, Language: tag})
+					}
+				}
+			}
+		}
+	case []any:
+		for _, item := range v {
+			if obj, ok := item.(map[string]any); ok {
+				values = append(values, localizedValues(obj)...)
+			}
+		}
+	}
+	return values
+}
+
+// parseDistributions extracts DataDownload objects from a distribution
+// property, which may be a single object or an array.
+func parseDistributions(val any) []*hubv1.Distribution {
+	var items []any
+	switch v := val.(type) {
+	case map[string]any:
+		items = []any{v}
+	case []any:
+		items = v
+	}
+
+	var dists []*hubv1.Distribution
+	for _, item := range items {
+		obj, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		d := &hubv1.Distribution{
+			Url:         getString(obj, "contentUrl"),
+			Name:        getString(obj, "name"),
+			Format:      getString(obj, "encodingFormat"),
+			Description: getString(obj, "description"),
+			SizeBytes:   parseContentSize(obj["contentSize"]),
+		}
+		if sha := getString(obj, "sha256"); sha != "" {
+			d.Checksums = append(d.Checksums, hub.NewChecksum("sha256", sha))
+		}
+		if d.Url == "" && d.Name == "" {
+			continue
+		}
+		dists = append(dists, d)
+	}
+	return dists
+}
+
+// parseAssociatedMedia extracts MediaObjects from an associatedMedia
+// property, which may be a single object or an array.
+func parseAssociatedMedia(val any) []*hubv1.File {
+	var items []any
+	switch v := val.(type) {
+	case map[string]any:
+		items = []any{v}
+	case []any:
+		items = v
+	}
+
+	var files []*hubv1.File
+	for _, item := range items {
+		obj, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		f := &hubv1.File{
+			Path:        getString(obj, "contentUrl"),
+			Name:        getString(obj, "name"),
+			MimeType:    getString(obj, "encodingFormat"),
+			Description: getString(obj, "description"),
+			SizeBytes:   parseContentSize(obj["contentSize"]),
+		}
+		hub.SetChecksum(f, "sha256", getString(obj, "sha256"))
+		if f.Path == "" && f.Name == "" {
+			continue
+		}
+		files = append(files, f)
+	}
+	return files
+}
+
+// parseContentSize reads a byte count from a contentSize value such as
+// 2048 or "2048 bytes". Sizes in other units are not converted.
+func parseContentSize(val any) int64 {
+	switch v := val.(type) {
+	case float64:
+		return int64(v)
+	case string:
+		fields := strings.Fields(v)
+		if len(fields) == 0 || (len(fields) > 1 && !strings.EqualFold(fields[1], "bytes")) {
+			return 0
+		}
+		n, _ := strconv.ParseInt(fields[0], 10, 64)
+		return n
+	}
+	return 0
+}
+
+// schemaResourceTypes maps schema.org types, including the CreativeWork
+// subtypes publishers use, to hub resource types.
+var schemaResourceTypes = map[string]hubv1.ResourceTypeValue{
+	"ScholarlyArticle":            hubv1.ResourceTypeValue_RESOURCE_TYPE_ARTICLE,
+	"Article":                     hubv1.ResourceTypeValue_RESOURCE_TYPE_ARTICLE,
+	"TechArticle":                 hubv1.ResourceTypeValue_RESOURCE_TYPE_ARTICLE,
+	"BlogPosting":                 hubv1.ResourceTypeValue_RESOURCE_TYPE_ARTICLE,
+	"NewsArticle":                 hubv1.ResourceTypeValue_RESOURCE_TYPE_NEWSPAPER_ARTICLE,
+	"Newspaper":                   hubv1.ResourceTypeValue_RESOURCE_TYPE_NEWSPAPER,
+	"Book":                        hubv1.ResourceTypeValue_RESOURCE_TYPE_BOOK,
+	"Chapter":                     hubv1.ResourceTypeValue_RESOURCE_TYPE_BOOK_CHAPTER,
+	"Dataset":                     hubv1.ResourceTypeValue_RESOURCE_TYPE_DATASET,
+	"Collection":                  hubv1.ResourceTypeValue_RESOURCE_TYPE_COLLECTION,
+	"DigitalDocument":             hubv1.ResourceTypeValue_RESOURCE_TYPE_THESIS,
+	"Thesis":                      hubv1.ResourceTypeValue_RESOURCE_TYPE_THESIS,
+	"Manuscript":                  hubv1.ResourceTypeValue_RESOURCE_TYPE_MANUSCRIPT,
+	"ArchiveComponent":            hubv1.ResourceTypeValue_RESOURCE_TYPE_ARCHIVAL_MATERIAL,
+	"AudioObject":                 hubv1.ResourceTypeValue_RESOURCE_TYPE_AUDIO,
+	"MusicRecording":              hubv1.ResourceTypeValue_RESOURCE_TYPE_AUDIO,
+	"PodcastEpisode":              hubv1.ResourceTypeValue_RESOURCE_TYPE_AUDIO,
+	"ImageObject":                 hubv1.ResourceTypeValue_RESOURCE_TYPE_IMAGE,
+	"Photograph":                  hubv1.ResourceTypeValue_RESOURCE_TYPE_IMAGE,
+	"Painting":                    hubv1.ResourceTypeValue_RESOURCE_TYPE_IMAGE,
+	"Drawing":                     hubv1.ResourceTypeValue_RESOURCE_TYPE_IMAGE,
+	"VisualArtwork":               hubv1.ResourceTypeValue_RESOURCE_TYPE_IMAGE,
+	"VideoObject":                 hubv1.ResourceTypeValue_RESOURCE_TYPE_VIDEO,
+	"Movie":                       hubv1.ResourceTypeValue_RESOURCE_TYPE_VIDEO,
+	"PublicationIssue":            hubv1.ResourceTypeValue_RESOURCE_TYPE_PERIODICAL,
+	"PublicationVolume":           hubv1.ResourceTypeValue_RESOURCE_TYPE_JOURNAL,
+	"Periodical":                  hubv1.ResourceTypeValue_RESOURCE_TYPE_JOURNAL,
+	"Report":                      hubv1.ResourceTypeValue_RESOURCE_TYPE_REPORT,
+	"SoftwareSourceCode":          hubv1.ResourceTypeValue_RESOURCE_TYPE_SOFTWARE,
+	"SoftwareApplication":         hubv1.ResourceTypeValue_RESOURCE_TYPE_SOFTWARE,
+	"WebPage":                     hubv1.ResourceTypeValue_RESOURCE_TYPE_WEBPAGE,
+	"WebSite":                     hubv1.ResourceTypeValue_RESOURCE_TYPE_WEBPAGE,
+	"Map":                         hubv1.ResourceTypeValue_RESOURCE_TYPE_MAP,
+	"Poster":                      hubv1.ResourceTypeValue_RESOURCE_TYPE_POSTER,
+	"Presentation":                hubv1.ResourceTypeValue_RESOURCE_TYPE_PRESENTATION,
+	"PresentationDigitalDocument": hubv1.ResourceTypeValue_RESOURCE_TYPE_PRESENTATION,
+	"Review":                      hubv1.ResourceTypeValue_RESOURCE_TYPE_PEER_REVIEW,
+}
+
+// primarySchemaType returns the most specific of a document's types: the
+// first that maps to a hub resource type, else the first other than
+// CreativeWork (which the serializer adds as a fallback type).
+func primarySchemaType(doc map[string]any) string {
+	types := schemaTypes(doc)
+	for _, t := range types {
+		if _, ok := schemaResourceTypes[t]; ok {
+			return t
+		}
+	}
+	for _, t := range types {
+		if t != "CreativeWork" {
+			return t
+		}
+	}
+	if len(types) > 0 {
+		return types[0]
+	}
+	return ""
+}
+
 // mapSchemaTypeToResourceType converts schema.org @type to hub ResourceType.
 func mapSchemaTypeToResourceType(schemaType string) *hubv1.ResourceType {
 	rt := &hubv1.ResourceType{
 		Original: schemaType,
+		Type:     hubv1.ResourceTypeValue_RESOURCE_TYPE_OTHER,
 	}
-
-	switch schemaType {
-	case "ScholarlyArticle", "Article":
-		rt.Type = hubv1.ResourceTypeValue_RESOURCE_TYPE_ARTICLE
-	case "Book":
-		rt.Type = hubv1.ResourceTypeValue_RESOURCE_TYPE_BOOK
-	case "Chapter":
-		rt.Type = hubv1.ResourceTypeValue_RESOURCE_TYPE_BOOK_CHAPTER
-	case "Dataset":
-		rt.Type = hubv1.ResourceTypeValue_RESOURCE_TYPE_DATASET
-	case "Collection":
-		rt.Type = hubv1.ResourceTypeValue_RESOURCE_TYPE_COLLECTION
-	case "DigitalDocument", "Thesis":
-		rt.Type = hubv1.ResourceTypeValue_RESOURCE_TYPE_THESIS
-	case "Manuscript":
-		rt.Type = hubv1.ResourceTypeValue_RESOURCE_TYPE_MANUSCRIPT
-	case "AudioObject":
-		rt.Type = hubv1.ResourceTypeValue_RESOURCE_TYPE_AUDIO
-	case "ImageObject", "Photograph":
-		rt.Type = hubv1.ResourceTypeValue_RESOURCE_TYPE_IMAGE
-	case "VideoObject":
-		rt.Type = hubv1.ResourceTypeValue_RESOURCE_TYPE_VIDEO
-	case "PublicationIssue":
-		rt.Type = hubv1.ResourceTypeValue_RESOURCE_TYPE_PERIODICAL
-	case "PublicationVolume", "Periodical":
-		rt.Type = hubv1.ResourceTypeValue_RESOURCE_TYPE_JOURNAL
-	case "Report":
-		rt.Type = hubv1.ResourceTypeValue_RESOURCE_TYPE_REPORT
-	case "SoftwareSourceCode", "SoftwareApplication":
-		rt.Type = hubv1.ResourceTypeValue_RESOURCE_TYPE_SOFTWARE
-	case "WebPage":
-		rt.Type = hubv1.ResourceTypeValue_RESOURCE_TYPE_WEBPAGE
-	case "Map":
-		rt.Type = hubv1.ResourceTypeValue_RESOURCE_TYPE_MAP
-	case "Presentation", "PresentationDigitalDocument":
-		rt.Type = hubv1.ResourceTypeValue_RESOURCE_TYPE_PRESENTATION
-	default:
-		rt.Type = hubv1.ResourceTypeValue_RESOURCE_TYPE_OTHER
+	if t, ok := schemaResourceTypes[schemaType]; ok {
+		rt.Type = t
 	}
-
 	return rt
 }
 
@@ -351,8 +518,7 @@ func parsePersonOrOrg(obj map[string]any, role string) *hubv1.Contributor {
 		Role: role,
 	}
 
-	objType := getString(obj, "@type")
-	if objType == "Organization" {
+	if isOrganization(obj) {
 		contrib.Type = hubv1.ContributorType_CONTRIBUTOR_TYPE_ORGANIZATION
 		contrib.Name = getString(obj, "name")
 		if contrib.Name == "" {
@@ -391,7 +557,13 @@ func parsePersonOrOrg(obj map[string]any, role string) *hubv1.Contributor {
 		}
 	}
 
-	// ORCID from sameAs
+	// ORCID from @id or sameAs
+	if id := getString(obj, "@id"); strings.Contains(id, "orcid.org") {
+		contrib.Identifiers = append(contrib.Identifiers, &hubv1.Identifier{
+			Type:  hubv1.IdentifierType_IDENTIFIER_TYPE_ORCID,
+			Value: extractOrcid(id),
+		})
+	}
 	if sameAs := obj["sameAs"]; sameAs != nil {
 		switch s := sameAs.(type) {
 		case string:
@@ -416,6 +588,130 @@ func parsePersonOrOrg(obj map[string]any, role string) *hubv1.Contributor {
 	return contrib
 }
 
+// organizationTypes are the schema.org types parsed as organizations.
+var organizationTypes = []string{
+	"Organization", "CollegeOrUniversity", "EducationalOrganization",
+	"ResearchOrganization", "Corporation", "GovernmentOrganization",
+	"Library", "NGO",
+}
+
+// isOrganization reports whether an agent object is an organization.
+func isOrganization(obj map[string]any) bool {
+	return slices.ContainsFunc(organizationTypes, func(t string) bool { return hasType(obj, t) })
+}
+
+// termSetVocabularies maps the KnownVocabularies sources to hub
+// vocabularies.
+var termSetVocabularies = map[string]hubv1.SubjectVocabulary{
+	"aat":   hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_AAT,
+	"lcsh":  hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LCSH,
+	"lcnaf": hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LCNAF,
+	"tgn":   hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_GETTY_TGN,
+	"mesh":  hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_MESH,
+	"fast":  hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_FAST,
+}
+
+// parseDefinedTerms parses a genre, keywords, or about value: strings,
+// DefinedTerms, or a list of either. Strings that are URLs become the
+// subject's URI; split breaks other strings on commas. Subjects not in a
+// known vocabulary get vocab.
+func parseDefinedTerms(val any, vocab hubv1.SubjectVocabulary, split bool) []*hubv1.Subject {
+	var subjects []*hubv1.Subject
+	switch v := val.(type) {
+	case string:
+		values := []string{v}
+		if split && !strings.HasPrefix(v, "http") {
+			values = strings.Split(v, ",")
+		}
+		for _, value := range values {
+			value = strings.TrimSpace(value)
+			switch {
+			case value == "":
+			case strings.HasPrefix(value, "http://") || strings.HasPrefix(value, "https://"):
+				subjects = append(subjects, &hubv1.Subject{Uri: value, Vocabulary: termVocabulary(value, nil, vocab)})
+			default:
+				subjects = append(subjects, &hubv1.Subject{Value: value, Vocabulary: vocab})
+			}
+		}
+	case map[string]any:
+		if s := parseDefinedTerm(v, vocab); s != nil {
+			subjects = append(subjects, s)
+		}
+	case []any:
+		for _, item := range v {
+			subjects = append(subjects, parseDefinedTerms(item, vocab, split)...)
+		}
+	}
+	return subjects
+}
+
+// parseDefinedTerm parses a DefinedTerm (or any object with a name) to a
+// subject.
+func parseDefinedTerm(obj map[string]any, vocab hubv1.SubjectVocabulary) *hubv1.Subject {
+	s := &hubv1.Subject{
+		Value:    getString(obj, "name"),
+		Uri:      getString(obj, "url"),
+		SourceId: getString(obj, "termCode"),
+	}
+	if s.Uri == "" && strings.HasPrefix(getString(obj, "@id"), "http") {
+		s.Uri = getString(obj, "@id")
+	}
+	if s.Value == "" && s.Uri == "" {
+		return nil
+	}
+	set, _ := obj["inDefinedTermSet"].(map[string]any)
+	if set == nil {
+		if url := getString(obj, "inDefinedTermSet"); url != "" {
+			set = map[string]any{"url": url}
+		}
+	}
+	s.Vocabulary = termVocabulary(s.Uri, set, vocab)
+	return s
+}
+
+// termVocabulary returns the hub vocabulary of a term, from its term set's
+// URL or name or else its own URI, or vocab when neither is known.
+func termVocabulary(uri string, set map[string]any, vocab hubv1.SubjectVocabulary) hubv1.SubjectVocabulary {
+	candidates := []string{uri}
+	if set != nil {
+		candidates = []string{getString(set, "url"), getString(set, "@id"), getString(set, "name"), uri}
+	}
+	for _, c := range candidates {
+		if c == "" {
+			continue
+		}
+		for source, info := range KnownVocabularies {
+			if c == info.Name || strings.HasPrefix(trimScheme(c), trimScheme(info.URL)) {
+				return termSetVocabularies[source]
+			}
+		}
+	}
+	return vocab
+}
+
+// trimScheme returns a URL without its http or https scheme.
+func trimScheme(url string) string {
+	return strings.TrimPrefix(strings.TrimPrefix(url, "https://"), "http://")
+}
+
+// addTemporalCoverage adds a temporalCoverage interval as the range of the
+// date it starts on, or as a date of its own. Coverage that is not an
+// interval (e.g. "Renaissance") is not a date.
+func addTemporalCoverage(record *hubv1.Record, coverage string) {
+	dv, _ := helpers.ParseEDTF(coverage, hubv1.DateType_DATE_TYPE_OTHER)
+	if !hub.IsInterval(dv) {
+		return
+	}
+	for i, d := range record.Dates {
+		if dv.Year != 0 && d.Year == dv.Year && d.Month == dv.Month && d.Day == dv.Day {
+			dv.Type = d.Type
+			record.Dates[i] = dv
+			return
+		}
+	}
+	record.Dates = append(record.Dates, dv)
+}
+
 // parseDate parses a date string to hub DateValue.
 func parseDate(dateStr string, dateType hubv1.DateType) *hubv1.DateValue {
 	dv := &hubv1.DateValue{
@@ -470,69 +766,114 @@ func parseIdentifierFromURL(url string) *hubv1.Identifier {
 		}
 	case strings.Contains(url, "handle.net"):
 		id.Type = hubv1.IdentifierType_IDENTIFIER_TYPE_HANDLE
+	case strings.Contains(url, "/ark:"):
+		id.Type = hubv1.IdentifierType_IDENTIFIER_TYPE_ARK
+		id.Value = hub.NormalizeIdentifier(url, id.Type)
 	default:
 		id.Type = hubv1.IdentifierType_IDENTIFIER_TYPE_URL
+		if hub.DetectIdentifierType(url) == hubv1.IdentifierType_IDENTIFIER_TYPE_PURL {
+			id.Type = hubv1.IdentifierType_IDENTIFIER_TYPE_PURL
+		}
 	}
 
 	return id
 }
 
-// parseIdentifiers parses schema.org identifier property.
+// parseIdentifiers parses schema.org identifier property: strings (URLs
+// typed by their host), PropertyValues, or a list of either.
 func parseIdentifiers(val any) []*hubv1.Identifier {
 	var ids []*hubv1.Identifier
 
 	switch v := val.(type) {
 	case string:
-		ids = append(ids, &hubv1.Identifier{
-			Type:  hubv1.IdentifierType_IDENTIFIER_TYPE_LOCAL,
-			Value: v,
-		})
+		if id := parseIdentifierString(v); id != nil {
+			ids = append(ids, id)
+		}
 	case map[string]any:
-		ids = append(ids, parsePropertyValue(v))
+		if id := parsePropertyValue(v); id != nil {
+			ids = append(ids, id)
+		}
 	case []any:
 		for _, item := range v {
-			switch i := item.(type) {
-			case string:
-				ids = append(ids, &hubv1.Identifier{
-					Type:  hubv1.IdentifierType_IDENTIFIER_TYPE_LOCAL,
-					Value: i,
-				})
-			case map[string]any:
-				ids = append(ids, parsePropertyValue(i))
-			}
+			ids = append(ids, parseIdentifiers(item)...)
 		}
 	}
 
 	return ids
 }
 
-// parsePropertyValue parses a PropertyValue object to Identifier.
+// parseIdentifierString parses a bare identifier string.
+func parseIdentifierString(v string) *hubv1.Identifier {
+	v = strings.TrimSpace(v)
+	switch {
+	case v == "":
+		return nil
+	case strings.HasPrefix(v, "http://") || strings.HasPrefix(v, "https://"):
+		return parseIdentifierFromURL(v)
+	}
+	return &hubv1.Identifier{
+		Type:  hubv1.IdentifierType_IDENTIFIER_TYPE_LOCAL,
+		Value: v,
+	}
+}
+
+// propertyIDAliases maps propertyID values other than hub identifier type
+// names to identifier types.
+var propertyIDAliases = map[string]hubv1.IdentifierType{
+	"pubmed":     hubv1.IdentifierType_IDENTIFIER_TYPE_PMID,
+	"pmc":        hubv1.IdentifierType_IDENTIFIER_TYPE_PMCID,
+	"hdl":        hubv1.IdentifierType_IDENTIFIER_TYPE_HANDLE,
+	"uri":        hubv1.IdentifierType_IDENTIFIER_TYPE_URL,
+	"isbn13":     hubv1.IdentifierType_IDENTIFIER_TYPE_ISBN,
+	"isbn10":     hubv1.IdentifierType_IDENTIFIER_TYPE_ISBN,
+	"eissn":      hubv1.IdentifierType_IDENTIFIER_TYPE_ISSN,
+	"identifier": hubv1.IdentifierType_IDENTIFIER_TYPE_LOCAL,
+}
+
+// parsePropertyValue parses a PropertyValue object to Identifier. The
+// type comes from propertyID (a name, or a URL whose last segment names
+// it) or, without one, from name. Returns nil without a value.
 func parsePropertyValue(pv map[string]any) *hubv1.Identifier {
-	id := &hubv1.Identifier{
-		Value: getString(pv, "value"),
+	var value string
+	switch v := pv["value"].(type) {
+	case string:
+		value = strings.TrimSpace(v)
+	case float64:
+		value = strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	if value == "" {
+		value = getString(pv, "url")
+	}
+	if value == "" {
+		return nil
 	}
 
-	propID := strings.ToLower(getString(pv, "propertyID"))
-	switch propID {
-	case "doi":
-		id.Type = hubv1.IdentifierType_IDENTIFIER_TYPE_DOI
-	case "isbn":
-		id.Type = hubv1.IdentifierType_IDENTIFIER_TYPE_ISBN
-	case "issn":
-		id.Type = hubv1.IdentifierType_IDENTIFIER_TYPE_ISSN
-	case "handle":
-		id.Type = hubv1.IdentifierType_IDENTIFIER_TYPE_HANDLE
-	case "pmid":
-		id.Type = hubv1.IdentifierType_IDENTIFIER_TYPE_PMID
-	case "pmcid":
-		id.Type = hubv1.IdentifierType_IDENTIFIER_TYPE_PMCID
-	case "arxiv":
-		id.Type = hubv1.IdentifierType_IDENTIFIER_TYPE_ARXIV
-	default:
-		id.Type = hubv1.IdentifierType_IDENTIFIER_TYPE_LOCAL
+	propID := getString(pv, "propertyID")
+	if propID == "" {
+		propID = getString(pv, "name")
+	}
+	propID = strings.TrimRight(propID, "/")
+	if i := strings.LastIndexAny(propID, "/#"); i >= 0 && strings.Contains(propID, "://") {
+		propID = propID[i+1:]
 	}
+	propID = strings.ToLower(strings.TrimSpace(propID))
 
-	return id
+	idType, ok := propertyIDAliases[propID]
+	if !ok {
+		if n, found := hubv1.IdentifierType_value["IDENTIFIER_TYPE_"+strings.ToUpper(strings.ReplaceAll(propID, "-", "_"))]; found && n != 0 {
+			idType, ok = hubv1.IdentifierType(n), true
+		}
+	}
+	if !ok {
+		if idType = hub.DetectIdentifierType(value); idType == hubv1.IdentifierType_IDENTIFIER_TYPE_UNSPECIFIED {
+			idType = hubv1.IdentifierType_IDENTIFIER_TYPE_LOCAL
+		}
+	}
+
+	return &hubv1.Identifier{
+		Type:  idType,
+		Value: hub.NormalizeIdentifier(value, idType),
+	}
 }
 
 // parseRelation parses isPartOf/hasPart to hub Relation.
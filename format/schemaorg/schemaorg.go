@@ -15,9 +15,10 @@ type Format struct{}
 
 // Ensure Format implements the interfaces
 var (
-	_ format.Format     = (*Format)(nil)
-	_ format.Parser     = (*Format)(nil)
-	_ format.Serializer = (*Format)(nil)
+	_ format.Format         = (*Format)(nil)
+	_ format.Parser         = (*Format)(nil)
+	_ format.Serializer     = (*Format)(nil)
+	_ format.FieldDescriber = (*Format)(nil)
 )
 
 // Name returns the format identifier.
@@ -32,16 +33,36 @@ func (f *Format) Description() string {
 
 // Extensions returns file extensions associated with this format.
 func (f *Format) Extensions() []string {
-	return []string{"jsonld"}
+	return []string{"jsonld", "html"}
 }
 
-// CanParse returns true if the input looks like schema.org JSON-LD.
+// writtenFields are the hub record fields Serialize writes.
+var writtenFields = []string{
+	"title", "titles", "alt_title", "abstract", "abstracts",
+	"contributors", "dates",
+	"resource_type", "genres", "subjects", "language", "publisher", "rights",
+	"identifiers", "description", "physical_desc", "files", "distributions",
+	"degree_info", "copyright_statement", "rights_holder",
+	"relations.part_of", "relations.member_of",
+}
+
+// WrittenFields returns the hub record fields Serialize writes.
+func (f *Format) WrittenFields(opts *format.SerializeOptions) []string {
+	return writtenFields
+}
+
+// CanParse returns true if the input looks like schema.org JSON-LD, or an
+// HTML page embedding it.
 func (f *Format) CanParse(peek []byte) bool {
 	peek = bytes.TrimSpace(peek)
 	if len(peek) == 0 {
 		return false
 	}
 
+	if isHTML(peek) {
+		return bytes.Contains(bytes.ToLower(peek), []byte("application/ld+json"))
+	}
+
 	// Must be JSON
 	if peek[0] != '{' && peek[0] != '[' {
 		return false
@@ -8,6 +8,7 @@ import (
 
 	"github.com/lehigh-university-libraries/crosswalk/format"
 	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
 )
 
 func TestSerializeScholarlyArticle(t *testing.T) {
@@ -142,6 +143,127 @@ func TestSerializeDataset(t *testing.T) {
 	}
 }
 
+func TestDatasetDistributionRoundTrip(t *testing.T) {
+	record := &hubv1.Record{
+		Title:        "Stream gauge readings",
+		ResourceType: &hubv1.ResourceType{Type: hubv1.ResourceTypeValue_RESOURCE_TYPE_DATASET},
+		Distributions: []*hubv1.Distribution{
+			{
+				Url:       "https://example.com/files/readings.csv",
+				Name:      "readings.csv",
+				Format:    "text/csv",
+				SizeBytes: 2048,
+				Checksums: []*hubv1.Checksum{hub.NewChecksum("sha256", "abc123")},
+			},
+			{Url: "https://example.com/files/README.txt", Format: "text/plain"},
+		},
+	}
+
+	f := &Format{}
+	var buf bytes.Buffer
+	if err := f.Serialize(&buf, []*hubv1.Record{record}, &format.SerializeOptions{}); err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("Invalid JSON output: %v", err)
+	}
+	dist, _ := doc["distribution"].([]any)
+	if len(dist) != 2 {
+		t.Fatalf("distribution = %v", doc["distribution"])
+	}
+	first := dist[0].(map[string]any)
+	if first["@type"] != "DataDownload" || first["contentSize"] != "2048 bytes" || first["sha256"] != "abc123" {
+		t.Errorf("distribution[0] = %v", first)
+	}
+
+	parsed, err := f.Parse(bytes.NewReader(buf.Bytes()), nil)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	got := parsed[0].Distributions
+	if len(got) != 2 || got[0].SizeBytes != 2048 || hub.GetDistributionChecksum(got[0], "sha256") != "abc123" {
+		t.Errorf("parsed distributions = %v", got)
+	}
+}
+
+func TestAssociatedMediaRoundTrip(t *testing.T) {
+	record := &hubv1.Record{
+		Title: "Letter",
+		Files: []*hubv1.File{
+			{
+				Path:      "https://example.com/files/letter.pdf",
+				Name:      "letter.pdf",
+				MimeType:  "application/pdf",
+				SizeBytes: 4096,
+				Checksums: []*hubv1.Checksum{hub.NewChecksum("sha256", "def456")},
+			},
+			{Path: "https://example.com/files/letter.jpg", MimeType: "image/jpeg"},
+		},
+	}
+
+	f := &Format{}
+	var buf bytes.Buffer
+	if err := f.Serialize(&buf, []*hubv1.Record{record}, &format.SerializeOptions{}); err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+	parsed, err := f.Parse(bytes.NewReader(buf.Bytes()), nil)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	got := parsed[0].Files
+	if len(got) != 2 || got[0].Path != "https://example.com/files/letter.pdf" || got[0].MimeType != "application/pdf" ||
+		got[0].SizeBytes != 4096 || hub.GetChecksum(got[0], "sha256") != "def456" || got[1].Path != "https://example.com/files/letter.jpg" {
+		t.Errorf("parsed files = %v", got)
+	}
+}
+
+func TestParallelTitlesLanguageMaps(t *testing.T) {
+	record := &hubv1.Record{
+		Title:    "Oral histories of the Lehigh Valley",
+		Language: "en",
+		Titles: []*hubv1.LocalizedString{
+			{Value: "Oral histories of the Lehigh Valley", Language: "en"},
+			{Value: "Historias orales del valle de Lehigh", Language: "es"},
+		},
+		Abstract: "Interviews with residents.",
+	}
+
+	f := &Format{}
+	var buf bytes.Buffer
+	if err := f.Serialize(&buf, []*hubv1.Record{record}, &format.SerializeOptions{}); err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("Invalid JSON output: %v", err)
+	}
+	name, _ := doc["name"].(map[string]any)
+	if name["en"] != "Oral histories of the Lehigh Valley" || name["es"] != "Historias orales del valle de Lehigh" {
+		t.Errorf("name = %v", doc["name"])
+	}
+	if doc["abstract"] != "Interviews with residents." {
+		t.Errorf("abstract in one language should stay a string, got %v", doc["abstract"])
+	}
+	context, _ := doc["@context"].([]any)
+	if len(context) != 2 || context[0] != "https://schema.org" {
+		t.Fatalf("@context = %v", doc["@context"])
+	}
+	if terms, _ := context[1].(map[string]any); terms["name"] == nil || terms["abstract"] != nil {
+		t.Errorf("language container terms = %v", context[1])
+	}
+
+	parsed, err := f.Parse(bytes.NewReader(buf.Bytes()), nil)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	got := parsed[0]
+	if got.Title != record.Title || hub.TitleIn(got, "es") != "Historias orales del valle de Lehigh" {
+		t.Errorf("parsed titles = %q, %v", got.Title, got.Titles)
+	}
+}
+
 func TestSerializeDatasetAbstractMapsToDescription(t *testing.T) {
 	record := &hubv1.Record{
 		Title:    "Dataset With Abstract",
@@ -176,6 +298,151 @@ func TestSerializeDatasetAbstractMapsToDescription(t *testing.T) {
 	}
 }
 
+func TestSerializeMembershipPathChain(t *testing.T) {
+	archives := &hubv1.Record{Title: "University Archives"}
+	hub.SetExtra(archives, "nid", "1")
+	photos := &hubv1.Record{
+		Title: "Photographs",
+		Relations: []*hubv1.Relation{
+			{Type: hubv1.RelationType_RELATION_TYPE_MEMBER_OF, SourceId: "1", TargetTitle: "1"},
+		},
+	}
+	hub.SetExtra(photos, "nid", "2")
+	decade := &hubv1.Record{
+		Title: "1960s",
+		Relations: []*hubv1.Relation{
+			{Type: hubv1.RelationType_RELATION_TYPE_MEMBER_OF, SourceId: "2", TargetTitle: "2"},
+		},
+	}
+	hub.SetExtra(decade, "nid", "3")
+	item := &hubv1.Record{
+		Title: "Homecoming Parade",
+		Relations: []*hubv1.Relation{
+			{Type: hubv1.RelationType_RELATION_TYPE_MEMBER_OF, SourceId: "3", TargetUri: "https://example.com/node/3"},
+		},
+	}
+
+	hub.ComputeMembershipPaths([]*hubv1.Record{archives, photos, decade, item})
+
+	if got := strings.Join(hub.MembershipTitles(item), " > "); got != "University Archives > Photographs > 1960s" {
+		t.Fatalf("membership path = %q", got)
+	}
+	if len(archives.MembershipPath) != 0 {
+		t.Errorf("root collection should have no membership path, got %d", len(archives.MembershipPath))
+	}
+
+	var buf bytes.Buffer
+	if err := (&Format{}).Serialize(&buf, []*hubv1.Record{item}, &format.SerializeOptions{}); err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("Invalid JSON output: %v", err)
+	}
+
+	var names []string
+	node, _ := doc["isPartOf"].(map[string]any)
+	for node != nil {
+		names = append(names, node["name"].(string))
+		node, _ = node["isPartOf"].(map[string]any)
+	}
+	if got := strings.Join(names, " < "); got != "1960s < Photographs < University Archives" {
+		t.Errorf("isPartOf chain = %q", got)
+	}
+}
+
+func TestCopyrightRoundTrip(t *testing.T) {
+	record := &hubv1.Record{
+		Title:              "Campus Map",
+		RightsHolder:       "Lehigh University",
+		CopyrightStatement: "© 2024 Lehigh University. All rights reserved.",
+		Dates: []*hubv1.DateValue{
+			{Type: hubv1.DateType_DATE_TYPE_COPYRIGHT, Year: 2024},
+		},
+		Rights: []*hubv1.Rights{
+			{Uri: "http://rightsstatements.org/vocab/InC/1.0/"},
+		},
+	}
+
+	f := &Format{}
+	var buf bytes.Buffer
+	if err := f.Serialize(&buf, []*hubv1.Record{record}, &format.SerializeOptions{}); err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("Invalid JSON output: %v", err)
+	}
+	holder, _ := doc["copyrightHolder"].(map[string]any)
+	if holder["@type"] != "Organization" || holder["name"] != "Lehigh University" {
+		t.Errorf("copyrightHolder = %v", doc["copyrightHolder"])
+	}
+	if doc["copyrightNotice"] != record.CopyrightStatement {
+		t.Errorf("copyrightNotice = %v", doc["copyrightNotice"])
+	}
+
+	parsed, err := f.Parse(bytes.NewReader(buf.Bytes()), nil)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	got := parsed[0]
+	if got.RightsHolder != "Lehigh University" {
+		t.Errorf("RightsHolder = %q", got.RightsHolder)
+	}
+	if got.CopyrightStatement != record.CopyrightStatement {
+		t.Errorf("CopyrightStatement = %q", got.CopyrightStatement)
+	}
+	if year := hub.CopyrightYear(got); year != 2024 {
+		t.Errorf("CopyrightYear = %d, want 2024", year)
+	}
+	if len(got.Rights) != 1 || got.Rights[0].Uri != "http://rightsstatements.org/vocab/InC/1.0/" {
+		t.Errorf("license should stay separate from copyright, got %v", got.Rights)
+	}
+}
+
+func TestTemporalCoverageRoundTrip(t *testing.T) {
+	record := &hubv1.Record{
+		Title: "Lehigh Valley air quality readings",
+		Dates: []*hubv1.DateValue{
+			{Type: hubv1.DateType_DATE_TYPE_ISSUED, Year: 2021, Month: 3, Precision: hubv1.DatePrecision_DATE_PRECISION_MONTH},
+			{Type: hubv1.DateType_DATE_TYPE_COLLECTED, Year: 2015, EndBound: hubv1.DateBound_DATE_BOUND_OPEN, IsRange: true},
+		},
+	}
+
+	f := &Format{}
+	var buf bytes.Buffer
+	if err := f.Serialize(&buf, []*hubv1.Record{record}, &format.SerializeOptions{}); err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("Invalid JSON output: %v", err)
+	}
+	if doc["temporalCoverage"] != "2015/.." {
+		t.Errorf("temporalCoverage = %v", doc["temporalCoverage"])
+	}
+	if doc["datePublished"] != "2021-03" {
+		t.Errorf("datePublished = %v", doc["datePublished"])
+	}
+
+	parsed, err := f.Parse(bytes.NewReader(buf.Bytes()), nil)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	var interval *hubv1.DateValue
+	for _, d := range parsed[0].Dates {
+		if hub.IsInterval(d) {
+			interval = d
+		}
+	}
+	if interval == nil || interval.Year != 2015 || interval.EndBound != hubv1.DateBound_DATE_BOUND_OPEN {
+		t.Errorf("interval: got %v", parsed[0].Dates)
+	}
+}
+
 func TestSerializeThesis(t *testing.T) {
 	record := &hubv1.Record{
 		Title: "Test Thesis",
@@ -328,6 +595,127 @@ func TestParseScholarlyArticle(t *testing.T) {
 	}
 }
 
+func TestParseGraph(t *testing.T) {
+	// A graph as SEO plugins emit it: the article is the one work no other
+	// node refers to, with its author, page, and site as separate nodes
+	input := `{
+		"@context": "https://schema.org",
+		"@graph": [
+			{"@type": "WebSite", "@id": "https://example.edu/#website", "name": "Example News"},
+			{
+				"@type": "WebPage",
+				"@id": "https://example.edu/story/#webpage",
+				"isPartOf": {"@id": "https://example.edu/#website"}
+			},
+			{
+				"@type": ["NewsArticle", "CreativeWork"],
+				"@id": "https://example.edu/story/#article",
+				"headline": "Graph Story",
+				"author": {"@id": "https://orcid.org/0000-0001-2345-6789"},
+				"publisher": {"@id": "https://example.edu/#org"},
+				"mainEntityOfPage": {"@id": "https://example.edu/story/#webpage"}
+			},
+			{"@type": "Person", "@id": "https://orcid.org/0000-0001-2345-6789", "name": "Ada Reporter"},
+			{"@type": "CollegeOrUniversity", "@id": "https://example.edu/#org", "name": "Example University"}
+		]
+	}`
+
+	records, err := (&Format{}).Parse(strings.NewReader(input), nil)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("Expected 1 record, got %d", len(records))
+	}
+	r := records[0]
+	if r.Title != "Graph Story" {
+		t.Errorf("Title = %q", r.Title)
+	}
+	if got := r.GetResourceType().GetType(); got != hubv1.ResourceTypeValue_RESOURCE_TYPE_NEWSPAPER_ARTICLE {
+		t.Errorf("ResourceType = %v, want NEWSPAPER_ARTICLE", got)
+	}
+	if r.GetResourceType().GetOriginal() != "NewsArticle" {
+		t.Errorf("ResourceType.Original = %q", r.GetResourceType().GetOriginal())
+	}
+	if r.Publisher != "Example University" {
+		t.Errorf("Publisher = %q", r.Publisher)
+	}
+	if len(r.Contributors) != 1 || r.Contributors[0].Name != "Ada Reporter" {
+		t.Fatalf("Contributors = %v", r.Contributors)
+	}
+	if ids := r.Contributors[0].Identifiers; len(ids) != 1 || ids[0].Value != "0000-0001-2345-6789" {
+		t.Errorf("Contributor identifiers = %v, want the ORCID from @id", ids)
+	}
+}
+
+func TestParseDefinedTermsAndPropertyValues(t *testing.T) {
+	input := `[{
+		"@type": "Photograph",
+		"name": "Steel Mill",
+		"genre": [
+			{
+				"@type": "DefinedTerm",
+				"name": "gelatin silver prints",
+				"url": "http://vocab.getty.edu/aat/300128695",
+				"inDefinedTermSet": {"@type": "DefinedTermSet", "url": "http://vocab.getty.edu/aat"}
+			},
+			"https://id.loc.gov/authorities/genreForms/gf2017027249"
+		],
+		"keywords": "steel, industry",
+		"about": [
+			{"@type": "DefinedTerm", "name": "Steel industry and trade", "@id": "http://id.loc.gov/authorities/subjects/sh85127854"}
+		],
+		"identifier": [
+			{"@type": "PropertyValue", "propertyID": "https://registry.identifiers.org/registry/doi", "value": "https://doi.org/10.1234/MILL"},
+			{"@type": "PropertyValue", "name": "PubMed", "value": 12345678},
+			{"@type": "PropertyValue", "propertyID": "local"},
+			"https://hdl.handle.net/1234/5"
+		]
+	}]`
+
+	records, err := (&Format{}).Parse(strings.NewReader(input), nil)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	r := records[0]
+
+	if len(r.Genres) != 2 {
+		t.Fatalf("Genres = %v", r.Genres)
+	}
+	if g := r.Genres[0]; g.Value != "gelatin silver prints" || g.Uri != "http://vocab.getty.edu/aat/300128695" || g.Vocabulary != hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_AAT {
+		t.Errorf("Genres[0] = %v", g)
+	}
+	if g := r.Genres[1]; g.Uri != "https://id.loc.gov/authorities/genreForms/gf2017027249" || g.Vocabulary != hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_GENRE {
+		t.Errorf("Genres[1] = %v", g)
+	}
+
+	var subjects []string
+	for _, s := range r.Subjects {
+		subjects = append(subjects, s.Value+"|"+s.Vocabulary.String())
+	}
+	want := []string{
+		"steel|SUBJECT_VOCABULARY_KEYWORDS",
+		"industry|SUBJECT_VOCABULARY_KEYWORDS",
+		"Steel industry and trade|SUBJECT_VOCABULARY_LCSH",
+	}
+	if strings.Join(subjects, ";") != strings.Join(want, ";") {
+		t.Errorf("Subjects = %v, want %v", subjects, want)
+	}
+
+	var ids []string
+	for _, id := range r.Identifiers {
+		ids = append(ids, id.Type.String()+"="+id.Value)
+	}
+	wantIDs := []string{
+		"IDENTIFIER_TYPE_DOI=10.1234/MILL",
+		"IDENTIFIER_TYPE_PMID=12345678",
+		"IDENTIFIER_TYPE_HANDLE=https://hdl.handle.net/1234/5",
+	}
+	if strings.Join(ids, ";") != strings.Join(wantIDs, ";") {
+		t.Errorf("Identifiers = %v, want %v", ids, wantIDs)
+	}
+}
+
 func TestRoundTrip(t *testing.T) {
 	original := &hubv1.Record{
 		Title:       "Round Trip Test",
@@ -388,6 +776,65 @@ func TestRoundTrip(t *testing.T) {
 	}
 }
 
+func TestParseHTML(t *testing.T) {
+	// An item page with the site and article in separate scripts, the
+	// article in a CDATA section, and a canonical link relative to the page
+	input := `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <title>Embedded Story</title>
+  <link rel="canonical" href="/items/42">
+  <script type="text/javascript">var ld = "<script type=application/ld+json>";</script>
+  <script type="application/ld+json">
+    {"@context": "https://schema.org", "@type": "WebSite", "@id": "https://partner.example.org/#site", "name": "Partner"}
+  </script>
+  <SCRIPT TYPE='application/ld+json; charset=utf-8'>//<![CDATA[
+    {
+      "@context": "https://schema.org",
+      "@type": "ScholarlyArticle",
+      "name": "Embedded Story & More",
+      "author": {"@type": "Person", "name": "Ada Reporter"},
+      "isPartOf": {"@id": "https://partner.example.org/#site"}
+    }
+  //]]></SCRIPT>
+</head>
+<body><p>Story</p></body>
+</html>`
+
+	records, err := (&Format{}).Parse(strings.NewReader(input), &format.ParseOptions{
+		SourceName: "https://partner.example.org/items/42?utm_source=feed",
+	})
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("Expected 1 record, got %d", len(records))
+	}
+	r := records[0]
+	if r.Title != "Embedded Story & More" {
+		t.Errorf("Title = %q", r.Title)
+	}
+	if len(r.Contributors) != 1 || r.Contributors[0].Name != "Ada Reporter" {
+		t.Errorf("Contributors = %v", r.Contributors)
+	}
+	var urls []string
+	for _, id := range r.Identifiers {
+		if id.Type == hubv1.IdentifierType_IDENTIFIER_TYPE_URL {
+			urls = append(urls, id.Value)
+		}
+	}
+	if len(urls) != 1 || urls[0] != "https://partner.example.org/items/42" {
+		t.Errorf("URL identifiers = %v, want the canonical page URL", urls)
+	}
+}
+
+func TestParseHTMLWithoutJSONLD(t *testing.T) {
+	_, err := (&Format{}).Parse(strings.NewReader(`<html><head><title>Nothing</title></head></html>`), nil)
+	if err == nil || !strings.Contains(err.Error(), "no application/ld+json") {
+		t.Errorf("expected missing JSON-LD error, got %v", err)
+	}
+}
+
 func TestCanParse(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -409,6 +856,16 @@ func TestCanParse(t *testing.T) {
 			input:    `{"nid": [{"value": 123}], "uuid": [{"value": "abc"}], "field_title": []}`,
 			expected: false,
 		},
+		{
+			name:     "html with embedded json-ld",
+			input:    `<!DOCTYPE html><html><head><script type="application/ld+json">{"@type": "Book"}</script></head></html>`,
+			expected: true,
+		},
+		{
+			name:     "html without json-ld",
+			input:    `<!DOCTYPE html><html><head><title>Page</title></head></html>`,
+			expected: false,
+		},
 		{
 			name:     "plain json",
 			input:    `{"name": "test", "value": 123}`,
@@ -1,6 +1,7 @@
 package schemaorg
 
 import (
+	"cmp"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -8,6 +9,8 @@ import (
 
 	"github.com/lehigh-university-libraries/crosswalk/format"
 	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/helpers/lang"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
 )
 
 // Serialize writes hub records as schema.org JSON-LD.
@@ -18,7 +21,7 @@ func (f *Format) Serialize(w io.Writer, records []*hubv1.Record, opts *format.Se
 
 	jsonldDocs := make([]any, 0, len(records))
 	for _, record := range records {
-		doc, err := recordToSchemaOrg(record)
+		doc, err := recordToSchemaOrg(record, opts)
 		if err != nil {
 			return fmt.Errorf("converting record: %w", err)
 		}
@@ -38,46 +41,46 @@ func (f *Format) Serialize(w io.Writer, records []*hubv1.Record, opts *format.Se
 }
 
 // recordToSchemaOrg converts a hub record to the appropriate schema.org type.
-func recordToSchemaOrg(record *hubv1.Record) (any, error) {
+func recordToSchemaOrg(record *hubv1.Record, opts *format.SerializeOptions) (any, error) {
 	schemaType := determineSchemaType(record)
 
 	switch schemaType {
 	case TypeScholarlyArticle:
-		return recordToScholarlyArticle(record), nil
+		return recordToScholarlyArticle(record, opts), nil
 	case TypeBook:
-		return recordToBook(record), nil
+		return recordToBook(record, opts), nil
 	case TypeDataset:
-		return recordToDataset(record), nil
+		return recordToDataset(record, opts), nil
 	case TypeCollection:
-		return recordToCollection(record), nil
+		return recordToCollection(record, opts), nil
 	case TypeThesis:
-		return recordToThesis(record), nil
+		return recordToThesis(record, opts), nil
 	case TypeReport:
-		return recordToCreativeWork(record, schemaType), nil
+		return recordToCreativeWork(record, schemaType, opts), nil
 	case TypePeriodical:
-		return recordToCreativeWork(record, schemaType), nil
+		return recordToCreativeWork(record, schemaType, opts), nil
 	case TypeMap:
-		return recordToCreativeWork(record, schemaType), nil
+		return recordToCreativeWork(record, schemaType, opts), nil
 	case TypePoster:
-		return recordToCreativeWork(record, schemaType), nil
+		return recordToCreativeWork(record, schemaType, opts), nil
 	case TypePresentationDoc:
-		return recordToCreativeWork(record, schemaType), nil
+		return recordToCreativeWork(record, schemaType, opts), nil
 	case TypeDigitalDocument:
-		return recordToDigitalDocument(record), nil
+		return recordToDigitalDocument(record, opts), nil
 	case TypeManuscript:
-		return recordToManuscript(record), nil
+		return recordToManuscript(record, opts), nil
 	case TypeAudioObject:
-		return recordToAudioObject(record), nil
+		return recordToAudioObject(record, opts), nil
 	case TypeImageObject:
-		return recordToImageObject(record), nil
+		return recordToImageObject(record, opts), nil
 	case TypeVideoObject:
-		return recordToVideoObject(record), nil
+		return recordToVideoObject(record, opts), nil
 	case TypePublicationIssue:
-		return recordToPublicationIssue(record), nil
+		return recordToPublicationIssue(record, opts), nil
 	case TypePublicationVolume:
-		return recordToPublicationVolume(record), nil
+		return recordToPublicationVolume(record, opts), nil
 	default:
-		return recordToCreativeWork(record, schemaType), nil
+		return recordToCreativeWork(record, schemaType, opts), nil
 	}
 }
 
@@ -143,7 +146,7 @@ func determineSchemaType(record *hubv1.Record) SchemaType {
 }
 
 // buildCreativeWorkBase creates the common CreativeWork fields.
-func buildCreativeWorkBase(record *hubv1.Record, schemaType SchemaType) CreativeWork {
+func buildCreativeWorkBase(record *hubv1.Record, schemaType SchemaType, opts *format.SerializeOptions) CreativeWork {
 	cw := CreativeWork{
 		Thing: Thing{
 			Context: "https://schema.org",
@@ -151,8 +154,14 @@ func buildCreativeWorkBase(record *hubv1.Record, schemaType SchemaType) Creative
 		},
 	}
 
-	// Title
-	if record.Title != "" {
+	// Title and abstract. Values given in several languages are written
+	// as language maps, which need their terms declared in the context.
+	var mapped []string
+	if titles := hub.Titles(record); hub.IsMultilingual(titles) {
+		cw.Name = languageMap(titles)
+		cw.Headline = cw.Name
+		mapped = append(mapped, "name", "headline")
+	} else if record.Title != "" {
 		cw.Name = record.Title
 		cw.Headline = record.Title
 	}
@@ -161,9 +170,15 @@ func buildCreativeWorkBase(record *hubv1.Record, schemaType SchemaType) Creative
 	}
 
 	// Descriptions
-	if record.Abstract != "" {
+	if abstracts := hub.Abstracts(record); hub.IsMultilingual(abstracts) {
+		cw.Abstract = languageMap(abstracts)
+		mapped = append(mapped, "abstract")
+	} else if record.Abstract != "" {
 		cw.Abstract = record.Abstract
 	}
+	if len(mapped) > 0 {
+		cw.Context = languageContext(mapped)
+	}
 	if record.Description != "" {
 		cw.Description = record.Description
 	}
@@ -182,9 +197,18 @@ func buildCreativeWorkBase(record *hubv1.Record, schemaType SchemaType) Creative
 		}
 	}
 
-	// Dates
+	// Dates; the first range is the temporal coverage, and the start of
+	// each range its date
 	for _, d := range record.Dates {
-		dateStr := formatDate(d)
+		if hub.IsInterval(d) {
+			if cw.TemporalCoverage == "" {
+				cw.TemporalCoverage = format.FormatInterval(d, opts.Dates, "..")
+			}
+			if d.Year == 0 {
+				continue
+			}
+		}
+		dateStr := format.FormatDate(d, opts.Dates)
 		if dateStr == "" {
 			continue
 		}
@@ -202,7 +226,7 @@ func buildCreativeWorkBase(record *hubv1.Record, schemaType SchemaType) Creative
 
 	// Language
 	if record.Language != "" {
-		cw.InLanguage = record.Language
+		cw.InLanguage = lang.Normalize(record.Language, cmp.Or(opts.Languages, lang.ISO6391))
 	}
 
 	// Publisher
@@ -250,6 +274,12 @@ func buildCreativeWorkBase(record *hubv1.Record, schemaType SchemaType) Creative
 		}
 	}
 
+	// Copyright
+	if holder := hub.RightsHolder(record); holder != "" {
+		cw.CopyrightHolder = copyrightHolder(record, holder)
+	}
+	cw.CopyrightNotice = record.CopyrightStatement
+
 	// Identifiers
 	ids := buildIdentifiers(record.Identifiers)
 	if len(ids) > 0 {
@@ -277,10 +307,93 @@ func buildCreativeWorkBase(record *hubv1.Record, schemaType SchemaType) Creative
 			cw.IsPartOf = relationToCreativeWork(rel)
 		}
 	}
+	if len(record.MembershipPath) > 0 {
+		cw.IsPartOf = membershipChain(record.MembershipPath)
+	}
+
+	// Files
+	if len(record.Files) > 0 {
+		media := make([]*MediaObject, 0, len(record.Files))
+		for _, f := range record.Files {
+			media = append(media, fileToMediaObject(f))
+		}
+		if len(media) == 1 {
+			cw.AssociatedMedia = media[0]
+		} else {
+			cw.AssociatedMedia = media
+		}
+	}
 
 	return cw
 }
 
+// copyrightHolder builds a Person or Organization for the rights holder,
+// taking the type from a matching contributor and defaulting to Organization.
+func copyrightHolder(record *hubv1.Record, name string) any {
+	for _, c := range record.Contributors {
+		if c.Name == name && c.Type == hubv1.ContributorType_CONTRIBUTOR_TYPE_PERSON {
+			return &Person{Thing: Thing{Type: TypePerson, Name: name}}
+		}
+	}
+	return &Organization{Thing: Thing{Type: TypeOrganization, Name: name}}
+}
+
+// languageMap builds a JSON-LD language map from language-tagged values.
+// Untagged values go under @none, and a language given twice maps to an
+// array.
+func languageMap(values []*hubv1.LocalizedString) map[string]any {
+	m := make(map[string]any, len(values))
+	for _, v := range values {
+		lang := v.Language
+		if lang == "" {
+			lang = "@none"
+		}
+		switch existing := m[lang].(type) {
+		case nil:
+			m[lang] = v.Value
+		case string:
+			m[lang] = []string{existing, v.Value}
+		case []string:
+			m[lang] = append(existing, v.Value)
+		}
+	}
+	return m
+}
+
+// languageContext returns the schema.org context with the given terms
+// redefined as language containers, so their language maps are read as
+// language-tagged strings.
+func languageContext(terms []string) []any {
+	defs := make(map[string]any, len(terms))
+	for _, term := range terms {
+		defs[term] = map[string]string{"@id": "schema:" + term, "@container": "@language"}
+	}
+	return []any{"https://schema.org", defs}
+}
+
+// fileToMediaObject converts a hub file to a schema.org MediaObject,
+// carrying its size and SHA-256 fixity value when known.
+func fileToMediaObject(f *hubv1.File) *MediaObject {
+	mo := &MediaObject{
+		CreativeWork: CreativeWork{
+			Thing: Thing{
+				Type:        TypeMediaObject,
+				Description: f.Description,
+			},
+		},
+		ContentURL:     f.Path,
+		EncodingFormat: f.MimeType,
+		Sha256:         hub.GetChecksum(f, "sha256"),
+	}
+	if f.Name != "" {
+		mo.Name = f.Name
+	}
+	if f.SizeBytes > 0 {
+		mo.ContentSize = fmt.Sprintf("%d bytes", f.SizeBytes)
+	}
+	return mo
+}
+
 func schemaTypeWithGoogleFallback(schemaType SchemaType) any {
 	// Keep a broadly supported fallback type for schema.org classes that are
 	// commonly not consumed by Google rich results.
@@ -314,11 +427,9 @@ func categorizeContributors(contribs []*hubv1.Contributor) (authors, editors, ot
 // contributorToPersonOrOrg converts a hub contributor to Person or Organization.
 func contributorToPersonOrOrg(c *hubv1.Contributor) any {
 	if c.Type == hubv1.ContributorType_CONTRIBUTOR_TYPE_ORGANIZATION {
-		org := &Organization{
-			Thing: Thing{
-				Type: TypeOrganization,
-				Name: c.Name,
-			},
+		org := &Organization{Thing: Thing{Type: TypeOrganization}}
+		if c.Name != "" {
+			org.Name = c.Name
 		}
 		// Add ISNI/other identifiers
 		for _, id := range c.Identifiers {
@@ -329,11 +440,9 @@ func contributorToPersonOrOrg(c *hubv1.Contributor) any {
 		return org
 	}
 
-	person := &Person{
-		Thing: Thing{
-			Type: TypePerson,
-			Name: c.Name,
-		},
+	person := &Person{Thing: Thing{Type: TypePerson}}
+	if c.Name != "" {
+		person.Name = c.Name
 	}
 
 	// Use parsed name if available
@@ -380,22 +489,6 @@ func contributorToPersonOrOrg(c *hubv1.Contributor) any {
 	return person
 }
 
-// formatDate formats a hub DateValue to ISO 8601 string.
-func formatDate(d *hubv1.DateValue) string {
-	if d.Year == 0 {
-		return d.Raw
-	}
-
-	// Build ISO 8601 date based on precision
-	if d.Month == 0 {
-		return fmt.Sprintf("%04d", d.Year)
-	}
-	if d.Day == 0 {
-		return fmt.Sprintf("%04d-%02d", d.Year, d.Month)
-	}
-	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
-}
-
 // extractKeywords extracts keywords from subjects.
 func extractKeywords(subjects []*hubv1.Subject) []string {
 	var keywords []string
@@ -512,6 +605,24 @@ func relationToCreativeWork(rel *hubv1.Relation) any {
 	return result
 }
 
+// membershipChain nests the membership path (root first) into an isPartOf
+// chain, so the immediate parent is outermost and each level points at its
+// own parent: 1960s -> Photographs -> University Archives.
+func membershipChain(path []*hubv1.Relation) any {
+	var chain any
+	for _, seg := range path {
+		node := relationToCreativeWork(seg)
+		if node == nil {
+			continue
+		}
+		if m, ok := node.(map[string]any); ok && chain != nil {
+			m["isPartOf"] = chain
+		}
+		chain = node
+	}
+	return chain
+}
+
 // extractURLPath extracts the path from a URL.
 func extractURLPath(uri string) string {
 	// Find the path after the host
@@ -610,6 +721,15 @@ func buildIdentifiers(ids []*hubv1.Identifier) []PropertyValue {
 		case hubv1.IdentifierType_IDENTIFIER_TYPE_ARXIV:
 			pv.PropertyID = "arxiv"
 			pv.Name = "arXiv ID"
+		case hubv1.IdentifierType_IDENTIFIER_TYPE_ARK:
+			pv.PropertyID = "ark"
+			pv.Name = "ARK"
+		case hubv1.IdentifierType_IDENTIFIER_TYPE_URN:
+			pv.PropertyID = "urn"
+			pv.Name = "URN"
+		case hubv1.IdentifierType_IDENTIFIER_TYPE_PURL:
+			pv.PropertyID = "purl"
+			pv.Name = "PURL"
 		case hubv1.IdentifierType_IDENTIFIER_TYPE_LOCAL:
 			pv.PropertyID = "local"
 			pv.Name = "Local ID"
@@ -623,8 +743,8 @@ func buildIdentifiers(ids []*hubv1.Identifier) []PropertyValue {
 
 // Type-specific converters
 
-func recordToScholarlyArticle(record *hubv1.Record) *ScholarlyArticle {
-	base := buildCreativeWorkBase(record, TypeScholarlyArticle)
+func recordToScholarlyArticle(record *hubv1.Record, opts *format.SerializeOptions) *ScholarlyArticle {
+	base := buildCreativeWorkBase(record, TypeScholarlyArticle, opts)
 	article := &ScholarlyArticle{
 		CreativeWork: base,
 	}
@@ -637,8 +757,8 @@ func recordToScholarlyArticle(record *hubv1.Record) *ScholarlyArticle {
 	return article
 }
 
-func recordToBook(record *hubv1.Record) *Book {
-	base := buildCreativeWorkBase(record, TypeBook)
+func recordToBook(record *hubv1.Record, opts *format.SerializeOptions) *Book {
+	base := buildCreativeWorkBase(record, TypeBook, opts)
 	book := &Book{
 		CreativeWork: base,
 	}
@@ -654,29 +774,65 @@ func recordToBook(record *hubv1.Record) *Book {
 	return book
 }
 
-func recordToDataset(record *hubv1.Record) *Dataset {
-	base := buildCreativeWorkBase(record, TypeDataset)
-	// For Dataset, consumers expect summary text in description.
-	if base.Abstract != "" {
+func recordToDataset(record *hubv1.Record, opts *format.SerializeOptions) *Dataset {
+	base := buildCreativeWorkBase(record, TypeDataset, opts)
+	// For Dataset, consumers expect summary text in description. An
+	// abstract given in several languages also stays as a language map.
+	if base.Abstract != nil {
 		if base.Description == "" {
-			base.Description = base.Abstract
+			base.Description = record.Abstract
+		}
+		if _, ok := base.Abstract.(string); ok {
+			base.Abstract = nil
 		}
-		base.Abstract = ""
 	}
-	return &Dataset{
+	ds := &Dataset{
 		CreativeWork: base,
 	}
+	if dists := hub.Distributions(record); len(dists) > 0 {
+		downloads := make([]*DataDownload, 0, len(dists))
+		for _, d := range dists {
+			downloads = append(downloads, distributionToDataDownload(d))
+		}
+		ds.Distribution = downloads
+	}
+	return ds
+}
+
+// distributionToDataDownload converts a hub distribution to a schema.org
+// DataDownload.
+func distributionToDataDownload(d *hubv1.Distribution) *DataDownload {
+	dd := &DataDownload{
+		MediaObject: MediaObject{
+			CreativeWork: CreativeWork{
+				Thing: Thing{
+					Type:        TypeDataDownload,
+					Description: d.Description,
+				},
+			},
+			ContentURL:     d.Url,
+			EncodingFormat: d.Format,
+			Sha256:         hub.GetDistributionChecksum(d, "sha256"),
+		},
+	}
+	if d.Name != "" {
+		dd.Name = d.Name
+	}
+	if d.SizeBytes > 0 {
+		dd.ContentSize = fmt.Sprintf("%d bytes", d.SizeBytes)
+	}
+	return dd
 }
 
-func recordToCollection(record *hubv1.Record) *Collection {
-	base := buildCreativeWorkBase(record, TypeCollection)
+func recordToCollection(record *hubv1.Record, opts *format.SerializeOptions) *Collection {
+	base := buildCreativeWorkBase(record, TypeCollection, opts)
 	return &Collection{
 		CreativeWork: base,
 	}
 }
 
-func recordToThesis(record *hubv1.Record) *CreativeWork {
-	base := buildCreativeWorkBase(record, TypeThesis)
+func recordToThesis(record *hubv1.Record, opts *format.SerializeOptions) *CreativeWork {
+	base := buildCreativeWorkBase(record, TypeThesis, opts)
 
 	// Preserve thesis/dissertation-specific enrichment that was previously
 	// done in DigitalDocument output.
@@ -707,8 +863,8 @@ func recordToThesis(record *hubv1.Record) *CreativeWork {
 	return &base
 }
 
-func recordToDigitalDocument(record *hubv1.Record) *DigitalDocument {
-	base := buildCreativeWorkBase(record, TypeDigitalDocument)
+func recordToDigitalDocument(record *hubv1.Record, opts *format.SerializeOptions) *DigitalDocument {
+	base := buildCreativeWorkBase(record, TypeDigitalDocument, opts)
 	doc := &DigitalDocument{
 		CreativeWork: base,
 	}
@@ -738,15 +894,15 @@ func recordToDigitalDocument(record *hubv1.Record) *DigitalDocument {
 	return doc
 }
 
-func recordToManuscript(record *hubv1.Record) *Manuscript {
-	base := buildCreativeWorkBase(record, TypeManuscript)
+func recordToManuscript(record *hubv1.Record, opts *format.SerializeOptions) *Manuscript {
+	base := buildCreativeWorkBase(record, TypeManuscript, opts)
 	return &Manuscript{
 		CreativeWork: base,
 	}
 }
 
-func recordToAudioObject(record *hubv1.Record) *AudioObject {
-	base := buildCreativeWorkBase(record, TypeAudioObject)
+func recordToAudioObject(record *hubv1.Record, opts *format.SerializeOptions) *AudioObject {
+	base := buildCreativeWorkBase(record, TypeAudioObject, opts)
 	audio := &AudioObject{
 		MediaObject: MediaObject{
 			CreativeWork: base,
@@ -764,8 +920,8 @@ func recordToAudioObject(record *hubv1.Record) *AudioObject {
 	return audio
 }
 
-func recordToImageObject(record *hubv1.Record) *ImageObject {
-	base := buildCreativeWorkBase(record, TypeImageObject)
+func recordToImageObject(record *hubv1.Record, opts *format.SerializeOptions) *ImageObject {
+	base := buildCreativeWorkBase(record, TypeImageObject, opts)
 	image := &ImageObject{
 		MediaObject: MediaObject{
 			CreativeWork: base,
@@ -783,8 +939,8 @@ func recordToImageObject(record *hubv1.Record) *ImageObject {
 	return image
 }
 
-func recordToVideoObject(record *hubv1.Record) *VideoObject {
-	base := buildCreativeWorkBase(record, TypeVideoObject)
+func recordToVideoObject(record *hubv1.Record, opts *format.SerializeOptions) *VideoObject {
+	base := buildCreativeWorkBase(record, TypeVideoObject, opts)
 	video := &VideoObject{
 		MediaObject: MediaObject{
 			CreativeWork: base,
@@ -802,21 +958,21 @@ func recordToVideoObject(record *hubv1.Record) *VideoObject {
 	return video
 }
 
-func recordToPublicationIssue(record *hubv1.Record) *PublicationIssue {
-	base := buildCreativeWorkBase(record, TypePublicationIssue)
+func recordToPublicationIssue(record *hubv1.Record, opts *format.SerializeOptions) *PublicationIssue {
+	base := buildCreativeWorkBase(record, TypePublicationIssue, opts)
 	return &PublicationIssue{
 		CreativeWork: base,
 	}
 }
 
-func recordToPublicationVolume(record *hubv1.Record) *PublicationVolume {
-	base := buildCreativeWorkBase(record, TypePublicationVolume)
+func recordToPublicationVolume(record *hubv1.Record, opts *format.SerializeOptions) *PublicationVolume {
+	base := buildCreativeWorkBase(record, TypePublicationVolume, opts)
 	return &PublicationVolume{
 		CreativeWork: base,
 	}
 }
 
-func recordToCreativeWork(record *hubv1.Record, schemaType SchemaType) *CreativeWork {
-	base := buildCreativeWorkBase(record, schemaType)
+func recordToCreativeWork(record *hubv1.Record, schemaType SchemaType, opts *format.SerializeOptions) *CreativeWork {
+	base := buildCreativeWorkBase(record, schemaType, opts)
 	return &base
 }
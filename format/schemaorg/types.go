@@ -19,6 +19,8 @@ const (
 	TypeAudioObject       SchemaType = "AudioObject"
 	TypeImageObject       SchemaType = "ImageObject"
 	TypeVideoObject       SchemaType = "VideoObject"
+	TypeMediaObject       SchemaType = "MediaObject"
+	TypeDataDownload      SchemaType = "DataDownload"
 	TypePublicationIssue  SchemaType = "PublicationIssue"
 	TypePublicationVolume SchemaType = "PublicationVolume"
 	TypePerson            SchemaType = "Person"
@@ -31,7 +33,7 @@ type Thing struct {
 	Context     any    `json:"@context,omitempty"`
 	Type        any    `json:"@type"`
 	ID          string `json:"@id,omitempty"`
-	Name        string `json:"name,omitempty"`
+	Name        any    `json:"name,omitempty"` // string or language map
 	Description string `json:"description,omitempty"`
 	URL         string `json:"url,omitempty"`
 	Identifier  any    `json:"identifier,omitempty"` // string, []string, or []PropertyValue
@@ -43,9 +45,9 @@ type CreativeWork struct {
 	Thing
 
 	// Core properties
-	Headline         string `json:"headline,omitempty"`
+	Headline         any    `json:"headline,omitempty"` // string or language map
 	AlternativeTitle string `json:"alternativeHeadline,omitempty"`
-	Abstract         string `json:"abstract,omitempty"`
+	Abstract         any    `json:"abstract,omitempty"` // string or language map
 
 	// Authorship
 	Author      any `json:"author,omitempty"`      // Person, Organization, or array
@@ -85,6 +87,9 @@ type CreativeWork struct {
 	ContentSize    string `json:"contentSize,omitempty"`
 	ContentURL     string `json:"contentUrl,omitempty"`
 
+	// Files
+	AssociatedMedia any `json:"associatedMedia,omitempty"` // MediaObject or array
+
 	// Location
 	LocationCreated  any    `json:"locationCreated,omitempty"` // Place
 	SpatialCoverage  any    `json:"spatialCoverage,omitempty"` // Place or Text
@@ -172,6 +177,12 @@ type MediaObject struct {
 	PlayerType        string `json:"playerType,omitempty"`
 	ProductionCompany any    `json:"productionCompany,omitempty"`
 	UploadDate        string `json:"uploadDate,omitempty"`
+	Sha256            string `json:"sha256,omitempty"`
+}
+
+// DataDownload represents one downloadable distribution of a dataset.
+type DataDownload struct {
+	MediaObject
 }
 
 // AudioObject represents audio content.
@@ -0,0 +1,69 @@
+// Package scholix provides a serializer for Scholix link packages, the
+// JSON exchange format used to report data-literature links to aggregators
+// such as Scholexplorer.
+package scholix
+
+import (
+	"bytes"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+)
+
+// Version is the Scholix schema version this implementation targets.
+const Version = "4.0"
+
+// Format implements the Scholix link package format.
+type Format struct{}
+
+// Ensure Format implements the interfaces
+var (
+	_ format.Format         = (*Format)(nil)
+	_ format.Serializer     = (*Format)(nil)
+	_ format.FieldDescriber = (*Format)(nil)
+)
+
+// Name returns the format identifier.
+func (f *Format) Name() string {
+	return "scholix"
+}
+
+// Description returns a human-readable format description.
+func (f *Format) Description() string {
+	return "Scholix link packages (v" + Version + ")"
+}
+
+// Extensions returns file extensions associated with this format.
+func (f *Format) Extensions() []string {
+	return []string{"scholix"}
+}
+
+// writtenFields are the hub record fields Serialize writes.
+var writtenFields = []string{
+	"title", "contributors", "dates", "resource_type", "publisher", "rights",
+	"identifiers", "relations.references", "relations.cites",
+	"relations.is_cited_by", "relations.supplements",
+	"relations.is_supplement_to", "relations.supplemented_by",
+	"relations.derived_from", "relations.source_of", "relations.documents",
+	"relations.is_documented_by", "relations.describes",
+	"relations.is_described_by", "relations.requires",
+	"relations.required_by", "relations.reviews", "relations.related_to",
+}
+
+// WrittenFields returns the hub record fields Serialize writes.
+func (f *Format) WrittenFields(opts *format.SerializeOptions) []string {
+	return writtenFields
+}
+
+// CanParse returns true if the input looks like Scholix JSON.
+func (f *Format) CanParse(peek []byte) bool {
+	peek = bytes.TrimSpace(peek)
+	if len(peek) == 0 || (peek[0] != '[' && peek[0] != '{') {
+		return false
+	}
+	return bytes.Contains(peek, []byte(`"RelationshipType"`)) &&
+		bytes.Contains(peek, []byte(`"LinkProvider"`))
+}
+
+func init() {
+	format.Register(&Format{})
+}
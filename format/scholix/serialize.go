@@ -0,0 +1,298 @@
+package scholix
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+// defaultProvider names the link provider when SerializeOptions.Provider is unset.
+const defaultProvider = "Crosswalk"
+
+// relationshipTypes maps hub relation types to Scholix relationship types.
+// The SubType keeps the original DataCite relation so aggregators don't
+// lose precision when several hub relations collapse into IsRelatedTo.
+// Hierarchical and version relations are not data-literature links.
+var relationshipTypes = map[hubv1.RelationType]RelationshipType{
+	hubv1.RelationType_RELATION_TYPE_REFERENCES:       {Name: "References", SubType: "References"},
+	hubv1.RelationType_RELATION_TYPE_CITES:            {Name: "References", SubType: "Cites"},
+	hubv1.RelationType_RELATION_TYPE_IS_CITED_BY:      {Name: "IsReferencedBy", SubType: "IsCitedBy"},
+	hubv1.RelationType_RELATION_TYPE_SUPPLEMENTS:      {Name: "IsSupplementTo", SubType: "IsSupplementTo"},
+	hubv1.RelationType_RELATION_TYPE_IS_SUPPLEMENT_TO: {Name: "IsSupplementTo", SubType: "IsSupplementTo"},
+	hubv1.RelationType_RELATION_TYPE_SUPPLEMENTED_BY:  {Name: "IsSupplementedBy", SubType: "IsSupplementedBy"},
+	hubv1.RelationType_RELATION_TYPE_DERIVED_FROM:     {Name: "IsRelatedTo", SubType: "IsDerivedFrom"},
+	hubv1.RelationType_RELATION_TYPE_SOURCE_OF:        {Name: "IsRelatedTo", SubType: "IsSourceOf"},
+	hubv1.RelationType_RELATION_TYPE_DOCUMENTS:        {Name: "IsRelatedTo", SubType: "Documents"},
+	hubv1.RelationType_RELATION_TYPE_IS_DOCUMENTED_BY: {Name: "IsRelatedTo", SubType: "IsDocumentedBy"},
+	hubv1.RelationType_RELATION_TYPE_DESCRIBES:        {Name: "IsRelatedTo", SubType: "Describes"},
+	hubv1.RelationType_RELATION_TYPE_IS_DESCRIBED_BY:  {Name: "IsRelatedTo", SubType: "IsDescribedBy"},
+	hubv1.RelationType_RELATION_TYPE_REQUIRES:         {Name: "IsRelatedTo", SubType: "Requires"},
+	hubv1.RelationType_RELATION_TYPE_REQUIRED_BY:      {Name: "IsRelatedTo", SubType: "IsRequiredBy"},
+	hubv1.RelationType_RELATION_TYPE_REVIEWS:          {Name: "IsRelatedTo", SubType: "Reviews"},
+	hubv1.RelationType_RELATION_TYPE_RELATED_TO:       {Name: "IsRelatedTo"},
+}
+
+// Serialize writes the data-literature links found in the batch as a JSON
+// array of Scholix link packages. A link is emitted for each supported
+// relation where at least one side is a dataset and both sides carry a
+// persistent identifier. Targets that are also records in the batch are
+// described in full; others are described from the relation alone.
+func (f *Format) Serialize(w io.Writer, records []*hubv1.Record, opts *format.SerializeOptions) error {
+	if opts == nil {
+		opts = format.NewSerializeOptions()
+	}
+
+	provider := opts.Provider
+	if provider == "" {
+		provider = defaultProvider
+	}
+	linkDate := time.Now().UTC().Format("2006-01-02")
+
+	// Index records by identifier so targets in the batch resolve fully
+	index := make(map[string]*hubv1.Record)
+	for _, r := range records {
+		for _, id := range r.Identifiers {
+			if _, ok := idScheme(id.Type); ok {
+				index[identifierKey(id)] = r
+			}
+		}
+	}
+
+	links := make([]Link, 0)
+	seen := make(map[string]bool)
+
+	for _, record := range records {
+		source := recordToObject(record)
+
+		for _, rel := range record.Relations {
+			relType, ok := relationshipTypes[rel.Type]
+			if !ok {
+				continue
+			}
+			if len(source.Identifier) == 0 {
+				slog.Warn("skipping Scholix link: source has no persistent identifier",
+					"title", record.Title, "relation", rel.Type.String())
+				continue
+			}
+
+			target := relationToObject(rel, index)
+			if target == nil {
+				slog.Warn("skipping Scholix link: target has no persistent identifier",
+					"title", record.Title, "target", rel.TargetTitle)
+				continue
+			}
+
+			// Scholix covers links involving research data
+			if source.Type.Name != TypeDataset && target.Type.Name != TypeDataset {
+				continue
+			}
+
+			key := source.Identifier[0].ID + "|" + relType.Name + "|" + relType.SubType + "|" + target.Identifier[0].ID
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			if relType.SubType != "" {
+				relType.SubTypeSchema = "DataCite"
+			}
+
+			links = append(links, Link{
+				LinkPublicationDate: linkDate,
+				LinkProvider:        []Organization{{Name: provider}},
+				RelationshipType:    relType,
+				LicenseURL:          licenseURL(record),
+				Source:              source,
+				Target:              target,
+			})
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	if opts.Pretty {
+		encoder.SetIndent("", "  ")
+	}
+	return encoder.Encode(links)
+}
+
+// recordToObject describes a hub record as a Scholix object.
+func recordToObject(record *hubv1.Record) *ScholarlyObject {
+	obj := &ScholarlyObject{
+		Identifier: recordIdentifiers(record),
+		Type:       ObjectType{Name: objectType(record.GetResourceType().GetType())},
+		Title:      record.Title,
+	}
+
+	for _, c := range record.Contributors {
+		if !isCreator(c) || c.Name == "" {
+			continue
+		}
+		creator := Creator{Name: c.Name}
+		for _, id := range c.Identifiers {
+			if id.Type == hubv1.IdentifierType_IDENTIFIER_TYPE_ORCID {
+				creator.Identifier = append(creator.Identifier, Identifier{
+					ID:       id.Value,
+					IDScheme: "orcid",
+					IDURL:    hub.IdentifierURI(id),
+				})
+			}
+		}
+		obj.Creator = append(obj.Creator, creator)
+	}
+
+	if d := hub.PrimaryDate(record); d != nil {
+		obj.PublicationDate = hub.FormatDate(d)
+	}
+	if record.Publisher != "" {
+		obj.Publisher = []Organization{{Name: record.Publisher}}
+	}
+
+	return obj
+}
+
+// relationToObject describes a relation target, preferring the full record
+// when the target is part of the batch. Returns nil when the target has no
+// persistent identifier.
+func relationToObject(rel *hubv1.Relation, index map[string]*hubv1.Record) *ScholarlyObject {
+	var id *hubv1.Identifier
+	switch {
+	case rel.TargetId != "":
+		id = hub.NewIdentifier(rel.TargetId, rel.TargetIdType)
+	case rel.TargetUri != "":
+		id = hub.NewIdentifier(rel.TargetUri, hubv1.IdentifierType_IDENTIFIER_TYPE_UNSPECIFIED)
+	default:
+		return nil
+	}
+
+	if r, ok := index[identifierKey(id)]; ok {
+		return recordToObject(r)
+	}
+
+	sid, ok := toIdentifier(id)
+	if !ok {
+		// Local IDs such as node IDs only make sense with a URI
+		if rel.TargetUri == "" {
+			return nil
+		}
+		sid = Identifier{ID: rel.TargetUri, IDScheme: "url", IDURL: rel.TargetUri}
+	}
+
+	return &ScholarlyObject{
+		Identifier: []Identifier{sid},
+		Type:       ObjectType{Name: objectType(rel.TargetResourceType)},
+		Title:      rel.TargetTitle,
+	}
+}
+
+// recordIdentifiers returns the record's persistent identifiers, DOI first.
+func recordIdentifiers(record *hubv1.Record) []Identifier {
+	var ids []Identifier
+	for _, id := range record.Identifiers {
+		sid, ok := toIdentifier(id)
+		if !ok {
+			continue
+		}
+		if id.Type == hubv1.IdentifierType_IDENTIFIER_TYPE_DOI {
+			ids = append([]Identifier{sid}, ids...)
+		} else {
+			ids = append(ids, sid)
+		}
+	}
+	return ids
+}
+
+func toIdentifier(id *hubv1.Identifier) (Identifier, bool) {
+	scheme, ok := idScheme(id.Type)
+	if !ok || id.Value == "" {
+		return Identifier{}, false
+	}
+	return Identifier{
+		ID:       id.Value,
+		IDScheme: scheme,
+		IDURL:    hub.IdentifierURI(id),
+	}, true
+}
+
+// idScheme maps hub identifier types to Scholix identifier schemes.
+// Only globally resolvable identifiers are usable in a link.
+func idScheme(t hubv1.IdentifierType) (string, bool) {
+	switch t {
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_DOI:
+		return "doi", true
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_HANDLE:
+		return "handle", true
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_PMID:
+		return "pmid", true
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_PMCID:
+		return "pmc", true
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_ARXIV:
+		return "arXiv", true
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_URL:
+		return "url", true
+	default:
+		return "", false
+	}
+}
+
+func identifierKey(id *hubv1.Identifier) string {
+	return id.Type.String() + ":" + strings.ToLower(id.Value)
+}
+
+// objectType maps hub resource types to Scholix object types.
+func objectType(t hubv1.ResourceTypeValue) string {
+	switch t {
+	case hubv1.ResourceTypeValue_RESOURCE_TYPE_DATASET:
+		return TypeDataset
+	case hubv1.ResourceTypeValue_RESOURCE_TYPE_SOFTWARE:
+		return TypeSoftware
+	case hubv1.ResourceTypeValue_RESOURCE_TYPE_ARTICLE,
+		hubv1.ResourceTypeValue_RESOURCE_TYPE_BOOK,
+		hubv1.ResourceTypeValue_RESOURCE_TYPE_BOOK_CHAPTER,
+		hubv1.ResourceTypeValue_RESOURCE_TYPE_CONFERENCE_PAPER,
+		hubv1.ResourceTypeValue_RESOURCE_TYPE_CONFERENCE_PROCEEDING,
+		hubv1.ResourceTypeValue_RESOURCE_TYPE_DISSERTATION,
+		hubv1.ResourceTypeValue_RESOURCE_TYPE_THESIS,
+		hubv1.ResourceTypeValue_RESOURCE_TYPE_REPORT,
+		hubv1.ResourceTypeValue_RESOURCE_TYPE_TECHNICAL_REPORT,
+		hubv1.ResourceTypeValue_RESOURCE_TYPE_WORKING_PAPER,
+		hubv1.ResourceTypeValue_RESOURCE_TYPE_PREPRINT,
+		hubv1.ResourceTypeValue_RESOURCE_TYPE_JOURNAL,
+		hubv1.ResourceTypeValue_RESOURCE_TYPE_PERIODICAL,
+		hubv1.ResourceTypeValue_RESOURCE_TYPE_NEWSPAPER_ARTICLE,
+		hubv1.ResourceTypeValue_RESOURCE_TYPE_PEER_REVIEW,
+		hubv1.ResourceTypeValue_RESOURCE_TYPE_TEXT:
+		return TypePublication
+	default:
+		return TypeOther
+	}
+}
+
+// isCreator reports whether a contributor is an author/creator. Contributors
+// without a role are treated as creators.
+func isCreator(c *hubv1.Contributor) bool {
+	role := c.RoleCode
+	if role == "" {
+		role = c.Role
+	}
+	role = strings.TrimPrefix(strings.ToLower(role), "relators:")
+	switch role {
+	case "", "aut", "cre", "author", "creator":
+		return true
+	}
+	return false
+}
+
+func licenseURL(record *hubv1.Record) string {
+	for _, r := range record.Rights {
+		if r.Uri != "" {
+			return r.Uri
+		}
+	}
+	return ""
+}
@@ -0,0 +1,136 @@
+package scholix
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+func serialize(t *testing.T, records []*hubv1.Record, opts *format.SerializeOptions) []Link {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := (&Format{}).Serialize(&buf, records, opts); err != nil {
+		t.Fatalf("Serialize error: %v", err)
+	}
+	var links []Link
+	if err := json.Unmarshal(buf.Bytes(), &links); err != nil {
+		t.Fatalf("invalid JSON %q: %v", buf.String(), err)
+	}
+	return links
+}
+
+func TestSerializeDatasetArticleLink(t *testing.T) {
+	dataset := &hubv1.Record{
+		Title:        "Stream Temperature Measurements",
+		ResourceType: &hubv1.ResourceType{Type: hubv1.ResourceTypeValue_RESOURCE_TYPE_DATASET},
+		Identifiers: []*hubv1.Identifier{
+			hub.NewIdentifier("10.5061/dryad.abc123", hubv1.IdentifierType_IDENTIFIER_TYPE_DOI),
+		},
+		Contributors: []*hubv1.Contributor{
+			{
+				Name:     "Smith, Jane",
+				RoleCode: "relators:cre",
+				Identifiers: []*hubv1.Identifier{
+					hub.NewIdentifier("0000-0001-2345-6789", hubv1.IdentifierType_IDENTIFIER_TYPE_ORCID),
+				},
+			},
+			{Name: "Doe, John", RoleCode: "relators:ths"},
+		},
+		Publisher: "Lehigh University",
+		Dates:     []*hubv1.DateValue{hub.NewDateFromYear(2023, hubv1.DateType_DATE_TYPE_ISSUED)},
+		Relations: []*hubv1.Relation{
+			{
+				Type:         hubv1.RelationType_RELATION_TYPE_IS_SUPPLEMENT_TO,
+				TargetId:     "10.1234/article.1",
+				TargetIdType: hubv1.IdentifierType_IDENTIFIER_TYPE_DOI,
+			},
+			// Hierarchical relations are not data-literature links
+			{Type: hubv1.RelationType_RELATION_TYPE_MEMBER_OF, TargetTitle: "Research Data"},
+		},
+	}
+	article := &hubv1.Record{
+		Title:        "Warming Trends in Pennsylvania Streams",
+		ResourceType: &hubv1.ResourceType{Type: hubv1.ResourceTypeValue_RESOURCE_TYPE_ARTICLE},
+		Identifiers: []*hubv1.Identifier{
+			hub.NewIdentifier("https://doi.org/10.1234/ARTICLE.1", hubv1.IdentifierType_IDENTIFIER_TYPE_UNSPECIFIED),
+		},
+	}
+
+	opts := format.NewSerializeOptions()
+	opts.Provider = "Lehigh University Libraries"
+	links := serialize(t, []*hubv1.Record{dataset, article}, opts)
+
+	if len(links) != 1 {
+		t.Fatalf("expected 1 link, got %d", len(links))
+	}
+	link := links[0]
+
+	if link.LinkProvider[0].Name != "Lehigh University Libraries" {
+		t.Errorf("LinkProvider = %+v", link.LinkProvider)
+	}
+	if link.LinkPublicationDate == "" {
+		t.Error("LinkPublicationDate is empty")
+	}
+	want := RelationshipType{Name: "IsSupplementTo", SubType: "IsSupplementTo", SubTypeSchema: "DataCite"}
+	if link.RelationshipType != want {
+		t.Errorf("RelationshipType = %+v, want %+v", link.RelationshipType, want)
+	}
+
+	src := link.Source
+	if src.Type.Name != TypeDataset {
+		t.Errorf("source type = %q", src.Type.Name)
+	}
+	if src.Identifier[0] != (Identifier{ID: "10.5061/dryad.abc123", IDScheme: "doi", IDURL: "https://doi.org/10.5061/dryad.abc123"}) {
+		t.Errorf("source identifier = %+v", src.Identifier[0])
+	}
+	if len(src.Creator) != 1 || src.Creator[0].Name != "Smith, Jane" || src.Creator[0].Identifier[0].IDScheme != "orcid" {
+		t.Errorf("source creators = %+v", src.Creator)
+	}
+	if src.PublicationDate != "2023" || src.Publisher[0].Name != "Lehigh University" {
+		t.Errorf("source date/publisher = %q / %+v", src.PublicationDate, src.Publisher)
+	}
+
+	// Target resolved from the batch, not just the relation
+	if link.Target.Title != "Warming Trends in Pennsylvania Streams" || link.Target.Type.Name != TypePublication {
+		t.Errorf("target = %+v", link.Target)
+	}
+}
+
+func TestSerializeSkipsNonDataLinks(t *testing.T) {
+	article := &hubv1.Record{
+		Title:        "An Article",
+		ResourceType: &hubv1.ResourceType{Type: hubv1.ResourceTypeValue_RESOURCE_TYPE_ARTICLE},
+		Identifiers: []*hubv1.Identifier{
+			hub.NewIdentifier("10.1234/a", hubv1.IdentifierType_IDENTIFIER_TYPE_DOI),
+		},
+		Relations: []*hubv1.Relation{
+			{
+				Type:               hubv1.RelationType_RELATION_TYPE_CITES,
+				TargetId:           "10.1234/b",
+				TargetIdType:       hubv1.IdentifierType_IDENTIFIER_TYPE_DOI,
+				TargetResourceType: hubv1.ResourceTypeValue_RESOURCE_TYPE_ARTICLE,
+			},
+			{
+				Type:               hubv1.RelationType_RELATION_TYPE_REFERENCES,
+				TargetUri:          "https://hdl.handle.net/1234/5678",
+				TargetResourceType: hubv1.ResourceTypeValue_RESOURCE_TYPE_DATASET,
+			},
+		},
+	}
+
+	links := serialize(t, []*hubv1.Record{article}, nil)
+	if len(links) != 1 {
+		t.Fatalf("expected only the dataset link, got %d", len(links))
+	}
+	got := links[0].Target.Identifier[0]
+	if got.IDScheme != "handle" || got.ID != "1234/5678" {
+		t.Errorf("target identifier = %+v", got)
+	}
+	if links[0].LinkProvider[0].Name != defaultProvider {
+		t.Errorf("LinkProvider = %+v", links[0].LinkProvider)
+	}
+}
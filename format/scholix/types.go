@@ -0,0 +1,63 @@
+package scholix
+
+// Scholix object types.
+const (
+	TypePublication = "publication"
+	TypeDataset     = "dataset"
+	TypeSoftware    = "software"
+	TypeOther       = "other"
+)
+
+// Link is a single Scholix link information package.
+type Link struct {
+	LinkPublicationDate string           `json:"LinkPublicationDate"`
+	LinkProvider        []Organization   `json:"LinkProvider"`
+	RelationshipType    RelationshipType `json:"RelationshipType"`
+	LicenseURL          string           `json:"LicenseURL,omitempty"`
+	Source              *ScholarlyObject `json:"Source"`
+	Target              *ScholarlyObject `json:"Target"`
+}
+
+// RelationshipType names the link semantics. Name is one of the Scholix
+// relationship types; SubType carries the finer DataCite relation type.
+type RelationshipType struct {
+	Name          string `json:"Name"`
+	SubType       string `json:"SubType,omitempty"`
+	SubTypeSchema string `json:"SubTypeSchema,omitempty"`
+}
+
+// ScholarlyObject is the source or target of a link.
+type ScholarlyObject struct {
+	Identifier      []Identifier   `json:"Identifier"`
+	Type            ObjectType     `json:"Type"`
+	Title           string         `json:"Title,omitempty"`
+	Creator         []Creator      `json:"Creator,omitempty"`
+	PublicationDate string         `json:"PublicationDate,omitempty"`
+	Publisher       []Organization `json:"Publisher,omitempty"`
+}
+
+// ObjectType classifies a scholarly object.
+type ObjectType struct {
+	Name          string `json:"Name"`
+	SubType       string `json:"SubType,omitempty"`
+	SubTypeSchema string `json:"SubTypeSchema,omitempty"`
+}
+
+// Identifier is a persistent identifier with its scheme.
+type Identifier struct {
+	ID       string `json:"ID"`
+	IDScheme string `json:"IDScheme"`
+	IDURL    string `json:"IDURL,omitempty"`
+}
+
+// Creator is a person or organization credited on an object.
+type Creator struct {
+	Name       string       `json:"Name"`
+	Identifier []Identifier `json:"Identifier,omitempty"`
+}
+
+// Organization is a link provider or publisher.
+type Organization struct {
+	Name       string       `json:"Name"`
+	Identifier []Identifier `json:"Identifier,omitempty"`
+}
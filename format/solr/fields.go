@@ -0,0 +1,97 @@
+package solr
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"os"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+//go:embed fields.yaml
+var defaultFieldsYAML []byte
+
+// FieldMap maps Solr document fields to the hub field paths that fill them.
+type FieldMap struct {
+	// ID is the path of the document's unique key
+	ID string `yaml:"id"`
+
+	// Fields maps Solr field names to hub field paths
+	Fields map[string]string `yaml:"fields"`
+
+	// repeated records which fields can hold more than one value, so they
+	// are written as arrays
+	repeated map[string]bool
+}
+
+// DefaultFieldMap returns the field mapping used without a mapping file.
+func DefaultFieldMap() *FieldMap {
+	m, err := parseFieldMap(defaultFieldsYAML, "")
+	if err != nil {
+		panic(fmt.Sprintf("solr: embedded fields.yaml: %v", err))
+	}
+	return m
+}
+
+// LoadFieldMap reads a field mapping file. Its fields replace the
+// defaults; an id it leaves out keeps the default's.
+func LoadFieldMap(path string) (*FieldMap, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	m, err := parseFieldMap(data, DefaultFieldMap().ID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return m, nil
+}
+
+// parseFieldMap decodes a field mapping, using id when it sets none, and
+// checks its paths against the hub schema.
+func parseFieldMap(data []byte, id string) (*FieldMap, error) {
+	var m FieldMap
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&m); err != nil {
+		return nil, err
+	}
+	if m.ID == "" {
+		m.ID = id
+	}
+	if m.ID == "" {
+		return nil, fmt.Errorf("no id")
+	}
+	if _, err := hub.PathRepeated(m.ID); err != nil {
+		return nil, fmt.Errorf("id: %w", err)
+	}
+	if len(m.Fields) == 0 {
+		return nil, fmt.Errorf("no fields")
+	}
+	m.repeated = make(map[string]bool, len(m.Fields))
+	for _, name := range m.names() {
+		if name == "id" {
+			return nil, fmt.Errorf("fields: set the unique key with id, not a field named id")
+		}
+		repeated, err := hub.PathRepeated(m.Fields[name])
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", name, err)
+		}
+		m.repeated[name] = repeated
+	}
+	return &m, nil
+}
+
+// names returns the Solr field names in sorted order.
+func (m *FieldMap) names() []string {
+	names := make([]string, 0, len(m.Fields))
+	for name := range m.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
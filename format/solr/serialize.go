@@ -0,0 +1,65 @@
+package solr
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+// Serialize writes the records as a JSON array of Solr documents.
+func (f *Format) Serialize(w io.Writer, records []*hubv1.Record, opts *format.SerializeOptions) error {
+	if opts == nil {
+		opts = format.NewSerializeOptions()
+	}
+	m, err := fieldMap(opts)
+	if err != nil {
+		return fmt.Errorf("loading field map: %w", err)
+	}
+
+	docs := make([]map[string]any, 0, len(records))
+	for i, r := range records {
+		docs = append(docs, document(r, i, m))
+	}
+
+	encoder := json.NewEncoder(w)
+	if opts.Pretty {
+		encoder.SetIndent("", "  ")
+	}
+	return encoder.Encode(docs)
+}
+
+// fieldMap returns the field mapping opts.FieldMap names, or the default.
+func fieldMap(opts *format.SerializeOptions) (*FieldMap, error) {
+	if opts == nil || opts.FieldMap == "" {
+		return DefaultFieldMap(), nil
+	}
+	return LoadFieldMap(opts.FieldMap)
+}
+
+// document maps one record, the i'th of the batch, to a Solr document.
+// Fields with no value are left out.
+func document(r *hubv1.Record, i int, m *FieldMap) map[string]any {
+	doc := make(map[string]any, len(m.Fields)+1)
+	if ids := hub.PathValues(r, m.ID); len(ids) > 0 {
+		doc["id"] = ids[0]
+	} else {
+		doc["id"] = fmt.Sprintf("record-%d", i+1)
+		slog.Warn("Solr document has no id value; using its position", "title", r.Title, "id", doc["id"])
+	}
+	for name, path := range m.Fields {
+		values := hub.PathValues(r, path)
+		switch {
+		case len(values) == 0:
+		case m.repeated[name]:
+			doc[name] = values
+		default:
+			doc[name] = values[0]
+		}
+	}
+	return doc
+}
@@ -0,0 +1,77 @@
+// Package solr provides a serializer that writes hub records as Apache Solr
+// JSON documents, ready to post to a core's /update handler, so crosswalked
+// metadata can be reviewed in an Islandora or Blacklight discovery index
+// before migration.
+//
+// Solr field names come from a field mapping: a YAML file mapping each Solr
+// field to the hub field path that fills it, named with
+// SerializeOptions.FieldMap. Without one, fields.yaml in this package is
+// used, which follows Blacklight's dynamic field conventions. Fields whose
+// path crosses a repeated hub field are written as arrays.
+package solr
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+)
+
+// Format implements the Solr JSON document format.
+type Format struct{}
+
+// Ensure Format implements the interfaces
+var (
+	_ format.Format         = (*Format)(nil)
+	_ format.Serializer     = (*Format)(nil)
+	_ format.FieldDescriber = (*Format)(nil)
+)
+
+// Name returns the format identifier.
+func (f *Format) Name() string {
+	return "solr"
+}
+
+// Description returns a human-readable format description.
+func (f *Format) Description() string {
+	return "Apache Solr JSON documents (for Islandora and Blacklight indexes)"
+}
+
+// Extensions returns file extensions associated with this format.
+func (f *Format) Extensions() []string {
+	return []string{"solr"}
+}
+
+// WrittenFields returns the hub record fields the field mapping reads.
+func (f *Format) WrittenFields(opts *format.SerializeOptions) []string {
+	m, err := fieldMap(opts)
+	if err != nil {
+		m = DefaultFieldMap()
+	}
+	seen := make(map[string]bool)
+	paths := []string{m.ID}
+	for _, path := range m.Fields {
+		paths = append(paths, path)
+	}
+	for _, path := range paths {
+		for _, alt := range strings.Split(path, "|") {
+			field, _, _ := strings.Cut(strings.TrimSpace(alt), ".")
+			seen[field] = true
+		}
+	}
+	fields := make([]string, 0, len(seen))
+	for field := range seen {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// CanParse returns false; Solr documents are an output format only.
+func (f *Format) CanParse(peek []byte) bool {
+	return false
+}
+
+func init() {
+	format.Register(&Format{})
+}
@@ -0,0 +1,117 @@
+package solr
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+func testRecords() []*hubv1.Record {
+	return []*hubv1.Record{
+		{
+			Title: "Field notes",
+			Contributors: []*hubv1.Contributor{
+				{Name: "Smith, Jane", Type: hubv1.ContributorType_CONTRIBUTOR_TYPE_PERSON},
+				{Name: "Lehigh University", Type: hubv1.ContributorType_CONTRIBUTOR_TYPE_ORGANIZATION},
+			},
+			Dates:        []*hubv1.DateValue{{Type: hubv1.DateType_DATE_TYPE_ISSUED, Year: 1999, Precision: hubv1.DatePrecision_DATE_PRECISION_YEAR}},
+			ResourceType: &hubv1.ResourceType{Type: hubv1.ResourceTypeValue_RESOURCE_TYPE_ARTICLE},
+			Identifiers: []*hubv1.Identifier{
+				hub.NewIdentifier("2027/abc", hubv1.IdentifierType_IDENTIFIER_TYPE_HANDLE),
+				hub.NewIdentifier("10.1234/abcd", hubv1.IdentifierType_IDENTIFIER_TYPE_DOI),
+			},
+			Relations: []*hubv1.Relation{{Type: hubv1.RelationType_RELATION_TYPE_MEMBER_OF, TargetTitle: "Geology papers"}},
+		},
+		{Title: "Second record"},
+	}
+}
+
+// serialize returns the documents Serialize writes.
+func serialize(t *testing.T, opts *format.SerializeOptions) []map[string]any {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := (&Format{}).Serialize(&buf, testRecords(), opts); err != nil {
+		t.Fatal(err)
+	}
+	var docs []map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &docs); err != nil {
+		t.Fatalf("invalid JSON %s: %v", buf.String(), err)
+	}
+	return docs
+}
+
+func TestSerializeDefaultFields(t *testing.T) {
+	docs := serialize(t, nil)
+	if len(docs) != 2 {
+		t.Fatalf("got %d documents, want 2", len(docs))
+	}
+	want := map[string]any{
+		"id":                 "10.1234/abcd",
+		"title_tesim":        "Field notes",
+		"title_ssi":          "Field notes",
+		"author_tesim":       []any{"Smith, Jane", "Lehigh University"},
+		"author_ssim":        []any{"Smith, Jane", "Lehigh University"},
+		"date_ssim":          []any{"1999"},
+		"year_isim":          []any{"1999"},
+		"resource_type_ssim": "article",
+		"identifier_ssim":    []any{"2027/abc", "10.1234/abcd"},
+		"member_of_ssim":     []any{"Geology papers"},
+	}
+	if !reflect.DeepEqual(docs[0], want) {
+		t.Errorf("got %v\nwant %v", docs[0], want)
+	}
+	if docs[1]["id"] != "record-2" || docs[1]["title_tesim"] != "Second record" {
+		t.Errorf("second document: got %v", docs[1])
+	}
+}
+
+func TestSerializeFieldMap(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fields.yaml")
+	data := "id: identifiers.handle\nfields:\n  title: title\n  creator: contributors.person.name\n"
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	docs := serialize(t, &format.SerializeOptions{FieldMap: path})
+	want := map[string]any{"id": "2027/abc", "title": "Field notes", "creator": []any{"Smith, Jane"}}
+	if !reflect.DeepEqual(docs[0], want) {
+		t.Errorf("got %v, want %v", docs[0], want)
+	}
+
+	// A mapping without an id keeps the default's
+	if err := os.WriteFile(path, []byte("fields:\n  title: title\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if docs := serialize(t, &format.SerializeOptions{FieldMap: path}); docs[0]["id"] != "10.1234/abcd" {
+		t.Errorf("default id: got %v", docs[0]["id"])
+	}
+}
+
+func TestLoadFieldMapErrors(t *testing.T) {
+	tests := map[string]string{
+		"unknown path": "fields:\n  title_t: titel\n",
+		"unknown type": "fields:\n  doi_s: identifiers.dio\n",
+		"unknown key":  "feilds:\n  title_t: title\n",
+		"id field":     "fields:\n  id: title\n",
+		"no fields":    "id: identifiers.doi\n",
+		"empty alt":    "fields:\n  date_s: dates.issued|\n",
+		"bad id path":  "id: identifier\nfields:\n  title_t: title\n",
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "fields.yaml")
+			if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := LoadFieldMap(path); err == nil {
+				t.Error("want error")
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package format
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+)
+
+// PreserveSource stores a record's source payload, gzip-compressed, in its
+// SourceInfo, setting the source format when the parser hasn't.
+func PreserveSource(record *hubv1.Record, formatName string, raw []byte) error {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(raw); err != nil {
+		return fmt.Errorf("compressing source payload: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("compressing source payload: %w", err)
+	}
+	if record.SourceInfo == nil {
+		record.SourceInfo = &hubv1.SourceInfo{}
+	}
+	if record.SourceInfo.Format == "" {
+		record.SourceInfo.Format = formatName
+	}
+	record.SourceInfo.Payload = buf.Bytes()
+	return nil
+}
+
+// PreservedSource returns the source payload kept by PreserveSource when
+// the record was parsed from the named format, or nil when it wasn't or
+// nothing was kept. Payloads from other formats are no use to a
+// serializer, so they are ignored.
+func PreservedSource(record *hubv1.Record, formatName string) ([]byte, error) {
+	si := record.GetSourceInfo()
+	if len(si.GetPayload()) == 0 || si.GetFormat() != formatName {
+		return nil, nil
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(si.Payload))
+	if err != nil {
+		return nil, fmt.Errorf("reading source payload: %w", err)
+	}
+	raw, err := io.ReadAll(zr)
+	if err != nil {
+		return nil, fmt.Errorf("reading source payload: %w", err)
+	}
+	return raw, nil
+}
@@ -0,0 +1,640 @@
+package tei
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/helpers"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+// Document is a TEI document; only its header is read.
+type Document struct {
+	ID     string `xml:"http://www.w3.org/XML/1998/namespace id,attr"`
+	Header Header `xml:"teiHeader"`
+}
+
+// Corpus is a teiCorpus: a header for the corpus and its texts, which may
+// be corpora themselves.
+type Corpus struct {
+	ID        string     `xml:"http://www.w3.org/XML/1998/namespace id,attr"`
+	Header    Header     `xml:"teiHeader"`
+	Documents []Document `xml:"TEI"`
+	Corpora   []Corpus   `xml:"teiCorpus"`
+}
+
+// Parse reads a TEI document, teiCorpus, or bare teiHeader and returns a
+// record per header.
+func (f *Format) Parse(r io.Reader, opts *format.ParseOptions) ([]*hubv1.Record, error) {
+	if opts == nil {
+		opts = format.NewParseOptions()
+	}
+
+	d := xml.NewDecoder(r)
+	var start xml.StartElement
+	for {
+		tok, err := d.Token()
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("no TEI document found in input")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parsing TEI XML: %w", err)
+		}
+		if s, ok := tok.(xml.StartElement); ok {
+			start = s
+			break
+		}
+	}
+
+	var records []*hubv1.Record
+	switch start.Name.Local {
+	case "TEI":
+		var doc Document
+		if err := d.DecodeElement(&doc, &start); err != nil {
+			return nil, fmt.Errorf("parsing TEI XML: %w", err)
+		}
+		records = append(records, headerToRecord(&doc.Header, doc.ID, opts))
+	case "teiCorpus":
+		var corpus Corpus
+		if err := d.DecodeElement(&corpus, &start); err != nil {
+			return nil, fmt.Errorf("parsing TEI XML: %w", err)
+		}
+		records = corpusRecords(&corpus, nil, opts)
+	case "teiHeader":
+		var header Header
+		if err := d.DecodeElement(&header, &start); err != nil {
+			return nil, fmt.Errorf("parsing TEI XML: %w", err)
+		}
+		records = append(records, headerToRecord(&header, "", opts))
+	default:
+		return nil, fmt.Errorf("not a TEI document: root element is <%s>", start.Name.Local)
+	}
+	return records, nil
+}
+
+// corpusRecords returns a record for the corpus followed by the records of
+// its texts, each a member of the corpus.
+func corpusRecords(corpus *Corpus, parent *hubv1.Record, opts *format.ParseOptions) []*hubv1.Record {
+	record := headerToRecord(&corpus.Header, corpus.ID, opts)
+	record.ResourceType = hub.NewResourceType("teiCorpus", "tei")
+	record.ResourceType.Type = hubv1.ResourceTypeValue_RESOURCE_TYPE_COLLECTION
+	memberOf(record, parent)
+
+	records := []*hubv1.Record{record}
+	for i := range corpus.Documents {
+		doc := &corpus.Documents[i]
+		text := headerToRecord(&doc.Header, doc.ID, opts)
+		memberOf(text, record)
+		records = append(records, text)
+	}
+	for i := range corpus.Corpora {
+		records = append(records, corpusRecords(&corpus.Corpora[i], record, opts)...)
+	}
+	return records
+}
+
+// memberOf relates a record to the corpus containing it, by the corpus's
+// xml:id when it has one.
+func memberOf(record, corpus *hubv1.Record) {
+	if corpus == nil {
+		return
+	}
+	rel := hub.NewRelation(hubv1.RelationType_RELATION_TYPE_MEMBER_OF, corpus.Title)
+	if corpus.SourceInfo != nil {
+		rel.TargetId = corpus.SourceInfo.SourceId
+	}
+	record.Relations = append(record.Relations, rel)
+}
+
+// headerToRecord maps a teiHeader to a hub record.
+func headerToRecord(h *Header, id string, opts *format.ParseOptions) *hubv1.Record {
+	record := hub.NewRecord()
+	fd := &h.FileDesc
+	ts := &fd.TitleStmt
+
+	mapTitles(record, ts.Titles)
+
+	// Those responsible for the text
+	for _, a := range ts.Authors {
+		addContributor(record, a, "aut")
+	}
+	for _, a := range ts.Editors {
+		addContributor(record, a, "edt")
+	}
+	for _, a := range ts.Sponsors {
+		addContributor(record, a, "spn")
+	}
+	for _, a := range ts.Principals {
+		addContributor(record, a, "Principal investigator")
+	}
+	for _, rs := range ts.RespStmts {
+		resp := strings.TrimSpace(first(rs.Resp))
+		for _, a := range rs.Names {
+			if a.Element == "name" || a.Element == "persName" || a.Element == "orgName" {
+				addContributor(record, a, resp)
+			}
+		}
+	}
+	for _, a := range ts.Funders {
+		if a.Name != "" {
+			record.Funders = append(record.Funders, &hubv1.Funder{Name: a.Name})
+		}
+	}
+
+	record.Edition = first(fd.EditionStmt.Editions)
+	if record.Edition == "" {
+		record.Edition = first(fd.EditionStmt.P)
+	}
+	record.PhysicalDesc = first(fd.Extent)
+	mapPublication(record, &fd.PublicationStmt)
+
+	for _, ss := range fd.SeriesStmts {
+		for _, t := range ss.Titles {
+			if t.Text != "" {
+				record.Relations = append(record.Relations,
+					hub.NewRelation(hubv1.RelationType_RELATION_TYPE_IN_SERIES, t.Text))
+				break
+			}
+		}
+	}
+	for _, n := range fd.NotesStmt.Notes {
+		if n != "" {
+			record.Notes = append(record.Notes, string(n))
+		}
+	}
+
+	mapSource(record, &fd.SourceDesc)
+	mapProfile(record, &h.ProfileDesc, opts)
+
+	record.ResourceType = hub.NewResourceType("TEI", "tei")
+	record.ResourceType.Type = hubv1.ResourceTypeValue_RESOURCE_TYPE_TEXT
+	record.SourceInfo = &hubv1.SourceInfo{
+		Format:        "tei",
+		FormatVersion: Version,
+		SourceId:      strings.TrimSpace(id),
+	}
+	return record
+}
+
+// mapTitles sets the main title, with its subtitles, and keeps other
+// titles as alternatives. Main titles in several languages are kept as
+// language-tagged titles. Series and journal titles (levels s and j) are
+// series relations.
+func mapTitles(record *hubv1.Record, titles []Title) {
+	var subtitle string
+	for _, t := range titles {
+		if t.Type == "sub" && t.Text != "" {
+			subtitle = t.Text
+			break
+		}
+	}
+
+	for _, t := range titles {
+		if t.Text == "" {
+			continue
+		}
+		switch {
+		case t.Level == "s" || t.Level == "j":
+			record.Relations = append(record.Relations,
+				hub.NewRelation(hubv1.RelationType_RELATION_TYPE_IN_SERIES, t.Text))
+		case t.Type == "sub":
+		case t.Type == "" || t.Type == "main" || t.Type == "full":
+			title := t.Text
+			if subtitle != "" && t.Type != "full" {
+				title += ": " + subtitle
+			}
+			if record.Title == "" || t.Lang != "" {
+				hub.AddTitle(record, title, t.Lang)
+			} else {
+				record.AltTitle = append(record.AltTitle, title)
+			}
+		default:
+			record.AltTitle = append(record.AltTitle, t.Text)
+		}
+	}
+	// A lone untagged title needs no language-tagged copy
+	if len(record.Titles) == 1 && record.Titles[0].Language == "" {
+		record.Titles = nil
+	}
+}
+
+// addContributor adds an agent to the record's contributors. role is a
+// MARC relator code or, from a respStmt, the responsibility in words; an
+// agent's own role attribute wins.
+func addContributor(record *hubv1.Record, a Agent, role string) {
+	c := agentToContributor(a)
+	if c == nil {
+		return
+	}
+	if a.Role != "" {
+		role = a.Role
+	}
+	if code := helpers.NormalizeRole(role); helpers.MARCRelators[code] != "" {
+		c.RoleCode = "relators:" + code
+		c.Role = helpers.RelatorLabel(code)
+	} else {
+		c.Role = role
+	}
+	c.DegreeRole = hub.DegreeRoleOf(c)
+	record.Contributors = append(record.Contributors, c)
+}
+
+// agentToContributor maps an agent's name, type, and identifiers, or
+// returns nil for an agent without a name.
+func agentToContributor(a Agent) *hubv1.Contributor {
+	if a.Name == "" {
+		return nil
+	}
+	c := &hubv1.Contributor{Name: a.Name, Type: hubv1.ContributorType_CONTRIBUTOR_TYPE_PERSON}
+	switch {
+	case a.Kind == "orgName":
+		c.Type = hubv1.ContributorType_CONTRIBUTOR_TYPE_ORGANIZATION
+	case len(a.Surnames) > 0:
+		c.ParsedName = &hubv1.ParsedName{
+			Family: strings.Join(a.Surnames, " "),
+			Given:  strings.Join(a.Forenames, " "),
+		}
+		c.Name = hub.ParsedNameInverted(c.ParsedName)
+	default:
+		c.ParsedName = helpers.ParseName(a.Name)
+	}
+
+	for _, aff := range a.Affiliations {
+		c.Affiliations = append(c.Affiliations, &hubv1.Affiliation{Name: aff})
+	}
+
+	ids := slices.Clone(a.IDNos)
+	if a.Ref != "" {
+		ids = append(ids, IDNo{Text: a.Ref})
+	}
+	for _, id := range ids {
+		value := strings.TrimSpace(id.Text)
+		switch {
+		case value == "":
+		case strings.EqualFold(id.Type, "ORCID") || strings.Contains(value, "orcid.org/"):
+			value = strings.TrimPrefix(strings.TrimPrefix(value, "https://orcid.org/"), "http://orcid.org/")
+			c.Identifiers = append(c.Identifiers, &hubv1.Identifier{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_ORCID, Value: value})
+		case strings.EqualFold(id.Type, "ISNI"):
+			c.Identifiers = append(c.Identifiers, &hubv1.Identifier{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_ISNI, Value: value})
+		case strings.HasPrefix(value, "http://") || strings.HasPrefix(value, "https://"):
+			// A name authority (VIAF, LCNAF, Wikidata, …)
+			if c.AuthorityUri == "" {
+				c.AuthorityUri = value
+			}
+		}
+	}
+	return c
+}
+
+// mapPublication maps the publicationStmt: publisher (or distributor or
+// authority), place, date, identifiers, and availability.
+func mapPublication(record *hubv1.Record, ps *PublicationStmt) {
+	record.Publisher = first(ps.Publishers)
+	if record.Publisher == "" {
+		record.Publisher = first(ps.Distributors)
+	}
+	if record.Publisher == "" {
+		record.Publisher = first(ps.Authorities)
+	}
+	record.PlacePublished = first(ps.PubPlaces)
+
+	for _, d := range ps.Dates {
+		if dv := parseDate(d, hubv1.DateType_DATE_TYPE_ISSUED); dv != nil {
+			record.Dates = append(record.Dates, dv)
+			break
+		}
+	}
+
+	for _, id := range ps.IDNos {
+		if identifier := mapIDNo(id); identifier != nil {
+			record.Identifiers = append(record.Identifiers, identifier)
+		}
+	}
+
+	record.IsPublic = true
+	for _, av := range ps.Availability {
+		for _, l := range av.Licences {
+			switch {
+			case l.Target != "":
+				rights := hub.NewRightsFromURI(l.Target)
+				if rights.Statement == l.Target && l.Text != "" {
+					rights.Statement = l.Text
+				}
+				record.Rights = append(record.Rights, rights)
+			case l.Text != "":
+				record.Rights = append(record.Rights, &hubv1.Rights{Statement: l.Text})
+			}
+		}
+		statement := first(av.P)
+		switch av.Status {
+		case "restricted":
+			record.IsPublic = false
+			record.AccessCondition = statement
+			if record.AccessCondition == "" {
+				record.AccessCondition = "restricted"
+			}
+		default:
+			if statement != "" && len(av.Licences) == 0 {
+				record.Rights = append(record.Rights, &hubv1.Rights{Statement: statement})
+			}
+		}
+	}
+}
+
+// idnoTypes maps idno types, lowercased, to identifier types.
+var idnoTypes = map[string]hubv1.IdentifierType{
+	"doi":    hubv1.IdentifierType_IDENTIFIER_TYPE_DOI,
+	"uri":    hubv1.IdentifierType_IDENTIFIER_TYPE_URL,
+	"url":    hubv1.IdentifierType_IDENTIFIER_TYPE_URL,
+	"handle": hubv1.IdentifierType_IDENTIFIER_TYPE_HANDLE,
+	"hdl":    hubv1.IdentifierType_IDENTIFIER_TYPE_HANDLE,
+	"isbn":   hubv1.IdentifierType_IDENTIFIER_TYPE_ISBN,
+	"issn":   hubv1.IdentifierType_IDENTIFIER_TYPE_ISSN,
+}
+
+// mapIDNo maps an idno to an identifier. Untyped identifiers are typed by
+// their form, and local when that says nothing.
+func mapIDNo(id IDNo) *hubv1.Identifier {
+	value := strings.TrimSpace(id.Text)
+	if value == "" {
+		return nil
+	}
+	idType, ok := idnoTypes[strings.ToLower(id.Type)]
+	if !ok || idType == hubv1.IdentifierType_IDENTIFIER_TYPE_URL {
+		if detected := hub.DetectIdentifierType(value); detected != hubv1.IdentifierType_IDENTIFIER_TYPE_UNSPECIFIED {
+			idType = detected
+		}
+	}
+	if idType == hubv1.IdentifierType_IDENTIFIER_TYPE_UNSPECIFIED {
+		idType = hubv1.IdentifierType_IDENTIFIER_TYPE_LOCAL
+	}
+	return hub.NewIdentifier(value, idType)
+}
+
+// mapSource describes what the text was made from. A bibliographic or
+// manuscript source makes the text a digitized one: its citation is kept
+// as the record's source, and its date and authors stand in for the
+// creation date and authors the header doesn't give.
+func mapSource(record *hubv1.Record, sd *SourceDesc) {
+	var citations []string
+	var dates []Date
+	var authors []Agent
+
+	for _, b := range sd.Bibls {
+		citations = append(citations, b.Text)
+		dates = append(dates, b.Dates...)
+		authors = append(authors, b.Authors...)
+	}
+	for _, bs := range sd.BiblStructs {
+		citations = append(citations, biblStructCitation(&bs))
+		dates = append(dates, bs.Monogr.Imprint.Dates...)
+		if bs.Analytic != nil {
+			authors = append(authors, bs.Analytic.Authors...)
+		} else {
+			authors = append(authors, bs.Monogr.Authors...)
+		}
+	}
+	for _, bf := range sd.BiblFulls {
+		var parts []string
+		for _, t := range bf.TitleStmt.Titles {
+			parts = appendNonEmpty(parts, t.Text)
+		}
+		parts = appendNonEmpty(parts, first(bf.PublicationStmt.PubPlaces), first(bf.PublicationStmt.Publishers))
+		citations = append(citations, strings.Join(parts, ". "))
+		dates = append(dates, bf.PublicationStmt.Dates...)
+		authors = append(authors, bf.TitleStmt.Authors...)
+	}
+	for _, ms := range sd.MsDescs {
+		id := &ms.Identifier
+		var parts []string
+		parts = appendNonEmpty(parts, string(id.Settlement), string(id.Institution), string(id.Repository), string(id.Collection))
+		for _, idno := range id.IDNos {
+			parts = appendNonEmpty(parts, idno.Text)
+		}
+		citation := strings.Join(parts, ", ")
+		if head := first(ms.Heads); head != "" {
+			citation = strings.TrimSpace(head + ". " + citation)
+		}
+		citations = append(citations, citation)
+		dates = append(dates, ms.OrigDate...)
+		authors = append(authors, ms.Authors...)
+	}
+
+	citations = slices.DeleteFunc(citations, func(s string) bool { return s == "" })
+	if len(citations) == 0 {
+		return
+	}
+	record.Source = strings.Join(citations, "\n")
+	record.DigitalOrigin = "reformatted digital"
+
+	for _, d := range dates {
+		if dv := parseDate(d, hubv1.DateType_DATE_TYPE_CREATED); dv != nil {
+			record.Dates = append(record.Dates, dv)
+			break
+		}
+	}
+	if !slices.ContainsFunc(record.Contributors, isAuthor) {
+		for _, a := range authors {
+			addContributor(record, a, "aut")
+		}
+	}
+}
+
+// biblStructCitation formats a biblStruct as "Authors. Article. Title.
+// Place: Publisher, Date".
+func biblStructCitation(bs *BiblStruct) string {
+	var parts []string
+	levels := []*Monogr{&bs.Monogr}
+	if bs.Analytic != nil {
+		levels = []*Monogr{bs.Analytic, &bs.Monogr}
+	}
+	var names []string
+	for _, a := range levels[0].Authors {
+		if c := agentToContributor(a); c != nil {
+			names = append(names, c.Name)
+		}
+	}
+	parts = appendNonEmpty(parts, strings.Join(names, "; "))
+	for _, m := range levels {
+		if len(m.Titles) > 0 {
+			parts = appendNonEmpty(parts, m.Titles[0].Text)
+		}
+	}
+
+	imprint := &bs.Monogr.Imprint
+	var pub string
+	if place := first(imprint.PubPlaces); place != "" {
+		pub = place
+	}
+	if publisher := first(imprint.Publishers); publisher != "" {
+		pub = strings.TrimPrefix(pub+": "+publisher, ": ")
+	}
+	if len(imprint.Dates) > 0 && imprint.Dates[0].Text != "" {
+		pub = strings.TrimPrefix(pub+", "+imprint.Dates[0].Text, ", ")
+	}
+	parts = appendNonEmpty(parts, pub)
+	return strings.Join(parts, ". ")
+}
+
+// isAuthor reports whether a contributor is an author or creator.
+func isAuthor(c *hubv1.Contributor) bool {
+	return c.RoleCode == "relators:aut" || c.RoleCode == "relators:cre"
+}
+
+// mapProfile maps the profileDesc: abstract, creation date, language, and
+// keywords and classification codes.
+func mapProfile(record *hubv1.Record, pd *ProfileDesc, opts *format.ParseOptions) {
+	for _, a := range pd.Abstracts {
+		text := a.Text
+		if opts.StripHTML {
+			text = helpers.CleanText(text)
+		}
+		if text != "" {
+			hub.AddAbstract(record, text, a.Lang)
+		}
+	}
+	if len(record.Abstracts) == 1 && record.Abstracts[0].Language == "" {
+		record.Abstracts = nil
+	}
+
+	// The date of composition comes before a source's date
+	for _, d := range pd.Creation.Dates {
+		if dv := parseDate(d, hubv1.DateType_DATE_TYPE_CREATED); dv != nil {
+			record.Dates = slices.DeleteFunc(record.Dates, func(d *hubv1.DateValue) bool {
+				return d.Type == hubv1.DateType_DATE_TYPE_CREATED
+			})
+			record.Dates = append(record.Dates, dv)
+			break
+		}
+	}
+
+	// The language most of the text is in
+	best := -1.0
+	for _, l := range pd.Languages {
+		if l.Ident == "" {
+			continue
+		}
+		usage, err := strconv.ParseFloat(l.Usage, 64)
+		if err != nil {
+			usage = 0
+		}
+		if usage > best {
+			record.Language = strings.TrimSpace(l.Ident)
+			best = usage
+		}
+	}
+
+	for _, kw := range pd.TextClass.Keywords {
+		vocab := schemeVocabulary(kw.Scheme, hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_KEYWORDS)
+		for _, t := range append(kw.Terms, kw.Items...) {
+			if t.Text == "" {
+				continue
+			}
+			record.Subjects = append(record.Subjects, &hubv1.Subject{
+				Value:      t.Text,
+				Vocabulary: vocab,
+				Uri:        t.Ref,
+				SourceId:   t.Key,
+				Type:       hubv1.SubjectType_SUBJECT_TYPE_TOPIC,
+			})
+		}
+	}
+	for _, cc := range pd.TextClass.ClassCodes {
+		if cc.Text == "" {
+			continue
+		}
+		record.Subjects = append(record.Subjects, &hubv1.Subject{
+			Value:      cc.Text,
+			Vocabulary: schemeVocabulary(cc.Scheme, hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LOCAL),
+			SourceId:   cc.Text,
+			Type:       hubv1.SubjectType_SUBJECT_TYPE_TOPIC,
+		})
+	}
+}
+
+// schemeVocabularies recognize the schemes of keywords and classCodes by
+// a fragment of their URI or name.
+var schemeVocabularies = []struct {
+	fragment string
+	vocab    hubv1.SubjectVocabulary
+}{
+	{"authorities/subjects", hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LCSH},
+	{"lcsh", hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LCSH},
+	{"classification", hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LCC},
+	{"lcc", hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LCC},
+	{"mesh", hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_MESH},
+	{"fast", hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_FAST},
+	{"aat", hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_AAT},
+	{"ddc", hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_DDC},
+	{"dewey", hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_DDC},
+}
+
+// schemeVocabulary returns the vocabulary of a scheme, or fallback when
+// there is no scheme; unrecognized schemes are local.
+func schemeVocabulary(scheme string, fallback hubv1.SubjectVocabulary) hubv1.SubjectVocabulary {
+	scheme = strings.ToLower(strings.TrimSpace(scheme))
+	if scheme == "" {
+		return fallback
+	}
+	for _, sv := range schemeVocabularies {
+		if strings.Contains(scheme, sv.fragment) {
+			return sv.vocab
+		}
+	}
+	return hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LOCAL
+}
+
+// parseDate reads a date from its normalized attributes, as EDTF (a range
+// for from/to and notBefore/notAfter), falling back to its text. The text
+// is kept as the raw value.
+func parseDate(d Date, dateType hubv1.DateType) *hubv1.DateValue {
+	normal := d.When
+	switch {
+	case normal != "":
+	case d.From != "" || d.To != "":
+		normal = cmpOr(d.From, "..") + "/" + cmpOr(d.To, "..")
+	case d.NotBefore != "" || d.NotAfter != "":
+		normal = cmpOr(d.NotBefore, "..") + "/" + cmpOr(d.NotAfter, "..")
+	default:
+		normal = d.Text
+	}
+	if normal == "" {
+		return nil
+	}
+	dv, err := helpers.ParseEDTF(normal, dateType)
+	if err != nil {
+		if d.Text == "" {
+			return nil
+		}
+		return &hubv1.DateValue{Type: dateType, Raw: d.Text}
+	}
+	if d.Text != "" {
+		dv.Raw = d.Text
+	}
+	return dv
+}
+
+// cmpOr returns s, or fallback when s is empty.
+func cmpOr(s, fallback string) string {
+	if s == "" {
+		return fallback
+	}
+	return s
+}
+
+// appendNonEmpty appends the values that aren't empty.
+func appendNonEmpty(list []string, values ...string) []string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
+		}
+	}
+	return list
+}
@@ -0,0 +1,207 @@
+package tei
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+func parseFile(t *testing.T, path string, opts *format.ParseOptions) []*hubv1.Record {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	records, err := (&Format{}).Parse(f, opts)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	return records
+}
+
+func TestParse(t *testing.T) {
+	records := parseFile(t, "testdata/edition.xml", nil)
+	if len(records) != 1 {
+		t.Fatalf("expected 1 record, got %d", len(records))
+	}
+	record := records[0]
+
+	if record.Title != "The Diary of Asa Packer, 1867: A Digital Edition" {
+		t.Errorf("Title: got %q", record.Title)
+	}
+	if len(record.AltTitle) != 1 || record.AltTitle[0] != "Packer Diary" {
+		t.Errorf("AltTitle: got %v", record.AltTitle)
+	}
+	if rels := hub.GetRelationsByType(record, hubv1.RelationType_RELATION_TYPE_IN_SERIES); len(rels) != 1 ||
+		rels[0].TargetTitle != "Lehigh Valley Documents" {
+		t.Errorf("series: got %v", rels)
+	}
+
+	if len(record.Contributors) != 4 {
+		t.Fatalf("expected 4 contributors, got %v", record.Contributors)
+	}
+	author := record.Contributors[0]
+	if author.Name != "Packer, Asa" || author.RoleCode != "relators:aut" || author.ParsedName.GetGiven() != "Asa" {
+		t.Errorf("author: got %v", author)
+	}
+	if author.AuthorityUri != "http://viaf.org/viaf/12345678" {
+		t.Errorf("author authority: got %q", author.AuthorityUri)
+	}
+	editor := record.Contributors[1]
+	if editor.RoleCode != "relators:edt" || len(editor.Identifiers) != 1 || editor.Identifiers[0].Value != "0000-0002-1825-0097" {
+		t.Errorf("editor: got %v", editor)
+	}
+	if len(editor.Affiliations) != 1 || editor.Affiliations[0].Name != "Lehigh University" {
+		t.Errorf("editor affiliations: got %v", editor.Affiliations)
+	}
+	if c := record.Contributors[2]; c.Name != "Dana Kim" || c.Role != "Transcriber" {
+		t.Errorf("respStmt person: got %v", c)
+	}
+	if c := record.Contributors[3]; c.Type != hubv1.ContributorType_CONTRIBUTOR_TYPE_ORGANIZATION || c.Role != "TEI encoding" {
+		t.Errorf("respStmt organization: got %v", c)
+	}
+	if len(record.Funders) != 1 || record.Funders[0].Name != "National Endowment for the Humanities" {
+		t.Errorf("Funders: got %v", record.Funders)
+	}
+
+	if record.Edition != "Version 1.2" || record.PhysicalDesc != "112 pages" {
+		t.Errorf("Edition/PhysicalDesc: got %q, %q", record.Edition, record.PhysicalDesc)
+	}
+	if record.Publisher != "Lehigh University Libraries" || record.PlacePublished != "Bethlehem, PA" {
+		t.Errorf("Publisher/Place: got %q, %q", record.Publisher, record.PlacePublished)
+	}
+	if d := hub.GetDateIssued(record); d == nil || d.Year != 2025 || d.Month != 4 || d.Day != 1 {
+		t.Errorf("issued: got %v", d)
+	}
+	if id := hub.GetDOI(record); id == nil || id.Value != "10.5555/packer.1867" {
+		t.Errorf("DOI: got %v", id)
+	}
+	if id := hub.GetIdentifier(record, hubv1.IdentifierType_IDENTIFIER_TYPE_LOCAL); id == nil || id.Value != "LUL-TEI-0042" {
+		t.Errorf("local idno: got %v", id)
+	}
+	if len(record.Rights) != 1 || record.Rights[0].Uri != "http://creativecommons.org/licenses/by/4.0/" {
+		t.Errorf("Rights: got %v", record.Rights)
+	}
+	if len(record.Notes) != 1 || !strings.HasPrefix(record.Notes[0], "Pages 40") {
+		t.Errorf("Notes: got %v", record.Notes)
+	}
+
+	if !strings.Contains(record.Source, "Lehigh University Special Collections, SC MS 0101") ||
+		record.DigitalOrigin != "reformatted digital" {
+		t.Errorf("Source/DigitalOrigin: got %q, %q", record.Source, record.DigitalOrigin)
+	}
+	if d := hub.GetDate(record, hubv1.DateType_DATE_TYPE_CREATED); d == nil || d.Year != 1867 || d.EndYear != 1867 || d.Raw != "1867" {
+		t.Errorf("origDate: got %v", d)
+	}
+
+	if !strings.HasPrefix(record.Abstract, "A year of entries") || !strings.Contains(record.Abstract, "covering railroad business") {
+		t.Errorf("Abstract: got %q", record.Abstract)
+	}
+	if record.Language != "en" {
+		t.Errorf("Language should be the most used: got %q", record.Language)
+	}
+	if len(record.Subjects) != 3 {
+		t.Fatalf("expected 3 subjects, got %v", record.Subjects)
+	}
+	if s := record.Subjects[0]; s.Vocabulary != hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LCSH || s.Uri == "" {
+		t.Errorf("LCSH keyword: got %v", s)
+	}
+	if s := record.Subjects[1]; s.Vocabulary != hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_KEYWORDS || s.Value != "diaries" {
+		t.Errorf("keyword: got %v", s)
+	}
+	if s := record.Subjects[2]; s.Vocabulary != hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LCC || s.Value != "HE2791" {
+		t.Errorf("classCode: got %v", s)
+	}
+
+	if record.ResourceType.Type != hubv1.ResourceTypeValue_RESOURCE_TYPE_TEXT {
+		t.Errorf("ResourceType: got %v", record.ResourceType)
+	}
+	if si := record.SourceInfo; si.Format != "tei" || si.FormatVersion != "P5" || si.SourceId != "packer-diary-1867" {
+		t.Errorf("SourceInfo: got %v", si)
+	}
+}
+
+func TestParseCorpus(t *testing.T) {
+	records := parseFile(t, "testdata/corpus.xml", nil)
+	if len(records) != 2 {
+		t.Fatalf("expected corpus and text records, got %d", len(records))
+	}
+
+	corpus := records[0]
+	if corpus.Title != "Lehigh Family Letters" || corpus.ResourceType.Type != hubv1.ResourceTypeValue_RESOURCE_TYPE_COLLECTION {
+		t.Errorf("corpus: got %q, %v", corpus.Title, corpus.ResourceType)
+	}
+	if corpus.Publisher != "Lehigh University Libraries" {
+		t.Errorf("authority as publisher: got %q", corpus.Publisher)
+	}
+	if corpus.IsPublic || corpus.AccessCondition != "Lehigh users only" {
+		t.Errorf("restricted availability: got %v, %q", corpus.IsPublic, corpus.AccessCondition)
+	}
+	if corpus.Source != "" {
+		t.Errorf("a born-digital corpus has no source: got %q", corpus.Source)
+	}
+
+	letter := records[1]
+	rels := hub.GetRelationsByType(letter, hubv1.RelationType_RELATION_TYPE_MEMBER_OF)
+	if len(rels) != 1 || rels[0].TargetId != "lehigh-letters" || rels[0].TargetTitle != "Lehigh Family Letters" {
+		t.Errorf("member of: got %v", rels)
+	}
+	if len(letter.Contributors) != 1 || letter.Contributors[0].Name != "Packer, Asa" {
+		t.Errorf("source author should stand in: got %v", letter.Contributors)
+	}
+	if letter.Source != "Packer, Asa. Letter to Mary Packer. 12 March 1869" {
+		t.Errorf("Source: got %q", letter.Source)
+	}
+	if d := hub.GetDate(letter, hubv1.DateType_DATE_TYPE_CREATED); d == nil || d.Day != 10 {
+		t.Errorf("creation date should win over the source date: got %v", d)
+	}
+}
+
+func TestParseHeader(t *testing.T) {
+	input := `<teiHeader xmlns="http://www.tei-c.org/ns/1.0"><fileDesc>
+		<titleStmt><title xml:lang="en">Letters</title><title xml:lang="de">Briefe</title></titleStmt>
+		<publicationStmt><p/></publicationStmt><sourceDesc><p/></sourceDesc>
+	</fileDesc></teiHeader>`
+	records, err := (&Format{}).Parse(strings.NewReader(input), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 || records[0].Title != "Letters" || len(records[0].Titles) != 2 {
+		t.Errorf("got %v", records)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := map[string]string{
+		"empty":     "",
+		"not tei":   `<mods xmlns="http://www.loc.gov/mods/v3"><titleInfo><title>x</title></titleInfo></mods>`,
+		"malformed": `<TEI xmlns="http://www.tei-c.org/ns/1.0"><teiHeader>`,
+	}
+	for name, input := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := (&Format{}).Parse(strings.NewReader(input), nil); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestCanParse(t *testing.T) {
+	f := &Format{}
+	data, err := os.ReadFile("testdata/edition.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !f.CanParse(data) {
+		t.Error("expected CanParse for TEI")
+	}
+	if f.CanParse([]byte(`<mods xmlns="http://www.loc.gov/mods/v3"/>`)) {
+		t.Error("unexpected CanParse for MODS")
+	}
+}
@@ -0,0 +1,67 @@
+// Package tei provides a parser for the headers of TEI P5 documents.
+//
+// A TEI document becomes one hub record, read from its teiHeader; the text
+// itself is not read. A teiCorpus becomes a collection record followed by
+// a record for each of its texts, related to the corpus as members. A bare
+// teiHeader is read as a single record.
+//
+// The mapping covers:
+//   - titleStmt: main titles with their subtitles (in several languages
+//     when given), other titles as alternative titles, series titles as
+//     series relations, authors, editors, sponsors, principals, and
+//     respStmt names with their responsibility mapped to MARC relators,
+//     and funders
+//   - editionStmt, extent (as the physical description), and notesStmt
+//   - publicationStmt: publisher (or distributor or authority), place,
+//     date issued, idno identifiers, and availability as licences, rights
+//     statements, and access conditions
+//   - sourceDesc: a bibl, biblStruct, biblFull, or msDesc citation as the
+//     source of a digitized text, with its date and authors used when the
+//     header gives none
+//   - profileDesc: abstracts, date of creation, the main language, and
+//     keywords and classification codes as subjects
+package tei
+
+import (
+	"bytes"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+)
+
+// Version is the TEI version read.
+const Version = "P5"
+
+// Format implements the TEI P5 header format.
+type Format struct{}
+
+// Ensure Format implements the interfaces
+var (
+	_ format.Format = (*Format)(nil)
+	_ format.Parser = (*Format)(nil)
+)
+
+// Name returns the format identifier.
+func (f *Format) Name() string {
+	return "tei"
+}
+
+// Description returns a human-readable format description.
+func (f *Format) Description() string {
+	return "TEI P5 headers (teiHeader) of digitized texts and editions"
+}
+
+// Extensions returns file extensions associated with this format.
+func (f *Format) Extensions() []string {
+	return []string{"xml", "tei"}
+}
+
+// CanParse returns true if the input looks like a TEI document.
+func (f *Format) CanParse(peek []byte) bool {
+	return bytes.Contains(peek, []byte("http://www.tei-c.org/ns/1.0")) ||
+		bytes.Contains(peek, []byte("<teiHeader")) ||
+		bytes.Contains(peek, []byte("<teiCorpus"))
+}
+
+func init() {
+	format.Register(&Format{})
+}
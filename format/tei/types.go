@@ -0,0 +1,517 @@
+package tei
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+// Header is a teiHeader. Only the parts that describe the text are read;
+// encodingDesc and revisionDesc are skipped.
+type Header struct {
+	FileDesc    FileDesc    `xml:"fileDesc"`
+	ProfileDesc ProfileDesc `xml:"profileDesc"`
+}
+
+// FileDesc is the bibliographic description of the electronic text and,
+// in sourceDesc, of what it was made from. biblFull reuses it.
+type FileDesc struct {
+	TitleStmt       TitleStmt       `xml:"titleStmt"`
+	EditionStmt     EditionStmt     `xml:"editionStmt"`
+	Extent          []Text          `xml:"extent"`
+	PublicationStmt PublicationStmt `xml:"publicationStmt"`
+	SeriesStmts     []SeriesStmt    `xml:"seriesStmt"`
+	NotesStmt       NotesStmt       `xml:"notesStmt"`
+	SourceDesc      SourceDesc      `xml:"sourceDesc"`
+}
+
+// TitleStmt names the text and those responsible for it.
+type TitleStmt struct {
+	Titles     []Title    `xml:"title"`
+	Authors    []Agent    `xml:"author"`
+	Editors    []Agent    `xml:"editor"`
+	Sponsors   []Agent    `xml:"sponsor"`
+	Funders    []Agent    `xml:"funder"`
+	Principals []Agent    `xml:"principal"`
+	RespStmts  []RespStmt `xml:"respStmt"`
+}
+
+// EditionStmt describes the edition, in edition elements or prose.
+type EditionStmt struct {
+	Editions []Text `xml:"edition"`
+	P        []Text `xml:"p"`
+}
+
+// PublicationStmt says who published or distributes the text, where, when,
+// and under what terms. Unstructured statements in prose are ignored.
+type PublicationStmt struct {
+	Publishers   []Text         `xml:"publisher"`
+	Distributors []Text         `xml:"distributor"`
+	Authorities  []Text         `xml:"authority"`
+	PubPlaces    []Text         `xml:"pubPlace"`
+	Dates        []Date         `xml:"date"`
+	IDNos        []IDNo         `xml:"idno"`
+	Availability []Availability `xml:"availability"`
+}
+
+// Availability gives the access status and licences of the text.
+type Availability struct {
+	Status   string    `xml:"status,attr"`
+	Licences []Licence `xml:"licence"`
+	P        []Text    `xml:"p"`
+}
+
+// Licence is a licence, by URI in its target, its text, or both.
+type Licence struct {
+	Target string
+	Text   string
+}
+
+// UnmarshalXML reads the target attribute and the licence text.
+func (l *Licence) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	l.Target = strings.TrimSpace(attr(start, "target"))
+	text, err := collectText(d)
+	l.Text = text
+	return err
+}
+
+// SeriesStmt names a series the text was published in.
+type SeriesStmt struct {
+	Titles []Title `xml:"title"`
+	IDNos  []IDNo  `xml:"idno"`
+}
+
+// NotesStmt holds notes on the text.
+type NotesStmt struct {
+	Notes []Text `xml:"note"`
+}
+
+// RespStmt credits names with a responsibility given in resp.
+type RespStmt struct {
+	Resp  []Text  `xml:"resp"`
+	Names []Agent `xml:",any"`
+}
+
+// SourceDesc describes the source the text was transcribed or digitized
+// from, or says in prose that it was born digital.
+type SourceDesc struct {
+	Bibls       []Bibl       `xml:"bibl"`
+	BiblStructs []BiblStruct `xml:"biblStruct"`
+	BiblFulls   []FileDesc   `xml:"biblFull"`
+	MsDescs     []MsDesc     `xml:"msDesc"`
+	P           []Text       `xml:"p"`
+}
+
+// BiblStruct is a structured citation of a printed source.
+type BiblStruct struct {
+	Analytic *Monogr `xml:"analytic"`
+	Monogr   Monogr  `xml:"monogr"`
+}
+
+// Monogr is the monographic (or, for analytic, article) level of a
+// biblStruct.
+type Monogr struct {
+	Titles  []Title `xml:"title"`
+	Authors []Agent `xml:"author"`
+	Editors []Agent `xml:"editor"`
+	IDNos   []IDNo  `xml:"idno"`
+	Imprint struct {
+		Publishers []Text `xml:"publisher"`
+		PubPlaces  []Text `xml:"pubPlace"`
+		Dates      []Date `xml:"date"`
+	} `xml:"imprint"`
+}
+
+// MsDesc describes a manuscript source.
+type MsDesc struct {
+	Identifier struct {
+		Settlement  Text   `xml:"settlement"`
+		Institution Text   `xml:"institution"`
+		Repository  Text   `xml:"repository"`
+		Collection  Text   `xml:"collection"`
+		IDNos       []IDNo `xml:"idno"`
+	} `xml:"msIdentifier"`
+	Heads    []Text  `xml:"head"`
+	OrigDate []Date  `xml:"history>origin>origDate"`
+	Authors  []Agent `xml:"msContents>msItem>author"`
+}
+
+// ProfileDesc describes the text's languages, classification, and
+// creation.
+type ProfileDesc struct {
+	Abstracts []Abstract `xml:"abstract"`
+	Creation  struct {
+		Dates []Date `xml:"date"`
+	} `xml:"creation"`
+	Languages []Language `xml:"langUsage>language"`
+	TextClass struct {
+		Keywords   []Keywords  `xml:"keywords"`
+		ClassCodes []ClassCode `xml:"classCode"`
+	} `xml:"textClass"`
+}
+
+// Abstract is a summary of the text, in a language.
+type Abstract struct {
+	Lang string
+	Text string
+}
+
+// UnmarshalXML reads xml:lang and the abstract's paragraphs.
+func (a *Abstract) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	a.Lang = attr(start, "lang")
+	text, err := collectText(d)
+	a.Text = text
+	return err
+}
+
+// Language is a language used in the text, by its BCP 47 ident, with the
+// approximate percentage of the text in it.
+type Language struct {
+	Ident string `xml:"ident,attr"`
+	Usage string `xml:"usage,attr"`
+}
+
+// Keywords are terms from one scheme, given as term elements or as the
+// items of a list.
+type Keywords struct {
+	Scheme string `xml:"scheme,attr"`
+	Terms  []Term `xml:"term"`
+	Items  []Term `xml:"list>item"`
+}
+
+// Term is a keyword, with the URI of the concept when there is one.
+type Term struct {
+	Ref  string
+	Key  string
+	Text string
+}
+
+// UnmarshalXML reads the ref and key attributes and the term text.
+func (t *Term) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	t.Ref = strings.TrimSpace(attr(start, "ref"))
+	t.Key = strings.TrimSpace(attr(start, "key"))
+	text, err := collectText(d)
+	t.Text = text
+	return err
+}
+
+// ClassCode is a classification number in a scheme.
+type ClassCode struct {
+	Scheme string
+	Text   string
+}
+
+// UnmarshalXML reads the scheme attribute and the code.
+func (c *ClassCode) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	c.Scheme = strings.TrimSpace(attr(start, "scheme"))
+	text, err := collectText(d)
+	c.Text = text
+	return err
+}
+
+// Title is a title with its type (main, sub, alt, …), level (a, m, j, s,
+// u), and language.
+type Title struct {
+	Type  string
+	Level string
+	Lang  string
+	Text  string
+}
+
+// UnmarshalXML reads the title's attributes and text.
+func (t *Title) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	t.Type = strings.TrimSpace(attr(start, "type"))
+	t.Level = strings.TrimSpace(attr(start, "level"))
+	t.Lang = attr(start, "lang")
+	text, err := collectText(d)
+	t.Text = text
+	return err
+}
+
+// Date is a date in its text and, when normalized, its when, from/to, or
+// notBefore/notAfter attributes.
+type Date struct {
+	When      string
+	From      string
+	To        string
+	NotBefore string
+	NotAfter  string
+	Text      string
+}
+
+// UnmarshalXML reads the date's attributes and text.
+func (dt *Date) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	dt.When = strings.TrimSpace(attr(start, "when"))
+	dt.From = strings.TrimSpace(attr(start, "from"))
+	dt.To = strings.TrimSpace(attr(start, "to"))
+	dt.NotBefore = strings.TrimSpace(attr(start, "notBefore"))
+	dt.NotAfter = strings.TrimSpace(attr(start, "notAfter"))
+	text, err := collectText(d)
+	dt.Text = text
+	return err
+}
+
+// IDNo is an identifier with its type (DOI, URI, ISBN, …).
+type IDNo struct {
+	Type string
+	Text string
+}
+
+// UnmarshalXML reads the type attribute and the identifier.
+func (id *IDNo) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	id.Type = strings.TrimSpace(attr(start, "type"))
+	text, err := collectText(d)
+	id.Text = text
+	return err
+}
+
+// Agent is a person or organization responsible for a text: an author,
+// editor, sponsor, funder, principal, or a name in a respStmt. Its name is
+// its text, or the persName or orgName inside it, with forename and
+// surname kept when they are marked up; idno, affiliation, and email
+// children are read apart from the name.
+type Agent struct {
+	Element      string // author, editor, persName, orgName, …
+	Role         string
+	Ref          string
+	Kind         string // persName or orgName, when marked up
+	Name         string
+	Forenames    []string
+	Surnames     []string
+	IDNos        []IDNo
+	Affiliations []string
+}
+
+// agentApart are the children of an agent that aren't part of its name.
+var agentApart = map[string]bool{
+	"idno": true, "affiliation": true, "email": true, "note": true, "date": true,
+}
+
+// UnmarshalXML reads an agent's name and identifiers.
+func (a *Agent) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	a.Element = start.Name.Local
+	a.Role = strings.TrimSpace(attr(start, "role"))
+	a.Ref = strings.TrimSpace(attr(start, "ref"))
+	if a.Element == "persName" || a.Element == "orgName" {
+		a.Kind = a.Element
+	}
+
+	var name strings.Builder
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch tok := tok.(type) {
+		case xml.CharData:
+			name.Write(tok)
+		case xml.StartElement:
+			switch local := tok.Name.Local; {
+			case local == "idno":
+				var id IDNo
+				if err := id.UnmarshalXML(d, tok); err != nil {
+					return err
+				}
+				a.IDNos = append(a.IDNos, id)
+			case local == "affiliation":
+				text, err := collectText(d)
+				if err != nil {
+					return err
+				}
+				if text != "" {
+					a.Affiliations = append(a.Affiliations, text)
+				}
+			case agentApart[local]:
+				if err := d.Skip(); err != nil {
+					return err
+				}
+			case local == "persName" || local == "orgName" || local == "name":
+				// The marked-up name stands for the agent
+				var inner Agent
+				if err := inner.UnmarshalXML(d, tok); err != nil {
+					return err
+				}
+				if a.Kind == "" {
+					a.Kind = inner.Kind
+				}
+				if a.Ref == "" {
+					a.Ref = inner.Ref
+				}
+				a.Forenames = append(a.Forenames, inner.Forenames...)
+				a.Surnames = append(a.Surnames, inner.Surnames...)
+				a.IDNos = append(a.IDNos, inner.IDNos...)
+				a.Affiliations = append(a.Affiliations, inner.Affiliations...)
+				name.WriteString(" " + inner.Name + " ")
+			default:
+				text, err := collectText(d)
+				if err != nil {
+					return err
+				}
+				switch local {
+				case "forename":
+					a.Forenames = append(a.Forenames, text)
+				case "surname":
+					a.Surnames = append(a.Surnames, text)
+				}
+				name.WriteString(" " + text + " ")
+			}
+		case xml.EndElement:
+			a.Name = strings.Trim(strings.Join(strings.Fields(name.String()), " "), " ,;")
+			return nil
+		}
+	}
+}
+
+// Bibl is a loosely structured citation. Its text is the whole citation;
+// the authors, titles, and dates marked up within it are kept as well.
+type Bibl struct {
+	Text    string
+	Authors []Agent
+	Titles  []Title
+	Dates   []Date
+}
+
+// UnmarshalXML reads the citation's text and its marked-up parts.
+func (b *Bibl) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var text strings.Builder
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch tok := tok.(type) {
+		case xml.CharData:
+			text.Write(tok)
+		case xml.StartElement:
+			switch tok.Name.Local {
+			case "author", "editor":
+				var agent Agent
+				if err := agent.UnmarshalXML(d, tok); err != nil {
+					return err
+				}
+				if tok.Name.Local == "author" {
+					b.Authors = append(b.Authors, agent)
+				}
+				text.WriteString(agent.Name)
+			case "title":
+				var title Title
+				if err := title.UnmarshalXML(d, tok); err != nil {
+					return err
+				}
+				b.Titles = append(b.Titles, title)
+				text.WriteString(title.Text)
+			case "date":
+				var date Date
+				if err := date.UnmarshalXML(d, tok); err != nil {
+					return err
+				}
+				b.Dates = append(b.Dates, date)
+				text.WriteString(date.Text)
+			default:
+				inner, err := collectText(d)
+				if err != nil {
+					return err
+				}
+				text.WriteString(inner)
+			}
+		case xml.EndElement:
+			b.Text = strings.Join(strings.Fields(text.String()), " ")
+			return nil
+		}
+	}
+}
+
+// Text is the character data of an element, with whitespace collapsed
+// and paragraphs separated by blank lines.
+type Text string
+
+// UnmarshalXML collects the character data beneath the element.
+func (t *Text) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	text, err := collectText(d)
+	*t = Text(text)
+	return err
+}
+
+// paragraphBreak marks the end of a block while collecting text; it cannot
+// appear in XML character data.
+const paragraphBreak = "\x00"
+
+// inlineElements are TEI phrase-level elements, which add no space around
+// their text (e.g. "<hi>W</hi>ork").
+var inlineElements = map[string]bool{
+	"hi": true, "emph": true, "title": true, "foreign": true, "term": true,
+	"ref": true, "ptr": true, "name": true, "persName": true, "orgName": true,
+	"placeName": true, "date": true, "num": true, "q": true, "quote": true,
+	"abbr": true, "expan": true, "choice": true, "orig": true, "reg": true,
+	"sic": true, "corr": true, "unclear": true, "supplied": true, "add": true,
+	"del": true, "soCalled": true, "mentioned": true, "gloss": true, "rs": true,
+}
+
+// blockElements end a paragraph.
+var blockElements = map[string]bool{"p": true, "ab": true, "item": true, "head": true}
+
+// collectText reads the rest of the current element and returns its text.
+// Editorial deletions and notes are left out; line breaks become spaces.
+func collectText(d *xml.Decoder) (string, error) {
+	var b strings.Builder
+	depth := 0
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return "", err
+		}
+		switch tok := tok.(type) {
+		case xml.CharData:
+			b.Write(tok)
+		case xml.StartElement:
+			switch tok.Name.Local {
+			case "del", "note", "fw":
+				if err := d.Skip(); err != nil {
+					return "", err
+				}
+				continue
+			}
+			depth++
+			if !inlineElements[tok.Name.Local] {
+				b.WriteByte(' ')
+			}
+		case xml.EndElement:
+			if depth == 0 {
+				var paras []string
+				for _, p := range strings.Split(b.String(), paragraphBreak) {
+					if p = strings.Join(strings.Fields(p), " "); p != "" {
+						paras = append(paras, p)
+					}
+				}
+				return strings.Join(paras, "\n\n"), nil
+			}
+			depth--
+			switch {
+			case blockElements[tok.Name.Local]:
+				b.WriteString(paragraphBreak)
+			case !inlineElements[tok.Name.Local]:
+				b.WriteByte(' ')
+			}
+		}
+	}
+}
+
+// attr returns the value of the start element's attribute with the local
+// name, in any namespace (xml:lang and xml:id included).
+func attr(start xml.StartElement, local string) string {
+	for _, a := range start.Attr {
+		if a.Name.Local == local {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// first returns the first non-empty text.
+func first(texts []Text) string {
+	for _, t := range texts {
+		if t != "" {
+			return string(t)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,64 @@
+package wikidata
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// writeQuickStatements writes entities as QuickStatements V1 commands:
+// tab-separated lines, starting with CREATE for new items, each naming the
+// item (LAST for the one just created), a property or label code, a
+// value, and any qualifier property and value pairs.
+func writeQuickStatements(w io.Writer, entities []*Entity) error {
+	bw := bufio.NewWriter(w)
+	for _, e := range entities {
+		subject := e.ID
+		if subject == "" {
+			subject = "LAST"
+			fmt.Fprintln(bw, "CREATE")
+		}
+		for _, lang := range slices.Sorted(maps.Keys(e.Labels)) {
+			fmt.Fprintf(bw, "%s\tL%s\t%s\n", subject, lang, qsString(e.Labels[lang].Value))
+		}
+		for _, lang := range slices.Sorted(maps.Keys(e.Descriptions)) {
+			fmt.Fprintf(bw, "%s\tD%s\t%s\n", subject, lang, qsString(e.Descriptions[lang].Value))
+		}
+		for _, property := range e.properties {
+			for _, claim := range e.Claims[property] {
+				line := []string{subject, property, qsValue(claim.MainSnak.DataValue)}
+				for _, qp := range claim.QualifiersOrder {
+					for _, q := range claim.Qualifiers[qp] {
+						line = append(line, qp, qsValue(q.DataValue))
+					}
+				}
+				fmt.Fprintln(bw, strings.Join(line, "\t"))
+			}
+		}
+	}
+	return bw.Flush()
+}
+
+// qsValue formats a value in QuickStatements syntax.
+func qsValue(v *DataValue) string {
+	switch val := v.Value.(type) {
+	case EntityID:
+		return val.ID
+	case MonolingualText:
+		return val.Language + ":" + qsString(val.Text)
+	case Time:
+		return fmt.Sprintf("%s/%d", val.Time, val.Precision)
+	case string:
+		return qsString(val)
+	}
+	return ""
+}
+
+// qsString quotes a string value. QuickStatements has no escape for
+// double quotes, so they are written as single quotes.
+func qsString(s string) string {
+	return `"` + strings.ReplaceAll(oneLine(s), `"`, `'`) + `"`
+}
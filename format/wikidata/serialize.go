@@ -0,0 +1,454 @@
+package wikidata
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/helpers"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+// instance is a class an item is an instance of (P31), with the English
+// label used in the item description.
+type instance struct {
+	qid   string
+	label string
+}
+
+var (
+	thesis         = instance{"Q1266946", "thesis"}
+	doctoralThesis = instance{"Q187685", "doctoral thesis"}
+	mastersThesis  = instance{"Q1907875", "master's thesis"}
+	creativeWork   = instance{"Q17537576", "creative work"}
+)
+
+// instanceTypes maps hub resource types to Wikidata classes. Types
+// without a close class are written as creative works.
+var instanceTypes = map[hubv1.ResourceTypeValue]instance{
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_ARTICLE:               {"Q13442814", "scholarly article"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_BOOK:                  {"Q571", "book"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_BOOK_CHAPTER:          {"Q1980247", "chapter"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_CONFERENCE_PAPER:      {"Q23927052", "conference paper"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_CONFERENCE_PROCEEDING: {"Q1143604", "proceedings"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_DATASET:               {"Q1172284", "data set"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_DISSERTATION:          doctoralThesis,
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_THESIS:                thesis,
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_IMAGE:                 {"Q478798", "image"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_JOURNAL:               {"Q5633421", "scientific journal"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_REPORT:                {"Q10870555", "report"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_TECHNICAL_REPORT:      {"Q3099732", "technical report"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_WORKING_PAPER:         {"Q1228945", "working paper"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_PREPRINT:              {"Q580922", "preprint"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_POSTER:                {"Q429785", "poster"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_PRESENTATION:          {"Q604733", "presentation"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_SOFTWARE:              {"Q7397", "software"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_MAP:                   {"Q4006", "map"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_NEWSPAPER_ARTICLE:     {"Q5707594", "news article"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_MANUSCRIPT:            {"Q87167", "manuscript"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_PATENT:                {"Q253623", "patent"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_STANDARD:              {"Q317623", "technical standard"},
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_WEBPAGE:               {"Q36774", "web page"},
+}
+
+// languages maps ISO 639 codes to the language's Wikimedia code and
+// Wikidata item, for labels, monolingual text, and P407.
+var languages = map[string]struct{ code, qid string }{
+	"en": {"en", "Q1860"}, "eng": {"en", "Q1860"},
+	"fr": {"fr", "Q150"}, "fre": {"fr", "Q150"}, "fra": {"fr", "Q150"},
+	"de": {"de", "Q188"}, "ger": {"de", "Q188"}, "deu": {"de", "Q188"},
+	"es": {"es", "Q1321"}, "spa": {"es", "Q1321"},
+	"it": {"it", "Q652"}, "ita": {"it", "Q652"},
+	"pt": {"pt", "Q5146"}, "por": {"pt", "Q5146"},
+	"nl": {"nl", "Q7411"}, "dut": {"nl", "Q7411"}, "nld": {"nl", "Q7411"},
+	"zh": {"zh", "Q7850"}, "chi": {"zh", "Q7850"}, "zho": {"zh", "Q7850"},
+	"ja": {"ja", "Q5287"}, "jpn": {"ja", "Q5287"},
+	"ko": {"ko", "Q9176"}, "kor": {"ko", "Q9176"},
+	"ru": {"ru", "Q7737"}, "rus": {"ru", "Q7737"},
+	"ar": {"ar", "Q13955"}, "ara": {"ar", "Q13955"},
+	"pl": {"pl", "Q809"}, "pol": {"pl", "Q809"},
+	"sv": {"sv", "Q9027"}, "swe": {"sv", "Q9027"},
+	"la": {"la", "Q397"}, "lat": {"la", "Q397"},
+}
+
+// licenses maps Creative Commons licenses, keyed as in "by-nc-4.0", to
+// their Wikidata items.
+var licenses = map[string]string{
+	"by-4.0":       "Q20007257",
+	"by-sa-4.0":    "Q18199165",
+	"by-nc-4.0":    "Q34179348",
+	"by-nd-4.0":    "Q36795408",
+	"by-nc-sa-4.0": "Q42553662",
+	"by-nc-nd-4.0": "Q24082749",
+	"cc0-1.0":      "Q6938433",
+}
+
+// idProperties maps hub identifier types to Wikidata external identifier
+// properties.
+var idProperties = map[hubv1.IdentifierType]string{
+	hubv1.IdentifierType_IDENTIFIER_TYPE_DOI:    "P356",
+	hubv1.IdentifierType_IDENTIFIER_TYPE_PMID:   "P698",
+	hubv1.IdentifierType_IDENTIFIER_TYPE_PMCID:  "P932",
+	hubv1.IdentifierType_IDENTIFIER_TYPE_ARXIV:  "P818",
+	hubv1.IdentifierType_IDENTIFIER_TYPE_HANDLE: "P1184",
+	hubv1.IdentifierType_IDENTIFIER_TYPE_URL:    "P953",
+}
+
+// qidPattern matches a Wikidata item URL such as
+// http://www.wikidata.org/entity/Q42.
+var qidPattern = regexp.MustCompile(`wikidata\.org/(?:wiki|entity)/(Q[1-9]\d*)/?$`)
+
+// viafPattern matches a VIAF cluster URL.
+var viafPattern = regexp.MustCompile(`viaf\.org/viaf/(\d+)`)
+
+// maxLabel is the longest label or description Wikidata accepts.
+const maxLabel = 250
+
+// Serialize writes hub records as QuickStatements commands or Wikibase
+// entity JSON. Records with nothing to write are skipped.
+func (f *Format) Serialize(w io.Writer, records []*hubv1.Record, opts *format.SerializeOptions) error {
+	if opts == nil {
+		opts = format.NewSerializeOptions()
+	}
+	if err := checkVariant(opts.Variant); err != nil {
+		return err
+	}
+
+	entities := make([]*Entity, 0, len(records))
+	for i, record := range records {
+		e := recordToEntity(record)
+		if e.isEmpty() {
+			slog.Warn("skipping record with nothing to write to Wikidata", "record", i)
+			continue
+		}
+		entities = append(entities, e)
+	}
+
+	if opts.Variant == VariantWikibase {
+		encoder := json.NewEncoder(w)
+		if opts.Pretty {
+			encoder.SetIndent("", "  ")
+		}
+		if len(entities) == 1 {
+			return encoder.Encode(entities[0])
+		}
+		return encoder.Encode(entities)
+	}
+	return writeQuickStatements(w, entities)
+}
+
+// checkVariant rejects output variants this format doesn't know.
+func checkVariant(variant string) error {
+	switch variant {
+	case "", VariantQuickStatements, VariantWikibase:
+		return nil
+	}
+	return fmt.Errorf("unknown wikidata variant %q (want %s or %s)", variant, VariantQuickStatements, VariantWikibase)
+}
+
+// recordToEntity converts a hub record to a Wikidata item.
+func recordToEntity(record *hubv1.Record) *Entity {
+	e := newEntity(recordQID(record))
+
+	lang, langQID := "en", ""
+	if l, ok := languages[languageKey(record.Language)]; ok {
+		lang, langQID = l.code, l.qid
+	}
+
+	class := instanceOf(record)
+	if record.ResourceType != nil || record.DegreeInfo != nil || record.Title != "" {
+		e.add("P31", itemValue(class.qid))
+	}
+
+	if title := oneLine(record.Title); title != "" {
+		e.Labels[lang] = Term{Language: lang, Value: truncate(title, maxLabel)}
+		e.add("P1476", monolingualValue(title, lang))
+	}
+
+	firstAuthor := addAuthors(e, record.Contributors)
+	if len(e.Labels) > 0 {
+		desc := class.label
+		if firstAuthor != "" {
+			desc += " by " + firstAuthor
+		}
+		e.Descriptions["en"] = Term{Language: "en", Value: truncate(desc, maxLabel)}
+	}
+
+	if d := publicationDate(record); d != nil {
+		e.add("P577", timeValue(d.Year, d.Month, d.Day))
+	}
+	if langQID != "" {
+		e.add("P407", itemValue(langQID))
+	}
+
+	for _, id := range record.Identifiers {
+		property, ok := idProperties[id.Type]
+		if !ok || qidPattern.MatchString(id.Value) {
+			continue
+		}
+		value := hub.NormalizeIdentifier(id.Value, id.Type)
+		if id.Type == hubv1.IdentifierType_IDENTIFIER_TYPE_DOI {
+			// Wikidata keeps DOIs in upper case
+			value = strings.ToUpper(value)
+		}
+		e.add(property, stringValue(value))
+	}
+
+	for _, r := range record.Rights {
+		if qid := licenseQID(r); qid != "" {
+			e.add("P275", itemValue(qid))
+		}
+	}
+
+	for _, s := range record.Subjects {
+		if qid := itemQID(s.Uri); qid != "" {
+			e.add("P921", itemValue(qid))
+		}
+	}
+
+	if di := record.DegreeInfo; di != nil {
+		if qid := itemQID(di.Institution); qid != "" {
+			e.add("P4101", itemValue(qid))
+		}
+	}
+
+	return e
+}
+
+// addAuthors adds each author as P50 when their item is known, or as P2093
+// otherwise, numbered with P1545. It returns the first author's name.
+func addAuthors(e *Entity, contributors []*hubv1.Contributor) string {
+	first := ""
+	ordinal := 0
+	for _, c := range contributors {
+		name := oneLine(c.Name)
+		if name == "" && c.ParsedName != nil {
+			name = oneLine(strings.TrimSpace(c.ParsedName.Given + " " + c.ParsedName.Family))
+		}
+		if name == "" || !isAuthor(c) {
+			continue
+		}
+		ordinal++
+		if first == "" {
+			first = displayName(c, name)
+		}
+
+		series := qualifier("P1545", stringValue(strconv.Itoa(ordinal)))
+		if qid := contributorQID(c); qid != "" {
+			e.add("P50", itemValue(qid), series)
+			continue
+		}
+		qualifiers := []Snak{series}
+		if orcid := contributorORCID(c); orcid != "" {
+			qualifiers = append(qualifiers, qualifier("P496", stringValue(orcid)))
+		}
+		if viaf := contributorVIAF(c); viaf != "" {
+			qualifiers = append(qualifiers, qualifier("P214", stringValue(viaf)))
+		}
+		e.add("P2093", stringValue(displayName(c, name)), qualifiers...)
+	}
+	return first
+}
+
+// isAuthor reports whether a contributor is an author or creator.
+// Contributors without a role are treated as authors.
+func isAuthor(c *hubv1.Contributor) bool {
+	code := helpers.NormalizeRole(c.Role)
+	if c.RoleCode != "" {
+		code = strings.ToLower(helpers.RelatorCodeFromURI(c.RoleCode))
+	}
+	switch code {
+	case "", "aut", "cre":
+		return true
+	}
+	return false
+}
+
+// displayName returns a person's name in direct order ("Jane Smith"), as
+// Wikidata writes author name strings.
+func displayName(c *hubv1.Contributor, name string) string {
+	if c.Type == hubv1.ContributorType_CONTRIBUTOR_TYPE_ORGANIZATION {
+		return name
+	}
+	p := c.ParsedName
+	if p == nil || p.Family == "" {
+		p = helpers.ParseName(name)
+	}
+	if p == nil || p.Family == "" {
+		return name
+	}
+	prefix := p.Prefix
+	if strings.HasPrefix(p.Family, prefix+" ") {
+		prefix = ""
+	}
+	parts := []string{p.Given, p.Middle, prefix, p.Family, p.Suffix}
+	var out []string
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return strings.Join(out, " ")
+}
+
+// instanceOf returns the item's class, distinguishing doctoral and
+// master's theses by degree level.
+func instanceOf(record *hubv1.Record) instance {
+	rt := hubv1.ResourceTypeValue_RESOURCE_TYPE_UNSPECIFIED
+	if record.ResourceType != nil {
+		rt = record.ResourceType.Type
+	}
+	isThesis := rt == hubv1.ResourceTypeValue_RESOURCE_TYPE_THESIS || rt == hubv1.ResourceTypeValue_RESOURCE_TYPE_DISSERTATION
+	if di := record.DegreeInfo; di != nil && (isThesis || rt == hubv1.ResourceTypeValue_RESOURCE_TYPE_UNSPECIFIED) {
+		level := strings.ToLower(di.DegreeLevel + " " + di.DegreeName)
+		switch {
+		case strings.Contains(level, "doctor") || strings.Contains(level, "ph.d") || strings.Contains(level, "phd"):
+			return doctoralThesis
+		case strings.Contains(level, "master"):
+			return mastersThesis
+		case rt == hubv1.ResourceTypeValue_RESOURCE_TYPE_UNSPECIFIED:
+			return thesis
+		}
+	}
+	if class, ok := instanceTypes[rt]; ok {
+		return class
+	}
+	return creativeWork
+}
+
+// publicationDate returns the issued, published, or degree date.
+func publicationDate(record *hubv1.Record) *hubv1.DateValue {
+	for _, dt := range []hubv1.DateType{
+		hubv1.DateType_DATE_TYPE_ISSUED,
+		hubv1.DateType_DATE_TYPE_PUBLISHED,
+	} {
+		if d := hub.GetDate(record, dt); d != nil && d.Year > 0 {
+			return d
+		}
+	}
+	if di := record.DegreeInfo; di != nil && di.Date != nil && di.Date.Year > 0 {
+		return di.Date
+	}
+	return nil
+}
+
+// recordQID returns the QID of the record's existing Wikidata item, if it
+// has one.
+func recordQID(record *hubv1.Record) string {
+	if qid := strings.TrimSpace(hub.GetExtraString(record, "wikidata_id")); qid != "" {
+		if itemValue(qid) != nil {
+			return qid
+		}
+		return itemQID(qid)
+	}
+	for _, id := range record.Identifiers {
+		if qid := itemQID(id.Value); qid != "" {
+			return qid
+		}
+	}
+	return ""
+}
+
+// contributorQID returns the QID of a contributor's Wikidata item from
+// their identifiers, authority URI, or URL.
+func contributorQID(c *hubv1.Contributor) string {
+	for _, id := range c.Identifiers {
+		if qid := itemQID(id.Value); qid != "" {
+			return qid
+		}
+	}
+	if qid := itemQID(c.AuthorityUri); qid != "" {
+		return qid
+	}
+	return itemQID(c.Url)
+}
+
+// contributorORCID returns a contributor's bare ORCID iD.
+func contributorORCID(c *hubv1.Contributor) string {
+	for _, id := range c.Identifiers {
+		if id.Type == hubv1.IdentifierType_IDENTIFIER_TYPE_ORCID {
+			return hub.NormalizeIdentifier(id.Value, id.Type)
+		}
+	}
+	return ""
+}
+
+// contributorVIAF returns a contributor's VIAF ID from a VIAF URL in
+// their identifiers or authority URI.
+func contributorVIAF(c *hubv1.Contributor) string {
+	candidates := []string{c.AuthorityUri}
+	for _, id := range c.Identifiers {
+		candidates = append(candidates, id.Value)
+	}
+	for _, s := range candidates {
+		if m := viafPattern.FindStringSubmatch(s); m != nil {
+			return m[1]
+		}
+	}
+	if strings.EqualFold(c.AuthoritySource, "viaf") {
+		if id := strings.TrimSpace(c.AuthorityUri); id != "" && strings.Trim(id, "0123456789") == "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// itemQID returns the QID in a Wikidata item URL, or "".
+func itemQID(s string) string {
+	if m := qidPattern.FindStringSubmatch(strings.TrimSpace(s)); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// licenseQID returns the Wikidata item for a Creative Commons license,
+// from its URI or license ID.
+func licenseQID(r *hubv1.Rights) string {
+	key := ""
+	if _, path, ok := strings.Cut(r.Uri, "creativecommons.org/"); ok {
+		parts := strings.Split(strings.Trim(path, "/"), "/")
+		switch {
+		case len(parts) >= 3 && parts[0] == "licenses":
+			key = parts[1] + "-" + parts[2]
+		case len(parts) >= 3 && parts[0] == "publicdomain" && parts[1] == "zero":
+			key = "cc0-" + parts[2]
+		}
+	}
+	if key == "" && r.License != "" {
+		key = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(r.License), " ", "-"))
+		key = strings.TrimPrefix(key, "cc-")
+		if key == "cc0" {
+			key = "cc0-1.0"
+		}
+	}
+	return licenses[key]
+}
+
+// languageKey returns the lowercased primary subtag of a language code.
+func languageKey(lang string) string {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	if i := strings.IndexAny(lang, "-_"); i > 0 {
+		lang = lang[:i]
+	}
+	return lang
+}
+
+// oneLine collapses whitespace, including newlines, to single spaces.
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// truncate shortens s to at most n runes.
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return strings.TrimSpace(string(r[:n-1])) + "…"
+}
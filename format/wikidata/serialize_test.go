@@ -0,0 +1,193 @@
+package wikidata
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+func thesisRecord() *hubv1.Record {
+	return &hubv1.Record{
+		Title:        "Sediment transport in the \"Lehigh Gap\"",
+		Language:     "eng",
+		ResourceType: &hubv1.ResourceType{Type: hubv1.ResourceTypeValue_RESOURCE_TYPE_THESIS},
+		DegreeInfo: &hubv1.DegreeInfo{
+			DegreeName:  "Doctor of Philosophy",
+			DegreeLevel: "Doctoral",
+			Institution: "http://www.wikidata.org/entity/Q1142658",
+		},
+		Contributors: []*hubv1.Contributor{
+			{
+				Name:         "Smith, Jane",
+				ParsedName:   &hubv1.ParsedName{Given: "Jane", Family: "Smith"},
+				RoleCode:     "relators:aut",
+				Identifiers:  []*hubv1.Identifier{hub.NewIdentifier("https://orcid.org/0000-0002-1825-0097", hubv1.IdentifierType_IDENTIFIER_TYPE_ORCID)},
+				AuthorityUri: "http://viaf.org/viaf/102333412",
+			},
+			{
+				Name:         "Jones, Robert",
+				Role:         "Thesis advisor",
+				AuthorityUri: "https://www.wikidata.org/wiki/Q42",
+			},
+		},
+		Dates: []*hubv1.DateValue{hub.NewDateFromYearMonth(2024, 5, hubv1.DateType_DATE_TYPE_ISSUED)},
+		Identifiers: []*hubv1.Identifier{
+			hub.NewIdentifier("10.1234/etd.5678", hubv1.IdentifierType_IDENTIFIER_TYPE_DOI),
+			hub.NewIdentifier("https://preserve.lehigh.edu/etd/5678", hubv1.IdentifierType_IDENTIFIER_TYPE_URL),
+		},
+		Rights: []*hubv1.Rights{{Uri: "https://creativecommons.org/licenses/by-nc/4.0/"}},
+		Subjects: []*hubv1.Subject{
+			{Value: "Sediment transport", Uri: "http://www.wikidata.org/entity/Q1140435"},
+			{Value: "Rivers"},
+		},
+	}
+}
+
+func serialize(t *testing.T, records []*hubv1.Record, variant string) string {
+	t.Helper()
+	opts := format.NewSerializeOptions()
+	opts.Variant = variant
+	var buf bytes.Buffer
+	if err := (&Format{}).Serialize(&buf, records, opts); err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+	return buf.String()
+}
+
+func TestSerializeQuickStatements(t *testing.T) {
+	got := serialize(t, []*hubv1.Record{thesisRecord()}, "")
+	want := strings.Join([]string{
+		"CREATE",
+		"LAST\tLen\t\"Sediment transport in the 'Lehigh Gap'\"",
+		"LAST\tDen\t\"doctoral thesis by Jane Smith\"",
+		"LAST\tP31\tQ187685",
+		"LAST\tP1476\ten:\"Sediment transport in the 'Lehigh Gap'\"",
+		"LAST\tP2093\t\"Jane Smith\"\tP1545\t\"1\"\tP496\t\"0000-0002-1825-0097\"\tP214\t\"102333412\"",
+		"LAST\tP577\t+2024-05-00T00:00:00Z/10",
+		"LAST\tP407\tQ1860",
+		"LAST\tP356\t\"10.1234/ETD.5678\"",
+		"LAST\tP953\t\"https://preserve.lehigh.edu/etd/5678\"",
+		"LAST\tP275\tQ34179348",
+		"LAST\tP921\tQ1140435",
+		"LAST\tP4101\tQ1142658",
+	}, "\n") + "\n"
+	if got != want {
+		t.Errorf("QuickStatements:\n got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestSerializeAuthorItems(t *testing.T) {
+	record := &hubv1.Record{
+		Title:        "Coauthored article",
+		ResourceType: &hubv1.ResourceType{Type: hubv1.ResourceTypeValue_RESOURCE_TYPE_ARTICLE},
+		Contributors: []*hubv1.Contributor{
+			{Name: "Douglas Adams", Identifiers: []*hubv1.Identifier{{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_URL, Value: "http://www.wikidata.org/entity/Q42"}}},
+			{Name: "Ana Rivera", RoleCode: "relators:aut"},
+			{Name: "Robert Jones", RoleCode: "relators:edt"},
+		},
+		Identifiers: []*hubv1.Identifier{{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_URL, Value: "https://www.wikidata.org/wiki/Q123456"}},
+	}
+	got := serialize(t, []*hubv1.Record{record}, VariantQuickStatements)
+
+	if strings.Contains(got, "CREATE") || !strings.HasPrefix(got, "Q123456\tLen\t") {
+		t.Errorf("expected edits to the existing item:\n%s", got)
+	}
+	for _, line := range []string{
+		"Q123456\tDen\t\"scholarly article by Douglas Adams\"",
+		"Q123456\tP31\tQ13442814",
+		"Q123456\tP50\tQ42\tP1545\t\"1\"",
+		"Q123456\tP2093\t\"Ana Rivera\"\tP1545\t\"2\"",
+	} {
+		if !strings.Contains(got, line+"\n") {
+			t.Errorf("missing %q in:\n%s", line, got)
+		}
+	}
+	if strings.Contains(got, "Jones") || strings.Contains(got, "P953") {
+		t.Errorf("editors and the item's own URL should not be written:\n%s", got)
+	}
+}
+
+func TestSerializeWikibaseJSON(t *testing.T) {
+	got := serialize(t, []*hubv1.Record{thesisRecord()}, VariantWikibase)
+
+	var entity map[string]any
+	if err := json.Unmarshal([]byte(got), &entity); err != nil {
+		t.Fatalf("single record should be one entity object: %v\n%s", err, got)
+	}
+	if entity["type"] != "item" || entity["id"] != nil {
+		t.Errorf("type/id: got %v, %v", entity["type"], entity["id"])
+	}
+
+	var e Entity
+	if err := json.Unmarshal([]byte(got), &e); err != nil {
+		t.Fatal(err)
+	}
+	if e.Labels["en"].Value != "Sediment transport in the \"Lehigh Gap\"" {
+		t.Errorf("label: got %v", e.Labels)
+	}
+	p31 := e.Claims["P31"]
+	if len(p31) != 1 || p31[0].MainSnak.DataValue.Type != "wikibase-entityid" {
+		t.Fatalf("P31: got %v", p31)
+	}
+	if id := p31[0].MainSnak.DataValue.Value.(map[string]any); id["id"] != "Q187685" || id["numeric-id"] != float64(187685) {
+		t.Errorf("P31 value: got %v", id)
+	}
+	p2093 := e.Claims["P2093"]
+	if len(p2093) != 1 || strings.Join(p2093[0].QualifiersOrder, ",") != "P1545,P496,P214" {
+		t.Errorf("P2093 qualifiers: got %v", p2093)
+	}
+	p577 := e.Claims["P577"]
+	if len(p577) != 1 {
+		t.Fatalf("P577: got %v", p577)
+	}
+	if tv := p577[0].MainSnak.DataValue.Value.(map[string]any); tv["time"] != "+2024-05-00T00:00:00Z" || tv["precision"] != float64(10) ||
+		tv["calendarmodel"] != gregorian {
+		t.Errorf("P577 value: got %v", tv)
+	}
+
+	batch := serialize(t, []*hubv1.Record{thesisRecord(), {Title: "Second"}}, VariantWikibase)
+	var entities []Entity
+	if err := json.Unmarshal([]byte(batch), &entities); err != nil || len(entities) != 2 {
+		t.Errorf("batch should be an array of 2 entities: %v\n%s", err, batch)
+	}
+}
+
+func TestSerializeInstanceOf(t *testing.T) {
+	tests := []struct {
+		name   string
+		record *hubv1.Record
+		want   string
+	}{
+		{"master's thesis", &hubv1.Record{ResourceType: &hubv1.ResourceType{Type: hubv1.ResourceTypeValue_RESOURCE_TYPE_THESIS}, DegreeInfo: &hubv1.DegreeInfo{DegreeName: "Master of Science"}}, "Q1907875"},
+		{"thesis", &hubv1.Record{ResourceType: &hubv1.ResourceType{Type: hubv1.ResourceTypeValue_RESOURCE_TYPE_THESIS}}, "Q1266946"},
+		{"dissertation", &hubv1.Record{ResourceType: &hubv1.ResourceType{Type: hubv1.ResourceTypeValue_RESOURCE_TYPE_DISSERTATION}}, "Q187685"},
+		{"degree without type", &hubv1.Record{DegreeInfo: &hubv1.DegreeInfo{DegreeLevel: "Bachelors"}}, "Q1266946"},
+		{"unmapped type", &hubv1.Record{ResourceType: &hubv1.ResourceType{Type: hubv1.ResourceTypeValue_RESOURCE_TYPE_AUDIO}}, "Q17537576"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := instanceOf(tt.record).qid; got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSerializeSkipsEmpty(t *testing.T) {
+	if got := serialize(t, []*hubv1.Record{{}}, ""); got != "" {
+		t.Errorf("expected no output, got %q", got)
+	}
+}
+
+func TestSerializeUnknownVariant(t *testing.T) {
+	opts := format.NewSerializeOptions()
+	opts.Variant = "rdf"
+	if err := (&Format{}).Serialize(&bytes.Buffer{}, []*hubv1.Record{thesisRecord()}, opts); err == nil {
+		t.Error("expected an error for an unknown variant")
+	}
+}
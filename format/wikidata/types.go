@@ -0,0 +1,177 @@
+package wikidata
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// gregorian is the calendar model of every time value written.
+const gregorian = "http://www.wikidata.org/entity/Q1985727"
+
+// Entity is a Wikibase item in the JSON shape wbeditentity accepts. It is
+// also the model the QuickStatements writer reads, so both variants carry
+// the same statements.
+type Entity struct {
+	ID           string             `json:"id,omitempty"`
+	Type         string             `json:"type"`
+	Labels       map[string]Term    `json:"labels,omitempty"`
+	Descriptions map[string]Term    `json:"descriptions,omitempty"`
+	Claims       map[string][]Claim `json:"claims,omitempty"`
+
+	// properties lists the claim properties in the order they were added,
+	// which JSON object keys don't keep
+	properties []string
+}
+
+// Term is a label or description in one language.
+type Term struct {
+	Language string `json:"language"`
+	Value    string `json:"value"`
+}
+
+// Claim is a statement: a main snak and its qualifiers.
+type Claim struct {
+	MainSnak        Snak              `json:"mainsnak"`
+	Type            string            `json:"type"`
+	Rank            string            `json:"rank"`
+	Qualifiers      map[string][]Snak `json:"qualifiers,omitempty"`
+	QualifiersOrder []string          `json:"qualifiers-order,omitempty"`
+}
+
+// Snak is a property and its value.
+type Snak struct {
+	SnakType  string     `json:"snaktype"`
+	Property  string     `json:"property"`
+	DataValue *DataValue `json:"datavalue,omitempty"`
+}
+
+// DataValue is a typed value: an EntityID, MonolingualText, Time, or string.
+type DataValue struct {
+	Value any    `json:"value"`
+	Type  string `json:"type"`
+}
+
+// EntityID is an item value.
+type EntityID struct {
+	EntityType string `json:"entity-type"`
+	NumericID  int    `json:"numeric-id"`
+	ID         string `json:"id"`
+}
+
+// MonolingualText is text in one language, such as a title.
+type MonolingualText struct {
+	Text     string `json:"text"`
+	Language string `json:"language"`
+}
+
+// Time is a point in time at year (9), month (10), or day (11) precision.
+type Time struct {
+	Time          string `json:"time"`
+	Timezone      int    `json:"timezone"`
+	Before        int    `json:"before"`
+	After         int    `json:"after"`
+	Precision     int    `json:"precision"`
+	CalendarModel string `json:"calendarmodel"`
+}
+
+// newEntity returns an empty item, to be created or, when id is set,
+// edited.
+func newEntity(id string) *Entity {
+	return &Entity{
+		ID:           id,
+		Type:         "item",
+		Labels:       map[string]Term{},
+		Descriptions: map[string]Term{},
+		Claims:       map[string][]Claim{},
+	}
+}
+
+// isEmpty reports whether the entity has nothing to write.
+func (e *Entity) isEmpty() bool {
+	return len(e.Labels) == 0 && len(e.Descriptions) == 0 && len(e.Claims) == 0
+}
+
+// add appends a statement with the given value and qualifiers.
+func (e *Entity) add(property string, value *DataValue, qualifiers ...Snak) {
+	if value == nil {
+		return
+	}
+	claim := Claim{
+		MainSnak: Snak{SnakType: "value", Property: property, DataValue: value},
+		Type:     "statement",
+		Rank:     "normal",
+	}
+	for _, q := range qualifiers {
+		if claim.Qualifiers == nil {
+			claim.Qualifiers = map[string][]Snak{}
+		}
+		if _, ok := claim.Qualifiers[q.Property]; !ok {
+			claim.QualifiersOrder = append(claim.QualifiersOrder, q.Property)
+		}
+		claim.Qualifiers[q.Property] = append(claim.Qualifiers[q.Property], q)
+	}
+	if _, ok := e.Claims[property]; !ok {
+		e.properties = append(e.properties, property)
+	}
+	e.Claims[property] = append(e.Claims[property], claim)
+}
+
+// qualifier returns a qualifier snak.
+func qualifier(property string, value *DataValue) Snak {
+	return Snak{SnakType: "value", Property: property, DataValue: value}
+}
+
+// itemValue returns an item value for a QID such as "Q5".
+func itemValue(qid string) *DataValue {
+	n, err := strconv.Atoi(strings.TrimPrefix(qid, "Q"))
+	if err != nil || !strings.HasPrefix(qid, "Q") {
+		return nil
+	}
+	return &DataValue{
+		Value: EntityID{EntityType: "item", NumericID: n, ID: qid},
+		Type:  "wikibase-entityid",
+	}
+}
+
+// stringValue returns a string value, used for external identifiers and
+// URLs as well as plain strings.
+func stringValue(s string) *DataValue {
+	if s == "" {
+		return nil
+	}
+	return &DataValue{Value: s, Type: "string"}
+}
+
+// monolingualValue returns text in a language.
+func monolingualValue(text, language string) *DataValue {
+	if text == "" {
+		return nil
+	}
+	return &DataValue{Value: MonolingualText{Text: text, Language: language}, Type: "monolingualtext"}
+}
+
+// timeValue returns a Gregorian date at the precision the parts give.
+func timeValue(year, month, day int32) *DataValue {
+	if year <= 0 {
+		return nil
+	}
+	precision := 9
+	switch {
+	case month > 0 && day > 0:
+		precision = 11
+	case month > 0:
+		precision = 10
+		day = 0
+	default:
+		month, day = 0, 0
+	}
+	return &DataValue{
+		Value: Time{
+			Time:          fmt.Sprintf("+%04d-%02d-%02dT00:00:00Z", year, month, day),
+			Precision:     precision,
+			CalendarModel: gregorian,
+		},
+		Type: "time",
+	}
+}
@@ -0,0 +1,85 @@
+// Package wikidata provides a serializer that writes hub records as
+// Wikidata items, for pushing theses and other works to Wikidata.
+//
+// Two variants are written, selected with SerializeOptions.Variant:
+//   - quickstatements (the default): QuickStatements V1 commands, one
+//     CREATE block per record, for pasting into the QuickStatements tool
+//   - wikibase: Wikibase entity JSON, the data argument wbeditentity
+//     takes; a single record is written as one entity, a batch as an array
+//
+// Each item gets an English (or the record's language) label and a
+// description, and the statements:
+//   - P31 instance of, from the resource type (and degree level for theses)
+//   - P1476 title
+//   - P50 author for authors whose Wikidata item is known, from a
+//     wikidata.org identifier or authority URI; other authors are written
+//     as P2093 author name string, qualified with their ORCID iD (P496)
+//     and VIAF ID (P214) so they can be promoted to P50 later. Both carry
+//     a P1545 series ordinal.
+//   - P577 publication date, P407 language of work
+//   - P356 DOI, P698 PubMed ID, P932 PMCID, P818 arXiv ID, P1184 Handle ID,
+//     P953 full work available at URL
+//   - P275 copyright license for Creative Commons licenses
+//   - P921 main subject for subjects with a Wikidata URI, and P4101
+//     dissertation submitted to when the degree institution is a Wikidata
+//     item
+//
+// A record with a Wikidata identifier (a wikidata.org URL, or the
+// wikidata_id extra field) adds statements to that item instead of
+// creating one.
+package wikidata
+
+import (
+	"github.com/lehigh-university-libraries/crosswalk/format"
+)
+
+// Output variants, selected with SerializeOptions.Variant.
+const (
+	VariantQuickStatements = "quickstatements"
+	VariantWikibase        = "wikibase"
+)
+
+// Format implements the Wikidata format.
+type Format struct{}
+
+// Ensure Format implements the interfaces
+var (
+	_ format.Format         = (*Format)(nil)
+	_ format.Serializer     = (*Format)(nil)
+	_ format.FieldDescriber = (*Format)(nil)
+)
+
+// Name returns the format identifier.
+func (f *Format) Name() string {
+	return "wikidata"
+}
+
+// Description returns a human-readable format description.
+func (f *Format) Description() string {
+	return "Wikidata items (QuickStatements or Wikibase JSON)"
+}
+
+// Extensions returns file extensions associated with this format.
+func (f *Format) Extensions() []string {
+	return []string{"qs", "json"}
+}
+
+// writtenFields are the hub record fields Serialize writes.
+var writtenFields = []string{
+	"title", "contributors", "dates", "resource_type", "language",
+	"identifiers", "rights",
+}
+
+// WrittenFields returns the hub record fields Serialize writes.
+func (f *Format) WrittenFields(opts *format.SerializeOptions) []string {
+	return writtenFields
+}
+
+// CanParse returns false; Wikidata is an output-only format.
+func (f *Format) CanParse(_ []byte) bool {
+	return false
+}
+
+func init() {
+	format.Register(&Format{})
+}
@@ -0,0 +1,522 @@
+package zenodo
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/helpers"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+// uploadTypes maps Zenodo upload types, and publication and image
+// subtypes as "type-subtype", to hub resource types.
+var uploadTypes = map[string]hubv1.ResourceTypeValue{
+	"publication":                       hubv1.ResourceTypeValue_RESOURCE_TYPE_TEXT,
+	"publication-article":               hubv1.ResourceTypeValue_RESOURCE_TYPE_ARTICLE,
+	"publication-book":                  hubv1.ResourceTypeValue_RESOURCE_TYPE_BOOK,
+	"publication-section":               hubv1.ResourceTypeValue_RESOURCE_TYPE_BOOK_CHAPTER,
+	"publication-conferencepaper":       hubv1.ResourceTypeValue_RESOURCE_TYPE_CONFERENCE_PAPER,
+	"publication-patent":                hubv1.ResourceTypeValue_RESOURCE_TYPE_PATENT,
+	"publication-preprint":              hubv1.ResourceTypeValue_RESOURCE_TYPE_PREPRINT,
+	"publication-report":                hubv1.ResourceTypeValue_RESOURCE_TYPE_REPORT,
+	"publication-technicalnote":         hubv1.ResourceTypeValue_RESOURCE_TYPE_TECHNICAL_REPORT,
+	"publication-thesis":                hubv1.ResourceTypeValue_RESOURCE_TYPE_THESIS,
+	"publication-workingpaper":          hubv1.ResourceTypeValue_RESOURCE_TYPE_WORKING_PAPER,
+	"publication-deliverable":           hubv1.ResourceTypeValue_RESOURCE_TYPE_REPORT,
+	"publication-milestone":             hubv1.ResourceTypeValue_RESOURCE_TYPE_REPORT,
+	"publication-proposal":              hubv1.ResourceTypeValue_RESOURCE_TYPE_TEXT,
+	"publication-datamanagementplan":    hubv1.ResourceTypeValue_RESOURCE_TYPE_TEXT,
+	"publication-softwaredocumentation": hubv1.ResourceTypeValue_RESOURCE_TYPE_TEXT,
+	"poster":                            hubv1.ResourceTypeValue_RESOURCE_TYPE_POSTER,
+	"presentation":                      hubv1.ResourceTypeValue_RESOURCE_TYPE_PRESENTATION,
+	"dataset":                           hubv1.ResourceTypeValue_RESOURCE_TYPE_DATASET,
+	"image":                             hubv1.ResourceTypeValue_RESOURCE_TYPE_IMAGE,
+	"video":                             hubv1.ResourceTypeValue_RESOURCE_TYPE_VIDEO,
+	"software":                          hubv1.ResourceTypeValue_RESOURCE_TYPE_SOFTWARE,
+	"physicalobject":                    hubv1.ResourceTypeValue_RESOURCE_TYPE_OBJECT,
+}
+
+// relations maps Zenodo related identifier relations, lowercased, to hub
+// relation types.
+var relations = map[string]hubv1.RelationType{
+	"ispartof":            hubv1.RelationType_RELATION_TYPE_PART_OF,
+	"haspart":             hubv1.RelationType_RELATION_TYPE_HAS_PART,
+	"cites":               hubv1.RelationType_RELATION_TYPE_CITES,
+	"iscitedby":           hubv1.RelationType_RELATION_TYPE_IS_CITED_BY,
+	"references":          hubv1.RelationType_RELATION_TYPE_REFERENCES,
+	"isreferencedby":      hubv1.RelationType_RELATION_TYPE_IS_CITED_BY,
+	"issupplementto":      hubv1.RelationType_RELATION_TYPE_IS_SUPPLEMENT_TO,
+	"issupplementedby":    hubv1.RelationType_RELATION_TYPE_SUPPLEMENTED_BY,
+	"isversionof":         hubv1.RelationType_RELATION_TYPE_VERSION_OF,
+	"isnewversionof":      hubv1.RelationType_RELATION_TYPE_VERSION_OF,
+	"hasversion":          hubv1.RelationType_RELATION_TYPE_HAS_VERSION,
+	"ispreviousversionof": hubv1.RelationType_RELATION_TYPE_HAS_VERSION,
+	"obsoletes":           hubv1.RelationType_RELATION_TYPE_REPLACES,
+	"continues":           hubv1.RelationType_RELATION_TYPE_REPLACES,
+	"isobsoletedby":       hubv1.RelationType_RELATION_TYPE_IS_REPLACED_BY,
+	"iscontinuedby":       hubv1.RelationType_RELATION_TYPE_IS_REPLACED_BY,
+	"isvariantformof":     hubv1.RelationType_RELATION_TYPE_FORMAT_OF,
+	"isoriginalformof":    hubv1.RelationType_RELATION_TYPE_HAS_FORMAT,
+	"isderivedfrom":       hubv1.RelationType_RELATION_TYPE_DERIVED_FROM,
+	"issourceof":          hubv1.RelationType_RELATION_TYPE_SOURCE_OF,
+	"documents":           hubv1.RelationType_RELATION_TYPE_DOCUMENTS,
+	"isdocumentedby":      hubv1.RelationType_RELATION_TYPE_IS_DOCUMENTED_BY,
+	"describes":           hubv1.RelationType_RELATION_TYPE_DESCRIBES,
+	"isdescribedby":       hubv1.RelationType_RELATION_TYPE_IS_DESCRIBED_BY,
+	"isidenticalto":       hubv1.RelationType_RELATION_TYPE_IDENTICAL_TO,
+	"requires":            hubv1.RelationType_RELATION_TYPE_REQUIRES,
+	"isrequiredby":        hubv1.RelationType_RELATION_TYPE_REQUIRED_BY,
+	"reviews":             hubv1.RelationType_RELATION_TYPE_REVIEWS,
+}
+
+// contributorTypes maps Zenodo contributor types to MARC relator codes.
+// Types without a close relator are kept as the role with no code.
+var contributorTypes = map[string]string{
+	"DataCollector":      "dtc",
+	"DataCurator":        "cur",
+	"Distributor":        "dst",
+	"Editor":             "edt",
+	"HostingInstitution": "his",
+	"Producer":           "pro",
+	"Researcher":         "res",
+	"RightsHolder":       "cph",
+	"Sponsor":            "spn",
+	"Supervisor":         "ths",
+	"Other":              "oth",
+}
+
+// organizationTypes are contributor types that name organizations.
+var organizationTypes = map[string]bool{
+	"Distributor":           true,
+	"HostingInstitution":    true,
+	"RegistrationAgency":    true,
+	"RegistrationAuthority": true,
+	"ResearchGroup":         true,
+	"Sponsor":               true,
+}
+
+// schemes maps Zenodo identifier schemes to hub identifier types.
+var schemes = map[string]hubv1.IdentifierType{
+	"doi":    hubv1.IdentifierType_IDENTIFIER_TYPE_DOI,
+	"handle": hubv1.IdentifierType_IDENTIFIER_TYPE_HANDLE,
+	"isbn":   hubv1.IdentifierType_IDENTIFIER_TYPE_ISBN,
+	"issn":   hubv1.IdentifierType_IDENTIFIER_TYPE_ISSN,
+	"eissn":  hubv1.IdentifierType_IDENTIFIER_TYPE_ISSN,
+	"pmid":   hubv1.IdentifierType_IDENTIFIER_TYPE_PMID,
+	"pmcid":  hubv1.IdentifierType_IDENTIFIER_TYPE_PMCID,
+	"arxiv":  hubv1.IdentifierType_IDENTIFIER_TYPE_ARXIV,
+	"url":    hubv1.IdentifierType_IDENTIFIER_TYPE_URL,
+	"orcid":  hubv1.IdentifierType_IDENTIFIER_TYPE_ORCID,
+	"isni":   hubv1.IdentifierType_IDENTIFIER_TYPE_ISNI,
+	"ark":    hubv1.IdentifierType_IDENTIFIER_TYPE_ARK,
+	"urn":    hubv1.IdentifierType_IDENTIFIER_TYPE_URN,
+	"purl":   hubv1.IdentifierType_IDENTIFIER_TYPE_PURL,
+}
+
+// Parse reads Zenodo record JSON and returns hub records. The input may
+// be a single record, an array of records, or a search response whose
+// records are in hits.hits.
+func (f *Format) Parse(r io.Reader, opts *format.ParseOptions) ([]*hubv1.Record, error) {
+	if opts == nil {
+		opts = format.NewParseOptions()
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("reading input: %w", err)
+	}
+
+	zrecords, err := decodeRecords(data)
+	if err != nil {
+		return nil, fmt.Errorf("parsing Zenodo JSON: %w", err)
+	}
+	if len(zrecords) == 0 {
+		return nil, fmt.Errorf("no Zenodo records found in input")
+	}
+
+	records := make([]*hubv1.Record, 0, len(zrecords))
+	for _, z := range zrecords {
+		records = append(records, recordToHub(z, opts))
+	}
+	return records, nil
+}
+
+// decodeRecords decodes a single record, an array of records, or a
+// search response.
+func decodeRecords(data []byte) ([]*Record, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	if data[0] == '[' {
+		var records []*Record
+		if err := json.Unmarshal(data, &records); err != nil {
+			return nil, err
+		}
+		return records, nil
+	}
+
+	var probe map[string]json.RawMessage
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, err
+	}
+	if _, ok := probe["hits"]; ok {
+		var resp SearchResponse
+		if err := json.Unmarshal(data, &resp); err != nil {
+			return nil, err
+		}
+		return resp.Hits.Hits, nil
+	}
+	if _, ok := probe["metadata"]; !ok {
+		return nil, fmt.Errorf("expected a record with \"metadata\" or a search response with \"hits\"")
+	}
+
+	var rec Record
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return nil, err
+	}
+	return []*Record{&rec}, nil
+}
+
+// recordToHub converts a Zenodo record to a hub record.
+func recordToHub(z *Record, opts *format.ParseOptions) *hubv1.Record {
+	m := &z.Metadata
+	record := &hubv1.Record{
+		Title:           strings.TrimSpace(m.Title),
+		Abstract:        opts.CleanText(m.Description),
+		Language:        m.Language,
+		Version:         m.Version,
+		AccessCondition: m.AccessConditions,
+		IsPublic:        m.AccessRight == "open",
+	}
+	if notes := opts.CleanText(m.Notes); notes != "" {
+		record.Notes = append(record.Notes, notes)
+	}
+
+	record.ResourceType = resourceType(m.ResourceType)
+
+	for _, p := range m.Creators {
+		if c := personToContributor(p, "cre"); c != nil {
+			record.Contributors = append(record.Contributors, c)
+		}
+	}
+	for _, p := range m.Contributors {
+		code, ok := contributorTypes[p.Type]
+		c := personToContributor(p, code)
+		if c == nil {
+			continue
+		}
+		if !ok {
+			c.Role = p.Type
+		}
+		record.Contributors = append(record.Contributors, c)
+	}
+
+	if d := parseDate(m.PublicationDate, hubv1.DateType_DATE_TYPE_ISSUED); d != nil {
+		record.Dates = append(record.Dates, d)
+	}
+	if d := parseDate(m.EmbargoDate, hubv1.DateType_DATE_TYPE_AVAILABLE); d != nil {
+		record.Dates = append(record.Dates, d)
+	}
+	for _, dr := range m.Dates {
+		if d := dateRange(dr); d != nil {
+			record.Dates = append(record.Dates, d)
+		}
+	}
+
+	for _, kw := range m.Keywords {
+		if kw = strings.TrimSpace(kw); kw != "" {
+			record.Subjects = append(record.Subjects, &hubv1.Subject{
+				Value:      kw,
+				Vocabulary: hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_KEYWORDS,
+			})
+		}
+	}
+	for _, s := range m.Subjects {
+		subject := &hubv1.Subject{
+			Value:      s.Term,
+			Vocabulary: hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LOCAL,
+		}
+		if strings.HasPrefix(s.Identifier, "http") {
+			subject.Uri = s.Identifier
+		} else {
+			subject.SourceId = s.Identifier
+		}
+		record.Subjects = append(record.Subjects, subject)
+	}
+
+	if m.License != nil && m.License.ID != "" {
+		record.Rights = append(record.Rights, licenseRights(m.License.ID))
+	}
+
+	doi := m.DOI
+	if doi == "" {
+		doi = z.DOI
+	}
+	if doi != "" {
+		record.Identifiers = append(record.Identifiers,
+			hub.NewIdentifier(doi, hubv1.IdentifierType_IDENTIFIER_TYPE_DOI))
+	}
+	if z.Links.HTML != "" {
+		record.Identifiers = append(record.Identifiers,
+			hub.NewIdentifier(z.Links.HTML, hubv1.IdentifierType_IDENTIFIER_TYPE_URL))
+	}
+	for _, ai := range m.AlternateIdentifiers {
+		if id := toIdentifier(ai); id != nil {
+			record.Identifiers = append(record.Identifiers, id)
+		}
+	}
+	for _, ri := range m.RelatedIdentifiers {
+		if strings.EqualFold(ri.Relation, "isAlternateIdentifier") {
+			if id := toIdentifier(ri); id != nil {
+				record.Identifiers = append(record.Identifiers, id)
+			}
+			continue
+		}
+		record.Relations = append(record.Relations, toRelation(ri))
+	}
+	if z.ConceptDOI != "" && z.ConceptDOI != doi {
+		// Every version of a record shares its concept DOI
+		record.Relations = append(record.Relations, &hubv1.Relation{
+			Type:         hubv1.RelationType_RELATION_TYPE_VERSION_OF,
+			TargetId:     hub.NormalizeIdentifier(z.ConceptDOI, hubv1.IdentifierType_IDENTIFIER_TYPE_DOI),
+			TargetIdType: hubv1.IdentifierType_IDENTIFIER_TYPE_DOI,
+		})
+	}
+	for _, ref := range m.References {
+		if ref = strings.TrimSpace(ref); ref != "" {
+			record.Relations = append(record.Relations,
+				hub.NewRelation(hubv1.RelationType_RELATION_TYPE_REFERENCES, ref))
+		}
+	}
+
+	if j := m.Journal; j != nil && j.Title != "" {
+		record.Publication = &hubv1.PublicationDetails{
+			Title:  j.Title,
+			Volume: j.Volume,
+			Issue:  j.Issue,
+			Pages:  j.Pages,
+		}
+	}
+	if p := m.PartOf; p != nil && p.Title != "" {
+		record.Publication = &hubv1.PublicationDetails{Title: p.Title, Pages: p.Pages}
+	}
+	if im := m.Imprint; im != nil {
+		record.Publisher = im.Publisher
+		record.PlacePublished = im.Place
+		if im.ISBN != "" {
+			record.Identifiers = append(record.Identifiers,
+				hub.NewIdentifier(im.ISBN, hubv1.IdentifierType_IDENTIFIER_TYPE_ISBN))
+		}
+	}
+	if th := m.Thesis; th != nil {
+		if th.University != "" {
+			record.DegreeInfo = &hubv1.DegreeInfo{Institution: th.University}
+		}
+		for _, p := range th.Supervisors {
+			if c := personToContributor(p, "ths"); c != nil {
+				record.Contributors = append(record.Contributors, c)
+			}
+		}
+	}
+
+	for _, g := range m.Grants {
+		funder := &hubv1.Funder{
+			Name:       g.Funder.Name,
+			AwardTitle: g.Title,
+			AwardUri:   g.URL,
+		}
+		if g.Funder.DOI != "" {
+			funder.Identifier = g.Funder.DOI
+			funder.IdentifierType = "Crossref Funder ID"
+		}
+		if g.Code != "" {
+			funder.AwardNumbers = []string{g.Code}
+		}
+		record.Funders = append(record.Funders, funder)
+	}
+
+	for _, zf := range z.Files {
+		file := &hubv1.File{
+			Name:      zf.Key,
+			Path:      zf.Links.Self,
+			SizeBytes: zf.Size,
+		}
+		if algo, value, ok := strings.Cut(zf.Checksum, ":"); ok {
+			file.Checksums = append(file.Checksums, hub.NewChecksum(algo, value))
+		}
+		record.Files = append(record.Files, file)
+	}
+
+	if len(m.Communities) > 0 {
+		ids := make([]any, 0, len(m.Communities))
+		for _, c := range m.Communities {
+			ids = append(ids, c.ID)
+		}
+		hub.SetExtra(record, "zenodo_communities", ids)
+	}
+	if m.AccessRight != "" {
+		hub.SetExtra(record, "zenodo_access_right", m.AccessRight)
+	}
+	if z.ID != "" {
+		hub.SetExtra(record, "zenodo_id", string(z.ID))
+	}
+
+	record.SourceInfo = &hubv1.SourceInfo{
+		Format:   "zenodo",
+		SourceId: string(z.ID),
+	}
+	return record
+}
+
+func resourceType(rt ResourceType) *hubv1.ResourceType {
+	if rt.Type == "" {
+		return nil
+	}
+	original := rt.Type
+	if rt.Subtype != "" {
+		original += "-" + rt.Subtype
+	}
+	t, ok := uploadTypes[original]
+	if !ok {
+		t, ok = uploadTypes[rt.Type]
+	}
+	if !ok {
+		t = hubv1.ResourceTypeValue_RESOURCE_TYPE_OTHER
+	}
+	return &hubv1.ResourceType{Type: t, Original: original, Vocabulary: "zenodo"}
+}
+
+// personToContributor converts a Zenodo creator or contributor, giving it
+// the relator code when one is known.
+func personToContributor(p Person, code string) *hubv1.Contributor {
+	name := strings.TrimSpace(p.Name)
+	if name == "" {
+		return nil
+	}
+	c := &hubv1.Contributor{
+		Name: name,
+		Type: hubv1.ContributorType_CONTRIBUTOR_TYPE_PERSON,
+	}
+	switch {
+	case organizationTypes[p.Type]:
+		c.Type = hubv1.ContributorType_CONTRIBUTOR_TYPE_ORGANIZATION
+	case strings.Contains(name, ","):
+		// Zenodo asks for person names as "Family, Given"
+		c.ParsedName = helpers.ParseName(name)
+	}
+	if code != "" {
+		c.RoleCode = "relators:" + code
+		c.Role = helpers.RelatorLabel(code)
+	}
+	if p.ORCID != "" {
+		c.Identifiers = append(c.Identifiers,
+			hub.NewIdentifier(p.ORCID, hubv1.IdentifierType_IDENTIFIER_TYPE_ORCID))
+	}
+	if aff := strings.TrimSpace(p.Affiliation); aff != "" {
+		c.Affiliations = []*hubv1.Affiliation{{Name: aff}}
+	}
+	return c
+}
+
+// parseDate parses a Zenodo ISO 8601 date, which may be reduced to a year
+// or year and month.
+func parseDate(s string, dateType hubv1.DateType) *hubv1.DateValue {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil
+	}
+	d, err := helpers.ParseEDTF(s, dateType)
+	if err != nil {
+		return nil
+	}
+	return d
+}
+
+// dateRange converts a Zenodo dates entry; a start and end make a range.
+func dateRange(dr DateRange) *hubv1.DateValue {
+	dateType := hubv1.DateType_DATE_TYPE_OTHER
+	switch strings.ToLower(dr.Type) {
+	case "collected":
+		dateType = hubv1.DateType_DATE_TYPE_COLLECTED
+	case "valid":
+		dateType = hubv1.DateType_DATE_TYPE_VALID
+	}
+	value := dr.Start
+	if dr.Start != "" && dr.End != "" && dr.Start != dr.End {
+		value = dr.Start + "/" + dr.End
+	} else if value == "" {
+		value = dr.End
+	}
+	return parseDate(value, dateType)
+}
+
+// licenseRights returns rights for a Zenodo license ID, with the license's
+// URI for Creative Commons licenses.
+func licenseRights(id string) *hubv1.Rights {
+	id = strings.ToLower(strings.TrimSpace(id))
+	rights := &hubv1.Rights{License: id}
+	if uri := ccLicenseURI(id); uri != "" {
+		rights.Uri = uri
+		rights.Statement = hub.LabelForRightsURI(uri)
+	}
+	return rights
+}
+
+// ccLicenseURI returns the Creative Commons deed URI for a license ID such
+// as "cc-by-4.0" or "cc0-1.0".
+func ccLicenseURI(id string) string {
+	if id == "cc0-1.0" || id == "cc-zero" {
+		return "https://creativecommons.org/publicdomain/zero/1.0/"
+	}
+	rest, ok := strings.CutPrefix(id, "cc-")
+	if !ok {
+		return ""
+	}
+	i := strings.LastIndex(rest, "-")
+	if i < 0 {
+		return ""
+	}
+	return "https://creativecommons.org/licenses/" + rest[:i] + "/" + rest[i+1:] + "/"
+}
+
+// toIdentifier converts an alternate identifier to a hub identifier.
+func toIdentifier(ri RelatedIdentifier) *hubv1.Identifier {
+	value := strings.TrimSpace(ri.Identifier)
+	if value == "" {
+		return nil
+	}
+	return hub.NewIdentifier(value, schemes[strings.ToLower(ri.Scheme)])
+}
+
+// toRelation converts a related identifier to a hub relation.
+func toRelation(ri RelatedIdentifier) *hubv1.Relation {
+	relType, ok := relations[strings.ToLower(ri.Relation)]
+	if !ok {
+		relType = hubv1.RelationType_RELATION_TYPE_RELATED_TO
+	}
+	id := toIdentifier(ri)
+	rel := &hubv1.Relation{Type: relType}
+	if id != nil {
+		rel.TargetId = id.Value
+		rel.TargetIdType = id.Type
+		if id.Type == hubv1.IdentifierType_IDENTIFIER_TYPE_URL {
+			rel.TargetUri = id.Value
+		}
+	}
+	if ri.ResourceType != "" {
+		rel.TargetResourceType = uploadTypes[ri.ResourceType]
+		if rel.TargetResourceType == hubv1.ResourceTypeValue_RESOURCE_TYPE_UNSPECIFIED {
+			if t, _, ok := strings.Cut(ri.ResourceType, "-"); ok {
+				rel.TargetResourceType = uploadTypes[t]
+			}
+		}
+	}
+	return rel
+}
@@ -0,0 +1,180 @@
+package zenodo
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+func parseFile(t *testing.T, path string) []*hubv1.Record {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	records, err := (&Format{}).Parse(f, nil)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	return records
+}
+
+func TestParse(t *testing.T) {
+	records := parseFile(t, "testdata/record.json")
+	if len(records) != 1 {
+		t.Fatalf("expected 1 record, got %d", len(records))
+	}
+	r := records[0]
+
+	if r.Title != "Lehigh River water quality measurements, 2019-2022" {
+		t.Errorf("Title: got %q", r.Title)
+	}
+	if !strings.HasPrefix(r.Abstract, "Weekly measurements of dissolved oxygen & temperature.") || strings.Contains(r.Abstract, "<p>") {
+		t.Errorf("Abstract: got %q", r.Abstract)
+	}
+	if r.ResourceType.Type != hubv1.ResourceTypeValue_RESOURCE_TYPE_DATASET || r.ResourceType.Original != "dataset" {
+		t.Errorf("ResourceType: got %v", r.ResourceType)
+	}
+	if r.Version != "2.1" || r.Language != "eng" || !r.IsPublic {
+		t.Errorf("Version/Language/IsPublic: got %q, %q, %v", r.Version, r.Language, r.IsPublic)
+	}
+	if len(r.Notes) != 1 || r.Notes[0] != "Sensor 3 was offline in March 2021." {
+		t.Errorf("Notes: got %v", r.Notes)
+	}
+
+	if len(r.Contributors) != 5 {
+		t.Fatalf("expected 5 contributors, got %v", r.Contributors)
+	}
+	first := r.Contributors[0]
+	if first.RoleCode != "relators:cre" || first.ParsedName.GetFamily() != "Nguyen" ||
+		len(first.Identifiers) != 1 || first.Identifiers[0].Value != "0000-0002-1825-0097" ||
+		len(first.Affiliations) != 1 || first.Affiliations[0].Name != "Lehigh University" {
+		t.Errorf("first creator: got %v", first)
+	}
+	if c := r.Contributors[2]; c.RoleCode != "relators:cur" {
+		t.Errorf("data curator: got %v", c)
+	}
+	if c := r.Contributors[3]; c.Type != hubv1.ContributorType_CONTRIBUTOR_TYPE_ORGANIZATION || c.RoleCode != "relators:his" {
+		t.Errorf("hosting institution: got %v", c)
+	}
+	if c := r.Contributors[4]; c.RoleCode != "" || c.Role != "ContactPerson" {
+		t.Errorf("contact person: got %v", c)
+	}
+
+	if d := hub.GetDateIssued(r); d == nil || d.Year != 2023 || d.Month != 6 || d.Day != 5 {
+		t.Errorf("issued: got %v", d)
+	}
+	if d := hub.GetDate(r, hubv1.DateType_DATE_TYPE_COLLECTED); d == nil || !d.IsRange || d.Year != 2019 || d.EndYear != 2022 {
+		t.Errorf("collected: got %v", d)
+	}
+
+	if kw := hub.GetKeywords(r); len(kw) != 2 || kw[0].Value != "water quality" {
+		t.Errorf("keywords: got %v", kw)
+	}
+	if len(r.Subjects) != 3 || r.Subjects[2].Uri != "http://id.loc.gov/authorities/subjects/sh85145602" {
+		t.Errorf("subjects: got %v", r.Subjects)
+	}
+
+	if len(r.Rights) != 1 || r.Rights[0].License != "cc-by-4.0" ||
+		r.Rights[0].Uri != "https://creativecommons.org/licenses/by/4.0/" {
+		t.Errorf("Rights: got %v", r.Rights)
+	}
+
+	if doi := hub.GetDOI(r); doi == nil || doi.Value != "10.5281/zenodo.8012345" {
+		t.Errorf("DOI: got %v", doi)
+	}
+	if h := hub.GetIdentifier(r, hubv1.IdentifierType_IDENTIFIER_TYPE_HANDLE); h == nil || h.Value != "2027/lehigh.river.2019" {
+		t.Errorf("alternate identifier: got %v", h)
+	}
+
+	supp := hub.GetRelationsByType(r, hubv1.RelationType_RELATION_TYPE_IS_SUPPLEMENT_TO)
+	if len(supp) != 1 || supp[0].TargetId != "10.1234/jwq.2023.77" || supp[0].TargetIdType != hubv1.IdentifierType_IDENTIFIER_TYPE_DOI ||
+		supp[0].TargetResourceType != hubv1.ResourceTypeValue_RESOURCE_TYPE_ARTICLE {
+		t.Errorf("isSupplementTo: got %v", supp)
+	}
+	if rels := hub.GetRelationsByType(r, hubv1.RelationType_RELATION_TYPE_DERIVED_FROM); len(rels) != 1 || rels[0].TargetUri != "https://github.com/lehigh/river-data" {
+		t.Errorf("isDerivedFrom: got %v", rels)
+	}
+	if rels := hub.GetRelationsByType(r, hubv1.RelationType_RELATION_TYPE_VERSION_OF); len(rels) != 1 || rels[0].TargetId != "10.5281/zenodo.8012344" {
+		t.Errorf("concept DOI: got %v", rels)
+	}
+	if rels := hub.GetRelationsByType(r, hubv1.RelationType_RELATION_TYPE_REFERENCES); len(rels) != 1 || !strings.HasPrefix(rels[0].TargetTitle, "Smith, J.") {
+		t.Errorf("references: got %v", rels)
+	}
+
+	if len(r.Funders) != 1 || r.Funders[0].Identifier != "10.13039/100000001" || r.Funders[0].AwardNumbers[0] != "EAR-1234567" {
+		t.Errorf("Funders: got %v", r.Funders)
+	}
+	if len(r.Files) != 1 || r.Files[0].Name != "measurements.csv" || hub.GetChecksum(r.Files[0], "md5") != "9e107d9d372bb6826bd81d3542a419d6" {
+		t.Errorf("Files: got %v", r.Files)
+	}
+
+	comms, _ := hub.GetExtra(r, "zenodo_communities")
+	if list, ok := comms.([]any); !ok || len(list) != 2 || list[0] != "lehigh" {
+		t.Errorf("zenodo_communities: got %v", comms)
+	}
+	if hub.GetExtraString(r, "zenodo_id") != "8012345" || r.SourceInfo.GetSourceId() != "8012345" {
+		t.Errorf("record ID: got %q, %v", hub.GetExtraString(r, "zenodo_id"), r.SourceInfo)
+	}
+}
+
+func TestParseSearchResponse(t *testing.T) {
+	input := `{"hits": {"total": 2, "hits": [
+		{"id": 1, "metadata": {"title": "One", "resource_type": {"type": "publication", "subtype": "article"}}},
+		{"id": "abcd-1234", "metadata": {"title": "Two", "resource_type": {"type": "image", "subtype": "photo"}, "license": "CC0-1.0"}}
+	]}}`
+	records, err := (&Format{}).Parse(strings.NewReader(input), nil)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+	if rt := records[0].ResourceType; rt.Type != hubv1.ResourceTypeValue_RESOURCE_TYPE_ARTICLE || rt.Original != "publication-article" {
+		t.Errorf("publication subtype: got %v", rt)
+	}
+	if rt := records[1].ResourceType; rt.Type != hubv1.ResourceTypeValue_RESOURCE_TYPE_IMAGE || rt.Original != "image-photo" {
+		t.Errorf("image subtype: got %v", rt)
+	}
+	if hub.GetExtraString(records[1], "zenodo_id") != "abcd-1234" {
+		t.Errorf("string ID: got %q", hub.GetExtraString(records[1], "zenodo_id"))
+	}
+	if rights := records[1].Rights; len(rights) != 1 || rights[0].Uri != "https://creativecommons.org/publicdomain/zero/1.0/" {
+		t.Errorf("bare license ID: got %v", rights)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := map[string]string{
+		"empty":       "",
+		"not json":    "<record/>",
+		"no metadata": `{"id": 1}`,
+		"no hits":     `{"hits": {"hits": []}}`,
+	}
+	for name, input := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := (&Format{}).Parse(strings.NewReader(input), nil); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestCanParse(t *testing.T) {
+	f := &Format{}
+	data, err := os.ReadFile("testdata/record.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !f.CanParse(data) {
+		t.Error("expected CanParse for a Zenodo record")
+	}
+	if f.CanParse([]byte(`{"id": "https://openalex.org/W1", "authorships": []}`)) {
+		t.Error("unexpected CanParse for OpenAlex")
+	}
+}
@@ -0,0 +1,547 @@
+package zenodo
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/helpers"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+// hubUploadTypes maps hub resource types to Zenodo upload types, with
+// the publication or image subtype after a hyphen.
+var hubUploadTypes = map[hubv1.ResourceTypeValue]string{
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_ARTICLE:               "publication-article",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_NEWSPAPER_ARTICLE:     "publication-article",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_PEER_REVIEW:           "publication-article",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_BOOK:                  "publication-book",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_BOOK_CHAPTER:          "publication-section",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_CONFERENCE_PAPER:      "publication-conferencepaper",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_CONFERENCE_PROCEEDING: "publication-conferencepaper",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_PATENT:                "publication-patent",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_PREPRINT:              "publication-preprint",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_REPORT:                "publication-report",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_TECHNICAL_REPORT:      "publication-technicalnote",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_THESIS:                "publication-thesis",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_DISSERTATION:          "publication-thesis",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_WORKING_PAPER:         "publication-workingpaper",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_TEXT:                  "publication-other",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_JOURNAL:               "publication-other",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_PERIODICAL:            "publication-other",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_NEWSPAPER:             "publication-other",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_MANUSCRIPT:            "publication-other",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_STANDARD:              "publication-other",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_POSTER:                "poster",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_PRESENTATION:          "presentation",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_DATASET:               "dataset",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_IMAGE:                 "image-other",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_MAP:                   "image-other",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_VIDEO:                 "video",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_AUDIO:                 "video",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_SOFTWARE:              "software",
+	hubv1.ResourceTypeValue_RESOURCE_TYPE_OBJECT:                "physicalobject",
+}
+
+// relationNames maps hub relation types to Zenodo relations.
+var relationNames = map[hubv1.RelationType]string{
+	hubv1.RelationType_RELATION_TYPE_PART_OF:          "isPartOf",
+	hubv1.RelationType_RELATION_TYPE_MEMBER_OF:        "isPartOf",
+	hubv1.RelationType_RELATION_TYPE_HAS_PART:         "hasPart",
+	hubv1.RelationType_RELATION_TYPE_HAS_MEMBER:       "hasPart",
+	hubv1.RelationType_RELATION_TYPE_CITES:            "cites",
+	hubv1.RelationType_RELATION_TYPE_REFERENCES:       "references",
+	hubv1.RelationType_RELATION_TYPE_IS_CITED_BY:      "isCitedBy",
+	hubv1.RelationType_RELATION_TYPE_SUPPLEMENTS:      "isSupplementTo",
+	hubv1.RelationType_RELATION_TYPE_IS_SUPPLEMENT_TO: "isSupplementTo",
+	hubv1.RelationType_RELATION_TYPE_SUPPLEMENTED_BY:  "isSupplementedBy",
+	hubv1.RelationType_RELATION_TYPE_VERSION_OF:       "isVersionOf",
+	hubv1.RelationType_RELATION_TYPE_HAS_VERSION:      "hasVersion",
+	hubv1.RelationType_RELATION_TYPE_REPLACES:         "obsoletes",
+	hubv1.RelationType_RELATION_TYPE_IS_REPLACED_BY:   "isObsoletedBy",
+	hubv1.RelationType_RELATION_TYPE_FORMAT_OF:        "isVariantFormOf",
+	hubv1.RelationType_RELATION_TYPE_HAS_FORMAT:       "isOriginalFormOf",
+	hubv1.RelationType_RELATION_TYPE_DERIVED_FROM:     "isDerivedFrom",
+	hubv1.RelationType_RELATION_TYPE_SOURCE_OF:        "isSourceOf",
+	hubv1.RelationType_RELATION_TYPE_DOCUMENTS:        "documents",
+	hubv1.RelationType_RELATION_TYPE_IS_DOCUMENTED_BY: "isDocumentedBy",
+	hubv1.RelationType_RELATION_TYPE_DESCRIBES:        "describes",
+	hubv1.RelationType_RELATION_TYPE_IS_DESCRIBED_BY:  "isDescribedBy",
+	hubv1.RelationType_RELATION_TYPE_IDENTICAL_TO:     "isIdenticalTo",
+	hubv1.RelationType_RELATION_TYPE_SAME_AS:          "isIdenticalTo",
+	hubv1.RelationType_RELATION_TYPE_REQUIRES:         "requires",
+	hubv1.RelationType_RELATION_TYPE_REQUIRED_BY:      "isRequiredBy",
+	hubv1.RelationType_RELATION_TYPE_REVIEWS:          "reviews",
+}
+
+// relatorContributorTypes maps MARC relator codes to Zenodo contributor
+// types, the reverse of contributorTypes.
+var relatorContributorTypes = map[string]string{
+	"dtc": "DataCollector",
+	"cur": "DataCurator",
+	"dst": "Distributor",
+	"edt": "Editor",
+	"his": "HostingInstitution",
+	"pro": "Producer",
+	"res": "Researcher",
+	"cph": "RightsHolder",
+	"spn": "Sponsor",
+	"ths": "Supervisor",
+	"oth": "Other",
+}
+
+// zenodoContributorTypes are the contributor types the deposit API
+// accepts.
+var zenodoContributorTypes = map[string]bool{
+	"ContactPerson": true, "DataCollector": true, "DataCurator": true,
+	"DataManager": true, "Distributor": true, "Editor": true,
+	"HostingInstitution": true, "Other": true, "Producer": true,
+	"ProjectLeader": true, "ProjectManager": true, "ProjectMember": true,
+	"RegistrationAgency": true, "RegistrationAuthority": true,
+	"RelatedPerson": true, "Researcher": true, "ResearchGroup": true,
+	"RightsHolder": true, "Sponsor": true, "Supervisor": true,
+	"WorkPackageLeader": true,
+}
+
+// alternateIdentifierTypes are the record identifiers written as
+// isAlternateIdentifier related identifiers.
+var alternateIdentifierTypes = map[hubv1.IdentifierType]bool{
+	hubv1.IdentifierType_IDENTIFIER_TYPE_HANDLE: true,
+	hubv1.IdentifierType_IDENTIFIER_TYPE_URL:    true,
+	hubv1.IdentifierType_IDENTIFIER_TYPE_ARXIV:  true,
+	hubv1.IdentifierType_IDENTIFIER_TYPE_PMID:   true,
+	hubv1.IdentifierType_IDENTIFIER_TYPE_PMCID:  true,
+	hubv1.IdentifierType_IDENTIFIER_TYPE_ISSN:   true,
+	hubv1.IdentifierType_IDENTIFIER_TYPE_ARK:    true,
+	hubv1.IdentifierType_IDENTIFIER_TYPE_URN:    true,
+	hubv1.IdentifierType_IDENTIFIER_TYPE_PURL:   true,
+}
+
+// Serialize writes hub records as Zenodo deposition metadata. A single
+// record is written as one deposition object; a batch as an array.
+func (f *Format) Serialize(w io.Writer, records []*hubv1.Record, opts *format.SerializeOptions) error {
+	if opts == nil {
+		opts = format.NewSerializeOptions()
+	}
+
+	deps := make([]Deposition, 0, len(records))
+	for _, record := range records {
+		deps = append(deps, Deposition{Metadata: recordToDeposition(record)})
+	}
+
+	encoder := json.NewEncoder(w)
+	if opts.Pretty {
+		encoder.SetIndent("", "  ")
+	}
+	if len(deps) == 1 {
+		return encoder.Encode(deps[0])
+	}
+	return encoder.Encode(deps)
+}
+
+// recordToDeposition converts a hub record to deposition metadata.
+func recordToDeposition(record *hubv1.Record) DepositionMetadata {
+	m := DepositionMetadata{
+		Title:    record.Title,
+		Creators: []Person{},
+		Version:  record.Version,
+		Language: record.Language,
+		Notes:    strings.Join(record.Notes, "\n\n"),
+	}
+
+	upload := uploadType(record.ResourceType)
+	m.UploadType, m.PublicationType, m.ImageType = splitUploadType(upload)
+
+	for _, dt := range []hubv1.DateType{
+		hubv1.DateType_DATE_TYPE_ISSUED,
+		hubv1.DateType_DATE_TYPE_PUBLISHED,
+		hubv1.DateType_DATE_TYPE_CREATED,
+	} {
+		if d := hub.GetDate(record, dt); d != nil && d.Year > 0 {
+			m.PublicationDate = isoDate(d.Year, d.Month, d.Day)
+			break
+		}
+	}
+	for _, d := range record.Dates {
+		var kind string
+		switch d.Type {
+		case hubv1.DateType_DATE_TYPE_COLLECTED:
+			kind = "Collected"
+		case hubv1.DateType_DATE_TYPE_VALID:
+			kind = "Valid"
+		default:
+			continue
+		}
+		dr := DateRange{Type: kind, Start: isoDate(d.Year, d.Month, d.Day)}
+		if d.IsRange {
+			dr.End = isoDate(d.EndYear, d.EndMonth, d.EndDay)
+		} else {
+			dr.End = dr.Start
+		}
+		m.Dates = append(m.Dates, dr)
+	}
+
+	for _, c := range record.Contributors {
+		p := contributorToPerson(c)
+		if p.Name == "" {
+			continue
+		}
+		code := relatorCode(c)
+		switch {
+		case isCreator(c):
+			m.Creators = append(m.Creators, p)
+		case code == "ths" && m.PublicationType == "thesis":
+			m.ThesisSupervisors = append(m.ThesisSupervisors, p)
+		default:
+			p.Type = contributorType(c, code)
+			m.Contributors = append(m.Contributors, p)
+		}
+	}
+
+	m.Description = descriptionHTML(record)
+
+	setAccess(&m, record)
+
+	if doi := hub.GetDOI(record); doi != nil {
+		m.DOI = doi.Value
+	}
+	for _, id := range record.Identifiers {
+		switch {
+		case id.Type == hubv1.IdentifierType_IDENTIFIER_TYPE_ISBN && m.ImprintISBN == "":
+			m.ImprintISBN = id.Value
+		case alternateIdentifierTypes[id.Type] && !isZenodoURL(id.Value):
+			m.RelatedIdentifiers = append(m.RelatedIdentifiers, RelatedIdentifier{
+				Identifier: id.Value,
+				Relation:   "isAlternateIdentifier",
+			})
+		}
+	}
+
+	for _, s := range record.Subjects {
+		value := strings.TrimSpace(s.Value)
+		if value == "" {
+			continue
+		}
+		identifier := s.Uri
+		if identifier == "" {
+			identifier = s.SourceId
+		}
+		if identifier == "" || s.Vocabulary == hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_KEYWORDS {
+			m.Keywords = append(m.Keywords, value)
+			continue
+		}
+		subject := Subject{Term: value, Identifier: identifier}
+		if s.Uri != "" {
+			subject.Scheme = "url"
+		}
+		m.Subjects = append(m.Subjects, subject)
+	}
+
+	for _, rel := range record.Relations {
+		name, ok := relationNames[rel.Type]
+		if !ok {
+			continue
+		}
+		target := relationTarget(rel)
+		if target == "" {
+			// Free-text citations are kept as references
+			if (rel.Type == hubv1.RelationType_RELATION_TYPE_REFERENCES || rel.Type == hubv1.RelationType_RELATION_TYPE_CITES) && rel.TargetTitle != "" {
+				m.References = append(m.References, rel.TargetTitle)
+			}
+			continue
+		}
+		m.RelatedIdentifiers = append(m.RelatedIdentifiers, RelatedIdentifier{
+			Identifier:   target,
+			Relation:     name,
+			ResourceType: hubUploadTypes[rel.TargetResourceType],
+		})
+	}
+
+	if pub := record.Publication; pub != nil {
+		if m.PublicationType == "section" {
+			m.PartOfTitle = pub.Title
+			m.PartOfPages = pub.Pages
+		} else {
+			m.JournalTitle = pub.Title
+			m.JournalVolume = pub.Volume
+			m.JournalIssue = pub.Issue
+			m.JournalPages = pub.Pages
+		}
+	}
+	m.ImprintPublisher = record.Publisher
+	m.ImprintPlace = record.PlacePublished
+	if record.DegreeInfo != nil {
+		m.ThesisUniversity = record.DegreeInfo.Institution
+	}
+
+	for _, funder := range record.Funders {
+		funderDOI := hub.NormalizeIdentifier(funder.Identifier, hubv1.IdentifierType_IDENTIFIER_TYPE_DOI)
+		if !strings.HasPrefix(funderDOI, "10.") {
+			continue
+		}
+		for _, award := range funder.AwardNumbers {
+			m.Grants = append(m.Grants, DepositionGrant{ID: funderDOI + "::" + award})
+		}
+	}
+
+	for _, id := range communities(record) {
+		m.Communities = append(m.Communities, DepositionCommunity{Identifier: id})
+	}
+
+	return m
+}
+
+// uploadType returns the record's Zenodo upload type, keeping the
+// original when the record came from Zenodo.
+func uploadType(rt *hubv1.ResourceType) string {
+	if rt == nil {
+		return "other"
+	}
+	if rt.Vocabulary == "zenodo" && rt.Original != "" {
+		return rt.Original
+	}
+	if t, ok := hubUploadTypes[rt.Type]; ok {
+		return t
+	}
+	return "other"
+}
+
+// splitUploadType splits "publication-article" into the upload type and
+// its publication or image subtype.
+func splitUploadType(upload string) (uploadType, publicationType, imageType string) {
+	uploadType, subtype, _ := strings.Cut(upload, "-")
+	switch uploadType {
+	case "publication":
+		if subtype == "" {
+			subtype = "other"
+		}
+		return uploadType, subtype, ""
+	case "image":
+		if subtype == "" {
+			subtype = "other"
+		}
+		return uploadType, "", subtype
+	}
+	return uploadType, "", ""
+}
+
+// isoDate formats a date to the precision it has.
+func isoDate(year, month, day int32) string {
+	if year == 0 {
+		return ""
+	}
+	s := fmt.Sprintf("%04d", year)
+	if month > 0 {
+		s += fmt.Sprintf("-%02d", month)
+		if day > 0 {
+			s += fmt.Sprintf("-%02d", day)
+		}
+	}
+	return s
+}
+
+// contributorToPerson converts a hub contributor, writing person names
+// inverted as Zenodo asks.
+func contributorToPerson(c *hubv1.Contributor) Person {
+	p := Person{Name: c.Name}
+	if c.Type != hubv1.ContributorType_CONTRIBUTOR_TYPE_ORGANIZATION {
+		if name := hub.ParsedNameInverted(c.ParsedName); name != "" {
+			p.Name = name
+		} else if c.Name != "" {
+			p.Name = helpers.FormatNameInverted(c.Name)
+		}
+	}
+	for _, id := range c.Identifiers {
+		if id.Type == hubv1.IdentifierType_IDENTIFIER_TYPE_ORCID {
+			p.ORCID = hub.NormalizeIdentifier(id.Value, id.Type)
+			break
+		}
+	}
+	if len(c.Affiliations) > 0 {
+		p.Affiliation = c.Affiliations[0].Name
+	} else {
+		p.Affiliation = c.Affiliation
+	}
+	return p
+}
+
+// relatorCode returns the contributor's MARC relator code, from the role
+// code or the role label.
+func relatorCode(c *hubv1.Contributor) string {
+	if c.RoleCode != "" {
+		return strings.ToLower(helpers.RelatorCodeFromURI(c.RoleCode))
+	}
+	return helpers.NormalizeRole(c.Role)
+}
+
+// isCreator reports whether a contributor is an author or creator.
+// Contributors without a role are treated as creators.
+func isCreator(c *hubv1.Contributor) bool {
+	switch relatorCode(c) {
+	case "", "aut", "cre":
+		return true
+	}
+	return false
+}
+
+// contributorType returns the Zenodo contributor type for a contributor,
+// falling back to Other.
+func contributorType(c *hubv1.Contributor, code string) string {
+	if t, ok := relatorContributorTypes[code]; ok {
+		return t
+	}
+	if zenodoContributorTypes[c.Role] {
+		return c.Role
+	}
+	return "Other"
+}
+
+// descriptionHTML returns the record's abstract (or description, or
+// title, since the deposit API requires one) as HTML.
+func descriptionHTML(record *hubv1.Record) string {
+	text := record.Abstract
+	if text == "" {
+		text = record.Description
+	}
+	if text == "" {
+		text = record.Title
+	}
+	text = strings.TrimSpace(text)
+	if strings.HasPrefix(text, "<") {
+		return text
+	}
+	var paras []string
+	for _, p := range strings.Split(text, "\n\n") {
+		if p = strings.TrimSpace(p); p != "" {
+			paras = append(paras, "<p>"+html.EscapeString(p)+"</p>")
+		}
+	}
+	return strings.Join(paras, "")
+}
+
+// setAccess sets the access right, license, embargo date, and access
+// conditions. The access right kept from a Zenodo record wins; otherwise
+// a future availability date means embargoed, and an access condition on
+// a non-public record means restricted.
+func setAccess(m *DepositionMetadata, record *hubv1.Record) {
+	available := hub.GetDate(record, hubv1.DateType_DATE_TYPE_AVAILABLE)
+
+	access := hub.GetExtraString(record, "zenodo_access_right")
+	if access == "" {
+		switch {
+		case available != nil && isFuture(available):
+			access = "embargoed"
+		case record.AccessCondition != "" && !record.IsPublic:
+			access = "restricted"
+		default:
+			access = "open"
+		}
+	}
+	m.AccessRight = access
+
+	switch access {
+	case "open", "embargoed":
+		m.License = licenseID(record)
+		if access == "embargoed" && available != nil {
+			m.EmbargoDate = isoDate(available.Year, available.Month, available.Day)
+		}
+	case "restricted":
+		m.AccessConditions = record.AccessCondition
+	}
+}
+
+func isFuture(d *hubv1.DateValue) bool {
+	month, day := max(d.Month, 1), max(d.Day, 1)
+	t := time.Date(int(d.Year), time.Month(month), int(day), 0, 0, 0, 0, time.UTC)
+	return t.After(time.Now())
+}
+
+// licenseID returns the Zenodo license ID for the record's first license,
+// deriving Creative Commons IDs from their URIs.
+func licenseID(record *hubv1.Record) string {
+	for _, r := range record.Rights {
+		if r.License != "" {
+			return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(r.License), " ", "-"))
+		}
+		if id := ccLicenseID(r.Uri); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// ccLicenseID returns the license ID for a Creative Commons URI, such as
+// "cc-by-4.0" for https://creativecommons.org/licenses/by/4.0/.
+func ccLicenseID(uri string) string {
+	_, path, ok := strings.Cut(uri, "creativecommons.org/")
+	if !ok {
+		return ""
+	}
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) < 3 {
+		return ""
+	}
+	switch parts[0] {
+	case "licenses":
+		return "cc-" + parts[1] + "-" + parts[2]
+	case "publicdomain":
+		if parts[1] == "zero" {
+			return "cc0-" + parts[2]
+		}
+	}
+	return ""
+}
+
+// relationTarget returns the persistent identifier of a relation's
+// target, or "" when it has none Zenodo could resolve.
+func relationTarget(rel *hubv1.Relation) string {
+	if rel.TargetUri != "" {
+		return rel.TargetUri
+	}
+	switch rel.TargetIdType {
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_UNSPECIFIED,
+		hubv1.IdentifierType_IDENTIFIER_TYPE_LOCAL,
+		hubv1.IdentifierType_IDENTIFIER_TYPE_PID,
+		hubv1.IdentifierType_IDENTIFIER_TYPE_NID,
+		hubv1.IdentifierType_IDENTIFIER_TYPE_UUID,
+		hubv1.IdentifierType_IDENTIFIER_TYPE_CALL_NUMBER:
+		return ""
+	}
+	return rel.TargetId
+}
+
+// isZenodoURL reports whether a URL is a Zenodo landing page, which the
+// deposit API assigns itself.
+func isZenodoURL(value string) bool {
+	return strings.Contains(value, "zenodo.org/record")
+}
+
+// communities returns the community identifiers in the record's
+// zenodo_communities extra field, a list or a "|"-separated string.
+func communities(record *hubv1.Record) []string {
+	v, ok := hub.GetExtra(record, "zenodo_communities")
+	if !ok {
+		return nil
+	}
+	var ids []string
+	switch v := v.(type) {
+	case []any:
+		for _, item := range v {
+			if s, ok := item.(string); ok && s != "" {
+				ids = append(ids, s)
+			}
+		}
+	case string:
+		for _, s := range strings.Split(v, "|") {
+			if s = strings.TrimSpace(s); s != "" {
+				ids = append(ids, s)
+			}
+		}
+	}
+	return ids
+}
@@ -0,0 +1,163 @@
+package zenodo
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+func serializeOne(t *testing.T, record *hubv1.Record) DepositionMetadata {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := (&Format{}).Serialize(&buf, []*hubv1.Record{record}, nil); err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+	var dep Deposition
+	if err := json.Unmarshal(buf.Bytes(), &dep); err != nil {
+		t.Fatalf("output is not a deposition object: %v\n%s", err, buf.String())
+	}
+	return dep.Metadata
+}
+
+func TestSerializeRecord(t *testing.T) {
+	m := serializeOne(t, parseFile(t, "testdata/record.json")[0])
+
+	if m.UploadType != "dataset" || m.PublicationDate != "2023-06-05" || m.Title == "" {
+		t.Errorf("upload type/date/title: got %q, %q, %q", m.UploadType, m.PublicationDate, m.Title)
+	}
+	if len(m.Creators) != 2 || m.Creators[0].Name != "Nguyen, Linh" || m.Creators[0].ORCID != "0000-0002-1825-0097" ||
+		m.Creators[0].Affiliation != "Lehigh University" {
+		t.Errorf("creators: got %v", m.Creators)
+	}
+	types := map[string]string{}
+	for _, c := range m.Contributors {
+		types[c.Name] = c.Type
+	}
+	if types["Rivera, Ana"] != "DataCurator" || types["Lehigh Environmental Initiative"] != "HostingInstitution" ||
+		types["Park, Jun"] != "ContactPerson" {
+		t.Errorf("contributor types: got %v", types)
+	}
+	if m.AccessRight != "open" || m.License != "cc-by-4.0" {
+		t.Errorf("access: got %q, %q", m.AccessRight, m.License)
+	}
+	if m.DOI != "10.5281/zenodo.8012345" {
+		t.Errorf("DOI: got %q", m.DOI)
+	}
+	if !strings.Contains(m.Description, "dissolved oxygen &amp; temperature") {
+		t.Errorf("Description: got %q", m.Description)
+	}
+
+	related := map[string]string{}
+	for _, ri := range m.RelatedIdentifiers {
+		related[ri.Relation] = ri.Identifier
+		if strings.Contains(ri.Identifier, "zenodo.org/record") {
+			t.Errorf("landing page written as a related identifier: %v", ri)
+		}
+	}
+	want := map[string]string{
+		"isSupplementTo":        "10.1234/jwq.2023.77",
+		"isDerivedFrom":         "https://github.com/lehigh/river-data",
+		"isAlternateIdentifier": "2027/lehigh.river.2019",
+		"isVersionOf":           "10.5281/zenodo.8012344",
+	}
+	for relation, id := range want {
+		if related[relation] != id {
+			t.Errorf("related_identifiers %s: got %q, want %q", relation, related[relation], id)
+		}
+	}
+	if len(m.References) != 1 {
+		t.Errorf("references: got %v", m.References)
+	}
+
+	if len(m.Communities) != 2 || m.Communities[0].Identifier != "lehigh" {
+		t.Errorf("communities: got %v", m.Communities)
+	}
+	if len(m.Grants) != 1 || m.Grants[0].ID != "10.13039/100000001::EAR-1234567" {
+		t.Errorf("grants: got %v", m.Grants)
+	}
+	if len(m.Keywords) != 2 || len(m.Subjects) != 1 || m.Subjects[0].Scheme != "url" {
+		t.Errorf("keywords/subjects: got %v, %v", m.Keywords, m.Subjects)
+	}
+	if len(m.Dates) != 1 || m.Dates[0].Type != "Collected" || m.Dates[0].Start != "2019-01-01" || m.Dates[0].End != "2022-12-31" {
+		t.Errorf("dates: got %v", m.Dates)
+	}
+}
+
+func TestSerializeThesisEmbargo(t *testing.T) {
+	record := &hubv1.Record{
+		Title:        "Sediment transport in the Lehigh Gap",
+		ResourceType: &hubv1.ResourceType{Type: hubv1.ResourceTypeValue_RESOURCE_TYPE_DISSERTATION},
+		Contributors: []*hubv1.Contributor{
+			{Name: "Jane Smith", RoleCode: "relators:aut"},
+			{Name: "Robert Jones", Role: "Thesis advisor"},
+		},
+		Dates: []*hubv1.DateValue{
+			{Type: hubv1.DateType_DATE_TYPE_ISSUED, Year: 2024, Month: 5},
+			{Type: hubv1.DateType_DATE_TYPE_AVAILABLE, Year: 2999, Month: 1, Day: 1},
+		},
+		Rights:     []*hubv1.Rights{{Uri: "https://creativecommons.org/licenses/by-nc/4.0/"}},
+		DegreeInfo: &hubv1.DegreeInfo{Institution: "Lehigh University"},
+		Identifiers: []*hubv1.Identifier{
+			hub.NewIdentifier("https://zenodo.org/records/1", hubv1.IdentifierType_IDENTIFIER_TYPE_URL),
+		},
+	}
+	hub.SetExtra(record, "zenodo_communities", "lehigh | theses")
+
+	m := serializeOne(t, record)
+
+	if m.UploadType != "publication" || m.PublicationType != "thesis" || m.PublicationDate != "2024-05" {
+		t.Errorf("upload type/date: got %q, %q, %q", m.UploadType, m.PublicationType, m.PublicationDate)
+	}
+	if len(m.Creators) != 1 || m.Creators[0].Name != "Smith, Jane" {
+		t.Errorf("creators: got %v", m.Creators)
+	}
+	if len(m.ThesisSupervisors) != 1 || len(m.Contributors) != 0 || m.ThesisUniversity != "Lehigh University" {
+		t.Errorf("thesis: got %v, %v, %q", m.ThesisSupervisors, m.Contributors, m.ThesisUniversity)
+	}
+	if m.AccessRight != "embargoed" || m.EmbargoDate != "2999-01-01" || m.License != "cc-by-nc-4.0" {
+		t.Errorf("access: got %q, %q, %q", m.AccessRight, m.EmbargoDate, m.License)
+	}
+	if m.Description != "<p>Sediment transport in the Lehigh Gap</p>" {
+		t.Errorf("Description: got %q", m.Description)
+	}
+	if len(m.RelatedIdentifiers) != 0 {
+		t.Errorf("related_identifiers: got %v", m.RelatedIdentifiers)
+	}
+	if len(m.Communities) != 2 || m.Communities[1].Identifier != "theses" {
+		t.Errorf("communities: got %v", m.Communities)
+	}
+}
+
+func TestSerializeRestricted(t *testing.T) {
+	record := &hubv1.Record{
+		Title:           "Interview transcripts",
+		AccessCondition: "Available to Lehigh affiliates on request.",
+		Rights:          []*hubv1.Rights{{License: "CC-BY-4.0"}},
+	}
+	m := serializeOne(t, record)
+	if m.AccessRight != "restricted" || m.AccessConditions != record.AccessCondition || m.License != "" {
+		t.Errorf("access: got %q, %q, %q", m.AccessRight, m.AccessConditions, m.License)
+	}
+	if m.UploadType != "other" || m.Creators == nil {
+		t.Errorf("defaults: got %q, %v", m.UploadType, m.Creators)
+	}
+}
+
+func TestSerializeBatch(t *testing.T) {
+	records := []*hubv1.Record{{Title: "One"}, {Title: "Two"}}
+	var buf bytes.Buffer
+	if err := (&Format{}).Serialize(&buf, records, nil); err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+	var deps []Deposition
+	if err := json.Unmarshal(buf.Bytes(), &deps); err != nil {
+		t.Fatalf("batch output is not an array: %v", err)
+	}
+	if len(deps) != 2 || deps[1].Metadata.Title != "Two" {
+		t.Errorf("batch: got %v", deps)
+	}
+}
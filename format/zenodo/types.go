@@ -0,0 +1,252 @@
+package zenodo
+
+import (
+	"encoding/json"
+)
+
+// SearchResponse is a page of records from the Zenodo search API.
+type SearchResponse struct {
+	Hits struct {
+		Hits  []*Record `json:"hits"`
+		Total int       `json:"total"`
+	} `json:"hits"`
+}
+
+// Record is a published Zenodo record as returned by the records API.
+// Only the fields crosswalk maps are decoded.
+type Record struct {
+	ID           ID       `json:"id"`
+	ConceptRecID ID       `json:"conceptrecid"`
+	DOI          string   `json:"doi"`
+	ConceptDOI   string   `json:"conceptdoi"`
+	Created      string   `json:"created"`
+	Updated      string   `json:"updated"`
+	Links        Links    `json:"links"`
+	Metadata     Metadata `json:"metadata"`
+	Files        []File   `json:"files"`
+}
+
+// ID is a record ID, which Zenodo sends as a number and InvenioRDM as a
+// string.
+type ID string
+
+// UnmarshalJSON accepts a JSON number or string.
+func (id *ID) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*id = ID(s)
+		return nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return err
+	}
+	*id = ID(n.String())
+	return nil
+}
+
+// Links are the record's API and landing page URLs.
+type Links struct {
+	Self string `json:"self"`
+	HTML string `json:"html"`
+	DOI  string `json:"doi"`
+}
+
+// Metadata is a published record's descriptive metadata.
+type Metadata struct {
+	Title                string              `json:"title"`
+	DOI                  string              `json:"doi"`
+	PublicationDate      string              `json:"publication_date"`
+	Description          string              `json:"description"`
+	AccessRight          string              `json:"access_right"`
+	AccessConditions     string              `json:"access_conditions"`
+	EmbargoDate          string              `json:"embargo_date"`
+	Creators             []Person            `json:"creators"`
+	Contributors         []Person            `json:"contributors"`
+	Keywords             []string            `json:"keywords"`
+	Subjects             []Subject           `json:"subjects"`
+	Language             string              `json:"language"`
+	License              *License            `json:"license"`
+	ResourceType         ResourceType        `json:"resource_type"`
+	RelatedIdentifiers   []RelatedIdentifier `json:"related_identifiers"`
+	AlternateIdentifiers []RelatedIdentifier `json:"alternate_identifiers"`
+	Communities          []Community         `json:"communities"`
+	Grants               []Grant             `json:"grants"`
+	Journal              *Journal            `json:"journal"`
+	Imprint              *Imprint            `json:"imprint"`
+	PartOf               *PartOf             `json:"part_of"`
+	Thesis               *Thesis             `json:"thesis"`
+	Version              string              `json:"version"`
+	Notes                string              `json:"notes"`
+	References           []string            `json:"references"`
+	Dates                []DateRange         `json:"dates"`
+}
+
+// Person is a creator, contributor, or thesis supervisor. Type is set
+// only for contributors.
+type Person struct {
+	Name        string `json:"name"`
+	Affiliation string `json:"affiliation,omitempty"`
+	ORCID       string `json:"orcid,omitempty"`
+	GND         string `json:"gnd,omitempty"`
+	Type        string `json:"type,omitempty"`
+}
+
+// Subject is a controlled subject term with its identifier.
+type Subject struct {
+	Term       string `json:"term"`
+	Identifier string `json:"identifier"`
+	Scheme     string `json:"scheme,omitempty"`
+}
+
+// License is a record's license, by Zenodo license ID (e.g. "cc-by-4.0").
+type License struct {
+	ID string `json:"id"`
+}
+
+// UnmarshalJSON accepts a license object or a bare license ID.
+func (l *License) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		l.ID = s
+		return nil
+	}
+	type license License
+	return json.Unmarshal(data, (*license)(l))
+}
+
+// ResourceType is a record's upload type and, for publications and
+// images, its subtype.
+type ResourceType struct {
+	Type    string `json:"type"`
+	Subtype string `json:"subtype"`
+	Title   string `json:"title"`
+}
+
+// RelatedIdentifier links the record to another resource by identifier.
+type RelatedIdentifier struct {
+	Identifier   string `json:"identifier"`
+	Relation     string `json:"relation,omitempty"`
+	ResourceType string `json:"resource_type,omitempty"`
+	Scheme       string `json:"scheme,omitempty"`
+}
+
+// Community is a community a published record belongs to.
+type Community struct {
+	ID string `json:"id"`
+}
+
+// Grant is a funding award credited on a record.
+type Grant struct {
+	Code   string      `json:"code"`
+	Title  string      `json:"title"`
+	URL    string      `json:"url"`
+	Funder GrantFunder `json:"funder"`
+}
+
+// GrantFunder is the funder of a grant.
+type GrantFunder struct {
+	Name string `json:"name"`
+	DOI  string `json:"doi"`
+}
+
+// Journal holds the journal a publication appeared in.
+type Journal struct {
+	Title  string `json:"title"`
+	Volume string `json:"volume"`
+	Issue  string `json:"issue"`
+	Pages  string `json:"pages"`
+}
+
+// Imprint holds a book's or report's publisher details.
+type Imprint struct {
+	Publisher string `json:"publisher"`
+	ISBN      string `json:"isbn"`
+	Place     string `json:"place"`
+}
+
+// PartOf holds the book a section appeared in.
+type PartOf struct {
+	Title string `json:"title"`
+	Pages string `json:"pages"`
+}
+
+// Thesis holds a thesis's degree details.
+type Thesis struct {
+	University  string   `json:"university"`
+	Supervisors []Person `json:"supervisors"`
+}
+
+// DateRange is a dated event such as data collection or validity.
+type DateRange struct {
+	Start       string `json:"start,omitempty"`
+	End         string `json:"end,omitempty"`
+	Type        string `json:"type"`
+	Description string `json:"description,omitempty"`
+}
+
+// File is a file attached to a published record.
+type File struct {
+	Key      string `json:"key"`
+	Size     int64  `json:"size"`
+	Checksum string `json:"checksum"`
+	Links    struct {
+		Self string `json:"self"`
+	} `json:"links"`
+}
+
+// Deposition is the body of a deposit API create or update request.
+type Deposition struct {
+	Metadata DepositionMetadata `json:"metadata"`
+}
+
+// DepositionMetadata is deposition metadata as the deposit API accepts
+// it. Unlike published record metadata, the resource type, journal,
+// imprint, part-of, and thesis details are flat fields.
+type DepositionMetadata struct {
+	UploadType         string                `json:"upload_type"`
+	PublicationType    string                `json:"publication_type,omitempty"`
+	ImageType          string                `json:"image_type,omitempty"`
+	PublicationDate    string                `json:"publication_date,omitempty"`
+	Title              string                `json:"title"`
+	Creators           []Person              `json:"creators"`
+	Description        string                `json:"description"`
+	AccessRight        string                `json:"access_right"`
+	License            string                `json:"license,omitempty"`
+	EmbargoDate        string                `json:"embargo_date,omitempty"`
+	AccessConditions   string                `json:"access_conditions,omitempty"`
+	DOI                string                `json:"doi,omitempty"`
+	Keywords           []string              `json:"keywords,omitempty"`
+	Notes              string                `json:"notes,omitempty"`
+	RelatedIdentifiers []RelatedIdentifier   `json:"related_identifiers,omitempty"`
+	Contributors       []Person              `json:"contributors,omitempty"`
+	References         []string              `json:"references,omitempty"`
+	Communities        []DepositionCommunity `json:"communities,omitempty"`
+	Grants             []DepositionGrant     `json:"grants,omitempty"`
+	JournalTitle       string                `json:"journal_title,omitempty"`
+	JournalVolume      string                `json:"journal_volume,omitempty"`
+	JournalIssue       string                `json:"journal_issue,omitempty"`
+	JournalPages       string                `json:"journal_pages,omitempty"`
+	ImprintPublisher   string                `json:"imprint_publisher,omitempty"`
+	ImprintISBN        string                `json:"imprint_isbn,omitempty"`
+	ImprintPlace       string                `json:"imprint_place,omitempty"`
+	PartOfTitle        string                `json:"partof_title,omitempty"`
+	PartOfPages        string                `json:"partof_pages,omitempty"`
+	ThesisSupervisors  []Person              `json:"thesis_supervisors,omitempty"`
+	ThesisUniversity   string                `json:"thesis_university,omitempty"`
+	Subjects           []Subject             `json:"subjects,omitempty"`
+	Version            string                `json:"version,omitempty"`
+	Language           string                `json:"language,omitempty"`
+	Dates              []DateRange           `json:"dates,omitempty"`
+}
+
+// DepositionCommunity names a community to submit the deposition to.
+type DepositionCommunity struct {
+	Identifier string `json:"identifier"`
+}
+
+// DepositionGrant names a grant by its Zenodo grant ID, the funder DOI
+// and award number joined by "::".
+type DepositionGrant struct {
+	ID string `json:"id"`
+}
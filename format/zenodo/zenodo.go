@@ -0,0 +1,95 @@
+// Package zenodo provides a format plugin for Zenodo metadata.
+//
+// The parser reads record JSON from the Zenodo REST API: a single record
+// (/api/records/:id), an array of records, or a search response whose
+// records are in hits.hits. The serializer writes deposition metadata
+// ({"metadata": {...}}) in the shape the deposit API accepts when creating
+// or updating a deposition; a batch is written as a JSON array of them.
+//
+// Both directions cover:
+//   - upload type (resource type), title, description, publication date,
+//     version, language, keywords, subjects, and notes
+//   - creators and contributors, with ORCIDs and affiliations; Zenodo
+//     contributor types are mapped to MARC relators where one fits
+//   - license (as a Rights license ID, with its Creative Commons URI),
+//     access right, embargo date, and access conditions
+//   - DOI, alternate identifiers, and related_identifiers (relations with
+//     a persistent identifier)
+//   - journal, imprint, part-of, and thesis details, and grants (funders
+//     with a funder DOI and award number)
+//
+// Communities have no hub field; they are kept in the zenodo_communities
+// extra field as a list of community identifiers, and the access right in
+// zenodo_access_right. The Zenodo record ID is kept in zenodo_id.
+package zenodo
+
+import (
+	"bytes"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+)
+
+// Format implements the Zenodo format.
+type Format struct{}
+
+// Ensure Format implements the interfaces
+var (
+	_ format.Format         = (*Format)(nil)
+	_ format.Parser         = (*Format)(nil)
+	_ format.Serializer     = (*Format)(nil)
+	_ format.FieldDescriber = (*Format)(nil)
+)
+
+// Name returns the format identifier.
+func (f *Format) Name() string {
+	return "zenodo"
+}
+
+// Description returns a human-readable format description.
+func (f *Format) Description() string {
+	return "Zenodo record and deposition metadata JSON"
+}
+
+// Extensions returns file extensions associated with this format.
+func (f *Format) Extensions() []string {
+	return []string{"json"}
+}
+
+// writtenFields are the hub record fields Serialize writes.
+var writtenFields = []string{
+	"title", "abstract", "description", "contributors", "dates",
+	"resource_type", "subjects", "language", "publisher", "place_published",
+	"publication", "rights", "is_public", "access_condition", "identifiers",
+	"notes", "version", "degree_info", "funders",
+	"relations.part_of", "relations.member_of", "relations.has_part",
+	"relations.has_member", "relations.references", "relations.cites",
+	"relations.is_cited_by", "relations.version_of", "relations.has_version",
+	"relations.replaces", "relations.is_replaced_by", "relations.format_of",
+	"relations.has_format", "relations.derived_from", "relations.source_of",
+	"relations.supplements", "relations.is_supplement_to",
+	"relations.supplemented_by", "relations.documents",
+	"relations.is_documented_by", "relations.describes",
+	"relations.is_described_by", "relations.identical_to",
+	"relations.same_as", "relations.requires", "relations.required_by",
+	"relations.reviews",
+}
+
+// WrittenFields returns the hub record fields Serialize writes.
+func (f *Format) WrittenFields(opts *format.SerializeOptions) []string {
+	return writtenFields
+}
+
+// CanParse returns true if the input looks like Zenodo record JSON.
+func (f *Format) CanParse(peek []byte) bool {
+	peek = bytes.TrimSpace(peek)
+	if len(peek) == 0 || (peek[0] != '{' && peek[0] != '[') {
+		return false
+	}
+	return bytes.Contains(peek, []byte("zenodo.org")) ||
+		bytes.Contains(peek, []byte(`"conceptrecid"`)) ||
+		bytes.Contains(peek, []byte(`"upload_type"`))
+}
+
+func init() {
+	format.Register(&Format{})
+}
@@ -129,6 +129,56 @@ func (ContributorType) EnumDescriptor() ([]byte, []int) {
 	return file_hub_v1_hub_proto_rawDescGZIP(), []int{1}
 }
 
+// DegreeRole is a contributor's part in awarding a thesis or dissertation.
+type DegreeRole int32
+
+const (
+	DegreeRole_DEGREE_ROLE_UNSPECIFIED      DegreeRole = 0
+	DegreeRole_DEGREE_ROLE_ADVISOR          DegreeRole = 1 // Thesis advisor or degree supervisor (relators:ths, relators:dgs)
+	DegreeRole_DEGREE_ROLE_COMMITTEE_MEMBER DegreeRole = 2 // Degree committee member (relators:dgc)
+)
+
+// Enum value maps for DegreeRole.
+var (
+	DegreeRole_name = map[int32]string{
+		0: "DEGREE_ROLE_UNSPECIFIED",
+		1: "DEGREE_ROLE_ADVISOR",
+		2: "DEGREE_ROLE_COMMITTEE_MEMBER",
+	}
+	DegreeRole_value = map[string]int32{
+		"DEGREE_ROLE_UNSPECIFIED":      0,
+		"DEGREE_ROLE_ADVISOR":          1,
+		"DEGREE_ROLE_COMMITTEE_MEMBER": 2,
+	}
+)
+
+func (x DegreeRole) Enum() *DegreeRole {
+	p := new(DegreeRole)
+	*p = x
+	return p
+}
+
+func (x DegreeRole) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DegreeRole) Descriptor() protoreflect.EnumDescriptor {
+	return file_hub_v1_hub_proto_enumTypes[2].Descriptor()
+}
+
+func (DegreeRole) Type() protoreflect.EnumType {
+	return &file_hub_v1_hub_proto_enumTypes[2]
+}
+
+func (x DegreeRole) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DegreeRole.Descriptor instead.
+func (DegreeRole) EnumDescriptor() ([]byte, []int) {
+	return file_hub_v1_hub_proto_rawDescGZIP(), []int{2}
+}
+
 // DateType indicates the semantic meaning of a date.
 type DateType int32
 
@@ -196,11 +246,11 @@ func (x DateType) String() string {
 }
 
 func (DateType) Descriptor() protoreflect.EnumDescriptor {
-	return file_hub_v1_hub_proto_enumTypes[2].Descriptor()
+	return file_hub_v1_hub_proto_enumTypes[3].Descriptor()
 }
 
 func (DateType) Type() protoreflect.EnumType {
-	return &file_hub_v1_hub_proto_enumTypes[2]
+	return &file_hub_v1_hub_proto_enumTypes[3]
 }
 
 func (x DateType) Number() protoreflect.EnumNumber {
@@ -209,7 +259,7 @@ func (x DateType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use DateType.Descriptor instead.
 func (DateType) EnumDescriptor() ([]byte, []int) {
-	return file_hub_v1_hub_proto_rawDescGZIP(), []int{2}
+	return file_hub_v1_hub_proto_rawDescGZIP(), []int{3}
 }
 
 // DatePrecision indicates the granularity of a date.
@@ -223,6 +273,7 @@ const (
 	DatePrecision_DATE_PRECISION_TIME        DatePrecision = 4
 	DatePrecision_DATE_PRECISION_DECADE      DatePrecision = 5
 	DatePrecision_DATE_PRECISION_CENTURY     DatePrecision = 6
+	DatePrecision_DATE_PRECISION_SEASON      DatePrecision = 7 // Year and season, e.g. EDTF "2001-21"
 )
 
 // Enum value maps for DatePrecision.
@@ -235,6 +286,7 @@ var (
 		4: "DATE_PRECISION_TIME",
 		5: "DATE_PRECISION_DECADE",
 		6: "DATE_PRECISION_CENTURY",
+		7: "DATE_PRECISION_SEASON",
 	}
 	DatePrecision_value = map[string]int32{
 		"DATE_PRECISION_UNSPECIFIED": 0,
@@ -244,6 +296,7 @@ var (
 		"DATE_PRECISION_TIME":        4,
 		"DATE_PRECISION_DECADE":      5,
 		"DATE_PRECISION_CENTURY":     6,
+		"DATE_PRECISION_SEASON":      7,
 	}
 )
 
@@ -258,11 +311,11 @@ func (x DatePrecision) String() string {
 }
 
 func (DatePrecision) Descriptor() protoreflect.EnumDescriptor {
-	return file_hub_v1_hub_proto_enumTypes[3].Descriptor()
+	return file_hub_v1_hub_proto_enumTypes[4].Descriptor()
 }
 
 func (DatePrecision) Type() protoreflect.EnumType {
-	return &file_hub_v1_hub_proto_enumTypes[3]
+	return &file_hub_v1_hub_proto_enumTypes[4]
 }
 
 func (x DatePrecision) Number() protoreflect.EnumNumber {
@@ -271,7 +324,7 @@ func (x DatePrecision) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use DatePrecision.Descriptor instead.
 func (DatePrecision) EnumDescriptor() ([]byte, []int) {
-	return file_hub_v1_hub_proto_rawDescGZIP(), []int{3}
+	return file_hub_v1_hub_proto_rawDescGZIP(), []int{4}
 }
 
 // DateQualifier indicates uncertainty or approximation.
@@ -311,11 +364,11 @@ func (x DateQualifier) String() string {
 }
 
 func (DateQualifier) Descriptor() protoreflect.EnumDescriptor {
-	return file_hub_v1_hub_proto_enumTypes[4].Descriptor()
+	return file_hub_v1_hub_proto_enumTypes[5].Descriptor()
 }
 
 func (DateQualifier) Type() protoreflect.EnumType {
-	return &file_hub_v1_hub_proto_enumTypes[4]
+	return &file_hub_v1_hub_proto_enumTypes[5]
 }
 
 func (x DateQualifier) Number() protoreflect.EnumNumber {
@@ -324,7 +377,107 @@ func (x DateQualifier) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use DateQualifier.Descriptor instead.
 func (DateQualifier) EnumDescriptor() ([]byte, []int) {
-	return file_hub_v1_hub_proto_rawDescGZIP(), []int{4}
+	return file_hub_v1_hub_proto_rawDescGZIP(), []int{5}
+}
+
+// DateBound indicates an interval end that is not a date.
+type DateBound int32
+
+const (
+	DateBound_DATE_BOUND_UNSPECIFIED DateBound = 0 // The end is the given date
+	DateBound_DATE_BOUND_OPEN        DateBound = 1 // ..
+	DateBound_DATE_BOUND_UNKNOWN     DateBound = 2 // Empty
+)
+
+// Enum value maps for DateBound.
+var (
+	DateBound_name = map[int32]string{
+		0: "DATE_BOUND_UNSPECIFIED",
+		1: "DATE_BOUND_OPEN",
+		2: "DATE_BOUND_UNKNOWN",
+	}
+	DateBound_value = map[string]int32{
+		"DATE_BOUND_UNSPECIFIED": 0,
+		"DATE_BOUND_OPEN":        1,
+		"DATE_BOUND_UNKNOWN":     2,
+	}
+)
+
+func (x DateBound) Enum() *DateBound {
+	p := new(DateBound)
+	*p = x
+	return p
+}
+
+func (x DateBound) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DateBound) Descriptor() protoreflect.EnumDescriptor {
+	return file_hub_v1_hub_proto_enumTypes[6].Descriptor()
+}
+
+func (DateBound) Type() protoreflect.EnumType {
+	return &file_hub_v1_hub_proto_enumTypes[6]
+}
+
+func (x DateBound) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DateBound.Descriptor instead.
+func (DateBound) EnumDescriptor() ([]byte, []int) {
+	return file_hub_v1_hub_proto_rawDescGZIP(), []int{6}
+}
+
+// DateSetType indicates how the members of a date set apply.
+type DateSetType int32
+
+const (
+	DateSetType_DATE_SET_TYPE_UNSPECIFIED DateSetType = 0
+	DateSetType_DATE_SET_TYPE_ONE_OF      DateSetType = 1 // [1990,1995]
+	DateSetType_DATE_SET_TYPE_ALL_OF      DateSetType = 2 // {1990,1995}
+)
+
+// Enum value maps for DateSetType.
+var (
+	DateSetType_name = map[int32]string{
+		0: "DATE_SET_TYPE_UNSPECIFIED",
+		1: "DATE_SET_TYPE_ONE_OF",
+		2: "DATE_SET_TYPE_ALL_OF",
+	}
+	DateSetType_value = map[string]int32{
+		"DATE_SET_TYPE_UNSPECIFIED": 0,
+		"DATE_SET_TYPE_ONE_OF":      1,
+		"DATE_SET_TYPE_ALL_OF":      2,
+	}
+)
+
+func (x DateSetType) Enum() *DateSetType {
+	p := new(DateSetType)
+	*p = x
+	return p
+}
+
+func (x DateSetType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DateSetType) Descriptor() protoreflect.EnumDescriptor {
+	return file_hub_v1_hub_proto_enumTypes[7].Descriptor()
+}
+
+func (DateSetType) Type() protoreflect.EnumType {
+	return &file_hub_v1_hub_proto_enumTypes[7]
+}
+
+func (x DateSetType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DateSetType.Descriptor instead.
+func (DateSetType) EnumDescriptor() ([]byte, []int) {
+	return file_hub_v1_hub_proto_rawDescGZIP(), []int{7}
 }
 
 // IdentifierType represents the type of identifier.
@@ -348,6 +501,9 @@ const (
 	IdentifierType_IDENTIFIER_TYPE_ISNI          IdentifierType = 14
 	IdentifierType_IDENTIFIER_TYPE_REPORT_NUMBER IdentifierType = 15
 	IdentifierType_IDENTIFIER_TYPE_CALL_NUMBER   IdentifierType = 16
+	IdentifierType_IDENTIFIER_TYPE_ARK           IdentifierType = 17 // Archival Resource Key ("ark:/13030/tf5p30086k")
+	IdentifierType_IDENTIFIER_TYPE_URN           IdentifierType = 18 // URN, e.g. a national bibliography number ("urn:nbn:de:...")
+	IdentifierType_IDENTIFIER_TYPE_PURL          IdentifierType = 19 // Persistent URL (purl.org and other PURL resolvers)
 )
 
 // Enum value maps for IdentifierType.
@@ -370,6 +526,9 @@ var (
 		14: "IDENTIFIER_TYPE_ISNI",
 		15: "IDENTIFIER_TYPE_REPORT_NUMBER",
 		16: "IDENTIFIER_TYPE_CALL_NUMBER",
+		17: "IDENTIFIER_TYPE_ARK",
+		18: "IDENTIFIER_TYPE_URN",
+		19: "IDENTIFIER_TYPE_PURL",
 	}
 	IdentifierType_value = map[string]int32{
 		"IDENTIFIER_TYPE_UNSPECIFIED":   0,
@@ -389,6 +548,9 @@ var (
 		"IDENTIFIER_TYPE_ISNI":          14,
 		"IDENTIFIER_TYPE_REPORT_NUMBER": 15,
 		"IDENTIFIER_TYPE_CALL_NUMBER":   16,
+		"IDENTIFIER_TYPE_ARK":           17,
+		"IDENTIFIER_TYPE_URN":           18,
+		"IDENTIFIER_TYPE_PURL":          19,
 	}
 )
 
@@ -403,11 +565,11 @@ func (x IdentifierType) String() string {
 }
 
 func (IdentifierType) Descriptor() protoreflect.EnumDescriptor {
-	return file_hub_v1_hub_proto_enumTypes[5].Descriptor()
+	return file_hub_v1_hub_proto_enumTypes[8].Descriptor()
 }
 
 func (IdentifierType) Type() protoreflect.EnumType {
-	return &file_hub_v1_hub_proto_enumTypes[5]
+	return &file_hub_v1_hub_proto_enumTypes[8]
 }
 
 func (x IdentifierType) Number() protoreflect.EnumNumber {
@@ -416,7 +578,7 @@ func (x IdentifierType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use IdentifierType.Descriptor instead.
 func (IdentifierType) EnumDescriptor() ([]byte, []int) {
-	return file_hub_v1_hub_proto_rawDescGZIP(), []int{5}
+	return file_hub_v1_hub_proto_rawDescGZIP(), []int{8}
 }
 
 // SubjectType indicates the type of subject (topic, name, place).
@@ -465,11 +627,11 @@ func (x SubjectType) String() string {
 }
 
 func (SubjectType) Descriptor() protoreflect.EnumDescriptor {
-	return file_hub_v1_hub_proto_enumTypes[6].Descriptor()
+	return file_hub_v1_hub_proto_enumTypes[9].Descriptor()
 }
 
 func (SubjectType) Type() protoreflect.EnumType {
-	return &file_hub_v1_hub_proto_enumTypes[6]
+	return &file_hub_v1_hub_proto_enumTypes[9]
 }
 
 func (x SubjectType) Number() protoreflect.EnumNumber {
@@ -478,7 +640,7 @@ func (x SubjectType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use SubjectType.Descriptor instead.
 func (SubjectType) EnumDescriptor() ([]byte, []int) {
-	return file_hub_v1_hub_proto_rawDescGZIP(), []int{6}
+	return file_hub_v1_hub_proto_rawDescGZIP(), []int{9}
 }
 
 // SubjectVocabulary identifies the vocabulary a subject term comes from.
@@ -501,6 +663,8 @@ const (
 	SubjectVocabulary_SUBJECT_VOCABULARY_MSC         SubjectVocabulary = 13 // Mathematics Subject Classification
 	SubjectVocabulary_SUBJECT_VOCABULARY_ACM         SubjectVocabulary = 14 // ACM Computing Classification System
 	SubjectVocabulary_SUBJECT_VOCABULARY_PACS        SubjectVocabulary = 15 // Physics and Astronomy Classification Scheme
+	SubjectVocabulary_SUBJECT_VOCABULARY_BISAC       SubjectVocabulary = 16 // BISAC Subject Headings (book trade)
+	SubjectVocabulary_SUBJECT_VOCABULARY_THEMA       SubjectVocabulary = 17 // Thema subject categories (book trade)
 )
 
 // Enum value maps for SubjectVocabulary.
@@ -522,6 +686,8 @@ var (
 		13: "SUBJECT_VOCABULARY_MSC",
 		14: "SUBJECT_VOCABULARY_ACM",
 		15: "SUBJECT_VOCABULARY_PACS",
+		16: "SUBJECT_VOCABULARY_BISAC",
+		17: "SUBJECT_VOCABULARY_THEMA",
 	}
 	SubjectVocabulary_value = map[string]int32{
 		"SUBJECT_VOCABULARY_UNSPECIFIED": 0,
@@ -540,6 +706,8 @@ var (
 		"SUBJECT_VOCABULARY_MSC":         13,
 		"SUBJECT_VOCABULARY_ACM":         14,
 		"SUBJECT_VOCABULARY_PACS":        15,
+		"SUBJECT_VOCABULARY_BISAC":       16,
+		"SUBJECT_VOCABULARY_THEMA":       17,
 	}
 )
 
@@ -554,11 +722,11 @@ func (x SubjectVocabulary) String() string {
 }
 
 func (SubjectVocabulary) Descriptor() protoreflect.EnumDescriptor {
-	return file_hub_v1_hub_proto_enumTypes[7].Descriptor()
+	return file_hub_v1_hub_proto_enumTypes[10].Descriptor()
 }
 
 func (SubjectVocabulary) Type() protoreflect.EnumType {
-	return &file_hub_v1_hub_proto_enumTypes[7]
+	return &file_hub_v1_hub_proto_enumTypes[10]
 }
 
 func (x SubjectVocabulary) Number() protoreflect.EnumNumber {
@@ -567,7 +735,7 @@ func (x SubjectVocabulary) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use SubjectVocabulary.Descriptor instead.
 func (SubjectVocabulary) EnumDescriptor() ([]byte, []int) {
-	return file_hub_v1_hub_proto_rawDescGZIP(), []int{7}
+	return file_hub_v1_hub_proto_rawDescGZIP(), []int{10}
 }
 
 // ResourceTypeValue is a normalized resource type.
@@ -700,11 +868,11 @@ func (x ResourceTypeValue) String() string {
 }
 
 func (ResourceTypeValue) Descriptor() protoreflect.EnumDescriptor {
-	return file_hub_v1_hub_proto_enumTypes[8].Descriptor()
+	return file_hub_v1_hub_proto_enumTypes[11].Descriptor()
 }
 
 func (ResourceTypeValue) Type() protoreflect.EnumType {
-	return &file_hub_v1_hub_proto_enumTypes[8]
+	return &file_hub_v1_hub_proto_enumTypes[11]
 }
 
 func (x ResourceTypeValue) Number() protoreflect.EnumNumber {
@@ -713,7 +881,7 @@ func (x ResourceTypeValue) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ResourceTypeValue.Descriptor instead.
 func (ResourceTypeValue) EnumDescriptor() ([]byte, []int) {
-	return file_hub_v1_hub_proto_rawDescGZIP(), []int{8}
+	return file_hub_v1_hub_proto_rawDescGZIP(), []int{11}
 }
 
 // RelationType represents the type of relationship between resources.
@@ -856,11 +1024,11 @@ func (x RelationType) String() string {
 }
 
 func (RelationType) Descriptor() protoreflect.EnumDescriptor {
-	return file_hub_v1_hub_proto_enumTypes[9].Descriptor()
+	return file_hub_v1_hub_proto_enumTypes[12].Descriptor()
 }
 
 func (RelationType) Type() protoreflect.EnumType {
-	return &file_hub_v1_hub_proto_enumTypes[9]
+	return &file_hub_v1_hub_proto_enumTypes[12]
 }
 
 func (x RelationType) Number() protoreflect.EnumNumber {
@@ -869,7 +1037,7 @@ func (x RelationType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use RelationType.Descriptor instead.
 func (RelationType) EnumDescriptor() ([]byte, []int) {
-	return file_hub_v1_hub_proto_rawDescGZIP(), []int{9}
+	return file_hub_v1_hub_proto_rawDescGZIP(), []int{12}
 }
 
 // Record represents a single scholarly work with its metadata.
@@ -891,9 +1059,12 @@ type Record struct {
 	Subjects []*Subject `protobuf:"bytes,8,rep,name=subjects,proto3" json:"subjects,omitempty"`
 	Language string     `protobuf:"bytes,9,opt,name=language,proto3" json:"language,omitempty"`
 	// Publication info
-	Publisher      string              `protobuf:"bytes,10,opt,name=publisher,proto3" json:"publisher,omitempty"`
-	PlacePublished string              `protobuf:"bytes,11,opt,name=place_published,json=placePublished,proto3" json:"place_published,omitempty"`
-	Publication    *PublicationDetails `protobuf:"bytes,24,opt,name=publication,proto3" json:"publication,omitempty"`
+	Publisher      string `protobuf:"bytes,10,opt,name=publisher,proto3" json:"publisher,omitempty"`
+	PlacePublished string `protobuf:"bytes,11,opt,name=place_published,json=placePublished,proto3" json:"place_published,omitempty"`
+	// place_published resolved to a country and region; place_published
+	// keeps the form the source gave.
+	PublicationPlace *Place              `protobuf:"bytes,52,opt,name=publication_place,json=publicationPlace,proto3" json:"publication_place,omitempty"`
+	Publication      *PublicationDetails `protobuf:"bytes,24,opt,name=publication,proto3" json:"publication,omitempty"`
 	// Rights and access
 	Rights          []*Rights `protobuf:"bytes,12,rep,name=rights,proto3" json:"rights,omitempty"`
 	IsPublic        bool      `protobuf:"varint,25,opt,name=is_public,json=isPublic,proto3" json:"is_public,omitempty"`
@@ -939,15 +1110,33 @@ type Record struct {
 	LocalRestriction string `protobuf:"bytes,43,opt,name=local_restriction,json=localRestriction,proto3" json:"local_restriction,omitempty"`
 	// Structured geographic location
 	Geographic *HierarchicalGeographic `protobuf:"bytes,44,opt,name=geographic,proto3" json:"geographic,omitempty"`
+	// Ancestor collections from the root down to the immediate parent
+	// (e.g. University Archives > Photographs > 1960s). Computed across a
+	// batch from member_of/part_of relations.
+	MembershipPath []*Relation `protobuf:"bytes,45,rep,name=membership_path,json=membershipPath,proto3" json:"membership_path,omitempty"`
+	// Copyright holder and statement, kept apart from license/usage rights.
+	// The copyright year is a DATE_TYPE_COPYRIGHT entry in dates.
+	RightsHolder       string `protobuf:"bytes,46,opt,name=rights_holder,json=rightsHolder,proto3" json:"rights_holder,omitempty"`
+	CopyrightStatement string `protobuf:"bytes,47,opt,name=copyright_statement,json=copyrightStatement,proto3" json:"copyright_statement,omitempty"` // e.g. "© 2024 Lehigh University. All rights reserved."
+	// Downloadable forms of a dataset (e.g. CSV, XLSX, README).
+	Distributions []*Distribution `protobuf:"bytes,48,rep,name=distributions,proto3" json:"distributions,omitempty"`
+	// Physical copies and where they are shelved. Call numbers live here
+	// rather than in identifiers: they locate a copy, not the work.
+	Holdings []*Holding `protobuf:"bytes,49,rep,name=holdings,proto3" json:"holdings,omitempty"`
+	// The title and abstract in each language the work gives them (e.g.
+	// parallel English and Spanish titles). title and abstract remain the
+	// values in the record's language; see hub.Titles and hub.Abstracts.
+	Titles    []*LocalizedString `protobuf:"bytes,50,rep,name=titles,proto3" json:"titles,omitempty"`
+	Abstracts []*LocalizedString `protobuf:"bytes,51,rep,name=abstracts,proto3" json:"abstracts,omitempty"`
 	// Extra holds additional fields that don't map to standard Hub fields.
 	// Used for round-trip preservation and format-specific data.
 	//
-	//  1. This is a TEMPORARY home - if a field appears in >50% of records,
-	//     promote it to a first-class field in the Hub schema.
-	//  2. Enforce consistent types - don't let spokes mix string "10" and int 10.
-	//  3. Use machine names as keys (dc_creator), never human labels ("Creator").
-	//  4. Data here is NOT indexed/searchable - only use for pass-through.
-	//  5. Store semantics, not presentation (ISO dates, not "March 15, 2024").
+	// 1. This is a TEMPORARY home - if a field appears in >50% of records,
+	//    promote it to a first-class field in the Hub schema.
+	// 2. Enforce consistent types - don't let spokes mix string "10" and int 10.
+	// 3. Use machine names as keys (dc_creator), never human labels ("Creator").
+	// 4. Data here is NOT indexed/searchable - only use for pass-through.
+	// 5. Store semantics, not presentation (ISO dates, not "March 15, 2024").
 	//
 	// Periodic audit: Run `crosswalk audit-extras` to find promotion candidates.
 	Extra *structpb.Struct `protobuf:"bytes,22,opt,name=extra,proto3" json:"extra,omitempty"`
@@ -1064,6 +1253,13 @@ func (x *Record) GetPlacePublished() string {
 	return ""
 }
 
+func (x *Record) GetPublicationPlace() *Place {
+	if x != nil {
+		return x.PublicationPlace
+	}
+	return nil
+}
+
 func (x *Record) GetPublication() *PublicationDetails {
 	if x != nil {
 		return x.Publication
@@ -1281,6 +1477,55 @@ func (x *Record) GetGeographic() *HierarchicalGeographic {
 	return nil
 }
 
+func (x *Record) GetMembershipPath() []*Relation {
+	if x != nil {
+		return x.MembershipPath
+	}
+	return nil
+}
+
+func (x *Record) GetRightsHolder() string {
+	if x != nil {
+		return x.RightsHolder
+	}
+	return ""
+}
+
+func (x *Record) GetCopyrightStatement() string {
+	if x != nil {
+		return x.CopyrightStatement
+	}
+	return ""
+}
+
+func (x *Record) GetDistributions() []*Distribution {
+	if x != nil {
+		return x.Distributions
+	}
+	return nil
+}
+
+func (x *Record) GetHoldings() []*Holding {
+	if x != nil {
+		return x.Holdings
+	}
+	return nil
+}
+
+func (x *Record) GetTitles() []*LocalizedString {
+	if x != nil {
+		return x.Titles
+	}
+	return nil
+}
+
+func (x *Record) GetAbstracts() []*LocalizedString {
+	if x != nil {
+		return x.Abstracts
+	}
+	return nil
+}
+
 func (x *Record) GetExtra() *structpb.Struct {
 	if x != nil {
 		return x.Extra
@@ -1310,8 +1555,15 @@ type SourceInfo struct {
 	Profile string `protobuf:"bytes,5,opt,name=profile,proto3" json:"profile,omitempty"`
 	// Fields that were placed in 'extra' (for audit purposes)
 	UnmappedFields []string `protobuf:"bytes,6,rep,name=unmapped_fields,json=unmappedFields,proto3" json:"unmapped_fields,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	// OAI-PMH header, when the record was harvested inside an OAI envelope
+	Oai *OaiHeader `protobuf:"bytes,7,opt,name=oai,proto3" json:"oai,omitempty"`
+	// Transformations applied since parsing, in order (convert --provenance)
+	Steps []*TransformationStep `protobuf:"bytes,8,rep,name=steps,proto3" json:"steps,omitempty"`
+	// The source record as parsed, gzip-compressed (convert --preserve-source),
+	// so a serializer for the same format can replay what the hub lacks
+	Payload       []byte `protobuf:"bytes,9,opt,name=payload,proto3" json:"payload,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *SourceInfo) Reset() {
@@ -1386,31 +1638,59 @@ func (x *SourceInfo) GetUnmappedFields() []string {
 	return nil
 }
 
-// Group represents a container with child records.
-// Used for hierarchical outputs like CrossRef XML (issue + articles).
-type Group struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Type          GroupType              `protobuf:"varint,1,opt,name=type,proto3,enum=hub.v1.GroupType" json:"type,omitempty"`
-	Container     *Record                `protobuf:"bytes,2,opt,name=container,proto3" json:"container,omitempty"` // Parent record (e.g., journal issue)
-	Members       []*Record              `protobuf:"bytes,3,rep,name=members,proto3" json:"members,omitempty"`     // Child records (e.g., articles)
+func (x *SourceInfo) GetOai() *OaiHeader {
+	if x != nil {
+		return x.Oai
+	}
+	return nil
+}
+
+func (x *SourceInfo) GetSteps() []*TransformationStep {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+func (x *SourceInfo) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+// TransformationStep is one stage of a conversion applied to a record,
+// kept so curators can review how the output was produced.
+type TransformationStep struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Stage: "parse", "enrich", "post-process", or "serialize"
+	Stage string `protobuf:"bytes,1,opt,name=stage,proto3" json:"stage,omitempty"`
+	// Parser, enricher, post-processor, or serializer name
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// When the step ran
+	At *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=at,proto3" json:"at,omitempty"`
+	// Hub fields the step added, removed, or changed
+	ChangedFields []string `protobuf:"bytes,4,rep,name=changed_fields,json=changedFields,proto3" json:"changed_fields,omitempty"`
+	// Warnings for the record, such as fields the target format drops
+	Warnings      []string `protobuf:"bytes,5,rep,name=warnings,proto3" json:"warnings,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Group) Reset() {
-	*x = Group{}
+func (x *TransformationStep) Reset() {
+	*x = TransformationStep{}
 	mi := &file_hub_v1_hub_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Group) String() string {
+func (x *TransformationStep) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Group) ProtoMessage() {}
+func (*TransformationStep) ProtoMessage() {}
 
-func (x *Group) ProtoReflect() protoreflect.Message {
+func (x *TransformationStep) ProtoReflect() protoreflect.Message {
 	mi := &file_hub_v1_hub_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1422,81 +1702,234 @@ func (x *Group) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Group.ProtoReflect.Descriptor instead.
-func (*Group) Descriptor() ([]byte, []int) {
+// Deprecated: Use TransformationStep.ProtoReflect.Descriptor instead.
+func (*TransformationStep) Descriptor() ([]byte, []int) {
 	return file_hub_v1_hub_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *Group) GetType() GroupType {
+func (x *TransformationStep) GetStage() string {
 	if x != nil {
-		return x.Type
+		return x.Stage
 	}
-	return GroupType_GROUP_TYPE_UNSPECIFIED
+	return ""
 }
 
-func (x *Group) GetContainer() *Record {
+func (x *TransformationStep) GetName() string {
 	if x != nil {
-		return x.Container
+		return x.Name
 	}
-	return nil
+	return ""
 }
 
-func (x *Group) GetMembers() []*Record {
+func (x *TransformationStep) GetAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Members
+		return x.At
 	}
 	return nil
 }
 
-// Contributor represents a person or organization that contributed to a work.
-// Maps to schema.org Person or Organization with contributor-specific extensions.
-type Contributor struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Name is the full name as a single string (e.g., "Fries, Albert F. Jr.")
-	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	// ParsedName contains parsed name components if available
-	ParsedName *ParsedName `protobuf:"bytes,2,opt,name=parsed_name,json=parsedName,proto3" json:"parsed_name,omitempty"`
-	// Role is the contributor's role (e.g., "creator", "author", "editor")
-	Role string `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
-	// RoleCode is the original role code (e.g., "relators:cre")
-	RoleCode string `protobuf:"bytes,4,opt,name=role_code,json=roleCode,proto3" json:"role_code,omitempty"`
-	// Type indicates whether this is a person or organization
-	Type ContributorType `protobuf:"varint,5,opt,name=type,proto3,enum=hub.v1.ContributorType" json:"type,omitempty"`
-	// Identifiers for the contributor (ORCID, ISNI, etc.)
-	Identifiers []*Identifier `protobuf:"bytes,6,rep,name=identifiers,proto3" json:"identifiers,omitempty"`
-	// Affiliation is the contributor's institutional affiliation (deprecated, use affiliations)
-	Affiliation string `protobuf:"bytes,7,opt,name=affiliation,proto3" json:"affiliation,omitempty"`
-	// SourceID is the original ID from the source system
-	SourceId string `protobuf:"bytes,8,opt,name=source_id,json=sourceId,proto3" json:"source_id,omitempty"`
-	// Affiliations for the contributor (preferred over single affiliation field)
-	Affiliations []*Affiliation `protobuf:"bytes,9,rep,name=affiliations,proto3" json:"affiliations,omitempty"`
-	// Email address (schema.org Person.email)
-	Email string `protobuf:"bytes,10,opt,name=email,proto3" json:"email,omitempty"`
-	// Status or job title (e.g., "Graduate Student", "Faculty", "Professor")
-	// Maps to schema.org Person.jobTitle
-	Status string `protobuf:"bytes,11,opt,name=status,proto3" json:"status,omitempty"`
-	// URL for the contributor's profile or homepage (schema.org Person.url)
-	Url string `protobuf:"bytes,12,opt,name=url,proto3" json:"url,omitempty"`
-	// Description or bio (schema.org Person.description)
-	Description string `protobuf:"bytes,13,opt,name=description,proto3" json:"description,omitempty"`
-	// AdditionalName for middle names or other name parts (schema.org Person.additionalName)
-	AdditionalName string `protobuf:"bytes,14,opt,name=additional_name,json=additionalName,proto3" json:"additional_name,omitempty"`
-	// AlumniOf lists educational institutions the person attended (schema.org Person.alumniOf)
-	// Each entry is an organization name; use Affiliation for structured data with identifiers
-	AlumniOf []string `protobuf:"bytes,15,rep,name=alumni_of,json=alumniOf,proto3" json:"alumni_of,omitempty"`
-	// AuthorityURI is a canonical URI for the contributor from a name authority
-	// (e.g., LCNAF, VIAF, ULAN). In Islandora, this comes from field_authority_link.
-	// This is a convenience field; the same URI should also be in identifiers[].
-	AuthorityUri string `protobuf:"bytes,16,opt,name=authority_uri,json=authorityUri,proto3" json:"authority_uri,omitempty"`
-	// AuthoritySource identifies the vocabulary (e.g., "lcnaf", "viaf", "ulan")
-	AuthoritySource string `protobuf:"bytes,17,opt,name=authority_source,json=authoritySource,proto3" json:"authority_source,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+func (x *TransformationStep) GetChangedFields() []string {
+	if x != nil {
+		return x.ChangedFields
+	}
+	return nil
+}
+
+func (x *TransformationStep) GetWarnings() []string {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+// OaiHeader holds the OAI-PMH <header> of a harvested record, for
+// incremental harvesting and provenance reporting.
+type OaiHeader struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// OAI identifier (e.g. "oai:arXiv.org:2401.00001")
+	Identifier string `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty"`
+	// Datestamp as sent by the repository (day or seconds granularity)
+	Datestamp string `protobuf:"bytes,2,opt,name=datestamp,proto3" json:"datestamp,omitempty"`
+	// setSpec values the record belongs to
+	SetSpecs []string `protobuf:"bytes,3,rep,name=set_specs,json=setSpecs,proto3" json:"set_specs,omitempty"`
+	// True when the header has status="deleted"
+	Deleted       bool `protobuf:"varint,4,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OaiHeader) Reset() {
+	*x = OaiHeader{}
+	mi := &file_hub_v1_hub_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OaiHeader) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OaiHeader) ProtoMessage() {}
+
+func (x *OaiHeader) ProtoReflect() protoreflect.Message {
+	mi := &file_hub_v1_hub_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OaiHeader.ProtoReflect.Descriptor instead.
+func (*OaiHeader) Descriptor() ([]byte, []int) {
+	return file_hub_v1_hub_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *OaiHeader) GetIdentifier() string {
+	if x != nil {
+		return x.Identifier
+	}
+	return ""
+}
+
+func (x *OaiHeader) GetDatestamp() string {
+	if x != nil {
+		return x.Datestamp
+	}
+	return ""
+}
+
+func (x *OaiHeader) GetSetSpecs() []string {
+	if x != nil {
+		return x.SetSpecs
+	}
+	return nil
+}
+
+func (x *OaiHeader) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+// Group represents a container with child records.
+// Used for hierarchical outputs like CrossRef XML (issue + articles).
+type Group struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          GroupType              `protobuf:"varint,1,opt,name=type,proto3,enum=hub.v1.GroupType" json:"type,omitempty"`
+	Container     *Record                `protobuf:"bytes,2,opt,name=container,proto3" json:"container,omitempty"` // Parent record (e.g., journal issue)
+	Members       []*Record              `protobuf:"bytes,3,rep,name=members,proto3" json:"members,omitempty"`     // Child records (e.g., articles)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Group) Reset() {
+	*x = Group{}
+	mi := &file_hub_v1_hub_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Group) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Group) ProtoMessage() {}
+
+func (x *Group) ProtoReflect() protoreflect.Message {
+	mi := &file_hub_v1_hub_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Group.ProtoReflect.Descriptor instead.
+func (*Group) Descriptor() ([]byte, []int) {
+	return file_hub_v1_hub_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Group) GetType() GroupType {
+	if x != nil {
+		return x.Type
+	}
+	return GroupType_GROUP_TYPE_UNSPECIFIED
+}
+
+func (x *Group) GetContainer() *Record {
+	if x != nil {
+		return x.Container
+	}
+	return nil
+}
+
+func (x *Group) GetMembers() []*Record {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+// Contributor represents a person or organization that contributed to a work.
+// Maps to schema.org Person or Organization with contributor-specific extensions.
+type Contributor struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name is the full name as a single string (e.g., "Fries, Albert F. Jr.")
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// ParsedName contains parsed name components if available
+	ParsedName *ParsedName `protobuf:"bytes,2,opt,name=parsed_name,json=parsedName,proto3" json:"parsed_name,omitempty"`
+	// Role is the contributor's role (e.g., "creator", "author", "editor")
+	Role string `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	// RoleCode is the original role code (e.g., "relators:cre")
+	RoleCode string `protobuf:"bytes,4,opt,name=role_code,json=roleCode,proto3" json:"role_code,omitempty"`
+	// Type indicates whether this is a person or organization
+	Type ContributorType `protobuf:"varint,5,opt,name=type,proto3,enum=hub.v1.ContributorType" json:"type,omitempty"`
+	// Identifiers for the contributor (ORCID, ISNI, etc.)
+	Identifiers []*Identifier `protobuf:"bytes,6,rep,name=identifiers,proto3" json:"identifiers,omitempty"`
+	// Affiliation is the contributor's institutional affiliation (deprecated, use affiliations)
+	Affiliation string `protobuf:"bytes,7,opt,name=affiliation,proto3" json:"affiliation,omitempty"`
+	// SourceID is the original ID from the source system
+	SourceId string `protobuf:"bytes,8,opt,name=source_id,json=sourceId,proto3" json:"source_id,omitempty"`
+	// Affiliations for the contributor (preferred over single affiliation field)
+	Affiliations []*Affiliation `protobuf:"bytes,9,rep,name=affiliations,proto3" json:"affiliations,omitempty"`
+	// Email address (schema.org Person.email)
+	Email string `protobuf:"bytes,10,opt,name=email,proto3" json:"email,omitempty"`
+	// Status or job title (e.g., "Graduate Student", "Faculty", "Professor")
+	// Maps to schema.org Person.jobTitle
+	Status string `protobuf:"bytes,11,opt,name=status,proto3" json:"status,omitempty"`
+	// URL for the contributor's profile or homepage (schema.org Person.url)
+	Url string `protobuf:"bytes,12,opt,name=url,proto3" json:"url,omitempty"`
+	// Description or bio (schema.org Person.description)
+	Description string `protobuf:"bytes,13,opt,name=description,proto3" json:"description,omitempty"`
+	// AdditionalName for middle names or other name parts (schema.org Person.additionalName)
+	AdditionalName string `protobuf:"bytes,14,opt,name=additional_name,json=additionalName,proto3" json:"additional_name,omitempty"`
+	// AlumniOf lists educational institutions the person attended (schema.org Person.alumniOf)
+	// Each entry is an organization name; use Affiliation for structured data with identifiers
+	AlumniOf []string `protobuf:"bytes,15,rep,name=alumni_of,json=alumniOf,proto3" json:"alumni_of,omitempty"`
+	// AuthorityURI is a canonical URI for the contributor from a name authority
+	// (e.g., LCNAF, VIAF, ULAN). In Islandora, this comes from field_authority_link.
+	// This is a convenience field; the same URI should also be in identifiers[].
+	AuthorityUri string `protobuf:"bytes,16,opt,name=authority_uri,json=authorityUri,proto3" json:"authority_uri,omitempty"`
+	// AuthoritySource identifies the vocabulary (e.g., "lcnaf", "viaf", "ulan")
+	AuthoritySource string `protobuf:"bytes,17,opt,name=authority_source,json=authoritySource,proto3" json:"authority_source,omitempty"`
+	// DegreeRole is the contributor's part in the degree a thesis or
+	// dissertation was submitted for (advisor, committee member)
+	DegreeRole    DegreeRole `protobuf:"varint,18,opt,name=degree_role,json=degreeRole,proto3,enum=hub.v1.DegreeRole" json:"degree_role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Contributor) Reset() {
 	*x = Contributor{}
-	mi := &file_hub_v1_hub_proto_msgTypes[3]
+	mi := &file_hub_v1_hub_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1508,7 +1941,7 @@ func (x *Contributor) String() string {
 func (*Contributor) ProtoMessage() {}
 
 func (x *Contributor) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_v1_hub_proto_msgTypes[3]
+	mi := &file_hub_v1_hub_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1521,7 +1954,7 @@ func (x *Contributor) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Contributor.ProtoReflect.Descriptor instead.
 func (*Contributor) Descriptor() ([]byte, []int) {
-	return file_hub_v1_hub_proto_rawDescGZIP(), []int{3}
+	return file_hub_v1_hub_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *Contributor) GetName() string {
@@ -1643,6 +2076,13 @@ func (x *Contributor) GetAuthoritySource() string {
 	return ""
 }
 
+func (x *Contributor) GetDegreeRole() DegreeRole {
+	if x != nil {
+		return x.DegreeRole
+	}
+	return DegreeRole_DEGREE_ROLE_UNSPECIFIED
+}
+
 // ParsedName contains parsed components of a personal name.
 type ParsedName struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1659,7 +2099,7 @@ type ParsedName struct {
 
 func (x *ParsedName) Reset() {
 	*x = ParsedName{}
-	mi := &file_hub_v1_hub_proto_msgTypes[4]
+	mi := &file_hub_v1_hub_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1671,7 +2111,7 @@ func (x *ParsedName) String() string {
 func (*ParsedName) ProtoMessage() {}
 
 func (x *ParsedName) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_v1_hub_proto_msgTypes[4]
+	mi := &file_hub_v1_hub_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1684,7 +2124,7 @@ func (x *ParsedName) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ParsedName.ProtoReflect.Descriptor instead.
 func (*ParsedName) Descriptor() ([]byte, []int) {
-	return file_hub_v1_hub_proto_rawDescGZIP(), []int{4}
+	return file_hub_v1_hub_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ParsedName) GetFamily() string {
@@ -1760,15 +2200,28 @@ type DateValue struct {
 	IsRange bool `protobuf:"varint,11,opt,name=is_range,json=isRange,proto3" json:"is_range,omitempty"`
 	// Time component if available
 	Time *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=time,proto3" json:"time,omitempty"`
-	// Season (e.g., "Spring", "Winter")
-	Season        string `protobuf:"bytes,13,opt,name=season,proto3" json:"season,omitempty"`
+	// Season (e.g., "Spring", "Winter"); a range ending in another season
+	// has both, separated by a slash ("Spring/Summer")
+	Season string `protobuf:"bytes,13,opt,name=season,proto3" json:"season,omitempty"`
+	// Precision and qualifier of the end of a range; an unspecified end
+	// precision is the start's
+	EndPrecision DatePrecision `protobuf:"varint,14,opt,name=end_precision,json=endPrecision,proto3,enum=hub.v1.DatePrecision" json:"end_precision,omitempty"`
+	EndQualifier DateQualifier `protobuf:"varint,15,opt,name=end_qualifier,json=endQualifier,proto3,enum=hub.v1.DateQualifier" json:"end_qualifier,omitempty"`
+	// Open (EDTF "..") or unknown (empty) ends of an interval; a range
+	// with an open or unknown start has no start year
+	StartBound DateBound `protobuf:"varint,16,opt,name=start_bound,json=startBound,proto3,enum=hub.v1.DateBound" json:"start_bound,omitempty"`
+	EndBound   DateBound `protobuf:"varint,17,opt,name=end_bound,json=endBound,proto3,enum=hub.v1.DateBound" json:"end_bound,omitempty"`
+	// A set of dates (EDTF "[...]" or "{...}"), of which one or all apply.
+	// Year through end_day span the members.
+	SetType       DateSetType  `protobuf:"varint,18,opt,name=set_type,json=setType,proto3,enum=hub.v1.DateSetType" json:"set_type,omitempty"`
+	SetMembers    []*DateValue `protobuf:"bytes,19,rep,name=set_members,json=setMembers,proto3" json:"set_members,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *DateValue) Reset() {
 	*x = DateValue{}
-	mi := &file_hub_v1_hub_proto_msgTypes[5]
+	mi := &file_hub_v1_hub_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1780,7 +2233,7 @@ func (x *DateValue) String() string {
 func (*DateValue) ProtoMessage() {}
 
 func (x *DateValue) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_v1_hub_proto_msgTypes[5]
+	mi := &file_hub_v1_hub_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1793,7 +2246,7 @@ func (x *DateValue) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DateValue.ProtoReflect.Descriptor instead.
 func (*DateValue) Descriptor() ([]byte, []int) {
-	return file_hub_v1_hub_proto_rawDescGZIP(), []int{5}
+	return file_hub_v1_hub_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *DateValue) GetType() DateType {
@@ -1887,6 +2340,48 @@ func (x *DateValue) GetSeason() string {
 	return ""
 }
 
+func (x *DateValue) GetEndPrecision() DatePrecision {
+	if x != nil {
+		return x.EndPrecision
+	}
+	return DatePrecision_DATE_PRECISION_UNSPECIFIED
+}
+
+func (x *DateValue) GetEndQualifier() DateQualifier {
+	if x != nil {
+		return x.EndQualifier
+	}
+	return DateQualifier_DATE_QUALIFIER_UNSPECIFIED
+}
+
+func (x *DateValue) GetStartBound() DateBound {
+	if x != nil {
+		return x.StartBound
+	}
+	return DateBound_DATE_BOUND_UNSPECIFIED
+}
+
+func (x *DateValue) GetEndBound() DateBound {
+	if x != nil {
+		return x.EndBound
+	}
+	return DateBound_DATE_BOUND_UNSPECIFIED
+}
+
+func (x *DateValue) GetSetType() DateSetType {
+	if x != nil {
+		return x.SetType
+	}
+	return DateSetType_DATE_SET_TYPE_UNSPECIFIED
+}
+
+func (x *DateValue) GetSetMembers() []*DateValue {
+	if x != nil {
+		return x.SetMembers
+	}
+	return nil
+}
+
 // Identifier represents a typed identifier for a scholarly work.
 type Identifier struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1900,7 +2395,7 @@ type Identifier struct {
 
 func (x *Identifier) Reset() {
 	*x = Identifier{}
-	mi := &file_hub_v1_hub_proto_msgTypes[6]
+	mi := &file_hub_v1_hub_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1912,7 +2407,7 @@ func (x *Identifier) String() string {
 func (*Identifier) ProtoMessage() {}
 
 func (x *Identifier) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_v1_hub_proto_msgTypes[6]
+	mi := &file_hub_v1_hub_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1925,7 +2420,7 @@ func (x *Identifier) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Identifier.ProtoReflect.Descriptor instead.
 func (*Identifier) Descriptor() ([]byte, []int) {
-	return file_hub_v1_hub_proto_rawDescGZIP(), []int{6}
+	return file_hub_v1_hub_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *Identifier) GetType() IdentifierType {
@@ -1970,7 +2465,7 @@ type Subject struct {
 
 func (x *Subject) Reset() {
 	*x = Subject{}
-	mi := &file_hub_v1_hub_proto_msgTypes[7]
+	mi := &file_hub_v1_hub_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1982,7 +2477,7 @@ func (x *Subject) String() string {
 func (*Subject) ProtoMessage() {}
 
 func (x *Subject) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_v1_hub_proto_msgTypes[7]
+	mi := &file_hub_v1_hub_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1995,7 +2490,7 @@ func (x *Subject) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Subject.ProtoReflect.Descriptor instead.
 func (*Subject) Descriptor() ([]byte, []int) {
-	return file_hub_v1_hub_proto_rawDescGZIP(), []int{7}
+	return file_hub_v1_hub_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *Subject) GetValue() string {
@@ -2046,7 +2541,7 @@ type Rights struct {
 
 func (x *Rights) Reset() {
 	*x = Rights{}
-	mi := &file_hub_v1_hub_proto_msgTypes[8]
+	mi := &file_hub_v1_hub_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2058,7 +2553,7 @@ func (x *Rights) String() string {
 func (*Rights) ProtoMessage() {}
 
 func (x *Rights) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_v1_hub_proto_msgTypes[8]
+	mi := &file_hub_v1_hub_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2071,7 +2566,7 @@ func (x *Rights) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Rights.ProtoReflect.Descriptor instead.
 func (*Rights) Descriptor() ([]byte, []int) {
-	return file_hub_v1_hub_proto_rawDescGZIP(), []int{8}
+	return file_hub_v1_hub_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *Rights) GetStatement() string {
@@ -2114,7 +2609,7 @@ type ResourceType struct {
 
 func (x *ResourceType) Reset() {
 	*x = ResourceType{}
-	mi := &file_hub_v1_hub_proto_msgTypes[9]
+	mi := &file_hub_v1_hub_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2126,7 +2621,7 @@ func (x *ResourceType) String() string {
 func (*ResourceType) ProtoMessage() {}
 
 func (x *ResourceType) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_v1_hub_proto_msgTypes[9]
+	mi := &file_hub_v1_hub_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2139,7 +2634,7 @@ func (x *ResourceType) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResourceType.ProtoReflect.Descriptor instead.
 func (*ResourceType) Descriptor() ([]byte, []int) {
-	return file_hub_v1_hub_proto_rawDescGZIP(), []int{9}
+	return file_hub_v1_hub_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *ResourceType) GetType() ResourceTypeValue {
@@ -2181,7 +2676,7 @@ type Relation struct {
 
 func (x *Relation) Reset() {
 	*x = Relation{}
-	mi := &file_hub_v1_hub_proto_msgTypes[10]
+	mi := &file_hub_v1_hub_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2193,7 +2688,7 @@ func (x *Relation) String() string {
 func (*Relation) ProtoMessage() {}
 
 func (x *Relation) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_v1_hub_proto_msgTypes[10]
+	mi := &file_hub_v1_hub_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2206,7 +2701,7 @@ func (x *Relation) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Relation.ProtoReflect.Descriptor instead.
 func (*Relation) Descriptor() ([]byte, []int) {
-	return file_hub_v1_hub_proto_rawDescGZIP(), []int{10}
+	return file_hub_v1_hub_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *Relation) GetType() RelationType {
@@ -2286,7 +2781,7 @@ type DegreeInfo struct {
 
 func (x *DegreeInfo) Reset() {
 	*x = DegreeInfo{}
-	mi := &file_hub_v1_hub_proto_msgTypes[11]
+	mi := &file_hub_v1_hub_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2298,7 +2793,7 @@ func (x *DegreeInfo) String() string {
 func (*DegreeInfo) ProtoMessage() {}
 
 func (x *DegreeInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_v1_hub_proto_msgTypes[11]
+	mi := &file_hub_v1_hub_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2311,7 +2806,7 @@ func (x *DegreeInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DegreeInfo.ProtoReflect.Descriptor instead.
 func (*DegreeInfo) Descriptor() ([]byte, []int) {
-	return file_hub_v1_hub_proto_rawDescGZIP(), []int{11}
+	return file_hub_v1_hub_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *DegreeInfo) GetDegreeName() string {
@@ -2364,7 +2859,7 @@ type Funder struct {
 
 func (x *Funder) Reset() {
 	*x = Funder{}
-	mi := &file_hub_v1_hub_proto_msgTypes[12]
+	mi := &file_hub_v1_hub_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2376,7 +2871,7 @@ func (x *Funder) String() string {
 func (*Funder) ProtoMessage() {}
 
 func (x *Funder) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_v1_hub_proto_msgTypes[12]
+	mi := &file_hub_v1_hub_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2389,7 +2884,7 @@ func (x *Funder) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Funder.ProtoReflect.Descriptor instead.
 func (*Funder) Descriptor() ([]byte, []int) {
-	return file_hub_v1_hub_proto_rawDescGZIP(), []int{12}
+	return file_hub_v1_hub_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *Funder) GetName() string {
@@ -2446,7 +2941,7 @@ type Affiliation struct {
 
 func (x *Affiliation) Reset() {
 	*x = Affiliation{}
-	mi := &file_hub_v1_hub_proto_msgTypes[13]
+	mi := &file_hub_v1_hub_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2458,7 +2953,7 @@ func (x *Affiliation) String() string {
 func (*Affiliation) ProtoMessage() {}
 
 func (x *Affiliation) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_v1_hub_proto_msgTypes[13]
+	mi := &file_hub_v1_hub_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2471,7 +2966,7 @@ func (x *Affiliation) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Affiliation.ProtoReflect.Descriptor instead.
 func (*Affiliation) Descriptor() ([]byte, []int) {
-	return file_hub_v1_hub_proto_rawDescGZIP(), []int{13}
+	return file_hub_v1_hub_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *Affiliation) GetName() string {
@@ -2495,22 +2990,77 @@ func (x *Affiliation) GetIdentifierType() string {
 	return ""
 }
 
-// File represents a file associated with the record.
+// LocalizedString is a text value tagged with its language.
+type LocalizedString struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Language      string                 `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"` // BCP 47 tag (e.g. "en", "es")
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LocalizedString) Reset() {
+	*x = LocalizedString{}
+	mi := &file_hub_v1_hub_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LocalizedString) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LocalizedString) ProtoMessage() {}
+
+func (x *LocalizedString) ProtoReflect() protoreflect.Message {
+	mi := &file_hub_v1_hub_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LocalizedString.ProtoReflect.Descriptor instead.
+func (*LocalizedString) Descriptor() ([]byte, []int) {
+	return file_hub_v1_hub_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *LocalizedString) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *LocalizedString) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+// File represents a file associated with the record (e.g. an Islandora media file).
 type File struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"` // Local path or URL
 	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
 	MimeType      string                 `protobuf:"bytes,3,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
 	SizeBytes     int64                  `protobuf:"varint,4,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
 	Description   string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
-	Role          string                 `protobuf:"bytes,6,opt,name=role,proto3" json:"role,omitempty"` // e.g. "supplemental", "service", "thumbnail"
+	Role          string                 `protobuf:"bytes,6,opt,name=role,proto3" json:"role,omitempty"`           // Use of the file: "original", "service", "thumbnail", "supplemental", ...
+	Checksums     []*Checksum            `protobuf:"bytes,7,rep,name=checksums,proto3" json:"checksums,omitempty"` // Fixity values for the file
+	Media         *MediaInfo             `protobuf:"bytes,8,opt,name=media,proto3" json:"media,omitempty"`         // Technical metadata of an audiovisual instantiation
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *File) Reset() {
 	*x = File{}
-	mi := &file_hub_v1_hub_proto_msgTypes[14]
+	mi := &file_hub_v1_hub_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2522,7 +3072,7 @@ func (x *File) String() string {
 func (*File) ProtoMessage() {}
 
 func (x *File) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_v1_hub_proto_msgTypes[14]
+	mi := &file_hub_v1_hub_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2535,7 +3085,7 @@ func (x *File) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use File.ProtoReflect.Descriptor instead.
 func (*File) Descriptor() ([]byte, []int) {
-	return file_hub_v1_hub_proto_rawDescGZIP(), []int{14}
+	return file_hub_v1_hub_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *File) GetPath() string {
@@ -2580,6 +3130,326 @@ func (x *File) GetRole() string {
 	return ""
 }
 
+func (x *File) GetChecksums() []*Checksum {
+	if x != nil {
+		return x.Checksums
+	}
+	return nil
+}
+
+func (x *File) GetMedia() *MediaInfo {
+	if x != nil {
+		return x.Media
+	}
+	return nil
+}
+
+// MediaInfo is the technical metadata of one instantiation of an
+// audiovisual work: a digital file or a physical carrier such as a tape.
+// Values are kept as given, with their units (e.g. "48 kHz", "24 bit").
+// A physical carrier is a file without a path.
+type MediaInfo struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	MediaType            string                 `protobuf:"bytes,1,opt,name=media_type,json=mediaType,proto3" json:"media_type,omitempty"`                                  // "Sound", "Moving Image", "Static Image", "Text"
+	Generation           string                 `protobuf:"bytes,2,opt,name=generation,proto3" json:"generation,omitempty"`                                                 // e.g. "Original", "Preservation master", "Access copy"
+	PhysicalFormat       string                 `protobuf:"bytes,3,opt,name=physical_format,json=physicalFormat,proto3" json:"physical_format,omitempty"`                   // Carrier of a physical instantiation (e.g. "1/4 inch audio tape")
+	Location             string                 `protobuf:"bytes,4,opt,name=location,proto3" json:"location,omitempty"`                                                     // Where a physical carrier is held (e.g. "Vault B, shelf 12")
+	Duration             string                 `protobuf:"bytes,5,opt,name=duration,proto3" json:"duration,omitempty"`                                                     // Running time (e.g. "00:28:30")
+	Standard             string                 `protobuf:"bytes,6,opt,name=standard,proto3" json:"standard,omitempty"`                                                     // Broadcast or file standard (e.g. "NTSC", "Broadcast WAV")
+	Tracks               string                 `protobuf:"bytes,7,opt,name=tracks,proto3" json:"tracks,omitempty"`                                                         // e.g. "2 audio tracks"
+	ChannelConfiguration string                 `protobuf:"bytes,8,opt,name=channel_configuration,json=channelConfiguration,proto3" json:"channel_configuration,omitempty"` // e.g. "Stereo"
+	DataRate             string                 `protobuf:"bytes,9,opt,name=data_rate,json=dataRate,proto3" json:"data_rate,omitempty"`                                     // e.g. "2304 kbps"
+	SamplingRate         string                 `protobuf:"bytes,10,opt,name=sampling_rate,json=samplingRate,proto3" json:"sampling_rate,omitempty"`                        // e.g. "48 kHz"
+	BitDepth             string                 `protobuf:"bytes,11,opt,name=bit_depth,json=bitDepth,proto3" json:"bit_depth,omitempty"`                                    // e.g. "24 bit"
+	FrameRate            string                 `protobuf:"bytes,12,opt,name=frame_rate,json=frameRate,proto3" json:"frame_rate,omitempty"`                                 // e.g. "29.97 fps"
+	FrameSize            string                 `protobuf:"bytes,13,opt,name=frame_size,json=frameSize,proto3" json:"frame_size,omitempty"`                                 // e.g. "720x486"
+	AspectRatio          string                 `protobuf:"bytes,14,opt,name=aspect_ratio,json=aspectRatio,proto3" json:"aspect_ratio,omitempty"`                           // e.g. "4:3"
+	Colors               string                 `protobuf:"bytes,15,opt,name=colors,proto3" json:"colors,omitempty"`                                                        // e.g. "Color", "B&W"
+	Encoding             string                 `protobuf:"bytes,16,opt,name=encoding,proto3" json:"encoding,omitempty"`                                                    // Codec (e.g. "PCM", "H.264")
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *MediaInfo) Reset() {
+	*x = MediaInfo{}
+	mi := &file_hub_v1_hub_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MediaInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MediaInfo) ProtoMessage() {}
+
+func (x *MediaInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_hub_v1_hub_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MediaInfo.ProtoReflect.Descriptor instead.
+func (*MediaInfo) Descriptor() ([]byte, []int) {
+	return file_hub_v1_hub_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *MediaInfo) GetMediaType() string {
+	if x != nil {
+		return x.MediaType
+	}
+	return ""
+}
+
+func (x *MediaInfo) GetGeneration() string {
+	if x != nil {
+		return x.Generation
+	}
+	return ""
+}
+
+func (x *MediaInfo) GetPhysicalFormat() string {
+	if x != nil {
+		return x.PhysicalFormat
+	}
+	return ""
+}
+
+func (x *MediaInfo) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *MediaInfo) GetDuration() string {
+	if x != nil {
+		return x.Duration
+	}
+	return ""
+}
+
+func (x *MediaInfo) GetStandard() string {
+	if x != nil {
+		return x.Standard
+	}
+	return ""
+}
+
+func (x *MediaInfo) GetTracks() string {
+	if x != nil {
+		return x.Tracks
+	}
+	return ""
+}
+
+func (x *MediaInfo) GetChannelConfiguration() string {
+	if x != nil {
+		return x.ChannelConfiguration
+	}
+	return ""
+}
+
+func (x *MediaInfo) GetDataRate() string {
+	if x != nil {
+		return x.DataRate
+	}
+	return ""
+}
+
+func (x *MediaInfo) GetSamplingRate() string {
+	if x != nil {
+		return x.SamplingRate
+	}
+	return ""
+}
+
+func (x *MediaInfo) GetBitDepth() string {
+	if x != nil {
+		return x.BitDepth
+	}
+	return ""
+}
+
+func (x *MediaInfo) GetFrameRate() string {
+	if x != nil {
+		return x.FrameRate
+	}
+	return ""
+}
+
+func (x *MediaInfo) GetFrameSize() string {
+	if x != nil {
+		return x.FrameSize
+	}
+	return ""
+}
+
+func (x *MediaInfo) GetAspectRatio() string {
+	if x != nil {
+		return x.AspectRatio
+	}
+	return ""
+}
+
+func (x *MediaInfo) GetColors() string {
+	if x != nil {
+		return x.Colors
+	}
+	return ""
+}
+
+func (x *MediaInfo) GetEncoding() string {
+	if x != nil {
+		return x.Encoding
+	}
+	return ""
+}
+
+// Distribution is one downloadable form of a dataset.
+type Distribution struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Format        string                 `protobuf:"bytes,3,opt,name=format,proto3" json:"format,omitempty"` // MIME type (e.g. "text/csv")
+	SizeBytes     int64                  `protobuf:"varint,4,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	Checksums     []*Checksum            `protobuf:"bytes,5,rep,name=checksums,proto3" json:"checksums,omitempty"`
+	Description   string                 `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Distribution) Reset() {
+	*x = Distribution{}
+	mi := &file_hub_v1_hub_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Distribution) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Distribution) ProtoMessage() {}
+
+func (x *Distribution) ProtoReflect() protoreflect.Message {
+	mi := &file_hub_v1_hub_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Distribution.ProtoReflect.Descriptor instead.
+func (*Distribution) Descriptor() ([]byte, []int) {
+	return file_hub_v1_hub_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Distribution) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Distribution) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Distribution) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *Distribution) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *Distribution) GetChecksums() []*Checksum {
+	if x != nil {
+		return x.Checksums
+	}
+	return nil
+}
+
+func (x *Distribution) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+// Checksum is a fixity value for a file.
+type Checksum struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Algorithm     string                 `protobuf:"bytes,1,opt,name=algorithm,proto3" json:"algorithm,omitempty"` // Lowercase algorithm name (e.g. "md5", "sha1", "sha256")
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`         // Lowercase hex digest
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Checksum) Reset() {
+	*x = Checksum{}
+	mi := &file_hub_v1_hub_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Checksum) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Checksum) ProtoMessage() {}
+
+func (x *Checksum) ProtoReflect() protoreflect.Message {
+	mi := &file_hub_v1_hub_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Checksum.ProtoReflect.Descriptor instead.
+func (*Checksum) Descriptor() ([]byte, []int) {
+	return file_hub_v1_hub_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *Checksum) GetAlgorithm() string {
+	if x != nil {
+		return x.Algorithm
+	}
+	return ""
+}
+
+func (x *Checksum) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
 // ArchivalLocation represents physical archival location.
 type ArchivalLocation struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -2593,7 +3463,7 @@ type ArchivalLocation struct {
 
 func (x *ArchivalLocation) Reset() {
 	*x = ArchivalLocation{}
-	mi := &file_hub_v1_hub_proto_msgTypes[15]
+	mi := &file_hub_v1_hub_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2605,7 +3475,7 @@ func (x *ArchivalLocation) String() string {
 func (*ArchivalLocation) ProtoMessage() {}
 
 func (x *ArchivalLocation) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_v1_hub_proto_msgTypes[15]
+	mi := &file_hub_v1_hub_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2618,7 +3488,7 @@ func (x *ArchivalLocation) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ArchivalLocation.ProtoReflect.Descriptor instead.
 func (*ArchivalLocation) Descriptor() ([]byte, []int) {
-	return file_hub_v1_hub_proto_rawDescGZIP(), []int{15}
+	return file_hub_v1_hub_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *ArchivalLocation) GetCollection() string {
@@ -2649,6 +3519,75 @@ func (x *ArchivalLocation) GetFolder() string {
 	return ""
 }
 
+// Holding describes a physical copy of a resource and where it is held.
+type Holding struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Institution      string                 `protobuf:"bytes,1,opt,name=institution,proto3" json:"institution,omitempty"`                                     // Holding institution or repository
+	Sublocation      string                 `protobuf:"bytes,2,opt,name=sublocation,proto3" json:"sublocation,omitempty"`                                     // Library, branch, or collection within the institution
+	CallNumber       string                 `protobuf:"bytes,3,opt,name=call_number,json=callNumber,proto3" json:"call_number,omitempty"`                     // Call number or other shelf locator
+	CallNumberScheme string                 `protobuf:"bytes,4,opt,name=call_number_scheme,json=callNumberScheme,proto3" json:"call_number_scheme,omitempty"` // Classification scheme (e.g. "lcc", "ddc", "local")
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Holding) Reset() {
+	*x = Holding{}
+	mi := &file_hub_v1_hub_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Holding) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Holding) ProtoMessage() {}
+
+func (x *Holding) ProtoReflect() protoreflect.Message {
+	mi := &file_hub_v1_hub_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Holding.ProtoReflect.Descriptor instead.
+func (*Holding) Descriptor() ([]byte, []int) {
+	return file_hub_v1_hub_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *Holding) GetInstitution() string {
+	if x != nil {
+		return x.Institution
+	}
+	return ""
+}
+
+func (x *Holding) GetSublocation() string {
+	if x != nil {
+		return x.Sublocation
+	}
+	return ""
+}
+
+func (x *Holding) GetCallNumber() string {
+	if x != nil {
+		return x.CallNumber
+	}
+	return ""
+}
+
+func (x *Holding) GetCallNumberScheme() string {
+	if x != nil {
+		return x.CallNumberScheme
+	}
+	return ""
+}
+
 // PublicationDetails holds specific publication metadata often found in citations.
 type PublicationDetails struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -2664,7 +3603,7 @@ type PublicationDetails struct {
 
 func (x *PublicationDetails) Reset() {
 	*x = PublicationDetails{}
-	mi := &file_hub_v1_hub_proto_msgTypes[16]
+	mi := &file_hub_v1_hub_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2676,7 +3615,7 @@ func (x *PublicationDetails) String() string {
 func (*PublicationDetails) ProtoMessage() {}
 
 func (x *PublicationDetails) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_v1_hub_proto_msgTypes[16]
+	mi := &file_hub_v1_hub_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2689,7 +3628,7 @@ func (x *PublicationDetails) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PublicationDetails.ProtoReflect.Descriptor instead.
 func (*PublicationDetails) Descriptor() ([]byte, []int) {
-	return file_hub_v1_hub_proto_rawDescGZIP(), []int{16}
+	return file_hub_v1_hub_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *PublicationDetails) GetTitle() string {
@@ -2749,7 +3688,7 @@ type HierarchicalGeographic struct {
 
 func (x *HierarchicalGeographic) Reset() {
 	*x = HierarchicalGeographic{}
-	mi := &file_hub_v1_hub_proto_msgTypes[17]
+	mi := &file_hub_v1_hub_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2761,7 +3700,7 @@ func (x *HierarchicalGeographic) String() string {
 func (*HierarchicalGeographic) ProtoMessage() {}
 
 func (x *HierarchicalGeographic) ProtoReflect() protoreflect.Message {
-	mi := &file_hub_v1_hub_proto_msgTypes[17]
+	mi := &file_hub_v1_hub_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2774,7 +3713,7 @@ func (x *HierarchicalGeographic) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HierarchicalGeographic.ProtoReflect.Descriptor instead.
 func (*HierarchicalGeographic) Descriptor() ([]byte, []int) {
-	return file_hub_v1_hub_proto_rawDescGZIP(), []int{17}
+	return file_hub_v1_hub_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *HierarchicalGeographic) GetCountry() string {
@@ -2812,11 +3751,105 @@ func (x *HierarchicalGeographic) GetArea() string {
 	return ""
 }
 
+// Place is a place name resolved against the MARC country codes and
+// ISO 3166, e.g. "Bethlehem, Pa." as Bethlehem, Pennsylvania (pau, US-PA).
+type Place struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                  // Normalized form ("Bethlehem, Pennsylvania")
+	City          string                 `protobuf:"bytes,2,opt,name=city,proto3" json:"city,omitempty"`                                  // Locality, if given ("Bethlehem")
+	Region        string                 `protobuf:"bytes,3,opt,name=region,proto3" json:"region,omitempty"`                              // State, province, or constituent country ("Pennsylvania")
+	RegionCode    string                 `protobuf:"bytes,4,opt,name=region_code,json=regionCode,proto3" json:"region_code,omitempty"`    // ISO 3166-2 ("US-PA")
+	Country       string                 `protobuf:"bytes,5,opt,name=country,proto3" json:"country,omitempty"`                            // Country name ("United States")
+	CountryCode   string                 `protobuf:"bytes,6,opt,name=country_code,json=countryCode,proto3" json:"country_code,omitempty"` // ISO 3166-1 alpha-2 ("US")
+	MarcCountry   string                 `protobuf:"bytes,7,opt,name=marc_country,json=marcCountry,proto3" json:"marc_country,omitempty"` // MARC Code List for Countries ("pau")
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Place) Reset() {
+	*x = Place{}
+	mi := &file_hub_v1_hub_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Place) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Place) ProtoMessage() {}
+
+func (x *Place) ProtoReflect() protoreflect.Message {
+	mi := &file_hub_v1_hub_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Place.ProtoReflect.Descriptor instead.
+func (*Place) Descriptor() ([]byte, []int) {
+	return file_hub_v1_hub_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *Place) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Place) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *Place) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *Place) GetRegionCode() string {
+	if x != nil {
+		return x.RegionCode
+	}
+	return ""
+}
+
+func (x *Place) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *Place) GetCountryCode() string {
+	if x != nil {
+		return x.CountryCode
+	}
+	return ""
+}
+
+func (x *Place) GetMarcCountry() string {
+	if x != nil {
+		return x.MarcCountry
+	}
+	return ""
+}
+
 var File_hub_v1_hub_proto protoreflect.FileDescriptor
 
 const file_hub_v1_hub_proto_rawDesc = "" +
 	"\n" +
-	"\x10hub/v1/hub.proto\x12\x06hub.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cgoogle/protobuf/struct.proto\"\xda\r\n" +
+	"\x10hub/v1/hub.proto\x12\x06hub.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cgoogle/protobuf/struct.proto\"\xf8\x10\n" +
 	"\x06Record\x12\x14\n" +
 	"\x05title\x18\x01 \x01(\tR\x05title\x12\x1b\n" +
 	"\talt_title\x18\x02 \x03(\tR\baltTitle\x12\x1a\n" +
@@ -2829,7 +3862,8 @@ const file_hub_v1_hub_proto_rawDesc = "" +
 	"\blanguage\x18\t \x01(\tR\blanguage\x12\x1c\n" +
 	"\tpublisher\x18\n" +
 	" \x01(\tR\tpublisher\x12'\n" +
-	"\x0fplace_published\x18\v \x01(\tR\x0eplacePublished\x12<\n" +
+	"\x0fplace_published\x18\v \x01(\tR\x0eplacePublished\x12:\n" +
+	"\x11publication_place\x184 \x01(\v2\r.hub.v1.PlaceR\x10publicationPlace\x12<\n" +
 	"\vpublication\x18\x18 \x01(\v2\x1a.hub.v1.PublicationDetailsR\vpublication\x12&\n" +
 	"\x06rights\x18\f \x03(\v2\x0e.hub.v1.RightsR\x06rights\x12\x1b\n" +
 	"\tis_public\x18\x19 \x01(\bR\bisPublic\x12)\n" +
@@ -2867,10 +3901,17 @@ const file_hub_v1_hub_proto_rawDesc = "" +
 	"\x11local_restriction\x18+ \x01(\tR\x10localRestriction\x12>\n" +
 	"\n" +
 	"geographic\x18, \x01(\v2\x1e.hub.v1.HierarchicalGeographicR\n" +
-	"geographic\x12-\n" +
+	"geographic\x129\n" +
+	"\x0fmembership_path\x18- \x03(\v2\x10.hub.v1.RelationR\x0emembershipPath\x12#\n" +
+	"\rrights_holder\x18. \x01(\tR\frightsHolder\x12/\n" +
+	"\x13copyright_statement\x18/ \x01(\tR\x12copyrightStatement\x12:\n" +
+	"\rdistributions\x180 \x03(\v2\x14.hub.v1.DistributionR\rdistributions\x12+\n" +
+	"\bholdings\x181 \x03(\v2\x0f.hub.v1.HoldingR\bholdings\x12/\n" +
+	"\x06titles\x182 \x03(\v2\x17.hub.v1.LocalizedStringR\x06titles\x125\n" +
+	"\tabstracts\x183 \x03(\v2\x17.hub.v1.LocalizedStringR\tabstracts\x12-\n" +
 	"\x05extra\x18\x16 \x01(\v2\x17.google.protobuf.StructR\x05extra\x123\n" +
 	"\vsource_info\x18\x17 \x01(\v2\x12.hub.v1.SourceInfoR\n" +
-	"sourceInfo\"\xe4\x01\n" +
+	"sourceInfo\"\xd5\x02\n" +
 	"\n" +
 	"SourceInfo\x12\x16\n" +
 	"\x06format\x18\x01 \x01(\tR\x06format\x12%\n" +
@@ -2878,11 +3919,27 @@ const file_hub_v1_hub_proto_rawDesc = "" +
 	"\tsource_id\x18\x03 \x01(\tR\bsourceId\x127\n" +
 	"\tparsed_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\bparsedAt\x12\x18\n" +
 	"\aprofile\x18\x05 \x01(\tR\aprofile\x12'\n" +
-	"\x0funmapped_fields\x18\x06 \x03(\tR\x0eunmappedFields\"\x86\x01\n" +
+	"\x0funmapped_fields\x18\x06 \x03(\tR\x0eunmappedFields\x12#\n" +
+	"\x03oai\x18\a \x01(\v2\x11.hub.v1.OaiHeaderR\x03oai\x120\n" +
+	"\x05steps\x18\b \x03(\v2\x1a.hub.v1.TransformationStepR\x05steps\x12\x18\n" +
+	"\apayload\x18\t \x01(\fR\apayload\"\xad\x01\n" +
+	"\x12TransformationStep\x12\x14\n" +
+	"\x05stage\x18\x01 \x01(\tR\x05stage\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12*\n" +
+	"\x02at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x02at\x12%\n" +
+	"\x0echanged_fields\x18\x04 \x03(\tR\rchangedFields\x12\x1a\n" +
+	"\bwarnings\x18\x05 \x03(\tR\bwarnings\"\x80\x01\n" +
+	"\tOaiHeader\x12\x1e\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\tR\n" +
+	"identifier\x12\x1c\n" +
+	"\tdatestamp\x18\x02 \x01(\tR\tdatestamp\x12\x1b\n" +
+	"\tset_specs\x18\x03 \x03(\tR\bsetSpecs\x12\x18\n" +
+	"\adeleted\x18\x04 \x01(\bR\adeleted\"\x86\x01\n" +
 	"\x05Group\x12%\n" +
 	"\x04type\x18\x01 \x01(\x0e2\x11.hub.v1.GroupTypeR\x04type\x12,\n" +
 	"\tcontainer\x18\x02 \x01(\v2\x0e.hub.v1.RecordR\tcontainer\x12(\n" +
-	"\amembers\x18\x03 \x03(\v2\x0e.hub.v1.RecordR\amembers\"\xda\x04\n" +
+	"\amembers\x18\x03 \x03(\v2\x0e.hub.v1.RecordR\amembers\"\x8f\x05\n" +
 	"\vContributor\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x123\n" +
 	"\vparsed_name\x18\x02 \x01(\v2\x12.hub.v1.ParsedNameR\n" +
@@ -2902,7 +3959,9 @@ const file_hub_v1_hub_proto_rawDesc = "" +
 	"\x0fadditional_name\x18\x0e \x01(\tR\x0eadditionalName\x12\x1b\n" +
 	"\talumni_of\x18\x0f \x03(\tR\balumniOf\x12#\n" +
 	"\rauthority_uri\x18\x10 \x01(\tR\fauthorityUri\x12)\n" +
-	"\x10authority_source\x18\x11 \x01(\tR\x0fauthoritySource\"\xbf\x01\n" +
+	"\x10authority_source\x18\x11 \x01(\tR\x0fauthoritySource\x123\n" +
+	"\vdegree_role\x18\x12 \x01(\x0e2\x12.hub.v1.DegreeRoleR\n" +
+	"degreeRole\"\xbf\x01\n" +
 	"\n" +
 	"ParsedName\x12\x16\n" +
 	"\x06family\x18\x01 \x01(\tR\x06family\x12\x14\n" +
@@ -2913,7 +3972,7 @@ const file_hub_v1_hub_proto_rawDesc = "" +
 	"\tfull_name\x18\x06 \x01(\tR\bfullName\x12\x1e\n" +
 	"\n" +
 	"normalized\x18\a \x01(\tR\n" +
-	"normalized\"\x9d\x03\n" +
+	"normalized\"\xdd\x05\n" +
 	"\tDateValue\x12$\n" +
 	"\x04type\x18\x01 \x01(\x0e2\x10.hub.v1.DateTypeR\x04type\x12\x10\n" +
 	"\x03raw\x18\x02 \x01(\tR\x03raw\x12\x12\n" +
@@ -2928,7 +3987,15 @@ const file_hub_v1_hub_proto_rawDesc = "" +
 	" \x01(\x0e2\x15.hub.v1.DateQualifierR\tqualifier\x12\x19\n" +
 	"\bis_range\x18\v \x01(\bR\aisRange\x12.\n" +
 	"\x04time\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x16\n" +
-	"\x06season\x18\r \x01(\tR\x06season\"\x8b\x01\n" +
+	"\x06season\x18\r \x01(\tR\x06season\x12:\n" +
+	"\rend_precision\x18\x0e \x01(\x0e2\x15.hub.v1.DatePrecisionR\fendPrecision\x12:\n" +
+	"\rend_qualifier\x18\x0f \x01(\x0e2\x15.hub.v1.DateQualifierR\fendQualifier\x122\n" +
+	"\vstart_bound\x18\x10 \x01(\x0e2\x11.hub.v1.DateBoundR\n" +
+	"startBound\x12.\n" +
+	"\tend_bound\x18\x11 \x01(\x0e2\x11.hub.v1.DateBoundR\bendBound\x12.\n" +
+	"\bset_type\x18\x12 \x01(\x0e2\x13.hub.v1.DateSetTypeR\asetType\x122\n" +
+	"\vset_members\x18\x13 \x03(\v2\x11.hub.v1.DateValueR\n" +
+	"setMembers\"\x8b\x01\n" +
 	"\n" +
 	"Identifier\x12*\n" +
 	"\x04type\x18\x01 \x01(\x0e2\x16.hub.v1.IdentifierTypeR\x04type\x12\x14\n" +
@@ -2990,7 +4057,10 @@ const file_hub_v1_hub_proto_rawDesc = "" +
 	"\n" +
 	"identifier\x18\x02 \x01(\tR\n" +
 	"identifier\x12'\n" +
-	"\x0fidentifier_type\x18\x03 \x01(\tR\x0eidentifierType\"\xa0\x01\n" +
+	"\x0fidentifier_type\x18\x03 \x01(\tR\x0eidentifierType\"C\n" +
+	"\x0fLocalizedString\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\x12\x1a\n" +
+	"\blanguage\x18\x02 \x01(\tR\blanguage\"\xf9\x01\n" +
 	"\x04File\x12\x12\n" +
 	"\x04path\x18\x01 \x01(\tR\x04path\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1b\n" +
@@ -2998,14 +4068,56 @@ const file_hub_v1_hub_proto_rawDesc = "" +
 	"\n" +
 	"size_bytes\x18\x04 \x01(\x03R\tsizeBytes\x12 \n" +
 	"\vdescription\x18\x05 \x01(\tR\vdescription\x12\x12\n" +
-	"\x04role\x18\x06 \x01(\tR\x04role\"t\n" +
+	"\x04role\x18\x06 \x01(\tR\x04role\x12.\n" +
+	"\tchecksums\x18\a \x03(\v2\x10.hub.v1.ChecksumR\tchecksums\x12'\n" +
+	"\x05media\x18\b \x01(\v2\x11.hub.v1.MediaInfoR\x05media\"\x88\x04\n" +
+	"\tMediaInfo\x12\x1d\n" +
+	"\n" +
+	"media_type\x18\x01 \x01(\tR\tmediaType\x12\x1e\n" +
+	"\n" +
+	"generation\x18\x02 \x01(\tR\n" +
+	"generation\x12'\n" +
+	"\x0fphysical_format\x18\x03 \x01(\tR\x0ephysicalFormat\x12\x1a\n" +
+	"\blocation\x18\x04 \x01(\tR\blocation\x12\x1a\n" +
+	"\bduration\x18\x05 \x01(\tR\bduration\x12\x1a\n" +
+	"\bstandard\x18\x06 \x01(\tR\bstandard\x12\x16\n" +
+	"\x06tracks\x18\a \x01(\tR\x06tracks\x123\n" +
+	"\x15channel_configuration\x18\b \x01(\tR\x14channelConfiguration\x12\x1b\n" +
+	"\tdata_rate\x18\t \x01(\tR\bdataRate\x12#\n" +
+	"\rsampling_rate\x18\n" +
+	" \x01(\tR\fsamplingRate\x12\x1b\n" +
+	"\tbit_depth\x18\v \x01(\tR\bbitDepth\x12\x1d\n" +
+	"\n" +
+	"frame_rate\x18\f \x01(\tR\tframeRate\x12\x1d\n" +
+	"\n" +
+	"frame_size\x18\r \x01(\tR\tframeSize\x12!\n" +
+	"\faspect_ratio\x18\x0e \x01(\tR\vaspectRatio\x12\x16\n" +
+	"\x06colors\x18\x0f \x01(\tR\x06colors\x12\x1a\n" +
+	"\bencoding\x18\x10 \x01(\tR\bencoding\"\xbd\x01\n" +
+	"\fDistribution\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06format\x18\x03 \x01(\tR\x06format\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x04 \x01(\x03R\tsizeBytes\x12.\n" +
+	"\tchecksums\x18\x05 \x03(\v2\x10.hub.v1.ChecksumR\tchecksums\x12 \n" +
+	"\vdescription\x18\x06 \x01(\tR\vdescription\">\n" +
+	"\bChecksum\x12\x1c\n" +
+	"\talgorithm\x18\x01 \x01(\tR\talgorithm\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\"t\n" +
 	"\x10ArchivalLocation\x12\x1e\n" +
 	"\n" +
 	"collection\x18\x01 \x01(\tR\n" +
 	"collection\x12\x16\n" +
 	"\x06series\x18\x02 \x01(\tR\x06series\x12\x10\n" +
 	"\x03box\x18\x03 \x01(\tR\x03box\x12\x16\n" +
-	"\x06folder\x18\x04 \x01(\tR\x06folder\"\x99\x01\n" +
+	"\x06folder\x18\x04 \x01(\tR\x06folder\"\x9c\x01\n" +
+	"\aHolding\x12 \n" +
+	"\vinstitution\x18\x01 \x01(\tR\vinstitution\x12 \n" +
+	"\vsublocation\x18\x02 \x01(\tR\vsublocation\x12\x1f\n" +
+	"\vcall_number\x18\x03 \x01(\tR\n" +
+	"callNumber\x12,\n" +
+	"\x12call_number_scheme\x18\x04 \x01(\tR\x10callNumberScheme\"\x99\x01\n" +
 	"\x12PublicationDetails\x12\x14\n" +
 	"\x05title\x18\x01 \x01(\tR\x05title\x12\x16\n" +
 	"\x06volume\x18\x02 \x01(\tR\x06volume\x12\x14\n" +
@@ -3018,7 +4130,16 @@ const file_hub_v1_hub_proto_rawDesc = "" +
 	"\x05state\x18\x02 \x01(\tR\x05state\x12\x16\n" +
 	"\x06county\x18\x03 \x01(\tR\x06county\x12\x12\n" +
 	"\x04city\x18\x04 \x01(\tR\x04city\x12\x12\n" +
-	"\x04area\x18\x05 \x01(\tR\x04area*\x86\x01\n" +
+	"\x04area\x18\x05 \x01(\tR\x04area\"\xc8\x01\n" +
+	"\x05Place\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04city\x18\x02 \x01(\tR\x04city\x12\x16\n" +
+	"\x06region\x18\x03 \x01(\tR\x06region\x12\x1f\n" +
+	"\vregion_code\x18\x04 \x01(\tR\n" +
+	"regionCode\x12\x18\n" +
+	"\acountry\x18\x05 \x01(\tR\acountry\x12!\n" +
+	"\fcountry_code\x18\x06 \x01(\tR\vcountryCode\x12!\n" +
+	"\fmarc_country\x18\a \x01(\tR\vmarcCountry*\x86\x01\n" +
 	"\tGroupType\x12\x1a\n" +
 	"\x16GROUP_TYPE_UNSPECIFIED\x10\x00\x12\x14\n" +
 	"\x10GROUP_TYPE_ISSUE\x10\x01\x12\x19\n" +
@@ -3028,7 +4149,12 @@ const file_hub_v1_hub_proto_rawDesc = "" +
 	"\x0fContributorType\x12 \n" +
 	"\x1cCONTRIBUTOR_TYPE_UNSPECIFIED\x10\x00\x12\x1b\n" +
 	"\x17CONTRIBUTOR_TYPE_PERSON\x10\x01\x12!\n" +
-	"\x1dCONTRIBUTOR_TYPE_ORGANIZATION\x10\x02*\xd8\x02\n" +
+	"\x1dCONTRIBUTOR_TYPE_ORGANIZATION\x10\x02*d\n" +
+	"\n" +
+	"DegreeRole\x12\x1b\n" +
+	"\x17DEGREE_ROLE_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13DEGREE_ROLE_ADVISOR\x10\x01\x12 \n" +
+	"\x1cDEGREE_ROLE_COMMITTEE_MEMBER\x10\x02*\xd8\x02\n" +
 	"\bDateType\x12\x19\n" +
 	"\x15DATE_TYPE_UNSPECIFIED\x10\x00\x12\x14\n" +
 	"\x10DATE_TYPE_ISSUED\x10\x01\x12\x15\n" +
@@ -3044,7 +4170,7 @@ const file_hub_v1_hub_proto_rawDesc = "" +
 	"\x12\x13\n" +
 	"\x0fDATE_TYPE_VALID\x10\v\x12\x15\n" +
 	"\x11DATE_TYPE_UPDATED\x10\f\x12\x17\n" +
-	"\x13DATE_TYPE_COLLECTED\x10\r*\xca\x01\n" +
+	"\x13DATE_TYPE_COLLECTED\x10\r*\xe5\x01\n" +
 	"\rDatePrecision\x12\x1e\n" +
 	"\x1aDATE_PRECISION_UNSPECIFIED\x10\x00\x12\x17\n" +
 	"\x13DATE_PRECISION_YEAR\x10\x01\x12\x18\n" +
@@ -3052,12 +4178,21 @@ const file_hub_v1_hub_proto_rawDesc = "" +
 	"\x12DATE_PRECISION_DAY\x10\x03\x12\x17\n" +
 	"\x13DATE_PRECISION_TIME\x10\x04\x12\x19\n" +
 	"\x15DATE_PRECISION_DECADE\x10\x05\x12\x1a\n" +
-	"\x16DATE_PRECISION_CENTURY\x10\x06*\x86\x01\n" +
+	"\x16DATE_PRECISION_CENTURY\x10\x06\x12\x19\n" +
+	"\x15DATE_PRECISION_SEASON\x10\a*\x86\x01\n" +
 	"\rDateQualifier\x12\x1e\n" +
 	"\x1aDATE_QUALIFIER_UNSPECIFIED\x10\x00\x12\x1e\n" +
 	"\x1aDATE_QUALIFIER_APPROXIMATE\x10\x01\x12\x1c\n" +
 	"\x18DATE_QUALIFIER_UNCERTAIN\x10\x02\x12\x17\n" +
-	"\x13DATE_QUALIFIER_BOTH\x10\x03*\xe3\x03\n" +
+	"\x13DATE_QUALIFIER_BOTH\x10\x03*T\n" +
+	"\tDateBound\x12\x1a\n" +
+	"\x16DATE_BOUND_UNSPECIFIED\x10\x00\x12\x13\n" +
+	"\x0fDATE_BOUND_OPEN\x10\x01\x12\x16\n" +
+	"\x12DATE_BOUND_UNKNOWN\x10\x02*`\n" +
+	"\vDateSetType\x12\x1d\n" +
+	"\x19DATE_SET_TYPE_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14DATE_SET_TYPE_ONE_OF\x10\x01\x12\x18\n" +
+	"\x14DATE_SET_TYPE_ALL_OF\x10\x02*\xaf\x04\n" +
 	"\x0eIdentifierType\x12\x1f\n" +
 	"\x1bIDENTIFIER_TYPE_UNSPECIFIED\x10\x00\x12\x17\n" +
 	"\x13IDENTIFIER_TYPE_DOI\x10\x01\x12\x17\n" +
@@ -3076,7 +4211,10 @@ const file_hub_v1_hub_proto_rawDesc = "" +
 	"\x14IDENTIFIER_TYPE_UUID\x10\r\x12\x18\n" +
 	"\x14IDENTIFIER_TYPE_ISNI\x10\x0e\x12!\n" +
 	"\x1dIDENTIFIER_TYPE_REPORT_NUMBER\x10\x0f\x12\x1f\n" +
-	"\x1bIDENTIFIER_TYPE_CALL_NUMBER\x10\x10*\xc2\x01\n" +
+	"\x1bIDENTIFIER_TYPE_CALL_NUMBER\x10\x10\x12\x17\n" +
+	"\x13IDENTIFIER_TYPE_ARK\x10\x11\x12\x17\n" +
+	"\x13IDENTIFIER_TYPE_URN\x10\x12\x12\x18\n" +
+	"\x14IDENTIFIER_TYPE_PURL\x10\x13*\xc2\x01\n" +
 	"\vSubjectType\x12\x1c\n" +
 	"\x18SUBJECT_TYPE_UNSPECIFIED\x10\x00\x12\x16\n" +
 	"\x12SUBJECT_TYPE_TOPIC\x10\x01\x12\x15\n" +
@@ -3084,7 +4222,7 @@ const file_hub_v1_hub_proto_rawDesc = "" +
 	"\x17SUBJECT_TYPE_GEOGRAPHIC\x10\x03\x12\x19\n" +
 	"\x15SUBJECT_TYPE_TEMPORAL\x10\x04\x12\x16\n" +
 	"\x12SUBJECT_TYPE_GENRE\x10\x05\x12\x16\n" +
-	"\x12SUBJECT_TYPE_TITLE\x10\x06*\xf2\x03\n" +
+	"\x12SUBJECT_TYPE_TITLE\x10\x06*\xae\x04\n" +
 	"\x11SubjectVocabulary\x12\"\n" +
 	"\x1eSUBJECT_VOCABULARY_UNSPECIFIED\x10\x00\x12\x1b\n" +
 	"\x17SUBJECT_VOCABULARY_LCSH\x10\x01\x12\x1b\n" +
@@ -3102,7 +4240,9 @@ const file_hub_v1_hub_proto_rawDesc = "" +
 	"\x18SUBJECT_VOCABULARY_ARXIV\x10\f\x12\x1a\n" +
 	"\x16SUBJECT_VOCABULARY_MSC\x10\r\x12\x1a\n" +
 	"\x16SUBJECT_VOCABULARY_ACM\x10\x0e\x12\x1b\n" +
-	"\x17SUBJECT_VOCABULARY_PACS\x10\x0f*\x90\b\n" +
+	"\x17SUBJECT_VOCABULARY_PACS\x10\x0f\x12\x1c\n" +
+	"\x18SUBJECT_VOCABULARY_BISAC\x10\x10\x12\x1c\n" +
+	"\x18SUBJECT_VOCABULARY_THEMA\x10\x11*\x90\b\n" +
 	"\x11ResourceTypeValue\x12\x1d\n" +
 	"\x19RESOURCE_TYPE_UNSPECIFIED\x10\x00\x12\x19\n" +
 	"\x15RESOURCE_TYPE_ARTICLE\x10\x01\x12\x16\n" +
@@ -3175,9 +4315,7 @@ const file_hub_v1_hub_proto_rawDesc = "" +
 	"\x1dRELATION_TYPE_SUPPLEMENTED_BY\x10\x1e\x12\x1d\n" +
 	"\x19RELATION_TYPE_REQUIRED_BY\x10\x1f\x12\x1a\n" +
 	"\x16RELATION_TYPE_REQUIRES\x10 \x12\x19\n" +
-	"\x15RELATION_TYPE_REVIEWS\x10!B\x95\x01\n" +
-	"\n" +
-	"com.hub.v1B\bHubProtoP\x01ZDgithub.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1;hubv1\xa2\x02\x03HXX\xaa\x02\x06Hub.V1\xca\x02\x06Hub\\V1\xe2\x02\x12Hub\\V1\\GPBMetadata\xea\x02\aHub::V1b\x06proto3"
+	"\x15RELATION_TYPE_REVIEWS\x10!B?Z=github.com/lehigh-university-libraries/crosswalk/hub/v1;hubv1b\x06proto3"
 
 var (
 	file_hub_v1_hub_proto_rawDescOnce sync.Once
@@ -3191,83 +4329,113 @@ func file_hub_v1_hub_proto_rawDescGZIP() []byte {
 	return file_hub_v1_hub_proto_rawDescData
 }
 
-var file_hub_v1_hub_proto_enumTypes = make([]protoimpl.EnumInfo, 10)
-var file_hub_v1_hub_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_hub_v1_hub_proto_enumTypes = make([]protoimpl.EnumInfo, 13)
+var file_hub_v1_hub_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
 var file_hub_v1_hub_proto_goTypes = []any{
 	(GroupType)(0),                 // 0: hub.v1.GroupType
 	(ContributorType)(0),           // 1: hub.v1.ContributorType
-	(DateType)(0),                  // 2: hub.v1.DateType
-	(DatePrecision)(0),             // 3: hub.v1.DatePrecision
-	(DateQualifier)(0),             // 4: hub.v1.DateQualifier
-	(IdentifierType)(0),            // 5: hub.v1.IdentifierType
-	(SubjectType)(0),               // 6: hub.v1.SubjectType
-	(SubjectVocabulary)(0),         // 7: hub.v1.SubjectVocabulary
-	(ResourceTypeValue)(0),         // 8: hub.v1.ResourceTypeValue
-	(RelationType)(0),              // 9: hub.v1.RelationType
-	(*Record)(nil),                 // 10: hub.v1.Record
-	(*SourceInfo)(nil),             // 11: hub.v1.SourceInfo
-	(*Group)(nil),                  // 12: hub.v1.Group
-	(*Contributor)(nil),            // 13: hub.v1.Contributor
-	(*ParsedName)(nil),             // 14: hub.v1.ParsedName
-	(*DateValue)(nil),              // 15: hub.v1.DateValue
-	(*Identifier)(nil),             // 16: hub.v1.Identifier
-	(*Subject)(nil),                // 17: hub.v1.Subject
-	(*Rights)(nil),                 // 18: hub.v1.Rights
-	(*ResourceType)(nil),           // 19: hub.v1.ResourceType
-	(*Relation)(nil),               // 20: hub.v1.Relation
-	(*DegreeInfo)(nil),             // 21: hub.v1.DegreeInfo
-	(*Funder)(nil),                 // 22: hub.v1.Funder
-	(*Affiliation)(nil),            // 23: hub.v1.Affiliation
-	(*File)(nil),                   // 24: hub.v1.File
-	(*ArchivalLocation)(nil),       // 25: hub.v1.ArchivalLocation
-	(*PublicationDetails)(nil),     // 26: hub.v1.PublicationDetails
-	(*HierarchicalGeographic)(nil), // 27: hub.v1.HierarchicalGeographic
-	(*structpb.Struct)(nil),        // 28: google.protobuf.Struct
-	(*timestamppb.Timestamp)(nil),  // 29: google.protobuf.Timestamp
+	(DegreeRole)(0),                // 2: hub.v1.DegreeRole
+	(DateType)(0),                  // 3: hub.v1.DateType
+	(DatePrecision)(0),             // 4: hub.v1.DatePrecision
+	(DateQualifier)(0),             // 5: hub.v1.DateQualifier
+	(DateBound)(0),                 // 6: hub.v1.DateBound
+	(DateSetType)(0),               // 7: hub.v1.DateSetType
+	(IdentifierType)(0),            // 8: hub.v1.IdentifierType
+	(SubjectType)(0),               // 9: hub.v1.SubjectType
+	(SubjectVocabulary)(0),         // 10: hub.v1.SubjectVocabulary
+	(ResourceTypeValue)(0),         // 11: hub.v1.ResourceTypeValue
+	(RelationType)(0),              // 12: hub.v1.RelationType
+	(*Record)(nil),                 // 13: hub.v1.Record
+	(*SourceInfo)(nil),             // 14: hub.v1.SourceInfo
+	(*TransformationStep)(nil),     // 15: hub.v1.TransformationStep
+	(*OaiHeader)(nil),              // 16: hub.v1.OaiHeader
+	(*Group)(nil),                  // 17: hub.v1.Group
+	(*Contributor)(nil),            // 18: hub.v1.Contributor
+	(*ParsedName)(nil),             // 19: hub.v1.ParsedName
+	(*DateValue)(nil),              // 20: hub.v1.DateValue
+	(*Identifier)(nil),             // 21: hub.v1.Identifier
+	(*Subject)(nil),                // 22: hub.v1.Subject
+	(*Rights)(nil),                 // 23: hub.v1.Rights
+	(*ResourceType)(nil),           // 24: hub.v1.ResourceType
+	(*Relation)(nil),               // 25: hub.v1.Relation
+	(*DegreeInfo)(nil),             // 26: hub.v1.DegreeInfo
+	(*Funder)(nil),                 // 27: hub.v1.Funder
+	(*Affiliation)(nil),            // 28: hub.v1.Affiliation
+	(*LocalizedString)(nil),        // 29: hub.v1.LocalizedString
+	(*File)(nil),                   // 30: hub.v1.File
+	(*MediaInfo)(nil),              // 31: hub.v1.MediaInfo
+	(*Distribution)(nil),           // 32: hub.v1.Distribution
+	(*Checksum)(nil),               // 33: hub.v1.Checksum
+	(*ArchivalLocation)(nil),       // 34: hub.v1.ArchivalLocation
+	(*Holding)(nil),                // 35: hub.v1.Holding
+	(*PublicationDetails)(nil),     // 36: hub.v1.PublicationDetails
+	(*HierarchicalGeographic)(nil), // 37: hub.v1.HierarchicalGeographic
+	(*Place)(nil),                  // 38: hub.v1.Place
+	(*structpb.Struct)(nil),        // 39: google.protobuf.Struct
+	(*timestamppb.Timestamp)(nil),  // 40: google.protobuf.Timestamp
 }
 var file_hub_v1_hub_proto_depIdxs = []int32{
-	13, // 0: hub.v1.Record.contributors:type_name -> hub.v1.Contributor
-	15, // 1: hub.v1.Record.dates:type_name -> hub.v1.DateValue
-	19, // 2: hub.v1.Record.resource_type:type_name -> hub.v1.ResourceType
-	17, // 3: hub.v1.Record.genres:type_name -> hub.v1.Subject
-	17, // 4: hub.v1.Record.subjects:type_name -> hub.v1.Subject
-	26, // 5: hub.v1.Record.publication:type_name -> hub.v1.PublicationDetails
-	18, // 6: hub.v1.Record.rights:type_name -> hub.v1.Rights
-	16, // 7: hub.v1.Record.identifiers:type_name -> hub.v1.Identifier
-	25, // 8: hub.v1.Record.archival_location:type_name -> hub.v1.ArchivalLocation
-	24, // 9: hub.v1.Record.files:type_name -> hub.v1.File
-	17, // 10: hub.v1.Record.physical_form:type_name -> hub.v1.Subject
-	20, // 11: hub.v1.Record.relations:type_name -> hub.v1.Relation
-	21, // 12: hub.v1.Record.degree_info:type_name -> hub.v1.DegreeInfo
-	22, // 13: hub.v1.Record.funders:type_name -> hub.v1.Funder
-	27, // 14: hub.v1.Record.geographic:type_name -> hub.v1.HierarchicalGeographic
-	28, // 15: hub.v1.Record.extra:type_name -> google.protobuf.Struct
-	11, // 16: hub.v1.Record.source_info:type_name -> hub.v1.SourceInfo
-	29, // 17: hub.v1.SourceInfo.parsed_at:type_name -> google.protobuf.Timestamp
-	0,  // 18: hub.v1.Group.type:type_name -> hub.v1.GroupType
-	10, // 19: hub.v1.Group.container:type_name -> hub.v1.Record
-	10, // 20: hub.v1.Group.members:type_name -> hub.v1.Record
-	14, // 21: hub.v1.Contributor.parsed_name:type_name -> hub.v1.ParsedName
-	1,  // 22: hub.v1.Contributor.type:type_name -> hub.v1.ContributorType
-	16, // 23: hub.v1.Contributor.identifiers:type_name -> hub.v1.Identifier
-	23, // 24: hub.v1.Contributor.affiliations:type_name -> hub.v1.Affiliation
-	2,  // 25: hub.v1.DateValue.type:type_name -> hub.v1.DateType
-	3,  // 26: hub.v1.DateValue.precision:type_name -> hub.v1.DatePrecision
-	4,  // 27: hub.v1.DateValue.qualifier:type_name -> hub.v1.DateQualifier
-	29, // 28: hub.v1.DateValue.time:type_name -> google.protobuf.Timestamp
-	5,  // 29: hub.v1.Identifier.type:type_name -> hub.v1.IdentifierType
-	7,  // 30: hub.v1.Subject.vocabulary:type_name -> hub.v1.SubjectVocabulary
-	6,  // 31: hub.v1.Subject.type:type_name -> hub.v1.SubjectType
-	8,  // 32: hub.v1.ResourceType.type:type_name -> hub.v1.ResourceTypeValue
-	9,  // 33: hub.v1.Relation.type:type_name -> hub.v1.RelationType
-	5,  // 34: hub.v1.Relation.target_id_type:type_name -> hub.v1.IdentifierType
-	8,  // 35: hub.v1.Relation.target_resource_type:type_name -> hub.v1.ResourceTypeValue
-	15, // 36: hub.v1.DegreeInfo.date:type_name -> hub.v1.DateValue
-	37, // [37:37] is the sub-list for method output_type
-	37, // [37:37] is the sub-list for method input_type
-	37, // [37:37] is the sub-list for extension type_name
-	37, // [37:37] is the sub-list for extension extendee
-	0,  // [0:37] is the sub-list for field type_name
+	18, // 0: hub.v1.Record.contributors:type_name -> hub.v1.Contributor
+	20, // 1: hub.v1.Record.dates:type_name -> hub.v1.DateValue
+	24, // 2: hub.v1.Record.resource_type:type_name -> hub.v1.ResourceType
+	22, // 3: hub.v1.Record.genres:type_name -> hub.v1.Subject
+	22, // 4: hub.v1.Record.subjects:type_name -> hub.v1.Subject
+	38, // 5: hub.v1.Record.publication_place:type_name -> hub.v1.Place
+	36, // 6: hub.v1.Record.publication:type_name -> hub.v1.PublicationDetails
+	23, // 7: hub.v1.Record.rights:type_name -> hub.v1.Rights
+	21, // 8: hub.v1.Record.identifiers:type_name -> hub.v1.Identifier
+	34, // 9: hub.v1.Record.archival_location:type_name -> hub.v1.ArchivalLocation
+	30, // 10: hub.v1.Record.files:type_name -> hub.v1.File
+	22, // 11: hub.v1.Record.physical_form:type_name -> hub.v1.Subject
+	25, // 12: hub.v1.Record.relations:type_name -> hub.v1.Relation
+	26, // 13: hub.v1.Record.degree_info:type_name -> hub.v1.DegreeInfo
+	27, // 14: hub.v1.Record.funders:type_name -> hub.v1.Funder
+	37, // 15: hub.v1.Record.geographic:type_name -> hub.v1.HierarchicalGeographic
+	25, // 16: hub.v1.Record.membership_path:type_name -> hub.v1.Relation
+	32, // 17: hub.v1.Record.distributions:type_name -> hub.v1.Distribution
+	35, // 18: hub.v1.Record.holdings:type_name -> hub.v1.Holding
+	29, // 19: hub.v1.Record.titles:type_name -> hub.v1.LocalizedString
+	29, // 20: hub.v1.Record.abstracts:type_name -> hub.v1.LocalizedString
+	39, // 21: hub.v1.Record.extra:type_name -> google.protobuf.Struct
+	14, // 22: hub.v1.Record.source_info:type_name -> hub.v1.SourceInfo
+	40, // 23: hub.v1.SourceInfo.parsed_at:type_name -> google.protobuf.Timestamp
+	16, // 24: hub.v1.SourceInfo.oai:type_name -> hub.v1.OaiHeader
+	15, // 25: hub.v1.SourceInfo.steps:type_name -> hub.v1.TransformationStep
+	40, // 26: hub.v1.TransformationStep.at:type_name -> google.protobuf.Timestamp
+	0,  // 27: hub.v1.Group.type:type_name -> hub.v1.GroupType
+	13, // 28: hub.v1.Group.container:type_name -> hub.v1.Record
+	13, // 29: hub.v1.Group.members:type_name -> hub.v1.Record
+	19, // 30: hub.v1.Contributor.parsed_name:type_name -> hub.v1.ParsedName
+	1,  // 31: hub.v1.Contributor.type:type_name -> hub.v1.ContributorType
+	21, // 32: hub.v1.Contributor.identifiers:type_name -> hub.v1.Identifier
+	28, // 33: hub.v1.Contributor.affiliations:type_name -> hub.v1.Affiliation
+	2,  // 34: hub.v1.Contributor.degree_role:type_name -> hub.v1.DegreeRole
+	3,  // 35: hub.v1.DateValue.type:type_name -> hub.v1.DateType
+	4,  // 36: hub.v1.DateValue.precision:type_name -> hub.v1.DatePrecision
+	5,  // 37: hub.v1.DateValue.qualifier:type_name -> hub.v1.DateQualifier
+	40, // 38: hub.v1.DateValue.time:type_name -> google.protobuf.Timestamp
+	4,  // 39: hub.v1.DateValue.end_precision:type_name -> hub.v1.DatePrecision
+	5,  // 40: hub.v1.DateValue.end_qualifier:type_name -> hub.v1.DateQualifier
+	6,  // 41: hub.v1.DateValue.start_bound:type_name -> hub.v1.DateBound
+	6,  // 42: hub.v1.DateValue.end_bound:type_name -> hub.v1.DateBound
+	7,  // 43: hub.v1.DateValue.set_type:type_name -> hub.v1.DateSetType
+	20, // 44: hub.v1.DateValue.set_members:type_name -> hub.v1.DateValue
+	8,  // 45: hub.v1.Identifier.type:type_name -> hub.v1.IdentifierType
+	10, // 46: hub.v1.Subject.vocabulary:type_name -> hub.v1.SubjectVocabulary
+	9,  // 47: hub.v1.Subject.type:type_name -> hub.v1.SubjectType
+	11, // 48: hub.v1.ResourceType.type:type_name -> hub.v1.ResourceTypeValue
+	12, // 49: hub.v1.Relation.type:type_name -> hub.v1.RelationType
+	8,  // 50: hub.v1.Relation.target_id_type:type_name -> hub.v1.IdentifierType
+	11, // 51: hub.v1.Relation.target_resource_type:type_name -> hub.v1.ResourceTypeValue
+	20, // 52: hub.v1.DegreeInfo.date:type_name -> hub.v1.DateValue
+	33, // 53: hub.v1.File.checksums:type_name -> hub.v1.Checksum
+	31, // 54: hub.v1.File.media:type_name -> hub.v1.MediaInfo
+	33, // 55: hub.v1.Distribution.checksums:type_name -> hub.v1.Checksum
+	56, // [56:56] is the sub-list for method output_type
+	56, // [56:56] is the sub-list for method input_type
+	56, // [56:56] is the sub-list for extension type_name
+	56, // [56:56] is the sub-list for extension extendee
+	0,  // [0:56] is the sub-list for field type_name
 }
 
 func init() { file_hub_v1_hub_proto_init() }
@@ -3280,8 +4448,8 @@ func file_hub_v1_hub_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_hub_v1_hub_proto_rawDesc), len(file_hub_v1_hub_proto_rawDesc)),
-			NumEnums:      10,
-			NumMessages:   18,
+			NumEnums:      13,
+			NumMessages:   26,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
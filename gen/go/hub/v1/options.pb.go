@@ -58,8 +58,7 @@ type FieldOptions struct {
 	ResourceType string `protobuf:"bytes,16,opt,name=resource_type,json=resourceType,proto3" json:"resource_type,omitempty"`
 	// Parser to apply when reading this field
 	// Values: passthrough, edtf, iso8601, year, bibtex_name, csl_name, split,
-	//
-	//	strip_html, normalize_whitespace, doi, isbn, orcid, url, relator, custom
+	//         strip_html, normalize_whitespace, doi, isbn, orcid, url, relator, custom
 	Parser string `protobuf:"bytes,20,opt,name=parser,proto3" json:"parser,omitempty"`
 	// Custom parser name (when parser = "custom")
 	CustomParser string `protobuf:"bytes,21,opt,name=custom_parser,json=customParser,proto3" json:"custom_parser,omitempty"`
@@ -67,6 +66,20 @@ type FieldOptions struct {
 	DateFormat string `protobuf:"bytes,22,opt,name=date_format,json=dateFormat,proto3" json:"date_format,omitempty"`
 	// Delimiter for splitting multi-value strings
 	Delimiter string `protobuf:"bytes,23,opt,name=delimiter,proto3" json:"delimiter,omitempty"`
+	// Split each value into several on this separator
+	Split string `protobuf:"bytes,80,opt,name=split,proto3" json:"split,omitempty"`
+	// Keep the part of each value this regular expression matches, or its
+	// first group when it has one; values it doesn't match are dropped
+	RegexExtract string `protobuf:"bytes,81,opt,name=regex_extract,json=regexExtract,proto3" json:"regex_extract,omitempty"`
+	// Transform directives, as in a mapping profile's transform
+	// (e.g., "s/^Digitized: //", "strip_html", "lowercase")
+	Transform []string `protobuf:"bytes,82,rep,name=transform,proto3" json:"transform,omitempty"`
+	// Text added to the start of each value that doesn't already have it
+	Prefix string `protobuf:"bytes,83,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	// Text added to the end of each value that doesn't already have it
+	Suffix string `protobuf:"bytes,84,opt,name=suffix,proto3" json:"suffix,omitempty"`
+	// Join the values into one with this separator
+	Join string `protobuf:"bytes,85,opt,name=join,proto3" json:"join,omitempty"`
 	// Validators to apply (comma-separated)
 	// Values: required, doi, isbn, issn, orcid, url, email, iso8601, edtf, year_range, pattern, length, range
 	Validators string `protobuf:"bytes,30,opt,name=validators,proto3" json:"validators,omitempty"`
@@ -229,6 +242,48 @@ func (x *FieldOptions) GetDelimiter() string {
 	return ""
 }
 
+func (x *FieldOptions) GetSplit() string {
+	if x != nil {
+		return x.Split
+	}
+	return ""
+}
+
+func (x *FieldOptions) GetRegexExtract() string {
+	if x != nil {
+		return x.RegexExtract
+	}
+	return ""
+}
+
+func (x *FieldOptions) GetTransform() []string {
+	if x != nil {
+		return x.Transform
+	}
+	return nil
+}
+
+func (x *FieldOptions) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *FieldOptions) GetSuffix() string {
+	if x != nil {
+		return x.Suffix
+	}
+	return ""
+}
+
+func (x *FieldOptions) GetJoin() string {
+	if x != nil {
+		return x.Join
+	}
+	return ""
+}
+
 func (x *FieldOptions) GetValidators() string {
 	if x != nil {
 		return x.Validators
@@ -659,7 +714,7 @@ var File_hub_v1_options_proto protoreflect.FileDescriptor
 
 const file_hub_v1_options_proto_rawDesc = "" +
 	"\n" +
-	"\x14hub/v1/options.proto\x12\x06hub.v1\x1a google/protobuf/descriptor.proto\"\xa9\b\n" +
+	"\x14hub/v1/options.proto\x12\x06hub.v1\x1a google/protobuf/descriptor.proto\"\xc6\t\n" +
 	"\fFieldOptions\x12\x16\n" +
 	"\x06target\x18\x01 \x01(\tR\x06target\x12\x1b\n" +
 	"\tdate_type\x18\n" +
@@ -674,7 +729,13 @@ const file_hub_v1_options_proto_rawDesc = "" +
 	"\rcustom_parser\x18\x15 \x01(\tR\fcustomParser\x12\x1f\n" +
 	"\vdate_format\x18\x16 \x01(\tR\n" +
 	"dateFormat\x12\x1c\n" +
-	"\tdelimiter\x18\x17 \x01(\tR\tdelimiter\x12\x1e\n" +
+	"\tdelimiter\x18\x17 \x01(\tR\tdelimiter\x12\x14\n" +
+	"\x05split\x18P \x01(\tR\x05split\x12#\n" +
+	"\rregex_extract\x18Q \x01(\tR\fregexExtract\x12\x1c\n" +
+	"\ttransform\x18R \x03(\tR\ttransform\x12\x16\n" +
+	"\x06prefix\x18S \x01(\tR\x06prefix\x12\x16\n" +
+	"\x06suffix\x18T \x01(\tR\x06suffix\x12\x12\n" +
+	"\x04join\x18U \x01(\tR\x04join\x12\x1e\n" +
 	"\n" +
 	"validators\x18\x1e \x01(\tR\n" +
 	"validators\x12\x18\n" +
@@ -724,9 +785,7 @@ const file_hub_v1_options_proto_rawDesc = "" +
 	"\x05field\x12\x1d.google.protobuf.FieldOptions\x18ц\x03 \x01(\v2\x14.hub.v1.FieldOptionsR\x05field:S\n" +
 	"\amessage\x12\x1f.google.protobuf.MessageOptions\x18ц\x03 \x01(\v2\x16.hub.v1.MessageOptionsR\amessage:\\\n" +
 	"\n" +
-	"enum_value\x12!.google.protobuf.EnumValueOptions\x18ц\x03 \x01(\v2\x18.hub.v1.EnumValueOptionsR\tenumValueB\x99\x01\n" +
-	"\n" +
-	"com.hub.v1B\fOptionsProtoP\x01ZDgithub.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1;hubv1\xa2\x02\x03HXX\xaa\x02\x06Hub.V1\xca\x02\x06Hub\\V1\xe2\x02\x12Hub\\V1\\GPBMetadata\xea\x02\aHub::V1b\x06proto3"
+	"enum_value\x12!.google.protobuf.EnumValueOptions\x18ц\x03 \x01(\v2\x18.hub.v1.EnumValueOptionsR\tenumValueB?Z=github.com/lehigh-university-libraries/crosswalk/hub/v1;hubv1b\x06proto3"
 
 var (
 	file_hub_v1_options_proto_rawDescOnce sync.Once
@@ -230,7 +230,9 @@ type Entry struct {
 	// Primary class (for arXiv)
 	Primaryclass string `protobuf:"bytes,37,opt,name=primaryclass,proto3" json:"primaryclass,omitempty"`
 	// URL access date
-	Urldate       string `protobuf:"bytes,38,opt,name=urldate,proto3" json:"urldate,omitempty"`
+	Urldate string `protobuf:"bytes,38,opt,name=urldate,proto3" json:"urldate,omitempty"`
+	// Full publication date (BibLaTeX)
+	Date          string `protobuf:"bytes,39,opt,name=date,proto3" json:"date,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -531,6 +533,13 @@ func (x *Entry) GetUrldate() string {
 	return ""
 }
 
+func (x *Entry) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
 // Person - A person (author or editor) in BibTeX format.
 type Person struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -680,7 +689,7 @@ var File_spoke_bibtex_v1_bibtex_proto protoreflect.FileDescriptor
 
 const file_spoke_bibtex_v1_bibtex_proto_rawDesc = "" +
 	"\n" +
-	"\x1cspoke/bibtex/v1/bibtex.proto\x12\x0fspoke.bibtex.v1\x1a\x14hub/v1/options.proto\"\xd0\x14\n" +
+	"\x1cspoke/bibtex/v1/bibtex.proto\x12\x0fspoke.bibtex.v1\x1a\x14hub/v1/options.proto\"\xbb\x15\n" +
 	"\x05Entry\x12x\n" +
 	"\n" +
 	"entry_type\x18\x01 \x01(\x0e2\x1a.spoke.bibtex.v1.EntryTypeB=\x8a\xb5\x189\n" +
@@ -766,7 +775,9 @@ const file_spoke_bibtex_v1_bibtex_proto_rawDesc = "" +
 	"\fprimaryclass\x18% \x01(\tB*\x8a\xb5\x18&\n" +
 	"\x05extra\xea\x03\x1carXiv primary classificationR\fprimaryclass\x12N\n" +
 	"\aurldate\x18& \x01(\tB4\x8a\xb5\x180\n" +
-	"\x05datesR\x05other\xa2\x01\aiso8601\xea\x03\x15Date URL was accessedR\aurldate:<\x8a\xb5\x188\n" +
+	"\x05datesR\x05other\xa2\x01\aiso8601\xea\x03\x15Date URL was accessedR\aurldate\x12i\n" +
+	"\x04date\x18' \x01(\tBU\x8a\xb5\x18Q\n" +
+	"\x05datesR\x06issued\xa2\x01\aiso8601\xea\x035BibLaTeX publication date, in place of year and monthR\x04date:<\x8a\xb5\x188\n" +
 	"\x06Record\x10\x01\x1a,BibTeX bibliography entry maps to Hub Record\"\xc3\x02\n" +
 	"\x06Person\x12,\n" +
 	"\x04name\x18\x01 \x01(\tB\x18\x8a\xb5\x18\x14\n" +
@@ -451,7 +451,9 @@ type JournalIssue struct {
 	// Issue number
 	Issue string `protobuf:"bytes,3,opt,name=issue,proto3" json:"issue,omitempty"`
 	// DOI for the issue
-	DoiData       *DoiData `protobuf:"bytes,4,opt,name=doi_data,json=doiData,proto3" json:"doi_data,omitempty"`
+	DoiData *DoiData `protobuf:"bytes,4,opt,name=doi_data,json=doiData,proto3" json:"doi_data,omitempty"`
+	// Volume, as the schema nests it
+	JournalVolume *JournalVolume `protobuf:"bytes,5,opt,name=journal_volume,json=journalVolume,proto3" json:"journal_volume,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -514,6 +516,68 @@ func (x *JournalIssue) GetDoiData() *DoiData {
 	return nil
 }
 
+func (x *JournalIssue) GetJournalVolume() *JournalVolume {
+	if x != nil {
+		return x.JournalVolume
+	}
+	return nil
+}
+
+// JournalVolume - The volume a journal issue belongs to.
+type JournalVolume struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Volume number
+	Volume string `protobuf:"bytes,1,opt,name=volume,proto3" json:"volume,omitempty"`
+	// DOI for the volume
+	DoiData       *DoiData `protobuf:"bytes,2,opt,name=doi_data,json=doiData,proto3" json:"doi_data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JournalVolume) Reset() {
+	*x = JournalVolume{}
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JournalVolume) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JournalVolume) ProtoMessage() {}
+
+func (x *JournalVolume) ProtoReflect() protoreflect.Message {
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JournalVolume.ProtoReflect.Descriptor instead.
+func (*JournalVolume) Descriptor() ([]byte, []int) {
+	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *JournalVolume) GetVolume() string {
+	if x != nil {
+		return x.Volume
+	}
+	return ""
+}
+
+func (x *JournalVolume) GetDoiData() *DoiData {
+	if x != nil {
+		return x.DoiData
+	}
+	return nil
+}
+
 // JournalArticle - An article in a journal.
 type JournalArticle struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -534,14 +598,16 @@ type JournalArticle struct {
 	// Funding information
 	Program []*FundingInfo `protobuf:"bytes,8,rep,name=program,proto3" json:"program,omitempty"`
 	// License
-	License       []*License `protobuf:"bytes,9,rep,name=license,proto3" json:"license,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	License []*License `protobuf:"bytes,9,rep,name=license,proto3" json:"license,omitempty"`
+	// AccessIndicators program: license references
+	AccessIndicators *AccessIndicators `protobuf:"bytes,10,opt,name=access_indicators,json=accessIndicators,proto3" json:"access_indicators,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *JournalArticle) Reset() {
 	*x = JournalArticle{}
-	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[7]
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -553,7 +619,7 @@ func (x *JournalArticle) String() string {
 func (*JournalArticle) ProtoMessage() {}
 
 func (x *JournalArticle) ProtoReflect() protoreflect.Message {
-	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[7]
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -566,7 +632,7 @@ func (x *JournalArticle) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use JournalArticle.ProtoReflect.Descriptor instead.
 func (*JournalArticle) Descriptor() ([]byte, []int) {
-	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{7}
+	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *JournalArticle) GetTitles() *Titles {
@@ -632,6 +698,13 @@ func (x *JournalArticle) GetLicense() []*License {
 	return nil
 }
 
+func (x *JournalArticle) GetAccessIndicators() *AccessIndicators {
+	if x != nil {
+		return x.AccessIndicators
+	}
+	return nil
+}
+
 // Book - A book or book chapter.
 type Book struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -649,7 +722,7 @@ type Book struct {
 
 func (x *Book) Reset() {
 	*x = Book{}
-	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[8]
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -661,7 +734,7 @@ func (x *Book) String() string {
 func (*Book) ProtoMessage() {}
 
 func (x *Book) ProtoReflect() protoreflect.Message {
-	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[8]
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -674,7 +747,7 @@ func (x *Book) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Book.ProtoReflect.Descriptor instead.
 func (*Book) Descriptor() ([]byte, []int) {
-	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{8}
+	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *Book) GetBookType() string {
@@ -724,13 +797,21 @@ type BookMetadata struct {
 	DoiData *DoiData `protobuf:"bytes,7,opt,name=doi_data,json=doiData,proto3" json:"doi_data,omitempty"`
 	// Edition number
 	EditionNumber string `protobuf:"bytes,8,opt,name=edition_number,json=editionNumber,proto3" json:"edition_number,omitempty"`
+	// Copyright and licensing assertions
+	Assertion []*Assertion `protobuf:"bytes,9,rep,name=assertion,proto3" json:"assertion,omitempty"`
+	// Citation list
+	CitationList *CitationList `protobuf:"bytes,10,opt,name=citation_list,json=citationList,proto3" json:"citation_list,omitempty"`
+	// AccessIndicators program: license references
+	AccessIndicators *AccessIndicators `protobuf:"bytes,11,opt,name=access_indicators,json=accessIndicators,proto3" json:"access_indicators,omitempty"`
+	// Funding information (FundRef program)
+	Program       []*FundingInfo `protobuf:"bytes,12,rep,name=program,proto3" json:"program,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *BookMetadata) Reset() {
 	*x = BookMetadata{}
-	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[9]
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -742,7 +823,7 @@ func (x *BookMetadata) String() string {
 func (*BookMetadata) ProtoMessage() {}
 
 func (x *BookMetadata) ProtoReflect() protoreflect.Message {
-	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[9]
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -755,7 +836,7 @@ func (x *BookMetadata) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BookMetadata.ProtoReflect.Descriptor instead.
 func (*BookMetadata) Descriptor() ([]byte, []int) {
-	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{9}
+	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *BookMetadata) GetTitles() *Titles {
@@ -814,6 +895,34 @@ func (x *BookMetadata) GetEditionNumber() string {
 	return ""
 }
 
+func (x *BookMetadata) GetAssertion() []*Assertion {
+	if x != nil {
+		return x.Assertion
+	}
+	return nil
+}
+
+func (x *BookMetadata) GetCitationList() *CitationList {
+	if x != nil {
+		return x.CitationList
+	}
+	return nil
+}
+
+func (x *BookMetadata) GetAccessIndicators() *AccessIndicators {
+	if x != nil {
+		return x.AccessIndicators
+	}
+	return nil
+}
+
+func (x *BookMetadata) GetProgram() []*FundingInfo {
+	if x != nil {
+		return x.Program
+	}
+	return nil
+}
+
 // BookSeriesMetadata - Metadata for a book series.
 type BookSeriesMetadata struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -829,7 +938,7 @@ type BookSeriesMetadata struct {
 
 func (x *BookSeriesMetadata) Reset() {
 	*x = BookSeriesMetadata{}
-	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[10]
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -841,7 +950,7 @@ func (x *BookSeriesMetadata) String() string {
 func (*BookSeriesMetadata) ProtoMessage() {}
 
 func (x *BookSeriesMetadata) ProtoReflect() protoreflect.Message {
-	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[10]
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -854,7 +963,7 @@ func (x *BookSeriesMetadata) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BookSeriesMetadata.ProtoReflect.Descriptor instead.
 func (*BookSeriesMetadata) Descriptor() ([]byte, []int) {
-	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{10}
+	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *BookSeriesMetadata) GetSeriesTitle() string {
@@ -892,14 +1001,20 @@ type ContentItem struct {
 	// Pages
 	Pages *Pages `protobuf:"bytes,5,opt,name=pages,proto3" json:"pages,omitempty"`
 	// DOI data
-	DoiData       *DoiData `protobuf:"bytes,6,opt,name=doi_data,json=doiData,proto3" json:"doi_data,omitempty"`
+	DoiData *DoiData `protobuf:"bytes,6,opt,name=doi_data,json=doiData,proto3" json:"doi_data,omitempty"`
+	// Citation list
+	CitationList *CitationList `protobuf:"bytes,7,opt,name=citation_list,json=citationList,proto3" json:"citation_list,omitempty"`
+	// AccessIndicators program: license references
+	AccessIndicators *AccessIndicators `protobuf:"bytes,8,opt,name=access_indicators,json=accessIndicators,proto3" json:"access_indicators,omitempty"`
+	// Funding information (FundRef program)
+	Program       []*FundingInfo `protobuf:"bytes,9,rep,name=program,proto3" json:"program,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ContentItem) Reset() {
 	*x = ContentItem{}
-	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[11]
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -911,7 +1026,7 @@ func (x *ContentItem) String() string {
 func (*ContentItem) ProtoMessage() {}
 
 func (x *ContentItem) ProtoReflect() protoreflect.Message {
-	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[11]
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -924,7 +1039,7 @@ func (x *ContentItem) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ContentItem.ProtoReflect.Descriptor instead.
 func (*ContentItem) Descriptor() ([]byte, []int) {
-	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{11}
+	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *ContentItem) GetComponentType() string {
@@ -969,6 +1084,27 @@ func (x *ContentItem) GetDoiData() *DoiData {
 	return nil
 }
 
+func (x *ContentItem) GetCitationList() *CitationList {
+	if x != nil {
+		return x.CitationList
+	}
+	return nil
+}
+
+func (x *ContentItem) GetAccessIndicators() *AccessIndicators {
+	if x != nil {
+		return x.AccessIndicators
+	}
+	return nil
+}
+
+func (x *ContentItem) GetProgram() []*FundingInfo {
+	if x != nil {
+		return x.Program
+	}
+	return nil
+}
+
 // Conference - A conference proceeding.
 type Conference struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -984,7 +1120,7 @@ type Conference struct {
 
 func (x *Conference) Reset() {
 	*x = Conference{}
-	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[12]
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -996,7 +1132,7 @@ func (x *Conference) String() string {
 func (*Conference) ProtoMessage() {}
 
 func (x *Conference) ProtoReflect() protoreflect.Message {
-	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[12]
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1009,7 +1145,7 @@ func (x *Conference) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Conference.ProtoReflect.Descriptor instead.
 func (*Conference) Descriptor() ([]byte, []int) {
-	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{12}
+	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *Conference) GetEventMetadata() *EventMetadata {
@@ -1052,7 +1188,7 @@ type EventMetadata struct {
 
 func (x *EventMetadata) Reset() {
 	*x = EventMetadata{}
-	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[13]
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1064,7 +1200,7 @@ func (x *EventMetadata) String() string {
 func (*EventMetadata) ProtoMessage() {}
 
 func (x *EventMetadata) ProtoReflect() protoreflect.Message {
-	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[13]
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1077,7 +1213,7 @@ func (x *EventMetadata) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EventMetadata.ProtoReflect.Descriptor instead.
 func (*EventMetadata) Descriptor() ([]byte, []int) {
-	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{13}
+	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *EventMetadata) GetConferenceName() string {
@@ -1134,7 +1270,7 @@ type ProceedingsMetadata struct {
 
 func (x *ProceedingsMetadata) Reset() {
 	*x = ProceedingsMetadata{}
-	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[14]
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1146,7 +1282,7 @@ func (x *ProceedingsMetadata) String() string {
 func (*ProceedingsMetadata) ProtoMessage() {}
 
 func (x *ProceedingsMetadata) ProtoReflect() protoreflect.Message {
-	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[14]
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1159,7 +1295,7 @@ func (x *ProceedingsMetadata) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProceedingsMetadata.ProtoReflect.Descriptor instead.
 func (*ProceedingsMetadata) Descriptor() ([]byte, []int) {
-	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{14}
+	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *ProceedingsMetadata) GetProceedingsTitle() string {
@@ -1211,14 +1347,20 @@ type ConferencePaper struct {
 	// Abstract
 	Abstract string `protobuf:"bytes,5,opt,name=abstract,proto3" json:"abstract,omitempty"`
 	// DOI data
-	DoiData       *DoiData `protobuf:"bytes,6,opt,name=doi_data,json=doiData,proto3" json:"doi_data,omitempty"`
+	DoiData *DoiData `protobuf:"bytes,6,opt,name=doi_data,json=doiData,proto3" json:"doi_data,omitempty"`
+	// Citation list
+	CitationList *CitationList `protobuf:"bytes,7,opt,name=citation_list,json=citationList,proto3" json:"citation_list,omitempty"`
+	// AccessIndicators program: license references
+	AccessIndicators *AccessIndicators `protobuf:"bytes,8,opt,name=access_indicators,json=accessIndicators,proto3" json:"access_indicators,omitempty"`
+	// Funding information (FundRef program)
+	Program       []*FundingInfo `protobuf:"bytes,9,rep,name=program,proto3" json:"program,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ConferencePaper) Reset() {
 	*x = ConferencePaper{}
-	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[15]
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1230,7 +1372,7 @@ func (x *ConferencePaper) String() string {
 func (*ConferencePaper) ProtoMessage() {}
 
 func (x *ConferencePaper) ProtoReflect() protoreflect.Message {
-	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[15]
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1243,7 +1385,7 @@ func (x *ConferencePaper) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConferencePaper.ProtoReflect.Descriptor instead.
 func (*ConferencePaper) Descriptor() ([]byte, []int) {
-	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{15}
+	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *ConferencePaper) GetTitles() *Titles {
@@ -1288,6 +1430,27 @@ func (x *ConferencePaper) GetDoiData() *DoiData {
 	return nil
 }
 
+func (x *ConferencePaper) GetCitationList() *CitationList {
+	if x != nil {
+		return x.CitationList
+	}
+	return nil
+}
+
+func (x *ConferencePaper) GetAccessIndicators() *AccessIndicators {
+	if x != nil {
+		return x.AccessIndicators
+	}
+	return nil
+}
+
+func (x *ConferencePaper) GetProgram() []*FundingInfo {
+	if x != nil {
+		return x.Program
+	}
+	return nil
+}
+
 // Dataset - A dataset registration.
 type Dataset struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -1304,14 +1467,22 @@ type Dataset struct {
 	// DOI data
 	DoiData *DoiData `protobuf:"bytes,6,opt,name=doi_data,json=doiData,proto3" json:"doi_data,omitempty"`
 	// Format/MIME type
-	Format        []string `protobuf:"bytes,7,rep,name=format,proto3" json:"format,omitempty"`
+	Format []string `protobuf:"bytes,7,rep,name=format,proto3" json:"format,omitempty"`
+	// Copyright and licensing assertions
+	Assertion []*Assertion `protobuf:"bytes,8,rep,name=assertion,proto3" json:"assertion,omitempty"`
+	// Citation list
+	CitationList *CitationList `protobuf:"bytes,9,opt,name=citation_list,json=citationList,proto3" json:"citation_list,omitempty"`
+	// AccessIndicators program: license references
+	AccessIndicators *AccessIndicators `protobuf:"bytes,10,opt,name=access_indicators,json=accessIndicators,proto3" json:"access_indicators,omitempty"`
+	// Funding information (FundRef program)
+	Program       []*FundingInfo `protobuf:"bytes,11,rep,name=program,proto3" json:"program,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Dataset) Reset() {
 	*x = Dataset{}
-	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[16]
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1323,7 +1494,7 @@ func (x *Dataset) String() string {
 func (*Dataset) ProtoMessage() {}
 
 func (x *Dataset) ProtoReflect() protoreflect.Message {
-	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[16]
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1336,7 +1507,7 @@ func (x *Dataset) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Dataset.ProtoReflect.Descriptor instead.
 func (*Dataset) Descriptor() ([]byte, []int) {
-	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{16}
+	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *Dataset) GetDatasetType() string {
@@ -1388,6 +1559,34 @@ func (x *Dataset) GetFormat() []string {
 	return nil
 }
 
+func (x *Dataset) GetAssertion() []*Assertion {
+	if x != nil {
+		return x.Assertion
+	}
+	return nil
+}
+
+func (x *Dataset) GetCitationList() *CitationList {
+	if x != nil {
+		return x.CitationList
+	}
+	return nil
+}
+
+func (x *Dataset) GetAccessIndicators() *AccessIndicators {
+	if x != nil {
+		return x.AccessIndicators
+	}
+	return nil
+}
+
+func (x *Dataset) GetProgram() []*FundingInfo {
+	if x != nil {
+		return x.Program
+	}
+	return nil
+}
+
 // Dissertation - A thesis or dissertation.
 type Dissertation struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -1404,14 +1603,22 @@ type Dissertation struct {
 	// Abstract
 	Abstract string `protobuf:"bytes,6,opt,name=abstract,proto3" json:"abstract,omitempty"`
 	// DOI data
-	DoiData       *DoiData `protobuf:"bytes,7,opt,name=doi_data,json=doiData,proto3" json:"doi_data,omitempty"`
+	DoiData *DoiData `protobuf:"bytes,7,opt,name=doi_data,json=doiData,proto3" json:"doi_data,omitempty"`
+	// Copyright and licensing assertions
+	Assertion []*Assertion `protobuf:"bytes,8,rep,name=assertion,proto3" json:"assertion,omitempty"`
+	// Citation list
+	CitationList *CitationList `protobuf:"bytes,9,opt,name=citation_list,json=citationList,proto3" json:"citation_list,omitempty"`
+	// AccessIndicators program: license references
+	AccessIndicators *AccessIndicators `protobuf:"bytes,10,opt,name=access_indicators,json=accessIndicators,proto3" json:"access_indicators,omitempty"`
+	// Funding information (FundRef program)
+	Program       []*FundingInfo `protobuf:"bytes,11,rep,name=program,proto3" json:"program,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Dissertation) Reset() {
 	*x = Dissertation{}
-	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[17]
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1423,7 +1630,7 @@ func (x *Dissertation) String() string {
 func (*Dissertation) ProtoMessage() {}
 
 func (x *Dissertation) ProtoReflect() protoreflect.Message {
-	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[17]
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1436,7 +1643,7 @@ func (x *Dissertation) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Dissertation.ProtoReflect.Descriptor instead.
 func (*Dissertation) Descriptor() ([]byte, []int) {
-	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{17}
+	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *Dissertation) GetTitles() *Titles {
@@ -1488,6 +1695,34 @@ func (x *Dissertation) GetDoiData() *DoiData {
 	return nil
 }
 
+func (x *Dissertation) GetAssertion() []*Assertion {
+	if x != nil {
+		return x.Assertion
+	}
+	return nil
+}
+
+func (x *Dissertation) GetCitationList() *CitationList {
+	if x != nil {
+		return x.CitationList
+	}
+	return nil
+}
+
+func (x *Dissertation) GetAccessIndicators() *AccessIndicators {
+	if x != nil {
+		return x.AccessIndicators
+	}
+	return nil
+}
+
+func (x *Dissertation) GetProgram() []*FundingInfo {
+	if x != nil {
+		return x.Program
+	}
+	return nil
+}
+
 // Institution - An educational institution.
 type Institution struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -1503,7 +1738,7 @@ type Institution struct {
 
 func (x *Institution) Reset() {
 	*x = Institution{}
-	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[18]
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1515,7 +1750,7 @@ func (x *Institution) String() string {
 func (*Institution) ProtoMessage() {}
 
 func (x *Institution) ProtoReflect() protoreflect.Message {
-	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[18]
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1528,7 +1763,7 @@ func (x *Institution) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Institution.ProtoReflect.Descriptor instead.
 func (*Institution) Descriptor() ([]byte, []int) {
-	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{18}
+	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *Institution) GetInstitutionName() string {
@@ -1568,14 +1803,22 @@ type PostedContent struct {
 	// DOI data
 	DoiData *DoiData `protobuf:"bytes,6,opt,name=doi_data,json=doiData,proto3" json:"doi_data,omitempty"`
 	// Group title (e.g., subject area)
-	GroupTitle    string `protobuf:"bytes,7,opt,name=group_title,json=groupTitle,proto3" json:"group_title,omitempty"`
+	GroupTitle string `protobuf:"bytes,7,opt,name=group_title,json=groupTitle,proto3" json:"group_title,omitempty"`
+	// Copyright and licensing assertions
+	Assertion []*Assertion `protobuf:"bytes,8,rep,name=assertion,proto3" json:"assertion,omitempty"`
+	// Citation list
+	CitationList *CitationList `protobuf:"bytes,9,opt,name=citation_list,json=citationList,proto3" json:"citation_list,omitempty"`
+	// AccessIndicators program: license references
+	AccessIndicators *AccessIndicators `protobuf:"bytes,10,opt,name=access_indicators,json=accessIndicators,proto3" json:"access_indicators,omitempty"`
+	// Funding information (FundRef program)
+	Program       []*FundingInfo `protobuf:"bytes,11,rep,name=program,proto3" json:"program,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *PostedContent) Reset() {
 	*x = PostedContent{}
-	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[19]
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1587,7 +1830,7 @@ func (x *PostedContent) String() string {
 func (*PostedContent) ProtoMessage() {}
 
 func (x *PostedContent) ProtoReflect() protoreflect.Message {
-	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[19]
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1600,7 +1843,7 @@ func (x *PostedContent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PostedContent.ProtoReflect.Descriptor instead.
 func (*PostedContent) Descriptor() ([]byte, []int) {
-	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{19}
+	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *PostedContent) GetType() string {
@@ -1652,6 +1895,34 @@ func (x *PostedContent) GetGroupTitle() string {
 	return ""
 }
 
+func (x *PostedContent) GetAssertion() []*Assertion {
+	if x != nil {
+		return x.Assertion
+	}
+	return nil
+}
+
+func (x *PostedContent) GetCitationList() *CitationList {
+	if x != nil {
+		return x.CitationList
+	}
+	return nil
+}
+
+func (x *PostedContent) GetAccessIndicators() *AccessIndicators {
+	if x != nil {
+		return x.AccessIndicators
+	}
+	return nil
+}
+
+func (x *PostedContent) GetProgram() []*FundingInfo {
+	if x != nil {
+		return x.Program
+	}
+	return nil
+}
+
 // PeerReview - A peer review.
 type PeerReview struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -1666,14 +1937,20 @@ type PeerReview struct {
 	// Review date
 	ReviewDate *PublicationDate `protobuf:"bytes,5,opt,name=review_date,json=reviewDate,proto3" json:"review_date,omitempty"`
 	// DOI data
-	DoiData       *DoiData `protobuf:"bytes,6,opt,name=doi_data,json=doiData,proto3" json:"doi_data,omitempty"`
+	DoiData *DoiData `protobuf:"bytes,6,opt,name=doi_data,json=doiData,proto3" json:"doi_data,omitempty"`
+	// Citation list
+	CitationList *CitationList `protobuf:"bytes,7,opt,name=citation_list,json=citationList,proto3" json:"citation_list,omitempty"`
+	// AccessIndicators program: license references
+	AccessIndicators *AccessIndicators `protobuf:"bytes,8,opt,name=access_indicators,json=accessIndicators,proto3" json:"access_indicators,omitempty"`
+	// Funding information (FundRef program)
+	Program       []*FundingInfo `protobuf:"bytes,9,rep,name=program,proto3" json:"program,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *PeerReview) Reset() {
 	*x = PeerReview{}
-	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[20]
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1685,7 +1962,7 @@ func (x *PeerReview) String() string {
 func (*PeerReview) ProtoMessage() {}
 
 func (x *PeerReview) ProtoReflect() protoreflect.Message {
-	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[20]
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1698,7 +1975,7 @@ func (x *PeerReview) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PeerReview.ProtoReflect.Descriptor instead.
 func (*PeerReview) Descriptor() ([]byte, []int) {
-	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{20}
+	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *PeerReview) GetType() string {
@@ -1743,6 +2020,27 @@ func (x *PeerReview) GetDoiData() *DoiData {
 	return nil
 }
 
+func (x *PeerReview) GetCitationList() *CitationList {
+	if x != nil {
+		return x.CitationList
+	}
+	return nil
+}
+
+func (x *PeerReview) GetAccessIndicators() *AccessIndicators {
+	if x != nil {
+		return x.AccessIndicators
+	}
+	return nil
+}
+
+func (x *PeerReview) GetProgram() []*FundingInfo {
+	if x != nil {
+		return x.Program
+	}
+	return nil
+}
+
 // Titles - Title information.
 type Titles struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -1758,7 +2056,7 @@ type Titles struct {
 
 func (x *Titles) Reset() {
 	*x = Titles{}
-	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[21]
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1770,7 +2068,7 @@ func (x *Titles) String() string {
 func (*Titles) ProtoMessage() {}
 
 func (x *Titles) ProtoReflect() protoreflect.Message {
-	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[21]
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1783,7 +2081,7 @@ func (x *Titles) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Titles.ProtoReflect.Descriptor instead.
 func (*Titles) Descriptor() ([]byte, []int) {
-	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{21}
+	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *Titles) GetTitle() string {
@@ -1818,7 +2116,7 @@ type Contributors struct {
 
 func (x *Contributors) Reset() {
 	*x = Contributors{}
-	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[22]
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1830,7 +2128,7 @@ func (x *Contributors) String() string {
 func (*Contributors) ProtoMessage() {}
 
 func (x *Contributors) ProtoReflect() protoreflect.Message {
-	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[22]
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1843,7 +2141,7 @@ func (x *Contributors) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Contributors.ProtoReflect.Descriptor instead.
 func (*Contributors) Descriptor() ([]byte, []int) {
-	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{22}
+	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *Contributors) GetPersonName() []*PersonName {
@@ -1883,7 +2181,7 @@ type PersonName struct {
 
 func (x *PersonName) Reset() {
 	*x = PersonName{}
-	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[23]
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1895,7 +2193,7 @@ func (x *PersonName) String() string {
 func (*PersonName) ProtoMessage() {}
 
 func (x *PersonName) ProtoReflect() protoreflect.Message {
-	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[23]
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1908,7 +2206,7 @@ func (x *PersonName) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PersonName.ProtoReflect.Descriptor instead.
 func (*PersonName) Descriptor() ([]byte, []int) {
-	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{23}
+	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *PersonName) GetContributorRole() string {
@@ -1975,7 +2273,7 @@ type Organization struct {
 
 func (x *Organization) Reset() {
 	*x = Organization{}
-	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[24]
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1987,7 +2285,7 @@ func (x *Organization) String() string {
 func (*Organization) ProtoMessage() {}
 
 func (x *Organization) ProtoReflect() protoreflect.Message {
-	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[24]
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2000,7 +2298,7 @@ func (x *Organization) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Organization.ProtoReflect.Descriptor instead.
 func (*Organization) Descriptor() ([]byte, []int) {
-	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{24}
+	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *Organization) GetContributorRole() string {
@@ -2034,7 +2332,7 @@ type Affiliation struct {
 
 func (x *Affiliation) Reset() {
 	*x = Affiliation{}
-	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[25]
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2046,7 +2344,7 @@ func (x *Affiliation) String() string {
 func (*Affiliation) ProtoMessage() {}
 
 func (x *Affiliation) ProtoReflect() protoreflect.Message {
-	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[25]
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2059,7 +2357,7 @@ func (x *Affiliation) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Affiliation.ProtoReflect.Descriptor instead.
 func (*Affiliation) Descriptor() ([]byte, []int) {
-	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{25}
+	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *Affiliation) GetName() string {
@@ -2080,7 +2378,7 @@ type Publisher struct {
 
 func (x *Publisher) Reset() {
 	*x = Publisher{}
-	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[26]
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2092,7 +2390,7 @@ func (x *Publisher) String() string {
 func (*Publisher) ProtoMessage() {}
 
 func (x *Publisher) ProtoReflect() protoreflect.Message {
-	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[26]
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2105,7 +2403,7 @@ func (x *Publisher) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Publisher.ProtoReflect.Descriptor instead.
 func (*Publisher) Descriptor() ([]byte, []int) {
-	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{26}
+	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *Publisher) GetPublisherName() string {
@@ -2139,7 +2437,7 @@ type PublicationDate struct {
 
 func (x *PublicationDate) Reset() {
 	*x = PublicationDate{}
-	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[27]
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2151,7 +2449,7 @@ func (x *PublicationDate) String() string {
 func (*PublicationDate) ProtoMessage() {}
 
 func (x *PublicationDate) ProtoReflect() protoreflect.Message {
-	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[27]
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2164,7 +2462,7 @@ func (x *PublicationDate) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PublicationDate.ProtoReflect.Descriptor instead.
 func (*PublicationDate) Descriptor() ([]byte, []int) {
-	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{27}
+	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *PublicationDate) GetMediaType() string {
@@ -2207,7 +2505,7 @@ type Pages struct {
 
 func (x *Pages) Reset() {
 	*x = Pages{}
-	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[28]
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2219,7 +2517,7 @@ func (x *Pages) String() string {
 func (*Pages) ProtoMessage() {}
 
 func (x *Pages) ProtoReflect() protoreflect.Message {
-	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[28]
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2232,7 +2530,7 @@ func (x *Pages) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Pages.ProtoReflect.Descriptor instead.
 func (*Pages) Descriptor() ([]byte, []int) {
-	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{28}
+	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *Pages) GetFirstPage() string {
@@ -2271,7 +2569,7 @@ type DoiData struct {
 
 func (x *DoiData) Reset() {
 	*x = DoiData{}
-	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[29]
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2283,7 +2581,7 @@ func (x *DoiData) String() string {
 func (*DoiData) ProtoMessage() {}
 
 func (x *DoiData) ProtoReflect() protoreflect.Message {
-	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[29]
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2296,7 +2594,7 @@ func (x *DoiData) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DoiData.ProtoReflect.Descriptor instead.
 func (*DoiData) Descriptor() ([]byte, []int) {
-	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{29}
+	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *DoiData) GetDoi() string {
@@ -2335,7 +2633,7 @@ type Item struct {
 
 func (x *Item) Reset() {
 	*x = Item{}
-	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[30]
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2347,7 +2645,7 @@ func (x *Item) String() string {
 func (*Item) ProtoMessage() {}
 
 func (x *Item) ProtoReflect() protoreflect.Message {
-	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[30]
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2360,7 +2658,7 @@ func (x *Item) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Item.ProtoReflect.Descriptor instead.
 func (*Item) Descriptor() ([]byte, []int) {
-	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{30}
+	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *Item) GetResource() string {
@@ -2394,7 +2692,7 @@ type CitationList struct {
 
 func (x *CitationList) Reset() {
 	*x = CitationList{}
-	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[31]
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2406,7 +2704,7 @@ func (x *CitationList) String() string {
 func (*CitationList) ProtoMessage() {}
 
 func (x *CitationList) ProtoReflect() protoreflect.Message {
-	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[31]
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2419,7 +2717,7 @@ func (x *CitationList) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CitationList.ProtoReflect.Descriptor instead.
 func (*CitationList) Descriptor() ([]byte, []int) {
-	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{31}
+	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *CitationList) GetCitation() []*Citation {
@@ -2458,7 +2756,7 @@ type Citation struct {
 
 func (x *Citation) Reset() {
 	*x = Citation{}
-	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[32]
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2470,7 +2768,7 @@ func (x *Citation) String() string {
 func (*Citation) ProtoMessage() {}
 
 func (x *Citation) ProtoReflect() protoreflect.Message {
-	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[32]
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2483,7 +2781,7 @@ func (x *Citation) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Citation.ProtoReflect.Descriptor instead.
 func (*Citation) Descriptor() ([]byte, []int) {
-	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{32}
+	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *Citation) GetKey() string {
@@ -2571,7 +2869,7 @@ type FundingInfo struct {
 
 func (x *FundingInfo) Reset() {
 	*x = FundingInfo{}
-	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[33]
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2583,7 +2881,7 @@ func (x *FundingInfo) String() string {
 func (*FundingInfo) ProtoMessage() {}
 
 func (x *FundingInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[33]
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2596,7 +2894,7 @@ func (x *FundingInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FundingInfo.ProtoReflect.Descriptor instead.
 func (*FundingInfo) Descriptor() ([]byte, []int) {
-	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{33}
+	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *FundingInfo) GetName() string {
@@ -2635,7 +2933,7 @@ type License struct {
 
 func (x *License) Reset() {
 	*x = License{}
-	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[34]
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2647,7 +2945,7 @@ func (x *License) String() string {
 func (*License) ProtoMessage() {}
 
 func (x *License) ProtoReflect() protoreflect.Message {
-	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[34]
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2660,7 +2958,7 @@ func (x *License) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use License.ProtoReflect.Descriptor instead.
 func (*License) Descriptor() ([]byte, []int) {
-	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{34}
+	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *License) GetLicenseRef() string {
@@ -2684,6 +2982,145 @@ func (x *License) GetAppliesTo() string {
 	return ""
 }
 
+// AccessIndicators - The AccessIndicators program, in the
+// http://www.crossref.org/AccessIndicators.xsd namespace, giving the
+// licenses that apply to each version of the content.
+type AccessIndicators struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Program name: AccessIndicators
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// License references
+	LicenseRef    []*License `protobuf:"bytes,2,rep,name=license_ref,json=licenseRef,proto3" json:"license_ref,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccessIndicators) Reset() {
+	*x = AccessIndicators{}
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccessIndicators) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccessIndicators) ProtoMessage() {}
+
+func (x *AccessIndicators) ProtoReflect() protoreflect.Message {
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccessIndicators.ProtoReflect.Descriptor instead.
+func (*AccessIndicators) Descriptor() ([]byte, []int) {
+	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *AccessIndicators) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AccessIndicators) GetLicenseRef() []*License {
+	if x != nil {
+		return x.LicenseRef
+	}
+	return nil
+}
+
+// Assertion - A Crossmark custom metadata assertion.
+type Assertion struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Assertion name: copyright_statement, copyright_holder, copyright_year, license
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Human-readable label
+	Label string `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	// Group name, e.g. copyright_and_licensing
+	GroupName string `protobuf:"bytes,3,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
+	// Group label
+	GroupLabel string `protobuf:"bytes,4,opt,name=group_label,json=groupLabel,proto3" json:"group_label,omitempty"`
+	// Assertion value
+	Value         string `protobuf:"bytes,5,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Assertion) Reset() {
+	*x = Assertion{}
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Assertion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Assertion) ProtoMessage() {}
+
+func (x *Assertion) ProtoReflect() protoreflect.Message {
+	mi := &file_spoke_crossref_v5_3_1_crossref_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Assertion.ProtoReflect.Descriptor instead.
+func (*Assertion) Descriptor() ([]byte, []int) {
+	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *Assertion) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Assertion) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *Assertion) GetGroupName() string {
+	if x != nil {
+		return x.GroupName
+	}
+	return ""
+}
+
+func (x *Assertion) GetGroupLabel() string {
+	if x != nil {
+		return x.GroupLabel
+	}
+	return ""
+}
+
+func (x *Assertion) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
 var File_spoke_crossref_v5_3_1_crossref_proto protoreflect.FileDescriptor
 
 const file_spoke_crossref_v5_3_1_crossref_proto_rawDesc = "" +
@@ -2735,7 +3172,7 @@ const file_spoke_crossref_v5_3_1_crossref_proto_rawDesc = "" +
 	"\x0fissn_electronic\x18\x04 \x01(\tB8\x8a\xb5\x184\n" +
 	"\videntifiersZ\x05eissn\xf2\x01\x04issn\xea\x03\x0fElectronic ISSN\xb2\x04\x04issnR\x0eissnElectronic\x12e\n" +
 	"\bdoi_data\x18\x05 \x01(\v2\x1e.spoke.crossref.v5_3_1.DoiDataB*\x8a\xb5\x18&\n" +
-	"\videntifiers\xea\x03\vJournal DOI\xb2\x04\bdoi_dataR\adoiData:\x16\x8a\xb5\x18\x12R\x10journal_metadata\"\xde\x02\n" +
+	"\videntifiers\xea\x03\vJournal DOI\xb2\x04\bdoi_dataR\adoiData:\x16\x8a\xb5\x18\x12R\x10journal_metadata\"\xda\x03\n" +
 	"\fJournalIssue\x12y\n" +
 	"\x10publication_date\x18\x01 \x01(\v2&.spoke.crossref.v5_3_1.PublicationDateB&\x8a\xb5\x18\"\n" +
 	"\x05datesR\x06issued\xb2\x04\x10publication_dateR\x0fpublicationDate\x123\n" +
@@ -2744,7 +3181,14 @@ const file_spoke_crossref_v5_3_1_crossref_proto_rawDesc = "" +
 	"\x05issue\x18\x03 \x01(\tB\x1a\x8a\xb5\x18\x16\n" +
 	"\x05extra\xea\x03\fIssue numberR\x05issue\x12W\n" +
 	"\bdoi_data\x18\x04 \x01(\v2\x1e.spoke.crossref.v5_3_1.DoiDataB\x1c\x8a\xb5\x18\x18\n" +
-	"\videntifiers\xb2\x04\bdoi_dataR\adoiData:\x13\x8a\xb5\x18\x0fR\rjournal_issue\"\x9d\a\n" +
+	"\videntifiers\xb2\x04\bdoi_dataR\adoiData\x12z\n" +
+	"\x0ejournal_volume\x18\x05 \x01(\v2$.spoke.crossref.v5_3_1.JournalVolumeB-\x8a\xb5\x18)\n" +
+	"\x05extra\xea\x03\x0eJournal volume\xb2\x04\x0ejournal_volumeR\rjournalVolume:\x13\x8a\xb5\x18\x0fR\rjournal_issue\"\xb3\x01\n" +
+	"\rJournalVolume\x123\n" +
+	"\x06volume\x18\x01 \x01(\tB\x1b\x8a\xb5\x18\x17\n" +
+	"\x05extra\xea\x03\rVolume numberR\x06volume\x12W\n" +
+	"\bdoi_data\x18\x02 \x01(\v2\x1e.spoke.crossref.v5_3_1.DoiDataB\x1c\x8a\xb5\x18\x18\n" +
+	"\videntifiers\xb2\x04\bdoi_dataR\adoiData:\x14\x8a\xb5\x18\x10R\x0ejournal_volume\"\xc1\b\n" +
 	"\x0eJournalArticle\x12E\n" +
 	"\x06titles\x18\x01 \x01(\v2\x1d.spoke.crossref.v5_3_1.TitlesB\x0e\x8a\xb5\x18\n" +
 	"\n" +
@@ -2766,8 +3210,12 @@ const file_spoke_crossref_v5_3_1_crossref_proto_rawDesc = "" +
 	"\aprogram\x18\b \x03(\v2\".spoke.crossref.v5_3_1.FundingInfoB\x17\x8a\xb5\x18\x13\n" +
 	"\afunders\xb2\x04\aprogramR\aprogram\x12T\n" +
 	"\alicense\x18\t \x03(\v2\x1e.spoke.crossref.v5_3_1.LicenseB\x1a\x8a\xb5\x18\x16\n" +
-	"\x06rights\xb2\x04\vlicense_refR\alicense:]\x8a\xb5\x18Y\n" +
-	"\x06Record\x10\x01\x1a<CrossRef JournalArticle maps to Hub Record with ARTICLE typeR\x0fjournal_article\"\xb7\x04\n" +
+	"\x06rights\xb2\x04\vlicense_refR\alicense\x12o\n" +
+	"\x11access_indicators\x18\n" +
+	" \x01(\v2'.spoke.crossref.v5_3_1.AccessIndicatorsB\x19\x8a\xb5\x18\x15\n" +
+	"\x06rights\xb2\x04\n" +
+	"ai:programR\x10accessIndicators:\x8f\x01\x8a\xb5\x18\x8a\x01\n" +
+	"\x06Record\x10\x01\x1a<CrossRef JournalArticle maps to Hub Record with ARTICLE typeR\x0fjournal_articleZ/ai=http://www.crossref.org/AccessIndicators.xsd\"\xb7\x04\n" +
 	"\x04Book\x12K\n" +
 	"\tbook_type\x18\x01 \x01(\tB.\x8a\xb5\x18*\n" +
 	"\rresource_type\xea\x03\tBook type\xb2\x04\tbook_type\xc0\x04\x01R\bbookType\x12t\n" +
@@ -2777,7 +3225,8 @@ const file_spoke_crossref_v5_3_1_crossref_proto_rawDesc = "" +
 	"\trelationsz\tin_series\xea\x03\x12Series information\xb2\x04\x14book_series_metadataR\x12bookSeriesMetadata\x12\x7f\n" +
 	"\fcontent_item\x18\x04 \x03(\v2\".spoke.crossref.v5_3_1.ContentItemB8\x8a\xb5\x184\n" +
 	"\trelationsz\bhas_part\xea\x03\rBook chapters\xb2\x04\fcontent_itemR\vcontentItem:E\x8a\xb5\x18A\n" +
-	"\x06Record\x10\x01\x1a/CrossRef Book maps to Hub Record with BOOK typeR\x04book\"\xb2\x06\n" +
+	"\x06Record\x10\x01\x1a/CrossRef Book maps to Hub Record with BOOK typeR\x04book\"\xb3\n" +
+	"\n" +
 	"\fBookMetadata\x12E\n" +
 	"\x06titles\x18\x01 \x01(\v2\x1d.spoke.crossref.v5_3_1.TitlesB\x0e\x8a\xb5\x18\n" +
 	"\n" +
@@ -2797,15 +3246,26 @@ const file_spoke_crossref_v5_3_1_crossref_proto_rawDesc = "" +
 	"\bdoi_data\x18\a \x01(\v2\x1e.spoke.crossref.v5_3_1.DoiDataB!\x8a\xb5\x18\x1d\n" +
 	"\videntifiersZ\x03doi\xb2\x04\bdoi_dataR\adoiData\x12T\n" +
 	"\x0eedition_number\x18\b \x01(\tB-\x8a\xb5\x18)\n" +
-	"\x05extra\xea\x03\x0eEdition number\xb2\x04\x0eedition_numberR\reditionNumber:G\x8a\xb5\x18C\n" +
-	"\x06Record\x10\x01\x1a(CrossRef BookMetadata maps to Hub RecordR\rbook_metadata\"\xe7\x01\n" +
+	"\x05extra\xea\x03\x0eEdition number\xb2\x04\x0eedition_numberR\reditionNumber\x12\x86\x01\n" +
+	"\tassertion\x18\t \x03(\v2 .spoke.crossref.v5_3_1.AssertionBF\x8a\xb5\x18B\n" +
+	"\x05extra\xea\x03,Crossmark copyright and licensing assertions\xb2\x04\tassertionR\tassertion\x12}\n" +
+	"\rcitation_list\x18\n" +
+	" \x01(\v2#.spoke.crossref.v5_3_1.CitationListB3\x8a\xb5\x18/\n" +
+	"\trelationsz\x05cites\xea\x03\n" +
+	"References\xb2\x04\rcitation_listR\fcitationList\x12o\n" +
+	"\x11access_indicators\x18\v \x01(\v2'.spoke.crossref.v5_3_1.AccessIndicatorsB\x19\x8a\xb5\x18\x15\n" +
+	"\x06rights\xb2\x04\n" +
+	"ai:programR\x10accessIndicators\x12U\n" +
+	"\aprogram\x18\f \x03(\v2\".spoke.crossref.v5_3_1.FundingInfoB\x17\x8a\xb5\x18\x13\n" +
+	"\afunders\xb2\x04\aprogramR\aprogram:x\x8a\xb5\x18t\n" +
+	"\x06Record\x10\x01\x1a(CrossRef BookMetadata maps to Hub RecordR\rbook_metadataZ/ai=http://www.crossref.org/AccessIndicators.xsd\"\xe7\x01\n" +
 	"\x12BookSeriesMetadata\x12L\n" +
 	"\fseries_title\x18\x01 \x01(\tB)\x8a\xb5\x18%\n" +
 	"\x05title\xea\x03\fSeries title\xb2\x04\fseries_titleR\vseriesTitle\x122\n" +
 	"\x04issn\x18\x02 \x01(\tB\x1e\x8a\xb5\x18\x1a\n" +
 	"\videntifiersZ\x04issn\xf2\x01\x04issnR\x04issn\x123\n" +
 	"\x06volume\x18\x03 \x01(\tB\x1b\x8a\xb5\x18\x17\n" +
-	"\x05extra\xea\x03\rVolume numberR\x06volume:\x1a\x8a\xb5\x18\x16R\x14book_series_metadata\"\x97\x05\n" +
+	"\x05extra\xea\x03\rVolume numberR\x06volume:\x1a\x8a\xb5\x18\x16R\x14book_series_metadata\"\x91\b\n" +
 	"\vContentItem\x12_\n" +
 	"\x0ecomponent_type\x18\x01 \x01(\tB8\x8a\xb5\x184\n" +
 	"\rresource_type\xea\x03\x0eComponent type\xb2\x04\x0ecomponent_type\xc0\x04\x01R\rcomponentType\x12E\n" +
@@ -2820,8 +3280,16 @@ const file_spoke_crossref_v5_3_1_crossref_proto_rawDesc = "" +
 	"\x05extra\xea\x03\n" +
 	"Page rangeR\x05pages\x12\\\n" +
 	"\bdoi_data\x18\x06 \x01(\v2\x1e.spoke.crossref.v5_3_1.DoiDataB!\x8a\xb5\x18\x1d\n" +
-	"\videntifiersZ\x03doi\xb2\x04\bdoi_dataR\adoiData:\\\x8a\xb5\x18X\n" +
-	"\x06Record\x10\x01\x1a>CrossRef ContentItem maps to Hub Record with BOOK_CHAPTER typeR\fcontent_item\"\xab\x04\n" +
+	"\videntifiersZ\x03doi\xb2\x04\bdoi_dataR\adoiData\x12}\n" +
+	"\rcitation_list\x18\a \x01(\v2#.spoke.crossref.v5_3_1.CitationListB3\x8a\xb5\x18/\n" +
+	"\trelationsz\x05cites\xea\x03\n" +
+	"References\xb2\x04\rcitation_listR\fcitationList\x12o\n" +
+	"\x11access_indicators\x18\b \x01(\v2'.spoke.crossref.v5_3_1.AccessIndicatorsB\x19\x8a\xb5\x18\x15\n" +
+	"\x06rights\xb2\x04\n" +
+	"ai:programR\x10accessIndicators\x12U\n" +
+	"\aprogram\x18\t \x03(\v2\".spoke.crossref.v5_3_1.FundingInfoB\x17\x8a\xb5\x18\x13\n" +
+	"\afunders\xb2\x04\aprogramR\aprogram:\x8e\x01\x8a\xb5\x18\x89\x01\n" +
+	"\x06Record\x10\x01\x1a>CrossRef ContentItem maps to Hub Record with BOOK_CHAPTER typeR\fcontent_itemZ/ai=http://www.crossref.org/AccessIndicators.xsd\"\xab\x04\n" +
 	"\n" +
 	"Conference\x12\x88\x01\n" +
 	"\x0eevent_metadata\x18\x01 \x01(\v2$.spoke.crossref.v5_3_1.EventMetadataB;\x8a\xb5\x187\n" +
@@ -2853,7 +3321,7 @@ const file_spoke_crossref_v5_3_1_crossref_proto_rawDesc = "" +
 	"\x04isbn\x18\x04 \x01(\tB\x1e\x8a\xb5\x18\x1a\n" +
 	"\videntifiersZ\x04isbn\xf2\x01\x04isbnR\x04isbn\x12\\\n" +
 	"\bdoi_data\x18\x05 \x01(\v2\x1e.spoke.crossref.v5_3_1.DoiDataB!\x8a\xb5\x18\x1d\n" +
-	"\videntifiersZ\x03doi\xb2\x04\bdoi_dataR\adoiData:\x1a\x8a\xb5\x18\x16R\x14proceedings_metadata\"\xf2\x04\n" +
+	"\videntifiersZ\x03doi\xb2\x04\bdoi_dataR\adoiData:\x1a\x8a\xb5\x18\x16R\x14proceedings_metadata\"\xec\a\n" +
 	"\x0fConferencePaper\x12E\n" +
 	"\x06titles\x18\x01 \x01(\v2\x1d.spoke.crossref.v5_3_1.TitlesB\x0e\x8a\xb5\x18\n" +
 	"\n" +
@@ -2869,8 +3337,16 @@ const file_spoke_crossref_v5_3_1_crossref_proto_rawDesc = "" +
 	"\n" +
 	"\babstractR\babstract\x12\\\n" +
 	"\bdoi_data\x18\x06 \x01(\v2\x1e.spoke.crossref.v5_3_1.DoiDataB!\x8a\xb5\x18\x1d\n" +
-	"\videntifiersZ\x03doi\xb2\x04\bdoi_dataR\adoiData:h\x8a\xb5\x18d\n" +
-	"\x06Record\x10\x01\x1aFCrossRef ConferencePaper maps to Hub Record with CONFERENCE_PAPER typeR\x10conference_paper\"\x95\x05\n" +
+	"\videntifiersZ\x03doi\xb2\x04\bdoi_dataR\adoiData\x12}\n" +
+	"\rcitation_list\x18\a \x01(\v2#.spoke.crossref.v5_3_1.CitationListB3\x8a\xb5\x18/\n" +
+	"\trelationsz\x05cites\xea\x03\n" +
+	"References\xb2\x04\rcitation_listR\fcitationList\x12o\n" +
+	"\x11access_indicators\x18\b \x01(\v2'.spoke.crossref.v5_3_1.AccessIndicatorsB\x19\x8a\xb5\x18\x15\n" +
+	"\x06rights\xb2\x04\n" +
+	"ai:programR\x10accessIndicators\x12U\n" +
+	"\aprogram\x18\t \x03(\v2\".spoke.crossref.v5_3_1.FundingInfoB\x17\x8a\xb5\x18\x13\n" +
+	"\afunders\xb2\x04\aprogramR\aprogram:\x9a\x01\x8a\xb5\x18\x95\x01\n" +
+	"\x06Record\x10\x01\x1aFCrossRef ConferencePaper maps to Hub Record with CONFERENCE_PAPER typeR\x10conference_paperZ/ai=http://www.crossref.org/AccessIndicators.xsd\"\x96\t\n" +
 	"\aDataset\x12W\n" +
 	"\fdataset_type\x18\x01 \x01(\tB4\x8a\xb5\x180\n" +
 	"\rresource_type\xea\x03\fDataset type\xb2\x04\fdataset_type\xc0\x04\x01R\vdatasetType\x12E\n" +
@@ -2887,8 +3363,19 @@ const file_spoke_crossref_v5_3_1_crossref_proto_rawDesc = "" +
 	"\bdoi_data\x18\x06 \x01(\v2\x1e.spoke.crossref.v5_3_1.DoiDataB!\x8a\xb5\x18\x1d\n" +
 	"\videntifiersZ\x03doi\xb2\x04\bdoi_dataR\adoiData\x122\n" +
 	"\x06format\x18\a \x03(\tB\x1a\x8a\xb5\x18\x16\n" +
-	"\x05extra\xea\x03\fFile formatsR\x06format:N\x8a\xb5\x18J\n" +
-	"\x06Record\x10\x01\x1a5CrossRef Dataset maps to Hub Record with DATASET typeR\adataset\"\xcd\x05\n" +
+	"\x05extra\xea\x03\fFile formatsR\x06format\x12\x86\x01\n" +
+	"\tassertion\x18\b \x03(\v2 .spoke.crossref.v5_3_1.AssertionBF\x8a\xb5\x18B\n" +
+	"\x05extra\xea\x03,Crossmark copyright and licensing assertions\xb2\x04\tassertionR\tassertion\x12}\n" +
+	"\rcitation_list\x18\t \x01(\v2#.spoke.crossref.v5_3_1.CitationListB3\x8a\xb5\x18/\n" +
+	"\trelationsz\x05cites\xea\x03\n" +
+	"References\xb2\x04\rcitation_listR\fcitationList\x12o\n" +
+	"\x11access_indicators\x18\n" +
+	" \x01(\v2'.spoke.crossref.v5_3_1.AccessIndicatorsB\x19\x8a\xb5\x18\x15\n" +
+	"\x06rights\xb2\x04\n" +
+	"ai:programR\x10accessIndicators\x12U\n" +
+	"\aprogram\x18\v \x03(\v2\".spoke.crossref.v5_3_1.FundingInfoB\x17\x8a\xb5\x18\x13\n" +
+	"\afunders\xb2\x04\aprogramR\aprogram:\x7f\x8a\xb5\x18{\n" +
+	"\x06Record\x10\x01\x1a5CrossRef Dataset maps to Hub Record with DATASET typeR\adatasetZ/ai=http://www.crossref.org/AccessIndicators.xsd\"\xd0\t\n" +
 	"\fDissertation\x12E\n" +
 	"\x06titles\x18\x01 \x01(\v2\x1d.spoke.crossref.v5_3_1.TitlesB\x0e\x8a\xb5\x18\n" +
 	"\n" +
@@ -2906,8 +3393,19 @@ const file_spoke_crossref_v5_3_1_crossref_proto_rawDesc = "" +
 	"\n" +
 	"\babstractR\babstract\x12\\\n" +
 	"\bdoi_data\x18\a \x01(\v2\x1e.spoke.crossref.v5_3_1.DoiDataB!\x8a\xb5\x18\x1d\n" +
-	"\videntifiersZ\x03doi\xb2\x04\bdoi_dataR\adoiData:]\x8a\xb5\x18Y\n" +
-	"\x06Record\x10\x01\x1a?CrossRef Dissertation maps to Hub Record with DISSERTATION typeR\fdissertation\"\xcc\x02\n" +
+	"\videntifiersZ\x03doi\xb2\x04\bdoi_dataR\adoiData\x12\x86\x01\n" +
+	"\tassertion\x18\b \x03(\v2 .spoke.crossref.v5_3_1.AssertionBF\x8a\xb5\x18B\n" +
+	"\x05extra\xea\x03,Crossmark copyright and licensing assertions\xb2\x04\tassertionR\tassertion\x12}\n" +
+	"\rcitation_list\x18\t \x01(\v2#.spoke.crossref.v5_3_1.CitationListB3\x8a\xb5\x18/\n" +
+	"\trelationsz\x05cites\xea\x03\n" +
+	"References\xb2\x04\rcitation_listR\fcitationList\x12o\n" +
+	"\x11access_indicators\x18\n" +
+	" \x01(\v2'.spoke.crossref.v5_3_1.AccessIndicatorsB\x19\x8a\xb5\x18\x15\n" +
+	"\x06rights\xb2\x04\n" +
+	"ai:programR\x10accessIndicators\x12U\n" +
+	"\aprogram\x18\v \x03(\v2\".spoke.crossref.v5_3_1.FundingInfoB\x17\x8a\xb5\x18\x13\n" +
+	"\afunders\xb2\x04\aprogramR\aprogram:\x8f\x01\x8a\xb5\x18\x8a\x01\n" +
+	"\x06Record\x10\x01\x1a?CrossRef Dissertation maps to Hub Record with DISSERTATION typeR\fdissertationZ/ai=http://www.crossref.org/AccessIndicators.xsd\"\xcc\x02\n" +
 	"\vInstitution\x12[\n" +
 	"\x10institution_name\x18\x01 \x01(\tB0\x8a\xb5\x18,\n" +
 	"\x04name\xea\x03\x10Institution name\xb2\x04\x10institution_nameR\x0finstitutionName\x12h\n" +
@@ -2915,7 +3413,7 @@ const file_spoke_crossref_v5_3_1_crossref_proto_rawDesc = "" +
 	"\x05extra\xea\x03\n" +
 	"Department\xb2\x04\x16institution_departmentR\x15institutionDepartment\x12c\n" +
 	"\x11institution_place\x18\x03 \x01(\tB6\x8a\xb5\x182\n" +
-	"\x05extra\xea\x03\x14Institution location\xb2\x04\x11institution_placeR\x10institutionPlace:\x11\x8a\xb5\x18\rR\vinstitution\"\xac\x05\n" +
+	"\x05extra\xea\x03\x14Institution location\xb2\x04\x11institution_placeR\x10institutionPlace:\x11\x8a\xb5\x18\rR\vinstitution\"\xaf\t\n" +
 	"\rPostedContent\x12@\n" +
 	"\x04type\x18\x01 \x01(\tB,\x8a\xb5\x18(\n" +
 	"\rresource_type\xea\x03\x13Posted content type\xc0\x04\x01R\x04type\x12E\n" +
@@ -2934,8 +3432,19 @@ const file_spoke_crossref_v5_3_1_crossref_proto_rawDesc = "" +
 	"\videntifiersZ\x03doi\xb2\x04\bdoi_dataR\adoiData\x12S\n" +
 	"\vgroup_title\x18\a \x01(\tB2\x8a\xb5\x18.\n" +
 	"\bsubjectsb\x05local\xea\x03\fSubject area\xb2\x04\vgroup_titleR\n" +
-	"groupTitle:\\\x8a\xb5\x18X\n" +
-	"\x06Record\x10\x01\x1a<CrossRef PostedContent maps to Hub Record with PREPRINT typeR\x0eposted_content\"\xcb\x04\n" +
+	"groupTitle\x12\x86\x01\n" +
+	"\tassertion\x18\b \x03(\v2 .spoke.crossref.v5_3_1.AssertionBF\x8a\xb5\x18B\n" +
+	"\x05extra\xea\x03,Crossmark copyright and licensing assertions\xb2\x04\tassertionR\tassertion\x12}\n" +
+	"\rcitation_list\x18\t \x01(\v2#.spoke.crossref.v5_3_1.CitationListB3\x8a\xb5\x18/\n" +
+	"\trelationsz\x05cites\xea\x03\n" +
+	"References\xb2\x04\rcitation_listR\fcitationList\x12o\n" +
+	"\x11access_indicators\x18\n" +
+	" \x01(\v2'.spoke.crossref.v5_3_1.AccessIndicatorsB\x19\x8a\xb5\x18\x15\n" +
+	"\x06rights\xb2\x04\n" +
+	"ai:programR\x10accessIndicators\x12U\n" +
+	"\aprogram\x18\v \x03(\v2\".spoke.crossref.v5_3_1.FundingInfoB\x17\x8a\xb5\x18\x13\n" +
+	"\afunders\xb2\x04\aprogramR\aprogram:\x8e\x01\x8a\xb5\x18\x89\x01\n" +
+	"\x06Record\x10\x01\x1a<CrossRef PostedContent maps to Hub Record with PREPRINT typeR\x0eposted_contentZ/ai=http://www.crossref.org/AccessIndicators.xsd\"\xc5\a\n" +
 	"\n" +
 	"PeerReview\x128\n" +
 	"\x04type\x18\x01 \x01(\tB$\x8a\xb5\x18 \n" +
@@ -2950,8 +3459,16 @@ const file_spoke_crossref_v5_3_1_crossref_proto_rawDesc = "" +
 	"\x05datesR\x06issued\xb2\x04\vreview_dateR\n" +
 	"reviewDate\x12\\\n" +
 	"\bdoi_data\x18\x06 \x01(\v2\x1e.spoke.crossref.v5_3_1.DoiDataB!\x8a\xb5\x18\x1d\n" +
-	"\videntifiersZ\x03doi\xb2\x04\bdoi_dataR\adoiData:Y\x8a\xb5\x18U\n" +
-	"\x06Record\x10\x01\x1a<CrossRef PeerReview maps to Hub Record with PEER_REVIEW typeR\vpeer_review\"\x96\x02\n" +
+	"\videntifiersZ\x03doi\xb2\x04\bdoi_dataR\adoiData\x12}\n" +
+	"\rcitation_list\x18\a \x01(\v2#.spoke.crossref.v5_3_1.CitationListB3\x8a\xb5\x18/\n" +
+	"\trelationsz\x05cites\xea\x03\n" +
+	"References\xb2\x04\rcitation_listR\fcitationList\x12o\n" +
+	"\x11access_indicators\x18\b \x01(\v2'.spoke.crossref.v5_3_1.AccessIndicatorsB\x19\x8a\xb5\x18\x15\n" +
+	"\x06rights\xb2\x04\n" +
+	"ai:programR\x10accessIndicators\x12U\n" +
+	"\aprogram\x18\t \x03(\v2\".spoke.crossref.v5_3_1.FundingInfoB\x17\x8a\xb5\x18\x13\n" +
+	"\afunders\xb2\x04\aprogramR\aprogram:\x8b\x01\x8a\xb5\x18\x86\x01\n" +
+	"\x06Record\x10\x01\x1a<CrossRef PeerReview maps to Hub Record with PEER_REVIEW typeR\vpeer_reviewZ/ai=http://www.crossref.org/AccessIndicators.xsd\"\x96\x02\n" +
 	"\x06Titles\x12$\n" +
 	"\x05title\x18\x01 \x01(\tB\x0e\x8a\xb5\x18\n" +
 	"\n" +
@@ -3098,7 +3615,28 @@ const file_spoke_crossref_v5_3_1_crossref_proto_rawDesc = "" +
 	"applies_to\x18\x03 \x01(\tB0\x8a\xb5\x18,\n" +
 	"\x05extra\xea\x03\x12Applies to version\xb2\x04\n" +
 	"applies_to\xc0\x04\x01R\tappliesTo:>\x8a\xb5\x18:\n" +
-	"\x06Rights\x1a#CrossRef License maps to Hub RightsR\vlicense_refB\xe7\x01\n" +
+	"\x06Rights\x1a#CrossRef License maps to Hub RightsR\vlicense_ref\"\xe7\x01\n" +
+	"\x10AccessIndicators\x12)\n" +
+	"\x04name\x18\x01 \x01(\tB\x15\x8a\xb5\x18\x11\n" +
+	"\x05extra\xb2\x04\x04name\xc0\x04\x01R\x04name\x12[\n" +
+	"\vlicense_ref\x18\x02 \x03(\v2\x1e.spoke.crossref.v5_3_1.LicenseB\x1a\x8a\xb5\x18\x16\n" +
+	"\x06rights\xb2\x04\vlicense_refR\n" +
+	"licenseRef:K\x8a\xb5\x18G\x1a<CrossRef AccessIndicators program carries license referencesR\aprogram\"\xdd\x02\n" +
+	"\tAssertion\x12)\n" +
+	"\x04name\x18\x01 \x01(\tB\x15\x8a\xb5\x18\x11\n" +
+	"\x05extra\xb2\x04\x04name\xc0\x04\x01R\x04name\x12,\n" +
+	"\x05label\x18\x02 \x01(\tB\x16\x8a\xb5\x18\x12\n" +
+	"\x05extra\xb2\x04\x05label\xc0\x04\x01R\x05label\x12:\n" +
+	"\n" +
+	"group_name\x18\x03 \x01(\tB\x1b\x8a\xb5\x18\x17\n" +
+	"\x05extra\xb2\x04\n" +
+	"group_name\xc0\x04\x01R\tgroupName\x12=\n" +
+	"\vgroup_label\x18\x04 \x01(\tB\x1c\x8a\xb5\x18\x18\n" +
+	"\x05extra\xb2\x04\vgroup_label\xc0\x04\x01R\n" +
+	"groupLabel\x12$\n" +
+	"\x05value\x18\x05 \x01(\tB\x0e\x8a\xb5\x18\n" +
+	"\n" +
+	"\x05extra\xc8\x04\x01R\x05value:V\x8a\xb5\x18R\x1aECrossRef Crossmark assertion carries copyright and license statementsR\tassertionB\xe7\x01\n" +
 	"\x19com.spoke.crossref.v5_3_1B\rCrossrefProtoP\x01ZMgithub.com/lehigh-university-libraries/crosswalk/gen/go/spoke/crossref/v5_3_1\xa2\x02\x03SCV\xaa\x02\x13Spoke.Crossref.V531\xca\x02\x13Spoke\\Crossref\\V531\xe2\x02\x1fSpoke\\Crossref\\V531\\GPBMetadata\xea\x02\x15Spoke::Crossref::V531b\x06proto3"
 
 var (
@@ -3113,7 +3651,7 @@ func file_spoke_crossref_v5_3_1_crossref_proto_rawDescGZIP() []byte {
 	return file_spoke_crossref_v5_3_1_crossref_proto_rawDescData
 }
 
-var file_spoke_crossref_v5_3_1_crossref_proto_msgTypes = make([]protoimpl.MessageInfo, 35)
+var file_spoke_crossref_v5_3_1_crossref_proto_msgTypes = make([]protoimpl.MessageInfo, 38)
 var file_spoke_crossref_v5_3_1_crossref_proto_goTypes = []any{
 	(*Deposit)(nil),             // 0: spoke.crossref.v5_3_1.Deposit
 	(*Head)(nil),                // 1: spoke.crossref.v5_3_1.Head
@@ -3122,111 +3660,143 @@ var file_spoke_crossref_v5_3_1_crossref_proto_goTypes = []any{
 	(*Journal)(nil),             // 4: spoke.crossref.v5_3_1.Journal
 	(*JournalMetadata)(nil),     // 5: spoke.crossref.v5_3_1.JournalMetadata
 	(*JournalIssue)(nil),        // 6: spoke.crossref.v5_3_1.JournalIssue
-	(*JournalArticle)(nil),      // 7: spoke.crossref.v5_3_1.JournalArticle
-	(*Book)(nil),                // 8: spoke.crossref.v5_3_1.Book
-	(*BookMetadata)(nil),        // 9: spoke.crossref.v5_3_1.BookMetadata
-	(*BookSeriesMetadata)(nil),  // 10: spoke.crossref.v5_3_1.BookSeriesMetadata
-	(*ContentItem)(nil),         // 11: spoke.crossref.v5_3_1.ContentItem
-	(*Conference)(nil),          // 12: spoke.crossref.v5_3_1.Conference
-	(*EventMetadata)(nil),       // 13: spoke.crossref.v5_3_1.EventMetadata
-	(*ProceedingsMetadata)(nil), // 14: spoke.crossref.v5_3_1.ProceedingsMetadata
-	(*ConferencePaper)(nil),     // 15: spoke.crossref.v5_3_1.ConferencePaper
-	(*Dataset)(nil),             // 16: spoke.crossref.v5_3_1.Dataset
-	(*Dissertation)(nil),        // 17: spoke.crossref.v5_3_1.Dissertation
-	(*Institution)(nil),         // 18: spoke.crossref.v5_3_1.Institution
-	(*PostedContent)(nil),       // 19: spoke.crossref.v5_3_1.PostedContent
-	(*PeerReview)(nil),          // 20: spoke.crossref.v5_3_1.PeerReview
-	(*Titles)(nil),              // 21: spoke.crossref.v5_3_1.Titles
-	(*Contributors)(nil),        // 22: spoke.crossref.v5_3_1.Contributors
-	(*PersonName)(nil),          // 23: spoke.crossref.v5_3_1.PersonName
-	(*Organization)(nil),        // 24: spoke.crossref.v5_3_1.Organization
-	(*Affiliation)(nil),         // 25: spoke.crossref.v5_3_1.Affiliation
-	(*Publisher)(nil),           // 26: spoke.crossref.v5_3_1.Publisher
-	(*PublicationDate)(nil),     // 27: spoke.crossref.v5_3_1.PublicationDate
-	(*Pages)(nil),               // 28: spoke.crossref.v5_3_1.Pages
-	(*DoiData)(nil),             // 29: spoke.crossref.v5_3_1.DoiData
-	(*Item)(nil),                // 30: spoke.crossref.v5_3_1.Item
-	(*CitationList)(nil),        // 31: spoke.crossref.v5_3_1.CitationList
-	(*Citation)(nil),            // 32: spoke.crossref.v5_3_1.Citation
-	(*FundingInfo)(nil),         // 33: spoke.crossref.v5_3_1.FundingInfo
-	(*License)(nil),             // 34: spoke.crossref.v5_3_1.License
+	(*JournalVolume)(nil),       // 7: spoke.crossref.v5_3_1.JournalVolume
+	(*JournalArticle)(nil),      // 8: spoke.crossref.v5_3_1.JournalArticle
+	(*Book)(nil),                // 9: spoke.crossref.v5_3_1.Book
+	(*BookMetadata)(nil),        // 10: spoke.crossref.v5_3_1.BookMetadata
+	(*BookSeriesMetadata)(nil),  // 11: spoke.crossref.v5_3_1.BookSeriesMetadata
+	(*ContentItem)(nil),         // 12: spoke.crossref.v5_3_1.ContentItem
+	(*Conference)(nil),          // 13: spoke.crossref.v5_3_1.Conference
+	(*EventMetadata)(nil),       // 14: spoke.crossref.v5_3_1.EventMetadata
+	(*ProceedingsMetadata)(nil), // 15: spoke.crossref.v5_3_1.ProceedingsMetadata
+	(*ConferencePaper)(nil),     // 16: spoke.crossref.v5_3_1.ConferencePaper
+	(*Dataset)(nil),             // 17: spoke.crossref.v5_3_1.Dataset
+	(*Dissertation)(nil),        // 18: spoke.crossref.v5_3_1.Dissertation
+	(*Institution)(nil),         // 19: spoke.crossref.v5_3_1.Institution
+	(*PostedContent)(nil),       // 20: spoke.crossref.v5_3_1.PostedContent
+	(*PeerReview)(nil),          // 21: spoke.crossref.v5_3_1.PeerReview
+	(*Titles)(nil),              // 22: spoke.crossref.v5_3_1.Titles
+	(*Contributors)(nil),        // 23: spoke.crossref.v5_3_1.Contributors
+	(*PersonName)(nil),          // 24: spoke.crossref.v5_3_1.PersonName
+	(*Organization)(nil),        // 25: spoke.crossref.v5_3_1.Organization
+	(*Affiliation)(nil),         // 26: spoke.crossref.v5_3_1.Affiliation
+	(*Publisher)(nil),           // 27: spoke.crossref.v5_3_1.Publisher
+	(*PublicationDate)(nil),     // 28: spoke.crossref.v5_3_1.PublicationDate
+	(*Pages)(nil),               // 29: spoke.crossref.v5_3_1.Pages
+	(*DoiData)(nil),             // 30: spoke.crossref.v5_3_1.DoiData
+	(*Item)(nil),                // 31: spoke.crossref.v5_3_1.Item
+	(*CitationList)(nil),        // 32: spoke.crossref.v5_3_1.CitationList
+	(*Citation)(nil),            // 33: spoke.crossref.v5_3_1.Citation
+	(*FundingInfo)(nil),         // 34: spoke.crossref.v5_3_1.FundingInfo
+	(*License)(nil),             // 35: spoke.crossref.v5_3_1.License
+	(*AccessIndicators)(nil),    // 36: spoke.crossref.v5_3_1.AccessIndicators
+	(*Assertion)(nil),           // 37: spoke.crossref.v5_3_1.Assertion
 }
 var file_spoke_crossref_v5_3_1_crossref_proto_depIdxs = []int32{
 	1,  // 0: spoke.crossref.v5_3_1.Deposit.head:type_name -> spoke.crossref.v5_3_1.Head
 	3,  // 1: spoke.crossref.v5_3_1.Deposit.body:type_name -> spoke.crossref.v5_3_1.Body
 	2,  // 2: spoke.crossref.v5_3_1.Head.depositor:type_name -> spoke.crossref.v5_3_1.Depositor
 	4,  // 3: spoke.crossref.v5_3_1.Body.journal:type_name -> spoke.crossref.v5_3_1.Journal
-	8,  // 4: spoke.crossref.v5_3_1.Body.book:type_name -> spoke.crossref.v5_3_1.Book
-	12, // 5: spoke.crossref.v5_3_1.Body.conference:type_name -> spoke.crossref.v5_3_1.Conference
-	16, // 6: spoke.crossref.v5_3_1.Body.dataset:type_name -> spoke.crossref.v5_3_1.Dataset
-	17, // 7: spoke.crossref.v5_3_1.Body.dissertation:type_name -> spoke.crossref.v5_3_1.Dissertation
-	19, // 8: spoke.crossref.v5_3_1.Body.posted_content:type_name -> spoke.crossref.v5_3_1.PostedContent
-	20, // 9: spoke.crossref.v5_3_1.Body.peer_review:type_name -> spoke.crossref.v5_3_1.PeerReview
+	9,  // 4: spoke.crossref.v5_3_1.Body.book:type_name -> spoke.crossref.v5_3_1.Book
+	13, // 5: spoke.crossref.v5_3_1.Body.conference:type_name -> spoke.crossref.v5_3_1.Conference
+	17, // 6: spoke.crossref.v5_3_1.Body.dataset:type_name -> spoke.crossref.v5_3_1.Dataset
+	18, // 7: spoke.crossref.v5_3_1.Body.dissertation:type_name -> spoke.crossref.v5_3_1.Dissertation
+	20, // 8: spoke.crossref.v5_3_1.Body.posted_content:type_name -> spoke.crossref.v5_3_1.PostedContent
+	21, // 9: spoke.crossref.v5_3_1.Body.peer_review:type_name -> spoke.crossref.v5_3_1.PeerReview
 	5,  // 10: spoke.crossref.v5_3_1.Journal.journal_metadata:type_name -> spoke.crossref.v5_3_1.JournalMetadata
 	6,  // 11: spoke.crossref.v5_3_1.Journal.journal_issue:type_name -> spoke.crossref.v5_3_1.JournalIssue
-	7,  // 12: spoke.crossref.v5_3_1.Journal.journal_article:type_name -> spoke.crossref.v5_3_1.JournalArticle
-	29, // 13: spoke.crossref.v5_3_1.JournalMetadata.doi_data:type_name -> spoke.crossref.v5_3_1.DoiData
-	27, // 14: spoke.crossref.v5_3_1.JournalIssue.publication_date:type_name -> spoke.crossref.v5_3_1.PublicationDate
-	29, // 15: spoke.crossref.v5_3_1.JournalIssue.doi_data:type_name -> spoke.crossref.v5_3_1.DoiData
-	21, // 16: spoke.crossref.v5_3_1.JournalArticle.titles:type_name -> spoke.crossref.v5_3_1.Titles
-	22, // 17: spoke.crossref.v5_3_1.JournalArticle.contributors:type_name -> spoke.crossref.v5_3_1.Contributors
-	27, // 18: spoke.crossref.v5_3_1.JournalArticle.publication_date:type_name -> spoke.crossref.v5_3_1.PublicationDate
-	28, // 19: spoke.crossref.v5_3_1.JournalArticle.pages:type_name -> spoke.crossref.v5_3_1.Pages
-	29, // 20: spoke.crossref.v5_3_1.JournalArticle.doi_data:type_name -> spoke.crossref.v5_3_1.DoiData
-	31, // 21: spoke.crossref.v5_3_1.JournalArticle.citation_list:type_name -> spoke.crossref.v5_3_1.CitationList
-	33, // 22: spoke.crossref.v5_3_1.JournalArticle.program:type_name -> spoke.crossref.v5_3_1.FundingInfo
-	34, // 23: spoke.crossref.v5_3_1.JournalArticle.license:type_name -> spoke.crossref.v5_3_1.License
-	9,  // 24: spoke.crossref.v5_3_1.Book.book_metadata:type_name -> spoke.crossref.v5_3_1.BookMetadata
-	10, // 25: spoke.crossref.v5_3_1.Book.book_series_metadata:type_name -> spoke.crossref.v5_3_1.BookSeriesMetadata
-	11, // 26: spoke.crossref.v5_3_1.Book.content_item:type_name -> spoke.crossref.v5_3_1.ContentItem
-	21, // 27: spoke.crossref.v5_3_1.BookMetadata.titles:type_name -> spoke.crossref.v5_3_1.Titles
-	22, // 28: spoke.crossref.v5_3_1.BookMetadata.contributors:type_name -> spoke.crossref.v5_3_1.Contributors
-	27, // 29: spoke.crossref.v5_3_1.BookMetadata.publication_date:type_name -> spoke.crossref.v5_3_1.PublicationDate
-	26, // 30: spoke.crossref.v5_3_1.BookMetadata.publisher:type_name -> spoke.crossref.v5_3_1.Publisher
-	29, // 31: spoke.crossref.v5_3_1.BookMetadata.doi_data:type_name -> spoke.crossref.v5_3_1.DoiData
-	21, // 32: spoke.crossref.v5_3_1.ContentItem.titles:type_name -> spoke.crossref.v5_3_1.Titles
-	22, // 33: spoke.crossref.v5_3_1.ContentItem.contributors:type_name -> spoke.crossref.v5_3_1.Contributors
-	27, // 34: spoke.crossref.v5_3_1.ContentItem.publication_date:type_name -> spoke.crossref.v5_3_1.PublicationDate
-	28, // 35: spoke.crossref.v5_3_1.ContentItem.pages:type_name -> spoke.crossref.v5_3_1.Pages
-	29, // 36: spoke.crossref.v5_3_1.ContentItem.doi_data:type_name -> spoke.crossref.v5_3_1.DoiData
-	13, // 37: spoke.crossref.v5_3_1.Conference.event_metadata:type_name -> spoke.crossref.v5_3_1.EventMetadata
-	14, // 38: spoke.crossref.v5_3_1.Conference.proceedings_metadata:type_name -> spoke.crossref.v5_3_1.ProceedingsMetadata
-	15, // 39: spoke.crossref.v5_3_1.Conference.conference_paper:type_name -> spoke.crossref.v5_3_1.ConferencePaper
-	26, // 40: spoke.crossref.v5_3_1.ProceedingsMetadata.publisher:type_name -> spoke.crossref.v5_3_1.Publisher
-	27, // 41: spoke.crossref.v5_3_1.ProceedingsMetadata.publication_date:type_name -> spoke.crossref.v5_3_1.PublicationDate
-	29, // 42: spoke.crossref.v5_3_1.ProceedingsMetadata.doi_data:type_name -> spoke.crossref.v5_3_1.DoiData
-	21, // 43: spoke.crossref.v5_3_1.ConferencePaper.titles:type_name -> spoke.crossref.v5_3_1.Titles
-	22, // 44: spoke.crossref.v5_3_1.ConferencePaper.contributors:type_name -> spoke.crossref.v5_3_1.Contributors
-	27, // 45: spoke.crossref.v5_3_1.ConferencePaper.publication_date:type_name -> spoke.crossref.v5_3_1.PublicationDate
-	28, // 46: spoke.crossref.v5_3_1.ConferencePaper.pages:type_name -> spoke.crossref.v5_3_1.Pages
-	29, // 47: spoke.crossref.v5_3_1.ConferencePaper.doi_data:type_name -> spoke.crossref.v5_3_1.DoiData
-	21, // 48: spoke.crossref.v5_3_1.Dataset.titles:type_name -> spoke.crossref.v5_3_1.Titles
-	22, // 49: spoke.crossref.v5_3_1.Dataset.contributors:type_name -> spoke.crossref.v5_3_1.Contributors
-	27, // 50: spoke.crossref.v5_3_1.Dataset.publication_date:type_name -> spoke.crossref.v5_3_1.PublicationDate
-	29, // 51: spoke.crossref.v5_3_1.Dataset.doi_data:type_name -> spoke.crossref.v5_3_1.DoiData
-	21, // 52: spoke.crossref.v5_3_1.Dissertation.titles:type_name -> spoke.crossref.v5_3_1.Titles
-	23, // 53: spoke.crossref.v5_3_1.Dissertation.person_name:type_name -> spoke.crossref.v5_3_1.PersonName
-	27, // 54: spoke.crossref.v5_3_1.Dissertation.approval_date:type_name -> spoke.crossref.v5_3_1.PublicationDate
-	18, // 55: spoke.crossref.v5_3_1.Dissertation.institution:type_name -> spoke.crossref.v5_3_1.Institution
-	29, // 56: spoke.crossref.v5_3_1.Dissertation.doi_data:type_name -> spoke.crossref.v5_3_1.DoiData
-	21, // 57: spoke.crossref.v5_3_1.PostedContent.titles:type_name -> spoke.crossref.v5_3_1.Titles
-	22, // 58: spoke.crossref.v5_3_1.PostedContent.contributors:type_name -> spoke.crossref.v5_3_1.Contributors
-	27, // 59: spoke.crossref.v5_3_1.PostedContent.posted_date:type_name -> spoke.crossref.v5_3_1.PublicationDate
-	29, // 60: spoke.crossref.v5_3_1.PostedContent.doi_data:type_name -> spoke.crossref.v5_3_1.DoiData
-	21, // 61: spoke.crossref.v5_3_1.PeerReview.titles:type_name -> spoke.crossref.v5_3_1.Titles
-	22, // 62: spoke.crossref.v5_3_1.PeerReview.contributors:type_name -> spoke.crossref.v5_3_1.Contributors
-	27, // 63: spoke.crossref.v5_3_1.PeerReview.review_date:type_name -> spoke.crossref.v5_3_1.PublicationDate
-	29, // 64: spoke.crossref.v5_3_1.PeerReview.doi_data:type_name -> spoke.crossref.v5_3_1.DoiData
-	23, // 65: spoke.crossref.v5_3_1.Contributors.person_name:type_name -> spoke.crossref.v5_3_1.PersonName
-	24, // 66: spoke.crossref.v5_3_1.Contributors.organization:type_name -> spoke.crossref.v5_3_1.Organization
-	25, // 67: spoke.crossref.v5_3_1.PersonName.affiliation:type_name -> spoke.crossref.v5_3_1.Affiliation
-	30, // 68: spoke.crossref.v5_3_1.DoiData.collection:type_name -> spoke.crossref.v5_3_1.Item
-	32, // 69: spoke.crossref.v5_3_1.CitationList.citation:type_name -> spoke.crossref.v5_3_1.Citation
-	70, // [70:70] is the sub-list for method output_type
-	70, // [70:70] is the sub-list for method input_type
-	70, // [70:70] is the sub-list for extension type_name
-	70, // [70:70] is the sub-list for extension extendee
-	0,  // [0:70] is the sub-list for field type_name
+	8,  // 12: spoke.crossref.v5_3_1.Journal.journal_article:type_name -> spoke.crossref.v5_3_1.JournalArticle
+	30, // 13: spoke.crossref.v5_3_1.JournalMetadata.doi_data:type_name -> spoke.crossref.v5_3_1.DoiData
+	28, // 14: spoke.crossref.v5_3_1.JournalIssue.publication_date:type_name -> spoke.crossref.v5_3_1.PublicationDate
+	30, // 15: spoke.crossref.v5_3_1.JournalIssue.doi_data:type_name -> spoke.crossref.v5_3_1.DoiData
+	7,  // 16: spoke.crossref.v5_3_1.JournalIssue.journal_volume:type_name -> spoke.crossref.v5_3_1.JournalVolume
+	30, // 17: spoke.crossref.v5_3_1.JournalVolume.doi_data:type_name -> spoke.crossref.v5_3_1.DoiData
+	22, // 18: spoke.crossref.v5_3_1.JournalArticle.titles:type_name -> spoke.crossref.v5_3_1.Titles
+	23, // 19: spoke.crossref.v5_3_1.JournalArticle.contributors:type_name -> spoke.crossref.v5_3_1.Contributors
+	28, // 20: spoke.crossref.v5_3_1.JournalArticle.publication_date:type_name -> spoke.crossref.v5_3_1.PublicationDate
+	29, // 21: spoke.crossref.v5_3_1.JournalArticle.pages:type_name -> spoke.crossref.v5_3_1.Pages
+	30, // 22: spoke.crossref.v5_3_1.JournalArticle.doi_data:type_name -> spoke.crossref.v5_3_1.DoiData
+	32, // 23: spoke.crossref.v5_3_1.JournalArticle.citation_list:type_name -> spoke.crossref.v5_3_1.CitationList
+	34, // 24: spoke.crossref.v5_3_1.JournalArticle.program:type_name -> spoke.crossref.v5_3_1.FundingInfo
+	35, // 25: spoke.crossref.v5_3_1.JournalArticle.license:type_name -> spoke.crossref.v5_3_1.License
+	36, // 26: spoke.crossref.v5_3_1.JournalArticle.access_indicators:type_name -> spoke.crossref.v5_3_1.AccessIndicators
+	10, // 27: spoke.crossref.v5_3_1.Book.book_metadata:type_name -> spoke.crossref.v5_3_1.BookMetadata
+	11, // 28: spoke.crossref.v5_3_1.Book.book_series_metadata:type_name -> spoke.crossref.v5_3_1.BookSeriesMetadata
+	12, // 29: spoke.crossref.v5_3_1.Book.content_item:type_name -> spoke.crossref.v5_3_1.ContentItem
+	22, // 30: spoke.crossref.v5_3_1.BookMetadata.titles:type_name -> spoke.crossref.v5_3_1.Titles
+	23, // 31: spoke.crossref.v5_3_1.BookMetadata.contributors:type_name -> spoke.crossref.v5_3_1.Contributors
+	28, // 32: spoke.crossref.v5_3_1.BookMetadata.publication_date:type_name -> spoke.crossref.v5_3_1.PublicationDate
+	27, // 33: spoke.crossref.v5_3_1.BookMetadata.publisher:type_name -> spoke.crossref.v5_3_1.Publisher
+	30, // 34: spoke.crossref.v5_3_1.BookMetadata.doi_data:type_name -> spoke.crossref.v5_3_1.DoiData
+	37, // 35: spoke.crossref.v5_3_1.BookMetadata.assertion:type_name -> spoke.crossref.v5_3_1.Assertion
+	32, // 36: spoke.crossref.v5_3_1.BookMetadata.citation_list:type_name -> spoke.crossref.v5_3_1.CitationList
+	36, // 37: spoke.crossref.v5_3_1.BookMetadata.access_indicators:type_name -> spoke.crossref.v5_3_1.AccessIndicators
+	34, // 38: spoke.crossref.v5_3_1.BookMetadata.program:type_name -> spoke.crossref.v5_3_1.FundingInfo
+	22, // 39: spoke.crossref.v5_3_1.ContentItem.titles:type_name -> spoke.crossref.v5_3_1.Titles
+	23, // 40: spoke.crossref.v5_3_1.ContentItem.contributors:type_name -> spoke.crossref.v5_3_1.Contributors
+	28, // 41: spoke.crossref.v5_3_1.ContentItem.publication_date:type_name -> spoke.crossref.v5_3_1.PublicationDate
+	29, // 42: spoke.crossref.v5_3_1.ContentItem.pages:type_name -> spoke.crossref.v5_3_1.Pages
+	30, // 43: spoke.crossref.v5_3_1.ContentItem.doi_data:type_name -> spoke.crossref.v5_3_1.DoiData
+	32, // 44: spoke.crossref.v5_3_1.ContentItem.citation_list:type_name -> spoke.crossref.v5_3_1.CitationList
+	36, // 45: spoke.crossref.v5_3_1.ContentItem.access_indicators:type_name -> spoke.crossref.v5_3_1.AccessIndicators
+	34, // 46: spoke.crossref.v5_3_1.ContentItem.program:type_name -> spoke.crossref.v5_3_1.FundingInfo
+	14, // 47: spoke.crossref.v5_3_1.Conference.event_metadata:type_name -> spoke.crossref.v5_3_1.EventMetadata
+	15, // 48: spoke.crossref.v5_3_1.Conference.proceedings_metadata:type_name -> spoke.crossref.v5_3_1.ProceedingsMetadata
+	16, // 49: spoke.crossref.v5_3_1.Conference.conference_paper:type_name -> spoke.crossref.v5_3_1.ConferencePaper
+	27, // 50: spoke.crossref.v5_3_1.ProceedingsMetadata.publisher:type_name -> spoke.crossref.v5_3_1.Publisher
+	28, // 51: spoke.crossref.v5_3_1.ProceedingsMetadata.publication_date:type_name -> spoke.crossref.v5_3_1.PublicationDate
+	30, // 52: spoke.crossref.v5_3_1.ProceedingsMetadata.doi_data:type_name -> spoke.crossref.v5_3_1.DoiData
+	22, // 53: spoke.crossref.v5_3_1.ConferencePaper.titles:type_name -> spoke.crossref.v5_3_1.Titles
+	23, // 54: spoke.crossref.v5_3_1.ConferencePaper.contributors:type_name -> spoke.crossref.v5_3_1.Contributors
+	28, // 55: spoke.crossref.v5_3_1.ConferencePaper.publication_date:type_name -> spoke.crossref.v5_3_1.PublicationDate
+	29, // 56: spoke.crossref.v5_3_1.ConferencePaper.pages:type_name -> spoke.crossref.v5_3_1.Pages
+	30, // 57: spoke.crossref.v5_3_1.ConferencePaper.doi_data:type_name -> spoke.crossref.v5_3_1.DoiData
+	32, // 58: spoke.crossref.v5_3_1.ConferencePaper.citation_list:type_name -> spoke.crossref.v5_3_1.CitationList
+	36, // 59: spoke.crossref.v5_3_1.ConferencePaper.access_indicators:type_name -> spoke.crossref.v5_3_1.AccessIndicators
+	34, // 60: spoke.crossref.v5_3_1.ConferencePaper.program:type_name -> spoke.crossref.v5_3_1.FundingInfo
+	22, // 61: spoke.crossref.v5_3_1.Dataset.titles:type_name -> spoke.crossref.v5_3_1.Titles
+	23, // 62: spoke.crossref.v5_3_1.Dataset.contributors:type_name -> spoke.crossref.v5_3_1.Contributors
+	28, // 63: spoke.crossref.v5_3_1.Dataset.publication_date:type_name -> spoke.crossref.v5_3_1.PublicationDate
+	30, // 64: spoke.crossref.v5_3_1.Dataset.doi_data:type_name -> spoke.crossref.v5_3_1.DoiData
+	37, // 65: spoke.crossref.v5_3_1.Dataset.assertion:type_name -> spoke.crossref.v5_3_1.Assertion
+	32, // 66: spoke.crossref.v5_3_1.Dataset.citation_list:type_name -> spoke.crossref.v5_3_1.CitationList
+	36, // 67: spoke.crossref.v5_3_1.Dataset.access_indicators:type_name -> spoke.crossref.v5_3_1.AccessIndicators
+	34, // 68: spoke.crossref.v5_3_1.Dataset.program:type_name -> spoke.crossref.v5_3_1.FundingInfo
+	22, // 69: spoke.crossref.v5_3_1.Dissertation.titles:type_name -> spoke.crossref.v5_3_1.Titles
+	24, // 70: spoke.crossref.v5_3_1.Dissertation.person_name:type_name -> spoke.crossref.v5_3_1.PersonName
+	28, // 71: spoke.crossref.v5_3_1.Dissertation.approval_date:type_name -> spoke.crossref.v5_3_1.PublicationDate
+	19, // 72: spoke.crossref.v5_3_1.Dissertation.institution:type_name -> spoke.crossref.v5_3_1.Institution
+	30, // 73: spoke.crossref.v5_3_1.Dissertation.doi_data:type_name -> spoke.crossref.v5_3_1.DoiData
+	37, // 74: spoke.crossref.v5_3_1.Dissertation.assertion:type_name -> spoke.crossref.v5_3_1.Assertion
+	32, // 75: spoke.crossref.v5_3_1.Dissertation.citation_list:type_name -> spoke.crossref.v5_3_1.CitationList
+	36, // 76: spoke.crossref.v5_3_1.Dissertation.access_indicators:type_name -> spoke.crossref.v5_3_1.AccessIndicators
+	34, // 77: spoke.crossref.v5_3_1.Dissertation.program:type_name -> spoke.crossref.v5_3_1.FundingInfo
+	22, // 78: spoke.crossref.v5_3_1.PostedContent.titles:type_name -> spoke.crossref.v5_3_1.Titles
+	23, // 79: spoke.crossref.v5_3_1.PostedContent.contributors:type_name -> spoke.crossref.v5_3_1.Contributors
+	28, // 80: spoke.crossref.v5_3_1.PostedContent.posted_date:type_name -> spoke.crossref.v5_3_1.PublicationDate
+	30, // 81: spoke.crossref.v5_3_1.PostedContent.doi_data:type_name -> spoke.crossref.v5_3_1.DoiData
+	37, // 82: spoke.crossref.v5_3_1.PostedContent.assertion:type_name -> spoke.crossref.v5_3_1.Assertion
+	32, // 83: spoke.crossref.v5_3_1.PostedContent.citation_list:type_name -> spoke.crossref.v5_3_1.CitationList
+	36, // 84: spoke.crossref.v5_3_1.PostedContent.access_indicators:type_name -> spoke.crossref.v5_3_1.AccessIndicators
+	34, // 85: spoke.crossref.v5_3_1.PostedContent.program:type_name -> spoke.crossref.v5_3_1.FundingInfo
+	22, // 86: spoke.crossref.v5_3_1.PeerReview.titles:type_name -> spoke.crossref.v5_3_1.Titles
+	23, // 87: spoke.crossref.v5_3_1.PeerReview.contributors:type_name -> spoke.crossref.v5_3_1.Contributors
+	28, // 88: spoke.crossref.v5_3_1.PeerReview.review_date:type_name -> spoke.crossref.v5_3_1.PublicationDate
+	30, // 89: spoke.crossref.v5_3_1.PeerReview.doi_data:type_name -> spoke.crossref.v5_3_1.DoiData
+	32, // 90: spoke.crossref.v5_3_1.PeerReview.citation_list:type_name -> spoke.crossref.v5_3_1.CitationList
+	36, // 91: spoke.crossref.v5_3_1.PeerReview.access_indicators:type_name -> spoke.crossref.v5_3_1.AccessIndicators
+	34, // 92: spoke.crossref.v5_3_1.PeerReview.program:type_name -> spoke.crossref.v5_3_1.FundingInfo
+	24, // 93: spoke.crossref.v5_3_1.Contributors.person_name:type_name -> spoke.crossref.v5_3_1.PersonName
+	25, // 94: spoke.crossref.v5_3_1.Contributors.organization:type_name -> spoke.crossref.v5_3_1.Organization
+	26, // 95: spoke.crossref.v5_3_1.PersonName.affiliation:type_name -> spoke.crossref.v5_3_1.Affiliation
+	31, // 96: spoke.crossref.v5_3_1.DoiData.collection:type_name -> spoke.crossref.v5_3_1.Item
+	33, // 97: spoke.crossref.v5_3_1.CitationList.citation:type_name -> spoke.crossref.v5_3_1.Citation
+	35, // 98: spoke.crossref.v5_3_1.AccessIndicators.license_ref:type_name -> spoke.crossref.v5_3_1.License
+	99, // [99:99] is the sub-list for method output_type
+	99, // [99:99] is the sub-list for method input_type
+	99, // [99:99] is the sub-list for extension type_name
+	99, // [99:99] is the sub-list for extension extendee
+	0,  // [0:99] is the sub-list for field type_name
 }
 
 func init() { file_spoke_crossref_v5_3_1_crossref_proto_init() }
@@ -3240,7 +3810,7 @@ func file_spoke_crossref_v5_3_1_crossref_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_spoke_crossref_v5_3_1_crossref_proto_rawDesc), len(file_spoke_crossref_v5_3_1_crossref_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   35,
+			NumMessages:   38,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
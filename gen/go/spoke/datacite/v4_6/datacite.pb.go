@@ -2255,9 +2255,11 @@ type RelatedItem struct {
 	// Edition
 	Edition string `protobuf:"bytes,13,opt,name=edition,proto3" json:"edition,omitempty"`
 	// Contributors
-	Contributors  []*Contributor `protobuf:"bytes,14,rep,name=contributors,proto3" json:"contributors,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	Contributors []*Contributor `protobuf:"bytes,14,rep,name=contributors,proto3" json:"contributors,omitempty"`
+	// Identifier (e.g., the journal's ISSN)
+	RelatedItemIdentifier *RelatedItemIdentifier `protobuf:"bytes,15,opt,name=related_item_identifier,json=relatedItemIdentifier,proto3" json:"related_item_identifier,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
 }
 
 func (x *RelatedItem) Reset() {
@@ -2388,6 +2390,68 @@ func (x *RelatedItem) GetContributors() []*Contributor {
 	return nil
 }
 
+func (x *RelatedItem) GetRelatedItemIdentifier() *RelatedItemIdentifier {
+	if x != nil {
+		return x.RelatedItemIdentifier
+	}
+	return nil
+}
+
+// RelatedItemIdentifier - The identifier of a related item.
+type RelatedItemIdentifier struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Identifier value
+	Value string `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	// Identifier type: ISSN, ISBN, DOI, URL, ...
+	RelatedItemIdentifierType string `protobuf:"bytes,2,opt,name=related_item_identifier_type,json=relatedItemIdentifierType,proto3" json:"related_item_identifier_type,omitempty"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *RelatedItemIdentifier) Reset() {
+	*x = RelatedItemIdentifier{}
+	mi := &file_spoke_datacite_v4_6_datacite_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RelatedItemIdentifier) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelatedItemIdentifier) ProtoMessage() {}
+
+func (x *RelatedItemIdentifier) ProtoReflect() protoreflect.Message {
+	mi := &file_spoke_datacite_v4_6_datacite_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelatedItemIdentifier.ProtoReflect.Descriptor instead.
+func (*RelatedItemIdentifier) Descriptor() ([]byte, []int) {
+	return file_spoke_datacite_v4_6_datacite_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RelatedItemIdentifier) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *RelatedItemIdentifier) GetRelatedItemIdentifierType() string {
+	if x != nil {
+		return x.RelatedItemIdentifierType
+	}
+	return ""
+}
+
 var File_spoke_datacite_v4_6_datacite_proto protoreflect.FileDescriptor
 
 const file_spoke_datacite_v4_6_datacite_proto_rawDesc = "" +
@@ -2656,7 +2720,7 @@ const file_spoke_datacite_v4_6_datacite_proto_rawDesc = "" +
 	"\vaward_title\xb2\x04\n" +
 	"awardTitleR\n" +
 	"awardTitle:L\x8a\xb5\x18H\n" +
-	"\x06Funder\x1a,DataCite FundingReference maps to Hub FunderR\x10fundingReference\"\xe9\b\n" +
+	"\x06Funder\x1a,DataCite FundingReference maps to Hub FunderR\x10fundingReference\"\xf7\t\n" +
 	"\vRelatedItem\x12d\n" +
 	"\rrelation_type\x18\x01 \x01(\x0e2!.spoke.datacite.v4_6.RelationTypeB\x1c\x8a\xb5\x18\x18\n" +
 	"\x04type\xb2\x04\frelationType\xc0\x04\x01R\frelationType\x12\x8a\x01\n" +
@@ -2689,8 +2753,15 @@ const file_spoke_datacite_v4_6_datacite_proto_rawDesc = "" +
 	"\aedition\x18\r \x01(\tB\x15\x8a\xb5\x18\x11\n" +
 	"\x05extra\xea\x03\aEditionR\aedition\x12m\n" +
 	"\fcontributors\x18\x0e \x03(\v2 .spoke.datacite.v4_6.ContributorB'\x8a\xb5\x18#\n" +
-	"\x05extra\xea\x03\x19Related item contributorsR\fcontributors:F\x8a\xb5\x18B\n" +
-	"\bRelation\x1a)DataCite RelatedItem maps to Hub RelationR\vrelatedItem*\xb4\t\n" +
+	"\x05extra\xea\x03\x19Related item contributorsR\fcontributors\x12\x8b\x01\n" +
+	"\x17related_item_identifier\x18\x0f \x01(\v2*.spoke.datacite.v4_6.RelatedItemIdentifierB'\x8a\xb5\x18#\n" +
+	"\ttarget_id\xb2\x04\x15relatedItemIdentifierR\x15relatedItemIdentifier:F\x8a\xb5\x18B\n" +
+	"\bRelation\x1a)DataCite RelatedItem maps to Hub RelationR\vrelatedItem\"\xd4\x01\n" +
+	"\x15RelatedItemIdentifier\x12(\n" +
+	"\x05value\x18\x01 \x01(\tB\x12\x8a\xb5\x18\x0e\n" +
+	"\ttarget_id\xc8\x04\x01R\x05value\x12t\n" +
+	"\x1crelated_item_identifier_type\x18\x02 \x01(\tB3\x8a\xb5\x18/\n" +
+	"\x0etarget_id_type\xb2\x04\x19relatedItemIdentifierType\xc0\x04\x01R\x19relatedItemIdentifierType:\x1b\x8a\xb5\x18\x17R\x15relatedItemIdentifier*\xb4\t\n" +
 	"\x0fContributorType\x12 \n" +
 	"\x1cCONTRIBUTOR_TYPE_UNSPECIFIED\x10\x00\x126\n" +
 	"\x1fCONTRIBUTOR_TYPE_CONTACT_PERSON\x10\x01\x1a\x11\x8a\xb5\x18\r\n" +
@@ -2954,35 +3025,36 @@ func file_spoke_datacite_v4_6_datacite_proto_rawDescGZIP() []byte {
 }
 
 var file_spoke_datacite_v4_6_datacite_proto_enumTypes = make([]protoimpl.EnumInfo, 7)
-var file_spoke_datacite_v4_6_datacite_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_spoke_datacite_v4_6_datacite_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
 var file_spoke_datacite_v4_6_datacite_proto_goTypes = []any{
-	(ContributorType)(0),        // 0: spoke.datacite.v4_6.ContributorType
-	(TitleType)(0),              // 1: spoke.datacite.v4_6.TitleType
-	(ResourceTypeGeneral)(0),    // 2: spoke.datacite.v4_6.ResourceTypeGeneral
-	(DateType)(0),               // 3: spoke.datacite.v4_6.DateType
-	(RelatedIdentifierType)(0),  // 4: spoke.datacite.v4_6.RelatedIdentifierType
-	(RelationType)(0),           // 5: spoke.datacite.v4_6.RelationType
-	(DescriptionType)(0),        // 6: spoke.datacite.v4_6.DescriptionType
-	(*Resource)(nil),            // 7: spoke.datacite.v4_6.Resource
-	(*Identifier)(nil),          // 8: spoke.datacite.v4_6.Identifier
-	(*Creator)(nil),             // 9: spoke.datacite.v4_6.Creator
-	(*Contributor)(nil),         // 10: spoke.datacite.v4_6.Contributor
-	(*NameIdentifier)(nil),      // 11: spoke.datacite.v4_6.NameIdentifier
-	(*Affiliation)(nil),         // 12: spoke.datacite.v4_6.Affiliation
-	(*Title)(nil),               // 13: spoke.datacite.v4_6.Title
-	(*ResourceType)(nil),        // 14: spoke.datacite.v4_6.ResourceType
-	(*Subject)(nil),             // 15: spoke.datacite.v4_6.Subject
-	(*Date)(nil),                // 16: spoke.datacite.v4_6.Date
-	(*AlternateIdentifier)(nil), // 17: spoke.datacite.v4_6.AlternateIdentifier
-	(*RelatedIdentifier)(nil),   // 18: spoke.datacite.v4_6.RelatedIdentifier
-	(*Rights)(nil),              // 19: spoke.datacite.v4_6.Rights
-	(*Description)(nil),         // 20: spoke.datacite.v4_6.Description
-	(*GeoLocation)(nil),         // 21: spoke.datacite.v4_6.GeoLocation
-	(*GeoLocationPoint)(nil),    // 22: spoke.datacite.v4_6.GeoLocationPoint
-	(*GeoLocationBox)(nil),      // 23: spoke.datacite.v4_6.GeoLocationBox
-	(*GeoLocationPolygon)(nil),  // 24: spoke.datacite.v4_6.GeoLocationPolygon
-	(*FundingReference)(nil),    // 25: spoke.datacite.v4_6.FundingReference
-	(*RelatedItem)(nil),         // 26: spoke.datacite.v4_6.RelatedItem
+	(ContributorType)(0),          // 0: spoke.datacite.v4_6.ContributorType
+	(TitleType)(0),                // 1: spoke.datacite.v4_6.TitleType
+	(ResourceTypeGeneral)(0),      // 2: spoke.datacite.v4_6.ResourceTypeGeneral
+	(DateType)(0),                 // 3: spoke.datacite.v4_6.DateType
+	(RelatedIdentifierType)(0),    // 4: spoke.datacite.v4_6.RelatedIdentifierType
+	(RelationType)(0),             // 5: spoke.datacite.v4_6.RelationType
+	(DescriptionType)(0),          // 6: spoke.datacite.v4_6.DescriptionType
+	(*Resource)(nil),              // 7: spoke.datacite.v4_6.Resource
+	(*Identifier)(nil),            // 8: spoke.datacite.v4_6.Identifier
+	(*Creator)(nil),               // 9: spoke.datacite.v4_6.Creator
+	(*Contributor)(nil),           // 10: spoke.datacite.v4_6.Contributor
+	(*NameIdentifier)(nil),        // 11: spoke.datacite.v4_6.NameIdentifier
+	(*Affiliation)(nil),           // 12: spoke.datacite.v4_6.Affiliation
+	(*Title)(nil),                 // 13: spoke.datacite.v4_6.Title
+	(*ResourceType)(nil),          // 14: spoke.datacite.v4_6.ResourceType
+	(*Subject)(nil),               // 15: spoke.datacite.v4_6.Subject
+	(*Date)(nil),                  // 16: spoke.datacite.v4_6.Date
+	(*AlternateIdentifier)(nil),   // 17: spoke.datacite.v4_6.AlternateIdentifier
+	(*RelatedIdentifier)(nil),     // 18: spoke.datacite.v4_6.RelatedIdentifier
+	(*Rights)(nil),                // 19: spoke.datacite.v4_6.Rights
+	(*Description)(nil),           // 20: spoke.datacite.v4_6.Description
+	(*GeoLocation)(nil),           // 21: spoke.datacite.v4_6.GeoLocation
+	(*GeoLocationPoint)(nil),      // 22: spoke.datacite.v4_6.GeoLocationPoint
+	(*GeoLocationBox)(nil),        // 23: spoke.datacite.v4_6.GeoLocationBox
+	(*GeoLocationPolygon)(nil),    // 24: spoke.datacite.v4_6.GeoLocationPolygon
+	(*FundingReference)(nil),      // 25: spoke.datacite.v4_6.FundingReference
+	(*RelatedItem)(nil),           // 26: spoke.datacite.v4_6.RelatedItem
+	(*RelatedItemIdentifier)(nil), // 27: spoke.datacite.v4_6.RelatedItemIdentifier
 }
 var file_spoke_datacite_v4_6_datacite_proto_depIdxs = []int32{
 	8,  // 0: spoke.datacite.v4_6.Resource.identifier:type_name -> spoke.datacite.v4_6.Identifier
@@ -3021,11 +3093,12 @@ var file_spoke_datacite_v4_6_datacite_proto_depIdxs = []int32{
 	13, // 33: spoke.datacite.v4_6.RelatedItem.titles:type_name -> spoke.datacite.v4_6.Title
 	9,  // 34: spoke.datacite.v4_6.RelatedItem.creators:type_name -> spoke.datacite.v4_6.Creator
 	10, // 35: spoke.datacite.v4_6.RelatedItem.contributors:type_name -> spoke.datacite.v4_6.Contributor
-	36, // [36:36] is the sub-list for method output_type
-	36, // [36:36] is the sub-list for method input_type
-	36, // [36:36] is the sub-list for extension type_name
-	36, // [36:36] is the sub-list for extension extendee
-	0,  // [0:36] is the sub-list for field type_name
+	27, // 36: spoke.datacite.v4_6.RelatedItem.related_item_identifier:type_name -> spoke.datacite.v4_6.RelatedItemIdentifier
+	37, // [37:37] is the sub-list for method output_type
+	37, // [37:37] is the sub-list for method input_type
+	37, // [37:37] is the sub-list for extension type_name
+	37, // [37:37] is the sub-list for extension extendee
+	0,  // [0:37] is the sub-list for field type_name
 }
 
 func init() { file_spoke_datacite_v4_6_datacite_proto_init() }
@@ -3039,7 +3112,7 @@ func file_spoke_datacite_v4_6_datacite_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_spoke_datacite_v4_6_datacite_proto_rawDesc), len(file_spoke_datacite_v4_6_datacite_proto_rawDesc)),
 			NumEnums:      7,
-			NumMessages:   20,
+			NumMessages:   21,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -4461,10 +4461,10 @@ const file_spoke_mods_v3_8_mods_proto_rawDesc = "" +
 	"Identifier\x1a\x1fMODS URL maps to Hub IdentifierR\x03url\"\x9d\x01\n" +
 	"\aHolding\x12}\n" +
 	"\x10copy_information\x18\x01 \x03(\v2 .spoke.mods.v3_8.CopyInformationB0\x8a\xb5\x18,\n" +
-	"\x05extra\xea\x03\x10Copy information\xb2\x04\x0fcopyInformationR\x0fcopyInformation:\x13\x8a\xb5\x18\x0fR\rholdingSimple\"\xe4\x03\n" +
-	"\x0fCopyInformation\x12;\n" +
-	"\vsublocation\x18\x01 \x01(\tB\x19\x8a\xb5\x18\x15\n" +
-	"\x05extra\xea\x03\vSublocationR\vsublocation\x12O\n" +
+	"\x05extra\xea\x03\x10Copy information\xb2\x04\x0fcopyInformationR\x0fcopyInformation:\x13\x8a\xb5\x18\x0fR\rholdingSimple\"\xf2\x03\n" +
+	"\x0fCopyInformation\x12I\n" +
+	"\vsublocation\x18\x01 \x01(\tB'\x8a\xb5\x18#\n" +
+	"\x05extra\xea\x03\vSublocation\xb2\x04\vsubLocationR\vsublocation\x12O\n" +
 	"\rshelf_locator\x18\x02 \x01(\tB*\x8a\xb5\x18&\n" +
 	"\x05extra\xea\x03\rShelf locator\xb2\x04\fshelfLocatorR\fshelfLocator\x12c\n" +
 	"\x12electronic_locator\x18\x03 \x01(\tB4\x8a\xb5\x180\n" +
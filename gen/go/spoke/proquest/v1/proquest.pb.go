@@ -9,7 +9,7 @@
 // 	protoc        (unknown)
 // source: spoke/proquest/v1/proquest.proto
 
-package proquestv1
+package v1
 
 import (
 	_ "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
@@ -564,19 +564,20 @@ func (x *PhoneFax) GetExt() string {
 }
 
 type Description struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	Title          string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
-	Degree         string                 `protobuf:"bytes,2,opt,name=degree,proto3" json:"degree,omitempty"`
-	DegreeLevel    string                 `protobuf:"bytes,3,opt,name=degree_level,json=degreeLevel,proto3" json:"degree_level,omitempty"`
-	Discipline     string                 `protobuf:"bytes,4,opt,name=discipline,proto3" json:"discipline,omitempty"`
-	Institution    *Institution           `protobuf:"bytes,5,opt,name=institution,proto3" json:"institution,omitempty"`
-	PageCount      int32                  `protobuf:"varint,6,opt,name=page_count,json=pageCount,proto3" json:"page_count,omitempty"`
-	Department     string                 `protobuf:"bytes,7,opt,name=department,proto3" json:"department,omitempty"` // lehigh_departments/lehigh_department
-	Advisors       []*Advisor             `protobuf:"bytes,8,rep,name=advisors,proto3" json:"advisors,omitempty"`
-	Categorization *Categorization        `protobuf:"bytes,9,opt,name=categorization,proto3" json:"categorization,omitempty"`
-	Dates          *Dates                 `protobuf:"bytes,10,opt,name=dates,proto3" json:"dates,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Title            string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Degree           string                 `protobuf:"bytes,2,opt,name=degree,proto3" json:"degree,omitempty"`
+	DegreeLevel      string                 `protobuf:"bytes,3,opt,name=degree_level,json=degreeLevel,proto3" json:"degree_level,omitempty"`
+	Discipline       string                 `protobuf:"bytes,4,opt,name=discipline,proto3" json:"discipline,omitempty"`
+	Institution      *Institution           `protobuf:"bytes,5,opt,name=institution,proto3" json:"institution,omitempty"`
+	PageCount        int32                  `protobuf:"varint,6,opt,name=page_count,json=pageCount,proto3" json:"page_count,omitempty"`
+	Department       string                 `protobuf:"bytes,7,opt,name=department,proto3" json:"department,omitempty"` // lehigh_departments/lehigh_department
+	Advisors         []*Advisor             `protobuf:"bytes,8,rep,name=advisors,proto3" json:"advisors,omitempty"`
+	Categorization   *Categorization        `protobuf:"bytes,9,opt,name=categorization,proto3" json:"categorization,omitempty"`
+	Dates            *Dates                 `protobuf:"bytes,10,opt,name=dates,proto3" json:"dates,omitempty"`
+	CommitteeMembers []*CommitteeMember     `protobuf:"bytes,11,rep,name=committee_members,json=committeeMembers,proto3" json:"committee_members,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *Description) Reset() {
@@ -679,6 +680,13 @@ func (x *Description) GetDates() *Dates {
 	return nil
 }
 
+func (x *Description) GetCommitteeMembers() []*CommitteeMember {
+	if x != nil {
+		return x.CommitteeMembers
+	}
+	return nil
+}
+
 type Institution struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -775,6 +783,50 @@ func (x *Advisor) GetName() *Name {
 	return nil
 }
 
+type CommitteeMember struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          *Name                  `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommitteeMember) Reset() {
+	*x = CommitteeMember{}
+	mi := &file_spoke_proquest_v1_proquest_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommitteeMember) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitteeMember) ProtoMessage() {}
+
+func (x *CommitteeMember) ProtoReflect() protoreflect.Message {
+	mi := &file_spoke_proquest_v1_proquest_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommitteeMember.ProtoReflect.Descriptor instead.
+func (*CommitteeMember) Descriptor() ([]byte, []int) {
+	return file_spoke_proquest_v1_proquest_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CommitteeMember) GetName() *Name {
+	if x != nil {
+		return x.Name
+	}
+	return nil
+}
+
 type Categorization struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Categories    []*Category            `protobuf:"bytes,1,rep,name=categories,proto3" json:"categories,omitempty"`
@@ -786,7 +838,7 @@ type Categorization struct {
 
 func (x *Categorization) Reset() {
 	*x = Categorization{}
-	mi := &file_spoke_proquest_v1_proquest_proto_msgTypes[11]
+	mi := &file_spoke_proquest_v1_proquest_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -798,7 +850,7 @@ func (x *Categorization) String() string {
 func (*Categorization) ProtoMessage() {}
 
 func (x *Categorization) ProtoReflect() protoreflect.Message {
-	mi := &file_spoke_proquest_v1_proquest_proto_msgTypes[11]
+	mi := &file_spoke_proquest_v1_proquest_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -811,7 +863,7 @@ func (x *Categorization) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Categorization.ProtoReflect.Descriptor instead.
 func (*Categorization) Descriptor() ([]byte, []int) {
-	return file_spoke_proquest_v1_proquest_proto_rawDescGZIP(), []int{11}
+	return file_spoke_proquest_v1_proquest_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *Categorization) GetCategories() []*Category {
@@ -844,7 +896,7 @@ type Category struct {
 
 func (x *Category) Reset() {
 	*x = Category{}
-	mi := &file_spoke_proquest_v1_proquest_proto_msgTypes[12]
+	mi := &file_spoke_proquest_v1_proquest_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -856,7 +908,7 @@ func (x *Category) String() string {
 func (*Category) ProtoMessage() {}
 
 func (x *Category) ProtoReflect() protoreflect.Message {
-	mi := &file_spoke_proquest_v1_proquest_proto_msgTypes[12]
+	mi := &file_spoke_proquest_v1_proquest_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -869,7 +921,7 @@ func (x *Category) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Category.ProtoReflect.Descriptor instead.
 func (*Category) Descriptor() ([]byte, []int) {
-	return file_spoke_proquest_v1_proquest_proto_rawDescGZIP(), []int{12}
+	return file_spoke_proquest_v1_proquest_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *Category) GetDescription() string {
@@ -889,7 +941,7 @@ type Dates struct {
 
 func (x *Dates) Reset() {
 	*x = Dates{}
-	mi := &file_spoke_proquest_v1_proquest_proto_msgTypes[13]
+	mi := &file_spoke_proquest_v1_proquest_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -901,7 +953,7 @@ func (x *Dates) String() string {
 func (*Dates) ProtoMessage() {}
 
 func (x *Dates) ProtoReflect() protoreflect.Message {
-	mi := &file_spoke_proquest_v1_proquest_proto_msgTypes[13]
+	mi := &file_spoke_proquest_v1_proquest_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -914,7 +966,7 @@ func (x *Dates) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Dates.ProtoReflect.Descriptor instead.
 func (*Dates) Descriptor() ([]byte, []int) {
-	return file_spoke_proquest_v1_proquest_proto_rawDescGZIP(), []int{13}
+	return file_spoke_proquest_v1_proquest_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *Dates) GetAcceptDate() string {
@@ -941,7 +993,7 @@ type Content struct {
 
 func (x *Content) Reset() {
 	*x = Content{}
-	mi := &file_spoke_proquest_v1_proquest_proto_msgTypes[14]
+	mi := &file_spoke_proquest_v1_proquest_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -953,7 +1005,7 @@ func (x *Content) String() string {
 func (*Content) ProtoMessage() {}
 
 func (x *Content) ProtoReflect() protoreflect.Message {
-	mi := &file_spoke_proquest_v1_proquest_proto_msgTypes[14]
+	mi := &file_spoke_proquest_v1_proquest_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -966,7 +1018,7 @@ func (x *Content) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Content.ProtoReflect.Descriptor instead.
 func (*Content) Descriptor() ([]byte, []int) {
-	return file_spoke_proquest_v1_proquest_proto_rawDescGZIP(), []int{14}
+	return file_spoke_proquest_v1_proquest_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *Content) GetAbstract() *Abstract {
@@ -992,7 +1044,7 @@ type Abstract struct {
 
 func (x *Abstract) Reset() {
 	*x = Abstract{}
-	mi := &file_spoke_proquest_v1_proquest_proto_msgTypes[15]
+	mi := &file_spoke_proquest_v1_proquest_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1004,7 +1056,7 @@ func (x *Abstract) String() string {
 func (*Abstract) ProtoMessage() {}
 
 func (x *Abstract) ProtoReflect() protoreflect.Message {
-	mi := &file_spoke_proquest_v1_proquest_proto_msgTypes[15]
+	mi := &file_spoke_proquest_v1_proquest_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1017,7 +1069,7 @@ func (x *Abstract) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Abstract.ProtoReflect.Descriptor instead.
 func (*Abstract) Descriptor() ([]byte, []int) {
-	return file_spoke_proquest_v1_proquest_proto_rawDescGZIP(), []int{15}
+	return file_spoke_proquest_v1_proquest_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *Abstract) GetParagraphs() []string {
@@ -1037,7 +1089,7 @@ type Binary struct {
 
 func (x *Binary) Reset() {
 	*x = Binary{}
-	mi := &file_spoke_proquest_v1_proquest_proto_msgTypes[16]
+	mi := &file_spoke_proquest_v1_proquest_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1049,7 +1101,7 @@ func (x *Binary) String() string {
 func (*Binary) ProtoMessage() {}
 
 func (x *Binary) ProtoReflect() protoreflect.Message {
-	mi := &file_spoke_proquest_v1_proquest_proto_msgTypes[16]
+	mi := &file_spoke_proquest_v1_proquest_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1062,7 +1114,7 @@ func (x *Binary) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Binary.ProtoReflect.Descriptor instead.
 func (*Binary) Descriptor() ([]byte, []int) {
-	return file_spoke_proquest_v1_proquest_proto_rawDescGZIP(), []int{16}
+	return file_spoke_proquest_v1_proquest_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *Binary) GetType() string {
@@ -1145,7 +1197,7 @@ const file_spoke_proquest_v1_proquest_proto_rawDesc = "" +
 	"\acountry\x18\x02 \x01(\tB\x14\x8a\xb5\x18\x10\xb2\x04\rDISS_cntry_cdR\acountry\x122\n" +
 	"\tarea_code\x18\x03 \x01(\tB\x15\x8a\xb5\x18\x11\xb2\x04\x0eDISS_area_codeR\bareaCode\x12-\n" +
 	"\x06number\x18\x04 \x01(\tB\x15\x8a\xb5\x18\x11\xb2\x04\x0eDISS_phone_numR\x06number\x12'\n" +
-	"\x03ext\x18\x05 \x01(\tB\x15\x8a\xb5\x18\x11\xb2\x04\x0eDISS_phone_extR\x03ext:\x14\x8a\xb5\x18\x10R\x0eDISS_phone_fax\"\xa6\x06\n" +
+	"\x03ext\x18\x05 \x01(\tB\x15\x8a\xb5\x18\x11\xb2\x04\x0eDISS_phone_extR\x03ext:\x14\x8a\xb5\x18\x10R\x0eDISS_phone_fax\"\xb9\a\n" +
 	"\vDescription\x12.\n" +
 	"\x05title\x18\x01 \x01(\tB\x18\x8a\xb5\x18\x14\n" +
 	"\x05title\xb2\x04\n" +
@@ -1174,7 +1226,9 @@ const file_spoke_proquest_v1_proquest_proto_rawDesc = "" +
 	"\x0ecategorization\x18\t \x01(\v2!.spoke.proquest.v1.CategorizationB\x1a\x8a\xb5\x18\x16\xb2\x04\x13DISS_categorizationR\x0ecategorization\x12A\n" +
 	"\x05dates\x18\n" +
 	" \x01(\v2\x18.spoke.proquest.v1.DatesB\x11\x8a\xb5\x18\r\xb2\x04\n" +
-	"DISS_datesR\x05dates:\x16\x8a\xb5\x18\x12R\x10DISS_description\"\xaa\x01\n" +
+	"DISS_datesR\x05dates\x12\x90\x01\n" +
+	"\x11committee_members\x18\v \x03(\v2\".spoke.proquest.v1.CommitteeMemberB?\x8a\xb5\x18;\n" +
+	"\fcontributorsj\x10committee memberr\x06person\xb2\x04\x10DISS_cmte_memberR\x10committeeMembers:\x16\x8a\xb5\x18\x12R\x10DISS_description\"\xaa\x01\n" +
 	"\vInstitution\x12B\n" +
 	"\x04name\x18\x01 \x01(\tB.\x8a\xb5\x18*\n" +
 	"\x17degree_info.institution\xb2\x04\x0eDISS_inst_nameR\x04name\x12?\n" +
@@ -1183,7 +1237,9 @@ const file_spoke_proquest_v1_proquest_proto_rawDesc = "" +
 	"\x05extra\xb2\x04\x11DISS_inst_contactR\n" +
 	"department:\x16\x8a\xb5\x18\x12R\x10DISS_institution\"\\\n" +
 	"\aAdvisor\x12=\n" +
-	"\x04name\x18\x01 \x01(\v2\x17.spoke.proquest.v1.NameB\x10\x8a\xb5\x18\f\xb2\x04\tDISS_nameR\x04name:\x12\x8a\xb5\x18\x0eR\fDISS_advisor\"\xff\x01\n" +
+	"\x04name\x18\x01 \x01(\v2\x17.spoke.proquest.v1.NameB\x10\x8a\xb5\x18\f\xb2\x04\tDISS_nameR\x04name:\x12\x8a\xb5\x18\x0eR\fDISS_advisor\"h\n" +
+	"\x0fCommitteeMember\x12=\n" +
+	"\x04name\x18\x01 \x01(\v2\x17.spoke.proquest.v1.NameB\x10\x8a\xb5\x18\f\xb2\x04\tDISS_nameR\x04name:\x16\x8a\xb5\x18\x12R\x10DISS_cmte_member\"\xff\x01\n" +
 	"\x0eCategorization\x12Q\n" +
 	"\n" +
 	"categories\x18\x01 \x03(\v2\x1b.spoke.proquest.v1.CategoryB\x14\x8a\xb5\x18\x10\xb2\x04\rDISS_categoryR\n" +
@@ -1214,8 +1270,8 @@ const file_spoke_proquest_v1_proquest_proto_rawDesc = "" +
 	"\x04type\x18\x01 \x01(\tB\a\x8a\xb5\x18\x03\xc0\x04\x01R\x04type\x12+\n" +
 	"\tfile_name\x18\x02 \x01(\tB\x0e\x8a\xb5\x18\n" +
 	"\n" +
-	"\x05files\xc8\x04\x01R\bfileName:\x11\x8a\xb5\x18\rR\vDISS_binaryB\xe2\x01\n" +
-	"\x15com.spoke.proquest.v1B\rProquestProtoP\x01ZTgithub.com/lehigh-university-libraries/crosswalk/gen/go/spoke/proquest/v1;proquestv1\xa2\x02\x03SPX\xaa\x02\x11Spoke.Proquest.V1\xca\x02\x11Spoke\\Proquest\\V1\xe2\x02\x1dSpoke\\Proquest\\V1\\GPBMetadata\xea\x02\x13Spoke::Proquest::V1b\x06proto3"
+	"\x05files\xc8\x04\x01R\bfileName:\x11\x8a\xb5\x18\rR\vDISS_binaryB\xd7\x01\n" +
+	"\x15com.spoke.proquest.v1B\rProquestProtoP\x01ZIgithub.com/lehigh-university-libraries/crosswalk/gen/go/spoke/proquest/v1\xa2\x02\x03SPV\xaa\x02\x11Spoke.Proquest.V1\xca\x02\x11Spoke\\Proquest\\V1\xe2\x02\x1dSpoke\\Proquest\\V1\\GPBMetadata\xea\x02\x13Spoke::Proquest::V1b\x06proto3"
 
 var (
 	file_spoke_proquest_v1_proquest_proto_rawDescOnce sync.Once
@@ -1229,31 +1285,32 @@ func file_spoke_proquest_v1_proquest_proto_rawDescGZIP() []byte {
 	return file_spoke_proquest_v1_proquest_proto_rawDescData
 }
 
-var file_spoke_proquest_v1_proquest_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_spoke_proquest_v1_proquest_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
 var file_spoke_proquest_v1_proquest_proto_goTypes = []any{
-	(*Submission)(nil),     // 0: spoke.proquest.v1.Submission
-	(*Repository)(nil),     // 1: spoke.proquest.v1.Repository
-	(*Authorship)(nil),     // 2: spoke.proquest.v1.Authorship
-	(*Author)(nil),         // 3: spoke.proquest.v1.Author
-	(*Name)(nil),           // 4: spoke.proquest.v1.Name
-	(*Contact)(nil),        // 5: spoke.proquest.v1.Contact
-	(*Address)(nil),        // 6: spoke.proquest.v1.Address
-	(*PhoneFax)(nil),       // 7: spoke.proquest.v1.PhoneFax
-	(*Description)(nil),    // 8: spoke.proquest.v1.Description
-	(*Institution)(nil),    // 9: spoke.proquest.v1.Institution
-	(*Advisor)(nil),        // 10: spoke.proquest.v1.Advisor
-	(*Categorization)(nil), // 11: spoke.proquest.v1.Categorization
-	(*Category)(nil),       // 12: spoke.proquest.v1.Category
-	(*Dates)(nil),          // 13: spoke.proquest.v1.Dates
-	(*Content)(nil),        // 14: spoke.proquest.v1.Content
-	(*Abstract)(nil),       // 15: spoke.proquest.v1.Abstract
-	(*Binary)(nil),         // 16: spoke.proquest.v1.Binary
+	(*Submission)(nil),      // 0: spoke.proquest.v1.Submission
+	(*Repository)(nil),      // 1: spoke.proquest.v1.Repository
+	(*Authorship)(nil),      // 2: spoke.proquest.v1.Authorship
+	(*Author)(nil),          // 3: spoke.proquest.v1.Author
+	(*Name)(nil),            // 4: spoke.proquest.v1.Name
+	(*Contact)(nil),         // 5: spoke.proquest.v1.Contact
+	(*Address)(nil),         // 6: spoke.proquest.v1.Address
+	(*PhoneFax)(nil),        // 7: spoke.proquest.v1.PhoneFax
+	(*Description)(nil),     // 8: spoke.proquest.v1.Description
+	(*Institution)(nil),     // 9: spoke.proquest.v1.Institution
+	(*Advisor)(nil),         // 10: spoke.proquest.v1.Advisor
+	(*CommitteeMember)(nil), // 11: spoke.proquest.v1.CommitteeMember
+	(*Categorization)(nil),  // 12: spoke.proquest.v1.Categorization
+	(*Category)(nil),        // 13: spoke.proquest.v1.Category
+	(*Dates)(nil),           // 14: spoke.proquest.v1.Dates
+	(*Content)(nil),         // 15: spoke.proquest.v1.Content
+	(*Abstract)(nil),        // 16: spoke.proquest.v1.Abstract
+	(*Binary)(nil),          // 17: spoke.proquest.v1.Binary
 }
 var file_spoke_proquest_v1_proquest_proto_depIdxs = []int32{
 	2,  // 0: spoke.proquest.v1.Submission.authorship:type_name -> spoke.proquest.v1.Authorship
 	8,  // 1: spoke.proquest.v1.Submission.description:type_name -> spoke.proquest.v1.Description
 	1,  // 2: spoke.proquest.v1.Submission.repository:type_name -> spoke.proquest.v1.Repository
-	14, // 3: spoke.proquest.v1.Submission.content:type_name -> spoke.proquest.v1.Content
+	15, // 3: spoke.proquest.v1.Submission.content:type_name -> spoke.proquest.v1.Content
 	3,  // 4: spoke.proquest.v1.Authorship.authors:type_name -> spoke.proquest.v1.Author
 	4,  // 5: spoke.proquest.v1.Author.name:type_name -> spoke.proquest.v1.Name
 	5,  // 6: spoke.proquest.v1.Author.contacts:type_name -> spoke.proquest.v1.Contact
@@ -1261,17 +1318,19 @@ var file_spoke_proquest_v1_proquest_proto_depIdxs = []int32{
 	7,  // 8: spoke.proquest.v1.Contact.phone:type_name -> spoke.proquest.v1.PhoneFax
 	9,  // 9: spoke.proquest.v1.Description.institution:type_name -> spoke.proquest.v1.Institution
 	10, // 10: spoke.proquest.v1.Description.advisors:type_name -> spoke.proquest.v1.Advisor
-	11, // 11: spoke.proquest.v1.Description.categorization:type_name -> spoke.proquest.v1.Categorization
-	13, // 12: spoke.proquest.v1.Description.dates:type_name -> spoke.proquest.v1.Dates
-	4,  // 13: spoke.proquest.v1.Advisor.name:type_name -> spoke.proquest.v1.Name
-	12, // 14: spoke.proquest.v1.Categorization.categories:type_name -> spoke.proquest.v1.Category
-	15, // 15: spoke.proquest.v1.Content.abstract:type_name -> spoke.proquest.v1.Abstract
-	16, // 16: spoke.proquest.v1.Content.binary:type_name -> spoke.proquest.v1.Binary
-	17, // [17:17] is the sub-list for method output_type
-	17, // [17:17] is the sub-list for method input_type
-	17, // [17:17] is the sub-list for extension type_name
-	17, // [17:17] is the sub-list for extension extendee
-	0,  // [0:17] is the sub-list for field type_name
+	12, // 11: spoke.proquest.v1.Description.categorization:type_name -> spoke.proquest.v1.Categorization
+	14, // 12: spoke.proquest.v1.Description.dates:type_name -> spoke.proquest.v1.Dates
+	11, // 13: spoke.proquest.v1.Description.committee_members:type_name -> spoke.proquest.v1.CommitteeMember
+	4,  // 14: spoke.proquest.v1.Advisor.name:type_name -> spoke.proquest.v1.Name
+	4,  // 15: spoke.proquest.v1.CommitteeMember.name:type_name -> spoke.proquest.v1.Name
+	13, // 16: spoke.proquest.v1.Categorization.categories:type_name -> spoke.proquest.v1.Category
+	16, // 17: spoke.proquest.v1.Content.abstract:type_name -> spoke.proquest.v1.Abstract
+	17, // 18: spoke.proquest.v1.Content.binary:type_name -> spoke.proquest.v1.Binary
+	19, // [19:19] is the sub-list for method output_type
+	19, // [19:19] is the sub-list for method input_type
+	19, // [19:19] is the sub-list for extension type_name
+	19, // [19:19] is the sub-list for extension extendee
+	0,  // [0:19] is the sub-list for field type_name
 }
 
 func init() { file_spoke_proquest_v1_proquest_proto_init() }
@@ -1285,7 +1344,7 @@ func file_spoke_proquest_v1_proquest_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_spoke_proquest_v1_proquest_proto_rawDesc), len(file_spoke_proquest_v1_proquest_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   17,
+			NumMessages:   18,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
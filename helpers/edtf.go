@@ -15,18 +15,19 @@ import (
 type DateValue = hubv1.DateValue
 
 // EDTFParser parses Extended Date/Time Format strings.
-// Supports a practical subset of EDTF Level 0 and Level 1.
+// Supports EDTF Level 0 and Level 1 and the practical parts of Level 2:
+// intervals with open ("..") or unknown (empty) ends, sets ("[...]" and
+// "{...}"), seasons and sub-year groupings (codes 21-41), and
+// qualification of the whole date or of its components ("1984?", "~1950",
+// "2004-?06-11"). Unspecified digits are supported for decades ("197X")
+// and centuries ("19XX"). Strings that are not EDTF keep their raw value
+// with no precision.
 type EDTFParser struct{}
 
 var (
-	// Year only: 1978
-	yearOnlyRegex = regexp.MustCompile(`^(\d{4})([~?%])?$`)
-
-	// Year-month: 1978-03
-	yearMonthRegex = regexp.MustCompile(`^(\d{4})-(\d{2})([~?%])?$`)
-
-	// Full date: 1978-03-15
-	fullDateRegex = regexp.MustCompile(`^(\d{4})-(\d{2})-(\d{2})([~?%])?$`)
+	// Date at year, month (or season), or day precision: 1978, 1978-03,
+	// 1978-03-15, 2001-21
+	dateRegex = regexp.MustCompile(`^(\d{4})(?:-(\d{2})(?:-(\d{2}))?)?$`)
 
 	// Decade: 197X or 1970s
 	decadeRegex = regexp.MustCompile(`^(\d{3})[Xx]$|^(\d{4})s$`)
@@ -34,9 +35,6 @@ var (
 	// Century: 19XX or "19th century"
 	centuryRegex = regexp.MustCompile(`^(\d{2})[Xx]{2}$`)
 
-	// Interval/range: 1978/1980 or 1978-03/1980-05
-	intervalRegex = regexp.MustCompile(`^(.+)/(.+)$`)
-
 	// ISO timestamp: 2024-12-13T22:43:14+00:00
 	timestampRegex = regexp.MustCompile(`^(\d{4})-(\d{2})-(\d{2})T(\d{2}):(\d{2}):(\d{2})`)
 )
@@ -80,107 +78,264 @@ func (p *EDTFParser) Parse(input string, dateType hubv1.DateType) (*hubv1.DateVa
 		}
 	}
 
-	// Check for interval/range
-	if matches := intervalRegex.FindStringSubmatch(input); matches != nil {
-		start := strings.TrimSpace(matches[1])
-		end := strings.TrimSpace(matches[2])
-
-		// Parse start date
-		startDate, _ := p.Parse(start, dateType)
-		result.Year = startDate.Year
-		result.Month = startDate.Month
-		result.Day = startDate.Day
-		result.Precision = startDate.Precision
-		result.Qualifier = startDate.Qualifier
-
-		// Parse end date
-		endDate, _ := p.Parse(end, dateType)
-		result.EndYear = endDate.Year
-		result.EndMonth = endDate.Month
-		result.EndDay = endDate.Day
-		result.IsRange = true
-
-		return result, nil
+	switch {
+	case strings.HasPrefix(input, "[") && strings.HasSuffix(input, "]"):
+		if parseSet(result, input[1:len(input)-1], hubv1.DateSetType_DATE_SET_TYPE_ONE_OF) {
+			return result, nil
+		}
+	case strings.HasPrefix(input, "{") && strings.HasSuffix(input, "}"):
+		if parseSet(result, input[1:len(input)-1], hubv1.DateSetType_DATE_SET_TYPE_ALL_OF) {
+			return result, nil
+		}
+	case strings.Contains(input, "/"):
+		// Interval: 1978/1980, 1978-03/1980-05, 1990/.., /1990
+		start, end, _ := strings.Cut(input, "/")
+		if parseRange(result, start, end, "..") {
+			return result, nil
+		}
+	default:
+		if parsePoint(result, input) {
+			return result, nil
+		}
 	}
 
-	// Try full date
-	if matches := fullDateRegex.FindStringSubmatch(input); matches != nil {
-		year, _ := strconv.Atoi(matches[1])
-		month, _ := strconv.Atoi(matches[2])
-		day, _ := strconv.Atoi(matches[3])
+	// Try plain integer year
+	if year, err := strconv.Atoi(input); err == nil && year > 0 && year < 3000 {
 		result.Year = int32(year)
-		result.Month = int32(month)
-		result.Day = int32(day)
-		result.Precision = hubv1.DatePrecision_DATE_PRECISION_DAY
-		result.Qualifier = parseQualifier(matches[4])
+		result.Precision = hubv1.DatePrecision_DATE_PRECISION_YEAR
 		return result, nil
 	}
 
-	// Try year-month
-	if matches := yearMonthRegex.FindStringSubmatch(input); matches != nil {
-		year, _ := strconv.Atoi(matches[1])
-		month, _ := strconv.Atoi(matches[2])
-		result.Year = int32(year)
-		result.Month = int32(month)
-		result.Precision = hubv1.DatePrecision_DATE_PRECISION_MONTH
-		result.Qualifier = parseQualifier(matches[3])
-		return result, nil
+	// Return raw value if we can't parse it
+	result.Precision = hubv1.DatePrecision_DATE_PRECISION_UNSPECIFIED
+	return result, nil
+}
+
+// point is a single parsed date.
+type point struct {
+	year, month, day int32
+	precision        hubv1.DatePrecision
+	qualifier        hubv1.DateQualifier
+	season           string
+}
+
+// parsePoint parses a single date into result, reporting whether it is
+// one.
+func parsePoint(result *hubv1.DateValue, s string) bool {
+	pt, ok := readPoint(s)
+	if !ok {
+		return false
 	}
+	result.Year, result.Month, result.Day = pt.year, pt.month, pt.day
+	result.Precision = pt.precision
+	result.Qualifier = pt.qualifier
+	result.Season = pt.season
+	return true
+}
 
-	// Try year only
-	if matches := yearOnlyRegex.FindStringSubmatch(input); matches != nil {
+// readPoint reads a single date. Qualifiers may follow the date or
+// precede or follow any of its components; they qualify the whole date.
+func readPoint(s string) (point, bool) {
+	var pt point
+	var approximate, uncertain bool
+	s = strings.Map(func(r rune) rune {
+		switch r {
+		case '~':
+			approximate = true
+		case '?':
+			uncertain = true
+		case '%':
+			approximate, uncertain = true, true
+		default:
+			return r
+		}
+		return -1
+	}, strings.TrimSpace(s))
+	switch {
+	case approximate && uncertain:
+		pt.qualifier = hubv1.DateQualifier_DATE_QUALIFIER_BOTH
+	case approximate:
+		pt.qualifier = hubv1.DateQualifier_DATE_QUALIFIER_APPROXIMATE
+	case uncertain:
+		pt.qualifier = hubv1.DateQualifier_DATE_QUALIFIER_UNCERTAIN
+	}
+
+	if matches := dateRegex.FindStringSubmatch(s); matches != nil {
 		year, _ := strconv.Atoi(matches[1])
-		result.Year = int32(year)
-		result.Precision = hubv1.DatePrecision_DATE_PRECISION_YEAR
-		result.Qualifier = parseQualifier(matches[2])
-		return result, nil
+		month, _ := strconv.Atoi(matches[2])
+		day, _ := strconv.Atoi(matches[3])
+		pt.year = int32(year)
+		switch {
+		case matches[2] == "":
+			pt.precision = hubv1.DatePrecision_DATE_PRECISION_YEAR
+		case matches[3] == "" && hub.SeasonName(month) != "":
+			pt.season = hub.SeasonName(month)
+			pt.precision = hubv1.DatePrecision_DATE_PRECISION_SEASON
+		case month < 1 || month > 12:
+			return pt, false
+		case matches[3] == "":
+			pt.month = int32(month)
+			pt.precision = hubv1.DatePrecision_DATE_PRECISION_MONTH
+		case day < 1 || day > 31:
+			return pt, false
+		default:
+			pt.month, pt.day = int32(month), int32(day)
+			pt.precision = hubv1.DatePrecision_DATE_PRECISION_DAY
+		}
+		return pt, true
 	}
 
-	// Try decade
-	if matches := decadeRegex.FindStringSubmatch(input); matches != nil {
-		var decadeStr string
-		if matches[1] != "" {
-			decadeStr = matches[1]
-		} else {
+	if matches := decadeRegex.FindStringSubmatch(s); matches != nil {
+		decadeStr := matches[1]
+		if decadeStr == "" {
 			decadeStr = matches[2][:3]
 		}
 		decade, _ := strconv.Atoi(decadeStr)
-		result.Year = int32(decade * 10)
-		result.Precision = hubv1.DatePrecision_DATE_PRECISION_DECADE
-		return result, nil
+		pt.year = int32(decade * 10)
+		pt.precision = hubv1.DatePrecision_DATE_PRECISION_DECADE
+		return pt, true
 	}
 
-	// Try century
-	if matches := centuryRegex.FindStringSubmatch(input); matches != nil {
+	if matches := centuryRegex.FindStringSubmatch(s); matches != nil {
 		century, _ := strconv.Atoi(matches[1])
-		result.Year = int32(century * 100)
-		result.Precision = hubv1.DatePrecision_DATE_PRECISION_CENTURY
-		return result, nil
+		pt.year = int32(century * 100)
+		pt.precision = hubv1.DatePrecision_DATE_PRECISION_CENTURY
+		return pt, true
 	}
 
-	// Try plain integer year
-	if year, err := strconv.Atoi(input); err == nil && year > 0 && year < 3000 {
-		result.Year = int32(year)
-		result.Precision = hubv1.DatePrecision_DATE_PRECISION_YEAR
-		return result, nil
+	return pt, false
+}
+
+// parseRange parses the ends of an interval, or of a range in a set, into
+// result. An end equal to open ("..", or "" in a set) is open, and any
+// other empty end unknown; a range with neither end a date is not one.
+func parseRange(result *hubv1.DateValue, start, end, open string) bool {
+	startPt, startBound, ok := readEnd(start, open)
+	if !ok {
+		return false
+	}
+	endPt, endBound, ok := readEnd(end, open)
+	if !ok || startBound != hubv1.DateBound_DATE_BOUND_UNSPECIFIED && endBound != hubv1.DateBound_DATE_BOUND_UNSPECIFIED {
+		return false
+	}
+	if startBound == hubv1.DateBound_DATE_BOUND_UNSPECIFIED && endBound == hubv1.DateBound_DATE_BOUND_UNSPECIFIED && inverted(startPt, endPt) {
+		return false
 	}
 
-	// Return raw value if we can't parse it
-	result.Precision = hubv1.DatePrecision_DATE_PRECISION_UNSPECIFIED
-	return result, nil
+	result.IsRange = true
+	result.StartBound, result.EndBound = startBound, endBound
+	result.Year, result.Month, result.Day = startPt.year, startPt.month, startPt.day
+	result.Precision = startPt.precision
+	result.Qualifier = startPt.qualifier
+	result.EndYear, result.EndMonth, result.EndDay = endPt.year, endPt.month, endPt.day
+	result.EndPrecision = endPt.precision
+	result.EndQualifier = endPt.qualifier
+	result.Season = rangeSeason(startPt.season, endPt.season)
+	return true
 }
 
-func parseQualifier(s string) hubv1.DateQualifier {
-	switch s {
-	case "~":
-		return hubv1.DateQualifier_DATE_QUALIFIER_APPROXIMATE
-	case "?":
-		return hubv1.DateQualifier_DATE_QUALIFIER_UNCERTAIN
-	case "%":
-		return hubv1.DateQualifier_DATE_QUALIFIER_BOTH
-	default:
-		return hubv1.DateQualifier_DATE_QUALIFIER_UNSPECIFIED
+// inverted reports whether a range ends before it starts. The start is
+// compared only as precisely as the end, so "2004-06/2004" is a range.
+func inverted(start, end point) bool {
+	month, day := start.month, start.day
+	if end.month == 0 {
+		month, day = 0, 0
+	} else if end.day == 0 {
+		day = 0
+	}
+	return dateKey(start.year, month, day) > dateKey(end.year, end.month, end.day)
+}
+
+// readEnd reads one end of a range: a date, or an open or unknown bound.
+func readEnd(s, open string) (point, hubv1.DateBound, bool) {
+	switch s = strings.TrimSpace(s); s {
+	case open:
+		return point{}, hubv1.DateBound_DATE_BOUND_OPEN, true
+	case "":
+		return point{}, hubv1.DateBound_DATE_BOUND_UNKNOWN, true
+	}
+	pt, ok := readPoint(s)
+	return pt, hubv1.DateBound_DATE_BOUND_UNSPECIFIED, ok
+}
+
+// parseSet parses the comma-separated members of a set into result,
+// which spans them: from the earliest start to the latest end. Members
+// are dates or ranges ("1667..1672"), and the earliest and latest may be
+// open ("..1760", "1760-12..").
+func parseSet(result *hubv1.DateValue, list string, setType hubv1.DateSetType) bool {
+	var members []*hubv1.DateValue
+	for _, item := range strings.Split(list, ",") {
+		m := &hubv1.DateValue{Type: result.Type}
+		if start, end, ok := strings.Cut(item, ".."); ok {
+			if !parseRange(m, start, end, "") {
+				return false
+			}
+		} else if !parsePoint(m, item) {
+			return false
+		}
+		members = append(members, m)
+	}
+
+	first, last := members[0], members[0]
+	for _, m := range members[1:] {
+		if first.StartBound == hubv1.DateBound_DATE_BOUND_UNSPECIFIED &&
+			(m.StartBound != hubv1.DateBound_DATE_BOUND_UNSPECIFIED || dateKey(m.Year, m.Month, m.Day) < dateKey(first.Year, first.Month, first.Day)) {
+			first = m
+		}
+		if last.EndBound == hubv1.DateBound_DATE_BOUND_UNSPECIFIED &&
+			(m.EndBound != hubv1.DateBound_DATE_BOUND_UNSPECIFIED || endKey(m) > endKey(last)) {
+			last = m
+		}
+	}
+
+	result.SetType = setType
+	result.SetMembers = members
+	result.StartBound = first.StartBound
+	result.Year, result.Month, result.Day = first.Year, first.Month, first.Day
+	result.Precision = first.Precision
+	result.Qualifier = first.Qualifier
+	result.Season, _, _ = strings.Cut(first.Season, "/")
+	if last == first && !last.IsRange {
+		return true
+	}
+
+	result.IsRange = true
+	result.EndBound = last.EndBound
+	endSeason := last.Season
+	if last.IsRange {
+		result.EndYear, result.EndMonth, result.EndDay = last.EndYear, last.EndMonth, last.EndDay
+		result.EndPrecision = last.EndPrecision
+		result.EndQualifier = last.EndQualifier
+		_, endSeason, _ = strings.Cut(last.Season, "/")
+	} else {
+		result.EndYear, result.EndMonth, result.EndDay = last.Year, last.Month, last.Day
+		result.EndPrecision = last.Precision
+		result.EndQualifier = last.Qualifier
+	}
+	result.Season = rangeSeason(result.Season, endSeason)
+	return true
+}
+
+// rangeSeason gives the season of a range: its start's season, and its
+// end's after a slash.
+func rangeSeason(start, end string) string {
+	if end == "" {
+		return start
+	}
+	return start + "/" + end
+}
+
+// dateKey orders dates; unspecified months and days sort first.
+func dateKey(year, month, day int32) int64 {
+	return int64(year)*10000 + int64(month)*100 + int64(day)
+}
+
+// endKey orders set members by their last date.
+func endKey(m *hubv1.DateValue) int64 {
+	if m.IsRange {
+		return dateKey(m.EndYear, m.EndMonth, m.EndDay)
 	}
+	return dateKey(m.Year, m.Month, m.Day)
 }
 
 // ParseEDTF is a convenience function to parse an EDTF string.
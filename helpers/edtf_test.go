@@ -0,0 +1,139 @@
+package helpers
+
+import (
+	"testing"
+
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+const (
+	approximate = hubv1.DateQualifier_DATE_QUALIFIER_APPROXIMATE
+	uncertain   = hubv1.DateQualifier_DATE_QUALIFIER_UNCERTAIN
+	both        = hubv1.DateQualifier_DATE_QUALIFIER_BOTH
+	open        = hubv1.DateBound_DATE_BOUND_OPEN
+	unknown     = hubv1.DateBound_DATE_BOUND_UNKNOWN
+)
+
+func TestParseEDTF(t *testing.T) {
+	tests := []struct {
+		input                string
+		year, month, day     int32
+		precision            hubv1.DatePrecision
+		qualifier            hubv1.DateQualifier
+		endYear, endMonth    int32
+		endPrecision         hubv1.DatePrecision
+		endQualifier         hubv1.DateQualifier
+		startBound, endBound hubv1.DateBound
+		season               string
+		isRange              bool
+		setType              hubv1.DateSetType
+		members              int
+		edtf                 string
+	}{
+		{input: "1978", year: 1978, precision: hubv1.DatePrecision_DATE_PRECISION_YEAR, edtf: "1978"},
+		{input: "1978-03-15", year: 1978, month: 3, day: 15, precision: hubv1.DatePrecision_DATE_PRECISION_DAY, edtf: "1978-03-15"},
+		{input: "197X", year: 1970, precision: hubv1.DatePrecision_DATE_PRECISION_DECADE, edtf: "197X"},
+		{input: "1984?", year: 1984, precision: hubv1.DatePrecision_DATE_PRECISION_YEAR, qualifier: uncertain, edtf: "1984?"},
+		{input: "~1950", year: 1950, precision: hubv1.DatePrecision_DATE_PRECISION_YEAR, qualifier: approximate, edtf: "1950~"},
+		{input: "2004-06-~11", year: 2004, month: 6, day: 11, precision: hubv1.DatePrecision_DATE_PRECISION_DAY, qualifier: approximate, edtf: "2004-06-11~"},
+		{input: "?2004-06~", year: 2004, month: 6, precision: hubv1.DatePrecision_DATE_PRECISION_MONTH, qualifier: both, edtf: "2004-06%"},
+		{input: "2001-21", year: 2001, precision: hubv1.DatePrecision_DATE_PRECISION_SEASON, season: "Spring", edtf: "2001-21"},
+		{input: "2001-34", year: 2001, precision: hubv1.DatePrecision_DATE_PRECISION_SEASON, season: "Quarter 2", edtf: "2001-34"},
+		{
+			input: "1990/2000", year: 1990, precision: hubv1.DatePrecision_DATE_PRECISION_YEAR,
+			endYear: 2000, endPrecision: hubv1.DatePrecision_DATE_PRECISION_YEAR, isRange: true, edtf: "1990/2000",
+		},
+		{
+			input: "1990~/2000-05", year: 1990, precision: hubv1.DatePrecision_DATE_PRECISION_YEAR, qualifier: approximate,
+			endYear: 2000, endMonth: 5, endPrecision: hubv1.DatePrecision_DATE_PRECISION_MONTH, isRange: true, edtf: "1990~/2000-05",
+		},
+		{
+			input: "1990/..", year: 1990, precision: hubv1.DatePrecision_DATE_PRECISION_YEAR,
+			endBound: open, isRange: true, edtf: "1990/..",
+		},
+		{
+			input: "../1990-04", endYear: 1990, endMonth: 4, endPrecision: hubv1.DatePrecision_DATE_PRECISION_MONTH,
+			startBound: open, isRange: true, edtf: "../1990-04",
+		},
+		{
+			input: "1990/", year: 1990, precision: hubv1.DatePrecision_DATE_PRECISION_YEAR,
+			endBound: unknown, isRange: true, edtf: "1990/",
+		},
+		{
+			input: "2001-21/2002-22", year: 2001, precision: hubv1.DatePrecision_DATE_PRECISION_SEASON,
+			endYear: 2002, endPrecision: hubv1.DatePrecision_DATE_PRECISION_SEASON, season: "Spring/Summer", isRange: true, edtf: "2001-21/2002-22",
+		},
+		{
+			input: "[1990,1995]", year: 1990, precision: hubv1.DatePrecision_DATE_PRECISION_YEAR,
+			endYear: 1995, endPrecision: hubv1.DatePrecision_DATE_PRECISION_YEAR, isRange: true,
+			setType: hubv1.DateSetType_DATE_SET_TYPE_ONE_OF, members: 2, edtf: "[1990,1995]",
+		},
+		{
+			input: "{1667,1668,1670..1672}", year: 1667, precision: hubv1.DatePrecision_DATE_PRECISION_YEAR,
+			endYear: 1672, endPrecision: hubv1.DatePrecision_DATE_PRECISION_YEAR, isRange: true,
+			setType: hubv1.DateSetType_DATE_SET_TYPE_ALL_OF, members: 3, edtf: "{1667,1668,1670..1672}",
+		},
+		{
+			input: "[..1760-12-03,1762]", startBound: open,
+			endYear: 1762, endPrecision: hubv1.DatePrecision_DATE_PRECISION_YEAR, isRange: true,
+			setType: hubv1.DateSetType_DATE_SET_TYPE_ONE_OF, members: 2, edtf: "[..1760-12-03,1762]",
+		},
+		{input: "[1999]", year: 1999, precision: hubv1.DatePrecision_DATE_PRECISION_YEAR, setType: hubv1.DateSetType_DATE_SET_TYPE_ONE_OF, members: 1, edtf: "[1999]"},
+		{input: "1978-13"},
+		{input: "3/15/1999"},
+		{input: "../.."},
+		{input: "2000/1990"},
+		{input: "1990-05-02/1990-05-01"},
+		{input: "{1667,1672..1670}"},
+		{
+			input: "2004-06/2004", year: 2004, month: 6, precision: hubv1.DatePrecision_DATE_PRECISION_MONTH,
+			endYear: 2004, endPrecision: hubv1.DatePrecision_DATE_PRECISION_YEAR, isRange: true, edtf: "2004-06/2004",
+		},
+		{input: "[1990,spring]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			d, err := ParseEDTF(tt.input, hubv1.DateType_DATE_TYPE_ISSUED)
+			if err != nil {
+				t.Fatalf("ParseEDTF failed: %v", err)
+			}
+			if d.Raw != tt.input {
+				t.Errorf("Raw: got %q", d.Raw)
+			}
+			if d.Year != tt.year || d.Month != tt.month || d.Day != tt.day || d.Precision != tt.precision || d.Qualifier != tt.qualifier {
+				t.Errorf("start: got %d-%d-%d %v %v", d.Year, d.Month, d.Day, d.Precision, d.Qualifier)
+			}
+			if d.EndYear != tt.endYear || d.EndMonth != tt.endMonth || d.EndPrecision != tt.endPrecision || d.EndQualifier != tt.endQualifier {
+				t.Errorf("end: got %d-%d %v %v", d.EndYear, d.EndMonth, d.EndPrecision, d.EndQualifier)
+			}
+			if d.StartBound != tt.startBound || d.EndBound != tt.endBound || d.IsRange != tt.isRange {
+				t.Errorf("bounds: got %v/%v, range %v", d.StartBound, d.EndBound, d.IsRange)
+			}
+			if d.Season != tt.season {
+				t.Errorf("Season: got %q", d.Season)
+			}
+			if d.SetType != tt.setType || len(d.SetMembers) != tt.members {
+				t.Errorf("set: got %v with %d members", d.SetType, len(d.SetMembers))
+			}
+			if got := hub.FormatEDTF(d); got != tt.edtf {
+				t.Errorf("FormatEDTF: got %q, want %q", got, tt.edtf)
+			}
+		})
+	}
+}
+
+func TestFormatDate(t *testing.T) {
+	for input, want := range map[string]string{
+		"2001-23":      "Autumn 2001",
+		"1990/..":      "1990/..",
+		"[1990,1995]":  "1990 or 1995",
+		"{1990,1995}":  "1990, 1995",
+		"1950?/1959-3": "",
+	} {
+		d, _ := ParseEDTF(input, hubv1.DateType_DATE_TYPE_ISSUED)
+		if got := hub.FormatDate(d); got != want {
+			t.Errorf("FormatDate(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
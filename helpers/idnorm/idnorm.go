@@ -0,0 +1,246 @@
+// Package idnorm validates identifiers and rewrites them in one canonical
+// form: DOIs bare and lowercased, ISBNs as unhyphenated ISBN-13s, ISSNs and
+// ORCIDs hyphenated with their check digits verified, and arXiv IDs without
+// the "arXiv:" prefix or URL.
+package idnorm
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+)
+
+var (
+	// ErrFormat is returned for values that aren't shaped like the
+	// identifier at all.
+	ErrFormat = errors.New("invalid format")
+	// ErrChecksum is returned for values whose check digit is wrong,
+	// usually a typo.
+	ErrChecksum = errors.New("check digit mismatch")
+)
+
+var (
+	doiRegex      = regexp.MustCompile(`^10\.\d{4,9}/\S+$`)
+	arxivRegex    = regexp.MustCompile(`^\d{4}\.\d{4,5}(v\d+)?$`)
+	arxivOldRegex = regexp.MustCompile(`^[a-z-]+(\.[A-Z]{2})?/\d{7}(v\d+)?$`)
+)
+
+// doiPrefixes are the URL and scheme forms DOIs arrive in, lowercased.
+var doiPrefixes = []string{
+	"https://doi.org/", "http://doi.org/",
+	"https://dx.doi.org/", "http://dx.doi.org/",
+	"info:doi/", "doi:",
+}
+
+// DOI returns a DOI bare ("10.1234/abc") and lowercased, since DOIs are
+// case-insensitive.
+func DOI(value string) (string, error) {
+	doi := strings.ToLower(strings.TrimSpace(value))
+	for _, prefix := range doiPrefixes {
+		if strings.HasPrefix(doi, prefix) {
+			doi = strings.TrimSpace(doi[len(prefix):])
+			break
+		}
+	}
+	if !doiRegex.MatchString(doi) {
+		return value, fmt.Errorf("DOI %q: %w (want 10.NNNN/suffix)", value, ErrFormat)
+	}
+	return doi, nil
+}
+
+// isbnDigits strips an ISBN to its digits and check character, dropping an
+// "ISBN" label and trailing qualifiers such as "(pbk.)".
+func isbnDigits(value string) string {
+	s := strings.TrimSpace(value)
+	if len(s) >= 4 && strings.EqualFold(s[:4], "isbn") {
+		s = strings.TrimLeft(s[4:], "-: ")
+	}
+	if i := strings.IndexAny(s, "(;"); i >= 0 {
+		s = s[:i]
+	}
+	return strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(s))
+}
+
+// ISBN validates an ISBN-10 or ISBN-13 and returns it as an unhyphenated
+// ISBN-13.
+func ISBN(value string) (string, error) {
+	s := isbnDigits(value)
+	switch {
+	case len(s) == 10 && allDigits(s[:9]) && (allDigits(s[9:]) || s[9] == 'X'):
+		if isbn10Check(s[:9]) != s[9] {
+			return value, fmt.Errorf("ISBN %q: %w", value, ErrChecksum)
+		}
+		return "978" + s[:9] + string(isbn13Check("978"+s[:9])), nil
+	case len(s) == 13 && allDigits(s):
+		if isbn13Check(s[:12]) != s[12] {
+			return value, fmt.Errorf("ISBN %q: %w", value, ErrChecksum)
+		}
+		return s, nil
+	}
+	return value, fmt.Errorf("ISBN %q: %w (want 10 or 13 digits)", value, ErrFormat)
+}
+
+// ISBN10 returns an ISBN as an unhyphenated ISBN-10. Only 978 ISBN-13s
+// have one.
+func ISBN10(value string) (string, error) {
+	isbn, err := ISBN(value)
+	if err != nil {
+		return value, err
+	}
+	if !strings.HasPrefix(isbn, "978") {
+		return value, fmt.Errorf("ISBN %q: %w (979 ISBNs have no ISBN-10)", value, ErrFormat)
+	}
+	return isbn[3:12] + string(isbn10Check(isbn[3:12])), nil
+}
+
+// ISSN validates an ISSN and returns it hyphenated ("0317-8471").
+func ISSN(value string) (string, error) {
+	s := strings.TrimSpace(value)
+	if len(s) >= 4 && strings.EqualFold(s[:4], "issn") {
+		s = strings.TrimLeft(s[4:], ": ")
+	}
+	s = strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(s))
+	if len(s) != 8 || !allDigits(s[:7]) || (!allDigits(s[7:]) && s[7] != 'X') {
+		return value, fmt.Errorf("ISSN %q: %w (want NNNN-NNNN)", value, ErrFormat)
+	}
+	sum := 0
+	for i := range 7 {
+		sum += int(s[i]-'0') * (8 - i)
+	}
+	if checkChar((11-sum%11)%11) != s[7] {
+		return value, fmt.Errorf("ISSN %q: %w", value, ErrChecksum)
+	}
+	return s[:4] + "-" + s[4:], nil
+}
+
+// ORCID validates an ORCID iD and returns it bare and hyphenated
+// ("0000-0002-1825-0097"); see ORCIDURI for the URI form.
+func ORCID(value string) (string, error) {
+	s := strings.TrimSpace(value)
+	for _, prefix := range []string{"https://orcid.org/", "http://orcid.org/", "orcid.org/"} {
+		if len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix) {
+			s = s[len(prefix):]
+			break
+		}
+	}
+	s = strings.ToUpper(strings.ReplaceAll(s, "-", ""))
+	if len(s) != 16 || !allDigits(s[:15]) || (!allDigits(s[15:]) && s[15] != 'X') {
+		return value, fmt.Errorf("ORCID %q: %w (want NNNN-NNNN-NNNN-NNNN)", value, ErrFormat)
+	}
+	// ISO 7064 MOD 11-2
+	total := 0
+	for i := range 15 {
+		total = (total + int(s[i]-'0')) * 2
+	}
+	if checkChar((12-total%11)%11) != s[15] {
+		return value, fmt.Errorf("ORCID %q: %w", value, ErrChecksum)
+	}
+	return s[0:4] + "-" + s[4:8] + "-" + s[8:12] + "-" + s[12:16], nil
+}
+
+// ORCIDURI returns an ORCID iD in the https://orcid.org/ form ORCID asks
+// for in displays and linked data.
+func ORCIDURI(value string) (string, error) {
+	orcid, err := ORCID(value)
+	if err != nil {
+		return value, err
+	}
+	return "https://orcid.org/" + orcid, nil
+}
+
+// ArXiv validates an arXiv identifier, new style ("2101.01234v2") or old
+// ("hep-th/9901001"), and returns it without an "arXiv:" prefix or URL.
+func ArXiv(value string) (string, error) {
+	s := strings.TrimSpace(value)
+	for _, prefix := range []string{"https://arxiv.org/abs/", "http://arxiv.org/abs/", "https://arxiv.org/pdf/", "http://arxiv.org/pdf/", "arxiv:"} {
+		if len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix) {
+			s = strings.TrimSuffix(s[len(prefix):], ".pdf")
+			break
+		}
+	}
+	if !arxivRegex.MatchString(s) && !arxivOldRegex.MatchString(s) {
+		return value, fmt.Errorf("arXiv ID %q: %w (want YYMM.NNNNN or archive/YYMMNNN)", value, ErrFormat)
+	}
+	return s, nil
+}
+
+// Normalize rewrites id.Value in its type's canonical form. An invalid
+// value is left as it was and the reason returned. Types idnorm doesn't
+// know are left alone.
+func Normalize(id *hubv1.Identifier) error {
+	var canon func(string) (string, error)
+	switch id.Type {
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_DOI:
+		canon = DOI
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_ISBN:
+		canon = ISBN
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_ISSN:
+		canon = ISSN
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_ORCID:
+		canon = ORCID
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_ARXIV:
+		canon = ArXiv
+	default:
+		return nil
+	}
+	value, err := canon(id.Value)
+	if err != nil {
+		return err
+	}
+	id.Value = value
+	return nil
+}
+
+// NormalizeRecord normalizes the record's identifiers and its
+// contributors' (ORCIDs). Invalid values are kept as the source gave them;
+// hub.Validate reports them.
+func NormalizeRecord(record *hubv1.Record) {
+	for _, id := range record.Identifiers {
+		_ = Normalize(id)
+	}
+	for _, c := range record.Contributors {
+		for _, id := range c.Identifiers {
+			_ = Normalize(id)
+		}
+	}
+}
+
+func allDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// checkChar renders a mod-11 check value, 10 as "X".
+func checkChar(n int) byte {
+	if n == 10 {
+		return 'X'
+	}
+	return byte('0' + n)
+}
+
+func isbn10Check(first9 string) byte {
+	sum := 0
+	for i := range 9 {
+		sum += int(first9[i]-'0') * (10 - i)
+	}
+	return checkChar((11 - sum%11) % 11)
+}
+
+func isbn13Check(first12 string) byte {
+	sum := 0
+	for i := range 12 {
+		d := int(first12[i] - '0')
+		if i%2 == 1 {
+			d *= 3
+		}
+		sum += d
+	}
+	return byte('0' + (10-sum%10)%10)
+}
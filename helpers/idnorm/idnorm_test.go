@@ -0,0 +1,111 @@
+package idnorm
+
+import (
+	"errors"
+	"testing"
+
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+)
+
+func TestCanonicalForms(t *testing.T) {
+	tests := []struct {
+		name  string
+		canon func(string) (string, error)
+		input string
+		want  string
+	}{
+		{"DOI", DOI, "10.1234/ABC.def", "10.1234/abc.def"},
+		{"DOI", DOI, "https://doi.org/10.1234/ABC", "10.1234/abc"},
+		{"DOI", DOI, "http://dx.doi.org/10.1234/abc", "10.1234/abc"},
+		{"DOI", DOI, " doi:10.1234/abc", "10.1234/abc"},
+		{"DOI", DOI, "info:doi/10.1234/abc", "10.1234/abc"},
+
+		{"ISBN", ISBN, "0-262-03384-4", "9780262033848"},
+		{"ISBN", ISBN, "0262033844 (hardcover)", "9780262033848"},
+		{"ISBN", ISBN, "ISBN 978-0-262-03384-8", "9780262033848"},
+		{"ISBN", ISBN, "080442957x", "9780804429573"},
+		{"ISBN-10", ISBN10, "978-0-262-03384-8", "0262033844"},
+		{"ISBN-10", ISBN10, "9780804429573", "080442957X"},
+
+		{"ISSN", ISSN, "03178471", "0317-8471"},
+		{"ISSN", ISSN, "ISSN 2049-3630", "2049-3630"},
+		{"ISSN", ISSN, "1050-124x", "1050-124X"},
+
+		{"ORCID", ORCID, "https://orcid.org/0000-0002-1825-0097", "0000-0002-1825-0097"},
+		{"ORCID", ORCID, "000000021825009X", ""},
+		{"ORCID", ORCID, "0000-0001-5109-3700", "0000-0001-5109-3700"},
+		{"ORCID", ORCID, "0000-0002-1694-233x", "0000-0002-1694-233X"},
+		{"ORCID URI", ORCIDURI, "0000-0002-1825-0097", "https://orcid.org/0000-0002-1825-0097"},
+
+		{"arXiv", ArXiv, "arXiv:2101.01234v2", "2101.01234v2"},
+		{"arXiv", ArXiv, "https://arxiv.org/abs/2101.01234", "2101.01234"},
+		{"arXiv", ArXiv, "https://arxiv.org/pdf/2101.01234v1.pdf", "2101.01234v1"},
+		{"arXiv", ArXiv, "hep-th/9901001", "hep-th/9901001"},
+		{"arXiv", ArXiv, "math.GT/0309136", "math.GT/0309136"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+" "+tt.input, func(t *testing.T) {
+			got, err := tt.canon(tt.input)
+			if tt.want == "" {
+				if err == nil {
+					t.Errorf("got %q, want an error", got)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("got %q, %v; want %q", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestErrors(t *testing.T) {
+	for _, tt := range []struct {
+		canon func(string) (string, error)
+		input string
+		want  error
+	}{
+		{DOI, "doi.org/abc", ErrFormat},
+		{ISBN, "0-262-03384-5", ErrChecksum},
+		{ISBN, "978-0-262-03384-9", ErrChecksum},
+		{ISBN, "12345", ErrFormat},
+		{ISBN10, "979-10-90636-07-1", ErrFormat},
+		{ISSN, "0317-8472", ErrChecksum},
+		{ISSN, "0317-847", ErrFormat},
+		{ORCID, "0000-0002-1825-0098", ErrChecksum},
+		{ORCID, "Jane Smith", ErrFormat},
+		{ArXiv, "arXiv:21010.1234", ErrFormat},
+	} {
+		got, err := tt.canon(tt.input)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("%q: got error %v, want %v", tt.input, err, tt.want)
+		}
+		if got != tt.input {
+			t.Errorf("%q: invalid value rewritten to %q", tt.input, got)
+		}
+	}
+}
+
+func TestNormalizeRecord(t *testing.T) {
+	record := &hubv1.Record{
+		Identifiers: []*hubv1.Identifier{
+			{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_DOI, Value: "https://doi.org/10.1234/ABC"},
+			{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_ISBN, Value: "0-262-03384-5"},
+			{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_URL, Value: "https://Example.edu/A"},
+		},
+		Contributors: []*hubv1.Contributor{{
+			Name:        "Carberry, Josiah",
+			Identifiers: []*hubv1.Identifier{{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_ORCID, Value: "http://orcid.org/0000000218250097"}},
+		}},
+	}
+	NormalizeRecord(record)
+
+	for i, want := range []string{"10.1234/abc", "0-262-03384-5", "https://Example.edu/A"} {
+		if got := record.Identifiers[i].Value; got != want {
+			t.Errorf("identifier %d: got %q, want %q", i, got, want)
+		}
+	}
+	if got := record.Contributors[0].Identifiers[0].Value; got != "0000-0002-1825-0097" {
+		t.Errorf("ORCID: got %q", got)
+	}
+}
@@ -0,0 +1,106 @@
+// Package lang normalizes language values, whether English names
+// ("English"), ISO 639-1, 639-2/B, 639-2/T, or 639-3 codes, or BCP 47 tags
+// such as Drupal langcodes ("pt-br"), to one ISO 639 code set.
+package lang
+
+import (
+	"cmp"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// languagesJSON lists every ISO 639-1 language, and the ISO 639-2
+// languages common in library metadata that have no 639-1 code, with their
+// codes and English names.
+//
+//go:embed languages.json
+var languagesJSON []byte
+
+// Language is an ISO 639 language.
+type Language struct {
+	// Name is the language's English name, and Names any others it goes by
+	Name  string   `json:"name"`
+	Names []string `json:"names,omitempty"`
+
+	Part1  string `json:"part1,omitempty"` // ISO 639-1 ("de")
+	Part2B string `json:"part2b"`          // ISO 639-2/B, bibliographic ("ger")
+	Part2T string `json:"part2t"`          // ISO 639-2/T, terminology ("deu")
+	Part3  string `json:"part3"`           // ISO 639-3 ("deu")
+}
+
+// CodeSet is an ISO 639 code set. Its values are the MODS authority names.
+type CodeSet string
+
+const (
+	ISO6391  CodeSet = "iso639-1"
+	ISO6392B CodeSet = "iso639-2b"
+	ISO6392T CodeSet = "iso639-2t"
+	ISO6393  CodeSet = "iso639-3"
+)
+
+// ParseCodeSet parses a code set name, accepting "" as the default.
+func ParseCodeSet(s string) (CodeSet, error) {
+	switch set := CodeSet(strings.ToLower(s)); set {
+	case "", ISO6391, ISO6392B, ISO6392T, ISO6393:
+		return set, nil
+	}
+	return "", fmt.Errorf("unknown language code set %q (want iso639-1, iso639-2b, iso639-2t, or iso639-3)", s)
+}
+
+// Code returns the language's code in set, or in the set nearest it if the
+// language has none there: a 639-2 or 639-3 code for ISO6391, as BCP 47
+// does, and the 639-2/T code otherwise. The empty set is ISO6391.
+func (l *Language) Code(set CodeSet) string {
+	switch set {
+	case ISO6392B:
+		return cmp.Or(l.Part2B, l.Part2T)
+	case ISO6392T:
+		return l.Part2T
+	case ISO6393:
+		return cmp.Or(l.Part3, l.Part2T)
+	default:
+		return cmp.Or(l.Part1, l.Part3, l.Part2T)
+	}
+}
+
+// index maps lowercased codes and names to languages.
+var index = func() map[string]*Language {
+	var languages []*Language
+	if err := json.Unmarshal(languagesJSON, &languages); err != nil {
+		panic(fmt.Sprintf("lang: embedded languages.json: %v", err))
+	}
+	index := make(map[string]*Language, len(languages)*4)
+	for _, l := range languages {
+		for _, key := range append([]string{l.Part1, l.Part2B, l.Part2T, l.Part3, l.Name}, l.Names...) {
+			if key != "" {
+				index[strings.ToLower(key)] = l
+			}
+		}
+	}
+	return index
+}()
+
+// Lookup finds the language a value names, by code, by English name, or by
+// the primary language of a BCP 47 tag ("en-US", "zh_Hant").
+func Lookup(value string) (*Language, bool) {
+	key := strings.ToLower(strings.Join(strings.Fields(value), " "))
+	if l, ok := index[key]; ok {
+		return l, true
+	}
+	if primary, _, ok := strings.Cut(strings.ReplaceAll(key, "_", "-"), "-"); ok && !strings.Contains(primary, " ") {
+		l, ok := index[primary]
+		return l, ok
+	}
+	return nil, false
+}
+
+// Normalize returns the code in set for the language a value names, or the
+// trimmed value if it names no known language.
+func Normalize(value string, set CodeSet) string {
+	if l, ok := Lookup(value); ok {
+		return l.Code(set)
+	}
+	return strings.TrimSpace(value)
+}
@@ -0,0 +1,58 @@
+package lang
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		value string
+		set   CodeSet
+		want  string
+	}{
+		{"English", ISO6391, "en"},
+		{"eng", ISO6391, "en"},
+		{"en", ISO6392B, "eng"},
+		{" english ", "", "en"},
+		{"German", ISO6392B, "ger"},
+		{"ger", ISO6392T, "deu"},
+		{"deu", ISO6392B, "ger"},
+		{"fra", ISO6393, "fra"},
+		{"pt-br", ISO6392B, "por"},
+		{"zh_Hant", ISO6392B, "chi"},
+		{"en-US", ISO6391, "en"},
+		{"Farsi", ISO6391, "fa"},
+		{"Greek, Modern (1453-)", ISO6391, "el"},
+		{"Luba-Katanga", ISO6392B, "lub"},
+		{"Hawaiian", ISO6391, "haw"},
+		{"grc", ISO6392B, "grc"},
+		{"und", ISO6391, "und"},
+		{"Klingon", ISO6391, "Klingon"},
+		{"English and French", ISO6391, "English and French"},
+		{"", ISO6391, ""},
+	}
+	for _, tt := range tests {
+		if got := Normalize(tt.value, tt.set); got != tt.want {
+			t.Errorf("Normalize(%q, %q) = %q, want %q", tt.value, tt.set, got, tt.want)
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	l, ok := Lookup("WEL")
+	if !ok || l.Name != "Welsh" || l.Part1 != "cy" || l.Part2T != "cym" {
+		t.Errorf("Lookup(WEL) = %v, %v", l, ok)
+	}
+	if _, ok := Lookup("xx-yy"); ok {
+		t.Error("Lookup accepted an unknown tag")
+	}
+}
+
+func TestParseCodeSet(t *testing.T) {
+	for _, s := range []string{"", "iso639-1", "ISO639-2B", "iso639-2t", "iso639-3"} {
+		if _, err := ParseCodeSet(s); err != nil {
+			t.Errorf("ParseCodeSet(%q): %v", s, err)
+		}
+	}
+	if _, err := ParseCodeSet("marc"); err == nil {
+		t.Error("ParseCodeSet accepted an unknown set")
+	}
+}
@@ -3,6 +3,7 @@ package helpers
 import (
 	"regexp"
 	"strings"
+	"unicode"
 
 	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
 	"github.com/lehigh-university-libraries/crosswalk/hub"
@@ -12,115 +13,309 @@ import (
 type NameParser struct{}
 
 var (
-	// Suffixes that appear after a name
-	suffixes = []string{"Jr.", "Jr", "Sr.", "Sr", "III", "II", "IV", "V", "PhD", "Ph.D.", "MD", "M.D.", "Esq.", "Esq"}
+	// Suffixes that appear after a name, compared without case or periods
+	suffixes = map[string]bool{
+		"jr": true, "sr": true, "ii": true, "iii": true, "iv": true, "2nd": true, "3rd": true,
+		"phd": true, "md": true, "dds": true, "esq": true,
+	}
+
+	// Suffixes that are also initials ("Smith, John V"), recognized only when
+	// set off by a comma ("Smith, John, V")
+	ambiguousSuffixes = map[string]bool{"i": true, "v": true, "vi": true}
+
+	// Nobiliary and patronymic particles that begin a family name
+	// ("van der Berg", "de la Cruz", "ibn Sina")
+	particles = map[string]bool{
+		"van": true, "von": true, "vom": true, "zu": true, "zum": true, "zur": true,
+		"de": true, "del": true, "della": true, "dei": true, "degli": true, "di": true, "da": true,
+		"das": true, "do": true, "dos": true, "du": true, "des": true, "der": true, "den": true,
+		"het": true, "'t": true, "ter": true, "ten": true, "te": true,
+		"le": true, "la": true, "les": true, "lo": true, "l'": true, "d'": true,
+		"af": true, "av": true, "al": true, "el": true,
+		"bin": true, "ibn": true, "ben": true, "bint": true, "ap": true,
+	}
+
+	// Titles dropped from the front of a name in direct order
+	titles = map[string]bool{
+		"dr": true, "prof": true, "professor": true, "mr": true, "mrs": true, "ms": true,
+		"mx": true, "sir": true, "dame": true, "rev": true, "fr": true,
+	}
+
+	// Words that mark a name as an organization's
+	orgKeywords = map[string]bool{
+		"university": true, "universität": true, "université": true, "universidad": true,
+		"universidade": true, "università": true, "college": true, "institute": true,
+		"institut": true, "instituto": true, "school": true, "library": true, "libraries": true,
+		"museum": true, "archives": true, "society": true, "association": true, "foundation": true,
+		"council": true, "committee": true, "commission": true, "department": true, "dept": true,
+		"center": true, "centre": true, "laboratory": true, "laboratories": true,
+		"consortium": true, "collaboration": true, "agency": true, "ministry": true,
+		"government": true, "academy": true, "hospital": true, "organization": true,
+		"organisation": true, "federation": true, "publishers": true, "publishing": true,
+		"corporation": true, "corp": true, "company": true, "inc": true, "llc": true,
+		"ltd": true, "gmbh": true,
+	}
 
-	// Name prefixes (nobiliary particles)
-	prefixes = []string{"van", "von", "de", "del", "della", "di", "da", "le", "la", "du", "des", "den", "der", "het", "ter", "ten", "op", "mc", "mac", "o'", "d'", "al-", "el-", "ibn"}
+	// Organization words that are also common surnames ("Bill Press",
+	// "Frederic Church"); they count only before the last word of an
+	// uninverted name ("Bank of America")
+	weakOrgKeywords = map[string]bool{
+		"press": true, "church": true, "bank": true, "board": true, "office": true,
+		"trust": true, "union": true, "group": true, "project": true,
+	}
 
-	// Pattern for "Last, First Middle" format
-	invertedNameRegex = regexp.MustCompile(`^([^,]+),\s*(.+)$`)
+	// Two-character family names in CJK scripts
+	compoundSurnames = map[string]bool{
+		"欧阳": true, "歐陽": true, "司马": true, "司馬": true, "诸葛": true, "諸葛": true,
+		"上官": true, "司徒": true, "东方": true, "東方": true, "皇甫": true, "尉迟": true,
+		"尉遲": true, "公孙": true, "公孫": true, "慕容": true, "夏侯": true, "令狐": true,
+		"端木": true, "南宫": true, "南宮": true, "西门": true, "西門": true,
+		"남궁": true, "황보": true, "제갈": true, "선우": true, "독고": true, "사공": true,
+	}
 )
 
-// Parse parses a name string into its components.
-// Handles both "First Last" and "Last, First" formats.
+// Parse parses a name string into its components. It handles "First Last",
+// "Last, First", and suffixes in either position ("Smith Jr., John"), keeps
+// particles with the family name, and splits unspaced CJK names after the
+// family name. It returns nil for organization names.
 func (p *NameParser) Parse(name string) *hubv1.ParsedName {
-	name = strings.TrimSpace(name)
-	if name == "" {
+	name = strings.Join(strings.Fields(name), " ")
+	if name == "" || IsOrganizationName(name) {
 		return nil
 	}
 
-	result := &hubv1.ParsedName{
-		FullName: name,
+	result := &hubv1.ParsedName{FullName: name}
+	if isCJK(name) {
+		parseCJK(result, name)
+	} else if segments := splitSegments(name, result); len(segments) > 1 {
+		parseInverted(result, segments)
+	} else if !parseDirect(result, strings.Fields(segments[0])) {
+		return nil
 	}
 
-	// Check for inverted format: "Last, First Middle Suffix"
-	if matches := invertedNameRegex.FindStringSubmatch(name); matches != nil {
-		result.Family = strings.TrimSpace(matches[1])
-		rest := strings.TrimSpace(matches[2])
+	// Build normalized form
+	result.Normalized = hub.ParsedNameInverted(result)
+
+	return result
+}
+
+// splitSegments splits a name on commas, moving segments that are only
+// suffixes ("Jr.", "PhD") to the result.
+func splitSegments(name string, result *hubv1.ParsedName) []string {
+	var segments, found []string
+	for i, seg := range strings.Split(name, ",") {
+		seg = strings.TrimSpace(seg)
+		switch {
+		case seg == "":
+		case i > 0 && isSuffix(seg), i > 1 && ambiguousSuffixes[nameKey(seg)]:
+			found = append(found, seg)
+		default:
+			segments = append(segments, seg)
+		}
+	}
+	if len(segments) == 0 {
+		// A bare suffix is a name of its own ("V")
+		return []string{name}
+	}
+	result.Suffix = strings.Join(found, " ")
+	return segments
+}
+
+// parseInverted reads "Family, Given Middle" segments, where a suffix may
+// trail either part and particles may lead the family name ("van der Berg,
+// Ludwig") or trail the given names ("Berg, Ludwig van der").
+func parseInverted(result *hubv1.ParsedName, segments []string) {
+	family := strings.Fields(segments[0])
+	given := strings.Fields(strings.Join(segments[1:], " "))
+
+	family, suffix := trimSuffixes(family)
+	given, givenSuffix := trimSuffixes(given)
+	result.Suffix = joinNonEmpty(suffix, givenSuffix, result.Suffix)
+
+	// Particles after the given names belong to the family name
+	end := len(given)
+	for end > 1 && isParticle(given[end-1]) {
+		end--
+	}
+	family = append(given[end:len(given):len(given)], family...)
+	given = given[:end]
+
+	// Particles leading the family name, keeping at least one word
+	n := 0
+	for n < len(family)-1 && isParticle(family[n]) {
+		n++
+	}
+	result.Prefix = strings.Join(family[:n], " ")
+	result.Family = strings.Join(family, " ")
+	if len(given) > 0 {
+		result.Given = given[0]
+		result.Middle = strings.Join(given[1:], " ")
+	}
+}
+
+// parseDirect reads "Given Middle Family Suffix" words, dropping leading
+// titles. It reports false if no name is left.
+func parseDirect(result *hubv1.ParsedName, words []string) bool {
+	for len(words) > 1 && titles[nameKey(words[0])] {
+		words = words[1:]
+	}
+	if len(words) > 1 {
+		var suffix string
+		words, suffix = trimSuffixes(words)
+		result.Suffix = joinNonEmpty(suffix, result.Suffix)
+	}
+	if len(words) == 0 {
+		return false
+	}
+	if len(words) == 1 {
+		// Single name - treat as family name
+		result.Family = words[0]
+		return true
+	}
 
-		// Extract suffix from the rest
-		rest, result.Suffix = extractSuffix(rest)
+	// The family name is the last word and any particles before it, but
+	// never the first word ("Van Morrison")
+	start := len(words) - 1
+	for start > 1 && isParticle(words[start-1]) {
+		start--
+	}
+	result.Prefix = strings.Join(words[start:len(words)-1], " ")
+	result.Family = strings.Join(words[start:], " ")
+	result.Given = words[0]
+	result.Middle = strings.Join(words[1:start], " ")
+	return true
+}
 
-		// Split remaining into given and middle
-		parts := strings.Fields(rest)
-		if len(parts) > 0 {
-			result.Given = parts[0]
+// parseCJK splits a Chinese, Japanese, or Korean name, which puts the family
+// name first. A spaced name splits at the first space; an unspaced one after
+// a compound surname, after the kanji before any kana, or after its first
+// character (its first two, for four-character names).
+func parseCJK(result *hubv1.ParsedName, name string) {
+	if family, given, ok := strings.Cut(name, " "); ok {
+		result.Family, result.Given = family, strings.ReplaceAll(given, " ", "")
+		return
+	}
+	runes := []rune(name)
+	n := 1
+	switch {
+	case len(runes) < 2:
+		n = len(runes)
+	case compoundSurnames[string(runes[:2])]:
+		n = 2
+	case hasKana(runes):
+		for n < len(runes) && unicode.Is(unicode.Han, runes[n]) {
+			n++
 		}
-		if len(parts) > 1 {
-			result.Middle = strings.Join(parts[1:], " ")
+		if n == len(runes) {
+			n = 1
 		}
-	} else {
-		// Direct format: "First Middle Prefix Last Suffix"
-		name, result.Suffix = extractSuffix(name)
-		parts := strings.Fields(name)
+	case len(runes) == 4:
+		n = 2
+	}
+	result.Family, result.Given = string(runes[:n]), string(runes[n:])
+}
 
-		if len(parts) == 0 {
-			return nil
+// IsOrganizationName reports whether a name looks like an organization's
+// rather than a person's: it has a word like "University" or "Inc.", an
+// ampersand, or is a single acronym ("NASA").
+func IsOrganizationName(name string) bool {
+	words := strings.Fields(name)
+	if len(words) == 0 {
+		return false
+	}
+	if len(words) == 1 {
+		return isAcronym(words[0])
+	}
+	inverted := strings.Contains(name, ",")
+	for i, w := range words {
+		key := nameKey(w)
+		if w == "&" || orgKeywords[key] || (weakOrgKeywords[key] && !inverted && i < len(words)-1) {
+			return true
 		}
+	}
+	return false
+}
 
-		if len(parts) == 1 {
-			// Single name - treat as family name
-			result.Family = parts[0]
-		} else {
-			// Find where the family name starts (after any prefix)
-			familyStart := len(parts) - 1
-
-			// Check for prefix before last name
-			if familyStart > 0 && isPrefix(parts[familyStart-1]) {
-				result.Prefix = parts[familyStart-1]
-				familyStart--
-			}
-
-			result.Family = strings.Join(parts[familyStart:], " ")
-			if result.Prefix != "" {
-				result.Family = result.Prefix + " " + parts[len(parts)-1]
-			}
-
-			// First name is always first part
-			result.Given = parts[0]
-
-			// Middle name(s) are everything between first and family
-			if familyStart > 1 {
-				middleParts := parts[1:familyStart]
-				result.Middle = strings.Join(middleParts, " ")
-			}
+// isAcronym reports whether a word is an all-capital acronym of two or more
+// letters, other than a Roman numeral suffix.
+func isAcronym(word string) bool {
+	letters := 0
+	for _, r := range word {
+		switch {
+		case unicode.IsUpper(r):
+			letters++
+		case unicode.IsDigit(r) || r == '&' || r == '-':
+		default:
+			return false
 		}
 	}
+	return letters >= 2 && !isSuffix(word)
+}
 
-	// Build normalized form
-	result.Normalized = hub.ParsedNameInverted(result)
+// trimSuffixes removes suffix words from the end of a name, leaving at least
+// one word.
+func trimSuffixes(words []string) ([]string, string) {
+	end := len(words)
+	for end > 1 && isSuffix(words[end-1]) {
+		end--
+	}
+	found := make([]string, 0, len(words)-end)
+	for _, w := range words[end:] {
+		found = append(found, strings.TrimSuffix(w, ","))
+	}
+	return words[:end], strings.Join(found, " ")
+}
 
-	return result
+// isSuffix checks if a word is a generational or academic suffix.
+func isSuffix(word string) bool {
+	return suffixes[nameKey(word)]
 }
 
-// extractSuffix extracts a suffix from a name string.
-func extractSuffix(name string) (string, string) {
-	// Look for common suffixes at the end
-	for _, suffix := range suffixes {
-		// Check with trailing comma (common format)
-		if strings.HasSuffix(name, ", "+suffix) {
-			return strings.TrimSuffix(name, ", "+suffix), suffix
-		}
-		// Check without comma
-		if strings.HasSuffix(name, " "+suffix) {
-			return strings.TrimSuffix(name, " "+suffix), suffix
+// isParticle checks if a word is a nobiliary or patronymic particle.
+func isParticle(word string) bool {
+	return particles[strings.ToLower(word)]
+}
+
+// nameKey lowercases a word and drops its periods and trailing comma, so
+// "Ph.D.," and "phd" compare equal.
+func nameKey(word string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSuffix(word, ","), ".", ""))
+}
+
+// isCJK reports whether a name is written in Han, Hangul, or kana with no
+// Latin letters.
+func isCJK(name string) bool {
+	cjk := false
+	for _, r := range name {
+		switch {
+		case unicode.In(r, unicode.Han, unicode.Hangul, unicode.Hiragana, unicode.Katakana):
+			cjk = true
+		case unicode.IsLetter(r):
+			return false
 		}
 	}
-	return name, ""
+	return cjk
 }
 
-// isPrefix checks if a word is a nobiliary particle.
-func isPrefix(word string) bool {
-	lower := strings.ToLower(word)
-	for _, prefix := range prefixes {
-		if lower == prefix || lower == strings.TrimSuffix(prefix, "'") {
+func hasKana(runes []rune) bool {
+	for _, r := range runes {
+		if unicode.In(r, unicode.Hiragana, unicode.Katakana) {
 			return true
 		}
 	}
 	return false
 }
 
+func joinNonEmpty(parts ...string) string {
+	var out []string
+	for _, p := range parts {
+		if p != "" {
+			out = append(out, p)
+		}
+	}
+	return strings.Join(out, " ")
+}
+
 // ParseName is a convenience function to parse a name string.
 func ParseName(name string) *hubv1.ParsedName {
 	parser := &NameParser{}
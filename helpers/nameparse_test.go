@@ -0,0 +1,131 @@
+package helpers
+
+import "testing"
+
+func TestParseName(t *testing.T) {
+	tests := []struct {
+		input                                 string
+		family, given, middle, suffix, prefix string
+		normalized                            string
+	}{
+		// Direct and inverted order
+		{input: "Jane Smith", family: "Smith", given: "Jane", normalized: "Smith, Jane"},
+		{input: "Smith, Jane", family: "Smith", given: "Jane", normalized: "Smith, Jane"},
+		{input: "  Jane   Q.  Smith ", family: "Smith", given: "Jane", middle: "Q.", normalized: "Smith, Jane Q."},
+		{input: "Smith, J. R. R.", family: "Smith", given: "J.", middle: "R. R."},
+		{input: "Tolkien, J.R.R.", family: "Tolkien", given: "J.R.R."},
+		{input: "Jean-Paul Sartre", family: "Sartre", given: "Jean-Paul"},
+		{input: "O'Brien, Flann", family: "O'Brien", given: "Flann"},
+		{input: "Madonna", family: "Madonna"},
+		{input: "Plato", family: "Plato"},
+
+		// Particles stay with the family name
+		{input: "Ludwig van der Berg", family: "van der Berg", given: "Ludwig", prefix: "van der", normalized: "van der Berg, Ludwig"},
+		{input: "van der Berg, Ludwig", family: "van der Berg", given: "Ludwig", prefix: "van der"},
+		{input: "Berg, Ludwig van der", family: "van der Berg", given: "Ludwig", prefix: "van der"},
+		{input: "Juan de la Cruz", family: "de la Cruz", given: "Juan", prefix: "de la"},
+		{input: "de la Cruz, Juan", family: "de la Cruz", given: "Juan", prefix: "de la"},
+		{input: "Sor Juana Inés de la Cruz", family: "de la Cruz", given: "Sor", middle: "Juana Inés", prefix: "de la"},
+		{input: "Johann Wolfgang von Goethe", family: "von Goethe", given: "Johann", middle: "Wolfgang", prefix: "von"},
+		{input: "Leonardo da Vinci", family: "da Vinci", given: "Leonardo", prefix: "da"},
+		{input: "Ludwig Van Beethoven", family: "Van Beethoven", given: "Ludwig", prefix: "Van"},
+		{input: "Abu Ali ibn Sina", family: "ibn Sina", given: "Abu", middle: "Ali", prefix: "ibn"},
+		{input: "Van Morrison", family: "Morrison", given: "Van"},
+		{input: "Da Silva", family: "Silva", given: "Da"},
+
+		// Suffixes in any position
+		{input: "Martin Luther King Jr.", family: "King", given: "Martin", middle: "Luther", suffix: "Jr."},
+		{input: "Martin Luther King, Jr.", family: "King", given: "Martin", middle: "Luther", suffix: "Jr."},
+		{input: "King, Martin Luther, Jr.", family: "King", given: "Martin", middle: "Luther", suffix: "Jr.", normalized: "King, Martin Luther Jr."},
+		{input: "King, Martin Luther Jr.", family: "King", given: "Martin", middle: "Luther", suffix: "Jr."},
+		{input: "King Jr., Martin Luther", family: "King", given: "Martin", middle: "Luther", suffix: "Jr."},
+		{input: "King, Jr., Martin Luther", family: "King", given: "Martin", middle: "Luther", suffix: "Jr."},
+		{input: "Davis, Sammy, Jr", family: "Davis", given: "Sammy", suffix: "Jr"},
+		{input: "Thurston Howell III", family: "Howell", given: "Thurston", suffix: "III"},
+		{input: "Howell, Thurston, III", family: "Howell", given: "Thurston", suffix: "III"},
+		{input: "Jane Smith, Ph.D.", family: "Smith", given: "Jane", suffix: "Ph.D."},
+		{input: "John Smith Jr. MD", family: "Smith", given: "John", suffix: "Jr. MD"},
+		{input: "Smith, John V", family: "Smith", given: "John", middle: "V"},
+		{input: "Smith, V", family: "Smith", given: "V"},
+		{input: "Smith, John, V", family: "Smith", given: "John", suffix: "V"},
+
+		// Titles are dropped from direct order
+		{input: "Dr. Jane Smith", family: "Smith", given: "Jane"},
+		{input: "Prof. Ludwig van der Berg", family: "van der Berg", given: "Ludwig", prefix: "van der"},
+
+		// CJK names put the family name first
+		{input: "王小明", family: "王", given: "小明", normalized: "王, 小明"},
+		{input: "李白", family: "李", given: "白"},
+		{input: "欧阳修", family: "欧阳", given: "修"},
+		{input: "諸葛亮", family: "諸葛", given: "亮"},
+		{input: "山田太郎", family: "山田", given: "太郎"},
+		{input: "山田 太郎", family: "山田", given: "太郎"},
+		{input: "佐藤はなこ", family: "佐藤", given: "はなこ"},
+		{input: "김민준", family: "김", given: "민준"},
+		{input: "남궁민", family: "남궁", given: "민"},
+		{input: "孔", family: "孔"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			p := ParseName(tt.input)
+			if p == nil {
+				t.Fatal("ParseName returned nil")
+			}
+			if p.Family != tt.family || p.Given != tt.given || p.Middle != tt.middle {
+				t.Errorf("got family %q, given %q, middle %q", p.Family, p.Given, p.Middle)
+			}
+			if p.Suffix != tt.suffix || p.Prefix != tt.prefix {
+				t.Errorf("got suffix %q, prefix %q", p.Suffix, p.Prefix)
+			}
+			if tt.normalized != "" && p.Normalized != tt.normalized {
+				t.Errorf("Normalized: got %q, want %q", p.Normalized, tt.normalized)
+			}
+		})
+	}
+}
+
+func TestParseNameOrganizations(t *testing.T) {
+	for _, name := range []string{
+		"Lehigh University",
+		"Lehigh University, Special Collections",
+		"Universität Heidelberg",
+		"Bank of America",
+		"Church of England",
+		"Oxford University Press",
+		"Smith & Wesson",
+		"ATLAS Collaboration",
+		"Acme Widgets, Inc.",
+		"NASA",
+		"UNESCO",
+		"AT&T",
+		"",
+		"   ",
+	} {
+		if p := ParseName(name); p != nil {
+			t.Errorf("ParseName(%q) = %v, want nil", name, p)
+		}
+	}
+
+	// Surnames that are also organization words
+	for _, name := range []string{"Bill Press", "Church, Frederic Edwin", "Frederic Edwin Church", "III", "Li"} {
+		if IsOrganizationName(name) {
+			t.Errorf("IsOrganizationName(%q) = true", name)
+		}
+	}
+}
+
+func TestFormatName(t *testing.T) {
+	for input, want := range map[string]string{
+		"Ludwig van der Berg":     "Ludwig van der Berg",
+		"Berg, Ludwig van der":    "Ludwig van der Berg",
+		"King Jr., Martin Luther": "Martin Luther King Jr.",
+		"Lehigh University":       "Lehigh University",
+	} {
+		if got := FormatNameDirect(input); got != want {
+			t.Errorf("FormatNameDirect(%q) = %q, want %q", input, got, want)
+		}
+	}
+	if got := FormatNameInverted("Juan de la Cruz"); got != "de la Cruz, Juan" {
+		t.Errorf("FormatNameInverted = %q", got)
+	}
+}
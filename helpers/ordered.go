@@ -0,0 +1,67 @@
+package helpers
+
+// MapOrdered converts the items produce emits on up to workers goroutines
+// and hands the results to consume in the order the items were emitted.
+// produce and consume run on the calling goroutine, so only convert has
+// to be safe for concurrent use. At most twice workers items are in
+// flight at once, which bounds memory however long the input is.
+//
+// The first error from any of the three stops the work and is returned
+// unchanged. With workers of 1 or less, items are converted one at a time
+// on the calling goroutine.
+func MapOrdered[In, Out any](workers int, produce func(emit func(In) error) error, convert func(In) (Out, error), consume func(Out) error) error {
+	if workers <= 1 {
+		return produce(func(in In) error {
+			out, err := convert(in)
+			if err != nil {
+				return err
+			}
+			return consume(out)
+		})
+	}
+
+	type result struct {
+		out Out
+		err error
+	}
+	sem := make(chan struct{}, workers)
+	var pending []chan result
+
+	// next waits for the oldest item and consumes it
+	next := func() error {
+		ch := pending[0]
+		pending = pending[1:]
+		r := <-ch
+		if r.err != nil {
+			return r.err
+		}
+		return consume(r.out)
+	}
+
+	err := produce(func(in In) error {
+		ch := make(chan result, 1)
+		sem <- struct{}{}
+		go func() {
+			defer func() { <-sem }()
+			out, err := convert(in)
+			ch <- result{out, err}
+		}()
+		pending = append(pending, ch)
+		for len(pending) >= 2*workers {
+			if err := next(); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		// Workers still running finish into their buffered channels
+		return err
+	}
+	for len(pending) > 0 {
+		if err := next(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+package helpers
+
+import "strings"
+
+// pageDashes separate the first and last pages of a range.
+const pageDashes = "-–—"
+
+// SplitPages splits a page range such as "12-15", "12–15" or the BibTeX
+// style "12--15" into its first and last pages. A single page comes back
+// as the first page with an empty last page.
+func SplitPages(pages string) (first, last string) {
+	i := strings.IndexAny(pages, pageDashes)
+	if i < 0 {
+		return strings.TrimSpace(pages), ""
+	}
+	first, last = pages[:i], strings.TrimLeft(pages[i:], pageDashes+" ")
+	return strings.TrimSpace(first), strings.TrimSpace(last)
+}
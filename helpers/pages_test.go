@@ -0,0 +1,25 @@
+package helpers
+
+import "testing"
+
+func TestSplitPages(t *testing.T) {
+	tests := []struct {
+		pages, first, last string
+	}{
+		{"12-15", "12", "15"},
+		{"12–15", "12", "15"},
+		{"12—15", "12", "15"},
+		{"12--15", "12", "15"},
+		{" 12 – 15 ", "12", "15"},
+		{"e1234", "e1234", ""},
+		{"S1-S5", "S1", "S5"},
+		{"12-", "12", ""},
+		{"", "", ""},
+	}
+	for _, tt := range tests {
+		first, last := SplitPages(tt.pages)
+		if first != tt.first || last != tt.last {
+			t.Errorf("SplitPages(%q): got %q, %q, want %q, %q", tt.pages, first, last, tt.first, tt.last)
+		}
+	}
+}
@@ -0,0 +1,215 @@
+// Package place resolves place-of-publication strings as catalogers and
+// publishers write them ("Bethlehem, Pa.", "Toronto, Ont., Canada",
+// "[London]") to a hubv1.Place carrying the MARC country code and the
+// ISO 3166 country and region codes.
+package place
+
+import (
+	"cmp"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+)
+
+// placesJSON lists countries and the divisions of them that have their own
+// MARC country code, with the abbreviations imprints use for them, and the
+// publishing centers common enough to resolve without a qualifier.
+//
+//go:embed places.json
+var placesJSON []byte
+
+// Jurisdiction is a country, or a division of one with its own MARC
+// country code: a U.S. state, Canadian province, Australian state, or
+// constituent country of the United Kingdom.
+type Jurisdiction struct {
+	// Name is the jurisdiction's English name, and Names the abbreviations
+	// and other forms imprints give it ("Pa.", "Penna.", "PA")
+	Name  string   `json:"name"`
+	Names []string `json:"names,omitempty"`
+
+	MARC    string `json:"marc"`             // MARC country code ("pau")
+	Country string `json:"country"`          // ISO 3166-1 alpha-2 ("US")
+	Region  string `json:"region,omitempty"` // ISO 3166-2, for divisions ("US-PA")
+}
+
+// index holds the embedded table, keyed for lookup.
+type index struct {
+	names     map[string]*Jurisdiction // name and abbreviation keys
+	codes     map[string]*Jurisdiction // MARC country codes
+	countries map[string]*Jurisdiction // ISO 3166-1 codes, countries only
+	cities    map[string]*Jurisdiction // well-known city keys
+}
+
+var places = func() *index {
+	var data struct {
+		Jurisdictions []*Jurisdiction   `json:"jurisdictions"`
+		Cities        map[string]string `json:"cities"`
+	}
+	if err := json.Unmarshal(placesJSON, &data); err != nil {
+		panic(fmt.Sprintf("place: embedded places.json: %v", err))
+	}
+	idx := &index{
+		names:     make(map[string]*Jurisdiction, len(data.Jurisdictions)*3),
+		codes:     make(map[string]*Jurisdiction, len(data.Jurisdictions)),
+		countries: make(map[string]*Jurisdiction),
+		cities:    make(map[string]*Jurisdiction, len(data.Cities)),
+	}
+	for _, j := range data.Jurisdictions {
+		for _, name := range append([]string{j.Name}, j.Names...) {
+			idx.names[key(name)] = j
+		}
+		idx.codes[j.MARC] = j
+		if j.Region == "" {
+			idx.countries[j.Country] = j
+		}
+	}
+	for city, code := range data.Cities {
+		j, ok := idx.codes[code]
+		if !ok {
+			panic(fmt.Sprintf("place: embedded places.json: city %q has unknown code %q", city, code))
+		}
+		idx.cities[key(city)] = j
+	}
+	return idx
+}()
+
+// unknownPlaces are the keys of the ways catalogers record that there is
+// no place of publication.
+var unknownPlaces = map[string]bool{
+	"sl":                              true, // [S.l.], sine loco
+	"sineloco":                        true,
+	"np":                              true, // n.p.
+	"placeofpublicationnotidentified": true, // RDA
+	"unknown":                         true,
+	"xx":                              true,
+}
+
+// key folds a name for lookup, so "Pa.", "PA", and "pa" match, as do
+// "N. Mex." and "N.Mex.".
+func key(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '.', ' ', '\t':
+			return -1
+		}
+		return r
+	}, strings.ToLower(s))
+}
+
+// Lookup finds the jurisdiction a name or abbreviation names.
+func Lookup(name string) (*Jurisdiction, bool) {
+	j, ok := places.names[key(name)]
+	return j, ok
+}
+
+// ByMARC finds the jurisdiction a MARC country code names. MARC codes are
+// looked up apart from names because they collide with postal
+// abbreviations ("ne" is the Netherlands, "NE" Nebraska).
+func ByMARC(code string) (*Jurisdiction, bool) {
+	j, ok := places.codes[strings.ToLower(strings.TrimSpace(code))]
+	return j, ok
+}
+
+// Parse resolves a place of publication. The last one or two
+// comma-separated parts may name a jurisdiction ("Pa.", "Ont., Canada"),
+// and what precedes them is the city. A bare city resolves only if it is a
+// well-known publishing center ("London"). Parse returns nil for values it
+// can't resolve and for statements that the place is unknown ("[S.l.]").
+// Of several places ("New York ; London"), the first is resolved.
+func Parse(value string) *hubv1.Place {
+	value, _, _ = strings.Cut(value, ";")
+	value = strings.Trim(strings.NewReplacer("[", "", "]", "").Replace(value), " :,/")
+	if value == "" || unknownPlaces[key(value)] {
+		return nil
+	}
+	var parts []string
+	for part := range strings.SplitSeq(value, ",") {
+		if part = strings.Join(strings.Fields(part), " "); part != "" {
+			parts = append(parts, part)
+		}
+	}
+
+	n := len(parts)
+	if n == 1 {
+		if j, ok := places.cities[key(parts[0])]; ok {
+			return newPlace(parts[0], j)
+		}
+		if j, ok := places.names[key(parts[0])]; ok {
+			return newPlace("", j)
+		}
+		return nil
+	}
+
+	j, ok := places.names[key(parts[n-1])]
+	if !ok {
+		return nil
+	}
+	n--
+	// A country after a region or city: "Toronto, Ont., Canada",
+	// "New York, USA"
+	if j.Region == "" {
+		if c, ok := places.cities[key(parts[n-1])]; ok && n == 1 && c.Country == j.Country {
+			j = c
+		} else if r, ok := places.names[key(parts[n-1])]; ok && r.Region != "" && r.Country == j.Country {
+			j = r
+			n--
+		}
+	}
+	return newPlace(strings.Join(parts[:n], ", "), j)
+}
+
+// FromMARC returns the place a MARC country code names, or nil if the code
+// is unknown or "xx" (no place, unknown, or undetermined).
+func FromMARC(code string) *hubv1.Place {
+	if j, ok := ByMARC(code); ok {
+		return newPlace("", j)
+	}
+	return nil
+}
+
+// Resolve resolves a place of publication given as text, a MARC country
+// code, or both, as MODS and MARC records give them. The text wins when it
+// agrees with the code's country, since it names the city; otherwise the
+// code does.
+func Resolve(text, marcCode string) *hubv1.Place {
+	p := Parse(text)
+	j, ok := ByMARC(marcCode)
+	if !ok || (p != nil && p.CountryCode == j.Country) {
+		return p
+	}
+	return newPlace("", j)
+}
+
+// Published returns the record's publication place, resolving
+// place_published if the source gave no structured place.
+func Published(record *hubv1.Record) *hubv1.Place {
+	if record.PublicationPlace != nil {
+		return record.PublicationPlace
+	}
+	return Parse(record.PlacePublished)
+}
+
+func newPlace(city string, j *Jurisdiction) *hubv1.Place {
+	p := &hubv1.Place{
+		City:        strings.TrimSuffix(city, "."),
+		CountryCode: j.Country,
+		MarcCountry: j.MARC,
+	}
+	if j.Region != "" {
+		p.Region, p.RegionCode = j.Name, j.Region
+	}
+	if c, ok := places.countries[j.Country]; ok {
+		p.Country = c.Name
+	}
+	p.Name = p.City
+	if area := cmp.Or(p.Region, p.Country); area != "" {
+		if p.Name != "" {
+			p.Name += ", "
+		}
+		p.Name += area
+	}
+	return p
+}
@@ -0,0 +1,69 @@
+package place
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input                    string
+		name, city, region, marc string
+		regionCode, countryCode  string
+	}{
+		{input: "Bethlehem, Pa.", name: "Bethlehem, Pennsylvania", city: "Bethlehem", region: "Pennsylvania", marc: "pau", regionCode: "US-PA", countryCode: "US"},
+		{input: "Bethlehem, PA", name: "Bethlehem, Pennsylvania", city: "Bethlehem", region: "Pennsylvania", marc: "pau", regionCode: "US-PA", countryCode: "US"},
+		{input: "[Bethlehem, Penna.] :", name: "Bethlehem, Pennsylvania", city: "Bethlehem", region: "Pennsylvania", marc: "pau", regionCode: "US-PA", countryCode: "US"},
+		{input: "Santa Fe, N. Mex.", name: "Santa Fe, New Mexico", city: "Santa Fe", region: "New Mexico", marc: "nmu", regionCode: "US-NM", countryCode: "US"},
+		{input: "Cambridge, Mass.", name: "Cambridge, Massachusetts", city: "Cambridge", region: "Massachusetts", marc: "mau", regionCode: "US-MA", countryCode: "US"},
+		{input: "Washington, D.C.", name: "Washington, District of Columbia", city: "Washington", region: "District of Columbia", marc: "dcu", regionCode: "US-DC", countryCode: "US"},
+		{input: "Toronto, Ont., Canada", name: "Toronto, Ontario", city: "Toronto", region: "Ontario", marc: "onc", regionCode: "CA-ON", countryCode: "CA"},
+		{input: "Sydney, N.S.W.", name: "Sydney, New South Wales", city: "Sydney", region: "New South Wales", marc: "xna", regionCode: "AU-NSW", countryCode: "AU"},
+		{input: "New York, USA", name: "New York, New York", city: "New York", region: "New York", marc: "nyu", regionCode: "US-NY", countryCode: "US"},
+		{input: "Leipzig, Germany", name: "Leipzig, Germany", city: "Leipzig", marc: "gw", countryCode: "DE"},
+		{input: "Utrecht, The Netherlands", name: "Utrecht, Netherlands", city: "Utrecht", marc: "ne", countryCode: "NL"},
+
+		// Well-known cities stand alone
+		{input: "London", name: "London, England", city: "London", region: "England", marc: "enk", regionCode: "GB-ENG", countryCode: "GB"},
+		{input: "New York ; London", name: "New York, New York", city: "New York", region: "New York", marc: "nyu", regionCode: "US-NY", countryCode: "US"},
+		{input: "Paris.", name: "Paris, France", city: "Paris", marc: "fr", countryCode: "FR"},
+
+		// A jurisdiction alone
+		{input: "Pennsylvania", name: "Pennsylvania", region: "Pennsylvania", marc: "pau", regionCode: "US-PA", countryCode: "US"},
+		{input: "France", name: "France", marc: "fr", countryCode: "FR"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			p := Parse(tt.input)
+			if p == nil {
+				t.Fatal("Parse returned nil")
+			}
+			if p.Name != tt.name || p.City != tt.city || p.Region != tt.region {
+				t.Errorf("got name %q, city %q, region %q", p.Name, p.City, p.Region)
+			}
+			if p.MarcCountry != tt.marc || p.RegionCode != tt.regionCode || p.CountryCode != tt.countryCode {
+				t.Errorf("got MARC %q, region code %q, country code %q", p.MarcCountry, p.RegionCode, p.CountryCode)
+			}
+		})
+	}
+
+	for _, input := range []string{"", "[S.l.]", "[Place of publication not identified]", "n.p.", "Bethlehem", "Bethlehem, Atlantis"} {
+		if p := Parse(input); p != nil {
+			t.Errorf("Parse(%q) = %v, want nil", input, p)
+		}
+	}
+}
+
+func TestResolve(t *testing.T) {
+	// The text names the city within the code's country
+	if p := Resolve("Bethlehem, Pa.", "xxu"); p == nil || p.City != "Bethlehem" || p.MarcCountry != "pau" {
+		t.Errorf("text and agreeing code: got %v", p)
+	}
+	// The code is all there is to go on
+	if p := Resolve("Bethlehem", "pau"); p == nil || p.Name != "Pennsylvania" || p.Country != "United States" {
+		t.Errorf("unresolved text: got %v", p)
+	}
+	if p := Resolve("", "ne "); p == nil || p.Country != "Netherlands" {
+		t.Errorf("padded 008 code: got %v", p)
+	}
+	if p := Resolve("", "xx "); p != nil {
+		t.Errorf("unknown place code: got %v", p)
+	}
+}
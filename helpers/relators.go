@@ -1,6 +1,11 @@
 package helpers
 
-import "strings"
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
 
 // MARCRelator represents a MARC relator code and its label.
 type MARCRelator struct {
@@ -8,76 +13,57 @@ type MARCRelator struct {
 	Label string
 }
 
+// relatorsJSON is the MARC Code List for Relators, by code, from
+// https://id.loc.gov/vocabulary/relators.
+//
+//go:embed relators.json
+var relatorsJSON []byte
+
 // MARCRelators maps MARC relator codes to human-readable labels.
-// This is a subset of the full MARC relator list, focusing on common scholarly roles.
-var MARCRelators = map[string]string{
-	// Primary creators
-	"aut": "Author",
-	"cre": "Creator",
-	"edt": "Editor",
-	"com": "Compiler",
-	"trl": "Translator",
-	"ill": "Illustrator",
-	"pht": "Photographer",
-	"art": "Artist",
-	"cmp": "Composer",
-
-	// Contributors
-	"ctb": "Contributor",
-	"aui": "Author of introduction",
-	"aft": "Author of afterword",
-	"ann": "Annotator",
-	"cmm": "Commentator",
-	"wpr": "Writer of preface",
-	"wam": "Writer of accompanying material",
-
-	// Thesis-related
-	"ths": "Thesis advisor",
-	"dgs": "Degree supervisor",
-	"dgc": "Degree committee member",
-	"opn": "Opponent",
-
-	// Publishing
-	"pbl": "Publisher",
-	"dst": "Distributor",
-	"bkd": "Book designer",
-	"bkp": "Book producer",
-	"prt": "Printer",
-	"tyg": "Typographer",
-
-	// Research
-	"res": "Researcher",
-	"fnd": "Funder",
-	"spn": "Sponsor",
-	"his": "Host institution",
-
-	// Data and software
-	"dtc": "Data contributor",
-	"dtm": "Data manager",
-	"prg": "Programmer",
-
-	// Performance
-	"prf": "Performer",
-	"act": "Actor",
-	"nrt": "Narrator",
-	"sng": "Singer",
-	"cnd": "Conductor",
-	"drt": "Director",
-	"pro": "Producer",
-
-	// Organization
-	"org": "Originator",
-	"isb": "Issuing body",
-	"cph": "Copyright holder",
-	"oth": "Other",
-
-	// Legacy/common
-	"col": "Collector",
-	"cur": "Curator",
-	"own": "Owner",
-	"dnr": "Donor",
+var MARCRelators = func() map[string]string {
+	var relators map[string]string
+	if err := json.Unmarshal(relatorsJSON, &relators); err != nil {
+		panic(fmt.Sprintf("helpers: embedded relators.json: %v", err))
+	}
+	return relators
+}()
+
+// relatorAliases maps role terms that are not relator labels, such as
+// abbreviations from MARC $e and plain-language roles, to relator codes.
+var relatorAliases = map[string]string{
+	"ed":                     "edt",
+	"eds":                    "edt",
+	"comp":                   "com",
+	"tr":                     "trl",
+	"trans":                  "trl",
+	"illus":                  "ill",
+	"joint author":           "aut",
+	"advisor":                "ths",
+	"committee":              "dgc",
+	"committee member":       "dgc",
+	"committee chair":        "ths",
+	"supervisor":             "dgs",
+	"graduate advisor":       "ths",
+	"principal investigator": "rth",
 }
 
+// relatorIndex maps normalized relator labels to codes. Labels ending in
+// ", etc." are indexed without it too.
+var relatorIndex = func() map[string]string {
+	index := make(map[string]string, len(MARCRelators)+len(relatorAliases))
+	for code, label := range MARCRelators {
+		key := roleKey(label)
+		index[key] = code
+		if short, ok := strings.CutSuffix(key, " etc"); ok {
+			index[short] = code
+		}
+	}
+	for alias, code := range relatorAliases {
+		index[alias] = code
+	}
+	return index
+}()
+
 // RelatorCodeFromURI extracts the relator code from a URI like "relators:cre"
 func RelatorCodeFromURI(uri string) string {
 	// Handle "relators:xxx" format
@@ -109,58 +95,88 @@ func RelatorLabel(codeOrURI string) string {
 	return codeOrURI
 }
 
-// NormalizeRole normalizes a role string to a canonical form.
-// Accepts MARC codes, URIs, or plain text labels.
-func NormalizeRole(role string) string {
+// RelatorCode returns the MARC relator code for a code, URI, or label, or
+// an empty string if none matches. Labels match without regard to case,
+// punctuation, plurals, or the "adviser" spelling ("Thesis Advisers." is
+// ths), and then allowing one typo.
+func RelatorCode(role string) string {
 	role = strings.TrimSpace(role)
 	if role == "" {
 		return ""
 	}
 
-	// Extract code if it's a URI
-	code := RelatorCodeFromURI(role)
+	// Check if it's a known MARC code or URI
+	code := strings.ToLower(RelatorCodeFromURI(role))
+	if _, ok := MARCRelators[code]; ok {
+		return code
+	}
 
-	// Check if it's a known MARC code
-	lowerCode := strings.ToLower(code)
-	if _, ok := MARCRelators[lowerCode]; ok {
-		return lowerCode
+	key := roleKey(role)
+	if code, ok := relatorIndex[key]; ok {
+		return code
+	}
+	if code, ok := relatorIndex[strings.TrimSuffix(key, "s")]; ok {
+		return code
 	}
 
-	// Try to match by label
-	lowerRole := strings.ToLower(role)
-	for c, label := range MARCRelators {
-		if strings.ToLower(label) == lowerRole {
-			return c
+	// Allow one typo in longer labels, if only one label is that close
+	if len(key) < 6 {
+		return ""
+	}
+	match := ""
+	for label, code := range relatorIndex {
+		if withinOneEdit(key, label) {
+			if match != "" && match != code {
+				return ""
+			}
+			match = code
 		}
 	}
+	return match
+}
+
+// roleKey lowercases a role and drops its punctuation, so "Author of
+// introduction, etc." and "author of introduction etc" compare equal.
+func roleKey(role string) string {
+	role = strings.Map(func(r rune) rune {
+		switch r {
+		case '.', ',', ';', ':', '(', ')', '[', ']':
+			return ' '
+		}
+		return r
+	}, strings.ToLower(role))
+	role = strings.Join(strings.Fields(role), " ")
+	return strings.ReplaceAll(role, "adviser", "advisor")
+}
 
-	// Handle some common aliases
-	aliases := map[string]string{
-		"author":           "aut",
-		"authors":          "aut",
-		"creator":          "cre",
-		"creators":         "cre",
-		"editor":           "edt",
-		"editors":          "edt",
-		"translator":       "trl",
-		"contributor":      "ctb",
-		"photographer":     "pht",
-		"illustrator":      "ill",
-		"advisor":          "ths",
-		"thesis advisor":   "ths",
-		"committee":        "dgc",
-		"committee member": "dgc",
-		"publisher":        "pbl",
-		"funder":           "fnd",
-		"sponsor":          "spn",
+// withinOneEdit reports whether a and b differ by at most one inserted,
+// deleted, or substituted byte.
+func withinOneEdit(a, b string) bool {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	if len(b)-len(a) > 1 {
+		return false
 	}
+	i := 0
+	for i < len(a) && a[i] == b[i] {
+		i++
+	}
+	if len(a) == len(b) {
+		return a[i+min(1, len(a)-i):] == b[i+min(1, len(b)-i):]
+	}
+	return a[i:] == b[i+1:]
+}
 
-	if normalized, ok := aliases[lowerRole]; ok {
-		return normalized
+// NormalizeRole normalizes a role string to a canonical form.
+// Accepts MARC codes, URIs, or plain text labels.
+func NormalizeRole(role string) string {
+	if code := RelatorCode(role); code != "" {
+		return code
 	}
 
 	// Return original if we can't normalize
-	return role
+	return strings.TrimSpace(role)
 }
 
 // IsCreatorRole returns true if the role is a primary creator role.
@@ -0,0 +1,50 @@
+package helpers
+
+import "testing"
+
+func TestRelatorCode(t *testing.T) {
+	for role, want := range map[string]string{
+		"aut":          "aut",
+		"relators:THS": "ths",
+		"http://id.loc.gov/vocabulary/relators/dgg": "dgg",
+		"Thesis advisor":               "ths",
+		"thesis adviser.":              "ths",
+		"Editors":                      "edt",
+		"author,":                      "aut",
+		"ed.":                          "edt",
+		"Author of introduction":       "aui",
+		"Author of introduction, etc.": "aui",
+		"Degree granting institution":  "dgg",
+		"Photograper":                  "pht",
+		"Host":                         "hst",
+		"Software developer":           "swd",
+		"Writer":                       "",
+		"":                             "",
+	} {
+		if got := RelatorCode(role); got != want {
+			t.Errorf("RelatorCode(%q) = %q, want %q", role, got, want)
+		}
+	}
+}
+
+func TestRelatorLabel(t *testing.T) {
+	if len(MARCRelators) < 250 {
+		t.Errorf("expected the full relator vocabulary, got %d codes", len(MARCRelators))
+	}
+	for code, want := range map[string]string{
+		"aut":          "Author",
+		"relators:dgs": "Degree supervisor",
+		"http://id.loc.gov/vocabulary/relators/rth": "Research team head",
+		"xyz": "xyz",
+	} {
+		if got := RelatorLabel(code); got != want {
+			t.Errorf("RelatorLabel(%q) = %q, want %q", code, got, want)
+		}
+	}
+	// Every label maps back to its own code
+	for code, label := range MARCRelators {
+		if got := RelatorCode(label); got != code {
+			t.Errorf("RelatorCode(%q) = %q, want %q", label, got, code)
+		}
+	}
+}
@@ -0,0 +1,449 @@
+package hub
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+)
+
+// ValidationProfile declares what a record needs before it is complete
+// enough for a target, such as the properties DataCite requires to mint a
+// DOI. Fields are named by hub proto paths: "title", "degree_info.institution",
+// and, for repeated fields with a type, the type's short name, as in
+// "identifiers.doi", "dates.issued", or "relations.member_of".
+type ValidationProfile struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description,omitempty"`
+
+	// Checks are the structural checks run before the profile's rules
+	Checks ValidationOptions `yaml:"checks"`
+
+	// Required lists fields every record must populate. An entry of
+	// alternatives separated by "|" is satisfied by any one of them.
+	Required []string `yaml:"required,omitempty"`
+
+	// Conditions require fields only of records that match them
+	Conditions []ValidationCondition `yaml:"conditions,omitempty"`
+
+	// Constraints restrict the values of fields that are populated
+	Constraints []ValueConstraint `yaml:"constraints,omitempty"`
+}
+
+// ValidationCondition requires fields of the records that populate When,
+// or, with Is, of those where a value at When is one of Is.
+type ValidationCondition struct {
+	When    string   `yaml:"when"`
+	Is      []string `yaml:"is,omitempty"`
+	Require []string `yaml:"require"`
+}
+
+// ValueConstraint restricts every value at Field. Enum values are
+// compared by short name (e.g. "article" for RESOURCE_TYPE_ARTICLE).
+type ValueConstraint struct {
+	Field string `yaml:"field"`
+
+	// OneOf lists the allowed values
+	OneOf []string `yaml:"one_of,omitempty"`
+
+	// Pattern is a regular expression each value must match
+	Pattern string `yaml:"pattern,omitempty"`
+
+	// MaxLength is the longest allowed value, in characters
+	MaxLength int `yaml:"max_length,omitempty"`
+
+	re *regexp.Regexp
+}
+
+// Compile checks that every field the profile names exists in the hub
+// schema and compiles its patterns. Profiles loaded from configuration
+// should be compiled before use.
+func (p *ValidationProfile) Compile() error {
+	var paths []string
+	for _, req := range p.Required {
+		paths = append(paths, strings.Split(req, "|")...)
+	}
+	for _, c := range p.Conditions {
+		if len(c.Require) == 0 {
+			return fmt.Errorf("profile %s: condition on %s requires nothing", p.Name, c.When)
+		}
+		paths = append(paths, c.When)
+		for _, req := range c.Require {
+			paths = append(paths, strings.Split(req, "|")...)
+		}
+	}
+	for _, path := range paths {
+		if _, err := resolvePath(path); err != nil {
+			return fmt.Errorf("profile %s: %w", p.Name, err)
+		}
+	}
+
+	for i := range p.Constraints {
+		c := &p.Constraints[i]
+		fd, err := resolvePath(c.Field)
+		if err != nil {
+			return fmt.Errorf("profile %s: %w", p.Name, err)
+		}
+		if fd.Kind() == protoreflect.MessageKind {
+			return fmt.Errorf("profile %s: constraint on %s: field is a message, not a value", p.Name, c.Field)
+		}
+		if c.Pattern != "" {
+			if c.re, err = regexp.Compile(c.Pattern); err != nil {
+				return fmt.Errorf("profile %s: constraint on %s: %w", p.Name, c.Field, err)
+			}
+		}
+	}
+	return nil
+}
+
+// builtinProfiles are the completeness profiles available by name.
+var builtinProfiles = map[string]*ValidationProfile{
+	"default": {
+		Name:        "default",
+		Description: "A title, and well-formed identifiers and dates",
+		Checks:      DefaultValidationOptions(),
+	},
+	"strict": {
+		Name:        "strict",
+		Description: "A title, identifier, contributor, and date, all well-formed",
+		Checks:      StrictValidationOptions(),
+	},
+	"datacite-mandatory": {
+		Name:        "datacite-mandatory",
+		Description: "DataCite mandatory properties: DOI, creator, title, publisher, publication year, and resource type",
+		Checks:      DefaultValidationOptions(),
+		Required: []string{
+			"identifiers.doi",
+			"contributors",
+			"title",
+			"publisher",
+			"dates.issued|dates.published",
+			"resource_type.type",
+		},
+		Constraints: []ValueConstraint{
+			// DataCite takes IETF BCP 47 language tags
+			{Field: "language", Pattern: `^[A-Za-z]{2,3}(-[A-Za-z0-9]{1,8})*$`},
+		},
+	},
+	"crossref-deposit": {
+		Name:        "crossref-deposit",
+		Description: "Crossref deposit: DOI, resource URL, contributor, title, publication date, and the container or institution the work type needs",
+		Checks:      DefaultValidationOptions(),
+		Required: []string{
+			"identifiers.doi",
+			"identifiers.url",
+			"contributors",
+			"title",
+			"dates.issued|dates.published",
+			"resource_type.type",
+		},
+		Conditions: []ValidationCondition{
+			{When: "resource_type.type", Is: []string{"article", "book_chapter", "conference_paper"}, Require: []string{"publication.title"}},
+			{When: "resource_type.type", Is: []string{"dissertation", "thesis"}, Require: []string{"degree_info.institution"}},
+		},
+	},
+	"islandora-minimum": {
+		Name:        "islandora-minimum",
+		Description: "Islandora ingest: a title that fits a Drupal node and a resource type to pick the model",
+		Checks:      DefaultValidationOptions(),
+		Required: []string{
+			"title",
+			"resource_type.type",
+		},
+		Constraints: []ValueConstraint{
+			// Drupal node titles are a 255-character column
+			{Field: "title", MaxLength: 255},
+		},
+	},
+}
+
+func init() {
+	for _, p := range builtinProfiles {
+		if err := p.Compile(); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// GetValidationProfile returns a built-in completeness profile by name. A
+// name without its suffix finds the one profile starting with it, so
+// "datacite" finds "datacite-mandatory".
+func GetValidationProfile(name string) (*ValidationProfile, bool) {
+	if p, ok := builtinProfiles[name]; ok {
+		return p, true
+	}
+	var found *ValidationProfile
+	for key, p := range builtinProfiles {
+		if strings.HasPrefix(key, name+"-") {
+			if found != nil {
+				return nil, false
+			}
+			found = p
+		}
+	}
+	return found, found != nil
+}
+
+// ValidationProfiles returns the names of the built-in completeness
+// profiles, sorted.
+func ValidationProfiles() []string {
+	names := make([]string, 0, len(builtinProfiles))
+	for name := range builtinProfiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// checkProfile applies the profile's rules to a record.
+func checkProfile(record *hubv1.Record, p *ValidationProfile, result *ValidationResult) {
+	msg := record.ProtoReflect()
+
+	for _, req := range p.Required {
+		if !anyPresent(msg, req) {
+			addRequired(result, requiredError(req, ""))
+		}
+	}
+
+	for _, c := range p.Conditions {
+		values := fieldValues(msg, c.When)
+		matched, label := len(values) > 0, c.When+" is present"
+		if len(c.Is) > 0 {
+			matched = false
+			for _, v := range values {
+				if s, ok := valueString(v); ok && containsFold(c.Is, s) {
+					matched, label = true, fmt.Sprintf("%s is %s", c.When, s)
+					break
+				}
+			}
+		}
+		if !matched {
+			continue
+		}
+		for _, req := range c.Require {
+			if !anyPresent(msg, req) {
+				addRequired(result, requiredError(req, label))
+			}
+		}
+	}
+
+	for _, c := range p.Constraints {
+		for _, v := range fieldValues(msg, c.Field) {
+			s, ok := valueString(v)
+			if !ok {
+				continue
+			}
+			if err := c.check(s); err != "" {
+				result.Errors = append(result.Errors, ValidationError{
+					Field:   c.Field,
+					Code:    "invalid_value",
+					Message: c.Field + " " + err,
+				})
+			}
+		}
+	}
+}
+
+// check returns why s breaks the constraint, or "" if it doesn't.
+func (c *ValueConstraint) check(s string) string {
+	if len(c.OneOf) > 0 && !containsFold(c.OneOf, s) {
+		return fmt.Sprintf("%q is not one of %s", s, strings.Join(c.OneOf, ", "))
+	}
+	if c.Pattern != "" {
+		re := c.re
+		if re == nil {
+			var err error
+			if re, err = regexp.Compile(c.Pattern); err != nil {
+				return fmt.Sprintf("invalid pattern %q: %v", c.Pattern, err)
+			}
+		}
+		if !re.MatchString(s) {
+			return fmt.Sprintf("%q does not match %s", s, c.Pattern)
+		}
+	}
+	if n := utf8.RuneCountInString(s); c.MaxLength > 0 && n > c.MaxLength {
+		return fmt.Sprintf("is %d characters, over the limit of %d", n, c.MaxLength)
+	}
+	return ""
+}
+
+// addRequired adds a missing-field error unless the structural checks
+// already reported the field missing.
+func addRequired(result *ValidationResult, e ValidationError) {
+	for _, existing := range result.Errors {
+		if existing.Field == e.Field && existing.Code == e.Code {
+			return
+		}
+	}
+	result.Errors = append(result.Errors, e)
+}
+
+func requiredError(req, when string) ValidationError {
+	alts := strings.Split(req, "|")
+	msg := alts[0] + " is required"
+	if len(alts) > 1 {
+		msg = "one of " + strings.Join(alts, ", ") + " is required"
+	}
+	if when != "" {
+		msg += " when " + when
+	}
+	return ValidationError{Field: alts[0], Code: "required", Message: msg}
+}
+
+// anyPresent reports whether the record populates any of the "|"
+// separated alternatives in req.
+func anyPresent(msg protoreflect.Message, req string) bool {
+	for _, path := range strings.Split(req, "|") {
+		if len(fieldValues(msg, path)) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// pathValue is a value found at a field path and the field holding it.
+type pathValue struct {
+	fd protoreflect.FieldDescriptor
+	v  protoreflect.Value
+}
+
+// fieldValues returns the populated values at path, walking every element
+// of repeated fields. Empty strings, zero numbers, unspecified enums, and
+// empty messages count as unpopulated.
+func fieldValues(msg protoreflect.Message, path string) []pathValue {
+	values := []pathValue{{v: protoreflect.ValueOfMessage(msg)}}
+	for _, seg := range strings.Split(path, ".") {
+		var next []pathValue
+		for _, pv := range values {
+			if pv.fd != nil && pv.fd.Kind() != protoreflect.MessageKind {
+				continue
+			}
+			m := pv.v.Message()
+			fd := m.Descriptor().Fields().ByName(protoreflect.Name(seg))
+			if fd == nil {
+				// A type qualifier keeps the elements of that type
+				if typeName(m) == seg {
+					next = append(next, pv)
+				}
+				continue
+			}
+			if !m.Has(fd) {
+				continue
+			}
+			val := m.Get(fd)
+			if fd.IsList() {
+				list := val.List()
+				for i := 0; i < list.Len(); i++ {
+					if populated(fd, list.Get(i)) {
+						next = append(next, pathValue{fd, list.Get(i)})
+					}
+				}
+			} else if populated(fd, val) {
+				next = append(next, pathValue{fd, val})
+			}
+		}
+		values = next
+	}
+	return values
+}
+
+// resolvePath checks path against the hub schema and returns the field it
+// ends on.
+func resolvePath(path string) (protoreflect.FieldDescriptor, error) {
+	md := (&hubv1.Record{}).ProtoReflect().Descriptor()
+	var last protoreflect.FieldDescriptor
+	for _, seg := range strings.Split(path, ".") {
+		if md == nil {
+			return nil, fmt.Errorf("unknown field %s: %s has no fields", path, last.Name())
+		}
+		if fd := md.Fields().ByName(protoreflect.Name(seg)); fd != nil {
+			last, md = fd, fd.Message()
+			continue
+		}
+		if last == nil || !hasTypeValue(md, seg) {
+			return nil, fmt.Errorf("unknown field %s", path)
+		}
+	}
+	return last, nil
+}
+
+func populated(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return proto.Size(v.Message().Interface()) > 0
+	case protoreflect.StringKind:
+		return strings.TrimSpace(v.String()) != ""
+	case protoreflect.EnumKind:
+		return v.Enum() != 0
+	default:
+		return v.IsValid() && !v.Equal(fd.Default())
+	}
+}
+
+// valueString renders a scalar value for comparison, with enums by short
+// name. Messages have no string form.
+func valueString(pv pathValue) (string, bool) {
+	switch pv.fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return "", false
+	case protoreflect.EnumKind:
+		return enumShortName(pv.fd.Enum(), pv.v.Enum()), true
+	case protoreflect.StringKind:
+		return strings.TrimSpace(pv.v.String()), true
+	default:
+		return fmt.Sprint(pv.v.Interface()), true
+	}
+}
+
+// typeName returns the short name of the message's "type" enum, or "".
+func typeName(m protoreflect.Message) string {
+	fd := m.Descriptor().Fields().ByName("type")
+	if fd == nil || fd.Kind() != protoreflect.EnumKind {
+		return ""
+	}
+	return enumShortName(fd.Enum(), m.Get(fd).Enum())
+}
+
+// hasTypeValue reports whether md has a "type" enum with a value named seg.
+func hasTypeValue(md protoreflect.MessageDescriptor, seg string) bool {
+	fd := md.Fields().ByName("type")
+	if fd == nil || fd.Kind() != protoreflect.EnumKind {
+		return false
+	}
+	values := fd.Enum().Values()
+	for i := 0; i < values.Len(); i++ {
+		if enumShortName(fd.Enum(), values.Get(i).Number()) == seg {
+			return true
+		}
+	}
+	return false
+}
+
+// enumShortName names an enum value without the prefix its zero value
+// carries, lowercased: RESOURCE_TYPE_BOOK_CHAPTER is "book_chapter".
+func enumShortName(ed protoreflect.EnumDescriptor, n protoreflect.EnumNumber) string {
+	v := ed.Values().ByNumber(n)
+	if v == nil {
+		return ""
+	}
+	prefix := ""
+	if zero := ed.Values().ByNumber(0); zero != nil {
+		prefix = strings.TrimSuffix(string(zero.Name()), "UNSPECIFIED")
+	}
+	return strings.ToLower(strings.TrimPrefix(string(v.Name()), prefix))
+}
+
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
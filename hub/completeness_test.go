@@ -0,0 +1,138 @@
+package hub
+
+import (
+	"strings"
+	"testing"
+
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+)
+
+func dataciteReady() *hubv1.Record {
+	return &hubv1.Record{
+		Title:        "Sediment transport in the Lehigh Gap",
+		Contributors: []*hubv1.Contributor{{Name: "Smith, Jane"}},
+		Publisher:    "Lehigh University",
+		Identifiers: []*hubv1.Identifier{
+			{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_DOI, Value: "10.1234/abcd"},
+		},
+		Dates:        []*hubv1.DateValue{{Type: hubv1.DateType_DATE_TYPE_PUBLISHED, Year: 2024}},
+		ResourceType: &hubv1.ResourceType{Type: hubv1.ResourceTypeValue_RESOURCE_TYPE_DATASET},
+		Language:     "en",
+	}
+}
+
+func TestValidateProfile(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile string
+		edit    func(*hubv1.Record)
+		want    []string // fields with errors
+	}{
+		{"complete", "datacite-mandatory", func(*hubv1.Record) {}, nil},
+		{
+			"missing DOI and publisher", "datacite-mandatory",
+			func(r *hubv1.Record) {
+				r.Identifiers[0].Type = hubv1.IdentifierType_IDENTIFIER_TYPE_HANDLE
+				r.Publisher = ""
+			},
+			[]string{"identifiers.doi", "publisher"},
+		},
+		{
+			"no publication date", "datacite-mandatory",
+			func(r *hubv1.Record) { r.Dates[0].Type = hubv1.DateType_DATE_TYPE_CREATED },
+			[]string{"dates.issued"},
+		},
+		{
+			"missing title reported once", "datacite-mandatory",
+			func(r *hubv1.Record) { r.Title = " " },
+			[]string{"title"},
+		},
+		{
+			"language not a BCP 47 tag", "datacite-mandatory",
+			func(r *hubv1.Record) { r.Language = "English (US)" },
+			[]string{"language"},
+		},
+		{
+			"thesis without institution", "crossref-deposit",
+			func(r *hubv1.Record) {
+				r.ResourceType.Type = hubv1.ResourceTypeValue_RESOURCE_TYPE_THESIS
+				r.Identifiers = append(r.Identifiers, &hubv1.Identifier{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_URL, Value: "https://example.edu/1"})
+			},
+			[]string{"degree_info.institution"},
+		},
+		{
+			"dataset needs no container", "crossref-deposit",
+			func(r *hubv1.Record) {
+				r.Identifiers = append(r.Identifiers, &hubv1.Identifier{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_URL, Value: "https://example.edu/1"})
+			},
+			nil,
+		},
+		{
+			"title too long for a node", "islandora-minimum",
+			func(r *hubv1.Record) { r.Title = strings.Repeat("a", 256) },
+			[]string{"title"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile, ok := GetValidationProfile(tt.profile)
+			if !ok {
+				t.Fatalf("no profile %s", tt.profile)
+			}
+			record := dataciteReady()
+			tt.edit(record)
+
+			var got []string
+			for _, e := range Validate(record, profile).Errors {
+				got = append(got, e.Field)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("error fields: got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateConditionMessage(t *testing.T) {
+	profile := &ValidationProfile{
+		Name: "test",
+		Conditions: []ValidationCondition{
+			{When: "resource_type.type", Is: []string{"article"}, Require: []string{"publication.title|publication.issn"}},
+		},
+	}
+	if err := profile.Compile(); err != nil {
+		t.Fatal(err)
+	}
+	record := &hubv1.Record{ResourceType: &hubv1.ResourceType{Type: hubv1.ResourceTypeValue_RESOURCE_TYPE_ARTICLE}}
+	result := Validate(record, profile)
+	if len(result.Errors) != 1 {
+		t.Fatalf("expected one error, got %v", result.Errors)
+	}
+	want := "one of publication.title, publication.issn is required when resource_type.type is article"
+	if result.Errors[0].Message != want {
+		t.Errorf("message: got %q, want %q", result.Errors[0].Message, want)
+	}
+}
+
+func TestCompileRejectsUnknownFields(t *testing.T) {
+	for _, p := range []*ValidationProfile{
+		{Name: "typo", Required: []string{"titel"}},
+		{Name: "bad type", Required: []string{"identifiers.nope"}},
+		{Name: "scalar path", Required: []string{"title.value"}},
+		{Name: "message constraint", Constraints: []ValueConstraint{{Field: "resource_type", OneOf: []string{"book"}}}},
+		{Name: "bad pattern", Constraints: []ValueConstraint{{Field: "language", Pattern: "("}}},
+	} {
+		if err := p.Compile(); err == nil {
+			t.Errorf("%s: expected an error", p.Name)
+		}
+	}
+}
+
+func TestGetValidationProfile(t *testing.T) {
+	if p, ok := GetValidationProfile("datacite"); !ok || p.Name != "datacite-mandatory" {
+		t.Errorf("datacite: got %v, %v", p, ok)
+	}
+	if _, ok := GetValidationProfile("nope"); ok {
+		t.Error("expected no profile named nope")
+	}
+}
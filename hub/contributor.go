@@ -49,12 +49,13 @@ func ParsedNameInverted(p *hubv1.ParsedName) string {
 }
 
 // ParsedNameDirect returns the name in "Given Middle Family Suffix" format.
+// A prefix already leading the family name ("van der Berg") is not repeated.
 func ParsedNameDirect(p *hubv1.ParsedName) string {
 	if p == nil {
 		return ""
 	}
 	var parts []string
-	if p.Prefix != "" {
+	if p.Prefix != "" && !strings.HasPrefix(p.Family, p.Prefix+" ") {
 		parts = append(parts, p.Prefix)
 	}
 	if p.Given != "" {
@@ -71,3 +72,25 @@ func ParsedNameDirect(p *hubv1.ParsedName) string {
 	}
 	return strings.Join(parts, " ")
 }
+
+// DegreeRoleOf returns the contributor's part in a thesis or dissertation's
+// degree: its DegreeRole, or one read from its role or MARC relator code
+// (ths and dgs for advisors, dgc for committee members).
+func DegreeRoleOf(c *hubv1.Contributor) hubv1.DegreeRole {
+	if c.DegreeRole != hubv1.DegreeRole_DEGREE_ROLE_UNSPECIFIED {
+		return c.DegreeRole
+	}
+	for _, role := range []string{c.RoleCode, c.Role} {
+		role = strings.ToLower(strings.TrimSpace(role))
+		if i := strings.LastIndexAny(role, ":/"); i >= 0 {
+			role = role[i+1:]
+		}
+		switch role {
+		case "ths", "dgs", "advisor", "thesis advisor", "degree supervisor", "supervisor":
+			return hubv1.DegreeRole_DEGREE_ROLE_ADVISOR
+		case "dgc", "committee", "committee member", "committee_member", "degree committee member":
+			return hubv1.DegreeRole_DEGREE_ROLE_COMMITTEE_MEMBER
+		}
+	}
+	return hubv1.DegreeRole_DEGREE_ROLE_UNSPECIFIED
+}
@@ -168,21 +168,47 @@ func (c *Converter) processField(result *ConversionResult, msgRef protoreflect.M
 	// Convert protoreflect.Value to Go value
 	goValue := c.protoValueToGo(value, fd)
 
-	// Apply parser if specified
+	// Apply transformation options
+	goValue, err := applyTransforms(goValue, opts)
+	if err != nil {
+		return &ConversionError{
+			Field:   mapping.Name,
+			Message: "transform failed",
+			Cause:   err,
+		}
+	}
+	if goValue == nil {
+		return nil
+	}
+
+	// Apply parser if specified, to each value of a list
 	if opts.Parser != "" {
 		parserOpts := &ParserOptions{
 			DateFormat: opts.DateFormat,
 			Delimiter:  opts.Delimiter,
 		}
-		parsed, err := c.parsers.Parse(opts.Parser, fmt.Sprintf("%v", goValue), parserOpts)
-		if err != nil {
-			return &ConversionError{
-				Field:   mapping.Name,
-				Message: "parser failed",
-				Cause:   err,
+		parse := func(v any) (any, error) {
+			parsed, err := c.parsers.Parse(opts.Parser, fmt.Sprintf("%v", v), parserOpts)
+			if err != nil {
+				return nil, &ConversionError{
+					Field:   mapping.Name,
+					Message: "parser failed",
+					Cause:   err,
+				}
 			}
+			return parsed, nil
+		}
+		if list, ok := goValue.([]any); ok {
+			parsed := make([]any, len(list))
+			for i, item := range list {
+				if parsed[i], err = parse(item); err != nil {
+					return err
+				}
+			}
+			goValue = parsed
+		} else if goValue, err = parse(goValue); err != nil {
+			return err
 		}
-		goValue = parsed
 	}
 
 	// Apply validators if specified
@@ -536,18 +562,16 @@ func (c *Converter) mapDates(record *hubv1.Record, value any, mapping FieldMappi
 		}
 	}
 
-	// Handle string values
-	str := toString(value)
-	if str == "" {
-		return nil
-	}
-
-	dateValue := &hubv1.DateValue{
-		Type: dateType,
-		Raw:  str,
+	for _, item := range listOf(value) {
+		str := toString(item)
+		if str == "" {
+			continue
+		}
+		record.Dates = append(record.Dates, &hubv1.DateValue{
+			Type: dateType,
+			Raw:  str,
+		})
 	}
-
-	record.Dates = append(record.Dates, dateValue)
 	return nil
 }
 
@@ -563,17 +587,16 @@ func (c *Converter) mapIdentifiers(record *hubv1.Record, value any, mapping Fiel
 		}
 	}
 
-	str := toString(value)
-	if str == "" {
-		return nil
-	}
-
-	identifier := &hubv1.Identifier{
-		Type:  idType,
-		Value: str,
+	for _, item := range listOf(value) {
+		str := toString(item)
+		if str == "" {
+			continue
+		}
+		record.Identifiers = append(record.Identifiers, &hubv1.Identifier{
+			Type:  idType,
+			Value: str,
+		})
 	}
-
-	record.Identifiers = append(record.Identifiers, identifier)
 	return nil
 }
 
@@ -627,17 +650,16 @@ func (c *Converter) mapRelations(record *hubv1.Record, value any, mapping FieldM
 		}
 	}
 
-	str := toString(value)
-	if str == "" {
-		return nil
-	}
-
-	relation := &hubv1.Relation{
-		Type:        relType,
-		TargetTitle: str,
+	for _, item := range listOf(value) {
+		str := toString(item)
+		if str == "" {
+			continue
+		}
+		record.Relations = append(record.Relations, &hubv1.Relation{
+			Type:        relType,
+			TargetTitle: str,
+		})
 	}
-
-	record.Relations = append(record.Relations, relation)
 	return nil
 }
 
@@ -1,8 +1,16 @@
 package convert
 
 import (
+	"slices"
 	"testing"
 
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
 	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
 	bibtexv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/spoke/bibtex/v1"
 )
@@ -502,3 +510,85 @@ func TestConversionError(t *testing.T) {
 		t.Error("Unwrap() should return cause")
 	}
 }
+
+func TestConverter_ToHub_Transforms(t *testing.T) {
+	msg := annotatedMessage(t,
+		annotatedField{name: "title_parts", repeated: true, opts: &hubv1.FieldOptions{Target: "title", Join: ": "}},
+		annotatedField{name: "doi", opts: &hubv1.FieldOptions{
+			Target:         "identifiers",
+			IdentifierType: "doi",
+			Split:          ";",
+			RegexExtract:   `10\..*`,
+			Prefix:         "https://doi.org/",
+		}},
+		annotatedField{name: "note", opts: &hubv1.FieldOptions{Target: "notes", Transform: []string{"s/^Digitized: //", "uppercase"}}},
+	)
+	md := msg.Descriptor()
+	parts := msg.Mutable(md.Fields().ByName("title_parts")).List()
+	parts.Append(protoreflect.ValueOfString("Crosswalks"))
+	parts.Append(protoreflect.ValueOfString("A History"))
+	msg.Set(md.Fields().ByName("doi"), protoreflect.ValueOfString("doi:10.1234/a; not a doi ; https://doi.org/10.5678/b"))
+	msg.Set(md.Fields().ByName("note"), protoreflect.ValueOfString("Digitized: may 2020"))
+
+	result, err := NewConverter().ToHub(msg)
+	if err != nil {
+		t.Fatalf("ToHub error: %v", err)
+	}
+	if got := result.Record.Title; got != "Crosswalks: A History" {
+		t.Errorf("Title = %q, want joined parts", got)
+	}
+	var dois []string
+	for _, id := range result.Record.Identifiers {
+		if id.Type == hubv1.IdentifierType_IDENTIFIER_TYPE_DOI {
+			dois = append(dois, id.Value)
+		}
+	}
+	want := []string{"https://doi.org/10.1234/a", "https://doi.org/10.5678/b"}
+	if !slices.Equal(dois, want) {
+		t.Errorf("DOIs = %v, want %v", dois, want)
+	}
+	if got := result.Record.Notes; !slices.Equal(got, []string{"MAY 2020"}) {
+		t.Errorf("Notes = %v, want [MAY 2020]", got)
+	}
+}
+
+// annotatedField is a string field of a message from annotatedMessage.
+type annotatedField struct {
+	name     string
+	repeated bool
+	opts     *hubv1.FieldOptions
+}
+
+// annotatedMessage returns an empty message whose string fields have the
+// given hub.v1.field annotations.
+func annotatedMessage(t *testing.T, fields ...annotatedField) *dynamicpb.Message {
+	t.Helper()
+	dp := &descriptorpb.DescriptorProto{Name: proto.String("Record")}
+	for i, f := range fields {
+		opts := &descriptorpb.FieldOptions{}
+		proto.SetExtension(opts, hubv1.E_Field, f.opts)
+		label := descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL
+		if f.repeated {
+			label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+		}
+		dp.Field = append(dp.Field, &descriptorpb.FieldDescriptorProto{
+			Name:    proto.String(f.name),
+			Number:  proto.Int32(int32(i + 1)),
+			Type:    descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+			Label:   label.Enum(),
+			Options: opts,
+		})
+	}
+	fdp := &descriptorpb.FileDescriptorProto{
+		Name:        proto.String("annotated.proto"),
+		Package:     proto.String("test"),
+		Syntax:      proto.String("proto3"),
+		Dependency:  []string{"hub/v1/options.proto"},
+		MessageType: []*descriptorpb.DescriptorProto{dp},
+	}
+	fd, err := protodesc.NewFile(fdp, protoregistry.GlobalFiles)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dynamicpb.NewMessage(fd.Messages().ByName("Record"))
+}
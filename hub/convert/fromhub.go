@@ -0,0 +1,394 @@
+package convert
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+)
+
+// FromHub fills a spoke proto message from a Hub Record using annotations,
+// the reverse of ToHub. A field takes the values of its target that its
+// date_type, identifier_type, role, subject_vocabulary or relation_type
+// selects; a field without one takes the values no other field of the
+// message selects. Values pass through the field's serializer, and a
+// singular field given several keeps the first, or joins them when it has
+// a join_delimiter or the join serializer. Transformation options only
+// apply toward the Hub.
+//
+// Fields that can't hold their values are reported together in the
+// returned error, after the rest of the message is filled.
+func (c *Converter) FromHub(record *hubv1.Record, msg proto.Message) error {
+	msgOpts := GetMessageOptions(msg)
+	if msgOpts != nil && msgOpts.Target != "Record" && msgOpts.Target != "" {
+		return fmt.Errorf("message target %q is not supported; expected 'Record'", msgOpts.Target)
+	}
+
+	mappings := GetMappedFields(msg)
+	claimed := claimedFilters(mappings)
+	msgRef := msg.ProtoReflect()
+
+	var errs []error
+	for _, mapping := range mappings {
+		values := hubValues(record, mapping, claimed[mapping.Options.Target])
+		if len(values) == 0 {
+			continue
+		}
+		if err := c.setField(msgRef, mapping, values); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// hubFilter returns the option selecting which of its target's values a
+// field takes: the role of contributors, the type of dates, identifiers
+// and relations, and the vocabulary of subjects.
+func hubFilter(opts *hubv1.FieldOptions) string {
+	switch opts.Target {
+	case "contributors":
+		return opts.Role
+	case "dates":
+		return opts.DateType
+	case "identifiers":
+		return opts.IdentifierType
+	case "subjects":
+		return opts.SubjectVocabulary
+	case "relations":
+		return opts.RelationType
+	}
+	return ""
+}
+
+// claimedFilters returns, by target, the filters the message's fields
+// declare, whose values fields without a filter leave alone.
+func claimedFilters(mappings []FieldMapping) map[string]map[string]bool {
+	claimed := make(map[string]map[string]bool)
+	for _, m := range mappings {
+		filter := hubFilter(m.Options)
+		if filter == "" {
+			continue
+		}
+		if claimed[m.Options.Target] == nil {
+			claimed[m.Options.Target] = make(map[string]bool)
+		}
+		claimed[m.Options.Target][strings.ToLower(filter)] = true
+	}
+	return claimed
+}
+
+// hubValues returns the Record's values for a field's target that the
+// field selects.
+func hubValues(record *hubv1.Record, mapping FieldMapping, claimed map[string]bool) []any {
+	opts := mapping.Options
+	filter := hubFilter(opts)
+	selects := func(have string) bool {
+		if filter != "" {
+			return strings.EqualFold(have, filter)
+		}
+		return !claimed[strings.ToLower(have)]
+	}
+
+	var values []any
+	add := func(s string) {
+		if s != "" {
+			values = append(values, s)
+		}
+	}
+
+	target := opts.Target
+	if prefix, field, ok := strings.Cut(target, "."); ok {
+		if prefix == "degree_info" {
+			if di := record.DegreeInfo; di != nil {
+				switch field {
+				case "institution":
+					add(di.Institution)
+				case "degree_name":
+					add(di.DegreeName)
+				case "department":
+					add(di.Department)
+				}
+			}
+			return values
+		}
+		return extraValues(record, target)
+	}
+
+	switch target {
+	case "title":
+		add(record.Title)
+	case "abstract":
+		add(record.Abstract)
+	case "publisher":
+		add(record.Publisher)
+	case "place_published":
+		add(record.PlacePublished)
+	case "language":
+		add(record.Language)
+	case "resource_type":
+		if rt := record.ResourceType; rt != nil && rt.Type != hubv1.ResourceTypeValue_RESOURCE_TYPE_UNSPECIFIED {
+			add(rt.Type.String())
+		}
+	case "contributors":
+		for _, contributor := range record.Contributors {
+			if selects(contributor.Role) {
+				values = append(values, contributor)
+			}
+		}
+	case "dates":
+		for _, d := range record.Dates {
+			if selects(enumKey(d.Type.String(), "DATE_TYPE_")) {
+				add(dateString(d))
+			}
+		}
+	case "identifiers":
+		for _, id := range record.Identifiers {
+			if selects(enumKey(id.Type.String(), "IDENTIFIER_TYPE_")) {
+				add(id.Value)
+			}
+		}
+	case "subjects":
+		for _, s := range record.Subjects {
+			if selects(enumKey(s.Vocabulary.String(), "SUBJECT_VOCABULARY_")) {
+				add(s.Value)
+			}
+		}
+	case "relations":
+		for _, r := range record.Relations {
+			if !selects(enumKey(r.Type.String(), "RELATION_TYPE_")) {
+				continue
+			}
+			if r.TargetTitle != "" {
+				add(r.TargetTitle)
+			} else {
+				add(r.TargetId)
+			}
+		}
+	case "notes":
+		for _, note := range record.Notes {
+			add(note)
+		}
+	default:
+		// Extra, and targets ToHub stores there, are keyed by field name
+		return extraValues(record, mapping.Name)
+	}
+	return values
+}
+
+// extraValues returns the items of a list in the Record's extra, or the
+// value of anything else.
+func extraValues(record *hubv1.Record, key string) []any {
+	if record.Extra == nil {
+		return nil
+	}
+	v, ok := record.Extra.Fields[key]
+	if !ok {
+		return nil
+	}
+	if list := v.GetListValue(); list != nil {
+		var values []any
+		for _, item := range list.Values {
+			values = append(values, item.AsInterface())
+		}
+		return values
+	}
+	return []any{v.AsInterface()}
+}
+
+// enumKey returns the annotation form of a Hub enum value name, e.g.
+// "issued" for DATE_TYPE_ISSUED.
+func enumKey(name, prefix string) string {
+	return strings.ToLower(strings.TrimPrefix(name, prefix))
+}
+
+// dateString returns a date as given, or from its parsed parts when it
+// has no raw form.
+func dateString(d *hubv1.DateValue) string {
+	switch {
+	case d.Raw != "":
+		return d.Raw
+	case d.Year == 0:
+		return ""
+	case d.Month == 0:
+		return fmt.Sprintf("%04d", d.Year)
+	case d.Day == 0:
+		return fmt.Sprintf("%04d-%02d", d.Year, d.Month)
+	}
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// setField sets a field of the spoke message to its Hub values.
+func (c *Converter) setField(msgRef protoreflect.Message, mapping FieldMapping, values []any) error {
+	fd := mapping.FieldDescriptor
+	opts := mapping.Options
+	if fd.IsMap() {
+		return &ConversionError{Field: mapping.Name, Message: "map fields are not supported"}
+	}
+
+	if fd.Kind() == protoreflect.MessageKind {
+		fill := func(m protoreflect.Message, v any) error {
+			if !populateMessageFromHub(m, v) {
+				return &ConversionError{Field: mapping.Name, Message: fmt.Sprintf("cannot set %T in a message field", v)}
+			}
+			return nil
+		}
+		if !fd.IsList() {
+			m := msgRef.NewField(fd).Message()
+			if err := fill(m, values[0]); err != nil {
+				return err
+			}
+			msgRef.Set(fd, protoreflect.ValueOfMessage(m))
+			return nil
+		}
+		list := msgRef.Mutable(fd).List()
+		for _, v := range values {
+			elem := list.NewElement()
+			if err := fill(elem.Message(), v); err != nil {
+				return err
+			}
+			list.Append(elem)
+		}
+		return nil
+	}
+
+	strs := make([]any, len(values))
+	for i, v := range values {
+		s, err := c.serialize(v, opts)
+		if err != nil {
+			return &ConversionError{Field: mapping.Name, Message: "serializer failed", Cause: err}
+		}
+		strs[i] = s
+	}
+
+	if !fd.IsList() {
+		s := strs[0].(string)
+		if len(strs) > 1 && (opts.JoinDelimiter != "" || opts.Serializer == "join") {
+			joined, err := c.serializers.Serialize("join", strs, c.serializerOptions(opts))
+			if err != nil {
+				return &ConversionError{Field: mapping.Name, Message: "serializer failed", Cause: err}
+			}
+			s = joined
+		}
+		pv, err := scalarValue(s, fd)
+		if err != nil {
+			return &ConversionError{Field: mapping.Name, Message: "invalid value", Cause: err}
+		}
+		msgRef.Set(fd, pv)
+		return nil
+	}
+
+	list := msgRef.Mutable(fd).List()
+	for _, s := range strs {
+		pv, err := scalarValue(s.(string), fd)
+		if err != nil {
+			return &ConversionError{Field: mapping.Name, Message: "invalid value", Cause: err}
+		}
+		list.Append(pv)
+	}
+	return nil
+}
+
+// serializerOptions returns the serializer options of a field.
+func (c *Converter) serializerOptions(opts *hubv1.FieldOptions) *SerializerOptions {
+	return &SerializerOptions{
+		DateFormat:    opts.DateFormat,
+		JoinDelimiter: opts.JoinDelimiter,
+	}
+}
+
+// serialize formats a Hub value with the field's serializer. Contributors
+// are their name, given to name serializers in parts when parsed.
+func (c *Converter) serialize(value any, opts *hubv1.FieldOptions) (string, error) {
+	if contributor, ok := value.(*hubv1.Contributor); ok {
+		value = contributor.Name
+		if pn := contributor.ParsedName; pn != nil && opts.Serializer != "" {
+			value = BibTeXName{Given: pn.Given, Family: pn.Family, Suffix: pn.Suffix}
+		}
+	}
+	if opts.Serializer == "" {
+		return toString(value), nil
+	}
+	return c.serializers.Serialize(opts.Serializer, value, c.serializerOptions(opts))
+}
+
+// scalarValue converts a serialized value to the kind of a spoke field.
+// Enum values match by their hub.v1.enum_value target or their name.
+func scalarValue(s string, fd protoreflect.FieldDescriptor) (protoreflect.Value, error) {
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(s), nil
+	case protoreflect.BytesKind:
+		return protoreflect.ValueOfBytes([]byte(s)), nil
+	case protoreflect.BoolKind:
+		b, err := strconv.ParseBool(s)
+		return protoreflect.ValueOfBool(b), err
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		n, err := strconv.ParseInt(s, 10, 32)
+		return protoreflect.ValueOfInt32(int32(n)), err
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		n, err := strconv.ParseInt(s, 10, 64)
+		return protoreflect.ValueOfInt64(n), err
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		n, err := strconv.ParseUint(s, 10, 32)
+		return protoreflect.ValueOfUint32(uint32(n)), err
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		n, err := strconv.ParseUint(s, 10, 64)
+		return protoreflect.ValueOfUint64(n), err
+	case protoreflect.FloatKind:
+		f, err := strconv.ParseFloat(s, 32)
+		return protoreflect.ValueOfFloat32(float32(f)), err
+	case protoreflect.DoubleKind:
+		f, err := strconv.ParseFloat(s, 64)
+		return protoreflect.ValueOfFloat64(f), err
+	case protoreflect.EnumKind:
+		values := fd.Enum().Values()
+		for i := 0; i < values.Len(); i++ {
+			ev := values.Get(i)
+			if opts := GetEnumValueOptions(ev); opts != nil && opts.Target == s {
+				return protoreflect.ValueOfEnum(ev.Number()), nil
+			}
+		}
+		for i := 0; i < values.Len(); i++ {
+			if ev := values.Get(i); strings.EqualFold(string(ev.Name()), s) {
+				return protoreflect.ValueOfEnum(ev.Number()), nil
+			}
+		}
+		return protoreflect.Value{}, fmt.Errorf("no %s value for %q", fd.Enum().FullName(), s)
+	}
+	return protoreflect.Value{}, fmt.Errorf("unsupported field kind %s", fd.Kind())
+}
+
+// populateMessageFromHub fills a spoke message from a Hub contributor, the
+// reverse of populateContributorFromMessage, reporting whether it could.
+func populateMessageFromHub(m protoreflect.Message, value any) bool {
+	contributor, ok := value.(*hubv1.Contributor)
+	if !ok {
+		return false
+	}
+	fields := m.Descriptor().Fields()
+	set := func(name, s string) {
+		if fd := fields.ByName(protoreflect.Name(name)); fd != nil && s != "" && fd.Kind() == protoreflect.StringKind && !fd.IsList() {
+			m.Set(fd, protoreflect.ValueOfString(s))
+		}
+	}
+
+	set("name", contributor.Name)
+	set("role", contributor.Role)
+	if pn := contributor.ParsedName; pn != nil {
+		set("given", pn.Given)
+		set("family", pn.Family)
+		set("suffix", pn.Suffix)
+	}
+	for _, id := range contributor.Identifiers {
+		if id.Type == hubv1.IdentifierType_IDENTIFIER_TYPE_ORCID {
+			set("orcid", id.Value)
+			break
+		}
+	}
+	return true
+}
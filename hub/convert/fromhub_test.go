@@ -0,0 +1,129 @@
+package convert
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	bibtexv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/spoke/bibtex/v1"
+)
+
+func TestConverter_FromHub_RoundTrip(t *testing.T) {
+	entry := &bibtexv1.Entry{
+		EntryType: bibtexv1.EntryType_ENTRY_TYPE_ARTICLE,
+		Title:     "Test Article Title",
+		Year:      "2023",
+		Doi:       "10.1234/test.doi",
+		Author: []*bibtexv1.Person{
+			{Name: "Smith, John", Given: "John", Family: "Smith", Orcid: "0000-0002-1825-0097"},
+			{Name: "Doe, Jane", Given: "Jane", Family: "Doe"},
+		},
+		Editor:    []*bibtexv1.Person{{Name: "Roe, Richard", Given: "Richard", Family: "Roe"}},
+		Keywords:  []string{"metadata", "crosswalks"},
+		Booktitle: "Collected Papers",
+		Volume:    "10",
+		Type:      "PhD Thesis",
+	}
+
+	c := NewConverter()
+	result, err := c.ToHub(entry)
+	if err != nil {
+		t.Fatalf("ToHub error: %v", err)
+	}
+	got := &bibtexv1.Entry{}
+	if err := c.FromHub(result.Record, got); err != nil {
+		t.Fatalf("FromHub error: %v", err)
+	}
+
+	// Fields sharing a target and filter all get its values: date and
+	// month are issued dates like year, and journal is part_of like
+	// booktitle
+	want := proto.Clone(entry).(*bibtexv1.Entry)
+	want.Date, want.Month, want.Journal = "2023", "2023", "Collected Papers"
+	if !proto.Equal(got, want) {
+		t.Errorf("round trip:\n got %v\nwant %v", got, want)
+	}
+}
+
+func TestConverter_FromHub_Filters(t *testing.T) {
+	msg := annotatedMessage(t,
+		annotatedField{name: "author", repeated: true, opts: &hubv1.FieldOptions{Target: "contributors", Role: "author"}},
+		annotatedField{name: "other_contributors", repeated: true, opts: &hubv1.FieldOptions{Target: "contributors", Serializer: "bibtex_name"}},
+		annotatedField{name: "issued", opts: &hubv1.FieldOptions{Target: "dates", DateType: "issued", Serializer: "year"}},
+		annotatedField{name: "other_dates", opts: &hubv1.FieldOptions{Target: "dates", JoinDelimiter: "; "}},
+		annotatedField{name: "doi", opts: &hubv1.FieldOptions{Target: "identifiers", IdentifierType: "doi"}},
+		annotatedField{name: "model", opts: &hubv1.FieldOptions{Target: "extra"}},
+		annotatedField{name: "degree", opts: &hubv1.FieldOptions{Target: "degree_info.degree_name"}},
+	)
+	extra, _ := structpb.NewStruct(map[string]any{"model": "Digital Document"})
+	record := &hubv1.Record{
+		Contributors: []*hubv1.Contributor{
+			{Name: "Smith, John", Role: "author"},
+			{Name: "Roe, R.", Role: "translator", ParsedName: &hubv1.ParsedName{Given: "Richard", Family: "Roe"}},
+			{Name: "Lehigh University", Role: "publisher"},
+		},
+		Dates: []*hubv1.DateValue{
+			{Type: hubv1.DateType_DATE_TYPE_ISSUED, Raw: "2020-05-01"},
+			{Type: hubv1.DateType_DATE_TYPE_CREATED, Raw: "2019"},
+			{Type: hubv1.DateType_DATE_TYPE_CAPTURED, Year: 2021, Month: 3},
+		},
+		Identifiers: []*hubv1.Identifier{
+			{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_URL, Value: "https://example.com"},
+			{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_DOI, Value: "10.1234/a"},
+			{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_DOI, Value: "10.1234/b"},
+		},
+		Extra: extra,
+	}
+
+	if err := NewConverter().FromHub(record, msg); err != nil {
+		t.Fatalf("FromHub error: %v", err)
+	}
+	md := msg.Descriptor()
+	list := func(name string) []string {
+		l := msg.Get(md.Fields().ByName(protoreflect.Name(name))).List()
+		var values []string
+		for i := 0; i < l.Len(); i++ {
+			values = append(values, l.Get(i).String())
+		}
+		return values
+	}
+	str := func(name string) string {
+		return msg.Get(md.Fields().ByName(protoreflect.Name(name))).String()
+	}
+
+	if got := list("author"); !slices.Equal(got, []string{"Smith, John"}) {
+		t.Errorf("author = %v", got)
+	}
+	// Contributors whose roles no field names, serialized from their parts
+	if got := list("other_contributors"); !slices.Equal(got, []string{"Roe, Richard", "Lehigh University"}) {
+		t.Errorf("other_contributors = %v", got)
+	}
+	if got := str("issued"); got != "2020" {
+		t.Errorf("issued = %q, want 2020", got)
+	}
+	if got := str("other_dates"); got != "2019; 2021-03" {
+		t.Errorf("other_dates = %q, want joined dates", got)
+	}
+	// A singular field keeps the first of several values
+	if got := str("doi"); got != "10.1234/a" {
+		t.Errorf("doi = %q, want 10.1234/a", got)
+	}
+	if got := str("model"); got != "Digital Document" {
+		t.Errorf("model = %q", got)
+	}
+	if msg.Has(md.Fields().ByName("degree")) {
+		t.Errorf("degree set without degree info")
+	}
+}
+
+func TestConverter_FromHub_MessageTarget(t *testing.T) {
+	err := NewConverter().FromHub(&hubv1.Record{Title: "Title"}, &bibtexv1.Person{})
+	if err == nil || !strings.Contains(err.Error(), "Contributor") {
+		t.Errorf("Person target: got %v", err)
+	}
+}
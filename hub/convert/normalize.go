@@ -0,0 +1,118 @@
+package convert
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+)
+
+// inPlaceParsers are parsers whose output has the same representation as
+// their input, so Normalize can write the result back into the spoke field.
+// Parsers that change representation (doi, orcid, edtf, split, the name
+// parsers) only make sense on the way into the hub and are skipped.
+var inPlaceParsers = map[string]bool{
+	"passthrough":          true,
+	"strip_html":           true,
+	"normalize_whitespace": true,
+	"trim":                 true,
+	"lowercase":            true,
+	"uppercase":            true,
+}
+
+// Normalize cleans a spoke message in place without converting it to a Hub
+// record: string values are trimmed, annotated parsers that keep the value's
+// representation are applied, and validators run. It is the fast path for
+// same-spoke conversions (e.g. normalizing an export), which would otherwise
+// pay for two conversions and drop anything the hub cannot represent.
+//
+// The returned errors are non-fatal, like ConversionResult.Errors.
+func (c *Converter) Normalize(msg proto.Message) []error {
+	var errs []error
+	c.normalizeMessage(msg.ProtoReflect(), &errs)
+	return errs
+}
+
+// normalizeMessage normalizes every populated field of m, recursing into
+// nested messages.
+func (c *Converter) normalizeMessage(m protoreflect.Message, errs *[]error) {
+	// Collect fields first; setting fields during Range is not allowed
+	var fields []protoreflect.FieldDescriptor
+	m.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		fields = append(fields, fd)
+		return true
+	})
+
+	for _, fd := range fields {
+		opts := GetFieldOptionsFromDescriptor(fd)
+		isMessage := fd.Kind() == protoreflect.MessageKind || fd.Kind() == protoreflect.GroupKind
+
+		switch {
+		case fd.IsMap():
+			if fd.MapValue().Kind() == protoreflect.MessageKind {
+				m.Get(fd).Map().Range(func(_ protoreflect.MapKey, v protoreflect.Value) bool {
+					c.normalizeMessage(v.Message(), errs)
+					return true
+				})
+			}
+		case fd.IsList():
+			list := m.Mutable(fd).List()
+			for i := 0; i < list.Len(); i++ {
+				switch {
+				case fd.Kind() == protoreflect.StringKind:
+					list.Set(i, protoreflect.ValueOfString(c.normalizeString(list.Get(i).String(), fd, opts, errs)))
+				case isMessage:
+					c.normalizeMessage(list.Get(i).Message(), errs)
+				}
+			}
+		case fd.Kind() == protoreflect.StringKind:
+			m.Set(fd, protoreflect.ValueOfString(c.normalizeString(m.Get(fd).String(), fd, opts, errs)))
+		case isMessage:
+			c.normalizeMessage(m.Mutable(fd).Message(), errs)
+		}
+
+		if opts != nil && opts.Validators != "" && m.Has(fd) {
+			validatorOpts := &ValidatorOptions{
+				FieldName: string(fd.Name()),
+				Pattern:   opts.Pattern,
+				MinLength: opts.MinLength,
+				MaxLength: opts.MaxLength,
+				MinValue:  opts.MinValue,
+				MaxValue:  opts.MaxValue,
+				MinCount:  opts.MinCount,
+				MaxCount:  opts.MaxCount,
+			}
+			*errs = append(*errs, c.validators.ValidateAll(opts.Validators, c.protoValueToGo(m.Get(fd), fd), validatorOpts)...)
+		}
+	}
+}
+
+// normalizeString trims a value and applies the field's parser when it is
+// one of the inPlaceParsers.
+func (c *Converter) normalizeString(s string, fd protoreflect.FieldDescriptor, opts *hubv1.FieldOptions, errs *[]error) string {
+	s = strings.TrimSpace(s)
+	if opts == nil || !inPlaceParsers[opts.Parser] {
+		return s
+	}
+
+	parserOpts := &ParserOptions{
+		DateFormat: opts.DateFormat,
+		Delimiter:  opts.Delimiter,
+	}
+	parsed, err := c.parsers.Parse(opts.Parser, s, parserOpts)
+	if err != nil {
+		*errs = append(*errs, &ConversionError{
+			Field:   string(fd.Name()),
+			Message: "parser failed",
+			Cause:   err,
+		})
+		return s
+	}
+	if str, ok := parsed.(string); ok {
+		return str
+	}
+	return fmt.Sprintf("%v", parsed)
+}
@@ -0,0 +1,33 @@
+package convert
+
+import (
+	"testing"
+
+	bibtexv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/spoke/bibtex/v1"
+)
+
+func TestConverter_Normalize(t *testing.T) {
+	entry := &bibtexv1.Entry{
+		Title:     "  <i>Deep</i>   Learning  ",
+		Doi:       "https://doi.org/10.1234/abc",
+		Publisher: " Lehigh University Press\n",
+		Keywords:  []string{" metadata ", "crosswalks"},
+	}
+
+	c := NewConverter()
+	c.Normalize(entry)
+
+	if entry.Title != "Deep Learning" {
+		t.Errorf("Title = %q, want strip_html applied", entry.Title)
+	}
+	if entry.Publisher != "Lehigh University Press" {
+		t.Errorf("Publisher = %q, want trimmed", entry.Publisher)
+	}
+	if entry.Keywords[0] != "metadata" {
+		t.Errorf("Keywords[0] = %q, want trimmed", entry.Keywords[0])
+	}
+	// The doi parser changes representation, so the spoke keeps its own form
+	if entry.Doi != "https://doi.org/10.1234/abc" {
+		t.Errorf("Doi = %q, want unchanged", entry.Doi)
+	}
+}
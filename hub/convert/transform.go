@@ -0,0 +1,71 @@
+package convert
+
+import (
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/mapping"
+)
+
+// transformOptions returns a field's transformation options (split,
+// regex_extract, transform, prefix, suffix, join).
+func transformOptions(opts *hubv1.FieldOptions) mapping.TransformOptions {
+	return mapping.TransformOptions{
+		Split:        opts.GetSplit(),
+		RegexExtract: opts.GetRegexExtract(),
+		Transform:    opts.GetTransform(),
+		Prefix:       opts.GetPrefix(),
+		Suffix:       opts.GetSuffix(),
+		Join:         opts.GetJoin(),
+	}
+}
+
+// applyTransforms applies a field's transformation options to its string
+// values, before the parser. A single value, or values joined into one,
+// is a string and several are a list, and a value the options drop
+// becomes nil. Values that aren't strings, such as nested messages, are
+// left alone.
+func applyTransforms(value any, opts *hubv1.FieldOptions) (any, error) {
+	topts := transformOptions(opts)
+	directives := topts.Directives()
+	if directives == nil {
+		return value, nil
+	}
+
+	_, scalar := value.(string)
+	var values []string
+	for _, item := range listOf(value) {
+		s, ok := item.(string)
+		if !ok {
+			return value, nil
+		}
+		values = append(values, s)
+	}
+
+	ft, err := mapping.FieldMapping{Transform: directives}.CompileTransform()
+	if err != nil {
+		return nil, err
+	}
+	out, err := ft.Apply(values, nil)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case len(out) == 0:
+		return nil, nil
+	case len(out) == 1 && (scalar || topts.Join != ""):
+		return out[0], nil
+	}
+	list := make([]any, len(out))
+	for i, v := range out {
+		list[i] = v
+	}
+	return list, nil
+}
+
+// listOf returns a field's values: the items of a repeated field, or the
+// value of a singular one.
+func listOf(value any) []any {
+	if list, ok := value.([]any); ok {
+		return list
+	}
+	return []any{value}
+}
@@ -0,0 +1,128 @@
+package hub
+
+import (
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strings"
+
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+)
+
+// CoverageReport lists the unmapped source fields observed in an export.
+type CoverageReport struct {
+	Format         string          `json:"format"`
+	Profile        string          `json:"profile,omitempty"`
+	Source         string          `json:"source"`
+	TotalRecords   int             `json:"total_records"`
+	UnmappedFields []UnmappedField `json:"unmapped_fields"`
+}
+
+// UnmappedField is a source field that had values but no profile mapping.
+type UnmappedField struct {
+	Field      string  `json:"field"`
+	Count      int     `json:"count"`
+	Percentage float64 `json:"percentage"`
+}
+
+// AuditCoverage counts, per source field, how many records reported it as
+// unmapped in SourceInfo. Fields listed in ignore are skipped.
+func AuditCoverage(records []*hubv1.Record, ignore []string) *CoverageReport {
+	skip := make(map[string]bool, len(ignore))
+	for _, field := range ignore {
+		skip[strings.TrimSpace(field)] = true
+	}
+
+	counts := make(map[string]int)
+	for _, record := range records {
+		seen := make(map[string]bool)
+		for _, field := range record.GetSourceInfo().GetUnmappedFields() {
+			if skip[field] || seen[field] {
+				continue
+			}
+			seen[field] = true
+			counts[field]++
+		}
+	}
+
+	report := &CoverageReport{
+		TotalRecords:   len(records),
+		UnmappedFields: make([]UnmappedField, 0, len(counts)),
+	}
+	for field, count := range counts {
+		report.UnmappedFields = append(report.UnmappedFields, UnmappedField{
+			Field:      field,
+			Count:      count,
+			Percentage: float64(count) / float64(len(records)) * 100,
+		})
+	}
+
+	// Most frequent first, then alphabetical for stable output
+	sort.Slice(report.UnmappedFields, func(i, j int) bool {
+		a, b := report.UnmappedFields[i], report.UnmappedFields[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.Field < b.Field
+	})
+
+	return report
+}
+
+// junitTestSuite is the subset of the JUnit XML schema understood by CI systems.
+type junitTestSuite struct {
+	XMLName   xml.Name        `xml:"testsuite"`
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Body    string `xml:",chardata"`
+}
+
+// JUnit renders the report as a JUnit test suite with one failing test
+// case per unmapped field, or a single passing case when fully mapped.
+func (r *CoverageReport) JUnit() ([]byte, error) {
+	className := "crosswalk.coverage." + r.Format
+	suite := junitTestSuite{
+		Name: "crosswalk field mapping coverage: " + r.Source,
+	}
+
+	if len(r.UnmappedFields) == 0 {
+		suite.TestCases = append(suite.TestCases, junitTestCase{
+			Name:      "all source fields mapped",
+			ClassName: className,
+		})
+	}
+
+	for _, uf := range r.UnmappedFields {
+		suite.TestCases = append(suite.TestCases, junitTestCase{
+			Name:      uf.Field,
+			ClassName: className,
+			Failure: &junitFailure{
+				Message: fmt.Sprintf("field %q is not mapped by profile %q", uf.Field, r.Profile),
+				Type:    "UnmappedField",
+				Body:    fmt.Sprintf("%d of %d records (%.1f%%) have a value for %s", uf.Count, r.TotalRecords, uf.Percentage, uf.Field),
+			},
+		})
+	}
+
+	suite.Tests = len(suite.TestCases)
+	suite.Failures = len(r.UnmappedFields)
+
+	out, err := xml.MarshalIndent(suite, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), out...), nil
+}
@@ -0,0 +1,98 @@
+package hub
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+)
+
+func unmappedRecord(fields ...string) *hubv1.Record {
+	return &hubv1.Record{SourceInfo: &hubv1.SourceInfo{UnmappedFields: fields}}
+}
+
+func TestAuditCoverage(t *testing.T) {
+	records := []*hubv1.Record{
+		unmappedRecord("field_notes", "batch_id", "field_notes"),
+		unmappedRecord("field_notes", "field_extent"),
+		unmappedRecord("batch_id", "field_extent"),
+		{},
+	}
+
+	report := AuditCoverage(records, []string{" batch_id "})
+	if report.TotalRecords != 4 {
+		t.Errorf("TotalRecords: got %d", report.TotalRecords)
+	}
+	want := []UnmappedField{
+		{Field: "field_extent", Count: 2, Percentage: 50},
+		{Field: "field_notes", Count: 2, Percentage: 50},
+	}
+	if len(report.UnmappedFields) != len(want) {
+		t.Fatalf("got %+v, want %+v", report.UnmappedFields, want)
+	}
+	for i, w := range want {
+		if report.UnmappedFields[i] != w {
+			t.Errorf("field %d: got %+v, want %+v", i, report.UnmappedFields[i], w)
+		}
+	}
+
+	if report := AuditCoverage(records, nil); report.UnmappedFields[0].Field != "batch_id" || report.UnmappedFields[0].Count != 2 {
+		t.Errorf("without ignore: got %+v", report.UnmappedFields)
+	}
+}
+
+func TestCoverageJUnit(t *testing.T) {
+	report := AuditCoverage([]*hubv1.Record{
+		unmappedRecord("field_notes", "field_extent"),
+		unmappedRecord("field_notes"),
+	}, nil)
+	report.Format, report.Profile, report.Source = "drupal", "islandora", "export.json"
+
+	out, err := report.JUnit()
+	if err != nil {
+		t.Fatal(err)
+	}
+	type junitSuite struct {
+		Tests     int `xml:"tests,attr"`
+		Failures  int `xml:"failures,attr"`
+		TestCases []struct {
+			Name      string `xml:"name,attr"`
+			ClassName string `xml:"classname,attr"`
+			Failure   *struct {
+				Message string `xml:"message,attr"`
+				Type    string `xml:"type,attr"`
+				Body    string `xml:",chardata"`
+			} `xml:"failure"`
+		} `xml:"testcase"`
+	}
+	var suite junitSuite
+	if err := xml.Unmarshal(out, &suite); err != nil {
+		t.Fatalf("JUnit output: %v\n%s", err, out)
+	}
+	if suite.Tests != 2 || suite.Failures != 2 || len(suite.TestCases) != 2 {
+		t.Fatalf("got %d tests, %d failures:\n%s", suite.Tests, suite.Failures, out)
+	}
+	tc := suite.TestCases[0]
+	if tc.Name != "field_notes" || tc.ClassName != "crosswalk.coverage.drupal" || tc.Failure == nil {
+		t.Fatalf("first case: got %+v", tc)
+	}
+	if tc.Failure.Type != "UnmappedField" || tc.Failure.Message != `field "field_notes" is not mapped by profile "islandora"` {
+		t.Errorf("failure: got %+v", tc.Failure)
+	}
+	if !strings.Contains(tc.Failure.Body, "2 of 2 records (100.0%)") {
+		t.Errorf("failure body: got %q", tc.Failure.Body)
+	}
+
+	out, err = AuditCoverage([]*hubv1.Record{{}}, nil).JUnit()
+	if err != nil {
+		t.Fatal(err)
+	}
+	suite = junitSuite{}
+	if err := xml.Unmarshal(out, &suite); err != nil {
+		t.Fatal(err)
+	}
+	if suite.Tests != 1 || suite.Failures != 0 || suite.TestCases[0].Failure != nil {
+		t.Errorf("fully mapped: got %d tests, %d failures:\n%s", suite.Tests, suite.Failures, out)
+	}
+}
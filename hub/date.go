@@ -2,6 +2,7 @@ package hub
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
@@ -17,91 +18,127 @@ func DateString(d *hubv1.DateValue) string {
 
 // FormatDate returns the date formatted according to its precision.
 func FormatDate(d *hubv1.DateValue) string {
-	if d.Year == 0 {
-		return ""
+	return formatDate(d, false)
+}
+
+// FormatEDTF returns the date in Extended Date/Time Format.
+func FormatEDTF(d *hubv1.DateValue) string {
+	return formatDate(d, true)
+}
+
+// formatDate writes a date, interval, or set, in EDTF or in the form
+// FormatDate uses, which writes decades as "1970s", centuries as
+// "20th century", and seasons as "Spring 2001".
+func formatDate(d *hubv1.DateValue, edtf bool) string {
+	if d.SetType == hubv1.DateSetType_DATE_SET_TYPE_UNSPECIFIED || len(d.SetMembers) == 0 {
+		return formatInterval(d, edtf, "/")
 	}
 
-	var result string
-	switch d.Precision {
-	case hubv1.DatePrecision_DATE_PRECISION_DECADE:
-		result = fmt.Sprintf("%d0s", d.Year/10)
-	case hubv1.DatePrecision_DATE_PRECISION_CENTURY:
-		result = fmt.Sprintf("%dth century", (d.Year/100)+1)
-	case hubv1.DatePrecision_DATE_PRECISION_YEAR:
-		result = fmt.Sprintf("%d", d.Year)
-	case hubv1.DatePrecision_DATE_PRECISION_MONTH:
-		result = fmt.Sprintf("%d-%02d", d.Year, d.Month)
-	case hubv1.DatePrecision_DATE_PRECISION_DAY, hubv1.DatePrecision_DATE_PRECISION_TIME:
-		result = fmt.Sprintf("%d-%02d-%02d", d.Year, d.Month, d.Day)
-	default:
-		result = fmt.Sprintf("%d", d.Year)
-	}
-
-	if d.IsRange && d.EndYear > 0 {
-		var end string
-		switch d.Precision {
-		case hubv1.DatePrecision_DATE_PRECISION_YEAR:
-			end = fmt.Sprintf("%d", d.EndYear)
-		case hubv1.DatePrecision_DATE_PRECISION_MONTH:
-			end = fmt.Sprintf("%d-%02d", d.EndYear, d.EndMonth)
-		case hubv1.DatePrecision_DATE_PRECISION_DAY:
-			end = fmt.Sprintf("%d-%02d-%02d", d.EndYear, d.EndMonth, d.EndDay)
-		default:
-			end = fmt.Sprintf("%d", d.EndYear)
+	members := make([]string, 0, len(d.SetMembers))
+	for _, m := range d.SetMembers {
+		if s := formatInterval(m, edtf, ".."); s != "" {
+			members = append(members, s)
 		}
-		result = result + "/" + end
 	}
+	allOf := d.SetType == hubv1.DateSetType_DATE_SET_TYPE_ALL_OF
+	switch {
+	case !edtf && allOf:
+		return strings.Join(members, ", ")
+	case !edtf:
+		return strings.Join(members, " or ")
+	case allOf:
+		return "{" + strings.Join(members, ",") + "}"
+	default:
+		return "[" + strings.Join(members, ",") + "]"
+	}
+}
 
-	switch d.Qualifier {
-	case hubv1.DateQualifier_DATE_QUALIFIER_APPROXIMATE:
-		result = result + "~"
-	case hubv1.DateQualifier_DATE_QUALIFIER_UNCERTAIN:
-		result = result + "?"
-	case hubv1.DateQualifier_DATE_QUALIFIER_BOTH:
-		result = result + "%"
+// formatInterval writes a date, or a range with its ends joined by sep:
+// "/" for an interval, where an open end is "..", or ".." for a set
+// member, where an open end is empty.
+func formatInterval(d *hubv1.DateValue, edtf bool, sep string) string {
+	startSeason, endSeason, _ := strings.Cut(d.Season, "/")
+	if !IsInterval(d) {
+		if d.Year == 0 {
+			return ""
+		}
+		return formatPoint(d.Year, d.Month, d.Day, d.Precision, startSeason, d.Qualifier, edtf)
 	}
 
-	return result
+	var start, end string
+	switch d.StartBound {
+	case hubv1.DateBound_DATE_BOUND_UNSPECIFIED:
+		if d.Year == 0 {
+			return ""
+		}
+		start = formatPoint(d.Year, d.Month, d.Day, d.Precision, startSeason, d.Qualifier, edtf)
+	case hubv1.DateBound_DATE_BOUND_OPEN:
+		if sep == "/" {
+			start = ".."
+		}
+	}
+	switch d.EndBound {
+	case hubv1.DateBound_DATE_BOUND_UNSPECIFIED:
+		precision := d.EndPrecision
+		if precision == hubv1.DatePrecision_DATE_PRECISION_UNSPECIFIED {
+			precision = d.Precision
+		}
+		end = formatPoint(d.EndYear, d.EndMonth, d.EndDay, precision, endSeason, d.EndQualifier, edtf)
+	case hubv1.DateBound_DATE_BOUND_OPEN:
+		if sep == "/" {
+			end = ".."
+		}
+	}
+	return start + sep + end
 }
 
-// FormatEDTF returns the date in Extended Date/Time Format.
-func FormatEDTF(d *hubv1.DateValue) string {
-	if d.Year == 0 {
-		return ""
-	}
+// IsInterval reports whether a date is a range with an end: one between
+// two dates, or with an open or unknown start or end. A range without an
+// end year is written as its start.
+func IsInterval(d *hubv1.DateValue) bool {
+	return d.StartBound != hubv1.DateBound_DATE_BOUND_UNSPECIFIED ||
+		d.EndBound != hubv1.DateBound_DATE_BOUND_UNSPECIFIED ||
+		d.IsRange && d.EndYear != 0
+}
 
+// formatPoint writes a single date at a precision, with its qualifier.
+func formatPoint(year, month, day int32, precision hubv1.DatePrecision, season string, q hubv1.DateQualifier, edtf bool) string {
 	var result string
-	switch d.Precision {
+	switch precision {
 	case hubv1.DatePrecision_DATE_PRECISION_DECADE:
-		result = fmt.Sprintf("%dX", d.Year/10)
+		if edtf {
+			result = fmt.Sprintf("%dX", year/10)
+		} else {
+			result = fmt.Sprintf("%d0s", year/10)
+		}
 	case hubv1.DatePrecision_DATE_PRECISION_CENTURY:
-		result = fmt.Sprintf("%dXX", d.Year/100)
-	case hubv1.DatePrecision_DATE_PRECISION_YEAR:
-		result = fmt.Sprintf("%04d", d.Year)
+		if edtf {
+			result = fmt.Sprintf("%dXX", year/100)
+		} else {
+			result = fmt.Sprintf("%dth century", (year/100)+1)
+		}
+	case hubv1.DatePrecision_DATE_PRECISION_SEASON:
+		code := SeasonCode(season)
+		switch {
+		case code == 0:
+			result = fmt.Sprintf("%04d", year)
+		case edtf:
+			result = fmt.Sprintf("%04d-%02d", year, code)
+		default:
+			result = fmt.Sprintf("%s %d", SeasonName(code), year)
+		}
 	case hubv1.DatePrecision_DATE_PRECISION_MONTH:
-		result = fmt.Sprintf("%04d-%02d", d.Year, d.Month)
+		result = fmt.Sprintf("%04d-%02d", year, month)
 	case hubv1.DatePrecision_DATE_PRECISION_DAY, hubv1.DatePrecision_DATE_PRECISION_TIME:
-		result = fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+		result = fmt.Sprintf("%04d-%02d-%02d", year, month, day)
 	default:
-		result = fmt.Sprintf("%04d", d.Year)
-	}
-
-	if d.IsRange && d.EndYear > 0 {
-		var end string
-		switch d.Precision {
-		case hubv1.DatePrecision_DATE_PRECISION_YEAR:
-			end = fmt.Sprintf("%04d", d.EndYear)
-		case hubv1.DatePrecision_DATE_PRECISION_MONTH:
-			end = fmt.Sprintf("%04d-%02d", d.EndYear, d.EndMonth)
-		case hubv1.DatePrecision_DATE_PRECISION_DAY:
-			end = fmt.Sprintf("%04d-%02d-%02d", d.EndYear, d.EndMonth, d.EndDay)
-		default:
-			end = fmt.Sprintf("%04d", d.EndYear)
-		}
-		result = result + "/" + end
+		result = fmt.Sprintf("%04d", year)
+	}
+	if !edtf {
+		result = strings.TrimLeft(result, "0")
 	}
 
-	switch d.Qualifier {
+	switch q {
 	case hubv1.DateQualifier_DATE_QUALIFIER_APPROXIMATE:
 		result = result + "~"
 	case hubv1.DateQualifier_DATE_QUALIFIER_UNCERTAIN:
@@ -109,10 +146,45 @@ func FormatEDTF(d *hubv1.DateValue) string {
 	case hubv1.DateQualifier_DATE_QUALIFIER_BOTH:
 		result = result + "%"
 	}
-
 	return result
 }
 
+// seasons names the EDTF season codes, 21 through 41.
+var seasons = []string{
+	"Spring", "Summer", "Autumn", "Winter",
+	"Spring (Northern Hemisphere)", "Summer (Northern Hemisphere)",
+	"Autumn (Northern Hemisphere)", "Winter (Northern Hemisphere)",
+	"Spring (Southern Hemisphere)", "Summer (Southern Hemisphere)",
+	"Autumn (Southern Hemisphere)", "Winter (Southern Hemisphere)",
+	"Quarter 1", "Quarter 2", "Quarter 3", "Quarter 4",
+	"Quadrimester 1", "Quadrimester 2", "Quadrimester 3",
+	"Semester 1", "Semester 2",
+}
+
+// SeasonName returns the name of an EDTF season code (21-41), or "" for
+// any other code.
+func SeasonName(code int) string {
+	if code < 21 || code >= 21+len(seasons) {
+		return ""
+	}
+	return seasons[code-21]
+}
+
+// SeasonCode returns the EDTF code of a season name, ignoring case, or 0
+// for an unknown name. "Fall" is autumn.
+func SeasonCode(name string) int {
+	name = strings.TrimSpace(name)
+	if strings.EqualFold(name, "Fall") {
+		name = "Autumn"
+	}
+	for i, s := range seasons {
+		if strings.EqualFold(s, name) {
+			return 21 + i
+		}
+	}
+	return 0
+}
+
 // DateToTime converts the DateValue to a time.Time.
 func DateToTime(d *hubv1.DateValue) time.Time {
 	if d.Year == 0 {
@@ -0,0 +1,273 @@
+package hub
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+)
+
+// DiffKind says how a field differs between two records.
+type DiffKind int
+
+const (
+	// DiffChanged is a single value that differs
+	DiffChanged DiffKind = iota
+	// DiffAdded is a value only the second record has
+	DiffAdded
+	// DiffRemoved is a value only the first record has
+	DiffRemoved
+	// DiffReordered is a repeated field with the same values in another order
+	DiffReordered
+)
+
+func (k DiffKind) String() string {
+	switch k {
+	case DiffAdded:
+		return "added"
+	case DiffRemoved:
+		return "removed"
+	case DiffReordered:
+		return "reordered"
+	default:
+		return "changed"
+	}
+}
+
+// FieldDiff is one difference between two hub records. Values are rendered
+// as text: scalars as themselves, enums by short name, and messages as
+// their populated fields, e.g. {name: "Smith, Jane", role: "author"}.
+type FieldDiff struct {
+	Field string // proto field path, e.g. "contributors" or "degree_info.institution"
+	Kind  DiffKind
+	Old   string // empty for DiffAdded
+	New   string // empty for DiffRemoved
+}
+
+func (d FieldDiff) String() string {
+	switch d.Kind {
+	case DiffAdded:
+		return fmt.Sprintf("+ %s: %s", d.Field, d.New)
+	case DiffRemoved:
+		return fmt.Sprintf("- %s: %s", d.Field, d.Old)
+	case DiffReordered:
+		return fmt.Sprintf("~ %s: reordered %s → %s", d.Field, d.Old, d.New)
+	default:
+		return fmt.Sprintf("~ %s: %s → %s", d.Field, d.Old, d.New)
+	}
+}
+
+// Diff compares two hub records field by field and returns their
+// differences in field order. Singular messages are compared field by
+// field; repeated fields by their elements, so an added contributor or a
+// dropped identifier is reported on its own rather than as a changed list.
+// Fields named in ignore (proto paths such as "source_info") are skipped.
+func Diff(a, b *hubv1.Record, ignore ...string) []FieldDiff {
+	skip := make(map[string]bool, len(ignore))
+	for _, path := range ignore {
+		skip[path] = true
+	}
+	var diffs []FieldDiff
+	diffMessage(a.ProtoReflect(), b.ProtoReflect(), "", skip, &diffs)
+	return diffs
+}
+
+func diffMessage(a, b protoreflect.Message, prefix string, skip map[string]bool, diffs *[]FieldDiff) {
+	fields := a.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		path := prefix + string(fd.Name())
+		if skip[path] {
+			continue
+		}
+		hasA, hasB := a.Has(fd), b.Has(fd)
+		if !hasA && !hasB {
+			continue
+		}
+
+		switch {
+		case fd.IsList():
+			diffList(fd, a.Get(fd).List(), b.Get(fd).List(), path, diffs)
+		case fd.IsMap():
+			// Struct keys (the record's extras) read as fields of their own
+			mapPrefix := path + "."
+			if fd.ContainingMessage().FullName() == "google.protobuf.Struct" {
+				mapPrefix = prefix
+			}
+			diffMap(fd, a.Get(fd).Map(), b.Get(fd).Map(), mapPrefix, skip, diffs)
+		case fd.Kind() == protoreflect.MessageKind && hasA && hasB:
+			diffMessage(a.Get(fd).Message(), b.Get(fd).Message(), path+".", skip, diffs)
+		default:
+			oldV, newV := "", ""
+			if hasA {
+				oldV = renderValue(fd, a.Get(fd))
+			}
+			if hasB {
+				newV = renderValue(fd, b.Get(fd))
+			}
+			switch {
+			case oldV == newV:
+			case !hasA:
+				*diffs = append(*diffs, FieldDiff{Field: path, Kind: DiffAdded, New: newV})
+			case !hasB:
+				*diffs = append(*diffs, FieldDiff{Field: path, Kind: DiffRemoved, Old: oldV})
+			default:
+				*diffs = append(*diffs, FieldDiff{Field: path, Kind: DiffChanged, Old: oldV, New: newV})
+			}
+		}
+	}
+}
+
+// diffList compares repeated fields as multisets, then by order.
+func diffList(fd protoreflect.FieldDescriptor, a, b protoreflect.List, path string, diffs *[]FieldDiff) {
+	render := func(list protoreflect.List) []string {
+		out := make([]string, list.Len())
+		for i := range out {
+			out[i] = renderValue(fd, list.Get(i))
+		}
+		return out
+	}
+	oldVals, newVals := render(a), render(b)
+
+	remaining := make(map[string]int)
+	for _, v := range newVals {
+		remaining[v]++
+	}
+	changed := false
+	for _, v := range oldVals {
+		if remaining[v] > 0 {
+			remaining[v]--
+			continue
+		}
+		changed = true
+		*diffs = append(*diffs, FieldDiff{Field: path, Kind: DiffRemoved, Old: v})
+	}
+	seen := make(map[string]int)
+	for _, v := range oldVals {
+		seen[v]++
+	}
+	for _, v := range newVals {
+		if seen[v] > 0 {
+			seen[v]--
+			continue
+		}
+		changed = true
+		*diffs = append(*diffs, FieldDiff{Field: path, Kind: DiffAdded, New: v})
+	}
+
+	if !changed && strings.Join(oldVals, "\x00") != strings.Join(newVals, "\x00") {
+		*diffs = append(*diffs, FieldDiff{
+			Field: path,
+			Kind:  DiffReordered,
+			Old:   "[" + strings.Join(oldVals, ", ") + "]",
+			New:   "[" + strings.Join(newVals, ", ") + "]",
+		})
+	}
+}
+
+// diffMap compares map fields entry by entry, in key order.
+func diffMap(fd protoreflect.FieldDescriptor, a, b protoreflect.Map, prefix string, skip map[string]bool, diffs *[]FieldDiff) {
+	keys := make(map[string]protoreflect.MapKey)
+	collect := func(k protoreflect.MapKey, _ protoreflect.Value) bool {
+		keys[k.String()] = k
+		return true
+	}
+	a.Range(collect)
+	b.Range(collect)
+	names := make([]string, 0, len(keys))
+	for name := range keys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		path := prefix + name
+		if skip[path] {
+			continue
+		}
+		k := keys[name]
+		oldV, newV := "", ""
+		if a.Has(k) {
+			oldV = renderValue(fd.MapValue(), a.Get(k))
+		}
+		if b.Has(k) {
+			newV = renderValue(fd.MapValue(), b.Get(k))
+		}
+		switch {
+		case oldV == newV:
+		case !a.Has(k):
+			*diffs = append(*diffs, FieldDiff{Field: path, Kind: DiffAdded, New: newV})
+		case !b.Has(k):
+			*diffs = append(*diffs, FieldDiff{Field: path, Kind: DiffRemoved, Old: oldV})
+		default:
+			*diffs = append(*diffs, FieldDiff{Field: path, Kind: DiffChanged, Old: oldV, New: newV})
+		}
+	}
+}
+
+// renderValue renders one value of fd as text.
+func renderValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) string {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		// Extras are arbitrary JSON, so they are shown as JSON
+		switch m := v.Message().Interface().(type) {
+		case *structpb.Value:
+			return renderJSON(m.AsInterface())
+		case *structpb.Struct:
+			return renderJSON(m.AsMap())
+		}
+		return renderMessage(v.Message())
+	case protoreflect.EnumKind:
+		return enumShortName(fd.Enum(), v.Enum())
+	case protoreflect.StringKind:
+		return strconv.Quote(v.String())
+	case protoreflect.BytesKind:
+		return fmt.Sprintf("%d bytes", len(v.Bytes()))
+	default:
+		return fmt.Sprint(v.Interface())
+	}
+}
+
+func renderJSON(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
+// renderMessage renders a message's populated fields in field order.
+func renderMessage(m protoreflect.Message) string {
+	var fds []protoreflect.FieldDescriptor
+	m.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		fds = append(fds, fd)
+		return true
+	})
+	sort.Slice(fds, func(i, j int) bool { return fds[i].Number() < fds[j].Number() })
+
+	parts := make([]string, 0, len(fds))
+	for _, fd := range fds {
+		v := m.Get(fd)
+		if fd.IsList() {
+			list := v.List()
+			items := make([]string, list.Len())
+			for i := range items {
+				items[i] = renderValue(fd, list.Get(i))
+			}
+			parts = append(parts, fmt.Sprintf("%s: [%s]", fd.Name(), strings.Join(items, ", ")))
+			continue
+		}
+		if fd.IsMap() {
+			parts = append(parts, fmt.Sprintf("%s: %d entries", fd.Name(), v.Map().Len()))
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%s: %s", fd.Name(), renderValue(fd, v)))
+	}
+	return "{" + strings.Join(parts, ", ") + "}"
+}
@@ -0,0 +1,71 @@
+package hub
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+)
+
+func TestDiff(t *testing.T) {
+	extraA, _ := structpb.NewStruct(map[string]any{"nid": "12", "pages": "1-10"})
+	extraB, _ := structpb.NewStruct(map[string]any{"nid": "12", "pages": "1-12"})
+	a := &hubv1.Record{
+		Title: "Sediment transport",
+		Contributors: []*hubv1.Contributor{
+			{Name: "Smith, Jane", Role: "author"},
+		},
+		Identifiers: []*hubv1.Identifier{
+			{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_DOI, Value: "10.1234/abcd"},
+			{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_URL, Value: "https://example.edu/1"},
+		},
+		DegreeInfo: &hubv1.DegreeInfo{Institution: "Lehigh University", DegreeName: "Ph.D."},
+		Language:   "en",
+		Extra:      extraA,
+		SourceInfo: &hubv1.SourceInfo{Format: "drupal"},
+	}
+	b := &hubv1.Record{
+		Title: "Sediment Transport",
+		Contributors: []*hubv1.Contributor{
+			{Name: "Smith, Jane", Role: "author"},
+			{Name: "Doe, John", Role: "editor"},
+		},
+		Identifiers: []*hubv1.Identifier{
+			{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_URL, Value: "https://example.edu/1"},
+		},
+		DegreeInfo: &hubv1.DegreeInfo{Institution: "Lehigh University"},
+		Extra:      extraB,
+		SourceInfo: &hubv1.SourceInfo{Format: "datacite"},
+	}
+
+	var got []string
+	for _, d := range Diff(a, b, "source_info") {
+		got = append(got, d.String())
+	}
+	want := []string{
+		`~ title: "Sediment transport" → "Sediment Transport"`,
+		`+ contributors: {name: "Doe, John", role: "editor"}`,
+		`- language: "en"`,
+		`- identifiers: {type: doi, value: "10.1234/abcd"}`,
+		`- degree_info.degree_name: "Ph.D."`,
+		`~ extra.pages: "1-10" → "1-12"`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("diff:\ngot:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestDiffReordered(t *testing.T) {
+	a := &hubv1.Record{AltTitle: []string{"One", "Two"}}
+	b := &hubv1.Record{AltTitle: []string{"Two", "One"}}
+
+	diffs := Diff(a, b)
+	if len(diffs) != 1 || diffs[0].Kind != DiffReordered || diffs[0].Field != "alt_title" {
+		t.Errorf("expected alt_title reordered, got %v", diffs)
+	}
+	if diffs := Diff(a, a); len(diffs) != 0 {
+		t.Errorf("expected no differences, got %v", diffs)
+	}
+}
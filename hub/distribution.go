@@ -0,0 +1,52 @@
+package hub
+
+import (
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+)
+
+// NewDistributionFromFile converts a hub file to a dataset distribution.
+func NewDistributionFromFile(f *hubv1.File) *hubv1.Distribution {
+	return &hubv1.Distribution{
+		Url:         f.Path,
+		Name:        f.Name,
+		Format:      f.MimeType,
+		SizeBytes:   f.SizeBytes,
+		Checksums:   f.Checksums,
+		Description: f.Description,
+	}
+}
+
+// GetDistributionChecksum returns the distribution's checksum value for an
+// algorithm, or "".
+func GetDistributionChecksum(d *hubv1.Distribution, algorithm string) string {
+	if d == nil {
+		return ""
+	}
+	algorithm = NormalizeChecksumAlgorithm(algorithm)
+	for _, c := range d.Checksums {
+		if c.Algorithm == algorithm {
+			return c.Value
+		}
+	}
+	return ""
+}
+
+// Distributions returns the record's dataset distributions. Datasets without
+// explicit distributions fall back to their original and supplemental files;
+// derivatives such as thumbnails and service files are not distributions.
+func Distributions(r *hubv1.Record) []*hubv1.Distribution {
+	if len(r.Distributions) > 0 {
+		return r.Distributions
+	}
+	if r.ResourceType == nil || r.ResourceType.Type != hubv1.ResourceTypeValue_RESOURCE_TYPE_DATASET {
+		return nil
+	}
+	var dists []*hubv1.Distribution
+	for _, f := range r.Files {
+		switch f.Role {
+		case "", "original", "preservation", "supplemental":
+			dists = append(dists, NewDistributionFromFile(f))
+		}
+	}
+	return dists
+}
@@ -0,0 +1,130 @@
+package hub
+
+import (
+	"strings"
+
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+)
+
+// File roles for the uses Islandora gives media. Other roles (e.g.
+// "supplemental") are free text.
+const (
+	FileRoleOriginal      = "original"
+	FileRoleService       = "service"
+	FileRolePreservation  = "preservation"
+	FileRoleIntermediate  = "intermediate"
+	FileRoleThumbnail     = "thumbnail"
+	FileRoleTranscript    = "transcript"
+	FileRoleExtractedText = "extracted_text"
+)
+
+// mediaUses pairs each role with its PCDM use URI and the name of the
+// Islandora Media Use term carrying it.
+var mediaUses = []struct{ role, uri, name string }{
+	{FileRoleOriginal, "http://pcdm.org/use#OriginalFile", "Original File"},
+	{FileRoleService, "http://pcdm.org/use#ServiceFile", "Service File"},
+	{FileRolePreservation, "http://pcdm.org/use#PreservationMasterFile", "Preservation Master File"},
+	{FileRoleIntermediate, "http://pcdm.org/use#IntermediateFile", "Intermediate File"},
+	{FileRoleThumbnail, "http://pcdm.org/use#ThumbnailImage", "Thumbnail Image"},
+	{FileRoleTranscript, "http://pcdm.org/use#Transcript", "Transcript"},
+	{FileRoleExtractedText, "http://pcdm.org/use#ExtractedText", "Extracted Text"},
+}
+
+// FileRoleFromMediaUse returns the file role for a PCDM use URI or an
+// Islandora Media Use term name, or "" if the use is not known.
+func FileRoleFromMediaUse(use string) string {
+	use = strings.TrimSpace(use)
+	for _, m := range mediaUses {
+		if use == m.uri || strings.EqualFold(use, m.name) {
+			return m.role
+		}
+	}
+	return ""
+}
+
+// MediaUseURI returns the PCDM use URI for a file role, or "" for roles
+// with no media use. An empty role is the original file.
+func MediaUseURI(role string) string {
+	if role == "" {
+		role = FileRoleOriginal
+	}
+	for _, m := range mediaUses {
+		if role == m.role {
+			return m.uri
+		}
+	}
+	return ""
+}
+
+// NormalizeChecksumAlgorithm returns the canonical lowercase algorithm name
+// (e.g. "SHA-256" and "SHA256" both become "sha256").
+func NormalizeChecksumAlgorithm(algorithm string) string {
+	a := strings.ToLower(strings.TrimSpace(algorithm))
+	a = strings.ReplaceAll(a, "-", "")
+	a = strings.ReplaceAll(a, "_", "")
+	return a
+}
+
+// NewChecksum creates a checksum with a normalized algorithm name and
+// lowercase hex value.
+func NewChecksum(algorithm, value string) *hubv1.Checksum {
+	return &hubv1.Checksum{
+		Algorithm: NormalizeChecksumAlgorithm(algorithm),
+		Value:     strings.ToLower(strings.TrimSpace(value)),
+	}
+}
+
+// GetChecksum returns the file's checksum value for an algorithm, or "".
+func GetChecksum(f *hubv1.File, algorithm string) string {
+	if f == nil {
+		return ""
+	}
+	algorithm = NormalizeChecksumAlgorithm(algorithm)
+	for _, c := range f.Checksums {
+		if c.Algorithm == algorithm {
+			return c.Value
+		}
+	}
+	return ""
+}
+
+// SetChecksum sets (or replaces) the file's checksum for an algorithm.
+// Empty values are ignored.
+func SetChecksum(f *hubv1.File, algorithm, value string) {
+	if f == nil || strings.TrimSpace(value) == "" {
+		return
+	}
+	c := NewChecksum(algorithm, value)
+	for i, existing := range f.Checksums {
+		if existing.Algorithm == c.Algorithm {
+			f.Checksums[i] = c
+			return
+		}
+	}
+	f.Checksums = append(f.Checksums, c)
+}
+
+// GetFileByRole returns the first file with the given role, or nil.
+func GetFileByRole(r *hubv1.Record, role string) *hubv1.File {
+	for _, f := range r.Files {
+		if f.Role == role {
+			return f
+		}
+	}
+	return nil
+}
+
+// PrimaryFile returns the record's main file: the first file without a
+// role or with an "original"/"preservation" role, else the first file.
+func PrimaryFile(r *hubv1.Record) *hubv1.File {
+	for _, f := range r.Files {
+		switch f.Role {
+		case "", FileRoleOriginal, FileRolePreservation:
+			return f
+		}
+	}
+	if len(r.Files) > 0 {
+		return r.Files[0]
+	}
+	return nil
+}
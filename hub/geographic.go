@@ -0,0 +1,68 @@
+package hub
+
+import (
+	"strconv"
+	"strings"
+
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+)
+
+// PlaceName joins a hierarchical geographic location from the most to the
+// least specific part, e.g. "Bethlehem, Northampton, Pennsylvania".
+func PlaceName(g *hubv1.HierarchicalGeographic) string {
+	if g == nil {
+		return ""
+	}
+	var parts []string
+	for _, p := range []string{g.Area, g.City, g.County, g.State, g.Country} {
+		if p = strings.TrimSpace(p); p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// GetCoordinates returns the point in a record's "coordinates" extra,
+// given as "lat,lng" text or as an object with lat and lng (or latitude
+// and longitude) members. It reports false when there is none or it is
+// out of range.
+func GetCoordinates(r *hubv1.Record) (lat, lon float64, ok bool) {
+	v, found := GetExtra(r, "coordinates")
+	if !found {
+		return 0, 0, false
+	}
+	switch c := v.(type) {
+	case string:
+		la, lo, found := strings.Cut(c, ",")
+		if !found {
+			return 0, 0, false
+		}
+		var err1, err2 error
+		lat, err1 = strconv.ParseFloat(strings.TrimSpace(la), 64)
+		lon, err2 = strconv.ParseFloat(strings.TrimSpace(lo), 64)
+		if err1 != nil || err2 != nil {
+			return 0, 0, false
+		}
+	case map[string]any:
+		var hasLat, hasLon bool
+		for _, key := range []string{"lat", "latitude"} {
+			if f, isNum := c[key].(float64); isNum {
+				lat, hasLat = f, true
+			}
+		}
+		for _, key := range []string{"lng", "lon", "longitude"} {
+			if f, isNum := c[key].(float64); isNum {
+				lon, hasLon = f, true
+			}
+		}
+		if !hasLat || !hasLon {
+			return 0, 0, false
+		}
+	default:
+		return 0, 0, false
+	}
+	if lat < -90 || lat > 90 || lon < -180 || lon > 180 {
+		return 0, 0, false
+	}
+	return lat, lon, true
+}
@@ -0,0 +1,53 @@
+package hub
+
+import (
+	"regexp"
+	"strings"
+
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+)
+
+// boxFolderPattern matches labelled box and folder numbers such as
+// "Box 3, Folder 12", "box 3 / folder 12" or "Box #3; Folders 12-14".
+var boxFolderPattern = regexp.MustCompile(`(?i)\b(box(?:es)?|folders?)\s*(?:no\.\s*|#\s*|:\s*)?([^\s,;/]+)`)
+
+// ParseBoxFolder parses a combined box/folder string into an archival
+// location. It reports false when no box or folder label was found; the
+// trimmed value is then kept as the box so nothing is lost.
+func ParseBoxFolder(s string) (*hubv1.ArchivalLocation, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, false
+	}
+	loc := &hubv1.ArchivalLocation{}
+	for _, m := range boxFolderPattern.FindAllStringSubmatch(s, -1) {
+		if strings.HasPrefix(strings.ToLower(m[1]), "box") {
+			if loc.Box == "" {
+				loc.Box = m[2]
+			}
+		} else if loc.Folder == "" {
+			loc.Folder = m[2]
+		}
+	}
+	if loc.Box == "" && loc.Folder == "" {
+		loc.Box = s
+		return loc, false
+	}
+	return loc, true
+}
+
+// BoxFolderString formats the box and folder of an archival location as
+// "Box 3, Folder 12", or "" when neither is set.
+func BoxFolderString(loc *hubv1.ArchivalLocation) string {
+	if loc == nil {
+		return ""
+	}
+	var parts []string
+	if loc.Box != "" {
+		parts = append(parts, "Box "+loc.Box)
+	}
+	if loc.Folder != "" {
+		parts = append(parts, "Folder "+loc.Folder)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -9,7 +9,8 @@ import (
 
 var (
 	doiRegex    = regexp.MustCompile(`^10\.\d{4,}/[^\s]+$`)
-	handleRegex = regexp.MustCompile(`^\d+\.\d+/[^\s]+$`)
+	handleRegex = regexp.MustCompile(`^\d+(\.\d+)*/\S+$`)
+	pmcidRegex  = regexp.MustCompile(`^(?i)pmc\d+$`)
 	orcidRegex  = regexp.MustCompile(`^\d{4}-\d{4}-\d{4}-\d{3}[\dX]$`)
 	isbnRegex   = regexp.MustCompile(`^(?:\d{10}|\d{13}|\d{1,5}-\d{1,7}-\d{1,7}-[\dX])$`)
 	issnRegex   = regexp.MustCompile(`^\d{4}-\d{3}[\dX]$`)
@@ -31,6 +32,16 @@ func IdentifierURI(id *hubv1.Identifier) string {
 		return "https://www.ncbi.nlm.nih.gov/pmc/articles/" + id.Value
 	case hubv1.IdentifierType_IDENTIFIER_TYPE_ARXIV:
 		return "https://arxiv.org/abs/" + id.Value
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_ARK:
+		if strings.HasPrefix(id.Value, "ark:") {
+			return "https://n2t.net/" + id.Value
+		}
+		return id.Value
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_URN:
+		if strings.HasPrefix(id.Value, "urn:nbn:") {
+			return "https://nbn-resolving.org/" + id.Value
+		}
+		return id.Value
 	case hubv1.IdentifierType_IDENTIFIER_TYPE_URL:
 		return id.Value
 	default:
@@ -53,14 +64,29 @@ func DetectIdentifierType(value string) hubv1.IdentifierType {
 		return hubv1.IdentifierType_IDENTIFIER_TYPE_DOI
 	}
 
+	// Check for ARK, bare or at any resolver ("https://n2t.net/ark:/...")
+	if strings.HasPrefix(value, "ark:") || strings.Contains(value, "/ark:") {
+		return hubv1.IdentifierType_IDENTIFIER_TYPE_ARK
+	}
+
+	// Check for URN (national bibliography numbers and other namespaces)
+	if strings.HasPrefix(strings.ToLower(value), "urn:") {
+		return hubv1.IdentifierType_IDENTIFIER_TYPE_URN
+	}
+
 	// Check for Handle
-	if handleRegex.MatchString(value) {
+	if isBareHandle(value) || strings.HasPrefix(value, "hdl:") {
 		return hubv1.IdentifierType_IDENTIFIER_TYPE_HANDLE
 	}
 	if strings.HasPrefix(value, "https://hdl.handle.net/") || strings.HasPrefix(value, "http://hdl.handle.net/") {
 		return hubv1.IdentifierType_IDENTIFIER_TYPE_HANDLE
 	}
 
+	// Check for PubMed Central ID
+	if pmcidRegex.MatchString(value) || strings.Contains(value, "ncbi.nlm.nih.gov/pmc/articles/") {
+		return hubv1.IdentifierType_IDENTIFIER_TYPE_PMCID
+	}
+
 	// Check for ORCID
 	if orcidRegex.MatchString(value) {
 		return hubv1.IdentifierType_IDENTIFIER_TYPE_ORCID
@@ -87,8 +113,11 @@ func DetectIdentifierType(value string) hubv1.IdentifierType {
 		return hubv1.IdentifierType_IDENTIFIER_TYPE_ISSN
 	}
 
-	// Check for URL
+	// Check for URL, PURLs first
 	if strings.HasPrefix(value, "http://") || strings.HasPrefix(value, "https://") {
+		if isPURL(value) {
+			return hubv1.IdentifierType_IDENTIFIER_TYPE_PURL
+		}
 		return hubv1.IdentifierType_IDENTIFIER_TYPE_URL
 	}
 
@@ -137,7 +166,52 @@ func NormalizeIdentifier(value string, idType hubv1.IdentifierType) string {
 	case hubv1.IdentifierType_IDENTIFIER_TYPE_ISBN, hubv1.IdentifierType_IDENTIFIER_TYPE_ISSN:
 		return strings.ToUpper(value)
 
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_ARK:
+		// Drop the resolver; the ARK itself starts at "ark:"
+		if i := strings.Index(value, "ark:"); i > 0 {
+			value = value[i:]
+		}
+		return value
+
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_URN:
+		// The "urn" scheme and namespace are case-insensitive
+		if parts := strings.SplitN(value, ":", 3); len(parts) == 3 {
+			return strings.ToLower(parts[0]+":"+parts[1]) + ":" + parts[2]
+		}
+		return value
+
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_PMCID:
+		value = strings.TrimRight(value, "/")
+		if i := strings.LastIndex(value, "/"); i >= 0 {
+			value = value[i+1:]
+		}
+		return strings.ToUpper(value[:min(3, len(value))]) + value[min(3, len(value)):]
+
 	default:
 		return value
 	}
 }
+
+// isBareHandle reports whether a value is a handle without a resolver
+// ("1721.1/12345", "2027/mdp.39015012345678"). A prefix without a dot
+// needs four digits and a suffix that isn't a number, so "2020/05" and
+// "2020/05/01" stay dates.
+func isBareHandle(value string) bool {
+	if !handleRegex.MatchString(value) {
+		return false
+	}
+	prefix, suffix, _ := strings.Cut(value, "/")
+	if strings.Contains(prefix, ".") {
+		return true
+	}
+	return len(prefix) >= 4 && !strings.Contains(suffix, "/") &&
+		strings.ContainsFunc(suffix, func(r rune) bool { return r < '0' || r > '9' })
+}
+
+// isPURL reports whether a URL is at a PURL resolver (purl.org,
+// purl.oclc.org, purl.archive.org, and the like).
+func isPURL(value string) bool {
+	host := value[strings.Index(value, "://")+3:]
+	host, _, _ = strings.Cut(host, "/")
+	return host == "purl.org" || strings.HasPrefix(host, "purl.") || strings.HasSuffix(host, ".purl.org")
+}
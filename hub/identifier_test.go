@@ -0,0 +1,64 @@
+package hub
+
+import (
+	"testing"
+
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+)
+
+func TestDetectIdentifierType(t *testing.T) {
+	for value, want := range map[string]hubv1.IdentifierType{
+		"10.1234/abc":                                    hubv1.IdentifierType_IDENTIFIER_TYPE_DOI,
+		"ark:/13030/tf5p30086k":                          hubv1.IdentifierType_IDENTIFIER_TYPE_ARK,
+		"ark:13030/tf5p30086k":                           hubv1.IdentifierType_IDENTIFIER_TYPE_ARK,
+		"https://n2t.net/ark:/13030/tf5p30086k":          hubv1.IdentifierType_IDENTIFIER_TYPE_ARK,
+		"https://digital.library.edu/ark:/87287/d7q30r":  hubv1.IdentifierType_IDENTIFIER_TYPE_ARK,
+		"urn:nbn:de:bvb:19-146642":                       hubv1.IdentifierType_IDENTIFIER_TYPE_URN,
+		"URN:NBN:fi-fe2021":                              hubv1.IdentifierType_IDENTIFIER_TYPE_URN,
+		"1721.1/12345":                                   hubv1.IdentifierType_IDENTIFIER_TYPE_HANDLE,
+		"2027/mdp.39015012345678":                        hubv1.IdentifierType_IDENTIFIER_TYPE_HANDLE,
+		"20.500.12345/abc":                               hubv1.IdentifierType_IDENTIFIER_TYPE_HANDLE,
+		"hdl:1721.1/12345":                               hubv1.IdentifierType_IDENTIFIER_TYPE_HANDLE,
+		"https://hdl.handle.net/1721.1/12345":            hubv1.IdentifierType_IDENTIFIER_TYPE_HANDLE,
+		"PMC1234567":                                     hubv1.IdentifierType_IDENTIFIER_TYPE_PMCID,
+		"https://www.ncbi.nlm.nih.gov/pmc/articles/PMC1": hubv1.IdentifierType_IDENTIFIER_TYPE_PMCID,
+		"http://purl.org/dc/terms/":                      hubv1.IdentifierType_IDENTIFIER_TYPE_PURL,
+		"https://purl.oclc.org/NET/example":              hubv1.IdentifierType_IDENTIFIER_TYPE_PURL,
+		"https://purl.archive.org/domain/item":           hubv1.IdentifierType_IDENTIFIER_TYPE_PURL,
+		"https://example.edu/purl.org":                   hubv1.IdentifierType_IDENTIFIER_TYPE_URL,
+		"2020/05":                                        hubv1.IdentifierType_IDENTIFIER_TYPE_UNSPECIFIED,
+		"2020/05/01":                                     hubv1.IdentifierType_IDENTIFIER_TYPE_UNSPECIFIED,
+	} {
+		if got := DetectIdentifierType(value); got != want {
+			t.Errorf("DetectIdentifierType(%q) = %v, want %v", value, got, want)
+		}
+	}
+}
+
+func TestNewIdentifierNormalizes(t *testing.T) {
+	for value, want := range map[string]string{
+		"https://n2t.net/ark:/13030/tf5p30086k":           "ark:/13030/tf5p30086k",
+		"URN:NBN:de:bvb:19-146642":                        "urn:nbn:de:bvb:19-146642",
+		"https://www.ncbi.nlm.nih.gov/pmc/articles/PMC1/": "PMC1",
+		"pmc1234":          "PMC1234",
+		"hdl:1721.1/12345": "1721.1/12345",
+	} {
+		id := NewIdentifier(value, hubv1.IdentifierType_IDENTIFIER_TYPE_UNSPECIFIED)
+		if id.Value != want {
+			t.Errorf("NewIdentifier(%q).Value = %q, want %q", value, id.Value, want)
+		}
+	}
+
+	for _, tt := range []struct {
+		id   *hubv1.Identifier
+		want string
+	}{
+		{&hubv1.Identifier{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_ARK, Value: "ark:/13030/tf5p30086k"}, "https://n2t.net/ark:/13030/tf5p30086k"},
+		{&hubv1.Identifier{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_URN, Value: "urn:nbn:de:bvb:19-146642"}, "https://nbn-resolving.org/urn:nbn:de:bvb:19-146642"},
+		{&hubv1.Identifier{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_PURL, Value: "http://purl.org/dc/terms/"}, "http://purl.org/dc/terms/"},
+	} {
+		if got := IdentifierURI(tt.id); got != tt.want {
+			t.Errorf("IdentifierURI(%v) = %q, want %q", tt.id, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package hub
+
+import (
+	"strings"
+
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+)
+
+// Titles returns the record's titles with their languages. Records without
+// language-tagged titles give their title, tagged with the record language.
+// The title in the record's language comes first.
+func Titles(r *hubv1.Record) []*hubv1.LocalizedString {
+	return localized(r.Titles, r.Title, r.Language)
+}
+
+// Abstracts returns the record's abstracts with their languages, as Titles
+// does for titles.
+func Abstracts(r *hubv1.Record) []*hubv1.LocalizedString {
+	return localized(r.Abstracts, r.Abstract, r.Language)
+}
+
+// TitleIn returns the record's title in a language, falling back to the
+// title in the record's language.
+func TitleIn(r *hubv1.Record, lang string) string {
+	return valueIn(r.Titles, r.Title, lang)
+}
+
+// AbstractIn returns the record's abstract in a language, falling back to
+// the abstract in the record's language.
+func AbstractIn(r *hubv1.Record, lang string) string {
+	return valueIn(r.Abstracts, r.Abstract, lang)
+}
+
+// AddTitle records a title in a language. The first title also becomes
+// the record's title, so formats that know one title still see it.
+func AddTitle(r *hubv1.Record, value, lang string) {
+	r.Titles = addLocalized(r.Titles, value, lang)
+	if r.Title == "" {
+		r.Title = strings.TrimSpace(value)
+	}
+}
+
+// AddAbstract records an abstract in a language, as AddTitle does.
+func AddAbstract(r *hubv1.Record, value, lang string) {
+	r.Abstracts = addLocalized(r.Abstracts, value, lang)
+	if r.Abstract == "" {
+		r.Abstract = strings.TrimSpace(value)
+	}
+}
+
+// IsMultilingual reports whether the values are given in more than one
+// language, which is when serializers tag them.
+func IsMultilingual(values []*hubv1.LocalizedString) bool {
+	for _, v := range values {
+		if !SameLanguage(v.Language, values[0].Language) {
+			return true
+		}
+	}
+	return false
+}
+
+// SameLanguage reports whether two language tags name the same language,
+// comparing only the primary subtag ("en-US" and "en" match).
+func SameLanguage(a, b string) bool {
+	return strings.EqualFold(primarySubtag(a), primarySubtag(b))
+}
+
+func primarySubtag(tag string) string {
+	tag = strings.TrimSpace(tag)
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+	return tag
+}
+
+func localized(values []*hubv1.LocalizedString, primary, lang string) []*hubv1.LocalizedString {
+	var out []*hubv1.LocalizedString
+	if primary != "" {
+		out = append(out, &hubv1.LocalizedString{Value: primary, Language: lang})
+	}
+	for _, v := range values {
+		if v.Value == "" {
+			continue
+		}
+		if primary != "" && v.Value == primary {
+			// The tagged copy of the primary value knows its language
+			// better than the record does
+			if v.Language != "" {
+				out[0].Language = v.Language
+			}
+			continue
+		}
+		out = append(out, v)
+	}
+	return out
+}
+
+func valueIn(values []*hubv1.LocalizedString, primary, lang string) string {
+	for _, v := range values {
+		if v.Value != "" && SameLanguage(v.Language, lang) {
+			return v.Value
+		}
+	}
+	return primary
+}
+
+func addLocalized(values []*hubv1.LocalizedString, value, lang string) []*hubv1.LocalizedString {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return values
+	}
+	for _, v := range values {
+		if v.Value == value && SameLanguage(v.Language, lang) {
+			return values
+		}
+	}
+	return append(values, &hubv1.LocalizedString{Value: value, Language: strings.TrimSpace(lang)})
+}
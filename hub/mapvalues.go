@@ -0,0 +1,93 @@
+package hub
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+)
+
+func init() {
+	RegisterPostProcessor("map_values", newMapValues)
+}
+
+// mapValueFields are the fields map_values can rewrite, each as a function
+// returning pointers to the record's values of that field.
+var mapValueFields = map[string]func(r *hubv1.Record) []*string{
+	"publisher": func(r *hubv1.Record) []*string {
+		return []*string{&r.Publisher}
+	},
+	"affiliations": func(r *hubv1.Record) []*string {
+		var vals []*string
+		for _, c := range r.Contributors {
+			for _, a := range c.Affiliations {
+				vals = append(vals, &a.Name)
+			}
+		}
+		return vals
+	},
+	"degree_info.institution": func(r *hubv1.Record) []*string {
+		if r.DegreeInfo == nil {
+			return nil
+		}
+		return []*string{&r.DegreeInfo.Institution}
+	},
+	"degree_info.department": func(r *hubv1.Record) []*string {
+		if r.DegreeInfo == nil {
+			return nil
+		}
+		return []*string{&r.DegreeInfo.Department}
+	},
+	"degree_info.degree_name": func(r *hubv1.Record) []*string {
+		if r.DegreeInfo == nil {
+			return nil
+		}
+		return []*string{&r.DegreeInfo.DegreeName}
+	},
+}
+
+// newMapValues builds the map_values post-processor, which replaces exact
+// values of one field, e.g. expanding department codes:
+//
+//	name: map_values
+//	config:
+//	  field: degree_info.department
+//	  values:
+//	    CSE: Computer Science and Engineering
+func newMapValues(config map[string]any) (PostProcessor, error) {
+	field, _ := config["field"].(string)
+	values, ok := mapValueFields[field]
+	if !ok {
+		names := make([]string, 0, len(mapValueFields))
+		for name := range mapValueFields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("field %q is not supported (want %s)", field, strings.Join(names, ", "))
+	}
+
+	raw, _ := config["values"].(map[string]any)
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("values must map old values to new ones")
+	}
+	replacements := make(map[string]string, len(raw))
+	for from, to := range raw {
+		s, ok := to.(string)
+		if !ok {
+			return nil, fmt.Errorf("value for %q must be a string", from)
+		}
+		replacements[from] = s
+	}
+
+	return func(records []*hubv1.Record) error {
+		for _, r := range records {
+			for _, v := range values(r) {
+				if to, ok := replacements[strings.TrimSpace(*v)]; ok {
+					*v = to
+				}
+			}
+		}
+		return nil
+	}, nil
+}
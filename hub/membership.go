@@ -0,0 +1,134 @@
+package hub
+
+import (
+	"fmt"
+	"strconv"
+
+	"google.golang.org/protobuf/proto"
+
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+)
+
+// ComputeMembershipPaths fills in MembershipPath on every record by walking
+// member_of/part_of relations through the other records in the batch.
+//
+// Parents are matched by nid (Extra "nid" or an NID identifier) or by any
+// identifier value against the relation's source_id/target_id. When a parent
+// is not in the batch, the relation itself becomes the root of the path.
+// Cycles stop at the first repeated record. A record with no such relation
+// keeps the path it already has, e.g. one parsed from a CSV column.
+func ComputeMembershipPaths(records []*hubv1.Record) {
+	index := make(map[string]*hubv1.Record)
+	for _, r := range records {
+		for _, key := range recordKeys(r) {
+			if _, exists := index[key]; !exists {
+				index[key] = r
+			}
+		}
+	}
+
+	for _, r := range records {
+		if path := membershipPath(r, index); len(path) > 0 {
+			r.MembershipPath = path
+		}
+	}
+}
+
+// MembershipTitles returns the titles along the record's membership path,
+// root first.
+func MembershipTitles(r *hubv1.Record) []string {
+	titles := make([]string, 0, len(r.MembershipPath))
+	for _, seg := range r.MembershipPath {
+		if seg.TargetTitle != "" {
+			titles = append(titles, seg.TargetTitle)
+		}
+	}
+	return titles
+}
+
+func membershipPath(r *hubv1.Record, index map[string]*hubv1.Record) []*hubv1.Relation {
+	var path []*hubv1.Relation
+	visited := map[*hubv1.Record]bool{r: true}
+
+	for cur := r; cur != nil; {
+		rel := parentRelation(cur)
+		if rel == nil {
+			break
+		}
+
+		parent := lookupParent(rel, index)
+		if parent != nil && visited[parent] {
+			break
+		}
+
+		seg := proto.Clone(rel).(*hubv1.Relation)
+		if parent != nil && parent.Title != "" {
+			seg.TargetTitle = parent.Title
+		}
+		path = append(path, seg)
+
+		if parent != nil {
+			visited[parent] = true
+		}
+		cur = parent
+	}
+
+	// Walked child to root; store root first
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// parentRelation returns the record's first member_of or part_of relation.
+func parentRelation(r *hubv1.Record) *hubv1.Relation {
+	for _, rel := range r.Relations {
+		switch rel.Type {
+		case hubv1.RelationType_RELATION_TYPE_MEMBER_OF,
+			hubv1.RelationType_RELATION_TYPE_PART_OF:
+			return rel
+		}
+	}
+	return nil
+}
+
+func lookupParent(rel *hubv1.Relation, index map[string]*hubv1.Record) *hubv1.Record {
+	for _, key := range []string{rel.SourceId, rel.TargetId} {
+		if key == "" {
+			continue
+		}
+		if parent, ok := index[key]; ok {
+			return parent
+		}
+	}
+	return nil
+}
+
+// recordKeys returns the values a relation may use to point at the record.
+func recordKeys(r *hubv1.Record) []string {
+	var keys []string
+	if v, ok := GetExtra(r, "nid"); ok {
+		if s := extraKey(v); s != "" {
+			keys = append(keys, s)
+		}
+	}
+	for _, id := range r.Identifiers {
+		if id.Value != "" {
+			keys = append(keys, id.Value)
+		}
+	}
+	return keys
+}
+
+func extraKey(v any) string {
+	switch t := v.(type) {
+	case string:
+		return t
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(t)
+	}
+}
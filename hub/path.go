@@ -0,0 +1,89 @@
+package hub
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+)
+
+// displayFields are the fields, in order of preference, that give a hub
+// message's value as text.
+var displayFields = []protoreflect.Name{
+	"value", "name", "statement", "target_title", "title", "label", "original", "uri", "url",
+}
+
+// PathValues returns the values at a hub field path, such as
+// "contributors.name" or "identifiers.doi", as text, in the path syntax of
+// validation profiles. Alternatives separated by "|" give the values of the
+// first that has any. Enums are named by short name; dates are rendered
+// with DateString, contributors with DisplayName, and other messages by
+// their value, name, or similar field.
+func PathValues(r *hubv1.Record, path string) []string {
+	msg := r.ProtoReflect()
+	for _, alt := range strings.Split(path, "|") {
+		var values []string
+		for _, pv := range fieldValues(msg, strings.TrimSpace(alt)) {
+			if s := displayString(pv); s != "" {
+				values = append(values, s)
+			}
+		}
+		if len(values) > 0 {
+			return values
+		}
+	}
+	return nil
+}
+
+// PathRepeated checks each alternative of a hub field path against the
+// schema and reports whether the path can hold more than one value.
+func PathRepeated(path string) (bool, error) {
+	md := (&hubv1.Record{}).ProtoReflect().Descriptor()
+	repeated := false
+	for _, alt := range strings.Split(path, "|") {
+		alt = strings.TrimSpace(alt)
+		if alt == "" {
+			return false, fmt.Errorf("empty field in %q", path)
+		}
+		if _, err := resolvePath(alt); err != nil {
+			return false, err
+		}
+		m := md
+		for _, seg := range strings.Split(alt, ".") {
+			fd := m.Fields().ByName(protoreflect.Name(seg))
+			if fd == nil {
+				continue
+			}
+			repeated = repeated || fd.IsList()
+			m = fd.Message()
+		}
+	}
+	return repeated, nil
+}
+
+// displayString renders a value found at a path as text.
+func displayString(pv pathValue) string {
+	if s, ok := valueString(pv); ok {
+		return s
+	}
+	m := pv.v.Message()
+	switch v := m.Interface().(type) {
+	case *hubv1.DateValue:
+		return DateString(v)
+	case *hubv1.Contributor:
+		return DisplayName(v)
+	}
+	fields := m.Descriptor().Fields()
+	for _, name := range displayFields {
+		fd := fields.ByName(name)
+		if fd == nil || fd.IsList() || fd.Kind() != protoreflect.StringKind {
+			continue
+		}
+		if s := strings.TrimSpace(m.Get(fd).String()); s != "" {
+			return s
+		}
+	}
+	return ""
+}
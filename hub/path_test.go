@@ -0,0 +1,51 @@
+package hub
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPathValues(t *testing.T) {
+	r := dataciteReady()
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{"title", []string{"Sediment transport in the Lehigh Gap"}},
+		{"contributors", []string{"Smith, Jane"}},
+		{"identifiers.doi", []string{"10.1234/abcd"}},
+		{"identifiers.handle|identifiers.doi.value", []string{"10.1234/abcd"}},
+		{"dates.published.year", []string{"2024"}},
+		{"resource_type.type", []string{"dataset"}},
+		{"identifiers.handle", nil},
+	}
+	for _, tt := range tests {
+		if got := PathValues(r, tt.path); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("PathValues(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestPathRepeated(t *testing.T) {
+	tests := map[string]bool{
+		"title":                   false,
+		"resource_type.type":      false,
+		"alt_title":               true,
+		"dates.issued.year":       true,
+		"title|identifiers.doi":   true,
+		"degree_info.institution": false,
+	}
+	for path, want := range tests {
+		got, err := PathRepeated(path)
+		if err != nil {
+			t.Errorf("PathRepeated(%q): %v", path, err)
+		} else if got != want {
+			t.Errorf("PathRepeated(%q) = %v, want %v", path, got, want)
+		}
+	}
+	for _, path := range []string{"titel", "dates.issued.yr", "title|"} {
+		if _, err := PathRepeated(path); err == nil {
+			t.Errorf("PathRepeated(%q): want error", path)
+		}
+	}
+}
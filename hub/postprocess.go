@@ -0,0 +1,136 @@
+package hub
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+)
+
+// PostProcessor rewrites parsed records in place before they are
+// serialized. Post-processors hold institution-specific cleanup (campus
+// name normalization, department code expansion) that does not belong in
+// any one parser.
+type PostProcessor func(records []*hubv1.Record) error
+
+// PostProcessorFactory builds a post-processor from its configuration, so
+// the same processor can be parameterized per institution from CLI config.
+type PostProcessorFactory func(config map[string]any) (PostProcessor, error)
+
+// PostProcessorRegistry manages post-processor factories keyed by name.
+type PostProcessorRegistry struct {
+	mu        sync.RWMutex
+	factories map[string]PostProcessorFactory
+}
+
+// NewPostProcessorRegistry creates an empty post-processor registry.
+func NewPostProcessorRegistry() *PostProcessorRegistry {
+	return &PostProcessorRegistry{
+		factories: make(map[string]PostProcessorFactory),
+	}
+}
+
+// Register adds a factory, replacing any existing one with the same name.
+func (r *PostProcessorRegistry) Register(name string, factory PostProcessorFactory) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.factories[name] = factory
+}
+
+// Get retrieves a factory by name.
+func (r *PostProcessorRegistry) Get(name string) (PostProcessorFactory, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	f, ok := r.factories[name]
+	return f, ok
+}
+
+// Build creates the named post-processor from its configuration.
+func (r *PostProcessorRegistry) Build(name string, config map[string]any) (PostProcessor, error) {
+	factory, ok := r.Get(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown post-processor %q", name)
+	}
+	p, err := factory(config)
+	if err != nil {
+		return nil, fmt.Errorf("configuring post-processor %q: %w", name, err)
+	}
+	return p, nil
+}
+
+// Names returns the registered post-processor names, sorted.
+func (r *PostProcessorRegistry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.factories))
+	for name := range r.factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Default post-processor registry instance.
+var defaultPostProcessors = NewPostProcessorRegistry()
+
+// DefaultPostProcessors returns the default post-processor registry.
+func DefaultPostProcessors() *PostProcessorRegistry {
+	return defaultPostProcessors
+}
+
+// RegisterPostProcessor registers a factory with the default registry.
+// Embedding applications call it from init to make their processors
+// available to configuration by name.
+func RegisterPostProcessor(name string, factory PostProcessorFactory) {
+	defaultPostProcessors.Register(name, factory)
+}
+
+// Pipeline is an ordered list of post-processors. The zero value is an
+// empty pipeline ready to use.
+type Pipeline struct {
+	steps []pipelineStep
+}
+
+type pipelineStep struct {
+	name string
+	fn   PostProcessor
+}
+
+// Add appends a post-processor; the name identifies it in errors.
+func (p *Pipeline) Add(name string, fn PostProcessor) {
+	p.steps = append(p.steps, pipelineStep{name: name, fn: fn})
+}
+
+// Len returns the number of post-processors in the pipeline.
+func (p *Pipeline) Len() int {
+	if p == nil {
+		return 0
+	}
+	return len(p.steps)
+}
+
+// Names returns the post-processors' names, in order.
+func (p *Pipeline) Names() []string {
+	if p == nil {
+		return nil
+	}
+	names := make([]string, len(p.steps))
+	for i, step := range p.steps {
+		names[i] = step.name
+	}
+	return names
+}
+
+// Run applies each post-processor in order, stopping at the first error.
+func (p *Pipeline) Run(records []*hubv1.Record) error {
+	if p == nil {
+		return nil
+	}
+	for _, step := range p.steps {
+		if err := step.fn(records); err != nil {
+			return fmt.Errorf("post-processor %q: %w", step.name, err)
+		}
+	}
+	return nil
+}
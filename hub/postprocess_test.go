@@ -0,0 +1,111 @@
+package hub
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+)
+
+func TestPipelineRun(t *testing.T) {
+	var order []string
+	step := func(name string) PostProcessor {
+		return func(records []*hubv1.Record) error {
+			order = append(order, name)
+			return nil
+		}
+	}
+
+	var p Pipeline
+	p.Add("first", step("first"))
+	p.Add("second", step("second"))
+	if err := p.Run(nil); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"first", "second"}; !slices.Equal(order, want) {
+		t.Errorf("ran %v, want %v", order, want)
+	}
+	if got := p.Names(); !slices.Equal(got, []string{"first", "second"}) {
+		t.Errorf("Names: got %v", got)
+	}
+
+	failure := errors.New("boom")
+	order = nil
+	p.Add("broken", func([]*hubv1.Record) error { return failure })
+	p.Add("after", step("after"))
+	err := p.Run(nil)
+	if !errors.Is(err, failure) || !strings.Contains(err.Error(), `post-processor "broken":`) {
+		t.Errorf("got %v, want the error wrapped with the step name", err)
+	}
+	if slices.Contains(order, "after") {
+		t.Error("pipeline kept running after an error")
+	}
+
+	var nilPipeline *Pipeline
+	if err := nilPipeline.Run(nil); err != nil || nilPipeline.Len() != 0 {
+		t.Errorf("nil pipeline: got %v, len %d", err, nilPipeline.Len())
+	}
+}
+
+func TestBuildUnknownPostProcessor(t *testing.T) {
+	_, err := NewPostProcessorRegistry().Build("nope", nil)
+	if err == nil || !strings.Contains(err.Error(), `unknown post-processor "nope"`) {
+		t.Errorf("got %v, want an unknown post-processor error", err)
+	}
+}
+
+func TestMapValuesConfigErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		config map[string]any
+		msg    string
+	}{
+		{"bad field", map[string]any{"field": "title", "values": map[string]any{"a": "b"}}, `field "title" is not supported`},
+		{"empty values", map[string]any{"field": "publisher", "values": map[string]any{}}, "values must map"},
+		{"missing values", map[string]any{"field": "publisher"}, "values must map"},
+		{"non-string value", map[string]any{"field": "publisher", "values": map[string]any{"LU": 1}}, `value for "LU" must be a string`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := DefaultPostProcessors().Build("map_values", tt.config)
+			if err == nil || !strings.Contains(err.Error(), tt.msg) {
+				t.Errorf("got %v, want an error containing %q", err, tt.msg)
+			}
+			if err != nil && !strings.Contains(err.Error(), `configuring post-processor "map_values"`) {
+				t.Errorf("error not wrapped with the post-processor name: %v", err)
+			}
+		})
+	}
+}
+
+func TestMapValues(t *testing.T) {
+	p, err := DefaultPostProcessors().Build("map_values", map[string]any{
+		"field":  "degree_info.department",
+		"values": map[string]any{"CSE": "Computer Science and Engineering"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	records := []*hubv1.Record{
+		{DegreeInfo: &hubv1.DegreeInfo{Department: "CSE"}},
+		{DegreeInfo: &hubv1.DegreeInfo{Department: "  CSE\n"}},
+		{DegreeInfo: &hubv1.DegreeInfo{Department: "CSEE"}},
+		{},
+	}
+	if err := p(records); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"Computer Science and Engineering", "Computer Science and Engineering", "CSEE"}
+	for i, w := range want {
+		if got := records[i].DegreeInfo.Department; got != w {
+			t.Errorf("record %d: got %q, want %q", i, got, w)
+		}
+	}
+	if records[3].DegreeInfo != nil {
+		t.Error("map_values added degree info to a record without any")
+	}
+}
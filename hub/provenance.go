@@ -0,0 +1,42 @@
+package hub
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+)
+
+// Transformation stages recorded in SourceInfo.steps.
+const (
+	StageParse       = "parse"
+	StageEnrich      = "enrich"
+	StagePostProcess = "post-process"
+	StageSerialize   = "serialize"
+)
+
+// AddStep appends a transformation step to the record's provenance,
+// stamping it with the current time when it has none.
+func AddStep(r *hubv1.Record, step *hubv1.TransformationStep) {
+	if step.At == nil {
+		step.At = timestamppb.Now()
+	}
+	if r.SourceInfo == nil {
+		r.SourceInfo = &hubv1.SourceInfo{}
+	}
+	r.SourceInfo.Steps = append(r.SourceInfo.Steps, step)
+}
+
+// ChangedFields returns the paths of the hub fields that differ between a
+// record before and after a step, in field order, each once. The record's
+// own provenance is not compared.
+func ChangedFields(before, after *hubv1.Record) []string {
+	var fields []string
+	seen := make(map[string]bool)
+	for _, d := range Diff(before, after, "source_info") {
+		if !seen[d.Field] {
+			seen[d.Field] = true
+			fields = append(fields, d.Field)
+		}
+	}
+	return fields
+}
@@ -0,0 +1,38 @@
+package hub
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+)
+
+func TestAddStepAndChangedFields(t *testing.T) {
+	r := &hubv1.Record{Title: "Sediment transport", Funders: []*hubv1.Funder{{Name: "NSF"}}}
+	before := proto.Clone(r).(*hubv1.Record)
+
+	r.Funders[0].Identifier = "https://doi.org/10.13039/100000001"
+	r.Funders = append(r.Funders, &hubv1.Funder{Name: "NIH"})
+	r.Rights = append(r.Rights, &hubv1.Rights{Uri: "https://creativecommons.org/licenses/by/4.0/"})
+	AddStep(r, &hubv1.TransformationStep{Stage: StageEnrich, Name: "crossref", ChangedFields: ChangedFields(before, r)})
+
+	steps := r.GetSourceInfo().GetSteps()
+	if len(steps) != 1 {
+		t.Fatalf("expected one step, got %d", len(steps))
+	}
+	if steps[0].At == nil {
+		t.Error("step should be timestamped")
+	}
+	if got, want := strings.Join(steps[0].ChangedFields, ","), "rights,funders"; got != want {
+		t.Errorf("changed fields: got %s, want %s", got, want)
+	}
+
+	// Recording a step is not itself a change
+	before = proto.Clone(r).(*hubv1.Record)
+	AddStep(r, &hubv1.TransformationStep{Stage: StageSerialize, Name: "csv"})
+	if changed := ChangedFields(before, r); len(changed) != 0 {
+		t.Errorf("expected no changes, got %v", changed)
+	}
+}
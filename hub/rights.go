@@ -124,3 +124,35 @@ func NewRightsFromURI(uri string) *hubv1.Rights {
 		Statement: LabelForRightsURI(uri),
 	}
 }
+
+// RightsHolder returns the record's copyright holder, falling back to the
+// holder on its first rights entry that has one.
+func RightsHolder(r *hubv1.Record) string {
+	if r.RightsHolder != "" {
+		return r.RightsHolder
+	}
+	for _, rights := range r.Rights {
+		if rights.Holder != "" {
+			return rights.Holder
+		}
+	}
+	return ""
+}
+
+// CopyrightYear returns the year of the record's copyright date, or 0.
+func CopyrightYear(r *hubv1.Record) int32 {
+	if d := GetDate(r, hubv1.DateType_DATE_TYPE_COPYRIGHT); d != nil {
+		return d.Year
+	}
+	return 0
+}
+
+// IsCopyrightStatement reports whether free text reads as a copyright
+// notice (e.g. "© 2024 Jane Doe") rather than a license or usage statement.
+func IsCopyrightStatement(text string) bool {
+	t := strings.ToLower(strings.TrimSpace(text))
+	return strings.HasPrefix(t, "©") ||
+		strings.HasPrefix(t, "(c)") ||
+		strings.HasPrefix(t, "copyright ") ||
+		strings.Contains(t, "all rights reserved")
+}
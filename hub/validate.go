@@ -1,12 +1,14 @@
 package hub
 
 import (
+	"errors"
 	"fmt"
 	"regexp"
 	"strings"
 	"time"
 
 	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/helpers/idnorm"
 	"google.golang.org/protobuf/types/known/structpb"
 )
 
@@ -52,19 +54,19 @@ func (r *ValidationResult) Error() error {
 // ValidationOptions configures validation behavior.
 type ValidationOptions struct {
 	// RequireTitle requires a non-empty title
-	RequireTitle bool
+	RequireTitle bool `yaml:"require_title"`
 	// RequireIdentifier requires at least one identifier
-	RequireIdentifier bool
+	RequireIdentifier bool `yaml:"require_identifier"`
 	// RequireContributor requires at least one contributor
-	RequireContributor bool
+	RequireContributor bool `yaml:"require_contributor"`
 	// RequireDate requires at least one date
-	RequireDate bool
+	RequireDate bool `yaml:"require_date"`
 	// StrictExtras warns about commonly-used extras fields that should be promoted
-	StrictExtras bool
+	StrictExtras bool `yaml:"strict_extras"`
 	// ValidateIdentifierFormats checks identifier format validity (DOI, ORCID, etc.)
-	ValidateIdentifierFormats bool
+	ValidateIdentifierFormats bool `yaml:"validate_identifier_formats"`
 	// ValidateDates checks date value validity
-	ValidateDates bool
+	ValidateDates bool `yaml:"validate_dates"`
 }
 
 // DefaultValidationOptions returns standard validation options.
@@ -93,9 +95,15 @@ func StrictValidationOptions() ValidationOptions {
 	}
 }
 
-// Validate validates a Hub record according to the given options.
-func Validate(record *hubv1.Record, opts ValidationOptions) *ValidationResult {
+// Validate checks a Hub record against a completeness profile: the
+// profile's structural checks, then its required fields, conditions, and
+// value constraints. A nil profile runs the default checks.
+func Validate(record *hubv1.Record, profile *ValidationProfile) *ValidationResult {
+	if profile == nil {
+		profile = builtinProfiles["default"]
+	}
 	result := &ValidationResult{}
+	opts := profile.Checks
 
 	// Required field checks
 	if opts.RequireTitle && strings.TrimSpace(record.GetTitle()) == "" {
@@ -136,6 +144,12 @@ func Validate(record *hubv1.Record, opts ValidationOptions) *ValidationResult {
 			if errs := validateIdentifier(id, i); len(errs) > 0 {
 				result.Errors = append(result.Errors, errs...)
 			}
+			result.Warnings = append(result.Warnings, identifierWarnings(id, fmt.Sprintf("identifiers[%d]", i))...)
+		}
+		for i, c := range record.GetContributors() {
+			for j, id := range c.GetIdentifiers() {
+				result.Warnings = append(result.Warnings, identifierWarnings(id, fmt.Sprintf("contributors[%d].identifiers[%d]", i, j))...)
+			}
 		}
 	}
 
@@ -161,6 +175,7 @@ func Validate(record *hubv1.Record, opts ValidationOptions) *ValidationResult {
 		result.Warnings = append(result.Warnings, warnings...)
 	}
 
+	checkProfile(record, profile, result)
 	return result
 }
 
@@ -240,6 +255,36 @@ func validateIdentifier(id *hubv1.Identifier, index int) []ValidationError {
 	return errs
 }
 
+// identifierWarnings reports what the format checks in validateIdentifier
+// let through: check digits that don't match, usually a typo in an
+// otherwise well-formed ISBN, ISSN, or ORCID, and malformed arXiv IDs.
+func identifierWarnings(id *hubv1.Identifier, field string) []ValidationError {
+	var canon func(string) (string, error)
+	switch id.GetType() {
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_ISBN:
+		canon = idnorm.ISBN
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_ISSN:
+		canon = idnorm.ISSN
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_ORCID:
+		canon = idnorm.ORCID
+	case hubv1.IdentifierType_IDENTIFIER_TYPE_ARXIV:
+		canon = idnorm.ArXiv
+	default:
+		return nil
+	}
+	if strings.TrimSpace(id.GetValue()) == "" {
+		return nil
+	}
+	_, err := canon(id.GetValue())
+	switch {
+	case errors.Is(err, idnorm.ErrChecksum):
+		return []ValidationError{{Field: field + ".value", Code: "invalid_checksum", Message: err.Error()}}
+	case err != nil && id.GetType() == hubv1.IdentifierType_IDENTIFIER_TYPE_ARXIV:
+		return []ValidationError{{Field: field + ".value", Code: "invalid_format", Message: err.Error()}}
+	}
+	return nil
+}
+
 func validateContributor(contrib *hubv1.Contributor, index int) []ValidationError {
 	var errs []ValidationError
 	field := fmt.Sprintf("contributors[%d]", index)
@@ -0,0 +1,44 @@
+package hub
+
+import (
+	"testing"
+
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+)
+
+func TestValidateIdentifierWarnings(t *testing.T) {
+	record := &hubv1.Record{
+		Title: "Sediment transport in the Lehigh Gap",
+		Identifiers: []*hubv1.Identifier{
+			{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_ISBN, Value: "0-262-03384-5"},
+			{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_ISSN, Value: "0317-8471"},
+			{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_ARXIV, Value: "arXiv:21010.1234"},
+		},
+		Contributors: []*hubv1.Contributor{{
+			Name:        "Carberry, Josiah",
+			Identifiers: []*hubv1.Identifier{{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_ORCID, Value: "0000-0002-1825-0098"}},
+		}},
+	}
+
+	result := Validate(record, nil)
+	if !result.IsValid() {
+		t.Errorf("check digit typos should warn, not fail: %v", result.Errors)
+	}
+	want := map[string]string{
+		"identifiers[0].value":                 "invalid_checksum",
+		"identifiers[2].value":                 "invalid_format",
+		"contributors[0].identifiers[0].value": "invalid_checksum",
+	}
+	got := map[string]string{}
+	for _, w := range result.Warnings {
+		got[w.Field] = w.Code
+	}
+	for field, code := range want {
+		if got[field] != code {
+			t.Errorf("%s: got warning %q, want %q", field, got[field], code)
+		}
+	}
+	if _, ok := got["identifiers[1].value"]; ok {
+		t.Errorf("valid ISSN warned about: %v", result.Warnings)
+	}
+}
@@ -0,0 +1,161 @@
+package mapping
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// irTargets are the hub fields a profile may map source fields to, by
+// base name.
+var irTargets = []string{
+	"Title", "AltTitle", "Abstract", "Description", "Contributors",
+	"ContributorRoles", "Dates", "ResourceType", "Genre", "Language",
+	"Rights", "RightsHolder", "CopyrightStatement", "Subjects", "Relations",
+	"Publication", "Identifiers", "Publisher", "PlacePublished",
+	"PhysicalDesc", "Notes", "TableOfContents", "Source", "DigitalOrigin",
+	"DegreeInfo", "Holdings", "ArchivalLocation", "Files", "Distributions",
+	"Extra",
+}
+
+// KnownIR reports whether ir names a hub field profiles can map to. Extra
+// needs a subfield naming the value (e.g., "Extra.nid").
+func KnownIR(ir string) bool {
+	base, subfield := IRFieldName(ir)
+	if base == "Extra" && subfield == "" {
+		return false
+	}
+	return slices.Contains(irTargets, base)
+}
+
+// PriorityKey returns the key under which parsers compare the priorities
+// of fields mapping to the same hub field: the IR, and the type when set,
+// so fields for different kinds of the same hub field don't compete.
+func (m FieldMapping) PriorityKey() string {
+	if m.Type != "" {
+		return m.IR + "/" + m.Type
+	}
+	return m.IR
+}
+
+// SourceField describes a field of a source format, for linting profiles
+// against it.
+type SourceField struct {
+	// Type is the field's type in the source (e.g., "edtf", "typed_relation")
+	Type string
+
+	// Hub is the hub field the source format's spoke maps it to, if any
+	Hub string
+}
+
+// Lint levels.
+const (
+	LintError   = "error"
+	LintWarning = "warning"
+)
+
+// LintFinding is a problem Lint found with a profile.
+type LintFinding struct {
+	// Level is LintError or LintWarning
+	Level string
+
+	// Field is the source field at fault, or for an unmapped hub field,
+	// the hub field
+	Field string
+
+	Message string
+}
+
+func (f LintFinding) String() string {
+	return fmt.Sprintf("%s: %s: %s", f.Level, f.Field, f.Message)
+}
+
+// Lint checks the profile for mistakes that loading it doesn't catch:
+// hub fields that don't exist, fields ranked by priority for a hub field
+// that tie, and, given the fields of the source format, source fields
+// the format doesn't have, EDTF fields mapped to dates without the edtf
+// parser, and hub fields the format carries that nothing maps to. sources
+// may be nil to skip the checks that need it; source names the format in
+// messages. Errors come before warnings.
+func (p *Profile) Lint(source string, sources map[string]SourceField) []LintFinding {
+	var errs, warns []LintFinding
+	fail := func(field, format string, args ...any) {
+		errs = append(errs, LintFinding{LintError, field, fmt.Sprintf(format, args...)})
+	}
+
+	names := make([]string, 0, len(p.Fields))
+	for name := range p.Fields {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	mapped := make(map[string]bool)
+	byKey := make(map[string][]string)
+	for _, name := range names {
+		m := p.Fields[name]
+		if m.IR == "" {
+			continue
+		}
+		src, known := sources[name]
+		if sources != nil && !known {
+			fail(name, "not a field of the %s spoke", source)
+		}
+
+		targets := []FieldMapping{m}
+		for _, rule := range m.Rules {
+			if rule.IR != "" {
+				targets = append(targets, FieldMapping{IR: rule.IR})
+			}
+		}
+		for _, t := range targets {
+			base, _ := IRFieldName(t.IR)
+			mapped[base] = true
+			if !KnownIR(t.IR) {
+				fail(name, "unknown hub field %q", t.IR)
+			} else if base == "Dates" && src.Type == "edtf" && m.Parser != "edtf" {
+				fail(name, "EDTF field mapped to %s without parser: edtf", t.IR)
+			}
+		}
+
+		key := m.PriorityKey()
+		byKey[key] = append(byKey[key], name)
+	}
+
+	// Priorities rank the fields competing for a hub field; a tie leaves
+	// the winner to chance
+	for _, name := range names {
+		m := p.Fields[name]
+		if m.IR == "" {
+			continue
+		}
+		group := byKey[m.PriorityKey()]
+		ranked := slices.ContainsFunc(group, func(other string) bool { return p.Fields[other].Priority != 0 })
+		for _, other := range group {
+			if ranked && other != name && p.Fields[other].Priority == m.Priority {
+				fail(name, "same priority (%d) as %s for %s", m.Priority, other, m.PriorityKey())
+				break
+			}
+		}
+	}
+
+	var carried []string
+	for _, src := range sources {
+		base, _ := IRFieldName(src.Hub)
+		if base != "" && base != "Extra" && !mapped[base] && !slices.Contains(carried, base) {
+			carried = append(carried, base)
+		}
+	}
+	slices.Sort(carried)
+	for _, base := range carried {
+		var from []string
+		for name, src := range sources {
+			if b, _ := IRFieldName(src.Hub); b == base {
+				from = append(from, name)
+			}
+		}
+		slices.Sort(from)
+		warns = append(warns, LintFinding{LintWarning, base, fmt.Sprintf("no field maps to it (%s carries it in %s)", source, strings.Join(from, ", "))})
+	}
+
+	return append(errs, warns...)
+}
@@ -0,0 +1,72 @@
+package mapping
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLint(t *testing.T) {
+	p, err := LoadProfileFromString(`
+name: lint
+format: drupal
+fields:
+  title:
+    ir: Title
+  field_full_title:
+    ir: Title
+    priority: 1
+  field_alt_title:
+    ir: Title
+    priority: 1
+  field_edtf_date_issued:
+    ir: Dates
+    date_type: issued
+  field_nickname:
+    ir: Extra.nickname
+  field_genre:
+    ir: Genres
+  field_identifier:
+    ir: Identifiers
+    rules:
+      - when: {matches: "^10\\."}
+        ir: Extra
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sources := map[string]SourceField{
+		"title":                  {Type: "string", Hub: "Title"},
+		"field_full_title":       {Type: "string", Hub: "Title"},
+		"field_alt_title":        {Type: "string", Hub: "AltTitle"},
+		"field_edtf_date_issued": {Type: "edtf", Hub: "Dates"},
+		"field_genre":            {Type: "entity_reference", Hub: "Genre"},
+		"field_identifier":       {Type: "string", Hub: "Identifiers"},
+		"field_publisher":        {Type: "string", Hub: "Publisher"},
+	}
+
+	var got []string
+	for _, f := range p.Lint("drupal", sources) {
+		got = append(got, f.String())
+	}
+	want := []string{
+		`error: field_edtf_date_issued: EDTF field mapped to Dates without parser: edtf`,
+		`error: field_genre: unknown hub field "Genres"`,
+		`error: field_identifier: unknown hub field "Extra"`,
+		`error: field_nickname: not a field of the drupal spoke`,
+		`error: field_alt_title: same priority (1) as field_full_title for Title`,
+		`error: field_full_title: same priority (1) as field_alt_title for Title`,
+		`warning: AltTitle: no field maps to it (drupal carries it in field_alt_title)`,
+		`warning: Genre: no field maps to it (drupal carries it in field_genre)`,
+		`warning: Publisher: no field maps to it (drupal carries it in field_publisher)`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("findings:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	// Without the source's fields, only the profile's own mistakes remain
+	for _, f := range p.Lint("drupal", nil) {
+		if strings.Contains(f.Message, "spoke") || strings.Contains(f.Message, "EDTF") || f.Level == LintWarning {
+			t.Errorf("unexpected finding without sources: %s", f)
+		}
+	}
+}
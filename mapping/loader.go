@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"gopkg.in/yaml.v3"
@@ -35,39 +36,131 @@ func NewProfileRegistry() (*ProfileRegistry, error) {
 			continue
 		}
 
-		data, err := embeddedProfiles.ReadFile("profiles/" + entry.Name())
+		profile, err := loadEmbeddedProfile(strings.TrimSuffix(entry.Name(), ".yaml"), nil)
 		if err != nil {
 			continue
 		}
-
-		profile, err := parseProfile(data)
-		if err != nil {
-			continue
-		}
-
-		// Use filename without extension as profile name if not set
-		if profile.Name == "" {
-			profile.Name = strings.TrimSuffix(entry.Name(), ".yaml")
-		}
 		r.profiles[profile.Name] = profile
 	}
 
 	return r, nil
 }
 
-// LoadProfile loads a profile from a file path.
+// LoadProfile loads a profile from a file path, merged over the profiles
+// it extends and includes.
 func LoadProfile(path string) (*Profile, error) {
+	return loadProfile(path, nil)
+}
+
+// LoadProfileFromString loads a profile from YAML content. Profiles it
+// extends or includes are found relative to the working directory.
+func LoadProfileFromString(content string) (*Profile, error) {
+	profile, err := parseProfile([]byte(content))
+	if err != nil {
+		return nil, err
+	}
+	return resolveBases(profile, ".", nil)
+}
+
+// loadProfile loads the profile at path. chain holds the profiles being
+// loaded that led to this one, to catch cycles.
+func loadProfile(path string, chain []string) (*Profile, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	if slices.Contains(chain, abs) {
+		return nil, fmt.Errorf("profile %s extends or includes itself", path)
+	}
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("reading profile file: %w", err)
 	}
+	profile, err := parseProfile(data)
+	if err != nil {
+		return nil, err
+	}
+	return resolveBases(profile, filepath.Dir(path), append(chain, abs))
+}
 
-	return parseProfile(data)
+// loadEmbeddedProfile loads an embedded profile by file name, without
+// .yaml.
+func loadEmbeddedProfile(name string, chain []string) (*Profile, error) {
+	key := "embedded:" + name
+	if slices.Contains(chain, key) {
+		return nil, fmt.Errorf("profile %s extends or includes itself", name)
+	}
+	data, err := embeddedProfiles.ReadFile("profiles/" + name + ".yaml")
+	if err != nil {
+		return nil, fmt.Errorf("unknown profile %q", name)
+	}
+	profile, err := parseProfile(data)
+	if err != nil {
+		return nil, err
+	}
+	if profile.Name == "" {
+		profile.Name = name
+	}
+	return resolveBases(profile, "", append(chain, key))
 }
 
-// LoadProfileFromString loads a profile from YAML content.
-func LoadProfileFromString(content string) (*Profile, error) {
-	return parseProfile([]byte(content))
+// resolveBases merges the profile over the profile it extends and then
+// each profile it includes, in order. dir is the directory the profile was
+// loaded from, or "" for an embedded profile, which can only extend other
+// embedded profiles.
+func resolveBases(profile *Profile, dir string, chain []string) (*Profile, error) {
+	if profile.Extends == "" && len(profile.Include) == 0 {
+		return profile, nil
+	}
+
+	var base *Profile
+	if ref := profile.Extends; ref != "" {
+		var err error
+		switch local := filepath.Join(dir, ref); {
+		case dir == "":
+			base, err = loadEmbeddedProfile(ref, chain)
+		case strings.HasSuffix(ref, ".yaml") || strings.HasSuffix(ref, ".yml") || strings.ContainsRune(ref, filepath.Separator):
+			base, err = loadProfile(resolvePath(dir, ref), chain)
+		case fileExists(local + ".yaml"):
+			base, err = loadProfile(local+".yaml", chain)
+		default:
+			base, err = loadEmbeddedProfile(ref, chain)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("profile %s: extends %s: %w", profile.Name, ref, err)
+		}
+	}
+
+	for _, ref := range profile.Include {
+		if dir == "" {
+			return nil, fmt.Errorf("profile %s: embedded profiles cannot include files", profile.Name)
+		}
+		included, err := loadProfile(resolvePath(dir, ref), chain)
+		if err != nil {
+			return nil, fmt.Errorf("profile %s: include %s: %w", profile.Name, ref, err)
+		}
+		if base == nil {
+			base = included
+		} else {
+			base = MergeProfiles(base, included)
+		}
+	}
+
+	return MergeProfiles(base, profile), nil
+}
+
+// resolvePath resolves a path in a profile relative to the profile's
+// directory.
+func resolvePath(dir, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(dir, path)
+}
+
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
 }
 
 func parseProfile(data []byte) (*Profile, error) {
@@ -75,9 +168,32 @@ func parseProfile(data []byte) (*Profile, error) {
 	if err := yaml.Unmarshal(data, &profile); err != nil {
 		return nil, fmt.Errorf("parsing profile YAML: %w", err)
 	}
+	if err := profile.Validate(); err != nil {
+		return nil, fmt.Errorf("profile %s: %w", profile.Name, err)
+	}
 	return &profile, nil
 }
 
+// Validate compiles every field's template, transform directives, and
+// rule patterns, so a bad expression fails when the profile loads rather
+// than partway through a conversion.
+func (p *Profile) Validate() error {
+	if p == nil {
+		return nil
+	}
+	for name, m := range p.Fields {
+		if _, err := m.CompileTransform(); err != nil {
+			return fmt.Errorf("field %s: %w", name, err)
+		}
+		for _, rule := range m.Rules {
+			if err := validateConditions(rule.When); err != nil {
+				return fmt.Errorf("field %s: rule: %w", name, err)
+			}
+		}
+	}
+	return nil
+}
+
 // Get retrieves a profile by name.
 func (r *ProfileRegistry) Get(name string) (*Profile, bool) {
 	p, ok := r.profiles[name]
@@ -131,6 +247,8 @@ func MergeProfiles(base, custom *Profile) *Profile {
 		merged := &Profile{
 			Name:        base.Name,
 			Format:      base.Format,
+			Version:     base.Version,
+			SchemaURL:   base.SchemaURL,
 			Description: base.Description,
 			Fields:      make(map[string]FieldMapping, len(base.Fields)),
 			Options:     base.Options,
@@ -143,6 +261,8 @@ func MergeProfiles(base, custom *Profile) *Profile {
 	merged := &Profile{
 		Name:        custom.Name,
 		Format:      custom.Format,
+		Version:     custom.Version,
+		SchemaURL:   custom.SchemaURL,
 		Description: custom.Description,
 		Fields:      make(map[string]FieldMapping),
 		Options:     base.Options,
@@ -151,6 +271,12 @@ func MergeProfiles(base, custom *Profile) *Profile {
 	if merged.Format == "" {
 		merged.Format = base.Format
 	}
+	if merged.Version == "" {
+		merged.Version = base.Version
+	}
+	if merged.SchemaURL == "" {
+		merged.SchemaURL = base.SchemaURL
+	}
 	if merged.Description == "" {
 		merged.Description = base.Description
 	}
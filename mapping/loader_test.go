@@ -0,0 +1,82 @@
+package mapping
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadProfileExtendsAndIncludes(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	write("default.yaml", `
+name: default
+format: drupal
+fields:
+  title: {ir: Title}
+  field_abstract: {ir: Abstract}
+  field_note: {ir: Notes}
+options:
+  multi_value_separator: "|"
+`)
+	write("thesis-overrides.yaml", `
+name: thesis-overrides
+fields:
+  field_department: {ir: DegreeInfo.Department}
+  field_note: {ir: Description}
+`)
+	path := write("etd.yaml", `
+name: etd
+extends: default
+include: [thesis-overrides.yaml]
+fields:
+  field_abstract: {ir: Abstract, transform: strip_html}
+`)
+
+	p, err := LoadProfile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Name != "etd" || p.Format != "drupal" || p.GetMultiValueSeparator() != "|" {
+		t.Errorf("got name %q, format %q, separator %q", p.Name, p.Format, p.GetMultiValueSeparator())
+	}
+	want := map[string]string{
+		"title":            "Title",
+		"field_abstract":   "Abstract",
+		"field_note":       "Description",
+		"field_department": "DegreeInfo.Department",
+	}
+	if len(p.Fields) != len(want) {
+		t.Errorf("got %d fields, want %d: %v", len(p.Fields), len(want), p.Fields)
+	}
+	for name, ir := range want {
+		if p.Fields[name].IR != ir {
+			t.Errorf("%s: got %q, want %q", name, p.Fields[name].IR, ir)
+		}
+	}
+	if len(p.Fields["field_abstract"].Transform) != 1 {
+		t.Error("the profile's own field should win over the base's")
+	}
+
+	// Embedded profiles can be extended by name
+	path = write("site.yaml", "name: site\nextends: islandora\nfields:\n  field_note: {ir: Notes}\n")
+	if p, err = LoadProfile(path); err != nil {
+		t.Fatal(err)
+	}
+	if p.Fields["field_edtf_date_issued"].IR != "Dates" || p.Fields["field_note"].IR != "Notes" {
+		t.Errorf("extending islandora: got %v", p.Fields)
+	}
+
+	write("a.yaml", "name: a\nextends: b\n")
+	write("b.yaml", "name: b\ninclude: [a.yaml]\n")
+	if _, err := LoadProfile(filepath.Join(dir, "a.yaml")); err == nil || !strings.Contains(err.Error(), "itself") {
+		t.Errorf("expected a cycle error, got %v", err)
+	}
+}
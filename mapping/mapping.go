@@ -19,6 +19,15 @@ type Profile struct {
 	// Description provides human-readable documentation
 	Description string `yaml:"description,omitempty" json:"description,omitempty"`
 
+	// Extends names a profile this one is based on: an embedded profile, a
+	// profile file in the same directory (without .yaml), or a path.
+	// Fields here override the base's.
+	Extends string `yaml:"extends,omitempty" json:"extends,omitempty"`
+
+	// Include lists profile files, relative to this one, merged in order
+	// over the base and under this profile's own fields
+	Include []string `yaml:"include,omitempty" json:"include,omitempty"`
+
 	// Fields maps source field names to IR field configurations
 	Fields map[string]FieldMapping `yaml:"fields" json:"fields"`
 
@@ -63,8 +72,17 @@ type FieldMapping struct {
 	// Vocabulary specifies the vocabulary for subject fields
 	Vocabulary string `yaml:"vocabulary,omitempty" json:"vocabulary,omitempty"`
 
-	// Transform specifies a transformation to apply (e.g., "strip_html", "lowercase")
-	Transform string `yaml:"transform,omitempty" json:"transform,omitempty"`
+	// Transform lists directives applied to each source value during
+	// parsing (e.g., "strip_html", "s/^Digitized: //"); see Transforms
+	Transform Transforms `yaml:"transform,omitempty" json:"transform,omitempty"`
+
+	// Template builds the value from the record's source fields, with Go
+	// template syntax (e.g., "{{.volume}}({{.issue}})"), before Transform
+	Template string `yaml:"template,omitempty" json:"template,omitempty"`
+
+	// Rules send values meeting a condition to another hub field (e.g., an
+	// identifier matching "^10\\." to Identifiers.doi); see Route
+	Rules []MappingRule `yaml:"rules,omitempty" json:"rules,omitempty"`
 
 	// Default is a default value if the source field is empty
 	Default string `yaml:"default,omitempty" json:"default,omitempty"`
@@ -77,6 +95,9 @@ type FieldMapping struct {
 
 	// Delimiter for multi-value fields in CSV format
 	Delimiter string `yaml:"delimiter,omitempty" json:"delimiter,omitempty"`
+
+	// MaxLength is the longest value the target field accepts; 0 means unlimited
+	MaxLength int `yaml:"max_length,omitempty" json:"max_length,omitempty"`
 }
 
 // ProfileOptions contains format-specific configuration options.
@@ -147,6 +168,7 @@ func DefaultCSVColumns() []string {
 		"publisher",
 		"place_published",
 		"member_of",
+		"membership_path",
 		"degree_name",
 		"degree_level",
 		"department",
@@ -0,0 +1,120 @@
+package mapping
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+
+	"github.com/lehigh-university-libraries/crosswalk/rules"
+)
+
+// MappingRule sends a field's values to another hub field when a condition
+// holds. A condition without a field tests the value being mapped; one
+// naming a field tests that source field, so a value's meaning can depend
+// on the record's other fields.
+type MappingRule struct {
+	// When is the condition a value must meet
+	When rules.Condition `yaml:"when" json:"when"`
+
+	// IR is the hub field matching values map to (default: the field's own)
+	IR string `yaml:"ir,omitempty" json:"ir,omitempty"`
+
+	// Type, DateType, RelationType, and Vocabulary override the field's
+	// own for matching values
+	Type         string `yaml:"type,omitempty" json:"type,omitempty"`
+	DateType     string `yaml:"date_type,omitempty" json:"date_type,omitempty"`
+	RelationType string `yaml:"relation_type,omitempty" json:"relation_type,omitempty"`
+	Vocabulary   string `yaml:"vocabulary,omitempty" json:"vocabulary,omitempty"`
+
+	// Skip drops matching values
+	Skip bool `yaml:"skip,omitempty" json:"skip,omitempty"`
+}
+
+// Route returns the mapping for one of a field's values: the field's own,
+// overridden by the first rule whose condition holds. fields holds the
+// record's source fields by name. ok is false when the rule skips the
+// value.
+func (m FieldMapping) Route(field, value string, fields map[string]string) (routed FieldMapping, ok bool) {
+	routed = m
+	routed.Rules = nil
+	if len(m.Rules) == 0 {
+		return routed, true
+	}
+
+	values := maps.Clone(fields)
+	if values == nil {
+		values = make(map[string]string, 1)
+	}
+	values[field] = value
+	for _, rule := range m.Rules {
+		when := conditionOn(rule.When, field)
+		if !when.Evaluate(values) {
+			continue
+		}
+		if rule.Skip {
+			return routed, false
+		}
+		if rule.IR != "" {
+			routed.IR = rule.IR
+		}
+		if rule.Type != "" {
+			routed.Type = rule.Type
+		}
+		if rule.DateType != "" {
+			routed.DateType = rule.DateType
+		}
+		if rule.RelationType != "" {
+			routed.RelationType = rule.RelationType
+		}
+		if rule.Vocabulary != "" {
+			routed.Vocabulary = rule.Vocabulary
+		}
+		return routed, true
+	}
+	return routed, true
+}
+
+// conditionOn points the condition's tests that name no field at field.
+func conditionOn(c rules.Condition, field string) rules.Condition {
+	c.All = conditionsOn(c.All, field)
+	c.Any = conditionsOn(c.Any, field)
+	if c.Not != nil {
+		not := conditionOn(*c.Not, field)
+		c.Not = &not
+	}
+	if c.Field == "" && len(c.All) == 0 && len(c.Any) == 0 && c.Not == nil {
+		c.Field = field
+	}
+	return c
+}
+
+func conditionsOn(cs []rules.Condition, field string) []rules.Condition {
+	if len(cs) == 0 {
+		return cs
+	}
+	out := make([]rules.Condition, len(cs))
+	for i, c := range cs {
+		out[i] = conditionOn(c, field)
+	}
+	return out
+}
+
+// validateConditions checks a condition's regular expressions compile,
+// since rules.Condition treats a bad pattern as a non-match.
+func validateConditions(c rules.Condition) error {
+	if c.Matches != "" {
+		if _, err := regexp.Compile(c.Matches); err != nil {
+			return fmt.Errorf("matches %q: %w", c.Matches, err)
+		}
+	}
+	subs := append(append([]rules.Condition(nil), c.All...), c.Any...)
+	if c.Not != nil {
+		subs = append(subs, *c.Not)
+	}
+	for _, sub := range subs {
+		if err := validateConditions(sub); err != nil {
+			return err
+		}
+	}
+	return nil
+}
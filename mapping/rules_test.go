@@ -0,0 +1,53 @@
+package mapping
+
+import (
+	"testing"
+
+	"github.com/lehigh-university-libraries/crosswalk/rules"
+)
+
+func TestRoute(t *testing.T) {
+	m := FieldMapping{IR: "Identifiers", Type: "local", Rules: []MappingRule{
+		{When: rules.Condition{Matches: `^10\.`}, Type: "doi"},
+		{When: rules.Condition{All: []rules.Condition{
+			{Field: "field_model", Equals: "Collection"},
+			{Not: &rules.Condition{Contains: "/"}},
+		}}, IR: "Extra.collection_code"},
+		{When: rules.Condition{In: []string{"n/a", "none"}}, Skip: true},
+	}}
+	fields := map[string]string{"field_model": "Collection"}
+
+	tests := []struct {
+		value, wantIR, wantType string
+		ok                      bool
+	}{
+		{"10.1234/abcd", "Identifiers", "doi", true},
+		{"LEH-001", "Extra.collection_code", "local", true},
+		{"https://example.edu/1", "Identifiers", "local", true},
+		{"N/A", "", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := m.Route("field_identifier", tt.value, fields)
+		if ok != tt.ok {
+			t.Errorf("%s: ok = %v, want %v", tt.value, ok, tt.ok)
+			continue
+		}
+		if ok && (got.IR != tt.wantIR || got.Type != tt.wantType) {
+			t.Errorf("%s: routed to %s/%s, want %s/%s", tt.value, got.IR, got.Type, tt.wantIR, tt.wantType)
+		}
+		if len(got.Rules) != 0 {
+			t.Errorf("%s: routed mapping should carry no rules", tt.value)
+		}
+	}
+
+	if got, _ := m.Route("field_identifier", "LEH-001", nil); got.IR != "Identifiers" {
+		t.Errorf("without field_model the collection rule should not match, got %s", got.IR)
+	}
+}
+
+func TestValidateRulePatterns(t *testing.T) {
+	_, err := LoadProfileFromString("name: bad\nfields:\n  id:\n    ir: Identifiers\n    rules:\n      - when: {matches: \"(\"}\n        type: doi\n")
+	if err == nil {
+		t.Error("expected a bad rule pattern to fail the load")
+	}
+}
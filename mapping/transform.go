@@ -0,0 +1,390 @@
+package mapping
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"text/template"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/lehigh-university-libraries/crosswalk/helpers"
+)
+
+// Transforms is a field's list of transform directives, applied in order
+// to each source value during parsing. In YAML it is a single directive or
+// a list of them:
+//
+//	s/pattern/replacement/flags  regex replace (flags: g all, i ignore case)
+//	strip_prefix:TEXT            remove a leading TEXT
+//	strip_suffix:TEXT            remove a trailing TEXT
+//	lowercase, uppercase, titlecase
+//	trim                         remove surrounding whitespace
+//	strip_html                   remove markup and decode entities
+//	split:DELIM                  split into one value per DELIM-separated part
+//	extract:REGEX                keep the match, or its first group; drop
+//	                             values that don't match
+//	add_prefix:TEXT              add a leading TEXT unless it's there
+//	add_suffix:TEXT              add a trailing TEXT unless it's there
+//	join:DELIM                   join the values into one, DELIM-separated
+//
+// The regex replacement expands $1 and ${name} as regexp.Expand does.
+type Transforms []string
+
+// UnmarshalYAML accepts a single directive or a list.
+func (t *Transforms) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*t = Transforms{node.Value}
+		return nil
+	}
+	var list []string
+	if err := node.Decode(&list); err != nil {
+		return err
+	}
+	*t = list
+	return nil
+}
+
+// TransformOptions are the transformation options of a hub.v1.field
+// annotation, which declare a spoke field's cleanup.
+type TransformOptions struct {
+	Split        string     `yaml:"split,omitempty" json:"split,omitempty"`
+	RegexExtract string     `yaml:"regex_extract,omitempty" json:"regex_extract,omitempty"`
+	Transform    Transforms `yaml:"transform,omitempty" json:"transform,omitempty"`
+	Prefix       string     `yaml:"prefix,omitempty" json:"prefix,omitempty"`
+	Suffix       string     `yaml:"suffix,omitempty" json:"suffix,omitempty"`
+	Join         string     `yaml:"join,omitempty" json:"join,omitempty"`
+}
+
+// Directives returns the options as transform directives, in the order
+// they apply: split, regex_extract, transform, prefix and suffix, then
+// join. It returns nil when no option is set.
+func (o TransformOptions) Directives() Transforms {
+	var t Transforms
+	add := func(name, arg string) {
+		if arg != "" {
+			t = append(t, name+":"+arg)
+		}
+	}
+	if o.Split != "" {
+		t = append(t, "split:"+o.Split, "trim")
+	}
+	add("extract", o.RegexExtract)
+	t = append(t, o.Transform...)
+	add("add_prefix", o.Prefix)
+	add("add_suffix", o.Suffix)
+	add("join", o.Join)
+	return t
+}
+
+// FieldTransform is a field's compiled template and transform directives.
+type FieldTransform struct {
+	tmpl  *template.Template
+	steps []func([]string) []string
+}
+
+// compiledTransforms caches FieldTransforms by their source, since parsers
+// look them up for every record.
+var compiledTransforms sync.Map
+
+// CompileTransform compiles the mapping's template and transform
+// directives. It returns nil when the mapping has neither.
+func (m FieldMapping) CompileTransform() (*FieldTransform, error) {
+	if m.Template == "" && len(m.Transform) == 0 {
+		return nil, nil
+	}
+	key := m.Template + "\x00" + strings.Join(m.Transform, "\x00")
+	if ft, ok := compiledTransforms.Load(key); ok {
+		return ft.(*FieldTransform), nil
+	}
+
+	ft := &FieldTransform{}
+	if m.Template != "" {
+		tmpl, err := template.New("field").Option("missingkey=zero").Parse(m.Template)
+		if err != nil {
+			return nil, fmt.Errorf("template %q: %w", m.Template, err)
+		}
+		ft.tmpl = tmpl
+	}
+	for _, d := range m.Transform {
+		if sep, ok := strings.CutPrefix(d, "join:"); ok {
+			ft.steps = append(ft.steps, func(values []string) []string {
+				return []string{strings.Join(values, sep)}
+			})
+			continue
+		}
+		step, err := compileDirective(d)
+		if err != nil {
+			return nil, fmt.Errorf("transform %q: %w", d, err)
+		}
+		ft.steps = append(ft.steps, func(values []string) []string {
+			var next []string
+			for _, v := range values {
+				next = append(next, step(v)...)
+			}
+			return next
+		})
+	}
+	compiledTransforms.Store(key, ft)
+	return ft, nil
+}
+
+// HasTemplate reports whether the field's value comes from a template
+// rather than the source field itself.
+func (t *FieldTransform) HasTemplate() bool {
+	return t != nil && t.tmpl != nil
+}
+
+// Apply transforms a field's source values. With a template, the values
+// are replaced by the template rendered over fields, the record's source
+// fields by name (e.g. {{.volume}}). Values left empty are dropped.
+func (t *FieldTransform) Apply(values []string, fields map[string]string) ([]string, error) {
+	if t == nil {
+		return values, nil
+	}
+	if t.tmpl != nil {
+		var buf bytes.Buffer
+		if err := t.tmpl.Execute(&buf, fields); err != nil {
+			return nil, fmt.Errorf("executing template: %w", err)
+		}
+		values = []string{buf.String()}
+	}
+	for _, step := range t.steps {
+		values = step(values)
+	}
+	var out []string
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out, nil
+}
+
+func compileDirective(d string) (func(string) []string, error) {
+	one := func(fn func(string) string) func(string) []string {
+		return func(s string) []string { return []string{fn(s)} }
+	}
+	name, arg, hasArg := strings.Cut(d, ":")
+	switch {
+	case strings.HasPrefix(d, "s") && len(d) > 1 && !isLetter(d[1]):
+		return compileSubstitution(d)
+	case name == "strip_prefix" && hasArg:
+		return one(func(s string) string { return strings.TrimPrefix(s, arg) }), nil
+	case name == "strip_suffix" && hasArg:
+		return one(func(s string) string { return strings.TrimSuffix(s, arg) }), nil
+	case name == "split" && hasArg && arg != "":
+		return func(s string) []string { return strings.Split(s, arg) }, nil
+	case name == "extract" && hasArg:
+		re, err := regexp.Compile(arg)
+		if err != nil {
+			return nil, err
+		}
+		return func(s string) []string {
+			m := re.FindStringSubmatch(s)
+			switch {
+			case m == nil:
+				return nil
+			case len(m) > 1:
+				return []string{m[1]}
+			}
+			return []string{m[0]}
+		}, nil
+	case name == "add_prefix" && hasArg:
+		return one(func(s string) string {
+			if strings.HasPrefix(s, arg) {
+				return s
+			}
+			return arg + s
+		}), nil
+	case name == "add_suffix" && hasArg:
+		return one(func(s string) string {
+			if strings.HasSuffix(s, arg) {
+				return s
+			}
+			return s + arg
+		}), nil
+	case d == "lowercase":
+		return one(strings.ToLower), nil
+	case d == "uppercase":
+		return one(strings.ToUpper), nil
+	case d == "titlecase":
+		return one(titleCase), nil
+	case d == "trim":
+		return one(strings.TrimSpace), nil
+	case d == "strip_html":
+		return one(helpers.CleanText), nil
+	}
+	return nil, fmt.Errorf("unknown directive")
+}
+
+// compileSubstitution compiles a sed-style s/pattern/replacement/flags,
+// where any punctuation may stand in for "/" and a backslash escapes it.
+func compileSubstitution(d string) (func(string) []string, error) {
+	delim := d[1]
+	var parts []string
+	var cur strings.Builder
+	for i := 2; i < len(d); i++ {
+		switch {
+		case d[i] == '\\' && i+1 < len(d) && d[i+1] == delim:
+			cur.WriteByte(delim)
+			i++
+		case d[i] == delim:
+			parts = append(parts, cur.String())
+			cur.Reset()
+		default:
+			cur.WriteByte(d[i])
+		}
+	}
+	parts = append(parts, cur.String())
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("expected s%cpattern%creplacement%cflags", delim, delim, delim)
+	}
+	pattern, repl, flags := parts[0], parts[1], parts[2]
+
+	global := false
+	for _, f := range flags {
+		switch f {
+		case 'g':
+			global = true
+		case 'i':
+			pattern = "(?i)" + pattern
+		default:
+			return nil, fmt.Errorf("unknown flag %q", f)
+		}
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	if global {
+		return func(s string) []string { return []string{re.ReplaceAllString(s, repl)} }, nil
+	}
+	return func(s string) []string {
+		loc := re.FindStringSubmatchIndex(s)
+		if loc == nil {
+			return []string{s}
+		}
+		expanded := re.ExpandString(nil, repl, s, loc)
+		return []string{s[:loc[0]] + string(expanded) + s[loc[1]:]}
+	}, nil
+}
+
+func isLetter(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b == '_'
+}
+
+// titleCase capitalizes the first letter of each word and lowercases the
+// rest.
+func titleCase(s string) string {
+	words := strings.Fields(strings.ToLower(s))
+	for i, w := range words {
+		r := []rune(w)
+		r[0] = unicode.ToTitle(r[0])
+		words[i] = string(r)
+	}
+	return strings.Join(words, " ")
+}
+
+// RowTransform applies a profile's templates and transform directives to
+// the rows of a tabular format. Columns match profile fields by name,
+// ignoring case. A cell's values are joined with the row's multi-value
+// separator, and template fields missing from the header become columns of
+// their own.
+type RowTransform struct {
+	header  []string
+	width   int // columns in the source header
+	columns map[int]*FieldTransform
+	sep     string
+}
+
+// RowTransform returns the transform for rows with the given header, or
+// nil when no profile field has a template or transform.
+func (p *Profile) RowTransform(header []string, sep string) (*RowTransform, error) {
+	if p == nil {
+		return nil, nil
+	}
+	t := &RowTransform{header: header, width: len(header), columns: make(map[int]*FieldTransform), sep: sep}
+	present := make(map[string]bool, len(header))
+	for i, col := range header {
+		name := strings.ToLower(strings.TrimSpace(col))
+		present[name] = true
+		ft, err := p.fieldTransform(name)
+		if err != nil {
+			return nil, err
+		}
+		if ft != nil {
+			t.columns[i] = ft
+		}
+	}
+	for name, m := range p.Fields {
+		if m.Template == "" || present[strings.ToLower(name)] {
+			continue
+		}
+		ft, err := m.CompileTransform()
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", name, err)
+		}
+		t.columns[len(t.header)] = ft
+		t.header = append(slices.Clip(t.header), name)
+	}
+	if len(t.columns) == 0 {
+		return nil, nil
+	}
+	return t, nil
+}
+
+// fieldTransform compiles the transform of the field a column names.
+func (p *Profile) fieldTransform(column string) (*FieldTransform, error) {
+	for name, m := range p.Fields {
+		if strings.EqualFold(name, column) {
+			ft, err := m.CompileTransform()
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", name, err)
+			}
+			return ft, nil
+		}
+	}
+	return nil, nil
+}
+
+// Header returns the header with any template columns added.
+func (t *RowTransform) Header() []string {
+	return t.header
+}
+
+// Apply returns the row with its cells transformed, in the order of Header.
+func (t *RowTransform) Apply(row []string) ([]string, error) {
+	if t == nil {
+		return row, nil
+	}
+	row = row[:min(len(row), t.width)]
+	fields := make(map[string]string, len(row))
+	for i, cell := range row {
+		col := strings.TrimSpace(t.header[i])
+		fields[col] = cell
+		fields[strings.ToLower(col)] = cell
+	}
+
+	out := make([]string, len(t.header))
+	copy(out, row)
+	for i, ft := range t.columns {
+		var values []string
+		if !ft.HasTemplate() {
+			if strings.TrimSpace(out[i]) == "" {
+				continue
+			}
+			values = []string{out[i]}
+		}
+		values, err := ft.Apply(values, fields)
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %w", t.header[i], err)
+		}
+		out[i] = strings.Join(values, t.sep)
+	}
+	return out, nil
+}
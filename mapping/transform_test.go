@@ -0,0 +1,137 @@
+package mapping
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFieldTransformApply(t *testing.T) {
+	tests := []struct {
+		transform Transforms
+		in        string
+		want      string
+	}{
+		{Transforms{"s/^Digitized: //"}, "Digitized: Annual report", "Annual report"},
+		{Transforms{"s/a/o/"}, "banana", "bonana"},
+		{Transforms{"s/a/o/g"}, "banana", "bonono"},
+		{Transforms{"s/BAN/pan/i"}, "banana", "panana"},
+		{Transforms{`s|/|-|g`}, "2001/02/03", "2001-02-03"},
+		{Transforms{`s/\//-/g`}, "2001/02/03", "2001-02-03"},
+		{Transforms{`s/(\w+), (\w+)/$2 $1/`}, "Smith, Jane", "Jane Smith"},
+		{Transforms{"strip_prefix:Box "}, "Box 12", "12"},
+		{Transforms{"strip_suffix:."}, "Report.", "Report"},
+		{Transforms{"titlecase"}, "the GREAT gatsby", "The Great Gatsby"},
+		{Transforms{"strip_html", "uppercase"}, "<p>fish &amp; chips</p>", "FISH & CHIPS"},
+		{Transforms{"split:;", "trim", "lowercase"}, "Budget; FINANCE", "budget|finance"},
+		{Transforms{`extract:10\..*`}, "doi:10.1234/abc", "10.1234/abc"},
+		{Transforms{`extract:^(\d{4})`, "split:-"}, "1998-05", "1998"},
+		{Transforms{"split:;", `extract:^\d+$`}, "12;n.p.;14", "12|14"},
+		{Transforms{"add_prefix:https://doi.org/"}, "10.1/x", "https://doi.org/10.1/x"},
+		{Transforms{"add_prefix:https://doi.org/"}, "https://doi.org/10.1/x", "https://doi.org/10.1/x"},
+		{Transforms{"add_suffix:."}, "Report", "Report."},
+		{Transforms{"split:|", "trim", "join:; "}, "a | b|c", "a; b; c"},
+	}
+	for _, tt := range tests {
+		ft, err := FieldMapping{Transform: tt.transform}.CompileTransform()
+		if err != nil {
+			t.Fatalf("%v: %v", tt.transform, err)
+		}
+		got, err := ft.Apply([]string{tt.in}, nil)
+		if err != nil {
+			t.Fatalf("%v: %v", tt.transform, err)
+		}
+		if strings.Join(got, "|") != tt.want {
+			t.Errorf("%v(%q) = %q, want %q", tt.transform, tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestTransformOptions(t *testing.T) {
+	opts := TransformOptions{
+		Split:        ";",
+		RegexExtract: `10\..*`,
+		Transform:    Transforms{"lowercase"},
+		Prefix:       "https://doi.org/",
+		Join:         " ",
+	}
+	ft, err := FieldMapping{Transform: opts.Directives()}.CompileTransform()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, _ := ft.Apply([]string{"doi:10.1/A; none; 10.2/B"}, nil)
+	if want := "https://doi.org/10.1/a https://doi.org/10.2/b"; strings.Join(got, "|") != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if d := (TransformOptions{}).Directives(); d != nil {
+		t.Errorf("no options give directives %v", d)
+	}
+}
+
+func TestFieldTransformTemplate(t *testing.T) {
+	ft, err := FieldMapping{Template: "{{.volume}}({{.issue}})", Transform: Transforms{"s/\\(\\)$//"}}.CompileTransform()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, _ := ft.Apply(nil, map[string]string{"volume": "12", "issue": "3"})
+	if strings.Join(got, "|") != "12(3)" {
+		t.Errorf("got %q, want 12(3)", got)
+	}
+	got, _ = ft.Apply(nil, map[string]string{"volume": "12"})
+	if strings.Join(got, "|") != "12" {
+		t.Errorf("missing fields should render empty, got %q", got)
+	}
+}
+
+func TestProfileTransformsLoad(t *testing.T) {
+	p, err := LoadProfileFromString(`
+name: cleanup
+fields:
+  title:
+    ir: Title
+    transform: "s/^Digitized: //"
+  keywords:
+    ir: Subjects.keywords
+    transform: ["split:;", lowercase]
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := p.Fields["keywords"].Transform; len(got) != 2 || got[1] != "lowercase" {
+		t.Errorf("list transform: got %v", got)
+	}
+	if got := p.Fields["title"].Transform; len(got) != 1 {
+		t.Errorf("single transform: got %v", got)
+	}
+
+	for _, bad := range []string{`transform: "s/(/x/"`, `transform: shout`, `template: "{{.volume"`} {
+		_, err := LoadProfileFromString("name: bad\nfields:\n  title:\n    ir: Title\n    " + bad + "\n")
+		if err == nil {
+			t.Errorf("%s: expected a load error", bad)
+		}
+	}
+}
+
+func TestRowTransform(t *testing.T) {
+	p := &Profile{Fields: map[string]FieldMapping{
+		"Title":    {IR: "Title", Transform: Transforms{"s/^Digitized: //"}},
+		"citation": {IR: "Notes", Template: "Vol. {{.volume}}({{.Issue}})"},
+	}}
+	rt, err := p.RowTransform([]string{"title", "volume", "Issue"}, "|")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(rt.Header(), ","); got != "title,volume,Issue,citation" {
+		t.Errorf("header: got %s", got)
+	}
+	row, err := rt.Apply([]string{"Digitized: Annual report", "12", "3"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(row, ","); got != "Annual report,12,3,Vol. 12(3)" {
+		t.Errorf("row: got %s", got)
+	}
+
+	if rt, _ := (&Profile{Fields: map[string]FieldMapping{"title": {IR: "Title"}}}).RowTransform([]string{"title"}, "|"); rt != nil {
+		t.Error("a profile without transforms needs no row transform")
+	}
+}
@@ -0,0 +1,295 @@
+// Package oaipmh is a small OAI-PMH 2.0 harvesting client. It issues
+// ListRecords requests, follows resumption tokens, and hands each response
+// page to the caller as-is, so format parsers read a live harvest exactly
+// as they read a saved one, OAI headers included.
+package oaipmh
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Datestamp granularities a repository reports in Identify.
+const (
+	GranularityDay     = "YYYY-MM-DD"
+	GranularitySeconds = "YYYY-MM-DDThh:mm:ssZ"
+)
+
+// Client harvests one OAI-PMH repository.
+type Client struct {
+	BaseURL    string
+	HTTPClient *http.Client
+	UserAgent  string
+
+	// Delay is the pause between page requests.
+	Delay time.Duration
+	// Retries is how many times a 503 Service Unavailable is retried,
+	// waiting for the Retry-After the repository asks for.
+	Retries int
+}
+
+// NewClient returns a client for the repository at baseURL.
+func NewClient(baseURL string) *Client {
+	return &Client{
+		BaseURL:    baseURL,
+		HTTPClient: &http.Client{Timeout: 60 * time.Second},
+		UserAgent:  "crosswalk-harvest (https://github.com/lehigh-university-libraries/crosswalk)",
+		Retries:    3,
+	}
+}
+
+// Request selects the records a ListRecords harvest returns.
+type Request struct {
+	MetadataPrefix string
+	Set            string
+	// From and Until are datestamps in the repository's granularity.
+	From  string
+	Until string
+}
+
+// Page is one ListRecords response.
+type Page struct {
+	// Body is the raw OAI-PMH response document.
+	Body         []byte
+	ResponseDate string
+	// Records counts records with metadata; Deleted counts records whose
+	// header has status="deleted" and no metadata.
+	Records int
+	Deleted int
+	// ResumptionToken is empty on the last page.
+	ResumptionToken  string
+	CompleteListSize int
+}
+
+// Summary totals a completed harvest.
+type Summary struct {
+	// ResponseDate is the first response's date, the from datestamp for
+	// the next incremental harvest.
+	ResponseDate string
+	Pages        int
+	Records      int
+	Deleted      int
+}
+
+// Error is an OAI-PMH protocol error, such as badArgument or
+// cannotDisseminateFormat.
+type Error struct {
+	Code    string
+	Message string
+}
+
+func (e *Error) Error() string {
+	if e.Message == "" {
+		return "OAI-PMH error " + e.Code
+	}
+	return fmt.Sprintf("OAI-PMH error %s: %s", e.Code, e.Message)
+}
+
+// Identify describes a repository.
+type Identify struct {
+	RepositoryName    string `xml:"repositoryName"`
+	BaseURL           string `xml:"baseURL"`
+	EarliestDatestamp string `xml:"earliestDatestamp"`
+	DeletedRecord     string `xml:"deletedRecord"`
+	Granularity       string `xml:"granularity"`
+}
+
+// envelope is the part of an OAI-PMH response the client reads; record
+// content is left for the format parsers.
+type envelope struct {
+	ResponseDate string `xml:"responseDate"`
+	Errors       []struct {
+		Code    string `xml:"code,attr"`
+		Message string `xml:",chardata"`
+	} `xml:"error"`
+	Identify    *Identify `xml:"Identify"`
+	ListRecords struct {
+		Records []struct {
+			Header struct {
+				Status string `xml:"status,attr"`
+			} `xml:"header"`
+			Metadata *struct{} `xml:"metadata"`
+		} `xml:"record"`
+		ResumptionToken struct {
+			Value            string `xml:",chardata"`
+			CompleteListSize string `xml:"completeListSize,attr"`
+		} `xml:"resumptionToken"`
+	} `xml:"ListRecords"`
+}
+
+// err returns the response's first protocol error, if any.
+func (e *envelope) err() *Error {
+	if len(e.Errors) == 0 {
+		return nil
+	}
+	return &Error{Code: e.Errors[0].Code, Message: strings.TrimSpace(e.Errors[0].Message)}
+}
+
+// Identify fetches the repository description.
+func (c *Client) Identify(ctx context.Context) (*Identify, error) {
+	_, env, err := c.get(ctx, url.Values{"verb": {"Identify"}})
+	if err != nil {
+		return nil, err
+	}
+	if oaiErr := env.err(); oaiErr != nil {
+		return nil, oaiErr
+	}
+	if env.Identify == nil {
+		return nil, fmt.Errorf("response has no Identify element")
+	}
+	return env.Identify, nil
+}
+
+// ListRecords harvests every page of records matching req, calling fn with
+// each page in order. A noRecordsMatch response ends the harvest with no
+// pages rather than an error. An error from fn stops the harvest.
+func (c *Client) ListRecords(ctx context.Context, req Request, fn func(*Page) error) (*Summary, error) {
+	if req.MetadataPrefix == "" {
+		return nil, fmt.Errorf("metadataPrefix is required")
+	}
+	params := url.Values{"verb": {"ListRecords"}, "metadataPrefix": {req.MetadataPrefix}}
+	if req.Set != "" {
+		params.Set("set", req.Set)
+	}
+	if req.From != "" {
+		params.Set("from", req.From)
+	}
+	if req.Until != "" {
+		params.Set("until", req.Until)
+	}
+
+	summary := &Summary{}
+	for {
+		body, env, err := c.get(ctx, params)
+		if err != nil {
+			return summary, err
+		}
+		if summary.ResponseDate == "" {
+			summary.ResponseDate = strings.TrimSpace(env.ResponseDate)
+		}
+		if oaiErr := env.err(); oaiErr != nil {
+			if oaiErr.Code == "noRecordsMatch" {
+				return summary, nil
+			}
+			return summary, oaiErr
+		}
+
+		page := &Page{
+			Body:            body,
+			ResponseDate:    strings.TrimSpace(env.ResponseDate),
+			ResumptionToken: strings.TrimSpace(env.ListRecords.ResumptionToken.Value),
+		}
+		page.CompleteListSize, _ = strconv.Atoi(env.ListRecords.ResumptionToken.CompleteListSize)
+		for _, rec := range env.ListRecords.Records {
+			switch {
+			case rec.Header.Status == "deleted":
+				page.Deleted++
+			case rec.Metadata != nil:
+				page.Records++
+			}
+		}
+
+		summary.Pages++
+		summary.Records += page.Records
+		summary.Deleted += page.Deleted
+		if err := fn(page); err != nil {
+			return summary, err
+		}
+
+		if page.ResumptionToken == "" {
+			return summary, nil
+		}
+		// Follow-up requests carry only the verb and the token
+		params = url.Values{"verb": {"ListRecords"}, "resumptionToken": {page.ResumptionToken}}
+		if err := sleep(ctx, c.Delay); err != nil {
+			return summary, err
+		}
+	}
+}
+
+// get issues one request and decodes the response envelope, retrying while
+// the repository answers 503 Service Unavailable.
+func (c *Client) get(ctx context.Context, params url.Values) ([]byte, *envelope, error) {
+	u, err := url.Parse(c.BaseURL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid base URL: %w", err)
+	}
+	u.RawQuery = params.Encode()
+
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		if c.UserAgent != "" {
+			req.Header.Set("User-Agent", c.UserAgent)
+		}
+
+		resp, err := c.HTTPClient.Do(req)
+		if err != nil {
+			return nil, nil, err
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, nil, fmt.Errorf("reading response: %w", err)
+		}
+
+		if resp.StatusCode == http.StatusServiceUnavailable && attempt < c.Retries {
+			if err := sleep(ctx, retryAfter(resp.Header.Get("Retry-After"))); err != nil {
+				return nil, nil, err
+			}
+			continue
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, nil, fmt.Errorf("unexpected status %s from %s", resp.Status, u.Redacted())
+		}
+
+		env := &envelope{}
+		if err := xml.Unmarshal(body, env); err != nil {
+			return nil, nil, fmt.Errorf("decoding OAI-PMH response: %w", err)
+		}
+		return body, env, nil
+	}
+}
+
+// retryAfter reads a Retry-After header given in seconds, defaulting to
+// ten seconds and capping long waits at five minutes.
+func retryAfter(header string) time.Duration {
+	seconds, err := strconv.Atoi(strings.TrimSpace(header))
+	if err != nil || seconds < 0 {
+		return 10 * time.Second
+	}
+	return min(time.Duration(seconds)*time.Second, 5*time.Minute)
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// Datestamp truncates a UTC datestamp to the repository's granularity, so
+// a responseDate can be sent back as from to a day-granularity repository.
+func Datestamp(stamp, granularity string) string {
+	if granularity == GranularityDay && len(stamp) > len("2006-01-02") {
+		return stamp[:len("2006-01-02")]
+	}
+	return stamp
+}
@@ -0,0 +1,197 @@
+package oaipmh
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const responseHead = `<?xml version="1.0" encoding="UTF-8"?>
+<OAI-PMH xmlns="http://www.openarchives.org/OAI/2.0/">
+  <responseDate>%s</responseDate>
+`
+
+func record(id, status string) string {
+	if status == "deleted" {
+		return `<record><header status="deleted"><identifier>` + id + `</identifier></header></record>`
+	}
+	return `<record><header><identifier>` + id + `</identifier></header>` +
+		`<metadata><oai_dc:dc xmlns:oai_dc="http://www.openarchives.org/OAI/2.0/oai_dc/" xmlns:dc="http://purl.org/dc/elements/1.1/">` +
+		`<dc:title>` + id + `</dc:title></oai_dc:dc></metadata></record>`
+}
+
+// repository serves two ListRecords pages joined by a resumption token and
+// records the query strings it receives.
+func repository(t *testing.T, queries *[]string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		*queries = append(*queries, r.URL.RawQuery)
+		fmt.Fprintf(w, responseHead, "2024-06-01T12:00:00Z")
+		switch {
+		case q.Get("verb") == "Identify":
+			fmt.Fprint(w, `<Identify><repositoryName>Test</repositoryName><granularity>YYYY-MM-DD</granularity></Identify>`)
+		case q.Get("resumptionToken") == "page2":
+			fmt.Fprint(w, `<ListRecords>`+record("oai:test:3", "")+`<resumptionToken completeListSize="3"/></ListRecords>`)
+		case q.Get("metadataPrefix") == "oai_dc" && q.Get("set") == "theses":
+			fmt.Fprint(w, `<ListRecords>`+record("oai:test:1", "")+record("oai:test:2", "deleted")+
+				`<resumptionToken completeListSize="3" cursor="0">page2</resumptionToken></ListRecords>`)
+		case q.Get("metadataPrefix") == "oai_dc":
+			fmt.Fprint(w, `<error code="noRecordsMatch">No matching records</error>`)
+		default:
+			fmt.Fprint(w, `<error code="cannotDisseminateFormat">Unknown prefix</error>`)
+		}
+		fmt.Fprint(w, `</OAI-PMH>`)
+	}))
+}
+
+func TestListRecordsFollowsResumptionTokens(t *testing.T) {
+	var queries []string
+	srv := repository(t, &queries)
+	defer srv.Close()
+
+	var pages []*Page
+	summary, err := NewClient(srv.URL).ListRecords(context.Background(),
+		Request{MetadataPrefix: "oai_dc", Set: "theses", From: "2024-01-01"},
+		func(p *Page) error {
+			pages = append(pages, p)
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("ListRecords failed: %v", err)
+	}
+
+	if len(pages) != 2 {
+		t.Fatalf("expected 2 pages, got %d", len(pages))
+	}
+	if pages[0].Records != 1 || pages[0].Deleted != 1 || pages[0].ResumptionToken != "page2" || pages[0].CompleteListSize != 3 {
+		t.Errorf("page 1: got %+v", pages[0])
+	}
+	if pages[1].Records != 1 || pages[1].ResumptionToken != "" {
+		t.Errorf("page 2: got %+v", pages[1])
+	}
+	if !strings.Contains(string(pages[1].Body), "oai:test:3") {
+		t.Errorf("page body should be the raw response:\n%s", pages[1].Body)
+	}
+	if *summary != (Summary{ResponseDate: "2024-06-01T12:00:00Z", Pages: 2, Records: 2, Deleted: 1}) {
+		t.Errorf("summary: got %+v", summary)
+	}
+
+	want := []string{
+		"from=2024-01-01&metadataPrefix=oai_dc&set=theses&verb=ListRecords",
+		"resumptionToken=page2&verb=ListRecords",
+	}
+	if strings.Join(queries, "\n") != strings.Join(want, "\n") {
+		t.Errorf("queries:\n got %v\nwant %v", queries, want)
+	}
+}
+
+func TestListRecordsNoRecordsMatch(t *testing.T) {
+	var queries []string
+	srv := repository(t, &queries)
+	defer srv.Close()
+
+	called := false
+	summary, err := NewClient(srv.URL).ListRecords(context.Background(), Request{MetadataPrefix: "oai_dc"},
+		func(*Page) error {
+			called = true
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("noRecordsMatch should not be an error: %v", err)
+	}
+	if called || summary.Pages != 0 || summary.ResponseDate != "2024-06-01T12:00:00Z" {
+		t.Errorf("expected an empty harvest with a response date, got %+v (called=%v)", summary, called)
+	}
+}
+
+func TestListRecordsProtocolError(t *testing.T) {
+	var queries []string
+	srv := repository(t, &queries)
+	defer srv.Close()
+
+	_, err := NewClient(srv.URL).ListRecords(context.Background(), Request{MetadataPrefix: "mods"},
+		func(*Page) error { return nil })
+	var oaiErr *Error
+	if !errors.As(err, &oaiErr) || oaiErr.Code != "cannotDisseminateFormat" || oaiErr.Message != "Unknown prefix" {
+		t.Errorf("expected a cannotDisseminateFormat error, got %v", err)
+	}
+}
+
+func TestListRecordsCallbackErrorStops(t *testing.T) {
+	var queries []string
+	srv := repository(t, &queries)
+	defer srv.Close()
+
+	stop := errors.New("stop")
+	_, err := NewClient(srv.URL).ListRecords(context.Background(), Request{MetadataPrefix: "oai_dc", Set: "theses"},
+		func(*Page) error { return stop })
+	if !errors.Is(err, stop) || len(queries) != 1 {
+		t.Errorf("expected the harvest to stop after one page, got %v after %d requests", err, len(queries))
+	}
+}
+
+func TestRetryOnServiceUnavailable(t *testing.T) {
+	attempts := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprintf(w, responseHead, "2024-06-01T12:00:00Z")
+		fmt.Fprint(w, `<Identify><granularity>YYYY-MM-DDThh:mm:ssZ</granularity></Identify></OAI-PMH>`)
+	}))
+	defer srv.Close()
+
+	id, err := NewClient(srv.URL).Identify(context.Background())
+	if err != nil {
+		t.Fatalf("Identify failed: %v", err)
+	}
+	if attempts != 2 || id.Granularity != GranularitySeconds {
+		t.Errorf("got %d attempts, granularity %q", attempts, id.Granularity)
+	}
+}
+
+func TestIdentify(t *testing.T) {
+	var queries []string
+	srv := repository(t, &queries)
+	defer srv.Close()
+
+	id, err := NewClient(srv.URL).Identify(context.Background())
+	if err != nil {
+		t.Fatalf("Identify failed: %v", err)
+	}
+	if id.RepositoryName != "Test" || id.Granularity != GranularityDay {
+		t.Errorf("got %+v", id)
+	}
+}
+
+func TestDatestamp(t *testing.T) {
+	if got := Datestamp("2024-06-01T12:00:00Z", GranularityDay); got != "2024-06-01" {
+		t.Errorf("day granularity: got %s", got)
+	}
+	if got := Datestamp("2024-06-01T12:00:00Z", GranularitySeconds); got != "2024-06-01T12:00:00Z" {
+		t.Errorf("seconds granularity: got %s", got)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := map[string]time.Duration{
+		"":      10 * time.Second,
+		"30":    30 * time.Second,
+		"86400": 5 * time.Minute,
+		"soon":  10 * time.Second,
+	}
+	for header, want := range tests {
+		if got := retryAfter(header); got != want {
+			t.Errorf("retryAfter(%q) = %v, want %v", header, got, want)
+		}
+	}
+}
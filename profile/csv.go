@@ -49,6 +49,9 @@ func AvailableHubFields() []HubField {
 		{Name: "DegreeInfo.DegreeLevel", Description: "Degree level (thesis)"},
 		{Name: "DegreeInfo.Department", Description: "Department (thesis)"},
 		{Name: "DegreeInfo.Institution", Description: "Institution (thesis)"},
+		{Name: "Holdings.CallNumber", Description: "Call number or shelf locator"},
+		{Name: "Holdings.Institution", Description: "Holding institution or physical location"},
+		{Name: "ArchivalLocation", Description: "Box/folder (e.g. \"Box 3, Folder 12\")"},
 		{Name: "Extra", Description: "Custom field (specify name)", HasSubtype: true},
 		{Name: "Skip", Description: "Ignore this column"},
 	}
@@ -259,75 +262,81 @@ func readCSVHeader(path string) ([]string, []string, error) {
 	return header, sample, nil
 }
 
+// csvSuggestions maps normalized column names (lowercase, words separated
+// by spaces) to hub fields.
+var csvSuggestions = map[string]string{
+	"title":             "Title",
+	"name":              "Title",
+	"alt title":         "AltTitle",
+	"alternative title": "AltTitle",
+	"subtitle":          "AltTitle",
+	"author":            "Contributors",
+	"authors":           "Contributors",
+	"creator":           "Contributors",
+	"creators":          "Contributors",
+	"contributor":       "Contributors",
+	"contributors":      "Contributors",
+	"date":              "Dates",
+	"year":              "Dates",
+	"date issued":       "Dates",
+	"date created":      "Dates",
+	"publication date":  "Dates",
+	"pub date":          "Dates",
+	"type":              "ResourceType",
+	"resource type":     "ResourceType",
+	"genre":             "Genre",
+	"language":          "Language",
+	"lang":              "Language",
+	"rights":            "Rights",
+	"license":           "Rights",
+	"abstract":          "Abstract",
+	"summary":           "Abstract",
+	"description":       "Description",
+	"doi":               "Identifiers",
+	"identifier":        "Identifiers",
+	"identifiers":       "Identifiers",
+	"isbn":              "Identifiers",
+	"issn":              "Identifiers",
+	"url":               "Identifiers",
+	"subject":           "Subjects",
+	"subjects":          "Subjects",
+	"keyword":           "Subjects",
+	"keywords":          "Subjects",
+	"topic":             "Subjects",
+	"topics":            "Subjects",
+	"publisher":         "Publisher",
+	"place":             "PlacePublished",
+	"place published":   "PlacePublished",
+	"publication place": "PlacePublished",
+	"notes":             "Notes",
+	"note":              "Notes",
+	"collection":        "Relations",
+	"member of":         "Relations",
+	"degree":            "DegreeInfo.DegreeName",
+	"degree name":       "DegreeInfo.DegreeName",
+	"degree level":      "DegreeInfo.DegreeLevel",
+	"department":        "DegreeInfo.Department",
+	"institution":       "DegreeInfo.Institution",
+	"call number":       "Holdings.CallNumber",
+	"shelf locator":     "Holdings.CallNumber",
+	"location":          "Holdings.Institution",
+	"box/folder":        "ArchivalLocation",
+	"nid":               "Extra.nid",
+	"uuid":              "Extra.uuid",
+	"id":                "Extra.id",
+}
+
 func suggestHubField(column string) string {
 	col := strings.ToLower(column)
 	col = strings.ReplaceAll(col, "_", " ")
 	col = strings.ReplaceAll(col, "-", " ")
 
-	suggestions := map[string]string{
-		"title":             "Title",
-		"name":              "Title",
-		"alt title":         "AltTitle",
-		"alternative title": "AltTitle",
-		"subtitle":          "AltTitle",
-		"author":            "Contributors",
-		"authors":           "Contributors",
-		"creator":           "Contributors",
-		"creators":          "Contributors",
-		"contributor":       "Contributors",
-		"contributors":      "Contributors",
-		"date":              "Dates",
-		"year":              "Dates",
-		"date issued":       "Dates",
-		"date created":      "Dates",
-		"publication date":  "Dates",
-		"pub date":          "Dates",
-		"type":              "ResourceType",
-		"resource type":     "ResourceType",
-		"genre":             "Genre",
-		"language":          "Language",
-		"lang":              "Language",
-		"rights":            "Rights",
-		"license":           "Rights",
-		"abstract":          "Abstract",
-		"summary":           "Abstract",
-		"description":       "Description",
-		"doi":               "Identifiers",
-		"identifier":        "Identifiers",
-		"identifiers":       "Identifiers",
-		"isbn":              "Identifiers",
-		"issn":              "Identifiers",
-		"url":               "Identifiers",
-		"subject":           "Subjects",
-		"subjects":          "Subjects",
-		"keyword":           "Subjects",
-		"keywords":          "Subjects",
-		"topic":             "Subjects",
-		"topics":            "Subjects",
-		"publisher":         "Publisher",
-		"place":             "PlacePublished",
-		"place published":   "PlacePublished",
-		"publication place": "PlacePublished",
-		"notes":             "Notes",
-		"note":              "Notes",
-		"collection":        "Relations",
-		"member of":         "Relations",
-		"degree":            "DegreeInfo.DegreeName",
-		"degree name":       "DegreeInfo.DegreeName",
-		"degree level":      "DegreeInfo.DegreeLevel",
-		"department":        "DegreeInfo.Department",
-		"institution":       "DegreeInfo.Institution",
-		"nid":               "Extra.nid",
-		"uuid":              "Extra.uuid",
-		"id":                "Extra.id",
-	}
-
-	if hub, ok := suggestions[col]; ok {
+	if hub, ok := csvSuggestions[col]; ok {
 		return hub
 	}
 
 	// Partial matching
-	for key, hub := range suggestions {
+	for key, hub := range csvSuggestions {
 		if strings.Contains(col, key) {
 			return hub
 		}
@@ -26,6 +26,10 @@ type DrupalFieldConfig struct {
 	Required    bool           `yaml:"required"`
 	FieldType   string         `yaml:"field_type"`
 	Settings    map[string]any `yaml:"settings"`
+
+	// MaxLength is the storage max_length for string fields, read from the
+	// matching field.storage.*.yml (0 when unlimited)
+	MaxLength int `yaml:"-"`
 }
 
 // DrupalSiteInfo holds site-level information.
@@ -130,6 +134,7 @@ func parseFieldConfigs(configPath string) ([]DrupalFieldConfig, error) {
 
 		// Only include node fields for now
 		if field.EntityType == "node" {
+			field.MaxLength = storageMaxLength(configPath, field.EntityType, field.FieldName)
 			fields = append(fields, field)
 		}
 	}
@@ -137,6 +142,24 @@ func parseFieldConfigs(configPath string) ([]DrupalFieldConfig, error) {
 	return fields, nil
 }
 
+// storageMaxLength reads settings.max_length from a field's storage config.
+// Drupal enforces it on string fields; other types have no such setting.
+func storageMaxLength(configPath, entityType, fieldName string) int {
+	data, err := os.ReadFile(filepath.Join(configPath, fmt.Sprintf("field.storage.%s.%s.yml", entityType, fieldName)))
+	if err != nil {
+		return 0
+	}
+	var storage struct {
+		Settings struct {
+			MaxLength int `yaml:"max_length"`
+		} `yaml:"settings"`
+	}
+	if yaml.Unmarshal(data, &storage) != nil {
+		return 0
+	}
+	return storage.Settings.MaxLength
+}
+
 func computeFieldFingerprint(fields []DrupalFieldConfig) string {
 	var names []string
 	for _, f := range fields {
@@ -211,6 +234,15 @@ func mapDrupalField(field DrupalFieldConfig) *FieldMapping {
 		mapping.Hub = "Language"
 		mapping.Resolve = "taxonomy_term"
 
+	// Copyright (before the generic rights match)
+	case strings.Contains(name, "rights_holder") || strings.Contains(name, "copyright_holder"):
+		mapping.Hub = "RightsHolder"
+		if fieldType == "entity_reference" {
+			mapping.Resolve = "taxonomy_term"
+		}
+	case strings.Contains(name, "copyright_statement") || strings.Contains(name, "copyright_notice"):
+		mapping.Hub = "CopyrightStatement"
+
 	// Rights
 	case strings.Contains(name, "rights"):
 		mapping.Hub = "Rights"
@@ -256,6 +288,16 @@ func mapDrupalField(field DrupalFieldConfig) *FieldMapping {
 		mapping.Hub = "Relations"
 		mapping.RelationType = "related_to"
 
+	// Physical holdings; call numbers are not identifiers
+	case strings.Contains(name, "call_number") || strings.Contains(name, "shelf_locator"):
+		mapping.Hub = "Holdings.CallNumber"
+	case strings.Contains(name, "physical_location") || strings.Contains(name, "holding_institution"):
+		mapping.Hub = "Holdings.Institution"
+	case strings.Contains(name, "sublocation"):
+		mapping.Hub = "Holdings.Sublocation"
+	case strings.Contains(name, "box_folder"):
+		mapping.Hub = "ArchivalLocation"
+
 	// Identifiers
 	case strings.Contains(name, "identifier"):
 		mapping.Hub = "Identifiers"
@@ -277,6 +319,18 @@ func mapDrupalField(field DrupalFieldConfig) *FieldMapping {
 	case strings.Contains(name, "institution"):
 		mapping.Hub = "DegreeInfo.Institution"
 
+	// Islandora media
+	case strings.Contains(name, "media_use"):
+		mapping.Hub = "FileRole"
+		mapping.Resolve = "taxonomy_term"
+	case name == "field_media_file" || name == "field_media_image" || name == "field_media_document" ||
+		name == "field_media_audio_file" || name == "field_media_video_file":
+		mapping.Hub = "Files"
+
+	// Dataset files
+	case strings.Contains(name, "distribution") || strings.Contains(name, "dataset_file"):
+		mapping.Hub = "Distributions"
+
 	// Notes and other
 	case strings.Contains(name, "note"):
 		mapping.Hub = "Notes"
@@ -297,6 +351,8 @@ func mapDrupalField(field DrupalFieldConfig) *FieldMapping {
 		mapping.Hub = "Extra." + cleanName
 	}
 
+	mapping.MaxLength = field.MaxLength
+
 	return mapping
 }
 
@@ -0,0 +1,449 @@
+package profile
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/lehigh-university-libraries/crosswalk/spoke"
+)
+
+// Generated is a starter profile guessed from sample data.
+type Generated struct {
+	*Profile
+
+	// Review holds, by source field, why its guess should be checked
+	Review map[string]string
+}
+
+// YAML renders the profile with a "# REVIEW" comment above each field
+// whose guess should be checked.
+func (g *Generated) YAML() ([]byte, error) {
+	var doc yaml.Node
+	if err := doc.Encode(g.Profile); err != nil {
+		return nil, fmt.Errorf("encoding profile: %w", err)
+	}
+	for i := 0; i+1 < len(doc.Content); i += 2 {
+		if doc.Content[i].Value != "fields" {
+			continue
+		}
+		fields := doc.Content[i+1]
+		for j := 0; j+1 < len(fields.Content); j += 2 {
+			if reason, ok := g.Review[fields.Content[j].Value]; ok {
+				fields.Content[j].HeadComment = "REVIEW: " + reason
+			}
+		}
+	}
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, fmt.Errorf("encoding profile: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func newGenerated(name, format, source string) *Generated {
+	return &Generated{
+		Profile: &Profile{
+			Name:        name,
+			Format:      format,
+			Description: "Generated from sample " + source,
+			Fields:      make(map[string]FieldMapping),
+			Options:     Options{MultiValueSeparator: "|"},
+		},
+		Review: make(map[string]string),
+	}
+}
+
+// guessField guesses a field's mapping with the spoke generator's
+// heuristics. The reason is empty for a confident guess.
+func guessField(name, fieldType string) (FieldMapping, string) {
+	hub, hubType, parser, matched := spoke.GuessHubField(name, fieldType, "")
+	m := hubMapping(hub, hubType, parser)
+	if !matched {
+		return m, "no heuristic matched the name; kept as " + hub
+	}
+	return m, ""
+}
+
+// hubMapping is the mapping to a hub field, with its subtype (a date type,
+// identifier type, and so on) set where the hub field takes it.
+func hubMapping(hub, hubType, parser string) FieldMapping {
+	m := FieldMapping{Hub: hub, Parser: parser}
+	switch hub {
+	case "Dates":
+		m.DateType = hubType
+	case "Relations":
+		m.RelationType = hubType
+	case "Identifiers":
+		m.Type = hubType
+	case "Subjects":
+		m.Vocabulary = hubType
+	}
+	return m
+}
+
+// FromCSVSpoke returns the CSV profile matching a spoke generated from a
+// sample spreadsheet (see spoke.GenerateCSVSpoke), so its columns can be
+// converted before the spoke is compiled in. Columns mapped to Extra are
+// marked for review.
+func FromCSVSpoke(name string, proto *spoke.ProtoFile) (*Generated, error) {
+	if len(proto.Messages) == 0 {
+		return nil, fmt.Errorf("spoke has no messages")
+	}
+	g := newGenerated(name, "csv", "CSV columns")
+	g.Options.CSVDelimiter = ","
+	for _, f := range proto.Messages[0].Fields {
+		if f.CSVColumn == "" {
+			continue
+		}
+		m := hubMapping(f.HubField, f.HubType, f.Parser)
+		if f.Delimiter != "" {
+			m.MultiValue = true
+			m.Delimiter = f.Delimiter
+		}
+		g.Source.CSVColumns = append(g.Source.CSVColumns, f.CSVColumn)
+		g.Fields[f.CSVColumn] = m
+		if strings.HasPrefix(f.HubField, "Extra.") {
+			g.Review[f.CSVColumn] = "no heuristic matched the name or values; kept as " + f.HubField
+		}
+	}
+	if len(g.Fields) == 0 {
+		return nil, fmt.Errorf("spoke has no CSV columns")
+	}
+	return g, nil
+}
+
+// GenerateFromDrupal guesses a profile from sample Drupal entity JSON, a
+// single entity or an array of them.
+func GenerateFromDrupal(name string, data []byte) (*Generated, error) {
+	var entities []map[string]json.RawMessage
+	if err := json.Unmarshal(data, &entities); err != nil {
+		var entity map[string]json.RawMessage
+		if err := json.Unmarshal(data, &entity); err != nil {
+			return nil, fmt.Errorf("parsing Drupal JSON: %w", err)
+		}
+		entities = append(entities, entity)
+	}
+
+	g := newGenerated(name, "drupal", "Drupal entities")
+	g.Options.StripHTML = true
+	g.Options.TaxonomyMode = "passthrough"
+	addCoreDrupalFields(g.Profile)
+	for core := range g.Fields {
+		if !hasKey(entities, core) {
+			delete(g.Fields, core)
+		}
+	}
+
+	// A field empty in one entity may have a value to learn its type from
+	// in another
+	sampled := make(map[string]bool)
+	for _, entity := range entities {
+		for field, raw := range entity {
+			if !strings.HasPrefix(field, "field_") || sampled[field] {
+				continue
+			}
+			sample, ok := sampleItem(raw)
+			sampled[field] = ok
+			fieldType := drupalItemType(field, sample)
+			m, reason := guessField(field, fieldType)
+			switch fieldType {
+			case "typed_relation":
+				m.Type = "typed_relation"
+				m.RoleField = "rel_type"
+				m.Resolve, _ = sample["target_type"].(string)
+			case "entity_reference":
+				m.Resolve, _ = sample["target_type"].(string)
+			}
+			if m.Hub == "" {
+				m.Hub = "Extra." + strings.TrimPrefix(field, "field_")
+				reason = "empty in the sample and no heuristic matched the name; kept as " + m.Hub
+			}
+			g.Fields[field] = m
+			g.Review[field] = reason
+			if reason == "" {
+				delete(g.Review, field)
+			}
+		}
+	}
+	return g, nil
+}
+
+func hasKey(entities []map[string]json.RawMessage, key string) bool {
+	for _, e := range entities {
+		if _, ok := e[key]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// sampleItem returns the first item of a Drupal field value.
+func sampleItem(raw json.RawMessage) (map[string]any, bool) {
+	var items []map[string]any
+	if err := json.Unmarshal(raw, &items); err != nil || len(items) == 0 {
+		return nil, false
+	}
+	return items[0], true
+}
+
+// drupalItemType infers a Drupal field type from the shape of an item.
+func drupalItemType(field string, item map[string]any) string {
+	switch {
+	case item == nil:
+		return ""
+	case item["rel_type"] != nil:
+		return "typed_relation"
+	case item["target_id"] != nil:
+		return "entity_reference"
+	case item["uri"] != nil:
+		return "link"
+	case strings.Contains(field, "edtf"):
+		return "edtf"
+	}
+	return "string"
+}
+
+// GenerateFromCSV guesses a profile from a CSV file's header, using its
+// first row to spot multi-value columns.
+func GenerateFromCSV(name string, r io.Reader) (*Generated, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading CSV header: %w", err)
+	}
+	sample, _ := reader.Read()
+
+	g := newGenerated(name, "csv", "CSV columns")
+	g.Source.CSVColumns = header
+	g.Options.CSVDelimiter = ","
+
+	for i, col := range header {
+		norm := strings.Join(strings.FieldsFunc(strings.ToLower(col), func(r rune) bool {
+			return r == '_' || r == '-' || unicode.IsSpace(r)
+		}), " ")
+
+		var m FieldMapping
+		var reason string
+		if hub, ok := csvSuggestions[norm]; ok {
+			m = FieldMapping{Hub: hub}
+		} else if guess, r := guessField("field_"+strings.ReplaceAll(norm, " ", "_"), "string"); r == "" {
+			m = guess
+		} else if hub := suggestHubField(col); hub != "" {
+			m = FieldMapping{Hub: hub}
+			reason = "guessed from part of the column name"
+		} else {
+			m, reason = guess, r
+		}
+
+		if m.Hub == "Dates" && m.DateType == "" {
+			switch {
+			case strings.Contains(norm, "issued"):
+				m.DateType = "issued"
+			case strings.Contains(norm, "created"):
+				m.DateType = "created"
+			default:
+				m.DateType = "issued"
+				if reason == "" {
+					reason = "date type not in the column name; issued assumed"
+				}
+			}
+		}
+		if i < len(sample) && strings.Contains(sample[i], g.Options.MultiValueSeparator) {
+			m.MultiValue = true
+			m.Delimiter = g.Options.MultiValueSeparator
+		}
+
+		g.Fields[col] = m
+		if reason != "" {
+			g.Review[col] = reason
+		}
+	}
+	return g, nil
+}
+
+// modsTargets maps MODS element paths, relative to <mods>, to hub fields.
+var modsTargets = map[string]FieldMapping{
+	"titleInfo/title":                         {Hub: "Title"},
+	"titleInfo/subTitle":                      {Hub: "AltTitle"},
+	"titleInfo[@type=alternative]/title":      {Hub: "AltTitle"},
+	"titleInfo[@type=translated]/title":       {Hub: "AltTitle"},
+	"titleInfo[@type=uniform]/title":          {Hub: "AltTitle"},
+	"titleInfo[@type=abbreviated]/title":      {Hub: "AltTitle"},
+	"name/namePart":                           {Hub: "Contributors"},
+	"originInfo/dateIssued":                   {Hub: "Dates", DateType: "issued", Parser: "edtf"},
+	"originInfo/dateCreated":                  {Hub: "Dates", DateType: "created", Parser: "edtf"},
+	"originInfo/dateCaptured":                 {Hub: "Dates", DateType: "captured", Parser: "edtf"},
+	"originInfo/copyrightDate":                {Hub: "Dates", DateType: "copyright", Parser: "edtf"},
+	"originInfo/dateModified":                 {Hub: "Dates", DateType: "modified", Parser: "edtf"},
+	"originInfo/dateValid":                    {Hub: "Dates", DateType: "valid", Parser: "edtf"},
+	"originInfo/publisher":                    {Hub: "Publisher"},
+	"originInfo/place/placeTerm":              {Hub: "PlacePublished"},
+	"originInfo/edition":                      {Hub: "Extra.edition"},
+	"originInfo/issuance":                     {Hub: "Extra.mode_of_issuance"},
+	"originInfo/frequency":                    {Hub: "Extra.frequency"},
+	"typeOfResource":                          {Hub: "ResourceType"},
+	"genre":                                   {Hub: "Genre"},
+	"language/languageTerm":                   {Hub: "Language"},
+	"physicalDescription/extent":              {Hub: "PhysicalDesc"},
+	"physicalDescription/form":                {Hub: "PhysicalDesc"},
+	"physicalDescription/digitalOrigin":       {Hub: "DigitalOrigin"},
+	"abstract":                                {Hub: "Abstract"},
+	"tableOfContents":                         {Hub: "TableOfContents"},
+	"note":                                    {Hub: "Notes"},
+	"subject/topic":                           {Hub: "Subjects"},
+	"subject/geographic":                      {Hub: "Subjects", Vocabulary: "geographic"},
+	"subject/temporal":                        {Hub: "Subjects", Vocabulary: "temporal"},
+	"subject/name/namePart":                   {Hub: "Subjects", Vocabulary: "name"},
+	"relatedItem[@type=host]/titleInfo/title": {Hub: "Relations", RelationType: "member_of"},
+	"identifier":                              {Hub: "Identifiers"},
+	"location/url":                            {Hub: "Identifiers", Type: "url"},
+	"location/shelfLocator":                   {Hub: "Holdings.CallNumber"},
+	"location/physicalLocation":               {Hub: "Holdings.Institution"},
+	"accessCondition":                         {Hub: "Rights"},
+}
+
+// GenerateFromMODS guesses a profile from a sample MODS document, keyed by
+// element path relative to <mods> (e.g., "originInfo/dateIssued").
+func GenerateFromMODS(name string, r io.Reader) (*Generated, error) {
+	paths, err := modsPaths(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no <mods> elements found")
+	}
+
+	g := newGenerated(name, "mods", "MODS")
+	for _, path := range paths {
+		base, attr := stripAttrs(path)
+		m, ok := modsTargets[path]
+		if !ok {
+			m, ok = modsTargets[base]
+		}
+		if ok && base == "identifier" && attr != "" {
+			m.Type = attr
+		}
+		if !ok {
+			leaf := base[strings.LastIndex(base, "/")+1:]
+			var reason string
+			if m, reason = guessField("field_"+snakeCase(leaf), "string"); reason == "" {
+				g.Review[path] = "guessed from the element name alone"
+			} else {
+				m.Hub = "Extra." + snakeCase(strings.ReplaceAll(base, "/", "_"))
+				g.Review[path] = "no heuristic matched the element; kept as " + m.Hub
+			}
+		}
+		g.Fields[path] = m
+	}
+	return g, nil
+}
+
+// modsPaths returns the paths of the elements holding text under each
+// <mods> element, sorted. titleInfo, identifier, and relatedItem keep
+// their type attribute, which changes their meaning.
+func modsPaths(r io.Reader) ([]string, error) {
+	dec := xml.NewDecoder(r)
+	seen := make(map[string]bool)
+	var stack []string
+	var text strings.Builder
+	inMODS := 0
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parsing MODS: %w", err)
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if t.Name.Local == "mods" {
+				inMODS++
+				stack = stack[:0]
+				continue
+			}
+			if inMODS == 0 {
+				continue
+			}
+			step := t.Name.Local
+			switch step {
+			case "titleInfo", "identifier", "relatedItem":
+				for _, a := range t.Attr {
+					if a.Name.Local == "type" {
+						step += "[@type=" + a.Value + "]"
+					}
+				}
+			}
+			stack = append(stack, step)
+			text.Reset()
+		case xml.CharData:
+			if inMODS > 0 {
+				text.Write(t)
+			}
+		case xml.EndElement:
+			if t.Name.Local == "mods" {
+				inMODS--
+				continue
+			}
+			if inMODS == 0 || len(stack) == 0 {
+				continue
+			}
+			if strings.TrimSpace(text.String()) != "" {
+				seen[strings.Join(stack, "/")] = true
+			}
+			text.Reset()
+			stack = stack[:len(stack)-1]
+		}
+	}
+	paths := make([]string, 0, len(seen))
+	for p := range seen {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// stripAttrs removes the attribute predicates from a path, returning the
+// last one's value.
+func stripAttrs(path string) (string, string) {
+	var b strings.Builder
+	var attr string
+	for {
+		i := strings.Index(path, "[@type=")
+		if i < 0 {
+			b.WriteString(path)
+			return b.String(), attr
+		}
+		j := strings.Index(path[i:], "]")
+		b.WriteString(path[:i])
+		attr = path[i+len("[@type=") : i+j]
+		path = path[i+j+1:]
+	}
+}
+
+// snakeCase converts a camelCase MODS element name to snake_case.
+func snakeCase(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,107 @@
+package profile
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lehigh-university-libraries/crosswalk/spoke"
+)
+
+func TestGenerateFromDrupal(t *testing.T) {
+	sample := `[
+  {"nid": [{"value": 1}], "title": [{"value": "Sediment"}],
+   "field_linked_agent": [{"target_id": 3, "target_type": "taxonomy_term", "rel_type": "relators:aut"}],
+   "field_edtf_date_issued": [{"value": "2020"}],
+   "field_frobnitz": [],
+   "langcode": [{"value": "en"}]},
+  {"field_frobnitz": [{"value": "x"}]}
+]`
+	g, err := GenerateFromDrupal("sample", []byte(sample))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.Fields["title"].Hub != "Title" || g.Fields["nid"].Hub != "Extra.nid" {
+		t.Errorf("core fields: got %v", g.Fields)
+	}
+	if _, ok := g.Fields["uuid"]; ok {
+		t.Error("core fields missing from the sample should be left out")
+	}
+	if _, ok := g.Fields["langcode"]; ok {
+		t.Error("base fields other than the core ones should be left out")
+	}
+	agent := g.Fields["field_linked_agent"]
+	if agent.Hub != "Contributors" || agent.Type != "typed_relation" || agent.RoleField != "rel_type" || agent.Resolve != "taxonomy_term" {
+		t.Errorf("field_linked_agent: got %+v", agent)
+	}
+	date := g.Fields["field_edtf_date_issued"]
+	if date.Hub != "Dates" || date.DateType != "issued" || date.Parser != "edtf" {
+		t.Errorf("field_edtf_date_issued: got %+v", date)
+	}
+	if g.Fields["field_frobnitz"].Hub != "Extra.frobnitz" || g.Review["field_frobnitz"] == "" {
+		t.Errorf("field_frobnitz: got %+v, review %q", g.Fields["field_frobnitz"], g.Review["field_frobnitz"])
+	}
+	if len(g.Review) != 1 {
+		t.Errorf("expected only field_frobnitz to need review, got %v", g.Review)
+	}
+
+	out, err := g.YAML()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(out), "# REVIEW: no heuristic matched the name; kept as Extra.frobnitz\n  field_frobnitz:") {
+		t.Errorf("review comment missing from:\n%s", out)
+	}
+}
+
+func TestGenerateFromMODS(t *testing.T) {
+	sample := `<mods xmlns="http://www.loc.gov/mods/v3">
+  <titleInfo type="alternative"><title>Mud</title></titleInfo>
+  <originInfo><dateCaptured>2021</dateCaptured><edition/></originInfo>
+  <identifier type="isbn">9780000000000</identifier>
+  <recordInfo><recordOrigin>converted</recordOrigin></recordInfo>
+</mods>`
+	g, err := GenerateFromMODS("sample", strings.NewReader(sample))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"titleInfo[@type=alternative]/title": "AltTitle",
+		"originInfo/dateCaptured":            "Dates",
+		"identifier[@type=isbn]":             "Identifiers",
+		"recordInfo/recordOrigin":            "Extra.record_info_record_origin",
+	}
+	if len(g.Fields) != len(want) {
+		t.Errorf("got fields %v", g.Fields)
+	}
+	for path, hub := range want {
+		if g.Fields[path].Hub != hub {
+			t.Errorf("%s: got %q, want %q", path, g.Fields[path].Hub, hub)
+		}
+	}
+	if g.Fields["identifier[@type=isbn]"].Type != "isbn" {
+		t.Errorf("identifier type: got %q", g.Fields["identifier[@type=isbn]"].Type)
+	}
+	if _, ok := g.Review["recordInfo/recordOrigin"]; !ok || len(g.Review) != 1 {
+		t.Errorf("review: got %v", g.Review)
+	}
+}
+
+func TestFromCSVSpoke(t *testing.T) {
+	proto, err := spoke.GenerateCSVSpoke("vendor", strings.NewReader("Title,Keywords,Pages\nA study,maps; rivers,120\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := FromCSVSpoke("vendor", proto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.Format != "csv" || strings.Join(g.Source.CSVColumns, ",") != "Title,Keywords,Pages" {
+		t.Errorf("got format %s, columns %v", g.Format, g.Source.CSVColumns)
+	}
+	if kw := g.Fields["Keywords"]; kw.Hub != "Subjects" || kw.Vocabulary != "keywords" || !kw.MultiValue || kw.Delimiter != ";" {
+		t.Errorf("Keywords: got %+v", kw)
+	}
+	if g.Fields["Pages"].Hub != "Extra.pages" || g.Review["Pages"] == "" || len(g.Review) != 1 {
+		t.Errorf("Pages: got %+v, review %v", g.Fields["Pages"], g.Review)
+	}
+}
@@ -8,6 +8,9 @@ import (
 	"strings"
 
 	"gopkg.in/yaml.v3"
+
+	"github.com/lehigh-university-libraries/crosswalk/mapping"
+	"github.com/lehigh-university-libraries/crosswalk/rules"
 )
 
 // Profile represents a mapping configuration for a specific source.
@@ -21,6 +24,14 @@ type Profile struct {
 	// Description provides human-readable documentation
 	Description string `yaml:"description,omitempty" json:"description,omitempty"`
 
+	// Extends names the profile this one is based on: a saved profile, an
+	// embedded profile, or a YAML file. Fields here override the base's.
+	Extends string `yaml:"extends,omitempty" json:"extends,omitempty"`
+
+	// Include lists profiles merged in order over the base and under this
+	// profile's own fields, named as for Extends
+	Include []string `yaml:"include,omitempty" json:"include,omitempty"`
+
 	// Source contains information about the original source for auto-discovery
 	Source SourceInfo `yaml:"source,omitempty" json:"source,omitempty"`
 
@@ -86,6 +97,34 @@ type FieldMapping struct {
 
 	// Skip indicates this field should be ignored
 	Skip bool `yaml:"skip,omitempty" json:"skip,omitempty"`
+
+	// MaxLength is the longest value the field accepts (from Drupal's
+	// max_length storage setting); 0 means unlimited
+	MaxLength int `yaml:"max_length,omitempty" json:"max_length,omitempty"`
+
+	// Transform lists directives applied to each source value during
+	// parsing (see mapping.Transforms)
+	Transform mapping.Transforms `yaml:"transform,omitempty" json:"transform,omitempty"`
+
+	// Template builds the value from the record's source fields (e.g.,
+	// "{{.volume}}({{.issue}})")
+	Template string `yaml:"template,omitempty" json:"template,omitempty"`
+
+	// Rules send values meeting a condition to another hub field, or drop
+	// them; the first matching rule wins
+	Rules []FieldRule `yaml:"rules,omitempty" json:"rules,omitempty"`
+}
+
+// FieldRule is a conditional override of a field's mapping (see
+// mapping.MappingRule).
+type FieldRule struct {
+	When         rules.Condition `yaml:"when" json:"when"`
+	Hub          string          `yaml:"hub,omitempty" json:"hub,omitempty"`
+	Type         string          `yaml:"type,omitempty" json:"type,omitempty"`
+	DateType     string          `yaml:"date_type,omitempty" json:"date_type,omitempty"`
+	RelationType string          `yaml:"relation_type,omitempty" json:"relation_type,omitempty"`
+	Vocabulary   string          `yaml:"vocabulary,omitempty" json:"vocabulary,omitempty"`
+	Skip         bool            `yaml:"skip,omitempty" json:"skip,omitempty"`
 }
 
 // Options contains format-specific configuration options.
@@ -301,6 +340,7 @@ func (p *Profile) ToMappingProfile() *MappingProfile {
 			Vocabulary:   fm.Vocabulary,
 			MultiValue:   fm.MultiValue,
 			Delimiter:    fm.Delimiter,
+			MaxLength:    fm.MaxLength,
 		}
 	}
 
@@ -330,6 +370,7 @@ type MappingFieldMapping struct {
 	Vocabulary   string `yaml:"vocabulary,omitempty" json:"vocabulary,omitempty"`
 	MultiValue   bool   `yaml:"multi_value,omitempty" json:"multi_value,omitempty"`
 	Delimiter    string `yaml:"delimiter,omitempty" json:"delimiter,omitempty"`
+	MaxLength    int    `yaml:"max_length,omitempty" json:"max_length,omitempty"`
 }
 
 // MappingProfileOptions mirrors the mapping.ProfileOptions type.
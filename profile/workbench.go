@@ -2,6 +2,11 @@ package profile
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
 )
 
 // CreateWorkbenchProfile creates a mapping profile for the islandora-workbench format
@@ -67,3 +72,62 @@ func parseFieldConfigsForBundle(configPath, bundle string) ([]DrupalFieldConfig,
 	}
 	return filtered, nil
 }
+
+// BundleFields returns the node field configs for a bundle in a Drupal
+// config/sync directory. An empty bundle returns fields from every bundle.
+func BundleFields(configPath, bundle string) ([]DrupalFieldConfig, error) {
+	info, err := os.Stat(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("config path not accessible: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("config path is not a directory: %s", configPath)
+	}
+	return parseFieldConfigsForBundle(configPath, bundle)
+}
+
+// MediaFileExtensions maps each media type in a Drupal config/sync directory
+// to the file extensions its source field accepts. Media types whose source
+// is not a file (e.g. oEmbed remote video) are left out.
+func MediaFileExtensions(configPath string) (map[string][]string, error) {
+	matches, err := filepath.Glob(filepath.Join(configPath, "media.type.*.yml"))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string][]string)
+	for _, path := range matches {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var mediaType struct {
+			ID                  string `yaml:"id"`
+			SourceConfiguration struct {
+				SourceField string `yaml:"source_field"`
+			} `yaml:"source_configuration"`
+		}
+		if yaml.Unmarshal(data, &mediaType) != nil || mediaType.ID == "" || mediaType.SourceConfiguration.SourceField == "" {
+			continue
+		}
+
+		fieldPath := filepath.Join(configPath, fmt.Sprintf("field.field.media.%s.%s.yml", mediaType.ID, mediaType.SourceConfiguration.SourceField))
+		data, err = os.ReadFile(fieldPath)
+		if err != nil {
+			continue
+		}
+		var field struct {
+			Settings struct {
+				FileExtensions string `yaml:"file_extensions"`
+			} `yaml:"settings"`
+		}
+		if yaml.Unmarshal(data, &field) != nil {
+			continue
+		}
+		if exts := strings.Fields(field.Settings.FileExtensions); len(exts) > 0 {
+			result[mediaType.ID] = exts
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,338 @@
+// Package server serves conversions over HTTP, so web applications and CI
+// pipelines can call crosswalk without shelling out to the CLI.
+//
+// Endpoints:
+//
+//	POST /convert?from=drupal&to=datacite   convert the request body
+//	GET  /formats                           list formats and capabilities
+//	GET  /healthz                           liveness check
+//
+// /convert takes the same settings as the convert command as query
+// parameters: variant, profile, columns (comma-separated), separator,
+// pretty, strip_html, lossless, and allow_empty. from may be omitted to
+// detect the source format from the body. Errors are returned as JSON
+// objects with an "error" message.
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	csvfmt "github.com/lehigh-university-libraries/crosswalk/format/csv"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+	"github.com/lehigh-university-libraries/crosswalk/mapping"
+)
+
+// DefaultMaxBodyBytes limits request bodies when Server.MaxBodyBytes is 0.
+const DefaultMaxBodyBytes = 32 << 20
+
+// Server converts request bodies between formats.
+type Server struct {
+	// Registry supplies the formats; each request works from a snapshot.
+	Registry *format.Registry
+
+	// Profile resolves the mapping profile for a source format and the
+	// profile query parameter (empty when not given). Nil means no profile.
+	Profile func(from, name string) (*mapping.Profile, error)
+
+	// Pipeline runs post-processors on every conversion through the hub.
+	Pipeline *hub.Pipeline
+
+	// MaxBodyBytes limits the request body size.
+	MaxBodyBytes int64
+}
+
+// New returns a server for the formats in registry.
+func New(registry *format.Registry) *Server {
+	return &Server{Registry: registry, MaxBodyBytes: DefaultMaxBodyBytes}
+}
+
+// Handler returns the HTTP handler serving the endpoints.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /convert", s.handleConvert)
+	mux.HandleFunc("GET /formats", s.handleFormats)
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		fmt.Fprintln(w, "ok")
+	})
+	return mux
+}
+
+// httpError is an error with the status code it is reported with.
+type httpError struct {
+	status int
+	err    error
+}
+
+func (e *httpError) Error() string { return e.err.Error() }
+
+func (e *httpError) Unwrap() error { return e.err }
+
+func errorf(status int, msg string, args ...any) error {
+	return &httpError{status: status, err: fmt.Errorf(msg, args...)}
+}
+
+func (s *Server) handleConvert(w http.ResponseWriter, r *http.Request) {
+	maxBody := s.MaxBodyBytes
+	if maxBody <= 0 {
+		maxBody = DefaultMaxBodyBytes
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBody))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeError(w, errorf(http.StatusRequestEntityTooLarge, "request body is larger than %d bytes", tooLarge.Limit))
+			return
+		}
+		writeError(w, errorf(http.StatusBadRequest, "reading request body: %v", err))
+		return
+	}
+
+	// Output is buffered so a failure partway through serialization is
+	// still reported with an error status rather than a truncated 200
+	var out bytes.Buffer
+	to, err := s.convert(r, body, &out)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType(to, out.Bytes()))
+	w.Header().Set("Content-Length", strconv.Itoa(out.Len()))
+	if _, err := w.Write(out.Bytes()); err != nil {
+		slog.Warn("writing response", "err", err)
+	}
+}
+
+// convert runs one conversion and returns the target format.
+func (s *Server) convert(r *http.Request, body []byte, out io.Writer) (format.Format, error) {
+	q := r.URL.Query()
+	registry := s.Registry.Clone()
+
+	toName := q.Get("to")
+	if toName == "" {
+		return nil, errorf(http.StatusBadRequest, "missing to parameter")
+	}
+	serializer, err := registry.GetSerializer(toName)
+	if err != nil {
+		return nil, errorf(http.StatusBadRequest, "target format: %v", err)
+	}
+
+	fromName := q.Get("from")
+	if fromName == "" {
+		detected, err := registry.DetectFromContent(body)
+		if err != nil {
+			return nil, errorf(http.StatusBadRequest, "missing from parameter and %v", err)
+		}
+		fromName = detected.Name()
+	}
+	parser, err := registry.GetParser(fromName)
+	if err != nil {
+		return nil, errorf(http.StatusBadRequest, "source format: %v", err)
+	}
+
+	pretty, err := boolParam(q.Get("pretty"), false)
+	if err != nil {
+		return nil, err
+	}
+	stripHTML, err := boolParam(q.Get("strip_html"), true)
+	if err != nil {
+		return nil, err
+	}
+	lossless, err := boolParam(q.Get("lossless"), false)
+	if err != nil {
+		return nil, err
+	}
+	allowEmpty, err := boolParam(q.Get("allow_empty"), false)
+	if err != nil {
+		return nil, err
+	}
+
+	// Profile names come from the client, so keep them to plain names: a
+	// path would let a request load any YAML file the server can read.
+	profileName := q.Get("profile")
+	if strings.ContainsAny(profileName, `/\`) || strings.Contains(profileName, "..") {
+		return nil, errorf(http.StatusBadRequest, "invalid profile name %q", profileName)
+	}
+	var profile *mapping.Profile
+	if s.Profile != nil {
+		if profile, err = s.Profile(fromName, profileName); err != nil {
+			return nil, errorf(http.StatusBadRequest, "loading profile: %v", err)
+		}
+	}
+
+	opts := registry.Options(toName, func(o *format.SerializeOptions) {
+		if profile != nil {
+			o.Profile = profile
+		}
+		if cols := q.Get("columns"); cols != "" {
+			o.Columns = strings.Split(cols, ",")
+		}
+		if len(o.Columns) == 0 && strings.EqualFold(toName, "csv") {
+			o.Columns = csvfmt.DefaultColumns()
+		}
+		if sep := q.Get("separator"); sep != "" {
+			o.MultiValueSeparator = sep
+		}
+		if v := q.Get("variant"); v != "" {
+			o.Variant = v
+		}
+		if q.Has("pretty") {
+			o.Pretty = pretty
+		}
+	})
+
+	// Same-format conversions take the normalizer fast path, as in the CLI
+	if normalizer, ok := serializer.(format.Normalizer); ok && strings.EqualFold(fromName, toName) &&
+		s.Pipeline.Len() == 0 && !normalizer.NeedsHub(opts) {
+		if err := normalizer.Normalize(bytes.NewReader(body), out, opts); err != nil {
+			return nil, errorf(http.StatusUnprocessableEntity, "normalizing input: %v", err)
+		}
+		return serializer, nil
+	}
+
+	records, err := parser.Parse(bytes.NewReader(body), &format.ParseOptions{
+		Profile:    profile,
+		StripHTML:  stripHTML,
+		SourceName: "request body",
+	})
+	if err != nil {
+		return nil, errorf(http.StatusUnprocessableEntity, "parsing input: %v", err)
+	}
+	if len(records) == 0 && !allowEmpty {
+		return nil, errorf(http.StatusUnprocessableEntity, "no records parsed (set allow_empty=true to get an empty %s document)", toName)
+	}
+
+	hub.ComputeMembershipPaths(records)
+	if err := s.Pipeline.Run(records); err != nil {
+		return nil, errorf(http.StatusInternalServerError, "%v", err)
+	}
+	if lossless {
+		if err := format.CheckLossless(serializer, records, opts); err != nil {
+			return nil, errorf(http.StatusUnprocessableEntity, "%v", err)
+		}
+	}
+
+	if err := serialize(serializer, records, opts, out); err != nil {
+		return nil, err
+	}
+	return serializer, nil
+}
+
+// serialize writes records. Serializers fail on what the records or
+// options ask for (an unknown variant, a value over a length limit, an
+// empty document), so their errors are reported as unprocessable input.
+func serialize(serializer format.Serializer, records []*hubv1.Record, opts *format.SerializeOptions, out io.Writer) error {
+	if err := serializer.Serialize(out, records, opts); err != nil {
+		return errorf(http.StatusUnprocessableEntity, "serializing output: %v", err)
+	}
+	return nil
+}
+
+// formatInfo describes a format in the /formats listing.
+type formatInfo struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Extensions  []string `json:"extensions"`
+	Parse       bool     `json:"parse"`
+	Serialize   bool     `json:"serialize"`
+}
+
+func (s *Server) handleFormats(w http.ResponseWriter, r *http.Request) {
+	registry := s.Registry.Clone()
+	infos := []formatInfo{}
+	for _, name := range registry.List() {
+		f, ok := registry.Get(name)
+		if !ok {
+			continue
+		}
+		_, canParse := f.(format.Parser)
+		_, canSerialize := f.(format.Serializer)
+		infos = append(infos, formatInfo{
+			Name:        f.Name(),
+			Description: f.Description(),
+			Extensions:  f.Extensions(),
+			Parse:       canParse,
+			Serialize:   canSerialize,
+		})
+	}
+	writeJSON(w, http.StatusOK, infos)
+}
+
+// boolParam parses an optional boolean query parameter.
+func boolParam(v string, def bool) (bool, error) {
+	if v == "" {
+		return def, nil
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, errorf(http.StatusBadRequest, "invalid boolean %q", v)
+	}
+	return b, nil
+}
+
+// contentTypes maps a format's primary extension to its media type.
+var contentTypes = map[string]string{
+	"json":    "application/json",
+	"jsonl":   "application/x-ndjson",
+	"csl":     "application/vnd.citationstyles.csl+json",
+	"jsonld":  "application/ld+json",
+	"scholix": "application/json",
+	"solr":    "application/json",
+	"iiif":    `application/ld+json;profile="http://iiif.io/api/presentation/3/context.json"`,
+	"xml":     "application/xml",
+	"mods":    "application/mods+xml",
+	"dc":      "application/xml",
+	"onix":    "application/xml",
+	"premis":  "application/xml",
+	"csv":     "text/csv; charset=utf-8",
+	"zip":     "application/zip",
+	"parquet": "application/vnd.apache.parquet",
+	"ttl":     "text/turtle; charset=utf-8",
+	"nt":      "application/n-triples",
+	"bib":     "application/x-bibtex; charset=utf-8",
+	"ris":     "application/x-research-info-systems; charset=utf-8",
+}
+
+// contentType returns the media type for a format's output, sniffing the
+// body for formats whose extension doesn't say.
+func contentType(f format.Format, body []byte) string {
+	if exts := f.Extensions(); len(exts) > 0 {
+		if ct, ok := contentTypes[exts[0]]; ok {
+			return ct
+		}
+	}
+	return http.DetectContentType(body)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	var he *httpError
+	if errors.As(err, &he) {
+		status = he.status
+	}
+	if status >= http.StatusInternalServerError {
+		slog.Error("conversion failed", "err", err)
+	}
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		slog.Warn("writing response", "err", err)
+	}
+}
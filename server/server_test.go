@@ -0,0 +1,221 @@
+package server
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	"github.com/lehigh-university-libraries/crosswalk/format/csl"
+	"github.com/lehigh-university-libraries/crosswalk/format/datacite"
+	"github.com/lehigh-university-libraries/crosswalk/format/dublincore"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+	"github.com/lehigh-university-libraries/crosswalk/mapping"
+)
+
+const oaiDC = `<?xml version="1.0" encoding="UTF-8"?>
+<oai_dc:dc xmlns:oai_dc="http://www.openarchives.org/OAI/2.0/oai_dc/" xmlns:dc="http://purl.org/dc/elements/1.1/">
+  <dc:title>Sediment transport in the Lehigh Gap</dc:title>
+  <dc:creator>Smith, Jane</dc:creator>
+  <dc:date>2024</dc:date>
+</oai_dc:dc>`
+
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	registry := format.NewRegistry()
+	registry.Register(&dublincore.Format{})
+	registry.Register(&datacite.Format{})
+	registry.Register(&csl.Format{})
+	srv := httptest.NewServer(New(registry).Handler())
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func post(t *testing.T, srv *httptest.Server, query, body string) (*http.Response, string) {
+	t.Helper()
+	resp, err := http.Post(srv.URL+"/convert?"+query, "application/xml", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("POST failed: %v", err)
+	}
+	defer resp.Body.Close()
+	out, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp, string(out)
+}
+
+func TestConvert(t *testing.T) {
+	srv := newTestServer(t)
+
+	resp, body := post(t, srv, "from=dublincore&to=csl", oaiDC)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status %d: %s", resp.StatusCode, body)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type: got %q", ct)
+	}
+	var item map[string]any
+	if err := json.Unmarshal([]byte(body), &item); err != nil {
+		t.Fatalf("response is not a CSL JSON item: %v\n%s", err, body)
+	}
+	if item["title"] != "Sediment transport in the Lehigh Gap" {
+		t.Errorf("title: got %v", item["title"])
+	}
+}
+
+func TestConvertDetectsSourceFormat(t *testing.T) {
+	srv := newTestServer(t)
+
+	resp, body := post(t, srv, "to=dublincore&variant=qualified", oaiDC)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status %d: %s", resp.StatusCode, body)
+	}
+	if !strings.Contains(body, "Sediment transport in the Lehigh Gap") {
+		t.Errorf("expected the title in the output:\n%s", body)
+	}
+}
+
+func TestConvertErrors(t *testing.T) {
+	srv := newTestServer(t)
+
+	tests := []struct {
+		name   string
+		query  string
+		body   string
+		status int
+		msg    string
+	}{
+		{"missing target", "from=dublincore", oaiDC, http.StatusBadRequest, "missing to"},
+		{"unknown target", "from=dublincore&to=nope", oaiDC, http.StatusBadRequest, "unknown format"},
+		{"output-only source", "from=csl&to=dublincore", oaiDC, http.StatusBadRequest, "does not support parsing"},
+		{"undetectable source", "to=csl", "plain text", http.StatusBadRequest, "could not detect"},
+		{"bad boolean", "from=dublincore&to=csl&pretty=maybe", oaiDC, http.StatusBadRequest, "invalid boolean"},
+		{"unparseable body", "from=dublincore&to=csl", "<not-xml", http.StatusUnprocessableEntity, "parsing input"},
+		{"unknown variant", "from=dublincore&to=dublincore&variant=rdf", oaiDC, http.StatusUnprocessableEntity, "rdf"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, body := post(t, srv, tt.query, tt.body)
+			if resp.StatusCode != tt.status {
+				t.Errorf("status: got %d, want %d (%s)", resp.StatusCode, tt.status, body)
+			}
+			var e map[string]string
+			if err := json.Unmarshal([]byte(body), &e); err != nil || !strings.Contains(e["error"], tt.msg) {
+				t.Errorf("expected a JSON error containing %q, got %s", tt.msg, body)
+			}
+		})
+	}
+}
+
+func TestConvertRejectsProfilePaths(t *testing.T) {
+	registry := format.NewRegistry()
+	registry.Register(&dublincore.Format{})
+	registry.Register(&csl.Format{})
+	s := New(registry)
+	s.Profile = func(from, name string) (*mapping.Profile, error) {
+		t.Errorf("profile %q was loaded", name)
+		return nil, nil
+	}
+	srv := httptest.NewServer(s.Handler())
+	t.Cleanup(srv.Close)
+
+	for _, name := range []string{"../../../srv/x", "sub/profile", `sub\profile`, ".."} {
+		t.Run(name, func(t *testing.T) {
+			resp, body := post(t, srv, "from=dublincore&to=csl&profile="+url.QueryEscape(name), oaiDC)
+			if resp.StatusCode != http.StatusBadRequest {
+				t.Errorf("status: got %d, want %d (%s)", resp.StatusCode, http.StatusBadRequest, body)
+			}
+			if !strings.Contains(body, "invalid profile name") {
+				t.Errorf("expected an invalid profile error, got %s", body)
+			}
+		})
+	}
+}
+
+func TestConvertLossless(t *testing.T) {
+	srv := newTestServer(t)
+
+	input := strings.Replace(oaiDC, "</oai_dc:dc>", "<dc:relation>https://example.edu/related</dc:relation></oai_dc:dc>", 1)
+	resp, body := post(t, srv, "from=dublincore&to=csl&lossless=true", input)
+	if resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("status: got %d, want 422 (%s)", resp.StatusCode, body)
+	}
+}
+
+func TestConvertBodyLimit(t *testing.T) {
+	registry := format.NewRegistry()
+	registry.Register(&dublincore.Format{})
+	registry.Register(&csl.Format{})
+	s := New(registry)
+	s.MaxBodyBytes = 16
+	srv := httptest.NewServer(s.Handler())
+	defer srv.Close()
+
+	resp, body := post(t, srv, "from=dublincore&to=csl", oaiDC)
+	if resp.StatusCode != http.StatusRequestEntityTooLarge {
+		t.Errorf("status: got %d, want 413 (%s)", resp.StatusCode, body)
+	}
+}
+
+func TestConvertRunsPipeline(t *testing.T) {
+	registry := format.NewRegistry()
+	registry.Register(&dublincore.Format{})
+	registry.Register(&csl.Format{})
+	s := New(registry)
+	s.Pipeline = &hub.Pipeline{}
+	s.Pipeline.Add("shout", func(records []*hubv1.Record) error {
+		for _, r := range records {
+			r.Title = strings.ToUpper(r.Title)
+		}
+		return nil
+	})
+	srv := httptest.NewServer(s.Handler())
+	defer srv.Close()
+
+	resp, body := post(t, srv, "from=dublincore&to=csl", oaiDC)
+	if resp.StatusCode != http.StatusOK || !strings.Contains(body, "SEDIMENT TRANSPORT") {
+		t.Errorf("expected the post-processed title, got %d: %s", resp.StatusCode, body)
+	}
+}
+
+func TestFormats(t *testing.T) {
+	srv := newTestServer(t)
+
+	resp, err := http.Get(srv.URL + "/formats")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	var infos []formatInfo
+	if err := json.NewDecoder(resp.Body).Decode(&infos); err != nil {
+		t.Fatal(err)
+	}
+	if len(infos) != 3 {
+		t.Fatalf("expected 3 formats, got %v", infos)
+	}
+	for _, info := range infos {
+		if info.Name == "csl" && (info.Parse || !info.Serialize) {
+			t.Errorf("csl capabilities: got %+v", info)
+		}
+	}
+}
+
+func TestMethodNotAllowed(t *testing.T) {
+	srv := newTestServer(t)
+
+	resp, err := http.Get(srv.URL + "/convert?from=dublincore&to=csl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("status: got %d, want 405", resp.StatusCode)
+	}
+}
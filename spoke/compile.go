@@ -0,0 +1,211 @@
+package spoke
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// defaultBufYAML and defaultBufGenYAML are the buf configuration written
+// to a module that has none, matching this repository's: Go bindings for
+// every proto under gen/go, import paths rooted there.
+const defaultBufYAML = `version: v2
+modules:
+  - path: .
+lint:
+  use:
+    - STANDARD
+  except:
+    - ENUM_VALUE_PREFIX
+    - PACKAGE_VERSION_SUFFIX
+`
+
+const defaultBufGenYAML = `version: v2
+managed:
+  enabled: true
+  override:
+    - file_option: go_package_prefix
+      value: {{module}}/gen/go
+
+plugins:
+  - remote: buf.build/protocolbuffers/go
+    out: gen/go
+    opt: paths=source_relative
+`
+
+// Compiled describes what Compile did.
+type Compiled struct {
+	// Root is the Go module directory the spoke was compiled in.
+	Root string
+	// BufConfig lists the buf configuration files written, none when the
+	// module already had them.
+	BufConfig []string
+	// Generator is the command that produced the Go bindings: "buf" or
+	// "protoc".
+	Generator string
+	// Registration is the file importing the spoke into the CLI, or ""
+	// when the module has no cmd package to register it in.
+	Registration string
+}
+
+// Compile turns a written spoke into a working one: it generates the Go
+// bindings for the proto at protoPath under the module's gen/go with buf,
+// or protoc and protoc-gen-go when buf isn't installed, writing a buf
+// configuration first if the module has none, and registers the spoke's
+// field metadata with the CLI so its format is available on the next
+// build.
+func Compile(ctx context.Context, protoPath string) (*Compiled, error) {
+	absProto, err := filepath.Abs(protoPath)
+	if err != nil {
+		return nil, err
+	}
+	root, module, err := findModule(filepath.Dir(absProto))
+	if err != nil {
+		return nil, err
+	}
+	rel, err := filepath.Rel(root, absProto)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return nil, fmt.Errorf("%s is outside the Go module at %s", protoPath, root)
+	}
+	rel = filepath.ToSlash(rel)
+	c := &Compiled{Root: root}
+
+	if c.BufConfig, err = writeBufConfig(root, module); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case lookPath("buf"):
+		c.Generator = "buf"
+		err = run(ctx, root, "buf", "generate", "--path", rel)
+	case lookPath("protoc") && lookPath("protoc-gen-go"):
+		c.Generator = "protoc"
+		if err = os.MkdirAll(filepath.Join(root, "gen", "go"), 0o755); err == nil {
+			err = run(ctx, root, "protoc", protocArgs(root, module, rel)...)
+		}
+	default:
+		return nil, errors.New("neither buf nor protoc with protoc-gen-go is installed (see make install-tools)")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("generating Go code with %s: %w", c.Generator, err)
+	}
+
+	if c.Registration, err = registerSpoke(root, module, path.Dir(rel)); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// findModule walks up from dir to the directory holding go.mod and
+// returns it with the module path.
+func findModule(dir string) (root, module string, err error) {
+	for d := dir; ; d = filepath.Dir(d) {
+		data, err := os.ReadFile(filepath.Join(d, "go.mod"))
+		if err == nil {
+			module = modulePath(data)
+			if module == "" {
+				return "", "", fmt.Errorf("%s names no module", filepath.Join(d, "go.mod"))
+			}
+			return d, module, nil
+		}
+		if parent := filepath.Dir(d); parent == d {
+			return "", "", fmt.Errorf("no go.mod found above %s; spokes are compiled inside a Go module", dir)
+		}
+	}
+}
+
+// modulePath returns the module path a go.mod declares.
+func modulePath(gomod []byte) string {
+	for line := range strings.SplitSeq(string(gomod), "\n") {
+		if rest, ok := strings.CutPrefix(strings.TrimSpace(line), "module"); ok && rest != "" && (rest[0] == ' ' || rest[0] == '\t') {
+			return strings.Trim(strings.TrimSpace(rest), `"`)
+		}
+	}
+	return ""
+}
+
+// writeBufConfig writes buf.yaml and buf.gen.yaml to root unless they
+// exist, returning the ones written.
+func writeBufConfig(root, module string) ([]string, error) {
+	var written []string
+	for _, file := range []struct{ name, content string }{
+		{"buf.yaml", defaultBufYAML},
+		{"buf.gen.yaml", strings.ReplaceAll(defaultBufGenYAML, "{{module}}", module)},
+	} {
+		p := filepath.Join(root, file.name)
+		if _, err := os.Stat(p); err == nil {
+			continue
+		}
+		if err := os.WriteFile(p, []byte(file.content), 0o644); err != nil {
+			return written, fmt.Errorf("writing %s: %w", file.name, err)
+		}
+		written = append(written, file.name)
+	}
+	return written, nil
+}
+
+// protocArgs are the protoc arguments generating rel's Go bindings as buf
+// would: under gen/go, with import paths rooted there, including those of
+// the hub options the proto imports.
+func protocArgs(root, module, rel string) []string {
+	args := []string{
+		"-I", ".",
+		"--go_out=gen/go",
+		"--go_opt=paths=source_relative",
+		"--go_opt=M" + rel + "=" + module + "/gen/go/" + path.Dir(rel),
+	}
+	if _, err := os.Stat(filepath.Join(root, "hub", "v1", "options.proto")); err == nil {
+		args = append(args, "--go_opt=Mhub/v1/options.proto="+module+"/gen/go/hub/v1")
+	}
+	return append(args, rel)
+}
+
+// registerSpoke writes a file to the module's cmd package importing the
+// spoke's package (dir, relative to root), whose init registers its field
+// metadata. It returns the file written, or "" without a cmd package.
+func registerSpoke(root, module, dir string) (string, error) {
+	cmdDir := filepath.Join(root, "cmd")
+	if info, err := os.Stat(cmdDir); err != nil || !info.IsDir() {
+		return "", nil
+	}
+	name := strings.ReplaceAll(strings.TrimPrefix(dir, "spoke/"), "/", "_")
+	file := filepath.Join(cmdDir, "spoke_"+name+"_gen.go")
+	src := fmt.Sprintf(`// Code generated by crosswalk spoke create --compile. DO NOT EDIT.
+
+package cmd
+
+// Register the %s spoke's field metadata
+import _ "%s/%s"
+`, name, module, dir)
+	if err := os.WriteFile(file, []byte(src), 0o644); err != nil {
+		return "", fmt.Errorf("registering spoke: %w", err)
+	}
+	return file, nil
+}
+
+// lookPath reports whether a command is installed.
+func lookPath(name string) bool {
+	_, err := exec.LookPath(name)
+	return err == nil
+}
+
+// run runs a command in dir, returning its output with any failure.
+func run(ctx context.Context, dir, name string, args ...string) error {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = dir
+	var out bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &out, &out
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(out.String()); msg != "" {
+			return fmt.Errorf("%w: %s", err, msg)
+		}
+		return err
+	}
+	return nil
+}
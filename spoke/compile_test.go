@@ -0,0 +1,87 @@
+package spoke
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestModulePath(t *testing.T) {
+	for gomod, want := range map[string]string{
+		"module github.com/lehigh-university-libraries/crosswalk\n\ngo 1.25\n": "github.com/lehigh-university-libraries/crosswalk",
+		"// comment\nmodule \"example.edu/spokes\"\n":                          "example.edu/spokes",
+		"modules are not declared here\n":                                      "",
+	} {
+		if got := modulePath([]byte(gomod)); got != want {
+			t.Errorf("%q: got %q, want %q", gomod, got, want)
+		}
+	}
+}
+
+func TestProtocArgs(t *testing.T) {
+	root := t.TempDir()
+	os.MkdirAll(filepath.Join(root, "hub", "v1"), 0o755)
+	os.WriteFile(filepath.Join(root, "hub", "v1", "options.proto"), nil, 0o644)
+
+	got := strings.Join(protocArgs(root, "example.edu/m", "spoke/lehigh/v1/lehigh.proto"), " ")
+	want := "-I . --go_out=gen/go --go_opt=paths=source_relative" +
+		" --go_opt=Mspoke/lehigh/v1/lehigh.proto=example.edu/m/gen/go/spoke/lehigh/v1" +
+		" --go_opt=Mhub/v1/options.proto=example.edu/m/gen/go/hub/v1" +
+		" spoke/lehigh/v1/lehigh.proto"
+	if got != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+}
+
+func TestCompile(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake buf is a shell script")
+	}
+	// A fake buf that records its arguments
+	bin := t.TempDir()
+	script := "#!/bin/sh\necho \"$@\" > buf-args\n"
+	if err := os.WriteFile(filepath.Join(bin, "buf"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin)
+
+	root := t.TempDir()
+	os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.edu/m\n\ngo 1.25\n"), 0o644)
+	os.WriteFile(filepath.Join(root, "buf.yaml"), []byte("version: v2\n"), 0o644)
+	os.MkdirAll(filepath.Join(root, "cmd"), 0o755)
+	protoPath := filepath.Join(root, "spoke", "lehigh", "v1", "lehigh.proto")
+	os.MkdirAll(filepath.Dir(protoPath), 0o755)
+	os.WriteFile(protoPath, []byte("syntax = \"proto3\";\n"), 0o644)
+
+	c, err := Compile(context.Background(), protoPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Generator != "buf" || len(c.BufConfig) != 1 || c.BufConfig[0] != "buf.gen.yaml" {
+		t.Errorf("got generator %q, wrote %v", c.Generator, c.BufConfig)
+	}
+	if data, _ := os.ReadFile(filepath.Join(root, "buf.yaml")); string(data) != "version: v2\n" {
+		t.Errorf("existing buf.yaml overwritten: %q", data)
+	}
+	if data, _ := os.ReadFile(filepath.Join(root, "buf.gen.yaml")); !strings.Contains(string(data), "value: example.edu/m/gen/go") {
+		t.Errorf("buf.gen.yaml:\n%s", data)
+	}
+	if data, _ := os.ReadFile(filepath.Join(root, "buf-args")); strings.TrimSpace(string(data)) != "generate --path spoke/lehigh/v1/lehigh.proto" {
+		t.Errorf("buf ran with %q", data)
+	}
+
+	if want := filepath.Join(root, "cmd", "spoke_lehigh_v1_gen.go"); c.Registration != want {
+		t.Errorf("registration %q, want %q", c.Registration, want)
+	}
+	if data, _ := os.ReadFile(c.Registration); !strings.Contains(string(data), `import _ "example.edu/m/spoke/lehigh/v1"`) {
+		t.Errorf("registration:\n%s", data)
+	}
+
+	t.Setenv("PATH", t.TempDir())
+	if _, err := Compile(context.Background(), protoPath); err == nil || !strings.Contains(err.Error(), "neither buf nor protoc") {
+		t.Errorf("no generator installed: got %v", err)
+	}
+}
@@ -0,0 +1,253 @@
+package spoke
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// csvSampleRows is how many rows of a sample spreadsheet are read to infer
+// its columns.
+const csvSampleRows = 1000
+
+// csvMultiValueSeparators are the separators a column's values are tried
+// against, most likely first.
+var csvMultiValueSeparators = []string{"|", ";"}
+
+// csvListItemMax is the longest a value separated by something other than
+// "|" may be and still count as a list item, so prose with semicolons
+// isn't split.
+const csvListItemMax = 100
+
+// csvColumnHubFields are hub fields for common spreadsheet column names the
+// Drupal field name heuristics don't know, by normalized column name.
+var csvColumnHubFields = map[string][2]string{
+	"title":    {"Title", ""},
+	"author":   {"Contributors", "author"},
+	"authors":  {"Contributors", "author"},
+	"creator":  {"Contributors", "creator"},
+	"creators": {"Contributors", "creator"},
+	"editor":   {"Contributors", "editor"},
+	"editors":  {"Contributors", "editor"},
+	"keywords": {"Subjects", "keywords"},
+	"tags":     {"Subjects", "keywords"},
+	"year":     {"Dates", "issued"},
+	"date":     {"Dates", "issued"},
+	"summary":  {"Abstract", ""},
+	"url":      {"Identifiers", "url"},
+	"link":     {"Identifiers", "url"},
+}
+
+var (
+	csvDatePattern = regexp.MustCompile(`^[12]\d{3}(-\d{2}(-\d{2})?)?[?~%]?$|^[12]\d{3}-\d{2}-\d{2}T`)
+	csvDOIPattern  = regexp.MustCompile(`^(https?://(dx\.)?doi\.org/|doi:)?10\.\d{4,9}/\S+$`)
+	csvURLPattern  = regexp.MustCompile(`^https?://\S+$`)
+)
+
+// GenerateCSVSpoke generates a proto file from a sample spreadsheet: a
+// field per column, named from its header, repeated when its values are
+// lists (separated by "|" or ";"), typed int64, double, or bool when
+// every value is one, and mapped to the hub by its name or, failing that,
+// by what its values look like (dates, DOIs, URLs).
+func GenerateCSVSpoke(name string, r io.Reader) (*ProtoFile, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading CSV header: %w", err)
+	}
+
+	columns := make([][]string, len(header))
+	for range csvSampleRows {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading CSV: %w", err)
+		}
+		for i := range header {
+			if i < len(row) && strings.TrimSpace(row[i]) != "" {
+				columns[i] = append(columns[i], strings.TrimSpace(row[i]))
+			}
+		}
+	}
+
+	proto := newSpokeProtoFile(name, "Generated from a sample CSV")
+	msg := ProtoMessage{
+		Name:    "Row",
+		Comment: "Row represents a row of the spreadsheet.",
+	}
+	taken := make(map[string]bool)
+	for i, col := range header {
+		col = strings.TrimSpace(strings.TrimPrefix(col, "\ufeff"))
+		if col == "" {
+			continue
+		}
+		pf := csvColumnToProto(col, columns[i], i+1)
+		base := pf.Name
+		for n := 2; taken[pf.Name]; n++ {
+			pf.Name = fmt.Sprintf("%s_%d", base, n)
+		}
+		taken[pf.Name] = true
+		msg.Fields = append(msg.Fields, pf)
+	}
+	if len(msg.Fields) == 0 {
+		return nil, fmt.Errorf("CSV header has no column names")
+	}
+
+	proto.Messages = append(proto.Messages, msg)
+	return proto, nil
+}
+
+func csvColumnToProto(col string, values []string, num int) ProtoField {
+	name := strings.Trim(nonIdentChars.ReplaceAllString(strings.ToLower(col), "_"), "_")
+	if name == "" || name[0] >= '0' && name[0] <= '9' {
+		name = "column_" + name
+	}
+	pf := ProtoField{
+		Name:        name,
+		Number:      num,
+		Comment:     col,
+		CSVColumn:   col,
+		Cardinality: 1,
+	}
+
+	pf.Delimiter = csvSeparator(values)
+	var items []string
+	for _, v := range values {
+		if pf.Delimiter == "" {
+			items = append(items, v)
+			continue
+		}
+		for item := range strings.SplitSeq(v, pf.Delimiter) {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+	}
+
+	// Numbers and booleans are typed only in Extra: hub fields are text,
+	// and an ISBN or year is an identifier or date, not a quantity
+	pf.Type = csvValueType(items)
+	mapFromCSVColumn(&pf, items)
+	if !strings.HasPrefix(pf.HubField, "Extra.") {
+		pf.Type = "string"
+	}
+	if pf.Delimiter != "" {
+		pf.Type = "repeated " + pf.Type
+		pf.Cardinality = -1
+	}
+	return pf
+}
+
+// csvSeparator returns the separator a column's values are lists with, or
+// "" for a single-valued column: the first of csvMultiValueSeparators that
+// splits some value into items, without splitting any into empty or, but
+// for "|", overlong ones.
+func csvSeparator(values []string) string {
+	for _, sep := range csvMultiValueSeparators {
+		splits := false
+		for _, v := range values {
+			if !strings.Contains(v, sep) {
+				continue
+			}
+			splits = true
+			for item := range strings.SplitSeq(v, sep) {
+				item = strings.TrimSpace(item)
+				if item == "" || sep != "|" && len(item) > csvListItemMax {
+					splits = false
+					break
+				}
+			}
+			if !splits {
+				break
+			}
+		}
+		if splits {
+			return sep
+		}
+	}
+	return ""
+}
+
+// csvValueType returns the proto scalar type every value parses as:
+// int64, double, or bool, and otherwise string. Years and other dates are
+// strings, parsed as EDTF, as are numbers with leading zeros, which are
+// identifiers more often than quantities.
+func csvValueType(values []string) string {
+	if len(values) == 0 {
+		return "string"
+	}
+	isInt, isFloat, isBool := true, true, true
+	for _, v := range values {
+		if _, err := strconv.ParseInt(v, 10, 64); err != nil {
+			isInt = false
+		}
+		if _, err := strconv.ParseFloat(v, 64); err != nil {
+			isFloat = false
+		}
+		if len(v) > 1 && v[0] == '0' && v[1] != '.' {
+			isInt, isFloat = false, false
+		}
+		switch strings.ToLower(v) {
+		case "true", "false":
+		default:
+			isBool = false
+		}
+	}
+	switch {
+	case isInt && allMatch(values, csvDatePattern):
+		return "string"
+	case isInt:
+		return "int64"
+	case isFloat:
+		return "double"
+	case isBool:
+		return "bool"
+	}
+	return "string"
+}
+
+// mapFromCSVColumn maps a column to the hub by its name, with the Drupal
+// field name heuristics and then common spreadsheet names, then by its
+// values. A column nothing matches is kept in Extra under its field name.
+func mapFromCSVColumn(pf *ProtoField, values []string) {
+	if mapFromFieldName(pf, DrupalFieldConfig{FieldName: "field_" + pf.Name}) {
+		return
+	}
+	if hub, ok := csvColumnHubFields[pf.Name]; ok {
+		pf.HubField, pf.HubType = hub[0], hub[1]
+		if pf.HubField == "Dates" {
+			pf.Parser = "edtf"
+		}
+		return
+	}
+
+	switch {
+	case pf.Type != "string":
+		pf.HubField = "Extra." + pf.Name
+	case allMatch(values, csvDatePattern):
+		pf.HubField, pf.HubType, pf.Parser = "Dates", "other", "edtf"
+	case allMatch(values, csvDOIPattern):
+		pf.HubField, pf.HubType = "Identifiers", "doi"
+	case allMatch(values, csvURLPattern):
+		pf.HubField, pf.HubType = "Identifiers", "url"
+	default:
+		pf.HubField = "Extra." + pf.Name
+	}
+}
+
+// allMatch reports whether every value matches re.
+func allMatch(values []string, re *regexp.Regexp) bool {
+	for _, v := range values {
+		if !re.MatchString(v) {
+			return false
+		}
+	}
+	return len(values) > 0
+}
@@ -0,0 +1,68 @@
+package spoke
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const vendorSheet = `Title,Authors,Year,Pages,Price,Open Access,DOI,Keywords,Notes,Record ID,Released,Title
+"A study",Smith J|Doe A,2019,120,19.99,true,10.1234/abc,maps; rivers,"A note; with a semicolon that reads like prose and goes on for a while, well past where anyone would call it a list item",0012,2019-03-01,Alt
+"Another",Roe B,2021-05,88,5,false,https://doi.org/10.5555/xyz,lakes,,0013,2020,
+`
+
+func TestGenerateCSVSpoke(t *testing.T) {
+	proto, err := GenerateCSVSpoke("vendor", strings.NewReader("\ufeff"+vendorSheet))
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := proto.Messages[0]
+	if msg.Name != "Row" || len(msg.Fields) != 12 {
+		t.Fatalf("got message %s with %d fields", msg.Name, len(msg.Fields))
+	}
+
+	tests := []struct {
+		column, name, typ, delimiter, hubField, hubType string
+	}{
+		{"Title", "title", "string", "", "Title", ""},
+		{"Authors", "authors", "repeated string", "|", "Contributors", "author"},
+		{"Year", "year", "string", "", "Dates", "issued"},
+		{"Pages", "pages", "int64", "", "Extra.pages", ""},
+		{"Price", "price", "double", "", "Extra.price", ""},
+		{"Open Access", "open_access", "bool", "", "Extra.open_access", ""},
+		{"DOI", "doi", "string", "", "Identifiers", "doi"},
+		{"Keywords", "keywords", "repeated string", ";", "Subjects", "keywords"},
+		{"Notes", "notes", "string", "", "Notes", ""},
+		{"Record ID", "record_id", "string", "", "Extra.record_id", ""},
+		{"Released", "released", "string", "", "Dates", "other"},
+		{"Title", "title_2", "string", "", "Title", ""},
+	}
+	for i, tt := range tests {
+		f := msg.Fields[i]
+		if f.CSVColumn != tt.column || f.Name != tt.name || f.Type != tt.typ || f.Delimiter != tt.delimiter || f.HubField != tt.hubField || f.HubType != tt.hubType || f.Number != i+1 {
+			t.Errorf("column %d: got %s %s %s = %d, delimiter %q, hub %s/%s", i, f.CSVColumn, f.Type, f.Name, f.Number, f.Delimiter, f.HubField, f.HubType)
+		}
+	}
+
+	ApplyAutoMappings(proto)
+	proto.FormatName = "vendor"
+	out := filepath.Join(t.TempDir(), "vendor.proto")
+	if err := WriteProto(proto, out); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(out)
+	if want := `// Authors [csv, delimiter "|"]`; !strings.Contains(string(data), want) {
+		t.Errorf("proto missing %s:\n%s", want, data)
+	}
+	meta, _ := os.ReadFile(strings.TrimSuffix(out, ".proto") + "_meta.go")
+	for _, want := range []string{`CSVColumn:    "Open Access",`, `Delimiter:    ";",`} {
+		if !strings.Contains(string(meta), want) {
+			t.Errorf("meta missing %s:\n%s", want, meta)
+		}
+	}
+
+	if _, err := GenerateCSVSpoke("empty", strings.NewReader("")); err == nil {
+		t.Error("empty CSV: want an error")
+	}
+}
@@ -0,0 +1,325 @@
+package spoke
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Field number limits: protobuf keeps 19000 to 19999 for itself, and no
+// number is above maxFieldNumber.
+const (
+	firstReservedNumber = 19000
+	lastReservedNumber  = 19999
+	maxFieldNumber      = 1<<29 - 1
+)
+
+// SpokeDiff is how a spoke's proto has drifted from what its Drupal
+// config generates now.
+type SpokeDiff struct {
+	// Message is the message compared, the spoke's main one.
+	Message string
+	// Added are fields the config has and the proto doesn't, each with
+	// the number it can safely take.
+	Added []FieldChange
+	// Removed are fields the proto has and the config no longer does.
+	Removed []FieldChange
+	// Retyped are fields whose proto or Drupal type changed.
+	Retyped []FieldChange
+}
+
+// FieldChange is one field that differs between a spoke's proto and its
+// config.
+type FieldChange struct {
+	Name          string
+	OldType       string // Proto type in the existing proto
+	NewType       string // Proto type generated from the config
+	OldDrupalType string
+	NewDrupalType string
+	// Number is the field's number in the existing proto, 0 for an added
+	// field.
+	Number int
+	// Suggested is the number an added field, or a retyped one that can't
+	// keep its number, should take: never one the proto has used or
+	// reserved.
+	Suggested int
+	// Compatible reports whether a retyped field's old and new types share
+	// a wire encoding, so it can keep its number and existing data still
+	// parses.
+	Compatible bool
+}
+
+// Empty reports whether the proto matches the config.
+func (d *SpokeDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Retyped) == 0
+}
+
+// Reserve returns the numbers and names the proto should reserve so they
+// aren't reused: those of removed fields, and the old numbers of retyped
+// fields that move.
+func (d *SpokeDiff) Reserve() (numbers []int, names []string) {
+	for _, f := range d.Removed {
+		numbers = append(numbers, f.Number)
+		names = append(names, f.Name)
+	}
+	for _, f := range d.Retyped {
+		if !f.Compatible {
+			numbers = append(numbers, f.Number)
+		}
+	}
+	slices.Sort(numbers)
+	return numbers, names
+}
+
+// existingField is a field declared in an existing proto.
+type existingField struct {
+	Name       string
+	Type       string
+	Number     int
+	DrupalType string
+}
+
+// existingMessage is a message declared in an existing proto.
+type existingMessage struct {
+	Fields   []existingField
+	Reserved []numberRange
+}
+
+// numberRange is an inclusive range of field numbers.
+type numberRange struct{ lo, hi int }
+
+var (
+	messagePattern    = regexp.MustCompile(`^\s*message\s+(\w+)\s*\{`)
+	protoFieldPattern = regexp.MustCompile(`^\s*((?:repeated\s+)?[\w.]+)\s+(\w+)\s*=\s*(\d+)`)
+	drupalTypePattern = regexp.MustCompile(`\[drupal:(\w+)\]`)
+	reservedPattern   = regexp.MustCompile(`^\s*reserved\s+([^;]+);`)
+)
+
+// DiffSpoke compares the main message of the proto at protoPath with the
+// first message of generated, the spoke its Drupal config produces now.
+// Fields are matched by name.
+func DiffSpoke(protoPath string, generated *ProtoFile) (*SpokeDiff, error) {
+	if len(generated.Messages) == 0 {
+		return nil, fmt.Errorf("generated spoke has no messages")
+	}
+	want := generated.Messages[0]
+	have, err := parseProtoMessage(protoPath, want.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &SpokeDiff{Message: want.Name}
+	used := make(map[int]bool)
+	byName := make(map[string]existingField)
+	for _, f := range have.Fields {
+		used[f.Number] = true
+		byName[f.Name] = f
+	}
+	taken := func(n int) bool {
+		if used[n] || n >= firstReservedNumber && n <= lastReservedNumber {
+			return true
+		}
+		for _, r := range have.Reserved {
+			if n >= r.lo && n <= r.hi {
+				return true
+			}
+		}
+		return false
+	}
+	// next returns preferred if it's free, else the first free number
+	// after every one in use, so new fields go at the end; failing that
+	// (the proto reserves up to max), the first free one
+	next := func(preferred int) int {
+		n := preferred
+		if n <= 0 || taken(n) {
+			n = 1
+			for m := range used {
+				n = max(n, m+1)
+			}
+			for _, r := range have.Reserved {
+				if r.hi < maxFieldNumber {
+					n = max(n, r.hi+1)
+				}
+			}
+			for n <= maxFieldNumber && taken(n) {
+				n++
+			}
+			if n > maxFieldNumber {
+				for n = 1; taken(n); n++ {
+				}
+			}
+		}
+		used[n] = true
+		return n
+	}
+
+	generatedNames := make(map[string]bool)
+	for _, f := range want.Fields {
+		generatedNames[f.Name] = true
+		old, ok := byName[f.Name]
+		if !ok {
+			d.Added = append(d.Added, FieldChange{
+				Name: f.Name, NewType: f.Type, NewDrupalType: f.DrupalType,
+				Suggested: next(f.Number),
+			})
+			continue
+		}
+		if normalizeType(old.Type) == normalizeType(f.Type) && old.DrupalType == f.DrupalType {
+			continue
+		}
+		change := FieldChange{
+			Name: f.Name, OldType: old.Type, NewType: f.Type,
+			OldDrupalType: old.DrupalType, NewDrupalType: f.DrupalType,
+			Number:     old.Number,
+			Compatible: wireCompatible(old.Type, f.Type),
+		}
+		if !change.Compatible {
+			change.Suggested = next(0)
+		}
+		d.Retyped = append(d.Retyped, change)
+	}
+	for _, f := range have.Fields {
+		if !generatedNames[f.Name] {
+			d.Removed = append(d.Removed, FieldChange{
+				Name: f.Name, OldType: f.Type, OldDrupalType: f.DrupalType, Number: f.Number,
+			})
+		}
+	}
+	return d, nil
+}
+
+// parseProtoMessage reads the fields and reserved numbers of the message
+// named name in the proto at protoPath.
+func parseProtoMessage(protoPath, name string) (*existingMessage, error) {
+	file, err := os.Open(protoPath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var (
+		msg        *existingMessage
+		depth      int
+		drupalType string
+	)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if msg == nil {
+			if m := messagePattern.FindStringSubmatch(line); m != nil && m[1] == name {
+				msg = &existingMessage{}
+				depth = 1
+			}
+			continue
+		}
+
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "//") {
+			if m := drupalTypePattern.FindStringSubmatch(trimmed); m != nil {
+				drupalType = m[1]
+			}
+			continue
+		}
+		// Only the message's own fields count, not those of nested
+		// messages
+		if depth == 1 {
+			if m := protoFieldPattern.FindStringSubmatch(line); m != nil {
+				num, _ := strconv.Atoi(m[3])
+				msg.Fields = append(msg.Fields, existingField{Name: m[2], Type: normalizeType(m[1]), Number: num, DrupalType: drupalType})
+			} else if m := reservedPattern.FindStringSubmatch(line); m != nil {
+				ranges, err := parseReserved(m[1])
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", protoPath, err)
+				}
+				msg.Reserved = append(msg.Reserved, ranges...)
+			}
+		}
+		if trimmed != "" {
+			drupalType = ""
+		}
+		depth += strings.Count(line, "{") - strings.Count(line, "}")
+		if depth <= 0 {
+			return msg, scanner.Err()
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if msg == nil {
+		return nil, fmt.Errorf("message %s not found in %s", name, protoPath)
+	}
+	return nil, fmt.Errorf("message %s in %s is not closed", name, protoPath)
+}
+
+// parseReserved returns the numbers a reserved statement lists, e.g.
+// `14, 20 to 22`. Reserved names don't take numbers and are skipped.
+func parseReserved(list string) ([]numberRange, error) {
+	var ranges []numberRange
+	for part := range strings.SplitSeq(list, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" || strings.HasPrefix(part, `"`) || strings.HasPrefix(part, "'") {
+			continue
+		}
+		lo, hi, isRange := strings.Cut(part, " to ")
+		r := numberRange{}
+		var err error
+		if r.lo, err = strconv.Atoi(strings.TrimSpace(lo)); err != nil {
+			return nil, fmt.Errorf("reserved %q: %w", part, err)
+		}
+		r.hi = r.lo
+		if isRange {
+			if hi = strings.TrimSpace(hi); hi == "max" {
+				r.hi = maxFieldNumber
+			} else if r.hi, err = strconv.Atoi(hi); err != nil {
+				return nil, fmt.Errorf("reserved %q: %w", part, err)
+			}
+		}
+		ranges = append(ranges, r)
+	}
+	return ranges, nil
+}
+
+// normalizeType collapses the whitespace in a proto type.
+func normalizeType(t string) string {
+	return strings.Join(strings.Fields(t), " ")
+}
+
+// wireCompatible reports whether data written as proto type from still
+// parses as type to, following the protobuf rules for updating a message
+// type: integer types sharing an encoding can be swapped, string and bytes
+// can, and a string, bytes, or message field can go between singular and
+// repeated.
+func wireCompatible(from, to string) bool {
+	fromBase, fromRepeated := strings.CutPrefix(normalizeType(from), "repeated ")
+	toBase, toRepeated := strings.CutPrefix(normalizeType(to), "repeated ")
+
+	group := func(t string) string {
+		switch t {
+		case "int32", "int64", "uint32", "uint64", "bool":
+			return "varint"
+		case "sint32", "sint64":
+			return "zigzag"
+		case "fixed32", "sfixed32":
+			return "fixed32"
+		case "fixed64", "sfixed64":
+			return "fixed64"
+		case "float", "double":
+			return t
+		case "string", "bytes":
+			return "bytes"
+		}
+		return "message:" + t
+	}
+	if group(fromBase) != group(toBase) {
+		return false
+	}
+	if fromRepeated == toRepeated {
+		return true
+	}
+	g := group(fromBase)
+	return g == "bytes" || strings.HasPrefix(g, "message:")
+}
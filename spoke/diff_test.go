@@ -0,0 +1,119 @@
+package spoke
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+const driftedProto = `syntax = "proto3";
+
+package spoke.lehigh.v1;
+
+// IslandoraObject represents a islandora_object from Drupal.
+message IslandoraObject {
+  reserved 30, 41 to 45;
+  reserved "pid";
+
+  int64 nid = 1;
+  string uuid = 2;
+  string title = 3;
+  string status = 4;
+  string created = 5;
+  string changed = 6;
+
+  // Contributors [drupal:string]
+  repeated string linked_agent = 20;
+
+  // Retired [drupal:string]
+  string retired = 40;
+
+  message Nested {
+    string model = 1;
+  }
+}
+`
+
+func TestDiffSpoke(t *testing.T) {
+	generated, err := GenerateDrupalSpoke("lehigh", "islandora_object", writeConfig(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	protoPath := filepath.Join(t.TempDir(), "lehigh.proto")
+	if err := os.WriteFile(protoPath, []byte(driftedProto), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := DiffSpoke(protoPath, generated)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// linked_agent can't keep 20 and takes the first number after the
+	// highest in use or reserved; model is generated at 30, which is
+	// reserved, so it takes the next
+	if len(d.Added) != 1 || d.Added[0].Name != "model" || d.Added[0].Suggested != 47 {
+		t.Errorf("added: got %+v", d.Added)
+	}
+	if len(d.Removed) != 1 || d.Removed[0].Name != "retired" || d.Removed[0].Number != 40 {
+		t.Errorf("removed: got %+v", d.Removed)
+	}
+	if len(d.Retyped) != 1 {
+		t.Fatalf("retyped: got %+v", d.Retyped)
+	}
+	if r := d.Retyped[0]; r.Name != "linked_agent" || r.Compatible || r.Suggested != 46 || r.OldDrupalType != "string" || r.NewDrupalType != "typed_relation" {
+		t.Errorf("retyped: got %+v", r)
+	}
+	numbers, names := d.Reserve()
+	if !slices.Equal(numbers, []int{20, 40}) || !slices.Equal(names, []string{"retired"}) {
+		t.Errorf("reserve: got %v %v", numbers, names)
+	}
+
+	// Regenerated from the same config, the spoke matches
+	out := filepath.Join(t.TempDir(), "lehigh.proto")
+	if err := WriteProto(generated, out); err != nil {
+		t.Fatal(err)
+	}
+	if d, err := DiffSpoke(out, generated); err != nil || !d.Empty() {
+		t.Errorf("fresh spoke: got %+v, %v", d, err)
+	}
+
+	generated.Messages[0].Name = "PublicationIssue"
+	if _, err := DiffSpoke(protoPath, generated); err == nil {
+		t.Error("missing message: want an error")
+	}
+}
+
+func TestParseReserved(t *testing.T) {
+	got, err := parseReserved(`3, 10 to 12, "old", 100 to max`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []numberRange{{3, 3}, {10, 12}, {100, maxFieldNumber}}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if _, err := parseReserved("ten"); err == nil {
+		t.Error("non-numeric: want an error")
+	}
+}
+
+func TestWireCompatible(t *testing.T) {
+	for _, tt := range []struct {
+		from, to string
+		want     bool
+	}{
+		{"string", "repeated string", true},
+		{"repeated TaxonomyRef", "TaxonomyRef", true},
+		{"string", "bytes", true},
+		{"int32", "int64", true},
+		{"int64", "repeated int64", false},
+		{"string", "int64", false},
+		{"sint32", "int32", false},
+		{"TaxonomyRef", "LinkedAgent", false},
+	} {
+		if got := wireCompatible(tt.from, tt.to); got != tt.want {
+			t.Errorf("%s -> %s: got %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,329 @@
+package spoke
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// DSpaceRegistry is a DSpace metadata schema registry export, the XML
+// `dspace dsrun org.dspace.administer.MetadataExporter` writes and
+// config/registries holds (dublin-core-types.xml and the like).
+type DSpaceRegistry struct {
+	Schemas []DSpaceSchema        `xml:"dc-schema"`
+	Fields  []DSpaceMetadataField `xml:"dc-type"`
+}
+
+// DSpaceSchema is a metadata schema a registry declares.
+type DSpaceSchema struct {
+	Name      string `xml:"name"`
+	Namespace string `xml:"namespace"`
+}
+
+// DSpaceMetadataField is one schema.element.qualifier field of a registry.
+type DSpaceMetadataField struct {
+	Schema    string `xml:"schema"`
+	Element   string `xml:"element"`
+	Qualifier string `xml:"qualifier"`
+	ScopeNote string `xml:"scope_note"`
+}
+
+// Name returns the field's dotted name, e.g. "dc.contributor.author".
+func (f DSpaceMetadataField) Name() string {
+	name := f.Schema + "." + f.Element
+	if f.Qualifier != "" {
+		name += "." + f.Qualifier
+	}
+	return name
+}
+
+var nonIdentChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// GenerateDSpaceSpoke generates a proto file from a DSpace metadata
+// registry export, or a directory of them. The message has a repeated
+// string field per metadata field, since DSpace allows any field several
+// values, with hub mappings guessed from its element and qualifier.
+func GenerateDSpaceSpoke(name, registryPath string) (*ProtoFile, error) {
+	fields, err := parseDSpaceRegistry(registryPath)
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("no metadata fields found in %s", registryPath)
+	}
+
+	proto := newSpokeProtoFile(name, fmt.Sprintf("Generated from DSpace metadata registry '%s'", filepath.Base(registryPath)))
+	msg := ProtoMessage{
+		Name:    "Item",
+		Comment: "Item represents a DSpace item.",
+		Fields: []ProtoField{
+			{Name: "id", Type: "string", Number: 1, Comment: "DSpace item UUID"},
+			{Name: "collection", Type: "repeated string", Number: 2, Comment: "Handles of the collections holding the item"},
+		},
+	}
+
+	// Fields are grouped by schema and element, each group starting at the
+	// next multiple of 10, so adding a qualifier renumbers nothing else
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].Name() < fields[j].Name()
+	})
+	fieldNum := 10
+	group := ""
+	seen := make(map[string]string)
+	for _, f := range fields {
+		if g := f.Schema + "." + f.Element; g != group {
+			if group != "" {
+				fieldNum = ((fieldNum-1)/10 + 1) * 10
+			}
+			group = g
+		}
+		pf := dspaceFieldToProto(f, fieldNum)
+		if other, ok := seen[pf.Name]; ok {
+			return nil, fmt.Errorf("metadata fields %s and %s both make proto field %s", other, f.Name(), pf.Name)
+		}
+		seen[pf.Name] = f.Name()
+		msg.Fields = append(msg.Fields, pf)
+		fieldNum++
+	}
+
+	proto.Messages = append(proto.Messages, msg)
+	return proto, nil
+}
+
+// parseDSpaceRegistry reads the metadata fields of a registry file, or of
+// every .xml registry in a directory. Fields declared twice are kept once.
+func parseDSpaceRegistry(registryPath string) ([]DSpaceMetadataField, error) {
+	info, err := os.Stat(registryPath)
+	if err != nil {
+		return nil, fmt.Errorf("registry path not accessible: %w", err)
+	}
+	files := []string{registryPath}
+	if info.IsDir() {
+		if files, err = filepath.Glob(filepath.Join(registryPath, "*.xml")); err != nil {
+			return nil, err
+		}
+	}
+
+	var fields []DSpaceMetadataField
+	declared := make(map[string]bool)
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		var reg DSpaceRegistry
+		if err := xml.Unmarshal(data, &reg); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", file, err)
+		}
+		for _, f := range reg.Fields {
+			f.Schema = strings.TrimSpace(f.Schema)
+			f.Element = strings.TrimSpace(f.Element)
+			f.Qualifier = strings.TrimSpace(f.Qualifier)
+			if f.Element == "" {
+				continue
+			}
+			// Registries from DSpace 1.x predate schemas; their fields are dc
+			if f.Schema == "" {
+				f.Schema = "dc"
+			}
+			if declared[f.Name()] {
+				continue
+			}
+			declared[f.Name()] = true
+			fields = append(fields, f)
+		}
+	}
+	return fields, nil
+}
+
+func dspaceFieldToProto(f DSpaceMetadataField, num int) ProtoField {
+	comment := strings.Join(strings.Fields(f.ScopeNote), " ")
+	if comment == "" {
+		comment = f.Name()
+	}
+	pf := ProtoField{
+		Name:        strings.Trim(nonIdentChars.ReplaceAllString(strings.ToLower(f.Name()), "_"), "_"),
+		Type:        "repeated string",
+		Number:      num,
+		Comment:     comment,
+		DSpaceField: f.Name(),
+		Cardinality: -1,
+	}
+	pf.RDFPredicate = dctermsPredicate(f)
+	mapFromDSpaceField(&pf, f)
+	return pf
+}
+
+// dctermsElements are the DCMI terms DSpace's dc elements are named for.
+var dctermsElements = map[string]bool{
+	"audience": true, "contributor": true, "coverage": true, "creator": true,
+	"date": true, "description": true, "format": true, "identifier": true,
+	"language": true, "provenance": true, "publisher": true, "relation": true,
+	"rights": true, "rightsholder": true, "source": true, "subject": true,
+	"title": true, "type": true,
+}
+
+// dctermsQualifiers are the DCMI terms of DSpace's dc qualifiers that
+// refine an element, by lowercased qualifier.
+var dctermsQualifiers = map[string]string{
+	"abstract": "abstract", "accepted": "dateAccepted", "accessrights": "accessRights",
+	"alternative": "alternative", "available": "available", "citation": "bibliographicCitation",
+	"copyright": "dateCopyrighted", "created": "created", "educationlevel": "educationLevel",
+	"extent": "extent", "hasformat": "hasFormat", "haspart": "hasPart",
+	"hasversion": "hasVersion", "isformatof": "isFormatOf", "ispartof": "isPartOf",
+	"isreferencedby": "isReferencedBy", "isreplacedby": "isReplacedBy", "isversionof": "isVersionOf",
+	"issued": "issued", "license": "license", "medium": "medium", "modified": "modified",
+	"references": "references", "replaces": "replaces", "requires": "requires",
+	"spatial": "spatial", "submitted": "dateSubmitted", "tableofcontents": "tableOfContents",
+	"temporal": "temporal", "valid": "valid",
+}
+
+// dctermsPredicate returns the DCMI term a dc or dcterms field is, e.g.
+// "dcterms:issued" for dc.date.issued: its qualifier's when that is a DCMI
+// refinement, else its element's, or "" for other schemas and elements.
+func dctermsPredicate(f DSpaceMetadataField) string {
+	if f.Schema != "dc" && f.Schema != "dcterms" {
+		return ""
+	}
+	if term, ok := dctermsQualifiers[strings.ToLower(f.Qualifier)]; ok {
+		return "dcterms:" + term
+	}
+	if element := strings.ToLower(f.Element); dctermsElements[element] {
+		if element == "rightsholder" {
+			return "dcterms:rightsHolder"
+		}
+		return "dcterms:" + element
+	}
+	return ""
+}
+
+// mapFromDSpaceField maps a DSpace metadata field to the hub by its element
+// and qualifier, then, for Dublin Core qualifiers that are DCMI terms
+// (dc.date.issued is dcterms:issued), by its RDF predicate. A field no
+// heuristic matches is kept in Extra under its underscored name.
+func mapFromDSpaceField(pf *ProtoField, f DSpaceMetadataField) {
+	element, qualifier := strings.ToLower(f.Element), strings.ToLower(f.Qualifier)
+
+	switch {
+	// DSpace's own bookkeeping, kept but not as descriptive metadata
+	case element == "date" && qualifier == "accessioned",
+		element == "description" && qualifier == "provenance":
+		pf.HubField = "Extra." + pf.Name
+
+	// Contributors
+	case element == "contributor" || element == "creator":
+		pf.HubField = "Contributors"
+		switch qualifier {
+		case "author", "editor", "advisor", "illustrator", "translator":
+			pf.HubType = qualifier
+		case "committeemember":
+			pf.HubType = "committee_member"
+		case "":
+			if element == "creator" {
+				pf.HubType = "creator"
+			}
+		}
+
+	// Titles
+	case element == "title" && qualifier == "":
+		pf.HubField = "Title"
+	case element == "title":
+		pf.HubField = "AltTitle"
+
+	// Dates
+	case element == "date":
+		pf.HubField = "Dates"
+		pf.Parser = "edtf"
+		switch qualifier {
+		case "issued", "created", "copyright", "available", "submitted", "accepted", "modified", "valid":
+			pf.HubType = qualifier
+		default:
+			pf.HubType = "other"
+		}
+
+	// Identifiers
+	case element == "identifier":
+		pf.HubField = "Identifiers"
+		switch qualifier {
+		case "doi", "isbn", "issn", "orcid", "pmid", "pmcid", "oclc", "ark", "urn":
+			pf.HubType = qualifier
+		case "uri":
+			// DSpace records an item's Handle URL here
+			pf.HubType = "handle"
+		case "citation":
+			pf.HubField = "Notes"
+		case "other", "":
+			pf.HubType = "other"
+		default:
+			pf.HubType = "local"
+		}
+
+	// Descriptions
+	case element == "description" && qualifier == "abstract":
+		pf.HubField = "Abstract"
+	case element == "description" && qualifier == "tableofcontents":
+		pf.HubField = "TableOfContents"
+	case element == "description":
+		pf.HubField = "Notes"
+	case element == "format" && qualifier == "extent":
+		pf.HubField = "PhysicalDesc"
+
+	// Subjects
+	case element == "subject":
+		pf.HubField = "Subjects"
+		switch qualifier {
+		case "lcsh", "mesh", "fast", "aat", "tgm":
+			pf.HubType = qualifier
+		case "":
+			pf.HubType = "keywords"
+		}
+	case element == "coverage" && qualifier == "spatial":
+		pf.HubField = "Subjects"
+		pf.HubType = "geographic"
+	case element == "coverage" && qualifier == "temporal":
+		pf.HubField = "Subjects"
+		pf.HubType = "temporal"
+
+	// Relations
+	case element == "relation" && (qualifier == "ispartof" || qualifier == "ispartofseries"):
+		pf.HubField = "Relations"
+		pf.HubType = "part_of"
+	case element == "relation":
+		pf.HubField = "Relations"
+		pf.HubType = "related_to"
+
+	// Everything else DSpace's dc registry has
+	case element == "type":
+		pf.HubField = "ResourceType"
+	case element == "language":
+		pf.HubField = "Language"
+	case element == "rights":
+		pf.HubField = "Rights"
+	case element == "publisher":
+		pf.HubField = "Publisher"
+
+	// ETD-MS thesis schema
+	case f.Schema == "thesis" && element == "degree":
+		switch qualifier {
+		case "name":
+			pf.HubField = "DegreeInfo.DegreeName"
+		case "level":
+			pf.HubField = "DegreeInfo.DegreeLevel"
+		case "discipline", "department":
+			pf.HubField = "DegreeInfo.Department"
+		case "grantor":
+			pf.HubField = "DegreeInfo.Institution"
+		default:
+			pf.HubField = "Extra." + pf.Name
+		}
+
+	default:
+		if pf.RDFPredicate == "" || !mapFromRDFPredicate(pf) {
+			pf.HubField = "Extra." + pf.Name
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package spoke
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// dspaceRegistry is an excerpt of DSpace's dublin-core-types.xml with a
+// local field and a field redeclared.
+const dspaceRegistry = `<?xml version="1.0"?>
+<dspace-dc-types>
+  <dspace-header>
+    <title>DSpace Dublin Core Types Registry</title>
+  </dspace-header>
+  <dc-schema>
+    <name>dc</name>
+    <namespace>http://dublincore.org/documents/dcmi-terms/</namespace>
+  </dc-schema>
+  <dc-type>
+    <schema>dc</schema>
+    <element>contributor</element>
+    <qualifier>author</qualifier>
+    <scope_note></scope_note>
+  </dc-type>
+  <dc-type>
+    <schema>dc</schema>
+    <element>contributor</element>
+    <qualifier>advisor</qualifier>
+    <scope_note>Use primarily for thesis advisor.</scope_note>
+  </dc-type>
+  <dc-type>
+    <schema>dc</schema>
+    <element>date</element>
+    <qualifier>accessioned</qualifier>
+    <scope_note>Date DSpace takes possession of item.</scope_note>
+  </dc-type>
+  <dc-type>
+    <schema>dc</schema>
+    <element>date</element>
+    <qualifier>issued</qualifier>
+    <scope_note>Date of publication or distribution.</scope_note>
+  </dc-type>
+  <dc-type>
+    <schema>dc</schema>
+    <element>identifier</element>
+    <qualifier>uri</qualifier>
+    <scope_note>Uniform Resource Identifier</scope_note>
+  </dc-type>
+  <dc-type>
+    <schema>dc</schema>
+    <element>title</element>
+    <scope_note>Title statement/title proper.</scope_note>
+  </dc-type>
+  <dc-type>
+    <schema>dc</schema>
+    <element>title</element>
+    <qualifier>alternative</qualifier>
+    <scope_note>Varying (or substitute) form of title proper appearing in item,
+      e.g. abbreviation or translation</scope_note>
+  </dc-type>
+  <dc-type>
+    <schema>dc</schema>
+    <element>audience</element>
+    <qualifier>educationLevel</qualifier>
+  </dc-type>
+  <dc-type>
+    <schema>local</schema>
+    <element>sponsor</element>
+  </dc-type>
+  <dc-type>
+    <schema>dc</schema>
+    <element>title</element>
+  </dc-type>
+</dspace-dc-types>
+`
+
+func TestGenerateDSpaceSpoke(t *testing.T) {
+	dir := t.TempDir()
+	registry := filepath.Join(dir, "dublin-core-types.xml")
+	if err := os.WriteFile(registry, []byte(dspaceRegistry), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	proto, err := GenerateDSpaceSpoke("lehigh-dspace", registry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if proto.Package != "spoke.lehigh_dspace.v1" {
+		t.Errorf("package %s", proto.Package)
+	}
+	msg := proto.Messages[0]
+	fields := make(map[string]ProtoField)
+	for _, f := range msg.Fields {
+		fields[f.Name] = f
+	}
+	if len(fields) != 11 {
+		t.Errorf("got %d fields, want id, collection, and 9 metadata fields", len(fields))
+	}
+
+	tests := []struct {
+		name, dspace, hubField, hubType string
+		number                          int
+	}{
+		{"dc_audience_educationlevel", "dc.audience.educationLevel", "Extra.dc_audience_educationlevel", "", 10},
+		{"dc_contributor_advisor", "dc.contributor.advisor", "Contributors", "advisor", 20},
+		{"dc_contributor_author", "dc.contributor.author", "Contributors", "author", 21},
+		{"dc_date_accessioned", "dc.date.accessioned", "Extra.dc_date_accessioned", "", 30},
+		{"dc_date_issued", "dc.date.issued", "Dates", "issued", 31},
+		{"dc_identifier_uri", "dc.identifier.uri", "Identifiers", "handle", 40},
+		{"dc_title", "dc.title", "Title", "", 50},
+		{"dc_title_alternative", "dc.title.alternative", "AltTitle", "", 51},
+		{"local_sponsor", "local.sponsor", "Extra.local_sponsor", "", 60},
+	}
+	for _, tt := range tests {
+		f, ok := fields[tt.name]
+		if !ok {
+			t.Errorf("no field %s", tt.name)
+			continue
+		}
+		if f.DSpaceField != tt.dspace || f.HubField != tt.hubField || f.HubType != tt.hubType || f.Number != tt.number || f.Type != "repeated string" {
+			t.Errorf("%s: got %s = %d, %s %s/%s", tt.name, f.DSpaceField, f.Number, f.Type, f.HubField, f.HubType)
+		}
+	}
+	for name, want := range map[string]string{
+		"dc_contributor_author":      "dcterms:contributor",
+		"dc_date_issued":             "dcterms:issued",
+		"dc_audience_educationlevel": "dcterms:educationLevel",
+		"local_sponsor":              "",
+	} {
+		if got := fields[name].RDFPredicate; got != want {
+			t.Errorf("%s predicate %q, want %q", name, got, want)
+		}
+	}
+	if got := fields["dc_title_alternative"].Comment; got != "Varying (or substitute) form of title proper appearing in item, e.g. abbreviation or translation" {
+		t.Errorf("comment %q", got)
+	}
+
+	ApplyAutoMappings(proto)
+	out := filepath.Join(t.TempDir(), "lehigh_dspace.proto")
+	proto.FormatName = "dspace"
+	if err := WriteProto(proto, out); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(out)
+	if want := `repeated string dc_date_issued = 31 [(hub.v1.field) = {target: "dates" date_type: "issued" parser: "edtf"}];`; !strings.Contains(string(data), want) {
+		t.Errorf("proto missing %s:\n%s", want, data)
+	}
+	if !strings.Contains(string(data), "// Date of publication or distribution. [dspace:dc.date.issued]") {
+		t.Errorf("proto does not name DSpace fields:\n%s", data)
+	}
+	meta, _ := os.ReadFile(strings.TrimSuffix(out, ".proto") + "_meta.go")
+	if !strings.Contains(string(meta), `DSpaceField:  "dc.contributor.author",`) {
+		t.Errorf("meta missing DSpace field:\n%s", meta)
+	}
+
+	// A directory reads every registry in it
+	if err := os.WriteFile(filepath.Join(dir, "local-types.xml"), []byte(`<dspace-dc-types><dc-type><schema>local</schema><element>funder</element></dc-type></dspace-dc-types>`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	proto, err = GenerateDSpaceSpoke("lehigh-dspace", dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(proto.Messages[0].Fields); n != 12 {
+		t.Errorf("directory: got %d fields, want 12", n)
+	}
+}
@@ -3,6 +3,7 @@ package spoke
 
 import (
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -11,6 +12,8 @@ import (
 	"text/template"
 
 	"gopkg.in/yaml.v3"
+
+	"github.com/lehigh-university-libraries/crosswalk/mapping"
 )
 
 // DrupalFieldConfig represents a Drupal field configuration from config/sync.
@@ -78,6 +81,32 @@ type ProtoField struct {
 	HubRelType    string // For relations: member_of, part_of, etc.
 	HubExtraKey   string // For extra fields: the key name
 	HubSkip       bool   // Whether to skip this field in Hub mapping
+
+	// Transform is the cleanup of the field's values (split, regex_extract,
+	// prefix, etc.) declared in its hub.v1.field annotation
+	Transform mapping.TransformOptions
+
+	// Bundles lists the bundles that have this field, in a union spoke,
+	// when not all of them do
+	Bundles []string
+
+	// DSpaceField is the DSpace metadata field (e.g. "dc.contributor.author")
+	// in a spoke generated from a DSpace registry
+	DSpaceField string
+
+	// CSVColumn is the column header in a spoke generated from a sample
+	// spreadsheet, and Delimiter the separator of its values when they
+	// are lists
+	CSVColumn string
+	Delimiter string
+
+	// XML serialization in a spoke generated from an XML schema: the
+	// element or attribute name when it isn't the field's, whether it's an
+	// attribute or the element's text, and whether the schema requires it
+	XMLName     string
+	XMLAttr     bool
+	XMLChardata bool
+	Required    bool
 }
 
 // ProtoMessage represents a message in the generated proto.
@@ -85,6 +114,13 @@ type ProtoMessage struct {
 	Name    string       // Message name (PascalCase)
 	Comment string       // Message comment
 	Fields  []ProtoField // Fields in order
+
+	// XMLName, XMLDefaultNS, and XMLNamespaces are the root element and
+	// namespaces of the main message of a spoke generated from an XML
+	// schema ("prefix=uri" for each namespace)
+	XMLName       string
+	XMLDefaultNS  string
+	XMLNamespaces []string
 }
 
 // ProtoFile represents a complete proto file.
@@ -97,6 +133,7 @@ type ProtoFile struct {
 	Enums         []ProtoEnum    // All enums
 	Description   string         // File description
 	UseHubOptions bool           // Whether to include hub.v1 annotations
+	Bundles       []string       // Bundles a union spoke covers; nil for a single bundle
 }
 
 // ProtoEnum represents an enum in the proto.
@@ -114,13 +151,8 @@ type ProtoEnumValue struct {
 
 // GenerateDrupalSpoke generates a proto file from a Drupal config directory.
 func GenerateDrupalSpoke(name, bundle, configPath string) (*ProtoFile, error) {
-	// Verify path exists
-	info, err := os.Stat(configPath)
-	if err != nil {
-		return nil, fmt.Errorf("config path not accessible: %w", err)
-	}
-	if !info.IsDir() {
-		return nil, fmt.Errorf("config path is not a directory: %s", configPath)
+	if err := checkConfigPath(configPath); err != nil {
+		return nil, err
 	}
 
 	// Parse field configs for the specific bundle
@@ -130,9 +162,9 @@ func GenerateDrupalSpoke(name, bundle, configPath string) (*ProtoFile, error) {
 	}
 
 	// Parse field storage for cardinality
-	storage, err := parseFieldStorage(configPath)
+	storageMap, err := parseStorageMap(configPath)
 	if err != nil {
-		return nil, fmt.Errorf("parsing field storage: %w", err)
+		return nil, err
 	}
 
 	// Parse RDF mapping if available
@@ -142,41 +174,153 @@ func GenerateDrupalSpoke(name, bundle, configPath string) (*ProtoFile, error) {
 		fmt.Fprintf(os.Stderr, "Note: No RDF mapping found for %s.%s, using field name heuristics\n", "node", bundle)
 	}
 
-	// Build storage map for quick lookup
+	// Generate proto
+	proto := newSpokeProtoFile(name, fmt.Sprintf("Generated from Drupal bundle '%s'", bundle))
+
+	// Create main message
+	mainMsg := ProtoMessage{
+		Name:    toPascalCase(bundle),
+		Comment: fmt.Sprintf("%s represents a %s from Drupal.", toPascalCase(bundle), bundle),
+		Fields:  coreDrupalFields(),
+	}
+	addCustomFields(&mainMsg, fields, storageMap, func(string) *DrupalRDFMapping { return rdfMapping })
+
+	proto.Messages = append(proto.Messages, mainMsg)
+
+	// Add helper messages for complex types
+	proto.Messages = append(proto.Messages, generateHelperMessages()...)
+
+	return proto, nil
+}
+
+// GenerateDrupalUnionSpoke generates a proto file with one message covering
+// several Drupal bundles, for a content model handled by one generated
+// package. The message has the union of the bundles' fields, each field
+// only some of them have noting which, and a bundle field naming the
+// bundle a node belongs to.
+func GenerateDrupalUnionSpoke(name string, bundles []string, configPath string) (*ProtoFile, error) {
+	if len(bundles) < 2 {
+		return nil, fmt.Errorf("a union spoke needs at least two bundles, got %d", len(bundles))
+	}
+	if err := checkConfigPath(configPath); err != nil {
+		return nil, err
+	}
+	storageMap, err := parseStorageMap(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// A field shared by several bundles is declared once; Drupal keeps one
+	// storage per field, so only its label and RDF mapping can differ, and
+	// the first bundle's are used
+	var fields []DrupalFieldConfig
+	owners := make(map[string][]string)
+	rdfMappings := make(map[string]*DrupalRDFMapping)
+	for _, bundle := range bundles {
+		bundleFields, err := parseFieldConfigs(configPath, bundle)
+		if err != nil {
+			return nil, fmt.Errorf("parsing field configs for %s: %w", bundle, err)
+		}
+		if len(bundleFields) == 0 {
+			return nil, fmt.Errorf("no field config found for bundle %q in %s", bundle, configPath)
+		}
+		rdfMapping, err := parseRDFMapping(configPath, "node", bundle)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Note: No RDF mapping found for %s.%s, using field name heuristics\n", "node", bundle)
+		}
+		for _, f := range bundleFields {
+			if _, seen := owners[f.FieldName]; !seen {
+				fields = append(fields, f)
+				rdfMappings[f.FieldName] = rdfMapping
+			}
+			owners[f.FieldName] = append(owners[f.FieldName], bundle)
+		}
+	}
+
+	proto := newSpokeProtoFile(name, fmt.Sprintf("Generated from Drupal bundles '%s'", strings.Join(bundles, "', '")))
+	proto.Bundles = bundles
+
+	msgName := toPascalCase(name)
+	unionMsg := ProtoMessage{
+		Name:    msgName,
+		Comment: fmt.Sprintf("%s represents a node of any of the bundles %s from Drupal.", msgName, strings.Join(bundles, ", ")),
+		Fields: append(coreDrupalFields(), ProtoField{
+			Name: "bundle", Type: "string", Number: 7,
+			Comment: "Drupal content type of the node: " + strings.Join(bundles, ", "),
+		}),
+	}
+	addCustomFields(&unionMsg, fields, storageMap, func(field string) *DrupalRDFMapping { return rdfMappings[field] })
+	for i := range unionMsg.Fields {
+		f := &unionMsg.Fields[i]
+		if f.DrupalField == "" {
+			continue
+		}
+		if f.Name == "bundle" {
+			return nil, fmt.Errorf("field %s clashes with the union's bundle field", f.DrupalField)
+		}
+		if have := owners[f.DrupalField]; len(have) < len(bundles) {
+			f.Bundles = have
+		}
+	}
+
+	proto.Messages = append(proto.Messages, unionMsg)
+	proto.Messages = append(proto.Messages, generateHelperMessages()...)
+	return proto, nil
+}
+
+// checkConfigPath verifies the config/sync directory exists.
+func checkConfigPath(configPath string) error {
+	info, err := os.Stat(configPath)
+	if err != nil {
+		return fmt.Errorf("config path not accessible: %w", err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("config path is not a directory: %s", configPath)
+	}
+	return nil
+}
+
+// parseStorageMap loads the node field storage, by field name.
+func parseStorageMap(configPath string) (map[string]DrupalFieldStorage, error) {
+	storage, err := parseFieldStorage(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("parsing field storage: %w", err)
+	}
 	storageMap := make(map[string]DrupalFieldStorage)
 	for _, s := range storage {
 		storageMap[s.FieldName] = s
 	}
+	return storageMap, nil
+}
 
+// newSpokeProtoFile returns an empty proto file for the spoke name.
+func newSpokeProtoFile(name, description string) *ProtoFile {
 	// Proto identifiers cannot contain hyphens; use underscores in package names.
 	protoName := strings.ReplaceAll(name, "-", "_")
-
-	// Generate proto
-	proto := &ProtoFile{
+	return &ProtoFile{
 		Package:     fmt.Sprintf("spoke.%s.v1", protoName),
 		GoPackage:   fmt.Sprintf("github.com/lehigh-university-libraries/crosswalk/spoke/%s/v1;%sv1", name, protoName),
 		PackageName: fmt.Sprintf("%sv1", protoName),
-		Description: fmt.Sprintf("Generated from Drupal bundle '%s'", bundle),
+		Description: description,
 	}
+}
 
-	// Create main message
-	mainMsg := ProtoMessage{
-		Name:    toPascalCase(bundle),
-		Comment: fmt.Sprintf("%s represents a %s from Drupal.", toPascalCase(bundle), bundle),
+// coreDrupalFields are the node base fields every message starts with.
+func coreDrupalFields() []ProtoField {
+	return []ProtoField{
+		{Name: "nid", Type: "int64", Number: 1, Comment: "Drupal node ID"},
+		{Name: "uuid", Type: "string", Number: 2, Comment: "Drupal UUID"},
+		{Name: "title", Type: "string", Number: 3, Comment: "Node title"},
+		{Name: "status", Type: "string", Number: 4, Comment: "Published status"},
+		{Name: "created", Type: "string", Number: 5, Comment: "Created timestamp"},
+		{Name: "changed", Type: "string", Number: 6, Comment: "Changed timestamp"},
 	}
+}
 
-	// Add core Drupal fields
-	fieldNum := 1
-	coreFields := []ProtoField{
-		{Name: "nid", Type: "int64", Number: fieldNum, Comment: "Drupal node ID"},
-		{Name: "uuid", Type: "string", Number: fieldNum + 1, Comment: "Drupal UUID"},
-		{Name: "title", Type: "string", Number: fieldNum + 2, Comment: "Node title"},
-		{Name: "status", Type: "string", Number: fieldNum + 3, Comment: "Published status"},
-		{Name: "created", Type: "string", Number: fieldNum + 4, Comment: "Created timestamp"},
-		{Name: "changed", Type: "string", Number: fieldNum + 5, Comment: "Changed timestamp"},
-	}
-	mainMsg.Fields = append(mainMsg.Fields, coreFields...)
-	fieldNum = 10 // Start custom fields at 10
+// addCustomFields appends the bundle fields to msg, numbered from 10 and
+// grouped by category, each category starting at the next multiple of 10.
+func addCustomFields(msg *ProtoMessage, fields []DrupalFieldConfig, storageMap map[string]DrupalFieldStorage, rdfFor func(field string) *DrupalRDFMapping) {
+	fieldNum := 10 // Start custom fields at 10
 
 	// Sort fields by name for consistent output
 	sort.Slice(fields, func(i, j int) bool {
@@ -187,23 +331,15 @@ func GenerateDrupalSpoke(name, bundle, configPath string) (*ProtoFile, error) {
 	categories := categorizeFields(fields)
 
 	for _, category := range categories {
-		// Add a comment for the category
 		for _, field := range category.Fields {
 			stor := storageMap[field.FieldName]
-			protoField := drupalFieldToProto(field, stor, rdfMapping, fieldNum)
-			mainMsg.Fields = append(mainMsg.Fields, protoField)
+			protoField := drupalFieldToProto(field, stor, rdfFor(field.FieldName), fieldNum)
+			msg.Fields = append(msg.Fields, protoField)
 			fieldNum++
 		}
 		// Add gap between categories
 		fieldNum = ((fieldNum / 10) + 1) * 10
 	}
-
-	proto.Messages = append(proto.Messages, mainMsg)
-
-	// Add helper messages for complex types
-	proto.Messages = append(proto.Messages, generateHelperMessages()...)
-
-	return proto, nil
 }
 
 type fieldCategory struct {
@@ -318,14 +454,24 @@ func drupalFieldToProto(field DrupalFieldConfig, storage DrupalFieldStorage, rdf
 
 // mapToHubField maps a Drupal field to its corresponding Hub schema field.
 // It first tries to use RDF predicate mapping (if available), then falls back to name heuristics.
-func mapToHubField(pf *ProtoField, field DrupalFieldConfig) {
+func mapToHubField(pf *ProtoField, field DrupalFieldConfig) bool {
 	// Try RDF predicate mapping first
 	if pf.RDFPredicate != "" && mapFromRDFPredicate(pf) {
-		return
+		return true
 	}
 
 	// Fall back to name-based heuristics
-	mapFromFieldName(pf, field)
+	return mapFromFieldName(pf, field)
+}
+
+// GuessHubField guesses the hub mapping of a Drupal field from its RDF
+// predicate, if any, and then its name, as spoke generation does. matched
+// is false when no heuristic applied and the field fell back to an Extra
+// entry, or, without a field type, to nothing.
+func GuessHubField(fieldName, fieldType, rdfPredicate string) (hubField, hubType, parser string, matched bool) {
+	pf := ProtoField{RDFPredicate: rdfPredicate}
+	matched = mapToHubField(&pf, DrupalFieldConfig{FieldName: fieldName, FieldType: fieldType})
+	return pf.HubField, pf.HubType, pf.Parser, matched
 }
 
 // mapFromRDFPredicate maps a field based on its RDF predicate. Returns true if mapped.
@@ -447,8 +593,9 @@ func mapFromRDFPredicate(pf *ProtoField) bool {
 	return true
 }
 
-// mapFromFieldName maps a field based on its Drupal field name (heuristic fallback).
-func mapFromFieldName(pf *ProtoField, field DrupalFieldConfig) {
+// mapFromFieldName maps a field based on its Drupal field name (heuristic
+// fallback). Returns false if no heuristic matched.
+func mapFromFieldName(pf *ProtoField, field DrupalFieldConfig) bool {
 	name := field.FieldName
 	fieldType := field.FieldType
 
@@ -593,7 +740,9 @@ func mapFromFieldName(pf *ProtoField, field DrupalFieldConfig) {
 			cleanName := strings.TrimPrefix(name, "field_")
 			pf.HubField = "Extra." + cleanName
 		}
+		return false
 	}
+	return true
 }
 
 func drupalTypeToProto(drupalType string) string {
@@ -798,47 +947,55 @@ func ApplyAutoMappings(proto *ProtoFile) {
 
 	mainMsg := &proto.Messages[0]
 	for i := range mainMsg.Fields {
-		field := &mainMsg.Fields[i]
-
-		// Skip core fields
-		if isCoreDrupalField(field.Name) {
-			continue
+		if needsHubMapping(mainMsg.Fields[i]) {
+			autoMapField(&mainMsg.Fields[i])
 		}
+	}
 
-		// Convert HubField to HubTarget
-		if field.HubField == "" {
-			continue
-		}
+	proto.UseHubOptions = true
+}
 
-		// Handle compound fields like "Extra.model" or "DegreeInfo.DegreeName"
-		if strings.HasPrefix(field.HubField, "Extra.") {
-			field.HubTarget = "extra"
-			field.HubExtraKey = strings.TrimPrefix(field.HubField, "Extra.")
-		} else if strings.HasPrefix(field.HubField, "DegreeInfo.") {
-			field.HubTarget = "degree_info"
-		} else {
-			// Map to lowercase target names
-			field.HubTarget = fieldToTarget(field.HubField)
-		}
+// needsHubMapping reports whether a field of a spoke's main message is
+// mapped to the hub: all but the core Drupal fields, though a
+// spreadsheet's columns are all its own.
+func needsHubMapping(field ProtoField) bool {
+	return !isCoreDrupalField(field.Name) || field.CSVColumn != ""
+}
 
-		// Convert HubType to appropriate type-specific field
-		if field.HubType != "" {
-			switch field.HubTarget {
-			case "dates":
-				field.HubDateType = field.HubType
-			case "identifiers":
-				field.HubIDType = field.HubType
-			case "subjects":
-				field.HubSubjectVoc = field.HubType
-			case "relations":
-				field.HubRelType = field.HubType
-			case "contributors":
-				field.HubRole = field.HubType
-			}
-		}
+// autoMapField converts a field's auto-detected HubField/HubType to its
+// Hub* annotation fields.
+func autoMapField(field *ProtoField) {
+	// Convert HubField to HubTarget
+	if field.HubField == "" {
+		return
 	}
 
-	proto.UseHubOptions = true
+	// Handle compound fields like "Extra.model" or "DegreeInfo.DegreeName"
+	if strings.HasPrefix(field.HubField, "Extra.") {
+		field.HubTarget = "extra"
+		field.HubExtraKey = strings.TrimPrefix(field.HubField, "Extra.")
+	} else if strings.HasPrefix(field.HubField, "DegreeInfo.") {
+		field.HubTarget = "degree_info"
+	} else {
+		// Map to lowercase target names
+		field.HubTarget = fieldToTarget(field.HubField)
+	}
+
+	// Convert HubType to appropriate type-specific field
+	if field.HubType != "" {
+		switch field.HubTarget {
+		case "dates":
+			field.HubDateType = field.HubType
+		case "identifiers":
+			field.HubIDType = field.HubType
+		case "subjects":
+			field.HubSubjectVoc = field.HubType
+		case "relations":
+			field.HubRelType = field.HubType
+		case "contributors":
+			field.HubRole = field.HubType
+		}
+	}
 }
 
 // fieldToTarget converts a HubField name to a hub target name.
@@ -872,13 +1029,47 @@ func fieldToTarget(hubField string) string {
 	return strings.ToLower(hubField)
 }
 
-// ApplyInteractiveMappings prompts the user to map each field to Hub targets.
-// If protoPath exists, it loads previous mappings to autofill selections.
-func ApplyInteractiveMappings(proto *ProtoFile, protoPath string) error {
+// ApplyInteractiveMappings prompts the user to map each field to Hub targets:
+// in a full-screen mapper when run in a terminal, else a prompt per field.
+// If protoPath exists, it loads previous mappings to autofill selections,
+// and a session, if not nil, overrides them.
+func ApplyInteractiveMappings(proto *ProtoFile, protoPath string, session *MappingSession) error {
+	if len(proto.Messages) == 0 {
+		return nil
+	}
+	mainMsg := &proto.Messages[0]
+
+	// In a terminal, map fields in the full-screen mapper
+	if isTerminal(os.Stdin) && isTerminal(os.Stdout) {
+		existing, err := ParseExistingProto(protoPath)
+		if err != nil {
+			return fmt.Errorf("parsing existing proto: %w", err)
+		}
+		start := make(map[string]FieldMapping)
+		for name, e := range existing {
+			if e.Target != "" {
+				start[name] = mappingFromExisting(e)
+			}
+		}
+		if session != nil {
+			maps.Copy(start, session.mappings())
+		}
+		if err := runMappingTUI(mainMsg, start); err != nil {
+			return err
+		}
+		proto.UseHubOptions = true
+		return nil
+	}
+
 	mapper, err := NewInteractiveMapper(protoPath)
 	if err != nil {
 		return fmt.Errorf("creating interactive mapper: %w", err)
 	}
+	if session != nil {
+		for name, m := range session.mappings() {
+			mapper.existing[name] = m.existing(name)
+		}
+	}
 
 	fmt.Println("\n╔═══════════════════════════════════════════════════════════════╗")
 	fmt.Println("║             Interactive Hub Field Mapping                     ║")
@@ -887,17 +1078,11 @@ func ApplyInteractiveMappings(proto *ProtoFile, protoPath string) error {
 	fmt.Println("║ Press Enter to accept the default (marked with *).            ║")
 	fmt.Println("╚═══════════════════════════════════════════════════════════════╝")
 
-	// Process main message fields
-	if len(proto.Messages) == 0 {
-		return nil
-	}
-
-	mainMsg := &proto.Messages[0]
 	for i := range mainMsg.Fields {
 		field := &mainMsg.Fields[i]
 
 		// Skip core Drupal fields that don't need Hub mapping
-		if isCoreDrupalField(field.Name) {
+		if !needsHubMapping(*field) {
 			continue
 		}
 
@@ -905,21 +1090,7 @@ func ApplyInteractiveMappings(proto *ProtoFile, protoPath string) error {
 		if err != nil {
 			return fmt.Errorf("mapping field %s: %w", field.Name, err)
 		}
-
-		// Apply the mapping to the field
-		field.HubSkip = mapping.Skip
-		if !mapping.Skip {
-			field.HubTarget = mapping.Target
-			field.HubDateType = mapping.DateType
-			field.HubIDType = mapping.IDType
-			field.HubRole = mapping.Role
-			field.HubSubjectVoc = mapping.SubjectVoc
-			field.HubRelType = mapping.RelType
-			field.HubExtraKey = mapping.ExtraKey
-			if mapping.Parser != "" {
-				field.Parser = mapping.Parser
-			}
-		}
+		mapping.apply(field)
 	}
 
 	// Enable hub options in output
@@ -932,7 +1103,7 @@ func ApplyInteractiveMappings(proto *ProtoFile, protoPath string) error {
 // isCoreDrupalField returns true if the field is a core Drupal field that doesn't need Hub mapping.
 func isCoreDrupalField(name string) bool {
 	switch name {
-	case "nid", "uuid", "status", "created", "changed":
+	case "nid", "uuid", "status", "created", "changed", "bundle":
 		return true
 	default:
 		return false
@@ -977,10 +1148,34 @@ func WriteProto(proto *ProtoFile, outputPath string) error {
 
 // HubAnnotation generates the hub.v1.field annotation for this field.
 func (f ProtoField) HubAnnotation() string {
-	if f.HubSkip || f.HubTarget == "" {
+	var parts []string
+	if !f.HubSkip && f.HubTarget != "" {
+		parts = append(parts, f.hubMappingOptions()...)
+	}
+
+	// XML serialization options
+	if f.Required {
+		parts = append(parts, "required: true")
+	}
+	if f.XMLName != "" {
+		parts = append(parts, fmt.Sprintf(`xml_name: "%s"`, f.XMLName))
+	}
+	if f.XMLAttr {
+		parts = append(parts, "xml_attr: true")
+	}
+	if f.XMLChardata {
+		parts = append(parts, "xml_chardata: true")
+	}
+
+	if len(parts) == 0 {
 		return ""
 	}
+	return " [(hub.v1.field) = {" + strings.Join(parts, " ") + "}]"
+}
 
+// hubMappingOptions returns the hub.v1.field options mapping the field to
+// its hub target.
+func (f ProtoField) hubMappingOptions() []string {
 	var parts []string
 	parts = append(parts, fmt.Sprintf(`target: "%s"`, f.HubTarget))
 
@@ -1006,7 +1201,30 @@ func (f ProtoField) HubAnnotation() string {
 		parts = append(parts, fmt.Sprintf(`parser: "%s"`, f.Parser))
 	}
 
-	return " [(hub.v1.field) = {" + strings.Join(parts, " ") + "}]"
+	// Transformation options, quoted since they hold separators and
+	// regular expressions
+	t := f.Transform
+	quoted := func(name, value string) {
+		if value != "" {
+			parts = append(parts, fmt.Sprintf("%s: %q", name, value))
+		}
+	}
+	quoted("split", t.Split)
+	quoted("regex_extract", t.RegexExtract)
+	for _, d := range t.Transform {
+		quoted("transform", d)
+	}
+	quoted("prefix", t.Prefix)
+	quoted("suffix", t.Suffix)
+	quoted("join", t.Join)
+
+	return parts
+}
+
+// TransformDirectives returns the field's transformation options as the
+// transform directives of a mapping profile.
+func (f ProtoField) TransformDirectives() []string {
+	return f.Transform.Directives()
 }
 
 // HasHubAnnotation returns true if this field has hub mapping.
@@ -1026,8 +1244,19 @@ import "hub/v1/options.proto";
 {{range .Messages}}
 // {{.Comment}}
 message {{.Name}} {
+{{- if and $.UseHubOptions .XMLName}}
+  option (hub.v1.message) = {
+    xml_name: "{{.XMLName}}"
+{{- if .XMLDefaultNS}}
+    xml_default_ns: "{{.XMLDefaultNS}}"
+{{- end}}
+{{- if .XMLNamespaces}}
+    xml_namespaces: [{{range $i, $ns := .XMLNamespaces}}{{if $i}}, {{end}}"{{$ns}}"{{end}}]
+{{- end}}
+  };
+{{end}}
 {{- range .Fields}}
-  {{if .Comment}}// {{.Comment}}{{if .DrupalType}} [drupal:{{.DrupalType}}]{{end}}
+  {{if .Comment}}// {{.Comment}}{{if .DrupalType}} [drupal:{{.DrupalType}}]{{end}}{{if .DSpaceField}} [dspace:{{.DSpaceField}}]{{end}}{{if .CSVColumn}} [csv{{if .Delimiter}}, delimiter "{{.Delimiter}}"{{end}}]{{end}}{{if .Bundles}} [bundles:{{range $i, $b := .Bundles}}{{if $i}},{{end}} {{$b}}{{end}}]{{end}}
   {{end}}{{.Type}} {{.Name}} = {{.Number}}{{if $.UseHubOptions}}{{.HubAnnotation}}{{end}};
 {{end -}}
 }
@@ -1045,10 +1274,19 @@ import (
 // FieldRegistry maps proto field names to their Drupal metadata.
 var FieldRegistry = map[string]spokeregistry.FieldMeta{
 {{- range .Messages}}{{if eq .Name (index $.Messages 0).Name}}
-{{- range .Fields}}{{if .DrupalField}}
+{{- range .Fields}}{{if or .DrupalField .DSpaceField .CSVColumn}}
 	"{{.Name}}": {
 		ProtoField:   "{{.Name}}",
 		DrupalField:  "{{.DrupalField}}",
+{{- if .DSpaceField}}
+		DSpaceField:  "{{.DSpaceField}}",
+{{- end}}
+{{- if .CSVColumn}}
+		CSVColumn:    {{printf "%q" .CSVColumn}},
+{{- end}}
+{{- if .Delimiter}}
+		Delimiter:    {{printf "%q" .Delimiter}},
+{{- end}}
 		DrupalType:   "{{.DrupalType}}",
 		Cardinality:  {{.Cardinality}},
 		TargetType:   "{{.TargetType}}",
@@ -1057,11 +1295,21 @@ var FieldRegistry = map[string]spokeregistry.FieldMeta{
 		HubField:     "{{.HubField}}",
 		HubType:      "{{.HubType}}",
 		Parser:       "{{.Parser}}",
+{{- with .TransformDirectives}}
+		Transform:    {{printf "%#v" .}},
+{{- end}}
+{{- if .Bundles}}
+		Bundles:      {{printf "%#v" .Bundles}},
+{{- end}}
 	},
 {{- end}}{{end}}
 {{- end}}{{end}}
 }
-{{if .FormatName}}
+{{if .Bundles}}
+// Bundles are the Drupal bundles the spoke's message covers; its bundle
+// field names the one a node belongs to.
+var Bundles = {{printf "%#v" .Bundles}}
+{{end}}{{if .FormatName}}
 func init() {
 	spokeregistry.Register("{{.FormatName}}", FieldRegistry)
 }
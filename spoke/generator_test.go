@@ -0,0 +1,89 @@
+package spoke
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// writeConfig writes a config/sync directory with two bundles sharing
+// field_linked_agent.
+func writeConfig(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		"field.storage.node.field_linked_agent.yml":                 "field_name: field_linked_agent\ntype: typed_relation\ncardinality: -1\n",
+		"field.storage.node.field_model.yml":                        "field_name: field_model\ntype: entity_reference\ncardinality: 1\n",
+		"field.storage.node.field_issue_number.yml":                 "field_name: field_issue_number\ntype: string\ncardinality: 1\n",
+		"field.field.node.islandora_object.field_linked_agent.yml":  "field_name: field_linked_agent\nbundle: islandora_object\nlabel: Contributors\nfield_type: typed_relation\n",
+		"field.field.node.islandora_object.field_model.yml":         "field_name: field_model\nbundle: islandora_object\nlabel: Model\nfield_type: entity_reference\n",
+		"field.field.node.publication_issue.field_linked_agent.yml": "field_name: field_linked_agent\nbundle: publication_issue\nlabel: Editors\nfield_type: typed_relation\n",
+		"field.field.node.publication_issue.field_issue_number.yml": "field_name: field_issue_number\nbundle: publication_issue\nlabel: Issue number\nfield_type: string\n",
+		"rdf.mapping.node.publication_issue.yml":                    "bundle: publication_issue\nfieldMappings:\n  field_issue_number:\n    properties:\n      - 'bibo:issue'\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestGenerateDrupalUnionSpoke(t *testing.T) {
+	dir := writeConfig(t)
+	proto, err := GenerateDrupalUnionSpoke("lehigh", []string{"islandora_object", "publication_issue"}, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := proto.Messages[0]
+	if msg.Name != "Lehigh" {
+		t.Errorf("message %s, want Lehigh", msg.Name)
+	}
+
+	fields := make(map[string]ProtoField)
+	numbers := make(map[int]string)
+	for _, f := range msg.Fields {
+		if other, ok := numbers[f.Number]; ok {
+			t.Errorf("fields %s and %s share number %d", other, f.Name, f.Number)
+		}
+		numbers[f.Number] = f.Name
+		fields[f.Name] = f
+	}
+	if f, ok := fields["bundle"]; !ok || f.Type != "string" {
+		t.Errorf("bundle discriminator: got %+v", f)
+	}
+	if f := fields["linked_agent"]; f.Bundles != nil || f.Comment != "Contributors" || f.Type != "repeated LinkedAgent" {
+		t.Errorf("shared field: got %+v", f)
+	}
+	if f := fields["model"]; !slices.Equal(f.Bundles, []string{"islandora_object"}) {
+		t.Errorf("model bundles: got %v", f.Bundles)
+	}
+	if f := fields["issue_number"]; !slices.Equal(f.Bundles, []string{"publication_issue"}) || f.RDFPredicate != "bibo:issue" {
+		t.Errorf("issue_number: got bundles %v, predicate %q", f.Bundles, f.RDFPredicate)
+	}
+
+	out := filepath.Join(t.TempDir(), "lehigh.proto")
+	proto.FormatName = "lehigh"
+	if err := WriteProto(proto, out); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(out)
+	if !strings.Contains(string(data), "// Model [drupal:entity_reference] [bundles: islandora_object]") {
+		t.Errorf("proto does not mark bundle-specific fields:\n%s", data)
+	}
+	meta, _ := os.ReadFile(strings.TrimSuffix(out, ".proto") + "_meta.go")
+	for _, want := range []string{
+		`var Bundles = []string{"islandora_object", "publication_issue"}`,
+		`Bundles:      []string{"publication_issue"},`,
+	} {
+		if !strings.Contains(string(meta), want) {
+			t.Errorf("meta missing %s:\n%s", want, meta)
+		}
+	}
+
+	if _, err := GenerateDrupalUnionSpoke("lehigh", []string{"islandora_object", "no_such_bundle"}, dir); err == nil {
+		t.Error("unknown bundle: want an error")
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"os"
 	"strconv"
 	"strings"
+
+	"github.com/lehigh-university-libraries/crosswalk/mapping"
 )
 
 // InteractiveMapper handles interactive field mapping prompts.
@@ -31,17 +33,90 @@ func NewInteractiveMapper(protoPath string) (*InteractiveMapper, error) {
 	}, nil
 }
 
-// FieldMapping represents the result of interactive field mapping.
+// FieldMapping represents the result of interactive field mapping. Its
+// YAML keys, used in mapping sessions, are the hub.v1.field options'.
 type FieldMapping struct {
-	Target     string // Hub target field
-	DateType   string // For date fields
-	IDType     string // For identifier fields
-	Role       string // For contributor fields
-	SubjectVoc string // For subject fields
-	RelType    string // For relation fields
-	ExtraKey   string // For extra fields
-	Parser     string // Parser to use
-	Skip       bool   // Whether to skip this field
+	Target     string `yaml:"target,omitempty"`             // Hub target field
+	DateType   string `yaml:"date_type,omitempty"`          // For date fields
+	IDType     string `yaml:"identifier_type,omitempty"`    // For identifier fields
+	Role       string `yaml:"role,omitempty"`               // For contributor fields
+	SubjectVoc string `yaml:"subject_vocabulary,omitempty"` // For subject fields
+	RelType    string `yaml:"relation_type,omitempty"`      // For relation fields
+	ExtraKey   string `yaml:"extra_key,omitempty"`          // For extra fields
+	Parser     string `yaml:"parser,omitempty"`             // Parser to use
+	Skip       bool   `yaml:"skip,omitempty"`               // Whether to skip this field
+
+	// Transform is the cleanup of the field's values
+	Transform mapping.TransformOptions `yaml:",inline"`
+}
+
+// apply sets the field's Hub* annotation fields from the mapping.
+func (m FieldMapping) apply(field *ProtoField) {
+	field.HubSkip = m.Skip
+	if m.Skip {
+		return
+	}
+	field.HubTarget = m.Target
+	field.HubDateType = m.DateType
+	field.HubIDType = m.IDType
+	field.HubRole = m.Role
+	field.HubSubjectVoc = m.SubjectVoc
+	field.HubRelType = m.RelType
+	field.HubExtraKey = m.ExtraKey
+	field.Transform = m.Transform
+	if m.Parser != "" {
+		field.Parser = m.Parser
+	}
+}
+
+// mappingOf returns the mapping a field's Hub* annotation fields hold.
+func mappingOf(field ProtoField) FieldMapping {
+	return FieldMapping{
+		Target:     field.HubTarget,
+		DateType:   field.HubDateType,
+		IDType:     field.HubIDType,
+		Role:       field.HubRole,
+		SubjectVoc: field.HubSubjectVoc,
+		RelType:    field.HubRelType,
+		ExtraKey:   field.HubExtraKey,
+		Parser:     field.Parser,
+		Skip:       field.HubSkip,
+		Transform:  field.Transform,
+	}
+}
+
+// mappingFromExisting returns the mapping a previously generated proto had.
+func mappingFromExisting(e ExistingMapping) FieldMapping {
+	return FieldMapping{
+		Target:     e.Target,
+		DateType:   e.DateType,
+		IDType:     e.IDType,
+		Role:       e.Role,
+		SubjectVoc: e.SubjectVoc,
+		RelType:    e.RelType,
+		Parser:     e.Parser,
+		Transform:  e.Transform,
+	}
+}
+
+// existing returns the mapping as the prompts' defaults, as though read
+// from a proto.
+func (m FieldMapping) existing(fieldName string) ExistingMapping {
+	e := ExistingMapping{
+		FieldName:  fieldName,
+		Target:     m.Target,
+		DateType:   m.DateType,
+		IDType:     m.IDType,
+		SubjectVoc: m.SubjectVoc,
+		Role:       m.Role,
+		RelType:    m.RelType,
+		Parser:     m.Parser,
+		Transform:  m.Transform,
+	}
+	if m.Skip {
+		e.Target = "(skip)"
+	}
+	return e
 }
 
 // MapField prompts the user to map a single field to the Hub schema.
@@ -63,12 +138,12 @@ func (im *InteractiveMapper) MapField(field ProtoField) (*FieldMapping, error) {
 	existing := im.existing[field.Name]
 
 	// Also check the auto-detected mapping from the generator
-	var suggested string
-	if field.HubField != "" {
-		suggested = field.HubField
-	}
+	guess := field
+	autoMapField(&guess)
+	suggested := guess.HubTarget
 
-	mapping := &FieldMapping{}
+	// The field's cleanup stays whatever target it's given
+	mapping := &FieldMapping{Transform: existing.Transform}
 
 	// Select target
 	target, err := im.selectTarget(existing, suggested)
@@ -199,30 +274,8 @@ func (im *InteractiveMapper) selectIdentifierType(existing ExistingMapping, fiel
 	idTypes := GetIdentifierTypes()
 	fmt.Println("\nSelect identifier type:")
 
-	// Try to guess from field name
 	defaultChoice := 1
-	suggested := ""
-	switch {
-	case strings.Contains(field.Name, "doi"):
-		suggested = "doi"
-	case strings.Contains(field.Name, "isbn"):
-		suggested = "isbn"
-	case strings.Contains(field.Name, "issn"):
-		suggested = "issn"
-	case strings.Contains(field.Name, "orcid"):
-		suggested = "orcid"
-	case strings.Contains(field.Name, "handle"):
-		suggested = "handle"
-	case strings.Contains(field.Name, "oclc"):
-		suggested = "oclc"
-	case strings.Contains(field.Name, "local"):
-		suggested = "local"
-	case strings.Contains(field.Name, "pid"):
-		suggested = "local"
-	case strings.Contains(field.Name, "url"):
-		suggested = "url"
-	}
-
+	suggested := suggestIdentifierType(field.Name)
 	if existing.IDType != "" {
 		suggested = existing.IDType
 	}
@@ -245,6 +298,29 @@ func (im *InteractiveMapper) selectIdentifierType(existing ExistingMapping, fiel
 	return idTypes[choice-1], nil
 }
 
+// suggestIdentifierType guesses an identifier field's type from its name.
+func suggestIdentifierType(name string) string {
+	switch {
+	case strings.Contains(name, "doi"):
+		return "doi"
+	case strings.Contains(name, "isbn"):
+		return "isbn"
+	case strings.Contains(name, "issn"):
+		return "issn"
+	case strings.Contains(name, "orcid"):
+		return "orcid"
+	case strings.Contains(name, "handle"):
+		return "handle"
+	case strings.Contains(name, "oclc"):
+		return "oclc"
+	case strings.Contains(name, "local"), strings.Contains(name, "pid"):
+		return "local"
+	case strings.Contains(name, "url"):
+		return "url"
+	}
+	return ""
+}
+
 // selectContributorRole prompts for contributor role selection.
 func (im *InteractiveMapper) selectContributorRole(existing ExistingMapping) (string, error) {
 	roles := GetContributorRoles()
@@ -282,24 +358,8 @@ func (im *InteractiveMapper) selectSubjectVocabulary(existing ExistingMapping, f
 	vocabs := GetSubjectVocabularies()
 	fmt.Println("\nSelect subject vocabulary:")
 
-	// Try to guess from field name
 	defaultChoice := 1
-	suggested := ""
-	switch {
-	case strings.Contains(field.Name, "geographic"):
-		suggested = "geographic"
-	case strings.Contains(field.Name, "temporal"):
-		suggested = "temporal"
-	case strings.Contains(field.Name, "name"):
-		suggested = "name"
-	case strings.Contains(field.Name, "genre"):
-		suggested = "genre"
-	case strings.Contains(field.Name, "lcsh"):
-		suggested = "lcsh"
-	case strings.Contains(field.Name, "mesh"):
-		suggested = "mesh"
-	}
-
+	suggested := suggestSubjectVocabulary(field.Name)
 	if existing.SubjectVoc != "" {
 		suggested = existing.SubjectVoc
 	}
@@ -322,6 +382,26 @@ func (im *InteractiveMapper) selectSubjectVocabulary(existing ExistingMapping, f
 	return vocabs[choice-1], nil
 }
 
+// suggestSubjectVocabulary guesses a subject field's vocabulary from its
+// name.
+func suggestSubjectVocabulary(name string) string {
+	switch {
+	case strings.Contains(name, "geographic"):
+		return "geographic"
+	case strings.Contains(name, "temporal"):
+		return "temporal"
+	case strings.Contains(name, "name"):
+		return "name"
+	case strings.Contains(name, "genre"):
+		return "genre"
+	case strings.Contains(name, "lcsh"):
+		return "lcsh"
+	case strings.Contains(name, "mesh"):
+		return "mesh"
+	}
+	return ""
+}
+
 // selectRelationType prompts for relation type selection.
 func (im *InteractiveMapper) selectRelationType(existing ExistingMapping) (string, error) {
 	relTypes := GetRelationTypes()
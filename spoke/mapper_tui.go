@@ -0,0 +1,797 @@
+package spoke
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// errMappingCanceled is returned when the mapper is quit without writing.
+var errMappingCanceled = errors.New("mapping canceled")
+
+// mapScreen is what the mapper shows.
+type mapScreen int
+
+const (
+	screenFields   mapScreen = iota // the field list
+	screenTargets                   // picking a hub target
+	screenOptions                   // picking a target's type, role, vocabulary, or parser
+	screenExtraKey                  // entering an extra field's key
+	screenSummary                   // reviewing the mappings before writing
+)
+
+// mapperField is a field in the mapper with its mapping as it stands.
+type mapperField struct {
+	field   ProtoField
+	mapping FieldMapping
+	// mapped reports whether the field has a mapping (or is skipped), and
+	// suggested whether it's the generator's guess, not yet confirmed
+	mapped    bool
+	suggested bool
+	selected  bool
+}
+
+// undoStep is one change the undo stack reverts: the fields it touched as
+// they were before it.
+type undoStep struct {
+	indices []int
+	before  []mapperField
+	label   string
+}
+
+// optionStep is one of the choices a target asks for after it's picked,
+// such as a date's type and parser.
+type optionStep struct {
+	title   string
+	choices []string
+	def     string
+	set     func(m *FieldMapping, choice string)
+}
+
+// pickerItem is a choice in the target or option picker.
+type pickerItem struct {
+	name, description string
+}
+
+var (
+	cursorStyle = lipgloss.NewStyle().Reverse(true)
+	titleStyle  = lipgloss.NewStyle().Bold(true)
+	faintStyle  = lipgloss.NewStyle().Faint(true)
+)
+
+// mappingTUI is the full-screen mapper: a field list to move through,
+// search, and select fields in, with pickers for a target and its
+// options, and a summary to confirm before the proto is written.
+type mappingTUI struct {
+	fields  []mapperField
+	targets []HubTarget
+	screen  mapScreen
+
+	cursor    int    // position in the visible fields
+	filter    string // field names shown, typed after /
+	filtering bool
+
+	// The assignment in progress: the fields it's for, the mapping so far,
+	// and the option pickers still to come
+	assignTo []int
+	pending  FieldMapping
+	steps    []optionStep
+
+	query  string // search in the current picker
+	choice int    // position in the picker's matches
+	input  string // the extra key being typed
+
+	undo      []undoStep
+	status    string
+	height    int
+	offset    int // first line of the summary shown
+	confirmed bool
+}
+
+// newMappingTUI returns a mapper for the fields of msg that are mapped to
+// the hub, starting each from its mapping in start (from the proto being
+// regenerated or a mapping session), else the generator's guess. A field
+// with an empty mapping in start stays unmapped.
+func newMappingTUI(msg *ProtoMessage, start map[string]FieldMapping) *mappingTUI {
+	m := &mappingTUI{targets: GetHubTargets()}
+	for _, f := range msg.Fields {
+		if !needsHubMapping(f) {
+			continue
+		}
+		mf := mapperField{field: f}
+		if mapping, ok := start[f.Name]; ok {
+			mf.mapping, mf.mapped = mapping, mapping.Target != "" || mapping.Skip
+		} else {
+			guess := f
+			autoMapField(&guess)
+			if guess.HubTarget != "" {
+				mf.mapping, mf.mapped, mf.suggested = mappingOf(guess), true, true
+			}
+		}
+		m.fields = append(m.fields, mf)
+	}
+	return m
+}
+
+// runMappingTUI runs the mapper on msg's fields and, once the summary is
+// confirmed, applies the mappings. Fields left unmapped get no annotation.
+func runMappingTUI(msg *ProtoMessage, start map[string]FieldMapping) error {
+	m := newMappingTUI(msg, start)
+	if len(m.fields) == 0 {
+		return nil
+	}
+	final, err := tea.NewProgram(m, tea.WithAltScreen()).Run()
+	if err != nil {
+		return err
+	}
+	if m = final.(*mappingTUI); !m.confirmed {
+		return errMappingCanceled
+	}
+	m.apply(msg)
+	return nil
+}
+
+// apply sets the mapped fields' Hub* annotation fields in msg.
+func (m *mappingTUI) apply(msg *ProtoMessage) {
+	byName := make(map[string]mapperField, len(m.fields))
+	for _, f := range m.fields {
+		byName[f.field.Name] = f
+	}
+	for i := range msg.Fields {
+		if f, ok := byName[msg.Fields[i].Name]; ok && f.mapped {
+			f.mapping.apply(&msg.Fields[i])
+		}
+	}
+}
+
+// isTerminal reports whether f is a terminal rather than a pipe or file.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// Init implements tea.Model.
+func (m *mappingTUI) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements tea.Model.
+func (m *mappingTUI) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.height = msg.Height
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyCtrlC {
+			return m, tea.Quit
+		}
+		// Keys typed faster than they're read arrive together
+		if msg.Type == tea.KeyRunes && len(msg.Runes) > 1 {
+			var cmds []tea.Cmd
+			for _, r := range msg.Runes {
+				_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+				cmds = append(cmds, cmd)
+			}
+			return m, tea.Sequence(cmds...)
+		}
+		m.status = ""
+		switch m.screen {
+		case screenFields:
+			return m, m.updateFields(msg)
+		case screenTargets, screenOptions:
+			m.updatePicker(msg)
+		case screenExtraKey:
+			m.updateExtraKey(msg)
+		case screenSummary:
+			return m, m.updateSummary(msg)
+		}
+	}
+	return m, nil
+}
+
+// visible returns the indices of the fields the filter shows.
+func (m *mappingTUI) visible() []int {
+	var shown []int
+	for i, f := range m.fields {
+		if _, ok := fuzzyScore(m.filter, f.field.Name); ok {
+			shown = append(shown, i)
+		}
+	}
+	return shown
+}
+
+// current returns the index of the field under the cursor, or -1.
+func (m *mappingTUI) current() int {
+	shown := m.visible()
+	if len(shown) == 0 {
+		return -1
+	}
+	m.cursor = min(max(m.cursor, 0), len(shown)-1)
+	return shown[m.cursor]
+}
+
+// targetsOf returns the fields an action applies to: those selected, or
+// else the one under the cursor.
+func (m *mappingTUI) targetsOf() []int {
+	var selected []int
+	for i, f := range m.fields {
+		if f.selected {
+			selected = append(selected, i)
+		}
+	}
+	if len(selected) > 0 {
+		return selected
+	}
+	if i := m.current(); i >= 0 {
+		return []int{i}
+	}
+	return nil
+}
+
+func (m *mappingTUI) updateFields(msg tea.KeyMsg) tea.Cmd {
+	if m.filtering {
+		switch msg.Type {
+		case tea.KeyEnter:
+			m.filtering = false
+		case tea.KeyEsc:
+			m.filtering, m.filter = false, ""
+		case tea.KeyBackspace:
+			m.filter = dropLastRune(m.filter)
+		case tea.KeyRunes, tea.KeySpace:
+			m.filter += string(msg.Runes)
+		}
+		m.cursor = 0
+		return nil
+	}
+
+	page := max(m.listHeight()-1, 1)
+	switch msg.String() {
+	case "up", "k":
+		m.cursor--
+	case "down", "j":
+		m.cursor++
+	case "pgup":
+		m.cursor -= page
+	case "pgdown":
+		m.cursor += page
+	case "home", "g":
+		m.cursor = 0
+	case "end", "G":
+		m.cursor = len(m.fields)
+	case "/":
+		m.filtering = true
+	case "esc":
+		m.filter = ""
+		m.clearSelection()
+	case " ":
+		if i := m.current(); i >= 0 {
+			m.fields[i].selected = !m.fields[i].selected
+			m.cursor++
+		}
+	case "s":
+		if i := m.current(); i >= 0 {
+			similar := m.similarFields(i)
+			for _, j := range similar {
+				m.fields[j].selected = true
+			}
+			m.status = fmt.Sprintf("Selected %d fields like %s", len(similar), m.fields[i].field.Name)
+		}
+	case "enter":
+		if to := m.targetsOf(); len(to) > 0 {
+			m.assignTo = to
+			m.pending = FieldMapping{}
+			m.openPicker(screenTargets, m.fields[to[0]].mapping.Target)
+		}
+	case "a":
+		// Accept suggestions as they are
+		var accepted []int
+		for _, i := range m.targetsOf() {
+			if m.fields[i].suggested {
+				accepted = append(accepted, i)
+			}
+		}
+		if len(accepted) > 0 {
+			m.change(accepted, "accept", func(f *mapperField) { f.suggested = false })
+		}
+	case "x":
+		if to := m.targetsOf(); len(to) > 0 {
+			m.change(to, "skip", func(f *mapperField) {
+				f.mapping, f.mapped, f.suggested = FieldMapping{Skip: true}, true, false
+			})
+		}
+	case "c":
+		if to := m.targetsOf(); len(to) > 0 {
+			m.change(to, "clear", func(f *mapperField) {
+				f.mapping, f.mapped, f.suggested = FieldMapping{}, false, false
+			})
+		}
+	case "u":
+		m.undoLast()
+	case "w", "tab":
+		m.screen, m.offset = screenSummary, 0
+	case "q":
+		return tea.Quit
+	}
+	m.current()
+	return nil
+}
+
+// similarFields returns the fields named like field i: those whose names
+// start with its name but for the last word, ignoring the field_ prefix
+// and a DSpace field's schema. For field_date_issued that's every
+// field_date_* field, and field_date itself.
+func (m *mappingTUI) similarFields(i int) []int {
+	words := nameWords(m.fields[i].field)
+	stem := words[:max(len(words)-1, 1)]
+	var similar []int
+	for j, f := range m.fields {
+		w := nameWords(f.field)
+		if len(w) >= len(stem) && strings.Join(w[:len(stem)], "_") == strings.Join(stem, "_") {
+			similar = append(similar, j)
+		}
+	}
+	return similar
+}
+
+// nameWords splits a field name into words, without the field_ prefix
+// Drupal names have or the schema DSpace ones start with.
+func nameWords(f ProtoField) []string {
+	words := strings.Split(strings.TrimPrefix(f.Name, "field_"), "_")
+	if f.DSpaceField != "" && len(words) > 1 {
+		words = words[1:]
+	}
+	return words
+}
+
+func (m *mappingTUI) clearSelection() {
+	for i := range m.fields {
+		m.fields[i].selected = false
+	}
+}
+
+// change applies edit to the fields at indices, recording the step so it
+// can be undone, and clears the selection.
+func (m *mappingTUI) change(indices []int, label string, edit func(f *mapperField)) {
+	step := undoStep{indices: indices, label: label}
+	for _, i := range indices {
+		before := m.fields[i]
+		before.selected = false
+		step.before = append(step.before, before)
+		edit(&m.fields[i])
+	}
+	m.undo = append(m.undo, step)
+	m.clearSelection()
+	m.status = fmt.Sprintf("%s: %s", label, m.describeFields(indices))
+}
+
+func (m *mappingTUI) undoLast() {
+	if len(m.undo) == 0 {
+		m.status = "Nothing to undo"
+		return
+	}
+	step := m.undo[len(m.undo)-1]
+	m.undo = m.undo[:len(m.undo)-1]
+	for k, i := range step.indices {
+		m.fields[i] = step.before[k]
+	}
+	m.status = fmt.Sprintf("Undid %s: %s", step.label, m.describeFields(step.indices))
+}
+
+// describeFields names the fields at indices, or counts them when there
+// are many.
+func (m *mappingTUI) describeFields(indices []int) string {
+	if len(indices) > 3 {
+		return fmt.Sprintf("%d fields", len(indices))
+	}
+	var names []string
+	for _, i := range indices {
+		names = append(names, m.fields[i].field.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+// openPicker shows the target picker, or the next option picker, with the
+// cursor on def.
+func (m *mappingTUI) openPicker(screen mapScreen, def string) {
+	m.screen, m.query, m.choice = screen, "", 0
+	for k, item := range m.pickerItems() {
+		if item.name == def {
+			m.choice = k
+		}
+	}
+}
+
+// pickerItems returns the current picker's choices matching the search,
+// best first.
+func (m *mappingTUI) pickerItems() []pickerItem {
+	var items []pickerItem
+	if m.screen == screenTargets {
+		for _, t := range m.targets {
+			items = append(items, pickerItem{t.Name, t.Description})
+		}
+	} else if len(m.steps) > 0 {
+		for _, c := range m.steps[0].choices {
+			items = append(items, pickerItem{name: c})
+		}
+	}
+	if m.query == "" {
+		return items
+	}
+
+	type scored struct {
+		item  pickerItem
+		score int
+	}
+	var matches []scored
+	for _, item := range items {
+		score, ok := fuzzyScore(m.query, item.name)
+		// A description match counts for less than a name match
+		if ds, dok := fuzzyScore(m.query, item.description); dok && (!ok || ds/2 > score) {
+			score, ok = ds/2, true
+		}
+		if ok {
+			matches = append(matches, scored{item, score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].score > matches[j].score })
+	items = items[:0]
+	for _, s := range matches {
+		items = append(items, s.item)
+	}
+	return items
+}
+
+func (m *mappingTUI) updatePicker(msg tea.KeyMsg) {
+	items := m.pickerItems()
+	switch msg.Type {
+	case tea.KeyUp:
+		m.choice--
+	case tea.KeyDown:
+		m.choice++
+	case tea.KeyEsc:
+		m.screen, m.steps = screenFields, nil
+		return
+	case tea.KeyBackspace:
+		m.query, m.choice = dropLastRune(m.query), 0
+	case tea.KeyRunes, tea.KeySpace:
+		m.query, m.choice = m.query+string(msg.Runes), 0
+	case tea.KeyEnter:
+		if len(items) == 0 {
+			return
+		}
+		m.choice = min(max(m.choice, 0), len(items)-1)
+		m.pick(items[m.choice].name)
+		return
+	}
+	m.choice = min(max(m.choice, 0), max(len(items)-1, 0))
+}
+
+// pick records the choice made in the current picker and moves on to the
+// next one, the extra key, or, when the mapping is complete, assigns it.
+func (m *mappingTUI) pick(choice string) {
+	if m.screen == screenTargets {
+		m.pending = FieldMapping{Target: choice}
+		m.steps = m.optionSteps(choice)
+	} else {
+		m.steps[0].set(&m.pending, choice)
+		m.steps = m.steps[1:]
+	}
+
+	switch {
+	case len(m.steps) > 0:
+		m.openPicker(screenOptions, m.steps[0].def)
+	case m.pending.Target == "extra" && len(m.assignTo) == 1:
+		m.screen = screenExtraKey
+		m.input = m.fields[m.assignTo[0]].mapping.ExtraKey
+		if m.input == "" {
+			m.input = m.fields[m.assignTo[0]].field.Name
+		}
+	default:
+		m.assign()
+	}
+}
+
+// optionSteps returns the pickers a target asks for after it's chosen,
+// defaulting to the first field's mapping or the guess from its name.
+func (m *mappingTUI) optionSteps(target string) []optionStep {
+	first := m.fields[m.assignTo[0]]
+	prior := first.mapping
+	orDefault := func(v, def string) string {
+		if v != "" {
+			return v
+		}
+		return def
+	}
+
+	switch m.targetType(target) {
+	case TargetDate:
+		return []optionStep{
+			{"Date type", GetDateTypes(), orDefault(prior.DateType, "issued"), func(fm *FieldMapping, c string) { fm.DateType = c }},
+			{"Parser", GetParsers(), orDefault(prior.Parser, "edtf"), func(fm *FieldMapping, c string) { fm.Parser = c }},
+		}
+	case TargetIdentifier:
+		return []optionStep{{"Identifier type", GetIdentifierTypes(), orDefault(prior.IDType, suggestIdentifierType(first.field.Name)), func(fm *FieldMapping, c string) { fm.IDType = c }}}
+	case TargetContributor:
+		return []optionStep{{"Contributor role", GetContributorRoles(), orDefault(prior.Role, "author"), func(fm *FieldMapping, c string) { fm.Role = c }}}
+	case TargetSubject:
+		return []optionStep{{"Subject vocabulary", GetSubjectVocabularies(), orDefault(prior.SubjectVoc, suggestSubjectVocabulary(first.field.Name)), func(fm *FieldMapping, c string) { fm.SubjectVoc = c }}}
+	case TargetRelation:
+		return []optionStep{{"Relation type", GetRelationTypes(), orDefault(prior.RelType, "member_of"), func(fm *FieldMapping, c string) { fm.RelType = c }}}
+	}
+	return nil
+}
+
+func (m *mappingTUI) targetType(name string) HubTargetType {
+	for _, t := range m.targets {
+		if t.Name == name {
+			return t.Type
+		}
+	}
+	return TargetSimple
+}
+
+func (m *mappingTUI) updateExtraKey(msg tea.KeyMsg) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.screen = screenFields
+	case tea.KeyBackspace:
+		m.input = dropLastRune(m.input)
+	case tea.KeyRunes:
+		m.input += string(msg.Runes)
+	case tea.KeyEnter:
+		if m.input = strings.TrimSpace(m.input); m.input == "" {
+			m.input = m.fields[m.assignTo[0]].field.Name
+		}
+		m.pending.ExtraKey = m.input
+		m.assign()
+	}
+}
+
+// assign gives the pending mapping to the fields being assigned. Several
+// fields mapped to extra each keep their own name as the key, and
+// contributor fields holding typed relations get the relator parser.
+func (m *mappingTUI) assign() {
+	pending, label := m.pending, "map to "+m.pending.Target
+	if pending.Target == "(skip)" {
+		pending, label = FieldMapping{Skip: true}, "skip"
+	}
+	m.change(m.assignTo, label, func(f *mapperField) {
+		mapping := pending
+		if mapping.Target == "extra" && mapping.ExtraKey == "" {
+			mapping.ExtraKey = f.field.Name
+		}
+		if !mapping.Skip {
+			mapping.Transform = f.mapping.Transform
+		}
+		if mapping.Target == "contributors" && (strings.Contains(f.field.Type, "LinkedAgent") || f.field.DrupalType == "typed_relation") {
+			mapping.Parser = "relator"
+		}
+		f.mapping, f.mapped, f.suggested = mapping, true, false
+	})
+	m.screen, m.assignTo, m.steps = screenFields, nil, nil
+}
+
+func (m *mappingTUI) updateSummary(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "enter", "y":
+		m.confirmed = true
+		return tea.Quit
+	case "esc", "n", "w", "tab":
+		m.screen = screenFields
+	case "up", "k":
+		m.offset = max(m.offset-1, 0)
+	case "down", "j":
+		m.offset = min(m.offset+1, max(len(m.fields)-1, 0))
+	case "q":
+		return tea.Quit
+	}
+	return nil
+}
+
+// listHeight is how many lines of a list fit between the header and help.
+func (m *mappingTUI) listHeight() int {
+	if m.height == 0 {
+		return 20
+	}
+	return max(m.height-6, 3)
+}
+
+// View implements tea.Model.
+func (m *mappingTUI) View() string {
+	var b strings.Builder
+	mapped, skipped, suggested := m.counts()
+	fmt.Fprintf(&b, "%s  %d fields: %d mapped (%d suggested), %d skipped, %d unmapped\n\n",
+		titleStyle.Render("Map fields to the hub"), len(m.fields), mapped, suggested, skipped, len(m.fields)-mapped-skipped)
+
+	switch m.screen {
+	case screenFields:
+		m.viewFields(&b)
+	case screenTargets, screenOptions:
+		m.viewPicker(&b)
+	case screenExtraKey:
+		fmt.Fprintf(&b, "Key for %s in extra: %s█\n\n", m.fields[m.assignTo[0]].field.Name, m.input)
+		b.WriteString(faintStyle.Render("enter: done  esc: cancel"))
+	case screenSummary:
+		m.viewSummary(&b)
+	}
+	return b.String()
+}
+
+// counts returns how many fields are mapped, skipped, and mapped only by
+// suggestion.
+func (m *mappingTUI) counts() (mapped, skipped, suggested int) {
+	for _, f := range m.fields {
+		switch {
+		case f.mapped && f.mapping.Skip:
+			skipped++
+		case f.mapped:
+			mapped++
+			if f.suggested {
+				suggested++
+			}
+		}
+	}
+	return mapped, skipped, suggested
+}
+
+func (m *mappingTUI) viewFields(b *strings.Builder) {
+	shown := m.visible()
+	cur := m.current()
+	height := m.listHeight()
+	start := 0
+	if m.cursor >= height {
+		start = m.cursor - height + 1
+	}
+	width := 0
+	for _, f := range m.fields {
+		width = max(width, len(f.field.Name))
+	}
+
+	for k := start; k < len(shown) && k < start+height; k++ {
+		f := m.fields[shown[k]]
+		mark := "[ ]"
+		if f.selected {
+			mark = "[x]"
+		}
+		line := fmt.Sprintf("%s %-*s  %s", mark, width, f.field.Name, describeMapping(f))
+		if shown[k] == cur {
+			line = cursorStyle.Render(line)
+		}
+		b.WriteString(line + "\n")
+	}
+	if len(shown) == 0 {
+		b.WriteString(faintStyle.Render("No fields match") + "\n")
+	}
+
+	b.WriteString("\n")
+	switch {
+	case m.filtering:
+		fmt.Fprintf(b, "/%s█\n", m.filter)
+	case m.status != "":
+		b.WriteString(m.status + "\n")
+	case cur >= 0:
+		b.WriteString(faintStyle.Render(fieldDetails(m.fields[cur].field)) + "\n")
+	}
+	b.WriteString(faintStyle.Render("enter: map  space: select  s: select similar  a: accept suggestion  x: skip  c: clear  u: undo  /: search  w: review  q: quit"))
+}
+
+// describeMapping is a field's mapping as the mapper lists it.
+func describeMapping(f mapperField) string {
+	switch {
+	case !f.mapped:
+		return faintStyle.Render("(unmapped)")
+	case f.mapping.Skip:
+		return "(skip)"
+	}
+	s := "→ " + f.mapping.Target
+	var opts []string
+	for _, o := range []string{f.mapping.DateType, f.mapping.IDType, f.mapping.Role, f.mapping.SubjectVoc, f.mapping.RelType, f.mapping.ExtraKey, f.mapping.Parser} {
+		if o != "" {
+			opts = append(opts, o)
+		}
+	}
+	if len(opts) > 0 {
+		s += " (" + strings.Join(opts, ", ") + ")"
+	}
+	if f.suggested {
+		s += " " + faintStyle.Render("suggested")
+	}
+	return s
+}
+
+// fieldDetails describes a field's type and source for the status line.
+func fieldDetails(f ProtoField) string {
+	details := []string{f.Type}
+	for _, d := range []string{f.DrupalType, f.DSpaceField, f.RDFPredicate, f.Comment} {
+		if d != "" && d != f.Name {
+			details = append(details, d)
+		}
+	}
+	return strings.Join(details, " · ")
+}
+
+func (m *mappingTUI) viewPicker(b *strings.Builder) {
+	title := "Hub target"
+	if m.screen == screenOptions && len(m.steps) > 0 {
+		title = m.steps[0].title
+	}
+	fmt.Fprintf(b, "%s for %s\n", titleStyle.Render(title), m.describeFields(m.assignTo))
+	fmt.Fprintf(b, "Search: %s█\n\n", m.query)
+
+	items := m.pickerItems()
+	height := m.listHeight() - 2
+	start := max(m.choice-height+1, 0)
+	for k := start; k < len(items) && k < start+height; k++ {
+		line := fmt.Sprintf("  %-18s %s", items[k].name, items[k].description)
+		if k == m.choice {
+			line = cursorStyle.Render(line)
+		}
+		b.WriteString(line + "\n")
+	}
+	if len(items) == 0 {
+		b.WriteString(faintStyle.Render("  No matches") + "\n")
+	}
+	b.WriteString("\n" + faintStyle.Render("type to search  ↑/↓: move  enter: choose  esc: cancel"))
+}
+
+func (m *mappingTUI) viewSummary(b *strings.Builder) {
+	b.WriteString(titleStyle.Render("Review before writing") + "\n\n")
+	width := 0
+	for _, f := range m.fields {
+		width = max(width, len(f.field.Name))
+	}
+	height := m.listHeight() - 2
+	for k := m.offset; k < len(m.fields) && k < m.offset+height; k++ {
+		fmt.Fprintf(b, "  %-*s  %s\n", width, m.fields[k].field.Name, describeMapping(m.fields[k]))
+	}
+	b.WriteString("\n" + faintStyle.Render("enter: write the proto  esc: back to fields  q: quit without writing"))
+}
+
+// fuzzyScore reports whether every character of query appears in s in
+// order, ignoring case, and scores the match: higher for characters that
+// follow one another or start a word, lower for characters skipped.
+func fuzzyScore(query, s string) (int, bool) {
+	if query == "" {
+		return 0, true
+	}
+	q := []rune(strings.ToLower(query))
+	text := []rune(s)
+	score, qi, last := 0, 0, -1
+	for i, r := range text {
+		if qi == len(q) {
+			break
+		}
+		if unicode.ToLower(r) != q[qi] {
+			continue
+		}
+		score++
+		switch {
+		case last == i-1:
+			score += 3
+		case i == 0 || !unicode.IsLetter(text[i-1]) && !unicode.IsDigit(text[i-1]):
+			score += 2
+		default:
+			score -= min(i-last-1, 3)
+		}
+		last = i
+		qi++
+	}
+	if qi < len(q) {
+		return 0, false
+	}
+	return score, true
+}
+
+// dropLastRune removes the last character of s.
+func dropLastRune(s string) string {
+	r := []rune(s)
+	if len(r) == 0 {
+		return s
+	}
+	return string(r[:len(r)-1])
+}
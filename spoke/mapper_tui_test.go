@@ -0,0 +1,185 @@
+package spoke
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// press sends the mapper keys: named ones like "enter" and "esc", and
+// anything else typed as text.
+func press(m *mappingTUI, keys ...string) {
+	named := map[string]tea.KeyType{
+		"enter": tea.KeyEnter, "esc": tea.KeyEsc, "backspace": tea.KeyBackspace,
+		"up": tea.KeyUp, "down": tea.KeyDown, "tab": tea.KeyTab, "space": tea.KeySpace,
+	}
+	for _, k := range keys {
+		if t, ok := named[k]; ok {
+			msg := tea.KeyMsg{Type: t}
+			if t == tea.KeySpace {
+				msg.Runes = []rune{' '}
+			}
+			m.Update(msg)
+			continue
+		}
+		for _, r := range k {
+			m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		}
+	}
+}
+
+func mapperMessage() *ProtoMessage {
+	return &ProtoMessage{
+		Name: "Node",
+		Fields: []ProtoField{
+			{Name: "nid", Type: "int64", Number: 1},
+			{Name: "title", Type: "string", Number: 2, HubField: "Title"},
+			{Name: "field_date_issued", Type: "string", Number: 3},
+			{Name: "field_date_created", Type: "string", Number: 4},
+			{Name: "field_date_captured", Type: "string", Number: 5},
+			{Name: "field_linked_agent", Type: "repeated LinkedAgent", Number: 6, DrupalType: "typed_relation"},
+			{Name: "field_model", Type: "string", Number: 7},
+		},
+	}
+}
+
+func TestMappingTUIStart(t *testing.T) {
+	m := newMappingTUI(mapperMessage(), map[string]FieldMapping{
+		"field_model":         {Target: "resource_type"},
+		"field_date_captured": {Skip: true},
+		"title":               {},
+	})
+	var names []string
+	for _, f := range m.fields {
+		names = append(names, f.field.Name)
+	}
+	// Core Drupal fields aren't mapped
+	if got := strings.Join(names, " "); got != "title field_date_issued field_date_created field_date_captured field_linked_agent field_model" {
+		t.Errorf("fields %s", got)
+	}
+	// Left unmapped, title isn't given the guess
+	if f := m.fields[0]; f.mapped || f.suggested {
+		t.Errorf("title starts %+v", f)
+	}
+	if f := m.fields[3]; !f.mapped || !f.mapping.Skip {
+		t.Errorf("field_date_captured starts %+v", f)
+	}
+
+	if f := m.fields[5]; !f.mapped || f.suggested || f.mapping.Target != "resource_type" {
+		t.Errorf("field_model starts %+v", f)
+	}
+	if f := m.fields[1]; f.mapped {
+		t.Errorf("field_date_issued starts mapped: %+v", f)
+	}
+}
+
+func TestMappingTUIBulkAssign(t *testing.T) {
+	msg := mapperMessage()
+	m := newMappingTUI(msg, nil)
+
+	// Select the date fields like field_date_issued, and map them all
+	press(m, "j", "s")
+	var selected []string
+	for _, f := range m.fields {
+		if f.selected {
+			selected = append(selected, f.field.Name)
+		}
+	}
+	if got := strings.Join(selected, " "); got != "field_date_issued field_date_created field_date_captured" {
+		t.Fatalf("similar fields %s", got)
+	}
+	press(m, "enter", "dat")
+	if items := m.pickerItems(); len(items) == 0 || items[0].name != "dates" {
+		t.Fatalf("search for dat found %v", items)
+	}
+	press(m, "enter")
+	if m.screen != screenOptions || m.steps[0].title != "Date type" {
+		t.Fatalf("after picking dates, screen %d", m.screen)
+	}
+	press(m, "crea", "enter", "enter")
+	if m.screen != screenFields {
+		t.Fatalf("after the parser, screen %d", m.screen)
+	}
+	for _, f := range m.fields[1:4] {
+		if want := (FieldMapping{Target: "dates", DateType: "created", Parser: "edtf"}); !reflect.DeepEqual(f.mapping, want) || f.selected {
+			t.Errorf("%s mapped %+v", f.field.Name, f.mapping)
+		}
+	}
+
+	// The agent field gets the relator parser with its role
+	press(m, "G", "k", "enter", "contrib", "enter", "editor", "enter")
+	if want := (FieldMapping{Target: "contributors", Role: "editor", Parser: "relator"}); !reflect.DeepEqual(m.fields[4].mapping, want) {
+		t.Errorf("field_linked_agent mapped %+v", m.fields[4].mapping)
+	}
+
+	// Undo reverts the contributor mapping, and then the bulk date one
+	press(m, "u")
+	if m.fields[4].mapped {
+		t.Errorf("undo left field_linked_agent %+v", m.fields[4].mapping)
+	}
+	press(m, "u")
+	for _, f := range m.fields[1:4] {
+		if f.mapped || f.selected {
+			t.Errorf("undo left %s %+v", f.field.Name, f)
+		}
+	}
+	press(m, "u")
+	if m.status != "Nothing to undo" {
+		t.Errorf("status %q", m.status)
+	}
+
+	// Map field_model to extra under its own key, skip the date fields,
+	// review, and write
+	press(m, "G", "enter", "extra", "enter", "backspace", "backspace", "backspace", "backspace", "backspace", "kind", "enter")
+	if want := (FieldMapping{Target: "extra", ExtraKey: "field_kind"}); !reflect.DeepEqual(m.fields[5].mapping, want) {
+		t.Errorf("field_model mapped %+v", m.fields[5].mapping)
+	}
+	// Keys typed quickly arrive in one message
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/date")})
+	press(m, "enter", "space", "space", "space", "x", "w")
+	if m.screen != screenSummary {
+		t.Fatalf("screen %d, want the summary", m.screen)
+	}
+	if view := m.View(); !strings.Contains(view, "2 mapped (1 suggested), 3 skipped, 1 unmapped") {
+		t.Errorf("summary header:\n%s", view)
+	}
+	press(m, "enter")
+	if !m.confirmed {
+		t.Fatal("summary not confirmed")
+	}
+
+	m.apply(msg)
+	got := make(map[string]ProtoField)
+	for _, f := range msg.Fields {
+		got[f.Name] = f
+	}
+	if f := got["title"]; f.HubTarget != "title" {
+		t.Errorf("title target %q", f.HubTarget)
+	}
+	if f := got["field_date_created"]; !f.HubSkip {
+		t.Errorf("field_date_created not skipped")
+	}
+	if f := got["field_model"]; f.HubTarget != "extra" || f.HubExtraKey != "field_kind" {
+		t.Errorf("field_model mapped to %s %s", f.HubTarget, f.HubExtraKey)
+	}
+	if f := got["field_linked_agent"]; f.HubTarget != "" || f.HubAnnotation() != "" {
+		t.Errorf("unmapped field_linked_agent annotated %s", f.HubAnnotation())
+	}
+}
+
+func TestFuzzyScore(t *testing.T) {
+	if _, ok := fuzzyScore("idn", "identifiers"); !ok {
+		t.Error("idn doesn't match identifiers")
+	}
+	if _, ok := fuzzyScore("xyz", "identifiers"); ok {
+		t.Error("xyz matches identifiers")
+	}
+	// Consecutive and word-start matches rank first
+	pub, _ := fuzzyScore("pub", "publisher")
+	place, _ := fuzzyScore("pub", "place_published")
+	if pub <= place {
+		t.Errorf("pub scores publisher %d, place_published %d", pub, place)
+	}
+}
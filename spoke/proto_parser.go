@@ -5,7 +5,10 @@ import (
 	"bufio"
 	"os"
 	"regexp"
+	"strconv"
 	"strings"
+
+	"github.com/lehigh-university-libraries/crosswalk/mapping"
 )
 
 // ExistingMapping represents an existing hub.v1 annotation from a proto file.
@@ -21,6 +24,7 @@ type ExistingMapping struct {
 	Validators  string
 	Description string
 	Required    bool
+	Transform   mapping.TransformOptions
 }
 
 // ParseExistingProto reads an existing proto file and extracts hub.v1.field annotations.
@@ -40,7 +44,7 @@ func ParseExistingProto(protoPath string) (map[string]ExistingMapping, error) {
 
 	// Patterns for extracting field info
 	fieldPattern := regexp.MustCompile(`^\s*(repeated\s+)?(\w+)\s+(\w+)\s*=\s*\d+`)
-	optionPattern := regexp.MustCompile(`\[\(hub\.v1\.field\)\s*=\s*\{([^}]+)\}\]`)
+	optionPattern := regexp.MustCompile(`\[\(hub\.v1\.field\)\s*=\s*\{(.+)\}\]`)
 
 	for scanner.Scan() {
 		line := scanner.Text()
@@ -72,6 +76,7 @@ func ParseExistingProto(protoPath string) (map[string]ExistingMapping, error) {
 		mapping.Validators = extractOption(optionStr, "validators")
 		mapping.Description = extractOption(optionStr, "description")
 		mapping.Required = strings.Contains(optionStr, "required: true")
+		mapping.Transform = extractTransformOptions(optionStr)
 
 		mappings[fieldName] = mapping
 	}
@@ -90,6 +95,38 @@ func extractOption(optionStr, name string) string {
 	return ""
 }
 
+// quotedOptionPattern matches a string option, whose value may hold
+// escaped quotes.
+var quotedOptionPattern = regexp.MustCompile(`\b(\w+):\s*("(?:[^"\\]|\\.)*")`)
+
+// extractTransformOptions extracts the transformation options from the
+// options string. Their values are quoted, since they hold separators and
+// regular expressions.
+func extractTransformOptions(optionStr string) mapping.TransformOptions {
+	var t mapping.TransformOptions
+	for _, m := range quotedOptionPattern.FindAllStringSubmatch(optionStr, -1) {
+		value, err := strconv.Unquote(m[2])
+		if err != nil {
+			continue
+		}
+		switch m[1] {
+		case "split":
+			t.Split = value
+		case "regex_extract":
+			t.RegexExtract = value
+		case "transform":
+			t.Transform = append(t.Transform, value)
+		case "prefix":
+			t.Prefix = value
+		case "suffix":
+			t.Suffix = value
+		case "join":
+			t.Join = value
+		}
+	}
+	return t
+}
+
 // HubTargetType represents a type of Hub target field.
 type HubTargetType int
 
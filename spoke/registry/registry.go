@@ -3,6 +3,11 @@
 package registry
 
 import (
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/lehigh-university-libraries/crosswalk/hub"
 	"github.com/lehigh-university-libraries/crosswalk/mapping"
 )
 
@@ -19,11 +24,25 @@ type FieldMeta struct {
 	TargetType   string // For entity_reference: target entity type (e.g., "taxonomy_term", "node")
 	TargetBundle string // For entity_reference: target bundle if restricted
 	RDFPredicate string // RDF predicate from Drupal RDF mapping (e.g., "dcterms:issued")
+	// Bundles lists the bundles that have the field, in a spoke covering
+	// several bundles, when not all of them do
+	Bundles []string
+	// DSpaceField is the DSpace metadata field (e.g. "dc.contributor.author")
+	// of a spoke generated from a DSpace registry, which has no Drupal field
+	DSpaceField string
+	// CSVColumn is the column header of a spoke generated from a sample
+	// spreadsheet, and Delimiter the separator of its values when they are
+	// lists
+	CSVColumn string
+	Delimiter string
 
 	// Hub mapping info
 	HubField string // Hub schema field (e.g., "Contributors", "Dates", "Extra.model")
 	HubType  string // Hub field subtype (e.g., "issued" for date type, "doi" for identifier type)
 	Parser   string // Parser to use (e.g., "edtf")
+	// Transform is the cleanup of the field's values, as transform
+	// directives, from its hub.v1.field transformation options
+	Transform []string
 }
 
 var registered = map[string]map[string]FieldMeta{}
@@ -44,6 +63,141 @@ func ProfileFrom(format string) (*mapping.Profile, bool) {
 	return buildProfile(format, fields), true
 }
 
+// Formats returns the names of the formats with a registered spoke, sorted.
+func Formats() []string {
+	names := make([]string, 0, len(registered))
+	for name := range registered {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// baseFields are the Drupal entity base fields, which every node has but
+// no spoke declares.
+var baseFields = []string{"nid", "uuid", "vid", "langcode", "type", "status", "title", "created", "changed"}
+
+// SourceFields returns the source fields of the registered spoke for the
+// given format, by source field name (see sourceField), for linting
+// profiles against it.
+// Returns (nil, false) if no spoke is registered for the format.
+func SourceFields(format string) (map[string]mapping.SourceField, bool) {
+	fields, ok := registered[format]
+	if !ok {
+		return nil, false
+	}
+	sources := make(map[string]mapping.SourceField, len(fields)+len(baseFields))
+	for _, name := range baseFields {
+		sources[name] = mapping.SourceField{}
+	}
+	sources["title"] = mapping.SourceField{Type: "string", Hub: "Title"}
+	for _, meta := range fields {
+		if sourceField(meta) == "" {
+			continue
+		}
+		sources[sourceField(meta)] = mapping.SourceField{Type: meta.DrupalType, Hub: hubField(meta)}
+	}
+	return sources, true
+}
+
+// DrupalFields returns the field metadata of the registered spoke for the
+// given format by Drupal field name: cardinality and reference targets
+// for writing entities back to a Drupal site.
+// Returns (nil, false) if no spoke is registered for the format.
+func DrupalFields(format string) (map[string]FieldMeta, bool) {
+	fields, ok := registered[format]
+	if !ok {
+		return nil, false
+	}
+	byName := make(map[string]FieldMeta, len(fields))
+	for _, meta := range fields {
+		if meta.DrupalField != "" {
+			byName[meta.DrupalField] = meta
+		}
+	}
+	return byName, true
+}
+
+// RDFPredicates returns the RDF predicates (e.g. "dcterms:issued") of the
+// registered spoke for the given format, by the hub field path each spoke
+// field fills (e.g. "dates.issued"), sorted. Fields mapped to Extra, or
+// whose subtype the hub schema can't select, are left out.
+// Returns (nil, false) if no spoke is registered for the format.
+func RDFPredicates(format string) (map[string][]string, bool) {
+	fields, ok := registered[format]
+	if !ok {
+		return nil, false
+	}
+	predicates := make(map[string][]string)
+	for _, meta := range fields {
+		path := hubPath(meta)
+		if meta.RDFPredicate == "" || path == "" {
+			continue
+		}
+		if _, err := hub.PathRepeated(path); err != nil {
+			continue
+		}
+		if !slices.Contains(predicates[path], meta.RDFPredicate) {
+			predicates[path] = append(predicates[path], meta.RDFPredicate)
+			slices.Sort(predicates[path])
+		}
+	}
+	return predicates, true
+}
+
+// hubPath returns the hub field path a spoke field fills, in the syntax of
+// hub.PathValues ("dates.issued"), or "" for Extra fields.
+func hubPath(meta FieldMeta) string {
+	field := hubField(meta)
+	if field == "" || strings.HasPrefix(field, "Extra") {
+		return ""
+	}
+	var path strings.Builder
+	for i, r := range field {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				path.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		path.WriteRune(r)
+	}
+	// Genre is the one IR name that isn't its proto field's
+	p := path.String()
+	if p == "genre" {
+		p = "genres"
+	}
+	if meta.HubType != "" {
+		p += "." + meta.HubType
+	}
+	return p
+}
+
+// sourceField returns the name a spoke field has in source data: its
+// Drupal field name, its DSpace field name in a DSpace spoke, or its
+// column in a spreadsheet's.
+func sourceField(meta FieldMeta) string {
+	switch {
+	case meta.DrupalField != "":
+		return meta.DrupalField
+	case meta.DSpaceField != "":
+		return meta.DSpaceField
+	}
+	return meta.CSVColumn
+}
+
+// hubField returns the hub field a spoke field maps to.
+func hubField(meta FieldMeta) string {
+	// Generated Drupal RDF mappings frequently map dcterms:type-backed
+	// field_genre terms to ResourceType. In the Islandora data model this
+	// field should populate Genre, while field_resource_type should drive
+	// Hub ResourceType. If both map to ResourceType they race/override.
+	if meta.DrupalField == "field_genre" && meta.TargetBundle == "genre" {
+		return "Genre"
+	}
+	return meta.HubField
+}
+
 // buildProfile converts a spoke FieldRegistry to a mapping.Profile.
 func buildProfile(format string, fields map[string]FieldMeta) *mapping.Profile {
 	p := &mapping.Profile{
@@ -58,22 +212,16 @@ func buildProfile(format string, fields map[string]FieldMeta) *mapping.Profile {
 	}
 
 	for _, meta := range fields {
-		if meta.DrupalField == "" || meta.HubField == "" {
+		if sourceField(meta) == "" || meta.HubField == "" {
 			continue
 		}
 
 		fm := mapping.FieldMapping{
-			IR:         meta.HubField,
+			IR:         hubField(meta),
 			MultiValue: meta.Cardinality == -1 || meta.Cardinality > 1,
+			Delimiter:  meta.Delimiter,
 			Parser:     meta.Parser,
-		}
-
-		// Generated Drupal RDF mappings frequently map dcterms:type-backed
-		// field_genre terms to ResourceType. In the Islandora data model this
-		// field should populate Genre, while field_resource_type should drive
-		// Hub ResourceType. If both map to ResourceType they race/override.
-		if meta.DrupalField == "field_genre" && meta.TargetBundle == "genre" {
-			fm.IR = "Genre"
+			Transform:  meta.Transform,
 		}
 
 		// Set Drupal type — for typed_relation this is the primary type signal
@@ -105,7 +253,7 @@ func buildProfile(format string, fields map[string]FieldMeta) *mapping.Profile {
 			fm.RoleField = "rel_type"
 		}
 
-		p.Fields[meta.DrupalField] = fm
+		p.Fields[sourceField(meta)] = fm
 	}
 
 	// Always include the title field
@@ -1,6 +1,9 @@
 package registry
 
-import "testing"
+import (
+	"slices"
+	"testing"
+)
 
 func TestBuildProfile_MapsFieldGenreToGenre(t *testing.T) {
 	fields := map[string]FieldMeta{
@@ -38,3 +41,92 @@ func TestBuildProfile_MapsFieldGenreToGenre(t *testing.T) {
 		t.Fatalf("field_resource_type IR = %q, want %q", rt.IR, "ResourceType")
 	}
 }
+
+func TestRDFPredicates(t *testing.T) {
+	Register("rdf-test", map[string]FieldMeta{
+		"issued":  {DrupalField: "field_edtf_date_issued", HubField: "Dates", HubType: "issued", RDFPredicate: "dcterms:issued"},
+		"alt":     {DrupalField: "field_alt_title", HubField: "AltTitle", RDFPredicate: "dcterms:alternative"},
+		"subject": {DrupalField: "field_subject", HubField: "Subjects", RDFPredicate: "dcterms:subject"},
+		"dewey":   {DrupalField: "field_dewey_classification", HubField: "Subjects", RDFPredicate: "dc11:subject"},
+		"genre":   {DrupalField: "field_genre", HubField: "ResourceType", TargetBundle: "genre", RDFPredicate: "dcterms:type"},
+		"oclc":    {DrupalField: "field_oclc_number", HubField: "Identifiers", HubType: "oclc", RDFPredicate: "dbpedia:oclc"},
+		"weight":  {DrupalField: "field_weight", HubField: "Extra.weight", RDFPredicate: "co:index"},
+		"model":   {DrupalField: "field_model", HubField: "Extra.model"},
+	})
+	defer delete(registered, "rdf-test")
+
+	got, ok := RDFPredicates("rdf-test")
+	if !ok {
+		t.Fatal("spoke not registered")
+	}
+	want := map[string][]string{
+		"dates.issued": {"dcterms:issued"},
+		"alt_title":    {"dcterms:alternative"},
+		"subjects":     {"dc11:subject", "dcterms:subject"},
+		"genres":       {"dcterms:type"},
+	}
+	if len(got) != len(want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	for path, preds := range want {
+		if !slices.Equal(got[path], preds) {
+			t.Errorf("%s: got %v, want %v", path, got[path], preds)
+		}
+	}
+}
+
+func TestDrupalFields(t *testing.T) {
+	Register("drupal-fields-test", map[string]FieldMeta{
+		"model":    {DrupalField: "field_model", TargetType: "taxonomy_term", TargetBundle: "islandora_models", Cardinality: 1},
+		"internal": {ProtoField: "internal"},
+	})
+	defer delete(registered, "drupal-fields-test")
+
+	got, ok := DrupalFields("drupal-fields-test")
+	if !ok {
+		t.Fatal("spoke not registered")
+	}
+	if len(got) != 1 || got["field_model"].TargetBundle != "islandora_models" {
+		t.Errorf("DrupalFields = %v, want only field_model", got)
+	}
+	if _, ok := DrupalFields("unregistered"); ok {
+		t.Error("expected no fields for an unregistered format")
+	}
+}
+
+func TestBuildProfile_DSpaceFields(t *testing.T) {
+	p := buildProfile("dspace", map[string]FieldMeta{
+		"dc_contributor_author": {DSpaceField: "dc.contributor.author", HubField: "Contributors", HubType: "author", Cardinality: -1},
+		"dc_date_issued":        {DSpaceField: "dc.date.issued", HubField: "Dates", HubType: "issued", Parser: "edtf", Cardinality: -1},
+	})
+
+	author, ok := p.Fields["dc.contributor.author"]
+	if !ok || author.IR != "Contributors" || !author.MultiValue {
+		t.Errorf("dc.contributor.author: got %+v", author)
+	}
+	if issued := p.Fields["dc.date.issued"]; issued.DateType != "issued" || issued.Parser != "edtf" {
+		t.Errorf("dc.date.issued: got %+v", issued)
+	}
+}
+
+func TestBuildProfile_CSVColumns(t *testing.T) {
+	p := buildProfile("vendor", map[string]FieldMeta{
+		"keywords": {CSVColumn: "Keywords", HubField: "Subjects", HubType: "keywords", Cardinality: -1, Delimiter: ";"},
+	})
+	if kw := p.Fields["Keywords"]; kw.IR != "Subjects" || kw.Vocabulary != "keywords" || !kw.MultiValue || kw.Delimiter != ";" {
+		t.Errorf("Keywords: got %+v", kw)
+	}
+}
+
+func TestBuildProfile_Transform(t *testing.T) {
+	p := buildProfile("vendor", map[string]FieldMeta{
+		"doi": {CSVColumn: "DOI", HubField: "Identifiers", HubType: "doi", Cardinality: 1, Transform: []string{"extract:10\\..*", "add_prefix:https://doi.org/"}},
+	})
+	ft, err := p.Fields["DOI"].CompileTransform()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := ft.Apply([]string{"doi:10.1234/a"}, nil); len(got) != 1 || got[0] != "https://doi.org/10.1234/a" {
+		t.Errorf("DOI transformed to %v", got)
+	}
+}
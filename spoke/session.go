@@ -0,0 +1,128 @@
+package spoke
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MappingSession is a record of the hub mapping decisions made for a
+// spoke's fields, saved as YAML apart from the proto so it can be
+// versioned, reviewed, and replayed with --mappings where no one can
+// answer prompts.
+type MappingSession struct {
+	// Fields are the main message's fields in proto order. A field with no
+	// target and not skipped was deliberately left unmapped.
+	Fields []SessionField `yaml:"fields"`
+}
+
+// SessionField is one field's mapping in a session.
+type SessionField struct {
+	Field        string `yaml:"field"`
+	FieldMapping `yaml:",inline"`
+}
+
+// NewMappingSession records the mappings of proto's main message.
+func NewMappingSession(proto *ProtoFile) *MappingSession {
+	s := &MappingSession{}
+	if len(proto.Messages) == 0 {
+		return s
+	}
+	for _, f := range proto.Messages[0].Fields {
+		if !needsHubMapping(f) {
+			continue
+		}
+		sf := SessionField{Field: f.Name}
+		if f.HubSkip {
+			sf.Skip = true
+		} else if f.HubTarget != "" {
+			sf.FieldMapping = mappingOf(f)
+		}
+		s.Fields = append(s.Fields, sf)
+	}
+	return s
+}
+
+// LoadMappingSession reads a session file.
+func LoadMappingSession(path string) (*MappingSession, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var s MappingSession
+	if err := yaml.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("parsing mapping session %s: %w", path, err)
+	}
+	seen := make(map[string]bool)
+	for _, f := range s.Fields {
+		if f.Field == "" {
+			return nil, fmt.Errorf("mapping session %s: a mapping has no field", path)
+		}
+		if seen[f.Field] {
+			return nil, fmt.Errorf("mapping session %s: field %s is mapped twice", path, f.Field)
+		}
+		seen[f.Field] = true
+	}
+	return &s, nil
+}
+
+// Save writes the session to path, headed by a comment naming the spoke.
+func (s *MappingSession) Save(path, spokeName string) error {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "# Hub mappings for the %s spoke, replayed with\n", spokeName)
+	fmt.Fprintf(&buf, "# crosswalk spoke create --mappings %s\n", path)
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(s); err != nil {
+		return fmt.Errorf("encoding mapping session: %w", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("writing mapping session: %w", err)
+	}
+	return nil
+}
+
+// mappings returns the session's mappings by field name.
+func (s *MappingSession) mappings() map[string]FieldMapping {
+	m := make(map[string]FieldMapping, len(s.Fields))
+	for _, f := range s.Fields {
+		m[f.Field] = f.FieldMapping
+	}
+	return m
+}
+
+// ApplyMappingSession maps proto's main message as the session does. A
+// field the session doesn't mention, such as one added since it was
+// saved, is mapped automatically and returned so it can be reviewed. A
+// field the session maps that the spoke no longer has is an error, since
+// its mapping would otherwise be dropped without notice.
+func ApplyMappingSession(proto *ProtoFile, s *MappingSession) ([]string, error) {
+	if len(proto.Messages) == 0 {
+		return nil, nil
+	}
+	msg := &proto.Messages[0]
+	mappings := s.mappings()
+	var unmentioned []string
+	for i := range msg.Fields {
+		field := &msg.Fields[i]
+		mapping, ok := mappings[field.Name]
+		delete(mappings, field.Name)
+		switch {
+		case !needsHubMapping(*field):
+		case !ok:
+			autoMapField(field)
+			unmentioned = append(unmentioned, field.Name)
+		default:
+			mapping.apply(field)
+		}
+	}
+	for _, f := range s.Fields {
+		if _, ok := mappings[f.Field]; ok {
+			return nil, fmt.Errorf("mapping session maps field %s, which the spoke doesn't have", f.Field)
+		}
+	}
+	proto.UseHubOptions = true
+	return unmentioned, nil
+}
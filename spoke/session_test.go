@@ -0,0 +1,144 @@
+package spoke
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/lehigh-university-libraries/crosswalk/mapping"
+)
+
+func TestMappingSession(t *testing.T) {
+	proto := &ProtoFile{Messages: []ProtoMessage{*mapperMessage()}}
+	fields := proto.Messages[0].Fields
+	FieldMapping{Target: "title"}.apply(&fields[1])
+	FieldMapping{Target: "dates", DateType: "issued", Parser: "edtf"}.apply(&fields[2])
+	FieldMapping{Skip: true}.apply(&fields[3])
+	FieldMapping{Target: "extra", ExtraKey: "model"}.apply(&fields[6])
+
+	path := filepath.Join(t.TempDir(), "session.yaml")
+	if err := NewMappingSession(proto).Save(path, "demo"); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(path)
+	want := `# Hub mappings for the demo spoke, replayed with
+# crosswalk spoke create --mappings ` + path + `
+fields:
+  - field: title
+    target: title
+  - field: field_date_issued
+    target: dates
+    date_type: issued
+    parser: edtf
+  - field: field_date_created
+    skip: true
+  - field: field_date_captured
+  - field: field_linked_agent
+  - field: field_model
+    target: extra
+    extra_key: model
+`
+	if string(data) != want {
+		t.Errorf("session file:\n%s\nwant:\n%s", data, want)
+	}
+
+	// Replaying it on a regeneration with a new field maps that field
+	// automatically, and leaves the fields left unmapped alone
+	session, err := LoadMappingSession(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	regenerated := &ProtoFile{Messages: []ProtoMessage{*mapperMessage()}}
+	msg := &regenerated.Messages[0]
+	msg.Fields = append(msg.Fields, ProtoField{Name: "field_abstract", Type: "string", Number: 8, HubField: "Abstract"})
+	unmentioned, err := ApplyMappingSession(regenerated, session)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(unmentioned, " ") != "field_abstract" {
+		t.Errorf("unmentioned fields %v", unmentioned)
+	}
+	if !regenerated.UseHubOptions {
+		t.Error("hub options not enabled")
+	}
+	for i, want := range []string{
+		"",
+		`target: "title"`,
+		`target: "dates" date_type: "issued" parser: "edtf"`,
+		"",
+		"",
+		"",
+		`target: "extra"`,
+		`target: "abstract"`,
+	} {
+		if got := msg.Fields[i].HubAnnotation(); !strings.Contains(got, want) || want == "" && got != "" {
+			t.Errorf("%s annotation %q, want %q", msg.Fields[i].Name, got, want)
+		}
+	}
+	if f := msg.Fields[3]; !f.HubSkip {
+		t.Errorf("%s not skipped", f.Name)
+	}
+	if f := msg.Fields[6]; f.HubExtraKey != "model" {
+		t.Errorf("%s extra key %q", f.Name, f.HubExtraKey)
+	}
+
+	// A session mapping a field the spoke lacks is an error
+	session.Fields = append(session.Fields, SessionField{Field: "field_gone", FieldMapping: FieldMapping{Target: "notes"}})
+	if _, err := ApplyMappingSession(&ProtoFile{Messages: []ProtoMessage{*mapperMessage()}}, session); err == nil || !strings.Contains(err.Error(), "field_gone") {
+		t.Errorf("missing field: got %v", err)
+	}
+
+	os.WriteFile(path, []byte("fields:\n  - field: title\n  - field: title\n    skip: true\n"), 0o644)
+	if _, err := LoadMappingSession(path); err == nil || !strings.Contains(err.Error(), "mapped twice") {
+		t.Errorf("duplicate field: got %v", err)
+	}
+}
+
+func TestTransformAnnotations(t *testing.T) {
+	proto := &ProtoFile{Package: "demo.v1", PackageName: "demov1", UseHubOptions: true, Messages: []ProtoMessage{*mapperMessage()}}
+	field := &proto.Messages[0].Fields[6]
+	field.DrupalField = field.Name
+	FieldMapping{Target: "identifiers", IDType: "doi", Transform: mapping.TransformOptions{
+		Split:        ";",
+		RegexExtract: `10\..*`,
+		Transform:    mapping.Transforms{`s/"//g`},
+		Prefix:       "https://doi.org/",
+	}}.apply(field)
+
+	want := `target: "identifiers" identifier_type: "doi" split: ";" regex_extract: "10\\..*" transform: "s/\"//g" prefix: "https://doi.org/"`
+	if got := field.HubAnnotation(); !strings.Contains(got, want) {
+		t.Errorf("annotation %s\nwant %s", got, want)
+	}
+
+	// The options survive a regeneration from the written proto
+	protoPath := filepath.Join(t.TempDir(), "demo.proto")
+	if err := WriteProto(proto, protoPath); err != nil {
+		t.Fatal(err)
+	}
+	existing, err := ParseExistingProto(protoPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := existing[field.Name]; !reflect.DeepEqual(got.Transform, field.Transform) || got.IDType != "doi" {
+		t.Errorf("parsed %+v", got)
+	}
+	meta, _ := os.ReadFile(strings.TrimSuffix(protoPath, ".proto") + "_meta.go")
+	if want := `Transform:    []string{"split:;", "trim", "extract:10\\..*", "s/\"//g", "add_prefix:https://doi.org/"},`; !strings.Contains(string(meta), want) {
+		t.Errorf("meta file lacks %s:\n%s", want, meta)
+	}
+
+	// And a mapping session
+	path := filepath.Join(t.TempDir(), "session.yaml")
+	if err := NewMappingSession(proto).Save(path, "demo"); err != nil {
+		t.Fatal(err)
+	}
+	session, err := LoadMappingSession(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := session.mappings()[field.Name]; !reflect.DeepEqual(got, mappingOf(*field)) {
+		t.Errorf("session mapping %+v", got)
+	}
+}
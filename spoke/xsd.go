@@ -0,0 +1,773 @@
+package spoke
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Namespaces an XML schema refers to by definition: XML Schema's own, for
+// its built-in types, and the one the xml prefix is bound to.
+const (
+	xsdNamespace = "http://www.w3.org/2001/XMLSchema"
+	xmlNamespace = "http://www.w3.org/XML/1998/namespace"
+)
+
+// xsdEnumNoteMax is how many of a simple type's enumerated values a field
+// comment lists.
+const xsdEnumNoteMax = 8
+
+// xsdNode is an element of a schema document. Schemas are read generically
+// rather than into typed structs so content models keep their order.
+type xsdNode struct {
+	XMLName  xml.Name
+	Attrs    []xml.Attr `xml:",any,attr"`
+	Children []*xsdNode `xml:",any"`
+	Text     string     `xml:",chardata"`
+}
+
+// attr returns the value of the node's unqualified attribute name.
+func (n *xsdNode) attr(name string) string {
+	for _, a := range n.Attrs {
+		if a.Name.Space == "" && a.Name.Local == name {
+			return strings.TrimSpace(a.Value)
+		}
+	}
+	return ""
+}
+
+// is reports whether the node is the XML Schema element local.
+func (n *xsdNode) is(local string) bool {
+	return n.XMLName.Space == xsdNamespace && n.XMLName.Local == local
+}
+
+// doc returns the first sentence of the node's annotation/documentation.
+func (n *xsdNode) doc() string {
+	for _, a := range n.Children {
+		if !a.is("annotation") {
+			continue
+		}
+		for _, d := range a.Children {
+			if !d.is("documentation") {
+				continue
+			}
+			text := strings.Join(strings.Fields(d.Text), " ")
+			if i := strings.Index(text, ". "); i >= 0 {
+				text = text[:i+1]
+			}
+			if text != "" {
+				return text
+			}
+		}
+	}
+	return ""
+}
+
+// xsdSchema is a schema document with the documents it includes and the
+// local ones it imports. Declarations are keyed by their qualified name in
+// Clark notation, "{namespace}local", as are the type, base, and ref
+// attributes of every node once loaded.
+type xsdSchema struct {
+	target   string
+	prefixes map[string]string // namespace URI → prefix, from the main document
+
+	elements        map[string]*xsdNode
+	complexTypes    map[string]*xsdNode
+	simpleTypes     map[string]*xsdNode
+	groups          map[string]*xsdNode
+	attributeGroups map[string]*xsdNode
+	attributes      map[string]*xsdNode
+
+	// topElements and complexOrder are the target namespace's top-level
+	// elements and named complex types in document order
+	topElements  []string
+	complexOrder []string
+
+	loaded map[string]bool
+}
+
+// loadXSD reads the schema at path, following includes and imports to
+// local files; remote ones are skipped, leaving the types they declare
+// unresolved.
+func loadXSD(path string) (*xsdSchema, error) {
+	s := &xsdSchema{
+		prefixes:        make(map[string]string),
+		elements:        make(map[string]*xsdNode),
+		complexTypes:    make(map[string]*xsdNode),
+		simpleTypes:     make(map[string]*xsdNode),
+		groups:          make(map[string]*xsdNode),
+		attributeGroups: make(map[string]*xsdNode),
+		attributes:      make(map[string]*xsdNode),
+		loaded:          make(map[string]bool),
+	}
+	if err := s.load(path, "", true); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// load reads one schema document. includer is the target namespace of the
+// document including it, which a document without its own takes on.
+func (s *xsdSchema) load(path, includer string, main bool) error {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	if s.loaded[abs] {
+		return nil
+	}
+	s.loaded[abs] = true
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("schema not accessible: %w", err)
+	}
+	var root xsdNode
+	if err := xml.Unmarshal(data, &root); err != nil {
+		return fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if !root.is("schema") {
+		return fmt.Errorf("%s is not an XML schema (root element %s)", path, root.XMLName.Local)
+	}
+
+	prefixes := make(map[string]string)
+	for _, a := range root.Attrs {
+		switch {
+		case a.Name.Space == "xmlns":
+			prefixes[a.Name.Local] = a.Value
+		case a.Name.Space == "" && a.Name.Local == "xmlns":
+			prefixes[""] = a.Value
+		}
+	}
+	target := root.attr("targetNamespace")
+	if target == "" {
+		// A chameleon include: its unqualified names are the includer's
+		target = includer
+		if _, ok := prefixes[""]; !ok {
+			prefixes[""] = includer
+		}
+	}
+	if main {
+		s.target = target
+		for prefix, uri := range prefixes {
+			if prefix != "" && uri != xsdNamespace && uri != target {
+				s.prefixes[uri] = prefix
+			}
+		}
+	}
+	qualifyRefs(&root, prefixes)
+
+	for _, c := range root.Children {
+		name := c.attr("name")
+		key := "{" + target + "}" + name
+		var decls map[string]*xsdNode
+		switch {
+		case c.is("element"):
+			decls = s.elements
+			if target == s.target && name != "" && s.elements[key] == nil {
+				s.topElements = append(s.topElements, key)
+			}
+		case c.is("complexType"):
+			decls = s.complexTypes
+			if target == s.target && name != "" && s.complexTypes[key] == nil {
+				s.complexOrder = append(s.complexOrder, key)
+			}
+		case c.is("simpleType"):
+			decls = s.simpleTypes
+		case c.is("group"):
+			decls = s.groups
+		case c.is("attributeGroup"):
+			decls = s.attributeGroups
+		case c.is("attribute"):
+			decls = s.attributes
+		case c.is("include"), c.is("redefine"), c.is("import"):
+			loc := c.attr("schemaLocation")
+			if loc == "" || strings.Contains(loc, "://") {
+				continue
+			}
+			loc = filepath.Join(filepath.Dir(path), filepath.FromSlash(loc))
+			if _, err := os.Stat(loc); err != nil {
+				continue
+			}
+			ns := target
+			if c.is("import") {
+				ns = ""
+			}
+			if err := s.load(loc, ns, false); err != nil {
+				return err
+			}
+		}
+		if decls != nil && name != "" && decls[key] == nil {
+			decls[key] = c
+		}
+	}
+	return nil
+}
+
+// qualifyRefs rewrites the QName attributes of n and its descendants in
+// Clark notation, resolving prefixes with the document's declarations.
+func qualifyRefs(n *xsdNode, prefixes map[string]string) {
+	for i, a := range n.Attrs {
+		if a.Name.Space != "" {
+			continue
+		}
+		switch a.Name.Local {
+		case "type", "base", "ref", "itemType":
+			n.Attrs[i].Value = clarkName(strings.TrimSpace(a.Value), prefixes)
+		case "memberTypes":
+			var members []string
+			for _, m := range strings.Fields(a.Value) {
+				members = append(members, clarkName(m, prefixes))
+			}
+			n.Attrs[i].Value = strings.Join(members, " ")
+		}
+	}
+	for _, c := range n.Children {
+		qualifyRefs(c, prefixes)
+	}
+}
+
+// clarkName returns the QName q as "{namespace}local". A prefix the
+// document doesn't declare stands in for its namespace, so the name
+// resolves to nothing.
+func clarkName(q string, prefixes map[string]string) string {
+	prefix, local, ok := strings.Cut(q, ":")
+	if !ok {
+		prefix, local = "", q
+	}
+	ns, declared := prefixes[prefix]
+	switch {
+	case prefix == "xml":
+		ns = xmlNamespace
+	case !declared && prefix != "":
+		ns = prefix
+	}
+	return "{" + ns + "}" + local
+}
+
+// splitClark splits "{namespace}local".
+func splitClark(name string) (ns, local string) {
+	if rest, ok := strings.CutPrefix(name, "{"); ok {
+		if ns, local, ok := strings.Cut(rest, "}"); ok {
+			return ns, local
+		}
+	}
+	return "", name
+}
+
+// GenerateXSDSpoke generates a proto file from an XML schema: a message
+// per complex type, starting with that of the root element (the schema's
+// first top-level element unless root names another), with a field per
+// element and attribute. An element that may occur more than once, or
+// sits in a sequence or choice that may, is a repeated field; one that
+// must occur is marked required. Fields carry the XML names, attributes,
+// and text content format/protoxml reads, so documents decode into the
+// generated message once it's compiled.
+func GenerateXSDSpoke(name, xsdPath, root string) (*ProtoFile, error) {
+	s, err := loadXSD(xsdPath)
+	if err != nil {
+		return nil, err
+	}
+	g := &xsdGenerator{
+		schema:   s,
+		proto:    newSpokeProtoFile(name, fmt.Sprintf("Generated from XML schema '%s'", filepath.Base(xsdPath))),
+		messages: make(map[*xsdNode]string),
+		reserved: make(map[*xsdNode]string),
+		names:    make(map[string]bool),
+		usedNS:   make(map[string]bool),
+	}
+	// Named types have first claim on their names, so an anonymous type
+	// met first doesn't take one
+	for _, key := range s.complexOrder {
+		_, local := splitClark(key)
+		g.reserved[s.complexTypes[key]] = g.uniqueName(xsdMessageName(local))
+	}
+
+	rootKey := ""
+	for _, key := range s.topElements {
+		if _, local := splitClark(key); root == "" || local == root {
+			rootKey = key
+			break
+		}
+	}
+	switch {
+	case rootKey != "":
+		_, local := splitClark(rootKey)
+		typ, _ := g.elementType(s.elements[rootKey], local)
+		if len(g.proto.Messages) == 0 {
+			return nil, fmt.Errorf("root element %s has simple type %s; it needs a complex type to make a message", local, typ)
+		}
+		msg := &g.proto.Messages[0]
+		msg.XMLName = local
+		msg.XMLDefaultNS = s.target
+	case root != "":
+		var names []string
+		for _, key := range s.topElements {
+			_, local := splitClark(key)
+			names = append(names, local)
+		}
+		return nil, fmt.Errorf("schema has no top-level element %s (it has %s)", root, strings.Join(names, ", "))
+	}
+
+	// Then every other element and complex type the schema declares,
+	// whether or not the root reaches it
+	for _, key := range s.topElements {
+		_, local := splitClark(key)
+		g.elementType(s.elements[key], local)
+	}
+	for _, key := range s.complexOrder {
+		_, local := splitClark(key)
+		g.messageFor(s.complexTypes[key], local, false)
+	}
+	if len(g.proto.Messages) == 0 {
+		return nil, fmt.Errorf("no complex types found in %s", xsdPath)
+	}
+
+	if rootKey != "" {
+		msg := &g.proto.Messages[0]
+		for uri := range g.usedNS {
+			if prefix, ok := s.prefixes[uri]; ok {
+				msg.XMLNamespaces = append(msg.XMLNamespaces, prefix+"="+uri)
+			}
+		}
+		sort.Strings(msg.XMLNamespaces)
+	}
+	return g.proto, nil
+}
+
+// xsdGenerator builds the messages of a schema.
+type xsdGenerator struct {
+	schema   *xsdSchema
+	proto    *ProtoFile
+	messages map[*xsdNode]string // complex type → its message
+	reserved map[*xsdNode]string // named complex type → its message name
+	names    map[string]bool     // message names taken
+	usedNS   map[string]bool     // foreign namespaces fields are named in
+	current  string              // message whose fields are being added
+}
+
+// messageFor returns the message of a complex type, generating it first
+// if need be. name is the type's name, or for an anonymous type that of
+// the element it's declared in.
+func (g *xsdGenerator) messageFor(ct *xsdNode, name string, anonymous bool) string {
+	if msg, ok := g.messages[ct]; ok {
+		return msg
+	}
+	msgName, ok := g.reserved[ct]
+	if !ok {
+		// An anonymous type whose element's name is taken is named for
+		// the message it's declared in too, e.g. CopyInformationNote
+		msgName = xsdMessageName(name)
+		if g.names[msgName] && g.current != "" {
+			msgName = g.current + msgName
+		}
+		msgName = g.uniqueName(msgName)
+	}
+	g.messages[ct] = msgName
+	parent := g.current
+	g.current = msgName
+	defer func() { g.current = parent }()
+
+	// The message is added before its fields are, since they may refer to
+	// it, and filled in by index since they may add others
+	i := len(g.proto.Messages)
+	g.proto.Messages = append(g.proto.Messages, ProtoMessage{Name: msgName})
+
+	fields := &xsdFields{taken: make(map[string]bool)}
+	g.content(ct, fields, false, false)
+	if ct.attr("mixed") == "true" {
+		fields.add(ProtoField{Name: "text", Type: "string", Comment: "Text content", XMLChardata: true, Cardinality: 1})
+	}
+
+	comment := fmt.Sprintf("%s is the %s complex type.", msgName, name)
+	if anonymous {
+		comment = fmt.Sprintf("%s is the content of the %s element.", msgName, name)
+	}
+	if doc := ct.doc(); doc != "" {
+		comment = msgName + " - " + doc
+	}
+	g.proto.Messages[i].Comment = comment
+	g.proto.Messages[i].Fields = fields.list
+	return msgName
+}
+
+// uniqueName returns name, numbered if another message has it, and
+// marks it taken.
+func (g *xsdGenerator) uniqueName(name string) string {
+	unique := name
+	for n := 2; g.names[unique]; n++ {
+		unique = fmt.Sprintf("%s%d", name, n)
+	}
+	g.names[unique] = true
+	return unique
+}
+
+// content adds the fields of a complex type's content model, or part of
+// one. repeated and optional are whether an enclosing particle lets its
+// elements occur more than once, or not at all.
+func (g *xsdGenerator) content(n *xsdNode, fields *xsdFields, repeated, optional bool) {
+	for _, c := range n.Children {
+		switch {
+		case c.is("sequence"), c.is("choice"), c.is("all"):
+			g.content(c, fields, repeated || xsdRepeats(c), optional || c.is("choice") || xsdOptional(c))
+
+		case c.is("group"):
+			if def := g.schema.groups[c.attr("ref")]; def != nil {
+				g.content(def, fields, repeated || xsdRepeats(c), optional || xsdOptional(c))
+			}
+
+		case c.is("element"):
+			g.element(c, fields, repeated, optional)
+
+		case c.is("attribute"):
+			g.attribute(c, fields)
+
+		case c.is("attributeGroup"):
+			if def := g.schema.attributeGroups[c.attr("ref")]; def != nil {
+				g.content(def, fields, false, false)
+			}
+
+		case c.is("simpleContent"):
+			for _, d := range c.Children {
+				if d.is("extension") || d.is("restriction") {
+					g.simpleContent(d, fields)
+				}
+			}
+
+		case c.is("complexContent"):
+			for _, d := range c.Children {
+				if !d.is("extension") && !d.is("restriction") {
+					continue
+				}
+				// An extension's content follows its base type's; a
+				// restriction restates the content it keeps
+				if base := g.schema.complexTypes[d.attr("base")]; base != nil && d.is("extension") {
+					g.content(base, fields, repeated, optional)
+				}
+				g.content(d, fields, repeated, optional)
+			}
+			if c.attr("mixed") == "true" {
+				fields.add(ProtoField{Name: "text", Type: "string", Comment: "Text content", XMLChardata: true, Cardinality: 1})
+			}
+		}
+	}
+}
+
+// simpleContent adds the fields of a complex type with simple content: its
+// text, typed as its base, and its attributes.
+func (g *xsdGenerator) simpleContent(d *xsdNode, fields *xsdFields) {
+	if base := g.schema.complexTypes[d.attr("base")]; base != nil {
+		g.content(base, fields, false, false)
+	} else {
+		typ, note := g.typeRef(d.attr("base"), "")
+		if t, n := g.restriction(d); t != "" {
+			typ, note = t, n
+		}
+		fields.add(ProtoField{Name: "value", Type: typ, Comment: xsdComment("Text content", note), XMLChardata: true, Cardinality: 1})
+	}
+	g.content(d, fields, false, false)
+}
+
+// element adds the field of an element declaration or reference.
+func (g *xsdGenerator) element(particle *xsdNode, fields *xsdFields, repeated, optional bool) {
+	decl, name, xmlName := particle, particle.attr("name"), particle.attr("name")
+	if ref := particle.attr("ref"); ref != "" {
+		ns, local := splitClark(ref)
+		name, xmlName = local, g.qualifiedName(ns, local)
+		decl = g.schema.elements[ref]
+	}
+	if name == "" {
+		return
+	}
+
+	typ, note := "string", fmt.Sprintf("element %s is not in the schema", xmlName)
+	if decl != nil {
+		typ, note = g.elementType(decl, name)
+	}
+	pf := ProtoField{
+		Name:        xsdFieldName(name),
+		Type:        typ,
+		XMLName:     xmlName,
+		Cardinality: 1,
+	}
+	doc := ""
+	if decl != nil {
+		doc = decl.doc()
+	}
+	if doc == "" {
+		doc = xmlName + " element"
+	}
+	pf.Comment = xsdComment(doc, note)
+
+	switch max := particle.attr("maxOccurs"); {
+	case repeated || max == "unbounded":
+		pf.Type = "repeated " + typ
+		pf.Cardinality = -1
+	case xsdRepeats(particle):
+		pf.Type = "repeated " + typ
+		pf.Cardinality, _ = strconv.Atoi(max)
+	default:
+		pf.Required = !optional && !xsdOptional(particle)
+	}
+	fields.add(pf)
+}
+
+// attribute adds the field of an attribute declaration or reference.
+func (g *xsdGenerator) attribute(a *xsdNode, fields *xsdFields) {
+	if a.attr("use") == "prohibited" {
+		return
+	}
+	decl, name, xmlName := a, a.attr("name"), a.attr("name")
+	if ref := a.attr("ref"); ref != "" {
+		ns, local := splitClark(ref)
+		name, xmlName = local, g.qualifiedName(ns, local)
+		decl = g.schema.attributes[ref]
+	}
+	if name == "" {
+		return
+	}
+
+	// Attributes the schema imports from elsewhere, such as xml:lang and
+	// xlink:href, are strings
+	typ, note := "string", ""
+	if decl != nil {
+		if t := decl.attr("type"); t != "" {
+			typ, note = g.typeRef(t, "")
+		}
+		for _, c := range decl.Children {
+			if c.is("simpleType") {
+				typ, note = g.simpleType(c)
+			}
+		}
+	}
+	doc := ""
+	if decl != nil {
+		doc = decl.doc()
+	}
+	if doc == "" {
+		doc = xmlName + " attribute"
+	}
+	fields.add(ProtoField{
+		Name:        xsdFieldName(name),
+		Type:        typ,
+		Comment:     xsdComment(doc, note),
+		XMLName:     xmlName,
+		XMLAttr:     true,
+		Required:    a.attr("use") == "required",
+		Cardinality: 1,
+	})
+}
+
+// elementType returns the proto type of an element declaration: the
+// message of its complex type, named or declared inline, or the scalar of
+// its simple type, with a note on the values a simple type allows.
+func (g *xsdGenerator) elementType(decl *xsdNode, name string) (typ, note string) {
+	if t := decl.attr("type"); t != "" {
+		return g.typeRef(t, name)
+	}
+	for _, c := range decl.Children {
+		switch {
+		case c.is("complexType"):
+			return g.messageFor(c, name, true), ""
+		case c.is("simpleType"):
+			return g.simpleType(c)
+		}
+	}
+	// No type is xs:anyType; its content is kept as text
+	return "string", ""
+}
+
+// typeRef returns the proto type of the named type ref.
+func (g *xsdGenerator) typeRef(ref, element string) (typ, note string) {
+	ns, local := splitClark(ref)
+	if ns == xsdNamespace {
+		return xsdBuiltinType(local), ""
+	}
+	if ct := g.schema.complexTypes[ref]; ct != nil {
+		return g.messageFor(ct, local, false), ""
+	}
+	if st := g.schema.simpleTypes[ref]; st != nil {
+		return g.simpleType(st)
+	}
+	return "string", fmt.Sprintf("type %s is not in the schema", local)
+}
+
+// simpleType returns the proto scalar a simple type's values parse as:
+// its base type's for a restriction, and string for lists and unions,
+// which are kept as the text they are written as.
+func (g *xsdGenerator) simpleType(st *xsdNode) (typ, note string) {
+	for _, c := range st.Children {
+		if c.is("restriction") {
+			if typ, note := g.restriction(c); typ != "" {
+				return typ, note
+			}
+			return g.typeRef(c.attr("base"), "")
+		}
+	}
+	return "string", ""
+}
+
+// restriction returns the type of a restriction declaring its base inline,
+// or with enumerated values, string and a note listing them. It returns ""
+// for a restriction whose base decides its type.
+func (g *xsdGenerator) restriction(r *xsdNode) (typ, note string) {
+	var values []string
+	for _, c := range r.Children {
+		switch {
+		case c.is("simpleType"):
+			typ, note = g.simpleType(c)
+		case c.is("enumeration"):
+			values = append(values, c.attr("value"))
+		}
+	}
+	if len(values) == 0 {
+		return typ, note
+	}
+	if len(values) > xsdEnumNoteMax {
+		values = append(values[:xsdEnumNoteMax], "...")
+	}
+	return "string", "one of: " + strings.Join(values, ", ")
+}
+
+// qualifiedName returns the XML name a field of a declaration in namespace
+// ns is written with: its local name in the target namespace, and
+// prefixed in a foreign one.
+func (g *xsdGenerator) qualifiedName(ns, local string) string {
+	switch ns {
+	case "", g.schema.target:
+		return local
+	case xmlNamespace:
+		return "xml:" + local
+	}
+	if prefix, ok := g.schema.prefixes[ns]; ok {
+		g.usedNS[ns] = true
+		return prefix + ":" + local
+	}
+	return local
+}
+
+// xsdFields collects a message's fields, numbered in order.
+type xsdFields struct {
+	list  []ProtoField
+	taken map[string]bool
+}
+
+// add appends a field, renaming it if another took its name: an attribute
+// named like an element gets an _attr suffix, anything else a number.
+func (f *xsdFields) add(pf ProtoField) {
+	if f.taken[pf.Name] && pf.XMLAttr && !f.taken[pf.Name+"_attr"] {
+		pf.Name += "_attr"
+	}
+	base := pf.Name
+	for n := 2; f.taken[pf.Name]; n++ {
+		pf.Name = fmt.Sprintf("%s_%d", base, n)
+	}
+	f.taken[pf.Name] = true
+	if pf.XMLName == pf.Name {
+		pf.XMLName = ""
+	}
+	pf.Number = len(f.list) + 1
+	f.list = append(f.list, pf)
+}
+
+// xsdRepeats reports whether a particle may occur more than once.
+func xsdRepeats(n *xsdNode) bool {
+	max := n.attr("maxOccurs")
+	if max == "unbounded" {
+		return true
+	}
+	m, err := strconv.Atoi(max)
+	return err == nil && m > 1
+}
+
+// xsdOptional reports whether a particle may not occur at all.
+func xsdOptional(n *xsdNode) bool {
+	return n.attr("minOccurs") == "0"
+}
+
+// xsdBuiltinType returns the proto scalar for a built-in XML Schema type.
+// Dates, times, and binary data stay strings, as written.
+func xsdBuiltinType(local string) string {
+	switch local {
+	case "boolean":
+		return "bool"
+	case "int", "short", "byte":
+		return "int32"
+	case "unsignedInt", "unsignedShort", "unsignedByte":
+		return "uint32"
+	case "long", "integer", "nonNegativeInteger", "positiveInteger", "nonPositiveInteger", "negativeInteger":
+		return "int64"
+	case "unsignedLong":
+		return "uint64"
+	case "float":
+		return "float"
+	case "double", "decimal":
+		return "double"
+	}
+	return "string"
+}
+
+// xsdComment joins a field's documentation and a note on its type.
+func xsdComment(doc, note string) string {
+	if note == "" {
+		return doc
+	}
+	return doc + "; " + note
+}
+
+// xsdFieldName returns the snake_case field name for an XML name, e.g.
+// "title_info" for titleInfo and "url_access" for URLAccess.
+func xsdFieldName(name string) string {
+	var b strings.Builder
+	rs := []rune(name)
+	for i, r := range rs {
+		if unicode.IsUpper(r) && i > 0 {
+			prev := rs[i-1]
+			nextLower := i+1 < len(rs) && unicode.IsLower(rs[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || unicode.IsUpper(prev) && nextLower {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	field := strings.Trim(nonIdentChars.ReplaceAllString(b.String(), "_"), "_")
+	if field == "" || field[0] >= '0' && field[0] <= '9' {
+		field = "field_" + field
+	}
+	return field
+}
+
+// xsdMessageName returns the PascalCase message name for a type or element
+// name, without the Type or Definition suffix schemas name types with:
+// "TitleInfo" for titleInfoDefinition.
+func xsdMessageName(name string) string {
+	for _, suffix := range []string{"Definition", "Type"} {
+		if trimmed, ok := strings.CutSuffix(name, suffix); ok && trimmed != "" {
+			name = trimmed
+			break
+		}
+	}
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) || r > unicode.MaxASCII {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	msg := b.String()
+	if msg == "" || unicode.IsDigit(rune(msg[0])) {
+		msg = "Type" + msg
+	}
+	return msg
+}
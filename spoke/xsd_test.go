@@ -0,0 +1,283 @@
+package spoke
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// etdmsSchema is an ETD-MS-like schema exercising what the XSD generator
+// handles: named, anonymous, extended, mixed, and simple-content types,
+// choices, element and attribute references, and an unresolved import.
+const etdmsSchema = `<?xml version="1.0" encoding="UTF-8"?>
+<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema"
+  xmlns="http://www.ndltd.org/standards/metadata/etdms/1.1/"
+  xmlns:xlink="http://www.w3.org/1999/xlink"
+  xmlns:fr="http://www.crossref.org/fundref.xsd"
+  targetNamespace="http://www.ndltd.org/standards/metadata/etdms/1.1/"
+  elementFormDefault="qualified">
+  <xs:include schemaLocation="etdms-common.xsd"/>
+  <xs:import namespace="http://www.crossref.org/fundref.xsd"
+    schemaLocation="https://www.crossref.org/schemas/fundref.xsd"/>
+
+  <xs:element name="thesis" type="thesisType"/>
+  <xs:element name="note" type="xs:string">
+    <xs:annotation><xs:documentation>A note about the thesis.</xs:documentation></xs:annotation>
+  </xs:element>
+
+  <xs:complexType name="thesisType">
+    <xs:annotation>
+      <xs:documentation>An electronic thesis or dissertation. Elements
+        follow ETD-MS 1.1.</xs:documentation>
+    </xs:annotation>
+    <xs:sequence>
+      <xs:element name="title" type="languageString"/>
+      <xs:element name="alternativeTitle" type="languageString" minOccurs="0" maxOccurs="unbounded"/>
+      <xs:element name="creator" type="agentType" maxOccurs="unbounded"/>
+      <xs:element name="contributor" type="advisorType" minOccurs="0" maxOccurs="unbounded"/>
+      <xs:choice maxOccurs="unbounded">
+        <xs:element name="subject" type="languageString"/>
+        <xs:element name="keyword" type="xs:string"/>
+      </xs:choice>
+      <xs:element name="date" type="xs:date"/>
+      <xs:element name="pages" type="xs:positiveInteger" minOccurs="0"/>
+      <xs:element name="type" type="typeType" minOccurs="0" maxOccurs="3"/>
+      <xs:element ref="note" minOccurs="0"/>
+      <xs:element name="degree" minOccurs="0">
+        <xs:complexType>
+          <xs:sequence>
+            <xs:element name="name" type="xs:string"/>
+            <xs:element name="level" type="levelType"/>
+            <xs:element name="grantor" type="xs:string" maxOccurs="unbounded"/>
+          </xs:sequence>
+        </xs:complexType>
+      </xs:element>
+      <xs:element name="funding" type="fr:program" minOccurs="0"/>
+      <xs:element name="description" type="richText" minOccurs="0"/>
+    </xs:sequence>
+    <xs:attribute name="type" type="xs:string"/>
+    <xs:attribute name="version" type="xs:decimal" use="required"/>
+  </xs:complexType>
+
+  <xs:complexType name="agentType">
+    <xs:sequence>
+      <xs:element name="name" type="xs:string"/>
+    </xs:sequence>
+    <xs:attribute name="role" type="xs:string"/>
+    <xs:attribute ref="xlink:href"/>
+  </xs:complexType>
+
+  <xs:complexType name="advisorType">
+    <xs:complexContent>
+      <xs:extension base="agentType">
+        <xs:sequence>
+          <xs:element name="institution" type="xs:string" minOccurs="0"/>
+        </xs:sequence>
+      </xs:extension>
+    </xs:complexContent>
+  </xs:complexType>
+
+  <xs:complexType name="richText" mixed="true">
+    <xs:sequence>
+      <xs:element name="em" type="xs:string" minOccurs="0" maxOccurs="unbounded"/>
+    </xs:sequence>
+  </xs:complexType>
+
+  <xs:simpleType name="levelType">
+    <xs:restriction base="xs:integer">
+      <xs:minInclusive value="0"/>
+    </xs:restriction>
+  </xs:simpleType>
+
+  <xs:simpleType name="typeType">
+    <xs:restriction base="xs:string">
+      <xs:enumeration value="Text"/>
+      <xs:enumeration value="Dataset"/>
+    </xs:restriction>
+  </xs:simpleType>
+</xs:schema>
+`
+
+// etdmsCommon is included without a target namespace, so its types take
+// on the including schema's.
+const etdmsCommon = `<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema">
+  <xs:complexType name="languageString">
+    <xs:simpleContent>
+      <xs:extension base="xs:string">
+        <xs:attribute ref="xml:lang"/>
+      </xs:extension>
+    </xs:simpleContent>
+  </xs:complexType>
+</xs:schema>
+`
+
+func TestGenerateXSDSpoke(t *testing.T) {
+	dir := t.TempDir()
+	schema := filepath.Join(dir, "etdms.xsd")
+	os.WriteFile(schema, []byte(etdmsSchema), 0o644)
+	os.WriteFile(filepath.Join(dir, "etdms-common.xsd"), []byte(etdmsCommon), 0o644)
+
+	proto, err := GenerateXSDSpoke("etdms", schema, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	messages := make(map[string]ProtoMessage)
+	var names []string
+	for _, m := range proto.Messages {
+		messages[m.Name] = m
+		names = append(names, m.Name)
+	}
+	if got := strings.Join(names, " "); got != "Thesis LanguageString Agent Advisor Degree RichText" {
+		t.Errorf("messages %s", got)
+	}
+
+	root := proto.Messages[0]
+	if root.XMLName != "thesis" || root.XMLDefaultNS != "http://www.ndltd.org/standards/metadata/etdms/1.1/" {
+		t.Errorf("root message is %s in %s", root.XMLName, root.XMLDefaultNS)
+	}
+	if len(root.XMLNamespaces) != 1 || root.XMLNamespaces[0] != "xlink=http://www.w3.org/1999/xlink" {
+		t.Errorf("namespaces %v", root.XMLNamespaces)
+	}
+	if root.Comment != "Thesis - An electronic thesis or dissertation." {
+		t.Errorf("comment %q", root.Comment)
+	}
+
+	type field struct {
+		typ         string
+		number      int
+		xmlName     string
+		required    bool
+		attr        bool
+		cardinality int
+	}
+	fieldsOf := func(msg string) map[string]field {
+		fields := make(map[string]field)
+		for _, f := range messages[msg].Fields {
+			fields[f.Name] = field{f.Type, f.Number, f.XMLName, f.Required, f.XMLAttr || f.XMLChardata, f.Cardinality}
+		}
+		return fields
+	}
+	for msg, want := range map[string]map[string]field{
+		"Thesis": {
+			"title":             {"LanguageString", 1, "", true, false, 1},
+			"alternative_title": {"repeated LanguageString", 2, "alternativeTitle", false, false, -1},
+			"creator":           {"repeated Agent", 3, "", false, false, -1},
+			"contributor":       {"repeated Advisor", 4, "", false, false, -1},
+			"subject":           {"repeated LanguageString", 5, "", false, false, -1},
+			"keyword":           {"repeated string", 6, "", false, false, -1},
+			"date":              {"string", 7, "", true, false, 1},
+			"pages":             {"int64", 8, "", false, false, 1},
+			"type":              {"repeated string", 9, "", false, false, 3},
+			"note":              {"string", 10, "", false, false, 1},
+			"degree":            {"Degree", 11, "", false, false, 1},
+			"funding":           {"string", 12, "", false, false, 1},
+			"description":       {"RichText", 13, "", false, false, 1},
+			"type_attr":         {"string", 14, "type", false, true, 1},
+			"version":           {"double", 15, "", true, true, 1},
+		},
+		"Degree": {
+			"name":    {"string", 1, "", true, false, 1},
+			"level":   {"int64", 2, "", true, false, 1},
+			"grantor": {"repeated string", 3, "", false, false, -1},
+		},
+		// An extension's fields follow its base's
+		"Advisor": {
+			"name":        {"string", 1, "", true, false, 1},
+			"role":        {"string", 2, "", false, true, 1},
+			"href":        {"string", 3, "xlink:href", false, true, 1},
+			"institution": {"string", 4, "", false, false, 1},
+		},
+		"LanguageString": {
+			"value": {"string", 1, "", false, true, 1},
+			"lang":  {"string", 2, "xml:lang", false, true, 1},
+		},
+		"RichText": {
+			"em":   {"repeated string", 1, "", false, false, -1},
+			"text": {"string", 2, "", false, true, 1},
+		},
+	} {
+		got := fieldsOf(msg)
+		if len(got) != len(want) {
+			t.Errorf("%s: got %d fields, want %d", msg, len(got), len(want))
+		}
+		for name, w := range want {
+			if g, ok := got[name]; !ok {
+				t.Errorf("%s: no field %s", msg, name)
+			} else if g != w {
+				t.Errorf("%s.%s: got %+v, want %+v", msg, name, g, w)
+			}
+		}
+	}
+
+	comments := make(map[string]string)
+	for _, f := range root.Fields {
+		comments[f.Name] = f.Comment
+	}
+	for name, want := range map[string]string{
+		"note":    "A note about the thesis.",
+		"type":    "type element; one of: Text, Dataset",
+		"funding": "funding element; type program is not in the schema",
+	} {
+		if comments[name] != want {
+			t.Errorf("%s comment %q, want %q", name, comments[name], want)
+		}
+	}
+
+	ApplyAutoMappings(proto)
+	out := filepath.Join(t.TempDir(), "etdms.proto")
+	if err := WriteProto(proto, out); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(out)
+	for _, want := range []string{
+		"message Thesis {\n  option (hub.v1.message) = {\n    xml_name: \"thesis\"\n" +
+			"    xml_default_ns: \"http://www.ndltd.org/standards/metadata/etdms/1.1/\"\n" +
+			"    xml_namespaces: [\"xlink=http://www.w3.org/1999/xlink\"]\n  };\n",
+		`repeated LanguageString alternative_title = 2 [(hub.v1.field) = {xml_name: "alternativeTitle"}];`,
+		`double version = 15 [(hub.v1.field) = {required: true xml_attr: true}];`,
+		`string lang = 2 [(hub.v1.field) = {xml_name: "xml:lang" xml_attr: true}];`,
+		`string value = 1 [(hub.v1.field) = {xml_chardata: true}];`,
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("proto missing %s:\n%s", want, data)
+		}
+	}
+	meta, _ := os.ReadFile(strings.TrimSuffix(out, ".proto") + "_meta.go")
+	if strings.Contains(string(meta), "spokeregistry.Register(") {
+		t.Errorf("XML spoke registers field metadata:\n%s", meta)
+	}
+
+	if _, err := GenerateXSDSpoke("etdms", schema, "note"); err == nil || !strings.Contains(err.Error(), "simple type") {
+		t.Errorf("simple root: got %v", err)
+	}
+	if _, err := GenerateXSDSpoke("etdms", schema, "dissertation"); err == nil || !strings.Contains(err.Error(), "it has thesis, note") {
+		t.Errorf("unknown root: got %v", err)
+	}
+}
+
+func TestXSDNames(t *testing.T) {
+	for name, want := range map[string]string{
+		"titleInfo":        "title_info",
+		"URLAccess":        "url_access",
+		"otherTypeAuthURI": "other_type_auth_uri",
+		"doi_data":         "doi_data",
+		"ID":               "id",
+		"3d-model":         "field_3d_model",
+	} {
+		if got := xsdFieldName(name); got != want {
+			t.Errorf("field name %s: got %s, want %s", name, got, want)
+		}
+	}
+	for name, want := range map[string]string{
+		"titleInfoDefinition": "TitleInfo",
+		"arXivRecordType":     "ArXivRecord",
+		"doi_data":            "DoiData",
+		"Type":                "Type",
+		"mods":                "Mods",
+	} {
+		if got := xsdMessageName(name); got != want {
+			t.Errorf("message name %s: got %s, want %s", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,297 @@
+// Package sqlite stores hub records in a SQLite database, so curators can
+// check a migration batch with SQL before serializing the final output.
+//
+// Each record is a row of the records table, which keeps the record's full
+// protojson (record_json) alongside columns for querying: title, resource
+// type, language, publisher, and issued date. The contributors,
+// identifiers, and subjects tables hold a row per value, keyed by
+// record_id and ordered by position:
+//
+//	SELECT r.title FROM records r
+//	LEFT JOIN identifiers i ON i.record_id = r.id AND i.type = 'doi'
+//	WHERE i.value IS NULL;
+//
+// record_json is what Records reads back; the other columns and tables are
+// derived from it when the record is added. Deleting a record's row drops
+// it from the batch, but edits to the derived columns are not read back.
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+
+	// Registers the pure-Go "sqlite" database/sql driver
+	_ "modernc.org/sqlite"
+)
+
+// schema creates the tables of a record store.
+const schema = `
+CREATE TABLE IF NOT EXISTS records (
+	id            INTEGER PRIMARY KEY,
+	batch         TEXT NOT NULL,
+	source_format TEXT,
+	title         TEXT,
+	resource_type TEXT,
+	language      TEXT,
+	publisher     TEXT,
+	date_issued   TEXT,
+	record_json   TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS records_batch ON records (batch);
+
+CREATE TABLE IF NOT EXISTS contributors (
+	record_id INTEGER NOT NULL REFERENCES records (id) ON DELETE CASCADE,
+	position  INTEGER NOT NULL,
+	name      TEXT,
+	type      TEXT,
+	role_code TEXT,
+	orcid     TEXT
+);
+CREATE INDEX IF NOT EXISTS contributors_record ON contributors (record_id);
+
+CREATE TABLE IF NOT EXISTS identifiers (
+	record_id INTEGER NOT NULL REFERENCES records (id) ON DELETE CASCADE,
+	position  INTEGER NOT NULL,
+	type      TEXT,
+	value     TEXT
+);
+CREATE INDEX IF NOT EXISTS identifiers_record ON identifiers (record_id);
+CREATE INDEX IF NOT EXISTS identifiers_value ON identifiers (value);
+
+CREATE TABLE IF NOT EXISTS subjects (
+	record_id  INTEGER NOT NULL REFERENCES records (id) ON DELETE CASCADE,
+	position   INTEGER NOT NULL,
+	value      TEXT,
+	vocabulary TEXT,
+	uri        TEXT
+);
+CREATE INDEX IF NOT EXISTS subjects_record ON subjects (record_id);
+`
+
+// marshal writes proto field names, matching the hub's JSON schema.
+var marshal = protojson.MarshalOptions{UseProtoNames: true}
+
+// Store is a SQLite database of hub records.
+type Store struct {
+	db *sql.DB
+}
+
+// Open opens the database at path, creating it and its tables if needed.
+func Open(path string) (*Store, error) {
+	db, err := sql.Open("sqlite", path+"?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)")
+	if err != nil {
+		return nil, fmt.Errorf("opening %s: %w", path, err)
+	}
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("creating tables in %s: %w", path, err)
+	}
+	return &Store{db: db}, nil
+}
+
+// Close closes the database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Add stores records as part of the named batch, in one transaction, and
+// returns the ID each was given.
+func (s *Store) Add(ctx context.Context, batch string, records []*hubv1.Record) (ids []int64, err error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	for i, r := range records {
+		id, err := addRecord(ctx, tx, batch, r)
+		if err != nil {
+			return nil, fmt.Errorf("record %d: %w", i, err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, tx.Commit()
+}
+
+// addRecord inserts one record and its contributors, identifiers, and
+// subjects.
+func addRecord(ctx context.Context, tx *sql.Tx, batch string, r *hubv1.Record) (int64, error) {
+	data, err := marshal.Marshal(r)
+	if err != nil {
+		return 0, fmt.Errorf("encoding: %w", err)
+	}
+	var dateIssued, resourceType string
+	if d := hub.GetDateIssued(r); d != nil {
+		dateIssued = hub.DateString(d)
+	}
+	if r.ResourceType != nil {
+		resourceType = hub.ResourceTypeString(r.ResourceType)
+	}
+
+	res, err := tx.ExecContext(ctx,
+		`INSERT INTO records (batch, source_format, title, resource_type, language, publisher, date_issued, record_json)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		batch, null(r.GetSourceInfo().GetFormat()), null(r.Title), null(resourceType),
+		null(r.Language), null(r.Publisher), null(dateIssued), string(data))
+	if err != nil {
+		return 0, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	for i, c := range r.Contributors {
+		var orcid string
+		for _, cid := range c.Identifiers {
+			if cid.Type == hubv1.IdentifierType_IDENTIFIER_TYPE_ORCID {
+				orcid = cid.Value
+				break
+			}
+		}
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO contributors (record_id, position, name, type, role_code, orcid) VALUES (?, ?, ?, ?, ?, ?)`,
+			id, i, null(c.Name), null(enumName(c.Type.String(), "CONTRIBUTOR_TYPE_")), null(c.RoleCode), null(orcid))
+		if err != nil {
+			return 0, err
+		}
+	}
+	for i, ident := range r.Identifiers {
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO identifiers (record_id, position, type, value) VALUES (?, ?, ?, ?)`,
+			id, i, null(enumName(ident.Type.String(), "IDENTIFIER_TYPE_")), null(ident.Value))
+		if err != nil {
+			return 0, err
+		}
+	}
+	for i, subj := range r.Subjects {
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO subjects (record_id, position, value, vocabulary, uri) VALUES (?, ?, ?, ?, ?)`,
+			id, i, null(subj.Value), null(enumName(subj.Vocabulary.String(), "SUBJECT_VOCABULARY_")), null(subj.Uri))
+		if err != nil {
+			return 0, err
+		}
+	}
+	return id, nil
+}
+
+// Records returns the records of the named batch, or of every batch when
+// batch is empty, in the order they were added.
+func (s *Store) Records(ctx context.Context, batch string) ([]*hubv1.Record, error) {
+	query := `SELECT id, record_json FROM records ORDER BY id`
+	var args []any
+	if batch != "" {
+		query = `SELECT id, record_json FROM records WHERE batch = ? ORDER BY id`
+		args = append(args, batch)
+	}
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []*hubv1.Record
+	for rows.Next() {
+		var id int64
+		var data string
+		if err := rows.Scan(&id, &data); err != nil {
+			return nil, err
+		}
+		r := &hubv1.Record{}
+		if err := protojson.Unmarshal([]byte(data), r); err != nil {
+			return nil, fmt.Errorf("record %d: decoding record_json: %w", id, err)
+		}
+		records = append(records, r)
+	}
+	return records, rows.Err()
+}
+
+// Batches returns the batches in the store with how many records each has.
+func (s *Store) Batches(ctx context.Context) (map[string]int, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT batch, count(*) FROM records GROUP BY batch`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	batches := make(map[string]int)
+	for rows.Next() {
+		var name string
+		var n int
+		if err := rows.Scan(&name, &n); err != nil {
+			return nil, err
+		}
+		batches[name] = n
+	}
+	return batches, rows.Err()
+}
+
+// DeleteBatch removes the named batch's records and returns how many
+// there were.
+func (s *Store) DeleteBatch(ctx context.Context, batch string) (int64, error) {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM records WHERE batch = ?`, batch)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// Query runs a SQL query and returns its column names and rows, with each
+// value as text and NULL as "".
+func (s *Store) Query(ctx context.Context, query string, args ...any) (columns []string, result [][]string, err error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	columns, err = rows.Columns()
+	if err != nil {
+		return nil, nil, err
+	}
+	for rows.Next() {
+		values := make([]sql.NullString, len(columns))
+		dest := make([]any, len(columns))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, nil, err
+		}
+		row := make([]string, len(columns))
+		for i, v := range values {
+			row[i] = v.String
+		}
+		result = append(result, row)
+	}
+	return columns, result, rows.Err()
+}
+
+// enumName returns a hub enum value's name without its prefix, lowercased
+// (IDENTIFIER_TYPE_DOI is "doi"), or "" when unspecified.
+func enumName(name, prefix string) string {
+	name = strings.ToLower(strings.TrimPrefix(name, prefix))
+	if name == "unspecified" {
+		return ""
+	}
+	return name
+}
+
+// null stores empty strings as NULL, so queries can test IS NULL.
+func null(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
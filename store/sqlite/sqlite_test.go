@@ -0,0 +1,94 @@
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+	"github.com/lehigh-university-libraries/crosswalk/hub"
+)
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+	s, err := Open(filepath.Join(t.TempDir(), "batch.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	records := []*hubv1.Record{
+		{
+			Title: "Field notes",
+			Contributors: []*hubv1.Contributor{
+				{Name: "Smith, Jane", RoleCode: "relators:aut"},
+				{Name: "Doe, John"},
+			},
+			Dates:       []*hubv1.DateValue{{Type: hubv1.DateType_DATE_TYPE_ISSUED, Year: 1999, Precision: hubv1.DatePrecision_DATE_PRECISION_YEAR}},
+			Identifiers: []*hubv1.Identifier{hub.NewIdentifier("10.1234/abcd", hubv1.IdentifierType_IDENTIFIER_TYPE_DOI)},
+			Subjects:    []*hubv1.Subject{{Value: "Geology", Vocabulary: hubv1.SubjectVocabulary_SUBJECT_VOCABULARY_LCSH}},
+		},
+		{Title: "Second record"},
+	}
+	if _, err := s.Add(ctx, "first", records); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Add(ctx, "second", records[1:]); err != nil {
+		t.Fatal(err)
+	}
+
+	_, rows, err := s.Query(ctx, `SELECT r.title, r.date_issued, i.value FROM records r
+		LEFT JOIN identifiers i ON i.record_id = r.id AND i.type = 'doi' ORDER BY r.id`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 3 || rows[0][1] != "1999" || rows[0][2] != "10.1234/abcd" || rows[1][2] != "" {
+		t.Errorf("got rows %v", rows)
+	}
+	_, rows, err = s.Query(ctx, `SELECT name, role_code FROM contributors ORDER BY position`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 || rows[0][1] != "relators:aut" || rows[1][1] != "" {
+		t.Errorf("contributors: got %v", rows)
+	}
+	_, rows, err = s.Query(ctx, `SELECT vocabulary FROM subjects`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 || rows[0][0] != "lcsh" {
+		t.Errorf("subjects: got %v", rows)
+	}
+
+	got, err := s.Records(ctx, "first")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || !proto.Equal(got[0], records[0]) {
+		t.Errorf("Records: got %v", got)
+	}
+
+	// Deleting a record drops it, and its rows, from the batch
+	if _, _, err := s.Query(ctx, `DELETE FROM records WHERE title = 'Field notes'`); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := s.Records(ctx, "first"); len(got) != 1 {
+		t.Errorf("after delete: got %d records, want 1", len(got))
+	}
+	if _, rows, _ := s.Query(ctx, `SELECT count(*) FROM contributors`); rows[0][0] != "0" {
+		t.Errorf("after delete: got %s contributors, want 0", rows[0][0])
+	}
+
+	if n, err := s.DeleteBatch(ctx, "first"); err != nil || n != 1 {
+		t.Errorf("DeleteBatch: got %d, %v", n, err)
+	}
+	batches, err := s.Batches(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(batches) != 1 || batches["second"] != 1 {
+		t.Errorf("Batches: got %v", batches)
+	}
+}
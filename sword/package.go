@@ -0,0 +1,300 @@
+package sword
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/md5"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/lehigh-university-libraries/crosswalk/format"
+	"github.com/lehigh-university-libraries/crosswalk/format/dublincore"
+	"github.com/lehigh-university-libraries/crosswalk/format/mods"
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+)
+
+// METS DSpace SIP elements. Only what DSpace's METS ingester reads is
+// written: the MODS description, the files, and a structMap tying them
+// together.
+type metsDocument struct {
+	XMLName   xml.Name      `xml:"mets"`
+	Xmlns     string        `xml:"xmlns,attr"`
+	XlinkNS   string        `xml:"xmlns:xlink,attr"`
+	ObjID     string        `xml:"OBJID,attr,omitempty"`
+	Label     string        `xml:"LABEL,attr,omitempty"`
+	Profile   string        `xml:"PROFILE,attr"`
+	Header    metsHeader    `xml:"metsHdr"`
+	DmdSec    metsDmdSec    `xml:"dmdSec"`
+	FileSec   *metsFileSec  `xml:"fileSec,omitempty"`
+	StructMap metsStructMap `xml:"structMap"`
+}
+
+type metsHeader struct {
+	CreateDate string `xml:"CREATEDATE,attr"`
+	Agent      struct {
+		Role string `xml:"ROLE,attr"`
+		Type string `xml:"TYPE,attr"`
+		Name string `xml:"name"`
+	} `xml:"agent"`
+}
+
+type metsDmdSec struct {
+	ID     string `xml:"ID,attr"`
+	MdWrap struct {
+		MDType  string `xml:"MDTYPE,attr"`
+		XMLData struct {
+			Inner []byte `xml:",innerxml"`
+		} `xml:"xmlData"`
+	} `xml:"mdWrap"`
+}
+
+type metsFileSec struct {
+	FileGrp struct {
+		Use   string     `xml:"USE,attr"`
+		Files []metsFile `xml:"file"`
+	} `xml:"fileGrp"`
+}
+
+type metsFile struct {
+	ID           string `xml:"ID,attr"`
+	MimeType     string `xml:"MIMETYPE,attr"`
+	Checksum     string `xml:"CHECKSUM,attr"`
+	ChecksumType string `xml:"CHECKSUMTYPE,attr"`
+	FLocat       struct {
+		LocType string `xml:"LOCTYPE,attr"`
+		Href    string `xml:"xlink:href,attr"`
+	} `xml:"FLocat"`
+}
+
+type metsStructMap struct {
+	Type string  `xml:"TYPE,attr"`
+	Div  metsDiv `xml:"div"`
+}
+
+type metsDiv struct {
+	ID    string    `xml:"ID,attr"`
+	DmdID string    `xml:"DMDID,attr,omitempty"`
+	Type  string    `xml:"TYPE,attr"`
+	Divs  []metsDiv `xml:"div,omitempty"`
+	Fptr  *metsFptr `xml:"fptr,omitempty"`
+}
+
+type metsFptr struct {
+	FileID string `xml:"FILEID,attr"`
+}
+
+// METSPackage builds a METS DSpace SIP zip for the record: mets.xml with
+// the record's MODS, and the record's local files. Remote files are left
+// out; a local file that can't be read fails the package.
+func METSPackage(r *hubv1.Record) ([]byte, error) {
+	var desc bytes.Buffer
+	if err := (&mods.Format{}).Serialize(&desc, []*hubv1.Record{r}, format.NewSerializeOptions()); err != nil {
+		return nil, fmt.Errorf("serializing MODS: %w", err)
+	}
+
+	doc := metsDocument{
+		Xmlns:   "http://www.loc.gov/METS/",
+		XlinkNS: "http://www.w3.org/1999/xlink",
+		Label:   r.Title,
+		Profile: "DSpace METS SIP Profile 1.0",
+	}
+	if len(r.Identifiers) > 0 {
+		doc.ObjID = r.Identifiers[0].Value
+	}
+	doc.Header.CreateDate = time.Now().UTC().Format(time.RFC3339)
+	doc.Header.Agent.Role = "CUSTODIAN"
+	doc.Header.Agent.Type = "OTHER"
+	doc.Header.Agent.Name = "crosswalk"
+	doc.DmdSec.ID = "dmd_1"
+	doc.DmdSec.MdWrap.MDType = "MODS"
+	doc.DmdSec.MdWrap.XMLData.Inner = bytes.TrimSpace(bytes.TrimPrefix(desc.Bytes(), []byte(xml.Header)))
+	doc.StructMap.Type = "LOGICAL"
+	doc.StructMap.Div = metsDiv{ID: "div_1", DmdID: "dmd_1", Type: "SWORD Object"}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	used := make(map[string]bool)
+	var files []metsFile
+	for _, file := range r.Files {
+		if file.Path == "" || strings.Contains(file.Path, "://") {
+			if file.Path != "" {
+				slog.Warn("leaving remote file out of deposit package", "title", r.Title, "path", file.Path)
+			}
+			continue
+		}
+		base := path.Base(filepath.ToSlash(file.Name))
+		if file.Name == "" {
+			base = filepath.Base(file.Path)
+		}
+		name := base
+		for n := 2; used[name]; n++ {
+			name = fmt.Sprintf("%d-%s", n, base)
+		}
+		used[name] = true
+
+		sum, err := addZipFile(zw, name, file.Path)
+		if err != nil {
+			return nil, err
+		}
+		mimeType := file.MimeType
+		if mimeType == "" {
+			mimeType = mime.TypeByExtension(path.Ext(name))
+		}
+		if mimeType == "" {
+			mimeType = "application/octet-stream"
+		}
+		f := metsFile{
+			ID:           fmt.Sprintf("file_%d", len(files)+1),
+			MimeType:     mimeType,
+			Checksum:     sum,
+			ChecksumType: "MD5",
+		}
+		f.FLocat.LocType = "URL"
+		f.FLocat.Href = name
+		files = append(files, f)
+
+		doc.StructMap.Div.Divs = append(doc.StructMap.Div.Divs, metsDiv{
+			ID:   fmt.Sprintf("div_%d", len(files)+1),
+			Type: "File",
+			Fptr: &metsFptr{FileID: f.ID},
+		})
+	}
+	if len(files) > 0 {
+		doc.FileSec = &metsFileSec{}
+		doc.FileSec.FileGrp.Use = "CONTENT"
+		doc.FileSec.FileGrp.Files = files
+	}
+
+	out, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshaling mets.xml: %w", err)
+	}
+	w, err := zw.Create("mets.xml")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(append([]byte(xml.Header), out...)); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// addZipFile copies the file at src into the zip as name and returns its
+// hex MD5.
+func addZipFile(zw *zip.Writer, name, src string) (string, error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return "", fmt.Errorf("reading file: %w", err)
+	}
+	defer in.Close()
+	w, err := zw.Create(name)
+	if err != nil {
+		return "", err
+	}
+	h := md5.New()
+	if _, err := io.Copy(io.MultiWriter(w, h), in); err != nil {
+		return "", fmt.Errorf("reading file: %w", err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// atomEntry is a SWORD Atom entry deposit: the record as DC Terms elements,
+// with the Atom elements the entry needs.
+type atomEntry struct {
+	XMLName xml.Name     `xml:"entry"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	TermsNS string       `xml:"xmlns:dcterms,attr"`
+	Title   string       `xml:"title"`
+	ID      string       `xml:"id"`
+	Updated string       `xml:"updated"`
+	Authors []atomAuthor `xml:"author"`
+	Summary string       `xml:"summary,omitempty"`
+	Terms   []dcTerm     `xml:",any"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type dcTerm struct {
+	XMLName xml.Name
+	Value   string `xml:",chardata"`
+}
+
+// DCEntry builds an Atom entry from the record's qualified Dublin Core,
+// moving every element into the DC Terms namespace as SWORD servers
+// expect.
+func DCEntry(r *hubv1.Record) ([]byte, error) {
+	opts := format.NewSerializeOptions()
+	opts.Variant = dublincore.VariantQualified
+	var desc bytes.Buffer
+	if err := (&dublincore.Format{}).Serialize(&desc, []*hubv1.Record{r}, opts); err != nil {
+		return nil, fmt.Errorf("serializing Dublin Core: %w", err)
+	}
+
+	entry := atomEntry{
+		Xmlns:   "http://www.w3.org/2005/Atom",
+		TermsNS: "http://purl.org/dc/terms/",
+		Title:   r.Title,
+		ID:      "urn:uuid:" + newUUID(),
+		Updated: time.Now().UTC().Format(time.RFC3339),
+		Summary: r.Abstract,
+	}
+	dec := xml.NewDecoder(&desc)
+	depth := 0
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading Dublin Core: %w", err)
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if depth == 1 {
+				var term dcTerm
+				if err := dec.DecodeElement(&term, &t); err != nil {
+					return nil, fmt.Errorf("reading Dublin Core: %w", err)
+				}
+				term.XMLName = xml.Name{Local: "dcterms:" + t.Name.Local}
+				entry.Terms = append(entry.Terms, term)
+				if t.Name.Local == "creator" {
+					entry.Authors = append(entry.Authors, atomAuthor{Name: term.Value})
+				}
+				continue
+			}
+			depth++
+		case xml.EndElement:
+			depth--
+		}
+	}
+
+	out, err := xml.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshaling Atom entry: %w", err)
+	}
+	return append([]byte(xml.Header), out...), nil
+}
+
+// newUUID returns a random (version 4) UUID.
+func newUUID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -0,0 +1,215 @@
+package sword
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/xml"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	hubv1 "github.com/lehigh-university-libraries/crosswalk/gen/go/hub/v1"
+)
+
+// metsRead is the part of mets.xml the tests check.
+type metsRead struct {
+	ObjID  string `xml:"OBJID,attr"`
+	DmdSec struct {
+		MdWrap struct {
+			MDType  string `xml:"MDTYPE,attr"`
+			XMLData struct {
+				Inner string `xml:",innerxml"`
+			} `xml:"xmlData"`
+		} `xml:"mdWrap"`
+	} `xml:"dmdSec"`
+	Files []struct {
+		ID           string `xml:"ID,attr"`
+		MimeType     string `xml:"MIMETYPE,attr"`
+		Checksum     string `xml:"CHECKSUM,attr"`
+		ChecksumType string `xml:"CHECKSUMTYPE,attr"`
+		FLocat       struct {
+			Href string `xml:"http://www.w3.org/1999/xlink href,attr"`
+		} `xml:"FLocat"`
+	} `xml:"fileSec>fileGrp>file"`
+	Div struct {
+		DmdID string `xml:"DMDID,attr"`
+		Divs  []struct {
+			Type string `xml:"TYPE,attr"`
+			Fptr struct {
+				FileID string `xml:"FILEID,attr"`
+			} `xml:"fptr"`
+		} `xml:"div"`
+	} `xml:"structMap>div"`
+}
+
+func md5Hex(b []byte) string {
+	sum := md5.Sum(b)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestMETSPackage(t *testing.T) {
+	dir := t.TempDir()
+	contents := map[string][]byte{
+		"thesis.pdf":           []byte("%PDF-1.7 thesis"),
+		"sub/thesis.pdf":       []byte("%PDF-1.7 appendix"),
+		"supplement/data.json": []byte(`{"depth": 1, "flow": 2}`),
+	}
+	for name, b := range contents {
+		p := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, b, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r := &hubv1.Record{
+		Title: "Sediment transport in the Lehigh Gap",
+		Identifiers: []*hubv1.Identifier{
+			{Type: hubv1.IdentifierType_IDENTIFIER_TYPE_LOCAL, Value: "etd-42"},
+		},
+		Files: []*hubv1.File{
+			{Path: filepath.Join(dir, "thesis.pdf"), MimeType: "application/pdf"},
+			{Path: filepath.Join(dir, "sub/thesis.pdf"), MimeType: "application/pdf"},
+			{Path: "https://example.edu/remote.pdf"},
+			{Path: filepath.Join(dir, "supplement/data.json"), Name: "data.json"},
+		},
+	}
+	pkg, err := METSPackage(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(pkg), int64(len(pkg)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries := make(map[string][]byte)
+	var names []string
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		entries[f.Name] = b
+		names = append(names, f.Name)
+	}
+	if want := []string{"thesis.pdf", "2-thesis.pdf", "data.json", "mets.xml"}; !slices.Equal(names, want) {
+		t.Fatalf("zip entries: got %v, want %v", names, want)
+	}
+	if !bytes.Equal(entries["2-thesis.pdf"], contents["sub/thesis.pdf"]) {
+		t.Errorf("2-thesis.pdf holds %q", entries["2-thesis.pdf"])
+	}
+
+	var doc metsRead
+	if err := xml.Unmarshal(entries["mets.xml"], &doc); err != nil {
+		t.Fatalf("mets.xml: %v\n%s", err, entries["mets.xml"])
+	}
+	if doc.ObjID != "etd-42" {
+		t.Errorf("OBJID: got %q", doc.ObjID)
+	}
+	if doc.DmdSec.MdWrap.MDType != "MODS" || !strings.Contains(doc.DmdSec.MdWrap.XMLData.Inner, "Sediment transport in the Lehigh Gap") {
+		t.Errorf("dmdSec does not wrap the record's MODS:\n%s", doc.DmdSec.MdWrap.XMLData.Inner)
+	}
+
+	wantFiles := []struct{ href, mime, content string }{
+		{"thesis.pdf", "application/pdf", "thesis.pdf"},
+		{"2-thesis.pdf", "application/pdf", "sub/thesis.pdf"},
+		{"data.json", "application/json", "supplement/data.json"},
+	}
+	if len(doc.Files) != len(wantFiles) {
+		t.Fatalf("fileSec lists %d files, want %d", len(doc.Files), len(wantFiles))
+	}
+	if doc.Div.DmdID != "dmd_1" || len(doc.Div.Divs) != len(wantFiles) {
+		t.Fatalf("structMap: got DMDID %q with %d divs", doc.Div.DmdID, len(doc.Div.Divs))
+	}
+	for i, w := range wantFiles {
+		f := doc.Files[i]
+		if f.FLocat.Href != w.href || f.MimeType != w.mime {
+			t.Errorf("file %d: got %s (%s), want %s (%s)", i, f.FLocat.Href, f.MimeType, w.href, w.mime)
+		}
+		if f.ChecksumType != "MD5" || f.Checksum != md5Hex(contents[w.content]) {
+			t.Errorf("file %d: got %s checksum %s, want MD5 %s", i, f.ChecksumType, f.Checksum, md5Hex(contents[w.content]))
+		}
+		if div := doc.Div.Divs[i]; div.Type != "File" || div.Fptr.FileID != f.ID {
+			t.Errorf("structMap div %d points at %q, want %q", i, div.Fptr.FileID, f.ID)
+		}
+	}
+}
+
+func TestMETSPackageWithoutFiles(t *testing.T) {
+	pkg, err := METSPackage(&hubv1.Record{Title: "Canal Lock Survey"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(pkg), int64(len(pkg)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(zr.File) != 1 || zr.File[0].Name != "mets.xml" {
+		t.Fatalf("expected only mets.xml in the package")
+	}
+	rc, err := zr.File[0].Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rc.Close()
+	b, _ := io.ReadAll(rc)
+	if strings.Contains(string(b), "<fileSec") {
+		t.Errorf("expected no fileSec without files:\n%s", b)
+	}
+}
+
+func TestMETSPackageMissingFile(t *testing.T) {
+	_, err := METSPackage(&hubv1.Record{
+		Title: "Canal Lock Survey",
+		Files: []*hubv1.File{{Path: filepath.Join(t.TempDir(), "missing.pdf")}},
+	})
+	if err == nil {
+		t.Fatal("expected an error for a file that can't be read")
+	}
+}
+
+func TestDCEntry(t *testing.T) {
+	entry, err := DCEntry(&hubv1.Record{
+		Title:    "Canal Lock Survey",
+		Abstract: "Survey of the canal locks.",
+		Contributors: []*hubv1.Contributor{
+			{Name: "Smith, Jane", Role: "author"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		Title   string `xml:"http://www.w3.org/2005/Atom title"`
+		Authors []struct {
+			Name string `xml:"name"`
+		} `xml:"http://www.w3.org/2005/Atom author"`
+		Summary string   `xml:"http://www.w3.org/2005/Atom summary"`
+		Titles  []string `xml:"http://purl.org/dc/terms/ title"`
+	}
+	if err := xml.Unmarshal(entry, &got); err != nil {
+		t.Fatalf("entry: %v\n%s", err, entry)
+	}
+	if got.Title != "Canal Lock Survey" || got.Summary != "Survey of the canal locks." {
+		t.Errorf("atom elements: got title %q, summary %q", got.Title, got.Summary)
+	}
+	if len(got.Authors) != 1 || got.Authors[0].Name != "Smith, Jane" {
+		t.Errorf("authors: got %+v", got.Authors)
+	}
+	if !slices.Contains(got.Titles, "Canal Lock Survey") {
+		t.Errorf("expected dcterms:title in the entry:\n%s", entry)
+	}
+}
@@ -0,0 +1,380 @@
+// Package sword is a small SWORD v2 deposit client. It reads a server's
+// service document to find the collections a user may deposit into, POSTs
+// packages to a collection, and decodes the deposit receipt that comes
+// back. METSPackage and DCEntry build the packages from hub records.
+package sword
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Packaging formats a collection lists in sword:acceptPackaging.
+const (
+	PackagingSimpleZip     = "http://purl.org/net/sword/package/SimpleZip"
+	PackagingMETSDSpaceSIP = "http://purl.org/net/sword/package/METSDSpaceSIP"
+	PackagingBinary        = "http://purl.org/net/sword/package/Binary"
+)
+
+// ContentTypeEntry is the media type of an Atom entry deposit, metadata
+// without a package.
+const ContentTypeEntry = "application/atom+xml;type=entry"
+
+// XML namespaces used by SWORD documents.
+const (
+	nsApp   = "http://www.w3.org/2007/app"
+	nsSword = "http://purl.org/net/sword/terms/"
+)
+
+// Client deposits into one SWORD v2 server.
+type Client struct {
+	// ServiceURL is the server's service document IRI.
+	ServiceURL string
+	HTTPClient *http.Client
+	UserAgent  string
+
+	// Username and Password are sent with HTTP basic authentication when
+	// Username is set.
+	Username string
+	Password string
+	// OnBehalfOf names the user a mediated deposit is made for.
+	OnBehalfOf string
+}
+
+// NewClient returns a client for the server whose service document is at
+// serviceURL.
+func NewClient(serviceURL string) *Client {
+	return &Client{
+		ServiceURL: serviceURL,
+		HTTPClient: &http.Client{Timeout: 5 * time.Minute},
+		UserAgent:  "crosswalk-deposit (https://github.com/lehigh-university-libraries/crosswalk)",
+	}
+}
+
+// ServiceDocument lists the collections a user may deposit into.
+type ServiceDocument struct {
+	Version    string
+	Workspaces []Workspace
+}
+
+// Workspace is a group of collections.
+type Workspace struct {
+	Title       string
+	Collections []Collection
+}
+
+// Collection is a deposit target. Href is its Col-IRI, made absolute
+// against the service document's IRI.
+type Collection struct {
+	Href            string
+	Title           string
+	Accept          []string
+	AcceptPackaging []string
+	Mediation       bool
+}
+
+// AcceptsPackaging reports whether the collection lists the packaging
+// format.
+func (c Collection) AcceptsPackaging(packaging string) bool {
+	return slices.Contains(c.AcceptPackaging, packaging)
+}
+
+// AcceptsEntries reports whether the collection takes Atom entry deposits.
+func (c Collection) AcceptsEntries() bool {
+	for _, accept := range c.Accept {
+		accept = strings.ReplaceAll(accept, " ", "")
+		if accept == "*/*" || strings.HasPrefix(accept, "application/atom+xml") {
+			return true
+		}
+	}
+	return false
+}
+
+// Collections returns every collection in the service document, in order.
+func (s *ServiceDocument) Collections() []Collection {
+	var all []Collection
+	for _, ws := range s.Workspaces {
+		all = append(all, ws.Collections...)
+	}
+	return all
+}
+
+// Deposit is one package, or one Atom entry, to POST to a collection.
+type Deposit struct {
+	Content     []byte
+	ContentType string
+	// Filename is sent in Content-Disposition; Atom entries have none.
+	Filename string
+	// Packaging is the package format's IRI; Atom entries have none.
+	Packaging string
+	// InProgress leaves the item open for further deposits instead of
+	// submitting it to the collection's workflow.
+	InProgress bool
+}
+
+// Receipt is the server's description of a deposited item.
+type Receipt struct {
+	// EditIRI identifies the item to later SWORD requests. It is the
+	// receipt's edit link, or the Location header when the server sends
+	// no receipt.
+	EditIRI      string
+	EditMediaIRI string
+	StatementIRI string
+	ID           string
+	// Alternate holds the item's splash pages, often a handle URL.
+	Alternate []string
+	// Identifiers are the receipt's dcterms:identifier values.
+	Identifiers []string
+	Treatment   string
+	Packaging   []string
+}
+
+// Error is a SWORD error document, or an unexpected response without one.
+type Error struct {
+	Status int
+	// Href is the error's IRI, e.g. http://purl.org/net/sword/error/ErrorContent.
+	Href    string
+	Summary string
+}
+
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("SWORD server returned %d %s", e.Status, http.StatusText(e.Status))
+	if e.Href != "" {
+		msg += " (" + e.Href + ")"
+	}
+	if e.Summary != "" {
+		msg += ": " + e.Summary
+	}
+	return msg
+}
+
+// ServiceDocument fetches and decodes the service document.
+func (c *Client) ServiceDocument(ctx context.Context) (*ServiceDocument, error) {
+	base, err := url.Parse(c.ServiceURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid service document IRI: %w", err)
+	}
+	req, err := c.newRequest(ctx, http.MethodGet, c.ServiceURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	_, body, err := c.do(req, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc xmlService
+	if err := xml.Unmarshal(body, &doc); err != nil {
+		return nil, fmt.Errorf("decoding service document: %w", err)
+	}
+	if doc.XMLName.Space != nsApp || doc.XMLName.Local != "service" {
+		return nil, fmt.Errorf("%s is not a service document", base.Redacted())
+	}
+
+	sd := &ServiceDocument{Version: strings.TrimSpace(doc.Version)}
+	for _, ws := range doc.Workspaces {
+		workspace := Workspace{Title: strings.TrimSpace(ws.Title)}
+		for _, col := range ws.Collections {
+			href, err := base.Parse(strings.TrimSpace(col.Href))
+			if err != nil {
+				return nil, fmt.Errorf("collection %q: invalid href: %w", col.Title, err)
+			}
+			collection := Collection{
+				Href:      href.String(),
+				Title:     strings.TrimSpace(col.Title),
+				Mediation: strings.TrimSpace(col.Mediation) == "true",
+			}
+			for _, a := range col.Accept {
+				collection.Accept = append(collection.Accept, strings.TrimSpace(a))
+			}
+			for _, p := range col.AcceptPackaging {
+				collection.AcceptPackaging = append(collection.AcceptPackaging, strings.TrimSpace(p))
+			}
+			workspace.Collections = append(workspace.Collections, collection)
+		}
+		sd.Workspaces = append(sd.Workspaces, workspace)
+	}
+	return sd, nil
+}
+
+// Deposit POSTs d to the collection at colIRI and returns the receipt.
+func (c *Client) Deposit(ctx context.Context, colIRI string, d *Deposit) (*Receipt, error) {
+	req, err := c.newRequest(ctx, http.MethodPost, colIRI, bytes.NewReader(d.Content))
+	if err != nil {
+		return nil, err
+	}
+	sum := md5.Sum(d.Content)
+	req.Header.Set("Content-Type", d.ContentType)
+	req.Header.Set("Content-MD5", hex.EncodeToString(sum[:]))
+	req.Header.Set("In-Progress", fmt.Sprint(d.InProgress))
+	if d.Filename != "" {
+		req.Header.Set("Content-Disposition", "attachment; filename="+quoteFilename(d.Filename))
+	}
+	if d.Packaging != "" {
+		req.Header.Set("Packaging", d.Packaging)
+	}
+
+	resp, body, err := c.do(req, http.StatusCreated, http.StatusAccepted)
+	if err != nil {
+		return nil, err
+	}
+
+	receipt := &Receipt{}
+	if len(bytes.TrimSpace(body)) > 0 {
+		if receipt, err = decodeReceipt(body); err != nil {
+			return nil, err
+		}
+	}
+	if receipt.EditIRI == "" {
+		receipt.EditIRI = resp.Header.Get("Location")
+	}
+	if receipt.EditIRI == "" {
+		return nil, fmt.Errorf("deposit receipt has no edit IRI and the response no Location header")
+	}
+	return receipt, nil
+}
+
+// newRequest builds a request with the client's credentials and headers.
+func (c *Client) newRequest(ctx context.Context, method, target string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return nil, err
+	}
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+	if c.Username != "" {
+		req.SetBasicAuth(c.Username, c.Password)
+	}
+	if c.OnBehalfOf != "" {
+		req.Header.Set("On-Behalf-Of", c.OnBehalfOf)
+	}
+	return req, nil
+}
+
+// do sends the request and reads the response, turning any status not in
+// ok into an *Error.
+func (c *Client) do(req *http.Request, ok ...int) (*http.Response, []byte, error) {
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading response: %w", err)
+	}
+	if !slices.Contains(ok, resp.StatusCode) {
+		return nil, nil, decodeError(resp.StatusCode, body)
+	}
+	return resp, body, nil
+}
+
+// decodeReceipt reads a deposit receipt, an Atom entry.
+func decodeReceipt(body []byte) (*Receipt, error) {
+	var entry xmlEntry
+	if err := xml.Unmarshal(body, &entry); err != nil {
+		return nil, fmt.Errorf("decoding deposit receipt: %w", err)
+	}
+	receipt := &Receipt{
+		ID:        strings.TrimSpace(entry.ID),
+		Treatment: strings.TrimSpace(entry.Treatment),
+	}
+	for _, link := range entry.Links {
+		href := strings.TrimSpace(link.Href)
+		switch link.Rel {
+		case "edit":
+			receipt.EditIRI = href
+		case "edit-media":
+			receipt.EditMediaIRI = href
+		case "alternate":
+			receipt.Alternate = append(receipt.Alternate, href)
+		case nsSword + "statement":
+			if receipt.StatementIRI == "" || strings.HasPrefix(link.Type, "application/atom+xml") {
+				receipt.StatementIRI = href
+			}
+		}
+	}
+	for _, id := range entry.Identifiers {
+		if id = strings.TrimSpace(id); id != "" {
+			receipt.Identifiers = append(receipt.Identifiers, id)
+		}
+	}
+	for _, p := range entry.Packaging {
+		receipt.Packaging = append(receipt.Packaging, strings.TrimSpace(p))
+	}
+	return receipt, nil
+}
+
+// decodeError reads a SWORD error document, falling back to the start of
+// the response body when there isn't one.
+func decodeError(status int, body []byte) error {
+	swordErr := &Error{Status: status}
+	var doc xmlError
+	if err := xml.Unmarshal(body, &doc); err == nil && doc.XMLName.Space == nsSword && doc.XMLName.Local == "error" {
+		swordErr.Href = doc.Href
+		swordErr.Summary = strings.TrimSpace(doc.Summary)
+		if swordErr.Summary == "" {
+			swordErr.Summary = strings.TrimSpace(doc.Verbose)
+		}
+		return swordErr
+	}
+	text := strings.Join(strings.Fields(string(body)), " ")
+	if len(text) > 200 {
+		text = text[:200] + "..."
+	}
+	swordErr.Summary = text
+	return swordErr
+}
+
+// quoteFilename quotes a Content-Disposition filename when it needs it.
+func quoteFilename(name string) string {
+	if strings.ContainsAny(name, " \t\";,") {
+		return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(name) + `"`
+	}
+	return name
+}
+
+type xmlService struct {
+	XMLName    xml.Name
+	Version    string `xml:"http://purl.org/net/sword/terms/ version"`
+	Workspaces []struct {
+		Title       string `xml:"http://www.w3.org/2005/Atom title"`
+		Collections []struct {
+			Href            string   `xml:"href,attr"`
+			Title           string   `xml:"http://www.w3.org/2005/Atom title"`
+			Accept          []string `xml:"http://www.w3.org/2007/app accept"`
+			AcceptPackaging []string `xml:"http://purl.org/net/sword/terms/ acceptPackaging"`
+			Mediation       string   `xml:"http://purl.org/net/sword/terms/ mediation"`
+		} `xml:"http://www.w3.org/2007/app collection"`
+	} `xml:"http://www.w3.org/2007/app workspace"`
+}
+
+type xmlEntry struct {
+	ID    string `xml:"http://www.w3.org/2005/Atom id"`
+	Links []struct {
+		Rel  string `xml:"rel,attr"`
+		Href string `xml:"href,attr"`
+		Type string `xml:"type,attr"`
+	} `xml:"http://www.w3.org/2005/Atom link"`
+	Identifiers []string `xml:"http://purl.org/dc/terms/ identifier"`
+	Treatment   string   `xml:"http://purl.org/net/sword/terms/ treatment"`
+	Packaging   []string `xml:"http://purl.org/net/sword/terms/ packaging"`
+}
+
+type xmlError struct {
+	XMLName xml.Name
+	Href    string `xml:"href,attr"`
+	Summary string `xml:"http://www.w3.org/2005/Atom summary"`
+	Verbose string `xml:"http://purl.org/net/sword/terms/ verboseDescription"`
+}
@@ -0,0 +1,196 @@
+package sword
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const serviceDocument = `<?xml version="1.0" encoding="UTF-8"?>
+<service xmlns="http://www.w3.org/2007/app" xmlns:atom="http://www.w3.org/2005/Atom"
+         xmlns:sword="http://purl.org/net/sword/terms/">
+  <sword:version>2.0</sword:version>
+  <workspace>
+    <atom:title>Repository</atom:title>
+    <collection href="/swordv2/collection/123456789/2">
+      <atom:title>Electronic Theses</atom:title>
+      <accept>application/zip</accept>
+      <sword:acceptPackaging>http://purl.org/net/sword/package/SimpleZip</sword:acceptPackaging>
+      <sword:acceptPackaging>http://purl.org/net/sword/package/METSDSpaceSIP</sword:acceptPackaging>
+      <sword:mediation>true</sword:mediation>
+    </collection>
+    <collection href="https://other.example.edu/col/9">
+      <atom:title>Datasets</atom:title>
+      <accept>*/*</accept>
+    </collection>
+  </workspace>
+</service>`
+
+const depositReceipt = `<?xml version="1.0" encoding="UTF-8"?>
+<entry xmlns="http://www.w3.org/2005/Atom" xmlns:sword="http://purl.org/net/sword/terms/"
+       xmlns:dcterms="http://purl.org/dc/terms/">
+  <id>%[1]s/swordv2/edit/42</id>
+  <title>A thesis</title>
+  <link rel="edit" href="%[1]s/swordv2/edit/42"/>
+  <link rel="edit-media" href="%[1]s/swordv2/edit-media/42"/>
+  <link rel="alternate" href="http://hdl.handle.net/123456789/42"/>
+  <link rel="http://purl.org/net/sword/terms/statement" type="application/rdf+xml" href="%[1]s/swordv2/statement/42.rdf"/>
+  <link rel="http://purl.org/net/sword/terms/statement" type="application/atom+xml;type=feed" href="%[1]s/swordv2/statement/42.atom"/>
+  <dcterms:identifier>http://hdl.handle.net/123456789/42</dcterms:identifier>
+  <sword:packaging>http://purl.org/net/sword/package/METSDSpaceSIP</sword:packaging>
+  <sword:treatment>Deposited into the workflow</sword:treatment>
+</entry>`
+
+func TestServiceDocument(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "depositor" || pass != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, serviceDocument)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL + "/swordv2/servicedocument")
+	c.Username, c.Password = "depositor", "secret"
+	sd, err := c.ServiceDocument(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sd.Version != "2.0" {
+		t.Errorf("Version = %q", sd.Version)
+	}
+	cols := sd.Collections()
+	if len(cols) != 2 {
+		t.Fatalf("got %d collections, want 2", len(cols))
+	}
+	theses := cols[0]
+	if theses.Href != srv.URL+"/swordv2/collection/123456789/2" {
+		t.Errorf("relative href not resolved: %q", theses.Href)
+	}
+	if theses.Title != "Electronic Theses" || !theses.Mediation {
+		t.Errorf("collection = %+v", theses)
+	}
+	if !theses.AcceptsPackaging(PackagingMETSDSpaceSIP) || theses.AcceptsPackaging(PackagingBinary) {
+		t.Errorf("AcceptPackaging = %v", theses.AcceptPackaging)
+	}
+	if theses.AcceptsEntries() {
+		t.Error("a zip-only collection should not take Atom entries")
+	}
+	if !cols[1].AcceptsEntries() {
+		t.Error("*/* should take Atom entries")
+	}
+
+	c.Password = "wrong"
+	_, err = c.ServiceDocument(context.Background())
+	var swordErr *Error
+	if !errors.As(err, &swordErr) || swordErr.Status != http.StatusUnauthorized {
+		t.Errorf("expected a 401 *Error, got %v", err)
+	}
+}
+
+func TestDeposit(t *testing.T) {
+	content := []byte("PK fake zip")
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		sum := md5.Sum(body)
+		want := map[string]string{
+			"Content-Type":        "application/zip",
+			"Content-MD5":         hex.EncodeToString(sum[:]),
+			"Content-Disposition": `attachment; filename="my record.zip"`,
+			"Packaging":           PackagingMETSDSpaceSIP,
+			"In-Progress":         "false",
+			"On-Behalf-Of":        "student",
+		}
+		for header, value := range want {
+			if got := r.Header.Get(header); got != value {
+				t.Errorf("%s = %q, want %q", header, got, value)
+			}
+		}
+		if r.Method != http.MethodPost || string(body) != string(content) {
+			t.Errorf("got %s with body %q", r.Method, body)
+		}
+		w.Header().Set("Location", srv.URL+"/swordv2/edit/42")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, depositReceipt, srv.URL)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL)
+	c.OnBehalfOf = "student"
+	receipt, err := c.Deposit(context.Background(), srv.URL+"/col", &Deposit{
+		Content:     content,
+		ContentType: "application/zip",
+		Filename:    "my record.zip",
+		Packaging:   PackagingMETSDSpaceSIP,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if receipt.EditIRI != srv.URL+"/swordv2/edit/42" || receipt.EditMediaIRI != srv.URL+"/swordv2/edit-media/42" {
+		t.Errorf("edit IRIs = %q, %q", receipt.EditIRI, receipt.EditMediaIRI)
+	}
+	if receipt.StatementIRI != srv.URL+"/swordv2/statement/42.atom" {
+		t.Errorf("StatementIRI = %q, want the Atom statement", receipt.StatementIRI)
+	}
+	if strings.Join(receipt.Alternate, " ") != "http://hdl.handle.net/123456789/42" {
+		t.Errorf("Alternate = %v", receipt.Alternate)
+	}
+	if strings.Join(receipt.Identifiers, " ") != "http://hdl.handle.net/123456789/42" {
+		t.Errorf("Identifiers = %v", receipt.Identifiers)
+	}
+	if receipt.Treatment != "Deposited into the workflow" {
+		t.Errorf("Treatment = %q", receipt.Treatment)
+	}
+}
+
+func TestDepositWithoutReceipt(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Location", "https://repo.example.edu/edit/7")
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	receipt, err := NewClient(srv.URL).Deposit(context.Background(), srv.URL, &Deposit{
+		Content:     []byte("<entry/>"),
+		ContentType: ContentTypeEntry,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if receipt.EditIRI != "https://repo.example.edu/edit/7" {
+		t.Errorf("EditIRI = %q, want the Location header", receipt.EditIRI)
+	}
+}
+
+func TestDepositError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusPreconditionFailed)
+		fmt.Fprint(w, `<sword:error xmlns="http://www.w3.org/2005/Atom" xmlns:sword="http://purl.org/net/sword/terms/"
+  href="http://purl.org/net/sword/error/ErrorChecksumMismatch">
+  <title>ERROR</title>
+  <summary>Checksum mismatch</summary>
+</sword:error>`)
+	}))
+	defer srv.Close()
+
+	_, err := NewClient(srv.URL).Deposit(context.Background(), srv.URL, &Deposit{Content: []byte("x")})
+	var swordErr *Error
+	if !errors.As(err, &swordErr) {
+		t.Fatalf("expected an *Error, got %v", err)
+	}
+	if swordErr.Href != "http://purl.org/net/sword/error/ErrorChecksumMismatch" || swordErr.Summary != "Checksum mismatch" {
+		t.Errorf("error = %+v", swordErr)
+	}
+	if !strings.Contains(err.Error(), "412") {
+		t.Errorf("message should carry the status: %q", err)
+	}
+}